@@ -0,0 +1,168 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+// ConvertTo converts this ZeroTrustWorkloadIdentityManager to the hub version (v1alpha2)
+func (src *ZeroTrustWorkloadIdentityManager) ConvertTo(dstRaw conversion.Hub) error {
+	return convert(src, dstRaw.(*v1alpha2.ZeroTrustWorkloadIdentityManager))
+}
+
+// ConvertFrom converts from the hub version (v1alpha2) to this version
+func (dst *ZeroTrustWorkloadIdentityManager) ConvertFrom(srcRaw conversion.Hub) error {
+	return convert(srcRaw.(*v1alpha2.ZeroTrustWorkloadIdentityManager), dst)
+}
+
+// ConvertTo converts this SpireServer to the hub version (v1alpha2)
+func (src *SpireServer) ConvertTo(dstRaw conversion.Hub) error {
+	return convert(src, dstRaw.(*v1alpha2.SpireServer))
+}
+
+// ConvertFrom converts from the hub version (v1alpha2) to this version
+func (dst *SpireServer) ConvertFrom(srcRaw conversion.Hub) error {
+	return convert(srcRaw.(*v1alpha2.SpireServer), dst)
+}
+
+// ConvertTo converts this SpireAgent to the hub version (v1alpha2)
+func (src *SpireAgent) ConvertTo(dstRaw conversion.Hub) error {
+	return convert(src, dstRaw.(*v1alpha2.SpireAgent))
+}
+
+// ConvertFrom converts from the hub version (v1alpha2) to this version
+func (dst *SpireAgent) ConvertFrom(srcRaw conversion.Hub) error {
+	return convert(srcRaw.(*v1alpha2.SpireAgent), dst)
+}
+
+// ConvertTo converts this SpireOIDCDiscoveryProvider to the hub version (v1alpha2)
+func (src *SpireOIDCDiscoveryProvider) ConvertTo(dstRaw conversion.Hub) error {
+	return convert(src, dstRaw.(*v1alpha2.SpireOIDCDiscoveryProvider))
+}
+
+// ConvertFrom converts from the hub version (v1alpha2) to this version
+func (dst *SpireOIDCDiscoveryProvider) ConvertFrom(srcRaw conversion.Hub) error {
+	return convert(srcRaw.(*v1alpha2.SpireOIDCDiscoveryProvider), dst)
+}
+
+// ConvertTo converts this SpiffeCSIDriver to the hub version (v1alpha2)
+func (src *SpiffeCSIDriver) ConvertTo(dstRaw conversion.Hub) error {
+	return convert(src, dstRaw.(*v1alpha2.SpiffeCSIDriver))
+}
+
+// ConvertFrom converts from the hub version (v1alpha2) to this version
+func (dst *SpiffeCSIDriver) ConvertFrom(srcRaw conversion.Hub) error {
+	return convert(srcRaw.(*v1alpha2.SpiffeCSIDriver), dst)
+}
+
+// convert copies src into dst, the same kind in v1alpha1 and v1alpha2. Both versions have the
+// same fields and differ only by the type of the boolean fields, strings holding "true" or
+// "false" in v1alpha1: the fields are matched by name and the booleans converted along the way.
+func convert(src, dst interface{}) error {
+	return convertValue(reflect.ValueOf(src).Elem(), reflect.ValueOf(dst).Elem(), "")
+}
+
+// parseBool parses a v1alpha1 boolean, "true" or "false" and empty when unset. Any other value is
+// rejected rather than read as false, as the objects stored before the enum was enforced may hold
+// one.
+func parseBool(value, path string) (bool, error) {
+	switch value {
+	case "true":
+		return true, nil
+	case "false", "":
+		return false, nil
+	}
+	return false, fmt.Errorf("cannot convert %s: invalid boolean %q, must be \"true\" or \"false\"", path, value)
+}
+
+func convertValue(src, dst reflect.Value, path string) error {
+	if src.Type() == dst.Type() {
+		dst.Set(src)
+		return nil
+	}
+
+	switch {
+	case src.Kind() == reflect.String && dst.Kind() == reflect.Bool:
+		b, err := parseBool(src.String(), path)
+		if err != nil {
+			return err
+		}
+		dst.SetBool(b)
+		return nil
+	case src.Kind() == reflect.Bool && dst.Kind() == reflect.String:
+		dst.SetString(strconv.FormatBool(src.Bool()))
+		return nil
+	case src.Kind() == reflect.String && dst.Type() == reflect.TypeOf((*bool)(nil)):
+		// An unset field is left unset, to be defaulted by the API server
+		if src.String() != "" {
+			b, err := parseBool(src.String(), path)
+			if err != nil {
+				return err
+			}
+			dst.Set(reflect.ValueOf(&b))
+		}
+		return nil
+	case src.Type() == reflect.TypeOf((*bool)(nil)) && dst.Kind() == reflect.String:
+		if !src.IsNil() {
+			dst.SetString(strconv.FormatBool(src.Elem().Bool()))
+		}
+		return nil
+	}
+
+	if src.Kind() != dst.Kind() {
+		return fmt.Errorf("cannot convert %s from %s to %s", path, src.Type(), dst.Type())
+	}
+	switch src.Kind() {
+	case reflect.Struct:
+		for i := 0; i < dst.NumField(); i++ {
+			name := dst.Type().Field(i).Name
+			field := src.FieldByName(name)
+			if !field.IsValid() {
+				return fmt.Errorf("cannot convert %s: field %s is missing from %s", path, name, src.Type())
+			}
+			if err := convertValue(field, dst.Field(i), path+"."+name); err != nil {
+				return err
+			}
+		}
+	case reflect.Ptr:
+		if src.IsNil() {
+			return nil
+		}
+		dst.Set(reflect.New(dst.Type().Elem()))
+		return convertValue(src.Elem(), dst.Elem(), path)
+	case reflect.Slice:
+		if src.IsNil() {
+			return nil
+		}
+		dst.Set(reflect.MakeSlice(dst.Type(), src.Len(), src.Len()))
+		for i := 0; i < src.Len(); i++ {
+			if err := convertValue(src.Index(i), dst.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return nil
+		}
+		dst.Set(reflect.MakeMapWithSize(dst.Type(), src.Len()))
+		for _, key := range src.MapKeys() {
+			value := reflect.New(dst.Type().Elem()).Elem()
+			if err := convertValue(src.MapIndex(key), value, fmt.Sprintf("%s[%v]", path, key)); err != nil {
+				return err
+			}
+			dst.SetMapIndex(key.Convert(dst.Type().Key()), value)
+		}
+	default:
+		// Named types of the same underlying type, e.g. string enums
+		if !src.Type().ConvertibleTo(dst.Type()) {
+			return fmt.Errorf("cannot convert %s from %s to %s", path, src.Type(), dst.Type())
+		}
+		dst.Set(src.Convert(dst.Type()))
+	}
+	return nil
+}
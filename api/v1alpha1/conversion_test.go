@@ -0,0 +1,193 @@
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+func TestSpireAgentConversion(t *testing.T) {
+	agent := &SpireAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", Labels: map[string]string{"team": "identity"}},
+		Spec: SpireAgentSpec{
+			NodeAttestor:      &NodeAttestor{K8sPSATEnabled: "false", JoinToken: &JoinTokenConfig{SecretName: "bootstrap"}},
+			WorkloadAttestors: &WorkloadAttestors{K8sEnabled: "true", DisableContainerSelectors: "true"},
+			CommonConfig:      CommonConfig{AdoptExistingResources: "false"},
+		},
+		Status: SpireAgentStatus{ConditionalStatus: ConditionalStatus{Ready: metav1.ConditionTrue}},
+	}
+
+	hub := &v1alpha2.SpireAgent{}
+	if err := agent.ConvertTo(hub); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if hub.Spec.NodeAttestor.K8sPSATEnabled == nil || *hub.Spec.NodeAttestor.K8sPSATEnabled {
+		t.Errorf("Expected k8sPSATEnabled false, got %v", hub.Spec.NodeAttestor.K8sPSATEnabled)
+	}
+	if !ptr.Deref(hub.Spec.WorkloadAttestors.DisableContainerSelectors, false) {
+		t.Errorf("Expected disableContainerSelectors true, got %v", hub.Spec.WorkloadAttestors.DisableContainerSelectors)
+	}
+	if hub.Spec.WorkloadAttestors.UseNewContainerLocator != nil {
+		t.Errorf("Expected unset useNewContainerLocator to stay unset, got %v", *hub.Spec.WorkloadAttestors.UseNewContainerLocator)
+	}
+	if hub.Spec.NodeAttestor.JoinToken.SecretName != "bootstrap" || hub.Labels["team"] != "identity" || hub.Status.Ready != metav1.ConditionTrue {
+		t.Errorf("Expected the other fields to be copied, got %+v", hub)
+	}
+
+	roundTrip := &SpireAgent{}
+	if err := roundTrip.ConvertFrom(hub); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(agent, roundTrip) {
+		t.Errorf("Expected the round trip to preserve the SpireAgent, got %+v", roundTrip)
+	}
+}
+
+func TestBooleanConversion(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    *bool
+		expectError bool
+	}{
+		{name: "true", value: "true", expected: ptr.To(true)},
+		{name: "false", value: "false", expected: ptr.To(false)},
+		{name: "unset", value: ""},
+		{name: "capitalized", value: "True", expectError: true},
+		{name: "numeric", value: "1", expectError: true},
+		{name: "yes", value: "yes", expectError: true},
+		{name: "padded", value: " true", expectError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Both the optional and the required booleans of v1alpha2
+			server := &SpireServer{Spec: SpireServerSpec{LogEvents: tt.value, Datastore: DataStore{DisableMigration: tt.value}}}
+			hub := &v1alpha2.SpireServer{}
+			err := server.ConvertTo(hub)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected an error for %q, got %+v", tt.value, hub.Spec)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(hub.Spec.LogEvents, tt.expected) {
+				t.Errorf("Expected logEvents %v, got %v", ptr.Deref(tt.expected, false), hub.Spec.LogEvents)
+			}
+		})
+	}
+}
+
+func TestSpireServerConversion(t *testing.T) {
+	hub := &v1alpha2.SpireServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Spec: v1alpha2.SpireServerSpec{
+			LogEvents:  ptr.To(true),
+			Datastore:  v1alpha2.DataStore{DatabaseType: "sqlite3", DisableMigration: ptr.To(false)},
+			Federation: &v1alpha2.FederationConfig{ManagedRoute: ptr.To(true)},
+		},
+	}
+
+	server := &SpireServer{}
+	if err := server.ConvertFrom(hub); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if server.Spec.LogEvents != "true" || server.Spec.Datastore.DisableMigration != "false" || server.Spec.Federation.ManagedRoute != "true" {
+		t.Errorf("Expected the booleans to be converted to strings, got %+v", server.Spec)
+	}
+	if server.Spec.JoinTokenAttestationEnabled != "" {
+		t.Errorf("Expected unset joinTokenAttestationEnabled to stay unset, got %q", server.Spec.JoinTokenAttestationEnabled)
+	}
+
+	roundTrip := &v1alpha2.SpireServer{}
+	if err := server.ConvertTo(roundTrip); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(hub, roundTrip) {
+		t.Errorf("Expected the round trip to preserve the SpireServer, got %+v", roundTrip)
+	}
+}
+
+func TestZeroTrustWorkloadIdentityManagerConversion(t *testing.T) {
+	ztwim := &ZeroTrustWorkloadIdentityManager{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Spec:       ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.org", WaitForSpireServer: "false"},
+		Status: ZeroTrustWorkloadIdentityManagerStatus{
+			Operands: []OperandStatus{
+				{Name: "cluster", Kind: "SpireServer", Ready: "true"},
+				{Name: "cluster", Kind: "SpireAgent", Ready: "false", Message: "Reconciling"},
+			},
+		},
+	}
+
+	hub := &v1alpha2.ZeroTrustWorkloadIdentityManager{}
+	if err := ztwim.ConvertTo(hub); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if ptr.Deref(hub.Spec.WaitForSpireServer, true) {
+		t.Errorf("Expected waitForSpireServer false, got %v", hub.Spec.WaitForSpireServer)
+	}
+	if len(hub.Status.Operands) != 2 || !hub.Status.Operands[0].Ready || hub.Status.Operands[1].Ready {
+		t.Errorf("Expected the readiness of the operands to be converted, got %+v", hub.Status.Operands)
+	}
+
+	roundTrip := &ZeroTrustWorkloadIdentityManager{}
+	if err := roundTrip.ConvertFrom(hub); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(ztwim, roundTrip) {
+		t.Errorf("Expected the round trip to preserve the ZeroTrustWorkloadIdentityManager, got %+v", roundTrip)
+	}
+}
+
+func TestSpiffeCSIDriverConversion(t *testing.T) {
+	hub := &v1alpha2.SpiffeCSIDriver{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Spec:       v1alpha2.SpiffeCSIDriverSpec{CommonConfig: v1alpha2.CommonConfig{AdoptExistingResources: ptr.To(true)}},
+	}
+
+	driver := &SpiffeCSIDriver{}
+	if err := driver.ConvertFrom(hub); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if driver.Spec.AdoptExistingResources != "true" {
+		t.Errorf("Expected adoptExistingResources \"true\", got %q", driver.Spec.AdoptExistingResources)
+	}
+
+	roundTrip := &v1alpha2.SpiffeCSIDriver{}
+	if err := driver.ConvertTo(roundTrip); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(hub, roundTrip) {
+		t.Errorf("Expected the round trip to preserve the SpiffeCSIDriver, got %+v", roundTrip)
+	}
+}
+
+func TestSpireOIDCDiscoveryProviderConversion(t *testing.T) {
+	oidc := &SpireOIDCDiscoveryProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Spec:       SpireOIDCDiscoveryProviderSpec{ManagedRoute: "false", FallbackClusterSPIFFEIDEnabled: "true", ReplicaCount: 2},
+	}
+
+	hub := &v1alpha2.SpireOIDCDiscoveryProvider{}
+	if err := oidc.ConvertTo(hub); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if ptr.Deref(hub.Spec.ManagedRoute, true) || !ptr.Deref(hub.Spec.FallbackClusterSPIFFEIDEnabled, false) || hub.Spec.ReplicaCount != 2 {
+		t.Errorf("Expected the spec to be converted, got %+v", hub.Spec)
+	}
+
+	roundTrip := &SpireOIDCDiscoveryProvider{}
+	if err := roundTrip.ConvertFrom(hub); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(oidc, roundTrip) {
+		t.Errorf("Expected the round trip to preserve the SpireOIDCDiscoveryProvider, got %+v", roundTrip)
+	}
+}
@@ -11,6 +11,27 @@ type ConditionalStatus struct {
 	// +listType=map
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// lastTraceID is the trace ID of the reconcile pass that last updated the status. The logs
+	// and Events of that pass carry the same trace ID.
+	// +optional
+	LastTraceID string `json:"lastTraceID,omitempty"`
+
+	// ready summarizes the status of the Ready condition, for display.
+	// +optional
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	Ready metav1.ConditionStatus `json:"ready,omitempty"`
+
+	// message summarizes the message of the Ready condition, for display.
+	// +optional
+	// +kubebuilder:validation:MaxLength=32768
+	Message string `json:"message,omitempty"`
+
+	// version is the version of the deployed operand, as labelled on its workload, or the
+	// version of the operator for the ZeroTrustWorkloadIdentityManager.
+	// +optional
+	// +kubebuilder:validation:MaxLength=64
+	Version string `json:"version,omitempty"`
 }
 
 // ObjectReference is a reference to an object with a given name, kind and group.
@@ -7,8 +7,13 @@ import (
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:object:root=true
+// +kubebuilder:deprecatedversion:warning="operator.openshift.io/v1alpha1 SpiffeCSIDriver is deprecated, use operator.openshift.io/v1alpha2 SpiffeCSIDriver"
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.ready`
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.version`
+// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +kubebuilder:validation:XValidation:rule="self.metadata.name == 'cluster'",message="SpiffeCSIDriver is a singleton, .metadata.name must be 'cluster'"
 // +operator-sdk:csv:customresourcedefinitions:displayName="SpiffeCSIDriver"
 
@@ -42,13 +47,107 @@ type SpiffeCSIDriverSpec struct {
 	// +kubebuilder:default:="csi.spiffe.io"
 	PluginName string `json:"pluginName,omitempty"`
 
+	// architectures restricts the SPIFFE CSI driver pods to nodes of the given CPU architectures.
+	// When unset, they run on every node whose architecture the operand images are published for.
+	// Nodes of other architectures are skipped and reported through the ArchitecturesSkipped condition.
+	// The CSI driver must run on every node running a SPIRE agent, so both should list the same architectures.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=4
+	// +listType=set
+	Architectures []Architecture `json:"architectures,omitempty"`
+
+	// svidDelivery selects how the driver delivers the SVIDs to the workload pods.
+	// SocketOnly mounts the Workload API socket of the SPIRE agent into the volumes, for applications calling the Workload API.
+	// SVIDFiles deploys the SVID files flavor of the driver, which in addition writes the X.509-SVID, its private key and
+	// the trust bundle as PEM files into the volumes whose svidFiles volume attribute is "true", and rewrites them as
+	// they are rotated, for applications reading PEM files.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="SocketOnly"
+	SVIDDelivery SVIDDeliveryMode `json:"svidDelivery,omitempty"`
+
+	// socketDirectory sets the SELinux context, group and permissions of the directory holding the Workload API socket,
+	// which the driver mounts into the workload pods, for workloads running with restricted SCCs or custom SELinux
+	// policies to access the socket.
+	// +kubebuilder:validation:Optional
+	SocketDirectory *SocketDirectoryConfig `json:"socketDirectory,omitempty"`
+
 	CommonConfig `json:",inline"`
 }
 
+// SocketDirectoryConfig sets the access to the directory holding the Workload API socket
+type SocketDirectoryConfig struct {
+	// seLinuxType is the SELinux type the directory and the socket are labelled with.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9_]+$`
+	// +kubebuilder:default:="container_file_t"
+	SELinuxType string `json:"seLinuxType,omitempty"`
+
+	// seLinuxLevel is the SELinux MLS level the directory and the socket are labelled with, e.g. s0 or s0:c123,c456.
+	// When unset, the level of the directory is left as is.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^s[0-9]+(-s[0-9]+)?(:c[0-9]+([.,]c[0-9]+)*)?$`
+	SELinuxLevel string `json:"seLinuxLevel,omitempty"`
+
+	// group is the ID of the group owning the directory, e.g. the fsGroup of the workloads.
+	// When unset, the group of the directory is left as is.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4294967294
+	Group *int64 `json:"group,omitempty"`
+
+	// mode is the octal permissions of the directory, e.g. 0750 to restrict the socket to the owning group.
+	// When unset, the permissions of the directory are left as is.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^0?[0-7]{3}$`
+	Mode string `json:"mode,omitempty"`
+}
+
+// SVIDDeliveryMode is how the SPIFFE CSI driver delivers the SVIDs to the workload pods
+// +kubebuilder:validation:Enum=SocketOnly;SVIDFiles
+type SVIDDeliveryMode string
+
+const (
+	// SVIDDeliverySocketOnly mounts the Workload API socket only
+	SVIDDeliverySocketOnly SVIDDeliveryMode = "SocketOnly"
+	// SVIDDeliverySVIDFiles writes the SVIDs as files in addition to mounting the socket
+	SVIDDeliverySVIDFiles SVIDDeliveryMode = "SVIDFiles"
+)
+
 // SpiffeCSIDriverStatus defines the observed state of the SPIFFE CSI driver reconciliation performed by the operator
 type SpiffeCSIDriverStatus struct {
 	// conditions holds information about the current state of the SPIFFE CSI driver deployment.
 	ConditionalStatus `json:",inline,omitempty"`
+
+	// incompatibleNodes lists the nodes selected for the driver whose kubelet or container
+	// runtime does not meet the requirements of the driver, where the pods mounting the
+	// Workload API socket fail to start.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=50
+	// +listType=map
+	// +listMapKey=nodeName
+	IncompatibleNodes []IncompatibleNode `json:"incompatibleNodes,omitempty"`
+}
+
+// IncompatibleNode is a node whose kubelet or container runtime does not meet the requirements
+// of the SPIFFE CSI driver.
+type IncompatibleNode struct {
+	// nodeName is the name of the node.
+	// +kubebuilder:validation:Required
+	NodeName string `json:"nodeName"`
+
+	// kubeletVersion is the version of the kubelet of the node.
+	// +kubebuilder:validation:Optional
+	KubeletVersion string `json:"kubeletVersion,omitempty"`
+
+	// containerRuntimeVersion is the container runtime of the node, e.g. cri-o://1.31.2.
+	// +kubebuilder:validation:Optional
+	ContainerRuntimeVersion string `json:"containerRuntimeVersion,omitempty"`
+
+	// reason explains why the node is not compatible.
+	// +kubebuilder:validation:Required
+	Reason string `json:"reason"`
 }
 
 // GetConditionalStatus returns the conditional status of the SpiffeCSIDriver
@@ -7,14 +7,27 @@ import (
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:object:root=true
+// +kubebuilder:deprecatedversion:warning="operator.openshift.io/v1alpha1 SpireAgent is deprecated, use operator.openshift.io/v1alpha2 SpireAgent"
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
-// +kubebuilder:validation:XValidation:rule="self.metadata.name == 'cluster'",message="SpireAgent is a singleton, .metadata.name must be 'cluster'"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.ready`
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.version`
+// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`
+// +kubebuilder:printcolumn:name="Attested",type=integer,JSONPath=`.status.attestedNodes.count`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:validation:XValidation:rule="self.metadata.name == 'cluster' || (has(self.spec.nodeSelector) && size(self.spec.nodeSelector) > 0)",message="SpireAgent pools other than 'cluster' must set spec.nodeSelector"
+// +kubebuilder:validation:XValidation:rule="size(self.metadata.name) <= 40",message="SpireAgent .metadata.name must be at most 40 characters"
 // +operator-sdk:csv:customresourcedefinitions:displayName="SpireAgent"
 
 // SpireAgent defines the configuration for the SPIRE Agent managed by zero trust workload identity manager.
 // The agent runs on each node and is responsible for node attestation,
 // SVID rotation, and exposing the Workload API to local workloads.
+//
+// The SpireAgent named "cluster" is the default agent pool. Additional SpireAgents define agent
+// pools with their own DaemonSet and configuration, e.g. for GPU nodes. Their nodeSelector must
+// select nodes disjoint from the other pools, and those nodes are excluded from the default pool.
+// The ServiceAccount, RBAC, Service and SecurityContextConstraints of the agents are shared and
+// managed through the default pool.
 type SpireAgent struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -56,10 +69,172 @@ type SpireAgentSpec struct {
 	// +kubebuilder:validation:Optional
 	WorkloadAttestors *WorkloadAttestors `json:"workloadAttestors,omitempty"`
 
+	// service customizes the spire-agent Service.
+	// +kubebuilder:validation:Optional
+	Service *ServiceConfig `json:"service,omitempty"`
+
+	// architectures restricts the SPIRE agents to nodes of the given CPU architectures.
+	// When unset, they run on every node whose architecture the operand images are published for.
+	// Nodes of other architectures are skipped and reported through the ArchitecturesSkipped condition.
+	// The CSI driver must run on every node running a SPIRE agent, so both should list the same architectures.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=4
+	// +listType=set
+	Architectures []Architecture `json:"architectures,omitempty"`
+
+	// healthProbe deploys a checker on every node running a SPIRE agent that periodically fetches
+	// an X.509-SVID through the Workload API mounted by the SPIFFE CSI driver, catching broken CSI
+	// mounts and agent attestation issues before workloads hit them.
+	// +kubebuilder:validation:Optional
+	HealthProbe *WorkloadAPIHealthProbe `json:"healthProbe,omitempty"`
+
+	// delegatedIdentity enables the Delegated Identity API of the SPIRE agents on an admin socket,
+	// for the node-local proxies allow-listed as authorized delegates to fetch SVIDs on behalf of
+	// the workloads of the node.
+	// +kubebuilder:validation:Optional
+	DelegatedIdentity *DelegatedIdentityConfig `json:"delegatedIdentity,omitempty"`
+
+	// experimentalFlags sets experimental SPIRE agent options, rendered into the "experimental"
+	// section of the agent configuration. They are only applied when the ExperimentalFlags feature
+	// is listed in the UNSUPPORTED_ADDON_FEATURES environment variable of the operator, and the
+	// resource is then reported with the UnsupportedConfiguration condition.
+	// Supported options: sync_interval, use_sync_authorized_entries and require_pq_kem.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxProperties=8
+	ExperimentalFlags map[string]string `json:"experimentalFlags,omitempty"`
+
+	// telemetry configures the telemetry of the SPIRE agent in addition to the Prometheus endpoint it serves.
+	// The Service of the bridge is managed through the default agent pool.
+	// +kubebuilder:validation:Optional
+	Telemetry *TelemetryConfig `json:"telemetry,omitempty"`
+
+	// availability configures how long the agents keep their identity through an outage of the
+	// SPIRE server or a disconnection of their node, e.g. on edge nodes losing their uplink for
+	// days, and how they recover from it without a restart.
+	// +kubebuilder:validation:Optional
+	Availability *AgentAvailabilityConfig `json:"availability,omitempty"`
+
+	// svidCache tunes the caches of the SVIDs served by the agents, e.g. for dense nodes running
+	// hundreds of pods. Larger caches take more memory from the agents, which their memory
+	// requests and limits must account for.
+	// +kubebuilder:validation:Optional
+	SVIDCache *AgentSVIDCacheConfig `json:"svidCache,omitempty"`
+
+	// socketExposureAudit periodically checks that no pod other than those of the operator mounts
+	// the agent socket directory through a hostPath volume, which bypasses the SPIFFE CSI driver.
+	// The pods found are reported in status.socketExposureViolations. The audit is run through the
+	// default agent pool, for the socket directories of all the pools.
+	// +kubebuilder:validation:Optional
+	SocketExposureAudit *SocketExposureAuditConfig `json:"socketExposureAudit,omitempty"`
+
 	CommonConfig `json:",inline"`
 }
 
+// SocketExposureAuditConfig configures the audit of the hostPath mounts of the agent socket directory.
+type SocketExposureAuditConfig struct {
+	// enabled specifies whether the pods of the cluster are audited.
+	// +kubebuilder:default:="false"
+	// +kubebuilder:validation:Enum:="true";"false"
+	// +kubebuilder:validation:Optional
+	Enabled string `json:"enabled,omitempty"`
+
+	// allowedServiceAccounts are the service accounts whose pods may mount the agent socket
+	// directory through a hostPath volume, as namespace:name, e.g. a node-level agent of a
+	// service mesh. Maximum 32 service accounts allowed.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?:[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +listType=set
+	AllowedServiceAccounts []string `json:"allowedServiceAccounts,omitempty"`
+}
+
+// WorkloadAPIHealthProbe configures the Workload API health probe. Nodes where the SVID fetch
+// fails are reported through the WorkloadAPIProbeHealthy condition, and their checker pods are
+// reported as not ready, e.g. in the kube_pod_status_ready metric.
+type WorkloadAPIHealthProbe struct {
+	// enabled specifies whether the health probe DaemonSet is deployed.
+	// +kubebuilder:default:="false"
+	// +kubebuilder:validation:Enum:="true";"false"
+	// +kubebuilder:validation:Optional
+	Enabled string `json:"enabled,omitempty"`
+
+	// interval is how often each checker fetches an SVID.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="duration(self) > duration('0s')",message="interval must be a positive duration"
+	// +kubebuilder:default="60s"
+	Interval metav1.Duration `json:"interval,omitempty"`
+}
+
+// RebootstrapMode selects when an agent fetches the trust bundle again and re-attests
+// +kubebuilder:validation:Enum=Never;Auto;Always
+type RebootstrapMode string
+
+const (
+	// RebootstrapModeNever never rebootstraps the agent, which has to be redeployed
+	RebootstrapModeNever RebootstrapMode = "Never"
+	// RebootstrapModeAuto rebootstraps the agent when its SVID expired or the server no longer
+	// trusts it, if the node attestor supports re-attestation
+	RebootstrapModeAuto RebootstrapMode = "Auto"
+	// RebootstrapModeAlways rebootstraps the agent whenever it can no longer reach the server
+	// with its SVID
+	RebootstrapModeAlways RebootstrapMode = "Always"
+)
+
+// AgentAvailabilityConfig configures the resilience of the agents to outages and disconnections
+type AgentAvailabilityConfig struct {
+	// target is the minimum time the agents should keep serving the SVIDs of their workloads
+	// while the SPIRE server is unreachable: the agents renew their own SVID and the SVIDs of
+	// their workloads at least target before they expire. It must be at least 24h, and shorter
+	// than the agentTTL of the SpireServer, the lifetime of the agent SVIDs, to take effect.
+	// When unset, the SVIDs are renewed at half of their lifetime.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('24h')",message="target must be at least 24h"
+	Target *metav1.Duration `json:"target,omitempty"`
+
+	// rebootstrapMode selects when an agent which can no longer reach the server with its SVID,
+	// e.g. after a disconnection longer than the lifetime of its SVID, fetches the trust bundle
+	// again and re-attests in place, rather than crash-looping until its pod is recreated.
+	// Re-attestation requires the k8s_psat node attestor; agents using join tokens must be
+	// given a new token.
+	// Valid values are: Never, Auto, Always.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=Never
+	RebootstrapMode RebootstrapMode `json:"rebootstrapMode,omitempty"`
+
+	// rebootstrapDelay is how long an agent keeps retrying with its SVID before it rebootstraps.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="duration(self) > duration('0s')",message="rebootstrapDelay must be a positive duration"
+	// +kubebuilder:default:="10m"
+	RebootstrapDelay metav1.Duration `json:"rebootstrapDelay,omitempty"`
+}
+
+// AgentSVIDCacheConfig sizes the least recently used caches of the SVIDs of an agent
+type AgentSVIDCacheConfig struct {
+	// x509MaxSize is the soft limit of the X509-SVIDs cached by an agent. Beyond it, the least
+	// recently used SVIDs of the workloads no longer running on the node are evicted, while the
+	// SVIDs of the running workloads are kept. When unset, the SPIRE default of 1000 applies.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100000
+	X509MaxSize *int32 `json:"x509MaxSize,omitempty"`
+
+	// jwtMaxSize is the limit of the JWT-SVIDs cached by an agent, beyond which the least
+	// recently used are evicted and minted again on their next fetch. When unset, the SPIRE
+	// default of 1000 applies.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100000
+	JWTMaxSize *int32 `json:"jwtMaxSize,omitempty"`
+}
+
 // NodeAttestor defines the configuration for the Node Attestor.
+// +kubebuilder:validation:XValidation:rule="!has(self.joinToken) || (has(self.k8sPSATEnabled) && self.k8sPSATEnabled == 'false')",message="joinToken requires k8sPSATEnabled to be 'false'"
 type NodeAttestor struct {
 	// k8sPSATEnabled specifies whether Kubernetes Projected Service Account Token (PSAT)
 	// node attestation is enabled. When enabled, the SPIRE agent uses K8s PSATs to prove
@@ -68,6 +243,50 @@ type NodeAttestor struct {
 	// +kubebuilder:validation:Enum:="true";"false"
 	// +kubebuilder:validation:Optional
 	K8sPSATEnabled string `json:"k8sPSATEnabled,omitempty"`
+
+	// psatAudience is the audience of the projected service account token the agents attest
+	// with. It must be one of the audiences accepted by the SpireServer.
+	// +kubebuilder:default:="spire-server"
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	PSATAudience string `json:"psatAudience,omitempty"`
+
+	// psatTokenExpirationSeconds is the requested lifetime of the projected service account token
+	// the agents attest with. The kubelet refreshes the token before it expires; the token is read
+	// when an agent attests or re-attests.
+	// +kubebuilder:default:=7200
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=600
+	// +kubebuilder:validation:Maximum=86400
+	PSATTokenExpirationSeconds int64 `json:"psatTokenExpirationSeconds,omitempty"`
+
+	// joinToken configures join token node attestation, where agents bootstrap with a token
+	// read from a Secret instead of a projected service account token. The SPIRE server must
+	// have joinTokenAttestationEnabled set for agents to attest.
+	// +kubebuilder:validation:Optional
+	JoinToken *JoinTokenConfig `json:"joinToken,omitempty"`
+}
+
+// JoinTokenConfig defines the Secret-sourced bootstrap token used by the SPIRE agent and its
+// rotation schedule. Rotating the token rolls out the agent DaemonSet; agents that have not yet
+// been restarted with the new token are reported in the BootstrapTokenAvailable condition.
+type JoinTokenConfig struct {
+	// secretName is the name of the Secret in the operator namespace holding the bootstrap token
+	// under the "token" key. The token must be registered with the SPIRE server, for example with
+	// "spire-server token generate".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	SecretName string `json:"secretName"`
+
+	// rotationInterval is how long a bootstrap token may stay in use before it is reported as due
+	// for rotation.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="720h"
+	RotationInterval metav1.Duration `json:"rotationInterval,omitempty"`
 }
 
 // WorkloadAttestors defines the configuration for the Workload Attestors.
@@ -99,6 +318,30 @@ type WorkloadAttestors struct {
 	// +kubebuilder:validation:Enum:="true";"false"
 	// +kubebuilder:validation:Optional
 	UseNewContainerLocator string `json:"useNewContainerLocator,omitempty"`
+
+	// additionalAttestors enables built-in SPIRE agent workload attestors besides k8s, with
+	// their plugin configuration passed through as HCL. The configuration is checked for
+	// syntax and may not set plugin or agent level keys, e.g. plugin_cmd.
+	// Maximum 3 attestors allowed.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=3
+	// +listType=map
+	// +listMapKey=name
+	AdditionalAttestors []AdditionalWorkloadAttestor `json:"additionalAttestors,omitempty"`
+}
+
+// AdditionalWorkloadAttestor configures a built-in SPIRE agent workload attestor
+type AdditionalWorkloadAttestor struct {
+	// name is the name of the workload attestor plugin.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=unix;systemd
+	Name string `json:"name"`
+
+	// pluginData is the configuration of the plugin, in HCL, e.g. discover_workload_path = true
+	// for the unix attestor.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=8192
+	PluginData string `json:"pluginData,omitempty"`
 }
 
 // WorkloadAttestorsVerification configures kubelet TLS certificate verification.
@@ -137,6 +380,134 @@ type WorkloadAttestorsVerification struct {
 type SpireAgentStatus struct {
 	// conditions holds information about the current state of the SPIRE agent deployment.
 	ConditionalStatus `json:",inline,omitempty"`
+
+	// clockSkewedNodes lists the nodes whose clock drifted from the clock of the SPIRE server
+	// beyond the tolerated skew, as measured from the agents running on them.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=50
+	// +listType=map
+	// +listMapKey=nodeName
+	ClockSkewedNodes []NodeClockSkew `json:"clockSkewedNodes,omitempty"`
+
+	// attestedNodes summarizes the agents attested to the SPIRE server, as listed by the server
+	// and refreshed periodically. It is reported on the default agent pool, for all the pools.
+	// +kubebuilder:validation:Optional
+	AttestedNodes *AttestedNodesSummary `json:"attestedNodes,omitempty"`
+
+	// socketExposureViolations lists the pods mounting the agent socket directory through a
+	// hostPath volume without being allowed to, as found by the last socket exposure audit.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=50
+	// +listType=map
+	// +listMapKey=namespace
+	// +listMapKey=pod
+	// +listMapKey=volume
+	SocketExposureViolations []SocketExposureViolation `json:"socketExposureViolations,omitempty"`
+
+	// projectedToken is the service account token projected into the agent pods, as deployed in
+	// the DaemonSet of the agent pool. It lags the spec while a DaemonSet update is held back.
+	// +kubebuilder:validation:Optional
+	ProjectedToken *ProjectedTokenStatus `json:"projectedToken,omitempty"`
+}
+
+// ProjectedTokenStatus is a service account token projected into the pods of an operand.
+type ProjectedTokenStatus struct {
+	// serviceAccount is the service account the token is issued to, as namespace:name.
+	// +kubebuilder:validation:Required
+	ServiceAccount string `json:"serviceAccount"`
+
+	// audience is the audience of the token.
+	// +kubebuilder:validation:Required
+	Audience string `json:"audience"`
+
+	// expirationSeconds is the requested lifetime of the token.
+	// +kubebuilder:validation:Optional
+	ExpirationSeconds int64 `json:"expirationSeconds,omitempty"`
+}
+
+// AttestedNodesSummary summarizes the agents attested to the SPIRE server.
+type AttestedNodesSummary struct {
+	// count is the number of attested agents, including the banned ones.
+	Count int32 `json:"count"`
+
+	// banned is the number of banned agents.
+	// +kubebuilder:validation:Optional
+	Banned int32 `json:"banned,omitempty"`
+
+	// pendingReattestation is the number of agents whose SVID expired without being renewed.
+	// They must attest again to get an identity, e.g. after their node was offline.
+	// +kubebuilder:validation:Optional
+	PendingReattestation int32 `json:"pendingReattestation,omitempty"`
+
+	// oldestSVIDExpiry is the expiry of the agent SVID renewed the longest time ago. The server
+	// does not record when an agent attested; as agent SVIDs share the same validity, the
+	// agent whose SVID expires first is the stalest one.
+	// +kubebuilder:validation:Optional
+	OldestSVIDExpiry *metav1.Time `json:"oldestSVIDExpiry,omitempty"`
+
+	// pendingAgents lists the agents pending re-attestation and their selectors.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=50
+	// +listType=map
+	// +listMapKey=spiffeID
+	PendingAgents []AttestedAgent `json:"pendingAgents,omitempty"`
+}
+
+// AttestedAgent is an agent attested to the SPIRE server.
+type AttestedAgent struct {
+	// spiffeID is the SPIFFE ID of the agent.
+	// +kubebuilder:validation:Required
+	SPIFFEID string `json:"spiffeID"`
+
+	// nodeName is the name of the node of the agent, when attested with its service account token.
+	// +kubebuilder:validation:Optional
+	NodeName string `json:"nodeName,omitempty"`
+
+	// attestationType is the node attestor the agent attested with.
+	// +kubebuilder:validation:Optional
+	AttestationType string `json:"attestationType,omitempty"`
+
+	// selectors are the selectors attributed to the agent during attestation, as type:value.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=atomic
+	Selectors []string `json:"selectors,omitempty"`
+
+	// svidExpiresAt is when the SVID of the agent expires.
+	// +kubebuilder:validation:Optional
+	SVIDExpiresAt *metav1.Time `json:"svidExpiresAt,omitempty"`
+}
+
+// SocketExposureViolation is a hostPath volume of a pod exposing the agent socket directory.
+type SocketExposureViolation struct {
+	// namespace is the namespace of the pod.
+	// +kubebuilder:validation:Required
+	Namespace string `json:"namespace"`
+
+	// pod is the name of the pod.
+	// +kubebuilder:validation:Required
+	Pod string `json:"pod"`
+
+	// volume is the name of the hostPath volume.
+	// +kubebuilder:validation:Required
+	Volume string `json:"volume"`
+
+	// hostPath is the path of the volume on the host, the agent socket directory or one of its
+	// parents or children.
+	// +kubebuilder:validation:Required
+	HostPath string `json:"hostPath"`
+}
+
+// NodeClockSkew reports the clock skew of a node relative to the SPIRE server.
+type NodeClockSkew struct {
+	// nodeName is the name of the node.
+	// +kubebuilder:validation:Required
+	NodeName string `json:"nodeName"`
+
+	// skew is how far the clock of the node is ahead of the clock of the SPIRE server.
+	// A negative skew means the clock of the node is behind.
+	// +kubebuilder:validation:Required
+	Skew metav1.Duration `json:"skew"`
 }
 
 // GetConditionalStatus returns the conditional status of the SpireAgent
@@ -157,3 +528,32 @@ type SpireAgentList struct {
 func init() {
 	SchemeBuilder.Register(&SpireAgent{}, &SpireAgentList{})
 }
+
+// DelegatedIdentityConfig defines the Delegated Identity API of the SPIRE agents
+type DelegatedIdentityConfig struct {
+	// enabled specifies whether the agents serve the Delegated Identity API. Setting it to false
+	// stops serving the API and removes the admin socket mount, keeping the configuration.
+	// +kubebuilder:default:="true"
+	// +kubebuilder:validation:Enum:="true";"false"
+	// +kubebuilder:validation:Optional
+	Enabled string `json:"enabled,omitempty"`
+
+	// adminSocketPath is the directory on the host where the agent admin socket serving the
+	// Delegated Identity API is created. It must differ from socketPath, which is exposed to
+	// every workload through the SPIFFE CSI driver.
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^/[a-zA-Z0-9._/\-]*$`
+	// +kubebuilder:default:="/run/spire/agent-admin"
+	AdminSocketPath string `json:"adminSocketPath,omitempty"`
+
+	// authorizedDelegates are the SPIFFE IDs of the workloads allowed to call the Delegated
+	// Identity API, e.g. spiffe://example.org/ns/mesh/sa/node-proxy. They must be in the trust
+	// domain of the agents. Maximum 16 delegates allowed.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:MaxLength=2048
+	// +kubebuilder:validation:items:Pattern=`^spiffe://`
+	// +listType=set
+	AuthorizedDelegates []string `json:"authorizedDelegates"`
+}
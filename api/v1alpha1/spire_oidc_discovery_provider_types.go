@@ -1,14 +1,20 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:object:root=true
+// +kubebuilder:deprecatedversion:warning="operator.openshift.io/v1alpha1 SpireOIDCDiscoveryProvider is deprecated, use operator.openshift.io/v1alpha2 SpireOIDCDiscoveryProvider"
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.ready`
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.version`
+// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +kubebuilder:validation:XValidation:rule="self.metadata.name == 'cluster'",message="SpireOIDCDiscoveryProvider is a singleton, .metadata.name must be 'cluster'"
 // +operator-sdk:csv:customresourcedefinitions:displayName="SpireOIDCDiscoveryProvider"
 
@@ -79,9 +85,151 @@ type SpireOIDCDiscoveryProviderSpec struct {
 	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
 	ExternalSecretRef string `json:"externalSecretRef,omitempty"`
 
+	// caching configures the Cache-Control header set on responses served through the managed
+	// Route, so CDNs and high-QPS token validators cache the discovery document and JWKS instead
+	// of requesting them on every validation. Only applies when managedRoute is "true".
+	// Request rates are reported by the router metrics of the spire-oidc-discovery-provider Route,
+	// e.g. haproxy_backend_http_responses_total{route="spire-oidc-discovery-provider"}.
+	// +kubebuilder:validation:Optional
+	Caching *OIDCCachingConfig `json:"caching,omitempty"`
+
+	// service customizes the OIDC discovery provider Service.
+	// +kubebuilder:validation:Optional
+	Service *ServiceConfig `json:"service,omitempty"`
+
+	// fallbackClusterSPIFFEIDEnabled controls the default fallback ClusterSPIFFEID, which
+	// registers every pod outside the operator namespace not matched by another ClusterSPIFFEID
+	// with an identity derived from its namespace and service account.
+	// "true": The operator maintains the default fallback ClusterSPIFFEID.
+	// "false": Only pods selected by explicitly created ClusterSPIFFEIDs are registered, and the
+	// operator deletes the default fallback ClusterSPIFFEID.
+	// +kubebuilder:default:="true"
+	// +kubebuilder:validation:Enum:="true";"false"
+	// +kubebuilder:validation:Optional
+	FallbackClusterSPIFFEIDEnabled string `json:"fallbackClusterSPIFFEIDEnabled,omitempty"`
+
+	// namespaceRegistrationPolicy selects the namespaces whose pods are registered by the default
+	// fallback ClusterSPIFFEID, from the spiffe.openshift.io/enabled namespace label.
+	// "OptOut": All namespaces are registered, except those labelled spiffe.openshift.io/enabled=false.
+	// "OptIn": Only the namespaces labelled spiffe.openshift.io/enabled=true are registered, for a
+	// controlled rollout of workload identity on multi-tenant clusters.
+	// +kubebuilder:default:="OptOut"
+	// +kubebuilder:validation:Enum:=OptOut;OptIn
+	// +kubebuilder:validation:Optional
+	NamespaceRegistrationPolicy NamespaceRegistrationPolicy `json:"namespaceRegistrationPolicy,omitempty"`
+
+	// additionalIssuers are issuers served next to jwtIssuer with the JWT signing keys of
+	// another trust domain, e.g. while relying parties move between federated trust domains.
+	// Each issuer is served from its own host, through a Route when managedRoute is "true". The
+	// SPIRE server must federate with the trust domains, whose bundles must not share key IDs
+	// with each other or with the trust domain of the cluster.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=4
+	// +listType=map
+	// +listMapKey=issuer
+	AdditionalIssuers []OIDCAdditionalIssuer `json:"additionalIssuers,omitempty"`
+
+	// jwtValidatingProxies are reverse proxies deployed in front of services which cannot
+	// validate JWT-SVIDs themselves. Each proxy only forwards the requests bearing a JWT-SVID
+	// issued by jwtIssuer for one of its audiences, validated against the keys served by the
+	// OIDC discovery provider, so legacy services enforce SPIFFE authentication without code
+	// changes. The proxy image is set by the RELATED_IMAGE_JWT_VALIDATING_PROXY environment
+	// variable of the operator.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=8
+	// +listType=map
+	// +listMapKey=name
+	JWTValidatingProxies []JWTValidatingProxy `json:"jwtValidatingProxies,omitempty"`
+
 	CommonConfig `json:",inline"`
 }
 
+// OIDCAdditionalIssuer is an issuer served with the JWT signing keys of a federated trust domain
+type OIDCAdditionalIssuer struct {
+	// issuer is the issuer URL, whose host serves the discovery document and the keys.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=512
+	// +kubebuilder:validation:Pattern=`^(?i)https?://[^\s?#]+$`
+	Issuer string `json:"issuer"`
+
+	// trustDomain is the federated trust domain whose JWT signing keys are served for the issuer.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=255
+	// +kubebuilder:validation:Pattern=`^[a-z0-9._-]{1,255}$`
+	TrustDomain string `json:"trustDomain"`
+}
+
+// JWTValidatingProxy is a reverse proxy only forwarding the requests authenticated by a JWT-SVID
+type JWTValidatingProxy struct {
+	// name of the proxy, from which the names of its Deployment, Service and ConfigMap are
+	// derived as jwt-proxy-<name>.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=40
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// audiences are the audiences accepted in the aud claim of the JWT-SVIDs.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	// +listType=set
+	Audiences []string `json:"audiences"`
+
+	// upstream is the URL of the service the authenticated requests are forwarded to, e.g.
+	// http://legacy-app.legacy.svc:8080. HTTPS upstreams must serve a certificate issued by the
+	// OpenShift service CA.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=512
+	// +kubebuilder:validation:Pattern=`^https?://[a-z0-9]([-a-z0-9.]*[a-z0-9])?(:[0-9]{1,5})?/?$`
+	Upstream string `json:"upstream"`
+
+	// port the proxy listens on, also the port of its Service.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1024
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default:=8080
+	Port int32 `json:"port,omitempty"`
+
+	// replicas is the number of replicas of the proxy.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=5
+	// +kubebuilder:default:=1
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// resources are the compute resources of the proxy container.
+	// +kubebuilder:validation:Optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// NamespaceRegistrationPolicy selects the namespaces registered by the default fallback ClusterSPIFFEID
+type NamespaceRegistrationPolicy string
+
+const (
+	// NamespaceRegistrationOptOut registers all namespaces except those labelled out
+	NamespaceRegistrationOptOut NamespaceRegistrationPolicy = "OptOut"
+	// NamespaceRegistrationOptIn only registers the namespaces labelled in
+	NamespaceRegistrationOptIn NamespaceRegistrationPolicy = "OptIn"
+)
+
+// OIDCCachingConfig defines how long clients may cache OIDC discovery provider responses
+type OIDCCachingConfig struct {
+	// maxAge is how long responses may be cached. Keep it well below the time a new JWT
+	// signing key is published before use, so validators see rotated keys in time.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="5m"
+	MaxAge metav1.Duration `json:"maxAge"`
+
+	// staleWhileRevalidate is how long a cached response may still be served while it is
+	// revalidated in the background. Not advertised when zero.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:validation:Optional
+	StaleWhileRevalidate metav1.Duration `json:"staleWhileRevalidate,omitempty"`
+}
+
 // SpireOIDCDiscoveryProviderStatus defines the observed state of the SPIRE OIDC discovery provider
 // reconciliation performed by the operator
 type SpireOIDCDiscoveryProviderStatus struct {
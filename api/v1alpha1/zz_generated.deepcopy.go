@@ -41,6 +41,153 @@ func (in *AcmeConfig) DeepCopy() *AcmeConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalWorkloadAttestor) DeepCopyInto(out *AdditionalWorkloadAttestor) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalWorkloadAttestor.
+func (in *AdditionalWorkloadAttestor) DeepCopy() *AdditionalWorkloadAttestor {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalWorkloadAttestor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentAvailabilityConfig) DeepCopyInto(out *AgentAvailabilityConfig) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	out.RebootstrapDelay = in.RebootstrapDelay
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentAvailabilityConfig.
+func (in *AgentAvailabilityConfig) DeepCopy() *AgentAvailabilityConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentAvailabilityConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentSVIDCacheConfig) DeepCopyInto(out *AgentSVIDCacheConfig) {
+	*out = *in
+	if in.X509MaxSize != nil {
+		in, out := &in.X509MaxSize, &out.X509MaxSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.JWTMaxSize != nil {
+		in, out := &in.JWTMaxSize, &out.JWTMaxSize
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentSVIDCacheConfig.
+func (in *AgentSVIDCacheConfig) DeepCopy() *AgentSVIDCacheConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentSVIDCacheConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AttestedAgent) DeepCopyInto(out *AttestedAgent) {
+	*out = *in
+	if in.Selectors != nil {
+		in, out := &in.Selectors, &out.Selectors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SVIDExpiresAt != nil {
+		in, out := &in.SVIDExpiresAt, &out.SVIDExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttestedAgent.
+func (in *AttestedAgent) DeepCopy() *AttestedAgent {
+	if in == nil {
+		return nil
+	}
+	out := new(AttestedAgent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AttestedNodesSummary) DeepCopyInto(out *AttestedNodesSummary) {
+	*out = *in
+	if in.OldestSVIDExpiry != nil {
+		in, out := &in.OldestSVIDExpiry, &out.OldestSVIDExpiry
+		*out = (*in).DeepCopy()
+	}
+	if in.PendingAgents != nil {
+		in, out := &in.PendingAgents, &out.PendingAgents
+		*out = make([]AttestedAgent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttestedNodesSummary.
+func (in *AttestedNodesSummary) DeepCopy() *AttestedNodesSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(AttestedNodesSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BreakGlassStatus) DeepCopyInto(out *BreakGlassStatus) {
+	*out = *in
+	if in.EntryIDs != nil {
+		in, out := &in.EntryIDs, &out.EntryIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BreakGlassStatus.
+func (in *BreakGlassStatus) DeepCopy() *BreakGlassStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BreakGlassStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleConfigMapTarget) DeepCopyInto(out *BundleConfigMapTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleConfigMapTarget.
+func (in *BundleConfigMapTarget) DeepCopy() *BundleConfigMapTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleConfigMapTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BundleEndpointConfig) DeepCopyInto(out *BundleEndpointConfig) {
 	*out = *in
@@ -61,6 +208,26 @@ func (in *BundleEndpointConfig) DeepCopy() *BundleEndpointConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleNotifierConfig) DeepCopyInto(out *BundleNotifierConfig) {
+	*out = *in
+	if in.ConfigMaps != nil {
+		in, out := &in.ConfigMaps, &out.ConfigMaps
+		*out = make([]BundleConfigMapTarget, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleNotifierConfig.
+func (in *BundleNotifierConfig) DeepCopy() *BundleNotifierConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleNotifierConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CASubject) DeepCopyInto(out *CASubject) {
 	*out = *in
@@ -114,6 +281,21 @@ func (in *CommonConfig) DeepCopyInto(out *CommonConfig) {
 			(*out)[key] = val
 		}
 	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedResources != nil {
+		in, out := &in.ManagedResources, &out.ManagedResources
+		*out = new(ManagedResources)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonConfig.
@@ -148,9 +330,60 @@ func (in *ConditionalStatus) DeepCopy() *ConditionalStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerManagerNamespaces) DeepCopyInto(out *ControllerManagerNamespaces) {
+	*out = *in
+	if in.Watch != nil {
+		in, out := &in.Watch, &out.Watch
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ignore != nil {
+		in, out := &in.Ignore, &out.Ignore
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerManagerNamespaces.
+func (in *ControllerManagerNamespaces) DeepCopy() *ControllerManagerNamespaces {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerManagerNamespaces)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerManagerWebhookConfig) DeepCopyInto(out *ControllerManagerWebhookConfig) {
+	*out = *in
+	out.CertificateExpiryWarning = in.CertificateExpiryWarning
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerManagerWebhookConfig.
+func (in *ControllerManagerWebhookConfig) DeepCopy() *ControllerManagerWebhookConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerManagerWebhookConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataStore) DeepCopyInto(out *DataStore) {
 	*out = *in
+	if in.UpgradeBackupCheck != nil {
+		in, out := &in.UpgradeBackupCheck, &out.UpgradeBackupCheck
+		*out = new(DatastoreBackupCheck)
+		**out = **in
+	}
+	if in.DiskUsage != nil {
+		in, out := &in.DiskUsage, &out.DiskUsage
+		*out = new(DatastoreDiskUsage)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStore.
@@ -163,6 +396,113 @@ func (in *DataStore) DeepCopy() *DataStore {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatastoreBackupCheck) DeepCopyInto(out *DatastoreBackupCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatastoreBackupCheck.
+func (in *DatastoreBackupCheck) DeepCopy() *DatastoreBackupCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(DatastoreBackupCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatastoreCompaction) DeepCopyInto(out *DatastoreCompaction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatastoreCompaction.
+func (in *DatastoreCompaction) DeepCopy() *DatastoreCompaction {
+	if in == nil {
+		return nil
+	}
+	out := new(DatastoreCompaction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatastoreDiskUsage) DeepCopyInto(out *DatastoreDiskUsage) {
+	*out = *in
+	if in.Compaction != nil {
+		in, out := &in.Compaction, &out.Compaction
+		*out = new(DatastoreCompaction)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatastoreDiskUsage.
+func (in *DatastoreDiskUsage) DeepCopy() *DatastoreDiskUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(DatastoreDiskUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DelegatedIdentityConfig) DeepCopyInto(out *DelegatedIdentityConfig) {
+	*out = *in
+	if in.AuthorizedDelegates != nil {
+		in, out := &in.AuthorizedDelegates, &out.AuthorizedDelegates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DelegatedIdentityConfig.
+func (in *DelegatedIdentityConfig) DeepCopy() *DelegatedIdentityConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DelegatedIdentityConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalAgentsConfig) DeepCopyInto(out *ExternalAgentsConfig) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalAgentsConfig.
+func (in *ExternalAgentsConfig) DeepCopy() *ExternalAgentsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalAgentsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalPlugin) DeepCopyInto(out *ExternalPlugin) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalPlugin.
+func (in *ExternalPlugin) DeepCopy() *ExternalPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FederatesWithConfig) DeepCopyInto(out *FederatesWithConfig) {
 	*out = *in
@@ -200,129 +540,1015 @@ func (in *FederationConfig) DeepCopy() *FederationConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HttpsWebConfig) DeepCopyInto(out *HttpsWebConfig) {
+func (in *FederationPartnerStatus) DeepCopyInto(out *FederationPartnerStatus) {
 	*out = *in
-	if in.Acme != nil {
-		in, out := &in.Acme, &out.Acme
-		*out = new(AcmeConfig)
-		**out = **in
-	}
-	if in.ServingCert != nil {
-		in, out := &in.ServingCert, &out.ServingCert
-		*out = new(ServingCertConfig)
-		**out = **in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HttpsWebConfig.
-func (in *HttpsWebConfig) DeepCopy() *HttpsWebConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FederationPartnerStatus.
+func (in *FederationPartnerStatus) DeepCopy() *FederationPartnerStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(HttpsWebConfig)
+	out := new(FederationPartnerStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeyManager) DeepCopyInto(out *KeyManager) {
+func (in *FederationStatus) DeepCopyInto(out *FederationStatus) {
 	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Partners != nil {
+		in, out := &in.Partners, &out.Partners
+		*out = make([]FederationPartnerStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyManager.
-func (in *KeyManager) DeepCopy() *KeyManager {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FederationStatus.
+func (in *FederationStatus) DeepCopy() *FederationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KeyManager)
+	out := new(FederationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeAttestor) DeepCopyInto(out *NodeAttestor) {
+func (in *HelmMigrationConfig) DeepCopyInto(out *HelmMigrationConfig) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAttestor.
-func (in *NodeAttestor) DeepCopy() *NodeAttestor {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmMigrationConfig.
+func (in *HelmMigrationConfig) DeepCopy() *HelmMigrationConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeAttestor)
+	out := new(HelmMigrationConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
+func (in *HttpsWebConfig) DeepCopyInto(out *HttpsWebConfig) {
 	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectReference.
-func (in *ObjectReference) DeepCopy() *ObjectReference {
-	if in == nil {
+	if in.Acme != nil {
+		in, out := &in.Acme, &out.Acme
+		*out = new(AcmeConfig)
+		**out = **in
+	}
+	if in.ServingCert != nil {
+		in, out := &in.ServingCert, &out.ServingCert
+		*out = new(ServingCertConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HttpsWebConfig.
+func (in *HttpsWebConfig) DeepCopy() *HttpsWebConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HttpsWebConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IncompatibleNode) DeepCopyInto(out *IncompatibleNode) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IncompatibleNode.
+func (in *IncompatibleNode) DeepCopy() *IncompatibleNode {
+	if in == nil {
+		return nil
+	}
+	out := new(IncompatibleNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTSVIDFile) DeepCopyInto(out *JWTSVIDFile) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTSVIDFile.
+func (in *JWTSVIDFile) DeepCopy() *JWTSVIDFile {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTSVIDFile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTValidatingProxy) DeepCopyInto(out *JWTValidatingProxy) {
+	*out = *in
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTValidatingProxy.
+func (in *JWTValidatingProxy) DeepCopy() *JWTValidatingProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTValidatingProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JoinTokenConfig) DeepCopyInto(out *JoinTokenConfig) {
+	*out = *in
+	out.RotationInterval = in.RotationInterval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JoinTokenConfig.
+func (in *JoinTokenConfig) DeepCopy() *JoinTokenConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(JoinTokenConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyManager) DeepCopyInto(out *KeyManager) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyManager.
+func (in *KeyManager) DeepCopy() *KeyManager {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyManager)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedResources) DeepCopyInto(out *ManagedResources) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedResources.
+func (in *ManagedResources) DeepCopy() *ManagedResources {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MintSVIDRequest) DeepCopyInto(out *MintSVIDRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MintSVIDRequest.
+func (in *MintSVIDRequest) DeepCopy() *MintSVIDRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(MintSVIDRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MintSVIDRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MintSVIDRequestList) DeepCopyInto(out *MintSVIDRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MintSVIDRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MintSVIDRequestList.
+func (in *MintSVIDRequestList) DeepCopy() *MintSVIDRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(MintSVIDRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MintSVIDRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MintSVIDRequestSpec) DeepCopyInto(out *MintSVIDRequestSpec) {
+	*out = *in
+	if in.Audience != nil {
+		in, out := &in.Audience, &out.Audience
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MintSVIDRequestSpec.
+func (in *MintSVIDRequestSpec) DeepCopy() *MintSVIDRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MintSVIDRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MintSVIDRequestStatus) DeepCopyInto(out *MintSVIDRequestStatus) {
+	*out = *in
+	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MintSVIDRequestStatus.
+func (in *MintSVIDRequestStatus) DeepCopy() *MintSVIDRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MintSVIDRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceGuardrailsConfig) DeepCopyInto(out *NamespaceGuardrailsConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceGuardrailsConfig.
+func (in *NamespaceGuardrailsConfig) DeepCopy() *NamespaceGuardrailsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceGuardrailsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeAttestor) DeepCopyInto(out *NodeAttestor) {
+	*out = *in
+	if in.JoinToken != nil {
+		in, out := &in.JoinToken, &out.JoinToken
+		*out = new(JoinTokenConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAttestor.
+func (in *NodeAttestor) DeepCopy() *NodeAttestor {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeAttestor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeClockSkew) DeepCopyInto(out *NodeClockSkew) {
+	*out = *in
+	out.Skew = in.Skew
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeClockSkew.
+func (in *NodeClockSkew) DeepCopy() *NodeClockSkew {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeClockSkew)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeLabelSelector) DeepCopyInto(out *NodeLabelSelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeLabelSelector.
+func (in *NodeLabelSelector) DeepCopy() *NodeLabelSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeLabelSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCAdditionalIssuer) DeepCopyInto(out *OIDCAdditionalIssuer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCAdditionalIssuer.
+func (in *OIDCAdditionalIssuer) DeepCopy() *OIDCAdditionalIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCAdditionalIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCCachingConfig) DeepCopyInto(out *OIDCCachingConfig) {
+	*out = *in
+	out.MaxAge = in.MaxAge
+	out.StaleWhileRevalidate = in.StaleWhileRevalidate
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCCachingConfig.
+func (in *OIDCCachingConfig) DeepCopy() *OIDCCachingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCCachingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectReference.
+func (in *ObjectReference) DeepCopy() *ObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperandStatus) DeepCopyInto(out *OperandStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandStatus.
+func (in *OperandStatus) DeepCopy() *OperandStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OperandStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PSATAttestationConfig) DeepCopyInto(out *PSATAttestationConfig) {
+	*out = *in
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAccountAllowList != nil {
+		in, out := &in.ServiceAccountAllowList, &out.ServiceAccountAllowList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeLabelSelectors != nil {
+		in, out := &in.NodeLabelSelectors, &out.NodeLabelSelectors
+		*out = make([]NodeLabelSelector, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PSATAttestationConfig.
+func (in *PSATAttestationConfig) DeepCopy() *PSATAttestationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PSATAttestationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Persistence) DeepCopyInto(out *Persistence) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Persistence.
+func (in *Persistence) DeepCopy() *Persistence {
+	if in == nil {
+		return nil
+	}
+	out := new(Persistence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginStatus) DeepCopyInto(out *PluginStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginStatus.
+func (in *PluginStatus) DeepCopy() *PluginStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectedTokenStatus) DeepCopyInto(out *ProjectedTokenStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectedTokenStatus.
+func (in *ProjectedTokenStatus) DeepCopy() *ProjectedTokenStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectedTokenStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitConfig) DeepCopyInto(out *RateLimitConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitConfig.
+func (in *RateLimitConfig) DeepCopy() *RateLimitConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistrationLimits) DeepCopyInto(out *RegistrationLimits) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistrationLimits.
+func (in *RegistrationLimits) DeepCopy() *RegistrationLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistrationLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutVerificationConfig) DeepCopyInto(out *RolloutVerificationConfig) {
+	*out = *in
+	out.Timeout = in.Timeout
+	out.MinBundleValidity = in.MinBundleValidity
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutVerificationConfig.
+func (in *RolloutVerificationConfig) DeepCopy() *RolloutVerificationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutVerificationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutVerificationStatus) DeepCopyInto(out *RolloutVerificationStatus) {
+	*out = *in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutVerificationStatus.
+func (in *RolloutVerificationStatus) DeepCopy() *RolloutVerificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutVerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SPIFFEIDPolicy) DeepCopyInto(out *SPIFFEIDPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SPIFFEIDPolicy.
+func (in *SPIFFEIDPolicy) DeepCopy() *SPIFFEIDPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SPIFFEIDPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SPIFFEIDPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SPIFFEIDPolicyList) DeepCopyInto(out *SPIFFEIDPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SPIFFEIDPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SPIFFEIDPolicyList.
+func (in *SPIFFEIDPolicyList) DeepCopy() *SPIFFEIDPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(SPIFFEIDPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SPIFFEIDPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SPIFFEIDPolicyRule) DeepCopyInto(out *SPIFFEIDPolicyRule) {
+	*out = *in
+	in.EntrySelector.DeepCopyInto(&out.EntrySelector)
+	if in.AllowedPathPrefixes != nil {
+		in, out := &in.AllowedPathPrefixes, &out.AllowedPathPrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SPIFFEIDPolicyRule.
+func (in *SPIFFEIDPolicyRule) DeepCopy() *SPIFFEIDPolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(SPIFFEIDPolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SPIFFEIDPolicySpec) DeepCopyInto(out *SPIFFEIDPolicySpec) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]SPIFFEIDPolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SPIFFEIDPolicySpec.
+func (in *SPIFFEIDPolicySpec) DeepCopy() *SPIFFEIDPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SPIFFEIDPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SPIFFEIDPolicyStatus) DeepCopyInto(out *SPIFFEIDPolicyStatus) {
+	*out = *in
+	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
+	if in.Violations != nil {
+		in, out := &in.Violations, &out.Violations
+		*out = make([]SPIFFEIDPolicyViolation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SPIFFEIDPolicyStatus.
+func (in *SPIFFEIDPolicyStatus) DeepCopy() *SPIFFEIDPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SPIFFEIDPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SPIFFEIDPolicyViolation) DeepCopyInto(out *SPIFFEIDPolicyViolation) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SPIFFEIDPolicyViolation.
+func (in *SPIFFEIDPolicyViolation) DeepCopy() *SPIFFEIDPolicyViolation {
+	if in == nil {
+		return nil
+	}
+	out := new(SPIFFEIDPolicyViolation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretReference) DeepCopyInto(out *SecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
+func (in *SecretReference) DeepCopy() *SecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceConfig) DeepCopyInto(out *ServiceConfig) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]ServicePortConfig, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceConfig.
+func (in *ServiceConfig) DeepCopy() *ServiceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServicePortConfig) DeepCopyInto(out *ServicePortConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicePortConfig.
+func (in *ServicePortConfig) DeepCopy() *ServicePortConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ServicePortConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServingCertConfig) DeepCopyInto(out *ServingCertConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServingCertConfig.
+func (in *ServingCertConfig) DeepCopy() *ServingCertConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ServingCertConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SizingRecommendation) DeepCopyInto(out *SizingRecommendation) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PeakAgentSyncLatency != nil {
+		in, out := &in.PeakAgentSyncLatency, &out.PeakAgentSyncLatency
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ObservedSince != nil {
+		in, out := &in.ObservedSince, &out.ObservedSince
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SizingRecommendation.
+func (in *SizingRecommendation) DeepCopy() *SizingRecommendation {
+	if in == nil {
+		return nil
+	}
+	out := new(SizingRecommendation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SizingRecommendationsConfig) DeepCopyInto(out *SizingRecommendationsConfig) {
+	*out = *in
+	out.Window = in.Window
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SizingRecommendationsConfig.
+func (in *SizingRecommendationsConfig) DeepCopy() *SizingRecommendationsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SizingRecommendationsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SocketDirectoryConfig) DeepCopyInto(out *SocketDirectoryConfig) {
+	*out = *in
+	if in.Group != nil {
+		in, out := &in.Group, &out.Group
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SocketDirectoryConfig.
+func (in *SocketDirectoryConfig) DeepCopy() *SocketDirectoryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SocketDirectoryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SocketExposureAuditConfig) DeepCopyInto(out *SocketExposureAuditConfig) {
+	*out = *in
+	if in.AllowedServiceAccounts != nil {
+		in, out := &in.AllowedServiceAccounts, &out.AllowedServiceAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SocketExposureAuditConfig.
+func (in *SocketExposureAuditConfig) DeepCopy() *SocketExposureAuditConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SocketExposureAuditConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SocketExposureViolation) DeepCopyInto(out *SocketExposureViolation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SocketExposureViolation.
+func (in *SocketExposureViolation) DeepCopy() *SocketExposureViolation {
+	if in == nil {
+		return nil
+	}
+	out := new(SocketExposureViolation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpiffeCSIDriver) DeepCopyInto(out *SpiffeCSIDriver) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiffeCSIDriver.
+func (in *SpiffeCSIDriver) DeepCopy() *SpiffeCSIDriver {
+	if in == nil {
 		return nil
 	}
-	out := new(ObjectReference)
-	in.DeepCopyInto(out)
-	return out
+	out := new(SpiffeCSIDriver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpiffeCSIDriver) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OperandStatus) DeepCopyInto(out *OperandStatus) {
+func (in *SpiffeCSIDriverList) DeepCopyInto(out *SpiffeCSIDriverList) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SpiffeCSIDriver, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandStatus.
-func (in *OperandStatus) DeepCopy() *OperandStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiffeCSIDriverList.
+func (in *SpiffeCSIDriverList) DeepCopy() *SpiffeCSIDriverList {
 	if in == nil {
 		return nil
 	}
-	out := new(OperandStatus)
+	out := new(SpiffeCSIDriverList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpiffeCSIDriverList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Persistence) DeepCopyInto(out *Persistence) {
+func (in *SpiffeCSIDriverSpec) DeepCopyInto(out *SpiffeCSIDriverSpec) {
 	*out = *in
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]Architecture, len(*in))
+		copy(*out, *in)
+	}
+	if in.SocketDirectory != nil {
+		in, out := &in.SocketDirectory, &out.SocketDirectory
+		*out = new(SocketDirectoryConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	in.CommonConfig.DeepCopyInto(&out.CommonConfig)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Persistence.
-func (in *Persistence) DeepCopy() *Persistence {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiffeCSIDriverSpec.
+func (in *SpiffeCSIDriverSpec) DeepCopy() *SpiffeCSIDriverSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Persistence)
+	out := new(SpiffeCSIDriverSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServingCertConfig) DeepCopyInto(out *ServingCertConfig) {
+func (in *SpiffeCSIDriverStatus) DeepCopyInto(out *SpiffeCSIDriverStatus) {
 	*out = *in
+	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
+	if in.IncompatibleNodes != nil {
+		in, out := &in.IncompatibleNodes, &out.IncompatibleNodes
+		*out = make([]IncompatibleNode, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServingCertConfig.
-func (in *ServingCertConfig) DeepCopy() *ServingCertConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiffeCSIDriverStatus.
+func (in *SpiffeCSIDriverStatus) DeepCopy() *SpiffeCSIDriverStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ServingCertConfig)
+	out := new(SpiffeCSIDriverStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SpiffeCSIDriver) DeepCopyInto(out *SpiffeCSIDriver) {
+func (in *SpiffeHelperConfig) DeepCopyInto(out *SpiffeHelperConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -330,18 +1556,18 @@ func (in *SpiffeCSIDriver) DeepCopyInto(out *SpiffeCSIDriver) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiffeCSIDriver.
-func (in *SpiffeCSIDriver) DeepCopy() *SpiffeCSIDriver {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiffeHelperConfig.
+func (in *SpiffeHelperConfig) DeepCopy() *SpiffeHelperConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(SpiffeCSIDriver)
+	out := new(SpiffeHelperConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SpiffeCSIDriver) DeepCopyObject() runtime.Object {
+func (in *SpiffeHelperConfig) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -349,31 +1575,31 @@ func (in *SpiffeCSIDriver) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SpiffeCSIDriverList) DeepCopyInto(out *SpiffeCSIDriverList) {
+func (in *SpiffeHelperConfigList) DeepCopyInto(out *SpiffeHelperConfigList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]SpiffeCSIDriver, len(*in))
+		*out = make([]SpiffeHelperConfig, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiffeCSIDriverList.
-func (in *SpiffeCSIDriverList) DeepCopy() *SpiffeCSIDriverList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiffeHelperConfigList.
+func (in *SpiffeHelperConfigList) DeepCopy() *SpiffeHelperConfigList {
 	if in == nil {
 		return nil
 	}
-	out := new(SpiffeCSIDriverList)
+	out := new(SpiffeHelperConfigList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SpiffeCSIDriverList) DeepCopyObject() runtime.Object {
+func (in *SpiffeHelperConfigList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -381,33 +1607,47 @@ func (in *SpiffeCSIDriverList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SpiffeCSIDriverSpec) DeepCopyInto(out *SpiffeCSIDriverSpec) {
+func (in *SpiffeHelperConfigSpec) DeepCopyInto(out *SpiffeHelperConfigSpec) {
 	*out = *in
-	in.CommonConfig.DeepCopyInto(&out.CommonConfig)
+	if in.JWTSVIDs != nil {
+		in, out := &in.JWTSVIDs, &out.JWTSVIDs
+		*out = make([]JWTSVIDFile, len(*in))
+		copy(*out, *in)
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiffeCSIDriverSpec.
-func (in *SpiffeCSIDriverSpec) DeepCopy() *SpiffeCSIDriverSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiffeHelperConfigSpec.
+func (in *SpiffeHelperConfigSpec) DeepCopy() *SpiffeHelperConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SpiffeCSIDriverSpec)
+	out := new(SpiffeHelperConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SpiffeCSIDriverStatus) DeepCopyInto(out *SpiffeCSIDriverStatus) {
+func (in *SpiffeHelperConfigStatus) DeepCopyInto(out *SpiffeHelperConfigStatus) {
 	*out = *in
 	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiffeCSIDriverStatus.
-func (in *SpiffeCSIDriverStatus) DeepCopy() *SpiffeCSIDriverStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiffeHelperConfigStatus.
+func (in *SpiffeHelperConfigStatus) DeepCopy() *SpiffeHelperConfigStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SpiffeCSIDriverStatus)
+	out := new(SpiffeHelperConfigStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -477,13 +1717,60 @@ func (in *SpireAgentSpec) DeepCopyInto(out *SpireAgentSpec) {
 	if in.NodeAttestor != nil {
 		in, out := &in.NodeAttestor, &out.NodeAttestor
 		*out = new(NodeAttestor)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.WorkloadAttestors != nil {
 		in, out := &in.WorkloadAttestors, &out.WorkloadAttestors
 		*out = new(WorkloadAttestors)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ServiceConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]Architecture, len(*in))
+		copy(*out, *in)
+	}
+	if in.HealthProbe != nil {
+		in, out := &in.HealthProbe, &out.HealthProbe
+		*out = new(WorkloadAPIHealthProbe)
+		**out = **in
+	}
+	if in.DelegatedIdentity != nil {
+		in, out := &in.DelegatedIdentity, &out.DelegatedIdentity
+		*out = new(DelegatedIdentityConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExperimentalFlags != nil {
+		in, out := &in.ExperimentalFlags, &out.ExperimentalFlags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Telemetry != nil {
+		in, out := &in.Telemetry, &out.Telemetry
+		*out = new(TelemetryConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Availability != nil {
+		in, out := &in.Availability, &out.Availability
+		*out = new(AgentAvailabilityConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SVIDCache != nil {
+		in, out := &in.SVIDCache, &out.SVIDCache
+		*out = new(AgentSVIDCacheConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SocketExposureAudit != nil {
+		in, out := &in.SocketExposureAudit, &out.SocketExposureAudit
+		*out = new(SocketExposureAuditConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	in.CommonConfig.DeepCopyInto(&out.CommonConfig)
 }
 
@@ -501,6 +1788,26 @@ func (in *SpireAgentSpec) DeepCopy() *SpireAgentSpec {
 func (in *SpireAgentStatus) DeepCopyInto(out *SpireAgentStatus) {
 	*out = *in
 	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
+	if in.ClockSkewedNodes != nil {
+		in, out := &in.ClockSkewedNodes, &out.ClockSkewedNodes
+		*out = make([]NodeClockSkew, len(*in))
+		copy(*out, *in)
+	}
+	if in.AttestedNodes != nil {
+		in, out := &in.AttestedNodes, &out.AttestedNodes
+		*out = new(AttestedNodesSummary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SocketExposureViolations != nil {
+		in, out := &in.SocketExposureViolations, &out.SocketExposureViolations
+		*out = make([]SocketExposureViolation, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProjectedToken != nil {
+		in, out := &in.ProjectedToken, &out.ProjectedToken
+		*out = new(ProjectedTokenStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpireAgentStatus.
@@ -575,6 +1882,28 @@ func (in *SpireOIDCDiscoveryProviderList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpireOIDCDiscoveryProviderSpec) DeepCopyInto(out *SpireOIDCDiscoveryProviderSpec) {
 	*out = *in
+	if in.Caching != nil {
+		in, out := &in.Caching, &out.Caching
+		*out = new(OIDCCachingConfig)
+		**out = **in
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ServiceConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalIssuers != nil {
+		in, out := &in.AdditionalIssuers, &out.AdditionalIssuers
+		*out = make([]OIDCAdditionalIssuer, len(*in))
+		copy(*out, *in)
+	}
+	if in.JWTValidatingProxies != nil {
+		in, out := &in.JWTValidatingProxies, &out.JWTValidatingProxies
+		*out = make([]JWTValidatingProxy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.CommonConfig.DeepCopyInto(&out.CommonConfig)
 }
 
@@ -666,9 +1995,19 @@ func (in *SpireServerList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpireServerSpec) DeepCopyInto(out *SpireServerSpec) {
 	*out = *in
+	if in.JwtIssuerAliases != nil {
+		in, out := &in.JwtIssuerAliases, &out.JwtIssuerAliases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	out.CAValidity = in.CAValidity
 	out.DefaultX509Validity = in.DefaultX509Validity
 	out.DefaultJWTValidity = in.DefaultJWTValidity
+	if in.AgentTTL != nil {
+		in, out := &in.AgentTTL, &out.AgentTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	if in.KeyManager != nil {
 		in, out := &in.KeyManager, &out.KeyManager
 		*out = new(KeyManager)
@@ -676,12 +2015,91 @@ func (in *SpireServerSpec) DeepCopyInto(out *SpireServerSpec) {
 	}
 	out.CASubject = in.CASubject
 	out.Persistence = in.Persistence
-	out.Datastore = in.Datastore
+	in.Datastore.DeepCopyInto(&out.Datastore)
 	if in.Federation != nil {
 		in, out := &in.Federation, &out.Federation
 		*out = new(FederationConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]corev1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdminIDs != nil {
+		in, out := &in.AdminIDs, &out.AdminIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PSAT != nil {
+		in, out := &in.PSAT, &out.PSAT
+		*out = new(PSATAttestationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ServiceConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalAgents != nil {
+		in, out := &in.ExternalAgents, &out.ExternalAgents
+		*out = new(ExternalAgentsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(RegistrationLimits)
+		**out = **in
+	}
+	if in.BundleNotifier != nil {
+		in, out := &in.BundleNotifier, &out.BundleNotifier
+		*out = new(BundleNotifierConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControllerManagerWebhook != nil {
+		in, out := &in.ControllerManagerWebhook, &out.ControllerManagerWebhook
+		*out = new(ControllerManagerWebhookConfig)
+		**out = **in
+	}
+	if in.ControllerManagerNamespaces != nil {
+		in, out := &in.ControllerManagerNamespaces, &out.ControllerManagerNamespaces
+		*out = new(ControllerManagerNamespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalPlugins != nil {
+		in, out := &in.ExternalPlugins, &out.ExternalPlugins
+		*out = make([]ExternalPlugin, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExperimentalFlags != nil {
+		in, out := &in.ExperimentalFlags, &out.ExperimentalFlags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Telemetry != nil {
+		in, out := &in.Telemetry, &out.Telemetry
+		*out = new(TelemetryConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RolloutVerification != nil {
+		in, out := &in.RolloutVerification, &out.RolloutVerification
+		*out = new(RolloutVerificationConfig)
+		**out = **in
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimitConfig)
+		**out = **in
+	}
+	if in.SizingRecommendations != nil {
+		in, out := &in.SizingRecommendations, &out.SizingRecommendations
+		*out = new(SizingRecommendationsConfig)
+		**out = **in
+	}
 	in.CommonConfig.DeepCopyInto(&out.CommonConfig)
 }
 
@@ -699,6 +2117,39 @@ func (in *SpireServerSpec) DeepCopy() *SpireServerSpec {
 func (in *SpireServerStatus) DeepCopyInto(out *SpireServerStatus) {
 	*out = *in
 	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]PluginStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.WebhookCertificateExpiry != nil {
+		in, out := &in.WebhookCertificateExpiry, &out.WebhookCertificateExpiry
+		*out = (*in).DeepCopy()
+	}
+	if in.BreakGlass != nil {
+		in, out := &in.BreakGlass, &out.BreakGlass
+		*out = new(BreakGlassStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServerAPIOutageSince != nil {
+		in, out := &in.ServerAPIOutageSince, &out.ServerAPIOutageSince
+		*out = (*in).DeepCopy()
+	}
+	if in.RolloutVerification != nil {
+		in, out := &in.RolloutVerification, &out.RolloutVerification
+		*out = new(RolloutVerificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Federation != nil {
+		in, out := &in.Federation, &out.Federation
+		*out = new(FederationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SizingRecommendation != nil {
+		in, out := &in.SizingRecommendation, &out.SizingRecommendation
+		*out = new(SizingRecommendation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpireServerStatus.
@@ -711,6 +2162,97 @@ func (in *SpireServerStatus) DeepCopy() *SpireServerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatsdBridgeConfig) DeepCopyInto(out *StatsdBridgeConfig) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatsdBridgeConfig.
+func (in *StatsdBridgeConfig) DeepCopy() *StatsdBridgeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(StatsdBridgeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TelemetryConfig) DeepCopyInto(out *TelemetryConfig) {
+	*out = *in
+	if in.StatsdBridge != nil {
+		in, out := &in.StatsdBridge, &out.StatsdBridge
+		*out = new(StatsdBridgeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TelemetryConfig.
+func (in *TelemetryConfig) DeepCopy() *TelemetryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TelemetryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrustBundleFormats) DeepCopyInto(out *TrustBundleFormats) {
+	*out = *in
+	if in.PEMKeys != nil {
+		in, out := &in.PEMKeys, &out.PEMKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrustBundleFormats.
+func (in *TrustBundleFormats) DeepCopy() *TrustBundleFormats {
+	if in == nil {
+		return nil
+	}
+	out := new(TrustBundleFormats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrustDomainMigrationConfig) DeepCopyInto(out *TrustDomainMigrationConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrustDomainMigrationConfig.
+func (in *TrustDomainMigrationConfig) DeepCopy() *TrustDomainMigrationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TrustDomainMigrationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadAPIHealthProbe) DeepCopyInto(out *WorkloadAPIHealthProbe) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadAPIHealthProbe.
+func (in *WorkloadAPIHealthProbe) DeepCopy() *WorkloadAPIHealthProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadAPIHealthProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadAttestors) DeepCopyInto(out *WorkloadAttestors) {
 	*out = *in
@@ -719,6 +2261,11 @@ func (in *WorkloadAttestors) DeepCopyInto(out *WorkloadAttestors) {
 		*out = new(WorkloadAttestorsVerification)
 		**out = **in
 	}
+	if in.AdditionalAttestors != nil {
+		in, out := &in.AdditionalAttestors, &out.AdditionalAttestors
+		*out = make([]AdditionalWorkloadAttestor, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadAttestors.
@@ -751,7 +2298,7 @@ func (in *ZeroTrustWorkloadIdentityManager) DeepCopyInto(out *ZeroTrustWorkloadI
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -808,6 +2355,41 @@ func (in *ZeroTrustWorkloadIdentityManagerList) DeepCopyObject() runtime.Object
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ZeroTrustWorkloadIdentityManagerSpec) DeepCopyInto(out *ZeroTrustWorkloadIdentityManagerSpec) {
 	*out = *in
+	if in.BundleFormats != nil {
+		in, out := &in.BundleFormats, &out.BundleFormats
+		*out = new(TrustBundleFormats)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HelmMigration != nil {
+		in, out := &in.HelmMigration, &out.HelmMigration
+		*out = new(HelmMigrationConfig)
+		**out = **in
+	}
+	if in.NamespaceGuardrails != nil {
+		in, out := &in.NamespaceGuardrails, &out.NamespaceGuardrails
+		*out = new(NamespaceGuardrailsConfig)
+		**out = **in
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.TrustDomainMigration != nil {
+		in, out := &in.TrustDomainMigration, &out.TrustDomainMigration
+		*out = new(TrustDomainMigrationConfig)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.StatusGracePeriod != nil {
+		in, out := &in.StatusGracePeriod, &out.StatusGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZeroTrustWorkloadIdentityManagerSpec.
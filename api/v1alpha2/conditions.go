@@ -0,0 +1,50 @@
+package v1alpha2
+
+const (
+	// Degraded is the condition type used to inform state of the operator when
+	// it has failed with irrecoverable error like permission issues. It is also
+	// published on the OLM OperatorCondition when any operand has failed.
+	// DebugEnabled has the following options:
+	//   Status:
+	//   - True
+	//   - False
+	//   Reason:
+	//   - Failed
+	//   - Ready: no operand has failed
+	//   - TransientError: the reconciliation failed and is retried with backoff
+	//   - TerminalError: the reconciliation failed and is not retried, e.g. on permission issues
+	//   - InvalidConfiguration: the configuration must be changed for the reconciliation to succeed
+	//   - MultipleInstances: more than one instance of a singleton resource exists
+	Degraded string = "Degraded"
+
+	// Ready is the condition type used to inform state of readiness of the
+	// operator to process spire enabling requests.
+	//   Status:
+	//   - True
+	//   - False
+	//   Reason:
+	//   - Progressing
+	//   - Failed
+	//   - Ready: operand successfully deployed and ready
+	Ready string = "Ready"
+
+	// Upgradeable indicates whether the operator and operands are in a state
+	// that allows for safe upgrades. It is True when all existing operand CRs
+	// are ready, and CreateOnlyMode is not enabled. CRs that don't exist yet are OK.
+	//   Status:
+	//   - True: Safe to upgrade (all existing CRs are ready, CRs that don't exist are OK, and no CreateOnlyMode)
+	//   - False: Not safe to upgrade (any existing CR is not ready, an operand is not yet at the
+	//     version expected by the operator, or CreateOnlyMode enabled)
+	//   Reason:
+	//   - Ready: All existing operands are ready or CRs don't exist yet
+	//   - OperandsNotReady: Some existing operands are not ready, or CreateOnlyMode is enabled
+	//   - OperandVersionSkew: Some operands are still being rolled to the operator's version
+	Upgradeable string = "Upgradeable"
+)
+
+const (
+	ReasonFailed           string = "Failed"
+	ReasonReady            string = "Ready"
+	ReasonInProgress       string = "Progressing"
+	ReasonOperandsNotReady string = "OperandsNotReady"
+)
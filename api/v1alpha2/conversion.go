@@ -0,0 +1,20 @@
+package v1alpha2
+
+// The kinds whose v1alpha1 schema differs from v1alpha2 are converted through the conversion
+// webhook, with v1alpha2 as the hub. The schema of the other kinds is the same in both
+// versions, their CRDs use the None conversion strategy.
+
+// Hub marks ZeroTrustWorkloadIdentityManager as a conversion hub
+func (*ZeroTrustWorkloadIdentityManager) Hub() {}
+
+// Hub marks SpireServer as a conversion hub
+func (*SpireServer) Hub() {}
+
+// Hub marks SpireAgent as a conversion hub
+func (*SpireAgent) Hub() {}
+
+// Hub marks SpireOIDCDiscoveryProvider as a conversion hub
+func (*SpireOIDCDiscoveryProvider) Hub() {}
+
+// Hub marks SpiffeCSIDriver as a conversion hub
+func (*SpiffeCSIDriver) Hub() {}
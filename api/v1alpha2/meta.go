@@ -0,0 +1,47 @@
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ConditionalStatus struct {
+	// conditions holds information about the current state of the SPIRE resources deployment.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// lastTraceID is the trace ID of the reconcile pass that last updated the status. The logs
+	// and Events of that pass carry the same trace ID.
+	// +optional
+	LastTraceID string `json:"lastTraceID,omitempty"`
+
+	// ready summarizes the status of the Ready condition, for display.
+	// +optional
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	Ready metav1.ConditionStatus `json:"ready,omitempty"`
+
+	// message summarizes the message of the Ready condition, for display.
+	// +optional
+	// +kubebuilder:validation:MaxLength=32768
+	Message string `json:"message,omitempty"`
+
+	// version is the version of the deployed operand, as labelled on its workload, or the
+	// version of the operator for the ZeroTrustWorkloadIdentityManager.
+	// +optional
+	// +kubebuilder:validation:MaxLength=64
+	Version string `json:"version,omitempty"`
+}
+
+// ObjectReference is a reference to an object with a given name, kind and group.
+type ObjectReference struct {
+	// Name of the resource being referred to.
+	Name string `json:"name"`
+	// Kind of the resource being referred to.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Group of the resource being referred to.
+	// +optional
+	Group string `json:"group,omitempty"`
+}
@@ -0,0 +1,126 @@
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SVIDType is the type of a minted SVID
+// +kubebuilder:validation:Enum=X509;JWT
+type SVIDType string
+
+const (
+	// SVIDTypeX509 mints an X509-SVID with its private key
+	SVIDTypeX509 SVIDType = "X509"
+	// SVIDTypeJWT mints a JWT-SVID
+	SVIDTypeJWT SVIDType = "JWT"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="SPIFFE ID",type=string,JSONPath=`.spec.spiffeID`
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+// +kubebuilder:printcolumn:name="Minted",type=string,JSONPath=`.status.conditions[?(@.type=="Minted")].status`
+// +kubebuilder:printcolumn:name="Expires",type=date,JSONPath=`.status.expiresAt`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +operator-sdk:csv:customresourcedefinitions:displayName="MintSVIDRequest"
+
+// MintSVIDRequest requests a one-off SVID from the SPIRE server, e.g. to bootstrap an external
+// service which does not run on Kubernetes into the trust domain. The request is consumed once:
+// the SVID is minted by the SPIRE server, written to the Secret of spec.secretRef and never
+// renewed. Deleting the request deletes the Secret.
+type MintSVIDRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              MintSVIDRequestSpec   `json:"spec,omitempty"`
+	Status            MintSVIDRequestStatus `json:"status,omitempty"`
+}
+
+// MintSVIDRequestSpec defines the SVID to mint and where to write it.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable, create a new MintSVIDRequest instead"
+// +kubebuilder:validation:XValidation:rule="self.type != 'JWT' || (has(self.audience) && size(self.audience) > 0)",message="audience is required for JWT SVIDs"
+// +kubebuilder:validation:XValidation:rule="self.type == 'JWT' || !has(self.audience)",message="audience is only supported for JWT SVIDs"
+type MintSVIDRequestSpec struct {
+	// spiffeID is the SPIFFE ID of the SVID. It must belong to the trust domain of the cluster
+	// and must not be one of the identities of SPIRE and of the operator: the IDs under /spire/
+	// and /ztwim/, the IDs of the workloads of the operator namespace and the admin IDs of the
+	// SpireServer are rejected.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^spiffe://[a-z0-9._-]+(/[A-Za-z0-9._-]+)+$`
+	SPIFFEID string `json:"spiffeID"`
+
+	// type is the type of the SVID: X509 mints an X509-SVID and its private key, JWT mints a JWT-SVID.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="X509"
+	Type SVIDType `json:"type,omitempty"`
+
+	// audience is the audience of a JWT-SVID.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:MaxLength=256
+	// +listType=set
+	Audience []string `json:"audience,omitempty"`
+
+	// ttl is the validity period of the SVID, 168h at most. When unset, the default X509 or JWT
+	// validity of the SpireServer applies.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="duration(self) > duration('0s')",message="ttl must be a positive duration"
+	// +kubebuilder:validation:XValidation:rule="duration(self) <= duration('168h')",message="ttl must be at most 168h"
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// secretRef is the Secret the SVID is written to. An X509-SVID is written to the tls.crt,
+	// tls.key and ca.crt keys, a JWT-SVID to the token key. The Secret must not exist. The
+	// operator is only granted access to its namespace until the request is served.
+	// +kubebuilder:validation:Required
+	SecretRef SecretReference `json:"secretRef"`
+}
+
+// SecretReference is a reference to a Secret in a given namespace.
+type SecretReference struct {
+	// name is the name of the Secret.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// namespace is the namespace of the Secret.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Namespace string `json:"namespace"`
+}
+
+// MintSVIDRequestStatus defines the observed state of the MintSVIDRequest
+type MintSVIDRequestStatus struct {
+	// conditions holds the state of the request. Minted is True once the SVID was written to
+	// the Secret, and False with the reason Failed when the request cannot be served.
+	ConditionalStatus `json:",inline,omitempty"`
+
+	// expiresAt is when the minted SVID expires.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+}
+
+// GetConditionalStatus returns the conditional status of the MintSVIDRequest
+func (m *MintSVIDRequest) GetConditionalStatus() ConditionalStatus {
+	return m.Status.ConditionalStatus
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MintSVIDRequestList contains a list of MintSVIDRequest
+type MintSVIDRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MintSVIDRequest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MintSVIDRequest{}, &MintSVIDRequestList{})
+}
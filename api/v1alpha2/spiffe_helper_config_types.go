@@ -0,0 +1,134 @@
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cert Dir",type=string,JSONPath=`.spec.certDir`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +operator-sdk:csv:customresourcedefinitions:displayName="SpiffeHelperConfig"
+
+// SpiffeHelperConfig configures the spiffe-helper sidecar injected into the pods of its namespace
+// labelled with ztwim.openshift.io/spiffe-helper=<name>. The sidecar fetches the SVIDs of the pod
+// from the Workload API and writes them to files of a volume shared with the application
+// containers, for applications which cannot use the Workload API. The configuration of the
+// helper is rendered to the spiffe-helper-<name> ConfigMap of the namespace.
+type SpiffeHelperConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              SpiffeHelperConfigSpec   `json:"spec,omitempty"`
+	Status            SpiffeHelperConfigStatus `json:"status,omitempty"`
+}
+
+// SpiffeHelperConfigSpec defines the files written by the spiffe-helper sidecar.
+type SpiffeHelperConfigSpec struct {
+	// certDir is the directory the SVIDs are written to, mounted read-only in the application
+	// containers.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="/run/spiffe/certs"
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^/[A-Za-z0-9._/-]*$`
+	CertDir string `json:"certDir,omitempty"`
+
+	// svidFileName is the file of the X509-SVID.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="svid.pem"
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9._-]+$`
+	SVIDFileName string `json:"svidFileName,omitempty"`
+
+	// svidKeyFileName is the file of the private key of the X509-SVID.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="svid_key.pem"
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9._-]+$`
+	SVIDKeyFileName string `json:"svidKeyFileName,omitempty"`
+
+	// svidBundleFileName is the file of the X.509 trust bundle.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="svid_bundle.pem"
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9._-]+$`
+	SVIDBundleFileName string `json:"svidBundleFileName,omitempty"`
+
+	// jwtSVIDs are the JWT-SVIDs to write, one file per audience.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=8
+	// +listType=map
+	// +listMapKey=fileName
+	JWTSVIDs []JWTSVIDFile `json:"jwtSVIDs,omitempty"`
+
+	// jwtBundleFileName is the file of the JWT trust bundle. It is not written when unset.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9._-]+$`
+	JWTBundleFileName string `json:"jwtBundleFileName,omitempty"`
+
+	// includeFederatedDomains adds the trust bundles of the federated trust domains to the
+	// bundle files.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=false
+	IncludeFederatedDomains bool `json:"includeFederatedDomains,omitempty"`
+
+	// containers are the application containers certDir is mounted in. It is mounted in all
+	// the containers of the pod when unset.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=set
+	Containers []string `json:"containers,omitempty"`
+
+	// resources are the compute resources of the sidecar.
+	// +kubebuilder:validation:Optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// JWTSVIDFile is a JWT-SVID written by the spiffe-helper sidecar.
+type JWTSVIDFile struct {
+	// audience is the audience of the JWT-SVID.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Audience string `json:"audience"`
+
+	// fileName is the file of the JWT-SVID, in certDir.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9._-]+$`
+	FileName string `json:"fileName"`
+}
+
+// SpiffeHelperConfigStatus defines the observed state of the SpiffeHelperConfig
+type SpiffeHelperConfigStatus struct {
+	// conditions holds the state of the helper configuration. Ready is True once the
+	// configuration was rendered to its ConfigMap.
+	ConditionalStatus `json:",inline,omitempty"`
+
+	// configMapName is the ConfigMap of the namespace the helper configuration is rendered to.
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+}
+
+// GetConditionalStatus returns the conditional status of the SpiffeHelperConfig
+func (s *SpiffeHelperConfig) GetConditionalStatus() ConditionalStatus {
+	return s.Status.ConditionalStatus
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SpiffeHelperConfigList contains a list of SpiffeHelperConfig
+type SpiffeHelperConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SpiffeHelperConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SpiffeHelperConfig{}, &SpiffeHelperConfigList{})
+}
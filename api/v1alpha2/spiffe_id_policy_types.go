@@ -0,0 +1,137 @@
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SPIFFEIDPolicyEnforcement is how the violations of a SPIFFEIDPolicy are handled on admission
+// +kubebuilder:validation:Enum=Deny;Warn
+type SPIFFEIDPolicyEnforcement string
+
+const (
+	// SPIFFEIDPolicyEnforcementDeny refuses the entries violating the policy
+	SPIFFEIDPolicyEnforcementDeny SPIFFEIDPolicyEnforcement = "Deny"
+	// SPIFFEIDPolicyEnforcementWarn admits the entries violating the policy with a warning
+	SPIFFEIDPolicyEnforcementWarn SPIFFEIDPolicyEnforcement = "Warn"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Enforcement",type=string,JSONPath=`.spec.enforcement`
+// +kubebuilder:printcolumn:name="Violations",type=integer,JSONPath=`.status.violationCount`
+// +kubebuilder:printcolumn:name="Compliant",type=string,JSONPath=`.status.conditions[?(@.type=="Compliant")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +operator-sdk:csv:customresourcedefinitions:displayName="SPIFFEIDPolicy"
+
+// SPIFFEIDPolicy restricts the SPIFFE ID paths the ClusterSPIFFEIDs and ClusterStaticEntries may
+// allocate, giving platform teams guardrails over the identity namespace of the trust domain. The
+// entries are refused on admission when they violate the policy, and the existing entries are
+// audited periodically, their violations reported in the status.
+type SPIFFEIDPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              SPIFFEIDPolicySpec   `json:"spec,omitempty"`
+	Status            SPIFFEIDPolicyStatus `json:"status,omitempty"`
+}
+
+// SPIFFEIDPolicySpec defines the SPIFFE ID paths allowed to the entries selected by each rule.
+type SPIFFEIDPolicySpec struct {
+	// rules allocate SPIFFE ID paths to the entries they select. An entry selected by rules must
+	// allocate a path under the prefixes of one of them; entries selected by no rule are not
+	// restricted by the policy. An entry selected by no rule can be restricted by a rule with an
+	// empty entrySelector, which selects every entry.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=map
+	// +listMapKey=name
+	Rules []SPIFFEIDPolicyRule `json:"rules"`
+
+	// enforcement is how the violations are handled on admission: Deny refuses the entries,
+	// Warn admits them with a warning. The violations are audited either way.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="Deny"
+	Enforcement SPIFFEIDPolicyEnforcement `json:"enforcement,omitempty"`
+}
+
+// SPIFFEIDPolicyRule allows SPIFFE ID path prefixes to the entries it selects, e.g. the entries
+// of a team.
+type SPIFFEIDPolicyRule struct {
+	// name identifies the rule in the violations.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// entrySelector selects the ClusterSPIFFEIDs and ClusterStaticEntries the rule applies to by
+	// their labels. The entries managed by the operator are never selected.
+	// +kubebuilder:validation:Required
+	EntrySelector metav1.LabelSelector `json:"entrySelector"`
+
+	// allowedPathPrefixes are the SPIFFE ID paths the selected entries may allocate, e.g.
+	// /ns/payments. A prefix matches whole path segments: /ns/payments allows /ns/payments/api
+	// but not /ns/payments-v2. The SPIFFE ID templates of ClusterSPIFFEIDs are matched as
+	// written, so a prefix holding a template expression only matches the same expression.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:MaxLength=2048
+	// +kubebuilder:validation:items:Pattern=`^/`
+	// +listType=set
+	AllowedPathPrefixes []string `json:"allowedPathPrefixes"`
+}
+
+// SPIFFEIDPolicyViolation is an existing entry violating the policy
+type SPIFFEIDPolicyViolation struct {
+	// kind is the kind of the entry, ClusterSPIFFEID or ClusterStaticEntry.
+	Kind string `json:"kind"`
+
+	// name is the name of the entry.
+	Name string `json:"name"`
+
+	// spiffeID is the SPIFFE ID, or SPIFFE ID template, of the entry.
+	SPIFFEID string `json:"spiffeID"`
+
+	// rules are the rules selecting the entry, none of which allows its path.
+	// +listType=atomic
+	Rules []string `json:"rules"`
+}
+
+// SPIFFEIDPolicyStatus defines the observed state of the SPIFFEIDPolicy
+type SPIFFEIDPolicyStatus struct {
+	// conditions holds the state of the policy. Compliant is True when no existing entry violates
+	// the policy and False when some do.
+	ConditionalStatus `json:",inline,omitempty"`
+
+	// violations lists the entries violating the policy, at most 50.
+	// +optional
+	// +kubebuilder:validation:MaxItems=50
+	// +listType=atomic
+	Violations []SPIFFEIDPolicyViolation `json:"violations,omitempty"`
+
+	// violationCount is the number of entries violating the policy.
+	// +optional
+	ViolationCount int32 `json:"violationCount,omitempty"`
+}
+
+// GetConditionalStatus returns the conditional status of the SPIFFEIDPolicy
+func (p *SPIFFEIDPolicy) GetConditionalStatus() ConditionalStatus {
+	return p.Status.ConditionalStatus
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SPIFFEIDPolicyList contains a list of SPIFFEIDPolicy
+type SPIFFEIDPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SPIFFEIDPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SPIFFEIDPolicy{}, &SPIFFEIDPolicyList{})
+}
@@ -0,0 +1,1110 @@
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.ready`
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.version`
+// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:validation:XValidation:rule="oldSelf == null || !has(oldSelf.spec.federation) || has(self.spec.federation)",message="Federation configuration cannot be removed once set."
+// +kubebuilder:validation:XValidation:rule="self.metadata.name == 'cluster'",message="SpireServer is a singleton, .metadata.name must be 'cluster'"
+// +kubebuilder:validation:XValidation:rule="oldSelf.spec.persistence.size == self.spec.persistence.size",message="spec.persistence.size is immutable"
+// +kubebuilder:validation:XValidation:rule="oldSelf.spec.persistence.accessMode == self.spec.persistence.accessMode",message="spec.persistence.accessMode is immutable"
+// +kubebuilder:validation:XValidation:rule="oldSelf.spec.persistence.storageClass == self.spec.persistence.storageClass",message="spec.persistence.storageClass is immutable"
+// +operator-sdk:csv:customresourcedefinitions:displayName="SpireServer"
+
+// SpireServer defines the configuration for the SPIRE Server managed by zero trust workload identity manager.
+// This includes details related to trust domain, data storage, plugins
+// and other configs required for workload authentication.
+type SpireServer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              SpireServerSpec   `json:"spec,omitempty"`
+	Status            SpireServerStatus `json:"status,omitempty"`
+}
+
+// SpireServerSpec defines the specifications for configuring the SPIRE server.
+// +kubebuilder:validation:XValidation:rule="duration(self.caValidity) > duration('0s')",message="caValidity must be a positive duration"
+// +kubebuilder:validation:XValidation:rule="duration(self.defaultX509Validity) > duration('0s') && duration(self.defaultX509Validity) < duration(self.caValidity)",message="defaultX509Validity must be a positive duration shorter than caValidity"
+// +kubebuilder:validation:XValidation:rule="duration(self.defaultJWTValidity) > duration('0s') && duration(self.defaultJWTValidity) < duration(self.caValidity)",message="defaultJWTValidity must be a positive duration shorter than caValidity"
+// +kubebuilder:validation:XValidation:rule="!has(self.agentTTL) || (duration(self.agentTTL) > duration('0s') && duration(self.agentTTL) <= duration(self.caValidity))",message="agentTTL must be a positive duration not exceeding caValidity"
+type SpireServerSpec struct {
+	// logLevel sets the logging level for the operand.
+	// Valid values are: debug, info, warn, error.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=debug;info;warn;error
+	// +kubebuilder:default:="info"
+	LogLevel string `json:"logLevel,omitempty"`
+
+	// logFormat sets the logging format for the operand.
+	// Valid values are: text, json.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=text;json
+	// +kubebuilder:default:="text"
+	LogFormat string `json:"logFormat,omitempty"`
+
+	// logEvents re-emits the significant entries of the server logs as Events on the
+	// SpireServer, for visibility in standard tooling: the activation of X509 CAs and JWT keys
+	// (Normal), and the errors of the CA manager, the datastore and the plugins (Warning). The
+	// logs are read by the operator through the pods/log API at each reconciliation, at least
+	// every minute. CA activations are logged at the info level, so they are missed with
+	// logLevel warn or error.
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	LogEvents *bool `json:"logEvents,omitempty"`
+
+	// jwtIssuer is the JWT issuer url.
+	// Must be a valid HTTPS or HTTP URL.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=512
+	// +kubebuilder:validation:Pattern=`^(?i)https?://[^\s?#]+$`
+	JwtIssuer string `json:"jwtIssuer"`
+
+	// jwtIssuerAliases are previous JWT issuer urls. The OIDC discovery provider keeps serving
+	// them, with a managed Route per alias, so JWT-SVIDs issued before a jwtIssuer change keep
+	// validating until they expire. Remove an alias once its tokens have expired.
+	// Maximum 4 aliases allowed.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=4
+	// +kubebuilder:validation:items:MaxLength=512
+	// +kubebuilder:validation:items:Pattern=`^(?i)https?://[^\s?#]+$`
+	// +listType=set
+	JwtIssuerAliases []string `json:"jwtIssuerAliases,omitempty"`
+
+	// caValidity is the validity period (TTL) for the SPIRE Server's own CA certificate.
+	// This determines how long the server's root or intermediate certificate is valid.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="24h"
+	CAValidity metav1.Duration `json:"caValidity"`
+
+	// defaultX509Validity is the default validity period (TTL) for X.509 SVIDs issued to workloads.
+	// This value is used if a specific TTL is not configured for a registration entry.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="1h"
+	DefaultX509Validity metav1.Duration `json:"defaultX509Validity"`
+
+	// defaultJWTValidity is the default validity period (TTL) for JWT SVIDs issued to workloads.
+	// This value is used if a specific TTL is not configured for a registration entry.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="5m"
+	DefaultJWTValidity metav1.Duration `json:"defaultJWTValidity"`
+
+	// agentTTL is the validity period (TTL) of the SVIDs issued to the SPIRE agents. Agents of
+	// nodes which may stay disconnected for long, e.g. edge nodes, need a TTL longer than the
+	// disconnection, and longer than the availability target of the SpireAgent. It may not
+	// exceed caValidity. When unset, the agent SVIDs are valid for defaultX509Validity.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:validation:Optional
+	AgentTTL *metav1.Duration `json:"agentTTL,omitempty"`
+
+	// caKeyType specifies the key type used for the server CA (both X509 and JWT).
+	// Valid values are: rsa-2048, rsa-4096, ec-p256, ec-p384.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=rsa-2048;rsa-4096;ec-p256;ec-p384
+	// +kubebuilder:default="rsa-2048"
+	CAKeyType string `json:"caKeyType,omitempty"`
+
+	// jwtKeyType specifies the key type used for JWT signing.
+	// Valid values are: rsa-2048, rsa-4096, ec-p256, ec-p384.
+	// This field is optional and will only be set in the SPIRE server configuration if explicitly provided.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=rsa-2048;rsa-4096;ec-p256;ec-p384
+	JWTKeyType string `json:"jwtKeyType,omitempty"`
+
+	// keyManager configures the SPIRE server key manager.
+	// +kubebuilder:validation:Optional
+	KeyManager *KeyManager `json:"keyManager,omitempty"`
+
+	// caSubject contains subject information for the SPIRE CA.
+	// +kubebuilder:validation:Required
+	CASubject CASubject `json:"caSubject,omitempty"`
+
+	// persistence configures storage for the SPIRE server.
+	// This field is required and immutable once set.
+	// +kubebuilder:validation:Required
+	Persistence Persistence `json:"persistence"`
+
+	// datastore configures the SPIRE server SQL datastore backend.
+	// +kubebuilder:validation:Required
+	Datastore DataStore `json:"datastore,omitempty"`
+
+	// federation configures SPIRE federation endpoints and relationships
+	// +kubebuilder:validation:Optional
+	Federation *FederationConfig `json:"federation,omitempty"`
+
+	// hostAliases are added to the /etc/hosts file of the SPIRE server pod, so the bundle
+	// endpoints of federated trust domains can be reached by IP where they are not resolvable,
+	// e.g. in air-gapped or lab environments. Changing them rolls the server.
+	// ref: https://kubernetes.io/docs/tasks/network/customize-hosts-file-for-pods/
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=32
+	// +listType=atomic
+	HostAliases []corev1.HostAlias `json:"hostAliases,omitempty"`
+
+	// joinTokenAttestationEnabled enables the join_token node attestor, so agents configured
+	// with a Secret-sourced bootstrap token can attest in addition to k8s_psat agents.
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	JoinTokenAttestationEnabled *bool `json:"joinTokenAttestationEnabled,omitempty"`
+
+	// adminIDs are the SPIFFE IDs granted access to the admin APIs of the server, e.g. to manage
+	// registration entries or agents from outside the server pod. IDs of another trust domain
+	// must be of a domain listed in federation.federatesWith. Maximum 16 IDs allowed.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:MaxLength=2048
+	// +kubebuilder:validation:items:Pattern=`^spiffe://`
+	// +listType=set
+	AdminIDs []string `json:"adminIDs,omitempty"`
+
+	// psat configures the k8s_psat node attestor: the audiences accepted for the projected
+	// service account tokens of the agents and the service accounts allowed to attest. They must
+	// match the SpireAgent, which is reported through the PSATAttestationConsistent condition.
+	// +kubebuilder:validation:Optional
+	PSAT *PSATAttestationConfig `json:"psat,omitempty"`
+
+	// service customizes the spire-server Service.
+	// In-cluster agents connect to port 443 of the Service, so the grpc port should only be
+	// overridden when all agents are external.
+	// +kubebuilder:validation:Optional
+	Service *ServiceConfig `json:"service,omitempty"`
+
+	// externalAgents exposes the SPIRE server API outside the cluster for agents running on
+	// external machines, e.g. VMs attesting with join tokens, and publishes the material
+	// they need to bootstrap.
+	// +kubebuilder:validation:Optional
+	ExternalAgents *ExternalAgentsConfig `json:"externalAgents,omitempty"`
+
+	// limits sets thresholds on the number of registration entries, protecting the datastore
+	// from runaway ClusterSPIFFEID selectors.
+	// +kubebuilder:validation:Optional
+	Limits *RegistrationLimits `json:"limits,omitempty"`
+
+	// bundleNotifier configures the k8sbundle notifier beyond the trust bundle ConfigMap of the
+	// operator namespace: it can publish the trust bundle to ConfigMaps in other namespaces, and
+	// inject it as the CA bundle of labelled admission webhooks.
+	// +kubebuilder:validation:Optional
+	BundleNotifier *BundleNotifierConfig `json:"bundleNotifier,omitempty"`
+
+	// controllerManagerWebhook configures the ValidatingWebhookConfiguration of the
+	// spire-controller-manager, which validates ClusterSPIFFEIDs and ClusterFederatedTrustDomains.
+	// +kubebuilder:validation:Optional
+	ControllerManagerWebhook *ControllerManagerWebhookConfig `json:"controllerManagerWebhook,omitempty"`
+
+	// controllerManagerNamespaces restricts the namespaces whose pods the spire-controller-manager
+	// evaluates the ClusterSPIFFEIDs against, for very large clusters where evaluating all the
+	// namespaces is expensive.
+	// +kubebuilder:validation:Optional
+	ControllerManagerNamespaces *ControllerManagerNamespaces `json:"controllerManagerNamespaces,omitempty"`
+
+	// externalPlugins adds external SPIRE server plugins, e.g. custom NodeAttestors or
+	// UpstreamAuthorities, without forking the operator. The plugin binary is copied from its
+	// image by an init container, and verified against its checksum by the server.
+	// Maximum 8 plugins allowed.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=8
+	// +listType=map
+	// +listMapKey=type
+	// +listMapKey=name
+	ExternalPlugins []ExternalPlugin `json:"externalPlugins,omitempty"`
+
+	// experimentalFlags sets experimental SPIRE server options, rendered into the "experimental"
+	// section of the server configuration. They are only applied when the ExperimentalFlags feature
+	// is listed in the UNSUPPORTED_ADDON_FEATURES environment variable of the operator, and the
+	// resource is then reported with the UnsupportedConfiguration condition.
+	// Supported options: cache_reload_interval, events_based_cache, prune_events_older_than, sql_transaction_timeout and require_pq_kem.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxProperties=8
+	ExperimentalFlags map[string]string `json:"experimentalFlags,omitempty"`
+
+	// telemetry configures the telemetry of the SPIRE server in addition to the Prometheus endpoint it serves.
+	// +kubebuilder:validation:Optional
+	Telemetry *TelemetryConfig `json:"telemetry,omitempty"`
+
+	// rolloutVerification runs a verification Job once each rollout of the SPIRE server
+	// StatefulSet completes, fetching an X.509-SVID through the Workload API and checking its
+	// chain and the freshness of the trust bundle. The result is reported through the
+	// RolloutVerified condition, and the SpireServer is not Ready until it passes.
+	// +kubebuilder:validation:Optional
+	RolloutVerification *RolloutVerificationConfig `json:"rolloutVerification,omitempty"`
+
+	// rateLimit configures the rate limits the SPIRE server applies per caller IP address to
+	// node attestation and to the signing of SVIDs. The limits, and their bursts, are fixed by
+	// SPIRE and can only be turned off, e.g. for nodes running bursty batch jobs which spawn
+	// thousands of pods at once: their agent signs the SVIDs of all the pods of the node and is
+	// throttled like a single caller. SPIRE has no setting for the burst of the signing limit or
+	// for the number of SVIDs signed concurrently, so neither is configurable.
+	// +kubebuilder:validation:Optional
+	RateLimit *RateLimitConfig `json:"rateLimit,omitempty"`
+
+	// sizingRecommendations samples the SVID issuance rates and the latency of the agent syncs
+	// from the metrics of the SPIRE server, and recommends a sizing profile, with the resources
+	// of the server and the datastore pool settings it implies, in status.sizingRecommendation.
+	// Nothing is changed on the operands: the recommendation helps right-sizing before the load
+	// causes an outage.
+	// +kubebuilder:validation:Optional
+	SizingRecommendations *SizingRecommendationsConfig `json:"sizingRecommendations,omitempty"`
+
+	CommonConfig `json:",inline"`
+}
+
+// SizingRecommendationsConfig configures the sizing recommendations of the SPIRE server
+type SizingRecommendationsConfig struct {
+	// enabled specifies whether the load of the server is sampled and sizing recommended.
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// window is how long the samples are kept: the recommendation follows the peak load
+	// observed over the window. The samples are kept by the operator in memory, so the window
+	// starts over when the operator restarts.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1h') && duration(self) <= duration('720h')",message="window must be between 1h and 720h"
+	// +kubebuilder:default="24h"
+	Window metav1.Duration `json:"window,omitempty"`
+}
+
+// RateLimitConfig turns the rate limits of the SPIRE server on or off
+type RateLimitConfig struct {
+	// attestation limits the node attestations of each caller. It cannot be disabled with the
+	// sqlite3 datastore: attestations write to the datastore, and a wave of them, e.g. when a
+	// machine pool is replaced, would hold the single writer lock of sqlite3 and stall the
+	// signing of SVIDs.
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Optional
+	Attestation *bool `json:"attestation,omitempty"`
+
+	// signing limits the signing of X509-SVIDs and JWT-SVIDs requested by each caller, agents
+	// included. Signing does not write to the datastore.
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Optional
+	Signing *bool `json:"signing,omitempty"`
+}
+
+// RolloutVerificationConfig configures the verification Job run after each rollout of the
+// SPIRE server. The Job fetches its SVID like any workload, so it requires the SPIRE agents and
+// the SPIFFE CSI driver to be deployed.
+type RolloutVerificationConfig struct {
+	// enabled specifies whether each rollout of the SPIRE server is verified.
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// timeout is how long the verification Job may run, retries included, before the rollout
+	// is reported as failing verification.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="duration(self) > duration('0s')",message="timeout must be a positive duration"
+	// +kubebuilder:default="5m"
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+
+	// minBundleValidity is how long at least one CA of the trust bundle must remain valid for
+	// the bundle to be considered fresh.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="duration(self) > duration('0s')",message="minBundleValidity must be a positive duration"
+	// +kubebuilder:default="1h"
+	MinBundleValidity metav1.Duration `json:"minBundleValidity,omitempty"`
+}
+
+// BundleNotifierConfig configures the targets of the k8sbundle notifier
+type BundleNotifierConfig struct {
+	// webhookLabel is the label key marking the ValidatingWebhookConfigurations and
+	// MutatingWebhookConfigurations whose CA bundle is kept in sync with the trust bundle. A
+	// webhook configuration is selected when the label is set to "true".
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=317
+	// +kubebuilder:validation:Pattern=`^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`
+	WebhookLabel string `json:"webhookLabel,omitempty"`
+
+	// configMaps are additional ConfigMaps the trust bundle is published to, e.g. in the
+	// namespaces of the webhooks secured with SPIRE-issued certificates. The ConfigMaps must
+	// exist: the notifier updates them but does not create them.
+	// Maximum 32 ConfigMaps allowed.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=32
+	// +listType=map
+	// +listMapKey=namespace
+	// +listMapKey=name
+	ConfigMaps []BundleConfigMapTarget `json:"configMaps,omitempty"`
+}
+
+// ControllerManagerWebhookConfig configures the spire-controller-manager webhook. Its serving
+// certificate is an X509-SVID minted from the SPIRE server and rotated by the
+// spire-controller-manager, which keeps the caBundle of the webhook in sync with the trust bundle.
+type ControllerManagerWebhookConfig struct {
+	// failurePolicy defines how errors calling the webhook are handled.
+	// "Ignore": the resources are admitted unvalidated while the webhook is unavailable.
+	// "Fail": the resources are rejected while the webhook is unavailable.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum:="Ignore";"Fail"
+	// +kubebuilder:default:="Ignore"
+	FailurePolicy string `json:"failurePolicy,omitempty"`
+
+	// certificateExpiryWarning is how long before its expiry the serving certificate is reported
+	// as expiring in the WebhookCertValid condition, as it should have been rotated by then.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="duration(self) > duration('0s')",message="certificateExpiryWarning must be a positive duration"
+	// +kubebuilder:default="1h"
+	CertificateExpiryWarning metav1.Duration `json:"certificateExpiryWarning,omitempty"`
+}
+
+// ControllerManagerNamespaces selects the namespaces watched by the spire-controller-manager.
+// Pods of the other namespaces get no registration entry from the ClusterSPIFFEIDs.
+type ControllerManagerNamespaces struct {
+	// watch lists the only namespaces watched, all the namespaces when empty. The operator
+	// namespace is always watched, as the operands get their identities from ClusterSPIFFEIDs.
+	// Maximum 64 namespaces allowed.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=64
+	// +kubebuilder:validation:items:MaxLength=63
+	// +kubebuilder:validation:items:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +listType=set
+	Watch []string `json:"watch,omitempty"`
+
+	// ignore lists regular expressions of the namespaces not watched, in addition to
+	// kube-system, kube-public, local-path-storage and openshift-*. They must not match the
+	// operator namespace.
+	// Maximum 64 expressions allowed.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=64
+	// +kubebuilder:validation:items:MaxLength=256
+	// +listType=set
+	Ignore []string `json:"ignore,omitempty"`
+}
+
+// BundleConfigMapTarget is a ConfigMap the trust bundle is published to
+type BundleConfigMapTarget struct {
+	// namespace is the namespace of the ConfigMap.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Namespace string `json:"namespace"`
+
+	// name is the name of the ConfigMap.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	Name string `json:"name"`
+
+	// key is the ConfigMap key holding the PEM encoded trust bundle.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="bundle.crt"
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	Key string `json:"key,omitempty"`
+}
+
+// ExternalPlugin defines an external SPIRE server plugin delivered as an OCI image
+type ExternalPlugin struct {
+	// type is the SPIRE plugin type.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=NodeAttestor;UpstreamAuthority;Notifier;CredentialComposer;BundlePublisher
+	Type string `json:"type"`
+
+	// name is the name of the plugin in the server configuration.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=40
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([a-z0-9_]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// image is the OCI image holding the plugin binary. It must provide cp, which copies the
+	// binary into the spire-server pod.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=512
+	Image string `json:"image"`
+
+	// path is the absolute path of the plugin binary in the image.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^/[^\s]*$`
+	Path string `json:"path"`
+
+	// checksum is the hex encoded SHA256 checksum of the plugin binary, verified by the server
+	// before loading the plugin.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-f0-9]{64}$`
+	Checksum string `json:"checksum"`
+
+	// pluginData is the configuration of the plugin, in HCL.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=16384
+	PluginData string `json:"pluginData,omitempty"`
+}
+
+// PSATAttestationConfig defines the k8s_psat node attestor settings of the SPIRE server
+type PSATAttestationConfig struct {
+	// audiences are the audiences accepted for the agent tokens. Defaults to spire-server.
+	// Maximum 8 audiences allowed.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=253
+	// +listType=set
+	Audiences []string `json:"audiences,omitempty"`
+
+	// serviceAccountAllowList are the service accounts allowed to attest, as namespace:name.
+	// Defaults to the spire-agent service account of the operator namespace.
+	// Maximum 16 service accounts allowed.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?:[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +listType=set
+	ServiceAccountAllowList []string `json:"serviceAccountAllowList,omitempty"`
+
+	// nodeLabelSelectors expose node labels, e.g. the zone, region or instance type, as selectors
+	// of the attested agents and as agent aliases, so registration entries can be scoped by
+	// topology. Maximum 8 labels allowed.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:XValidation:rule="self.all(x, self.exists_one(y, y.aliasName == x.aliasName))",message="aliasName must be unique"
+	// +listType=map
+	// +listMapKey=labelKey
+	NodeLabelSelectors []NodeLabelSelector `json:"nodeLabelSelectors,omitempty"`
+}
+
+// NodeLabelSelector exposes a node label as a selector of the SPIRE agents running on the nodes
+type NodeLabelSelector struct {
+	// labelKey is the node label, e.g. topology.kubernetes.io/zone. The agents are attested with
+	// the k8s_psat:agent_node_label:<labelKey>:<value> selector.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=317
+	// +kubebuilder:validation:Pattern=`^([a-z0-9]([-a-z0-9.]*[a-z0-9])?/)?[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`
+	LabelKey string `json:"labelKey"`
+
+	// aliasName names the agent aliases of the label. The agents of the nodes labelled with a
+	// value are aliased as spiffe://<trustDomain>/spire/agent/k8s_psat/<clusterName>/<aliasName>/<value>,
+	// which registration entries can use as their parent ID.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	AliasName string `json:"aliasName"`
+}
+
+// RegistrationLimits defines the thresholds on the registration entries of the SPIRE server.
+// Entries are counted from the ClusterSPIFFEID and ClusterStaticEntry resources reconciled by
+// spire-controller-manager.
+type RegistrationLimits struct {
+	// maxRegistrationEntries is the number of registration entries above which the SpireServer
+	// is reported as not ready.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	MaxRegistrationEntries int32 `json:"maxRegistrationEntries"`
+
+	// warningThresholdPercent is the percentage of maxRegistrationEntries from which a warning
+	// Event is emitted.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default:=80
+	WarningThresholdPercent int32 `json:"warningThresholdPercent,omitempty"`
+}
+
+// ExternalAgentsConfig defines how the SPIRE server API is exposed to agents outside the cluster
+type ExternalAgentsConfig struct {
+	// exposure determines how the server API is exposed.
+	// "Route": a passthrough OpenShift Route to the spire-server Service.
+	// "LoadBalancer": a dedicated LoadBalancer Service.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Route;LoadBalancer
+	// +kubebuilder:default:="Route"
+	Exposure string `json:"exposure,omitempty"`
+
+	// host is the hostname of the Route. Assigned by the router when not set.
+	// Only used when exposure is Route.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	Host string `json:"host,omitempty"`
+
+	// annotations to add to the Route or LoadBalancer Service, e.g. for DNS publishing
+	// or to request an internal load balancer.
+	// Maximum 64 annotations allowed.
+	// +mapType=granular
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxProperties=64
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// FederationConfig defines federation bundle endpoint and federated trust domains
+type FederationConfig struct {
+	// bundleEndpoint configures this cluster's federation bundle endpoint
+	// +kubebuilder:validation:Required
+	BundleEndpoint BundleEndpointConfig `json:"bundleEndpoint"`
+
+	// federatesWith lists trust domains this cluster federates with
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=50
+	FederatesWith []FederatesWithConfig `json:"federatesWith,omitempty"`
+
+	// managedRoute enables or disables automatic Route creation for the federation endpoint
+	// true: Allows automatic exposure of federation endpoint through a managed OpenShift Route.
+	// false: Allows administrators to manually configure exposure using custom OpenShift Routes or ingress, offering more control over routing behavior.
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Optional
+	ManagedRoute *bool `json:"managedRoute,omitempty"`
+
+	// egressPolicy generates the egress allowances letting the SPIRE server reach the bundle
+	// endpoints of the federatesWith trust domains in clusters restricting egress traffic.
+	// "None": No egress allowance is generated.
+	// "NetworkPolicy": A NetworkPolicy allows the SPIRE server pods to reach the addresses the
+	// hosts of the bundle endpoints resolve to, once another NetworkPolicy restricts their egress.
+	// "EgressFirewall": Rules allowing the hosts of the bundle endpoints are added ahead of the
+	// rules of the default OVN-Kubernetes EgressFirewall of the operator namespace, once it exists.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=None;NetworkPolicy;EgressFirewall
+	// +kubebuilder:default:="None"
+	EgressPolicy FederationEgressPolicy `json:"egressPolicy,omitempty"`
+}
+
+// FederationEgressPolicy selects how the egress to the bundle endpoints of the federation
+// partners is allowed
+type FederationEgressPolicy string
+
+const (
+	// FederationEgressPolicyNone generates no egress allowance
+	FederationEgressPolicyNone FederationEgressPolicy = "None"
+	// FederationEgressPolicyNetworkPolicy allows the egress through a NetworkPolicy
+	FederationEgressPolicyNetworkPolicy FederationEgressPolicy = "NetworkPolicy"
+	// FederationEgressPolicyEgressFirewall allows the egress through the EgressFirewall rules
+	FederationEgressPolicyEgressFirewall FederationEgressPolicy = "EgressFirewall"
+)
+
+// BundleEndpointConfig configures how this cluster exposes its federation bundle
+// The federation endpoint is exposed on 0.0.0.0:8443
+// +kubebuilder:validation:XValidation:rule="self.profile == 'https_web' ? has(self.httpsWeb) : true",message="httpsWeb is required when profile is https_web"
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.profile) || oldSelf.profile == self.profile",message="profile is immutable and cannot be changed once set"
+type BundleEndpointConfig struct {
+	// profile is the bundle endpoint authentication profile
+	// +kubebuilder:validation:Enum=https_spiffe;https_web
+	// +kubebuilder:default=https_spiffe
+	Profile BundleEndpointProfile `json:"profile"`
+
+	// refreshHint is the hint for bundle refresh interval in seconds
+	// +kubebuilder:validation:Minimum=60
+	// +kubebuilder:validation:Maximum=3600
+	// +kubebuilder:default=300
+	RefreshHint int32 `json:"refreshHint,omitempty"`
+
+	// httpsWeb configures the https_web profile (required if profile is https_web)
+	// +kubebuilder:validation:Optional
+	HttpsWeb *HttpsWebConfig `json:"httpsWeb,omitempty"`
+}
+
+// BundleEndpointProfile represents the authentication profile for bundle endpoint
+// +kubebuilder:validation:Enum=https_spiffe;https_web
+type BundleEndpointProfile string
+
+const (
+	// HttpsSpiffeProfile uses SPIFFE authentication (default)
+	HttpsSpiffeProfile BundleEndpointProfile = "https_spiffe"
+
+	// HttpsWebProfile uses Web PKI (X.509 certificates from public CA)
+	HttpsWebProfile BundleEndpointProfile = "https_web"
+)
+
+// HttpsWebConfig configures https_web profile authentication
+// +kubebuilder:validation:XValidation:rule="(has(self.acme) && !has(self.servingCert)) || (!has(self.acme) && has(self.servingCert))",message="exactly one of acme or servingCert must be set"
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.acme) || has(self.acme)",message="cannot switch from acme to servingCert configuration"
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.servingCert) || has(self.servingCert)",message="cannot switch from servingCert to acme configuration"
+type HttpsWebConfig struct {
+	// acme configures automatic certificate management using ACME protocol
+	// Mutually exclusive with servingCert
+	// +kubebuilder:validation:Optional
+	Acme *AcmeConfig `json:"acme,omitempty"`
+
+	// servingCert configures certificate from a Kubernetes Secret
+	// Mutually exclusive with acme
+	// +kubebuilder:validation:Optional
+	ServingCert *ServingCertConfig `json:"servingCert,omitempty"`
+}
+
+// AcmeConfig configures ACME certificate provisioning
+type AcmeConfig struct {
+	// directoryUrl is the ACME directory URL (e.g., Let's Encrypt)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https://.*`
+	DirectoryUrl string `json:"directoryUrl"`
+
+	// domainName is the domain name for the certificate
+	// +kubebuilder:validation:Required
+	DomainName string `json:"domainName"`
+
+	// email for ACME account registration
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9][a-zA-Z0-9._%+-]*[a-zA-Z0-9]@[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?)*\.[a-zA-Z]{2,}$`
+	Email string `json:"email"`
+
+	// tosAccepted indicates acceptance of Terms of Service
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	TosAccepted *bool `json:"tosAccepted,omitempty"`
+}
+
+// ServingCertConfig configures TLS certificates for the federation endpoint.
+// The service CA certificate is always used for internal communication from the Route to the
+// SPIRE server pod. For external communication from clients to the Route, the certificate is
+// controlled by ExternalSecretRef.
+type ServingCertConfig struct {
+	// fileSyncInterval is how often to check for certificate updates (seconds)
+	// +kubebuilder:validation:Minimum=3600
+	// +kubebuilder:validation:Maximum=7776000
+	// +kubebuilder:default=86400
+	FileSyncInterval int32 `json:"fileSyncInterval,omitempty"`
+
+	// externalSecretRef is a reference to an externally managed secret that contains
+	// the TLS certificate for the SPIRE server federation Route host. The secret must
+	// be in the same namespace where the operator and operands are deployed and must
+	// contain tls.crt and tls.key fields. The OpenShift Ingress Operator will read
+	// this secret to configure the route's TLS certificate.
+	// +kubebuilder:validation:Optional
+	ExternalSecretRef string `json:"externalSecretRef,omitempty"`
+}
+
+// FederatesWithConfig represents a remote trust domain to federate with
+// +kubebuilder:validation:XValidation:rule="self.bundleEndpointProfile == 'https_spiffe' ? has(self.endpointSpiffeId) && self.endpointSpiffeId != '' : true",message="endpointSpiffeId is required when bundleEndpointProfile is https_spiffe"
+type FederatesWithConfig struct {
+	// trustDomain is the federated trust domain name
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-z0-9._-]{1,255}$`
+	TrustDomain string `json:"trustDomain"`
+
+	// bundleEndpointUrl is the URL of the remote federation endpoint
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https://.*`
+	BundleEndpointUrl string `json:"bundleEndpointUrl"`
+
+	// bundleEndpointProfile is the authentication profile of the remote endpoint
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=https_spiffe;https_web
+	BundleEndpointProfile BundleEndpointProfile `json:"bundleEndpointProfile"`
+
+	// endpointSpiffeId is required for https_spiffe profile
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^spiffe://.*`
+	EndpointSpiffeId string `json:"endpointSpiffeId,omitempty"`
+}
+
+// Persistence defines volume-related settings.
+type Persistence struct {
+	// size of the persistent volume (e.g., 1Gi).
+	// +kubebuilder:validation:Pattern=^[1-9][0-9]*Gi$
+	// +kubebuilder:default:="1Gi"
+	Size string `json:"size"`
+
+	// accessMode for the volume.
+	// +kubebuilder:validation:Enum=ReadWriteOnce;ReadWriteOncePod;ReadWriteMany
+	// +kubebuilder:default:=ReadWriteOnce
+	AccessMode string `json:"accessMode"`
+
+	// storageClass to be used for the PVC.
+	// +kubebuilder:validation:optional
+	// +kubebuilder:default:=""
+	StorageClass string `json:"storageClass,omitempty"`
+}
+
+// DataStore configures the Spire SQL datastore backend.
+type DataStore struct {
+	// databaseType specifies type of database to use.
+	// +kubebuilder:validation:Enum=sql;sqlite3;postgres;mysql;aws_postgresql;aws_mysql
+	// +kubebuilder:default:=sqlite3
+	DatabaseType string `json:"databaseType"`
+
+	// connectionString contains connection credentials required for the SPIRE server datastore.
+	// Must not be empty and should contain valid connection parameters for the specified database type.
+	// For PostgreSQL with SSL, include sslmode and certificate paths in the connection string.
+	// Example: "dbname=spire user=spire host=postgres.example.com sslmode=verify-full sslrootcert=/run/spire/db/certs/ca.crt"
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:default:=/run/spire/data/datastore.sqlite3
+	ConnectionString string `json:"connectionString"`
+
+	// tlsSecretName specifies the name of a Kubernetes Secret containing TLS certificates for database connections.
+	// The Secret will be mounted at /run/spire/db/certs in the SPIRE server container.
+	// The Secret should contain keys like 'ca.crt', 'tls.crt', 'tls.key' for the respective certificates.
+	// For PostgreSQL, reference these certificates in the connectionString, e.g.:
+	// "sslmode=verify-full sslrootcert=/run/spire/db/certs/ca.crt sslcert=/run/spire/db/certs/tls.crt sslkey=/run/spire/db/certs/tls.key"
+	// +kubebuilder:validation:Optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
+	// DB pool config
+	// maxOpenConns specifies the maximum number of open database connections.
+	// Must be between 1 and 10000.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10000
+	// +kubebuilder:default:=100
+	// +kubebuilder:validation:Optional
+	MaxOpenConns int `json:"maxOpenConns"`
+
+	// maxIdleConns specifies the maximum number of idle database connections.
+	// Must be between 0 and 10000.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10000
+	// +kubebuilder:default:=2
+	// +kubebuilder:validation:Optional
+	MaxIdleConns int `json:"maxIdleConns"`
+
+	// connMaxLifetime specifies the maximum lifetime of a database connection in seconds.
+	// A value of 0 means connections are not closed due to age.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	ConnMaxLifetime int `json:"connMaxLifetime"`
+
+	// disableMigration specifies the migration state
+	// If true, disables DB auto-migration.
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	DisableMigration *bool `json:"disableMigration,omitempty"`
+
+	// upgradeBackupCheck configures the datastore backup verification performed
+	// before a SPIRE server version upgrade is rolled out.
+	// +kubebuilder:validation:Optional
+	UpgradeBackupCheck *DatastoreBackupCheck `json:"upgradeBackupCheck,omitempty"`
+
+	// diskUsage configures the periodic checks of the disk usage of the sqlite3 datastore volume,
+	// and its optional compaction. It only applies to the sqlite3 database type.
+	// +kubebuilder:validation:Optional
+	DiskUsage *DatastoreDiskUsage `json:"diskUsage,omitempty"`
+}
+
+// DatastoreBackupCheck configures verification of a recent datastore backup before
+// the SPIRE server StatefulSet is updated to a new SPIRE version.
+type DatastoreBackupCheck struct {
+	// enabled holds back SPIRE server version upgrades until a recent datastore backup
+	// is recorded on the SpireServer resource through the
+	// ztwim.openshift.io/last-datastore-backup annotation, as an RFC 3339 timestamp.
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// maxBackupAge is the maximum age of the recorded backup, as a duration (e.g. 24h).
+	// +kubebuilder:default:="24h"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(ns|us|ms|s|m|h))+$`
+	// +kubebuilder:validation:Optional
+	MaxBackupAge string `json:"maxBackupAge,omitempty"`
+}
+
+// DatastoreDiskUsage defines the disk usage checks of the sqlite3 datastore volume. The usage
+// is read from the volume statistics of the kubelet running the SPIRE server.
+type DatastoreDiskUsage struct {
+	// pressureThresholdPercent is the percentage of the volume capacity from which the
+	// DatastorePressure condition is set and a warning Event is emitted.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default:=80
+	PressureThresholdPercent int32 `json:"pressureThresholdPercent,omitempty"`
+
+	// compaction schedules a VACUUM of the sqlite3 datastore, reclaiming the space left by
+	// deleted entries. Compaction locks the datastore while it runs and needs free space for a
+	// copy of the database, so it should run in a maintenance window, before the volume is full.
+	// +kubebuilder:validation:Optional
+	Compaction *DatastoreCompaction `json:"compaction,omitempty"`
+}
+
+// DatastoreCompaction defines the window in which the sqlite3 datastore is compacted
+type DatastoreCompaction struct {
+	// schedule is the start of the compaction window, in cron format, e.g. "0 3 * * 0".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=9
+	// +kubebuilder:validation:MaxLength=128
+	Schedule string `json:"schedule"`
+
+	// window is how long after the scheduled time the compaction may start and run, as a
+	// duration (e.g. 1h). A compaction still running at the end of the window is stopped.
+	// +kubebuilder:default:="1h"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(s|m|h))+$`
+	// +kubebuilder:validation:Optional
+	Window string `json:"window,omitempty"`
+
+	// image provides the sqlite3 binary running the compaction.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+}
+
+// KeyManager defines configuration for the SPIRE server key manager
+type KeyManager struct {
+	// diskEnabled enables the disk-based key manager.
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Optional
+	DiskEnabled *bool `json:"diskEnabled,omitempty"`
+
+	// memoryEnabled enables the memory-based key manager
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	MemoryEnabled *bool `json:"memoryEnabled,omitempty"`
+}
+
+// CASubject defines the subject information for the SPIRE CA.
+type CASubject struct {
+	// country specifies the country for the CA.
+	// ISO 3166-1 alpha-2 country code (2 characters).
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=2
+	Country string `json:"country,omitempty"`
+
+	// organization specifies the organization for the CA.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=64
+	Organization string `json:"organization,omitempty"`
+
+	// commonName specifies the common name for the CA.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=255
+	CommonName string `json:"commonName,omitempty"`
+}
+
+// SpireServerStatus defines the observed state of the SPIRE server reconciliation performed by the operator.
+type SpireServerStatus struct {
+	// conditions holds information about the current state of the SPIRE server resources.
+	ConditionalStatus `json:",inline,omitempty"`
+
+	// plugins holds the status of each plugin configured on the SPIRE server, as reported by
+	// the health endpoint of the server.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=64
+	Plugins []PluginStatus `json:"plugins,omitempty"`
+
+	// webhookCertificateExpiry is the expiry of the serving certificate of the
+	// spire-controller-manager webhook, as last observed by the operator.
+	// +optional
+	WebhookCertificateExpiry *metav1.Time `json:"webhookCertificateExpiry,omitempty"`
+
+	// breakGlass is the emergency admin identity issued through the
+	// ztwim.openshift.io/break-glass-admin annotation, kept after its expiry until the annotation
+	// is removed so it is issued once.
+	// +optional
+	BreakGlass *BreakGlassStatus `json:"breakGlass,omitempty"`
+
+	// serverAPIOutageSince is when the gRPC health checks of the SPIRE server API started
+	// failing, unset while the API is serving.
+	// +optional
+	ServerAPIOutageSince *metav1.Time `json:"serverAPIOutageSince,omitempty"`
+
+	// rolloutVerification is the result of the verification of the last rollout of the SPIRE
+	// server StatefulSet.
+	// +optional
+	RolloutVerification *RolloutVerificationStatus `json:"rolloutVerification,omitempty"`
+
+	// federation is the result of the last conformance check of the bundle endpoints of the
+	// federation partners, requested through the ztwim.openshift.io/check-federation annotation.
+	// +optional
+	Federation *FederationStatus `json:"federation,omitempty"`
+
+	// sizingRecommendation is the sizing recommended from the load observed on the SPIRE server,
+	// set while spec.sizingRecommendations is enabled.
+	// +optional
+	SizingRecommendation *SizingRecommendation `json:"sizingRecommendation,omitempty"`
+}
+
+// SizingRecommendation is the sizing recommended from the peak load observed on the SPIRE server
+type SizingRecommendation struct {
+	// sizingProfile is the smallest sizing profile handling the observed load.
+	// +kubebuilder:validation:Required
+	SizingProfile SizingProfile `json:"sizingProfile"`
+
+	// resources are the resources of the SPIRE server under the recommended profile.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// maxOpenConns is the maximum number of open datastore connections under the recommended
+	// profile, unset for sqlite3 which does not benefit from a larger pool.
+	// +optional
+	MaxOpenConns int32 `json:"maxOpenConns,omitempty"`
+
+	// maxIdleConns is the maximum number of idle datastore connections under the recommended
+	// profile, unset for sqlite3.
+	// +optional
+	MaxIdleConns int32 `json:"maxIdleConns,omitempty"`
+
+	// peakX509SVIDsPerMinute is the highest rate of X509-SVIDs signed by the server observed
+	// over the window.
+	// +optional
+	PeakX509SVIDsPerMinute int64 `json:"peakX509SVIDsPerMinute,omitempty"`
+
+	// peakJWTSVIDsPerMinute is the highest rate of JWT-SVIDs signed by the server observed over
+	// the window.
+	// +optional
+	PeakJWTSVIDsPerMinute int64 `json:"peakJWTSVIDsPerMinute,omitempty"`
+
+	// peakAgentSyncLatency is the highest 99th percentile of the latency of the syncs of the
+	// authorized entries of the agents observed over the window.
+	// +optional
+	PeakAgentSyncLatency *metav1.Duration `json:"peakAgentSyncLatency,omitempty"`
+
+	// observedSince is when the oldest sample of the window was taken.
+	// +optional
+	ObservedSince *metav1.Time `json:"observedSince,omitempty"`
+}
+
+// FederationStatus is a conformance check of the bundle endpoints of the federation partners.
+type FederationStatus struct {
+	// checkRequest is the value of the ztwim.openshift.io/check-federation annotation the check
+	// was run for. Setting the annotation to another value runs the check again.
+	// +optional
+	CheckRequest string `json:"checkRequest,omitempty"`
+
+	// lastCheckTime is when the bundle endpoints were last checked.
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+
+	// partners holds the result of the check of each federated trust domain.
+	// +optional
+	// +listType=map
+	// +listMapKey=trustDomain
+	// +kubebuilder:validation:MaxItems=50
+	Partners []FederationPartnerStatus `json:"partners,omitempty"`
+}
+
+// FederationPartnerStatus is the conformance of the bundle endpoint of a federated trust domain.
+type FederationPartnerStatus struct {
+	// trustDomain is the federated trust domain.
+	// +kubebuilder:validation:Required
+	TrustDomain string `json:"trustDomain"`
+
+	// refreshHint is the refresh hint of the bundle served by the endpoint, in seconds.
+	// +optional
+	RefreshHint int64 `json:"refreshHint,omitempty"`
+
+	// conditions reports the checks of the bundle endpoint: EndpointReachable, ProfileCompatible,
+	// CertificateValid, BundleValid and RefreshHintValid.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=8
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// RolloutVerificationResult is the result of a rollout verification
+// +kubebuilder:validation:Enum=Running;Succeeded;Failed
+type RolloutVerificationResult string
+
+const (
+	RolloutVerificationRunning   RolloutVerificationResult = "Running"
+	RolloutVerificationSucceeded RolloutVerificationResult = "Succeeded"
+	RolloutVerificationFailed    RolloutVerificationResult = "Failed"
+)
+
+// RolloutVerificationStatus is the verification of a rollout of the SPIRE server StatefulSet.
+type RolloutVerificationStatus struct {
+	// revision is the StatefulSet revision that was verified.
+	// +kubebuilder:validation:Required
+	Revision string `json:"revision"`
+
+	// result is the result of the verification.
+	// +kubebuilder:validation:Required
+	Result RolloutVerificationResult `json:"result"`
+
+	// message describes the result, e.g. why the verification failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// completionTime is when the verification Job finished.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// BreakGlassStatus is an emergency admin identity issued by the operator.
+type BreakGlassStatus struct {
+	// subject is the <namespace>/<serviceAccount> the identity is issued to.
+	// +kubebuilder:validation:Required
+	Subject string `json:"subject"`
+
+	// spiffeID is the SPIFFE ID of the identity.
+	// +kubebuilder:validation:Required
+	SPIFFEID string `json:"spiffeID"`
+
+	// entryIDs are the IDs of the registration entries issuing the identity.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=2
+	EntryIDs []string `json:"entryIDs,omitempty"`
+
+	// issuedBy is the field manager which set the annotation.
+	// +optional
+	IssuedBy string `json:"issuedBy,omitempty"`
+
+	// expiresAt is when the registration entries expire.
+	// +kubebuilder:validation:Required
+	ExpiresAt metav1.Time `json:"expiresAt"`
+}
+
+// PluginStatus is the status of a single SPIRE server plugin.
+type PluginStatus struct {
+	// type is the SPIRE plugin type, e.g. DataStore or KeyManager.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=64
+	Type string `json:"type"`
+
+	// name is the name of the plugin in the server configuration.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=64
+	Name string `json:"name"`
+
+	// status is Healthy when the plugin is loaded and passes the health checks of the server,
+	// Unhealthy when a health check fails, and Unknown when the server cannot be reached.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Healthy;Unhealthy;Unknown
+	Status PluginHealth `json:"status"`
+
+	// message provides details about the status, e.g. the error of a failing health check.
+	// +optional
+	// +kubebuilder:validation:MaxLength=1024
+	Message string `json:"message,omitempty"`
+}
+
+// PluginHealth is the health of a SPIRE server plugin.
+type PluginHealth string
+
+const (
+	PluginHealthy   PluginHealth = "Healthy"
+	PluginUnhealthy PluginHealth = "Unhealthy"
+	PluginUnknown   PluginHealth = "Unknown"
+)
+
+// GetConditionalStatus returns the conditional status of the SpireServer
+func (s *SpireServer) GetConditionalStatus() ConditionalStatus {
+	return s.Status.ConditionalStatus
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SpireServerList contains a list of SpireServer
+type SpireServerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SpireServer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SpireServer{}, &SpireServerList{})
+}
@@ -0,0 +1,610 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.ready`
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.version`
+// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:validation:XValidation:rule="self.metadata.name == 'cluster'",message="ZeroTrustWorkloadIdentityManager is a singleton, .metadata.name must be 'cluster'"
+// +operator-sdk:csv:customresourcedefinitions:displayName="ZeroTrustWorkloadIdentityManager"
+
+// ZeroTrustWorkloadIdentityManager defines the configuration for the
+// operator that manages the lifecycle of SPIRE components in OpenShift
+// clusters.
+//
+// Note: This resource is *intended as a global config for operands managed
+// by zero-trust-workload-identity-manager. It does not contain
+// low-level configuration for SPIRE components, which is managed separately
+// in the SpireConfig CRD.
+type ZeroTrustWorkloadIdentityManager struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ZeroTrustWorkloadIdentityManagerSpec   `json:"spec,omitempty"`
+	Status            ZeroTrustWorkloadIdentityManagerStatus `json:"status,omitempty"`
+}
+
+// ZeroTrustWorkloadIdentityManagerStatus defines the observed state of ZeroTrustWorkloadIdentityManager.
+// It aggregates the status from all managed operand CRs and provides an overall health view.
+type ZeroTrustWorkloadIdentityManagerStatus struct {
+	// conditions represent the latest available observations of the zero-trust-workload-identity-manager's state.
+	// This includes the aggregated status from all managed operand CRs.
+	ConditionalStatus `json:",inline,omitempty"`
+
+	// operands holds the status of each managed operand CR.
+	// Operands are indexed by their kind and name: operands are named "cluster", except
+	// additional SpireAgent pools, which are listed after the default one.
+	// This provides a quick overview of the health of each SPIRE component.
+	// +optional
+	// +listType=map
+	// +listMapKey=kind
+	// +listMapKey=name
+	Operands []OperandStatus `json:"operands,omitempty"`
+
+	// staticAssetsChecksum is the checksum of the static manifests embedded in the operator
+	// image, which identifies their version. The StaticAssetsValid condition reports whether
+	// they passed the integrity check at startup.
+	// +optional
+	StaticAssetsChecksum string `json:"staticAssetsChecksum,omitempty"`
+}
+
+// OperandStatus represents the status of a single managed operand CR.
+// Each operand corresponds to a SPIRE component (e.g., SpireServer, SpireAgent).
+type OperandStatus struct {
+	// name is the name of the operand resource.
+	// For singleton resources, this is typically "cluster".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +required
+	Name string `json:"name"`
+
+	// kind is the Kind of the operand CR.
+	// Must be one of: SpireServer, SpireAgent, SpiffeCSIDriver, SpireOIDCDiscoveryProvider.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=SpireServer;SpireAgent;SpiffeCSIDriver;SpireOIDCDiscoveryProvider
+	// +required
+	Kind string `json:"kind"`
+
+	// ready indicates whether the operand is in a ready state.
+	// An operand is considered ready when all its resources are available and functioning correctly.
+	// +kubebuilder:validation:Required
+	// +required
+	Ready bool `json:"ready"`
+
+	// message provides human-readable details about the operand's current state.
+	// This may include information about why an operand is not ready or other relevant status details.
+	// +optional
+	// +kubebuilder:validation:MaxLength=32768
+	Message string `json:"message,omitempty"`
+
+	// conditions represent the latest available observations of the operand's state.
+	// This includes key conditions from the operand CR that are relevant for overall health monitoring.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ZeroTrustWorkloadIdentityManagerList contains a list of ZeroTrustWorkloadIdentityManager
+type ZeroTrustWorkloadIdentityManagerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ZeroTrustWorkloadIdentityManager `json:"items"`
+}
+
+// ZeroTrustWorkloadIdentityManagerSpec defines the desired state of the ZeroTrustWorkloadIdentityManager
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.trustDomain) || (has(self.trustDomain) && self.trustDomain == oldSelf.trustDomain) || (has(self.trustDomain) && has(oldSelf.trustDomainMigration) && oldSelf.trustDomainMigration.stage == 'Complete' && self.trustDomain == oldSelf.trustDomainMigration.newTrustDomain)",message="trustDomain is immutable and cannot be changed, except to the newTrustDomain of a trustDomainMigration in the Complete stage"
+// +kubebuilder:validation:XValidation:rule="!has(self.trustDomainMigration) || !has(self.trustDomain) || self.trustDomainMigration.oldTrustDomain == self.trustDomain || self.trustDomainMigration.newTrustDomain == self.trustDomain",message="trustDomainMigration.oldTrustDomain must be the trustDomain"
+type ZeroTrustWorkloadIdentityManagerSpec struct {
+	// trustDomain to be used for the SPIFFE identifiers.
+	// This field is immutable, except to complete a trustDomainMigration.
+	// Must be a valid SPIFFE trust domain (lowercase alphanumeric, hyphens, and dots).
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([a-z0-9\-\.]*[a-z0-9])?$`
+	TrustDomain string `json:"trustDomain,omitempty"`
+
+	// clusterName identifies this cluster within the trust domain.
+	// This field is immutable.
+	// Must be a valid DNS-1123 subdomain.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="clusterName is immutable and cannot be changed"
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// bundleConfigMap is the name of the ConfigMap that stores the SPIRE trust bundle.
+	// This ConfigMap contains the root certificates for the trust domain specified in trustDomain.
+	// The operator will create and maintain this ConfigMap.
+	// Changing this field migrates the trust bundle: the new ConfigMap is created with the
+	// current bundle, the SPIRE server and agents are moved to it, and the previous ConfigMap
+	// is deleted once they have rolled out. Progress is reported by the BundleConfigMapMigration
+	// condition.
+	// Must be a valid Kubernetes name.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=spire-bundle
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	BundleConfigMap string `json:"bundleConfigMap"`
+
+	// bundleFormats publishes the trust bundle under additional keys of the bundleConfigMap, for
+	// consumers expecting another key name or the SPIFFE bundle format. The PEM bundle is
+	// always published under bundle.crt, which the SPIRE agents read; the additional keys are
+	// derived from it and kept in sync as the CAs rotate. Progress is reported by the
+	// BundleFormatsPublished condition.
+	// +kubebuilder:validation:Optional
+	BundleFormats *TrustBundleFormats `json:"bundleFormats,omitempty"`
+
+	// helmMigration configures adoption of an existing helm-deployed SPIRE installation.
+	// When enabled, helm-managed SPIRE resources in the operator namespace are detected,
+	// their configuration is imported into the operand CRs and the resources are taken over
+	// by the operator.
+	// +kubebuilder:validation:Optional
+	HelmMigration *HelmMigrationConfig `json:"helmMigration,omitempty"`
+
+	// sizingProfile selects recommended resource requests and limits for every operand, and
+	// datastore connection pool settings for the SPIRE server, for a cluster of the given size.
+	// Resources set on an operand CR take precedence over the profile, as do datastore pool
+	// settings changed from their defaults. The pool settings do not apply to sqlite3.
+	// When unset, no resources are set on the operands, except on single-node clusters where
+	// the singleNode profile is used.
+	// Valid values are: small, medium, large, singleNode.
+	// +kubebuilder:validation:Optional
+	SizingProfile SizingProfile `json:"sizingProfile,omitempty"`
+
+	// topologyProfile selects the defaults depending on the topology of the cluster.
+	// HighlyAvailable spreads the replicas of the operands across nodes and protects them with
+	// PodDisruptionBudgets. SingleNode reduces the footprint of the operands for single-node
+	// edge clusters: no PodDisruptionBudgets, no topology spread, pod anti-affinity of the
+	// operands is dropped and the singleNode sizing profile is used unless sizingProfile is set.
+	// When unset, the profile is detected from the control plane topology of the
+	// Infrastructure config, and follows it when it changes.
+	// Valid values are: HighlyAvailable, SingleNode.
+	// +kubebuilder:validation:Optional
+	TopologyProfile TopologyProfile `json:"topologyProfile,omitempty"`
+
+	// namespaceGuardrails creates a ResourceQuota and a LimitRange in the operator namespace,
+	// derived from the sizing profile, protecting the cluster from runaway resource usage, e.g.
+	// pods piling up when a configuration error makes them fail. The quota allows twice the
+	// resource requests of the operands on the largest cluster of the sizing profile: about
+	// 50 nodes for small, 250 for medium, 1000 for large and a single node for singleNode.
+	// When no sizing profile is used, the quota is sized for the medium profile.
+	// When unset, no guardrails are created and the ones previously created are removed.
+	// +kubebuilder:validation:Optional
+	NamespaceGuardrails *NamespaceGuardrailsConfig `json:"namespaceGuardrails,omitempty"`
+
+	// maintenanceWindows restricts the disruptive changes to the operands, i.e. the updates of
+	// the SPIRE server StatefulSet and of the SPIRE agent and SPIFFE CSI driver DaemonSets which
+	// restart their pods, to the given recurring windows. Changes made outside of a window are
+	// deferred and reported by the ChangesPending condition of the operand, then applied
+	// automatically when the next window opens. Other resources are updated immediately.
+	// When unset, changes are applied immediately.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=atomic
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// waitForSpireServer holds back the creation of the SPIRE agent and SPIFFE CSI driver
+	// DaemonSets until the SpireServer is Ready, so the agents do not crash-loop against a server
+	// which does not exist yet during the initial install. The operands report the
+	// WaitingForDependency condition meanwhile. Once created, the DaemonSets are reconciled
+	// regardless of the state of the server.
+	// +kubebuilder:default:=true
+	// +kubebuilder:validation:Optional
+	WaitForSpireServer *bool `json:"waitForSpireServer,omitempty"`
+
+	// trustDomainMigration moves the operands from oldTrustDomain, the current trustDomain, to
+	// newTrustDomain through the stages set by the administrator:
+	// Prepare snapshots the trust bundle of oldTrustDomain and publishes it under the
+	// previous-bundle.crt key of the bundleConfigMap.
+	// Cutover renders the SPIRE server, agents and OIDC discovery provider with newTrustDomain:
+	// spire-controller-manager re-registers the workloads in newTrustDomain, while the bundle of
+	// oldTrustDomain stays published under previous-bundle.crt so peers of either trust domain
+	// are trusted during the transition. Setting Prepare again rolls back.
+	// SPIFFE IDs set on the operands, e.g. the adminIDs of the server, must be moved to
+	// newTrustDomain along with the cutover.
+	// Complete removes the bundle of oldTrustDomain. trustDomain may then be set to
+	// newTrustDomain and trustDomainMigration removed.
+	// Progress is reported by the TrustDomainMigration condition.
+	// +kubebuilder:validation:Optional
+	TrustDomainMigration *TrustDomainMigrationConfig `json:"trustDomainMigration,omitempty"`
+
+	// imagePullSecrets are the Secrets in the operator namespace used to pull the operand
+	// images, e.g. the credentials of a mirror registry in a disconnected cluster. They apply to
+	// every operand which sets no imagePullSecrets of its own. Pull failures of the operand pods,
+	// missing pull secrets and operand images referenced by tag from a repository mirrored by an
+	// ImageDigestMirrorSet are reported by the ImagesAvailable condition.
+	// Maximum 8 secrets allowed.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=8
+	// +listType=atomic
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// statusGracePeriod is how long the operator keeps reporting the last healthy conditions of
+	// the operands while they fail for reasons an unreachable API server causes, e.g. transient
+	// reconciliation errors or operand workloads which cannot be read. It suits edge clusters
+	// with intermittent connectivity to the control plane, whose outages would otherwise flip
+	// the Ready conditions back and forth. A failure lasting longer than the grace period is
+	// reported at the next reconciliation. Failures caused by the configuration are reported
+	// immediately. When unset, failures are reported immediately.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:validation:Optional
+	StatusGracePeriod *metav1.Duration `json:"statusGracePeriod,omitempty"`
+}
+
+// TrustDomainMigrationStage is a stage of a trust domain migration
+// +kubebuilder:validation:Enum=Prepare;Cutover;Complete
+type TrustDomainMigrationStage string
+
+const (
+	// TrustDomainMigrationStagePrepare snapshots the trust bundle of the old trust domain
+	TrustDomainMigrationStagePrepare TrustDomainMigrationStage = "Prepare"
+	// TrustDomainMigrationStageCutover moves the operands to the new trust domain
+	TrustDomainMigrationStageCutover TrustDomainMigrationStage = "Cutover"
+	// TrustDomainMigrationStageComplete removes the trust bundle of the old trust domain
+	TrustDomainMigrationStageComplete TrustDomainMigrationStage = "Complete"
+)
+
+// TrustDomainMigrationConfig configures the migration of the operands to another trust domain
+// +kubebuilder:validation:XValidation:rule="self.oldTrustDomain != self.newTrustDomain",message="newTrustDomain must differ from oldTrustDomain"
+type TrustDomainMigrationConfig struct {
+	// oldTrustDomain is the trust domain migrated from. It must be the trustDomain until the
+	// migration is complete.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([a-z0-9\-\.]*[a-z0-9])?$`
+	OldTrustDomain string `json:"oldTrustDomain"`
+
+	// newTrustDomain is the trust domain migrated to.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([a-z0-9\-\.]*[a-z0-9])?$`
+	NewTrustDomain string `json:"newTrustDomain"`
+
+	// stage is the stage of the migration.
+	// Valid values are: Prepare, Cutover, Complete.
+	// +kubebuilder:default:=Prepare
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="oldSelf == self || (oldSelf == 'Prepare' && self == 'Cutover') || (oldSelf == 'Cutover' && (self == 'Prepare' || self == 'Complete'))",message="stage moves from Prepare to Cutover, then back to Prepare or on to Complete"
+	Stage TrustDomainMigrationStage `json:"stage,omitempty"`
+}
+
+// MaintenanceWindow is a recurring window during which disruptive changes are applied to the operands
+type MaintenanceWindow struct {
+	// schedule is the opening of the window, in cron format evaluated in UTC, e.g. "0 2 * * 6".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=9
+	// +kubebuilder:validation:MaxLength=128
+	Schedule string `json:"schedule"`
+
+	// duration is how long the window stays open after the scheduled time, as a duration
+	// (e.g. 2h). Changes are only started while the window is open, a rollout started in the
+	// window is not interrupted when it closes.
+	// +kubebuilder:default:="1h"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(s|m|h))+$`
+	// +kubebuilder:validation:Optional
+	Duration string `json:"duration,omitempty"`
+}
+
+// NamespaceGuardrailsConfig selects the guardrails created in the operator namespace
+type NamespaceGuardrailsConfig struct {
+	// resourceQuota enables the ResourceQuota bounding the number of pods and the resource
+	// requests of the namespace. Pods declaring no resource requests are rejected by the quota,
+	// unless the LimitRange sets default requests.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=true
+	ResourceQuota *bool `json:"resourceQuota,omitempty"`
+
+	// limitRange enables the LimitRange setting default resource requests on the containers of
+	// the namespace which declare none.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:=true
+	LimitRange *bool `json:"limitRange,omitempty"`
+}
+
+// SizingProfile is a preset of operand resources and datastore settings for a cluster size
+// +kubebuilder:validation:Enum=small;medium;large;singleNode
+type SizingProfile string
+
+const (
+	// SizingProfileSmall suits clusters of up to about 50 nodes
+	SizingProfileSmall SizingProfile = "small"
+	// SizingProfileMedium suits clusters of up to about 250 nodes
+	SizingProfileMedium SizingProfile = "medium"
+	// SizingProfileLarge suits clusters of more than 250 nodes
+	SizingProfileLarge SizingProfile = "large"
+	// SizingProfileSingleNode suits single-node edge clusters, with minimal requests
+	SizingProfileSingleNode SizingProfile = "singleNode"
+)
+
+// TopologyProfile is the set of defaults applied for the topology of the cluster
+// +kubebuilder:validation:Enum=HighlyAvailable;SingleNode
+type TopologyProfile string
+
+const (
+	// TopologyProfileHighlyAvailable suits clusters with several nodes
+	TopologyProfileHighlyAvailable TopologyProfile = "HighlyAvailable"
+	// TopologyProfileSingleNode suits single-node clusters
+	TopologyProfileSingleNode TopologyProfile = "SingleNode"
+)
+
+// TrustBundleFormats defines the additional keys the trust bundle is published to
+// +kubebuilder:validation:XValidation:rule="!has(self.pemKeys) || !has(self.spiffeKey) || !(self.spiffeKey in self.pemKeys)",message="spiffeKey must differ from the pemKeys"
+type TrustBundleFormats struct {
+	// pemKeys are additional keys the PEM bundle is copied to, e.g. ca.crt.
+	// Maximum 4 keys allowed.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=4
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=253
+	// +kubebuilder:validation:items:Pattern=`^[-._a-zA-Z0-9]+$`
+	// +kubebuilder:validation:XValidation:rule="!self.exists(k, k == 'bundle.crt')",message="bundle.crt always holds the PEM bundle"
+	// +listType=set
+	PEMKeys []string `json:"pemKeys,omitempty"`
+
+	// spiffeKey is the key the trust bundle is published to in the SPIFFE bundle format, a JWK
+	// set, e.g. bundle.spiffe. It holds the X.509 authorities of the trust domain.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	// +kubebuilder:validation:XValidation:rule="self != 'bundle.crt'",message="bundle.crt always holds the PEM bundle"
+	SPIFFEKey string `json:"spiffeKey,omitempty"`
+}
+
+// HelmMigrationConfig configures the adoption of a helm-deployed SPIRE stack.
+type HelmMigrationConfig struct {
+	// enabled turns on detection and adoption of helm-managed SPIRE resources.
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// releaseName restricts adoption to resources of the given helm release.
+	// When empty, resources of any helm release found in the operator namespace are adopted.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=53
+	ReleaseName string `json:"releaseName,omitempty"`
+}
+
+// CommonConfig has similar config required for all other APIs
+type CommonConfig struct {
+
+	// labels to apply to all resources managed by the API.
+	// Maximum 64 labels allowed. Label keys and values must be valid Kubernetes labels.
+	// +mapType=granular
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxProperties=64
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// resources define the resource requirements.
+	// ref: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+	// +kubebuilder:validation:Optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// affinity defines scheduling affinity rules.
+	// ref: https://kubernetes.io/docs/concepts/scheduling-eviction/assign-pod-node/
+	// +kubebuilder:validation:Optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// tolerations define the pod tolerations.
+	// Maximum 50 tolerations allowed.
+	// ref: https://kubernetes.io/docs/concepts/scheduling-eviction/taint-and-toleration/
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=50
+	// +listType=atomic
+	Tolerations []*corev1.Toleration `json:"tolerations,omitempty"`
+
+	// nodeSelector defines the scheduling criteria using node labels.
+	// Maximum 50 node selectors allowed.
+	// ref: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxProperties=50
+	// +mapType=atomic
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// dnsPolicy is the DNS policy of the operand pods, e.g. None to resolve only through the
+	// nameservers of dnsConfig. Defaults to ClusterFirst.
+	// ref: https://kubernetes.io/docs/concepts/services-networking/dns-pod-service/#pod-s-dns-policy
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=ClusterFirst;ClusterFirstWithHostNet;Default;None
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// dnsConfig adds nameservers, search domains and resolver options to the DNS configuration
+	// of the operand pods, e.g. a corporate resolver of the federation endpoints. It is required
+	// when dnsPolicy is None.
+	// ref: https://kubernetes.io/docs/concepts/services-networking/dns-pod-service/#pod-dns-config
+	// +kubebuilder:validation:Optional
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+
+	// imagePullSecrets are the Secrets in the operator namespace used to pull the images of the
+	// operand pods. When unset, the imagePullSecrets of the ZeroTrustWorkloadIdentityManager
+	// are used.
+	// Maximum 8 secrets allowed.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=8
+	// +listType=atomic
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// adoptExistingResources allows the operator to take over resources that already exist
+	// with the name of a managed resource but are not controlled by any owner, for example
+	// resources created manually before the operand CR. Adopted resources get the operand CR
+	// as controller owner and are converged to the desired state. Resources controlled by
+	// another owner are never adopted, nor are workloads whose selector or volume claim
+	// templates differ from the desired ones, since they cannot be updated: the operand is
+	// reported Degraded with the InvalidConfiguration reason until they are deleted.
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	AdoptExistingResources *bool `json:"adoptExistingResources,omitempty"`
+
+	// managedResources hands over the resources of the given kinds generated for the operand:
+	// an Unmanaged kind is no longer created, updated or deleted by the operator, so it can be
+	// taken over, e.g. to customize the SecurityContextConstraints or the ConfigMap. The
+	// operator keeps managing every other kind.
+	// +kubebuilder:validation:Optional
+	ManagedResources *ManagedResources `json:"managedResources,omitempty"`
+}
+
+// ManagementState tells whether the operator manages a kind of resource
+// +kubebuilder:validation:Enum=Managed;Unmanaged
+type ManagementState string
+
+const (
+	// ManagementStateManaged resources are converged to their desired state by the operator
+	ManagementStateManaged ManagementState = "Managed"
+	// ManagementStateUnmanaged resources are left alone by the operator
+	ManagementStateUnmanaged ManagementState = "Unmanaged"
+)
+
+// ManagedResources sets the management state of each kind of generated resource. Kinds not
+// generated for the operand are ignored.
+type ManagedResources struct {
+	// serviceAccount is the management state of the ServiceAccounts.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="Managed"
+	ServiceAccount ManagementState `json:"serviceAccount,omitempty"`
+
+	// service is the management state of the Services.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="Managed"
+	Service ManagementState `json:"service,omitempty"`
+
+	// configMap is the management state of the ConfigMaps.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="Managed"
+	ConfigMap ManagementState `json:"configMap,omitempty"`
+
+	// rbac is the management state of the ClusterRoles, ClusterRoleBindings, Roles and RoleBindings.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="Managed"
+	RBAC ManagementState `json:"rbac,omitempty"`
+
+	// workload is the management state of the StatefulSet, DaemonSet or Deployment running the operand.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="Managed"
+	Workload ManagementState `json:"workload,omitempty"`
+
+	// securityContextConstraints is the management state of the SecurityContextConstraints.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="Managed"
+	SecurityContextConstraints ManagementState `json:"securityContextConstraints,omitempty"`
+
+	// route is the management state of the Routes.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default:="Managed"
+	Route ManagementState `json:"route,omitempty"`
+}
+
+// Architecture is a CPU architecture the operand pods can run on, as in the kubernetes.io/arch node label
+// +kubebuilder:validation:Enum=amd64;arm64;ppc64le;s390x
+type Architecture string
+
+// TelemetryConfig configures the telemetry of a SPIRE component
+type TelemetryConfig struct {
+	// statsdBridge deploys a statsd_exporter sidecar the SPIRE component sends its DogStatsD telemetry to,
+	// and exposes the Prometheus metrics it translates them to through a Service.
+	// +kubebuilder:validation:Optional
+	StatsdBridge *StatsdBridgeConfig `json:"statsdBridge,omitempty"`
+}
+
+// StatsdBridgeConfig configures the statsd to Prometheus bridge sidecar of a SPIRE component
+type StatsdBridgeConfig struct {
+	// metricsPort is the port the bridge serves the Prometheus metrics on.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1024
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:validation:XValidation:rule="!(self in [8080, 8081, 8082, 8083, 8443, 9125, 9402, 9443, 9982])",message="metricsPort must not collide with the ports of the SPIRE component"
+	// +kubebuilder:default:=9102
+	MetricsPort int32 `json:"metricsPort,omitempty"`
+
+	// resources are the compute resources of the bridge container.
+	// +kubebuilder:validation:Optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// ServiceConfig customizes the Service exposing an operand
+// +kubebuilder:validation:XValidation:rule="self.type != 'ClusterIP' || !has(self.ports) || self.ports.all(p, !has(p.nodePort))",message="nodePort can only be set when type is NodePort or LoadBalancer"
+type ServiceConfig struct {
+	// type determines how the Service is exposed.
+	// Valid values are: ClusterIP, NodePort, LoadBalancer.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	// +kubebuilder:default:="ClusterIP"
+	Type corev1.ServiceType `json:"type,omitempty"`
+
+	// annotations to add to the Service, e.g. to request an internal load balancer.
+	// Annotations set by the operator take precedence.
+	// Maximum 64 annotations allowed.
+	// +mapType=granular
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxProperties=64
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// ports overrides the port numbers of the Service ports, matched by name.
+	// Maximum 10 port overrides allowed.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=map
+	// +listMapKey=name
+	Ports []ServicePortConfig `json:"ports,omitempty"`
+}
+
+// ServicePortConfig overrides a port of an operand Service
+type ServicePortConfig struct {
+	// name of the Service port to override, e.g. grpc, https or metrics.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=15
+	Name string `json:"name"`
+
+	// port is the port exposed by the Service. Defaults to the port of the operand.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port,omitempty"`
+
+	// nodePort is the port on each node the Service is exposed on when type is NodePort
+	// or LoadBalancer. Allocated by Kubernetes when not set.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	NodePort int32 `json:"nodePort,omitempty"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ZeroTrustWorkloadIdentityManager{}, &ZeroTrustWorkloadIdentityManagerList{})
+}
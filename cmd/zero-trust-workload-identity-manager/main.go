@@ -19,10 +19,16 @@ package main
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"expvar"
 	"flag"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
 	"os"
 	"path/filepath"
+	"time"
 
+	configv1 "github.com/openshift/api/config/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	operatorv1 "github.com/operator-framework/api/pkg/operators/v1"
 
@@ -37,17 +43,28 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 
+	"k8s.io/client-go/discovery"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	operatoropenshiftiov1alpha1 "github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	operatoropenshiftiov1alpha2 "github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/dependencies"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/effectiveconfig"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/initialconfig"
+	mintSVIDRequestController "github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/mint-svid-request"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/servingcert"
 	spiffeCsiDriverController "github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/spiffe-csi-driver"
+	spiffeHelperController "github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/spiffe-helper"
+	spiffeIDPolicyController "github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/spiffe-id-policy"
 	spireAgentController "github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/spire-agent"
 	spireOIDCDiscoveryProviderController "github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/spire-oidc-discovery-provider"
 	spireServerController "github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/spire-server"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 	ztwimController "github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/zero-trust-workload-identity-manager"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/operator/assets"
+	ztwimWebhook "github.com/openshift/zero-trust-workload-identity-manager/pkg/webhook"
 
 	securityv1 "github.com/openshift/api/security/v1"
 
@@ -65,6 +82,10 @@ const (
 	metricsKeyFileName = "tls.key"
 
 	openshiftCACertificateFile = "/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt"
+
+	// webhookCertFileName is the webhook serving certificate mounted by OLM in the
+	// default certificate directory of the webhook server
+	webhookCertFileName = "tls.crt"
 )
 
 var (
@@ -76,9 +97,60 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(operatoropenshiftiov1alpha1.AddToScheme(scheme))
+	utilruntime.Must(operatoropenshiftiov1alpha2.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
+// kubeAPIFlagEnv maps the API client flags to the environment variables setting them when they
+// are not passed, as an OLM Subscription can set the environment of the operator but not its
+// arguments
+var kubeAPIFlagEnv = map[string]string{
+	"kube-api-qps":                       "KUBE_API_QPS",
+	"kube-api-burst":                     "KUBE_API_BURST",
+	"kube-api-call-timeout":              "KUBE_API_CALL_TIMEOUT",
+	"kube-api-disable-client-throttling": "KUBE_API_DISABLE_CLIENT_THROTTLING",
+	"leader-elect-lease-duration":        "LEADER_ELECT_LEASE_DURATION",
+	"leader-elect-renew-deadline":        "LEADER_ELECT_RENEW_DEADLINE",
+	"leader-elect-retry-period":          "LEADER_ELECT_RETRY_PERIOD",
+}
+
+// applyFlagEnv sets the flags not passed on the command line from their environment variables
+func applyFlagEnv(fs *flag.FlagSet, env map[string]string) error {
+	passed := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { passed[f.Name] = true })
+	for name, envName := range env {
+		value, ok := os.LookupEnv(envName)
+		if passed[name] || !ok || value == "" {
+			continue
+		}
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("invalid %s %q: %w", envName, value, err)
+		}
+	}
+	return nil
+}
+
+const (
+	assetIntegrityEnforce = "enforce"
+	assetIntegrityWarn    = "warn"
+)
+
+// checkAssetIntegrity verifies the embedded static assets, failing in the enforce mode
+func checkAssetIntegrity(mode string) error {
+	if mode != assetIntegrityEnforce && mode != assetIntegrityWarn {
+		return fmt.Errorf("invalid --asset-integrity %q, expected %q or %q", mode, assetIntegrityEnforce, assetIntegrityWarn)
+	}
+	if err := assets.Verify(); err != nil {
+		if mode == assetIntegrityEnforce {
+			return err
+		}
+		setupLog.Error(err, "continuing with corrupted static assets", "asset-integrity", mode)
+		return nil
+	}
+	setupLog.Info("Static assets verified", "checksum", assets.ManifestChecksum)
+	return nil
+}
+
 func main() {
 	var (
 		metricsAddr          string
@@ -86,8 +158,22 @@ func main() {
 		probeAddr            string
 		secureMetrics        bool
 		enableHTTP2          bool
+		enablePprof          bool
+		enableConfigExport   bool
 		logLevel             int
 		metricsCerts         string
+		metricsCertSecret    string
+		metricsService       string
+		webhookCertSecret    string
+		webhookService       string
+		kubeAPIQPS           float64
+		kubeAPIBurst         int
+		kubeAPICallTimeout   time.Duration
+		kubeAPINoThrottling  bool
+		leaseDuration        time.Duration
+		renewDeadline        time.Duration
+		retryPeriod          time.Duration
+		assetIntegrity       string
 		metricsTLSOpts       []func(*tls.Config)
 		webhookTLSOpts       []func(*tls.Config)
 	)
@@ -101,10 +187,50 @@ func main() {
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.BoolVar(&enablePprof, "enable-pprof", false,
+		"If set, pprof and expvar diagnostics are served under /debug on the metrics endpoint, "+
+			"protected by the same authentication and authorization as /metrics. Requires --metrics-secure.")
+	flag.BoolVar(&enableConfigExport, "enable-config-export", false,
+		"If set, the effective configuration of each operand, with credentials redacted, is served under "+
+			"/debug/config on the metrics endpoint, protected by the same authentication and authorization as /metrics. "+
+			"Requires --metrics-secure.")
 	flag.IntVar(&logLevel, "v", 2, "operator log verbosity")
 	flag.StringVar(&metricsCerts, "metrics-cert-dir", "",
-		"Secret name containing the certificates for the metrics server which should be present in operator namespace. "+
-			"If not provided self-signed certificates will be used")
+		"Deprecated: use --metrics-serving-cert-secret. Directory containing the tls.crt and tls.key of the metrics server. "+
+			"If neither is provided self-signed certificates will be used")
+	flag.StringVar(&metricsCertSecret, "metrics-serving-cert-secret", "",
+		"Secret of the operator namespace the OpenShift service CA issues the certificate of the metrics server to. "+
+			"The Service named by --metrics-service is annotated to request it, and the certificate is reloaded when rotated.")
+	flag.StringVar(&metricsService, "metrics-service", "zero-trust-workload-identity-manager-metrics-service",
+		"Service of the metrics server, annotated to request its certificate when --metrics-serving-cert-secret is set.")
+	flag.StringVar(&webhookCertSecret, "webhook-serving-cert-secret", "",
+		"Secret of the operator namespace the OpenShift service CA issues the certificate of the webhook server to. "+
+			"The Service named by --webhook-service is annotated to request it, and the certificate is reloaded when rotated. "+
+			"The webhook configurations must then trust the service CA. If not provided the certificate mounted by OLM is used.")
+	flag.StringVar(&webhookService, "webhook-service", "zero-trust-workload-identity-manager-webhook-service",
+		"Service of the webhook server, annotated to request its certificate when --webhook-serving-cert-secret is set.")
+	flag.Float64Var(&kubeAPIQPS, "kube-api-qps", 50,
+		"Maximum sustained rate of requests of the operator to the API server. "+
+			"The request rates are exposed by the ztwim_client_call_duration_seconds metric.")
+	flag.IntVar(&kubeAPIBurst, "kube-api-burst", 100, "Maximum burst of requests of the operator to the API server.")
+	flag.DurationVar(&kubeAPICallTimeout, "kube-api-call-timeout", customClient.DefaultCallTimeout,
+		"Deadline of each call of the operator to the API server or its cache, after which the reconcile is retried. "+
+			"Set to 0 to disable it.")
+	flag.BoolVar(&kubeAPINoThrottling, "kube-api-disable-client-throttling", false,
+		"If set, the requests of the operator are not throttled by the client, and --kube-api-qps and --kube-api-burst "+
+			"are ignored. Suits large clusters where API Priority and Fairness of the API server bounds the load.")
+	// The lease timings of OpenShift operators, which keep the leader through API server outages
+	// and rollouts of up to a couple of minutes rather than restarting the operator
+	flag.DurationVar(&leaseDuration, "leader-elect-lease-duration", 137*time.Second,
+		"Duration non-leader candidates wait before acquiring the leadership. Longer leases keep the operator running "+
+			"through API server outages, e.g. on edge clusters with intermittent connectivity.")
+	flag.DurationVar(&renewDeadline, "leader-elect-renew-deadline", 107*time.Second,
+		"Duration the leader retries renewing its lease before giving up the leadership and exiting.")
+	flag.DurationVar(&retryPeriod, "leader-elect-retry-period", 26*time.Second,
+		"Duration the candidates wait between attempts to acquire or renew the leadership.")
+	flag.StringVar(&assetIntegrity, "asset-integrity", assetIntegrityEnforce,
+		"What to do when the static assets embedded in the operator fail their integrity check: "+
+			"'enforce' refuses to start, 'warn' starts and reports the StaticAssetsValid condition as False.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -114,6 +240,11 @@ func main() {
 	logConfig := textlogger.NewConfig(textlogger.Verbosity(logLevel))
 	ctrl.SetLogger(textlogger.NewLogger(logConfig))
 
+	if err := applyFlagEnv(flag.CommandLine, kubeAPIFlagEnv); err != nil {
+		setupLog.Error(err, "invalid API client setting")
+		os.Exit(1)
+	}
+
 	// Validate that OPERATOR_NAMESPACE is set
 	operatorNamespace := utils.GetOperatorNamespace()
 	if operatorNamespace == "" {
@@ -122,6 +253,11 @@ func main() {
 	}
 	setupLog.Info("Operator namespace configured", "namespace", operatorNamespace)
 
+	if err := checkAssetIntegrity(assetIntegrity); err != nil {
+		setupLog.Error(err, "refusing to start with corrupted static assets")
+		os.Exit(1)
+	}
+
 	if !enableHTTP2 {
 		// if the enable-http2 flag is false (the default), http/2 should be disabled
 		// due to its vulnerabilities.
@@ -133,6 +269,13 @@ func main() {
 		webhookTLSOpts = append(webhookTLSOpts, disableHTTP2)
 	}
 
+	// The certificates issued by the service CA are read through the API once the manager starts
+	var metricsCertSource, webhookCertSource *servingcert.Source
+	if webhookCertSecret != "" {
+		webhookCertSource = servingcert.NewSource(webhookService, webhookCertSecret)
+		webhookTLSOpts = append(webhookTLSOpts, webhookCertSource.TLSOption)
+	}
+
 	webhookServer := webhook.NewServer(webhook.Options{
 		TLSOpts: webhookTLSOpts,
 	})
@@ -154,15 +297,16 @@ func main() {
 	if secureMetrics {
 		setupLog.Info("setting up secure metrics server")
 		metricsServerOptions.SecureServing = secureMetrics
-		if metricsCerts != "" {
-			if _, err := os.Stat(filepath.Join(metricsCerts, metricsCertFileName)); err != nil {
-				setupLog.Error(err, "metrics certificate file not found at configured path")
-				os.Exit(1)
-			}
-			if _, err := os.Stat(filepath.Join(metricsCerts, metricsKeyFileName)); err != nil {
-				setupLog.Error(err, "metrics private key file not found at configured path")
-				os.Exit(1)
-			}
+		switch {
+		case metricsCertSecret != "" && metricsCerts != "":
+			setupLog.Error(nil, "only one of --metrics-serving-cert-secret and --metrics-cert-dir can be set")
+			os.Exit(1)
+		case metricsCertSecret != "":
+			setupLog.Info("using the certificate issued by the service CA for metrics server", "secret", metricsCertSecret)
+			metricsCertSource = servingcert.NewSource(metricsService, metricsCertSecret)
+			metricsTLSOpts = append(metricsTLSOpts, metricsCertSource.TLSOption)
+		case metricsCerts != "":
+			// The key pair is watched by the metrics server, which fails to start if it is missing
 			setupLog.Info("using certificate key pair found in the configured dir for metrics server")
 			metricsServerOptions.CertDir = metricsCerts
 			metricsServerOptions.CertName = metricsCertFileName
@@ -185,27 +329,55 @@ func main() {
 		})
 		metricsServerOptions.TLSOpts = metricsTLSOpts
 	}
-	config := ctrl.GetConfigOrDie()
 
-	// Increase QPS and Burst to allow more concurrent API calls
-	config.QPS = 50    // Default is usually 5, increase as needed
-	config.Burst = 100 // Default is usually 10, increase as needed
+	if enablePprof {
+		if !secureMetrics || metricsAddr == "0" {
+			setupLog.Error(nil, "pprof endpoints require the secure metrics server to be enabled")
+			os.Exit(1)
+		}
+		setupLog.Info("enabling pprof and expvar diagnostics endpoints on the metrics server")
+		metricsServerOptions.ExtraHandlers = pprofHandlers()
+	}
+	if enableConfigExport && (!secureMetrics || metricsAddr == "0") {
+		setupLog.Error(nil, "the effective configuration endpoint requires the secure metrics server to be enabled")
+		os.Exit(1)
+	}
+	config := ctrl.GetConfigOrDie()
 
-	// Add OpenShift SCC scheme
-	if err := securityv1.AddToScheme(scheme); err != nil {
-		exitOnError(err, "unable to add securityv1 scheme")
+	// Increase QPS and Burst over the client-go defaults of 5 and 10 to allow more concurrent API calls
+	switch {
+	case kubeAPINoThrottling:
+		// A negative QPS disables the client-side rate limiter of client-go
+		setupLog.Info("client-side throttling of the API requests is disabled")
+		config.QPS = -1
+	case kubeAPIQPS <= 0 || kubeAPIBurst <= 0:
+		setupLog.Error(nil, "--kube-api-qps and --kube-api-burst must be positive", "qps", kubeAPIQPS, "burst", kubeAPIBurst)
+		os.Exit(1)
+	default:
+		config.QPS = float32(kubeAPIQPS)
+		config.Burst = kubeAPIBurst
 	}
+	customClient.SetCallTimeout(kubeAPICallTimeout)
+
 	if err := ctrlmgr.AddToScheme(scheme); err != nil {
 		exitOnError(err, "unable to add spiffev1alpha1 scheme")
 	}
 
-	if err := routev1.AddToScheme(scheme); err != nil {
-		exitOnError(err, "unable to add routev1 scheme")
+	// MicroShift, other distributions and plain Kubernetes clusters such as kind do not serve all
+	// the OpenShift APIs. The integrations with the missing ones are skipped, so the same operator
+	// runs there.
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	exitOnError(err, "unable to create discovery client")
+	capabilities, err := utils.DetectCapabilities(discoveryClient)
+	exitOnError(err, "unable to detect cluster capabilities")
+	if missing := capabilities.Missing(); len(missing) > 0 {
+		setupLog.Info("optional APIs not served by the cluster, their integrations are disabled", "capabilities", missing)
 	}
+	utils.SetCapabilities(capabilities)
 
-	// Add OperatorCondition scheme for OLM integration
-	if err := operatorv1.AddToScheme(scheme); err != nil {
-		exitOnError(err, "unable to add operatorv1 scheme")
+	// Only the types of the optional APIs served by the cluster are registered
+	if err := addCapabilitySchemes(scheme, capabilities); err != nil {
+		exitOnError(err, "unable to add the schemes of the optional APIs")
 	}
 
 	// Create unified cache builder to prevent race conditions between manager and reconciler caches
@@ -219,6 +391,9 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "24a59323.operator.openshift.io",
+		LeaseDuration:          &leaseDuration,
+		RenewDeadline:          &renewDeadline,
+		RetryPeriod:            &retryPeriod,
 		NewCache:               cacheBuilder,
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
@@ -234,6 +409,30 @@ func main() {
 	})
 	exitOnError(err, "unable to start manager")
 
+	if enableConfigExport {
+		// The operand ConfigMaps are labelled as managed by the operator, so they are served from the cache
+		setupLog.Info("enabling the effective configuration endpoint on the metrics server")
+		configClient, err := customClient.NewCustomClient(mgr)
+		exitOnError(err, "unable to set up the effective configuration client")
+		if err = mgr.AddMetricsServerExtraHandler(effectiveconfig.Path, effectiveconfig.Handler(configClient)); err != nil {
+			exitOnError(err, "unable to set up the effective configuration endpoint")
+		}
+	}
+
+	for _, source := range []*servingcert.Source{metricsCertSource, webhookCertSource} {
+		if source == nil {
+			continue
+		}
+		if err = source.SetupWithManager(mgr); err != nil {
+			exitOnError(err, "unable to set up serving certificate")
+		}
+	}
+
+	// Secrets and ConfigMaps referenced by the operand CRs are not labelled as managed by the
+	// operator, so they are watched through a dedicated cache
+	dependencyCache, err := dependencies.NewCache(mgr)
+	exitOnError(err, "unable to set up dependency cache")
+
 	ztwimControllerManager, err := ztwimController.New(mgr)
 	exitOnError(err, "unable to set up ztwim controller manager")
 	if err = ztwimControllerManager.SetupWithManager(mgr); err != nil {
@@ -242,7 +441,7 @@ func main() {
 
 	spireServerControllerManager, err := spireServerController.New(mgr)
 	exitOnError(err, "unable to set up spire server controller manager")
-	if err = spireServerControllerManager.SetupWithManager(mgr); err != nil {
+	if err = spireServerControllerManager.SetupWithManager(mgr, dependencyCache); err != nil {
 		exitOnError(err, "unable to setup spire server controller manager")
 	}
 
@@ -250,7 +449,7 @@ func main() {
 	if err != nil {
 		exitOnError(err, "unable to set up spire agent controller manager")
 	}
-	if err = spireAgentControllerManager.SetupWithManager(mgr); err != nil {
+	if err = spireAgentControllerManager.SetupWithManager(mgr, dependencyCache); err != nil {
 		exitOnError(err, "unable to setup spire agent controller manager")
 	}
 
@@ -258,7 +457,7 @@ func main() {
 	if err != nil {
 		exitOnError(err, "unable to set up spiffe csi driver controller manager")
 	}
-	if err = spiffeCsiDriverControllerManager.SetupWithManager(mgr); err != nil {
+	if err = spiffeCsiDriverControllerManager.SetupWithManager(mgr, dependencyCache); err != nil {
 		exitOnError(err, "unable to setup spiffe csi driver controller manager")
 	}
 
@@ -266,10 +465,67 @@ func main() {
 	if err != nil {
 		exitOnError(err, "unable to set up spire OIDC discovery provider controller manager")
 	}
-	if err = spireOIDCDiscoveryProviderControllerManager.SetupWithManager(mgr); err != nil {
+	if err = spireOIDCDiscoveryProviderControllerManager.SetupWithManager(mgr, dependencyCache); err != nil {
 		exitOnError(err, "unable to setup spire OIDC discovery provider controller manager")
 	}
 
+	mintSVIDRequestControllerManager, err := mintSVIDRequestController.New(mgr)
+	if err != nil {
+		exitOnError(err, "unable to set up MintSVIDRequest controller manager")
+	}
+	if err = mintSVIDRequestControllerManager.SetupWithManager(mgr); err != nil {
+		exitOnError(err, "unable to setup MintSVIDRequest controller manager")
+	}
+
+	spiffeHelperControllerManager, err := spiffeHelperController.New(mgr)
+	if err != nil {
+		exitOnError(err, "unable to set up spiffe-helper controller manager")
+	}
+	if err = spiffeHelperControllerManager.SetupWithManager(mgr); err != nil {
+		exitOnError(err, "unable to setup spiffe-helper controller manager")
+	}
+
+	spiffeIDPolicyControllerManager, err := spiffeIDPolicyController.New(mgr)
+	if err != nil {
+		exitOnError(err, "unable to set up SPIFFEIDPolicy controller manager")
+	}
+	if err = spiffeIDPolicyControllerManager.SetupWithManager(mgr); err != nil {
+		exitOnError(err, "unable to setup SPIFFEIDPolicy controller manager")
+	}
+
+	// The webhook serving certificate is issued by the service CA or provisioned by OLM; skip the
+	// webhooks when it is neither, e.g. when running the operator locally, as the webhook server
+	// cannot start
+	webhookCertDir := filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs")
+	if _, err := os.Stat(filepath.Join(webhookCertDir, webhookCertFileName)); err == nil || webhookCertSource != nil {
+		if err = ztwimWebhook.SetupConversionWebhookWithManager(mgr); err != nil {
+			exitOnError(err, "unable to set up conversion webhook")
+		}
+		if err = ztwimWebhook.SetupSpireServerWebhookWithManager(mgr); err != nil {
+			exitOnError(err, "unable to set up spire server webhook")
+		}
+		if err = ztwimWebhook.SetupClusterSPIFFEIDWebhookWithManager(mgr); err != nil {
+			exitOnError(err, "unable to set up ClusterSPIFFEID webhook")
+		}
+		if err = ztwimWebhook.SetupClusterStaticEntryWebhookWithManager(mgr); err != nil {
+			exitOnError(err, "unable to set up ClusterStaticEntry webhook")
+		}
+		if err = ztwimWebhook.SetupSpiffeHelperWebhookWithManager(mgr); err != nil {
+			exitOnError(err, "unable to set up spiffe-helper injection webhook")
+		}
+	} else {
+		setupLog.Info("webhook serving certificate not found, the conversion of v1alpha1 resources, SpireServer deletion protection, ClusterSPIFFEID and ClusterStaticEntry validation and spiffe-helper injection are disabled", "certDir", webhookCertDir)
+	}
+
+	// Create the CRs supplied at install time, if any
+	initialConfig, err := initialconfig.New(mgr)
+	exitOnError(err, "unable to load initial configuration")
+	if initialConfig != nil {
+		if err = mgr.Add(initialConfig); err != nil {
+			exitOnError(err, "unable to set up initial configuration")
+		}
+	}
+
 	if err = mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		exitOnError(err, "unable to set up health check")
 	}
@@ -284,6 +540,42 @@ func main() {
 	exitOnError(err, "problem running manager")
 }
 
+// pprofHandlers returns the runtime diagnostics handlers served alongside /metrics.
+// They are registered as extra handlers of the metrics server so that the
+// FilterProvider applies the same authn/authz checks used for metrics.
+func pprofHandlers() map[string]http.Handler {
+	return map[string]http.Handler{
+		"/debug/pprof/":        http.HandlerFunc(pprof.Index),
+		"/debug/pprof/cmdline": http.HandlerFunc(pprof.Cmdline),
+		"/debug/pprof/profile": http.HandlerFunc(pprof.Profile),
+		"/debug/pprof/symbol":  http.HandlerFunc(pprof.Symbol),
+		"/debug/pprof/trace":   http.HandlerFunc(pprof.Trace),
+		"/debug/vars":          expvar.Handler(),
+	}
+}
+
+// capabilitySchemes registers the types of the optional OpenShift APIs
+var capabilitySchemes = map[utils.Capability]func(*runtime.Scheme) error{
+	utils.CapabilitySecurityContextConstraints: securityv1.AddToScheme,
+	utils.CapabilityRoute:                      routev1.AddToScheme,
+	utils.CapabilityInfrastructure:             configv1.AddToScheme,
+	utils.CapabilityOperatorCondition:          operatorv1.AddToScheme,
+	utils.CapabilityImageDigestMirrorSet:       configv1.AddToScheme,
+}
+
+// addCapabilitySchemes registers the types of the optional APIs served by the cluster
+func addCapabilitySchemes(scheme *runtime.Scheme, capabilities utils.Capabilities) error {
+	for capability, addToScheme := range capabilitySchemes {
+		if !capabilities[capability] {
+			continue
+		}
+		if err := addToScheme(scheme); err != nil {
+			return fmt.Errorf("failed to add the %s scheme: %w", capability, err)
+		}
+	}
+	return nil
+}
+
 func exitOnError(err error, logMessage string) {
 	if err != nil {
 		setupLog.Error(err, logMessage)
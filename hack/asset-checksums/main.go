@@ -0,0 +1,80 @@
+// asset-checksums records the SHA256 checksums of the static assets embedded by go-bindata, which
+// the operator verifies its embedded assets against when it starts.
+//
+// Usage: go run ./hack/asset-checksums <bindata directory> <output file>
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+func main() {
+	if len(os.Args) != 3 {
+		fmt.Fprintln(os.Stderr, "usage: asset-checksums <bindata directory> <output file>")
+		os.Exit(2)
+	}
+	if err := generate(os.Args[1], os.Args[2]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func generate(dir, output string) error {
+	checksums := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		checksums[filepath.ToSlash(name)] = hex.EncodeToString(sum[:])
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read the assets of %s: %w", dir, err)
+	}
+
+	names := make([]string, 0, len(checksums))
+	for name := range checksums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// The checksum of the manifest set is the checksum of the sorted "<checksum>  <name>" lines
+	var manifest strings.Builder
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by hack/asset-checksums. DO NOT EDIT.\n\n")
+	buf.WriteString("package assets\n\n")
+	buf.WriteString("// assetChecksums are the SHA256 checksums of the assets, recorded when they were embedded\n")
+	buf.WriteString("var assetChecksums = map[string]string{\n")
+	for _, name := range names {
+		fmt.Fprintf(&manifest, "%s  %s\n", checksums[name], name)
+		fmt.Fprintf(&buf, "\t%q: %q,\n", name, checksums[name])
+	}
+	buf.WriteString("}\n\n")
+	sum := sha256.Sum256([]byte(manifest.String()))
+	buf.WriteString("// ManifestChecksum is the checksum of the set of assets, which identifies its version\n")
+	fmt.Fprintf(&buf, "const ManifestChecksum = %q\n", hex.EncodeToString(sum[:]))
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format %s: %w", output, err)
+	}
+	return os.WriteFile(output, source, 0o644)
+}
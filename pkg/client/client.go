@@ -10,7 +10,9 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/retry"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
@@ -22,12 +24,15 @@ import (
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	storagev1 "k8s.io/api/storage/v1"
 
+	configv1 "github.com/openshift/api/config/v1"
 	routev1 "github.com/openshift/api/route/v1"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 )
 
@@ -35,29 +40,43 @@ var (
 	// cacheResources is the list of resources that the controller watches,
 	// and creates informers for.
 	cacheResources = []client.Object{
-		&rbacv1.Role{},
-		&rbacv1.RoleBinding{},
 		&rbacv1.ClusterRole{},
 		&rbacv1.ClusterRoleBinding{},
 		&storagev1.CSIDriver{},
+		&spiffev1alpha1.ClusterSPIFFEID{},
+	}
+
+	// operatorNamespaceCacheResources are the namespaced resources of cacheResources, only cached
+	// in the operator namespace which the operator holds the permissions on them in
+	operatorNamespaceCacheResources = []client.Object{
+		&rbacv1.Role{},
+		&rbacv1.RoleBinding{},
 		&corev1.ServiceAccount{},
 		&corev1.Service{},
 		&corev1.ConfigMap{},
 		&appsv1.Deployment{},
 		&appsv1.DaemonSet{},
 		&appsv1.StatefulSet{},
-		&admissionregistrationv1.ValidatingWebhookConfiguration{},
+		&batchv1.CronJob{},
+		&batchv1.Job{},
+		&policyv1.PodDisruptionBudget{},
 		&routev1.Route{},
-		&spiffev1alpha1.ClusterSPIFFEID{},
 	}
 
 	cacheResourceWithoutReqSelectors = []client.Object{
-		&v1alpha1.ZeroTrustWorkloadIdentityManager{},
-		&v1alpha1.SpireAgent{},
-		&v1alpha1.SpiffeCSIDriver{},
-		&v1alpha1.SpireServer{},
-		&v1alpha1.SpireOIDCDiscoveryProvider{},
+		&v1alpha2.ZeroTrustWorkloadIdentityManager{},
+		&v1alpha2.SpireAgent{},
+		&v1alpha2.SpiffeCSIDriver{},
+		&v1alpha2.SpireServer{},
+		&v1alpha2.SpireOIDCDiscoveryProvider{},
+		&v1alpha2.MintSVIDRequest{},
+		&v1alpha2.SpiffeHelperConfig{},
+		&v1alpha2.SPIFFEIDPolicy{},
 		&operatorv1.OperatorCondition{},
+		&configv1.Infrastructure{},
+		// Webhook configurations labelled by users for CA bundle injection are not managed by the
+		// operator, so they are cached regardless of the managed-by label
+		&admissionregistrationv1.ValidatingWebhookConfiguration{},
 	}
 
 	informerResources = []client.Object{
@@ -72,18 +91,39 @@ var (
 		&appsv1.Deployment{},
 		&appsv1.DaemonSet{},
 		&appsv1.StatefulSet{},
+		&batchv1.CronJob{},
+		&batchv1.Job{},
+		&policyv1.PodDisruptionBudget{},
 		&admissionregistrationv1.ValidatingWebhookConfiguration{},
-		&v1alpha1.ZeroTrustWorkloadIdentityManager{},
-		&v1alpha1.SpireAgent{},
-		&v1alpha1.SpiffeCSIDriver{},
-		&v1alpha1.SpireServer{},
-		&v1alpha1.SpireOIDCDiscoveryProvider{},
+		&v1alpha2.ZeroTrustWorkloadIdentityManager{},
+		&v1alpha2.SpireAgent{},
+		&v1alpha2.SpiffeCSIDriver{},
+		&v1alpha2.SpireServer{},
+		&v1alpha2.SpireOIDCDiscoveryProvider{},
+		&v1alpha2.MintSVIDRequest{},
+		&v1alpha2.SpiffeHelperConfig{},
+		&v1alpha2.SPIFFEIDPolicy{},
 		&routev1.Route{},
 		&spiffev1alpha1.ClusterSPIFFEID{},
 		&operatorv1.OperatorCondition{},
+		&configv1.Infrastructure{},
 	}
 )
 
+// isServed reports whether the cluster serves the resource. Resources of the optional OpenShift
+// APIs are left out of the cache on clusters without them, e.g. MicroShift.
+func isServed(obj client.Object) bool {
+	switch obj.(type) {
+	case *routev1.Route:
+		return utils.HasCapability(utils.CapabilityRoute)
+	case *operatorv1.OperatorCondition:
+		return utils.HasCapability(utils.CapabilityOperatorCondition)
+	case *configv1.Infrastructure:
+		return utils.HasCapability(utils.CapabilityInfrastructure)
+	}
+	return true
+}
+
 type customCtrlClientImpl struct {
 	client.Client
 	apiReader client.Reader
@@ -93,7 +133,9 @@ type customCtrlClientImpl struct {
 //counterfeiter:generate -o fakes . CustomCtrlClient
 type CustomCtrlClient interface {
 	Get(context.Context, client.ObjectKey, client.Object) error
+	GetUncached(context.Context, client.ObjectKey, client.Object) error
 	List(context.Context, client.ObjectList, ...client.ListOption) error
+	ListUncached(context.Context, client.ObjectList, ...client.ListOption) error
 	StatusUpdate(context.Context, client.Object, ...client.SubResourceUpdateOption) error
 	Update(context.Context, client.Object, ...client.UpdateOption) error
 	UpdateWithRetry(context.Context, client.Object, ...client.UpdateOption) error
@@ -120,31 +162,150 @@ func NewCustomClient(m manager.Manager) (CustomCtrlClient, error) {
 func (c *customCtrlClientImpl) Get(
 	ctx context.Context, key client.ObjectKey, obj client.Object,
 ) error {
-	return c.Client.Get(ctx, key, obj)
+	return observe(ctx, "get", sourceCache, obj, func(ctx context.Context) error {
+		return c.Client.Get(ctx, key, obj)
+	})
+}
+
+// GetUncached reads the object directly from the API server, bypassing the label-filtered
+// cache. It is used for resources that are not (yet) labelled as managed by the operator.
+func (c *customCtrlClientImpl) GetUncached(
+	ctx context.Context, key client.ObjectKey, obj client.Object,
+) error {
+	return observe(ctx, "get", sourceAPI, obj, func(ctx context.Context) error {
+		return c.apiReader.Get(ctx, key, obj)
+	})
 }
 
 func (c *customCtrlClientImpl) List(
 	ctx context.Context, list client.ObjectList, opts ...client.ListOption,
 ) error {
-	return c.Client.List(ctx, list, opts...)
+	return observe(ctx, "list", sourceCache, list, func(ctx context.Context) error {
+		return c.Client.List(ctx, list, opts...)
+	})
+}
+
+// ListUncached lists the objects directly from the API server, bypassing the label-filtered
+// cache. It is used for cluster resources the operator does not manage, e.g. Nodes.
+func (c *customCtrlClientImpl) ListUncached(
+	ctx context.Context, list client.ObjectList, opts ...client.ListOption,
+) error {
+	return observe(ctx, "list", sourceAPI, list, func(ctx context.Context) error {
+		return c.apiReader.List(ctx, list, opts...)
+	})
 }
 
 func (c *customCtrlClientImpl) Create(
 	ctx context.Context, obj client.Object, opts ...client.CreateOption,
 ) error {
-	return c.Client.Create(ctx, obj, opts...)
+	err := observe(ctx, "create", sourceAPI, obj, func(ctx context.Context) error {
+		return c.Client.Create(ctx, obj, opts...)
+	})
+	if err != nil && errors.IsAlreadyExists(err) && adoptExistingRequested(opts) {
+		return c.adoptExisting(ctx, obj, err)
+	}
+	return err
+}
+
+// AdoptExisting is a CreateOption that, when true, makes Create take over an object that
+// already exists without a controller owner instead of failing with AlreadyExists.
+// The existing object is replaced by the desired one, including its owner references. Workloads
+// whose immutable fields differ from the desired ones are not adopted, the returned error is an
+// invalid configuration error asking to delete them.
+type AdoptExisting bool
+
+// ApplyToCreate implements client.CreateOption; the option is handled by the custom client.
+func (AdoptExisting) ApplyToCreate(*client.CreateOptions) {}
+
+func adoptExistingRequested(opts []client.CreateOption) bool {
+	for _, opt := range opts {
+		if adopt, ok := opt.(AdoptExisting); ok && bool(adopt) {
+			return true
+		}
+	}
+	return false
+}
+
+// adoptExisting updates the pre-existing object to the desired state when it has no controller
+// owner. The existing object is read from the API server since unmanaged objects are not cached.
+func (c *customCtrlClientImpl) adoptExisting(ctx context.Context, obj client.Object, createErr error) error {
+	key := types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}
+	existing := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(client.Object)
+	if err := c.GetUncached(ctx, key, existing); err != nil {
+		return fmt.Errorf("failed to fetch existing %q for adoption: %w", key, err)
+	}
+	if owner := metav1.GetControllerOf(existing); owner != nil {
+		return fmt.Errorf("%w: controlled by %s %q, not adopting", createErr, owner.Kind, owner.Name)
+	}
+	if field := immutableFieldConflict(existing, obj); field != "" {
+		return utils.NewInvalidConfigurationError(
+			fmt.Errorf("%s of the existing object differs from the desired one and cannot be updated", field),
+			"cannot adopt existing %s %q, delete it to let the operator recreate it", kindOf(obj), key)
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	if err := c.Update(ctx, obj); err != nil {
+		return fmt.Errorf("failed to adopt existing %q: %w", key, err)
+	}
+	return nil
+}
+
+// immutableFieldConflict returns the immutable field of a workload that differs between the
+// existing object and the desired one, which the API server would reject the adoption for. It
+// returns an empty string when the existing object can be updated to the desired one.
+func immutableFieldConflict(existing, desired client.Object) string {
+	switch d := desired.(type) {
+	case *appsv1.StatefulSet:
+		e := existing.(*appsv1.StatefulSet)
+		if !equality.Semantic.DeepEqual(e.Spec.Selector, d.Spec.Selector) {
+			return "spec.selector"
+		}
+		if !volumeClaimTemplatesEqual(e.Spec.VolumeClaimTemplates, d.Spec.VolumeClaimTemplates) {
+			return "spec.volumeClaimTemplates"
+		}
+	case *appsv1.DaemonSet:
+		if !equality.Semantic.DeepEqual(existing.(*appsv1.DaemonSet).Spec.Selector, d.Spec.Selector) {
+			return "spec.selector"
+		}
+	case *appsv1.Deployment:
+		if !equality.Semantic.DeepEqual(existing.(*appsv1.Deployment).Spec.Selector, d.Spec.Selector) {
+			return "spec.selector"
+		}
+	}
+	return ""
+}
+
+// volumeClaimTemplatesEqual compares the volume claim template fields set by the operator. The
+// fields defaulted by the API server, e.g. the volume mode, are left out.
+func volumeClaimTemplatesEqual(existing, desired []corev1.PersistentVolumeClaim) bool {
+	if len(existing) != len(desired) {
+		return false
+	}
+	for i := range desired {
+		e, d := existing[i], desired[i]
+		if e.Name != d.Name ||
+			!equality.Semantic.DeepEqual(e.Spec.AccessModes, d.Spec.AccessModes) ||
+			!equality.Semantic.DeepEqual(e.Spec.Resources.Requests, d.Spec.Resources.Requests) ||
+			(d.Spec.StorageClassName != nil && !equality.Semantic.DeepEqual(e.Spec.StorageClassName, d.Spec.StorageClassName)) {
+			return false
+		}
+	}
+	return true
 }
 
 func (c *customCtrlClientImpl) Delete(
 	ctx context.Context, obj client.Object, opts ...client.DeleteOption,
 ) error {
-	return c.Client.Delete(ctx, obj, opts...)
+	return observe(ctx, "delete", sourceAPI, obj, func(ctx context.Context) error {
+		return c.Client.Delete(ctx, obj, opts...)
+	})
 }
 
 func (c *customCtrlClientImpl) Update(
 	ctx context.Context, obj client.Object, opts ...client.UpdateOption,
 ) error {
-	return c.Client.Update(ctx, obj, opts...)
+	return observe(ctx, "update", sourceAPI, obj, func(ctx context.Context) error {
+		return c.Client.Update(ctx, obj, opts...)
+	})
 }
 
 func (c *customCtrlClientImpl) UpdateWithRetry(
@@ -153,11 +314,11 @@ func (c *customCtrlClientImpl) UpdateWithRetry(
 	key := types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}
 	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		current := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(client.Object)
-		if err := c.Client.Get(ctx, key, current); err != nil {
+		if err := c.Get(ctx, key, current); err != nil {
 			return fmt.Errorf("failed to fetch latest %q for update: %w", key, err)
 		}
 		obj.SetResourceVersion(current.GetResourceVersion())
-		if err := c.Client.Update(ctx, obj, opts...); err != nil {
+		if err := c.Update(ctx, obj, opts...); err != nil {
 			return fmt.Errorf("failed to update %q resource: %w", key, err)
 		}
 		return nil
@@ -174,11 +335,11 @@ func (c *customCtrlClientImpl) StatusUpdateWithRetry(
 	key := types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}
 	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		current := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(client.Object)
-		if err := c.Client.Get(ctx, key, current); err != nil {
+		if err := c.Get(ctx, key, current); err != nil {
 			return fmt.Errorf("failed to fetch latest %q for update: %w", key, err)
 		}
 		obj.SetResourceVersion(current.GetResourceVersion())
-		if err := c.Client.Status().Update(ctx, obj, opts...); err != nil {
+		if err := c.StatusUpdate(ctx, obj, opts...); err != nil {
 			return fmt.Errorf("failed to update %q resource: %w", key, err)
 		}
 		return nil
@@ -191,17 +352,21 @@ func (c *customCtrlClientImpl) StatusUpdateWithRetry(
 func (c *customCtrlClientImpl) StatusUpdate(
 	ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption,
 ) error {
-	return c.Client.Status().Update(ctx, obj, opts...)
+	return observe(ctx, "update_status", sourceAPI, obj, func(ctx context.Context) error {
+		return c.Client.Status().Update(ctx, obj, opts...)
+	})
 }
 
 func (c *customCtrlClientImpl) Patch(
 	ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption,
 ) error {
-	return c.Client.Patch(ctx, obj, patch, opts...)
+	return observe(ctx, "patch", sourceAPI, obj, func(ctx context.Context) error {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	})
 }
 
 func (c *customCtrlClientImpl) Exists(ctx context.Context, key client.ObjectKey, obj client.Object) (bool, error) {
-	if err := c.Client.Get(ctx, key, obj); err != nil {
+	if err := c.Get(ctx, key, obj); err != nil {
 		if errors.IsNotFound(err) {
 			return false, nil
 		}
@@ -238,11 +403,26 @@ func NewCacheBuilder() (cache.NewCacheFunc, error) {
 		// Configure cache with custom label selectors
 		customCacheObjects := map[client.Object]cache.ByObject{}
 		for _, resource := range cacheResources {
+			if !isServed(resource) {
+				continue
+			}
 			customCacheObjects[resource] = cache.ByObject{
 				Label: managedResourceLabelReqSelector,
 			}
 		}
+		for _, resource := range operatorNamespaceCacheResources {
+			if !isServed(resource) {
+				continue
+			}
+			customCacheObjects[resource] = cache.ByObject{
+				Label:      managedResourceLabelReqSelector,
+				Namespaces: map[string]cache.Config{utils.GetOperatorNamespace(): {}},
+			}
+		}
 		for _, resource := range cacheResourceWithoutReqSelectors {
+			if !isServed(resource) {
+				continue
+			}
 			customCacheObjects[resource] = cache.ByObject{}
 		}
 
@@ -265,6 +445,9 @@ func NewCacheBuilder() (cache.NewCacheFunc, error) {
 
 		// Pre-register informers for all resources
 		for _, resource := range informerResources {
+			if !isServed(resource) {
+				continue
+			}
 			if _, err := newCache.GetInformer(context.Background(), resource); err != nil {
 				return nil, err
 			}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// adoptionTestClient serves the existing object of an adoption and records the updates
+type adoptionTestClient struct {
+	client.Client
+	existing client.Object
+	updates  []client.Object
+}
+
+func (c *adoptionTestClient) Create(context.Context, client.Object, ...client.CreateOption) error {
+	return errors.NewAlreadyExists(schema.GroupResource{}, c.existing.GetName())
+}
+
+func (c *adoptionTestClient) Get(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	switch o := obj.(type) {
+	case *appsv1.StatefulSet:
+		*o = *c.existing.(*appsv1.StatefulSet).DeepCopy()
+	case *appsv1.DaemonSet:
+		*o = *c.existing.(*appsv1.DaemonSet).DeepCopy()
+	}
+	return nil
+}
+
+func (c *adoptionTestClient) Update(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	c.updates = append(c.updates, obj)
+	return nil
+}
+
+func testStatefulSet(selector map[string]string, storage string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "spire-server", Namespace: "ns", ResourceVersion: "7"},
+		Spec: appsv1.StatefulSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{
+				ObjectMeta: metav1.ObjectMeta{Name: "spire-data"},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					Resources: corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse(storage),
+					}},
+				},
+			}},
+		},
+	}
+}
+
+// TestCreateAdoptExisting tests that existing workloads are only adopted when their immutable
+// fields match the desired ones
+func TestCreateAdoptExisting(t *testing.T) {
+	operatorSelector := map[string]string{"app.kubernetes.io/name": "spire-server", "app.kubernetes.io/instance": "cluster-zero-trust-workload-identity-manager"}
+	helmSelector := map[string]string{"app.kubernetes.io/name": "server", "app.kubernetes.io/instance": "spire"}
+
+	defaulted := testStatefulSet(operatorSelector, "1024Mi")
+	defaulted.Spec.VolumeClaimTemplates[0].Spec.VolumeMode = ptr.To(corev1.PersistentVolumeFilesystem)
+
+	tests := []struct {
+		name        string
+		existing    client.Object
+		desired     client.Object
+		expectField string
+	}{
+		{
+			name:     "matching StatefulSet is adopted",
+			existing: defaulted,
+			desired:  testStatefulSet(operatorSelector, "1Gi"),
+		},
+		{
+			name:        "StatefulSet with another selector",
+			existing:    testStatefulSet(helmSelector, "1Gi"),
+			desired:     testStatefulSet(operatorSelector, "1Gi"),
+			expectField: "spec.selector",
+		},
+		{
+			name:        "StatefulSet with another volume size",
+			existing:    testStatefulSet(operatorSelector, "5Gi"),
+			desired:     testStatefulSet(operatorSelector, "1Gi"),
+			expectField: "spec.volumeClaimTemplates",
+		},
+		{
+			name: "DaemonSet with another selector",
+			existing: &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "spire-agent", Namespace: "ns"},
+				Spec:       appsv1.DaemonSetSpec{Selector: &metav1.LabelSelector{MatchLabels: helmSelector}},
+			},
+			desired: &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "spire-agent", Namespace: "ns"},
+				Spec:       appsv1.DaemonSetSpec{Selector: &metav1.LabelSelector{MatchLabels: operatorSelector}},
+			},
+			expectField: "spec.selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClient := &adoptionTestClient{existing: tt.existing}
+			c := &customCtrlClientImpl{Client: testClient, apiReader: testClient}
+
+			err := c.Create(context.Background(), tt.desired, AdoptExisting(true))
+			if tt.expectField == "" {
+				if err != nil {
+					t.Fatalf("Expected the existing object to be adopted, got: %v", err)
+				}
+				if len(testClient.updates) != 1 || testClient.updates[0].GetResourceVersion() != "7" {
+					t.Errorf("Expected the existing object to be updated, got %v", testClient.updates)
+				}
+				return
+			}
+
+			if !utils.IsInvalidConfigurationError(err) {
+				t.Fatalf("Expected an invalid configuration error, got: %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.expectField) {
+				t.Errorf("Expected the error to name %s, got: %v", tt.expectField, err)
+			}
+			if len(testClient.updates) != 0 {
+				t.Errorf("Expected no update of the existing object, got %d", len(testClient.updates))
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DryRunClient is a CustomCtrlClient that serves reads from the wrapped client but records
+// writes instead of applying them, so the changes a reconcile would make can be reported.
+// Status updates are passed through, since they are used to publish the dry-run result.
+type DryRunClient struct {
+	CustomCtrlClient
+	changes []string
+}
+
+// NewDryRunClient returns a DryRunClient wrapping the given client
+func NewDryRunClient(c CustomCtrlClient) *DryRunClient {
+	return &DryRunClient{CustomCtrlClient: c}
+}
+
+// Changes returns the recorded writes in the order they were made,
+// e.g. "create ConfigMap zero-trust-workload-identity-manager/spire-agent"
+func (c *DryRunClient) Changes() []string {
+	return c.changes
+}
+
+func (c *DryRunClient) record(verb string, obj client.Object) {
+	c.changes = append(c.changes, describeChange(verb, obj))
+}
+
+// describeChange formats a write as "<verb> <Kind> [<namespace>/]<name>"
+func describeChange(verb string, obj client.Object) string {
+	return verb + " " + describeObject(obj)
+}
+
+// describeObject formats an object as "<Kind> [<namespace>/]<name>"
+func describeObject(obj client.Object) string {
+	name := obj.GetName()
+	if obj.GetNamespace() != "" {
+		name = obj.GetNamespace() + "/" + name
+	}
+	return fmt.Sprintf("%s %s", reflect.TypeOf(obj).Elem().Name(), name)
+}
+
+func (c *DryRunClient) Create(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+	c.record("create", obj)
+	return nil
+}
+
+func (c *DryRunClient) Update(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	c.record("update", obj)
+	return nil
+}
+
+func (c *DryRunClient) UpdateWithRetry(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	c.record("update", obj)
+	return nil
+}
+
+func (c *DryRunClient) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+	c.record("patch", obj)
+	return nil
+}
+
+func (c *DryRunClient) Delete(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+	c.record("delete", obj)
+	return nil
+}
+
+func (c *DryRunClient) CreateOrUpdateObject(_ context.Context, obj client.Object) error {
+	c.record("create or update", obj)
+	return nil
+}
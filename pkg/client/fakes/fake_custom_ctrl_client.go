@@ -76,6 +76,29 @@ type FakeCustomCtrlClient struct {
 	getReturnsOnCall map[int]struct {
 		result1 error
 	}
+	GetClientStub        func() clienta.Client
+	getClientMutex       sync.RWMutex
+	getClientArgsForCall []struct {
+	}
+	getClientReturns struct {
+		result1 clienta.Client
+	}
+	getClientReturnsOnCall map[int]struct {
+		result1 clienta.Client
+	}
+	GetUncachedStub        func(context.Context, clienta.ObjectKey, clienta.Object) error
+	getUncachedMutex       sync.RWMutex
+	getUncachedArgsForCall []struct {
+		arg1 context.Context
+		arg2 clienta.ObjectKey
+		arg3 clienta.Object
+	}
+	getUncachedReturns struct {
+		result1 error
+	}
+	getUncachedReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ListStub        func(context.Context, clienta.ObjectList, ...clienta.ListOption) error
 	listMutex       sync.RWMutex
 	listArgsForCall []struct {
@@ -89,6 +112,19 @@ type FakeCustomCtrlClient struct {
 	listReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ListUncachedStub        func(context.Context, clienta.ObjectList, ...clienta.ListOption) error
+	listUncachedMutex       sync.RWMutex
+	listUncachedArgsForCall []struct {
+		arg1 context.Context
+		arg2 clienta.ObjectList
+		arg3 []clienta.ListOption
+	}
+	listUncachedReturns struct {
+		result1 error
+	}
+	listUncachedReturnsOnCall map[int]struct {
+		result1 error
+	}
 	PatchStub        func(context.Context, clienta.Object, clienta.Patch, ...clienta.PatchOption) error
 	patchMutex       sync.RWMutex
 	patchArgsForCall []struct {
@@ -155,16 +191,6 @@ type FakeCustomCtrlClient struct {
 	updateWithRetryReturnsOnCall map[int]struct {
 		result1 error
 	}
-	GetClientStub        func() clienta.Client
-	getClientMutex       sync.RWMutex
-	getClientArgsForCall []struct {
-	}
-	getClientReturns struct {
-		result1 clienta.Client
-	}
-	getClientReturnsOnCall map[int]struct {
-		result1 clienta.Client
-	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -486,6 +512,122 @@ func (fake *FakeCustomCtrlClient) GetReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeCustomCtrlClient) GetClient() clienta.Client {
+	fake.getClientMutex.Lock()
+	ret, specificReturn := fake.getClientReturnsOnCall[len(fake.getClientArgsForCall)]
+	fake.getClientArgsForCall = append(fake.getClientArgsForCall, struct {
+	}{})
+	stub := fake.GetClientStub
+	fakeReturns := fake.getClientReturns
+	fake.recordInvocation("GetClient", []interface{}{})
+	fake.getClientMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCustomCtrlClient) GetClientCallCount() int {
+	fake.getClientMutex.RLock()
+	defer fake.getClientMutex.RUnlock()
+	return len(fake.getClientArgsForCall)
+}
+
+func (fake *FakeCustomCtrlClient) GetClientCalls(stub func() clienta.Client) {
+	fake.getClientMutex.Lock()
+	defer fake.getClientMutex.Unlock()
+	fake.GetClientStub = stub
+}
+
+func (fake *FakeCustomCtrlClient) GetClientReturns(result1 clienta.Client) {
+	fake.getClientMutex.Lock()
+	defer fake.getClientMutex.Unlock()
+	fake.GetClientStub = nil
+	fake.getClientReturns = struct {
+		result1 clienta.Client
+	}{result1}
+}
+
+func (fake *FakeCustomCtrlClient) GetClientReturnsOnCall(i int, result1 clienta.Client) {
+	fake.getClientMutex.Lock()
+	defer fake.getClientMutex.Unlock()
+	fake.GetClientStub = nil
+	if fake.getClientReturnsOnCall == nil {
+		fake.getClientReturnsOnCall = make(map[int]struct {
+			result1 clienta.Client
+		})
+	}
+	fake.getClientReturnsOnCall[i] = struct {
+		result1 clienta.Client
+	}{result1}
+}
+
+func (fake *FakeCustomCtrlClient) GetUncached(arg1 context.Context, arg2 clienta.ObjectKey, arg3 clienta.Object) error {
+	fake.getUncachedMutex.Lock()
+	ret, specificReturn := fake.getUncachedReturnsOnCall[len(fake.getUncachedArgsForCall)]
+	fake.getUncachedArgsForCall = append(fake.getUncachedArgsForCall, struct {
+		arg1 context.Context
+		arg2 clienta.ObjectKey
+		arg3 clienta.Object
+	}{arg1, arg2, arg3})
+	stub := fake.GetUncachedStub
+	fakeReturns := fake.getUncachedReturns
+	fake.recordInvocation("GetUncached", []interface{}{arg1, arg2, arg3})
+	fake.getUncachedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCustomCtrlClient) GetUncachedCallCount() int {
+	fake.getUncachedMutex.RLock()
+	defer fake.getUncachedMutex.RUnlock()
+	return len(fake.getUncachedArgsForCall)
+}
+
+func (fake *FakeCustomCtrlClient) GetUncachedCalls(stub func(context.Context, clienta.ObjectKey, clienta.Object) error) {
+	fake.getUncachedMutex.Lock()
+	defer fake.getUncachedMutex.Unlock()
+	fake.GetUncachedStub = stub
+}
+
+func (fake *FakeCustomCtrlClient) GetUncachedArgsForCall(i int) (context.Context, clienta.ObjectKey, clienta.Object) {
+	fake.getUncachedMutex.RLock()
+	defer fake.getUncachedMutex.RUnlock()
+	argsForCall := fake.getUncachedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCustomCtrlClient) GetUncachedReturns(result1 error) {
+	fake.getUncachedMutex.Lock()
+	defer fake.getUncachedMutex.Unlock()
+	fake.GetUncachedStub = nil
+	fake.getUncachedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCustomCtrlClient) GetUncachedReturnsOnCall(i int, result1 error) {
+	fake.getUncachedMutex.Lock()
+	defer fake.getUncachedMutex.Unlock()
+	fake.GetUncachedStub = nil
+	if fake.getUncachedReturnsOnCall == nil {
+		fake.getUncachedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.getUncachedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeCustomCtrlClient) List(arg1 context.Context, arg2 clienta.ObjectList, arg3 ...clienta.ListOption) error {
 	fake.listMutex.Lock()
 	ret, specificReturn := fake.listReturnsOnCall[len(fake.listArgsForCall)]
@@ -549,6 +691,69 @@ func (fake *FakeCustomCtrlClient) ListReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeCustomCtrlClient) ListUncached(arg1 context.Context, arg2 clienta.ObjectList, arg3 ...clienta.ListOption) error {
+	fake.listUncachedMutex.Lock()
+	ret, specificReturn := fake.listUncachedReturnsOnCall[len(fake.listUncachedArgsForCall)]
+	fake.listUncachedArgsForCall = append(fake.listUncachedArgsForCall, struct {
+		arg1 context.Context
+		arg2 clienta.ObjectList
+		arg3 []clienta.ListOption
+	}{arg1, arg2, arg3})
+	stub := fake.ListUncachedStub
+	fakeReturns := fake.listUncachedReturns
+	fake.recordInvocation("ListUncached", []interface{}{arg1, arg2, arg3})
+	fake.listUncachedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeCustomCtrlClient) ListUncachedCallCount() int {
+	fake.listUncachedMutex.RLock()
+	defer fake.listUncachedMutex.RUnlock()
+	return len(fake.listUncachedArgsForCall)
+}
+
+func (fake *FakeCustomCtrlClient) ListUncachedCalls(stub func(context.Context, clienta.ObjectList, ...clienta.ListOption) error) {
+	fake.listUncachedMutex.Lock()
+	defer fake.listUncachedMutex.Unlock()
+	fake.ListUncachedStub = stub
+}
+
+func (fake *FakeCustomCtrlClient) ListUncachedArgsForCall(i int) (context.Context, clienta.ObjectList, []clienta.ListOption) {
+	fake.listUncachedMutex.RLock()
+	defer fake.listUncachedMutex.RUnlock()
+	argsForCall := fake.listUncachedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCustomCtrlClient) ListUncachedReturns(result1 error) {
+	fake.listUncachedMutex.Lock()
+	defer fake.listUncachedMutex.Unlock()
+	fake.ListUncachedStub = nil
+	fake.listUncachedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCustomCtrlClient) ListUncachedReturnsOnCall(i int, result1 error) {
+	fake.listUncachedMutex.Lock()
+	defer fake.listUncachedMutex.Unlock()
+	fake.ListUncachedStub = nil
+	if fake.listUncachedReturnsOnCall == nil {
+		fake.listUncachedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.listUncachedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeCustomCtrlClient) Patch(arg1 context.Context, arg2 clienta.Object, arg3 clienta.Patch, arg4 ...clienta.PatchOption) error {
 	fake.patchMutex.Lock()
 	ret, specificReturn := fake.patchReturnsOnCall[len(fake.patchArgsForCall)]
@@ -876,12 +1081,16 @@ func (fake *FakeCustomCtrlClient) Invocations() map[string][][]interface{} {
 	defer fake.deleteMutex.RUnlock()
 	fake.existsMutex.RLock()
 	defer fake.existsMutex.RUnlock()
-	fake.getClientMutex.RLock()
-	defer fake.getClientMutex.RUnlock()
 	fake.getMutex.RLock()
 	defer fake.getMutex.RUnlock()
+	fake.getClientMutex.RLock()
+	defer fake.getClientMutex.RUnlock()
+	fake.getUncachedMutex.RLock()
+	defer fake.getUncachedMutex.RUnlock()
 	fake.listMutex.RLock()
 	defer fake.listMutex.RUnlock()
+	fake.listUncachedMutex.RLock()
+	defer fake.listUncachedMutex.RUnlock()
 	fake.patchMutex.RLock()
 	defer fake.patchMutex.RUnlock()
 	fake.statusUpdateMutex.RLock()
@@ -899,59 +1108,6 @@ func (fake *FakeCustomCtrlClient) Invocations() map[string][][]interface{} {
 	return copiedInvocations
 }
 
-func (fake *FakeCustomCtrlClient) GetClient() clienta.Client {
-	fake.getClientMutex.Lock()
-	ret, specificReturn := fake.getClientReturnsOnCall[len(fake.getClientArgsForCall)]
-	fake.getClientArgsForCall = append(fake.getClientArgsForCall, struct {
-	}{})
-	stub := fake.GetClientStub
-	fakeReturns := fake.getClientReturns
-	fake.recordInvocation("GetClient", []interface{}{})
-	fake.getClientMutex.Unlock()
-	if stub != nil {
-		return stub()
-	}
-	if specificReturn {
-		return ret.result1
-	}
-	return fakeReturns.result1
-}
-
-func (fake *FakeCustomCtrlClient) GetClientCallCount() int {
-	fake.getClientMutex.RLock()
-	defer fake.getClientMutex.RUnlock()
-	return len(fake.getClientArgsForCall)
-}
-
-func (fake *FakeCustomCtrlClient) GetClientCalls(stub func() clienta.Client) {
-	fake.getClientMutex.Lock()
-	defer fake.getClientMutex.Unlock()
-	fake.GetClientStub = stub
-}
-
-func (fake *FakeCustomCtrlClient) GetClientReturns(result1 clienta.Client) {
-	fake.getClientMutex.Lock()
-	defer fake.getClientMutex.Unlock()
-	fake.GetClientStub = nil
-	fake.getClientReturns = struct {
-		result1 clienta.Client
-	}{result1}
-}
-
-func (fake *FakeCustomCtrlClient) GetClientReturnsOnCall(i int, result1 clienta.Client) {
-	fake.getClientMutex.Lock()
-	defer fake.getClientMutex.Unlock()
-	fake.GetClientStub = nil
-	if fake.getClientReturnsOnCall == nil {
-		fake.getClientReturnsOnCall = make(map[int]struct {
-			result1 clienta.Client
-		})
-	}
-	fake.getClientReturnsOnCall[i] = struct {
-		result1 clienta.Client
-	}{result1}
-}
-
 func (fake *FakeCustomCtrlClient) recordInvocation(key string, args []interface{}) {
 	fake.invocationsMutex.Lock()
 	defer fake.invocationsMutex.Unlock()
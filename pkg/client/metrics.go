@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// DefaultCallTimeout is the default deadline of a single call of the client
+const DefaultCallTimeout = 30 * time.Second
+
+const (
+	// sourceCache labels the reads served from the informer cache
+	sourceCache = "cache"
+	// sourceAPI labels the calls reaching the API server
+	sourceAPI = "api"
+)
+
+// callTimeout is the deadline of a single call, none when not positive
+var callTimeout atomic.Int64
+
+func init() {
+	callTimeout.Store(int64(DefaultCallTimeout))
+	metrics.Registry.MustRegister(apiCallDuration)
+}
+
+// SetCallTimeout sets the deadline of every call of the clients, so a call stuck on the API
+// server or on an unsynced cache fails and is retried instead of blocking its reconcile.
+// A deadline of 0 disables it.
+func SetCallTimeout(timeout time.Duration) {
+	callTimeout.Store(int64(timeout))
+}
+
+var apiCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "ztwim_client_call_duration_seconds",
+	Help:    "Duration of the calls of the operator client, by operation, kind, source (cache or api) and result. The count tracks the call rate, e.g. to tune --kube-api-qps and --kube-api-burst.",
+	Buckets: []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+}, []string{"operation", "kind", "source", "result"})
+
+// observe runs the call under the call timeout and records its duration and result
+func observe(ctx context.Context, operation, source string, obj runtime.Object, call func(context.Context) error) error {
+	if timeout := time.Duration(callTimeout.Load()); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	err := call(ctx)
+	apiCallDuration.WithLabelValues(operation, kindOf(obj), source, callResult(err)).Observe(time.Since(start).Seconds())
+	return err
+}
+
+// kindOf returns the kind of the object, from its type when its TypeMeta is not set
+func kindOf(obj runtime.Object) string {
+	if kind := obj.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		return kind
+	}
+	return reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
+}
+
+// callResult classifies the outcome of a call: the errors expected in normal operation, e.g. a
+// missing object, are told apart from the ones retried with backoff and the terminal ones
+func callResult(err error) string {
+	switch {
+	case err == nil:
+		return "success"
+	case apierrors.IsNotFound(err):
+		return "not_found"
+	case apierrors.IsAlreadyExists(err):
+		return "already_exists"
+	case apierrors.IsConflict(err):
+		return "conflict"
+	case apierrors.IsTooManyRequests(err):
+		return "throttled"
+	case errors.Is(err, context.DeadlineExceeded) || apierrors.IsTimeout(err) || apierrors.IsServerTimeout(err):
+		return "timeout"
+	}
+	switch utils.ClassifyError(err) {
+	case utils.IrrecoverableError:
+		return "terminal"
+	case utils.InvalidConfigurationError:
+		return "invalid"
+	default:
+		return "retry"
+	}
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RecordingClient is a CustomCtrlClient that applies writes through the wrapped client and
+// records the ones that succeeded, so the resources changed by a reconcile can be audited.
+// Status updates are not recorded. The resource of the last write that failed is kept as well.
+type RecordingClient struct {
+	CustomCtrlClient
+	changes []string
+	failure string
+}
+
+// NewRecordingClient returns a RecordingClient wrapping the given client
+func NewRecordingClient(c CustomCtrlClient) *RecordingClient {
+	return &RecordingClient{CustomCtrlClient: c}
+}
+
+// Changes returns the applied writes in the order they were made,
+// e.g. "update DaemonSet zero-trust-workload-identity-manager/spire-agent"
+func (c *RecordingClient) Changes() []string {
+	return c.changes
+}
+
+// Failure returns the resource of the last write that failed, e.g.
+// "DaemonSet zero-trust-workload-identity-manager/spire-agent", or an empty string
+func (c *RecordingClient) Failure() string {
+	return c.failure
+}
+
+func (c *RecordingClient) record(verb string, obj client.Object, err error) error {
+	if err == nil {
+		c.changes = append(c.changes, describeChange(verb, obj))
+	} else {
+		c.failure = describeObject(obj)
+	}
+	return err
+}
+
+func (c *RecordingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return c.record("create", obj, c.CustomCtrlClient.Create(ctx, obj, opts...))
+}
+
+func (c *RecordingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.record("update", obj, c.CustomCtrlClient.Update(ctx, obj, opts...))
+}
+
+func (c *RecordingClient) UpdateWithRetry(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.record("update", obj, c.CustomCtrlClient.UpdateWithRetry(ctx, obj, opts...))
+}
+
+func (c *RecordingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.record("patch", obj, c.CustomCtrlClient.Patch(ctx, obj, patch, opts...))
+}
+
+func (c *RecordingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	return c.record("delete", obj, c.CustomCtrlClient.Delete(ctx, obj, opts...))
+}
+
+func (c *RecordingClient) CreateOrUpdateObject(ctx context.Context, obj client.Object) error {
+	return c.record("create or update", obj, c.CustomCtrlClient.CreateOrUpdateObject(ctx, obj))
+}
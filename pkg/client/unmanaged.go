@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// UnmanagedClient is a CustomCtrlClient that serves reads from the wrapped client but skips the
+// writes to objects of unmanaged kinds, so users can take over a generated resource while the
+// operator keeps managing the others. Status updates are passed through.
+type UnmanagedClient struct {
+	CustomCtrlClient
+	kinds   []string
+	skipped []string
+}
+
+// NewUnmanagedClient returns an UnmanagedClient wrapping the given client. kinds are named by
+// their Go type, e.g. "DaemonSet" or "SecurityContextConstraints".
+func NewUnmanagedClient(c CustomCtrlClient, kinds []string) *UnmanagedClient {
+	return &UnmanagedClient{CustomCtrlClient: c, kinds: kinds}
+}
+
+// Skipped returns the skipped writes in the order they were attempted,
+// e.g. "update DaemonSet zero-trust-workload-identity-manager/spire-agent"
+func (c *UnmanagedClient) Skipped() []string {
+	return c.skipped
+}
+
+// skip reports whether obj is of an unmanaged kind, recording the write if so
+func (c *UnmanagedClient) skip(verb string, obj client.Object) bool {
+	if !slices.Contains(c.kinds, reflect.TypeOf(obj).Elem().Name()) {
+		return false
+	}
+	c.skipped = append(c.skipped, describeChange(verb, obj))
+	return true
+}
+
+func (c *UnmanagedClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if c.skip("create", obj) {
+		return nil
+	}
+	return c.CustomCtrlClient.Create(ctx, obj, opts...)
+}
+
+func (c *UnmanagedClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if c.skip("update", obj) {
+		return nil
+	}
+	return c.CustomCtrlClient.Update(ctx, obj, opts...)
+}
+
+func (c *UnmanagedClient) UpdateWithRetry(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if c.skip("update", obj) {
+		return nil
+	}
+	return c.CustomCtrlClient.UpdateWithRetry(ctx, obj, opts...)
+}
+
+func (c *UnmanagedClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if c.skip("patch", obj) {
+		return nil
+	}
+	return c.CustomCtrlClient.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *UnmanagedClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if c.skip("delete", obj) {
+		return nil
+	}
+	return c.CustomCtrlClient.Delete(ctx, obj, opts...)
+}
+
+func (c *UnmanagedClient) CreateOrUpdateObject(ctx context.Context, obj client.Object) error {
+	if c.skip("create or update", obj) {
+		return nil
+	}
+	return c.CustomCtrlClient.CreateOrUpdateObject(ctx, obj)
+}
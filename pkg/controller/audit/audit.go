@@ -0,0 +1,235 @@
+// Package audit keeps an append-only trail of the spec changes applied by the operator to each
+// operand CR, together with the resources the operator changed as a result. The trail is stored
+// in a ConfigMap owned by the CR and every record is also emitted as an annotated Event.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	// lastSpecKey holds the fingerprint of the last audited spec, used to compute changed fields
+	lastSpecKey = "last-applied-spec-fingerprint"
+	// recordKeyPrefix prefixes the zero-padded sequence number of every record
+	recordKeyPrefix = "record-"
+)
+
+// Record is a single entry of the audit trail
+type Record struct {
+	Time          string   `json:"time"`
+	Generation    int64    `json:"generation"`
+	FieldManager  string   `json:"fieldManager,omitempty"`
+	ChangedFields []string `json:"changedFields,omitempty"`
+	Resources     []string `json:"resources,omitempty"`
+}
+
+// TrailName returns the name of the audit trail ConfigMap of the operand CR, e.g. "spireagent-audit-trail"
+func TrailName(owner client.Object) string {
+	return strings.ToLower(reflect.TypeOf(owner).Elem().Name()) + "-audit-trail"
+}
+
+// Append records the spec fields changed since the last audited generation and the resources
+// the operator changed while reconciling it. Nothing is recorded when neither changed. Records
+// beyond AuditRecordLimit are pruned, oldest first.
+func Append(ctx context.Context, c customClient.CustomCtrlClient, scheme *runtime.Scheme, recorder record.EventRecorder, owner client.Object, spec interface{}, resources []string) error {
+	fingerprint, err := fingerprintSpec(spec)
+	if err != nil {
+		return err
+	}
+
+	trail := &corev1.ConfigMap{}
+	key := types.NamespacedName{Name: TrailName(owner), Namespace: utils.GetOperatorNamespace()}
+	exists := true
+	if err := c.Get(ctx, key, trail); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get audit trail %s: %w", key.Name, err)
+		}
+		exists = false
+		trail = newTrail(key, owner)
+		if err := controllerutil.SetControllerReference(owner, trail, scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference on audit trail: %w", err)
+		}
+	}
+	if trail.Data == nil {
+		trail.Data = map[string]string{}
+	}
+
+	previous := map[string]string{}
+	if last := trail.Data[lastSpecKey]; last != "" {
+		if err := json.Unmarshal([]byte(last), &previous); err != nil {
+			return fmt.Errorf("failed to parse last audited spec of %s: %w", key.Name, err)
+		}
+	}
+	changed := changedFields(previous, fingerprint)
+	if len(changed) == 0 && len(resources) == 0 {
+		return nil
+	}
+
+	entry := Record{
+		Time:          time.Now().UTC().Format(time.RFC3339),
+		Generation:    owner.GetGeneration(),
+		ChangedFields: changed,
+		Resources:     resources,
+	}
+	if len(changed) > 0 {
+		entry.FieldManager = specFieldManager(owner)
+	}
+	entryJSON, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+
+	recordKeys := sortedRecordKeys(trail.Data)
+	sequence := 1
+	if len(recordKeys) > 0 {
+		last, _ := strconv.Atoi(strings.TrimPrefix(recordKeys[len(recordKeys)-1], recordKeyPrefix))
+		sequence = last + 1
+	}
+	recordKey := fmt.Sprintf("%s%010d", recordKeyPrefix, sequence)
+	trail.Data[recordKey] = string(entryJSON)
+	fingerprintJSON, err := json.Marshal(fingerprint)
+	if err != nil {
+		return fmt.Errorf("failed to marshal spec fingerprint: %w", err)
+	}
+	trail.Data[lastSpecKey] = string(fingerprintJSON)
+	for len(recordKeys) >= utils.AuditRecordLimit {
+		delete(trail.Data, recordKeys[0])
+		recordKeys = recordKeys[1:]
+	}
+
+	if exists {
+		err = c.Update(ctx, trail)
+	} else {
+		err = c.Create(ctx, trail)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write audit trail %s: %w", key.Name, err)
+	}
+
+	reason := utils.AuditReasonResourcesReconciled
+	message := fmt.Sprintf("Generation %d: applied %d resource change(s): %s", entry.Generation, len(resources), strings.Join(resources, "; "))
+	if len(changed) > 0 {
+		reason = utils.AuditReasonSpecChangeApplied
+		message = fmt.Sprintf("Generation %d: %s changed by %s, applied %d resource change(s)",
+			entry.Generation, strings.Join(changed, ", "), fieldManagerOrUnknown(entry.FieldManager), len(resources))
+	}
+	recorder.AnnotatedEventf(owner, map[string]string{
+		utils.AuditRecordAnnotation:       key.Name + "/" + recordKey,
+		utils.AuditGenerationAnnotation:   strconv.FormatInt(entry.Generation, 10),
+		utils.AuditFieldManagerAnnotation: entry.FieldManager,
+	}, corev1.EventTypeNormal, reason, "%s", message)
+	return nil
+}
+
+func newTrail(key types.NamespacedName, owner client.Object) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Labels: map[string]string{
+				utils.AppManagedByLabelKey: utils.AppManagedByLabelValue,
+				utils.AuditTrailOfLabel:    strings.ToLower(reflect.TypeOf(owner).Elem().Name()),
+			},
+		},
+	}
+}
+
+// specFieldManager returns the field manager of the most recent write to the spec, taken from
+// the managed fields of the CR
+func specFieldManager(owner client.Object) string {
+	var manager string
+	var latest time.Time
+	for _, entry := range owner.GetManagedFields() {
+		if entry.Subresource != "" || entry.FieldsV1 == nil || !strings.Contains(string(entry.FieldsV1.Raw), `"f:spec"`) {
+			continue
+		}
+		if entry.Time != nil && (manager == "" || entry.Time.After(latest)) {
+			manager, latest = entry.Manager, entry.Time.Time
+		}
+	}
+	return manager
+}
+
+func fieldManagerOrUnknown(manager string) string {
+	if manager == "" {
+		return "unknown field manager"
+	}
+	return manager
+}
+
+func sortedRecordKeys(data map[string]string) []string {
+	var keys []string
+	for k := range data {
+		if strings.HasPrefix(k, recordKeyPrefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// fingerprintSpec flattens a spec into the hashes of its leaf fields keyed by path, e.g.
+// "spec.logLevel". Values are hashed so that secrets in the spec, such as datastore connection
+// strings, are never written to the trail. Lists are fingerprinted as a whole.
+func fingerprintSpec(spec interface{}) (map[string]string, error) {
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal spec: %w", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(specJSON, &fields); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+	fingerprint := map[string]string{}
+	collectFingerprints("spec", fields, fingerprint)
+	return fingerprint, nil
+}
+
+func collectFingerprints(path string, value interface{}, fingerprint map[string]string) {
+	if fields, ok := value.(map[string]interface{}); ok {
+		for k, v := range fields {
+			collectFingerprints(path+"."+k, v, fingerprint)
+		}
+		return
+	}
+	valueJSON, _ := json.Marshal(value)
+	fingerprint[path] = utils.GenerateConfigHash(valueJSON)
+}
+
+// changedFields returns the sorted paths of the fields that were added, removed or changed
+// between two spec fingerprints
+func changedFields(previous, current map[string]string) []string {
+	var fields []string
+	for path, hash := range current {
+		if previous[path] != hash {
+			fields = append(fields, path)
+		}
+	}
+	for path := range previous {
+		if _, ok := current[path]; !ok {
+			fields = append(fields, path)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
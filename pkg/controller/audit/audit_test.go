@@ -0,0 +1,181 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = v1alpha2.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	return scheme
+}
+
+// trailClient returns a fake client that serves the given trail, or NotFound when it is nil
+func trailClient(trail *corev1.ConfigMap) *fakes.FakeCustomCtrlClient {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		if trail == nil {
+			return kerrors.NewNotFound(corev1.Resource("configmaps"), key.Name)
+		}
+		*obj.(*corev1.ConfigMap) = *trail.DeepCopy()
+		return nil
+	}
+	return fakeClient
+}
+
+func newServer(connectionString string) *v1alpha2.SpireServer {
+	return &v1alpha2.SpireServer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "cluster",
+			Generation: 2,
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "kubectl-edit", Operation: metav1.ManagedFieldsOperationUpdate, Time: &metav1.Time{Time: time.Unix(200, 0)}, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:logLevel":{}}}`)}},
+				{Manager: "kubectl-create", Operation: metav1.ManagedFieldsOperationUpdate, Time: &metav1.Time{Time: time.Unix(100, 0)}, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{}}`)}},
+				{Manager: "operator", Operation: metav1.ManagedFieldsOperationUpdate, Subresource: "status", Time: &metav1.Time{Time: time.Unix(300, 0)}, FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:status":{}}`)}},
+			},
+		},
+		Spec: v1alpha2.SpireServerSpec{
+			LogLevel:  "info",
+			Datastore: v1alpha2.DataStore{ConnectionString: connectionString},
+		},
+	}
+}
+
+func lastRecord(t *testing.T, trail *corev1.ConfigMap) Record {
+	t.Helper()
+	keys := sortedRecordKeys(trail.Data)
+	if len(keys) == 0 {
+		t.Fatal("Expected the trail to hold a record")
+	}
+	var entry Record
+	if err := json.Unmarshal([]byte(trail.Data[keys[len(keys)-1]]), &entry); err != nil {
+		t.Fatalf("Failed to parse record: %v", err)
+	}
+	return entry
+}
+
+func TestAppend_CreatesTrail(t *testing.T) {
+	fakeClient := trailClient(nil)
+	recorder := record.NewFakeRecorder(10)
+	server := newServer("postgresql://user:secret@db:5432/spire")
+
+	err := Append(context.Background(), fakeClient, newScheme(), recorder, server, server.Spec, []string{"create StatefulSet ns/spire-server"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fakeClient.CreateCallCount() != 1 {
+		t.Fatalf("Expected the trail to be created, got %d creates", fakeClient.CreateCallCount())
+	}
+	_, obj, _ := fakeClient.CreateArgsForCall(0)
+	trail := obj.(*corev1.ConfigMap)
+	if trail.Name != "spireserver-audit-trail" || trail.Labels[utils.AuditTrailOfLabel] != "spireserver" {
+		t.Errorf("Unexpected trail metadata: %s %v", trail.Name, trail.Labels)
+	}
+	if len(trail.OwnerReferences) != 1 {
+		t.Errorf("Expected the trail to be owned by the CR, got %v", trail.OwnerReferences)
+	}
+	for k, v := range trail.Data {
+		if strings.Contains(v, "secret") {
+			t.Errorf("Expected spec values to be fingerprinted, found secret in %s", k)
+		}
+	}
+
+	entry := lastRecord(t, trail)
+	if entry.Generation != 2 || entry.FieldManager != "kubectl-edit" {
+		t.Errorf("Expected generation 2 by the latest spec manager, got %d by %q", entry.Generation, entry.FieldManager)
+	}
+	if len(entry.Resources) != 1 || entry.Resources[0] != "create StatefulSet ns/spire-server" {
+		t.Errorf("Expected resource changes to be recorded, got %v", entry.Resources)
+	}
+
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, utils.AuditReasonSpecChangeApplied) || !strings.Contains(event, "spec.logLevel") {
+			t.Errorf("Unexpected event: %s", event)
+		}
+	default:
+		t.Error("Expected an audit event")
+	}
+}
+
+func TestAppend_ChangedFields(t *testing.T) {
+	previous := newServer("postgresql://user:secret@db:5432/spire")
+	fingerprint, _ := fingerprintSpec(previous.Spec)
+	fingerprintJSON, _ := json.Marshal(fingerprint)
+	trail := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "spireserver-audit-trail"},
+		Data: map[string]string{
+			lastSpecKey:         string(fingerprintJSON),
+			"record-0000000007": `{"generation":1}`,
+		},
+	}
+
+	t.Run("unchanged spec without resource changes is not recorded", func(t *testing.T) {
+		fakeClient := trailClient(trail)
+		if err := Append(context.Background(), fakeClient, newScheme(), record.NewFakeRecorder(10), previous, previous.Spec, nil); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fakeClient.UpdateCallCount() != 0 || fakeClient.CreateCallCount() != 0 {
+			t.Error("Expected the trail not to be written")
+		}
+	})
+
+	t.Run("changed fields are appended", func(t *testing.T) {
+		fakeClient := trailClient(trail)
+		server := newServer("postgresql://user:rotated@db:5432/spire")
+		server.Spec.LogLevel = "debug"
+		if err := Append(context.Background(), fakeClient, newScheme(), record.NewFakeRecorder(10), server, server.Spec, nil); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fakeClient.UpdateCallCount() != 1 {
+			t.Fatalf("Expected the trail to be updated, got %d updates", fakeClient.UpdateCallCount())
+		}
+		_, obj, _ := fakeClient.UpdateArgsForCall(0)
+		updated := obj.(*corev1.ConfigMap)
+		if _, ok := updated.Data["record-0000000008"]; !ok {
+			t.Errorf("Expected the record to follow the latest sequence, got keys %v", sortedRecordKeys(updated.Data))
+		}
+		entry := lastRecord(t, updated)
+		if got := strings.Join(entry.ChangedFields, ","); got != "spec.datastore.connectionString,spec.logLevel" {
+			t.Errorf("Unexpected changed fields: %s", got)
+		}
+	})
+}
+
+func TestAppend_PrunesOldestRecords(t *testing.T) {
+	data := map[string]string{}
+	for i := 1; i <= utils.AuditRecordLimit; i++ {
+		data[fmt.Sprintf("%s%010d", recordKeyPrefix, i)] = "{}"
+	}
+	fakeClient := trailClient(&corev1.ConfigMap{Data: data})
+	server := newServer("")
+
+	if err := Append(context.Background(), fakeClient, newScheme(), record.NewFakeRecorder(10), server, server.Spec, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	_, obj, _ := fakeClient.UpdateArgsForCall(0)
+	keys := sortedRecordKeys(obj.(*corev1.ConfigMap).Data)
+	if len(keys) != utils.AuditRecordLimit {
+		t.Errorf("Expected %d records, got %d", utils.AuditRecordLimit, len(keys))
+	}
+	if keys[0] != fmt.Sprintf("%s%010d", recordKeyPrefix, 2) {
+		t.Errorf("Expected the oldest record to be pruned, first record is %s", keys[0])
+	}
+}
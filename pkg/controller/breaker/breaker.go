@@ -0,0 +1,101 @@
+// Package breaker backs off the reconciliation of an operand whose managed resource keeps failing
+// to apply. Once a resource failed Threshold times in a row, the operand CR is marked Degraded, a
+// Warning Event is emitted and the reconciliation is retried every RequeueInterval instead of at
+// the rate of the controller's backoff, sparing the API server and its audit log.
+package breaker
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/pipeline"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	// Threshold is the number of consecutive failures of a resource that trips the breaker
+	Threshold = 5
+	// RequeueInterval is how often the reconciliation is retried while the breaker is tripped
+	RequeueInterval = 5 * time.Minute
+	// unknownResource keys failures that happened outside of a write, e.g. while reading
+	unknownResource = "reconcile"
+)
+
+// Breaker counts the consecutive reconciliation failures of each managed resource of a
+// controller. A nil Breaker never trips.
+type Breaker struct {
+	mu       sync.Mutex
+	failures map[string]int
+}
+
+// New returns a Breaker without recorded failures
+func New() *Breaker {
+	return &Breaker{failures: map[string]int{}}
+}
+
+// Result records the outcome of a reconciliation of owner and returns what Reconcile should
+// return. A success, or an error that is not retried anyway, resets the recorded failures.
+// resource is the managed resource whose write failed, if known; the failed steps of a pipeline
+// error are counted each instead. The failures of the resources that did not fail this time are
+// forgotten, so the breaker only trips on a resource failing every time.
+func (b *Breaker) Result(recorder record.EventRecorder, owner client.Object, statusMgr *status.Manager, resource string, err error) (ctrl.Result, error) {
+	if b == nil || err == nil || utils.ClassifyError(err) != utils.RetryRequiredError {
+		b.reset()
+		return utils.ReconcileResult(err)
+	}
+
+	resources := pipeline.FailedResources(err)
+	if len(resources) == 0 {
+		if resource == "" {
+			resource = unknownResource
+		}
+		resources = []string{resource}
+	}
+	resource, failures := b.recordFailures(resources)
+	if failures < Threshold {
+		return utils.ReconcileResult(err)
+	}
+
+	message := fmt.Sprintf("%s failed %d consecutive times, retrying every %s: %v", resource, failures, RequeueInterval, err)
+	statusMgr.AddCondition(v1alpha2.Degraded, utils.DegradedReasonRepeatedFailures, message, metav1.ConditionTrue)
+	if failures == Threshold {
+		recorder.Event(owner, corev1.EventTypeWarning, utils.DegradedReasonRepeatedFailures, utils.WithRunbook(utils.DegradedReasonRepeatedFailures, message))
+	}
+	return ctrl.Result{RequeueAfter: RequeueInterval}, nil
+}
+
+// recordFailures counts a failure of each resource, forgets the others and returns the resource
+// that failed the most consecutive times
+func (b *Breaker) recordFailures(resources []string) (string, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	failing := map[string]int{}
+	worst, most := "", 0
+	for _, resource := range resources {
+		failing[resource] = b.failures[resource] + 1
+		if failing[resource] > most {
+			worst, most = resource, failing[resource]
+		}
+	}
+	b.failures = failing
+	return worst, most
+}
+
+func (b *Breaker) reset() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	clear(b.failures)
+}
@@ -0,0 +1,148 @@
+package breaker
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/pipeline"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const failingResource = "DaemonSet zero-trust-workload-identity-manager/spire-agent"
+
+func TestBreakerResult(t *testing.T) {
+	owner := &v1alpha2.SpireAgent{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}}
+	transient := errors.New("etcdserver: request timed out")
+
+	t.Run("trips after consecutive failures", func(t *testing.T) {
+		b := New()
+		recorder := record.NewFakeRecorder(10)
+		for i := 1; i < Threshold; i++ {
+			statusMgr := status.NewManager(&fakes.FakeCustomCtrlClient{})
+			result, err := b.Result(recorder, owner, statusMgr, failingResource, transient)
+			if err == nil || result.RequeueAfter != 0 {
+				t.Fatalf("Expected failure %d to be retried with backoff, got %v %v", i, result, err)
+			}
+		}
+
+		statusMgr := status.NewManager(&fakes.FakeCustomCtrlClient{})
+		result, err := b.Result(recorder, owner, statusMgr, failingResource, transient)
+		if err != nil || result.RequeueAfter != RequeueInterval {
+			t.Fatalf("Expected a tripped breaker to requeue after %s, got %v %v", RequeueInterval, result, err)
+		}
+		cond, ok := statusMgr.GetCondition(v1alpha2.Degraded)
+		if !ok || cond.Reason != utils.DegradedReasonRepeatedFailures || !strings.Contains(cond.Message, failingResource) {
+			t.Errorf("Expected Degraded with the failing resource, got %v", cond)
+		}
+		if len(recorder.Events) != 1 {
+			t.Fatalf("Expected one Warning event, got %d", len(recorder.Events))
+		}
+		if event := <-recorder.Events; !strings.HasPrefix(event, "Warning "+utils.DegradedReasonRepeatedFailures) {
+			t.Errorf("Expected a RepeatedFailures Warning event, got %q", event)
+		}
+
+		if _, err := b.Result(recorder, owner, status.NewManager(&fakes.FakeCustomCtrlClient{}), failingResource, transient); err != nil {
+			t.Errorf("Expected the breaker to stay tripped, got %v", err)
+		}
+		if len(recorder.Events) != 0 {
+			t.Errorf("Expected no further events while tripped, got %d", len(recorder.Events))
+		}
+	})
+
+	t.Run("success resets the failures", func(t *testing.T) {
+		b := New()
+		recorder := record.NewFakeRecorder(10)
+		for i := 1; i < Threshold; i++ {
+			_, _ = b.Result(recorder, owner, status.NewManager(&fakes.FakeCustomCtrlClient{}), failingResource, transient)
+		}
+		if _, err := b.Result(recorder, owner, status.NewManager(&fakes.FakeCustomCtrlClient{}), "", nil); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if _, err := b.Result(recorder, owner, status.NewManager(&fakes.FakeCustomCtrlClient{}), failingResource, transient); err == nil {
+			t.Error("Expected the failure count to restart after a success")
+		}
+	})
+
+	t.Run("failures are counted per resource", func(t *testing.T) {
+		b := New()
+		recorder := record.NewFakeRecorder(10)
+		for i := 1; i < Threshold; i++ {
+			_, _ = b.Result(recorder, owner, status.NewManager(&fakes.FakeCustomCtrlClient{}), failingResource, transient)
+		}
+		if _, err := b.Result(recorder, owner, status.NewManager(&fakes.FakeCustomCtrlClient{}), "Service zero-trust-workload-identity-manager/spire-agent", transient); err == nil {
+			t.Error("Expected a different resource not to trip the breaker")
+		}
+	})
+
+	t.Run("recovered resources are forgotten", func(t *testing.T) {
+		b := New()
+		recorder := record.NewFakeRecorder(10)
+		for i := 1; i < Threshold; i++ {
+			_, _ = b.Result(recorder, owner, status.NewManager(&fakes.FakeCustomCtrlClient{}), failingResource, transient)
+		}
+		_, _ = b.Result(recorder, owner, status.NewManager(&fakes.FakeCustomCtrlClient{}), "Service zero-trust-workload-identity-manager/spire-agent", transient)
+		if _, err := b.Result(recorder, owner, status.NewManager(&fakes.FakeCustomCtrlClient{}), failingResource, transient); err == nil {
+			t.Error("Expected the failure count to restart after the resource was applied")
+		}
+	})
+
+	t.Run("failed pipeline steps are counted each", func(t *testing.T) {
+		b := New()
+		recorder := record.NewFakeRecorder(10)
+		run := func(steps ...pipeline.Step) (ctrl.Result, error) {
+			return b.Result(recorder, owner, status.NewManager(&fakes.FakeCustomCtrlClient{}), "", pipeline.Run(steps...))
+		}
+		failing := pipeline.Step{Resource: "DaemonSet", Run: func() error { return transient }}
+		flapping := func(i int) pipeline.Step {
+			return pipeline.Step{Resource: "Service", Run: func() error {
+				if i%2 == 0 {
+					return transient
+				}
+				return nil
+			}}
+		}
+		for i := 1; i < Threshold; i++ {
+			if _, err := run(flapping(i), failing); err == nil {
+				t.Fatalf("Expected failure %d to be retried with backoff", i)
+			}
+		}
+		statusMgr := status.NewManager(&fakes.FakeCustomCtrlClient{})
+		result, err := b.Result(recorder, owner, statusMgr, "", pipeline.Run(flapping(Threshold), failing))
+		if err != nil || result.RequeueAfter != RequeueInterval {
+			t.Fatalf("Expected the step failing every time to trip the breaker, got %v %v", result, err)
+		}
+		if cond, _ := statusMgr.GetCondition(v1alpha2.Degraded); !strings.HasPrefix(cond.Message, "DaemonSet failed") {
+			t.Errorf("Expected Degraded to name the failing step, got %q", cond.Message)
+		}
+	})
+
+	t.Run("errors that are not retried do not count", func(t *testing.T) {
+		b := New()
+		forbidden := kerrors.NewForbidden(schema.GroupResource{Resource: "daemonsets"}, "spire-agent", errors.New("denied"))
+		for i := 0; i <= Threshold; i++ {
+			result, _ := b.Result(record.NewFakeRecorder(10), owner, status.NewManager(&fakes.FakeCustomCtrlClient{}), failingResource, forbidden)
+			if result.RequeueAfter != 0 {
+				t.Fatalf("Expected terminal errors never to trip the breaker, got %v", result)
+			}
+		}
+	})
+
+	t.Run("nil breaker never trips", func(t *testing.T) {
+		var b *Breaker
+		for i := 0; i <= Threshold; i++ {
+			if _, err := b.Result(record.NewFakeRecorder(10), owner, status.NewManager(&fakes.FakeCustomCtrlClient{}), failingResource, transient); err == nil {
+				t.Fatal("Expected a nil breaker to return the error")
+			}
+		}
+	})
+}
@@ -0,0 +1,184 @@
+// Package conditionhistory keeps a bounded history of the condition transitions of the
+// ZeroTrustWorkloadIdentityManager and of the operands it aggregates, so that conditions which
+// flapped and recovered before anyone looked can still be diagnosed. The history is stored in a
+// ConfigMap owned by the ZeroTrustWorkloadIdentityManager, one record per transition.
+package conditionhistory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	// ConfigMapName is the name of the condition history ConfigMap in the operator namespace
+	ConfigMapName = "zerotrustworkloadidentitymanager-condition-history"
+
+	// lastStateKey holds the last recorded status and reason of every condition
+	lastStateKey = "last-recorded-conditions"
+	// recordKeyPrefix prefixes the zero-padded sequence number of every record
+	recordKeyPrefix = "record-"
+
+	// maxMessageLength bounds the message kept with every transition
+	maxMessageLength = 256
+)
+
+// Transition is a change of the status or the reason of a condition
+type Transition struct {
+	Time string `json:"time"`
+	// Source is the resource of the condition, e.g. "SpireAgent/cluster"
+	Source  string                 `json:"source"`
+	Type    string                 `json:"type"`
+	Status  metav1.ConditionStatus `json:"status"`
+	Reason  string                 `json:"reason"`
+	Message string                 `json:"message,omitempty"`
+}
+
+// Record appends the transitions of the observed conditions, keyed by source, to the condition
+// history of the owner. Transitions are found against the last recorded state of every condition,
+// kept in the history, so they are recorded once even when the status they were observed in is
+// not written. Records beyond ConditionHistoryRecordLimit are pruned, oldest first.
+func Record(ctx context.Context, c customClient.CustomCtrlClient, scheme *runtime.Scheme, owner client.Object, observed map[string][]metav1.Condition, now time.Time) error {
+	history := &corev1.ConfigMap{}
+	key := types.NamespacedName{Name: ConfigMapName, Namespace: utils.GetOperatorNamespace()}
+	exists := true
+	if err := c.Get(ctx, key, history); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get condition history %s: %w", key.Name, err)
+		}
+		exists = false
+		history = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				Labels: map[string]string{
+					utils.AppManagedByLabelKey: utils.AppManagedByLabelValue,
+				},
+			},
+		}
+		if err := controllerutil.SetControllerReference(owner, history, scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference on condition history: %w", err)
+		}
+	}
+	if history.Data == nil {
+		history.Data = map[string]string{}
+	}
+
+	last := map[string]string{}
+	if state := history.Data[lastStateKey]; state != "" {
+		if err := json.Unmarshal([]byte(state), &last); err != nil {
+			return fmt.Errorf("failed to parse last recorded conditions of %s: %w", key.Name, err)
+		}
+	}
+	transitions := diff(last, observed, now)
+	if len(transitions) == 0 {
+		return nil
+	}
+
+	recordKeys := sortedRecordKeys(history.Data)
+	sequence := 1
+	if len(recordKeys) > 0 {
+		previous, _ := strconv.Atoi(strings.TrimPrefix(recordKeys[len(recordKeys)-1], recordKeyPrefix))
+		sequence = previous + 1
+	}
+	for _, transition := range transitions {
+		transitionJSON, err := json.Marshal(transition)
+		if err != nil {
+			return fmt.Errorf("failed to marshal condition transition: %w", err)
+		}
+		recordKey := fmt.Sprintf("%s%010d", recordKeyPrefix, sequence)
+		history.Data[recordKey] = string(transitionJSON)
+		recordKeys = append(recordKeys, recordKey)
+		sequence++
+	}
+	for len(recordKeys) > utils.ConditionHistoryRecordLimit {
+		delete(history.Data, recordKeys[0])
+		recordKeys = recordKeys[1:]
+	}
+	stateJSON, err := json.Marshal(last)
+	if err != nil {
+		return fmt.Errorf("failed to marshal last recorded conditions: %w", err)
+	}
+	history.Data[lastStateKey] = string(stateJSON)
+
+	if exists {
+		err = c.Update(ctx, history)
+	} else {
+		err = c.Create(ctx, history)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write condition history %s: %w", key.Name, err)
+	}
+	return nil
+}
+
+// diff returns the transitions of the observed conditions from their last recorded state, which it
+// updates. Conditions appearing are transitions, conditions removed are not.
+func diff(last map[string]string, observed map[string][]metav1.Condition, now time.Time) []Transition {
+	sources := make([]string, 0, len(observed))
+	for source := range observed {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	var transitions []Transition
+	for _, source := range sources {
+		for _, cond := range observed[source] {
+			stateKey := source + "/" + cond.Type
+			state := string(cond.Status) + "/" + cond.Reason
+			previous := last[stateKey]
+			if previous == state {
+				continue
+			}
+			last[stateKey] = state
+			// The last transition time is only bumped by status changes, not reason changes
+			at := now
+			if !cond.LastTransitionTime.IsZero() && !strings.HasPrefix(previous, string(cond.Status)+"/") {
+				at = cond.LastTransitionTime.Time
+			}
+			transitions = append(transitions, Transition{
+				Time:    at.UTC().Format(time.RFC3339),
+				Source:  source,
+				Type:    cond.Type,
+				Status:  cond.Status,
+				Reason:  cond.Reason,
+				Message: truncate(cond.Message),
+			})
+		}
+	}
+	return transitions
+}
+
+func truncate(message string) string {
+	if len(message) <= maxMessageLength {
+		return message
+	}
+	return message[:maxMessageLength-3] + "..."
+}
+
+func sortedRecordKeys(data map[string]string) []string {
+	var keys []string
+	for k := range data {
+		if strings.HasPrefix(k, recordKeyPrefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,132 @@
+package conditionhistory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = v1alpha2.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	return scheme
+}
+
+// historyClient returns a fake client serving the last written history, or NotFound before the
+// history is created
+func historyClient() (*fakes.FakeCustomCtrlClient, func() *corev1.ConfigMap) {
+	var history *corev1.ConfigMap
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		if history == nil {
+			return kerrors.NewNotFound(corev1.Resource("configmaps"), key.Name)
+		}
+		*obj.(*corev1.ConfigMap) = *history.DeepCopy()
+		return nil
+	}
+	write := func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+		history = obj.(*corev1.ConfigMap).DeepCopy()
+		return nil
+	}
+	fakeClient.CreateStub = write
+	fakeClient.UpdateStub = func(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		return write(ctx, obj)
+	}
+	return fakeClient, func() *corev1.ConfigMap { return history }
+}
+
+func records(t *testing.T, history *corev1.ConfigMap) []Transition {
+	t.Helper()
+	var transitions []Transition
+	for _, key := range sortedRecordKeys(history.Data) {
+		var transition Transition
+		if err := json.Unmarshal([]byte(history.Data[key]), &transition); err != nil {
+			t.Fatalf("Failed to parse record %s: %v", key, err)
+		}
+		transitions = append(transitions, transition)
+	}
+	return transitions
+}
+
+func TestRecord(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+	fakeClient, history := historyClient()
+	owner := &v1alpha2.ZeroTrustWorkloadIdentityManager{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	flapped := metav1.NewTime(now.Add(-time.Minute))
+
+	observe := func(status metav1.ConditionStatus, reason string) map[string][]metav1.Condition {
+		return map[string][]metav1.Condition{
+			"SpireAgent/cluster": {{Type: utils.ClockSkewStatusType, Status: status, Reason: reason, LastTransitionTime: flapped}},
+		}
+	}
+	record := func(observed map[string][]metav1.Condition) {
+		t.Helper()
+		if err := Record(context.Background(), fakeClient, newScheme(), owner, observed, now); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	record(observe(metav1.ConditionTrue, "ClockSkewDetected"))
+	if fakeClient.CreateCallCount() != 1 {
+		t.Fatalf("Expected the history to be created, got %d creates", fakeClient.CreateCallCount())
+	}
+	if refs := history().OwnerReferences; len(refs) != 1 || refs[0].Kind != "ZeroTrustWorkloadIdentityManager" {
+		t.Errorf("Expected the history to be owned by the ZeroTrustWorkloadIdentityManager, got %v", refs)
+	}
+
+	// Observing the same state again, e.g. while the status is not written, records nothing
+	record(observe(metav1.ConditionTrue, "ClockSkewDetected"))
+	if fakeClient.UpdateCallCount() != 0 {
+		t.Errorf("Expected no update without transition, got %d", fakeClient.UpdateCallCount())
+	}
+
+	record(observe(metav1.ConditionFalse, "ClocksInSync"))
+	transitions := records(t, history())
+	if len(transitions) != 2 {
+		t.Fatalf("Expected 2 transitions, got %v", transitions)
+	}
+	recovered := transitions[1]
+	if recovered.Source != "SpireAgent/cluster" || recovered.Status != metav1.ConditionFalse || recovered.Reason != "ClocksInSync" {
+		t.Errorf("Unexpected transition %v", recovered)
+	}
+	if recovered.Time != flapped.UTC().Format(time.RFC3339) {
+		t.Errorf("Expected the transition at the last transition time %s, got %s", flapped.UTC().Format(time.RFC3339), recovered.Time)
+	}
+}
+
+func TestRecord_PrunesOldestRecords(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+	fakeClient, history := historyClient()
+	owner := &v1alpha2.ZeroTrustWorkloadIdentityManager{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}}
+
+	for i := 0; i < utils.ConditionHistoryRecordLimit+5; i++ {
+		observed := map[string][]metav1.Condition{
+			"SpireServer/cluster": {{Type: "Ready", Status: metav1.ConditionFalse, Reason: fmt.Sprintf("Reason%d", i)}},
+		}
+		if err := Record(context.Background(), fakeClient, newScheme(), owner, observed, time.Now()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	transitions := records(t, history())
+	if len(transitions) != utils.ConditionHistoryRecordLimit {
+		t.Fatalf("Expected %d records, got %d", utils.ConditionHistoryRecordLimit, len(transitions))
+	}
+	if transitions[0].Reason != "Reason5" {
+		t.Errorf("Expected the oldest records to be pruned, got %s first", transitions[0].Reason)
+	}
+}
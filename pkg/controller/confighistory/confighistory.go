@@ -0,0 +1,197 @@
+// Package confighistory keeps the last rendered revisions of the operand ConfigMaps and lets a
+// stored revision be pinned through the config rollback annotation on the operand CR.
+package confighistory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// RevisionName returns the name of the ConfigMap holding the given revision of a ConfigMap
+func RevisionName(configMapName string, revision int) string {
+	return fmt.Sprintf("%s-rev-%d", configMapName, revision)
+}
+
+// ListRevisions returns the stored revisions of the named ConfigMap, oldest first
+func ListRevisions(ctx context.Context, c customClient.CustomCtrlClient, namespace, configMapName string) ([]corev1.ConfigMap, error) {
+	var list corev1.ConfigMapList
+	if err := c.List(ctx, &list, client.InNamespace(namespace), client.MatchingLabels{utils.ConfigRevisionOfLabel: configMapName}); err != nil {
+		return nil, fmt.Errorf("failed to list revisions of ConfigMap %s: %w", configMapName, err)
+	}
+	revisions := make([]corev1.ConfigMap, 0, len(list.Items))
+	for _, item := range list.Items {
+		if _, err := revisionNumber(&item); err == nil {
+			revisions = append(revisions, item)
+		}
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		a, _ := revisionNumber(&revisions[i])
+		b, _ := revisionNumber(&revisions[j])
+		return a < b
+	})
+	return revisions, nil
+}
+
+// RecordRevision stores the data of the applied ConfigMap as a new revision when it differs from
+// the latest stored revision, and prunes revisions beyond ConfigRevisionHistoryLimit. Revisions
+// are owned by the operand CR so they are garbage collected with it.
+func RecordRevision(ctx context.Context, c customClient.CustomCtrlClient, scheme *runtime.Scheme, owner client.Object, applied *corev1.ConfigMap) error {
+	revisions, err := ListRevisions(ctx, c, applied.Namespace, applied.Name)
+	if err != nil {
+		return err
+	}
+
+	next := 1
+	if len(revisions) > 0 {
+		latest := revisions[len(revisions)-1]
+		if utils.GenerateMapHash(latest.Data) == utils.GenerateMapHash(applied.Data) {
+			return nil
+		}
+		n, _ := revisionNumber(&latest)
+		next = n + 1
+	}
+
+	labels := make(map[string]string, len(applied.Labels)+1)
+	for k, v := range applied.Labels {
+		labels[k] = v
+	}
+	labels[utils.ConfigRevisionOfLabel] = applied.Name
+
+	revision := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      RevisionName(applied.Name, next),
+			Namespace: applied.Namespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				utils.ConfigRevisionAnnotation: strconv.Itoa(next),
+			},
+		},
+		Data: applied.Data,
+	}
+	if err := controllerutil.SetControllerReference(owner, revision, scheme); err != nil {
+		return fmt.Errorf("failed to set controller reference on %s: %w", revision.Name, err)
+	}
+	if err := c.Create(ctx, revision); err != nil {
+		return fmt.Errorf("failed to create config revision %s: %w", revision.Name, err)
+	}
+
+	revisions = append(revisions, *revision)
+	for len(revisions) > utils.ConfigRevisionHistoryLimit {
+		if err := c.Delete(ctx, &revisions[0]); err != nil {
+			return fmt.Errorf("failed to prune config revision %s: %w", revisions[0].Name, err)
+		}
+		revisions = revisions[1:]
+	}
+	return nil
+}
+
+// ApplyRollback replaces the data of the desired ConfigMap with the stored revision requested by
+// the config rollback annotation on the owner. It returns the config hash of the pinned revision
+// and true while a rollback is active. The ConfigRollback condition reports the pinned revision,
+// or why the requested revision could not be used, in which case the rendered config is kept.
+func ApplyRollback(ctx context.Context, c customClient.CustomCtrlClient, owner client.Object, existingConditions []metav1.Condition, desired *corev1.ConfigMap, statusMgr *status.Manager) (string, bool) {
+	value, ok := owner.GetAnnotations()[utils.ConfigRollbackAnnotation]
+	if !ok {
+		// Only report the transition if a rollback was previously active
+		existing := apimeta.FindStatusCondition(existingConditions, utils.ConfigRollbackStatusType)
+		if existing != nil && existing.Status == metav1.ConditionTrue {
+			if _, set := statusMgr.GetCondition(utils.ConfigRollbackStatusType); !set {
+				statusMgr.AddCondition(utils.ConfigRollbackStatusType, utils.ConfigRollbackReasonDisabled,
+					"Config rollback is disabled: ConfigMaps are rendered from the CR spec",
+					metav1.ConditionFalse)
+			}
+		}
+		return "", false
+	}
+
+	revision, requested, err := parseRollbackRevision(value, desired.Name)
+	if err != nil {
+		addRollbackCondition(statusMgr, utils.ConfigRollbackReasonInvalid, err.Error(), metav1.ConditionFalse)
+		return "", false
+	}
+	if !requested {
+		return "", false
+	}
+
+	var stored corev1.ConfigMap
+	key := types.NamespacedName{Name: RevisionName(desired.Name, revision), Namespace: desired.Namespace}
+	if err := c.Get(ctx, key, &stored); err != nil {
+		addRollbackCondition(statusMgr, utils.ConfigRollbackReasonNotFound,
+			fmt.Sprintf("Revision %d of ConfigMap %s is not available: %v", revision, desired.Name, err),
+			metav1.ConditionFalse)
+		return "", false
+	}
+
+	desired.Data = stored.Data
+	if desired.Annotations == nil {
+		desired.Annotations = map[string]string{}
+	}
+	desired.Annotations[utils.ConfigRevisionAnnotation] = strconv.Itoa(revision)
+	addRollbackCondition(statusMgr, utils.ConfigRollbackReasonActive,
+		fmt.Sprintf("ConfigMap %s is pinned to revision %d", desired.Name, revision),
+		metav1.ConditionTrue)
+	return utils.GenerateMapHash(stored.Data), true
+}
+
+// addRollbackCondition merges the result for one ConfigMap into the ConfigRollback condition, so
+// operands with several ConfigMaps report all of them. A failure is never hidden by a success.
+func addRollbackCondition(statusMgr *status.Manager, reason, message string, conditionStatus metav1.ConditionStatus) {
+	if existing, ok := statusMgr.GetCondition(utils.ConfigRollbackStatusType); ok && existing.Reason != utils.ConfigRollbackReasonDisabled {
+		message = existing.Message + "; " + message
+		if existing.Status == metav1.ConditionFalse {
+			reason, conditionStatus = existing.Reason, existing.Status
+		}
+	}
+	statusMgr.AddCondition(utils.ConfigRollbackStatusType, reason, message, conditionStatus)
+}
+
+// parseRollbackRevision parses the rollback annotation, which is either a single revision applied
+// to every ConfigMap of the operand ("3") or a list of ConfigMap revisions
+// ("spire-server=3,spire-controller-manager=2"). It returns false when no revision is requested
+// for the named ConfigMap.
+func parseRollbackRevision(value, configMapName string) (int, bool, error) {
+	value = strings.TrimSpace(value)
+	if !strings.Contains(value, "=") {
+		revision, err := strconv.Atoi(value)
+		if err != nil || revision < 1 {
+			return 0, false, fmt.Errorf("invalid %s annotation %q: expected a revision number", utils.ConfigRollbackAnnotation, value)
+		}
+		return revision, true, nil
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		name, rev, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found {
+			return 0, false, fmt.Errorf("invalid %s annotation entry %q: expected <configmap>=<revision>", utils.ConfigRollbackAnnotation, entry)
+		}
+		if strings.TrimSpace(name) != configMapName {
+			continue
+		}
+		revision, err := strconv.Atoi(strings.TrimSpace(rev))
+		if err != nil || revision < 1 {
+			return 0, false, fmt.Errorf("invalid %s annotation entry %q: expected a revision number", utils.ConfigRollbackAnnotation, entry)
+		}
+		return revision, true, nil
+	}
+	return 0, false, nil
+}
+
+func revisionNumber(cm *corev1.ConfigMap) (int, error) {
+	return strconv.Atoi(cm.Annotations[utils.ConfigRevisionAnnotation])
+}
@@ -0,0 +1,250 @@
+package confighistory
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = v1alpha2.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	return scheme
+}
+
+func revisionConfigMap(name string, revision int, data map[string]string) corev1.ConfigMap {
+	return corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        RevisionName(name, revision),
+			Namespace:   "ns",
+			Labels:      map[string]string{utils.ConfigRevisionOfLabel: name},
+			Annotations: map[string]string{utils.ConfigRevisionAnnotation: strconv.Itoa(revision)},
+		},
+		Data: data,
+	}
+}
+
+func listReturning(items ...corev1.ConfigMap) func(context.Context, client.ObjectList, ...client.ListOption) error {
+	return func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+		list.(*corev1.ConfigMapList).Items = items
+		return nil
+	}
+}
+
+func TestRecordRevision(t *testing.T) {
+	owner := &v1alpha2.SpireAgent{ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "uid"}}
+	applied := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "spire-agent",
+			Namespace: "ns",
+			Labels:    map[string]string{utils.AppManagedByLabelKey: utils.AppManagedByLabelValue},
+		},
+		Data: map[string]string{"agent.conf": "new"},
+	}
+
+	t.Run("first revision", func(t *testing.T) {
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		if err := RecordRevision(context.Background(), fakeClient, newScheme(), owner, applied); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fakeClient.CreateCallCount() != 1 {
+			t.Fatalf("Expected Create to be called once, got %d", fakeClient.CreateCallCount())
+		}
+		_, obj, _ := fakeClient.CreateArgsForCall(0)
+		revision := obj.(*corev1.ConfigMap)
+		if revision.Name != "spire-agent-rev-1" {
+			t.Errorf("Expected revision name spire-agent-rev-1, got %s", revision.Name)
+		}
+		if revision.Labels[utils.ConfigRevisionOfLabel] != "spire-agent" || revision.Labels[utils.AppManagedByLabelKey] != utils.AppManagedByLabelValue {
+			t.Errorf("Expected revision to carry the applied and revision labels, got %v", revision.Labels)
+		}
+		if len(revision.OwnerReferences) != 1 || revision.OwnerReferences[0].Name != "cluster" {
+			t.Errorf("Expected revision to be owned by the operand CR, got %v", revision.OwnerReferences)
+		}
+	})
+
+	t.Run("unchanged config is not recorded", func(t *testing.T) {
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		fakeClient.ListStub = listReturning(revisionConfigMap("spire-agent", 1, map[string]string{"agent.conf": "new"}))
+		if err := RecordRevision(context.Background(), fakeClient, newScheme(), owner, applied); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fakeClient.CreateCallCount() != 0 {
+			t.Errorf("Expected no revision to be created, got %d", fakeClient.CreateCallCount())
+		}
+	})
+
+	t.Run("prunes beyond history limit", func(t *testing.T) {
+		var items []corev1.ConfigMap
+		for i := utils.ConfigRevisionHistoryLimit; i >= 1; i-- {
+			items = append(items, revisionConfigMap("spire-agent", i, map[string]string{"agent.conf": strconv.Itoa(i)}))
+		}
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		fakeClient.ListStub = listReturning(items...)
+		if err := RecordRevision(context.Background(), fakeClient, newScheme(), owner, applied); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		_, obj, _ := fakeClient.CreateArgsForCall(0)
+		if name := obj.GetName(); name != RevisionName("spire-agent", utils.ConfigRevisionHistoryLimit+1) {
+			t.Errorf("Expected next revision to follow the latest, got %s", name)
+		}
+		if fakeClient.DeleteCallCount() != 1 {
+			t.Fatalf("Expected one revision to be pruned, got %d", fakeClient.DeleteCallCount())
+		}
+		_, deleted, _ := fakeClient.DeleteArgsForCall(0)
+		if deleted.GetName() != "spire-agent-rev-1" {
+			t.Errorf("Expected oldest revision to be pruned, got %s", deleted.GetName())
+		}
+	})
+}
+
+func TestApplyRollback(t *testing.T) {
+	stored := map[string]string{"agent.conf": "old"}
+	rolledBackConditions := []metav1.Condition{{Type: utils.ConfigRollbackStatusType, Status: metav1.ConditionTrue}}
+
+	tests := []struct {
+		name             string
+		annotation       *string
+		existing         []metav1.Condition
+		getErr           error
+		expectRolledBack bool
+		expectReason     string
+		expectStatus     metav1.ConditionStatus
+	}{
+		{
+			name: "no annotation",
+		},
+		{
+			name:             "rollback to stored revision",
+			annotation:       ptrTo("2"),
+			expectRolledBack: true,
+			expectReason:     utils.ConfigRollbackReasonActive,
+			expectStatus:     metav1.ConditionTrue,
+		},
+		{
+			name:             "rollback by configmap name",
+			annotation:       ptrTo("spire-server=1, spire-agent=2"),
+			expectRolledBack: true,
+			expectReason:     utils.ConfigRollbackReasonActive,
+			expectStatus:     metav1.ConditionTrue,
+		},
+		{
+			name:       "other configmap only",
+			annotation: ptrTo("spire-server=1"),
+		},
+		{
+			name:         "invalid revision",
+			annotation:   ptrTo("latest"),
+			expectReason: utils.ConfigRollbackReasonInvalid,
+			expectStatus: metav1.ConditionFalse,
+		},
+		{
+			name:         "revision not found",
+			annotation:   ptrTo("9"),
+			getErr:       kerrors.NewNotFound(corev1.Resource("configmaps"), "spire-agent-rev-9"),
+			expectReason: utils.ConfigRollbackReasonNotFound,
+			expectStatus: metav1.ConditionFalse,
+		},
+		{
+			name:         "annotation removed after rollback",
+			existing:     rolledBackConditions,
+			expectReason: utils.ConfigRollbackReasonDisabled,
+			expectStatus: metav1.ConditionFalse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				if tt.getErr != nil {
+					return tt.getErr
+				}
+				cm := revisionConfigMap("spire-agent", 2, stored)
+				*obj.(*corev1.ConfigMap) = cm
+				return nil
+			}
+			owner := &v1alpha2.SpireAgent{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}}
+			if tt.annotation != nil {
+				owner.Annotations = map[string]string{utils.ConfigRollbackAnnotation: *tt.annotation}
+			}
+			desired := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "spire-agent", Namespace: "ns"},
+				Data:       map[string]string{"agent.conf": "new"},
+			}
+			statusMgr := status.NewManager(fakeClient)
+
+			hash, rolledBack := ApplyRollback(context.Background(), fakeClient, owner, tt.existing, desired, statusMgr)
+			if rolledBack != tt.expectRolledBack {
+				t.Fatalf("Expected rolledBack=%v, got %v", tt.expectRolledBack, rolledBack)
+			}
+			if rolledBack {
+				if desired.Data["agent.conf"] != "old" {
+					t.Errorf("Expected desired data to be replaced by the stored revision, got %v", desired.Data)
+				}
+				if hash != utils.GenerateMapHash(stored) {
+					t.Errorf("Expected hash of the stored revision, got %s", hash)
+				}
+			} else if desired.Data["agent.conf"] != "new" {
+				t.Errorf("Expected rendered data to be kept, got %v", desired.Data)
+			}
+
+			cond, ok := statusMgr.GetCondition(utils.ConfigRollbackStatusType)
+			if tt.expectReason == "" {
+				if ok {
+					t.Errorf("Expected no ConfigRollback condition, got %v", cond)
+				}
+				return
+			}
+			if !ok || cond.Reason != tt.expectReason || cond.Status != tt.expectStatus {
+				t.Errorf("Expected ConfigRollback %s/%s, got %v", tt.expectStatus, tt.expectReason, cond)
+			}
+		})
+	}
+}
+
+func TestApplyRollback_MultipleConfigMaps(t *testing.T) {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		if strings.HasPrefix(key.Name, "spire-server") {
+			return kerrors.NewNotFound(corev1.Resource("configmaps"), key.Name)
+		}
+		*obj.(*corev1.ConfigMap) = revisionConfigMap("spire-controller-manager", 1, map[string]string{"k": "v"})
+		return nil
+	}
+	owner := &v1alpha2.SpireServer{ObjectMeta: metav1.ObjectMeta{
+		Name:        "cluster",
+		Annotations: map[string]string{utils.ConfigRollbackAnnotation: "1"},
+	}}
+	statusMgr := status.NewManager(fakeClient)
+
+	for _, name := range []string{"spire-server", "spire-controller-manager"} {
+		desired := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"}}
+		ApplyRollback(context.Background(), fakeClient, owner, nil, desired, statusMgr)
+	}
+
+	cond, _ := statusMgr.GetCondition(utils.ConfigRollbackStatusType)
+	if cond.Status != metav1.ConditionFalse || cond.Reason != utils.ConfigRollbackReasonNotFound {
+		t.Errorf("Expected a failed ConfigMap to keep the condition False/%s, got %s/%s", utils.ConfigRollbackReasonNotFound, cond.Status, cond.Reason)
+	}
+	if !strings.Contains(cond.Message, "spire-server") || !strings.Contains(cond.Message, "spire-controller-manager") {
+		t.Errorf("Expected message to report both ConfigMaps, got %q", cond.Message)
+	}
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,266 @@
+// Package dependencies tracks the Secrets and ConfigMaps in the operator namespace that the operand
+// CRs reference without the operator managing them, e.g. the datastore TLS Secret or the trusted CA
+// bundle. They are not labelled as managed by the operator and so are not in the manager's cache;
+// controllers watch them through a dedicated cache and roll the operand pods when their data changes.
+package dependencies
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// Kind is the kind of a referenced object
+type Kind string
+
+const (
+	KindSecret    Kind = "Secret"
+	KindConfigMap Kind = "ConfigMap"
+)
+
+// Reference is a Secret or ConfigMap in the operator namespace referenced by an operand CR
+type Reference struct {
+	Kind Kind
+	Name string
+}
+
+// Secret returns a reference to the named Secret
+func Secret(name string) Reference {
+	return Reference{Kind: KindSecret, Name: name}
+}
+
+// TrustedCABundle returns the reference to the trusted CA bundle ConfigMap, or nothing when no
+// trusted CA bundle is configured
+func TrustedCABundle() []Reference {
+	if !utils.IsTrustedCABundleConfigured() {
+		return nil
+	}
+	return []Reference{{Kind: KindConfigMap, Name: utils.GetTrustedCABundleConfigMapName()}}
+}
+
+// NewCache returns a cache of the Secrets in the operator namespace, and of the trusted CA bundle
+// ConfigMap when configured, and adds it to the manager so it is started with the controllers
+func NewCache(mgr ctrl.Manager) (cache.Cache, error) {
+	opts := cache.Options{
+		Scheme:            mgr.GetScheme(),
+		Mapper:            mgr.GetRESTMapper(),
+		DefaultNamespaces: map[string]cache.Config{utils.GetOperatorNamespace(): {}},
+	}
+	if utils.IsTrustedCABundleConfigured() {
+		opts.ByObject = map[client.Object]cache.ByObject{
+			&corev1.ConfigMap{}: {Field: fields.OneTermEqualSelector("metadata.name", utils.GetTrustedCABundleConfigMapName())},
+		}
+	}
+	dependencyCache, err := cache.New(mgr.GetConfig(), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dependency cache: %w", err)
+	}
+	if err := mgr.Add(dependencyCache); err != nil {
+		return nil, fmt.Errorf("failed to add dependency cache to the manager: %w", err)
+	}
+	return dependencyCache, nil
+}
+
+// Watch makes the controller reconcile the "cluster" CR whenever one of the objects returned by
+// refs changes. refs is evaluated on every change, so it follows the references of the current CR.
+func Watch(b *builder.Builder, dependencyCache cache.Cache, refs func(ctx context.Context) []Reference) *builder.Builder {
+	return WatchFor(b, dependencyCache, refs, clusterRequest)
+}
+
+// clusterRequest enqueues the "cluster" CR
+func clusterRequest(context.Context) []reconcile.Request {
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "cluster"}}}
+}
+
+// WatchFor is Watch for controllers reconciling several CRs, e.g. the SpireAgent pools: the CRs
+// returned by requests are reconciled whenever one of the objects returned by refs changes.
+func WatchFor(b *builder.Builder, dependencyCache cache.Cache, refs func(ctx context.Context) []Reference, requests func(ctx context.Context) []reconcile.Request) *builder.Builder {
+	b = b.WatchesRawSource(source.Kind(dependencyCache, &corev1.Secret{},
+		handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, secret *corev1.Secret) []reconcile.Request {
+			return enqueueIfReferenced(ctx, refs(ctx), Secret(secret.Name), requests)
+		})))
+	if utils.IsTrustedCABundleConfigured() {
+		b = b.WatchesRawSource(source.Kind(dependencyCache, &corev1.ConfigMap{},
+			handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, configMap *corev1.ConfigMap) []reconcile.Request {
+				return enqueueIfReferenced(ctx, refs(ctx), Reference{Kind: KindConfigMap, Name: configMap.Name}, requests)
+			})))
+	}
+	return b
+}
+
+func enqueueIfReferenced(ctx context.Context, refs []Reference, changed Reference, requests func(ctx context.Context) []reconcile.Request) []reconcile.Request {
+	for _, ref := range refs {
+		if ref == changed {
+			return requests(ctx)
+		}
+	}
+	return nil
+}
+
+// Hash returns a hash of the data of the referenced objects, or an empty string without
+// references. Missing objects hash as empty, so the pods roll once they are created. Objects
+// managed by the operator are left out: the content of the operand ConfigMaps is covered by their
+// config hash annotation, and the trust bundle is reloaded without rolling the pods.
+func Hash(ctx context.Context, c customClient.CustomCtrlClient, refs []Reference) (string, error) {
+	data := map[string]string{}
+	for _, ref := range refs {
+		key := types.NamespacedName{Name: ref.Name, Namespace: utils.GetOperatorNamespace()}
+		prefix := fmt.Sprintf("%s/%s/", ref.Kind, ref.Name)
+		switch ref.Kind {
+		case KindSecret:
+			var secret corev1.Secret
+			if err := c.GetUncached(ctx, key, &secret); err != nil && !kerrors.IsNotFound(err) {
+				return "", fmt.Errorf("failed to get Secret %s: %w", ref.Name, err)
+			}
+			if managedByOperator(&secret) {
+				continue
+			}
+			for k, v := range secret.Data {
+				data[prefix+k] = string(v)
+			}
+		case KindConfigMap:
+			var configMap corev1.ConfigMap
+			if err := c.GetUncached(ctx, key, &configMap); err != nil && !kerrors.IsNotFound(err) {
+				return "", fmt.Errorf("failed to get ConfigMap %s: %w", ref.Name, err)
+			}
+			if managedByOperator(&configMap) {
+				continue
+			}
+			for k, v := range configMap.Data {
+				data[prefix+k] = v
+			}
+			for k, v := range configMap.BinaryData {
+				data[prefix+k] = string(v)
+			}
+		}
+		// Hash the reference itself, so referencing another object rolls the pods even when both are empty
+		data[prefix] = ""
+	}
+	if len(data) == 0 {
+		return "", nil
+	}
+	return utils.GenerateMapHash(data), nil
+}
+
+func managedByOperator(obj client.Object) bool {
+	return obj.GetLabels()[utils.AppManagedByLabelKey] == utils.AppManagedByLabelValue
+}
+
+// FromPodSpec returns the Secrets and ConfigMaps the pod mounts as volumes or projected volume
+// sources, or reads in the environment of its containers, sorted and without duplicates
+func FromPodSpec(spec *corev1.PodSpec) []Reference {
+	seen := map[Reference]bool{}
+	add := func(kind Kind, name string) {
+		if name != "" {
+			seen[Reference{Kind: kind, Name: name}] = true
+		}
+	}
+	for _, volume := range spec.Volumes {
+		if volume.Secret != nil {
+			add(KindSecret, volume.Secret.SecretName)
+		}
+		if volume.ConfigMap != nil {
+			add(KindConfigMap, volume.ConfigMap.Name)
+		}
+		if volume.Projected == nil {
+			continue
+		}
+		for _, source := range volume.Projected.Sources {
+			if source.Secret != nil {
+				add(KindSecret, source.Secret.Name)
+			}
+			if source.ConfigMap != nil {
+				add(KindConfigMap, source.ConfigMap.Name)
+			}
+		}
+	}
+	for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for _, container := range containers {
+			for _, env := range container.Env {
+				if env.ValueFrom == nil {
+					continue
+				}
+				if env.ValueFrom.SecretKeyRef != nil {
+					add(KindSecret, env.ValueFrom.SecretKeyRef.Name)
+				}
+				if env.ValueFrom.ConfigMapKeyRef != nil {
+					add(KindConfigMap, env.ValueFrom.ConfigMapKeyRef.Name)
+				}
+			}
+			for _, envFrom := range container.EnvFrom {
+				if envFrom.SecretRef != nil {
+					add(KindSecret, envFrom.SecretRef.Name)
+				}
+				if envFrom.ConfigMapRef != nil {
+					add(KindConfigMap, envFrom.ConfigMapRef.Name)
+				}
+			}
+		}
+	}
+
+	refs := make([]Reference, 0, len(seen))
+	for ref := range seen {
+		refs = append(refs, ref)
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Kind != refs[j].Kind {
+			return refs[i].Kind < refs[j].Kind
+		}
+		return refs[i].Name < refs[j].Name
+	})
+	return refs
+}
+
+// Annotate sets the dependencies hash annotation on the pod template from the Secrets and
+// ConfigMaps it references, so the pods roll whenever one of them changes, whatever the operand
+// mounts them for. The annotation is removed when the pods reference no such object.
+func Annotate(ctx context.Context, c customClient.CustomCtrlClient, template *corev1.PodTemplateSpec) error {
+	hash, err := Hash(ctx, c, FromPodSpec(&template.Spec))
+	if err != nil {
+		return err
+	}
+	if hash == "" {
+		delete(template.Annotations, utils.DependenciesHashAnnotationKey)
+		return nil
+	}
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[utils.DependenciesHashAnnotationKey] = hash
+	return nil
+}
+
+// FromWorkload returns the Secrets and ConfigMaps referenced by the pod template of the deployed
+// StatefulSet, DaemonSet or Deployment with the name and namespace of obj, read from the cache.
+// It is empty when the workload is not deployed.
+func FromWorkload(ctx context.Context, c customClient.CustomCtrlClient, obj client.Object) []Reference {
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		return nil
+	}
+	switch workload := obj.(type) {
+	case *appsv1.StatefulSet:
+		return FromPodSpec(&workload.Spec.Template.Spec)
+	case *appsv1.DaemonSet:
+		return FromPodSpec(&workload.Spec.Template.Spec)
+	case *appsv1.Deployment:
+		return FromPodSpec(&workload.Spec.Template.Spec)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package dependencies
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// secretClient serves the given Secret data by name, and NotFound for anything else
+func secretClient(secrets map[string]map[string][]byte) *fakes.FakeCustomCtrlClient {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	fakeClient.GetUncachedStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		data, ok := secrets[key.Name]
+		if !ok {
+			return kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, key.Name)
+		}
+		obj.(*corev1.Secret).Data = data
+		return nil
+	}
+	return fakeClient
+}
+
+func TestHash(t *testing.T) {
+	ctx := context.Background()
+	refs := []Reference{Secret("db-tls")}
+
+	t.Run("no references", func(t *testing.T) {
+		hash, err := Hash(ctx, &fakes.FakeCustomCtrlClient{}, nil)
+		if err != nil || hash != "" {
+			t.Errorf("Expected an empty hash without references, got %q %v", hash, err)
+		}
+	})
+
+	t.Run("hash follows the data", func(t *testing.T) {
+		before, err := Hash(ctx, secretClient(map[string]map[string][]byte{"db-tls": {"tls.crt": []byte("old")}}), refs)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		same, _ := Hash(ctx, secretClient(map[string]map[string][]byte{"db-tls": {"tls.crt": []byte("old")}}), refs)
+		after, _ := Hash(ctx, secretClient(map[string]map[string][]byte{"db-tls": {"tls.crt": []byte("rotated")}}), refs)
+		if before == "" || before != same {
+			t.Errorf("Expected a stable hash, got %q and %q", before, same)
+		}
+		if before == after {
+			t.Error("Expected the hash to change when the Secret is rotated")
+		}
+	})
+
+	t.Run("missing object", func(t *testing.T) {
+		missing, err := Hash(ctx, secretClient(nil), refs)
+		if err != nil || missing == "" {
+			t.Fatalf("Expected a missing Secret to hash as empty, got %q %v", missing, err)
+		}
+		other, _ := Hash(ctx, secretClient(nil), []Reference{Secret("other-tls")})
+		if missing == other {
+			t.Error("Expected different references to hash differently")
+		}
+	})
+
+	t.Run("objects managed by the operator", func(t *testing.T) {
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		fakeClient.GetUncachedStub = func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			obj.SetLabels(map[string]string{utils.AppManagedByLabelKey: utils.AppManagedByLabelValue})
+			return nil
+		}
+		hash, err := Hash(ctx, fakeClient, []Reference{Reference{Kind: KindConfigMap, Name: "spire-bundle"}})
+		if err != nil || hash != "" {
+			t.Errorf("Expected objects managed by the operator to be left out, got %q %v", hash, err)
+		}
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		fakeClient.GetUncachedReturns(errors.New("connection refused"))
+		if _, err := Hash(ctx, fakeClient, refs); err == nil {
+			t.Error("Expected an error when the Secret cannot be read")
+		}
+	})
+}
+
+func TestFromPodSpec(t *testing.T) {
+	spec := &corev1.PodSpec{
+		Volumes: []corev1.Volume{
+			{Name: "tls", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "server-tls"}}},
+			{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "config"}}}},
+			{Name: "projected", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{
+				{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "server-tls"}}},
+				{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "bundle"}}},
+			}}}},
+			{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		},
+		InitContainers: []corev1.Container{{
+			EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "init-env"}}}},
+		}},
+		Containers: []corev1.Container{{
+			Env: []corev1.EnvVar{
+				{Name: "PLAIN", Value: "value"},
+				{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "join-token"}, Key: "token"}}},
+			},
+			EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "env"}}}},
+		}},
+	}
+
+	expected := []Reference{Reference{Kind: KindConfigMap, Name: "bundle"}, Reference{Kind: KindConfigMap, Name: "config"}, Reference{Kind: KindConfigMap, Name: "env"}, Secret("init-env"), Secret("join-token"), Secret("server-tls")}
+	refs := FromPodSpec(spec)
+	if len(refs) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, refs)
+	}
+	for i := range expected {
+		if refs[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, refs)
+			break
+		}
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	ctx := context.Background()
+	template := &corev1.PodTemplateSpec{Spec: corev1.PodSpec{Volumes: []corev1.Volume{
+		{Name: "tls", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "db-tls"}}},
+	}}}
+
+	if err := Annotate(ctx, secretClient(map[string]map[string][]byte{"db-tls": {"tls.crt": []byte("old")}}), template); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	before := template.Annotations[utils.DependenciesHashAnnotationKey]
+	if before == "" {
+		t.Fatal("Expected the dependencies hash annotation to be set")
+	}
+	if err := Annotate(ctx, secretClient(map[string]map[string][]byte{"db-tls": {"tls.crt": []byte("rotated")}}), template); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if template.Annotations[utils.DependenciesHashAnnotationKey] == before {
+		t.Error("Expected the annotation to change when the mounted Secret is rotated")
+	}
+
+	template.Spec.Volumes = nil
+	if err := Annotate(ctx, secretClient(nil), template); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := template.Annotations[utils.DependenciesHashAnnotationKey]; ok {
+		t.Error("Expected the annotation to be removed without dependencies")
+	}
+}
+
+func TestTrustedCABundle(t *testing.T) {
+	t.Setenv(utils.TrustedCABundleConfigMapEnvVar, "")
+	if refs := TrustedCABundle(); len(refs) != 0 {
+		t.Errorf("Expected no reference without a trusted CA bundle, got %v", refs)
+	}
+
+	t.Setenv(utils.TrustedCABundleConfigMapEnvVar, "trusted-ca")
+	refs := TrustedCABundle()
+	if len(refs) != 1 || refs[0] != (Reference{Kind: KindConfigMap, Name: "trusted-ca"}) {
+		t.Errorf("Expected the trusted CA bundle ConfigMap, got %v", refs)
+	}
+}
+
+func TestEnqueueIfReferenced(t *testing.T) {
+	refs := []Reference{Secret("db-tls"), {Kind: KindConfigMap, Name: "trusted-ca"}}
+
+	if requests := enqueueIfReferenced(context.Background(), refs, Secret("db-tls"), clusterRequest); len(requests) != 1 || requests[0].Name != "cluster" {
+		t.Errorf("Expected the cluster CR to be enqueued, got %v", requests)
+	}
+	if requests := enqueueIfReferenced(context.Background(), refs, Secret("trusted-ca"), clusterRequest); len(requests) != 0 {
+		t.Errorf("Expected a Secret named like a referenced ConfigMap to be ignored, got %v", requests)
+	}
+	if requests := enqueueIfReferenced(context.Background(), refs, Secret("unrelated"), clusterRequest); len(requests) != 0 {
+		t.Errorf("Expected unreferenced objects to be ignored, got %v", requests)
+	}
+}
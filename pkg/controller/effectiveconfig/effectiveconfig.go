@@ -0,0 +1,190 @@
+// Package effectiveconfig serves the configuration currently applied to each operand, as rendered
+// by the operator into the operand ConfigMaps, with credentials redacted. It answers "what config
+// is the server really running?" without reading the ConfigMaps and decoding the plugin
+// configuration files by hand.
+//
+// The handler is registered on the metrics server, so the same authentication and authorization
+// as /metrics apply.
+package effectiveconfig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	// Path is where the effective configuration is served on the metrics server
+	Path = "/debug/config"
+
+	// Redacted replaces the value of sensitive configuration keys
+	Redacted = "REDACTED"
+)
+
+// operandConfigMaps are the ConfigMaps holding the rendered configuration of each operand. The
+// ConfigMaps of the additional agent pools are found by their pool label.
+var operandConfigMaps = []struct {
+	operand   string
+	configMap string
+}{
+	{operand: "spire-server", configMap: "spire-server"},
+	{operand: "spire-controller-manager", configMap: "spire-controller-manager"},
+	{operand: "spire-agent", configMap: "spire-agent"},
+	{operand: "spire-oidc-discovery-provider", configMap: "spire-spiffe-oidc-discovery-provider"},
+}
+
+// sensitiveKeySuffixes identify the configuration keys holding credentials. Keys are matched by
+// suffix so that e.g. token_path is kept while join_token values are not.
+var sensitiveKeySuffixes = []string{
+	"password", "secret", "token", "connection_string", "private_key", "access_key", "api_key", "credentials",
+}
+
+// OperandConfig is the effective configuration of an operand
+type OperandConfig struct {
+	Operand   string `json:"operand"`
+	ConfigMap string `json:"configMap"`
+	// Files holds the decoded configuration files by ConfigMap key. HCL plugin configuration
+	// files are decoded to the same structure as the JSON ones.
+	Files map[string]interface{} `json:"files"`
+}
+
+// Handler returns the handler serving the effective configuration of the operands as JSON. The
+// operand query parameter limits the response to a single operand.
+func Handler(c customClient.CustomCtrlClient) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		configs, err := Collect(req.Context(), c, req.URL.Query().Get("operand"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(configs)
+	})
+}
+
+// Collect returns the redacted effective configuration of the operands, or of the named operand
+// only. Operands not deployed are left out.
+func Collect(ctx context.Context, c customClient.CustomCtrlClient, operand string) ([]OperandConfig, error) {
+	namespace := utils.GetOperatorNamespace()
+	var configs []OperandConfig
+	for _, o := range operandConfigMaps {
+		if operand != "" && operand != o.operand {
+			continue
+		}
+		var cm corev1.ConfigMap
+		err := c.Get(ctx, types.NamespacedName{Name: o.configMap, Namespace: namespace}, &cm)
+		if kerrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ConfigMap %s: %w", o.configMap, err)
+		}
+		configs = append(configs, operandConfig(o.operand, &cm))
+
+		if o.operand != "spire-agent" {
+			continue
+		}
+		var pools corev1.ConfigMapList
+		if err := c.List(ctx, &pools, client.InNamespace(namespace), client.HasLabels{utils.AgentPoolLabel}); err != nil {
+			return nil, fmt.Errorf("failed to list agent pool ConfigMaps: %w", err)
+		}
+		sort.Slice(pools.Items, func(i, j int) bool { return pools.Items[i].Name < pools.Items[j].Name })
+		for i := range pools.Items {
+			// Revisions of the pool ConfigMaps carry the pool label too
+			if _, revision := pools.Items[i].Labels[utils.ConfigRevisionOfLabel]; revision {
+				continue
+			}
+			configs = append(configs, operandConfig(o.operand, &pools.Items[i]))
+		}
+	}
+	return configs, nil
+}
+
+// operandConfig decodes and redacts the configuration files of the ConfigMap
+func operandConfig(operand string, cm *corev1.ConfigMap) OperandConfig {
+	files := make(map[string]interface{}, len(cm.Data))
+	for key, data := range cm.Data {
+		files[key] = decodeFile(key, data)
+	}
+	return OperandConfig{Operand: operand, ConfigMap: cm.Name, Files: files}
+}
+
+// decodeFile decodes a JSON, YAML or HCL configuration file and redacts its sensitive values.
+// Files which cannot be decoded are redacted entirely, as their credentials cannot be told apart.
+func decodeFile(key, data string) interface{} {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(data), &decoded); err == nil {
+		return redact(decoded)
+	}
+	if strings.HasSuffix(key, ".yaml") {
+		if err := yaml.Unmarshal([]byte(data), &decoded); err == nil {
+			return redact(decoded)
+		}
+		return Redacted
+	}
+	var object map[string]interface{}
+	if err := hcl.Unmarshal([]byte(data), &object); err == nil {
+		return redact(object)
+	}
+	return Redacted
+}
+
+// redact replaces the scalar values of sensitive keys, recursively
+func redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			if isSensitiveKey(key) && isScalar(nested) {
+				v[key] = Redacted
+				continue
+			}
+			v[key] = redact(nested)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redact(v[i])
+		}
+	case []map[string]interface{}:
+		for i := range v {
+			redact(v[i])
+		}
+	}
+	return value
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, suffix := range sensitiveKeySuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}, []map[string]interface{}:
+		return false
+	}
+	return true
+}
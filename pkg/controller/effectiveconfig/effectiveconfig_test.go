@@ -0,0 +1,132 @@
+package effectiveconfig
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	testServerConf = `{"plugins":{"DataStore":[{"sql":{"plugin_data":{"database_type":"postgres","connection_string":"postgres://spire:hunter2@db/spire"}}}],` +
+		`"NodeAttestor":[{"join_token":{"plugin_data":{}}}]},"server":{"trust_domain":"example.org"}}`
+	testPluginConf = `
+access_key_id = "AKIA"
+secret_access_key = "s3cr3t"
+region = "us-east-1"
+`
+)
+
+func newFakeClient(configMaps ...corev1.ConfigMap) *fakes.FakeCustomCtrlClient {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		for _, cm := range configMaps {
+			if cm.Name == key.Name {
+				cm.DeepCopyInto(obj.(*corev1.ConfigMap))
+				return nil
+			}
+		}
+		return kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, key.Name)
+	}
+	fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+		for _, cm := range configMaps {
+			if _, ok := cm.Labels[utils.AgentPoolLabel]; ok {
+				list.(*corev1.ConfigMapList).Items = append(list.(*corev1.ConfigMapList).Items, cm)
+			}
+		}
+		return nil
+	}
+	return fakeClient
+}
+
+func configMap(name string, labels map[string]string, data map[string]string) corev1.ConfigMap {
+	return corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}, Data: data}
+}
+
+func TestCollect(t *testing.T) {
+	fakeClient := newFakeClient(
+		configMap("spire-server", nil, map[string]string{
+			"server.conf":                testServerConf,
+			"plugin-keymanager-aws.conf": testPluginConf,
+			"plugin-broken.conf":         "not { valid",
+		}),
+		configMap("spire-agent", nil, map[string]string{"agent.conf": `{"agent":{"trust_domain":"example.org"}}`}),
+		configMap("spire-agent-edge", map[string]string{utils.AgentPoolLabel: "edge"}, map[string]string{"agent.conf": `{}`}),
+		configMap("spire-agent-edge-rev-1", map[string]string{utils.AgentPoolLabel: "edge", utils.ConfigRevisionOfLabel: "spire-agent-edge"}, map[string]string{"agent.conf": `{}`}),
+	)
+
+	configs, err := Collect(context.Background(), fakeClient, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var names []string
+	for _, config := range configs {
+		names = append(names, config.ConfigMap)
+	}
+	if len(names) != 3 || names[0] != "spire-server" || names[1] != "spire-agent" || names[2] != "spire-agent-edge" {
+		t.Fatalf("Expected the server, agent and agent pool ConfigMaps, got %v", names)
+	}
+
+	serverConf, _ := json.Marshal(configs[0].Files["server.conf"])
+	var server map[string]interface{}
+	_ = json.Unmarshal(serverConf, &server)
+	pluginData := server["plugins"].(map[string]interface{})["DataStore"].([]interface{})[0].(map[string]interface{})["sql"].(map[string]interface{})["plugin_data"].(map[string]interface{})
+	if pluginData["connection_string"] != Redacted || pluginData["database_type"] != "postgres" {
+		t.Errorf("Expected only the connection string to be redacted, got %v", pluginData)
+	}
+	if _, ok := server["plugins"].(map[string]interface{})["NodeAttestor"].([]interface{})[0].(map[string]interface{})["join_token"].(map[string]interface{}); !ok {
+		t.Error("Expected the join_token plugin configuration to be kept")
+	}
+
+	plugin := configs[0].Files["plugin-keymanager-aws.conf"].(map[string]interface{})
+	if plugin["secret_access_key"] != Redacted || plugin["region"] != "us-east-1" || plugin["access_key_id"] != "AKIA" {
+		t.Errorf("Expected the HCL secret to be redacted, got %v", plugin)
+	}
+	if configs[0].Files["plugin-broken.conf"] != Redacted {
+		t.Errorf("Expected an undecodable file to be redacted, got %v", configs[0].Files["plugin-broken.conf"])
+	}
+}
+
+func TestCollect_Operand(t *testing.T) {
+	fakeClient := newFakeClient(
+		configMap("spire-server", nil, map[string]string{"server.conf": `{}`}),
+		configMap("spire-spiffe-oidc-discovery-provider", nil, map[string]string{"oidc-discovery-provider.conf": `{}`}),
+	)
+	configs, err := Collect(context.Background(), fakeClient, "spire-oidc-discovery-provider")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(configs) != 1 || configs[0].ConfigMap != "spire-spiffe-oidc-discovery-provider" {
+		t.Errorf("Expected only the OIDC discovery provider configuration, got %+v", configs)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	handler := Handler(newFakeClient(configMap("spire-server", nil, map[string]string{"server.conf": `{}`})))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path, nil))
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("Expected a JSON response, got %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	var configs []OperandConfig
+	if err := json.Unmarshal(rec.Body.Bytes(), &configs); err != nil || len(configs) != 1 {
+		t.Errorf("Expected the server configuration, got %s (%v)", rec.Body.String(), err)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, Path, nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected POST to be rejected, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,162 @@
+// Package environment detects the resources of the cluster which conflict with the operands, such
+// as a csi.spiffe.io CSIDriver or SecurityContextConstraints of a previous SPIRE install, or a SPIRE
+// server deployed outside of the operator. Rather than fighting over them, controllers report the
+// conflicts in the EnvironmentConflict condition and stop reconciling the resources they would
+// have to take over.
+package environment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// RecheckInterval is how often the operand is reconciled while blocked by conflicting resources,
+// as they are not watched
+const RecheckInterval = 2 * time.Minute
+
+// Conflict is a resource of the cluster which conflicts with an operand
+type Conflict struct {
+	// Resource names the conflicting resource, e.g. "CSIDriver csi.spiffe.io"
+	Resource string
+	// Detail tells why the resource conflicts
+	Detail string
+	// Blocking conflicts are resources the operand would have to take over to be reconciled
+	Blocking bool
+}
+
+func (c Conflict) String() string {
+	return fmt.Sprintf("%s %s", c.Resource, c.Detail)
+}
+
+// foreignSpireServerSelectors select the SPIRE servers deployed by the upstream manifests and the
+// SPIFFE Helm charts
+var foreignSpireServerSelectors = []client.MatchingLabels{
+	{"app": "spire-server"},
+	{"app.kubernetes.io/name": "spire-server"},
+	{"app.kubernetes.io/name": "server", "app.kubernetes.io/component": "server"},
+}
+
+// CheckOwned returns a blocking conflict when the cluster-scoped object with the name of a resource
+// managed for the operand exists without being controlled by an operand of the owner kind. Objects without a
+// controller are not conflicts when their adoption is requested. Objects in the cache are labelled
+// as managed by the operator, so only the objects missing from it are read from the API server.
+func CheckOwned(ctx context.Context, c customClient.CustomCtrlClient, obj client.Object, kind, name, ownerKind string, adopt bool) (*Conflict, error) {
+	key := types.NamespacedName{Name: name}
+	if err := c.Get(ctx, key, obj); err == nil {
+		return nil, nil
+	} else if !kerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get %s %s: %w", kind, name, err)
+	}
+	if err := c.GetUncached(ctx, key, obj); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get %s %s: %w", kind, name, err)
+	}
+	owner := metav1.GetControllerOf(obj)
+	switch {
+	case owner != nil && owner.Kind == ownerKind:
+		return nil, nil
+	case owner == nil && (adopt || managedByOperator(obj)):
+		return nil, nil
+	case owner != nil:
+		return &Conflict{
+			Resource: fmt.Sprintf("%s %s", kind, name),
+			Detail:   fmt.Sprintf("is controlled by %s %s", owner.Kind, owner.Name),
+			Blocking: true,
+		}, nil
+	}
+	return &Conflict{
+		Resource: fmt.Sprintf("%s %s", kind, name),
+		Detail:   "already exists and is not managed by the operator, set adoptExistingResources to take it over",
+		Blocking: true,
+	}, nil
+}
+
+// ForeignSpireServers returns the SPIRE server StatefulSets and Deployments of the cluster which
+// are not deployed by the operator. They are reported, but do not block the operand: a second
+// SPIRE server is not sharing resources with it, though its controller manager may reconcile the
+// same ClusterSPIFFEIDs.
+func ForeignSpireServers(ctx context.Context, c customClient.CustomCtrlClient) ([]Conflict, error) {
+	seen := map[string]bool{}
+	var conflicts []Conflict
+	report := func(kind string, obj client.Object) {
+		if obj.GetNamespace() == utils.GetOperatorNamespace() && managedByOperator(obj) {
+			return
+		}
+		resource := fmt.Sprintf("%s %s/%s", kind, obj.GetNamespace(), obj.GetName())
+		if seen[resource] {
+			return
+		}
+		seen[resource] = true
+		conflicts = append(conflicts, Conflict{Resource: resource, Detail: "runs a SPIRE server not deployed by the operator"})
+	}
+
+	for _, selector := range foreignSpireServerSelectors {
+		var statefulSets appsv1.StatefulSetList
+		if err := c.ListUncached(ctx, &statefulSets, selector); err != nil {
+			return nil, fmt.Errorf("failed to list StatefulSets: %w", err)
+		}
+		for i := range statefulSets.Items {
+			report("StatefulSet", &statefulSets.Items[i])
+		}
+		var deployments appsv1.DeploymentList
+		if err := c.ListUncached(ctx, &deployments, selector); err != nil {
+			return nil, fmt.Errorf("failed to list Deployments: %w", err)
+		}
+		for i := range deployments.Items {
+			report("Deployment", &deployments.Items[i])
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].Resource < conflicts[j].Resource })
+	return conflicts, nil
+}
+
+// managedByOperator tells whether the object carries the managed-by label of the operator
+func managedByOperator(obj client.Object) bool {
+	return obj.GetLabels()[utils.AppManagedByLabelKey] == utils.AppManagedByLabelValue
+}
+
+// Report sets the EnvironmentConflict condition from the conflicts found for the operand. Blocking
+// conflicts are returned as an invalid configuration error, so the caller stops reconciling the
+// operand until they are removed. The condition is only cleared when it was previously set.
+func Report(statusMgr utils.StatusManager, conflicts []Conflict, existingConditions []metav1.Condition) error {
+	if len(conflicts) == 0 {
+		if apimeta.FindStatusCondition(existingConditions, utils.EnvironmentConflictStatusType) != nil {
+			statusMgr.AddCondition(utils.EnvironmentConflictStatusType, utils.EnvironmentConflictReasonResolved,
+				"No conflicting resource found in the cluster",
+				metav1.ConditionFalse)
+		}
+		return nil
+	}
+
+	details := make([]string, 0, len(conflicts))
+	var blocking []string
+	for _, conflict := range conflicts {
+		details = append(details, conflict.String())
+		if conflict.Blocking {
+			blocking = append(blocking, conflict.Resource)
+		}
+	}
+	message := fmt.Sprintf("Conflicting resources found in the cluster: %s", strings.Join(details, "; "))
+	statusMgr.AddCondition(utils.EnvironmentConflictStatusType, utils.EnvironmentConflictReasonDetected, message, metav1.ConditionTrue)
+	if len(blocking) > 0 {
+		return utils.NewInvalidConfigurationError(errors.New(message),
+			"resources not managed by the operator conflict with the operand: %s", strings.Join(blocking, ", "))
+	}
+	return nil
+}
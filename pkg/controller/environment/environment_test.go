@@ -0,0 +1,150 @@
+package environment
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func TestCheckOwned(t *testing.T) {
+	notFound := kerrors.NewNotFound(schema.GroupResource{}, "csi.spiffe.io")
+	tests := []struct {
+		name           string
+		cached         bool
+		existing       *storagev1.CSIDriver
+		adopt          bool
+		expectConflict bool
+	}{
+		{name: "managed resource in the cache", cached: true},
+		{name: "no existing resource"},
+		{
+			name:     "controlled by the operand",
+			existing: &storagev1.CSIDriver{ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{{Kind: "SpiffeCSIDriver", Name: "cluster", Controller: ptr.To(true)}}}},
+		},
+		{
+			name:           "controlled by another owner",
+			existing:       &storagev1.CSIDriver{ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{{Kind: "HelmRelease", Name: "spire", Controller: ptr.To(true)}}}},
+			adopt:          true,
+			expectConflict: true,
+		},
+		{
+			name:           "not owned",
+			existing:       &storagev1.CSIDriver{},
+			expectConflict: true,
+		},
+		{
+			name:     "not owned and adopted",
+			existing: &storagev1.CSIDriver{},
+			adopt:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			if !tt.cached {
+				fakeClient.GetReturns(notFound)
+			}
+			fakeClient.GetUncachedStub = func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				if tt.existing == nil {
+					return notFound
+				}
+				*obj.(*storagev1.CSIDriver) = *tt.existing
+				return nil
+			}
+
+			conflict, err := CheckOwned(context.Background(), fakeClient, &storagev1.CSIDriver{}, "CSIDriver", "csi.spiffe.io", "SpiffeCSIDriver", tt.adopt)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if (conflict != nil) != tt.expectConflict {
+				t.Errorf("Expected conflict %v, got %v", tt.expectConflict, conflict)
+			}
+			if conflict != nil && !conflict.Blocking {
+				t.Error("Expected the conflict to block the operand")
+			}
+		})
+	}
+}
+
+func TestForeignSpireServers(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "ztwim")
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	fakeClient.ListUncachedStub = func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+		statefulSets, ok := list.(*appsv1.StatefulSetList)
+		if !ok {
+			return nil
+		}
+		// The same StatefulSet matches several selectors
+		statefulSets.Items = []appsv1.StatefulSet{
+			{ObjectMeta: metav1.ObjectMeta{Name: "spire-server", Namespace: "spire"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "spire-server", Namespace: "ztwim", Labels: map[string]string{utils.AppManagedByLabelKey: utils.AppManagedByLabelValue}}},
+		}
+		return nil
+	}
+
+	conflicts, err := ForeignSpireServers(context.Background(), fakeClient)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Resource != "StatefulSet spire/spire-server" || conflicts[0].Blocking {
+		t.Errorf("Expected the foreign server to be reported once without blocking, got %v", conflicts)
+	}
+}
+
+func TestReport(t *testing.T) {
+	existing := []metav1.Condition{{Type: utils.EnvironmentConflictStatusType, Status: metav1.ConditionTrue}}
+	tests := []struct {
+		name         string
+		conflicts    []Conflict
+		existing     []metav1.Condition
+		expectReason string
+		expectErr    bool
+	}{
+		{name: "no conflict"},
+		{name: "conflict resolved", existing: existing, expectReason: utils.EnvironmentConflictReasonResolved},
+		{
+			name:         "foreign server",
+			conflicts:    []Conflict{{Resource: "StatefulSet spire/spire-server", Detail: "runs a SPIRE server not deployed by the operator"}},
+			expectReason: utils.EnvironmentConflictReasonDetected,
+		},
+		{
+			name:         "foreign SCC",
+			conflicts:    []Conflict{{Resource: "SecurityContextConstraints spire-agent", Detail: "is controlled by HelmRelease spire", Blocking: true}},
+			expectReason: utils.EnvironmentConflictReasonDetected,
+			expectErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statusMgr := status.NewManager(&fakes.FakeCustomCtrlClient{})
+			err := Report(statusMgr, tt.conflicts, tt.existing)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("Expected error %v, got %v", tt.expectErr, err)
+			}
+			if err != nil && utils.ClassifyError(err) != utils.InvalidConfigurationError {
+				t.Errorf("Expected an invalid configuration error, got %v", err)
+			}
+			cond, ok := statusMgr.GetCondition(utils.EnvironmentConflictStatusType)
+			if tt.expectReason == "" {
+				if ok {
+					t.Errorf("Expected no condition, got %v", cond)
+				}
+			} else if cond.Reason != tt.expectReason {
+				t.Errorf("Expected reason %s, got %s: %s", tt.expectReason, cond.Reason, cond.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+// Package initialconfig creates the ZeroTrustWorkloadIdentityManager and operand CRs from a
+// configuration supplied at install time, e.g. through the env of the OLM Subscription, so that
+// automated installs do not need to apply the CRs once the operator is running.
+//
+// The configuration is read from the INITIAL_CONFIG environment variable, or from the
+// config.yaml key of the ConfigMap in the operator namespace named by INITIAL_CONFIG_CONFIGMAP.
+// It is only applied while the ZeroTrustWorkloadIdentityManager does not exist, so CRs edited or
+// deleted afterwards are left alone.
+package initialconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	// ConfigEnv holds the initial configuration inline
+	ConfigEnv = "INITIAL_CONFIG"
+	// ConfigMapEnv names the ConfigMap in the operator namespace holding the initial configuration
+	ConfigMapEnv = "INITIAL_CONFIG_CONFIGMAP"
+	// ConfigMapKey is the key of the initial configuration in the ConfigMap
+	ConfigMapKey = "config.yaml"
+
+	// retryInterval is how often applying the configuration is retried, e.g. until the ConfigMap exists
+	retryInterval = 30 * time.Second
+)
+
+// +kubebuilder:rbac:groups=operator.openshift.io,resources=zerotrustworkloadidentitymanagers,verbs=create
+
+// Config is the initial configuration: the spec of each "cluster" CR to create.
+// zeroTrustWorkloadIdentityManager is required, the operand CRs are optional.
+type Config struct {
+	ZeroTrustWorkloadIdentityManager *v1alpha2.ZeroTrustWorkloadIdentityManagerSpec `json:"zeroTrustWorkloadIdentityManager,omitempty"`
+	SpireServer                      *v1alpha2.SpireServerSpec                      `json:"spireServer,omitempty"`
+	SpireAgent                       *v1alpha2.SpireAgentSpec                       `json:"spireAgent,omitempty"`
+	SpiffeCSIDriver                  *v1alpha2.SpiffeCSIDriverSpec                  `json:"spiffeCSIDriver,omitempty"`
+	SpireOIDCDiscoveryProvider       *v1alpha2.SpireOIDCDiscoveryProviderSpec       `json:"spireOIDCDiscoveryProvider,omitempty"`
+}
+
+// v1alpha1Config is the initial configuration written for the v1alpha1 specs, with "true" and
+// "false" strings instead of booleans
+type v1alpha1Config struct {
+	ZeroTrustWorkloadIdentityManager *v1alpha1.ZeroTrustWorkloadIdentityManagerSpec `json:"zeroTrustWorkloadIdentityManager,omitempty"`
+	SpireServer                      *v1alpha1.SpireServerSpec                      `json:"spireServer,omitempty"`
+	SpireAgent                       *v1alpha1.SpireAgentSpec                       `json:"spireAgent,omitempty"`
+	SpiffeCSIDriver                  *v1alpha1.SpiffeCSIDriverSpec                  `json:"spiffeCSIDriver,omitempty"`
+	SpireOIDCDiscoveryProvider       *v1alpha1.SpireOIDCDiscoveryProviderSpec       `json:"spireOIDCDiscoveryProvider,omitempty"`
+}
+
+// Parse parses an initial configuration in YAML or JSON. Unknown fields are rejected, so typos
+// are reported instead of being silently dropped. Configurations written for v1alpha1 are
+// converted to v1alpha2.
+func Parse(data []byte) (*Config, error) {
+	var config Config
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		converted, v1alpha1Err := parseV1alpha1(data)
+		if v1alpha1Err != nil {
+			return nil, fmt.Errorf("failed to parse initial configuration: %w", err)
+		}
+		config = *converted
+	}
+	if config.ZeroTrustWorkloadIdentityManager == nil {
+		return nil, errors.New("initial configuration has no zeroTrustWorkloadIdentityManager")
+	}
+	return &config, nil
+}
+
+func parseV1alpha1(data []byte) (*Config, error) {
+	var legacy v1alpha1Config
+	if err := yaml.UnmarshalStrict(data, &legacy); err != nil {
+		return nil, err
+	}
+
+	var config Config
+	if spec := legacy.ZeroTrustWorkloadIdentityManager; spec != nil {
+		var hub v1alpha2.ZeroTrustWorkloadIdentityManager
+		if err := (&v1alpha1.ZeroTrustWorkloadIdentityManager{Spec: *spec}).ConvertTo(&hub); err != nil {
+			return nil, err
+		}
+		config.ZeroTrustWorkloadIdentityManager = &hub.Spec
+	}
+	if spec := legacy.SpireServer; spec != nil {
+		var hub v1alpha2.SpireServer
+		if err := (&v1alpha1.SpireServer{Spec: *spec}).ConvertTo(&hub); err != nil {
+			return nil, err
+		}
+		config.SpireServer = &hub.Spec
+	}
+	if spec := legacy.SpireAgent; spec != nil {
+		var hub v1alpha2.SpireAgent
+		if err := (&v1alpha1.SpireAgent{Spec: *spec}).ConvertTo(&hub); err != nil {
+			return nil, err
+		}
+		config.SpireAgent = &hub.Spec
+	}
+	if spec := legacy.SpiffeCSIDriver; spec != nil {
+		var hub v1alpha2.SpiffeCSIDriver
+		if err := (&v1alpha1.SpiffeCSIDriver{Spec: *spec}).ConvertTo(&hub); err != nil {
+			return nil, err
+		}
+		config.SpiffeCSIDriver = &hub.Spec
+	}
+	if spec := legacy.SpireOIDCDiscoveryProvider; spec != nil {
+		var hub v1alpha2.SpireOIDCDiscoveryProvider
+		if err := (&v1alpha1.SpireOIDCDiscoveryProvider{Spec: *spec}).ConvertTo(&hub); err != nil {
+			return nil, err
+		}
+		config.SpireOIDCDiscoveryProvider = &hub.Spec
+	}
+	return &config, nil
+}
+
+// objects returns the CRs of the configuration, the ZeroTrustWorkloadIdentityManager last: its
+// existence marks the configuration as applied, so it is created once the operand CRs are.
+func (c *Config) objects() []client.Object {
+	name := metav1.ObjectMeta{Name: "cluster"}
+	var objects []client.Object
+	if c.SpireServer != nil {
+		objects = append(objects, &v1alpha2.SpireServer{ObjectMeta: name, Spec: *c.SpireServer})
+	}
+	if c.SpireAgent != nil {
+		objects = append(objects, &v1alpha2.SpireAgent{ObjectMeta: name, Spec: *c.SpireAgent})
+	}
+	if c.SpiffeCSIDriver != nil {
+		objects = append(objects, &v1alpha2.SpiffeCSIDriver{ObjectMeta: name, Spec: *c.SpiffeCSIDriver})
+	}
+	if c.SpireOIDCDiscoveryProvider != nil {
+		objects = append(objects, &v1alpha2.SpireOIDCDiscoveryProvider{ObjectMeta: name, Spec: *c.SpireOIDCDiscoveryProvider})
+	}
+	return append(objects, &v1alpha2.ZeroTrustWorkloadIdentityManager{ObjectMeta: name, Spec: *c.ZeroTrustWorkloadIdentityManager})
+}
+
+// Applier is a manager Runnable applying the initial configuration once the operator is leader
+type Applier struct {
+	ctrlClient    customClient.CustomCtrlClient
+	inline        *Config
+	configMapName string
+	log           logr.Logger
+}
+
+// New returns an Applier for the configured initial configuration, or nil when none is
+// configured. An inline configuration is parsed right away, so that it fails the operator start.
+func New(mgr ctrl.Manager) (*Applier, error) {
+	inline, configMapName := os.Getenv(ConfigEnv), os.Getenv(ConfigMapEnv)
+	if inline == "" && configMapName == "" {
+		return nil, nil
+	}
+	if inline != "" && configMapName != "" {
+		return nil, fmt.Errorf("only one of %s and %s can be set", ConfigEnv, ConfigMapEnv)
+	}
+
+	c, err := customClient.NewCustomClient(mgr)
+	if err != nil {
+		return nil, err
+	}
+	applier := &Applier{
+		ctrlClient:    c,
+		configMapName: configMapName,
+		log:           ctrl.Log.WithName("initial-config"),
+	}
+	if inline != "" {
+		config, err := Parse([]byte(inline))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", ConfigEnv, err)
+		}
+		applier.inline = config
+	}
+	return applier, nil
+}
+
+// NeedLeaderElection makes the configuration only be applied by the leader
+func (a *Applier) NeedLeaderElection() bool {
+	return true
+}
+
+// Start applies the initial configuration, retrying until it succeeds or the operator stops
+func (a *Applier) Start(ctx context.Context) error {
+	_ = wait.PollUntilContextCancel(ctx, retryInterval, true, func(ctx context.Context) (bool, error) {
+		if err := a.apply(ctx); err != nil {
+			a.log.Error(err, "failed to apply initial configuration, retrying", "interval", retryInterval)
+			return false, nil
+		}
+		return true, nil
+	})
+	return nil
+}
+
+func (a *Applier) apply(ctx context.Context) error {
+	var ztwim v1alpha2.ZeroTrustWorkloadIdentityManager
+	err := a.ctrlClient.GetUncached(ctx, types.NamespacedName{Name: "cluster"}, &ztwim)
+	if err == nil {
+		a.log.Info("ZeroTrustWorkloadIdentityManager already exists, skipping initial configuration")
+		return nil
+	}
+	if !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get ZeroTrustWorkloadIdentityManager: %w", err)
+	}
+
+	config, err := a.load(ctx)
+	if err != nil {
+		return err
+	}
+	for _, obj := range config.objects() {
+		kind := fmt.Sprintf("%T", obj)
+		if err := a.ctrlClient.Create(ctx, obj); err != nil {
+			if kerrors.IsAlreadyExists(err) {
+				a.log.Info("CR already exists, keeping it", "kind", kind)
+				continue
+			}
+			return fmt.Errorf("failed to create %s from initial configuration: %w", kind, err)
+		}
+		a.log.Info("Created CR from initial configuration", "kind", kind)
+	}
+	return nil
+}
+
+func (a *Applier) load(ctx context.Context) (*Config, error) {
+	if a.inline != nil {
+		return a.inline, nil
+	}
+	var configMap corev1.ConfigMap
+	if err := a.ctrlClient.GetUncached(ctx, types.NamespacedName{Name: a.configMapName, Namespace: utils.GetOperatorNamespace()}, &configMap); err != nil {
+		return nil, fmt.Errorf("failed to get initial configuration ConfigMap %s: %w", a.configMapName, err)
+	}
+	data, ok := configMap.Data[ConfigMapKey]
+	if !ok {
+		return nil, fmt.Errorf("initial configuration ConfigMap %s has no %s key", a.configMapName, ConfigMapKey)
+	}
+	return Parse([]byte(data))
+}
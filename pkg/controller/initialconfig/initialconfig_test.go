@@ -0,0 +1,140 @@
+package initialconfig
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+)
+
+const testConfig = `
+zeroTrustWorkloadIdentityManager:
+  trustDomain: example.org
+  clusterName: demo
+spireServer:
+  jwtIssuer: https://oidc.example.org
+spireOIDCDiscoveryProvider:
+  jwtIssuer: https://oidc.example.org
+`
+
+func TestParse(t *testing.T) {
+	config, err := Parse([]byte(testConfig))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.ZeroTrustWorkloadIdentityManager.TrustDomain != "example.org" || config.SpireServer.JwtIssuer != "https://oidc.example.org" {
+		t.Errorf("Expected the configured specs, got %+v", config)
+	}
+	if config.SpireAgent != nil || config.SpiffeCSIDriver != nil {
+		t.Error("Expected CRs missing from the configuration not to be created")
+	}
+
+	if _, err := Parse([]byte("spireServer:\n  jwtIssuer: https://oidc.example.org\n")); err == nil {
+		t.Error("Expected an error without zeroTrustWorkloadIdentityManager")
+	}
+	if _, err := Parse([]byte("zeroTrustWorkloadIdentityManager:\n  trustDomian: example.org\n")); err == nil {
+		t.Error("Expected an error for an unknown field")
+	}
+}
+
+func TestParseV1alpha1(t *testing.T) {
+	config, err := Parse([]byte(testConfig + "spireAgent:\n  nodeAttestor:\n    k8sPSATEnabled: \"false\"\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.SpireAgent == nil || config.SpireAgent.NodeAttestor.K8sPSATEnabled == nil || *config.SpireAgent.NodeAttestor.K8sPSATEnabled {
+		t.Errorf("Expected k8sPSATEnabled to be converted to false, got %+v", config.SpireAgent)
+	}
+	if config.ZeroTrustWorkloadIdentityManager.TrustDomain != "example.org" || config.SpireServer.JwtIssuer != "https://oidc.example.org" {
+		t.Errorf("Expected the configured specs, got %+v", config)
+	}
+
+	config, err = Parse([]byte(testConfig + "spireAgent:\n  nodeAttestor:\n    k8sPSATEnabled: false\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.SpireAgent.NodeAttestor.K8sPSATEnabled == nil || *config.SpireAgent.NodeAttestor.K8sPSATEnabled {
+		t.Errorf("Expected k8sPSATEnabled false, got %+v", config.SpireAgent.NodeAttestor)
+	}
+}
+
+func TestApply(t *testing.T) {
+	config, err := Parse([]byte(testConfig))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	notFound := kerrors.NewNotFound(schema.GroupResource{}, "cluster")
+
+	t.Run("creates the CRs, ZeroTrustWorkloadIdentityManager last", func(t *testing.T) {
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		fakeClient.GetUncachedReturns(notFound)
+		fakeClient.CreateReturnsOnCall(0, kerrors.NewAlreadyExists(schema.GroupResource{}, "cluster"))
+		applier := &Applier{ctrlClient: fakeClient, inline: config, log: logr.Discard()}
+
+		if err := applier.apply(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fakeClient.CreateCallCount() != 3 {
+			t.Fatalf("Expected 3 CRs to be created, got %d", fakeClient.CreateCallCount())
+		}
+		if _, obj, _ := fakeClient.CreateArgsForCall(2); obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager).Spec.ClusterName != "demo" {
+			t.Errorf("Expected the ZeroTrustWorkloadIdentityManager to be created last, got %T", obj)
+		}
+	})
+
+	t.Run("skipped once the ZeroTrustWorkloadIdentityManager exists", func(t *testing.T) {
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		applier := &Applier{ctrlClient: fakeClient, inline: config, log: logr.Discard()}
+		if err := applier.apply(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fakeClient.CreateCallCount() != 0 {
+			t.Errorf("Expected no CR to be created, got %d", fakeClient.CreateCallCount())
+		}
+	})
+
+	t.Run("create error", func(t *testing.T) {
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		fakeClient.GetUncachedReturns(notFound)
+		fakeClient.CreateReturns(errors.New("connection refused"))
+		applier := &Applier{ctrlClient: fakeClient, inline: config, log: logr.Discard()}
+		if err := applier.apply(context.Background()); err == nil {
+			t.Error("Expected the error to be returned so the configuration is retried")
+		}
+	})
+
+	t.Run("configuration from a ConfigMap", func(t *testing.T) {
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		fakeClient.GetUncachedStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			configMap, ok := obj.(*corev1.ConfigMap)
+			if !ok {
+				return notFound
+			}
+			if key.Name != "initial-config" {
+				return kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, key.Name)
+			}
+			configMap.Data = map[string]string{ConfigMapKey: testConfig}
+			return nil
+		}
+		applier := &Applier{ctrlClient: fakeClient, configMapName: "initial-config", log: logr.Discard()}
+		if err := applier.apply(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fakeClient.CreateCallCount() != 3 {
+			t.Errorf("Expected 3 CRs to be created, got %d", fakeClient.CreateCallCount())
+		}
+
+		applier.configMapName = "missing"
+		if err := applier.apply(context.Background()); err == nil {
+			t.Error("Expected an error while the ConfigMap does not exist")
+		}
+	})
+}
@@ -0,0 +1,145 @@
+// Package maintenance restricts the disruptive changes to the operands, i.e. the updates of the
+// SPIRE server StatefulSet and of the DaemonSets which restart their pods, to the maintenance
+// windows of the ZeroTrustWorkloadIdentityManager. Updates needed outside of a window are deferred
+// and reported by the ChangesPending condition of the operand, which is reconciled again when the
+// next window opens.
+package maintenance
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	// ReasonInvalidWindow is the ConfigurationValid reason of the operands when a maintenance
+	// window cannot be parsed
+	ReasonInvalidWindow = "InvalidMaintenanceWindow"
+
+	defaultDuration = time.Hour
+)
+
+// window is a parsed maintenance window
+type window struct {
+	schedule cron.Schedule
+	duration time.Duration
+}
+
+func parse(windows []v1alpha2.MaintenanceWindow) ([]window, error) {
+	parsed := make([]window, 0, len(windows))
+	for i, w := range windows {
+		schedule, err := cron.ParseStandard(w.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("maintenanceWindows[%d]: invalid schedule %q: %w", i, w.Schedule, err)
+		}
+		duration := defaultDuration
+		if w.Duration != "" {
+			duration, err = time.ParseDuration(w.Duration)
+			if err != nil {
+				return nil, fmt.Errorf("maintenanceWindows[%d]: invalid duration %q: %w", i, w.Duration, err)
+			}
+			if duration < time.Minute {
+				return nil, fmt.Errorf("maintenanceWindows[%d]: duration %q must be at least 1m", i, w.Duration)
+			}
+		}
+		parsed = append(parsed, window{schedule: schedule, duration: duration})
+	}
+	return parsed, nil
+}
+
+// Validate checks the schedule and the duration of every maintenance window
+func Validate(windows []v1alpha2.MaintenanceWindow) error {
+	_, err := parse(windows)
+	return err
+}
+
+// Open tells whether disruptive changes may be applied at the given time: without maintenance
+// windows, or while one of them is open. Otherwise it returns when the next window opens, which is
+// zero when no schedule ever matches again.
+func Open(windows []v1alpha2.MaintenanceWindow, now time.Time) (bool, time.Time, error) {
+	if len(windows) == 0 {
+		return true, time.Time{}, nil
+	}
+	parsed, err := parse(windows)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	now = now.UTC()
+	var next time.Time
+	for _, w := range parsed {
+		// The window is open when it was last scheduled within its duration
+		if start := w.schedule.Next(now.Add(-w.duration)); !start.IsZero() && !start.After(now) {
+			return true, time.Time{}, nil
+		}
+		if start := w.schedule.Next(now); !start.IsZero() && (next.IsZero() || start.Before(next)) {
+			next = start
+		}
+	}
+	return false, next, nil
+}
+
+// Allows returns true if the pending update of the resource may be applied now. Outside of the
+// maintenance windows the update is deferred and ChangesPending is set until the next window, in
+// which the update is applied and the condition cleared. Invalid windows defer the update, they are
+// reported by the validation of the operand.
+func Allows(statusMgr *status.Manager, windows []v1alpha2.MaintenanceWindow, resource string, existingConditions []metav1.Condition, now time.Time) bool {
+	open, next, err := Open(windows, now)
+	if err != nil {
+		statusMgr.AddCondition(utils.ChangesPendingStatusType, utils.ChangesPendingReasonDeferred,
+			fmt.Sprintf("%s update deferred: %v", resource, err),
+			metav1.ConditionTrue)
+		return false
+	}
+	if !open {
+		nextWindow := "no upcoming maintenance window"
+		if !next.IsZero() {
+			nextWindow = fmt.Sprintf("the maintenance window opening at %s", next.Format(time.RFC3339))
+		}
+		statusMgr.AddCondition(utils.ChangesPendingStatusType, utils.ChangesPendingReasonDeferred,
+			fmt.Sprintf("%s update deferred until %s", resource, nextWindow),
+			metav1.ConditionTrue)
+		return false
+	}
+
+	if apimeta.IsStatusConditionTrue(existingConditions, utils.ChangesPendingStatusType) {
+		statusMgr.AddCondition(utils.ChangesPendingStatusType, utils.ChangesPendingReasonApplied,
+			fmt.Sprintf("Deferred %s update applied in the maintenance window", resource),
+			metav1.ConditionFalse)
+	}
+	return true
+}
+
+// Settle clears ChangesPending once the resource no longer needs an update, e.g. when the
+// deferred change was reverted before the window opened
+func Settle(statusMgr *status.Manager, existingConditions []metav1.Condition) {
+	if apimeta.IsStatusConditionTrue(existingConditions, utils.ChangesPendingStatusType) {
+		statusMgr.AddCondition(utils.ChangesPendingStatusType, utils.ChangesPendingReasonNoChanges,
+			"No change pending",
+			metav1.ConditionFalse)
+	}
+}
+
+// RequeueAfter returns the delay before the operand is reconciled again: the given one, shortened
+// to the opening of the next maintenance window while changes are deferred
+func RequeueAfter(statusMgr *status.Manager, windows []v1alpha2.MaintenanceWindow, requeueAfter time.Duration, now time.Time) time.Duration {
+	cond, ok := statusMgr.GetCondition(utils.ChangesPendingStatusType)
+	if !ok || cond.Status != metav1.ConditionTrue {
+		return requeueAfter
+	}
+	_, next, err := Open(windows, now)
+	if err != nil || next.IsZero() {
+		return requeueAfter
+	}
+	if delay := next.Sub(now); requeueAfter == 0 || delay < requeueAfter {
+		return delay
+	}
+	return requeueAfter
+}
@@ -0,0 +1,129 @@
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// saturdayNight opens every Saturday at 02:00 UTC for two hours
+var saturdayNight = []v1alpha2.MaintenanceWindow{{Schedule: "0 2 * * 6", Duration: "2h"}}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		windows   []v1alpha2.MaintenanceWindow
+		expectErr bool
+	}{
+		{name: "none"},
+		{name: "valid", windows: saturdayNight},
+		{name: "default duration", windows: []v1alpha2.MaintenanceWindow{{Schedule: "30 1 * * *"}}},
+		{name: "invalid schedule", windows: []v1alpha2.MaintenanceWindow{{Schedule: "every saturday"}}, expectErr: true},
+		{name: "duration too short", windows: []v1alpha2.MaintenanceWindow{{Schedule: "0 2 * * 6", Duration: "30s"}}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.windows)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestOpen(t *testing.T) {
+	nextSaturday := time.Date(2026, 10, 17, 2, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		windows    []v1alpha2.MaintenanceWindow
+		now        time.Time
+		expectOpen bool
+		expectNext time.Time
+	}{
+		{name: "no window", now: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC), expectOpen: true},
+		{name: "before the window", windows: saturdayNight, now: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC), expectNext: nextSaturday},
+		{name: "at the opening", windows: saturdayNight, now: nextSaturday, expectOpen: true},
+		{name: "in the window", windows: saturdayNight, now: nextSaturday.Add(90 * time.Minute), expectOpen: true},
+		{name: "after the window", windows: saturdayNight, now: nextSaturday.Add(2 * time.Hour), expectNext: nextSaturday.AddDate(0, 0, 7)},
+		{
+			name:       "earliest of several windows",
+			windows:    append([]v1alpha2.MaintenanceWindow{{Schedule: "0 22 * * *"}}, saturdayNight...),
+			now:        time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+			expectNext: time.Date(2026, 10, 16, 22, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			open, next, err := Open(tt.windows, tt.now)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if open != tt.expectOpen || !next.Equal(tt.expectNext) {
+				t.Errorf("Expected open %v and next %s, got %v and %s", tt.expectOpen, tt.expectNext, open, next)
+			}
+		})
+	}
+}
+
+func TestAllows(t *testing.T) {
+	pending := []metav1.Condition{{Type: utils.ChangesPendingStatusType, Status: metav1.ConditionTrue}}
+	closed := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	open := time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		windows      []v1alpha2.MaintenanceWindow
+		existing     []metav1.Condition
+		now          time.Time
+		expectAllow  bool
+		expectReason string
+	}{
+		{name: "no window", now: closed, expectAllow: true},
+		{name: "window closed", windows: saturdayNight, now: closed, expectReason: utils.ChangesPendingReasonDeferred},
+		{name: "window open", windows: saturdayNight, now: open, expectAllow: true},
+		{name: "deferred changes applied", windows: saturdayNight, existing: pending, now: open, expectAllow: true, expectReason: utils.ChangesPendingReasonApplied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statusMgr := status.NewManager(&fakes.FakeCustomCtrlClient{})
+			if allowed := Allows(statusMgr, tt.windows, "DaemonSet spire-agent", tt.existing, tt.now); allowed != tt.expectAllow {
+				t.Errorf("Expected allowed %v, got %v", tt.expectAllow, allowed)
+			}
+			cond, ok := statusMgr.GetCondition(utils.ChangesPendingStatusType)
+			if tt.expectReason == "" {
+				if ok {
+					t.Errorf("Expected no condition, got %v", cond)
+				}
+			} else if cond.Reason != tt.expectReason {
+				t.Errorf("Expected reason %s, got %s: %s", tt.expectReason, cond.Reason, cond.Message)
+			}
+		})
+	}
+}
+
+func TestRequeueAfter(t *testing.T) {
+	now := time.Date(2026, 10, 17, 1, 0, 0, 0, time.UTC)
+
+	statusMgr := status.NewManager(&fakes.FakeCustomCtrlClient{})
+	if requeueAfter := RequeueAfter(statusMgr, saturdayNight, 0, now); requeueAfter != 0 {
+		t.Errorf("Expected no requeue without deferred changes, got %s", requeueAfter)
+	}
+
+	if Allows(statusMgr, saturdayNight, "StatefulSet spire-server", nil, now) {
+		t.Fatal("Expected the update to be deferred")
+	}
+	if requeueAfter := RequeueAfter(statusMgr, saturdayNight, 0, now); requeueAfter != time.Hour {
+		t.Errorf("Expected a requeue at the opening of the window, got %s", requeueAfter)
+	}
+	if requeueAfter := RequeueAfter(statusMgr, saturdayNight, 5*time.Minute, now); requeueAfter != 5*time.Minute {
+		t.Errorf("Expected the earlier requeue to be kept, got %s", requeueAfter)
+	}
+}
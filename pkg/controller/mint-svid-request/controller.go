@@ -0,0 +1,252 @@
+package mint_svid_request
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/namespaceaccess"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/spirecli"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// Minted reports whether the SVID of the request was minted
+const Minted = "Minted"
+
+// Reasons of the Minted condition
+const (
+	MintedReasonMinted  = "Minted"
+	MintedReasonPending = "Pending"
+)
+
+// maxTTL bounds the validity of a minted SVID, which is never renewed nor revoked
+const maxTTL = 7 * 24 * time.Hour
+
+// MintSVIDRequestReconciler serves MintSVIDRequests by minting their SVID once
+type MintSVIDRequestReconciler struct {
+	ctrlClient    customClient.CustomCtrlClient
+	eventRecorder record.EventRecorder
+	log           logr.Logger
+	scheme        *runtime.Scheme
+	minter        svidMinter
+}
+
+// +kubebuilder:rbac:groups=operator.openshift.io,resources=mintsvidrequests,verbs=get;list;watch
+// +kubebuilder:rbac:groups=operator.openshift.io,resources=mintsvidrequests/status,verbs=get;update
+// +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create;get,namespace=zero-trust-workload-identity-manager
+
+// New returns a new Reconciler instance.
+func New(mgr ctrl.Manager) (*MintSVIDRequestReconciler, error) {
+	c, err := customClient.NewCustomClient(mgr)
+	if err != nil {
+		return nil, err
+	}
+	cli, err := spirecli.NewPodExecRunner(mgr.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+	return &MintSVIDRequestReconciler{
+		ctrlClient:    c,
+		eventRecorder: mgr.GetEventRecorderFor(utils.ZeroTrustWorkloadIdentityManagerMintSVIDRequestControllerName),
+		log:           ctrl.Log.WithName(utils.ZeroTrustWorkloadIdentityManagerMintSVIDRequestControllerName),
+		scheme:        mgr.GetScheme(),
+		minter:        &cliMinter{cli: cli},
+	}, nil
+}
+
+func (r *MintSVIDRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, reconcileErr error) {
+	traced := *r
+	ctx, traced.log, traced.eventRecorder = utils.TraceReconcile(ctx, r.log, r.eventRecorder)
+	r = &traced
+	r.log.Info("reconciling", "controller", utils.ZeroTrustWorkloadIdentityManagerMintSVIDRequestControllerName, "name", req.Name)
+	var request v1alpha2.MintSVIDRequest
+	if err := r.ctrlClient.Get(ctx, req.NamespacedName, &request); err != nil {
+		if kerrors.IsNotFound(err) {
+			status.Forget(&request, req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		return utils.ReconcileResult(err)
+	}
+
+	// A request is consumed once: it is never minted again, whether it was served or rejected.
+	// The access to the namespace of its Secret is revoked again if a previous pass failed to.
+	if minted := apimeta.FindStatusCondition(request.Status.Conditions, Minted); minted != nil &&
+		(minted.Status == metav1.ConditionTrue || minted.Reason == v1alpha2.ReasonFailed) {
+		return utils.ReconcileResult(r.revokeAccess(ctx, &request))
+	}
+
+	statusMgr := status.NewManager(r.ctrlClient)
+	defer func() {
+		if err := statusMgr.ApplyStatus(ctx, &request, func() *v1alpha2.ConditionalStatus {
+			return &request.Status.ConditionalStatus
+		}); err != nil {
+			r.log.Error(err, "failed to update status")
+		}
+		res = statusMgr.RequeueHeldBack(res, reconcileErr)
+	}()
+
+	err := r.mint(ctx, &request, statusMgr)
+	if err == nil || utils.ClassifyError(err) == utils.InvalidConfigurationError {
+		// The request is consumed, the operator no longer needs the access to the namespace
+		if revokeErr := r.revokeAccess(ctx, &request); revokeErr != nil {
+			return utils.ReconcileResult(revokeErr)
+		}
+	}
+	return utils.ReconcileResult(err)
+}
+
+// revokeAccess revokes the access to the namespace of the Secret of a consumed request
+func (r *MintSVIDRequestReconciler) revokeAccess(ctx context.Context, request *v1alpha2.MintSVIDRequest) error {
+	namespace := request.Spec.SecretRef.Namespace
+	if err := namespaceaccess.Revoke(ctx, r.ctrlClient, namespace); err != nil {
+		r.log.Error(err, "failed to revoke the access to the namespace of the Secret", "namespace", namespace)
+		return utils.FromClientError(err, "failed to revoke the access to namespace %s", namespace)
+	}
+	return nil
+}
+
+// validateRequest checks that the request mints an SVID of the trust domain, with a bounded TTL,
+// for none of the identities of SPIRE and of the operator: the SPIRE server and agents, the
+// workloads of the operator namespace, the break-glass identities and the admin IDs of the server.
+func validateRequest(spec *v1alpha2.MintSVIDRequestSpec, trustDomain string, adminIDs []string) error {
+	idTrustDomain, err := utils.ParseSPIFFEID(spec.SPIFFEID)
+	if err != nil {
+		return err
+	}
+	if idTrustDomain != trustDomain {
+		return fmt.Errorf("SPIFFE ID %q is not in the trust domain %s", spec.SPIFFEID, trustDomain)
+	}
+	reserved := []string{"/spire/", "/ztwim/"}
+	if namespace := utils.GetOperatorNamespace(); namespace != "" {
+		reserved = append(reserved, fmt.Sprintf("/ns/%s/", namespace))
+	}
+	path := strings.TrimPrefix(spec.SPIFFEID, "spiffe://"+trustDomain) + "/"
+	for _, prefix := range reserved {
+		if strings.HasPrefix(path, prefix) {
+			return fmt.Errorf("SPIFFE ID %q is reserved for the identities of SPIRE and of the operator", spec.SPIFFEID)
+		}
+	}
+	if slices.Contains(adminIDs, spec.SPIFFEID) {
+		return fmt.Errorf("SPIFFE ID %q is an admin ID of the SPIRE server", spec.SPIFFEID)
+	}
+	if spec.TTL != nil && spec.TTL.Duration > maxTTL {
+		return fmt.Errorf("ttl %s exceeds the maximum of %s", spec.TTL.Duration, maxTTL)
+	}
+	return nil
+}
+
+// mint mints the SVID of the request and writes it to its Secret
+func (r *MintSVIDRequestReconciler) mint(ctx context.Context, request *v1alpha2.MintSVIDRequest, statusMgr *status.Manager) error {
+	spec := request.Spec
+	secretName := fmt.Sprintf("%s/%s", spec.SecretRef.Namespace, spec.SecretRef.Name)
+
+	var ztwim v1alpha2.ZeroTrustWorkloadIdentityManager
+	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: "cluster"}, &ztwim); err != nil {
+		statusMgr.AddCondition(Minted, MintedReasonPending,
+			fmt.Sprintf("Failed to get the ZeroTrustWorkloadIdentityManager: %v", err),
+			metav1.ConditionFalse)
+		return utils.FromClientError(err, "failed to get ZeroTrustWorkloadIdentityManager")
+	}
+	var server v1alpha2.SpireServer
+	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: "cluster"}, &server); err != nil {
+		statusMgr.AddCondition(Minted, MintedReasonPending,
+			fmt.Sprintf("Failed to get the SpireServer: %v", err),
+			metav1.ConditionFalse)
+		return utils.FromClientError(err, "failed to get SpireServer")
+	}
+	if err := validateRequest(&spec, utils.TrustDomain(&ztwim), server.Spec.AdminIDs); err != nil {
+		statusMgr.AddCondition(Minted, v1alpha2.ReasonFailed, err.Error(), metav1.ConditionFalse)
+		return utils.NewInvalidConfigurationError(err, "invalid MintSVIDRequest")
+	}
+
+	if err := namespaceaccess.Grant(ctx, r.ctrlClient, spec.SecretRef.Namespace); err != nil {
+		statusMgr.AddCondition(Minted, MintedReasonPending,
+			fmt.Sprintf("Failed to get access to namespace %s: %v", spec.SecretRef.Namespace, err),
+			metav1.ConditionFalse)
+		return utils.FromClientError(err, "failed to get access to namespace %s", spec.SecretRef.Namespace)
+	}
+
+	// Secrets are not labelled as managed by the operator until created, so they are read from the API server
+	var existing corev1.Secret
+	if err := r.ctrlClient.GetUncached(ctx, types.NamespacedName{Name: spec.SecretRef.Name, Namespace: spec.SecretRef.Namespace}, &existing); err == nil {
+		if metav1.IsControlledBy(&existing, request) {
+			// The Secret was written by a previous pass whose status update failed
+			statusMgr.AddCondition(Minted, MintedReasonMinted,
+				fmt.Sprintf("SVID of %s written to Secret %s", spec.SPIFFEID, secretName),
+				metav1.ConditionTrue)
+			return nil
+		}
+		err = fmt.Errorf("secret %s already exists", secretName)
+		statusMgr.AddCondition(Minted, v1alpha2.ReasonFailed, fmt.Sprintf("Secret %s already exists, the SVID is not written to existing Secrets", secretName), metav1.ConditionFalse)
+		return utils.NewInvalidConfigurationError(err, "invalid MintSVIDRequest")
+	} else if !kerrors.IsNotFound(err) {
+		statusMgr.AddCondition(Minted, MintedReasonPending,
+			fmt.Sprintf("Failed to get Secret %s: %v", secretName, err),
+			metav1.ConditionFalse)
+		// The access granted to the namespace may not be effective yet
+		return utils.NewRetryRequiredError(err, "failed to get Secret %s", secretName)
+	}
+
+	svid, err := r.minter.Mint(ctx, utils.GetOperatorNamespace(), spec)
+	if err != nil {
+		statusMgr.AddCondition(Minted, MintedReasonPending,
+			fmt.Sprintf("Failed to mint the SVID of %s: %v", spec.SPIFFEID, err),
+			metav1.ConditionFalse)
+		return utils.NewRetryRequiredError(err, "failed to mint the SVID of %s", spec.SPIFFEID)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      spec.SecretRef.Name,
+			Namespace: spec.SecretRef.Namespace,
+			Labels:    map[string]string{utils.AppManagedByLabelKey: utils.AppManagedByLabelValue},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: svid.Data,
+	}
+	if spec.Type != v1alpha2.SVIDTypeJWT {
+		secret.Type = corev1.SecretTypeTLS
+	}
+	if err := controllerutil.SetControllerReference(request, secret, r.scheme); err != nil {
+		return utils.FromError(err, "failed to set the owner of Secret %s", secretName)
+	}
+	if err := r.ctrlClient.Create(ctx, secret); err != nil {
+		statusMgr.AddCondition(Minted, MintedReasonPending,
+			fmt.Sprintf("Failed to create Secret %s: %v", secretName, err),
+			metav1.ConditionFalse)
+		return utils.FromClientError(err, "failed to create Secret %s", secretName)
+	}
+
+	request.Status.ExpiresAt = &metav1.Time{Time: svid.ExpiresAt}
+	message := fmt.Sprintf("SVID of %s written to Secret %s", spec.SPIFFEID, secretName)
+	statusMgr.AddCondition(Minted, MintedReasonMinted, message, metav1.ConditionTrue)
+	r.eventRecorder.Event(request, corev1.EventTypeNormal, MintedReasonMinted, message)
+	r.log.Info("minted SVID", "spiffeID", spec.SPIFFEID, "secret", secretName, "expiresAt", svid.ExpiresAt)
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *MintSVIDRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha2.MintSVIDRequest{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named(utils.ZeroTrustWorkloadIdentityManagerMintSVIDRequestControllerName).
+		Complete(r)
+}
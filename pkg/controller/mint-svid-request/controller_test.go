@@ -0,0 +1,274 @@
+package mint_svid_request
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+)
+
+// fakeMinter returns a fixed SVID, or error
+type fakeMinter struct {
+	svid  *mintedSVID
+	err   error
+	calls int
+}
+
+func (f *fakeMinter) Mint(_ context.Context, _ string, _ v1alpha2.MintSVIDRequestSpec) (*mintedSVID, error) {
+	f.calls++
+	return f.svid, f.err
+}
+
+func TestReconcile(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	svid := &mintedSVID{Data: map[string][]byte{corev1.TLSCertKey: []byte("cert")}, ExpiresAt: expiresAt}
+	request := v1alpha2.MintSVIDRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "legacy-billing", UID: "request-uid"},
+		Spec: v1alpha2.MintSVIDRequestSpec{
+			SPIFFEID:  "spiffe://example.org/external/legacy-billing",
+			Type:      v1alpha2.SVIDTypeX509,
+			SecretRef: v1alpha2.SecretReference{Name: "legacy-billing-svid", Namespace: "billing"},
+		},
+	}
+	isController := true
+	ownedSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{
+		{UID: "request-uid", Controller: &isController},
+	}}}
+
+	tests := []struct {
+		name         string
+		spiffeID     string
+		ttl          *metav1.Duration
+		conditions   []metav1.Condition
+		secret       *corev1.Secret
+		grantAccess  bool
+		mintErr      error
+		expectReason string
+		expectStatus metav1.ConditionStatus
+		expectMints  int
+		expectCreate bool
+		expectRevoke bool
+		expectError  bool
+	}{
+		{
+			name:         "minted",
+			expectReason: MintedReasonMinted,
+			expectStatus: metav1.ConditionTrue,
+			expectMints:  1,
+			expectCreate: true,
+		},
+		{
+			name:         "minted in a namespace the operator is granted access to",
+			grantAccess:  true,
+			expectReason: MintedReasonMinted,
+			expectStatus: metav1.ConditionTrue,
+			expectMints:  1,
+			expectCreate: true,
+			expectRevoke: true,
+		},
+		{
+			name:         "access kept for the retry of a failed mint",
+			grantAccess:  true,
+			mintErr:      errors.New("connection refused"),
+			expectReason: MintedReasonPending,
+			expectStatus: metav1.ConditionFalse,
+			expectMints:  1,
+			expectError:  true,
+		},
+		{
+			name:         "access revoked for a rejected request",
+			grantAccess:  true,
+			secret:       &corev1.Secret{},
+			expectReason: v1alpha2.ReasonFailed,
+			expectStatus: metav1.ConditionFalse,
+			expectRevoke: true,
+		},
+		{
+			name:         "outside the trust domain",
+			spiffeID:     "spiffe://other.org/external/legacy-billing",
+			expectReason: v1alpha2.ReasonFailed,
+			expectStatus: metav1.ConditionFalse,
+		},
+		{
+			name:         "SPIRE server ID",
+			spiffeID:     "spiffe://example.org/spire/server",
+			expectReason: v1alpha2.ReasonFailed,
+			expectStatus: metav1.ConditionFalse,
+		},
+		{
+			name:         "break-glass ID",
+			spiffeID:     "spiffe://example.org/ztwim/break-glass/ns/default/sa/admin",
+			expectReason: v1alpha2.ReasonFailed,
+			expectStatus: metav1.ConditionFalse,
+		},
+		{
+			name:         "workload of the operator namespace",
+			spiffeID:     "spiffe://example.org/ns/test-ns/sa/spire-spiffe-oidc-discovery-provider",
+			expectReason: v1alpha2.ReasonFailed,
+			expectStatus: metav1.ConditionFalse,
+		},
+		{
+			name:         "admin ID of the server",
+			spiffeID:     "spiffe://example.org/ops/admin",
+			expectReason: v1alpha2.ReasonFailed,
+			expectStatus: metav1.ConditionFalse,
+		},
+		{
+			name:         "ttl above the maximum",
+			ttl:          &metav1.Duration{Duration: 30 * 24 * time.Hour},
+			expectReason: v1alpha2.ReasonFailed,
+			expectStatus: metav1.ConditionFalse,
+		},
+		{
+			name:         "secret exists",
+			secret:       &corev1.Secret{},
+			expectReason: v1alpha2.ReasonFailed,
+			expectStatus: metav1.ConditionFalse,
+		},
+		{
+			name:         "secret written by a previous pass",
+			secret:       ownedSecret,
+			expectReason: MintedReasonMinted,
+			expectStatus: metav1.ConditionTrue,
+		},
+		{
+			name:         "mint fails",
+			mintErr:      errors.New("connection refused"),
+			expectReason: MintedReasonPending,
+			expectStatus: metav1.ConditionFalse,
+			expectMints:  1,
+			expectError:  true,
+		},
+		{
+			name:       "already minted",
+			conditions: []metav1.Condition{{Type: Minted, Status: metav1.ConditionTrue, Reason: MintedReasonMinted}},
+		},
+		{
+			name:       "already rejected",
+			conditions: []metav1.Condition{{Type: Minted, Status: metav1.ConditionFalse, Reason: v1alpha2.ReasonFailed}},
+		},
+		{
+			name:         "access revoked again for a consumed request",
+			grantAccess:  true,
+			conditions:   []metav1.Condition{{Type: Minted, Status: metav1.ConditionTrue, Reason: MintedReasonMinted}},
+			expectRevoke: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+			if tt.grantAccess {
+				t.Setenv("OPERATOR_SERVICE_ACCOUNT", "controller-manager")
+			}
+			current := request.DeepCopy()
+			current.Status.Conditions = tt.conditions
+			if tt.spiffeID != "" {
+				current.Spec.SPIFFEID = tt.spiffeID
+			}
+			current.Spec.TTL = tt.ttl
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				switch o := obj.(type) {
+				case *v1alpha2.MintSVIDRequest:
+					current.DeepCopyInto(o)
+				case *v1alpha2.ZeroTrustWorkloadIdentityManager:
+					o.Spec.TrustDomain = "example.org"
+				case *v1alpha2.SpireServer:
+					o.Spec.AdminIDs = []string{"spiffe://example.org/ops/admin"}
+				}
+				return nil
+			}
+			fakeClient.GetUncachedStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				if _, ok := obj.(*rbacv1.RoleBinding); ok {
+					return kerrors.NewNotFound(rbacv1.Resource("rolebindings"), key.Name)
+				}
+				if tt.secret == nil {
+					return kerrors.NewNotFound(corev1.Resource("secrets"), key.Name)
+				}
+				tt.secret.DeepCopyInto(obj.(*corev1.Secret))
+				return nil
+			}
+			scheme := runtime.NewScheme()
+			_ = v1alpha2.AddToScheme(scheme)
+			minter := &fakeMinter{svid: svid, err: tt.mintErr}
+			r := &MintSVIDRequestReconciler{
+				ctrlClient:    fakeClient,
+				eventRecorder: record.NewFakeRecorder(10),
+				log:           logr.Discard(),
+				scheme:        scheme,
+				minter:        minter,
+			}
+
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKey{Name: request.Name}})
+			if (err != nil) != tt.expectError {
+				t.Fatalf("Expected error %v, got %v", tt.expectError, err)
+			}
+			if minter.calls != tt.expectMints {
+				t.Errorf("Expected %d mints, got %d", tt.expectMints, minter.calls)
+			}
+			if (fakeClient.CreateCallCount() == 1) != tt.expectCreate {
+				t.Fatalf("Expected create %v, got %d creates", tt.expectCreate, fakeClient.CreateCallCount())
+			}
+			if granted := fakeClient.CreateOrUpdateObjectCallCount() == 1; granted != (tt.grantAccess && tt.conditions == nil) {
+				t.Errorf("Expected access granted %v, got %v", tt.grantAccess, granted)
+			}
+			if revoked := fakeClient.DeleteCallCount() == 1; revoked != tt.expectRevoke {
+				t.Errorf("Expected access revoked %v, got %v", tt.expectRevoke, revoked)
+			}
+			if tt.expectRevoke {
+				_, obj, _ := fakeClient.DeleteArgsForCall(0)
+				if binding, ok := obj.(*rbacv1.RoleBinding); !ok || binding.Namespace != "billing" {
+					t.Errorf("Expected the RoleBinding of namespace billing to be deleted, got %v", obj)
+				}
+			}
+			if tt.expectCreate {
+				_, obj, _ := fakeClient.CreateArgsForCall(0)
+				secret := obj.(*corev1.Secret)
+				if secret.Namespace != "billing" || secret.Type != corev1.SecretTypeTLS || string(secret.Data[corev1.TLSCertKey]) != "cert" {
+					t.Errorf("Unexpected Secret %v", secret)
+				}
+				if len(secret.OwnerReferences) != 1 || secret.OwnerReferences[0].Name != request.Name {
+					t.Errorf("Expected the Secret to be owned by the request, got %v", secret.OwnerReferences)
+				}
+			}
+
+			if tt.expectReason == "" {
+				if fakeClient.StatusUpdateWithRetryCallCount() != 0 {
+					t.Errorf("Expected a consumed request to be left alone")
+				}
+				return
+			}
+			if fakeClient.StatusUpdateWithRetryCallCount() != 1 {
+				t.Fatalf("Expected 1 status update, got %d", fakeClient.StatusUpdateWithRetryCallCount())
+			}
+			_, obj, _ := fakeClient.StatusUpdateWithRetryArgsForCall(0)
+			updated := obj.(*v1alpha2.MintSVIDRequest)
+			var minted *metav1.Condition
+			for i := range updated.Status.Conditions {
+				if updated.Status.Conditions[i].Type == Minted {
+					minted = &updated.Status.Conditions[i]
+				}
+			}
+			if minted == nil || minted.Reason != tt.expectReason || minted.Status != tt.expectStatus {
+				t.Errorf("Expected Minted %s/%s, got %v", tt.expectStatus, tt.expectReason, minted)
+			}
+			if tt.expectCreate && (updated.Status.ExpiresAt == nil || !updated.Status.ExpiresAt.Time.Equal(expiresAt)) {
+				t.Errorf("Expected expiresAt %v, got %v", expiresAt, updated.Status.ExpiresAt)
+			}
+		})
+	}
+}
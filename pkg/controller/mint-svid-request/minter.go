@@ -0,0 +1,134 @@
+package mint_svid_request
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/spirecli"
+)
+
+// mintedSVID is an SVID minted by the SPIRE server
+type mintedSVID struct {
+	// Data is the content of the Secret the SVID is written to
+	Data      map[string][]byte
+	ExpiresAt time.Time
+}
+
+// svidMinter mints SVIDs with the SPIRE server
+type svidMinter interface {
+	Mint(ctx context.Context, namespace string, spec v1alpha2.MintSVIDRequestSpec) (*mintedSVID, error)
+}
+
+// mintCommand returns the spire-server CLI command minting the SVID of the spec
+func mintCommand(spec v1alpha2.MintSVIDRequestSpec) []string {
+	command := []string{"spire-server"}
+	if spec.Type == v1alpha2.SVIDTypeJWT {
+		command = append(command, "jwt", "mint", "-spiffeID", spec.SPIFFEID)
+		for _, audience := range spec.Audience {
+			command = append(command, "-audience", audience)
+		}
+	} else {
+		command = append(command, "x509", "mint", "-spiffeID", spec.SPIFFEID)
+	}
+	if spec.TTL != nil && spec.TTL.Duration > 0 {
+		command = append(command, "-ttl", spec.TTL.Duration.String())
+	}
+	return command
+}
+
+// parseMintOutput parses the output of the spire-server mint command into the SVID
+func parseMintOutput(svidType v1alpha2.SVIDType, output []byte) (*mintedSVID, error) {
+	if svidType == v1alpha2.SVIDTypeJWT {
+		return parseJWTSVID(strings.TrimSpace(string(output)))
+	}
+	return parseX509SVID(output)
+}
+
+// parseX509SVID parses the sections printed by `spire-server x509 mint`: the certificate chain
+// of the SVID, its private key and the root CAs, each following its header line
+func parseX509SVID(output []byte) (*mintedSVID, error) {
+	sections := map[string]*bytes.Buffer{}
+	var current *bytes.Buffer
+	for _, line := range strings.SplitAfter(string(output), "\n") {
+		switch strings.TrimSpace(line) {
+		case "X509-SVID:", "Private key:", "Root CAs:":
+			current = &bytes.Buffer{}
+			sections[strings.TrimSpace(line)] = current
+			continue
+		}
+		if current != nil {
+			current.WriteString(line)
+		}
+	}
+	chain, key, roots := sections["X509-SVID:"], sections["Private key:"], sections["Root CAs:"]
+	if chain == nil || key == nil || roots == nil {
+		return nil, fmt.Errorf("unexpected output of the mint command: X509-SVID, private key or root CAs missing")
+	}
+
+	block, _ := pem.Decode(chain.Bytes())
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("unexpected output of the mint command: no X509-SVID certificate")
+	}
+	leaf, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the minted X509-SVID: %w", err)
+	}
+	return &mintedSVID{
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       bytes.TrimSpace(chain.Bytes()),
+			corev1.TLSPrivateKeyKey: bytes.TrimSpace(key.Bytes()),
+			"ca.crt":                bytes.TrimSpace(roots.Bytes()),
+		},
+		ExpiresAt: leaf.NotAfter,
+	}, nil
+}
+
+// parseJWTSVID reads the expiry of the token printed by `spire-server jwt mint`
+func parseJWTSVID(token string) (*mintedSVID, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("unexpected output of the mint command: not a JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the minted JWT-SVID: %w", err)
+	}
+	var claims struct {
+		Expiry json.Number `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("failed to decode the minted JWT-SVID: %w", err)
+	}
+	exp, err := strconv.ParseInt(claims.Expiry.String(), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("minted JWT-SVID has no valid expiry: %w", err)
+	}
+	return &mintedSVID{
+		Data:      map[string][]byte{"token": []byte(token)},
+		ExpiresAt: time.Unix(exp, 0),
+	}, nil
+}
+
+// cliMinter mints the SVIDs with the spire-server CLI
+type cliMinter struct {
+	cli spirecli.Runner
+}
+
+func (c *cliMinter) Mint(ctx context.Context, namespace string, spec v1alpha2.MintSVIDRequestSpec) (*mintedSVID, error) {
+	output, err := c.cli.Run(ctx, namespace, mintCommand(spec))
+	if err != nil {
+		return nil, err
+	}
+	return parseMintOutput(spec.Type, output)
+}
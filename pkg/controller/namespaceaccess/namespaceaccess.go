@@ -0,0 +1,88 @@
+// Package namespaceaccess grants the operator access to the namespaces it writes objects to outside
+// of its own, e.g. the Secrets of the MintSVIDRequests. The operator only holds permissions on the
+// namespaced objects of its own namespace: the access to another namespace is granted on demand,
+// by binding the namespace-access ClusterRole shipped with the operator to it in that namespace,
+// and revoked once no object of the operator needs it anymore.
+package namespaceaccess
+
+import (
+	"context"
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// ClusterRoleName is the ClusterRole granting the access to the ConfigMaps and Secrets of a
+// namespace, also the name of the RoleBindings granting it
+const ClusterRoleName = "zero-trust-workload-identity-manager-namespace-access"
+
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=bind,resourceNames=zero-trust-workload-identity-manager-namespace-access
+
+// Grant binds the namespace-access ClusterRole to the operator in the namespace. Nothing is granted
+// in the operator namespace, covered by the Role of the operator, nor when the operator runs
+// outside the cluster with the permissions of its user.
+func Grant(ctx context.Context, c customClient.CustomCtrlClient, namespace string) error {
+	serviceAccount := utils.GetOperatorServiceAccount()
+	if serviceAccount == "" || namespace == utils.GetOperatorNamespace() {
+		return nil
+	}
+	desired := generateRoleBinding(namespace, serviceAccount)
+
+	// The RoleBindings outside the operator namespace are not cached, they are read from the API server
+	var existing rbacv1.RoleBinding
+	err := c.GetUncached(ctx, client.ObjectKeyFromObject(desired), &existing)
+	if err == nil && existing.RoleRef == desired.RoleRef && equality.Semantic.DeepEqual(existing.Subjects, desired.Subjects) {
+		return nil
+	}
+	if err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get RoleBinding %s/%s: %w", namespace, ClusterRoleName, err)
+	}
+	if err := c.CreateOrUpdateObject(ctx, desired); err != nil {
+		return fmt.Errorf("failed to grant the operator access to namespace %s: %w", namespace, err)
+	}
+	return nil
+}
+
+// Revoke deletes the RoleBinding granting the operator access to the namespace, unless a
+// SpiffeHelperConfig of the namespace still needs it to keep its ConfigMap up to date.
+func Revoke(ctx context.Context, c customClient.CustomCtrlClient, namespace string) error {
+	if utils.GetOperatorServiceAccount() == "" || namespace == utils.GetOperatorNamespace() {
+		return nil
+	}
+	var configs v1alpha2.SpiffeHelperConfigList
+	if err := c.List(ctx, &configs, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed to list the SpiffeHelperConfigs of namespace %s: %w", namespace, err)
+	}
+	if len(configs.Items) > 0 {
+		return nil
+	}
+	binding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: ClusterRoleName, Namespace: namespace}}
+	if err := c.Delete(ctx, binding); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to revoke the operator access to namespace %s: %w", namespace, err)
+	}
+	return nil
+}
+
+func generateRoleBinding(namespace, serviceAccount string) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ClusterRoleName,
+			Namespace: namespace,
+			Labels:    map[string]string{utils.AppManagedByLabelKey: utils.AppManagedByLabelValue},
+		},
+		RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: ClusterRoleName},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      serviceAccount,
+			Namespace: utils.GetOperatorNamespace(),
+		}},
+	}
+}
@@ -0,0 +1,172 @@
+package namespaceaccess
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+)
+
+func TestGrant(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+	tests := []struct {
+		name           string
+		serviceAccount string
+		namespace      string
+		existing       *rbacv1.RoleBinding
+		getErr         error
+		expectWrite    bool
+		expectError    bool
+	}{
+		{
+			name:           "granted",
+			serviceAccount: "controller-manager",
+			namespace:      "billing",
+			expectWrite:    true,
+		},
+		{
+			name:           "already granted",
+			serviceAccount: "controller-manager",
+			namespace:      "billing",
+			existing:       generateRoleBinding("billing", "controller-manager"),
+		},
+		{
+			name:           "granted to another service account",
+			serviceAccount: "controller-manager",
+			namespace:      "billing",
+			existing:       generateRoleBinding("billing", "previous-manager"),
+			expectWrite:    true,
+		},
+		{
+			name:           "operator namespace",
+			serviceAccount: "controller-manager",
+			namespace:      "test-ns",
+		},
+		{
+			name:      "outside the cluster",
+			namespace: "billing",
+		},
+		{
+			name:           "get fails",
+			serviceAccount: "controller-manager",
+			namespace:      "billing",
+			getErr:         errors.New("connection refused"),
+			expectError:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPERATOR_SERVICE_ACCOUNT", tt.serviceAccount)
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.GetUncachedStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				if tt.getErr != nil {
+					return tt.getErr
+				}
+				if tt.existing == nil {
+					return kerrors.NewNotFound(rbacv1.Resource("rolebindings"), key.Name)
+				}
+				tt.existing.DeepCopyInto(obj.(*rbacv1.RoleBinding))
+				return nil
+			}
+
+			err := Grant(context.Background(), fakeClient, tt.namespace)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("Expected error %v, got %v", tt.expectError, err)
+			}
+			if written := fakeClient.CreateOrUpdateObjectCallCount() == 1; written != tt.expectWrite {
+				t.Fatalf("Expected write %v, got %d writes", tt.expectWrite, fakeClient.CreateOrUpdateObjectCallCount())
+			}
+			if !tt.expectWrite {
+				return
+			}
+			_, obj := fakeClient.CreateOrUpdateObjectArgsForCall(0)
+			binding := obj.(*rbacv1.RoleBinding)
+			if binding.Namespace != "billing" || binding.RoleRef.Name != ClusterRoleName {
+				t.Errorf("Unexpected RoleBinding %v", binding)
+			}
+			if len(binding.Subjects) != 1 || binding.Subjects[0].Name != "controller-manager" || binding.Subjects[0].Namespace != "test-ns" {
+				t.Errorf("Expected the RoleBinding to grant the operator service account, got %v", binding.Subjects)
+			}
+		})
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+	tests := []struct {
+		name           string
+		serviceAccount string
+		namespace      string
+		configs        int
+		deleteErr      error
+		expectDelete   bool
+		expectError    bool
+	}{
+		{
+			name:           "revoked",
+			serviceAccount: "controller-manager",
+			namespace:      "billing",
+			expectDelete:   true,
+		},
+		{
+			name:           "already revoked",
+			serviceAccount: "controller-manager",
+			namespace:      "billing",
+			deleteErr:      kerrors.NewNotFound(rbacv1.Resource("rolebindings"), ClusterRoleName),
+			expectDelete:   true,
+		},
+		{
+			name:           "SpiffeHelperConfig left",
+			serviceAccount: "controller-manager",
+			namespace:      "billing",
+			configs:        1,
+		},
+		{
+			name:           "operator namespace",
+			serviceAccount: "controller-manager",
+			namespace:      "test-ns",
+		},
+		{
+			name:           "delete fails",
+			serviceAccount: "controller-manager",
+			namespace:      "billing",
+			deleteErr:      errors.New("connection refused"),
+			expectDelete:   true,
+			expectError:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPERATOR_SERVICE_ACCOUNT", tt.serviceAccount)
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				list.(*v1alpha2.SpiffeHelperConfigList).Items = make([]v1alpha2.SpiffeHelperConfig, tt.configs)
+				return nil
+			}
+			fakeClient.DeleteReturns(tt.deleteErr)
+
+			err := Revoke(context.Background(), fakeClient, tt.namespace)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("Expected error %v, got %v", tt.expectError, err)
+			}
+			if deleted := fakeClient.DeleteCallCount() == 1; deleted != tt.expectDelete {
+				t.Fatalf("Expected delete %v, got %d deletes", tt.expectDelete, fakeClient.DeleteCallCount())
+			}
+			if !tt.expectDelete {
+				return
+			}
+			_, obj, _ := fakeClient.DeleteArgsForCall(0)
+			if obj.GetNamespace() != "billing" || obj.GetName() != ClusterRoleName {
+				t.Errorf("Unexpected RoleBinding %s/%s deleted", obj.GetNamespace(), obj.GetName())
+			}
+		})
+	}
+}
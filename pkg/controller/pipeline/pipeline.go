@@ -0,0 +1,110 @@
+// Package pipeline runs the steps reconciling the resources of an operand. A failing step does not
+// stop the pipeline: the steps that do not depend on it still run, so a transient error on one
+// resource does not leave the others unverified until the next successful reconcile, and every
+// retry verifies all the resources again.
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// Step reconciles one resource, or a group of resources applied together
+type Step struct {
+	// Resource names what the step reconciles, e.g. "DaemonSet"
+	Resource string
+	// After lists the resources of earlier steps the step depends on. It is skipped when one of
+	// them failed or was skipped, e.g. a workload rolled from the hash of a ConfigMap not applied.
+	After []string
+	Run   func() error
+}
+
+// Run runs the steps in order and returns the failures of the steps, nil when they all succeed
+func Run(steps ...Step) error {
+	failures := &Failures{}
+	failed := map[string]bool{}
+	for _, step := range steps {
+		if anyFailed(failed, step.After) {
+			failed[step.Resource] = true
+			failures.skipped = append(failures.skipped, step.Resource)
+			continue
+		}
+		if err := step.Run(); err != nil {
+			failed[step.Resource] = true
+			failures.errs = append(failures.errs, failure{resource: step.Resource, err: err})
+		}
+	}
+	if len(failures.errs) == 0 {
+		return nil
+	}
+	return failures
+}
+
+func anyFailed(failed map[string]bool, resources []string) bool {
+	for _, resource := range resources {
+		if failed[resource] {
+			return true
+		}
+	}
+	return false
+}
+
+type failure struct {
+	resource string
+	err      error
+}
+
+// Failures is the error of a pipeline in which steps failed
+type Failures struct {
+	errs    []failure
+	skipped []string
+}
+
+// Error lists the failed steps with their errors, then the steps skipped because of them
+func (f *Failures) Error() string {
+	if len(f.errs) == 1 && len(f.skipped) == 0 {
+		return f.errs[0].err.Error()
+	}
+	messages := make([]string, 0, len(f.errs))
+	for _, failure := range f.errs {
+		messages = append(messages, fmt.Sprintf("%s: %v", failure.resource, failure.err))
+	}
+	message := strings.Join(messages, "; ")
+	if len(f.skipped) > 0 {
+		message += fmt.Sprintf(" (skipped %s)", strings.Join(f.skipped, ", "))
+	}
+	return message
+}
+
+// Unwrap returns the error classifying the failures: the first one that is retried, so the
+// resources failing transiently are retried even when others need a configuration change, or
+// else the first one
+func (f *Failures) Unwrap() error {
+	for _, failure := range f.errs {
+		if utils.ClassifyError(failure.err) == utils.RetryRequiredError {
+			return failure.err
+		}
+	}
+	return f.errs[0].err
+}
+
+// Resources returns the resources of the failed steps, in the order they ran
+func (f *Failures) Resources() []string {
+	resources := make([]string, 0, len(f.errs))
+	for _, failure := range f.errs {
+		resources = append(resources, failure.resource)
+	}
+	return resources
+}
+
+// FailedResources returns the resources of the failed steps when err is the error of a pipeline
+func FailedResources(err error) []string {
+	var failures *Failures
+	if errors.As(err, &failures) {
+		return failures.Resources()
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package pipeline
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func TestRun(t *testing.T) {
+	transient := errors.New("etcdserver: request timed out")
+
+	t.Run("all steps succeed", func(t *testing.T) {
+		var ran []string
+		step := func(resource string) Step {
+			return Step{Resource: resource, Run: func() error { ran = append(ran, resource); return nil }}
+		}
+		if err := Run(step("ServiceAccount"), step("ConfigMap"), step("DaemonSet")); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !reflect.DeepEqual(ran, []string{"ServiceAccount", "ConfigMap", "DaemonSet"}) {
+			t.Errorf("Expected the steps to run in order, got %v", ran)
+		}
+	})
+
+	t.Run("a failure does not stop independent steps", func(t *testing.T) {
+		var ran []string
+		err := Run(
+			Step{Resource: "Service", Run: func() error { return transient }},
+			Step{Resource: "ConfigMap", Run: func() error { ran = append(ran, "ConfigMap"); return nil }},
+			Step{Resource: "DaemonSet", After: []string{"ConfigMap"}, Run: func() error { ran = append(ran, "DaemonSet"); return nil }},
+		)
+		if !reflect.DeepEqual(ran, []string{"ConfigMap", "DaemonSet"}) {
+			t.Errorf("Expected the steps after the failure to run, got %v", ran)
+		}
+		if !errors.Is(err, transient) || err.Error() != transient.Error() {
+			t.Errorf("Expected the error of the failed step, got %v", err)
+		}
+		if resources := FailedResources(err); !reflect.DeepEqual(resources, []string{"Service"}) {
+			t.Errorf("Expected Service to have failed, got %v", resources)
+		}
+	})
+
+	t.Run("dependent steps are skipped", func(t *testing.T) {
+		err := Run(
+			Step{Resource: "ConfigMap", Run: func() error { return transient }},
+			Step{Resource: "DaemonSet", After: []string{"ConfigMap"}, Run: func() error {
+				t.Error("Expected the DaemonSet not to be applied without its ConfigMap")
+				return nil
+			}},
+			Step{Resource: "HealthProbe", After: []string{"DaemonSet"}, Run: func() error {
+				t.Error("Expected the steps depending on a skipped step to be skipped")
+				return nil
+			}},
+		)
+		if err == nil || !strings.Contains(err.Error(), "skipped DaemonSet, HealthProbe") {
+			t.Errorf("Expected the skipped steps to be reported, got %v", err)
+		}
+		if resources := FailedResources(err); !reflect.DeepEqual(resources, []string{"ConfigMap"}) {
+			t.Errorf("Expected only ConfigMap to have failed, got %v", resources)
+		}
+	})
+
+	t.Run("retried failures classify the error", func(t *testing.T) {
+		invalid := kerrors.NewInvalid(schema.GroupKind{Kind: "Route"}, "spire-server", nil)
+		err := Run(
+			Step{Resource: "Route", Run: func() error { return invalid }},
+			Step{Resource: "StatefulSet", Run: func() error { return transient }},
+		)
+		if utils.ClassifyError(err) != utils.RetryRequiredError {
+			t.Errorf("Expected the transient failure to be retried, got %v", utils.ClassifyError(err))
+		}
+		if !strings.Contains(err.Error(), "Route: ") || !strings.Contains(err.Error(), "StatefulSet: ") {
+			t.Errorf("Expected every failure in the error, got %v", err)
+		}
+	})
+
+	t.Run("not a pipeline error", func(t *testing.T) {
+		if resources := FailedResources(transient); resources != nil {
+			t.Errorf("Expected no failed resources, got %v", resources)
+		}
+	})
+}
@@ -0,0 +1,154 @@
+// Package servingcert serves the certificates the OpenShift service CA issues for the Services of
+// the operator. The Service is annotated with the name of the Secret the service CA writes the
+// certificate to, and the Secret is read through the API rather than mounted, so the operator
+// starts before the certificate is issued and serves the rotated certificate without restarting.
+package servingcert
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	// refreshInterval is how often the Secret is read again for a rotated certificate
+	refreshInterval = time.Minute
+
+	// retryInterval is how often the Service annotation is retried
+	retryInterval = 10 * time.Second
+)
+
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;patch,resourceNames=zero-trust-workload-identity-manager-metrics-service;zero-trust-workload-identity-manager-webhook-service,namespace=zero-trust-workload-identity-manager
+
+// errNotIssued is returned for the handshakes before the service CA issues the certificate
+var errNotIssued = errors.New("serving certificate not issued yet")
+
+// Source is a manager Runnable keeping the serving certificate of a Service of the operator
+type Source struct {
+	ctrlClient customClient.CustomCtrlClient
+	service    string
+	secret     string
+	log        logr.Logger
+
+	mu              sync.RWMutex
+	certificate     *tls.Certificate
+	resourceVersion string
+}
+
+// NewSource returns a Source of the certificate issued to the Service in the Secret. It is created
+// before the manager, for the TLS options of its servers, and started with SetupWithManager.
+func NewSource(service, secret string) *Source {
+	return &Source{
+		service: service,
+		secret:  secret,
+		log:     ctrl.Log.WithName("serving-cert").WithValues("service", service, "secret", secret),
+	}
+}
+
+// SetupWithManager adds the Source to the manager
+func (s *Source) SetupWithManager(mgr ctrl.Manager) error {
+	c, err := customClient.NewCustomClient(mgr)
+	if err != nil {
+		return err
+	}
+	s.ctrlClient = c
+	return mgr.Add(s)
+}
+
+// TLSOption sets the Source as the certificate of a TLS server
+func (s *Source) TLSOption(c *tls.Config) {
+	c.GetCertificate = s.GetCertificate
+}
+
+// GetCertificate returns the current certificate, for tls.Config
+func (s *Source) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.certificate == nil {
+		return nil, errNotIssued
+	}
+	return s.certificate, nil
+}
+
+// NeedLeaderElection makes every replica serve the certificate, the servers run on all of them
+func (s *Source) NeedLeaderElection() bool {
+	return false
+}
+
+// Start requests the certificate from the service CA and reloads it until the operator stops
+func (s *Source) Start(ctx context.Context) error {
+	_ = wait.PollUntilContextCancel(ctx, retryInterval, true, func(ctx context.Context) (bool, error) {
+		if err := s.annotateService(ctx); err != nil {
+			s.log.Error(err, "failed to request the serving certificate, retrying", "interval", retryInterval)
+			return false, nil
+		}
+		return true, nil
+	})
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := s.reload(ctx); err != nil {
+			s.log.Error(err, "failed to load the serving certificate, retrying", "interval", refreshInterval)
+		}
+	}, refreshInterval)
+	return nil
+}
+
+// annotateService requests the certificate of the Service from the service CA
+func (s *Source) annotateService(ctx context.Context) error {
+	// The Services of the operator are not labelled as managed by it, so they are read from the API server
+	var service corev1.Service
+	if err := s.ctrlClient.GetUncached(ctx, types.NamespacedName{Name: s.service, Namespace: utils.GetOperatorNamespace()}, &service); err != nil {
+		return fmt.Errorf("failed to get Service %s: %w", s.service, err)
+	}
+	if service.Annotations[utils.ServiceCAAnnotationKey] == s.secret {
+		return nil
+	}
+	patch := client.MergeFrom(service.DeepCopy())
+	if service.Annotations == nil {
+		service.Annotations = map[string]string{}
+	}
+	service.Annotations[utils.ServiceCAAnnotationKey] = s.secret
+	if err := s.ctrlClient.Patch(ctx, &service, patch); err != nil {
+		return fmt.Errorf("failed to annotate Service %s: %w", s.service, err)
+	}
+	s.log.Info("requested the serving certificate from the service CA")
+	return nil
+}
+
+// reload loads the certificate from the Secret when it changed
+func (s *Source) reload(ctx context.Context) error {
+	// The Secret is written by the service CA, not labelled as managed by the operator
+	var secret corev1.Secret
+	if err := s.ctrlClient.GetUncached(ctx, types.NamespacedName{Name: s.secret, Namespace: utils.GetOperatorNamespace()}, &secret); err != nil {
+		return fmt.Errorf("failed to get Secret %s: %w", s.secret, err)
+	}
+	s.mu.RLock()
+	unchanged := s.certificate != nil && s.resourceVersion == secret.ResourceVersion
+	s.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	certificate, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return fmt.Errorf("invalid serving certificate in Secret %s: %w", s.secret, err)
+	}
+	s.mu.Lock()
+	s.certificate = &certificate
+	s.resourceVersion = secret.ResourceVersion
+	s.mu.Unlock()
+	s.log.Info("loaded the serving certificate", "notAfter", certificate.Leaf.NotAfter)
+	return nil
+}
@@ -0,0 +1,118 @@
+package servingcert
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// testKeyPair returns a PEM encoded self-signed certificate and its key
+func testKeyPair(t *testing.T, serial int64) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{SerialNumber: big.NewInt(serial), NotBefore: time.Now(), NotAfter: time.Now().Add(time.Hour)}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestAnnotateService(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expectPatch bool
+	}{
+		{name: "not annotated", expectPatch: true},
+		{name: "annotated with another Secret", annotations: map[string]string{utils.ServiceCAAnnotationKey: "other"}, expectPatch: true},
+		{name: "already annotated", annotations: map[string]string{utils.ServiceCAAnnotationKey: "metrics-serving-cert"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.GetUncachedStub = func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				obj.SetAnnotations(tt.annotations)
+				return nil
+			}
+			source := NewSource("metrics-service", "metrics-serving-cert")
+			source.ctrlClient = fakeClient
+
+			if err := source.annotateService(context.Background()); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if patched := fakeClient.PatchCallCount() == 1; patched != tt.expectPatch {
+				t.Fatalf("Expected patch %v, got %v", tt.expectPatch, patched)
+			}
+			if tt.expectPatch {
+				_, obj, _, _ := fakeClient.PatchArgsForCall(0)
+				if obj.GetAnnotations()[utils.ServiceCAAnnotationKey] != "metrics-serving-cert" {
+					t.Errorf("Expected the Service annotated, got %v", obj.GetAnnotations())
+				}
+			}
+		})
+	}
+}
+
+func TestReload(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+	secret := &corev1.Secret{}
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	fakeClient.GetUncachedStub = func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+		secret.DeepCopyInto(obj.(*corev1.Secret))
+		return nil
+	}
+	source := NewSource("metrics-service", "metrics-serving-cert")
+	source.ctrlClient = fakeClient
+
+	if _, err := source.GetCertificate(&tls.ClientHelloInfo{}); err == nil {
+		t.Error("Expected an error before the certificate is issued")
+	}
+	if err := source.reload(context.Background()); err == nil {
+		t.Error("Expected an error for a Secret without certificate")
+	}
+
+	cert, key := testKeyPair(t, 1)
+	secret.ObjectMeta = metav1.ObjectMeta{ResourceVersion: "1"}
+	secret.Data = map[string][]byte{corev1.TLSCertKey: cert, corev1.TLSPrivateKeyKey: key}
+	if err := source.reload(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	served, err := source.GetCertificate(&tls.ClientHelloInfo{})
+	if err != nil || served.Leaf.SerialNumber.Int64() != 1 {
+		t.Fatalf("Expected the issued certificate, got %v, %v", served, err)
+	}
+
+	// The rotated certificate is served once the Secret is updated
+	cert, key = testKeyPair(t, 2)
+	secret.ResourceVersion = "2"
+	secret.Data = map[string][]byte{corev1.TLSCertKey: cert, corev1.TLSPrivateKeyKey: key}
+	if err := source.reload(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if served, _ = source.GetCertificate(&tls.ClientHelloInfo{}); served.Leaf.SerialNumber.Int64() != 2 {
+		t.Errorf("Expected the rotated certificate, got serial %v", served.Leaf.SerialNumber)
+	}
+}
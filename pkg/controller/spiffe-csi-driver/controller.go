@@ -3,6 +3,7 @@ package spiffe_csi_driver
 import (
 	"context"
 	"fmt"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -18,17 +19,27 @@ import (
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/go-logr/logr"
 
+	configv1 "github.com/openshift/api/config/v1"
 	securityv1 "github.com/openshift/api/security/v1"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/audit"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/breaker"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/dependencies"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/environment"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/maintenance"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/pipeline"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/topology"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 )
 
@@ -37,15 +48,20 @@ const (
 	SecurityContextConstraintsAvailable = "SecurityContextConstraintsAvailable"
 	ServiceAccountAvailable             = "ServiceAccountAvailable"
 	CSIDriverAvailable                  = "CSIDriverAvailable"
+
+	// SVIDFilesImageMissing is the reason of the ConfigurationValid condition when the SVID files
+	// delivery is requested without the image of the flavor of the driver
+	SVIDFilesImageMissing = "SVIDFilesImageMissing"
 )
 
 // SpiffeCsiReconciler reconciles a SpiffeCsi object
 type SpiffeCsiReconciler struct {
-	ctrlClient    customClient.CustomCtrlClient
-	ctx           context.Context
-	eventRecorder record.EventRecorder
-	log           logr.Logger
-	scheme        *runtime.Scheme
+	ctrlClient     customClient.CustomCtrlClient
+	ctx            context.Context
+	eventRecorder  record.EventRecorder
+	log            logr.Logger
+	scheme         *runtime.Scheme
+	failureBreaker *breaker.Breaker
 }
 
 // New returns a new Reconciler instance.
@@ -55,102 +71,198 @@ func New(mgr ctrl.Manager) (*SpiffeCsiReconciler, error) {
 		return nil, err
 	}
 	return &SpiffeCsiReconciler{
-		ctrlClient:    c,
-		ctx:           context.Background(),
-		eventRecorder: mgr.GetEventRecorderFor(utils.ZeroTrustWorkloadIdentityManagerSpiffeCsiDriverControllerName),
-		log:           ctrl.Log.WithName(utils.ZeroTrustWorkloadIdentityManagerSpiffeCsiDriverControllerName),
-		scheme:        mgr.GetScheme(),
+		ctrlClient:     c,
+		ctx:            context.Background(),
+		eventRecorder:  mgr.GetEventRecorderFor(utils.ZeroTrustWorkloadIdentityManagerSpiffeCsiDriverControllerName),
+		log:            ctrl.Log.WithName(utils.ZeroTrustWorkloadIdentityManagerSpiffeCsiDriverControllerName),
+		scheme:         mgr.GetScheme(),
+		failureBreaker: breaker.New(),
 	}, nil
 }
 
-func (r *SpiffeCsiReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	r.log.Info(fmt.Sprintf("reconciling %s", utils.ZeroTrustWorkloadIdentityManagerSpiffeCsiDriverControllerName))
-	var spiffeCSIDriver v1alpha1.SpiffeCSIDriver
+func (r *SpiffeCsiReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, reconcileErr error) {
+	traced := *r
+	ctx, traced.log, traced.eventRecorder = utils.TraceReconcile(ctx, r.log, r.eventRecorder)
+	r = &traced
+	r.log.Info("reconciling", "controller", utils.ZeroTrustWorkloadIdentityManagerSpiffeCsiDriverControllerName, "name", req.Name)
+	var spiffeCSIDriver v1alpha2.SpiffeCSIDriver
 	if err := r.ctrlClient.Get(ctx, req.NamespacedName, &spiffeCSIDriver); err != nil {
 		if kerrors.IsNotFound(err) {
 			r.log.Info("SpiffeCsiDriver resource not found. Ignoring since object must be deleted or not been created.")
+			status.Forget(&spiffeCSIDriver, req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
-		return ctrl.Result{}, err
+		return utils.ReconcileResult(err)
 	}
 
 	// Set Ready to false at the start of reconciliation
-	status.SetInitialReconciliationStatus(ctx, r.ctrlClient, &spiffeCSIDriver, func() *v1alpha1.ConditionalStatus {
+	status.SetInitialReconciliationStatus(ctx, r.ctrlClient, &spiffeCSIDriver, func() *v1alpha2.ConditionalStatus {
 		return &spiffeCSIDriver.Status.ConditionalStatus
 	}, "SpiffeCSIDriver")
 
 	statusMgr := status.NewManager(r.ctrlClient)
 	defer func() {
-		if err := statusMgr.ApplyStatus(ctx, &spiffeCSIDriver, func() *v1alpha1.ConditionalStatus {
+		statusMgr.ReportWorkloadVersion(ctx, &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "spire-spiffe-csi-driver", Namespace: utils.GetOperatorNamespace()}})
+		if err := statusMgr.ApplyStatus(ctx, &spiffeCSIDriver, func() *v1alpha2.ConditionalStatus {
 			return &spiffeCSIDriver.Status.ConditionalStatus
 		}); err != nil {
 			r.log.Error(err, "failed to update status")
 		}
+		res = statusMgr.RequeueHeldBack(res, reconcileErr)
 	}()
 
-	var ztwim v1alpha1.ZeroTrustWorkloadIdentityManager
+	var ztwim v1alpha2.ZeroTrustWorkloadIdentityManager
 	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: "cluster"}, &ztwim); err != nil {
 		if kerrors.IsNotFound(err) {
 			r.log.Error(err, "failed to get ZeroTrustWorkloadIdentityManager")
-			statusMgr.AddCondition(v1alpha1.Ready, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(v1alpha2.Ready, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to retrieve ZeroTrustWorkloadIdentityManager from cluster"),
 				metav1.ConditionFalse)
 			return ctrl.Result{}, nil
 		}
-		return ctrl.Result{}, err
+		return utils.ReconcileResult(err)
 	}
+	statusMgr.SetGracePeriod(ztwim.Spec.StatusGracePeriod)
 
 	// Set ZTWIM as the owner of SpiffeCSIDriver only if needed
 	if utils.NeedsOwnerReferenceUpdate(&spiffeCSIDriver, &ztwim) {
 		if err := controllerutil.SetControllerReference(&ztwim, &spiffeCSIDriver, r.scheme); err != nil {
 			r.log.Error(err, "failed to set controller reference on SpiffeCSIDriver")
-			statusMgr.AddCondition(v1alpha1.Ready, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(v1alpha2.Ready, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to set owner reference on SpiffeCSIDriver: %v", err),
 				metav1.ConditionFalse)
-			return ctrl.Result{}, err
+			return utils.ReconcileResult(err)
 		}
 
 		// Persist the owner reference to the cluster
 		if err := r.ctrlClient.Update(ctx, &spiffeCSIDriver); err != nil {
 			r.log.Error(err, "failed to update SpiffeCSIDriver with owner reference")
-			statusMgr.AddCondition(v1alpha1.Ready, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(v1alpha2.Ready, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to update SpiffeCSIDriver with owner reference: %v", err),
 				metav1.ConditionFalse)
-			return ctrl.Result{}, err
+			return utils.ReconcileResult(err)
 		}
 	}
 
+	// Fill in the settings left unset from the sizing and topology profiles, in memory only
+	topologyProfile, err := topology.Resolve(ctx, r.ctrlClient, &ztwim)
+	if err != nil {
+		r.log.Error(err, "failed to resolve the topology profile")
+		return utils.ReconcileResult(err)
+	}
+	topology.ApplyToOperand(topologyProfile, ztwim.Spec.SizingProfile, utils.ResourceKindSpiffeCSIDriver, &spiffeCSIDriver.Spec.CommonConfig)
+	utils.ApplyImagePullSecrets(ztwim.Spec.ImagePullSecrets, &spiffeCSIDriver.Spec.CommonConfig)
+
 	// Handle create-only mode
 	createOnlyMode := r.handleCreateOnlyMode(&spiffeCSIDriver, statusMgr)
 
 	// Validate common configuration
 	if err := r.validateCommonConfig(&spiffeCSIDriver, statusMgr); err != nil {
+		statusMgr.SetDegradedCondition(utils.NewInvalidConfigurationError(err, "configuration validation failed"), spiffeCSIDriver.Status.Conditions)
 		return ctrl.Result{}, nil
 	}
 
-	// Reconcile static resources (ServiceAccount, CSI Driver)
-	if err := r.reconcileServiceAccount(ctx, &spiffeCSIDriver, statusMgr, createOnlyMode); err != nil {
-		return ctrl.Result{}, err
+	// Validate the flavor of the driver delivering the SVIDs is available
+	if err := r.validateSVIDDelivery(&spiffeCSIDriver, statusMgr); err != nil {
+		statusMgr.SetDegradedCondition(utils.NewInvalidConfigurationError(err, "configuration validation failed"), spiffeCSIDriver.Status.Conditions)
+		return ctrl.Result{}, nil
 	}
 
-	if err := r.reconcileCSIDriver(ctx, &spiffeCSIDriver, statusMgr, createOnlyMode); err != nil {
-		return ctrl.Result{}, err
+	// Validate the maintenance windows deferring the DaemonSet updates
+	if err := maintenance.Validate(ztwim.Spec.MaintenanceWindows); err != nil {
+		r.log.Error(err, "Invalid maintenance windows")
+		statusMgr.AddCondition(utils.ConditionTypeConfigurationValid, maintenance.ReasonInvalidWindow, err.Error(), metav1.ConditionFalse)
+		statusMgr.SetDegradedCondition(utils.NewInvalidConfigurationError(err, "configuration validation failed"), spiffeCSIDriver.Status.Conditions)
+		return ctrl.Result{}, nil
 	}
 
-	// Reconcile SCC
-	if err := r.reconcileSCC(ctx, &spiffeCSIDriver, statusMgr); err != nil {
-		return ctrl.Result{}, err
+	// Kinds of generated resources left to the user
+	unmanagedKinds := utils.UnmanagedKinds(spiffeCSIDriver.Spec.ManagedResources)
+
+	// Stop at the resources of another SPIFFE CSI driver install rather than fighting over them
+	if err := r.reportEnvironmentConflicts(ctx, &spiffeCSIDriver, statusMgr, unmanagedKinds); err != nil {
+		statusMgr.SetDegradedCondition(err, spiffeCSIDriver.Status.Conditions)
+		if utils.ClassifyError(err) == utils.InvalidConfigurationError {
+			statusMgr.AddCondition(utils.ConditionTypeConfigurationValid, utils.EnvironmentConflictStatusType, err.Error(), metav1.ConditionFalse)
+			// The conflicting resources are not watched, check them again periodically
+			return ctrl.Result{RequeueAfter: environment.RecheckInterval}, nil
+		}
+		return utils.ReconcileResult(err)
 	}
 
-	// Reconcile DaemonSet
-	if err := r.reconcileDaemonSet(ctx, &spiffeCSIDriver, statusMgr, createOnlyMode); err != nil {
-		return ctrl.Result{}, err
+	// Compute the pending changes without applying them when the dry-run annotation is set
+	if utils.IsDryRunRequested(&spiffeCSIDriver) {
+		dryRunClient := customClient.NewDryRunClient(r.ctrlClient)
+		dryRunReconciler := *r
+		dryRunReconciler.ctrlClient = customClient.NewUnmanagedClient(dryRunClient, unmanagedKinds)
+		err := dryRunReconciler.reconcileResources(ctx, &spiffeCSIDriver, status.NewManager(dryRunClient), &ztwim, createOnlyMode)
+		statusMgr.ReportDryRun(dryRunClient.Changes(), err)
+		return ctrl.Result{}, nil
+	}
+	statusMgr.ClearDryRun(spiffeCSIDriver.Status.Conditions)
+
+	// Hold back the creation of the driver until the SPIRE server is Ready
+	wait, err := statusMgr.WaitForSpireServer(ctx, &ztwim, &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "spire-spiffe-csi-driver", Namespace: utils.GetOperatorNamespace()}}, spiffeCSIDriver.Status.Conditions)
+	if err != nil {
+		r.log.Error(err, "failed to check the SpireServer dependency")
+		return utils.ReconcileResult(err)
+	}
+	if wait {
+		r.log.Info("Waiting for the SpireServer to be Ready before creating the SPIFFE CSI driver")
+		return ctrl.Result{}, nil
+	}
+
+	// Record the resources changed for this generation in the audit trail, leaving the
+	// resources of unmanaged kinds alone
+	recordingClient := customClient.NewRecordingClient(r.ctrlClient)
+	unmanagedClient := customClient.NewUnmanagedClient(recordingClient, unmanagedKinds)
+	auditedReconciler := *r
+	auditedReconciler.ctrlClient = unmanagedClient
+	err = auditedReconciler.reconcileResources(ctx, &spiffeCSIDriver, statusMgr, &ztwim, createOnlyMode)
+	if auditErr := audit.Append(ctx, r.ctrlClient, r.scheme, r.eventRecorder, &spiffeCSIDriver, spiffeCSIDriver.Spec, recordingClient.Changes()); auditErr != nil {
+		r.log.Error(auditErr, "failed to record audit trail")
+	}
+	statusMgr.ReportUnmanagedResources(unmanagedKinds, unmanagedClient.Skipped(), spiffeCSIDriver.Status.Conditions)
+	// Fail closed on the resources missing in create-only mode rather than reporting stale conditions
+	if createOnlyMode {
+		statusMgr.CheckRequiredResources(ctx, r.eventRecorder, &spiffeCSIDriver, spiffeCSIDriver.Status.Conditions, requiredResources()...)
+	}
+	statusMgr.SetDegradedCondition(err, spiffeCSIDriver.Status.Conditions)
+	result, err := r.failureBreaker.Result(r.eventRecorder, &spiffeCSIDriver, statusMgr, recordingClient.Failure(), err)
+	if err == nil {
+		// Apply the deferred changes when the next maintenance window opens
+		result.RequeueAfter = maintenance.RequeueAfter(statusMgr, ztwim.Spec.MaintenanceWindows, result.RequeueAfter, time.Now())
 	}
+	return result, err
+}
+
+// reconcileResources reconciles all resources managed for the SpiffeCSIDriver
+func (r *SpiffeCsiReconciler) reconcileResources(ctx context.Context, spiffeCSIDriver *v1alpha2.SpiffeCSIDriver, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, createOnlyMode bool) error {
+	err := pipeline.Run(
+		// Reconcile static resources (ServiceAccount, CSI Driver)
+		pipeline.Step{Resource: "ServiceAccount", Run: func() error {
+			return r.reconcileServiceAccount(ctx, spiffeCSIDriver, statusMgr, createOnlyMode)
+		}},
+		pipeline.Step{Resource: "CSIDriver", Run: func() error {
+			return r.reconcileCSIDriver(ctx, spiffeCSIDriver, statusMgr, createOnlyMode)
+		}},
+		// Reconcile SCC
+		pipeline.Step{Resource: "SCC", Run: func() error {
+			return r.reconcileSCC(ctx, spiffeCSIDriver, statusMgr)
+		}},
+		// Reconcile DaemonSet
+		pipeline.Step{Resource: "DaemonSet", Run: func() error {
+			return r.reconcileDaemonSet(ctx, spiffeCSIDriver, statusMgr, ztwim, createOnlyMode)
+		}},
+	)
 
-	return ctrl.Result{}, nil
+	// Check the kubelet and container runtime of the nodes running the driver
+	r.reconcileNodeCompatibility(ctx, spiffeCSIDriver, statusMgr)
+
+	return err
 }
 
-func (r *SpiffeCsiReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *SpiffeCsiReconciler) SetupWithManager(mgr ctrl.Manager, dependencyCache cache.Cache) error {
 	// Always enqueue the "cluster" CR for reconciliation
 	mapFunc := func(ctx context.Context, _ client.Object) []reconcile.Request {
 		return []reconcile.Request{
@@ -165,23 +277,46 @@ func (r *SpiffeCsiReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// Use component-specific predicate to only reconcile for csi component resources
 	controllerManagedResourcePredicates := builder.WithPredicates(utils.ControllerManagedResourcesForComponent(utils.ComponentCSI))
 
-	err := ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.SpiffeCSIDriver{}, builder.WithPredicates(utils.GenerationOrOwnerReferenceChangedPredicate)).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha2.SpiffeCSIDriver{}, builder.WithPredicates(predicate.Or(utils.GenerationOrOwnerReferenceChangedPredicate, predicate.AnnotationChangedPredicate{}))).
 		Named(utils.ZeroTrustWorkloadIdentityManagerSpiffeCsiDriverControllerName).
 		Watches(&appsv1.DaemonSet{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
 		Watches(&corev1.ServiceAccount{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
 		Watches(&storagev1.CSIDriver{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
-		Watches(&securityv1.SecurityContextConstraints{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
-		Watches(&v1alpha1.ZeroTrustWorkloadIdentityManager{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(utils.ZTWIMSpecChangedPredicate)).
-		Complete(r)
+		// Re-evaluate deferred upgrades once the SPIRE agents finish rolling out
+		Watches(&appsv1.DaemonSet{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(utils.ControllerManagedResourcesForComponent(utils.ComponentNodeAgent))).
+		Watches(&v1alpha2.ZeroTrustWorkloadIdentityManager{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(utils.ZTWIMSpecChangedPredicate)).
+		// The creation of the driver waits for the SPIRE server to be Ready
+		Watches(&v1alpha2.SpireServer{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(utils.ReadyConditionChangedPredicate))
+	if utils.HasCapability(utils.CapabilitySecurityContextConstraints) {
+		controllerBuilder = controllerBuilder.Watches(&securityv1.SecurityContextConstraints{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates)
+	}
+	if utils.HasCapability(utils.CapabilityInfrastructure) {
+		// Re-evaluate the topology defaults when the cluster changes between single-node and highly available
+		controllerBuilder = controllerBuilder.Watches(&configv1.Infrastructure{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(topology.ChangedPredicate))
+	}
+
+	// Roll the driver when the objects mounted by its pods change
+	err := dependencies.Watch(controllerBuilder, dependencyCache, func(ctx context.Context) []dependencies.Reference {
+		return dependencies.FromWorkload(ctx, r.ctrlClient, &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "spire-spiffe-csi-driver", Namespace: utils.GetOperatorNamespace()}})
+	}).Complete(r)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// requiredResources returns the resources the CSI driver cannot run without
+func requiredResources() []client.Object {
+	namespace := utils.GetOperatorNamespace()
+	return []client.Object{
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "spire-spiffe-csi-driver", Namespace: namespace}},
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "spire-spiffe-csi-driver", Namespace: namespace}},
+	}
+}
+
 // handleCreateOnlyMode checks and updates the create-only mode status
-func (r *SpiffeCsiReconciler) handleCreateOnlyMode(driver *v1alpha1.SpiffeCSIDriver, statusMgr *status.Manager) bool {
+func (r *SpiffeCsiReconciler) handleCreateOnlyMode(driver *v1alpha2.SpiffeCSIDriver, statusMgr *status.Manager) bool {
 	createOnlyMode := utils.IsInCreateOnlyMode()
 	if createOnlyMode {
 		r.log.Info("Running in create-only mode - will create resources if they don't exist but skip updates")
@@ -199,8 +334,32 @@ func (r *SpiffeCsiReconciler) handleCreateOnlyMode(driver *v1alpha1.SpiffeCSIDri
 	return createOnlyMode
 }
 
-// validateCommonConfig validates common configuration fields (affinity, tolerations, nodeSelector, resources, labels)
-func (r *SpiffeCsiReconciler) validateCommonConfig(driver *v1alpha1.SpiffeCSIDriver, statusMgr *status.Manager) error {
+// validateSVIDDelivery validates the image of the SVID files flavor of the driver is configured when requested
+func (r *SpiffeCsiReconciler) validateSVIDDelivery(driver *v1alpha2.SpiffeCSIDriver, statusMgr *status.Manager) error {
+	if driver.Spec.SVIDDelivery != v1alpha2.SVIDDeliverySVIDFiles || utils.GetSpiffeCSIDriverSVIDFilesImage() != "" {
+		return nil
+	}
+	err := fmt.Errorf("svidDelivery %s requires the image of the SVID files flavor of the driver, set with %s", v1alpha2.SVIDDeliverySVIDFiles, utils.SpiffeCSIDriverSVIDFilesImageEnv)
+	r.log.Error(err, "invalid SVID delivery")
+	statusMgr.AddCondition(utils.ConditionTypeConfigurationValid, SVIDFilesImageMissing, err.Error(), metav1.ConditionFalse)
+	return err
+}
+
+// validateCommonConfig validates common configuration fields (architectures, DNS, affinity, tolerations, nodeSelector, resources, labels)
+func (r *SpiffeCsiReconciler) validateCommonConfig(driver *v1alpha2.SpiffeCSIDriver, statusMgr *status.Manager) error {
+	if err := utils.ValidateArchitectures(driver.Spec.Architectures, utils.GetSupportedArchitectures()); err != nil {
+		r.log.Error(err, "invalid architectures")
+		statusMgr.AddCondition(utils.ConditionTypeConfigurationValid, utils.ArchitecturesReasonNoneSchedulable, err.Error(), metav1.ConditionFalse)
+		return err
+	}
+
+	if err := utils.ValidateCommonConfigDNS(driver.Spec.DNSPolicy, driver.Spec.DNSConfig); err != nil {
+		r.log.Error(err, "invalid DNS configuration")
+		statusMgr.AddCondition(utils.ConditionTypeConfigurationValid, utils.ConditionReasonInvalidDNSConfig,
+			fmt.Sprintf("DNS configuration validation failed: %v", err), metav1.ConditionFalse)
+		return err
+	}
+
 	return utils.ValidateAndUpdateStatus(
 		r.log,
 		statusMgr,
@@ -6,7 +6,7 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	corev1 "k8s.io/api/core/v1"
@@ -84,7 +84,7 @@ func TestReconcile_ZTWIMNotFound(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	csiDriver := &v1alpha1.SpiffeCSIDriver{
+	csiDriver := &v1alpha2.SpiffeCSIDriver{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 		},
@@ -95,7 +95,7 @@ func TestReconcile_ZTWIMNotFound(t *testing.T) {
 		callCount++
 		switch callCount {
 		case 1: // First call: Get SpiffeCSIDriver
-			if csi, ok := obj.(*v1alpha1.SpiffeCSIDriver); ok {
+			if csi, ok := obj.(*v1alpha2.SpiffeCSIDriver); ok {
 				*csi = *csiDriver
 			}
 			return nil
@@ -123,7 +123,7 @@ func TestReconcile_ZTWIMGetError(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	csiDriver := &v1alpha1.SpiffeCSIDriver{
+	csiDriver := &v1alpha2.SpiffeCSIDriver{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 		},
@@ -135,7 +135,7 @@ func TestReconcile_ZTWIMGetError(t *testing.T) {
 		callCount++
 		switch callCount {
 		case 1: // First call: Get SpiffeCSIDriver
-			if csi, ok := obj.(*v1alpha1.SpiffeCSIDriver); ok {
+			if csi, ok := obj.(*v1alpha2.SpiffeCSIDriver); ok {
 				*csi = *csiDriver
 			}
 			return nil
@@ -168,18 +168,18 @@ func TestReconcile_OwnerReferenceUpdateError(t *testing.T) {
 
 	// Register types in scheme for SetControllerReference
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	reconciler.scheme = scheme
 
 	// SpiffeCSIDriver without owner reference (needs update)
-	csiDriver := &v1alpha1.SpiffeCSIDriver{
+	csiDriver := &v1alpha2.SpiffeCSIDriver{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 		},
 	}
 
 	// ZTWIM with proper metadata
-	ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
@@ -191,12 +191,12 @@ func TestReconcile_OwnerReferenceUpdateError(t *testing.T) {
 		callCount++
 		switch callCount {
 		case 1: // Get SpiffeCSIDriver
-			if csi, ok := obj.(*v1alpha1.SpiffeCSIDriver); ok {
+			if csi, ok := obj.(*v1alpha2.SpiffeCSIDriver); ok {
 				*csi = *csiDriver
 			}
 			return nil
 		case 2: // Get ZTWIM
-			if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+			if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 				*z = *ztwim
 			}
 			return nil
@@ -226,7 +226,7 @@ func TestHandleCreateOnlyMode_Enabled(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	driver := &v1alpha1.SpiffeCSIDriver{
+	driver := &v1alpha2.SpiffeCSIDriver{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
 	}
 
@@ -247,7 +247,7 @@ func TestHandleCreateOnlyMode_Disabled(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	driver := &v1alpha1.SpiffeCSIDriver{
+	driver := &v1alpha2.SpiffeCSIDriver{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
 	}
 
@@ -269,10 +269,10 @@ func TestHandleCreateOnlyMode_DisabledWithPreviouslyEnabled(t *testing.T) {
 	reconciler := newTestReconciler(fakeClient)
 
 	// Driver with existing CreateOnlyMode condition set to True
-	driver := &v1alpha1.SpiffeCSIDriver{
+	driver := &v1alpha2.SpiffeCSIDriver{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Status: v1alpha1.SpiffeCSIDriverStatus{
-			ConditionalStatus: v1alpha1.ConditionalStatus{
+		Status: v1alpha2.SpiffeCSIDriverStatus{
+			ConditionalStatus: v1alpha2.ConditionalStatus{
 				Conditions: []metav1.Condition{
 					{
 						Type:   "CreateOnlyMode",
@@ -297,9 +297,9 @@ func TestValidateCommonConfig_ValidConfig(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	driver := &v1alpha1.SpiffeCSIDriver{
+	driver := &v1alpha2.SpiffeCSIDriver{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec:       v1alpha1.SpiffeCSIDriverSpec{},
+		Spec:       v1alpha2.SpiffeCSIDriverSpec{},
 	}
 
 	statusMgr := status.NewManager(fakeClient)
@@ -316,10 +316,10 @@ func TestValidateCommonConfig_InvalidAffinity(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	driver := &v1alpha1.SpiffeCSIDriver{
+	driver := &v1alpha2.SpiffeCSIDriver{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec: v1alpha1.SpiffeCSIDriverSpec{
-			CommonConfig: v1alpha1.CommonConfig{
+		Spec: v1alpha2.SpiffeCSIDriverSpec{
+			CommonConfig: v1alpha2.CommonConfig{
 				Affinity: &corev1.Affinity{
 					NodeAffinity: &corev1.NodeAffinity{
 						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
@@ -346,7 +346,7 @@ func TestHandleCreateOnlyMode_NotSet(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	driver := &v1alpha1.SpiffeCSIDriver{
+	driver := &v1alpha2.SpiffeCSIDriver{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
 	}
 
@@ -400,7 +400,7 @@ func TestReconcile_FullFlow(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &SpiffeCsiReconciler{
 		ctrlClient:    fakeClient,
@@ -410,7 +410,7 @@ func TestReconcile_FullFlow(t *testing.T) {
 		eventRecorder: record.NewFakeRecorder(100),
 	}
 
-	csiDriver := &v1alpha1.SpiffeCSIDriver{
+	csiDriver := &v1alpha2.SpiffeCSIDriver{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			OwnerReferences: []metav1.OwnerReference{
@@ -422,25 +422,25 @@ func TestReconcile_FullFlow(t *testing.T) {
 				},
 			},
 		},
-		Spec: v1alpha1.SpiffeCSIDriverSpec{},
+		Spec: v1alpha2.SpiffeCSIDriverSpec{},
 	}
 
-	ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
 		},
-		Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 			TrustDomain: "example.org",
 		},
 	}
 
 	fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 		switch v := obj.(type) {
-		case *v1alpha1.SpiffeCSIDriver:
+		case *v1alpha2.SpiffeCSIDriver:
 			*v = *csiDriver
 			return nil
-		case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+		case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 			*v = *ztwim
 			return nil
 		default:
@@ -468,10 +468,10 @@ func TestValidateCommonConfig_WithTolerations(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	driver := &v1alpha1.SpiffeCSIDriver{
+	driver := &v1alpha2.SpiffeCSIDriver{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec: v1alpha1.SpiffeCSIDriverSpec{
-			CommonConfig: v1alpha1.CommonConfig{
+		Spec: v1alpha2.SpiffeCSIDriverSpec{
+			CommonConfig: v1alpha2.CommonConfig{
 				Tolerations: []*corev1.Toleration{
 					{
 						Key:      "node-role.kubernetes.io/master",
@@ -496,10 +496,10 @@ func TestValidateCommonConfig_WithNodeSelector(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	driver := &v1alpha1.SpiffeCSIDriver{
+	driver := &v1alpha2.SpiffeCSIDriver{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec: v1alpha1.SpiffeCSIDriverSpec{
-			CommonConfig: v1alpha1.CommonConfig{
+		Spec: v1alpha2.SpiffeCSIDriverSpec{
+			CommonConfig: v1alpha2.CommonConfig{
 				NodeSelector: map[string]string{
 					"kubernetes.io/os": "linux",
 				},
@@ -520,10 +520,10 @@ func TestValidateCommonConfig_WithLabels(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	driver := &v1alpha1.SpiffeCSIDriver{
+	driver := &v1alpha2.SpiffeCSIDriver{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec: v1alpha1.SpiffeCSIDriverSpec{
-			CommonConfig: v1alpha1.CommonConfig{
+		Spec: v1alpha2.SpiffeCSIDriverSpec{
+			CommonConfig: v1alpha2.CommonConfig{
 				Labels: map[string]string{
 					"custom-label": "value",
 				},
@@ -571,7 +571,7 @@ func TestReconcile_AllScenarios(t *testing.T) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					callCount++
 					if callCount == 1 {
-						if csi, ok := obj.(*v1alpha1.SpiffeCSIDriver); ok {
+						if csi, ok := obj.(*v1alpha2.SpiffeCSIDriver); ok {
 							csi.Name = "cluster"
 						}
 						return nil
@@ -589,7 +589,7 @@ func TestReconcile_AllScenarios(t *testing.T) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					callCount++
 					if callCount == 1 {
-						if csi, ok := obj.(*v1alpha1.SpiffeCSIDriver); ok {
+						if csi, ok := obj.(*v1alpha2.SpiffeCSIDriver); ok {
 							csi.Name = "cluster"
 						}
 						return nil
@@ -608,13 +608,13 @@ func TestReconcile_AllScenarios(t *testing.T) {
 					callCount++
 					switch callCount {
 					case 1:
-						if csi, ok := obj.(*v1alpha1.SpiffeCSIDriver); ok {
+						if csi, ok := obj.(*v1alpha2.SpiffeCSIDriver); ok {
 							csi.Name = "cluster"
 							// No owner references
 						}
 						return nil
 					case 2:
-						if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+						if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 							z.Name = "cluster"
 							z.UID = "test-uid"
 						}
@@ -635,7 +635,7 @@ func TestReconcile_AllScenarios(t *testing.T) {
 					callCount++
 					switch callCount {
 					case 1:
-						if csi, ok := obj.(*v1alpha1.SpiffeCSIDriver); ok {
+						if csi, ok := obj.(*v1alpha2.SpiffeCSIDriver); ok {
 							csi.Name = "cluster"
 							csi.OwnerReferences = []metav1.OwnerReference{{
 								APIVersion: "operator.openshift.io/v1alpha1",
@@ -644,7 +644,7 @@ func TestReconcile_AllScenarios(t *testing.T) {
 								UID:        "test-uid",
 							}}
 							// Invalid affinity causes validation error
-							csi.Spec.CommonConfig = v1alpha1.CommonConfig{
+							csi.Spec.CommonConfig = v1alpha2.CommonConfig{
 								Affinity: &corev1.Affinity{
 									NodeAffinity: &corev1.NodeAffinity{
 										RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
@@ -656,7 +656,7 @@ func TestReconcile_AllScenarios(t *testing.T) {
 						}
 						return nil
 					case 2:
-						if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+						if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 							z.Name = "cluster"
 							z.UID = "test-uid"
 						}
@@ -676,7 +676,7 @@ func TestReconcile_AllScenarios(t *testing.T) {
 					callCount++
 					switch callCount {
 					case 1:
-						if csi, ok := obj.(*v1alpha1.SpiffeCSIDriver); ok {
+						if csi, ok := obj.(*v1alpha2.SpiffeCSIDriver); ok {
 							csi.Name = "cluster"
 							csi.OwnerReferences = []metav1.OwnerReference{{
 								APIVersion: "operator.openshift.io/v1alpha1",
@@ -687,7 +687,7 @@ func TestReconcile_AllScenarios(t *testing.T) {
 						}
 						return nil
 					case 2:
-						if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+						if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 							z.Name = "cluster"
 							z.UID = "test-uid"
 						}
@@ -706,7 +706,7 @@ func TestReconcile_AllScenarios(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 
 			reconciler := &SpiffeCsiReconciler{
 				ctrlClient:    fakeClient,
@@ -772,7 +772,7 @@ func TestReconcileServiceAccount_AllScenarios(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 
 			reconciler := &SpiffeCsiReconciler{
 				ctrlClient:    fakeClient,
@@ -789,7 +789,7 @@ func TestReconcileServiceAccount_AllScenarios(t *testing.T) {
 				fakeClient.CreateReturns(nil)
 			}
 
-			driver := &v1alpha1.SpiffeCSIDriver{
+			driver := &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			}
 			statusMgr := status.NewManager(fakeClient)
@@ -837,7 +837,7 @@ func TestReconcileCSIDriver_AllScenarios(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 
 			reconciler := &SpiffeCsiReconciler{
 				ctrlClient:    fakeClient,
@@ -854,7 +854,7 @@ func TestReconcileCSIDriver_AllScenarios(t *testing.T) {
 				fakeClient.CreateReturns(nil)
 			}
 
-			driver := &v1alpha1.SpiffeCSIDriver{
+			driver := &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			}
 			statusMgr := status.NewManager(fakeClient)
@@ -902,7 +902,7 @@ func TestReconcileSCC_AllScenarios(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 
 			reconciler := &SpiffeCsiReconciler{
 				ctrlClient:    fakeClient,
@@ -919,7 +919,7 @@ func TestReconcileSCC_AllScenarios(t *testing.T) {
 				fakeClient.CreateReturns(nil)
 			}
 
-			driver := &v1alpha1.SpiffeCSIDriver{
+			driver := &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			}
 			statusMgr := status.NewManager(fakeClient)
@@ -1005,7 +1005,7 @@ func TestHandleCreateOnlyMode_AllScenarios(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			reconciler := newTestReconciler(fakeClient)
 
-			driver := &v1alpha1.SpiffeCSIDriver{
+			driver := &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
 			}
 
@@ -1029,18 +1029,18 @@ func TestHandleCreateOnlyMode_AllScenarios(t *testing.T) {
 func TestValidateCommonConfig_AllScenarios(t *testing.T) {
 	tests := []struct {
 		name        string
-		spec        v1alpha1.SpiffeCSIDriverSpec
+		spec        v1alpha2.SpiffeCSIDriverSpec
 		expectError bool
 	}{
 		{
 			name:        "empty spec is valid",
-			spec:        v1alpha1.SpiffeCSIDriverSpec{},
+			spec:        v1alpha2.SpiffeCSIDriverSpec{},
 			expectError: false,
 		},
 		{
 			name: "valid tolerations",
-			spec: v1alpha1.SpiffeCSIDriverSpec{
-				CommonConfig: v1alpha1.CommonConfig{
+			spec: v1alpha2.SpiffeCSIDriverSpec{
+				CommonConfig: v1alpha2.CommonConfig{
 					Tolerations: []*corev1.Toleration{
 						{Key: "test", Operator: corev1.TolerationOpExists},
 					},
@@ -1050,8 +1050,8 @@ func TestValidateCommonConfig_AllScenarios(t *testing.T) {
 		},
 		{
 			name: "valid node selector",
-			spec: v1alpha1.SpiffeCSIDriverSpec{
-				CommonConfig: v1alpha1.CommonConfig{
+			spec: v1alpha2.SpiffeCSIDriverSpec{
+				CommonConfig: v1alpha2.CommonConfig{
 					NodeSelector: map[string]string{"key": "value"},
 				},
 			},
@@ -1059,8 +1059,8 @@ func TestValidateCommonConfig_AllScenarios(t *testing.T) {
 		},
 		{
 			name: "valid labels",
-			spec: v1alpha1.SpiffeCSIDriverSpec{
-				CommonConfig: v1alpha1.CommonConfig{
+			spec: v1alpha2.SpiffeCSIDriverSpec{
+				CommonConfig: v1alpha2.CommonConfig{
 					Labels: map[string]string{"app": "test"},
 				},
 			},
@@ -1068,8 +1068,8 @@ func TestValidateCommonConfig_AllScenarios(t *testing.T) {
 		},
 		{
 			name: "invalid affinity with empty terms",
-			spec: v1alpha1.SpiffeCSIDriverSpec{
-				CommonConfig: v1alpha1.CommonConfig{
+			spec: v1alpha2.SpiffeCSIDriverSpec{
+				CommonConfig: v1alpha2.CommonConfig{
 					Affinity: &corev1.Affinity{
 						NodeAffinity: &corev1.NodeAffinity{
 							RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
@@ -1088,7 +1088,7 @@ func TestValidateCommonConfig_AllScenarios(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			reconciler := newTestReconciler(fakeClient)
 
-			driver := &v1alpha1.SpiffeCSIDriver{
+			driver := &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
 				Spec:       tt.spec,
 			}
@@ -1121,7 +1121,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 					callCount++
 					switch callCount {
 					case 1:
-						if c, ok := obj.(*v1alpha1.SpiffeCSIDriver); ok {
+						if c, ok := obj.(*v1alpha2.SpiffeCSIDriver); ok {
 							c.Name = "cluster"
 							c.UID = "test-uid"
 							c.OwnerReferences = []metav1.OwnerReference{{
@@ -1133,7 +1133,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 						}
 						return nil
 					case 2:
-						if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+						if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 							z.Name = "cluster"
 							z.UID = "ztwim-uid"
 						}
@@ -1153,7 +1153,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 					callCount++
 					switch callCount {
 					case 1:
-						if c, ok := obj.(*v1alpha1.SpiffeCSIDriver); ok {
+						if c, ok := obj.(*v1alpha2.SpiffeCSIDriver); ok {
 							c.Name = "cluster"
 							c.UID = "test-uid"
 							c.OwnerReferences = []metav1.OwnerReference{{
@@ -1165,7 +1165,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 						}
 						return nil
 					case 2:
-						if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+						if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 							z.Name = "cluster"
 							z.UID = "ztwim-uid"
 						}
@@ -1187,7 +1187,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 					callCount++
 					switch callCount {
 					case 1:
-						if c, ok := obj.(*v1alpha1.SpiffeCSIDriver); ok {
+						if c, ok := obj.(*v1alpha2.SpiffeCSIDriver); ok {
 							c.Name = "cluster"
 							c.UID = "test-uid"
 							c.OwnerReferences = []metav1.OwnerReference{{
@@ -1199,7 +1199,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 						}
 						return nil
 					case 2:
-						if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+						if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 							z.Name = "cluster"
 							z.UID = "ztwim-uid"
 						}
@@ -1219,7 +1219,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 
 			reconciler := &SpiffeCsiReconciler{
 				ctrlClient:    fakeClient,
@@ -1261,7 +1261,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 func TestReconcile_SuccessfulPath_NoRequeue(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 
 	reconciler := &SpiffeCsiReconciler{
@@ -1277,7 +1277,7 @@ func TestReconcile_SuccessfulPath_NoRequeue(t *testing.T) {
 		callCount++
 		switch callCount {
 		case 1: // SpiffeCSIDriver
-			if c, ok := obj.(*v1alpha1.SpiffeCSIDriver); ok {
+			if c, ok := obj.(*v1alpha2.SpiffeCSIDriver); ok {
 				c.Name = "cluster"
 				c.UID = "test-uid"
 				c.OwnerReferences = []metav1.OwnerReference{{
@@ -1289,7 +1289,7 @@ func TestReconcile_SuccessfulPath_NoRequeue(t *testing.T) {
 			}
 			return nil
 		case 2: // ZTWIM
-			if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+			if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 				z.Name = "cluster"
 				z.UID = "ztwim-uid"
 			}
@@ -1352,7 +1352,7 @@ func TestHandleCreateOnlyMode_MutationKillers(t *testing.T) {
 			reconciler := newTestReconciler(fakeClient)
 			statusMgr := status.NewManager(fakeClient)
 
-			driver := &v1alpha1.SpiffeCSIDriver{
+			driver := &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
 			}
 
@@ -1380,7 +1380,7 @@ func TestHandleCreateOnlyMode_MutationKillers(t *testing.T) {
 func TestReconcile_SCCError_MutationKiller(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &SpiffeCsiReconciler{
 		ctrlClient:    fakeClient,
@@ -1395,7 +1395,7 @@ func TestReconcile_SCCError_MutationKiller(t *testing.T) {
 		callCount++
 		switch callCount {
 		case 1:
-			if c, ok := obj.(*v1alpha1.SpiffeCSIDriver); ok {
+			if c, ok := obj.(*v1alpha2.SpiffeCSIDriver); ok {
 				c.Name = "cluster"
 				c.UID = "test-uid"
 				c.OwnerReferences = []metav1.OwnerReference{{
@@ -1407,7 +1407,7 @@ func TestReconcile_SCCError_MutationKiller(t *testing.T) {
 			}
 			return nil
 		case 2:
-			if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+			if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 				z.Name = "cluster"
 				z.UID = "ztwim-uid"
 			}
@@ -1438,7 +1438,7 @@ func TestReconcile_SCCError_MutationKiller(t *testing.T) {
 func TestReconcile_DaemonSetError_MutationKiller(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &SpiffeCsiReconciler{
 		ctrlClient:    fakeClient,
@@ -1453,7 +1453,7 @@ func TestReconcile_DaemonSetError_MutationKiller(t *testing.T) {
 		callCount++
 		switch callCount {
 		case 1:
-			if c, ok := obj.(*v1alpha1.SpiffeCSIDriver); ok {
+			if c, ok := obj.(*v1alpha2.SpiffeCSIDriver); ok {
 				c.Name = "cluster"
 				c.UID = "test-uid"
 				c.OwnerReferences = []metav1.OwnerReference{{
@@ -1465,7 +1465,7 @@ func TestReconcile_DaemonSetError_MutationKiller(t *testing.T) {
 			}
 			return nil
 		case 2:
-			if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+			if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 				z.Name = "cluster"
 				z.UID = "ztwim-uid"
 			}
@@ -1491,3 +1491,34 @@ func TestReconcile_DaemonSetError_MutationKiller(t *testing.T) {
 		t.Errorf("Expected RequeueAfter=0 when error returned, got %v", result.RequeueAfter)
 	}
 }
+
+// TestValidateSVIDDelivery tests the SVID files delivery requires the image of its flavor of the driver
+func TestValidateSVIDDelivery(t *testing.T) {
+	tests := []struct {
+		name        string
+		delivery    v1alpha2.SVIDDeliveryMode
+		image       string
+		expectError bool
+	}{
+		{name: "socket only", delivery: v1alpha2.SVIDDeliverySocketOnly},
+		{name: "SVID files", delivery: v1alpha2.SVIDDeliverySVIDFiles, image: "spiffe-csi-driver-svid-files:test"},
+		{name: "SVID files without image", delivery: v1alpha2.SVIDDeliverySVIDFiles, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("RELATED_IMAGE_SPIFFE_CSI_DRIVER_SVID_FILES", tt.image)
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			reconciler := newTestReconciler(fakeClient)
+			driver := &v1alpha2.SpiffeCSIDriver{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec:       v1alpha2.SpiffeCSIDriverSpec{SVIDDelivery: tt.delivery},
+			}
+
+			err := reconciler.validateSVIDDelivery(driver, status.NewManager(fakeClient))
+			if (err != nil) != tt.expectError {
+				t.Errorf("Expected error %v, got: %v", tt.expectError, err)
+			}
+		})
+	}
+}
@@ -8,9 +8,11 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/operator/assets"
@@ -19,12 +21,18 @@ import (
 // Constants for status conditions are defined in controller.go
 
 // reconcileCSIDriver reconciles the Spiffe CSI Driver resource
-func (r *SpiffeCsiReconciler) reconcileCSIDriver(ctx context.Context, driver *v1alpha1.SpiffeCSIDriver, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpiffeCsiReconciler) reconcileCSIDriver(ctx context.Context, driver *v1alpha2.SpiffeCSIDriver, statusMgr *status.Manager, createOnlyMode bool) error {
 	desired := getSpiffeCSIDriver(driver.Spec.PluginName, driver.Spec.Labels)
+	// kubelet republishes the volumes periodically in SVID files mode, for the driver to rewrite
+	// the files as the SVIDs are rotated
+	svidFiles := driver.Spec.SVIDDelivery == v1alpha2.SVIDDeliverySVIDFiles
+	if svidFiles {
+		desired.Spec.RequiresRepublish = ptr.To(true)
+	}
 
 	if err := controllerutil.SetControllerReference(driver, desired, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference on CSI driver")
-		statusMgr.AddCondition(CSIDriverAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(CSIDriverAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to set owner reference on CSIDriver: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -38,23 +46,23 @@ func (r *SpiffeCsiReconciler) reconcileCSIDriver(ctx context.Context, driver *v1
 		if !kerrors.IsNotFound(err) {
 			// Unexpected error
 			r.log.Error(err, "failed to get CSI driver")
-			statusMgr.AddCondition(CSIDriverAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(CSIDriverAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to get CSIDriver: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(driver.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create CSI driver")
-			statusMgr.AddCondition(CSIDriverAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(CSIDriverAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to create CSIDriver: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		r.log.Info("Created CSIDriver", "name", desired.Name)
-		statusMgr.AddCondition(CSIDriverAvailable, v1alpha1.ReasonReady,
+		statusMgr.AddCondition(CSIDriverAvailable, v1alpha2.ReasonReady,
 			"All CSIDriver resources available",
 			metav1.ConditionTrue)
 		return nil
@@ -63,7 +71,7 @@ func (r *SpiffeCsiReconciler) reconcileCSIDriver(ctx context.Context, driver *v1
 	// Resource exists, check if we need to update
 	if createOnlyMode {
 		r.log.V(1).Info("CSIDriver exists, skipping update due to create-only mode", "name", desired.Name)
-		statusMgr.AddCondition(CSIDriverAvailable, v1alpha1.ReasonReady,
+		statusMgr.AddCondition(CSIDriverAvailable, v1alpha2.ReasonReady,
 			"All CSIDriver resources available",
 			metav1.ConditionTrue)
 		return nil
@@ -71,7 +79,13 @@ func (r *SpiffeCsiReconciler) reconcileCSIDriver(ctx context.Context, driver *v1
 
 	// Preserve fields set by Kubernetes from existing resource BEFORE comparison
 	desired.ResourceVersion = existing.ResourceVersion
-	desired.Spec.RequiresRepublish = existing.Spec.RequiresRepublish
+	if !svidFiles {
+		desired.Spec.RequiresRepublish = existing.Spec.RequiresRepublish
+		if ptr.Deref(existing.Spec.RequiresRepublish, false) {
+			// Stop the republishing when leaving SVID files mode
+			desired.Spec.RequiresRepublish = ptr.To(false)
+		}
+	}
 	desired.Spec.SELinuxMount = existing.Spec.SELinuxMount
 	desired.Spec.StorageCapacity = existing.Spec.StorageCapacity
 	desired.Spec.TokenRequests = existing.Spec.TokenRequests
@@ -90,7 +104,7 @@ func (r *SpiffeCsiReconciler) reconcileCSIDriver(ctx context.Context, driver *v1
 	// Check if update is needed
 	if !utils.ResourceNeedsUpdate(existing, desired) {
 		r.log.V(1).Info("CSIDriver is up to date", "name", desired.Name)
-		statusMgr.AddCondition(CSIDriverAvailable, v1alpha1.ReasonReady,
+		statusMgr.AddCondition(CSIDriverAvailable, v1alpha2.ReasonReady,
 			"All CSIDriver resources available",
 			metav1.ConditionTrue)
 		return nil
@@ -99,14 +113,14 @@ func (r *SpiffeCsiReconciler) reconcileCSIDriver(ctx context.Context, driver *v1
 	// Update the resource
 	if err := r.ctrlClient.Update(ctx, desired); err != nil {
 		r.log.Error(err, "failed to update CSI driver")
-		statusMgr.AddCondition(CSIDriverAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(CSIDriverAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to update CSIDriver: %v", err),
 			metav1.ConditionFalse)
 		return err
 	}
 
 	r.log.Info("Updated CSIDriver", "name", desired.Name)
-	statusMgr.AddCondition(CSIDriverAvailable, v1alpha1.ReasonReady,
+	statusMgr.AddCondition(CSIDriverAvailable, v1alpha2.ReasonReady,
 		"All CSIDriver resources available",
 		metav1.ConditionTrue)
 	return nil
@@ -6,7 +6,7 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
@@ -111,7 +111,7 @@ func TestGetSpiffeCSIDriver(t *testing.T) {
 // newCSITestReconciler creates a reconciler for CSI driver tests
 func newCSITestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpiffeCsiReconciler {
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = storagev1.AddToScheme(scheme)
 	return &SpiffeCsiReconciler{
 		ctrlClient:    fakeClient,
@@ -126,7 +126,7 @@ func newCSITestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpiffeCsiReco
 func TestReconcileCSIDriver(t *testing.T) {
 	tests := []struct {
 		name           string
-		driver         *v1alpha1.SpiffeCSIDriver
+		driver         *v1alpha2.SpiffeCSIDriver
 		setupClient    func(*fakes.FakeCustomCtrlClient)
 		createOnlyMode bool
 		useEmptyScheme bool
@@ -136,9 +136,9 @@ func TestReconcileCSIDriver(t *testing.T) {
 	}{
 		{
 			name: "create success",
-			driver: &v1alpha1.SpiffeCSIDriver{
+			driver: &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec:       v1alpha1.SpiffeCSIDriverSpec{PluginName: "csi.spiffe.io"},
+				Spec:       v1alpha2.SpiffeCSIDriverSpec{PluginName: "csi.spiffe.io"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
 				fc.GetReturns(kerrors.NewNotFound(schema.GroupResource{}, "csi.spiffe.io"))
@@ -148,9 +148,9 @@ func TestReconcileCSIDriver(t *testing.T) {
 		},
 		{
 			name: "create error",
-			driver: &v1alpha1.SpiffeCSIDriver{
+			driver: &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec:       v1alpha1.SpiffeCSIDriverSpec{PluginName: "csi.spiffe.io"},
+				Spec:       v1alpha2.SpiffeCSIDriverSpec{PluginName: "csi.spiffe.io"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
 				fc.GetReturns(kerrors.NewNotFound(schema.GroupResource{}, "csi.spiffe.io"))
@@ -160,9 +160,9 @@ func TestReconcileCSIDriver(t *testing.T) {
 		},
 		{
 			name: "get error",
-			driver: &v1alpha1.SpiffeCSIDriver{
+			driver: &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec:       v1alpha1.SpiffeCSIDriverSpec{PluginName: "csi.spiffe.io"},
+				Spec:       v1alpha2.SpiffeCSIDriverSpec{PluginName: "csi.spiffe.io"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
 				fc.GetReturns(errors.New("connection refused"))
@@ -171,9 +171,9 @@ func TestReconcileCSIDriver(t *testing.T) {
 		},
 		{
 			name: "create only mode skips update",
-			driver: &v1alpha1.SpiffeCSIDriver{
+			driver: &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec:       v1alpha1.SpiffeCSIDriverSpec{PluginName: "csi.spiffe.io"},
+				Spec:       v1alpha2.SpiffeCSIDriverSpec{PluginName: "csi.spiffe.io"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
 				existingCSI := &storagev1.CSIDriver{
@@ -190,11 +190,11 @@ func TestReconcileCSIDriver(t *testing.T) {
 		},
 		{
 			name: "update success",
-			driver: &v1alpha1.SpiffeCSIDriver{
+			driver: &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpiffeCSIDriverSpec{
+				Spec: v1alpha2.SpiffeCSIDriverSpec{
 					PluginName: "csi.spiffe.io",
-					CommonConfig: v1alpha1.CommonConfig{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -219,11 +219,11 @@ func TestReconcileCSIDriver(t *testing.T) {
 		},
 		{
 			name: "update error",
-			driver: &v1alpha1.SpiffeCSIDriver{
+			driver: &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpiffeCSIDriverSpec{
+				Spec: v1alpha2.SpiffeCSIDriverSpec{
 					PluginName: "csi.spiffe.io",
-					CommonConfig: v1alpha1.CommonConfig{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -249,9 +249,9 @@ func TestReconcileCSIDriver(t *testing.T) {
 		},
 		{
 			name: "set controller ref error",
-			driver: &v1alpha1.SpiffeCSIDriver{
+			driver: &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec:       v1alpha1.SpiffeCSIDriverSpec{PluginName: "csi.spiffe.io"},
+				Spec:       v1alpha2.SpiffeCSIDriverSpec{PluginName: "csi.spiffe.io"},
 			},
 			setupClient:    func(fc *fakes.FakeCustomCtrlClient) {},
 			useEmptyScheme: true,
@@ -297,3 +297,72 @@ func TestReconcileCSIDriver(t *testing.T) {
 		})
 	}
 }
+
+// TestReconcileCSIDriver_SVIDDelivery tests the volumes are republished in SVID files mode only
+func TestReconcileCSIDriver_SVIDDelivery(t *testing.T) {
+	republish, noRepublish := true, false
+	tests := []struct {
+		name            string
+		delivery        v1alpha2.SVIDDeliveryMode
+		existing        *bool
+		expectUpdate    bool
+		expectRepublish *bool
+	}{
+		{
+			name:            "SVID files mode enables republishing",
+			delivery:        v1alpha2.SVIDDeliverySVIDFiles,
+			expectUpdate:    true,
+			expectRepublish: &republish,
+		},
+		{
+			name:     "SVID files mode already republishing",
+			delivery: v1alpha2.SVIDDeliverySVIDFiles,
+			existing: &republish,
+		},
+		{
+			name:     "socket only mode leaves republishing unset",
+			delivery: v1alpha2.SVIDDeliverySocketOnly,
+		},
+		{
+			name:            "leaving SVID files mode stops republishing",
+			delivery:        v1alpha2.SVIDDeliverySocketOnly,
+			existing:        &republish,
+			expectUpdate:    true,
+			expectRepublish: &noRepublish,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			reconciler := newCSITestReconciler(fakeClient)
+			driver := &v1alpha2.SpiffeCSIDriver{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
+				Spec:       v1alpha2.SpiffeCSIDriverSpec{PluginName: "csi.spiffe.io", SVIDDelivery: tt.delivery},
+			}
+			existing := getSpiffeCSIDriver("csi.spiffe.io", nil)
+			existing.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: "operator.openshift.io/v1alpha1", Kind: "SpiffeCSIDriver", Name: "cluster", UID: "test-uid",
+				Controller: &republish, BlockOwnerDeletion: &republish,
+			}}
+			existing.Spec.RequiresRepublish = tt.existing
+			fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+				existing.DeepCopyInto(obj.(*storagev1.CSIDriver))
+				return nil
+			}
+
+			if err := reconciler.reconcileCSIDriver(context.Background(), driver, status.NewManager(fakeClient), false); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if updated := fakeClient.UpdateCallCount() == 1; updated != tt.expectUpdate {
+				t.Fatalf("Expected update %v, got %v", tt.expectUpdate, updated)
+			}
+			if tt.expectUpdate {
+				_, obj, _ := fakeClient.UpdateArgsForCall(0)
+				if got := obj.(*storagev1.CSIDriver).Spec.RequiresRepublish; got == nil || *got != *tt.expectRepublish {
+					t.Errorf("Expected requiresRepublish %v, got %v", *tt.expectRepublish, got)
+				}
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package spiffe_csi_driver
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -15,14 +17,35 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/dependencies"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/maintenance"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 )
 
 // reconcileDaemonSet reconciles the Spiffe CSI Driver DaemonSet
-func (r *SpiffeCsiReconciler) reconcileDaemonSet(ctx context.Context, driver *v1alpha1.SpiffeCSIDriver, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpiffeCsiReconciler) reconcileDaemonSet(ctx context.Context, driver *v1alpha2.SpiffeCSIDriver, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, createOnlyMode bool) error {
 	spiffeCsiDaemonset := generateSpiffeCsiDriverDaemonSet(driver.Spec)
+	nodeArchitectures, err := utils.GetNodeArchitectures(ctx, r.ctrlClient)
+	if err != nil {
+		r.log.Error(err, "failed to get node architectures")
+		statusMgr.AddCondition(DaemonSetAvailable, "SpiffeCSIDaemonSetGenerationFailed",
+			err.Error(),
+			metav1.ConditionFalse)
+		return err
+	}
+	placement := utils.ResolveArchitectures(driver.Spec.Architectures, nodeArchitectures, utils.GetSupportedArchitectures())
+	statusMgr.ReportSkippedArchitectures(placement.Skipped, driver.Status.Conditions)
+	spiffeCsiDaemonset.Spec.Template.Spec.Affinity = utils.WithArchitectureAffinity(spiffeCsiDaemonset.Spec.Template.Spec.Affinity, placement.Allowed)
+	if err := dependencies.Annotate(ctx, r.ctrlClient, &spiffeCsiDaemonset.Spec.Template); err != nil {
+		r.log.Error(err, "failed to hash the dependencies of the DaemonSet")
+		statusMgr.AddCondition(DaemonSetAvailable, "SpiffeCSIDaemonSetGenerationFailed",
+			err.Error(),
+			metav1.ConditionFalse)
+		return err
+	}
 	if err := controllerutil.SetControllerReference(driver, spiffeCsiDaemonset, r.scheme); err != nil {
 		r.log.Error(err, "failed to set owner reference for the DaemonSet resource")
 		statusMgr.AddCondition(DaemonSetAvailable, "SpiffeCSIDaemonSetGenerationFailed",
@@ -32,9 +55,9 @@ func (r *SpiffeCsiReconciler) reconcileDaemonSet(ctx context.Context, driver *v1
 	}
 
 	var existingSpiffeCsiDaemonSet appsv1.DaemonSet
-	err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: spiffeCsiDaemonset.Name, Namespace: spiffeCsiDaemonset.Namespace}, &existingSpiffeCsiDaemonSet)
+	err = r.ctrlClient.Get(ctx, types.NamespacedName{Name: spiffeCsiDaemonset.Name, Namespace: spiffeCsiDaemonset.Namespace}, &existingSpiffeCsiDaemonSet)
 	if err != nil && kerrors.IsNotFound(err) {
-		if err = r.ctrlClient.Create(ctx, spiffeCsiDaemonset); err != nil {
+		if err = r.ctrlClient.Create(ctx, spiffeCsiDaemonset, customClient.AdoptExisting(ptr.Deref(driver.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "Failed to create SpiffeCsiDaemon set")
 			statusMgr.AddCondition(DaemonSetAvailable, "SpiffeCSIDaemonSetCreationFailed",
 				err.Error(),
@@ -43,8 +66,14 @@ func (r *SpiffeCsiReconciler) reconcileDaemonSet(ctx context.Context, driver *v1
 		}
 		r.log.Info("Created spiffe csi DaemonSet")
 	} else if err == nil && needsUpdate(existingSpiffeCsiDaemonSet, *spiffeCsiDaemonset) {
+		versionChange := status.IsOperandVersionChange(existingSpiffeCsiDaemonSet.Labels, spiffeCsiDaemonset.Labels,
+			&existingSpiffeCsiDaemonSet.Spec.Template.Spec, &spiffeCsiDaemonset.Spec.Template.Spec, "spiffe-csi-driver")
 		if createOnlyMode {
 			r.log.Info("Skipping DaemonSet update due to create-only mode")
+		} else if !statusMgr.CheckUpgradeOrder(ctx, utils.ResourceKindSpiffeCSIDriver, driver.Status.ConditionalStatus.Conditions, versionChange) {
+			r.log.Info("Deferring spiffe csi DaemonSet upgrade until prerequisite operands are upgraded")
+		} else if !maintenance.Allows(statusMgr, ztwim.Spec.MaintenanceWindows, "DaemonSet "+spiffeCsiDaemonset.Name, driver.Status.Conditions, time.Now()) {
+			r.log.Info("Deferring spiffe csi DaemonSet update until the next maintenance window")
 		} else {
 			spiffeCsiDaemonset.ResourceVersion = existingSpiffeCsiDaemonSet.ResourceVersion
 			if err = r.ctrlClient.Update(ctx, spiffeCsiDaemonset); err != nil {
@@ -62,6 +91,8 @@ func (r *SpiffeCsiReconciler) reconcileDaemonSet(ctx context.Context, driver *v1
 			err.Error(),
 			metav1.ConditionFalse)
 		return err
+	} else {
+		maintenance.Settle(statusMgr, driver.Status.Conditions)
 	}
 
 	// Check DaemonSet health/readiness
@@ -72,10 +103,13 @@ func (r *SpiffeCsiReconciler) reconcileDaemonSet(ctx context.Context, driver *v1
 
 // needsUpdate returns true if DaemonSet needs to be updated.
 func needsUpdate(current, desired appsv1.DaemonSet) bool {
+	if current.Spec.Template.Annotations[utils.DependenciesHashAnnotationKey] != desired.Spec.Template.Annotations[utils.DependenciesHashAnnotationKey] {
+		return true
+	}
 	return utils.ResourceNeedsUpdate(&current, &desired)
 }
 
-func generateSpiffeCsiDriverDaemonSet(config v1alpha1.SpiffeCSIDriverSpec) *appsv1.DaemonSet {
+func generateSpiffeCsiDriverDaemonSet(config v1alpha2.SpiffeCSIDriverSpec) *appsv1.DaemonSet {
 
 	// Generate standardized labels once and reuse them
 	labels := utils.SpiffeCSIDriverLabels(config.Labels)
@@ -115,13 +149,14 @@ func generateSpiffeCsiDriverDaemonSet(config v1alpha1.SpiffeCSIDriverSpec) *apps
 					Affinity:           config.Affinity,
 					Tolerations:        utils.DerefTolerations(config.Tolerations),
 					NodeSelector:       utils.DerefNodeSelector(config.NodeSelector),
+					DNSPolicy:          utils.DerefDNSPolicy(config.DNSPolicy),
+					DNSConfig:          config.DNSConfig.DeepCopy(),
+					ImagePullSecrets:   config.ImagePullSecrets,
 					InitContainers: []corev1.Container{
 						{
-							Name:  "set-context",
-							Image: utils.GetSpiffeCsiInitContainerImage(),
-							Command: []string{
-								"chcon", "-Rvt", "container_file_t", "spire-agent-socket/",
-							},
+							Name:            "set-context",
+							Image:           utils.GetSpiffeCsiInitContainerImage(),
+							Command:         socketDirectoryCommand(config.SocketDirectory),
 							ImagePullPolicy: corev1.PullAlways,
 							SecurityContext: &corev1.SecurityContext{
 								Privileged: ptr.To(true),
@@ -142,7 +177,7 @@ func generateSpiffeCsiDriverDaemonSet(config v1alpha1.SpiffeCSIDriverSpec) *apps
 					Containers: []corev1.Container{
 						{
 							Name:  "spiffe-csi-driver",
-							Image: utils.GetSpiffeCSIDriverImage(),
+							Image: spiffeCSIDriverImage(config.SVIDDelivery),
 							Args: []string{
 								"-workload-api-socket-dir", "/spire-agent-socket",
 								"-plugin-name", config.PluginName,
@@ -274,6 +309,44 @@ func generateSpiffeCsiDriverDaemonSet(config v1alpha1.SpiffeCSIDriverSpec) *apps
 	return ds
 }
 
+// socketDirectoryCommand returns the command of the init container setting the SELinux context,
+// group and permissions of the directory holding the Workload API socket
+func socketDirectoryCommand(config *v1alpha2.SocketDirectoryConfig) []string {
+	const dir = "spire-agent-socket/"
+	if config == nil {
+		config = &v1alpha2.SocketDirectoryConfig{}
+	}
+	seLinuxType := config.SELinuxType
+	if seLinuxType == "" {
+		seLinuxType = "container_file_t"
+	}
+	// Only the SELinux type is set by default, without a shell
+	if config.SELinuxLevel == "" && config.Group == nil && config.Mode == "" {
+		return []string{"chcon", "-Rvt", seLinuxType, dir}
+	}
+
+	chcon := "chcon -Rv -t " + seLinuxType
+	if config.SELinuxLevel != "" {
+		chcon += " -l " + config.SELinuxLevel
+	}
+	commands := []string{chcon + " " + dir}
+	if config.Group != nil {
+		commands = append(commands, fmt.Sprintf("chgrp %d %s", *config.Group, dir))
+	}
+	if config.Mode != "" {
+		commands = append(commands, fmt.Sprintf("chmod %s %s", config.Mode, dir))
+	}
+	return []string{"sh", "-c", strings.Join(commands, " && ")}
+}
+
+// spiffeCSIDriverImage returns the image of the flavor of the driver delivering the SVIDs as requested
+func spiffeCSIDriverImage(delivery v1alpha2.SVIDDeliveryMode) string {
+	if delivery == v1alpha2.SVIDDeliverySVIDFiles {
+		return utils.GetSpiffeCSIDriverSVIDFilesImage()
+	}
+	return utils.GetSpiffeCSIDriverImage()
+}
+
 func hostPathTypePtr(t corev1.HostPathType) *corev1.HostPathType {
 	return &t
 }
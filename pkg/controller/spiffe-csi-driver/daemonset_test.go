@@ -4,10 +4,11 @@ import (
 	"context"
 	"errors"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/go-logr/logr"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
@@ -26,7 +27,7 @@ func TestGenerateSpiffeCsiDriverDaemonSet(t *testing.T) {
 	// Mock the utility functions that are called in the main function
 	// These would need to be properly mocked in a real test environment
 
-	config := v1alpha1.SpiffeCSIDriverSpec{
+	config := v1alpha2.SpiffeCSIDriverSpec{
 		AgentSocketPath: "/run/spire/agent-sockets",
 		PluginName:      "csi.spiffe.io",
 	}
@@ -116,7 +117,7 @@ func TestGenerateSpiffeCsiDriverDaemonSet(t *testing.T) {
 
 func TestGenerateSpiffeCsiDriverDaemonSetWithCustomConfig(t *testing.T) {
 	// Test with custom plugin name and agent socket path
-	config := v1alpha1.SpiffeCSIDriverSpec{
+	config := v1alpha2.SpiffeCSIDriverSpec{
 		AgentSocketPath: "/custom/agent/socket",
 		PluginName:      "csi.custom.io",
 	}
@@ -628,7 +629,7 @@ func TestReconcileDaemonSet(t *testing.T) {
 			fakeClient.CreateReturns(tt.createError)
 			fakeClient.UpdateReturns(tt.updateError)
 
-			err := reconciler.reconcileDaemonSet(context.Background(), driver, statusMgr, tt.createOnlyMode)
+			err := reconciler.reconcileDaemonSet(context.Background(), driver, statusMgr, &v1alpha2.ZeroTrustWorkloadIdentityManager{}, tt.createOnlyMode)
 
 			if tt.expectError && err == nil {
 				t.Error("Expected error but got none")
@@ -649,6 +650,38 @@ func TestReconcileDaemonSet(t *testing.T) {
 	}
 }
 
+func TestReconcileDaemonSet_UnsupportedNodeArchitecture(t *testing.T) {
+	t.Setenv(utils.SupportedArchitecturesEnv, "amd64,arm64")
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	fakeClient.GetReturns(kerrors.NewNotFound(schema.GroupResource{}, "spiffe-csi-driver"))
+	fakeClient.ListUncachedStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+		for _, arch := range []string{"amd64", "s390x"} {
+			list.(*metav1.PartialObjectMetadataList).Items = append(list.(*metav1.PartialObjectMetadataList).Items,
+				metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{corev1.LabelArchStable: arch}}})
+		}
+		return nil
+	}
+	statusMgr := status.NewManager(fakeClient)
+
+	if err := newDaemonSetTestReconciler(fakeClient).reconcileDaemonSet(context.Background(), createDaemonSetTestDriver(), statusMgr, &v1alpha2.ZeroTrustWorkloadIdentityManager{}, false); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	_, obj, _ := fakeClient.CreateArgsForCall(0)
+	affinity := obj.(*appsv1.DaemonSet).Spec.Template.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil {
+		t.Fatal("Expected the DaemonSet to be constrained to the supported architectures")
+	}
+	terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if len(terms) != 1 || len(terms[0].MatchExpressions) != 1 || !reflect.DeepEqual(terms[0].MatchExpressions[0].Values, []string{"amd64"}) {
+		t.Errorf("Expected the DaemonSet to require amd64 nodes, got %v", terms)
+	}
+	cond, ok := statusMgr.GetCondition(utils.ArchitecturesSkippedStatusType)
+	if !ok || cond.Status != metav1.ConditionTrue || !strings.Contains(cond.Message, "s390x") {
+		t.Errorf("Expected s390x to be reported as skipped, got %v", cond)
+	}
+}
+
 // TestNeedsUpdate tests the needsUpdate function
 func TestNeedsUpdate(t *testing.T) {
 	t.Run("same daemonsets do not need update", func(t *testing.T) {
@@ -687,7 +720,7 @@ func TestNeedsUpdate(t *testing.T) {
 // newDaemonSetTestReconciler creates a reconciler for DaemonSet tests
 func newDaemonSetTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpiffeCsiReconciler {
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = appsv1.AddToScheme(scheme)
 	return &SpiffeCsiReconciler{
 		ctrlClient:    fakeClient,
@@ -699,15 +732,69 @@ func newDaemonSetTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpiffeC
 }
 
 // createDaemonSetTestDriver creates a test SpiffeCSIDriver for DaemonSet tests
-func createDaemonSetTestDriver() *v1alpha1.SpiffeCSIDriver {
-	return &v1alpha1.SpiffeCSIDriver{
+func createDaemonSetTestDriver() *v1alpha2.SpiffeCSIDriver {
+	return &v1alpha2.SpiffeCSIDriver{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
 		},
-		Spec: v1alpha1.SpiffeCSIDriverSpec{
+		Spec: v1alpha2.SpiffeCSIDriverSpec{
 			AgentSocketPath: "/run/spire/agent-sockets",
 			PluginName:      "csi.spiffe.io",
 		},
 	}
 }
+
+func TestGenerateSpiffeCsiDriverDaemonSet_SVIDDelivery(t *testing.T) {
+	t.Setenv(utils.SpiffeCSIDriverImageEnv, "spiffe-csi-driver:test")
+	t.Setenv(utils.SpiffeCSIDriverSVIDFilesImageEnv, "spiffe-csi-driver-svid-files:test")
+
+	for delivery, expectedImage := range map[v1alpha2.SVIDDeliveryMode]string{
+		"":                              "spiffe-csi-driver:test",
+		v1alpha2.SVIDDeliverySocketOnly: "spiffe-csi-driver:test",
+		v1alpha2.SVIDDeliverySVIDFiles:  "spiffe-csi-driver-svid-files:test",
+	} {
+		daemonSet := generateSpiffeCsiDriverDaemonSet(v1alpha2.SpiffeCSIDriverSpec{PluginName: "csi.spiffe.io", SVIDDelivery: delivery})
+		if image := daemonSet.Spec.Template.Spec.Containers[0].Image; image != expectedImage {
+			t.Errorf("Expected image %q for delivery %q, got %q", expectedImage, delivery, image)
+		}
+	}
+}
+
+func TestSocketDirectoryCommand(t *testing.T) {
+	group := int64(1000680000)
+	tests := []struct {
+		name     string
+		config   *v1alpha2.SocketDirectoryConfig
+		expected []string
+	}{
+		{
+			name:     "default",
+			expected: []string{"chcon", "-Rvt", "container_file_t", "spire-agent-socket/"},
+		},
+		{
+			name:     "SELinux type",
+			config:   &v1alpha2.SocketDirectoryConfig{SELinuxType: "spire_socket_t"},
+			expected: []string{"chcon", "-Rvt", "spire_socket_t", "spire-agent-socket/"},
+		},
+		{
+			name:   "SELinux level, group and mode",
+			config: &v1alpha2.SocketDirectoryConfig{SELinuxLevel: "s0:c26,c5", Group: &group, Mode: "0750"},
+			expected: []string{"sh", "-c", "chcon -Rv -t container_file_t -l s0:c26,c5 spire-agent-socket/ && " +
+				"chgrp 1000680000 spire-agent-socket/ && chmod 0750 spire-agent-socket/"},
+		},
+		{
+			name:     "mode",
+			config:   &v1alpha2.SocketDirectoryConfig{SELinuxType: "spire_socket_t", Mode: "755"},
+			expected: []string{"sh", "-c", "chcon -Rv -t spire_socket_t spire-agent-socket/ && chmod 755 spire-agent-socket/"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if command := socketDirectoryCommand(tt.config); !reflect.DeepEqual(command, tt.expected) {
+				t.Errorf("Expected command %q, got %q", tt.expected, command)
+			}
+		})
+	}
+}
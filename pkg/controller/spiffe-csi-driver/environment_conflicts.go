@@ -0,0 +1,45 @@
+package spiffe_csi_driver
+
+import (
+	"context"
+	"slices"
+
+	securityv1 "github.com/openshift/api/security/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/environment"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// reportEnvironmentConflicts reports the CSIDriver and SecurityContextConstraints of another SPIFFE
+// CSI driver install, e.g. of the upstream Helm charts, in the EnvironmentConflict condition. They
+// are returned as an invalid configuration error so the driver is not reconciled over them.
+func (r *SpiffeCsiReconciler) reportEnvironmentConflicts(ctx context.Context, driver *v1alpha2.SpiffeCSIDriver, statusMgr *status.Manager, unmanagedKinds []string) error {
+	adopt := ptr.Deref(driver.Spec.AdoptExistingResources, false)
+	var conflicts []environment.Conflict
+
+	conflict, err := environment.CheckOwned(ctx, r.ctrlClient, &storagev1.CSIDriver{}, "CSIDriver", driver.Spec.PluginName, "SpiffeCSIDriver", adopt)
+	if err != nil {
+		return err
+	}
+	if conflict != nil {
+		conflicts = append(conflicts, *conflict)
+	}
+
+	// SecurityContextConstraints left to the user or not served by the cluster are not created by the operator
+	if !slices.Contains(unmanagedKinds, "SecurityContextConstraints") && utils.HasCapability(utils.CapabilitySecurityContextConstraints) {
+		sccName := generateSpiffeCSIDriverSCC(nil).Name
+		conflict, err := environment.CheckOwned(ctx, r.ctrlClient, &securityv1.SecurityContextConstraints{}, "SecurityContextConstraints", sccName, "SpiffeCSIDriver", adopt)
+		if err != nil {
+			return err
+		}
+		if conflict != nil {
+			conflicts = append(conflicts, *conflict)
+		}
+	}
+
+	return environment.Report(statusMgr, conflicts, driver.Status.Conditions)
+}
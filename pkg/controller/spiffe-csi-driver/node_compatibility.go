@@ -0,0 +1,167 @@
+package spiffe_csi_driver
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/version"
+	v1helper "k8s.io/component-helpers/scheduling/corev1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// Reasons of the NodesCompatible condition
+const (
+	NodesCompatibleReasonCompatible   = "NodesCompatible"
+	NodesCompatibleReasonIncompatible = "IncompatibleNodes"
+	NodesCompatibleReasonUnavailable  = "NodeCompatibilityUnavailable"
+)
+
+const (
+	// maxIncompatibleNodes caps the nodes recorded in status.incompatibleNodes
+	maxIncompatibleNodes = 50
+
+	// maxReportedIncompatibleNodes caps the nodes named in the condition message
+	maxReportedIncompatibleNodes = 5
+)
+
+// minKubeletVersion is the first kubelet serving CSI ephemeral inline volumes as GA, which the
+// driver is mounted through
+var minKubeletVersion = version.MustParseGeneric("1.25.0")
+
+// minRuntimeVersions are the first versions of the container runtimes propagating the mounts of
+// the driver into the pods, by runtime name as reported in the node status
+var minRuntimeVersions = map[string]*version.Version{
+	"cri-o":      version.MustParseGeneric("1.25.0"),
+	"containerd": version.MustParseGeneric("1.6.0"),
+}
+
+// daemonSetTolerations are the tolerations the DaemonSet controller adds to the pods of every
+// DaemonSet, so that node conditions do not keep the driver off the nodes
+var daemonSetTolerations = []corev1.Toleration{
+	{Key: corev1.TaintNodeNotReady, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+	{Key: corev1.TaintNodeUnreachable, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+	{Key: corev1.TaintNodeDiskPressure, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	{Key: corev1.TaintNodeMemoryPressure, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	{Key: corev1.TaintNodePIDPressure, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	{Key: corev1.TaintNodeUnschedulable, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+}
+
+// nodeIncompatibility returns why the kubelet or container runtime of a node does not meet the
+// requirements of the driver, empty when the node is compatible
+func nodeIncompatibility(info corev1.NodeSystemInfo) string {
+	kubelet, err := version.ParseGeneric(info.KubeletVersion)
+	if err != nil {
+		return fmt.Sprintf("kubelet version %q cannot be parsed", info.KubeletVersion)
+	}
+	if kubelet.LessThan(minKubeletVersion) {
+		return fmt.Sprintf("kubelet %s does not serve CSI inline volumes, %s or later is required", info.KubeletVersion, minKubeletVersion)
+	}
+
+	name, runtimeVersion, _ := strings.Cut(info.ContainerRuntimeVersion, "://")
+	minRuntime, ok := minRuntimeVersions[name]
+	if !ok {
+		return fmt.Sprintf("container runtime %q is not supported, cri-o or containerd is required", info.ContainerRuntimeVersion)
+	}
+	runtime, err := version.ParseGeneric(runtimeVersion)
+	if err != nil {
+		return fmt.Sprintf("container runtime version %q cannot be parsed", info.ContainerRuntimeVersion)
+	}
+	if runtime.LessThan(minRuntime) {
+		return fmt.Sprintf("%s %s does not propagate the mounts of the driver, %s or later is required", name, runtimeVersion, minRuntime)
+	}
+	return ""
+}
+
+// nodeRunsDriver returns whether the DaemonSet of the driver is placed on the node: the node
+// matches its node selector and architectures, and its tolerations tolerate the taints of the node
+func nodeRunsDriver(logger logr.Logger, node *corev1.Node, driver *v1alpha2.SpiffeCSIDriver) bool {
+	if !labels.SelectorFromSet(driver.Spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		return false
+	}
+	tolerations := append(utils.DerefTolerations(driver.Spec.Tolerations), daemonSetTolerations...)
+	if _, untolerated := v1helper.FindMatchingUntoleratedTaint(logger, node.Spec.Taints, tolerations, func(taint *corev1.Taint) bool {
+		return taint.Effect == corev1.TaintEffectNoSchedule || taint.Effect == corev1.TaintEffectNoExecute
+	}, false); untolerated {
+		return false
+	}
+	if len(driver.Spec.Architectures) == 0 {
+		return true
+	}
+	for _, arch := range driver.Spec.Architectures {
+		if string(arch) == node.Labels[corev1.LabelArchStable] {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcileNodeCompatibility checks the kubelet and container runtime of the nodes the driver is
+// placed on against the requirements of the driver, records the incompatible nodes in status
+// and sets the NodesCompatible condition. The pods mounting the Workload API socket otherwise
+// only fail at mount time, with a kubelet error far from the cause. The check is advisory and
+// does not affect the readiness of the driver. The nodes are not watched: they are checked again
+// as the DaemonSet status changes with the nodes joining the cluster or being upgraded.
+func (r *SpiffeCsiReconciler) reconcileNodeCompatibility(ctx context.Context, driver *v1alpha2.SpiffeCSIDriver, statusMgr *status.Manager) {
+	// Nodes are not labelled as managed by the operator, so they are listed from the API server
+	var nodes corev1.NodeList
+	if err := r.ctrlClient.ListUncached(ctx, &nodes, client.MatchingLabelsSelector{Selector: labels.SelectorFromSet(driver.Spec.NodeSelector)}); err != nil {
+		statusMgr.AddCondition(utils.NodesCompatibleStatusType, NodesCompatibleReasonUnavailable,
+			fmt.Sprintf("Failed to list the nodes: %v", err),
+			metav1.ConditionUnknown)
+		return
+	}
+
+	checked := 0
+	var incompatible []v1alpha2.IncompatibleNode
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if !nodeRunsDriver(r.log, node, driver) {
+			continue
+		}
+		checked++
+		if reason := nodeIncompatibility(node.Status.NodeInfo); reason != "" {
+			incompatible = append(incompatible, v1alpha2.IncompatibleNode{
+				NodeName:                node.Name,
+				KubeletVersion:          node.Status.NodeInfo.KubeletVersion,
+				ContainerRuntimeVersion: node.Status.NodeInfo.ContainerRuntimeVersion,
+				Reason:                  reason,
+			})
+		}
+	}
+	sort.Slice(incompatible, func(i, j int) bool { return incompatible[i].NodeName < incompatible[j].NodeName })
+	if len(incompatible) > maxIncompatibleNodes {
+		driver.Status.IncompatibleNodes = incompatible[:maxIncompatibleNodes]
+	} else {
+		driver.Status.IncompatibleNodes = incompatible
+	}
+
+	if len(incompatible) == 0 {
+		statusMgr.AddCondition(utils.NodesCompatibleStatusType, NodesCompatibleReasonCompatible,
+			fmt.Sprintf("The kubelet and container runtime of the %d node(s) running the driver meet its requirements", checked),
+			metav1.ConditionTrue)
+		return
+	}
+
+	var names []string
+	for _, node := range incompatible {
+		if len(names) == maxReportedIncompatibleNodes {
+			names = append(names, fmt.Sprintf("and %d more", len(incompatible)-len(names)))
+			break
+		}
+		names = append(names, fmt.Sprintf("%s (%s)", node.NodeName, node.Reason))
+	}
+	statusMgr.AddCondition(utils.NodesCompatibleStatusType, NodesCompatibleReasonIncompatible,
+		fmt.Sprintf("%d of %d node(s) running the driver cannot mount the Workload API socket into pods: %s",
+			len(incompatible), checked, strings.Join(names, ", ")),
+		metav1.ConditionFalse)
+}
@@ -0,0 +1,142 @@
+package spiffe_csi_driver
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func testNode(name, arch, kubelet, runtime string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{corev1.LabelArchStable: arch}},
+		Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{
+			KubeletVersion:          kubelet,
+			ContainerRuntimeVersion: runtime,
+		}},
+	}
+}
+
+func TestNodeIncompatibility(t *testing.T) {
+	tests := []struct {
+		name         string
+		kubelet      string
+		runtime      string
+		expectReason string
+	}{
+		{name: "openshift node", kubelet: "v1.31.4+a1b2c3d", runtime: "cri-o://1.31.2-3.rhaos4.18.git1234.el9"},
+		{name: "containerd node", kubelet: "v1.29.0", runtime: "containerd://1.7.13"},
+		{name: "old kubelet", kubelet: "v1.24.17", runtime: "containerd://1.7.13", expectReason: "does not serve CSI inline volumes"},
+		{name: "old containerd", kubelet: "v1.29.0", runtime: "containerd://1.5.9", expectReason: "does not propagate the mounts"},
+		{name: "docker", kubelet: "v1.29.0", runtime: "docker://24.0.7", expectReason: "is not supported"},
+		{name: "unparsable kubelet", kubelet: "unknown", runtime: "containerd://1.7.13", expectReason: "cannot be parsed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := nodeIncompatibility(corev1.NodeSystemInfo{KubeletVersion: tt.kubelet, ContainerRuntimeVersion: tt.runtime})
+			if tt.expectReason == "" && reason != "" || !strings.Contains(reason, tt.expectReason) {
+				t.Errorf("Expected %q, got %q", tt.expectReason, reason)
+			}
+		})
+	}
+}
+
+func TestReconcileNodeCompatibility(t *testing.T) {
+	nodes := []corev1.Node{
+		testNode("node-a", "amd64", "v1.31.4", "cri-o://1.31.2"),
+		testNode("node-b", "amd64", "v1.24.0", "cri-o://1.24.6"),
+		testNode("node-c", "s390x", "v1.24.0", "docker://20.10.0"),
+		testNode("node-d", "amd64", "v1.24.0", "cri-o://1.24.6"),
+		testNode("node-e", "amd64", "v1.24.0", "cri-o://1.24.6"),
+	}
+	nodes[3].Spec.Taints = []corev1.Taint{
+		{Key: "node-role.kubernetes.io/infra", Effect: corev1.TaintEffectNoSchedule},
+		{Key: "example.com/maintenance", Effect: corev1.TaintEffectPreferNoSchedule},
+	}
+	nodes[4].Spec.Taints = []corev1.Taint{{Key: corev1.TaintNodeUnschedulable, Effect: corev1.TaintEffectNoSchedule}}
+
+	tests := []struct {
+		name               string
+		architectures      []v1alpha2.Architecture
+		tolerations        []*corev1.Toleration
+		listErr            error
+		expectStatus       metav1.ConditionStatus
+		expectReason       string
+		expectIncompatible []string
+	}{
+		{
+			name:               "incompatible nodes",
+			expectStatus:       metav1.ConditionFalse,
+			expectReason:       NodesCompatibleReasonIncompatible,
+			expectIncompatible: []string{"node-b", "node-c", "node-e"},
+		},
+		{
+			name:               "nodes of other architectures skipped",
+			architectures:      []v1alpha2.Architecture{"amd64"},
+			expectStatus:       metav1.ConditionFalse,
+			expectReason:       NodesCompatibleReasonIncompatible,
+			expectIncompatible: []string{"node-b", "node-e"},
+		},
+		{
+			name:               "tainted nodes tolerated",
+			architectures:      []v1alpha2.Architecture{"amd64"},
+			tolerations:        []*corev1.Toleration{{Key: "node-role.kubernetes.io/infra", Operator: corev1.TolerationOpExists}},
+			expectStatus:       metav1.ConditionFalse,
+			expectReason:       NodesCompatibleReasonIncompatible,
+			expectIncompatible: []string{"node-b", "node-d", "node-e"},
+		},
+		{
+			name:          "compatible nodes",
+			architectures: []v1alpha2.Architecture{"arm64"},
+			expectStatus:  metav1.ConditionTrue,
+			expectReason:  NodesCompatibleReasonCompatible,
+		},
+		{
+			name:         "nodes not available",
+			listErr:      errors.New("forbidden"),
+			expectStatus: metav1.ConditionUnknown,
+			expectReason: NodesCompatibleReasonUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.ListUncachedStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				if tt.listErr != nil {
+					return tt.listErr
+				}
+				list.(*corev1.NodeList).Items = nodes
+				return nil
+			}
+			reconciler := &SpiffeCsiReconciler{ctrlClient: fakeClient}
+			driver := &v1alpha2.SpiffeCSIDriver{}
+			driver.Spec.Architectures = tt.architectures
+			driver.Spec.Tolerations = tt.tolerations
+			statusMgr := status.NewManager(fakeClient)
+
+			reconciler.reconcileNodeCompatibility(context.Background(), driver, statusMgr)
+			cond, _ := statusMgr.GetCondition(utils.NodesCompatibleStatusType)
+			if cond.Status != tt.expectStatus || cond.Reason != tt.expectReason {
+				t.Errorf("Expected %s/%s, got %s/%s: %s", tt.expectStatus, tt.expectReason, cond.Status, cond.Reason, cond.Message)
+			}
+			if len(driver.Status.IncompatibleNodes) != len(tt.expectIncompatible) {
+				t.Fatalf("Expected incompatible nodes %v, got %v", tt.expectIncompatible, driver.Status.IncompatibleNodes)
+			}
+			for i, name := range tt.expectIncompatible {
+				if driver.Status.IncompatibleNodes[i].NodeName != name {
+					t.Errorf("Expected incompatible node %s, got %s", name, driver.Status.IncompatibleNodes[i].NodeName)
+				}
+			}
+		})
+	}
+}
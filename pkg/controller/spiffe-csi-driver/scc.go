@@ -13,7 +13,8 @@ import (
 	"k8s.io/utils/ptr"
 
 	securityv1 "github.com/openshift/api/security/v1"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 )
@@ -62,7 +63,16 @@ func generateSpiffeCSIDriverSCC(customLabels map[string]string) *securityv1.Secu
 }
 
 // reconcileSCC reconciles the Spiffe CSI Driver Security Context Constraints
-func (r *SpiffeCsiReconciler) reconcileSCC(ctx context.Context, driver *v1alpha1.SpiffeCSIDriver, statusMgr *status.Manager) error {
+func (r *SpiffeCsiReconciler) reconcileSCC(ctx context.Context, driver *v1alpha2.SpiffeCSIDriver, statusMgr *status.Manager) error {
+	if !utils.HasCapability(utils.CapabilitySecurityContextConstraints) {
+		// Without SecurityContextConstraints pod security admission applies: the operator
+		// namespace must allow privileged pods
+		statusMgr.AddCondition(SecurityContextConstraintsAvailable, utils.ReasonAPINotServed,
+			"SecurityContextConstraints are not served by the cluster, pod security admission applies",
+			metav1.ConditionTrue)
+		return nil
+	}
+
 	desired := generateSpiffeCSIDriverSCC(driver.Spec.Labels)
 	if err := controllerutil.SetControllerReference(driver, desired, r.scheme); err != nil {
 		r.log.Error(err, "failed to set the owner reference for the SCC resource")
@@ -87,7 +97,7 @@ func (r *SpiffeCsiReconciler) reconcileSCC(ctx context.Context, driver *v1alpha1
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(driver.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "Failed to create SpiffeCsiSCC")
 			statusMgr.AddCondition(SecurityContextConstraintsAvailable, "SpiffeCSISCCCreationFailed",
 				err.Error(),
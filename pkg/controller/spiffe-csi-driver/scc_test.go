@@ -8,7 +8,7 @@ import (
 
 	"github.com/go-logr/logr"
 	securityv1 "github.com/openshift/api/security/v1"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
@@ -516,7 +516,7 @@ func TestSCCImmutability(t *testing.T) {
 // newSCCTestReconciler creates a reconciler for SCC tests
 func newSCCTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpiffeCsiReconciler {
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = securityv1.AddToScheme(scheme)
 	return &SpiffeCsiReconciler{
 		ctrlClient:    fakeClient,
@@ -531,7 +531,7 @@ func newSCCTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpiffeCsiReco
 func TestReconcileSCC(t *testing.T) {
 	tests := []struct {
 		name           string
-		driver         *v1alpha1.SpiffeCSIDriver
+		driver         *v1alpha2.SpiffeCSIDriver
 		setupClient    func(*fakes.FakeCustomCtrlClient)
 		useEmptyScheme bool
 		expectError    bool
@@ -540,7 +540,7 @@ func TestReconcileSCC(t *testing.T) {
 	}{
 		{
 			name: "create success",
-			driver: &v1alpha1.SpiffeCSIDriver{
+			driver: &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -551,7 +551,7 @@ func TestReconcileSCC(t *testing.T) {
 		},
 		{
 			name: "create error",
-			driver: &v1alpha1.SpiffeCSIDriver{
+			driver: &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -562,7 +562,7 @@ func TestReconcileSCC(t *testing.T) {
 		},
 		{
 			name: "get error",
-			driver: &v1alpha1.SpiffeCSIDriver{
+			driver: &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -572,7 +572,7 @@ func TestReconcileSCC(t *testing.T) {
 		},
 		{
 			name: "no update needed",
-			driver: &v1alpha1.SpiffeCSIDriver{
+			driver: &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -588,10 +588,10 @@ func TestReconcileSCC(t *testing.T) {
 		},
 		{
 			name: "update success",
-			driver: &v1alpha1.SpiffeCSIDriver{
+			driver: &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpiffeCSIDriverSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpiffeCSIDriverSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -616,10 +616,10 @@ func TestReconcileSCC(t *testing.T) {
 		},
 		{
 			name: "update error",
-			driver: &v1alpha1.SpiffeCSIDriver{
+			driver: &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpiffeCSIDriverSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpiffeCSIDriverSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -645,7 +645,7 @@ func TestReconcileSCC(t *testing.T) {
 		},
 		{
 			name: "set controller ref error",
-			driver: &v1alpha1.SpiffeCSIDriver{
+			driver: &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient:    func(fc *fakes.FakeCustomCtrlClient) {},
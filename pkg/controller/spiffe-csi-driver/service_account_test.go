@@ -6,7 +6,7 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
@@ -52,7 +52,7 @@ func TestGetSpiffeCSIDriverServiceAccount(t *testing.T) {
 // newSATestReconciler creates a reconciler for ServiceAccount tests
 func newSATestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpiffeCsiReconciler {
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 	return &SpiffeCsiReconciler{
 		ctrlClient:    fakeClient,
@@ -67,7 +67,7 @@ func newSATestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpiffeCsiRecon
 func TestReconcileServiceAccount(t *testing.T) {
 	tests := []struct {
 		name           string
-		driver         *v1alpha1.SpiffeCSIDriver
+		driver         *v1alpha2.SpiffeCSIDriver
 		setupClient    func(*fakes.FakeCustomCtrlClient)
 		createOnlyMode bool
 		useEmptyScheme bool
@@ -77,7 +77,7 @@ func TestReconcileServiceAccount(t *testing.T) {
 	}{
 		{
 			name: "create success",
-			driver: &v1alpha1.SpiffeCSIDriver{
+			driver: &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -88,7 +88,7 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "create error",
-			driver: &v1alpha1.SpiffeCSIDriver{
+			driver: &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -99,7 +99,7 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "get error",
-			driver: &v1alpha1.SpiffeCSIDriver{
+			driver: &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -109,7 +109,7 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "create only mode skips update",
-			driver: &v1alpha1.SpiffeCSIDriver{
+			driver: &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -131,10 +131,10 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "update success",
-			driver: &v1alpha1.SpiffeCSIDriver{
+			driver: &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpiffeCSIDriverSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpiffeCSIDriverSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -160,10 +160,10 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "update error",
-			driver: &v1alpha1.SpiffeCSIDriver{
+			driver: &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpiffeCSIDriverSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpiffeCSIDriverSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -190,7 +190,7 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "set controller ref error",
-			driver: &v1alpha1.SpiffeCSIDriver{
+			driver: &v1alpha2.SpiffeCSIDriver{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient:    func(fc *fakes.FakeCustomCtrlClient) {},
@@ -0,0 +1,153 @@
+package spiffe_helper
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/namespaceaccess"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// ConfigMapAvailable reports the ConfigMap the helper configuration is rendered to
+const ConfigMapAvailable = "ConfigMapAvailable"
+
+// Reasons of the ConfigMapAvailable condition
+const (
+	ConfigMapReasonAvailable = "SpiffeHelperConfigMapAvailable"
+	ConfigMapReasonFailed    = "SpiffeHelperConfigMapCreationFailed"
+)
+
+// SpiffeHelperConfigReconciler renders the SpiffeHelperConfigs to the ConfigMaps mounted by the
+// injected spiffe-helper sidecars
+type SpiffeHelperConfigReconciler struct {
+	ctrlClient    customClient.CustomCtrlClient
+	eventRecorder record.EventRecorder
+	log           logr.Logger
+	scheme        *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=operator.openshift.io,resources=spiffehelperconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=operator.openshift.io,resources=spiffehelperconfigs/status,verbs=get;update
+
+// New returns a new Reconciler instance.
+func New(mgr ctrl.Manager) (*SpiffeHelperConfigReconciler, error) {
+	c, err := customClient.NewCustomClient(mgr)
+	if err != nil {
+		return nil, err
+	}
+	return &SpiffeHelperConfigReconciler{
+		ctrlClient:    c,
+		eventRecorder: mgr.GetEventRecorderFor(utils.ZeroTrustWorkloadIdentityManagerSpiffeHelperControllerName),
+		log:           ctrl.Log.WithName(utils.ZeroTrustWorkloadIdentityManagerSpiffeHelperControllerName),
+		scheme:        mgr.GetScheme(),
+	}, nil
+}
+
+func (r *SpiffeHelperConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, reconcileErr error) {
+	traced := *r
+	ctx, traced.log, traced.eventRecorder = utils.TraceReconcile(ctx, r.log, r.eventRecorder)
+	r = &traced
+	r.log.Info("reconciling", "controller", utils.ZeroTrustWorkloadIdentityManagerSpiffeHelperControllerName, "namespace", req.Namespace, "name", req.Name)
+	var config v1alpha2.SpiffeHelperConfig
+	if err := r.ctrlClient.Get(ctx, req.NamespacedName, &config); err != nil {
+		if kerrors.IsNotFound(err) {
+			status.Forget(&config, req.NamespacedName)
+			// The ConfigMap is deleted with its owner, the access to the namespace is no longer needed
+			if err := namespaceaccess.Revoke(ctx, r.ctrlClient, req.Namespace); err != nil {
+				return utils.ReconcileResult(utils.FromClientError(err, "failed to revoke the access to namespace %s", req.Namespace))
+			}
+			return ctrl.Result{}, nil
+		}
+		return utils.ReconcileResult(err)
+	}
+
+	statusMgr := status.NewManager(r.ctrlClient)
+	defer func() {
+		statusMgr.SetReadyCondition()
+		if err := statusMgr.ApplyStatus(ctx, &config, func() *v1alpha2.ConditionalStatus {
+			return &config.Status.ConditionalStatus
+		}); err != nil {
+			r.log.Error(err, "failed to update status")
+		}
+		res = statusMgr.RequeueHeldBack(res, reconcileErr)
+	}()
+
+	return utils.ReconcileResult(r.reconcileConfigMap(ctx, &config, statusMgr))
+}
+
+// reconcileConfigMap renders the helper configuration to its ConfigMap
+func (r *SpiffeHelperConfigReconciler) reconcileConfigMap(ctx context.Context, config *v1alpha2.SpiffeHelperConfig, statusMgr *status.Manager) error {
+	desired := generateConfigMap(config)
+	name := fmt.Sprintf("%s/%s", desired.Namespace, desired.Name)
+	if err := controllerutil.SetControllerReference(config, desired, r.scheme); err != nil {
+		return utils.FromError(err, "failed to set the owner of ConfigMap %s", name)
+	}
+
+	if err := namespaceaccess.Grant(ctx, r.ctrlClient, desired.Namespace); err != nil {
+		statusMgr.AddCondition(ConfigMapAvailable, ConfigMapReasonFailed,
+			fmt.Sprintf("Failed to get access to namespace %s: %v", desired.Namespace, err),
+			metav1.ConditionFalse)
+		return utils.FromClientError(err, "failed to get access to namespace %s", desired.Namespace)
+	}
+
+	// The ConfigMaps outside the operator namespace are not cached, they are read from the API server
+	var existing corev1.ConfigMap
+	err := r.ctrlClient.GetUncached(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, &existing)
+	switch {
+	case kerrors.IsNotFound(err):
+		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+			statusMgr.AddCondition(ConfigMapAvailable, ConfigMapReasonFailed,
+				fmt.Sprintf("Failed to create ConfigMap %s: %v", name, err),
+				metav1.ConditionFalse)
+			return utils.FromClientError(err, "failed to create ConfigMap %s", name)
+		}
+		r.log.Info("Created ConfigMap", "Namespace", desired.Namespace, "Name", desired.Name)
+	case err != nil:
+		statusMgr.AddCondition(ConfigMapAvailable, ConfigMapReasonFailed,
+			fmt.Sprintf("Failed to get ConfigMap %s: %v", name, err),
+			metav1.ConditionFalse)
+		// The access granted to the namespace may not be effective yet
+		return utils.NewRetryRequiredError(err, "failed to get ConfigMap %s", name)
+	case !equality.Semantic.DeepEqual(existing.Data, desired.Data) || !equality.Semantic.DeepEqual(existing.Labels, desired.Labels):
+		desired.ResourceVersion = existing.ResourceVersion
+		if err := r.ctrlClient.Update(ctx, desired); err != nil {
+			statusMgr.AddCondition(ConfigMapAvailable, ConfigMapReasonFailed,
+				fmt.Sprintf("Failed to update ConfigMap %s: %v", name, err),
+				metav1.ConditionFalse)
+			return utils.FromClientError(err, "failed to update ConfigMap %s", name)
+		}
+		r.log.Info("Updated ConfigMap", "Namespace", desired.Namespace, "Name", desired.Name)
+	}
+
+	config.Status.ConfigMapName = desired.Name
+	statusMgr.AddCondition(ConfigMapAvailable, ConfigMapReasonAvailable,
+		fmt.Sprintf("Helper configuration rendered to ConfigMap %s, mounted by the pods labelled %s=%s", desired.Name, utils.SpiffeHelperLabel, config.Name),
+		metav1.ConditionTrue)
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager. The ConfigMaps are in the namespaces of
+// the configurations, which the operator does not watch: they are only written when their
+// configuration changes.
+func (r *SpiffeHelperConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha2.SpiffeHelperConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named(utils.ZeroTrustWorkloadIdentityManagerSpiffeHelperControllerName).
+		Complete(r)
+}
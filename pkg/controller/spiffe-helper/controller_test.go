@@ -0,0 +1,142 @@
+package spiffe_helper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+)
+
+func newTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpiffeHelperConfigReconciler {
+	scheme := runtime.NewScheme()
+	_ = v1alpha2.AddToScheme(scheme)
+	return &SpiffeHelperConfigReconciler{
+		ctrlClient:    fakeClient,
+		eventRecorder: record.NewFakeRecorder(10),
+		log:           logr.Discard(),
+		scheme:        scheme,
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	config := v1alpha2.SpiffeHelperConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "legacy-app", Namespace: "apps", UID: "config-uid"},
+	}
+	tests := []struct {
+		name         string
+		existing     *corev1.ConfigMap
+		createErr    error
+		expectCreate bool
+		expectUpdate bool
+		expectStatus metav1.ConditionStatus
+		expectError  bool
+	}{
+		{
+			name:         "ConfigMap created",
+			expectCreate: true,
+			expectStatus: metav1.ConditionTrue,
+		},
+		{
+			name:         "ConfigMap up to date",
+			existing:     generateConfigMap(&config),
+			expectStatus: metav1.ConditionTrue,
+		},
+		{
+			name:         "ConfigMap updated",
+			existing:     &corev1.ConfigMap{Data: map[string]string{configFileName: "cert_dir = \"/old\""}},
+			expectUpdate: true,
+			expectStatus: metav1.ConditionTrue,
+		},
+		{
+			name:         "ConfigMap creation failed",
+			createErr:    errors.New("forbidden"),
+			expectCreate: true,
+			expectStatus: metav1.ConditionFalse,
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				config.DeepCopyInto(obj.(*v1alpha2.SpiffeHelperConfig))
+				return nil
+			}
+			fakeClient.GetUncachedStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				if tt.existing == nil {
+					return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+				}
+				tt.existing.DeepCopyInto(obj.(*corev1.ConfigMap))
+				return nil
+			}
+			fakeClient.CreateReturns(tt.createErr)
+			r := newTestReconciler(fakeClient)
+
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&config)})
+			if (err != nil) != tt.expectError {
+				t.Fatalf("Expected error %v, got %v", tt.expectError, err)
+			}
+			if created := fakeClient.CreateCallCount() == 1; created != tt.expectCreate {
+				t.Errorf("Expected create %v, got %v", tt.expectCreate, created)
+			}
+			if updated := fakeClient.UpdateCallCount() == 1; updated != tt.expectUpdate {
+				t.Errorf("Expected update %v, got %v", tt.expectUpdate, updated)
+			}
+			if tt.expectCreate {
+				_, obj, _ := fakeClient.CreateArgsForCall(0)
+				if cm := obj.(*corev1.ConfigMap); cm.Name != "spiffe-helper-legacy-app" || cm.Namespace != "apps" || len(cm.OwnerReferences) != 1 {
+					t.Errorf("Unexpected ConfigMap %v", cm.ObjectMeta)
+				}
+			}
+
+			if fakeClient.StatusUpdateWithRetryCallCount() != 1 {
+				t.Fatalf("Expected the status updated once, got %d", fakeClient.StatusUpdateWithRetryCallCount())
+			}
+			_, obj, _ := fakeClient.StatusUpdateWithRetryArgsForCall(0)
+			updated := obj.(*v1alpha2.SpiffeHelperConfig)
+			var found bool
+			for _, cond := range updated.Status.Conditions {
+				if cond.Type == ConfigMapAvailable {
+					found = true
+					if cond.Status != tt.expectStatus {
+						t.Errorf("Expected %s %s, got %v", ConfigMapAvailable, tt.expectStatus, cond)
+					}
+				}
+			}
+			if !found {
+				t.Errorf("Expected the %s condition, got %v", ConfigMapAvailable, updated.Status.Conditions)
+			}
+		})
+	}
+}
+
+func TestReconcile_Deleted(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+	t.Setenv("OPERATOR_SERVICE_ACCOUNT", "controller-manager")
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	fakeClient.GetReturns(kerrors.NewNotFound(schema.GroupResource{}, "legacy-app"))
+	r := newTestReconciler(fakeClient)
+
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKey{Namespace: "apps", Name: "legacy-app"}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if fakeClient.DeleteCallCount() != 1 {
+		t.Fatalf("Expected the access to the namespace revoked, got %d deletes", fakeClient.DeleteCallCount())
+	}
+	if _, obj, _ := fakeClient.DeleteArgsForCall(0); obj.GetNamespace() != "apps" {
+		t.Errorf("Expected the access to namespace apps revoked, got %s", obj.GetNamespace())
+	}
+}
@@ -0,0 +1,182 @@
+package spiffe_helper
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	// ContainerName is the name of the injected sidecar
+	ContainerName = "spiffe-helper"
+
+	// configFileName is the key of the helper configuration in its ConfigMap
+	configFileName = "helper.conf"
+
+	certsVolumeName       = "spiffe-helper-certs"
+	configVolumeName      = "spiffe-helper-config"
+	workloadAPIVolumeName = "spiffe-helper-workload-api"
+
+	configMountPath      = "/etc/spiffe-helper"
+	workloadAPIMountPath = "/spiffe-workload-api"
+
+	// agentSocketName is the Workload API socket of the SPIRE agent, exposed by the CSI driver
+	agentSocketName = "spire-agent.sock"
+	csiDriverName   = "csi.spiffe.io"
+
+	// Defaults of the SpiffeHelperConfig, applied by the API server
+	defaultCertDir            = "/run/spiffe/certs"
+	defaultSVIDFileName       = "svid.pem"
+	defaultSVIDKeyFileName    = "svid_key.pem"
+	defaultSVIDBundleFileName = "svid_bundle.pem"
+)
+
+// ConfigMapName returns the ConfigMap the configuration of the SpiffeHelperConfig is rendered to
+func ConfigMapName(configName string) string {
+	return "spiffe-helper-" + configName
+}
+
+// withDefaults returns the spec with the defaults of the API applied
+func withDefaults(spec v1alpha2.SpiffeHelperConfigSpec) v1alpha2.SpiffeHelperConfigSpec {
+	if spec.CertDir == "" {
+		spec.CertDir = defaultCertDir
+	}
+	if spec.SVIDFileName == "" {
+		spec.SVIDFileName = defaultSVIDFileName
+	}
+	if spec.SVIDKeyFileName == "" {
+		spec.SVIDKeyFileName = defaultSVIDKeyFileName
+	}
+	if spec.SVIDBundleFileName == "" {
+		spec.SVIDBundleFileName = defaultSVIDBundleFileName
+	}
+	return spec
+}
+
+// renderConfig renders the HCL configuration of spiffe-helper. The helper runs as a daemon
+// renewing the files, without a command to signal as it does not share the process namespace of
+// the applications, which are expected to reload the files themselves.
+func renderConfig(spec v1alpha2.SpiffeHelperConfigSpec) string {
+	spec = withDefaults(spec)
+	var b strings.Builder
+	setting := func(key, value string) {
+		fmt.Fprintf(&b, "%s = %s\n", key, value)
+	}
+	setting("agent_address", strconv.Quote(path.Join(workloadAPIMountPath, agentSocketName)))
+	setting("cmd", `""`)
+	setting("cmd_args", `""`)
+	setting("cert_dir", strconv.Quote(spec.CertDir))
+	setting("daemon_mode", "true")
+	setting("svid_file_name", strconv.Quote(spec.SVIDFileName))
+	setting("svid_key_file_name", strconv.Quote(spec.SVIDKeyFileName))
+	setting("svid_bundle_file_name", strconv.Quote(spec.SVIDBundleFileName))
+	setting("include_federated_domains", strconv.FormatBool(spec.IncludeFederatedDomains))
+	if spec.JWTBundleFileName != "" {
+		setting("jwt_bundle_file_name", strconv.Quote(spec.JWTBundleFileName))
+	}
+	if len(spec.JWTSVIDs) > 0 {
+		b.WriteString("jwt_svids = [\n")
+		for _, jwtSVID := range spec.JWTSVIDs {
+			fmt.Fprintf(&b, "  {\n    jwt_audience = %s\n    jwt_svid_file_name = %s\n  },\n",
+				strconv.Quote(jwtSVID.Audience), strconv.Quote(jwtSVID.FileName))
+		}
+		b.WriteString("]\n")
+	}
+	return b.String()
+}
+
+// generateConfigMap returns the ConfigMap of the helper configuration of the SpiffeHelperConfig
+func generateConfigMap(config *v1alpha2.SpiffeHelperConfig) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName(config.Name),
+			Namespace: config.Namespace,
+			Labels: map[string]string{
+				utils.AppManagedByLabelKey: utils.AppManagedByLabelValue,
+				utils.SpiffeHelperLabel:    config.Name,
+			},
+		},
+		Data: map[string]string{configFileName: renderConfig(config.Spec)},
+	}
+}
+
+// Inject adds the spiffe-helper sidecar configured by the SpiffeHelperConfig to the pod, and
+// mounts the directory of the files it writes in the application containers. The sidecar is
+// started before the other containers, as a restartable init container. Pods which already run
+// the sidecar are left as is.
+func Inject(pod *corev1.Pod, config *v1alpha2.SpiffeHelperConfig, image string) error {
+	if image == "" {
+		return fmt.Errorf("the spiffe-helper image is not configured, %s is not set", utils.SpiffeHelperImageEnv)
+	}
+	if slices.ContainsFunc(pod.Spec.InitContainers, func(c corev1.Container) bool { return c.Name == ContainerName }) {
+		return nil
+	}
+	spec := withDefaults(config.Spec)
+
+	sidecar := corev1.Container{
+		Name:          ContainerName,
+		Image:         image,
+		Args:          []string{"-config", path.Join(configMountPath, configFileName)},
+		RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways),
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: certsVolumeName, MountPath: spec.CertDir},
+			{Name: configVolumeName, MountPath: configMountPath, ReadOnly: true},
+			{Name: workloadAPIVolumeName, MountPath: workloadAPIMountPath, ReadOnly: true},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: ptr.To(false),
+			ReadOnlyRootFilesystem:   ptr.To(true),
+			Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+			SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		},
+	}
+	if spec.Resources != nil {
+		sidecar.Resources = *spec.Resources
+	}
+	pod.Spec.InitContainers = append([]corev1.Container{sidecar}, pod.Spec.InitContainers...)
+
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		if len(spec.Containers) > 0 && !slices.Contains(spec.Containers, container.Name) {
+			continue
+		}
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      certsVolumeName,
+			MountPath: spec.CertDir,
+			ReadOnly:  true,
+		})
+	}
+
+	pod.Spec.Volumes = append(pod.Spec.Volumes,
+		corev1.Volume{
+			Name: certsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory},
+			},
+		},
+		corev1.Volume{
+			Name: configVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: ConfigMapName(config.Name)},
+				},
+			},
+		},
+		corev1.Volume{
+			Name: workloadAPIVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				CSI: &corev1.CSIVolumeSource{Driver: csiDriverName, ReadOnly: ptr.To(true)},
+			},
+		},
+	)
+	return nil
+}
@@ -0,0 +1,77 @@
+package spiffe_helper
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+func TestRenderConfig(t *testing.T) {
+	config := renderConfig(v1alpha2.SpiffeHelperConfigSpec{
+		JWTSVIDs:          []v1alpha2.JWTSVIDFile{{Audience: "db", FileName: "db.token"}},
+		JWTBundleFileName: "jwt_bundle.json",
+	})
+	for _, expected := range []string{
+		`agent_address = "/spiffe-workload-api/spire-agent.sock"`,
+		`cert_dir = "/run/spiffe/certs"`,
+		`daemon_mode = true`,
+		`svid_file_name = "svid.pem"`,
+		`svid_key_file_name = "svid_key.pem"`,
+		`svid_bundle_file_name = "svid_bundle.pem"`,
+		`jwt_bundle_file_name = "jwt_bundle.json"`,
+		`jwt_audience = "db"`,
+		`jwt_svid_file_name = "db.token"`,
+	} {
+		if !strings.Contains(config, expected) {
+			t.Errorf("Expected %q in the configuration:\n%s", expected, config)
+		}
+	}
+
+	if config := renderConfig(v1alpha2.SpiffeHelperConfigSpec{}); strings.Contains(config, "jwt_") {
+		t.Errorf("Expected no JWT settings when none are configured:\n%s", config)
+	}
+}
+
+func TestInject(t *testing.T) {
+	config := &v1alpha2.SpiffeHelperConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "legacy-app", Namespace: "apps"},
+		Spec:       v1alpha2.SpiffeHelperConfigSpec{CertDir: "/certs", Containers: []string{"app"}},
+	}
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "migrate"}},
+		Containers:     []corev1.Container{{Name: "app"}, {Name: "proxy"}},
+	}}
+
+	if err := Inject(pod, config, ""); err == nil {
+		t.Fatal("Expected an error without image")
+	}
+	if err := Inject(pod, config, "ghcr.io/spiffe/spiffe-helper:test"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(pod.Spec.InitContainers) != 2 || pod.Spec.InitContainers[0].Name != ContainerName {
+		t.Fatalf("Expected the sidecar to start first, got %v", pod.Spec.InitContainers)
+	}
+	sidecar := pod.Spec.InitContainers[0]
+	if sidecar.RestartPolicy == nil || *sidecar.RestartPolicy != corev1.ContainerRestartPolicyAlways {
+		t.Error("Expected the sidecar to be a restartable init container")
+	}
+	if len(pod.Spec.Containers[0].VolumeMounts) != 1 || pod.Spec.Containers[0].VolumeMounts[0].MountPath != "/certs" || !pod.Spec.Containers[0].VolumeMounts[0].ReadOnly {
+		t.Errorf("Expected the certificates mounted read-only in the selected container, got %v", pod.Spec.Containers[0].VolumeMounts)
+	}
+	if len(pod.Spec.Containers[1].VolumeMounts) != 0 {
+		t.Errorf("Expected the certificates not mounted in the other containers, got %v", pod.Spec.Containers[1].VolumeMounts)
+	}
+	if len(pod.Spec.Volumes) != 3 || pod.Spec.Volumes[1].ConfigMap.Name != "spiffe-helper-legacy-app" || pod.Spec.Volumes[2].CSI.Driver != csiDriverName {
+		t.Errorf("Unexpected volumes %v", pod.Spec.Volumes)
+	}
+
+	// The injection is idempotent
+	if err := Inject(pod, config, "ghcr.io/spiffe/spiffe-helper:test"); err != nil || len(pod.Spec.InitContainers) != 2 || len(pod.Spec.Volumes) != 3 {
+		t.Errorf("Expected the pod left as is, got %v, %v", pod.Spec.InitContainers, err)
+	}
+}
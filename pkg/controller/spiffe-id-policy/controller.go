@@ -0,0 +1,205 @@
+package spiffe_id_policy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	spiffev1alpha1 "github.com/spiffe/spire-controller-manager/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// Compliant reports whether the existing entries comply with the policy
+const Compliant = "Compliant"
+
+// Reasons of the Compliant condition
+const (
+	CompliantReasonCompliant   = "Compliant"
+	CompliantReasonViolations  = "PolicyViolations"
+	CompliantReasonAuditFailed = "AuditFailed"
+)
+
+const (
+	// auditInterval is how often the existing entries are audited, as the entries created by
+	// users are not cached by the operator
+	auditInterval = 5 * time.Minute
+
+	// maxReportedViolations bounds the violations listed in the status
+	maxReportedViolations = 50
+
+	// maxViolationsInMessage bounds the violations named in the Compliant condition
+	maxViolationsInMessage = 5
+)
+
+// SPIFFEIDPolicyReconciler audits the existing ClusterSPIFFEIDs and ClusterStaticEntries against
+// the SPIFFEIDPolicies, reporting the entries admitted before a policy or while the webhook was
+// unavailable
+type SPIFFEIDPolicyReconciler struct {
+	ctrlClient    customClient.CustomCtrlClient
+	eventRecorder record.EventRecorder
+	log           logr.Logger
+}
+
+// +kubebuilder:rbac:groups=operator.openshift.io,resources=spiffeidpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=operator.openshift.io,resources=spiffeidpolicies/status,verbs=get;update
+
+// New returns a new Reconciler instance.
+func New(mgr ctrl.Manager) (*SPIFFEIDPolicyReconciler, error) {
+	c, err := customClient.NewCustomClient(mgr)
+	if err != nil {
+		return nil, err
+	}
+	return &SPIFFEIDPolicyReconciler{
+		ctrlClient:    c,
+		eventRecorder: mgr.GetEventRecorderFor(utils.ZeroTrustWorkloadIdentityManagerSPIFFEIDPolicyControllerName),
+		log:           ctrl.Log.WithName(utils.ZeroTrustWorkloadIdentityManagerSPIFFEIDPolicyControllerName),
+	}, nil
+}
+
+func (r *SPIFFEIDPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, reconcileErr error) {
+	traced := *r
+	ctx, traced.log, traced.eventRecorder = utils.TraceReconcile(ctx, r.log, r.eventRecorder)
+	r = &traced
+	r.log.Info("reconciling", "controller", utils.ZeroTrustWorkloadIdentityManagerSPIFFEIDPolicyControllerName, "name", req.Name)
+	var policy v1alpha2.SPIFFEIDPolicy
+	if err := r.ctrlClient.Get(ctx, req.NamespacedName, &policy); err != nil {
+		if kerrors.IsNotFound(err) {
+			status.Forget(&policy, req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		return utils.ReconcileResult(err)
+	}
+
+	statusMgr := status.NewManager(r.ctrlClient)
+	defer func() {
+		statusMgr.SetReadyCondition()
+		if err := statusMgr.ApplyStatus(ctx, &policy, func() *v1alpha2.ConditionalStatus {
+			return &policy.Status.ConditionalStatus
+		}); err != nil {
+			r.log.Error(err, "failed to update status")
+		}
+		res = statusMgr.RequeueHeldBack(res, reconcileErr)
+	}()
+
+	if err := r.audit(ctx, &policy, statusMgr); err != nil {
+		return utils.ReconcileResult(err)
+	}
+	return ctrl.Result{RequeueAfter: auditInterval}, nil
+}
+
+// audit checks the existing entries against the policy and reports its violations
+func (r *SPIFFEIDPolicyReconciler) audit(ctx context.Context, policy *v1alpha2.SPIFFEIDPolicy, statusMgr *status.Manager) error {
+	entries, err := r.listEntries(ctx)
+	if err != nil {
+		statusMgr.AddCondition(Compliant, CompliantReasonAuditFailed,
+			fmt.Sprintf("Failed to list the entries: %v", err),
+			metav1.ConditionUnknown)
+		return err
+	}
+
+	var violations []v1alpha2.SPIFFEIDPolicyViolation
+	for _, entry := range entries {
+		rules, err := Check(policy, entry)
+		if err != nil {
+			statusMgr.AddCondition(Compliant, v1alpha2.ReasonFailed, err.Error(), metav1.ConditionFalse)
+			return utils.NewInvalidConfigurationError(err, "invalid SPIFFEIDPolicy")
+		}
+		if len(rules) > 0 {
+			violations = append(violations, v1alpha2.SPIFFEIDPolicyViolation{
+				Kind:     entry.Kind,
+				Name:     entry.Name,
+				SPIFFEID: entry.SPIFFEID,
+				Rules:    rules,
+			})
+		}
+	}
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].Kind != violations[j].Kind {
+			return violations[i].Kind < violations[j].Kind
+		}
+		return violations[i].Name < violations[j].Name
+	})
+
+	policy.Status.ViolationCount = int32(len(violations))
+	policy.Status.Violations = nil
+	if len(violations) > 0 {
+		policy.Status.Violations = violations[:min(len(violations), maxReportedViolations)]
+	}
+	if len(violations) == 0 {
+		statusMgr.AddCondition(Compliant, CompliantReasonCompliant,
+			fmt.Sprintf("The %d entries selected by the policy comply with it", countSelected(policy, entries)),
+			metav1.ConditionTrue)
+		return nil
+	}
+
+	// The violating entries are named in the message, so that the status is written when they change
+	var names []string
+	for _, violation := range violations[:min(len(violations), maxViolationsInMessage)] {
+		names = append(names, violation.Kind+"/"+violation.Name)
+	}
+	if len(violations) > maxViolationsInMessage {
+		names = append(names, fmt.Sprintf("and %d more", len(violations)-maxViolationsInMessage))
+	}
+	message := fmt.Sprintf("%d entries violate the policy: %s", len(violations), strings.Join(names, ", "))
+	if !apimeta.IsStatusConditionFalse(policy.Status.Conditions, Compliant) {
+		r.eventRecorder.Event(policy, corev1.EventTypeWarning, CompliantReasonViolations, message)
+	}
+	statusMgr.AddCondition(Compliant, CompliantReasonViolations, message, metav1.ConditionFalse)
+	return nil
+}
+
+// listEntries lists the ClusterSPIFFEIDs and ClusterStaticEntries from the API server, as the
+// cache only holds those managed by the operator
+func (r *SPIFFEIDPolicyReconciler) listEntries(ctx context.Context) ([]Entry, error) {
+	var clusterSPIFFEIDs spiffev1alpha1.ClusterSPIFFEIDList
+	if err := r.ctrlClient.ListUncached(ctx, &clusterSPIFFEIDs); err != nil {
+		return nil, utils.FromClientError(err, "failed to list ClusterSPIFFEIDs")
+	}
+	var staticEntries spiffev1alpha1.ClusterStaticEntryList
+	if err := r.ctrlClient.ListUncached(ctx, &staticEntries); err != nil {
+		return nil, utils.FromClientError(err, "failed to list ClusterStaticEntries")
+	}
+
+	entries := make([]Entry, 0, len(clusterSPIFFEIDs.Items)+len(staticEntries.Items))
+	for i := range clusterSPIFFEIDs.Items {
+		entries = append(entries, EntryFromClusterSPIFFEID(&clusterSPIFFEIDs.Items[i]))
+	}
+	for i := range staticEntries.Items {
+		entries = append(entries, EntryFromClusterStaticEntry(&staticEntries.Items[i]))
+	}
+	return entries, nil
+}
+
+// countSelected returns the number of entries selected by a rule of the policy
+func countSelected(policy *v1alpha2.SPIFFEIDPolicy, entries []Entry) int {
+	var selected int
+	for _, entry := range entries {
+		if Selects(policy, entry) {
+			selected++
+		}
+	}
+	return selected
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SPIFFEIDPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha2.SPIFFEIDPolicy{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named(utils.ZeroTrustWorkloadIdentityManagerSPIFFEIDPolicyControllerName).
+		Complete(r)
+}
@@ -0,0 +1,116 @@
+package spiffe_id_policy
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	spiffev1alpha1 "github.com/spiffe/spire-controller-manager/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+)
+
+func TestReconcile(t *testing.T) {
+	tests := []struct {
+		name             string
+		clusterSPIFFEIDs []spiffev1alpha1.ClusterSPIFFEID
+		staticEntries    []spiffev1alpha1.ClusterStaticEntry
+		existingStatus   metav1.ConditionStatus
+		expectStatus     metav1.ConditionStatus
+		expectViolations []string
+		expectEvent      bool
+	}{
+		{
+			name: "compliant",
+			clusterSPIFFEIDs: []spiffev1alpha1.ClusterSPIFFEID{{
+				ObjectMeta: metav1.ObjectMeta{Name: "payments-api", Labels: map[string]string{"team": "payments"}},
+				Spec:       spiffev1alpha1.ClusterSPIFFEIDSpec{SPIFFEIDTemplate: "spiffe://example.org/ns/payments/api"},
+			}},
+			expectStatus: metav1.ConditionTrue,
+		},
+		{
+			name: "violations",
+			clusterSPIFFEIDs: []spiffev1alpha1.ClusterSPIFFEID{{
+				ObjectMeta: metav1.ObjectMeta{Name: "payments-api", Labels: map[string]string{"team": "payments"}},
+				Spec:       spiffev1alpha1.ClusterSPIFFEIDSpec{SPIFFEIDTemplate: "spiffe://example.org/ns/billing/api"},
+			}},
+			staticEntries: []spiffev1alpha1.ClusterStaticEntry{{
+				ObjectMeta: metav1.ObjectMeta{Name: "payments-db", Labels: map[string]string{"team": "payments"}},
+				Spec:       spiffev1alpha1.ClusterStaticEntrySpec{SPIFFEID: "spiffe://example.org/db"},
+			}},
+			expectStatus:     metav1.ConditionFalse,
+			expectViolations: []string{"ClusterSPIFFEID/payments-api", "ClusterStaticEntry/payments-db"},
+			expectEvent:      true,
+		},
+		{
+			name: "ongoing violations",
+			staticEntries: []spiffev1alpha1.ClusterStaticEntry{{
+				ObjectMeta: metav1.ObjectMeta{Name: "payments-db", Labels: map[string]string{"team": "payments"}},
+				Spec:       spiffev1alpha1.ClusterStaticEntrySpec{SPIFFEID: "spiffe://example.org/db"},
+			}},
+			existingStatus:   metav1.ConditionFalse,
+			expectStatus:     metav1.ConditionFalse,
+			expectViolations: []string{"ClusterStaticEntry/payments-db"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := newTestPolicy()
+			if tt.existingStatus != "" {
+				policy.Status.Conditions = []metav1.Condition{{Type: Compliant, Status: tt.existingStatus, Reason: CompliantReasonViolations}}
+			}
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.GetStub = func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				policy.DeepCopyInto(obj.(*v1alpha2.SPIFFEIDPolicy))
+				return nil
+			}
+			fakeClient.ListUncachedStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				switch l := list.(type) {
+				case *spiffev1alpha1.ClusterSPIFFEIDList:
+					l.Items = tt.clusterSPIFFEIDs
+				case *spiffev1alpha1.ClusterStaticEntryList:
+					l.Items = tt.staticEntries
+				}
+				return nil
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := &SPIFFEIDPolicyReconciler{ctrlClient: fakeClient, eventRecorder: recorder, log: logr.Discard()}
+
+			result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(policy)})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result.RequeueAfter != auditInterval {
+				t.Errorf("Expected the audit requeued after %s, got %s", auditInterval, result.RequeueAfter)
+			}
+			if fakeClient.StatusUpdateWithRetryCallCount() != 1 {
+				t.Fatalf("Expected the status updated once, got %d", fakeClient.StatusUpdateWithRetryCallCount())
+			}
+			_, obj, _ := fakeClient.StatusUpdateWithRetryArgsForCall(0)
+			updated := obj.(*v1alpha2.SPIFFEIDPolicy)
+
+			var violations []string
+			for _, violation := range updated.Status.Violations {
+				violations = append(violations, violation.Kind+"/"+violation.Name)
+			}
+			if strings.Join(violations, ",") != strings.Join(tt.expectViolations, ",") || int(updated.Status.ViolationCount) != len(tt.expectViolations) {
+				t.Errorf("Expected violations %v, got %v (%d)", tt.expectViolations, violations, updated.Status.ViolationCount)
+			}
+			for _, cond := range updated.Status.Conditions {
+				if cond.Type == Compliant && cond.Status != tt.expectStatus {
+					t.Errorf("Expected %s %s, got %v", Compliant, tt.expectStatus, cond)
+				}
+			}
+			if events := len(recorder.Events); (events == 1) != tt.expectEvent {
+				t.Errorf("Expected event %v, got %d events", tt.expectEvent, events)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package spiffe_id_policy
+
+import (
+	"fmt"
+	"strings"
+
+	spiffev1alpha1 "github.com/spiffe/spire-controller-manager/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// Kinds of the entries checked against the policies
+const (
+	KindClusterSPIFFEID    = "ClusterSPIFFEID"
+	KindClusterStaticEntry = "ClusterStaticEntry"
+)
+
+// Entry is a ClusterSPIFFEID or ClusterStaticEntry checked against the policies
+type Entry struct {
+	Kind   string
+	Name   string
+	Labels map[string]string
+	// SPIFFEID is the SPIFFE ID of a ClusterStaticEntry, or the SPIFFE ID template of a
+	// ClusterSPIFFEID
+	SPIFFEID string
+}
+
+// EntryFromClusterSPIFFEID returns the entry of a ClusterSPIFFEID
+func EntryFromClusterSPIFFEID(clusterSPIFFEID *spiffev1alpha1.ClusterSPIFFEID) Entry {
+	return Entry{
+		Kind:     KindClusterSPIFFEID,
+		Name:     clusterSPIFFEID.Name,
+		Labels:   clusterSPIFFEID.Labels,
+		SPIFFEID: clusterSPIFFEID.Spec.SPIFFEIDTemplate,
+	}
+}
+
+// EntryFromClusterStaticEntry returns the entry of a ClusterStaticEntry
+func EntryFromClusterStaticEntry(entry *spiffev1alpha1.ClusterStaticEntry) Entry {
+	return Entry{
+		Kind:     KindClusterStaticEntry,
+		Name:     entry.Name,
+		Labels:   entry.Labels,
+		SPIFFEID: entry.Spec.SPIFFEID,
+	}
+}
+
+// Check returns the names of the rules of the policy selecting the entry when none of them allows
+// its SPIFFE ID path, nil when the entry complies with the policy. The entries managed by the
+// operator are never selected.
+func Check(policy *v1alpha2.SPIFFEIDPolicy, entry Entry) ([]string, error) {
+	if entry.Labels[utils.AppManagedByLabelKey] == utils.AppManagedByLabelValue {
+		return nil, nil
+	}
+	path := spiffeIDPath(entry.SPIFFEID)
+	var selecting []string
+	for _, rule := range policy.Spec.Rules {
+		selector, err := metav1.LabelSelectorAsSelector(&rule.EntrySelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid entrySelector of rule %s: %w", rule.Name, err)
+		}
+		if !selector.Matches(labels.Set(entry.Labels)) {
+			continue
+		}
+		for _, prefix := range rule.AllowedPathPrefixes {
+			if pathHasPrefix(path, prefix) {
+				return nil, nil
+			}
+		}
+		selecting = append(selecting, rule.Name)
+	}
+	return selecting, nil
+}
+
+// Selects reports whether a rule of the policy selects the entry
+func Selects(policy *v1alpha2.SPIFFEIDPolicy, entry Entry) bool {
+	if entry.Labels[utils.AppManagedByLabelKey] == utils.AppManagedByLabelValue {
+		return false
+	}
+	for _, rule := range policy.Spec.Rules {
+		selector, err := metav1.LabelSelectorAsSelector(&rule.EntrySelector)
+		if err == nil && selector.Matches(labels.Set(entry.Labels)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Message describes the violation of the policy by the entry
+func Message(policy *v1alpha2.SPIFFEIDPolicy, entry Entry, rules []string) string {
+	return fmt.Sprintf("%s %s: SPIFFE ID %q is outside the paths allowed by rules %s of SPIFFEIDPolicy %s",
+		entry.Kind, entry.Name, entry.SPIFFEID, strings.Join(rules, ", "), policy.Name)
+}
+
+// spiffeIDPath returns the path of a SPIFFE ID or SPIFFE ID template, empty when it has none
+func spiffeIDPath(id string) string {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(id), "spiffe://")
+	if !ok {
+		return ""
+	}
+	if _, path, found := strings.Cut(rest, "/"); found {
+		return "/" + path
+	}
+	return ""
+}
+
+// pathHasPrefix reports whether the path is under the prefix, matching whole path segments
+func pathHasPrefix(path, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if path == "" {
+		return false
+	}
+	return prefix == "" || path == prefix || strings.HasPrefix(path, prefix+"/")
+}
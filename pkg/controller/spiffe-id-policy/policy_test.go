@@ -0,0 +1,116 @@
+package spiffe_id_policy
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func newTestPolicy() *v1alpha2.SPIFFEIDPolicy {
+	return &v1alpha2.SPIFFEIDPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-paths"},
+		Spec: v1alpha2.SPIFFEIDPolicySpec{
+			Rules: []v1alpha2.SPIFFEIDPolicyRule{
+				{
+					Name:                "payments",
+					EntrySelector:       metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+					AllowedPathPrefixes: []string{"/ns/payments", "/ns/{{ .PodMeta.Namespace }}/payments/"},
+				},
+				{
+					Name:                "shared",
+					EntrySelector:       metav1.LabelSelector{MatchLabels: map[string]string{"shared": "true"}},
+					AllowedPathPrefixes: []string{"/shared"},
+				},
+			},
+		},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		labels      map[string]string
+		spiffeID    string
+		expectRules []string
+	}{
+		{
+			name:     "allowed prefix",
+			labels:   map[string]string{"team": "payments"},
+			spiffeID: "spiffe://example.org/ns/payments/sa/api",
+		},
+		{
+			name:     "prefix itself",
+			labels:   map[string]string{"team": "payments"},
+			spiffeID: "spiffe://example.org/ns/payments",
+		},
+		{
+			name:     "template matched as written",
+			labels:   map[string]string{"team": "payments"},
+			spiffeID: "spiffe://{{ .TrustDomain }}/ns/{{ .PodMeta.Namespace }}/payments/{{ .PodSpec.ServiceAccountName }}",
+		},
+		{
+			name:        "partial segment",
+			labels:      map[string]string{"team": "payments"},
+			spiffeID:    "spiffe://example.org/ns/payments-v2/sa/api",
+			expectRules: []string{"payments"},
+		},
+		{
+			name:        "template expanding outside the prefix",
+			labels:      map[string]string{"team": "payments"},
+			spiffeID:    "spiffe://example.org/ns/{{ .PodMeta.Namespace }}/sa/api",
+			expectRules: []string{"payments"},
+		},
+		{
+			name:     "allowed by one of the selecting rules",
+			labels:   map[string]string{"team": "payments", "shared": "true"},
+			spiffeID: "spiffe://example.org/shared/cache",
+		},
+		{
+			name:        "allowed by no selecting rule",
+			labels:      map[string]string{"team": "payments", "shared": "true"},
+			spiffeID:    "spiffe://example.org/ns/billing",
+			expectRules: []string{"payments", "shared"},
+		},
+		{
+			name:     "not selected",
+			labels:   map[string]string{"team": "billing"},
+			spiffeID: "spiffe://example.org/ns/payments",
+		},
+		{
+			name:     "managed by the operator",
+			labels:   map[string]string{"team": "payments", utils.AppManagedByLabelKey: utils.AppManagedByLabelValue},
+			spiffeID: "spiffe://example.org/spire/oidc",
+		},
+		{
+			name:        "no path",
+			labels:      map[string]string{"team": "payments"},
+			spiffeID:    "spiffe://example.org",
+			expectRules: []string{"payments"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := Entry{Kind: KindClusterStaticEntry, Name: "entry", Labels: tt.labels, SPIFFEID: tt.spiffeID}
+			rules, err := Check(newTestPolicy(), entry)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(rules, tt.expectRules) {
+				t.Errorf("Expected violated rules %v, got %v", tt.expectRules, rules)
+			}
+		})
+	}
+}
+
+func TestCheckInvalidSelector(t *testing.T) {
+	policy := newTestPolicy()
+	policy.Spec.Rules[0].EntrySelector = metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Unknown"}}}
+	if _, err := Check(policy, Entry{Labels: map[string]string{"team": "payments"}}); err == nil {
+		t.Error("Expected an error for an invalid entrySelector")
+	}
+}
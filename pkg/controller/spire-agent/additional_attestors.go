@@ -0,0 +1,117 @@
+package spire_agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+const (
+	// spireAgentConfigMountPath is where the spire-agent ConfigMap is mounted
+	spireAgentConfigMountPath = "/opt/spire/conf/agent"
+
+	// systemdAttestorName is the workload attestor reading the unit of processes over D-Bus
+	systemdAttestorName = "systemd"
+	// dbusSystemBusSocket is the host D-Bus socket the systemd attestor connects to
+	dbusSystemBusSocket = "/run/dbus/system_bus_socket"
+)
+
+// bannedAttestorKeys may not be set in the configuration of an additional attestor: they
+// configure the plugin itself or the agent, which the operator manages
+var bannedAttestorKeys = []string{
+	"plugin_cmd", "plugin_checksum", "plugin_data", "plugin_data_file", "enabled",
+	"agent", "plugins", "telemetry", "health_checks",
+}
+
+// validateAdditionalAttestors checks the configuration of the additional workload attestors is
+// valid HCL without banned keys
+func validateAdditionalAttestors(workloadAttestors *v1alpha2.WorkloadAttestors) error {
+	if workloadAttestors == nil {
+		return nil
+	}
+	for _, attestor := range workloadAttestors.AdditionalAttestors {
+		file, err := hcl.Parse(attestor.PluginData)
+		if err != nil {
+			return fmt.Errorf("invalid pluginData of the %s workload attestor: %w", attestor.Name, err)
+		}
+		list, ok := file.Node.(*ast.ObjectList)
+		if !ok {
+			continue
+		}
+		for _, item := range list.Items {
+			for _, key := range item.Keys {
+				if name, _ := key.Token.Value().(string); slices.Contains(bannedAttestorKeys, name) {
+					return fmt.Errorf("pluginData of the %s workload attestor may not set %s", attestor.Name, name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// attestorConfigKey is the key of the attestor configuration in the spire-agent ConfigMap
+func attestorConfigKey(attestor v1alpha2.AdditionalWorkloadAttestor) string {
+	return "workloadattestor-" + attestor.Name + ".conf"
+}
+
+// addAdditionalAttestorsToConfig adds the additional workload attestors to the plugins section of
+// agent.conf. Their configuration is HCL, so it is read from a file rather than rendered into the
+// JSON config.
+func addAdditionalAttestorsToConfig(plugins map[string]interface{}, workloadAttestors *v1alpha2.WorkloadAttestors) {
+	if workloadAttestors == nil {
+		return
+	}
+	for _, attestor := range workloadAttestors.AdditionalAttestors {
+		pluginConfig := map[string]interface{}{}
+		if attestor.PluginData != "" {
+			pluginConfig["plugin_data_file"] = path.Join(spireAgentConfigMountPath, attestorConfigKey(attestor))
+		} else {
+			pluginConfig["plugin_data"] = map[string]interface{}{}
+		}
+		existing, _ := plugins["WorkloadAttestor"].([]map[string]interface{})
+		plugins["WorkloadAttestor"] = append(existing, map[string]interface{}{attestor.Name: pluginConfig})
+	}
+}
+
+// additionalAttestorConfigFiles returns the configuration files of the additional attestors, by ConfigMap key
+func additionalAttestorConfigFiles(workloadAttestors *v1alpha2.WorkloadAttestors) map[string]string {
+	files := map[string]string{}
+	if workloadAttestors == nil {
+		return files
+	}
+	for _, attestor := range workloadAttestors.AdditionalAttestors {
+		if attestor.PluginData != "" {
+			files[attestorConfigKey(attestor)] = attestor.PluginData
+		}
+	}
+	return files
+}
+
+// additionalAttestorConfigHashInput returns the attestor configuration files to include in the
+// config hash, so the agents are rolled when they change. It is empty without attestor
+// configuration, which keeps the hash of existing agents.
+func additionalAttestorConfigHashInput(workloadAttestors *v1alpha2.WorkloadAttestors) []byte {
+	files := additionalAttestorConfigFiles(workloadAttestors)
+	if len(files) == 0 {
+		return nil
+	}
+	// Map keys are marshalled sorted, so the input is stable
+	data, _ := json.Marshal(files)
+	return data
+}
+
+// hasAdditionalAttestor reports whether the named additional attestor is enabled
+func hasAdditionalAttestor(workloadAttestors *v1alpha2.WorkloadAttestors, name string) bool {
+	if workloadAttestors == nil {
+		return false
+	}
+	return slices.ContainsFunc(workloadAttestors.AdditionalAttestors, func(attestor v1alpha2.AdditionalWorkloadAttestor) bool {
+		return attestor.Name == name
+	})
+}
@@ -0,0 +1,86 @@
+package spire_agent
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+func TestValidateAdditionalAttestors(t *testing.T) {
+	tests := []struct {
+		name       string
+		pluginData string
+		expectErr  string
+	}{
+		{name: "no configuration"},
+		{name: "valid configuration", pluginData: "discover_workload_path = true\nworkload_size_limit = -1"},
+		{name: "invalid syntax", pluginData: `discover_workload_path = "true`, expectErr: "invalid pluginData of the unix workload attestor"},
+		{name: "plugin key", pluginData: `plugin_cmd = "/tmp/attestor"`, expectErr: "may not set plugin_cmd"},
+		{name: "agent block", pluginData: "agent {\n  trust_domain = \"evil.org\"\n}", expectErr: "may not set agent"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAdditionalAttestors(&v1alpha2.WorkloadAttestors{
+				AdditionalAttestors: []v1alpha2.AdditionalWorkloadAttestor{{Name: "unix", PluginData: tt.pluginData}},
+			})
+			if tt.expectErr == "" && err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if tt.expectErr != "" && (err == nil || !strings.Contains(err.Error(), tt.expectErr)) {
+				t.Fatalf("Expected error containing %q, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestAdditionalAttestorsConfig(t *testing.T) {
+	agent := &v1alpha2.SpireAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Spec: v1alpha2.SpireAgentSpec{
+			WorkloadAttestors: &v1alpha2.WorkloadAttestors{
+				K8sEnabled: ptr.To(true),
+				AdditionalAttestors: []v1alpha2.AdditionalWorkloadAttestor{
+					{Name: "unix", PluginData: "discover_workload_path = true"},
+					{Name: "systemd"},
+				},
+			},
+		},
+	}
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.org", ClusterName: "test", BundleConfigMap: "spire-bundle"},
+	}
+
+	cm, hash, err := generateSpireAgentConfigMap(agent, ztwim)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cm.Data["workloadattestor-unix.conf"] != "discover_workload_path = true" {
+		t.Errorf("Expected the unix attestor configuration file in the ConfigMap, got keys %v", cm.Data)
+	}
+
+	attestors := generateAgentConfig(agent, ztwim)["plugins"].(map[string]interface{})["WorkloadAttestor"].([]map[string]interface{})
+	if len(attestors) != 3 {
+		t.Fatalf("Expected the k8s, unix and systemd attestors, got %v", attestors)
+	}
+	if unix := attestors[1]["unix"].(map[string]interface{}); unix["plugin_data_file"] != "/opt/spire/conf/agent/workloadattestor-unix.conf" {
+		t.Errorf("Expected the unix attestor to read its configuration file, got %v", unix)
+	}
+
+	agent.Spec.WorkloadAttestors.AdditionalAttestors[0].PluginData = "discover_workload_path = false"
+	if _, changed, _ := generateSpireAgentConfigMap(agent, ztwim); changed == hash {
+		t.Error("Expected the config hash to change with the attestor configuration, so agents are rolled")
+	}
+
+	ds := generateSpireAgentDaemonSet(agent.Spec, ztwim, hash)
+	mounted := false
+	for _, mount := range ds.Spec.Template.Spec.Containers[0].VolumeMounts {
+		mounted = mounted || mount.MountPath == dbusSystemBusSocket
+	}
+	if !mounted {
+		t.Error("Expected the D-Bus socket to be mounted for the systemd attestor")
+	}
+}
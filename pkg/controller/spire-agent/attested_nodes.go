@@ -0,0 +1,147 @@
+package spire_agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// Reasons of the ReattestationPending condition
+const (
+	ReattestationPendingReasonPending     = "AgentsPendingReattestation"
+	ReattestationPendingReasonNone        = "AgentsAttested"
+	ReattestationPendingReasonUnavailable = "AttestedNodesUnavailable"
+)
+
+const (
+	// maxPendingAgents caps the agents recorded in status.attestedNodes.pendingAgents
+	maxPendingAgents = 50
+
+	// psatNodeNameSelector is the selector of the node name of the agents attested with k8s_psat
+	psatNodeNameSelector = "k8s_psat:agent_node_name:"
+)
+
+// agentListCommand lists the attested agents, with the same fields as the ListAgents API
+var agentListCommand = []string{"spire-server", "agent", "list", "-output", "json"}
+
+// listedAgents is the output of agentListCommand
+type listedAgents struct {
+	Agents []struct {
+		ID struct {
+			TrustDomain string `json:"trust_domain"`
+			Path        string `json:"path"`
+		} `json:"id"`
+		AttestationType string `json:"attestation_type"`
+		// int64 fields are quoted in the JSON encoding of protobuf
+		X509SVIDExpiresAt int64 `json:"x509svid_expires_at,string"`
+		Selectors         []struct {
+			Type  string `json:"type"`
+			Value string `json:"value"`
+		} `json:"selectors"`
+		Banned bool `json:"banned"`
+	} `json:"agents"`
+}
+
+// summarizeAttestedNodes summarizes the agents listed by the SPIRE server at the given time
+func summarizeAttestedNodes(output []byte, now time.Time) (*v1alpha2.AttestedNodesSummary, error) {
+	var listed listedAgents
+	if err := json.Unmarshal(output, &listed); err != nil {
+		return nil, fmt.Errorf("unexpected output of the agent list command: %w", err)
+	}
+
+	summary := &v1alpha2.AttestedNodesSummary{Count: int32(len(listed.Agents))}
+	var pending []v1alpha2.AttestedAgent
+	for _, listedAgent := range listed.Agents {
+		if listedAgent.Banned {
+			summary.Banned++
+			continue
+		}
+		expiresAt := metav1.NewTime(time.Unix(listedAgent.X509SVIDExpiresAt, 0))
+		if summary.OldestSVIDExpiry == nil || expiresAt.Before(summary.OldestSVIDExpiry) {
+			summary.OldestSVIDExpiry = &expiresAt
+		}
+		if expiresAt.After(now) {
+			continue
+		}
+		summary.PendingReattestation++
+		agent := v1alpha2.AttestedAgent{
+			SPIFFEID:        fmt.Sprintf("spiffe://%s%s", listedAgent.ID.TrustDomain, listedAgent.ID.Path),
+			AttestationType: listedAgent.AttestationType,
+			SVIDExpiresAt:   &expiresAt,
+		}
+		for _, selector := range listedAgent.Selectors {
+			typed := selector.Type + ":" + selector.Value
+			if nodeName, ok := strings.CutPrefix(typed, psatNodeNameSelector); ok {
+				agent.NodeName = nodeName
+			}
+			agent.Selectors = append(agent.Selectors, typed)
+		}
+		sort.Strings(agent.Selectors)
+		pending = append(pending, agent)
+	}
+
+	sort.Slice(pending, func(i, j int) bool { return pending[i].SPIFFEID < pending[j].SPIFFEID })
+	if len(pending) > maxPendingAgents {
+		pending = pending[:maxPendingAgents]
+	}
+	summary.PendingAgents = pending
+	return summary, nil
+}
+
+// reconcileAttestedNodes lists the agents attested to the SPIRE server, records their summary in
+// status and sets the ReattestationPending condition. It gives visibility on the fleet of agents
+// without access to the spire-server CLI. The listing is best effort: the summary is left as is
+// when the server cannot be reached.
+func (r *SpireAgentReconciler) reconcileAttestedNodes(ctx context.Context, agent *v1alpha2.SpireAgent, statusMgr *status.Manager) {
+	if r.cli == nil {
+		return
+	}
+	output, err := r.cli.Run(ctx, utils.GetOperatorNamespace(), agentListCommand)
+	var summary *v1alpha2.AttestedNodesSummary
+	if err == nil {
+		summary, err = summarizeAttestedNodes(output, time.Now())
+	}
+	if err != nil {
+		r.log.V(1).Info("attested agents not available", "reason", err.Error())
+		statusMgr.AddCondition(utils.ReattestationPendingStatusType, ReattestationPendingReasonUnavailable,
+			fmt.Sprintf("Failed to list the agents attested to the SPIRE server: %v", err),
+			metav1.ConditionUnknown)
+		return
+	}
+	agent.Status.AttestedNodes = summary
+
+	if summary.PendingReattestation == 0 {
+		statusMgr.AddCondition(utils.ReattestationPendingStatusType, ReattestationPendingReasonNone,
+			fmt.Sprintf("%d agent(s) attested, %d banned, none pending re-attestation", summary.Count, summary.Banned),
+			metav1.ConditionFalse)
+		return
+	}
+
+	names := make([]string, 0, maxReportedFailingNodes)
+	for _, pending := range summary.PendingAgents {
+		if len(names) == maxReportedFailingNodes {
+			break
+		}
+		name := pending.NodeName
+		if name == "" {
+			name = pending.SPIFFEID
+		}
+		names = append(names, name)
+	}
+	if int(summary.PendingReattestation) > len(names) {
+		names = append(names, fmt.Sprintf("and %d more", int(summary.PendingReattestation)-len(names)))
+	}
+	statusMgr.AddCondition(utils.ReattestationPendingStatusType, ReattestationPendingReasonPending,
+		fmt.Sprintf("%d of %d agent(s) attested hold an expired SVID and must attest again: %s",
+			summary.PendingReattestation, summary.Count, strings.Join(names, ", ")),
+		metav1.ConditionTrue)
+}
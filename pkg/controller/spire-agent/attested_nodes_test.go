@@ -0,0 +1,116 @@
+package spire_agent
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// fakeCLI returns a fixed output, or error
+type fakeCLI struct {
+	output string
+	err    error
+}
+
+func (f *fakeCLI) Run(_ context.Context, _ string, _ []string) ([]byte, error) {
+	return []byte(f.output), f.err
+}
+
+const agentListOutput = `{"agents":[
+	{"id":{"trust_domain":"example.org","path":"/spire/agent/k8s_psat/cluster/node-a"},"attestation_type":"k8s_psat",
+	 "x509svid_expires_at":"1700003600","selectors":[{"type":"k8s_psat","value":"cluster:cluster"},{"type":"k8s_psat","value":"agent_node_name:node-a"}],"banned":false,"can_reattest":true},
+	{"id":{"trust_domain":"example.org","path":"/spire/agent/k8s_psat/cluster/node-b"},"attestation_type":"k8s_psat",
+	 "x509svid_expires_at":"1700000600","selectors":[{"type":"k8s_psat","value":"agent_node_name:node-b"}],"banned":false,"can_reattest":true},
+	{"id":{"trust_domain":"example.org","path":"/spire/agent/join_token/abc"},"attestation_type":"join_token",
+	 "x509svid_expires_at":"1700000000","selectors":[],"banned":true,"can_reattest":false}
+],"next_page_token":""}`
+
+func TestSummarizeAttestedNodes(t *testing.T) {
+	summary, err := summarizeAttestedNodes([]byte(agentListOutput), time.Unix(1700001800, 0))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if summary.Count != 3 || summary.Banned != 1 || summary.PendingReattestation != 1 {
+		t.Errorf("Expected 3 agents, 1 banned and 1 pending, got %+v", summary)
+	}
+	if summary.OldestSVIDExpiry == nil || summary.OldestSVIDExpiry.Unix() != 1700000600 {
+		t.Errorf("Expected the oldest SVID of the non banned agents, got %v", summary.OldestSVIDExpiry)
+	}
+	if len(summary.PendingAgents) != 1 {
+		t.Fatalf("Expected 1 pending agent, got %v", summary.PendingAgents)
+	}
+	pending := summary.PendingAgents[0]
+	if pending.SPIFFEID != "spiffe://example.org/spire/agent/k8s_psat/cluster/node-b" || pending.NodeName != "node-b" ||
+		pending.AttestationType != "k8s_psat" || !slices.Equal(pending.Selectors, []string{"k8s_psat:agent_node_name:node-b"}) {
+		t.Errorf("Unexpected pending agent %+v", pending)
+	}
+
+	if _, err := summarizeAttestedNodes([]byte("Error: connection refused"), time.Now()); err == nil {
+		t.Error("Expected an error for an output which is not JSON")
+	}
+}
+
+func TestReconcileAttestedNodes(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+	tests := []struct {
+		name          string
+		cli           *fakeCLI
+		expectReason  string
+		expectStatus  metav1.ConditionStatus
+		expectSummary bool
+	}{
+		{
+			name:         "server not reachable",
+			cli:          &fakeCLI{err: errors.New("pod spire-server-0 not found")},
+			expectReason: ReattestationPendingReasonUnavailable,
+			expectStatus: metav1.ConditionUnknown,
+		},
+		{
+			name:          "agents pending re-attestation",
+			cli:           &fakeCLI{output: agentListOutput},
+			expectReason:  ReattestationPendingReasonPending,
+			expectStatus:  metav1.ConditionTrue,
+			expectSummary: true,
+		},
+		{
+			name:          "no agent attested",
+			cli:           &fakeCLI{output: `{"agents":[]}`},
+			expectReason:  ReattestationPendingReasonNone,
+			expectStatus:  metav1.ConditionFalse,
+			expectSummary: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			r := newTestReconciler(fakeClient)
+			r.cli = tt.cli
+			statusMgr := status.NewManager(fakeClient)
+			agent := &v1alpha2.SpireAgent{}
+
+			r.reconcileAttestedNodes(context.Background(), agent, statusMgr)
+
+			cond, ok := statusMgr.GetCondition(utils.ReattestationPendingStatusType)
+			if !ok || cond.Reason != tt.expectReason || cond.Status != tt.expectStatus {
+				t.Errorf("Expected %s/%s, got %v", tt.expectStatus, tt.expectReason, cond)
+			}
+			if (agent.Status.AttestedNodes != nil) != tt.expectSummary {
+				t.Errorf("Expected summary %v, got %v", tt.expectSummary, agent.Status.AttestedNodes)
+			}
+			if tt.expectReason == ReattestationPendingReasonPending && !strings.Contains(cond.Message, "node-b") {
+				t.Errorf("Expected the pending node in the message, got %q", cond.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package spire_agent
+
+import (
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+func benchmarkAgent() (*v1alpha2.SpireAgent, *v1alpha2.ZeroTrustWorkloadIdentityManager) {
+	agent := &v1alpha2.SpireAgent{Spec: v1alpha2.SpireAgentSpec{
+		LogLevel:          "info",
+		LogFormat:         "text",
+		NodeAttestor:      &v1alpha2.NodeAttestor{K8sPSATEnabled: ptr.To(true)},
+		WorkloadAttestors: &v1alpha2.WorkloadAttestors{K8sEnabled: ptr.To(true)},
+	}}
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.org", ClusterName: "cluster", BundleConfigMap: "spire-bundle"},
+	}
+	return agent, ztwim
+}
+
+func BenchmarkGenerateSpireAgentConfigMap(b *testing.B) {
+	agent, ztwim := benchmarkAgent()
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, _, err := generateSpireAgentConfigMap(agent, ztwim); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGenerateSpireAgentDaemonSet(b *testing.B) {
+	agent, ztwim := benchmarkAgent()
+	b.ReportAllocs()
+	for b.Loop() {
+		generateSpireAgentDaemonSet(agent.Spec, ztwim, "agent-config-hash")
+	}
+}
+
+// BenchmarkSpireAgentStaticResources renders the resources built from the static assets at
+// each reconcile
+func BenchmarkSpireAgentStaticResources(b *testing.B) {
+	labels := map[string]string{"team": "platform"}
+	b.ReportAllocs()
+	for b.Loop() {
+		getSpireAgentClusterRole(labels)
+		getSpireAgentClusterRoleBinding(labels)
+		getSpireAgentService(labels)
+		getSpireAgentServiceAccount(labels)
+	}
+}
@@ -0,0 +1,182 @@
+package spire_agent
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// Reasons of the ClockSkew condition
+const (
+	ClockSkewReasonDetected    = "ClockSkewDetected"
+	ClockSkewReasonInSync      = "ClocksInSync"
+	ClockSkewReasonUnavailable = "ClockSkewUnavailable"
+)
+
+const (
+	// clockSkewTolerance is the skew beyond which a node is reported. The server backdates the
+	// SVIDs it signs by 10s, so a node behind by more rejects freshly issued SVIDs as not yet valid.
+	clockSkewTolerance = 5 * time.Second
+
+	// clockSkewCheckInterval is how often the clocks are compared, as the operator is not
+	// notified of clock changes
+	clockSkewCheckInterval = 5 * time.Minute
+
+	// clockReadTimeout bounds the read of the clock of a single pod
+	clockReadTimeout = 5 * time.Second
+
+	// maxClockSkewedNodes caps the nodes recorded in status.clockSkewedNodes
+	maxClockSkewedNodes = 50
+
+	// The health listeners of agent.conf and server.conf, whose responses carry the clock of the pod
+	agentHealthPort     = "9982"
+	serverHealthPort    = "8080"
+	healthLivePath      = "/live"
+	spireServerPodName  = "spire-server-0"
+	spireAgentNameLabel = "spire-agent"
+)
+
+// clockReader reads the clock of a pod
+type clockReader interface {
+	// ClockOffset returns how far the clock of the pod is ahead of the local clock
+	ClockOffset(ctx context.Context, namespace, podName, port, path string) (time.Duration, error)
+}
+
+// podProxyClockReader reads the Date header of an HTTP endpoint of the pod through the pods/proxy
+// subresource, which passes the header of the pod through
+type podProxyClockReader struct {
+	restClient rest.Interface
+	httpClient *http.Client
+}
+
+func (p *podProxyClockReader) ClockOffset(ctx context.Context, namespace, podName, port, path string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, clockReadTimeout)
+	defer cancel()
+	url := p.restClient.Get().Namespace(namespace).Resource("pods").Name(podName + ":" + port).
+		SubResource("proxy").Suffix(path).URL()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	sent := time.Now()
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	received := time.Now()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return 0, fmt.Errorf("pod %s returned no valid Date header: %w", podName, err)
+	}
+	// The Date header is truncated to the second and was set while the request was in flight
+	midpoint := sent.Add(received.Sub(sent) / 2)
+	return date.Add(500 * time.Millisecond).Sub(midpoint), nil
+}
+
+// reconcileClockSkew compares the clock of the node of every running agent with the clock of the
+// SPIRE server, records the nodes beyond the tolerated skew in status and sets the ClockSkew
+// condition, emitting a warning Event for every newly skewed node. Skewed clocks surface as
+// x509 validation failures of the SVIDs, which are hard to trace back to the node. The check is
+// best effort: the clocks which cannot be read are skipped.
+func (r *SpireAgentReconciler) reconcileClockSkew(ctx context.Context, agent *v1alpha2.SpireAgent, statusMgr *status.Manager) {
+	if r.clocks == nil {
+		return
+	}
+	namespace := utils.GetOperatorNamespace()
+
+	serverOffset, err := r.clocks.ClockOffset(ctx, namespace, spireServerPodName, serverHealthPort, healthLivePath)
+	if err != nil {
+		r.log.V(1).Info("clock of the SPIRE server not available", "reason", err.Error())
+		statusMgr.AddCondition(utils.ClockSkewStatusType, ClockSkewReasonUnavailable,
+			fmt.Sprintf("Failed to read the clock of the SPIRE server: %v", err),
+			metav1.ConditionUnknown)
+		return
+	}
+
+	// Pods are not labelled as managed by the operator, so they are listed from the API server
+	var pods corev1.PodList
+	if err := r.ctrlClient.ListUncached(ctx, &pods, client.InNamespace(namespace),
+		client.MatchingLabels{"app.kubernetes.io/name": spireAgentNameLabel}); err != nil {
+		statusMgr.AddCondition(utils.ClockSkewStatusType, ClockSkewReasonUnavailable,
+			fmt.Sprintf("Failed to list the agent pods: %v", err),
+			metav1.ConditionUnknown)
+		return
+	}
+
+	checked := 0
+	var skewed []v1alpha2.NodeClockSkew
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName == "" || pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		offset, err := r.clocks.ClockOffset(ctx, namespace, pod.Name, agentHealthPort, healthLivePath)
+		if err != nil {
+			r.log.V(1).Info("clock of the agent not available", "pod", pod.Name, "reason", err.Error())
+			continue
+		}
+		checked++
+		if skew := (offset - serverOffset).Round(time.Second); skew > clockSkewTolerance || skew < -clockSkewTolerance {
+			skewed = append(skewed, v1alpha2.NodeClockSkew{NodeName: pod.Spec.NodeName, Skew: metav1.Duration{Duration: skew}})
+		}
+	}
+	if checked == 0 {
+		statusMgr.AddCondition(utils.ClockSkewStatusType, ClockSkewReasonUnavailable,
+			"The clock of no running agent could be read",
+			metav1.ConditionUnknown)
+		return
+	}
+
+	sort.Slice(skewed, func(i, j int) bool { return skewed[i].NodeName < skewed[j].NodeName })
+	previous := make(map[string]bool, len(agent.Status.ClockSkewedNodes))
+	for _, node := range agent.Status.ClockSkewedNodes {
+		previous[node.NodeName] = true
+	}
+	if len(skewed) > maxClockSkewedNodes {
+		agent.Status.ClockSkewedNodes = skewed[:maxClockSkewedNodes]
+	} else {
+		agent.Status.ClockSkewedNodes = skewed
+	}
+
+	if len(skewed) == 0 {
+		statusMgr.AddCondition(utils.ClockSkewStatusType, ClockSkewReasonInSync,
+			fmt.Sprintf("The clocks of the %d node(s) checked are within %s of the SPIRE server", checked, clockSkewTolerance),
+			metav1.ConditionFalse)
+		return
+	}
+
+	names := make([]string, 0, maxReportedFailingNodes)
+	for _, node := range skewed {
+		if len(names) < maxReportedFailingNodes {
+			names = append(names, fmt.Sprintf("%s (%s)", node.NodeName, node.Skew.Duration))
+		}
+		// Warn once per node when it drifts, not on every check
+		if !previous[node.NodeName] {
+			message := fmt.Sprintf("The clock of node %s is %s off the clock of the SPIRE server, beyond the tolerated %s: SVIDs may be rejected as not yet valid or expired on it. Check the time synchronization (chrony) of the node",
+				node.NodeName, node.Skew.Duration, clockSkewTolerance)
+			r.eventRecorder.Event(agent, corev1.EventTypeWarning, ClockSkewReasonDetected, utils.WithRunbook(ClockSkewReasonDetected, message))
+		}
+	}
+	if len(skewed) > len(names) {
+		names = append(names, fmt.Sprintf("and %d more", len(skewed)-len(names)))
+	}
+	statusMgr.AddCondition(utils.ClockSkewStatusType, ClockSkewReasonDetected,
+		fmt.Sprintf("%d of %d node(s) checked have a clock skewed beyond %s from the SPIRE server: %s",
+			len(skewed), checked, clockSkewTolerance, strings.Join(names, ", ")),
+		metav1.ConditionTrue)
+}
@@ -0,0 +1,127 @@
+package spire_agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// fakeClocks returns the clock offset of every pod, and an error for the pods it does not know
+type fakeClocks map[string]time.Duration
+
+func (f fakeClocks) ClockOffset(_ context.Context, _, podName, _, _ string) (time.Duration, error) {
+	offset, ok := f[podName]
+	if !ok {
+		return 0, errors.New("connection refused")
+	}
+	return offset, nil
+}
+
+func agentPod(name, node string, phase corev1.PodPhase) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       corev1.PodSpec{NodeName: node},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestReconcileClockSkew(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+	pods := []corev1.Pod{
+		agentPod("spire-agent-a", "node-a", corev1.PodRunning),
+		agentPod("spire-agent-b", "node-b", corev1.PodRunning),
+		agentPod("spire-agent-c", "node-c", corev1.PodPending),
+	}
+
+	tests := []struct {
+		name           string
+		clocks         fakeClocks
+		previousSkewed []v1alpha2.NodeClockSkew
+		expectReason   string
+		expectStatus   metav1.ConditionStatus
+		expectSkewed   []v1alpha2.NodeClockSkew
+		expectEvents   int
+	}{
+		{
+			name:         "server clock not available",
+			clocks:       fakeClocks{"spire-agent-a": 0},
+			expectReason: ClockSkewReasonUnavailable,
+			expectStatus: metav1.ConditionUnknown,
+		},
+		{
+			name:         "no agent clock available",
+			clocks:       fakeClocks{spireServerPodName: 0},
+			expectReason: ClockSkewReasonUnavailable,
+			expectStatus: metav1.ConditionUnknown,
+		},
+		{
+			name:         "clocks in sync",
+			clocks:       fakeClocks{spireServerPodName: 2 * time.Second, "spire-agent-a": 4 * time.Second, "spire-agent-b": -time.Second},
+			expectReason: ClockSkewReasonInSync,
+			expectStatus: metav1.ConditionFalse,
+		},
+		{
+			name:         "node behind the server",
+			clocks:       fakeClocks{spireServerPodName: time.Second, "spire-agent-a": 0, "spire-agent-b": -29 * time.Second},
+			expectReason: ClockSkewReasonDetected,
+			expectStatus: metav1.ConditionTrue,
+			expectSkewed: []v1alpha2.NodeClockSkew{{NodeName: "node-b", Skew: metav1.Duration{Duration: -30 * time.Second}}},
+			expectEvents: 1,
+		},
+		{
+			name:           "node already reported",
+			clocks:         fakeClocks{spireServerPodName: 0, "spire-agent-a": time.Minute, "spire-agent-b": 0},
+			previousSkewed: []v1alpha2.NodeClockSkew{{NodeName: "node-a", Skew: metav1.Duration{Duration: time.Minute}}},
+			expectReason:   ClockSkewReasonDetected,
+			expectStatus:   metav1.ConditionTrue,
+			expectSkewed:   []v1alpha2.NodeClockSkew{{NodeName: "node-a", Skew: metav1.Duration{Duration: time.Minute}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.ListUncachedStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				list.(*corev1.PodList).Items = pods
+				return nil
+			}
+			recorder := record.NewFakeRecorder(10)
+			reconciler := newTestReconciler(fakeClient)
+			reconciler.eventRecorder = recorder
+			reconciler.clocks = tt.clocks
+			agent := &v1alpha2.SpireAgent{ObjectMeta: metav1.ObjectMeta{Name: utils.DefaultAgentPool}}
+			agent.Status.ClockSkewedNodes = tt.previousSkewed
+			statusMgr := status.NewManager(fakeClient)
+
+			reconciler.reconcileClockSkew(context.Background(), agent, statusMgr)
+			cond, _ := statusMgr.GetCondition(utils.ClockSkewStatusType)
+			if cond.Reason != tt.expectReason || cond.Status != tt.expectStatus {
+				t.Errorf("Expected %s/%s, got %s/%s: %s", tt.expectStatus, tt.expectReason, cond.Status, cond.Reason, cond.Message)
+			}
+			if tt.expectStatus != metav1.ConditionUnknown {
+				if len(agent.Status.ClockSkewedNodes) != len(tt.expectSkewed) {
+					t.Fatalf("Expected skewed nodes %v, got %v", tt.expectSkewed, agent.Status.ClockSkewedNodes)
+				}
+				for i, node := range tt.expectSkewed {
+					if agent.Status.ClockSkewedNodes[i] != node {
+						t.Errorf("Expected skewed node %v, got %v", node, agent.Status.ClockSkewedNodes[i])
+					}
+				}
+			}
+			if len(recorder.Events) != tt.expectEvents {
+				t.Errorf("Expected %d events, got %d", tt.expectEvents, len(recorder.Events))
+			}
+		})
+	}
+}
@@ -5,22 +5,27 @@ import (
 	"encoding/json"
 	"fmt"
 	"path"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/confighistory"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/telemetry"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 )
 
 // reconcileConfigMap reconciles the Spire Agent ConfigMap
-func (r *SpireAgentReconciler) reconcileConfigMap(ctx context.Context, agent *v1alpha1.SpireAgent, statusMgr *status.Manager, ztwim *v1alpha1.ZeroTrustWorkloadIdentityManager, createOnlyMode bool) (string, error) {
+func (r *SpireAgentReconciler) reconcileConfigMap(ctx context.Context, agent *v1alpha2.SpireAgent, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, createOnlyMode bool) (string, error) {
 	spireAgentConfigMap, spireAgentConfigHash, err := generateSpireAgentConfigMap(agent, ztwim)
 	if err != nil {
 		r.log.Error(err, "failed to generate spire-agent config map")
@@ -29,6 +34,12 @@ func (r *SpireAgentReconciler) reconcileConfigMap(ctx context.Context, agent *v1
 			metav1.ConditionFalse)
 		return "", err
 	}
+	applyAgentPoolToConfigMap(spireAgentConfigMap, agent.Name)
+
+	rollbackHash, rolledBack := confighistory.ApplyRollback(ctx, r.ctrlClient, agent, agent.Status.Conditions, spireAgentConfigMap, statusMgr)
+	if rolledBack {
+		spireAgentConfigHash = rollbackHash
+	}
 
 	if err = controllerutil.SetControllerReference(agent, spireAgentConfigMap, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference")
@@ -41,7 +52,7 @@ func (r *SpireAgentReconciler) reconcileConfigMap(ctx context.Context, agent *v1
 	var existingSpireAgentCM corev1.ConfigMap
 	err = r.ctrlClient.Get(ctx, types.NamespacedName{Name: spireAgentConfigMap.Name, Namespace: spireAgentConfigMap.Namespace}, &existingSpireAgentCM)
 	if err != nil && kerrors.IsNotFound(err) {
-		if err = r.ctrlClient.Create(ctx, spireAgentConfigMap); err != nil {
+		if err = r.ctrlClient.Create(ctx, spireAgentConfigMap, customClient.AdoptExisting(ptr.Deref(agent.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create spire-agent config map")
 			statusMgr.AddCondition(ConfigMapAvailable, "SpireAgentConfigMapGenerationFailed",
 				err.Error(),
@@ -49,7 +60,7 @@ func (r *SpireAgentReconciler) reconcileConfigMap(ctx context.Context, agent *v1
 			return "", fmt.Errorf("failed to create ConfigMap: %w", err)
 		}
 		r.log.Info("Created spire agent ConfigMap")
-	} else if err == nil && (existingSpireAgentCM.Data["agent.conf"] != spireAgentConfigMap.Data["agent.conf"] ||
+	} else if err == nil && (!equality.Semantic.DeepEqual(existingSpireAgentCM.Data, spireAgentConfigMap.Data) ||
 		!equality.Semantic.DeepEqual(existingSpireAgentCM.Labels, spireAgentConfigMap.Labels)) {
 		if createOnlyMode {
 			r.log.Info("Skipping ConfigMap update due to create-only mode")
@@ -75,10 +86,17 @@ func (r *SpireAgentReconciler) reconcileConfigMap(ctx context.Context, agent *v1
 		"Spire Agent ConfigMap resources applied",
 		metav1.ConditionTrue)
 
+	// A newly created ConfigMap is recorded on the reconcile triggered by its creation
+	if !rolledBack && !createOnlyMode && existingSpireAgentCM.Name != "" {
+		if err = confighistory.RecordRevision(ctx, r.ctrlClient, r.scheme, agent, spireAgentConfigMap); err != nil {
+			r.log.Error(err, "failed to record spire-agent config revision")
+		}
+	}
+
 	return spireAgentConfigHash, nil
 }
 
-func generateAgentConfig(cfg *v1alpha1.SpireAgent, ztwim *v1alpha1.ZeroTrustWorkloadIdentityManager) map[string]interface{} {
+func generateAgentConfig(cfg *v1alpha2.SpireAgent, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager) map[string]interface{} {
 	spireServerAddress := "spire-server." + utils.GetOperatorNamespace()
 	agentConf := map[string]interface{}{
 		"agent": map[string]interface{}{
@@ -90,7 +108,7 @@ func generateAgentConfig(cfg *v1alpha1.SpireAgent, ztwim *v1alpha1.ZeroTrustWork
 			"server_port":       "443",
 			"socket_path":       "/tmp/spire-agent/public/spire-agent.sock",
 			"trust_bundle_path": "/run/spire/bundle/bundle.crt",
-			"trust_domain":      ztwim.Spec.TrustDomain,
+			"trust_domain":      utils.TrustDomain(ztwim),
 		},
 		"health_checks": map[string]interface{}{
 			"bind_address":     "0.0.0.0",
@@ -112,7 +130,36 @@ func generateAgentConfig(cfg *v1alpha1.SpireAgent, ztwim *v1alpha1.ZeroTrustWork
 		},
 	}
 
-	if cfg.Spec.NodeAttestor != nil && cfg.Spec.NodeAttestor.K8sPSATEnabled == "true" {
+	// Serve the Delegated Identity API to the authorized delegates on the admin socket
+	if delegatedIdentityEnabled(&cfg.Spec) {
+		agentConf["agent"].(map[string]interface{})["admin_socket_path"] = spireAgentAdminSocketDir + "/admin.sock"
+		agentConf["agent"].(map[string]interface{})["authorized_delegates"] = cfg.Spec.DelegatedIdentity.AuthorizedDelegates
+	}
+
+	// Keep the SVIDs valid through outages of the server, and rebootstrap in place after them
+	if availability := cfg.Spec.Availability; availability != nil {
+		if availability.Target != nil {
+			agentConf["agent"].(map[string]interface{})["availability_target"] = availability.Target
+		}
+		if availability.RebootstrapMode != "" && availability.RebootstrapMode != v1alpha2.RebootstrapModeNever {
+			agentConf["agent"].(map[string]interface{})["rebootstrap_mode"] = strings.ToLower(string(availability.RebootstrapMode))
+			if availability.RebootstrapDelay.Duration > 0 {
+				agentConf["agent"].(map[string]interface{})["rebootstrap_delay"] = availability.RebootstrapDelay
+			}
+		}
+	}
+
+	// Size the SVID caches for the number of workloads of the nodes
+	if cache := cfg.Spec.SVIDCache; cache != nil {
+		if cache.X509MaxSize != nil {
+			agentConf["agent"].(map[string]interface{})["x509_svid_cache_max_size"] = *cache.X509MaxSize
+		}
+		if cache.JWTMaxSize != nil {
+			agentConf["agent"].(map[string]interface{})["jwt_svid_cache_max_size"] = *cache.JWTMaxSize
+		}
+	}
+
+	if cfg.Spec.NodeAttestor != nil && ptr.Deref(cfg.Spec.NodeAttestor.K8sPSATEnabled, false) {
 		agentConf["plugins"].(map[string]interface{})["NodeAttestor"] = []map[string]interface{}{
 			{
 				"k8s_psat": map[string]interface{}{
@@ -124,11 +171,23 @@ func generateAgentConfig(cfg *v1alpha1.SpireAgent, ztwim *v1alpha1.ZeroTrustWork
 		}
 	}
 
-	if cfg.Spec.WorkloadAttestors != nil && cfg.Spec.WorkloadAttestors.K8sEnabled == "true" {
+	if cfg.Spec.NodeAttestor != nil && cfg.Spec.NodeAttestor.JoinToken != nil {
+		// The token itself is passed with -joinToken from the bootstrap token Secret
+		agentConf["plugins"].(map[string]interface{})["NodeAttestor"] = []map[string]interface{}{
+			{"join_token": map[string]interface{}{"plugin_data": map[string]interface{}{}}},
+		}
+	}
+
+	// Experimental options are only rendered when enabled as an unsupported feature
+	if experimental := utils.ExperimentalConfig(cfg.Spec.ExperimentalFlags, utils.SpireAgentExperimentalFlags); experimental != nil {
+		agentConf["agent"].(map[string]interface{})["experimental"] = experimental
+	}
+
+	if cfg.Spec.WorkloadAttestors != nil && ptr.Deref(cfg.Spec.WorkloadAttestors.K8sEnabled, false) {
 		plugin := map[string]interface{}{
-			"disable_container_selectors":    utils.StringToBool(cfg.Spec.WorkloadAttestors.DisableContainerSelectors),
+			"disable_container_selectors":    ptr.Deref(cfg.Spec.WorkloadAttestors.DisableContainerSelectors, false),
 			"node_name_env":                  "MY_NODE_NAME",
-			"use_new_container_locator":      utils.StringToBool(cfg.Spec.WorkloadAttestors.UseNewContainerLocator),
+			"use_new_container_locator":      ptr.Deref(cfg.Spec.WorkloadAttestors.UseNewContainerLocator, false),
 			"verbose_container_locator_logs": false,
 		}
 
@@ -140,13 +199,18 @@ func generateAgentConfig(cfg *v1alpha1.SpireAgent, ztwim *v1alpha1.ZeroTrustWork
 		}
 	}
 
+	addAdditionalAttestorsToConfig(agentConf["plugins"].(map[string]interface{}), cfg.Spec.WorkloadAttestors)
+
+	// Send the telemetry to the statsd bridge, if any
+	telemetry.ConfigureSPIRE(agentConf["telemetry"].(map[string]interface{}), cfg.Spec.Telemetry)
+
 	return agentConf
 }
 
 // configureKubeletVerification configures the kubelet TLS verification settings
 // based on the WorkloadAttestorsVerification configuration.
 // This maps to SPIRE's skip_kubelet_verification and kubelet_ca_path options.
-func configureKubeletVerification(plugin map[string]interface{}, verification *v1alpha1.WorkloadAttestorsVerification) {
+func configureKubeletVerification(plugin map[string]interface{}, verification *v1alpha2.WorkloadAttestorsVerification) {
 	// Default to skip if no verification config is provided
 	if verification == nil || verification.Type == "" || verification.Type == utils.WorkloadAttestorVerificationTypeSkip {
 		plugin["skip_kubelet_verification"] = true
@@ -176,7 +240,7 @@ func configureKubeletVerification(plugin map[string]interface{}, verification *v
 
 // buildHostCertPath constructs the full path to the kubelet CA certificate.
 // Returns empty string if either hostCertBasePath or hostCertFileName is not specified.
-func buildHostCertPath(verification *v1alpha1.WorkloadAttestorsVerification) string {
+func buildHostCertPath(verification *v1alpha2.WorkloadAttestorsVerification) string {
 	if verification == nil {
 		return ""
 	}
@@ -189,14 +253,14 @@ func buildHostCertPath(verification *v1alpha1.WorkloadAttestorsVerification) str
 	return path.Join(verification.HostCertBasePath, verification.HostCertFileName)
 }
 
-func generateSpireAgentConfigMap(spireAgentConfig *v1alpha1.SpireAgent, ztwim *v1alpha1.ZeroTrustWorkloadIdentityManager) (*corev1.ConfigMap, string, error) {
+func generateSpireAgentConfigMap(spireAgentConfig *v1alpha2.SpireAgent, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager) (*corev1.ConfigMap, string, error) {
 	agentConfig := generateAgentConfig(spireAgentConfig, ztwim)
 	agentConfigJSON, err := json.MarshalIndent(agentConfig, "", "  ")
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to marshal agent config: %w", err)
 	}
 
-	spireAgentConfigHash := utils.GenerateConfigHash(agentConfigJSON)
+	spireAgentConfigHash := utils.GenerateConfigHash(append(agentConfigJSON, additionalAttestorConfigHashInput(spireAgentConfig.Spec.WorkloadAttestors)...))
 
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -211,6 +275,9 @@ func generateSpireAgentConfigMap(spireAgentConfig *v1alpha1.SpireAgent, ztwim *v
 			"agent.conf": string(agentConfigJSON),
 		},
 	}
+	for key, data := range additionalAttestorConfigFiles(spireAgentConfig.Spec.WorkloadAttestors) {
+		cm.Data[key] = data
+	}
 
 	return cm, spireAgentConfigHash, nil
 }
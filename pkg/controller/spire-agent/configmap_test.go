@@ -4,28 +4,30 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func TestGenerateAgentConfig(t *testing.T) {
 	tests := []struct {
 		name     string
-		cfg      *v1alpha1.SpireAgent
-		ztwim    *v1alpha1.ZeroTrustWorkloadIdentityManager
+		cfg      *v1alpha2.SpireAgent
+		ztwim    *v1alpha2.ZeroTrustWorkloadIdentityManager
 		expected map[string]interface{}
 	}{
 		{
 			name: "minimal config",
-			cfg: &v1alpha1.SpireAgent{
-				Spec: v1alpha1.SpireAgentSpec{},
+			cfg: &v1alpha2.SpireAgent{
+				Spec: v1alpha2.SpireAgentSpec{},
 			},
-			ztwim: &v1alpha1.ZeroTrustWorkloadIdentityManager{
-				Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+			ztwim: &v1alpha2.ZeroTrustWorkloadIdentityManager{
+				Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 					TrustDomain:     "example.org",
 					BundleConfigMap: "spire-bundle",
 				},
@@ -64,15 +66,15 @@ func TestGenerateAgentConfig(t *testing.T) {
 		},
 		{
 			name: "config with k8s_psat node attestor enabled",
-			cfg: &v1alpha1.SpireAgent{
-				Spec: v1alpha1.SpireAgentSpec{
-					NodeAttestor: &v1alpha1.NodeAttestor{
-						K8sPSATEnabled: "true",
+			cfg: &v1alpha2.SpireAgent{
+				Spec: v1alpha2.SpireAgentSpec{
+					NodeAttestor: &v1alpha2.NodeAttestor{
+						K8sPSATEnabled: ptr.To(true),
 					},
 				},
 			},
-			ztwim: &v1alpha1.ZeroTrustWorkloadIdentityManager{
-				Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+			ztwim: &v1alpha2.ZeroTrustWorkloadIdentityManager{
+				Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 					TrustDomain:     "test.domain",
 					ClusterName:     "test-cluster",
 					BundleConfigMap: "spire-bundle",
@@ -121,17 +123,17 @@ func TestGenerateAgentConfig(t *testing.T) {
 		},
 		{
 			name: "config with k8s workload attestor enabled",
-			cfg: &v1alpha1.SpireAgent{
-				Spec: v1alpha1.SpireAgentSpec{
-					WorkloadAttestors: &v1alpha1.WorkloadAttestors{
-						K8sEnabled:                "true",
-						DisableContainerSelectors: "true",
-						UseNewContainerLocator:    "false",
+			cfg: &v1alpha2.SpireAgent{
+				Spec: v1alpha2.SpireAgentSpec{
+					WorkloadAttestors: &v1alpha2.WorkloadAttestors{
+						K8sEnabled:                ptr.To(true),
+						DisableContainerSelectors: ptr.To(true),
+						UseNewContainerLocator:    ptr.To(false),
 					},
 				},
 			},
-			ztwim: &v1alpha1.ZeroTrustWorkloadIdentityManager{
-				Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+			ztwim: &v1alpha2.ZeroTrustWorkloadIdentityManager{
+				Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 					TrustDomain:     "workload.domain",
 					BundleConfigMap: "spire-bundle",
 				},
@@ -183,20 +185,20 @@ func TestGenerateAgentConfig(t *testing.T) {
 		},
 		{
 			name: "config with both attestors enabled",
-			cfg: &v1alpha1.SpireAgent{
-				Spec: v1alpha1.SpireAgentSpec{
-					NodeAttestor: &v1alpha1.NodeAttestor{
-						K8sPSATEnabled: "true",
+			cfg: &v1alpha2.SpireAgent{
+				Spec: v1alpha2.SpireAgentSpec{
+					NodeAttestor: &v1alpha2.NodeAttestor{
+						K8sPSATEnabled: ptr.To(true),
 					},
-					WorkloadAttestors: &v1alpha1.WorkloadAttestors{
-						K8sEnabled:                "true",
-						DisableContainerSelectors: "false",
-						UseNewContainerLocator:    "true",
+					WorkloadAttestors: &v1alpha2.WorkloadAttestors{
+						K8sEnabled:                ptr.To(true),
+						DisableContainerSelectors: ptr.To(false),
+						UseNewContainerLocator:    ptr.To(true),
 					},
 				},
 			},
-			ztwim: &v1alpha1.ZeroTrustWorkloadIdentityManager{
-				Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+			ztwim: &v1alpha2.ZeroTrustWorkloadIdentityManager{
+				Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 					TrustDomain:     "full.domain",
 					ClusterName:     "full-cluster",
 					BundleConfigMap: "spire-bundle",
@@ -258,15 +260,15 @@ func TestGenerateAgentConfig(t *testing.T) {
 		},
 		{
 			name: "config with node attestor disabled",
-			cfg: &v1alpha1.SpireAgent{
-				Spec: v1alpha1.SpireAgentSpec{
-					NodeAttestor: &v1alpha1.NodeAttestor{
-						K8sPSATEnabled: "false",
+			cfg: &v1alpha2.SpireAgent{
+				Spec: v1alpha2.SpireAgentSpec{
+					NodeAttestor: &v1alpha2.NodeAttestor{
+						K8sPSATEnabled: ptr.To(false),
 					},
 				},
 			},
-			ztwim: &v1alpha1.ZeroTrustWorkloadIdentityManager{
-				Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+			ztwim: &v1alpha2.ZeroTrustWorkloadIdentityManager{
+				Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 					TrustDomain:     "disabled.domain",
 					ClusterName:     "disabled-cluster",
 					BundleConfigMap: "spire-bundle",
@@ -306,15 +308,15 @@ func TestGenerateAgentConfig(t *testing.T) {
 		},
 		{
 			name: "config with workload attestor disabled",
-			cfg: &v1alpha1.SpireAgent{
-				Spec: v1alpha1.SpireAgentSpec{
-					WorkloadAttestors: &v1alpha1.WorkloadAttestors{
-						K8sEnabled: "false",
+			cfg: &v1alpha2.SpireAgent{
+				Spec: v1alpha2.SpireAgentSpec{
+					WorkloadAttestors: &v1alpha2.WorkloadAttestors{
+						K8sEnabled: ptr.To(false),
 					},
 				},
 			},
-			ztwim: &v1alpha1.ZeroTrustWorkloadIdentityManager{
-				Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+			ztwim: &v1alpha2.ZeroTrustWorkloadIdentityManager{
+				Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 					TrustDomain:     "workload-disabled.domain",
 					BundleConfigMap: "spire-bundle",
 				},
@@ -364,8 +366,8 @@ func TestGenerateAgentConfig(t *testing.T) {
 func TestGenerateSpireAgentConfigMap(t *testing.T) {
 	tests := []struct {
 		name                       string
-		spireAgentConfig           *v1alpha1.SpireAgent
-		ztwim                      *v1alpha1.ZeroTrustWorkloadIdentityManager
+		spireAgentConfig           *v1alpha2.SpireAgent
+		ztwim                      *v1alpha2.ZeroTrustWorkloadIdentityManager
 		expectedConfigMapName      string
 		expectedConfigMapNamespace string
 		expectError                bool
@@ -373,15 +375,15 @@ func TestGenerateSpireAgentConfigMap(t *testing.T) {
 	}{
 		{
 			name: "successful configmap generation",
-			spireAgentConfig: &v1alpha1.SpireAgent{
+			spireAgentConfig: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-agent-config",
 					Namespace: utils.GetOperatorNamespace(),
 				},
-				Spec: v1alpha1.SpireAgentSpec{},
+				Spec: v1alpha2.SpireAgentSpec{},
 			},
-			ztwim: &v1alpha1.ZeroTrustWorkloadIdentityManager{
-				Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+			ztwim: &v1alpha2.ZeroTrustWorkloadIdentityManager{
+				Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 					TrustDomain:     "example.org",
 					BundleConfigMap: "spire-bundle",
 				},
@@ -393,16 +395,16 @@ func TestGenerateSpireAgentConfigMap(t *testing.T) {
 		},
 		{
 			name: "configmap with custom labels",
-			spireAgentConfig: &v1alpha1.SpireAgent{
+			spireAgentConfig: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-agent-config",
 					Namespace: utils.GetOperatorNamespace(),
 				},
-				Spec: v1alpha1.SpireAgentSpec{
-					NodeAttestor: &v1alpha1.NodeAttestor{
-						K8sPSATEnabled: "true",
+				Spec: v1alpha2.SpireAgentSpec{
+					NodeAttestor: &v1alpha2.NodeAttestor{
+						K8sPSATEnabled: ptr.To(true),
 					},
-					CommonConfig: v1alpha1.CommonConfig{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{
 							"custom-label": "custom-value",
 							"environment":  "test",
@@ -411,8 +413,8 @@ func TestGenerateSpireAgentConfigMap(t *testing.T) {
 					},
 				},
 			},
-			ztwim: &v1alpha1.ZeroTrustWorkloadIdentityManager{
-				Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+			ztwim: &v1alpha2.ZeroTrustWorkloadIdentityManager{
+				Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 					TrustDomain:     "example.org",
 					ClusterName:     "test-cluster",
 					BundleConfigMap: "spire-bundle",
@@ -503,25 +505,25 @@ func TestGenerateSpireAgentConfigMap(t *testing.T) {
 }
 
 func TestGenerateSpireAgentConfigMapConsistency(t *testing.T) {
-	spireAgentConfig := &v1alpha1.SpireAgent{
+	spireAgentConfig := &v1alpha2.SpireAgent{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "consistency-test",
 			Namespace: utils.GetOperatorNamespace(),
 		},
-		Spec: v1alpha1.SpireAgentSpec{
-			NodeAttestor: &v1alpha1.NodeAttestor{
-				K8sPSATEnabled: "true",
+		Spec: v1alpha2.SpireAgentSpec{
+			NodeAttestor: &v1alpha2.NodeAttestor{
+				K8sPSATEnabled: ptr.To(true),
 			},
-			WorkloadAttestors: &v1alpha1.WorkloadAttestors{
-				K8sEnabled:                "true",
-				DisableContainerSelectors: "true",
-				UseNewContainerLocator:    "false",
+			WorkloadAttestors: &v1alpha2.WorkloadAttestors{
+				K8sEnabled:                ptr.To(true),
+				DisableContainerSelectors: ptr.To(true),
+				UseNewContainerLocator:    ptr.To(false),
 			},
 		},
 	}
 
-	ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
-		Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 			TrustDomain:     "consistency.test",
 			ClusterName:     "consistency-cluster",
 			BundleConfigMap: "spire-bundle",
@@ -548,28 +550,28 @@ func TestGenerateSpireAgentConfigMapConsistency(t *testing.T) {
 func TestGenerateAgentConfigNilChecks(t *testing.T) {
 	tests := []struct {
 		name string
-		cfg  *v1alpha1.SpireAgent
+		cfg  *v1alpha2.SpireAgent
 	}{
 		{
 			name: "nil node attestor",
-			cfg: &v1alpha1.SpireAgent{
-				Spec: v1alpha1.SpireAgentSpec{
+			cfg: &v1alpha2.SpireAgent{
+				Spec: v1alpha2.SpireAgentSpec{
 					NodeAttestor: nil,
 				},
 			},
 		},
 		{
 			name: "nil workload attestors",
-			cfg: &v1alpha1.SpireAgent{
-				Spec: v1alpha1.SpireAgentSpec{
+			cfg: &v1alpha2.SpireAgent{
+				Spec: v1alpha2.SpireAgentSpec{
 					WorkloadAttestors: nil,
 				},
 			},
 		},
 		{
 			name: "both nil",
-			cfg: &v1alpha1.SpireAgent{
-				Spec: v1alpha1.SpireAgentSpec{
+			cfg: &v1alpha2.SpireAgent{
+				Spec: v1alpha2.SpireAgentSpec{
 					NodeAttestor:      nil,
 					WorkloadAttestors: nil,
 				},
@@ -580,8 +582,8 @@ func TestGenerateAgentConfigNilChecks(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Should not panic
-			ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
-				Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+			ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+				Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 					TrustDomain:     "test.domain",
 					ClusterName:     "test-cluster",
 					BundleConfigMap: "spire-bundle",
@@ -604,17 +606,17 @@ func TestGenerateAgentConfigNilChecks(t *testing.T) {
 }
 
 func TestGenerateSpireAgentConfigMapEmptyLabels(t *testing.T) {
-	spireAgentConfig := &v1alpha1.SpireAgent{
+	spireAgentConfig := &v1alpha2.SpireAgent{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "empty-labels-test",
 			Namespace: utils.GetOperatorNamespace(),
 			Labels:    nil, // Explicitly nil labels
 		},
-		Spec: v1alpha1.SpireAgentSpec{},
+		Spec: v1alpha2.SpireAgentSpec{},
 	}
 
-	ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
-		Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 			TrustDomain:     "empty.labels",
 			BundleConfigMap: "spire-bundle",
 		},
@@ -633,7 +635,7 @@ func TestGenerateSpireAgentConfigMapEmptyLabels(t *testing.T) {
 func TestConfigureKubeletVerification(t *testing.T) {
 	tests := []struct {
 		name         string
-		verification *v1alpha1.WorkloadAttestorsVerification
+		verification *v1alpha2.WorkloadAttestorsVerification
 		expected     map[string]interface{}
 	}{
 		{
@@ -645,14 +647,14 @@ func TestConfigureKubeletVerification(t *testing.T) {
 		},
 		{
 			name:         "empty type defaults to skip",
-			verification: &v1alpha1.WorkloadAttestorsVerification{},
+			verification: &v1alpha2.WorkloadAttestorsVerification{},
 			expected: map[string]interface{}{
 				"skip_kubelet_verification": true,
 			},
 		},
 		{
 			name: "skip type",
-			verification: &v1alpha1.WorkloadAttestorsVerification{
+			verification: &v1alpha2.WorkloadAttestorsVerification{
 				Type: utils.WorkloadAttestorVerificationTypeSkip,
 			},
 			expected: map[string]interface{}{
@@ -661,7 +663,7 @@ func TestConfigureKubeletVerification(t *testing.T) {
 		},
 		{
 			name: "hostCert type with paths",
-			verification: &v1alpha1.WorkloadAttestorsVerification{
+			verification: &v1alpha2.WorkloadAttestorsVerification{
 				Type:             utils.WorkloadAttestorVerificationTypeHostCert,
 				HostCertBasePath: "/etc/kubernetes",
 				HostCertFileName: "kubelet-ca.crt",
@@ -673,7 +675,7 @@ func TestConfigureKubeletVerification(t *testing.T) {
 		},
 		{
 			name: "hostCert type with trailing slash in basePath",
-			verification: &v1alpha1.WorkloadAttestorsVerification{
+			verification: &v1alpha2.WorkloadAttestorsVerification{
 				Type:             utils.WorkloadAttestorVerificationTypeHostCert,
 				HostCertBasePath: "/etc/kubernetes/",
 				HostCertFileName: "kubelet-ca.crt",
@@ -685,7 +687,7 @@ func TestConfigureKubeletVerification(t *testing.T) {
 		},
 		{
 			name: "hostCert type without paths (CEL would block this, but test the fallback)",
-			verification: &v1alpha1.WorkloadAttestorsVerification{
+			verification: &v1alpha2.WorkloadAttestorsVerification{
 				Type: utils.WorkloadAttestorVerificationTypeHostCert,
 			},
 			expected: map[string]interface{}{
@@ -695,7 +697,7 @@ func TestConfigureKubeletVerification(t *testing.T) {
 		},
 		{
 			name: "auto type without paths (uses OpenShift defaults)",
-			verification: &v1alpha1.WorkloadAttestorsVerification{
+			verification: &v1alpha2.WorkloadAttestorsVerification{
 				Type: utils.WorkloadAttestorVerificationTypeAuto,
 			},
 			expected: map[string]interface{}{
@@ -705,7 +707,7 @@ func TestConfigureKubeletVerification(t *testing.T) {
 		},
 		{
 			name: "auto type with paths",
-			verification: &v1alpha1.WorkloadAttestorsVerification{
+			verification: &v1alpha2.WorkloadAttestorsVerification{
 				Type:             utils.WorkloadAttestorVerificationTypeAuto,
 				HostCertBasePath: "/etc/kubernetes",
 				HostCertFileName: "kubelet-ca.crt",
@@ -717,7 +719,7 @@ func TestConfigureKubeletVerification(t *testing.T) {
 		},
 		{
 			name: "auto type with trailing slash in basePath",
-			verification: &v1alpha1.WorkloadAttestorsVerification{
+			verification: &v1alpha2.WorkloadAttestorsVerification{
 				Type:             utils.WorkloadAttestorVerificationTypeAuto,
 				HostCertBasePath: "/etc/kubernetes/",
 				HostCertFileName: "kubelet-ca.crt",
@@ -729,7 +731,7 @@ func TestConfigureKubeletVerification(t *testing.T) {
 		},
 		{
 			name: "auto type with only basePath (falls back to defaults)",
-			verification: &v1alpha1.WorkloadAttestorsVerification{
+			verification: &v1alpha2.WorkloadAttestorsVerification{
 				Type:             utils.WorkloadAttestorVerificationTypeAuto,
 				HostCertBasePath: "/etc/kubernetes",
 			},
@@ -740,7 +742,7 @@ func TestConfigureKubeletVerification(t *testing.T) {
 		},
 		{
 			name: "auto type with custom paths",
-			verification: &v1alpha1.WorkloadAttestorsVerification{
+			verification: &v1alpha2.WorkloadAttestorsVerification{
 				Type:             utils.WorkloadAttestorVerificationTypeAuto,
 				HostCertBasePath: "/custom/path",
 				HostCertFileName: "custom-ca.crt",
@@ -752,7 +754,7 @@ func TestConfigureKubeletVerification(t *testing.T) {
 		},
 		{
 			name: "unknown type defaults to skip",
-			verification: &v1alpha1.WorkloadAttestorsVerification{
+			verification: &v1alpha2.WorkloadAttestorsVerification{
 				Type: "unknown",
 			},
 			expected: map[string]interface{}{
@@ -779,7 +781,7 @@ func TestConfigureKubeletVerification(t *testing.T) {
 func TestBuildHostCertPath(t *testing.T) {
 	tests := []struct {
 		name         string
-		verification *v1alpha1.WorkloadAttestorsVerification
+		verification *v1alpha2.WorkloadAttestorsVerification
 		expected     string
 	}{
 		{
@@ -789,26 +791,26 @@ func TestBuildHostCertPath(t *testing.T) {
 		},
 		{
 			name:         "empty verification",
-			verification: &v1alpha1.WorkloadAttestorsVerification{},
+			verification: &v1alpha2.WorkloadAttestorsVerification{},
 			expected:     "",
 		},
 		{
 			name: "only basePath",
-			verification: &v1alpha1.WorkloadAttestorsVerification{
+			verification: &v1alpha2.WorkloadAttestorsVerification{
 				HostCertBasePath: "/etc/kubernetes",
 			},
 			expected: "",
 		},
 		{
 			name: "only fileName",
-			verification: &v1alpha1.WorkloadAttestorsVerification{
+			verification: &v1alpha2.WorkloadAttestorsVerification{
 				HostCertFileName: "kubelet-ca.crt",
 			},
 			expected: "",
 		},
 		{
 			name: "both basePath and fileName",
-			verification: &v1alpha1.WorkloadAttestorsVerification{
+			verification: &v1alpha2.WorkloadAttestorsVerification{
 				HostCertBasePath: "/etc/kubernetes",
 				HostCertFileName: "kubelet-ca.crt",
 			},
@@ -816,7 +818,7 @@ func TestBuildHostCertPath(t *testing.T) {
 		},
 		{
 			name: "basePath with trailing slash normalizes correctly",
-			verification: &v1alpha1.WorkloadAttestorsVerification{
+			verification: &v1alpha2.WorkloadAttestorsVerification{
 				HostCertBasePath: "/etc/kubernetes/",
 				HostCertFileName: "kubelet-ca.crt",
 			},
@@ -824,7 +826,7 @@ func TestBuildHostCertPath(t *testing.T) {
 		},
 		{
 			name: "basePath with multiple trailing slashes normalizes correctly",
-			verification: &v1alpha1.WorkloadAttestorsVerification{
+			verification: &v1alpha2.WorkloadAttestorsVerification{
 				HostCertBasePath: "/etc/kubernetes///",
 				HostCertFileName: "kubelet-ca.crt",
 			},
@@ -832,7 +834,7 @@ func TestBuildHostCertPath(t *testing.T) {
 		},
 		{
 			name: "custom paths",
-			verification: &v1alpha1.WorkloadAttestorsVerification{
+			verification: &v1alpha2.WorkloadAttestorsVerification{
 				HostCertBasePath: "/custom/path",
 				HostCertFileName: "custom-ca.pem",
 			},
@@ -851,18 +853,18 @@ func TestBuildHostCertPath(t *testing.T) {
 func TestGenerateAgentConfigWithVerification(t *testing.T) {
 	tests := []struct {
 		name                     string
-		cfg                      *v1alpha1.SpireAgent
+		cfg                      *v1alpha2.SpireAgent
 		expectedSkipVerification bool
 		expectedKubeletCAPath    string
 		kubeletCAPathShouldBeSet bool
 	}{
 		{
 			name: "workload attestor with skip verification",
-			cfg: &v1alpha1.SpireAgent{
-				Spec: v1alpha1.SpireAgentSpec{
-					WorkloadAttestors: &v1alpha1.WorkloadAttestors{
-						K8sEnabled: "true",
-						WorkloadAttestorsVerification: &v1alpha1.WorkloadAttestorsVerification{
+			cfg: &v1alpha2.SpireAgent{
+				Spec: v1alpha2.SpireAgentSpec{
+					WorkloadAttestors: &v1alpha2.WorkloadAttestors{
+						K8sEnabled: ptr.To(true),
+						WorkloadAttestorsVerification: &v1alpha2.WorkloadAttestorsVerification{
 							Type: utils.WorkloadAttestorVerificationTypeSkip,
 						},
 					},
@@ -873,11 +875,11 @@ func TestGenerateAgentConfigWithVerification(t *testing.T) {
 		},
 		{
 			name: "workload attestor with hostCert verification",
-			cfg: &v1alpha1.SpireAgent{
-				Spec: v1alpha1.SpireAgentSpec{
-					WorkloadAttestors: &v1alpha1.WorkloadAttestors{
-						K8sEnabled: "true",
-						WorkloadAttestorsVerification: &v1alpha1.WorkloadAttestorsVerification{
+			cfg: &v1alpha2.SpireAgent{
+				Spec: v1alpha2.SpireAgentSpec{
+					WorkloadAttestors: &v1alpha2.WorkloadAttestors{
+						K8sEnabled: ptr.To(true),
+						WorkloadAttestorsVerification: &v1alpha2.WorkloadAttestorsVerification{
 							Type:             utils.WorkloadAttestorVerificationTypeHostCert,
 							HostCertBasePath: "/etc/kubernetes",
 							HostCertFileName: "kubelet-ca.crt",
@@ -891,11 +893,11 @@ func TestGenerateAgentConfigWithVerification(t *testing.T) {
 		},
 		{
 			name: "workload attestor with hostCert verification (trailing slash)",
-			cfg: &v1alpha1.SpireAgent{
-				Spec: v1alpha1.SpireAgentSpec{
-					WorkloadAttestors: &v1alpha1.WorkloadAttestors{
-						K8sEnabled: "true",
-						WorkloadAttestorsVerification: &v1alpha1.WorkloadAttestorsVerification{
+			cfg: &v1alpha2.SpireAgent{
+				Spec: v1alpha2.SpireAgentSpec{
+					WorkloadAttestors: &v1alpha2.WorkloadAttestors{
+						K8sEnabled: ptr.To(true),
+						WorkloadAttestorsVerification: &v1alpha2.WorkloadAttestorsVerification{
 							Type:             utils.WorkloadAttestorVerificationTypeHostCert,
 							HostCertBasePath: "/etc/kubernetes/",
 							HostCertFileName: "kubelet-ca.crt",
@@ -909,11 +911,11 @@ func TestGenerateAgentConfigWithVerification(t *testing.T) {
 		},
 		{
 			name: "workload attestor with auto verification (no paths - uses OpenShift defaults)",
-			cfg: &v1alpha1.SpireAgent{
-				Spec: v1alpha1.SpireAgentSpec{
-					WorkloadAttestors: &v1alpha1.WorkloadAttestors{
-						K8sEnabled: "true",
-						WorkloadAttestorsVerification: &v1alpha1.WorkloadAttestorsVerification{
+			cfg: &v1alpha2.SpireAgent{
+				Spec: v1alpha2.SpireAgentSpec{
+					WorkloadAttestors: &v1alpha2.WorkloadAttestors{
+						K8sEnabled: ptr.To(true),
+						WorkloadAttestorsVerification: &v1alpha2.WorkloadAttestorsVerification{
 							Type: utils.WorkloadAttestorVerificationTypeAuto,
 						},
 					},
@@ -925,11 +927,11 @@ func TestGenerateAgentConfigWithVerification(t *testing.T) {
 		},
 		{
 			name: "workload attestor with auto verification (with paths)",
-			cfg: &v1alpha1.SpireAgent{
-				Spec: v1alpha1.SpireAgentSpec{
-					WorkloadAttestors: &v1alpha1.WorkloadAttestors{
-						K8sEnabled: "true",
-						WorkloadAttestorsVerification: &v1alpha1.WorkloadAttestorsVerification{
+			cfg: &v1alpha2.SpireAgent{
+				Spec: v1alpha2.SpireAgentSpec{
+					WorkloadAttestors: &v1alpha2.WorkloadAttestors{
+						K8sEnabled: ptr.To(true),
+						WorkloadAttestorsVerification: &v1alpha2.WorkloadAttestorsVerification{
 							Type:             utils.WorkloadAttestorVerificationTypeAuto,
 							HostCertBasePath: "/etc/kubernetes",
 							HostCertFileName: "kubelet-ca.crt",
@@ -943,10 +945,10 @@ func TestGenerateAgentConfigWithVerification(t *testing.T) {
 		},
 		{
 			name: "workload attestor without verification config (defaults to skip)",
-			cfg: &v1alpha1.SpireAgent{
-				Spec: v1alpha1.SpireAgentSpec{
-					WorkloadAttestors: &v1alpha1.WorkloadAttestors{
-						K8sEnabled: "true",
+			cfg: &v1alpha2.SpireAgent{
+				Spec: v1alpha2.SpireAgentSpec{
+					WorkloadAttestors: &v1alpha2.WorkloadAttestors{
+						K8sEnabled: ptr.To(true),
 					},
 				},
 			},
@@ -957,8 +959,8 @@ func TestGenerateAgentConfigWithVerification(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
-				Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+			ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+				Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 					TrustDomain:     "test.domain",
 					ClusterName:     "test-cluster",
 					BundleConfigMap: "spire-bundle",
@@ -990,3 +992,53 @@ func TestGenerateAgentConfigWithVerification(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateAgentConfigWithAvailability(t *testing.T) {
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.org"},
+	}
+
+	agent := &v1alpha2.SpireAgent{Spec: v1alpha2.SpireAgentSpec{
+		Availability: &v1alpha2.AgentAvailabilityConfig{
+			Target:           &metav1.Duration{Duration: 48 * time.Hour},
+			RebootstrapMode:  v1alpha2.RebootstrapModeAuto,
+			RebootstrapDelay: metav1.Duration{Duration: 10 * time.Minute},
+		},
+	}}
+	agentConf := generateAgentConfig(agent, ztwim)["agent"].(map[string]interface{})
+	assert.Equal(t, &metav1.Duration{Duration: 48 * time.Hour}, agentConf["availability_target"])
+	assert.Equal(t, "auto", agentConf["rebootstrap_mode"])
+	assert.Equal(t, metav1.Duration{Duration: 10 * time.Minute}, agentConf["rebootstrap_delay"])
+
+	// Never leaves the rebootstrap settings to the SPIRE defaults
+	agent.Spec.Availability = &v1alpha2.AgentAvailabilityConfig{
+		RebootstrapMode:  v1alpha2.RebootstrapModeNever,
+		RebootstrapDelay: metav1.Duration{Duration: 10 * time.Minute},
+	}
+	agentConf = generateAgentConfig(agent, ztwim)["agent"].(map[string]interface{})
+	assert.NotContains(t, agentConf, "availability_target")
+	assert.NotContains(t, agentConf, "rebootstrap_mode")
+	assert.NotContains(t, agentConf, "rebootstrap_delay")
+}
+
+func TestGenerateAgentConfigWithSVIDCache(t *testing.T) {
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.org", ClusterName: "test-cluster"},
+	}
+	agent := &v1alpha2.SpireAgent{}
+	agentConf := generateAgentConfig(agent, ztwim)["agent"].(map[string]interface{})
+	assert.NotContains(t, agentConf, "x509_svid_cache_max_size")
+	assert.NotContains(t, agentConf, "jwt_svid_cache_max_size")
+	_, defaultHash, err := generateSpireAgentConfigMap(agent, ztwim)
+	require.NoError(t, err)
+
+	agent.Spec.SVIDCache = &v1alpha2.AgentSVIDCacheConfig{X509MaxSize: ptr.To[int32](5000), JWTMaxSize: ptr.To[int32](2000)}
+	agentConf = generateAgentConfig(agent, ztwim)["agent"].(map[string]interface{})
+	assert.Equal(t, int32(5000), agentConf["x509_svid_cache_max_size"])
+	assert.Equal(t, int32(2000), agentConf["jwt_svid_cache_max_size"])
+
+	// The agents are rolled with the new cache sizes
+	_, hash, err := generateSpireAgentConfigMap(agent, ztwim)
+	require.NoError(t, err)
+	assert.NotEqual(t, defaultHash, hash)
+}
@@ -4,9 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	configv1 "github.com/openshift/api/config/v1"
 	securityv1 "github.com/openshift/api/security/v1"
 	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/audit"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/breaker"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/dependencies"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -18,19 +23,28 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/go-logr/logr"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/environment"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/maintenance"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/pipeline"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/spirecli"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/topology"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+	spiffev1alpha1 "github.com/spiffe/spire-controller-manager/api/v1alpha1"
 )
 
 const (
@@ -41,17 +55,22 @@ const (
 	ServiceAvailable                    = "ServiceAvailable"
 	RBACAvailable                       = "RBACAvailable"
 	ConfigurationValid                  = "ConfigurationValid"
+	BootstrapTokenAvailable             = "BootstrapTokenAvailable"
+	WorkloadAPIProbeHealthy             = "WorkloadAPIProbeHealthy"
 )
 
 const spireAgentDaemonSetSpireAgentConfigHashAnnotationKey = "ztwim.openshift.io/spire-agent-config-hash"
 
 // SpireAgentReconciler reconciles a SpireAgent object
 type SpireAgentReconciler struct {
-	ctrlClient    customClient.CustomCtrlClient
-	ctx           context.Context
-	eventRecorder record.EventRecorder
-	log           logr.Logger
-	scheme        *runtime.Scheme
+	ctrlClient     customClient.CustomCtrlClient
+	ctx            context.Context
+	eventRecorder  record.EventRecorder
+	log            logr.Logger
+	scheme         *runtime.Scheme
+	failureBreaker *breaker.Breaker
+	clocks         clockReader
+	cli            spirecli.Runner
 }
 
 // New returns a new Reconciler instance.
@@ -60,129 +79,323 @@ func New(mgr ctrl.Manager) (*SpireAgentReconciler, error) {
 	if err != nil {
 		return nil, err
 	}
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+	cli, err := spirecli.NewPodExecRunner(mgr.GetConfig())
+	if err != nil {
+		return nil, err
+	}
 	return &SpireAgentReconciler{
-		ctrlClient:    c,
-		ctx:           context.Background(),
-		eventRecorder: mgr.GetEventRecorderFor(utils.ZeroTrustWorkloadIdentityManagerSpireAgentControllerName),
-		log:           ctrl.Log.WithName(utils.ZeroTrustWorkloadIdentityManagerSpireAgentControllerName),
-		scheme:        mgr.GetScheme(),
+		ctrlClient:     c,
+		ctx:            context.Background(),
+		eventRecorder:  mgr.GetEventRecorderFor(utils.ZeroTrustWorkloadIdentityManagerSpireAgentControllerName),
+		log:            ctrl.Log.WithName(utils.ZeroTrustWorkloadIdentityManagerSpireAgentControllerName),
+		scheme:         mgr.GetScheme(),
+		failureBreaker: breaker.New(),
+		clocks:         &podProxyClockReader{restClient: clientset.CoreV1().RESTClient(), httpClient: mgr.GetHTTPClient()},
+		cli:            cli,
 	}, nil
 }
 
-func (r *SpireAgentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	r.log.Info(fmt.Sprintf("reconciling %s", utils.ZeroTrustWorkloadIdentityManagerSpireAgentControllerName))
-	var agent v1alpha1.SpireAgent
+func (r *SpireAgentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, reconcileErr error) {
+	traced := *r
+	ctx, traced.log, traced.eventRecorder = utils.TraceReconcile(ctx, r.log, r.eventRecorder)
+	r = &traced
+	r.log.Info("reconciling", "controller", utils.ZeroTrustWorkloadIdentityManagerSpireAgentControllerName, "name", req.Name)
+	var agent v1alpha2.SpireAgent
 	if err := r.ctrlClient.Get(ctx, req.NamespacedName, &agent); err != nil {
 		if kerrors.IsNotFound(err) {
 			r.log.Info("SpireAgent resource not found. Ignoring since object must be deleted or not been created.")
+			status.Forget(&agent, req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
-		return ctrl.Result{}, err
+		return utils.ReconcileResult(err)
 	}
 
 	// Set Ready to false at the start of reconciliation
-	status.SetInitialReconciliationStatus(ctx, r.ctrlClient, &agent, func() *v1alpha1.ConditionalStatus {
+	status.SetInitialReconciliationStatus(ctx, r.ctrlClient, &agent, func() *v1alpha2.ConditionalStatus {
 		return &agent.Status.ConditionalStatus
 	}, "SpireAgent")
 
 	statusMgr := status.NewManager(r.ctrlClient)
 	defer func() {
-		if err := statusMgr.ApplyStatus(ctx, &agent, func() *v1alpha1.ConditionalStatus {
+		statusMgr.ReportWorkloadVersion(ctx, &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: agentPoolResourceName(agent.Name), Namespace: utils.GetOperatorNamespace()}})
+		if err := statusMgr.ApplyStatus(ctx, &agent, func() *v1alpha2.ConditionalStatus {
 			return &agent.Status.ConditionalStatus
 		}); err != nil {
 			r.log.Error(err, "failed to update status")
 		}
+		res = statusMgr.RequeueHeldBack(res, reconcileErr)
 	}()
 
-	var ztwim v1alpha1.ZeroTrustWorkloadIdentityManager
+	var ztwim v1alpha2.ZeroTrustWorkloadIdentityManager
 	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: "cluster"}, &ztwim); err != nil {
 		if kerrors.IsNotFound(err) {
 			r.log.Error(err, "failed to get ZeroTrustWorkloadIdentityManager")
-			statusMgr.AddCondition(v1alpha1.Ready, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(v1alpha2.Ready, v1alpha2.ReasonFailed,
 				"Failed to retrieve ZeroTrustWorkloadIdentityManager from cluster",
 				metav1.ConditionFalse)
 			return ctrl.Result{}, nil
 		}
-		return ctrl.Result{}, err
+		return utils.ReconcileResult(err)
 	}
+	statusMgr.SetGracePeriod(ztwim.Spec.StatusGracePeriod)
 
 	// Set ZTWIM as the owner of SpireAgent only if needed
 	if utils.NeedsOwnerReferenceUpdate(&agent, &ztwim) {
 		if err := controllerutil.SetControllerReference(&ztwim, &agent, r.scheme); err != nil {
 			r.log.Error(err, "failed to set controller reference on SpireAgent")
-			statusMgr.AddCondition(v1alpha1.Ready, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(v1alpha2.Ready, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to set owner reference on SpireAgent: %v", err),
 				metav1.ConditionFalse)
-			return ctrl.Result{}, err
+			return utils.ReconcileResult(err)
 		}
 
 		// Persist the owner reference to the cluster
 		if err := r.ctrlClient.Update(ctx, &agent); err != nil {
 			r.log.Error(err, "failed to update SpireAgent with owner reference")
-			statusMgr.AddCondition(v1alpha1.Ready, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(v1alpha2.Ready, v1alpha2.ReasonFailed,
 				fmt.Sprintf("failed to update SpireAgent with owner reference: %v", err),
 				metav1.ConditionFalse)
-			return ctrl.Result{}, err
+			return utils.ReconcileResult(err)
 		}
 	}
 
+	// Fill in the settings left unset from the sizing and topology profiles, in memory only
+	topologyProfile, err := topology.Resolve(ctx, r.ctrlClient, &ztwim)
+	if err != nil {
+		r.log.Error(err, "failed to resolve the topology profile")
+		return utils.ReconcileResult(err)
+	}
+	topology.ApplyToOperand(topologyProfile, ztwim.Spec.SizingProfile, utils.ResourceKindSpireAgent, &agent.Spec.CommonConfig)
+	utils.ApplyImagePullSecrets(ztwim.Spec.ImagePullSecrets, &agent.Spec.CommonConfig)
+
 	// Handle create-only mode
 	createOnlyMode := r.handleCreateOnlyMode(&agent, statusMgr)
 
 	// Validate configuration (including proxy)
-	if err := r.validateConfiguration(ctx, &agent, statusMgr); err != nil {
+	if err := r.validateConfiguration(ctx, &agent, statusMgr, &ztwim); err != nil {
+		statusMgr.SetDegradedCondition(utils.NewInvalidConfigurationError(err, "configuration validation failed"), agent.Status.Conditions)
 		return ctrl.Result{}, nil
 	}
 
-	// Reconcile static resources (RBAC, ServiceAccount, Service)
-	if err := r.reconcileServiceAccount(ctx, &agent, statusMgr, createOnlyMode); err != nil {
-		return ctrl.Result{}, err
+	// Validate the maintenance windows deferring the DaemonSet updates
+	if err := maintenance.Validate(ztwim.Spec.MaintenanceWindows); err != nil {
+		r.log.Error(err, "Invalid maintenance windows")
+		statusMgr.AddCondition(ConfigurationValid, maintenance.ReasonInvalidWindow, err.Error(), metav1.ConditionFalse)
+		statusMgr.SetDegradedCondition(utils.NewInvalidConfigurationError(err, "configuration validation failed"), agent.Status.Conditions)
+		return ctrl.Result{}, nil
 	}
 
-	if err := r.reconcileService(ctx, &agent, statusMgr, createOnlyMode); err != nil {
-		return ctrl.Result{}, err
-	}
+	// Kinds of generated resources left to the user
+	unmanagedKinds := utils.UnmanagedKinds(agent.Spec.ManagedResources)
 
-	if err := r.reconcileRBAC(ctx, &agent, statusMgr, createOnlyMode); err != nil {
-		return ctrl.Result{}, err
+	// Stop at the resources of another SPIRE agent install rather than fighting over them
+	if err := r.reportEnvironmentConflicts(ctx, &agent, statusMgr, unmanagedKinds); err != nil {
+		statusMgr.SetDegradedCondition(err, agent.Status.Conditions)
+		if utils.ClassifyError(err) == utils.InvalidConfigurationError {
+			statusMgr.AddCondition(ConfigurationValid, utils.EnvironmentConflictStatusType, err.Error(), metav1.ConditionFalse)
+			// The conflicting resources are not watched, check them again periodically
+			return ctrl.Result{RequeueAfter: environment.RecheckInterval}, nil
+		}
+		return utils.ReconcileResult(err)
 	}
 
-	// Reconcile SCC
-	if err := r.reconcileSCC(ctx, &agent, statusMgr); err != nil {
-		return ctrl.Result{}, err
+	// Compute the pending changes without applying them when the dry-run annotation is set
+	if utils.IsDryRunRequested(&agent) {
+		dryRunClient := customClient.NewDryRunClient(r.ctrlClient)
+		dryRunReconciler := *r
+		dryRunReconciler.ctrlClient = customClient.NewUnmanagedClient(dryRunClient, unmanagedKinds)
+		err := dryRunReconciler.reconcileResources(ctx, &agent, status.NewManager(dryRunClient), &ztwim, createOnlyMode)
+		statusMgr.ReportDryRun(dryRunClient.Changes(), err)
+		return ctrl.Result{}, nil
 	}
+	statusMgr.ClearDryRun(agent.Status.Conditions)
 
-	// Reconcile ConfigMap
-	configHash, err := r.reconcileConfigMap(ctx, &agent, statusMgr, &ztwim, createOnlyMode)
+	// Hold back the creation of the agents until the SPIRE server is Ready
+	wait, err := statusMgr.WaitForSpireServer(ctx, &ztwim, &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: agentPoolResourceName(agent.Name), Namespace: utils.GetOperatorNamespace()}}, agent.Status.Conditions)
 	if err != nil {
-		return ctrl.Result{}, err
+		r.log.Error(err, "failed to check the SpireServer dependency")
+		return utils.ReconcileResult(err)
+	}
+	if wait {
+		r.log.Info("Waiting for the SpireServer to be Ready before creating the SPIRE agents", "pool", agent.Name)
+		return ctrl.Result{}, nil
 	}
 
-	// Reconcile DaemonSet
-	if err := r.reconcileDaemonSet(ctx, &agent, statusMgr, &ztwim, createOnlyMode, configHash); err != nil {
-		return ctrl.Result{}, err
+	// Record the resources changed for this generation in the audit trail, leaving the
+	// resources of unmanaged kinds alone
+	recordingClient := customClient.NewRecordingClient(r.ctrlClient)
+	unmanagedClient := customClient.NewUnmanagedClient(recordingClient, unmanagedKinds)
+	auditedReconciler := *r
+	auditedReconciler.ctrlClient = unmanagedClient
+	err = auditedReconciler.reconcileResources(ctx, &agent, statusMgr, &ztwim, createOnlyMode)
+	if auditErr := audit.Append(ctx, r.ctrlClient, r.scheme, r.eventRecorder, &agent, agent.Spec, recordingClient.Changes()); auditErr != nil {
+		r.log.Error(auditErr, "failed to record audit trail")
+	}
+	statusMgr.ReportUnmanagedResources(unmanagedKinds, unmanagedClient.Skipped(), agent.Status.Conditions)
+	// Fail closed on the resources missing in create-only mode rather than reporting stale conditions
+	if createOnlyMode {
+		statusMgr.CheckRequiredResources(ctx, r.eventRecorder, &agent, agent.Status.Conditions, requiredResources(&agent, &ztwim)...)
+	}
+	statusMgr.SetDegradedCondition(err, agent.Status.Conditions)
+	result, err := r.failureBreaker.Result(r.eventRecorder, &agent, statusMgr, recordingClient.Failure(), err)
+	if err == nil && result.RequeueAfter == 0 && isDefaultPool(&agent) && (r.clocks != nil || r.cli != nil || socketExposureAuditEnabled(&agent)) {
+		// Clock changes of the nodes, the attested agents and the workload pods are not watched,
+		// check them again periodically
+		result.RequeueAfter = clockSkewCheckInterval
+	}
+	if err == nil {
+		// Apply the deferred changes when the next maintenance window opens
+		result.RequeueAfter = maintenance.RequeueAfter(statusMgr, ztwim.Spec.MaintenanceWindows, result.RequeueAfter, time.Now())
 	}
+	return result, err
+}
+
+// reconcileResources reconciles all resources managed for the SpireAgent
+func (r *SpireAgentReconciler) reconcileResources(ctx context.Context, agent *v1alpha2.SpireAgent, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, createOnlyMode bool) error {
+	var configHash, bootstrapTokenHash string
+	var steps []pipeline.Step
+	// The static resources are shared by the agent pools and managed through the default pool
+	if isDefaultPool(agent) {
+		steps = append(steps,
+			// Reconcile static resources (RBAC, ServiceAccount, Service)
+			pipeline.Step{Resource: "ServiceAccount", Run: func() error {
+				return r.reconcileServiceAccount(ctx, agent, statusMgr, createOnlyMode)
+			}},
+			pipeline.Step{Resource: "Service", Run: func() error {
+				return r.reconcileService(ctx, agent, statusMgr, createOnlyMode)
+			}},
+			pipeline.Step{Resource: "RBAC", Run: func() error {
+				return r.reconcileRBAC(ctx, agent, statusMgr, createOnlyMode)
+			}},
+			// Expose the metrics of the statsd telemetry bridges if enabled
+			pipeline.Step{Resource: "TelemetryBridge", Run: func() error {
+				return r.reconcileTelemetryBridge(ctx, agent, statusMgr, createOnlyMode)
+			}},
+			// Reconcile SCC
+			pipeline.Step{Resource: "SCC", Run: func() error {
+				return r.reconcileSCC(ctx, agent, statusMgr)
+			}},
+		)
+	}
+	steps = append(steps,
+		// Reconcile ConfigMap
+		pipeline.Step{Resource: "ConfigMap", Run: func() (err error) {
+			configHash, err = r.reconcileConfigMap(ctx, agent, statusMgr, ztwim, createOnlyMode)
+			return err
+		}},
+		// Resolve the bootstrap token used for join token attestation
+		pipeline.Step{Resource: "BootstrapToken", Run: func() (err error) {
+			bootstrapTokenHash, err = r.getBootstrapTokenHash(ctx, agent, statusMgr)
+			return err
+		}},
+		// Reconcile DaemonSet, rolled from the hashes of the ConfigMap and the bootstrap token
+		pipeline.Step{Resource: "DaemonSet", After: []string{"ConfigMap", "BootstrapToken"}, Run: func() error {
+			return r.reconcileDaemonSet(ctx, agent, statusMgr, ztwim, createOnlyMode, configHash, bootstrapTokenHash)
+		}},
+	)
+	// Reconcile the Workload API health probe, once the agents it checks are in place
+	if isDefaultPool(agent) {
+		steps = append(steps, pipeline.Step{Resource: "HealthProbe", After: []string{"DaemonSet"}, Run: func() error {
+			return r.reconcileHealthProbe(ctx, agent, statusMgr, createOnlyMode)
+		}})
+	}
+	err := pipeline.Run(steps...)
+
+	// Check the agents attest with a token the server accepts
+	r.reportPSATConsistency(ctx, agent, statusMgr)
+
+	// Check the clocks of the agent nodes agree with the server and summarize the attested
+	// agents, across all the agent pools
+	if isDefaultPool(agent) {
+		r.reconcileClockSkew(ctx, agent, statusMgr)
+		r.reconcileAttestedNodes(ctx, agent, statusMgr)
+		// Audit the hostPath mounts of the agent socket directories, if enabled
+		r.reconcileSocketExposure(ctx, agent, statusMgr)
+	}
+
+	return err
+}
+
+// validateAvailability checks the availability settings of the agent against the agent SVID
+// lifetime of the SpireServer, which is skipped until the SpireServer exists
+func (r *SpireAgentReconciler) validateAvailability(ctx context.Context, agent *v1alpha2.SpireAgent, statusMgr *status.Manager) error {
+	if agent.Spec.Availability == nil {
+		return nil
+	}
+	var serverSpec *v1alpha2.SpireServerSpec
+	var server v1alpha2.SpireServer
+	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: "cluster"}, &server); err == nil {
+		serverSpec = &server.Spec
+	} else if !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get SpireServer to validate the availability target: %w", err)
+	}
+	if err := utils.ValidateAgentAvailability(serverSpec, &agent.Spec); err != nil {
+		r.log.Error(err, "invalid availability configuration")
+		statusMgr.AddCondition(ConfigurationValid, "InvalidAvailability", err.Error(), metav1.ConditionFalse)
+		return err
+	}
+	return nil
+}
 
-	return ctrl.Result{}, nil
+// reportPSATConsistency sets PSATAttestationConsistent from the PSAT configuration of the
+// SpireAgent and the SpireServer, and from the token projected into the deployed agent pods,
+// reported in the status. It is not reported until the SpireServer exists.
+func (r *SpireAgentReconciler) reportPSATConsistency(ctx context.Context, agent *v1alpha2.SpireAgent, statusMgr *status.Manager) {
+	var server v1alpha2.SpireServer
+	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: "cluster"}, &server); err != nil {
+		if !kerrors.IsNotFound(err) {
+			r.log.Error(err, "failed to get SpireServer for the PSAT consistency check")
+		}
+		return
+	}
+	reason, message, consistent := utils.CheckPSATConsistency(&server.Spec, &agent.Spec)
+	if consistent {
+		var ds appsv1.DaemonSet
+		err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: agentPoolResourceName(agent.Name), Namespace: utils.GetOperatorNamespace()}, &ds)
+		if err == nil {
+			agent.Status.ProjectedToken = utils.ProjectedTokenFromPodSpec(&ds.Spec.Template.Spec, utils.GetOperatorNamespace()+":"+ds.Spec.Template.Spec.ServiceAccountName, psatTokenPath)
+			reason, message, consistent = utils.CheckPSATProjection(&server.Spec, &agent.Spec, agent.Status.ProjectedToken)
+		} else if !kerrors.IsNotFound(err) {
+			r.log.Error(err, "failed to get the agent DaemonSet for the PSAT consistency check")
+		}
+	}
+	conditionStatus := metav1.ConditionTrue
+	if !consistent {
+		conditionStatus = metav1.ConditionFalse
+	}
+	statusMgr.AddCondition(utils.PSATAttestationConsistentStatusType, reason, message, conditionStatus)
 }
 
-func (r *SpireAgentReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	// Always enqueue the "cluster" CR for reconciliation
-	mapFunc := func(ctx context.Context, _ client.Object) []reconcile.Request {
+func (r *SpireAgentReconciler) SetupWithManager(mgr ctrl.Manager, dependencyCache cache.Cache) error {
+	// Enqueue the agent pool of the resource, the "cluster" CR for the resources shared by the pools
+	mapFunc := func(ctx context.Context, obj client.Object) []reconcile.Request {
+		name := utils.DefaultAgentPool
+		if pool := obj.GetLabels()[utils.AgentPoolLabel]; pool != "" {
+			name = pool
+		}
 		return []reconcile.Request{
 			{
 				NamespacedName: types.NamespacedName{
-					Name: "cluster",
+					Name: name,
 				},
 			},
 		}
 	}
 
+	// Enqueue every agent pool
+	poolsMapFunc := func(ctx context.Context, _ client.Object) []reconcile.Request {
+		return r.agentPoolRequests(ctx)
+	}
+
 	// Use component-specific predicate to only reconcile for node-agent component resources
 	controllerManagedResourcePredicates := builder.WithPredicates(utils.ControllerManagedResourcesForComponent(utils.ComponentNodeAgent))
 
-	err := ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.SpireAgent{}, builder.WithPredicates(utils.GenerationOrOwnerReferenceChangedPredicate)).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha2.SpireAgent{}, builder.WithPredicates(predicate.Or(utils.GenerationOrOwnerReferenceChangedPredicate, predicate.AnnotationChangedPredicate{}))).
 		Named(utils.ZeroTrustWorkloadIdentityManagerSpireAgentControllerName).
 		Watches(&appsv1.DaemonSet{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
 		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
@@ -190,17 +403,57 @@ func (r *SpireAgentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&corev1.Service{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
 		Watches(&rbacv1.ClusterRole{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
 		Watches(&rbacv1.ClusterRoleBinding{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
-		Watches(&securityv1.SecurityContextConstraints{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
-		Watches(&v1alpha1.ZeroTrustWorkloadIdentityManager{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(utils.ZTWIMSpecChangedPredicate)).
-		Complete(r)
+		Watches(&spiffev1alpha1.ClusterSPIFFEID{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
+		// Re-evaluate deferred upgrades once the SPIRE server finishes rolling out
+		Watches(&appsv1.StatefulSet{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(utils.ControllerManagedResourcesForComponent(utils.ComponentControlPlane))).
+		// The sizing and topology profiles apply to every pool
+		Watches(&v1alpha2.ZeroTrustWorkloadIdentityManager{}, handler.EnqueueRequestsFromMapFunc(poolsMapFunc), builder.WithPredicates(utils.ZTWIMSpecChangedPredicate)).
+		// The PSAT configuration of the agents is checked against the server, and the creation of
+		// the agents waits for the server to be Ready
+		Watches(&v1alpha2.SpireServer{}, handler.EnqueueRequestsFromMapFunc(poolsMapFunc), builder.WithPredicates(predicate.Or[client.Object](predicate.GenerationChangedPredicate{}, utils.ReadyConditionChangedPredicate))).
+		// The pools are validated against each other, and the default pool excludes their nodes
+		Watches(&v1alpha2.SpireAgent{}, handler.EnqueueRequestsFromMapFunc(poolsMapFunc), builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	if utils.HasCapability(utils.CapabilitySecurityContextConstraints) {
+		controllerBuilder = controllerBuilder.Watches(&securityv1.SecurityContextConstraints{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates)
+	}
+	if utils.HasCapability(utils.CapabilityInfrastructure) {
+		controllerBuilder = controllerBuilder.Watches(&configv1.Infrastructure{}, handler.EnqueueRequestsFromMapFunc(poolsMapFunc), builder.WithPredicates(topology.ChangedPredicate))
+	}
+
+	// Roll the agents of every pool when the trusted CA bundle or the objects mounted by their pods
+	// change, and rotate the bootstrap token with its Secret
+	err := dependencies.WatchFor(controllerBuilder, dependencyCache, func(ctx context.Context) []dependencies.Reference {
+		refs := dependencies.TrustedCABundle()
+		var agents v1alpha2.SpireAgentList
+		if err := r.ctrlClient.List(ctx, &agents); err == nil {
+			for _, agent := range agents.Items {
+				if agent.Spec.NodeAttestor != nil && agent.Spec.NodeAttestor.JoinToken != nil {
+					refs = append(refs, dependencies.Secret(agent.Spec.NodeAttestor.JoinToken.SecretName))
+				}
+				refs = append(refs, dependencies.FromWorkload(ctx, r.ctrlClient, &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: agentPoolResourceName(agent.Name), Namespace: utils.GetOperatorNamespace()}})...)
+			}
+		}
+		return refs
+	}, r.agentPoolRequests).Complete(r)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// requiredResources returns the resources the agents of the pool cannot run without
+func requiredResources(agent *v1alpha2.SpireAgent, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager) []client.Object {
+	namespace := utils.GetOperatorNamespace()
+	return []client.Object{
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "spire-agent", Namespace: namespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: agentPoolResourceName(agent.Name), Namespace: namespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: ztwim.Spec.BundleConfigMap, Namespace: namespace}},
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: agentPoolResourceName(agent.Name), Namespace: namespace}},
+	}
+}
+
 // handleCreateOnlyMode checks and updates the create-only mode status
-func (r *SpireAgentReconciler) handleCreateOnlyMode(agent *v1alpha1.SpireAgent, statusMgr *status.Manager) bool {
+func (r *SpireAgentReconciler) handleCreateOnlyMode(agent *v1alpha2.SpireAgent, statusMgr *status.Manager) bool {
 	createOnlyMode := utils.IsInCreateOnlyMode()
 	if createOnlyMode {
 		r.log.Info("Running in create-only mode - will create resources if they don't exist but skip updates")
@@ -219,12 +472,52 @@ func (r *SpireAgentReconciler) handleCreateOnlyMode(agent *v1alpha1.SpireAgent,
 }
 
 // validateConfiguration validates SpireAgent configuration including proxy settings
-func (r *SpireAgentReconciler) validateConfiguration(ctx context.Context, agent *v1alpha1.SpireAgent, statusMgr *status.Manager) error {
+func (r *SpireAgentReconciler) validateConfiguration(ctx context.Context, agent *v1alpha2.SpireAgent, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager) error {
 	// Validate proxy configuration - if proxy is enabled, CA bundle ConfigMap must be configured
 	if err := r.validateProxyConfiguration(statusMgr); err != nil {
 		return err
 	}
 
+	if err := utils.ValidateArchitectures(agent.Spec.Architectures, utils.GetSupportedArchitectures()); err != nil {
+		r.log.Error(err, "invalid architectures")
+		statusMgr.AddCondition(ConfigurationValid, utils.ArchitecturesReasonNoneSchedulable, err.Error(), metav1.ConditionFalse)
+		return err
+	}
+
+	if err := validateAdditionalAttestors(agent.Spec.WorkloadAttestors); err != nil {
+		r.log.Error(err, "invalid additional workload attestors")
+		statusMgr.AddCondition(ConfigurationValid, "InvalidAdditionalAttestors", err.Error(), metav1.ConditionFalse)
+		return err
+	}
+
+	if err := validateDelegatedIdentity(&agent.Spec, utils.TrustDomain(ztwim)); err != nil {
+		r.log.Error(err, "invalid delegated identity configuration")
+		statusMgr.AddCondition(ConfigurationValid, "InvalidDelegatedIdentity", err.Error(), metav1.ConditionFalse)
+		return err
+	}
+
+	if err := r.validateAvailability(ctx, agent, statusMgr); err != nil {
+		return err
+	}
+
+	if err := utils.ValidateExperimentalFlags(agent.Spec.ExperimentalFlags, utils.SpireAgentExperimentalFlags); err != nil {
+		r.log.Error(err, "invalid experimental flags")
+		statusMgr.AddCondition(ConfigurationValid, "InvalidExperimentalFlags", err.Error(), metav1.ConditionFalse)
+		return err
+	}
+
+	if err := r.validateAgentPools(ctx, agent, statusMgr); err != nil {
+		return err
+	}
+	statusMgr.ReportExperimentalFlags(agent.Spec.ExperimentalFlags, agent.Status.Conditions)
+
+	if err := utils.ValidateCommonConfigDNS(agent.Spec.DNSPolicy, agent.Spec.DNSConfig); err != nil {
+		r.log.Error(err, "invalid DNS configuration")
+		statusMgr.AddCondition(utils.ConditionTypeConfigurationValid, utils.ConditionReasonInvalidDNSConfig,
+			fmt.Sprintf("DNS configuration validation failed: %v", err), metav1.ConditionFalse)
+		return err
+	}
+
 	return utils.ValidateAndUpdateStatus(
 		r.log,
 		statusMgr,
@@ -243,7 +536,7 @@ func (r *SpireAgentReconciler) validateProxyConfiguration(statusMgr *status.Mana
 	result := utils.ValidateProxyConfiguration()
 
 	if !result.Valid {
-		r.log.Error(errors.New(result.Reason), result.Message)
+		r.log.Error(errors.New(result.Reason), "invalid proxy configuration", "message", result.Message)
 		statusMgr.AddCondition(ConfigurationValid, result.Reason, result.Message, metav1.ConditionFalse)
 		return fmt.Errorf("proxy configuration invalid: %s", result.Message)
 	}
@@ -255,5 +548,11 @@ func needsUpdate(current, desired appsv1.DaemonSet) bool {
 	if current.Spec.Template.Annotations[spireAgentDaemonSetSpireAgentConfigHashAnnotationKey] != desired.Spec.Template.Annotations[spireAgentDaemonSetSpireAgentConfigHashAnnotationKey] {
 		return true
 	}
+	if current.Spec.Template.Annotations[spireAgentDaemonSetBootstrapTokenHashAnnotationKey] != desired.Spec.Template.Annotations[spireAgentDaemonSetBootstrapTokenHashAnnotationKey] {
+		return true
+	}
+	if current.Spec.Template.Annotations[utils.DependenciesHashAnnotationKey] != desired.Spec.Template.Annotations[utils.DependenciesHashAnnotationKey] {
+		return true
+	}
 	return utils.ResourceNeedsUpdate(&current, &desired)
 }
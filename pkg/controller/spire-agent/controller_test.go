@@ -3,20 +3,24 @@ package spire_agent
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/go-logr/logr"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -85,7 +89,7 @@ func TestReconcile_ZTWIMNotFound(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	spireAgent := &v1alpha1.SpireAgent{
+	spireAgent := &v1alpha2.SpireAgent{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 		},
@@ -96,7 +100,7 @@ func TestReconcile_ZTWIMNotFound(t *testing.T) {
 		callCount++
 		switch callCount {
 		case 1: // First call: Get SpireAgent
-			if sa, ok := obj.(*v1alpha1.SpireAgent); ok {
+			if sa, ok := obj.(*v1alpha2.SpireAgent); ok {
 				*sa = *spireAgent
 			}
 			return nil
@@ -124,7 +128,7 @@ func TestReconcile_ZTWIMGetError(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	spireAgent := &v1alpha1.SpireAgent{
+	spireAgent := &v1alpha2.SpireAgent{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 		},
@@ -136,7 +140,7 @@ func TestReconcile_ZTWIMGetError(t *testing.T) {
 		callCount++
 		switch callCount {
 		case 1: // First call: Get SpireAgent
-			if sa, ok := obj.(*v1alpha1.SpireAgent); ok {
+			if sa, ok := obj.(*v1alpha2.SpireAgent); ok {
 				*sa = *spireAgent
 			}
 			return nil
@@ -168,14 +172,14 @@ func TestReconcile_OwnerReferenceSetError(t *testing.T) {
 	reconciler := newTestReconciler(fakeClient)
 
 	// SpireAgent without owner reference (needs update)
-	spireAgent := &v1alpha1.SpireAgent{
+	spireAgent := &v1alpha2.SpireAgent{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 		},
 	}
 
 	// ZTWIM with proper metadata
-	ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
@@ -187,12 +191,12 @@ func TestReconcile_OwnerReferenceSetError(t *testing.T) {
 		callCount++
 		switch callCount {
 		case 1: // Get SpireAgent
-			if sa, ok := obj.(*v1alpha1.SpireAgent); ok {
+			if sa, ok := obj.(*v1alpha2.SpireAgent); ok {
 				*sa = *spireAgent
 			}
 			return nil
 		case 2: // Get ZTWIM
-			if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+			if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 				*z = *ztwim
 			}
 			return nil
@@ -224,18 +228,18 @@ func TestReconcile_OwnerReferenceUpdateError(t *testing.T) {
 
 	// Register types in scheme for SetControllerReference
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	reconciler.scheme = scheme
 
 	// SpireAgent without owner reference (needs update)
-	spireAgent := &v1alpha1.SpireAgent{
+	spireAgent := &v1alpha2.SpireAgent{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 		},
 	}
 
 	// ZTWIM with proper metadata
-	ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
@@ -247,12 +251,12 @@ func TestReconcile_OwnerReferenceUpdateError(t *testing.T) {
 		callCount++
 		switch callCount {
 		case 1: // Get SpireAgent
-			if sa, ok := obj.(*v1alpha1.SpireAgent); ok {
+			if sa, ok := obj.(*v1alpha2.SpireAgent); ok {
 				*sa = *spireAgent
 			}
 			return nil
 		case 2: // Get ZTWIM
-			if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+			if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 				*z = *ztwim
 			}
 			return nil
@@ -282,7 +286,7 @@ func TestHandleCreateOnlyMode_Enabled(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	agent := &v1alpha1.SpireAgent{
+	agent := &v1alpha2.SpireAgent{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
 	}
 
@@ -304,7 +308,7 @@ func TestHandleCreateOnlyMode_Disabled(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	agent := &v1alpha1.SpireAgent{
+	agent := &v1alpha2.SpireAgent{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
 	}
 
@@ -326,10 +330,10 @@ func TestHandleCreateOnlyMode_DisabledWithPreviouslyEnabled(t *testing.T) {
 	reconciler := newTestReconciler(fakeClient)
 
 	// Agent with existing CreateOnlyMode condition set to True
-	agent := &v1alpha1.SpireAgent{
+	agent := &v1alpha2.SpireAgent{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Status: v1alpha1.SpireAgentStatus{
-			ConditionalStatus: v1alpha1.ConditionalStatus{
+		Status: v1alpha2.SpireAgentStatus{
+			ConditionalStatus: v1alpha2.ConditionalStatus{
 				Conditions: []metav1.Condition{
 					{
 						Type:   "CreateOnlyMode",
@@ -462,13 +466,13 @@ func TestValidateConfiguration_ValidConfig(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	agent := &v1alpha1.SpireAgent{
+	agent := &v1alpha2.SpireAgent{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec:       v1alpha1.SpireAgentSpec{},
+		Spec:       v1alpha2.SpireAgentSpec{},
 	}
 
 	statusMgr := status.NewManager(fakeClient)
-	err := reconciler.validateConfiguration(context.Background(), agent, statusMgr)
+	err := reconciler.validateConfiguration(context.Background(), agent, statusMgr, &v1alpha2.ZeroTrustWorkloadIdentityManager{})
 
 	// With default/empty config, validation should pass
 	if err != nil {
@@ -481,10 +485,10 @@ func TestValidateConfiguration_InvalidAffinity(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	agent := &v1alpha1.SpireAgent{
+	agent := &v1alpha2.SpireAgent{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec: v1alpha1.SpireAgentSpec{
-			CommonConfig: v1alpha1.CommonConfig{
+		Spec: v1alpha2.SpireAgentSpec{
+			CommonConfig: v1alpha2.CommonConfig{
 				Affinity: &corev1.Affinity{
 					NodeAffinity: &corev1.NodeAffinity{
 						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
@@ -498,7 +502,7 @@ func TestValidateConfiguration_InvalidAffinity(t *testing.T) {
 	}
 
 	statusMgr := status.NewManager(fakeClient)
-	err := reconciler.validateConfiguration(context.Background(), agent, statusMgr)
+	err := reconciler.validateConfiguration(context.Background(), agent, statusMgr, &v1alpha2.ZeroTrustWorkloadIdentityManager{})
 
 	// Invalid affinity should return error
 	if err == nil {
@@ -533,7 +537,7 @@ func TestHandleCreateOnlyMode_NotSet(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	agent := &v1alpha1.SpireAgent{
+	agent := &v1alpha2.SpireAgent{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
 	}
 
@@ -552,7 +556,7 @@ func TestReconcile_FullFlow(t *testing.T) {
 
 	// Register types in scheme
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &SpireAgentReconciler{
 		ctrlClient:    fakeClient,
@@ -563,7 +567,7 @@ func TestReconcile_FullFlow(t *testing.T) {
 	}
 
 	// SpireAgent with owner reference already set
-	spireAgent := &v1alpha1.SpireAgent{
+	spireAgent := &v1alpha2.SpireAgent{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			OwnerReferences: []metav1.OwnerReference{
@@ -575,15 +579,15 @@ func TestReconcile_FullFlow(t *testing.T) {
 				},
 			},
 		},
-		Spec: v1alpha1.SpireAgentSpec{},
+		Spec: v1alpha2.SpireAgentSpec{},
 	}
 
-	ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
 		},
-		Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 			TrustDomain: "example.org",
 		},
 	}
@@ -592,10 +596,10 @@ func TestReconcile_FullFlow(t *testing.T) {
 	fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 		callCount++
 		switch v := obj.(type) {
-		case *v1alpha1.SpireAgent:
+		case *v1alpha2.SpireAgent:
 			*v = *spireAgent
 			return nil
-		case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+		case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 			*v = *ztwim
 			return nil
 		default:
@@ -621,6 +625,76 @@ func TestReconcile_FullFlow(t *testing.T) {
 	t.Log("Reconcile completed without panic")
 }
 
+// TestReconcile_DryRun tests that the dry-run annotation reports pending changes without applying them
+func TestReconcile_DryRun(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "zero-trust-workload-identity-manager")
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	reconciler := newReconcilerWithScheme(fakeClient)
+
+	spireAgent := &v1alpha2.SpireAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cluster",
+			Annotations: map[string]string{utils.DryRunAnnotation: "true"},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: "operator.openshift.io/v1alpha1",
+					Kind:       "ZeroTrustWorkloadIdentityManager",
+					Name:       "cluster",
+					UID:        "test-uid",
+					Controller: ptr.To(true),
+				},
+			},
+		},
+	}
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+		TypeMeta:   metav1.TypeMeta{Kind: "ZeroTrustWorkloadIdentityManager"},
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
+		Spec:       v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.org"},
+	}
+	fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+		switch v := obj.(type) {
+		case *v1alpha2.SpireAgent:
+			*v = *spireAgent
+			return nil
+		case *v1alpha2.ZeroTrustWorkloadIdentityManager:
+			*v = *ztwim
+			return nil
+		default:
+			return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+		}
+	}
+	// No SecurityContextConstraints of another install
+	fakeClient.GetUncachedReturns(kerrors.NewNotFound(schema.GroupResource{}, "spire-agent"))
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "cluster"}}
+	if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if fakeClient.CreateCallCount() != 0 || fakeClient.UpdateCallCount() != 0 {
+		t.Errorf("Expected no writes in dry-run mode, got %d creates and %d updates",
+			fakeClient.CreateCallCount(), fakeClient.UpdateCallCount())
+	}
+
+	callCount := fakeClient.StatusUpdateWithRetryCallCount()
+	if callCount == 0 {
+		t.Fatal("Expected status to be updated")
+	}
+	_, obj, _ := fakeClient.StatusUpdateWithRetryArgsForCall(callCount - 1)
+	agent := obj.(*v1alpha2.SpireAgent)
+	condition := apimeta.FindStatusCondition(agent.Status.Conditions, utils.DryRunStatusType)
+	if condition == nil || condition.Reason != utils.DryRunReasonPending {
+		t.Fatalf("Expected DryRun condition with reason %s, got %v", utils.DryRunReasonPending, condition)
+	}
+	if !strings.Contains(condition.Message, "create DaemonSet "+utils.GetOperatorNamespace()+"/spire-agent") {
+		t.Errorf("Expected pending DaemonSet creation in message, got %q", condition.Message)
+	}
+	ready := apimeta.FindStatusCondition(agent.Status.Conditions, v1alpha2.Ready)
+	if ready == nil || ready.Status != metav1.ConditionFalse || ready.Reason != v1alpha2.ReasonInProgress {
+		t.Errorf("Expected Ready to be Progressing while changes are pending, got %v", ready)
+	}
+}
+
 // TestSpireAgentReconciler_Fields tests SpireAgentReconciler struct fields
 func TestSpireAgentReconciler_Fields(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
@@ -699,7 +773,7 @@ func TestReconcile_ErrorScenarios(t *testing.T) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					callCount++
 					if callCount == 1 {
-						if sa, ok := obj.(*v1alpha1.SpireAgent); ok {
+						if sa, ok := obj.(*v1alpha2.SpireAgent); ok {
 							sa.Name = "cluster"
 						}
 						return nil
@@ -717,7 +791,7 @@ func TestReconcile_ErrorScenarios(t *testing.T) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					callCount++
 					if callCount == 1 {
-						if sa, ok := obj.(*v1alpha1.SpireAgent); ok {
+						if sa, ok := obj.(*v1alpha2.SpireAgent); ok {
 							sa.Name = "cluster"
 						}
 						return nil
@@ -736,12 +810,12 @@ func TestReconcile_ErrorScenarios(t *testing.T) {
 					callCount++
 					switch callCount {
 					case 1:
-						if sa, ok := obj.(*v1alpha1.SpireAgent); ok {
+						if sa, ok := obj.(*v1alpha2.SpireAgent); ok {
 							sa.Name = "cluster"
 						}
 						return nil
 					case 2:
-						if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+						if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 							z.Name = "cluster"
 							z.UID = "test-uid"
 						}
@@ -753,7 +827,7 @@ func TestReconcile_ErrorScenarios(t *testing.T) {
 			},
 			setupReconciler: func(r *SpireAgentReconciler) {
 				scheme := runtime.NewScheme()
-				_ = v1alpha1.AddToScheme(scheme)
+				_ = v1alpha2.AddToScheme(scheme)
 				r.scheme = scheme
 			},
 			expectError:   true,
@@ -879,7 +953,7 @@ func TestHandleCreateOnlyMode_AllScenarios(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			reconciler := newTestReconciler(fakeClient)
 
-			agent := &v1alpha1.SpireAgent{
+			agent := &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
 			}
 			if tt.existingCond != nil {
@@ -984,23 +1058,23 @@ func TestNeedsUpdate_AllScenarios(t *testing.T) {
 func TestValidateConfiguration_AllScenarios(t *testing.T) {
 	tests := []struct {
 		name        string
-		agent       *v1alpha1.SpireAgent
+		agent       *v1alpha2.SpireAgent
 		expectError bool
 	}{
 		{
 			name: "valid empty config",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec:       v1alpha1.SpireAgentSpec{},
+				Spec:       v1alpha2.SpireAgentSpec{},
 			},
 			expectError: false,
 		},
 		{
 			name: "invalid affinity with empty node selector terms",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec: v1alpha1.SpireAgentSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireAgentSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Affinity: &corev1.Affinity{
 							NodeAffinity: &corev1.NodeAffinity{
 								RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
@@ -1015,10 +1089,10 @@ func TestValidateConfiguration_AllScenarios(t *testing.T) {
 		},
 		{
 			name: "valid config with tolerations",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec: v1alpha1.SpireAgentSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireAgentSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Tolerations: []*corev1.Toleration{
 							{Key: "key1", Value: "value1", Effect: corev1.TaintEffectNoSchedule},
 						},
@@ -1029,10 +1103,10 @@ func TestValidateConfiguration_AllScenarios(t *testing.T) {
 		},
 		{
 			name: "valid config with node selector",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec: v1alpha1.SpireAgentSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireAgentSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						NodeSelector: map[string]string{"node-type": "worker"},
 					},
 				},
@@ -1047,7 +1121,7 @@ func TestValidateConfiguration_AllScenarios(t *testing.T) {
 			reconciler := newTestReconciler(fakeClient)
 			statusMgr := status.NewManager(fakeClient)
 
-			err := reconciler.validateConfiguration(context.Background(), tt.agent, statusMgr)
+			err := reconciler.validateConfiguration(context.Background(), tt.agent, statusMgr, &v1alpha2.ZeroTrustWorkloadIdentityManager{})
 
 			if tt.expectError && err == nil {
 				t.Fatal("Expected error but got nil")
@@ -1093,7 +1167,7 @@ func TestValidateConfiguration_ConditionUpdate(t *testing.T) {
 			reconciler := newTestReconciler(fakeClient)
 			statusMgr := status.NewManager(fakeClient)
 
-			agent := &v1alpha1.SpireAgent{
+			agent := &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
 			}
 
@@ -1107,7 +1181,7 @@ func TestValidateConfiguration_ConditionUpdate(t *testing.T) {
 				}
 			}
 
-			err := reconciler.validateConfiguration(context.Background(), agent, statusMgr)
+			err := reconciler.validateConfiguration(context.Background(), agent, statusMgr, &v1alpha2.ZeroTrustWorkloadIdentityManager{})
 			// validateConfiguration should succeed regardless of existing condition state
 			if err != nil {
 				t.Fatalf("Unexpected error: %v", err)
@@ -1205,7 +1279,7 @@ func TestReconcile_FullFlow_AllScenarios(t *testing.T) {
 
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 			_ = corev1.AddToScheme(scheme)
 			_ = appsv1.AddToScheme(scheme)
 
@@ -1217,7 +1291,7 @@ func TestReconcile_FullFlow_AllScenarios(t *testing.T) {
 				eventRecorder: record.NewFakeRecorder(100),
 			}
 
-			agent := &v1alpha1.SpireAgent{
+			agent := &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "cluster",
 					OwnerReferences: []metav1.OwnerReference{{
@@ -1227,15 +1301,15 @@ func TestReconcile_FullFlow_AllScenarios(t *testing.T) {
 						UID:        "test-uid",
 					}},
 				},
-				Spec: v1alpha1.SpireAgentSpec{},
+				Spec: v1alpha2.SpireAgentSpec{},
 			}
 
-			ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
+			ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "cluster",
 					UID:  "test-uid",
 				},
-				Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+				Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 					TrustDomain: "example.org",
 				},
 			}
@@ -1244,10 +1318,10 @@ func TestReconcile_FullFlow_AllScenarios(t *testing.T) {
 			fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 				getCallCount++
 				switch v := obj.(type) {
-				case *v1alpha1.SpireAgent:
+				case *v1alpha2.SpireAgent:
 					*v = *agent
 					return nil
-				case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+				case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 					*v = *ztwim
 					return nil
 				case *corev1.ConfigMap:
@@ -1344,7 +1418,7 @@ func TestReconcileConfigMap_AllScenarios(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 			_ = corev1.AddToScheme(scheme)
 
 			reconciler := &SpireAgentReconciler{
@@ -1355,17 +1429,17 @@ func TestReconcileConfigMap_AllScenarios(t *testing.T) {
 				eventRecorder: record.NewFakeRecorder(100),
 			}
 
-			agent := &v1alpha1.SpireAgent{
+			agent := &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "cluster",
 					UID:  "test-uid",
 				},
-				Spec: v1alpha1.SpireAgentSpec{},
+				Spec: v1alpha2.SpireAgentSpec{},
 			}
 
-			ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
+			ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+				Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 					TrustDomain: "example.org",
 				},
 			}
@@ -1460,7 +1534,7 @@ func TestReconcileDaemonSet_AllScenarios(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 			_ = appsv1.AddToScheme(scheme)
 
 			reconciler := &SpireAgentReconciler{
@@ -1471,17 +1545,17 @@ func TestReconcileDaemonSet_AllScenarios(t *testing.T) {
 				eventRecorder: record.NewFakeRecorder(100),
 			}
 
-			agent := &v1alpha1.SpireAgent{
+			agent := &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "cluster",
 					UID:  "test-uid",
 				},
-				Spec: v1alpha1.SpireAgentSpec{},
+				Spec: v1alpha2.SpireAgentSpec{},
 			}
 
-			ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
+			ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+				Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 					TrustDomain: "example.org",
 				},
 			}
@@ -1520,7 +1594,7 @@ func TestReconcileDaemonSet_AllScenarios(t *testing.T) {
 			}
 
 			statusMgr := status.NewManager(fakeClient)
-			err := reconciler.reconcileDaemonSet(context.Background(), agent, statusMgr, ztwim, tt.createOnlyMode, "test-hash")
+			err := reconciler.reconcileDaemonSet(context.Background(), agent, statusMgr, ztwim, tt.createOnlyMode, "test-hash", "")
 
 			if tt.expectError && err == nil {
 				t.Fatal("Expected error but got nil")
@@ -1561,7 +1635,7 @@ func TestReconcileRBAC_AllScenarios(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 
 			reconciler := &SpireAgentReconciler{
 				ctrlClient:    fakeClient,
@@ -1571,7 +1645,7 @@ func TestReconcileRBAC_AllScenarios(t *testing.T) {
 				eventRecorder: record.NewFakeRecorder(100),
 			}
 
-			agent := &v1alpha1.SpireAgent{
+			agent := &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "cluster",
 					UID:  "test-uid",
@@ -1616,7 +1690,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 					callCount++
 					switch callCount {
 					case 1:
-						if a, ok := obj.(*v1alpha1.SpireAgent); ok {
+						if a, ok := obj.(*v1alpha2.SpireAgent); ok {
 							a.Name = "cluster"
 							a.OwnerReferences = []metav1.OwnerReference{{
 								APIVersion: "operator.openshift.io/v1alpha1",
@@ -1627,7 +1701,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 						}
 						return nil
 					case 2:
-						if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+						if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 							z.Name = "cluster"
 							z.UID = "test-uid"
 							z.Spec.TrustDomain = "example.org"
@@ -1649,7 +1723,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 					callCount++
 					switch callCount {
 					case 1:
-						if a, ok := obj.(*v1alpha1.SpireAgent); ok {
+						if a, ok := obj.(*v1alpha2.SpireAgent); ok {
 							a.Name = "cluster"
 							a.OwnerReferences = []metav1.OwnerReference{{
 								APIVersion: "operator.openshift.io/v1alpha1",
@@ -1660,7 +1734,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 						}
 						return nil
 					case 2:
-						if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+						if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 							z.Name = "cluster"
 							z.UID = "test-uid"
 							z.Spec.TrustDomain = "example.org"
@@ -1684,7 +1758,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 					callCount++
 					switch callCount {
 					case 1:
-						if a, ok := obj.(*v1alpha1.SpireAgent); ok {
+						if a, ok := obj.(*v1alpha2.SpireAgent); ok {
 							a.Name = "cluster"
 							a.OwnerReferences = []metav1.OwnerReference{{
 								APIVersion: "operator.openshift.io/v1alpha1",
@@ -1695,7 +1769,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 						}
 						return nil
 					case 2:
-						if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+						if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 							z.Name = "cluster"
 							z.UID = "test-uid"
 							z.Spec.TrustDomain = "example.org"
@@ -1719,7 +1793,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 					callCount++
 					switch callCount {
 					case 1:
-						if a, ok := obj.(*v1alpha1.SpireAgent); ok {
+						if a, ok := obj.(*v1alpha2.SpireAgent); ok {
 							a.Name = "cluster"
 							a.OwnerReferences = []metav1.OwnerReference{{
 								APIVersion: "operator.openshift.io/v1alpha1",
@@ -1730,7 +1804,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 						}
 						return nil
 					case 2:
-						if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+						if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 							z.Name = "cluster"
 							z.UID = "test-uid"
 							z.Spec.TrustDomain = "example.org"
@@ -1752,7 +1826,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 
 			reconciler := &SpireAgentReconciler{
 				ctrlClient:    fakeClient,
@@ -1794,7 +1868,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 func TestReconcile_SuccessfulPath_NoRequeue(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 
 	reconciler := &SpireAgentReconciler{
@@ -1810,7 +1884,7 @@ func TestReconcile_SuccessfulPath_NoRequeue(t *testing.T) {
 		callCount++
 		switch callCount {
 		case 1: // SpireAgent
-			if a, ok := obj.(*v1alpha1.SpireAgent); ok {
+			if a, ok := obj.(*v1alpha2.SpireAgent); ok {
 				a.Name = "cluster"
 				a.UID = "test-uid"
 				a.OwnerReferences = []metav1.OwnerReference{{
@@ -1822,7 +1896,7 @@ func TestReconcile_SuccessfulPath_NoRequeue(t *testing.T) {
 			}
 			return nil
 		case 2: // ZTWIM
-			if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+			if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 				z.Name = "cluster"
 				z.UID = "ztwim-uid"
 				z.Spec.TrustDomain = "example.org"
@@ -1938,7 +2012,7 @@ func TestReconcile_ReconciliationStepErrors_MutationKillers(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 
 			reconciler := &SpireAgentReconciler{
 				ctrlClient:    fakeClient,
@@ -1953,7 +2027,7 @@ func TestReconcile_ReconciliationStepErrors_MutationKillers(t *testing.T) {
 				callCount++
 				switch callCount {
 				case 1:
-					if a, ok := obj.(*v1alpha1.SpireAgent); ok {
+					if a, ok := obj.(*v1alpha2.SpireAgent); ok {
 						a.Name = "cluster"
 						a.UID = "test-uid"
 						a.OwnerReferences = []metav1.OwnerReference{{
@@ -1965,14 +2039,14 @@ func TestReconcile_ReconciliationStepErrors_MutationKillers(t *testing.T) {
 					}
 					return nil
 				case 2:
-					if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+					if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 						z.Name = "cluster"
 						z.UID = "ztwim-uid"
 						z.Spec.TrustDomain = "example.org"
 					}
 					return nil
 				case 3:
-					if s, ok := obj.(*v1alpha1.SpireServer); ok {
+					if s, ok := obj.(*v1alpha2.SpireServer); ok {
 						s.Name = "cluster"
 					}
 					return nil
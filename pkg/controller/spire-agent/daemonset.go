@@ -3,6 +3,7 @@ package spire_agent
 import (
 	"context"
 	"fmt"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -14,14 +15,55 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/dependencies"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/maintenance"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/telemetry"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 )
 
+// psatTokenPath is the path of the service account token the agents attest with, in the
+// spire-token volume
+const psatTokenPath = "spire-agent"
+
 // reconcileDaemonSet reconciles the Spire Agent DaemonSet
-func (r *SpireAgentReconciler) reconcileDaemonSet(ctx context.Context, agent *v1alpha1.SpireAgent, statusMgr *status.Manager, ztwim *v1alpha1.ZeroTrustWorkloadIdentityManager, createOnlyMode bool, configHash string) error {
+func (r *SpireAgentReconciler) reconcileDaemonSet(ctx context.Context, agent *v1alpha2.SpireAgent, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, createOnlyMode bool, configHash, bootstrapTokenHash string) error {
 	spireAgentDaemonset := generateSpireAgentDaemonSet(agent.Spec, ztwim, configHash)
+	applyAgentPool(spireAgentDaemonset, agent.Name)
+	if err := dependencies.Annotate(ctx, r.ctrlClient, &spireAgentDaemonset.Spec.Template); err != nil {
+		r.log.Error(err, "failed to hash spire agent dependencies")
+		statusMgr.AddCondition(DaemonSetAvailable, "SpireAgentDaemonSetGenerationFailed",
+			err.Error(),
+			metav1.ConditionFalse)
+		return err
+	}
+	nodeArchitectures, err := utils.GetNodeArchitectures(ctx, r.ctrlClient)
+	if err != nil {
+		r.log.Error(err, "failed to get node architectures")
+		statusMgr.AddCondition(DaemonSetAvailable, "SpireAgentDaemonSetGenerationFailed",
+			err.Error(),
+			metav1.ConditionFalse)
+		return err
+	}
+	placement := utils.ResolveArchitectures(agent.Spec.Architectures, nodeArchitectures, utils.GetSupportedArchitectures())
+	statusMgr.ReportSkippedArchitectures(placement.Skipped, agent.Status.Conditions)
+	spireAgentDaemonset.Spec.Template.Spec.Affinity = utils.WithArchitectureAffinity(spireAgentDaemonset.Spec.Template.Spec.Affinity, placement.Allowed)
+	if isDefaultPool(agent) {
+		// Keep the default agents off the nodes of the other pools
+		pools, err := r.listAgentPools(ctx)
+		if err == nil {
+			spireAgentDaemonset.Spec.Template.Spec.Affinity, err = withPoolExclusion(spireAgentDaemonset.Spec.Template.Spec.Affinity, pools)
+		}
+		if err != nil {
+			r.log.Error(err, "failed to exclude the nodes of the agent pools")
+			statusMgr.AddCondition(DaemonSetAvailable, "SpireAgentDaemonSetGenerationFailed",
+				err.Error(),
+				metav1.ConditionFalse)
+			return err
+		}
+	}
 	if err := controllerutil.SetControllerReference(agent, spireAgentDaemonset, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference")
 		statusMgr.AddCondition(DaemonSetAvailable, "SpireAgentDaemonSetGenerationFailed",
@@ -31,19 +73,31 @@ func (r *SpireAgentReconciler) reconcileDaemonSet(ctx context.Context, agent *v1
 	}
 
 	var existingSpireAgentDaemonSet appsv1.DaemonSet
-	err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: spireAgentDaemonset.Name, Namespace: spireAgentDaemonset.Namespace}, &existingSpireAgentDaemonSet)
+	err = r.ctrlClient.Get(ctx, types.NamespacedName{Name: spireAgentDaemonset.Name, Namespace: spireAgentDaemonset.Namespace}, &existingSpireAgentDaemonSet)
+	var observedDaemonSet *appsv1.DaemonSet
+	if err == nil {
+		observedDaemonSet = &existingSpireAgentDaemonSet
+	}
+	now := time.Now()
+	setBootstrapTokenAnnotations(spireAgentDaemonset, observedDaemonSet, bootstrapTokenHash, now)
 	if err != nil && kerrors.IsNotFound(err) {
-		if err = r.ctrlClient.Create(ctx, spireAgentDaemonset); err != nil {
+		if err = r.ctrlClient.Create(ctx, spireAgentDaemonset, customClient.AdoptExisting(ptr.Deref(agent.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create spire-agent daemonset")
 			statusMgr.AddCondition(DaemonSetAvailable, "SpireAgentDaemonSetCreationFailed",
 				err.Error(),
 				metav1.ConditionFalse)
 			return fmt.Errorf("failed to create DaemonSet: %w", err)
 		}
-		r.log.Info("Created spire agent DaemonSet")
+		r.log.Info("Created spire agent DaemonSet", "name", spireAgentDaemonset.Name)
 	} else if err == nil && needsUpdate(existingSpireAgentDaemonSet, *spireAgentDaemonset) {
+		versionChange := status.IsOperandVersionChange(existingSpireAgentDaemonSet.Labels, spireAgentDaemonset.Labels,
+			&existingSpireAgentDaemonSet.Spec.Template.Spec, &spireAgentDaemonset.Spec.Template.Spec, "spire-agent")
 		if createOnlyMode {
 			r.log.Info("Skipping DaemonSet update due to create-only mode")
+		} else if !statusMgr.CheckUpgradeOrder(ctx, utils.ResourceKindSpireAgent, agent.Status.ConditionalStatus.Conditions, versionChange) {
+			r.log.Info("Deferring spire agent DaemonSet upgrade until prerequisite operands are upgraded")
+		} else if !maintenance.Allows(statusMgr, ztwim.Spec.MaintenanceWindows, "DaemonSet "+spireAgentDaemonset.Name, agent.Status.Conditions, now) {
+			r.log.Info("Deferring spire agent DaemonSet update until the next maintenance window")
 		} else {
 			spireAgentDaemonset.ResourceVersion = existingSpireAgentDaemonSet.ResourceVersion
 			if err = r.ctrlClient.Update(ctx, spireAgentDaemonset); err != nil {
@@ -53,7 +107,7 @@ func (r *SpireAgentReconciler) reconcileDaemonSet(ctx context.Context, agent *v1
 					metav1.ConditionFalse)
 				return fmt.Errorf("failed to update DaemonSet: %w", err)
 			}
-			r.log.Info("Updated spire agent DaemonSet")
+			r.log.Info("Updated spire agent DaemonSet", "name", spireAgentDaemonset.Name)
 		}
 	} else if err != nil {
 		r.log.Error(err, "failed to get spire-agent daemonset")
@@ -61,15 +115,18 @@ func (r *SpireAgentReconciler) reconcileDaemonSet(ctx context.Context, agent *v1
 			err.Error(),
 			metav1.ConditionFalse)
 		return err
+	} else {
+		maintenance.Settle(statusMgr, agent.Status.Conditions)
 	}
 
 	// Check DaemonSet health/readiness
 	statusMgr.CheckDaemonSetHealth(ctx, spireAgentDaemonset.Name, spireAgentDaemonset.Namespace, DaemonSetAvailable)
+	reportBootstrapTokenStatus(agent, statusMgr, spireAgentDaemonset, observedDaemonSet, now)
 
 	return nil
 }
 
-func generateSpireAgentDaemonSet(config v1alpha1.SpireAgentSpec, ztwim *v1alpha1.ZeroTrustWorkloadIdentityManager, spireAgentConfigHash string) *appsv1.DaemonSet {
+func generateSpireAgentDaemonSet(config v1alpha2.SpireAgentSpec, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, spireAgentConfigHash string) *appsv1.DaemonSet {
 
 	// Generate standardized labels once and reuse them
 	labels := utils.SpireAgentLabels(config.Labels)
@@ -82,7 +139,7 @@ func generateSpireAgentDaemonSet(config v1alpha1.SpireAgentSpec, ztwim *v1alpha1
 	}
 
 	volumeMounts := []corev1.VolumeMount{
-		{Name: "spire-config", MountPath: "/opt/spire/conf/agent", ReadOnly: true},
+		{Name: "spire-config", MountPath: spireAgentConfigMountPath, ReadOnly: true},
 		{Name: "spire-agent-persistence", MountPath: "/var/lib/spire"},
 		{Name: "spire-bundle", MountPath: "/run/spire/bundle", ReadOnly: true},
 		{Name: "spire-agent-socket-dir", MountPath: "/tmp/spire-agent/public"},
@@ -111,9 +168,9 @@ func generateSpireAgentDaemonSet(config v1alpha1.SpireAgentSpec, ztwim *v1alpha1
 					Sources: []corev1.VolumeProjection{
 						{
 							ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
-								Path:              "spire-agent",
-								ExpirationSeconds: ptr.To(int64(7200)),
-								Audience:          "spire-server",
+								Path:              psatTokenPath,
+								ExpirationSeconds: ptr.To(utils.PSATAgentTokenExpirationSeconds(&config)),
+								Audience:          utils.PSATAgentAudience(&config),
 							},
 						},
 					},
@@ -131,6 +188,24 @@ func generateSpireAgentDaemonSet(config v1alpha1.SpireAgentSpec, ztwim *v1alpha1
 		},
 	}
 
+	// Expose the admin socket serving the Delegated Identity API on the host
+	if delegatedIdentityEnabled(&config) {
+		for i := range volumes {
+			if volumes[i].Name == "spire-agent-admin-socket-dir" {
+				volumes[i].VolumeSource = corev1.VolumeSource{
+					HostPath: &corev1.HostPathVolumeSource{
+						Path: config.DelegatedIdentity.AdminSocketPath,
+						Type: hostPathTypePtr(corev1.HostPathDirectoryOrCreate),
+					},
+				}
+			}
+		}
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "spire-agent-admin-socket-dir",
+			MountPath: spireAgentAdminSocketDir,
+		})
+	}
+
 	// Conditionally add kubelet CA hostPath mount for hostCert verification mode
 	if hostCertPath := getHostCertMountPath(config.WorkloadAttestors); hostCertPath != "" {
 		volumes = append(volumes, corev1.Volume{
@@ -149,6 +224,23 @@ func generateSpireAgentDaemonSet(config v1alpha1.SpireAgentSpec, ztwim *v1alpha1
 		})
 	}
 
+	// Conditionally add the host D-Bus socket for the systemd workload attestor
+	if hasAdditionalAttestor(config.WorkloadAttestors, systemdAttestorName) {
+		volumes = append(volumes, corev1.Volume{
+			Name: "dbus-socket",
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: dbusSystemBusSocket,
+					Type: hostPathTypePtr(corev1.HostPathSocket),
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "dbus-socket",
+			MountPath: dbusSystemBusSocket,
+		})
+	}
+
 	ds := &appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "spire-agent",
@@ -176,7 +268,9 @@ func generateSpireAgentDaemonSet(config v1alpha1.SpireAgentSpec, ztwim *v1alpha1
 				Spec: corev1.PodSpec{
 					HostPID:            true,
 					HostNetwork:        false,
-					DNSPolicy:          corev1.DNSClusterFirst,
+					DNSPolicy:          utils.DerefDNSPolicy(config.DNSPolicy),
+					DNSConfig:          config.DNSConfig.DeepCopy(),
+					ImagePullSecrets:   config.ImagePullSecrets,
 					ServiceAccountName: "spire-agent",
 					Containers: []corev1.Container{
 						{
@@ -239,19 +333,42 @@ func generateSpireAgentDaemonSet(config v1alpha1.SpireAgentSpec, ztwim *v1alpha1
 		},
 	}
 
+	// Bootstrap with the join token from the Secret; $(JOIN_TOKEN) is expanded by the kubelet
+	if config.NodeAttestor != nil && config.NodeAttestor.JoinToken != nil {
+		container := &ds.Spec.Template.Spec.Containers[0]
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name: joinTokenEnvName,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: config.NodeAttestor.JoinToken.SecretName},
+					Key:                  joinTokenSecretKey,
+				},
+			},
+		})
+		container.Args = append(container.Args, "-joinToken", fmt.Sprintf("$(%s)", joinTokenEnvName))
+	}
+
+	// Label the admin socket so the delegates of other namespaces may connect to it
+	if delegatedIdentityEnabled(&config) {
+		ds.Spec.Template.Spec.Containers[0].SecurityContext.SELinuxOptions = &corev1.SELinuxOptions{Type: delegatedIdentitySELinuxType}
+	}
+
 	// Add proxy configuration with internal services added to NO_PROXY.
 	// spire-agent primarily communicates with internal services (spire-server, K8s API),
 	// but may need proxy for external access in some configurations (e.g., cloud attestation).
 	// The internal service names are added to NO_PROXY to ensure internal traffic bypasses the proxy.
 	utils.AddProxyConfigToPodWithInternalNoProxy(&ds.Spec.Template.Spec)
 
+	// Add the statsd telemetry bridge if enabled
+	telemetry.AddBridge(&ds.Spec.Template.Spec, config.Telemetry)
+
 	return ds
 }
 
 // getHostCertMountPath returns the host path to mount for kubelet CA verification.
 // Returns empty string if no host mount is needed (skip mode).
 // For auto mode without explicit paths, returns the OpenShift default path.
-func getHostCertMountPath(workloadAttestors *v1alpha1.WorkloadAttestors) string {
+func getHostCertMountPath(workloadAttestors *v1alpha2.WorkloadAttestors) string {
 	if workloadAttestors == nil || workloadAttestors.WorkloadAttestorsVerification == nil {
 		return ""
 	}
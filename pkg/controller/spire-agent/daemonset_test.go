@@ -3,17 +3,19 @@ package spire_agent
 import (
 	"testing"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 )
 
 func TestGetHostCertMountPath(t *testing.T) {
 	tests := []struct {
 		name              string
-		workloadAttestors *v1alpha1.WorkloadAttestors
+		workloadAttestors *v1alpha2.WorkloadAttestors
 		expected          string
 	}{
 		{
@@ -23,17 +25,17 @@ func TestGetHostCertMountPath(t *testing.T) {
 		},
 		{
 			name: "nil verification",
-			workloadAttestors: &v1alpha1.WorkloadAttestors{
-				K8sEnabled:                    "true",
+			workloadAttestors: &v1alpha2.WorkloadAttestors{
+				K8sEnabled:                    ptr.To(true),
 				WorkloadAttestorsVerification: nil,
 			},
 			expected: "",
 		},
 		{
 			name: "skip type - no mount needed",
-			workloadAttestors: &v1alpha1.WorkloadAttestors{
-				K8sEnabled: "true",
-				WorkloadAttestorsVerification: &v1alpha1.WorkloadAttestorsVerification{
+			workloadAttestors: &v1alpha2.WorkloadAttestors{
+				K8sEnabled: ptr.To(true),
+				WorkloadAttestorsVerification: &v1alpha2.WorkloadAttestorsVerification{
 					Type: utils.WorkloadAttestorVerificationTypeSkip,
 				},
 			},
@@ -41,9 +43,9 @@ func TestGetHostCertMountPath(t *testing.T) {
 		},
 		{
 			name: "hostCert type with both paths - mount needed",
-			workloadAttestors: &v1alpha1.WorkloadAttestors{
-				K8sEnabled: "true",
-				WorkloadAttestorsVerification: &v1alpha1.WorkloadAttestorsVerification{
+			workloadAttestors: &v1alpha2.WorkloadAttestors{
+				K8sEnabled: ptr.To(true),
+				WorkloadAttestorsVerification: &v1alpha2.WorkloadAttestorsVerification{
 					Type:             utils.WorkloadAttestorVerificationTypeHostCert,
 					HostCertBasePath: "/etc/kubernetes",
 					HostCertFileName: "kubelet-ca.crt",
@@ -53,9 +55,9 @@ func TestGetHostCertMountPath(t *testing.T) {
 		},
 		{
 			name: "hostCert type with only basePath - uses basePath (CEL would block this)",
-			workloadAttestors: &v1alpha1.WorkloadAttestors{
-				K8sEnabled: "true",
-				WorkloadAttestorsVerification: &v1alpha1.WorkloadAttestorsVerification{
+			workloadAttestors: &v1alpha2.WorkloadAttestors{
+				K8sEnabled: ptr.To(true),
+				WorkloadAttestorsVerification: &v1alpha2.WorkloadAttestorsVerification{
 					Type:             utils.WorkloadAttestorVerificationTypeHostCert,
 					HostCertBasePath: "/etc/kubernetes",
 				},
@@ -64,9 +66,9 @@ func TestGetHostCertMountPath(t *testing.T) {
 		},
 		{
 			name: "hostCert type with only fileName - uses empty basePath (CEL would block this)",
-			workloadAttestors: &v1alpha1.WorkloadAttestors{
-				K8sEnabled: "true",
-				WorkloadAttestorsVerification: &v1alpha1.WorkloadAttestorsVerification{
+			workloadAttestors: &v1alpha2.WorkloadAttestors{
+				K8sEnabled: ptr.To(true),
+				WorkloadAttestorsVerification: &v1alpha2.WorkloadAttestorsVerification{
 					Type:             utils.WorkloadAttestorVerificationTypeHostCert,
 					HostCertFileName: "kubelet-ca.crt",
 				},
@@ -75,9 +77,9 @@ func TestGetHostCertMountPath(t *testing.T) {
 		},
 		{
 			name: "auto type without paths - uses OpenShift defaults",
-			workloadAttestors: &v1alpha1.WorkloadAttestors{
-				K8sEnabled: "true",
-				WorkloadAttestorsVerification: &v1alpha1.WorkloadAttestorsVerification{
+			workloadAttestors: &v1alpha2.WorkloadAttestors{
+				K8sEnabled: ptr.To(true),
+				WorkloadAttestorsVerification: &v1alpha2.WorkloadAttestorsVerification{
 					Type: utils.WorkloadAttestorVerificationTypeAuto,
 				},
 			},
@@ -85,9 +87,9 @@ func TestGetHostCertMountPath(t *testing.T) {
 		},
 		{
 			name: "auto type with both paths - mount specified path",
-			workloadAttestors: &v1alpha1.WorkloadAttestors{
-				K8sEnabled: "true",
-				WorkloadAttestorsVerification: &v1alpha1.WorkloadAttestorsVerification{
+			workloadAttestors: &v1alpha2.WorkloadAttestors{
+				K8sEnabled: ptr.To(true),
+				WorkloadAttestorsVerification: &v1alpha2.WorkloadAttestorsVerification{
 					Type:             utils.WorkloadAttestorVerificationTypeAuto,
 					HostCertBasePath: "/custom/path",
 					HostCertFileName: "custom-ca.crt",
@@ -97,9 +99,9 @@ func TestGetHostCertMountPath(t *testing.T) {
 		},
 		{
 			name: "auto type with only basePath - uses OpenShift defaults",
-			workloadAttestors: &v1alpha1.WorkloadAttestors{
-				K8sEnabled: "true",
-				WorkloadAttestorsVerification: &v1alpha1.WorkloadAttestorsVerification{
+			workloadAttestors: &v1alpha2.WorkloadAttestors{
+				K8sEnabled: ptr.To(true),
+				WorkloadAttestorsVerification: &v1alpha2.WorkloadAttestorsVerification{
 					Type:             utils.WorkloadAttestorVerificationTypeAuto,
 					HostCertBasePath: "/etc/kubernetes",
 				},
@@ -108,9 +110,9 @@ func TestGetHostCertMountPath(t *testing.T) {
 		},
 		{
 			name: "unknown type - no mount",
-			workloadAttestors: &v1alpha1.WorkloadAttestors{
-				K8sEnabled: "true",
-				WorkloadAttestorsVerification: &v1alpha1.WorkloadAttestorsVerification{
+			workloadAttestors: &v1alpha2.WorkloadAttestors{
+				K8sEnabled: ptr.To(true),
+				WorkloadAttestorsVerification: &v1alpha2.WorkloadAttestorsVerification{
 					Type:             "unknown",
 					HostCertBasePath: "/etc/kubernetes",
 					HostCertFileName: "kubelet-ca.crt",
@@ -120,9 +122,9 @@ func TestGetHostCertMountPath(t *testing.T) {
 		},
 		{
 			name: "empty type - no mount",
-			workloadAttestors: &v1alpha1.WorkloadAttestors{
-				K8sEnabled: "true",
-				WorkloadAttestorsVerification: &v1alpha1.WorkloadAttestorsVerification{
+			workloadAttestors: &v1alpha2.WorkloadAttestors{
+				K8sEnabled: ptr.To(true),
+				WorkloadAttestorsVerification: &v1alpha2.WorkloadAttestorsVerification{
 					Type:             "",
 					HostCertBasePath: "/etc/kubernetes",
 					HostCertFileName: "kubelet-ca.crt",
@@ -132,9 +134,9 @@ func TestGetHostCertMountPath(t *testing.T) {
 		},
 		{
 			name: "custom path with hostCert",
-			workloadAttestors: &v1alpha1.WorkloadAttestors{
-				K8sEnabled: "true",
-				WorkloadAttestorsVerification: &v1alpha1.WorkloadAttestorsVerification{
+			workloadAttestors: &v1alpha2.WorkloadAttestors{
+				K8sEnabled: ptr.To(true),
+				WorkloadAttestorsVerification: &v1alpha2.WorkloadAttestorsVerification{
 					Type:             utils.WorkloadAttestorVerificationTypeHostCert,
 					HostCertBasePath: "/var/lib/kubelet/pki",
 					HostCertFileName: "ca.crt",
@@ -177,15 +179,15 @@ func assertSpireAgentContainerHardening(t *testing.T, c *corev1.Container) {
 }
 
 func TestGenerateSpireAgentDaemonSet_SCCSecurityHardening(t *testing.T) {
-	ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
-		Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 			TrustDomain:     "example.org",
 			BundleConfigMap: "spire-bundle",
 		},
 	}
 
 	t.Run("pod boundary matches spire-agent SCC (host PID, no host network/IPC/ports in SCC)", func(t *testing.T) {
-		spec := v1alpha1.SpireAgentSpec{
+		spec := v1alpha2.SpireAgentSpec{
 			SocketPath: "/tmp/spire-agent/public",
 		}
 		ds := generateSpireAgentDaemonSet(spec, ztwim, "test-config-hash")
@@ -200,11 +202,11 @@ func TestGenerateSpireAgentDaemonSet_SCCSecurityHardening(t *testing.T) {
 	})
 
 	t.Run("security context unchanged when kubelet CA hostPath is present", func(t *testing.T) {
-		spec := v1alpha1.SpireAgentSpec{
+		spec := v1alpha2.SpireAgentSpec{
 			SocketPath: "/tmp/spire-agent/public",
-			WorkloadAttestors: &v1alpha1.WorkloadAttestors{
-				K8sEnabled: "true",
-				WorkloadAttestorsVerification: &v1alpha1.WorkloadAttestorsVerification{
+			WorkloadAttestors: &v1alpha2.WorkloadAttestors{
+				K8sEnabled: ptr.To(true),
+				WorkloadAttestorsVerification: &v1alpha2.WorkloadAttestorsVerification{
 					Type: utils.WorkloadAttestorVerificationTypeAuto,
 				},
 			},
@@ -222,3 +224,47 @@ func TestGenerateSpireAgentDaemonSet_SCCSecurityHardening(t *testing.T) {
 		assertSpireAgentContainerHardening(t, &ds.Spec.Template.Spec.Containers[0])
 	})
 }
+
+func TestGenerateSpireAgentDaemonSet_PSATAudience(t *testing.T) {
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.org"},
+	}
+	tokenAudience := func(ds *appsv1.DaemonSet) string {
+		for _, v := range ds.Spec.Template.Spec.Volumes {
+			if v.Projected == nil {
+				continue
+			}
+			for _, source := range v.Projected.Sources {
+				if source.ServiceAccountToken != nil {
+					return source.ServiceAccountToken.Audience
+				}
+			}
+		}
+		return ""
+	}
+
+	ds := generateSpireAgentDaemonSet(v1alpha2.SpireAgentSpec{}, ztwim, "hash")
+	assert.Equal(t, utils.DefaultPSATAudience, tokenAudience(ds))
+
+	spec := v1alpha2.SpireAgentSpec{NodeAttestor: &v1alpha2.NodeAttestor{K8sPSATEnabled: ptr.To(true), PSATAudience: "spire-edge"}}
+	ds = generateSpireAgentDaemonSet(spec, ztwim, "hash")
+	assert.Equal(t, "spire-edge", tokenAudience(ds))
+}
+
+func TestGenerateSpireAgentDaemonSet_PSATTokenExpiration(t *testing.T) {
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.org"},
+	}
+
+	ds := generateSpireAgentDaemonSet(v1alpha2.SpireAgentSpec{}, ztwim, "hash")
+	token := utils.ProjectedTokenFromPodSpec(&ds.Spec.Template.Spec, "ns:spire-agent", psatTokenPath)
+	require.NotNil(t, token)
+	assert.Equal(t, int64(utils.DefaultPSATTokenExpirationSeconds), token.ExpirationSeconds)
+
+	spec := v1alpha2.SpireAgentSpec{NodeAttestor: &v1alpha2.NodeAttestor{K8sPSATEnabled: ptr.To(true), PSATTokenExpirationSeconds: 3600}}
+	ds = generateSpireAgentDaemonSet(spec, ztwim, "hash")
+	token = utils.ProjectedTokenFromPodSpec(&ds.Spec.Template.Spec, "ns:spire-agent", psatTokenPath)
+	require.NotNil(t, token)
+	assert.Equal(t, int64(3600), token.ExpirationSeconds)
+	assert.Equal(t, utils.DefaultPSATAudience, token.Audience)
+}
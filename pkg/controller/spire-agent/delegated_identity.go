@@ -0,0 +1,68 @@
+package spire_agent
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	// spireAgentAdminSocketDir is where the directory of the agent admin socket is mounted
+	spireAgentAdminSocketDir = "/tmp/spire-agent/private"
+
+	// delegatedIdentitySELinuxType is the SELinux type the agents serving the Delegated Identity
+	// API run with, so the delegates of other namespaces, confined to their own MCS categories,
+	// may connect to the admin socket created on the host
+	delegatedIdentitySELinuxType = "spc_t"
+)
+
+// delegatedIdentityEnabled reports whether the agents of the spec serve the Delegated Identity API
+func delegatedIdentityEnabled(spec *v1alpha2.SpireAgentSpec) bool {
+	return spec.DelegatedIdentity != nil && ptr.Deref(spec.DelegatedIdentity.Enabled, true)
+}
+
+// delegatedIdentityEnabledInAnyPool reports whether the agents of any pool serve the Delegated
+// Identity API, as the SecurityContextConstraints shared by the pools must then admit them
+func (r *SpireAgentReconciler) delegatedIdentityEnabledInAnyPool(ctx context.Context, agent *v1alpha2.SpireAgent) (bool, error) {
+	if delegatedIdentityEnabled(&agent.Spec) {
+		return true, nil
+	}
+	pools, err := r.listAgentPools(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, pool := range pools {
+		if delegatedIdentityEnabled(&pool.Spec) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// validateDelegatedIdentity checks the authorized delegates are SPIFFE IDs of the trust domain of
+// the agents, and the admin socket is not exposed alongside the Workload API socket. A disabled
+// configuration is not rendered and not checked.
+func validateDelegatedIdentity(spec *v1alpha2.SpireAgentSpec, trustDomain string) error {
+	if !delegatedIdentityEnabled(spec) {
+		return nil
+	}
+	delegated := spec.DelegatedIdentity
+	if filepath.Clean(delegated.AdminSocketPath) == filepath.Clean(spec.SocketPath) {
+		return fmt.Errorf("delegatedIdentity.adminSocketPath must differ from socketPath %s, which is exposed to every workload", spec.SocketPath)
+	}
+	for i, delegate := range delegated.AuthorizedDelegates {
+		delegateTrustDomain, err := utils.ParseSPIFFEID(delegate)
+		if err != nil {
+			return fmt.Errorf("delegatedIdentity.authorizedDelegates[%d]: %w", i, err)
+		}
+		if delegateTrustDomain != trustDomain {
+			return fmt.Errorf("delegatedIdentity.authorizedDelegates[%d]: %s is not in the trust domain %s of the agents", i, delegate, trustDomain)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package spire_agent
+
+import (
+	"testing"
+
+	securityv1 "github.com/openshift/api/security/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+func TestValidateDelegatedIdentity(t *testing.T) {
+	delegated := func(adminSocketPath string, delegates ...string) *v1alpha2.SpireAgentSpec {
+		return &v1alpha2.SpireAgentSpec{
+			SocketPath:        "/run/spire/agent-sockets",
+			DelegatedIdentity: &v1alpha2.DelegatedIdentityConfig{AdminSocketPath: adminSocketPath, AuthorizedDelegates: delegates},
+		}
+	}
+	tests := []struct {
+		name      string
+		spec      *v1alpha2.SpireAgentSpec
+		expectErr bool
+	}{
+		{name: "not configured", spec: &v1alpha2.SpireAgentSpec{}},
+		{name: "valid", spec: delegated("/run/spire/agent-admin", "spiffe://example.org/ns/mesh/sa/node-proxy")},
+		{name: "admin socket shared with workloads", spec: delegated("/run/spire/agent-sockets/", "spiffe://example.org/ns/mesh/sa/node-proxy"), expectErr: true},
+		{name: "invalid SPIFFE ID", spec: delegated("/run/spire/agent-admin", "spiffe://example.org/ns/../proxy"), expectErr: true},
+		{name: "foreign trust domain", spec: delegated("/run/spire/agent-admin", "spiffe://other.org/ns/mesh/sa/node-proxy"), expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDelegatedIdentity(tt.spec, "example.org")
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestDelegatedIdentityRendering(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.org", BundleConfigMap: "spire-bundle"}}
+	agent := &v1alpha2.SpireAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Spec: v1alpha2.SpireAgentSpec{
+			SocketPath: "/run/spire/agent-sockets",
+			DelegatedIdentity: &v1alpha2.DelegatedIdentityConfig{
+				AdminSocketPath:     "/run/spire/agent-admin",
+				AuthorizedDelegates: []string{"spiffe://example.org/ns/mesh/sa/node-proxy"},
+			},
+		},
+	}
+
+	agentConf := generateAgentConfig(agent, ztwim)["agent"].(map[string]interface{})
+	if agentConf["admin_socket_path"] != "/tmp/spire-agent/private/admin.sock" {
+		t.Errorf("Expected the admin socket in the private directory, got %v", agentConf["admin_socket_path"])
+	}
+	if delegates := agentConf["authorized_delegates"].([]string); len(delegates) != 1 || delegates[0] != "spiffe://example.org/ns/mesh/sa/node-proxy" {
+		t.Errorf("Expected the authorized delegates, got %v", delegates)
+	}
+
+	ds := generateSpireAgentDaemonSet(agent.Spec, ztwim, "hash")
+	mounted := false
+	for _, mount := range ds.Spec.Template.Spec.Containers[0].VolumeMounts {
+		mounted = mounted || (mount.Name == "spire-agent-admin-socket-dir" && mount.MountPath == spireAgentAdminSocketDir)
+	}
+	if !mounted {
+		t.Error("Expected the admin socket directory to be mounted")
+	}
+	for _, volume := range ds.Spec.Template.Spec.Volumes {
+		if volume.Name == "spire-agent-admin-socket-dir" && (volume.HostPath == nil || volume.HostPath.Path != "/run/spire/agent-admin") {
+			t.Errorf("Expected the admin socket directory on the host, got %v", volume.VolumeSource)
+		}
+	}
+
+	if opts := ds.Spec.Template.Spec.Containers[0].SecurityContext.SELinuxOptions; opts == nil || opts.Type != delegatedIdentitySELinuxType {
+		t.Errorf("Expected the agent to run with the SELinux type %s, got %v", delegatedIdentitySELinuxType, opts)
+	}
+
+	agent.Spec.DelegatedIdentity.Enabled = ptr.To(false)
+	if _, ok := generateAgentConfig(agent, ztwim)["agent"].(map[string]interface{})["admin_socket_path"]; ok {
+		t.Error("Expected no admin socket with delegated identity disabled")
+	}
+	ds = generateSpireAgentDaemonSet(agent.Spec, ztwim, "hash")
+	if ds.Spec.Template.Spec.Containers[0].SecurityContext.SELinuxOptions != nil {
+		t.Error("Expected no SELinux type with delegated identity disabled")
+	}
+	for _, volume := range ds.Spec.Template.Spec.Volumes {
+		if volume.Name == "spire-agent-admin-socket-dir" && volume.HostPath != nil {
+			t.Errorf("Expected the admin socket directory off the host with delegated identity disabled, got %v", volume.VolumeSource)
+		}
+	}
+
+	agent.Spec.DelegatedIdentity = nil
+	if _, ok := generateAgentConfig(agent, ztwim)["agent"].(map[string]interface{})["admin_socket_path"]; ok {
+		t.Error("Expected no admin socket without delegated identity")
+	}
+}
+
+func TestGenerateSpireAgentSCC_DelegatedIdentity(t *testing.T) {
+	agent := &v1alpha2.SpireAgent{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}}
+	if scc := generateSpireAgentSCC(agent, false); scc.SELinuxContext.Type != securityv1.SELinuxStrategyMustRunAs {
+		t.Errorf("Expected the SELinux context constrained, got %s", scc.SELinuxContext.Type)
+	}
+	if scc := generateSpireAgentSCC(agent, true); scc.SELinuxContext.Type != securityv1.SELinuxStrategyRunAsAny {
+		t.Errorf("Expected the SELinux context unconstrained for the Delegated Identity API, got %s", scc.SELinuxContext.Type)
+	}
+}
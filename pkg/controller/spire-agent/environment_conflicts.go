@@ -0,0 +1,38 @@
+package spire_agent
+
+import (
+	"context"
+	"slices"
+
+	"k8s.io/utils/ptr"
+
+	securityv1 "github.com/openshift/api/security/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/environment"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// reportEnvironmentConflicts reports the SecurityContextConstraints of another SPIRE agent install
+// in the EnvironmentConflict condition. They are returned as an invalid configuration error so the
+// agents are not reconciled over them. The SecurityContextConstraints are shared by the agent
+// pools and only checked through the default pool.
+func (r *SpireAgentReconciler) reportEnvironmentConflicts(ctx context.Context, agent *v1alpha2.SpireAgent, statusMgr *status.Manager, unmanagedKinds []string) error {
+	// SecurityContextConstraints left to the user or not served by the cluster are not created by the operator
+	if !isDefaultPool(agent) || slices.Contains(unmanagedKinds, "SecurityContextConstraints") || !utils.HasCapability(utils.CapabilitySecurityContextConstraints) {
+		return nil
+	}
+
+	var conflicts []environment.Conflict
+	sccName := generateSpireAgentSCC(agent, false).Name
+	conflict, err := environment.CheckOwned(ctx, r.ctrlClient, &securityv1.SecurityContextConstraints{}, "SecurityContextConstraints", sccName,
+		"SpireAgent", ptr.Deref(agent.Spec.AdoptExistingResources, false))
+	if err != nil {
+		return err
+	}
+	if conflict != nil {
+		conflicts = append(conflicts, *conflict)
+	}
+	return environment.Report(statusMgr, conflicts, agent.Status.Conditions)
+}
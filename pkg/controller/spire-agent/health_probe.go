@@ -0,0 +1,379 @@
+package spire_agent
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/version"
+	spiffev1alpha1 "github.com/spiffe/spire-controller-manager/api/v1alpha1"
+)
+
+const (
+	healthProbeName          = "spire-agent-health-probe"
+	healthProbeSocketDir     = "/spiffe-workload-api"
+	healthProbeCSIDriverName = "csi.spiffe.io"
+
+	// maxReportedFailingNodes caps the nodes named in the WorkloadAPIProbeHealthy message
+	maxReportedFailingNodes = 5
+)
+
+// Reasons of the WorkloadAPIProbeHealthy condition
+const (
+	WorkloadAPIProbeReasonPassing  = "WorkloadAPIProbePassing"
+	WorkloadAPIProbeReasonFailing  = "WorkloadAPIProbeFailing"
+	WorkloadAPIProbeReasonDisabled = "WorkloadAPIProbeDisabled"
+	WorkloadAPIProbeReasonFailed   = "WorkloadAPIProbeReconcileFailed"
+)
+
+// isHealthProbeEnabled reports whether the Workload API health probe is enabled for the SpireAgent
+func isHealthProbeEnabled(agent *v1alpha2.SpireAgent) bool {
+	return agent.Spec.HealthProbe != nil && ptr.Deref(agent.Spec.HealthProbe.Enabled, false)
+}
+
+// reconcileHealthProbe deploys the Workload API health probe when enabled, and removes it once
+// disabled. The probe pods run alongside the SPIRE agents, mount the Workload API through the
+// CSI driver like any workload, and are only ready while they can fetch an X.509-SVID.
+func (r *SpireAgentReconciler) reconcileHealthProbe(ctx context.Context, agent *v1alpha2.SpireAgent, statusMgr *status.Manager, createOnlyMode bool) error {
+	if !isHealthProbeEnabled(agent) {
+		return r.removeHealthProbe(ctx, agent, statusMgr)
+	}
+
+	if err := r.reconcileHealthProbeServiceAccount(ctx, agent); err != nil {
+		return r.healthProbeFailed(statusMgr, "ServiceAccount", err)
+	}
+	if err := r.reconcileHealthProbeClusterSPIFFEID(ctx, agent, createOnlyMode); err != nil {
+		return r.healthProbeFailed(statusMgr, "ClusterSPIFFEID", err)
+	}
+	ds, err := r.reconcileHealthProbeDaemonSet(ctx, agent, createOnlyMode)
+	if err != nil {
+		return r.healthProbeFailed(statusMgr, "DaemonSet", err)
+	}
+	return r.reportHealthProbeStatus(ctx, ds, statusMgr)
+}
+
+func (r *SpireAgentReconciler) healthProbeFailed(statusMgr *status.Manager, kind string, err error) error {
+	r.log.Error(err, "failed to reconcile health probe", "kind", kind)
+	statusMgr.AddCondition(WorkloadAPIProbeHealthy, WorkloadAPIProbeReasonFailed,
+		fmt.Sprintf("Failed to reconcile health probe %s: %v", kind, err),
+		metav1.ConditionFalse)
+	return err
+}
+
+func (r *SpireAgentReconciler) reconcileHealthProbeServiceAccount(ctx context.Context, agent *v1alpha2.SpireAgent) error {
+	desired := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      healthProbeName,
+			Namespace: utils.GetOperatorNamespace(),
+			Labels:    healthProbeLabels(agent.Spec.Labels),
+		},
+	}
+	if err := controllerutil.SetControllerReference(agent, desired, r.scheme); err != nil {
+		return err
+	}
+
+	var existing corev1.ServiceAccount
+	err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, &existing)
+	if kerrors.IsNotFound(err) {
+		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+			return err
+		}
+		r.log.Info("Created health probe ServiceAccount", "name", desired.Name)
+		return nil
+	}
+	return err
+}
+
+func (r *SpireAgentReconciler) reconcileHealthProbeClusterSPIFFEID(ctx context.Context, agent *v1alpha2.SpireAgent, createOnlyMode bool) error {
+	desired := generateHealthProbeClusterSPIFFEID(agent.Spec.Labels)
+	if err := controllerutil.SetControllerReference(agent, desired, r.scheme); err != nil {
+		return err
+	}
+
+	var existing spiffev1alpha1.ClusterSPIFFEID
+	err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: desired.Name}, &existing)
+	if kerrors.IsNotFound(err) {
+		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+			return err
+		}
+		r.log.Info("Created health probe ClusterSPIFFEID", "name", desired.Name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if createOnlyMode || !utils.ResourceNeedsUpdate(&existing, desired) {
+		return nil
+	}
+	desired.ResourceVersion = existing.ResourceVersion
+	if err := r.ctrlClient.Update(ctx, desired); err != nil {
+		return err
+	}
+	r.log.Info("Updated health probe ClusterSPIFFEID", "name", desired.Name)
+	return nil
+}
+
+func (r *SpireAgentReconciler) reconcileHealthProbeDaemonSet(ctx context.Context, agent *v1alpha2.SpireAgent, createOnlyMode bool) (*appsv1.DaemonSet, error) {
+	desired := generateHealthProbeDaemonSet(agent.Spec)
+
+	// Place the probes exactly like the running agents, architecture constraints included
+	var spireAgentDaemonSet appsv1.DaemonSet
+	err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: "spire-agent", Namespace: utils.GetOperatorNamespace()}, &spireAgentDaemonSet)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil {
+		podSpec := &desired.Spec.Template.Spec
+		podSpec.Affinity = spireAgentDaemonSet.Spec.Template.Spec.Affinity
+		podSpec.NodeSelector = spireAgentDaemonSet.Spec.Template.Spec.NodeSelector
+		podSpec.Tolerations = spireAgentDaemonSet.Spec.Template.Spec.Tolerations
+	}
+	if err := controllerutil.SetControllerReference(agent, desired, r.scheme); err != nil {
+		return nil, err
+	}
+
+	var existing appsv1.DaemonSet
+	err = r.ctrlClient.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, &existing)
+	if kerrors.IsNotFound(err) {
+		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+			return nil, err
+		}
+		r.log.Info("Created health probe DaemonSet", "name", desired.Name)
+		return desired, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if createOnlyMode || !utils.ResourceNeedsUpdate(&existing, desired) {
+		return &existing, nil
+	}
+	desired.ResourceVersion = existing.ResourceVersion
+	if err := r.ctrlClient.Update(ctx, desired); err != nil {
+		return nil, err
+	}
+	r.log.Info("Updated health probe DaemonSet", "name", desired.Name)
+	return desired, nil
+}
+
+// reportHealthProbeStatus sets WorkloadAPIProbeHealthy from the readiness of the probe pods,
+// naming the nodes where the SVID fetch fails
+func (r *SpireAgentReconciler) reportHealthProbeStatus(ctx context.Context, ds *appsv1.DaemonSet, statusMgr *status.Manager) error {
+	if ds.Status.DesiredNumberScheduled == 0 || ds.Status.ObservedGeneration != ds.Generation ||
+		ds.Status.UpdatedNumberScheduled != ds.Status.DesiredNumberScheduled {
+		// Pods that are still rolling out are not failures
+		statusMgr.AddCondition(WorkloadAPIProbeHealthy, "DaemonSetNotReady",
+			status.GetDaemonSetStatusMessage(ds),
+			metav1.ConditionFalse)
+		return nil
+	}
+	if ds.Status.NumberReady == ds.Status.DesiredNumberScheduled {
+		statusMgr.AddCondition(WorkloadAPIProbeHealthy, WorkloadAPIProbeReasonPassing,
+			fmt.Sprintf("SVID fetched through the Workload API on all %d nodes", ds.Status.DesiredNumberScheduled),
+			metav1.ConditionTrue)
+		return nil
+	}
+
+	var pods corev1.PodList
+	if err := r.ctrlClient.ListUncached(ctx, &pods, client.InNamespace(ds.Namespace), client.MatchingLabels(ds.Spec.Selector.MatchLabels)); err != nil {
+		return r.healthProbeFailed(statusMgr, "Pods", err)
+	}
+	failingNodes := failingHealthProbeNodes(pods.Items)
+	message := fmt.Sprintf("SVID fetch through the Workload API failing on %d/%d nodes",
+		ds.Status.DesiredNumberScheduled-ds.Status.NumberReady, ds.Status.DesiredNumberScheduled)
+	if len(failingNodes) > 0 {
+		message += ": " + strings.Join(failingNodes, ", ")
+	}
+	statusMgr.AddCondition(WorkloadAPIProbeHealthy, WorkloadAPIProbeReasonFailing, message, metav1.ConditionFalse)
+	return nil
+}
+
+// failingHealthProbeNodes returns the sorted nodes of the probe pods that are not ready
+func failingHealthProbeNodes(pods []corev1.Pod) []string {
+	var nodes []string
+	for _, pod := range pods {
+		ready := false
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == corev1.PodReady {
+				ready = cond.Status == corev1.ConditionTrue
+			}
+		}
+		if !ready && pod.Spec.NodeName != "" {
+			nodes = append(nodes, pod.Spec.NodeName)
+		}
+	}
+	slices.Sort(nodes)
+	if len(nodes) > maxReportedFailingNodes {
+		nodes = append(nodes[:maxReportedFailingNodes], fmt.Sprintf("and %d more", len(nodes)-maxReportedFailingNodes))
+	}
+	return nodes
+}
+
+// removeHealthProbe deletes the health probe resources left behind once the probe is disabled
+func (r *SpireAgentReconciler) removeHealthProbe(ctx context.Context, agent *v1alpha2.SpireAgent, statusMgr *status.Manager) error {
+	namespace := utils.GetOperatorNamespace()
+	objects := []client.Object{
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: healthProbeName, Namespace: namespace}},
+		&spiffev1alpha1.ClusterSPIFFEID{ObjectMeta: metav1.ObjectMeta{Name: healthProbeClusterSPIFFEIDName}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: healthProbeName, Namespace: namespace}},
+	}
+	for _, obj := range objects {
+		if err := r.ctrlClient.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			return r.healthProbeFailed(statusMgr, fmt.Sprintf("%T", obj), err)
+		}
+		if err := r.ctrlClient.Delete(ctx, obj); err != nil && !kerrors.IsNotFound(err) {
+			return r.healthProbeFailed(statusMgr, fmt.Sprintf("%T", obj), err)
+		}
+		r.log.Info("Deleted health probe resource", "kind", fmt.Sprintf("%T", obj), "name", obj.GetName())
+	}
+	if apimeta.FindStatusCondition(agent.Status.Conditions, WorkloadAPIProbeHealthy) != nil {
+		statusMgr.AddCondition(WorkloadAPIProbeHealthy, WorkloadAPIProbeReasonDisabled,
+			"Workload API health probe is disabled",
+			metav1.ConditionUnknown)
+	}
+	return nil
+}
+
+func healthProbeLabels(customLabels map[string]string) map[string]string {
+	return utils.StandardizedLabels(healthProbeName, utils.ComponentNodeAgent, version.SpireAgentVersion, customLabels)
+}
+
+const healthProbeClusterSPIFFEIDName = "zero-trust-workload-identity-manager-spire-agent-health-probe"
+
+func generateHealthProbeClusterSPIFFEID(customLabels map[string]string) *spiffev1alpha1.ClusterSPIFFEID {
+	return &spiffev1alpha1.ClusterSPIFFEID{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   healthProbeClusterSPIFFEIDName,
+			Labels: healthProbeLabels(customLabels),
+		},
+		Spec: spiffev1alpha1.ClusterSPIFFEIDSpec{
+			ClassName:        "zero-trust-workload-identity-manager-spire",
+			Hint:             "health-probe",
+			SPIFFEIDTemplate: "spiffe://{{ .TrustDomain }}/ns/{{ .PodMeta.Namespace }}/sa/{{ .PodSpec.ServiceAccountName }}",
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name":      healthProbeName,
+					"app.kubernetes.io/instance":  utils.StandardInstance,
+					"app.kubernetes.io/component": utils.ComponentNodeAgent,
+				},
+			},
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      "kubernetes.io/metadata.name",
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{utils.GetOperatorNamespace()},
+					},
+				},
+			},
+		},
+	}
+}
+
+func generateHealthProbeDaemonSet(config v1alpha2.SpireAgentSpec) *appsv1.DaemonSet {
+	labels := healthProbeLabels(config.Labels)
+	selectorLabels := map[string]string{
+		"app.kubernetes.io/name":      labels["app.kubernetes.io/name"],
+		"app.kubernetes.io/instance":  labels["app.kubernetes.io/instance"],
+		"app.kubernetes.io/component": labels["app.kubernetes.io/component"],
+	}
+
+	interval := config.HealthProbe.Interval.Duration
+	periodSeconds := int32(interval.Seconds())
+	if periodSeconds < 1 {
+		periodSeconds = 60
+	}
+	socketPath := healthProbeSocketDir + "/spire-agent.sock"
+
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      healthProbeName,
+			Namespace: utils.GetOperatorNamespace(),
+			Labels:    labels,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selectorLabels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: healthProbeName,
+					ImagePullSecrets:   config.ImagePullSecrets,
+					Containers: []corev1.Container{
+						{
+							Name:            "health-probe",
+							Image:           utils.GetSpireAgentImage(),
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							// Keep a Workload API stream open, as a workload would
+							Command: []string{"/opt/spire/bin/spire-agent", "api", "watch", "-socketPath", socketPath},
+							ReadinessProbe: &corev1.Probe{
+								PeriodSeconds:  periodSeconds,
+								TimeoutSeconds: 10,
+								ProbeHandler: corev1.ProbeHandler{
+									Exec: &corev1.ExecAction{
+										Command: []string{"/opt/spire/bin/spire-agent", "api", "fetch", "x509", "-socketPath", socketPath, "-timeout", "5s"},
+									},
+								},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "spiffe-workload-api", MountPath: healthProbeSocketDir, ReadOnly: true},
+							},
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("5m"),
+									corev1.ResourceMemory: resource.MustParse("16Mi"),
+								},
+								Limits: corev1.ResourceList{
+									corev1.ResourceCPU:    resource.MustParse("50m"),
+									corev1.ResourceMemory: resource.MustParse("64Mi"),
+								},
+							},
+							SecurityContext: &corev1.SecurityContext{
+								AllowPrivilegeEscalation: ptr.To(false),
+								Capabilities: &corev1.Capabilities{
+									Drop: []corev1.Capability{"ALL"},
+								},
+								ReadOnlyRootFilesystem: ptr.To(true),
+							},
+						},
+					},
+					Affinity:     config.Affinity,
+					NodeSelector: utils.DerefNodeSelector(config.NodeSelector),
+					Tolerations:  utils.DerefTolerations(config.Tolerations),
+					Volumes: []corev1.Volume{
+						{
+							Name: "spiffe-workload-api",
+							VolumeSource: corev1.VolumeSource{
+								CSI: &corev1.CSIVolumeSource{
+									Driver:   healthProbeCSIDriverName,
+									ReadOnly: ptr.To(true),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return ds
+}
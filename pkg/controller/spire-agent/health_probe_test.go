@@ -0,0 +1,134 @@
+package spire_agent
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+)
+
+func healthProbeAgent(enabled bool) *v1alpha2.SpireAgent {
+	return &v1alpha2.SpireAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "agent-uid"},
+		Spec: v1alpha2.SpireAgentSpec{
+			HealthProbe: &v1alpha2.WorkloadAPIHealthProbe{Enabled: ptr.To(enabled), Interval: metav1.Duration{Duration: 30 * time.Second}},
+		},
+	}
+}
+
+func TestGenerateHealthProbeDaemonSet(t *testing.T) {
+	ds := generateHealthProbeDaemonSet(healthProbeAgent(true).Spec)
+
+	podSpec := ds.Spec.Template.Spec
+	if len(podSpec.Volumes) != 1 || podSpec.Volumes[0].CSI == nil || podSpec.Volumes[0].CSI.Driver != healthProbeCSIDriverName {
+		t.Fatalf("Expected the Workload API to be mounted through the CSI driver, got %v", podSpec.Volumes)
+	}
+	probe := podSpec.Containers[0].ReadinessProbe
+	if probe == nil || probe.Exec == nil || !strings.Contains(strings.Join(probe.Exec.Command, " "), "api fetch x509") {
+		t.Fatalf("Expected readiness to fetch an X.509-SVID, got %v", probe)
+	}
+	if probe.PeriodSeconds != 30 {
+		t.Errorf("Expected the probe to run every 30s, got %ds", probe.PeriodSeconds)
+	}
+	if podSpec.ServiceAccountName != healthProbeName {
+		t.Errorf("Expected the probe to run as %s, got %s", healthProbeName, podSpec.ServiceAccountName)
+	}
+}
+
+func TestReconcileHealthProbe_Disabled(t *testing.T) {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	fakeClient.GetReturns(kerrors.NewNotFound(schema.GroupResource{}, healthProbeName))
+	statusMgr := status.NewManager(fakeClient)
+
+	if err := newTestReconciler(fakeClient).reconcileHealthProbe(context.Background(), healthProbeAgent(false), statusMgr, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fakeClient.CreateCallCount() != 0 || fakeClient.DeleteCallCount() != 0 {
+		t.Error("Expected nothing to be created or deleted while the probe is disabled")
+	}
+	if _, ok := statusMgr.GetCondition(WorkloadAPIProbeHealthy); ok {
+		t.Error("Expected no WorkloadAPIProbeHealthy condition when the probe was never enabled")
+	}
+
+	// Disabling a previously enabled probe removes its resources
+	fakeClient.GetReturns(nil)
+	agent := healthProbeAgent(false)
+	agent.Status.Conditions = []metav1.Condition{{Type: WorkloadAPIProbeHealthy, Status: metav1.ConditionTrue}}
+	if err := newTestReconciler(fakeClient).reconcileHealthProbe(context.Background(), agent, statusMgr, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fakeClient.DeleteCallCount() != 3 {
+		t.Errorf("Expected the DaemonSet, ClusterSPIFFEID and ServiceAccount to be deleted, got %d deletes", fakeClient.DeleteCallCount())
+	}
+	if cond, _ := statusMgr.GetCondition(WorkloadAPIProbeHealthy); cond.Reason != WorkloadAPIProbeReasonDisabled {
+		t.Errorf("Expected the probe to be reported as disabled, got %v", cond)
+	}
+}
+
+func TestReconcileHealthProbe_ReportsFailingNodes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1alpha2.AddToScheme(scheme)
+
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		ds, ok := obj.(*appsv1.DaemonSet)
+		if !ok || key.Name != healthProbeName {
+			return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+		}
+		*ds = *generateHealthProbeDaemonSet(healthProbeAgent(true).Spec)
+		ds.Status = appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberReady: 2}
+		return nil
+	}
+	fakeClient.ListUncachedStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+		notReady := []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}}
+		ready := []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		list.(*corev1.PodList).Items = []corev1.Pod{
+			{Spec: corev1.PodSpec{NodeName: "worker-0"}, Status: corev1.PodStatus{Conditions: ready}},
+			{Spec: corev1.PodSpec{NodeName: "worker-1"}, Status: corev1.PodStatus{Conditions: notReady}},
+			{Spec: corev1.PodSpec{NodeName: "worker-2"}, Status: corev1.PodStatus{Conditions: ready}},
+		}
+		return nil
+	}
+	reconciler := newTestReconciler(fakeClient)
+	reconciler.scheme = scheme
+	statusMgr := status.NewManager(fakeClient)
+
+	if err := reconciler.reconcileHealthProbe(context.Background(), healthProbeAgent(true), statusMgr, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fakeClient.CreateCallCount() != 2 {
+		t.Errorf("Expected the ServiceAccount and ClusterSPIFFEID to be created, got %d creates", fakeClient.CreateCallCount())
+	}
+	cond, ok := statusMgr.GetCondition(WorkloadAPIProbeHealthy)
+	if !ok || cond.Status != metav1.ConditionFalse || cond.Reason != WorkloadAPIProbeReasonFailing {
+		t.Fatalf("Expected the probe to be reported as failing, got %v", cond)
+	}
+	if !strings.Contains(cond.Message, "1/3 nodes: worker-1") {
+		t.Errorf("Expected the failing node to be named, got %q", cond.Message)
+	}
+}
+
+func TestFailingHealthProbeNodes(t *testing.T) {
+	var pods []corev1.Pod
+	for _, node := range []string{"g", "f", "e", "d", "c", "b", "a"} {
+		pods = append(pods, corev1.Pod{Spec: corev1.PodSpec{NodeName: node}})
+	}
+	nodes := failingHealthProbeNodes(pods)
+	expected := "a, b, c, d, e, and 2 more"
+	if got := strings.Join(nodes, ", "); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
@@ -0,0 +1,127 @@
+package spire_agent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	// joinTokenSecretKey is the key of the bootstrap token in the join token Secret
+	joinTokenSecretKey = "token"
+	// joinTokenEnvName is the agent container env var the bootstrap token is exposed in
+	joinTokenEnvName = "JOIN_TOKEN"
+
+	spireAgentDaemonSetBootstrapTokenHashAnnotationKey      = "ztwim.openshift.io/bootstrap-token-hash"
+	spireAgentDaemonSetBootstrapTokenRotatedAtAnnotationKey = "ztwim.openshift.io/bootstrap-token-rotated-at"
+)
+
+// getBootstrapTokenHash reads the join token Secret and returns the hash of the bootstrap token,
+// or an empty string when join token attestation is not configured. The Secret is user-provided
+// and not labelled as managed by the operator, so it is read uncached.
+func (r *SpireAgentReconciler) getBootstrapTokenHash(ctx context.Context, agent *v1alpha2.SpireAgent, statusMgr *status.Manager) (string, error) {
+	if agent.Spec.NodeAttestor == nil || agent.Spec.NodeAttestor.JoinToken == nil {
+		// Only report the transition if a bootstrap token was previously in use
+		if apimeta.FindStatusCondition(agent.Status.Conditions, BootstrapTokenAvailable) != nil {
+			statusMgr.AddCondition(BootstrapTokenAvailable, "BootstrapTokenNotConfigured",
+				"Join token attestation is not configured",
+				metav1.ConditionTrue)
+		}
+		return "", nil
+	}
+
+	secretName := agent.Spec.NodeAttestor.JoinToken.SecretName
+	var secret corev1.Secret
+	if err := r.ctrlClient.GetUncached(ctx, types.NamespacedName{Name: secretName, Namespace: utils.GetOperatorNamespace()}, &secret); err != nil {
+		reason := "BootstrapTokenSecretGetFailed"
+		if kerrors.IsNotFound(err) {
+			reason = "BootstrapTokenSecretNotFound"
+		}
+		statusMgr.AddCondition(BootstrapTokenAvailable, reason,
+			fmt.Sprintf("Failed to get bootstrap token Secret %s: %v", secretName, err),
+			metav1.ConditionFalse)
+		return "", fmt.Errorf("failed to get bootstrap token Secret %s: %w", secretName, err)
+	}
+
+	token := secret.Data[joinTokenSecretKey]
+	if len(token) == 0 {
+		err := fmt.Errorf("bootstrap token Secret %s has no %q key", secretName, joinTokenSecretKey)
+		statusMgr.AddCondition(BootstrapTokenAvailable, "BootstrapTokenInvalid",
+			err.Error(),
+			metav1.ConditionFalse)
+		return "", err
+	}
+
+	return utils.GenerateConfigHash(token), nil
+}
+
+// setBootstrapTokenAnnotations stamps the bootstrap token hash on the desired pod template, so a
+// rotated token rolls out the agents, and carries over the rotation time while the token is
+// unchanged.
+func setBootstrapTokenAnnotations(desired, existing *appsv1.DaemonSet, tokenHash string, now time.Time) {
+	if tokenHash == "" {
+		return
+	}
+	rotatedAt := now.UTC().Format(time.RFC3339)
+	if existing != nil && existing.Spec.Template.Annotations[spireAgentDaemonSetBootstrapTokenHashAnnotationKey] == tokenHash {
+		if previous, ok := existing.Spec.Template.Annotations[spireAgentDaemonSetBootstrapTokenRotatedAtAnnotationKey]; ok {
+			rotatedAt = previous
+		}
+	}
+	desired.Spec.Template.Annotations[spireAgentDaemonSetBootstrapTokenHashAnnotationKey] = tokenHash
+	desired.Spec.Template.Annotations[spireAgentDaemonSetBootstrapTokenRotatedAtAnnotationKey] = rotatedAt
+}
+
+// reportBootstrapTokenStatus reports the rotation schedule of the bootstrap token and the agents
+// that have not yet been restarted with the current token. existing is the DaemonSet as observed
+// before this reconcile; when the token was rotated in this reconcile, every scheduled agent
+// still runs with the previous token.
+func reportBootstrapTokenStatus(agent *v1alpha2.SpireAgent, statusMgr *status.Manager, desired, existing *appsv1.DaemonSet, now time.Time) {
+	tokenHash := desired.Spec.Template.Annotations[spireAgentDaemonSetBootstrapTokenHashAnnotationKey]
+	if tokenHash == "" {
+		return
+	}
+
+	var staleAgents int32
+	if existing != nil {
+		staleAgents = existing.Status.DesiredNumberScheduled
+		if existing.Spec.Template.Annotations[spireAgentDaemonSetBootstrapTokenHashAnnotationKey] == tokenHash &&
+			existing.Status.ObservedGeneration == existing.Generation {
+			staleAgents -= existing.Status.UpdatedNumberScheduled
+		}
+	}
+
+	rotatedAt, err := time.Parse(time.RFC3339, desired.Spec.Template.Annotations[spireAgentDaemonSetBootstrapTokenRotatedAtAnnotationKey])
+	if err != nil {
+		rotatedAt = now
+	}
+	rotationDue := rotatedAt.Add(agent.Spec.NodeAttestor.JoinToken.RotationInterval.Duration)
+
+	reason := "BootstrapTokenCurrent"
+	message := fmt.Sprintf("Bootstrap token rotated at %s, next rotation due at %s",
+		rotatedAt.UTC().Format(time.RFC3339), rotationDue.UTC().Format(time.RFC3339))
+	if agent.Spec.NodeAttestor.JoinToken.RotationInterval.Duration > 0 && !now.Before(rotationDue) {
+		reason = "BootstrapTokenRotationDue"
+		message = fmt.Sprintf("Bootstrap token rotation is overdue: rotated at %s, rotation due at %s",
+			rotatedAt.UTC().Format(time.RFC3339), rotationDue.UTC().Format(time.RFC3339))
+	}
+	if staleAgents > 0 {
+		if reason == "BootstrapTokenCurrent" {
+			reason = "BootstrapTokenStaleAgents"
+		}
+		message = fmt.Sprintf("%s; %d agent(s) still use the previous bootstrap token", message, staleAgents)
+	}
+
+	statusMgr.AddCondition(BootstrapTokenAvailable, reason, message, metav1.ConditionTrue)
+}
@@ -0,0 +1,221 @@
+package spire_agent
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func joinTokenAgent(rotationInterval time.Duration) *v1alpha2.SpireAgent {
+	return &v1alpha2.SpireAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Spec: v1alpha2.SpireAgentSpec{
+			NodeAttestor: &v1alpha2.NodeAttestor{
+				K8sPSATEnabled: ptr.To(false),
+				JoinToken: &v1alpha2.JoinTokenConfig{
+					SecretName:       "spire-agent-join-token",
+					RotationInterval: metav1.Duration{Duration: rotationInterval},
+				},
+			},
+		},
+	}
+}
+
+func TestGetBootstrapTokenHash(t *testing.T) {
+	tests := []struct {
+		name         string
+		agent        *v1alpha2.SpireAgent
+		secret       *corev1.Secret
+		getErr       error
+		expectHash   bool
+		expectErr    bool
+		expectReason string
+	}{
+		{
+			name:  "join token not configured",
+			agent: &v1alpha2.SpireAgent{},
+		},
+		{
+			name:         "join token disabled after use",
+			agent:        &v1alpha2.SpireAgent{Status: v1alpha2.SpireAgentStatus{ConditionalStatus: v1alpha2.ConditionalStatus{Conditions: []metav1.Condition{{Type: BootstrapTokenAvailable, Status: metav1.ConditionTrue}}}}},
+			expectReason: "BootstrapTokenNotConfigured",
+		},
+		{
+			name:       "secret with token",
+			agent:      joinTokenAgent(time.Hour),
+			secret:     &corev1.Secret{Data: map[string][]byte{"token": []byte("abc")}},
+			expectHash: true,
+		},
+		{
+			name:         "secret not found",
+			agent:        joinTokenAgent(time.Hour),
+			getErr:       kerrors.NewNotFound(corev1.Resource("secrets"), "spire-agent-join-token"),
+			expectErr:    true,
+			expectReason: "BootstrapTokenSecretNotFound",
+		},
+		{
+			name:         "secret without token key",
+			agent:        joinTokenAgent(time.Hour),
+			secret:       &corev1.Secret{Data: map[string][]byte{"other": []byte("abc")}},
+			expectErr:    true,
+			expectReason: "BootstrapTokenInvalid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.GetUncachedStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				if tt.getErr != nil {
+					return tt.getErr
+				}
+				if key.Name != "spire-agent-join-token" {
+					t.Errorf("Expected the configured Secret to be read, got %s", key.Name)
+				}
+				*obj.(*corev1.Secret) = *tt.secret
+				return nil
+			}
+			reconciler := newTestReconciler(fakeClient)
+			statusMgr := status.NewManager(fakeClient)
+
+			hash, err := reconciler.getBootstrapTokenHash(context.Background(), tt.agent, statusMgr)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("Expected error=%v, got %v", tt.expectErr, err)
+			}
+			if tt.expectHash && hash != utils.GenerateConfigHash([]byte("abc")) {
+				t.Errorf("Expected hash of the bootstrap token, got %q", hash)
+			}
+			if !tt.expectHash && hash != "" {
+				t.Errorf("Expected no hash, got %q", hash)
+			}
+			cond, ok := statusMgr.GetCondition(BootstrapTokenAvailable)
+			if tt.expectReason == "" {
+				if ok {
+					t.Errorf("Expected no %s condition, got %v", BootstrapTokenAvailable, cond)
+				}
+				return
+			}
+			if !ok || cond.Reason != tt.expectReason {
+				t.Errorf("Expected %s reason %s, got %v", BootstrapTokenAvailable, tt.expectReason, cond)
+			}
+		})
+	}
+}
+
+func TestGenerateSpireAgentDaemonSet_JoinToken(t *testing.T) {
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{BundleConfigMap: "spire-bundle"}}
+	ds := generateSpireAgentDaemonSet(joinTokenAgent(time.Hour).Spec, ztwim, "hash")
+	container := ds.Spec.Template.Spec.Containers[0]
+
+	if got := strings.Join(container.Args, " "); !strings.HasSuffix(got, "-joinToken $(JOIN_TOKEN)") {
+		t.Errorf("Expected -joinToken argument, got %q", got)
+	}
+	var found bool
+	for _, env := range container.Env {
+		if env.Name == joinTokenEnvName {
+			found = env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil &&
+				env.ValueFrom.SecretKeyRef.Name == "spire-agent-join-token" && env.ValueFrom.SecretKeyRef.Key == joinTokenSecretKey
+		}
+	}
+	if !found {
+		t.Errorf("Expected %s env sourced from the bootstrap token Secret, got %v", joinTokenEnvName, container.Env)
+	}
+
+	withoutToken := generateSpireAgentDaemonSet(v1alpha2.SpireAgentSpec{}, ztwim, "hash")
+	if len(withoutToken.Spec.Template.Spec.Containers[0].Args) != 2 {
+		t.Errorf("Expected no join token arguments without join token attestation, got %v", withoutToken.Spec.Template.Spec.Containers[0].Args)
+	}
+}
+
+func TestBootstrapTokenRotation(t *testing.T) {
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	rotatedAt := now.Add(-48 * time.Hour).Format(time.RFC3339)
+	existingWithToken := func(hash string, desired, updated int32) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			Spec: appsv1.DaemonSetSpec{Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+				spireAgentDaemonSetBootstrapTokenHashAnnotationKey:      hash,
+				spireAgentDaemonSetBootstrapTokenRotatedAtAnnotationKey: rotatedAt,
+			}}}},
+			Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: desired, UpdatedNumberScheduled: updated},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		existing        *appsv1.DaemonSet
+		rotation        time.Duration
+		expectRotatedAt string
+		expectReason    string
+		expectMessage   string
+	}{
+		{
+			name:            "unchanged token keeps rotation time",
+			existing:        existingWithToken("current", 3, 3),
+			rotation:        720 * time.Hour,
+			expectRotatedAt: rotatedAt,
+			expectReason:    "BootstrapTokenCurrent",
+		},
+		{
+			name:            "rotation overdue",
+			existing:        existingWithToken("current", 3, 3),
+			rotation:        24 * time.Hour,
+			expectRotatedAt: rotatedAt,
+			expectReason:    "BootstrapTokenRotationDue",
+		},
+		{
+			name:            "agents not yet rolled to the current token",
+			existing:        existingWithToken("current", 3, 1),
+			rotation:        720 * time.Hour,
+			expectRotatedAt: rotatedAt,
+			expectReason:    "BootstrapTokenStaleAgents",
+			expectMessage:   "2 agent(s) still use the previous bootstrap token",
+		},
+		{
+			name:            "rotated token restarts the rotation schedule",
+			existing:        existingWithToken("previous", 3, 3),
+			rotation:        24 * time.Hour,
+			expectRotatedAt: now.Format(time.RFC3339),
+			expectReason:    "BootstrapTokenStaleAgents",
+			expectMessage:   "3 agent(s) still use the previous bootstrap token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := joinTokenAgent(tt.rotation)
+			desired := generateSpireAgentDaemonSet(agent.Spec, &v1alpha2.ZeroTrustWorkloadIdentityManager{}, "hash")
+			statusMgr := status.NewManager(&fakes.FakeCustomCtrlClient{})
+
+			setBootstrapTokenAnnotations(desired, tt.existing, "current", now)
+			reportBootstrapTokenStatus(agent, statusMgr, desired, tt.existing, now)
+
+			if got := desired.Spec.Template.Annotations[spireAgentDaemonSetBootstrapTokenRotatedAtAnnotationKey]; got != tt.expectRotatedAt {
+				t.Errorf("Expected rotation time %s, got %s", tt.expectRotatedAt, got)
+			}
+			rotated := tt.existing.Spec.Template.Annotations[spireAgentDaemonSetBootstrapTokenHashAnnotationKey] != "current"
+			if rotated && !needsUpdate(*tt.existing, *desired) {
+				t.Error("Expected a rotated token to require a DaemonSet update")
+			}
+			cond, ok := statusMgr.GetCondition(BootstrapTokenAvailable)
+			if !ok || cond.Status != metav1.ConditionTrue || cond.Reason != tt.expectReason {
+				t.Fatalf("Expected %s True/%s, got %v", BootstrapTokenAvailable, tt.expectReason, cond)
+			}
+			if !strings.Contains(cond.Message, tt.expectMessage) {
+				t.Errorf("Expected message to contain %q, got %q", tt.expectMessage, cond.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package spire_agent
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	// maxPoolExclusionTerms caps the node selector terms keeping the default pool off the nodes of
+	// the other pools, as each label of a pool nodeSelector multiplies them
+	maxPoolExclusionTerms = 32
+
+	// Agent pool reasons
+	AgentPoolReasonOverlap       = "AgentPoolsOverlap"
+	AgentPoolReasonTooManyLabels = "AgentPoolSelectorsTooComplex"
+)
+
+// isDefaultPool reports whether the SpireAgent is the default agent pool
+func isDefaultPool(agent *v1alpha2.SpireAgent) bool {
+	return agent.Name == utils.DefaultAgentPool
+}
+
+// agentPoolResourceName returns the name of the DaemonSet and ConfigMap of the agent pool
+func agentPoolResourceName(pool string) string {
+	if pool == utils.DefaultAgentPool {
+		return "spire-agent"
+	}
+	return "spire-agent-" + pool
+}
+
+// listAgentPools returns the agent pools other than the default one, sorted by name
+func (r *SpireAgentReconciler) listAgentPools(ctx context.Context) ([]v1alpha2.SpireAgent, error) {
+	var agents v1alpha2.SpireAgentList
+	if err := r.ctrlClient.List(ctx, &agents); err != nil {
+		return nil, fmt.Errorf("failed to list SpireAgents: %w", err)
+	}
+	var pools []v1alpha2.SpireAgent
+	for _, agent := range agents.Items {
+		if !isDefaultPool(&agent) && agent.DeletionTimestamp == nil {
+			pools = append(pools, agent)
+		}
+	}
+	slices.SortFunc(pools, func(a, b v1alpha2.SpireAgent) int { return strings.Compare(a.Name, b.Name) })
+	return pools, nil
+}
+
+// validateAgentPools checks the nodeSelector of an agent pool selects nodes disjoint from the
+// other pools. The default pool is kept off the nodes of the other pools instead.
+func (r *SpireAgentReconciler) validateAgentPools(ctx context.Context, agent *v1alpha2.SpireAgent, statusMgr *status.Manager) error {
+	if isDefaultPool(agent) {
+		return nil
+	}
+	pools, err := r.listAgentPools(ctx)
+	if err != nil {
+		return err
+	}
+	for _, pool := range pools {
+		if pool.Name != agent.Name && !nodeSelectorsDisjoint(agent.Spec.NodeSelector, pool.Spec.NodeSelector) {
+			err := fmt.Errorf("nodeSelector of SpireAgent pool %s may select the nodes of pool %s: the pools must require a different value for at least one common label",
+				agent.Name, pool.Name)
+			r.log.Error(err, "overlapping agent pools")
+			statusMgr.AddCondition(ConfigurationValid, AgentPoolReasonOverlap, err.Error(), metav1.ConditionFalse)
+			return err
+		}
+	}
+	return nil
+}
+
+// nodeSelectorsDisjoint reports whether no node can match both nodeSelectors, i.e. they require
+// different values for a common label
+func nodeSelectorsDisjoint(a, b map[string]string) bool {
+	for key, value := range a {
+		if other, ok := b[key]; ok && other != value {
+			return true
+		}
+	}
+	return false
+}
+
+// withPoolExclusion returns a copy of affinity that additionally keeps the default pool off the
+// nodes selected by the other pools. A node is outside a pool when one of the labels of the pool
+// nodeSelector does not match, so every required node selector term is split into a term per
+// label of each pool, as the terms are ORed.
+func withPoolExclusion(affinity *corev1.Affinity, pools []v1alpha2.SpireAgent) (*corev1.Affinity, error) {
+	if len(pools) == 0 {
+		return affinity, nil
+	}
+	result := affinity.DeepCopy()
+	if result == nil {
+		result = &corev1.Affinity{}
+	}
+	if result.NodeAffinity == nil {
+		result.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	if result.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		result.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	selector := result.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	terms := selector.NodeSelectorTerms
+	if len(terms) == 0 {
+		terms = []corev1.NodeSelectorTerm{{}}
+	}
+	for _, pool := range pools {
+		keys := make([]string, 0, len(pool.Spec.NodeSelector))
+		for key := range pool.Spec.NodeSelector {
+			keys = append(keys, key)
+		}
+		if len(keys) == 0 {
+			continue
+		}
+		slices.Sort(keys)
+		if len(terms)*len(keys) > maxPoolExclusionTerms {
+			return nil, fmt.Errorf("the nodeSelectors of the SpireAgent pools combine to more than %d node selector terms: use fewer labels to select the pool nodes",
+				maxPoolExclusionTerms)
+		}
+		split := make([]corev1.NodeSelectorTerm, 0, len(terms)*len(keys))
+		for _, term := range terms {
+			for _, key := range keys {
+				excluded := *term.DeepCopy()
+				excluded.MatchExpressions = append(excluded.MatchExpressions, corev1.NodeSelectorRequirement{
+					Key:      key,
+					Operator: corev1.NodeSelectorOpNotIn,
+					Values:   []string{pool.Spec.NodeSelector[key]},
+				})
+				split = append(split, excluded)
+			}
+		}
+		terms = split
+	}
+	selector.NodeSelectorTerms = terms
+	return result, nil
+}
+
+// applyAgentPool names the DaemonSet after its agent pool and labels its pods with the pool, so
+// the DaemonSets of the pools select their own pods
+func applyAgentPool(ds *appsv1.DaemonSet, pool string) {
+	ds.Name = agentPoolResourceName(pool)
+	if pool == utils.DefaultAgentPool {
+		return
+	}
+	ds.Labels[utils.AgentPoolLabel] = pool
+	ds.Spec.Selector.MatchLabels[utils.AgentPoolLabel] = pool
+	ds.Spec.Template.Labels[utils.AgentPoolLabel] = pool
+	for i := range ds.Spec.Template.Spec.Volumes {
+		if volume := &ds.Spec.Template.Spec.Volumes[i]; volume.Name == "spire-config" {
+			volume.ConfigMap.Name = agentPoolResourceName(pool)
+		}
+	}
+}
+
+// applyAgentPoolToConfigMap names the ConfigMap after its agent pool
+func applyAgentPoolToConfigMap(cm *corev1.ConfigMap, pool string) {
+	cm.Name = agentPoolResourceName(pool)
+	if pool != utils.DefaultAgentPool {
+		cm.Labels[utils.AgentPoolLabel] = pool
+	}
+}
+
+// agentPoolRequests enqueues every agent pool, so the pools are validated again and the default
+// pool excludes the nodes of the other pools when a pool changes
+func (r *SpireAgentReconciler) agentPoolRequests(ctx context.Context) []reconcile.Request {
+	requests := []reconcile.Request{{NamespacedName: types.NamespacedName{Name: utils.DefaultAgentPool}}}
+	pools, err := r.listAgentPools(ctx)
+	if err != nil {
+		r.log.Error(err, "failed to list agent pools")
+		return requests
+	}
+	for _, pool := range pools {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: pool.Name}})
+	}
+	return requests
+}
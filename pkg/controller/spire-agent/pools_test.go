@@ -0,0 +1,127 @@
+package spire_agent
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func newAgentPool(name string, nodeSelector map[string]string) v1alpha2.SpireAgent {
+	agent := v1alpha2.SpireAgent{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	agent.Spec.NodeSelector = nodeSelector
+	return agent
+}
+
+func TestNodeSelectorsDisjoint(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b map[string]string
+		want bool
+	}{
+		{name: "different value for a common label", a: map[string]string{"pool": "gpu"}, b: map[string]string{"pool": "edge"}, want: true},
+		{name: "same selector", a: map[string]string{"pool": "gpu"}, b: map[string]string{"pool": "gpu"}},
+		{name: "different labels", a: map[string]string{"gpu": "true"}, b: map[string]string{"edge": "true"}},
+		{name: "subset", a: map[string]string{"pool": "gpu", "zone": "a"}, b: map[string]string{"pool": "gpu"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nodeSelectorsDisjoint(tt.a, tt.b); got != tt.want {
+				t.Errorf("Expected disjoint %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWithPoolExclusion(t *testing.T) {
+	pools := []v1alpha2.SpireAgent{
+		newAgentPool("gpu", map[string]string{"nvidia.com/gpu.present": "true"}),
+		newAgentPool("edge", map[string]string{"node-role.kubernetes.io/edge": "", "zone": "far"}),
+	}
+	affinity, err := withPoolExclusion(nil, pools)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	// Outside the gpu pool, and outside the edge pool through either of its labels
+	if len(terms) != 2 {
+		t.Fatalf("Expected 2 node selector terms, got %v", terms)
+	}
+	for _, term := range terms {
+		if len(term.MatchExpressions) != 2 || term.MatchExpressions[0].Key != "nvidia.com/gpu.present" ||
+			term.MatchExpressions[0].Operator != corev1.NodeSelectorOpNotIn {
+			t.Errorf("Expected every term to exclude the gpu pool, got %v", term.MatchExpressions)
+		}
+	}
+
+	if affinity, _ := withPoolExclusion(nil, nil); affinity != nil {
+		t.Errorf("Expected affinity unchanged without pools, got %v", affinity)
+	}
+
+	many := map[string]string{}
+	for _, key := range []string{"a", "b", "c", "d", "e", "f"} {
+		many[key] = "x"
+	}
+	if _, err := withPoolExclusion(nil, []v1alpha2.SpireAgent{newAgentPool("p1", many), newAgentPool("p2", many)}); err == nil {
+		t.Error("Expected an error when the exclusion needs too many terms")
+	}
+}
+
+func TestApplyAgentPool(t *testing.T) {
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.org"}}
+
+	ds := generateSpireAgentDaemonSet(v1alpha2.SpireAgentSpec{}, ztwim, "hash")
+	applyAgentPool(ds, utils.DefaultAgentPool)
+	if ds.Name != "spire-agent" || ds.Spec.Selector.MatchLabels[utils.AgentPoolLabel] != "" {
+		t.Errorf("Expected the default pool DaemonSet unchanged, got %s selecting %v", ds.Name, ds.Spec.Selector.MatchLabels)
+	}
+
+	ds = generateSpireAgentDaemonSet(v1alpha2.SpireAgentSpec{}, ztwim, "hash")
+	applyAgentPool(ds, "gpu")
+	if ds.Name != "spire-agent-gpu" {
+		t.Errorf("Expected DaemonSet spire-agent-gpu, got %s", ds.Name)
+	}
+	if ds.Spec.Selector.MatchLabels[utils.AgentPoolLabel] != "gpu" || ds.Spec.Template.Labels[utils.AgentPoolLabel] != "gpu" {
+		t.Errorf("Expected the pool pods to be selected by pool, got selector %v and labels %v", ds.Spec.Selector.MatchLabels, ds.Spec.Template.Labels)
+	}
+	for _, volume := range ds.Spec.Template.Spec.Volumes {
+		if volume.Name == "spire-config" && volume.ConfigMap.Name != "spire-agent-gpu" {
+			t.Errorf("Expected the pool configuration from ConfigMap spire-agent-gpu, got %s", volume.ConfigMap.Name)
+		}
+	}
+}
+
+func TestValidateAgentPools(t *testing.T) {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+		list.(*v1alpha2.SpireAgentList).Items = []v1alpha2.SpireAgent{
+			newAgentPool(utils.DefaultAgentPool, nil),
+			newAgentPool("gpu", map[string]string{"pool": "gpu"}),
+		}
+		return nil
+	}
+	r := newTestReconciler(fakeClient)
+
+	edge := newAgentPool("edge", map[string]string{"pool": "edge"})
+	if err := r.validateAgentPools(context.Background(), &edge, status.NewManager(fakeClient)); err != nil {
+		t.Errorf("Expected disjoint pools to be valid, got %v", err)
+	}
+
+	overlapping := newAgentPool("a100", map[string]string{"pool": "gpu", "gpu-model": "a100"})
+	statusMgr := status.NewManager(fakeClient)
+	err := r.validateAgentPools(context.Background(), &overlapping, statusMgr)
+	if err == nil || !strings.Contains(err.Error(), "pool gpu") {
+		t.Fatalf("Expected overlap with pool gpu, got %v", err)
+	}
+	if cond, ok := statusMgr.GetCondition(ConfigurationValid); !ok || cond.Reason != AgentPoolReasonOverlap {
+		t.Errorf("Expected ConfigurationValid with reason %s, got %v", AgentPoolReasonOverlap, cond)
+	}
+}
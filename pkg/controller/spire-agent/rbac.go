@@ -8,9 +8,11 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/operator/assets"
@@ -19,7 +21,7 @@ import (
 // Constants for status conditions are defined in controller.go
 
 // reconcileRBAC reconciles Spire Agent RBAC resources
-func (r *SpireAgentReconciler) reconcileRBAC(ctx context.Context, agent *v1alpha1.SpireAgent, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireAgentReconciler) reconcileRBAC(ctx context.Context, agent *v1alpha2.SpireAgent, statusMgr *status.Manager, createOnlyMode bool) error {
 	// ClusterRole
 	if err := r.reconcileClusterRole(ctx, agent, statusMgr, createOnlyMode); err != nil {
 		return err
@@ -32,19 +34,19 @@ func (r *SpireAgentReconciler) reconcileRBAC(ctx context.Context, agent *v1alpha
 
 	// Success status is set after ALL RBAC resources are created
 	// Set consolidated success status after all static resources are created
-	statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonReady,
+	statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonReady,
 		"All RBAC resources available",
 		metav1.ConditionTrue)
 	return nil
 }
 
 // reconcileClusterRole reconciles the Spire Agent ClusterRole
-func (r *SpireAgentReconciler) reconcileClusterRole(ctx context.Context, agent *v1alpha1.SpireAgent, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireAgentReconciler) reconcileClusterRole(ctx context.Context, agent *v1alpha2.SpireAgent, statusMgr *status.Manager, createOnlyMode bool) error {
 	desired := getSpireAgentClusterRole(agent.Spec.Labels)
 
 	if err := controllerutil.SetControllerReference(agent, desired, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference on cluster role")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to set owner reference on ClusterRole: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -58,16 +60,16 @@ func (r *SpireAgentReconciler) reconcileClusterRole(ctx context.Context, agent *
 		if !kerrors.IsNotFound(err) {
 			// Unexpected error
 			r.log.Error(err, "failed to get cluster role")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to get ClusterRole: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(agent.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create cluster role")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to create ClusterRole: %v", err),
 				metav1.ConditionFalse)
 			return err
@@ -93,7 +95,7 @@ func (r *SpireAgentReconciler) reconcileClusterRole(ctx context.Context, agent *
 	desired.ResourceVersion = existing.ResourceVersion
 	if err := r.ctrlClient.Update(ctx, desired); err != nil {
 		r.log.Error(err, "failed to update cluster role")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to update ClusterRole: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -104,12 +106,12 @@ func (r *SpireAgentReconciler) reconcileClusterRole(ctx context.Context, agent *
 }
 
 // reconcileClusterRoleBinding reconciles the Spire Agent ClusterRoleBinding
-func (r *SpireAgentReconciler) reconcileClusterRoleBinding(ctx context.Context, agent *v1alpha1.SpireAgent, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireAgentReconciler) reconcileClusterRoleBinding(ctx context.Context, agent *v1alpha2.SpireAgent, statusMgr *status.Manager, createOnlyMode bool) error {
 	desired := getSpireAgentClusterRoleBinding(agent.Spec.Labels)
 
 	if err := controllerutil.SetControllerReference(agent, desired, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference on cluster role binding")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to set owner reference on ClusterRoleBinding: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -123,16 +125,16 @@ func (r *SpireAgentReconciler) reconcileClusterRoleBinding(ctx context.Context,
 		if !kerrors.IsNotFound(err) {
 			// Unexpected error
 			r.log.Error(err, "failed to get cluster role binding")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to get ClusterRoleBinding: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(agent.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create cluster role binding")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to create ClusterRoleBinding: %v", err),
 				metav1.ConditionFalse)
 			return err
@@ -158,7 +160,7 @@ func (r *SpireAgentReconciler) reconcileClusterRoleBinding(ctx context.Context,
 	desired.ResourceVersion = existing.ResourceVersion
 	if err := r.ctrlClient.Update(ctx, desired); err != nil {
 		r.log.Error(err, "failed to update cluster role binding")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to update ClusterRoleBinding: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -6,7 +6,7 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
@@ -167,7 +167,7 @@ func TestGetSpireAgentClusterRoleBinding(t *testing.T) {
 // newRBACTestReconciler creates a reconciler for RBAC tests
 func newRBACTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireAgentReconciler {
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = rbacv1.AddToScheme(scheme)
 	return &SpireAgentReconciler{
 		ctrlClient:    fakeClient,
@@ -202,7 +202,7 @@ func TestReconcileRBAC(t *testing.T) {
 			reconciler := newRBACTestReconciler(fakeClient)
 			tt.setupClient(fakeClient)
 
-			agent := &v1alpha1.SpireAgent{
+			agent := &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			}
 			statusMgr := status.NewManager(fakeClient)
@@ -224,7 +224,7 @@ func TestReconcileRBAC(t *testing.T) {
 func TestReconcileClusterRole(t *testing.T) {
 	tests := []struct {
 		name           string
-		agent          *v1alpha1.SpireAgent
+		agent          *v1alpha2.SpireAgent
 		setupClient    func(*fakes.FakeCustomCtrlClient)
 		createOnlyMode bool
 		useEmptyScheme bool
@@ -234,7 +234,7 @@ func TestReconcileClusterRole(t *testing.T) {
 	}{
 		{
 			name: "create success",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -246,7 +246,7 @@ func TestReconcileClusterRole(t *testing.T) {
 		},
 		{
 			name: "create error",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -257,7 +257,7 @@ func TestReconcileClusterRole(t *testing.T) {
 		},
 		{
 			name: "get error",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -267,7 +267,7 @@ func TestReconcileClusterRole(t *testing.T) {
 		},
 		{
 			name: "create only mode skips update",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -290,10 +290,10 @@ func TestReconcileClusterRole(t *testing.T) {
 		},
 		{
 			name: "update success",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireAgentSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireAgentSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -319,10 +319,10 @@ func TestReconcileClusterRole(t *testing.T) {
 		},
 		{
 			name: "update error",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireAgentSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireAgentSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -348,7 +348,7 @@ func TestReconcileClusterRole(t *testing.T) {
 		},
 		{
 			name: "set controller ref error",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient:    func(fc *fakes.FakeCustomCtrlClient) {},
@@ -399,7 +399,7 @@ func TestReconcileClusterRole(t *testing.T) {
 func TestReconcileClusterRoleBinding(t *testing.T) {
 	tests := []struct {
 		name           string
-		agent          *v1alpha1.SpireAgent
+		agent          *v1alpha2.SpireAgent
 		setupClient    func(*fakes.FakeCustomCtrlClient)
 		createOnlyMode bool
 		useEmptyScheme bool
@@ -409,7 +409,7 @@ func TestReconcileClusterRoleBinding(t *testing.T) {
 	}{
 		{
 			name: "create success",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -421,7 +421,7 @@ func TestReconcileClusterRoleBinding(t *testing.T) {
 		},
 		{
 			name: "create error",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -432,7 +432,7 @@ func TestReconcileClusterRoleBinding(t *testing.T) {
 		},
 		{
 			name: "get error",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -442,7 +442,7 @@ func TestReconcileClusterRoleBinding(t *testing.T) {
 		},
 		{
 			name: "create only mode skips update",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -465,10 +465,10 @@ func TestReconcileClusterRoleBinding(t *testing.T) {
 		},
 		{
 			name: "update success",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireAgentSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireAgentSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -494,10 +494,10 @@ func TestReconcileClusterRoleBinding(t *testing.T) {
 		},
 		{
 			name: "update error",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireAgentSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireAgentSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -523,7 +523,7 @@ func TestReconcileClusterRoleBinding(t *testing.T) {
 		},
 		{
 			name: "set controller ref error",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient:    func(fc *fakes.FakeCustomCtrlClient) {},
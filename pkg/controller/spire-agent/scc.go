@@ -4,7 +4,8 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 	corev1 "k8s.io/api/core/v1"
@@ -17,9 +18,11 @@ import (
 	securityv1 "github.com/openshift/api/security/v1"
 )
 
-// generateSpireAgentSCC returns a SecurityContextConstraints object for spire-agent
-func generateSpireAgentSCC(config *v1alpha1.SpireAgent) *securityv1.SecurityContextConstraints {
-	return &securityv1.SecurityContextConstraints{
+// generateSpireAgentSCC returns a SecurityContextConstraints object for spire-agent. The agents
+// serving the Delegated Identity API run with their own SELinux type, so the SELinux context is
+// not constrained when delegatedIdentity is set.
+func generateSpireAgentSCC(config *v1alpha2.SpireAgent, delegatedIdentity bool) *securityv1.SecurityContextConstraints {
+	scc := &securityv1.SecurityContextConstraints{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:   "spire-agent",
 			Labels: utils.SpireAgentLabels(config.Spec.Labels),
@@ -61,11 +64,32 @@ func generateSpireAgentSCC(config *v1alpha1.SpireAgent) *securityv1.SecurityCont
 		},
 		Groups: []string{},
 	}
+	if delegatedIdentity {
+		scc.SELinuxContext.Type = securityv1.SELinuxStrategyRunAsAny
+	}
+	return scc
 }
 
 // reconcileSCC reconciles the Spire Agent Security Context Constraints
-func (r *SpireAgentReconciler) reconcileSCC(ctx context.Context, agent *v1alpha1.SpireAgent, statusMgr *status.Manager) error {
-	desired := generateSpireAgentSCC(agent)
+func (r *SpireAgentReconciler) reconcileSCC(ctx context.Context, agent *v1alpha2.SpireAgent, statusMgr *status.Manager) error {
+	if !utils.HasCapability(utils.CapabilitySecurityContextConstraints) {
+		// Without SecurityContextConstraints pod security admission applies: the operator
+		// namespace must allow privileged pods
+		statusMgr.AddCondition(SecurityContextConstraintsAvailable, utils.ReasonAPINotServed,
+			"SecurityContextConstraints are not served by the cluster, pod security admission applies",
+			metav1.ConditionTrue)
+		return nil
+	}
+
+	delegatedIdentity, err := r.delegatedIdentityEnabledInAnyPool(ctx, agent)
+	if err != nil {
+		r.log.Error(err, "failed to list agent pools")
+		statusMgr.AddCondition(SecurityContextConstraintsAvailable, "SpireAgentSCCGenerationFailed",
+			err.Error(),
+			metav1.ConditionFalse)
+		return err
+	}
+	desired := generateSpireAgentSCC(agent, delegatedIdentity)
 	if err := controllerutil.SetControllerReference(agent, desired, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference")
 		statusMgr.AddCondition(SecurityContextConstraintsAvailable, "SpireAgentSCCGenerationFailed",
@@ -76,7 +100,7 @@ func (r *SpireAgentReconciler) reconcileSCC(ctx context.Context, agent *v1alpha1
 
 	// Get existing resource (from cache)
 	existing := &securityv1.SecurityContextConstraints{}
-	err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: desired.Name}, existing)
+	err = r.ctrlClient.Get(ctx, types.NamespacedName{Name: desired.Name}, existing)
 
 	if err != nil {
 		if !kerrors.IsNotFound(err) {
@@ -89,7 +113,7 @@ func (r *SpireAgentReconciler) reconcileSCC(ctx context.Context, agent *v1alpha1
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(agent.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "Failed to create SpireAgentSCC")
 			statusMgr.AddCondition(SecurityContextConstraintsAvailable, "SpireAgentSCCCreationFailed",
 				err.Error(),
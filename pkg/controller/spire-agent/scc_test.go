@@ -9,7 +9,7 @@ import (
 
 	"github.com/go-logr/logr"
 	securityv1 "github.com/openshift/api/security/v1"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
@@ -25,15 +25,15 @@ func TestGenerateSpireAgentSCC(t *testing.T) {
 	customLabels := map[string]string{
 		"custom-label": "custom-value",
 	}
-	config := &v1alpha1.SpireAgent{
-		Spec: v1alpha1.SpireAgentSpec{
-			CommonConfig: v1alpha1.CommonConfig{
+	config := &v1alpha2.SpireAgent{
+		Spec: v1alpha2.SpireAgentSpec{
+			CommonConfig: v1alpha2.CommonConfig{
 				Labels: customLabels,
 			},
 		},
 	}
 
-	scc := generateSpireAgentSCC(config)
+	scc := generateSpireAgentSCC(config, false)
 	expectedLabels := utils.SpireAgentLabels(customLabels)
 
 	if scc.Name != "spire-agent" {
@@ -119,7 +119,7 @@ func TestGenerateSpireAgentSCC(t *testing.T) {
 // newSCCTestReconciler creates a reconciler for SCC tests
 func newSCCTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireAgentReconciler {
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = securityv1.AddToScheme(scheme)
 	return &SpireAgentReconciler{
 		ctrlClient:    fakeClient,
@@ -134,8 +134,8 @@ func newSCCTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireAgentRec
 func TestReconcileSCC(t *testing.T) {
 	tests := []struct {
 		name           string
-		agent          *v1alpha1.SpireAgent
-		setupClient    func(*fakes.FakeCustomCtrlClient, *v1alpha1.SpireAgent)
+		agent          *v1alpha2.SpireAgent
+		setupClient    func(*fakes.FakeCustomCtrlClient, *v1alpha2.SpireAgent)
 		useEmptyScheme bool
 		expectError    bool
 		expectCreate   bool
@@ -143,10 +143,10 @@ func TestReconcileSCC(t *testing.T) {
 	}{
 		{
 			name: "create success",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
-			setupClient: func(fc *fakes.FakeCustomCtrlClient, _ *v1alpha1.SpireAgent) {
+			setupClient: func(fc *fakes.FakeCustomCtrlClient, _ *v1alpha2.SpireAgent) {
 				fc.GetReturns(kerrors.NewNotFound(schema.GroupResource{}, "spire-agent"))
 				fc.CreateReturns(nil)
 			},
@@ -154,10 +154,10 @@ func TestReconcileSCC(t *testing.T) {
 		},
 		{
 			name: "create error",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
-			setupClient: func(fc *fakes.FakeCustomCtrlClient, _ *v1alpha1.SpireAgent) {
+			setupClient: func(fc *fakes.FakeCustomCtrlClient, _ *v1alpha2.SpireAgent) {
 				fc.GetReturns(kerrors.NewNotFound(schema.GroupResource{}, "spire-agent"))
 				fc.CreateReturns(errors.New("create failed"))
 			},
@@ -165,21 +165,21 @@ func TestReconcileSCC(t *testing.T) {
 		},
 		{
 			name: "get error",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
-			setupClient: func(fc *fakes.FakeCustomCtrlClient, _ *v1alpha1.SpireAgent) {
+			setupClient: func(fc *fakes.FakeCustomCtrlClient, _ *v1alpha2.SpireAgent) {
 				fc.GetReturns(errors.New("connection refused"))
 			},
 			expectError: true,
 		},
 		{
 			name: "no update needed",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
-			setupClient: func(fc *fakes.FakeCustomCtrlClient, agent *v1alpha1.SpireAgent) {
-				desiredSCC := generateSpireAgentSCC(agent)
+			setupClient: func(fc *fakes.FakeCustomCtrlClient, agent *v1alpha2.SpireAgent) {
+				desiredSCC := generateSpireAgentSCC(agent, false)
 				desiredSCC.ResourceVersion = "123"
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					if scc, ok := obj.(*securityv1.SecurityContextConstraints); ok {
@@ -191,15 +191,15 @@ func TestReconcileSCC(t *testing.T) {
 		},
 		{
 			name: "update success",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireAgentSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireAgentSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
 			},
-			setupClient: func(fc *fakes.FakeCustomCtrlClient, _ *v1alpha1.SpireAgent) {
+			setupClient: func(fc *fakes.FakeCustomCtrlClient, _ *v1alpha2.SpireAgent) {
 				existingSCC := &securityv1.SecurityContextConstraints{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:            "spire-agent",
@@ -219,15 +219,15 @@ func TestReconcileSCC(t *testing.T) {
 		},
 		{
 			name: "update error",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireAgentSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireAgentSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
 			},
-			setupClient: func(fc *fakes.FakeCustomCtrlClient, _ *v1alpha1.SpireAgent) {
+			setupClient: func(fc *fakes.FakeCustomCtrlClient, _ *v1alpha2.SpireAgent) {
 				existingSCC := &securityv1.SecurityContextConstraints{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:            "spire-agent",
@@ -248,10 +248,10 @@ func TestReconcileSCC(t *testing.T) {
 		},
 		{
 			name: "set controller ref error",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
-			setupClient:    func(fc *fakes.FakeCustomCtrlClient, _ *v1alpha1.SpireAgent) {},
+			setupClient:    func(fc *fakes.FakeCustomCtrlClient, _ *v1alpha2.SpireAgent) {},
 			useEmptyScheme: true,
 			expectError:    true,
 		},
@@ -296,18 +296,39 @@ func TestReconcileSCC(t *testing.T) {
 	}
 }
 
+func TestReconcileSCC_NotServed(t *testing.T) {
+	utils.SetCapabilities(utils.Capabilities{utils.CapabilitySecurityContextConstraints: false})
+	t.Cleanup(func() { utils.SetCapabilities(utils.AllCapabilities()) })
+
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	reconciler := newSCCTestReconciler(fakeClient)
+	statusMgr := status.NewManager(fakeClient)
+	agent := &v1alpha2.SpireAgent{ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"}}
+
+	if err := reconciler.reconcileSCC(context.Background(), agent, statusMgr); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if fakeClient.GetCallCount() != 0 || fakeClient.CreateCallCount() != 0 {
+		t.Error("Expected SecurityContextConstraints not to be read or created")
+	}
+	cond, ok := statusMgr.GetCondition(SecurityContextConstraintsAvailable)
+	if !ok || cond.Status != metav1.ConditionTrue || cond.Reason != utils.ReasonAPINotServed {
+		t.Errorf("Expected %s to be True with reason %s, got %+v", SecurityContextConstraintsAvailable, utils.ReasonAPINotServed, cond)
+	}
+}
+
 // TestReconcileSCC_PreservesExistingFields tests that reconcileSCC preserves OpenShift-managed fields
 func TestReconcileSCC_PreservesExistingFields(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newSCCTestReconciler(fakeClient)
 
-	agent := &v1alpha1.SpireAgent{
+	agent := &v1alpha2.SpireAgent{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
 		},
-		Spec: v1alpha1.SpireAgentSpec{
-			CommonConfig: v1alpha1.CommonConfig{
+		Spec: v1alpha2.SpireAgentSpec{
+			CommonConfig: v1alpha2.CommonConfig{
 				Labels: map[string]string{"new-label": "new-value"},
 			},
 		},
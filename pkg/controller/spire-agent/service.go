@@ -8,9 +8,11 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/operator/assets"
@@ -19,24 +21,31 @@ import (
 // Constants for status conditions are defined in controller.go
 
 // reconcileService reconciles the Service resource required for agents
-func (r *SpireAgentReconciler) reconcileService(ctx context.Context, agent *v1alpha1.SpireAgent, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireAgentReconciler) reconcileService(ctx context.Context, agent *v1alpha2.SpireAgent, statusMgr *status.Manager, createOnlyMode bool) error {
 	err := r.reconcileAgentService(ctx, agent, statusMgr, createOnlyMode)
 	if err != nil {
 		return err
 	}
-	statusMgr.AddCondition(ServiceAvailable, v1alpha1.ReasonReady,
+	statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonReady,
 		"All Service resources available",
 		metav1.ConditionTrue)
 	return nil
 }
 
 // reconcileAgentService reconciles the Spire Agent Service
-func (r *SpireAgentReconciler) reconcileAgentService(ctx context.Context, agent *v1alpha1.SpireAgent, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireAgentReconciler) reconcileAgentService(ctx context.Context, agent *v1alpha2.SpireAgent, statusMgr *status.Manager, createOnlyMode bool) error {
 	desired := getSpireAgentService(agent.Spec.Labels)
+	if err := utils.ApplyServiceConfig(desired, agent.Spec.Service); err != nil {
+		r.log.Error(err, "invalid service configuration")
+		statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonFailed,
+			fmt.Sprintf("Invalid Service configuration: %v", err),
+			metav1.ConditionFalse)
+		return err
+	}
 
 	if err := controllerutil.SetControllerReference(agent, desired, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference on service")
-		statusMgr.AddCondition(ServiceAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to set owner reference on Service: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -50,16 +59,16 @@ func (r *SpireAgentReconciler) reconcileAgentService(ctx context.Context, agent
 		if !kerrors.IsNotFound(err) {
 			// Unexpected error
 			r.log.Error(err, "failed to get service")
-			statusMgr.AddCondition(ServiceAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to get Service: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(agent.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create service")
-			statusMgr.AddCondition(ServiceAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to create Service: %v", err),
 				metav1.ConditionFalse)
 			return err
@@ -86,6 +95,7 @@ func (r *SpireAgentReconciler) reconcileAgentService(ctx context.Context, agent
 	if existing.Spec.HealthCheckNodePort != 0 {
 		desired.Spec.HealthCheckNodePort = existing.Spec.HealthCheckNodePort
 	}
+	utils.PreserveServiceNodePorts(existing, desired)
 
 	// Normalize ports - set default protocol to TCP if not specified
 	for i := range desired.Spec.Ports {
@@ -103,7 +113,7 @@ func (r *SpireAgentReconciler) reconcileAgentService(ctx context.Context, agent
 	// Update the resource
 	if err := r.ctrlClient.Update(ctx, desired); err != nil {
 		r.log.Error(err, "failed to update service")
-		statusMgr.AddCondition(ServiceAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to update Service: %v", err),
 			metav1.ConditionFalse)
 		return err
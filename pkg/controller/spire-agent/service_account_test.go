@@ -6,7 +6,8 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
@@ -16,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -98,7 +100,7 @@ func TestGetSpireAgentServiceAccount(t *testing.T) {
 // newReconcilerWithScheme creates a reconciler with scheme for testing reconcile functions
 func newReconcilerWithScheme(fakeClient *fakes.FakeCustomCtrlClient) *SpireAgentReconciler {
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 	return &SpireAgentReconciler{
 		ctrlClient:    fakeClient,
@@ -113,7 +115,7 @@ func newReconcilerWithScheme(fakeClient *fakes.FakeCustomCtrlClient) *SpireAgent
 func TestReconcileServiceAccount(t *testing.T) {
 	tests := []struct {
 		name           string
-		agent          *v1alpha1.SpireAgent
+		agent          *v1alpha2.SpireAgent
 		setupClient    func(*fakes.FakeCustomCtrlClient)
 		createOnlyMode bool
 		useEmptyScheme bool
@@ -123,7 +125,7 @@ func TestReconcileServiceAccount(t *testing.T) {
 	}{
 		{
 			name: "create success",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -134,7 +136,7 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "create error",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -145,7 +147,7 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "get error",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -155,7 +157,7 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "create only mode skips update",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -177,7 +179,7 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "no update needed",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -193,10 +195,10 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "update success",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireAgentSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireAgentSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -222,10 +224,10 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "update error",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireAgentSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireAgentSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -252,7 +254,7 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "set controller ref error",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient:    func(fc *fakes.FakeCustomCtrlClient) {},
@@ -299,3 +301,32 @@ func TestReconcileServiceAccount(t *testing.T) {
 		})
 	}
 }
+
+// TestReconcileServiceAccount_AdoptExistingResources tests that the adoptExistingResources toggle is passed to Create
+func TestReconcileServiceAccount_AdoptExistingResources(t *testing.T) {
+	for name, adopt := range map[string]*bool{"true": ptr.To(true), "false": ptr.To(false), "unset": nil} {
+		t.Run("adoptExistingResources="+name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			reconciler := newReconcilerWithScheme(fakeClient)
+			fakeClient.GetReturns(kerrors.NewNotFound(schema.GroupResource{}, "spire-agent"))
+
+			agent := &v1alpha2.SpireAgent{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
+				Spec: v1alpha2.SpireAgentSpec{
+					CommonConfig: v1alpha2.CommonConfig{AdoptExistingResources: adopt},
+				},
+			}
+			statusMgr := status.NewManager(fakeClient)
+			if err := reconciler.reconcileServiceAccount(context.Background(), agent, statusMgr, false); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if fakeClient.CreateCallCount() != 1 {
+				t.Fatalf("Expected Create to be called once, called %d times", fakeClient.CreateCallCount())
+			}
+			_, _, opts := fakeClient.CreateArgsForCall(0)
+			if len(opts) != 1 || opts[0] != customClient.AdoptExisting(ptr.Deref(adopt, false)) {
+				t.Errorf("Expected AdoptExisting(%v) create option, got %v", ptr.Deref(adopt, false), opts)
+			}
+		})
+	}
+}
@@ -6,7 +6,7 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
@@ -106,7 +106,7 @@ func TestGetSpireAgentService(t *testing.T) {
 // newServiceTestReconciler creates a reconciler for Service tests
 func newServiceTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireAgentReconciler {
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 	return &SpireAgentReconciler{
 		ctrlClient:    fakeClient,
@@ -121,7 +121,7 @@ func newServiceTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireAgen
 func TestReconcileAgentService(t *testing.T) {
 	tests := []struct {
 		name           string
-		agent          *v1alpha1.SpireAgent
+		agent          *v1alpha2.SpireAgent
 		setupClient    func(*fakes.FakeCustomCtrlClient)
 		createOnlyMode bool
 		useEmptyScheme bool
@@ -131,7 +131,7 @@ func TestReconcileAgentService(t *testing.T) {
 	}{
 		{
 			name: "create success",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -142,7 +142,7 @@ func TestReconcileAgentService(t *testing.T) {
 		},
 		{
 			name: "create error",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -153,7 +153,7 @@ func TestReconcileAgentService(t *testing.T) {
 		},
 		{
 			name: "get error",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -163,7 +163,7 @@ func TestReconcileAgentService(t *testing.T) {
 		},
 		{
 			name: "create only mode skips update",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -185,10 +185,10 @@ func TestReconcileAgentService(t *testing.T) {
 		},
 		{
 			name: "update success",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireAgentSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireAgentSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -215,10 +215,10 @@ func TestReconcileAgentService(t *testing.T) {
 		},
 		{
 			name: "update error",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireAgentSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireAgentSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -246,7 +246,7 @@ func TestReconcileAgentService(t *testing.T) {
 		},
 		{
 			name: "set controller ref error",
-			agent: &v1alpha1.SpireAgent{
+			agent: &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient:    func(fc *fakes.FakeCustomCtrlClient) {},
@@ -325,7 +325,7 @@ func TestReconcileService(t *testing.T) {
 			reconciler := newServiceTestReconciler(fakeClient)
 			tt.setupClient(fakeClient)
 
-			agent := &v1alpha1.SpireAgent{
+			agent := &v1alpha2.SpireAgent{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			}
 
@@ -0,0 +1,175 @@
+package spire_agent
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// Reasons of the AgentSocketExposed condition
+const (
+	SocketExposureReasonDetected      = "AgentSocketExposed"
+	SocketExposureReasonIsolated      = "AgentSocketIsolated"
+	SocketExposureReasonUnavailable   = "SocketExposureAuditUnavailable"
+	SocketExposureReasonNotConfigured = "SocketExposureAuditNotConfigured"
+)
+
+// maxSocketExposureViolations caps the pods recorded in status.socketExposureViolations
+const maxSocketExposureViolations = 50
+
+// socketExposureAuditEnabled returns whether the socket exposure audit is enabled
+func socketExposureAuditEnabled(agent *v1alpha2.SpireAgent) bool {
+	return agent.Spec.SocketExposureAudit != nil && ptr.Deref(agent.Spec.SocketExposureAudit.Enabled, false)
+}
+
+// exposesSocketDir reports whether a host path gives access to a socket directory: the directory
+// itself, one of its parents, or a path inside it such as the socket
+func exposesSocketDir(hostPath, socketDir string) bool {
+	hostPath, socketDir = path.Clean(hostPath), path.Clean(socketDir)
+	return hostPath == socketDir ||
+		hostPath == "/" ||
+		strings.HasPrefix(socketDir, hostPath+"/") ||
+		strings.HasPrefix(hostPath, socketDir+"/")
+}
+
+// socketExposureViolations returns the hostPath volumes of the pods exposing one of the socket
+// directories, skipping the pods of the operator and the pods of the allowed service accounts
+func socketExposureViolations(pods []corev1.Pod, socketDirs []string, allowedServiceAccounts []string, operatorNamespace string) []v1alpha2.SocketExposureViolation {
+	allowed := make(map[string]bool, len(allowedServiceAccounts))
+	for _, serviceAccount := range allowedServiceAccounts {
+		allowed[serviceAccount] = true
+	}
+	var violations []v1alpha2.SocketExposureViolation
+	for _, pod := range pods {
+		// The agents, the CSI driver and the health probe of the operator mount the directory
+		if pod.Namespace == operatorNamespace && pod.Labels[utils.AppManagedByLabelKey] == utils.AppManagedByLabelValue {
+			continue
+		}
+		serviceAccount := pod.Spec.ServiceAccountName
+		if serviceAccount == "" {
+			serviceAccount = "default"
+		}
+		if allowed[pod.Namespace+":"+serviceAccount] {
+			continue
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.HostPath == nil {
+				continue
+			}
+			for _, socketDir := range socketDirs {
+				if exposesSocketDir(volume.HostPath.Path, socketDir) {
+					violations = append(violations, v1alpha2.SocketExposureViolation{
+						Namespace: pod.Namespace,
+						Pod:       pod.Name,
+						Volume:    volume.Name,
+						HostPath:  volume.HostPath.Path,
+					})
+					break
+				}
+			}
+		}
+	}
+	sort.Slice(violations, func(i, j int) bool {
+		a, b := violations[i], violations[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Pod != b.Pod {
+			return a.Pod < b.Pod
+		}
+		return a.Volume < b.Volume
+	})
+	return violations
+}
+
+// reconcileSocketExposure audits the pods of the cluster for hostPath volumes exposing the agent
+// socket directory of any agent pool, which gives the pod the Workload API without the SPIFFE
+// CSI driver and outside of what the cluster administrator reviewed. The pods found are recorded
+// in status and reported through the AgentSocketExposed condition, emitting a warning Event for
+// every newly found pod. The audit does not evict the pods.
+func (r *SpireAgentReconciler) reconcileSocketExposure(ctx context.Context, agent *v1alpha2.SpireAgent, statusMgr *status.Manager) {
+	if !socketExposureAuditEnabled(agent) {
+		if apimeta.FindStatusCondition(agent.Status.Conditions, utils.AgentSocketExposedStatusType) != nil {
+			agent.Status.SocketExposureViolations = nil
+			statusMgr.AddCondition(utils.AgentSocketExposedStatusType, SocketExposureReasonNotConfigured,
+				"The socket exposure audit is disabled",
+				metav1.ConditionFalse)
+		}
+		return
+	}
+
+	socketDirs := []string{agent.Spec.SocketPath}
+	pools, err := r.listAgentPools(ctx)
+	if err != nil {
+		statusMgr.AddCondition(utils.AgentSocketExposedStatusType, SocketExposureReasonUnavailable,
+			err.Error(),
+			metav1.ConditionUnknown)
+		return
+	}
+	for _, pool := range pools {
+		socketDirs = append(socketDirs, pool.Spec.SocketPath)
+	}
+
+	// Pods of the workloads are not cached, they are listed from the API server
+	var pods corev1.PodList
+	if err := r.ctrlClient.ListUncached(ctx, &pods); err != nil {
+		statusMgr.AddCondition(utils.AgentSocketExposedStatusType, SocketExposureReasonUnavailable,
+			fmt.Sprintf("Failed to list the pods: %v", err),
+			metav1.ConditionUnknown)
+		return
+	}
+
+	violations := socketExposureViolations(pods.Items, socketDirs, agent.Spec.SocketExposureAudit.AllowedServiceAccounts, utils.GetOperatorNamespace())
+	previous := make(map[string]bool, len(agent.Status.SocketExposureViolations))
+	for _, violation := range agent.Status.SocketExposureViolations {
+		previous[violation.Namespace+"/"+violation.Pod] = true
+	}
+	if len(violations) > maxSocketExposureViolations {
+		agent.Status.SocketExposureViolations = violations[:maxSocketExposureViolations]
+	} else {
+		agent.Status.SocketExposureViolations = violations
+	}
+
+	if len(violations) == 0 {
+		statusMgr.AddCondition(utils.AgentSocketExposedStatusType, SocketExposureReasonIsolated,
+			fmt.Sprintf("No pod of the %d audited mounts the agent socket directory through a hostPath volume", len(pods.Items)),
+			metav1.ConditionFalse)
+		return
+	}
+
+	var names []string
+	seen := map[string]bool{}
+	for _, violation := range violations {
+		pod := violation.Namespace + "/" + violation.Pod
+		if seen[pod] {
+			continue
+		}
+		seen[pod] = true
+		if len(names) < maxReportedFailingNodes {
+			names = append(names, pod)
+		}
+		// Warn once per pod when it is found, not on every audit
+		if !previous[pod] {
+			message := fmt.Sprintf("Pod %s mounts %s through hostPath volume %s, exposing the SPIRE agent socket without the SPIFFE CSI driver. Remove the volume or allow the service account of the pod in spec.socketExposureAudit.allowedServiceAccounts",
+				pod, violation.HostPath, violation.Volume)
+			r.eventRecorder.Event(agent, corev1.EventTypeWarning, SocketExposureReasonDetected, utils.WithRunbook(SocketExposureReasonDetected, message))
+		}
+	}
+	if len(seen) > len(names) {
+		names = append(names, fmt.Sprintf("and %d more", len(seen)-len(names)))
+	}
+	statusMgr.AddCondition(utils.AgentSocketExposedStatusType, SocketExposureReasonDetected,
+		fmt.Sprintf("%d pod(s) mount the agent socket directory through a hostPath volume: %s", len(seen), strings.Join(names, ", ")),
+		metav1.ConditionTrue)
+}
@@ -0,0 +1,168 @@
+package spire_agent
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func hostPathPod(namespace, name, serviceAccount string, labels map[string]string, hostPaths ...string) corev1.Pod {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+		Spec:       corev1.PodSpec{ServiceAccountName: serviceAccount},
+	}
+	for i, hostPath := range hostPaths {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name:         "host-" + string(rune('a'+i)),
+			VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: hostPath}},
+		})
+	}
+	return pod
+}
+
+func TestExposesSocketDir(t *testing.T) {
+	tests := []struct {
+		hostPath string
+		expected bool
+	}{
+		{hostPath: "/run/spire/agent-sockets", expected: true},
+		{hostPath: "/run/spire/agent-sockets/", expected: true},
+		{hostPath: "/run/spire/agent-sockets/spire-agent.sock", expected: true},
+		{hostPath: "/run/spire", expected: true},
+		{hostPath: "/run", expected: true},
+		{hostPath: "/", expected: true},
+		{hostPath: "/run/spire/agent-sockets-other"},
+		{hostPath: "/run/spire/agent-admin"},
+		{hostPath: "/var/log"},
+	}
+	for _, tt := range tests {
+		if got := exposesSocketDir(tt.hostPath, "/run/spire/agent-sockets"); got != tt.expected {
+			t.Errorf("Expected %s to expose the socket directory %v, got %v", tt.hostPath, tt.expected, got)
+		}
+	}
+}
+
+func TestSocketExposureViolations(t *testing.T) {
+	operatorLabels := map[string]string{utils.AppManagedByLabelKey: utils.AppManagedByLabelValue}
+	pods := []corev1.Pod{
+		hostPathPod("test-ns", "spire-agent-abc", "spire-agent", operatorLabels, "/run/spire/agent-sockets"),
+		hostPathPod("mesh", "node-proxy-abc", "node-proxy", nil, "/run/spire/agent-sockets"),
+		hostPathPod("rogue", "debug", "", nil, "/var/log", "/run"),
+		hostPathPod("rogue", "collector", "collector", operatorLabels, "/run/spire/pool-sockets/spire-agent.sock"),
+		hostPathPod("apps", "app", "app", nil),
+	}
+
+	violations := socketExposureViolations(pods, []string{"/run/spire/agent-sockets", "/run/spire/pool-sockets"}, []string{"mesh:node-proxy"}, "test-ns")
+	expected := []v1alpha2.SocketExposureViolation{
+		{Namespace: "rogue", Pod: "collector", Volume: "host-a", HostPath: "/run/spire/pool-sockets/spire-agent.sock"},
+		{Namespace: "rogue", Pod: "debug", Volume: "host-b", HostPath: "/run"},
+	}
+	if len(violations) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, violations)
+	}
+	for i := range expected {
+		if violations[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected[i], violations[i])
+		}
+	}
+}
+
+func TestReconcileSocketExposure(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+	pods := []corev1.Pod{
+		hostPathPod("rogue", "debug", "default", nil, "/run/spire/agent-sockets"),
+		hostPathPod("apps", "app", "app", nil),
+	}
+	enabled := &v1alpha2.SocketExposureAuditConfig{Enabled: ptr.To(true)}
+
+	tests := []struct {
+		name             string
+		audit            *v1alpha2.SocketExposureAuditConfig
+		previous         []v1alpha2.SocketExposureViolation
+		conditions       []metav1.Condition
+		expectReason     string
+		expectStatus     metav1.ConditionStatus
+		expectViolations int
+		expectEvents     int
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:         "disabled after an audit",
+			previous:     []v1alpha2.SocketExposureViolation{{Namespace: "rogue", Pod: "debug", Volume: "host-a", HostPath: "/run"}},
+			conditions:   []metav1.Condition{{Type: utils.AgentSocketExposedStatusType, Status: metav1.ConditionTrue}},
+			expectReason: SocketExposureReasonNotConfigured,
+			expectStatus: metav1.ConditionFalse,
+		},
+		{
+			name:             "pod mounting the socket",
+			audit:            enabled,
+			expectReason:     SocketExposureReasonDetected,
+			expectStatus:     metav1.ConditionTrue,
+			expectViolations: 1,
+			expectEvents:     1,
+		},
+		{
+			name:             "pod already reported",
+			audit:            enabled,
+			previous:         []v1alpha2.SocketExposureViolation{{Namespace: "rogue", Pod: "debug", Volume: "host-a", HostPath: "/run/spire/agent-sockets"}},
+			expectReason:     SocketExposureReasonDetected,
+			expectStatus:     metav1.ConditionTrue,
+			expectViolations: 1,
+		},
+		{
+			name:         "pod allowed",
+			audit:        &v1alpha2.SocketExposureAuditConfig{Enabled: ptr.To(true), AllowedServiceAccounts: []string{"rogue:default"}},
+			expectReason: SocketExposureReasonIsolated,
+			expectStatus: metav1.ConditionFalse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.ListUncachedStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				list.(*corev1.PodList).Items = pods
+				return nil
+			}
+			recorder := record.NewFakeRecorder(10)
+			reconciler := newTestReconciler(fakeClient)
+			reconciler.eventRecorder = recorder
+			agent := &v1alpha2.SpireAgent{ObjectMeta: metav1.ObjectMeta{Name: utils.DefaultAgentPool}}
+			agent.Spec.SocketPath = "/run/spire/agent-sockets"
+			agent.Spec.SocketExposureAudit = tt.audit
+			agent.Status.SocketExposureViolations = tt.previous
+			agent.Status.Conditions = tt.conditions
+			statusMgr := status.NewManager(fakeClient)
+
+			reconciler.reconcileSocketExposure(context.Background(), agent, statusMgr)
+			cond, ok := statusMgr.GetCondition(utils.AgentSocketExposedStatusType)
+			if tt.expectReason == "" {
+				if ok || fakeClient.ListUncachedCallCount() != 0 {
+					t.Errorf("Expected no audit, got %v", cond)
+				}
+				return
+			}
+			if cond.Reason != tt.expectReason || cond.Status != tt.expectStatus {
+				t.Errorf("Expected %s/%s, got %s/%s: %s", tt.expectStatus, tt.expectReason, cond.Status, cond.Reason, cond.Message)
+			}
+			if len(agent.Status.SocketExposureViolations) != tt.expectViolations {
+				t.Errorf("Expected %d violations, got %v", tt.expectViolations, agent.Status.SocketExposureViolations)
+			}
+			if len(recorder.Events) != tt.expectEvents {
+				t.Errorf("Expected %d events, got %d", tt.expectEvents, len(recorder.Events))
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package spire_agent
+
+import (
+	"context"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/telemetry"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// reconcileTelemetryBridge exposes the metrics of the statsd telemetry bridges of the agents
+func (r *SpireAgentReconciler) reconcileTelemetryBridge(ctx context.Context, agent *v1alpha2.SpireAgent, statusMgr *status.Manager, createOnlyMode bool) error {
+	desired := telemetry.GenerateService("spire-agent", utils.SpireAgentLabels(agent.Spec.Labels), map[string]string{
+		"app.kubernetes.io/name":     "spire-agent",
+		"app.kubernetes.io/instance": utils.StandardInstance,
+	}, agent.Spec.Telemetry)
+	if err := telemetry.ReconcileService(ctx, r.ctrlClient, r.scheme, agent, desired, agent.Spec.Telemetry, agent.Status.Conditions,
+		statusMgr, createOnlyMode, ptr.Deref(agent.Spec.AdoptExistingResources, false)); err != nil {
+		r.log.Error(err, "failed to reconcile the telemetry bridge Service")
+		return err
+	}
+	return nil
+}
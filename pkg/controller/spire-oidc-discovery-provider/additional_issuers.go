@@ -0,0 +1,304 @@
+package spire_oidc_discovery_provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	// AdditionalIssuersValid reports whether the keys served for the additional issuers can be
+	// told apart from each other and from the keys of the jwtIssuer
+	AdditionalIssuersValid = "AdditionalIssuersValid"
+
+	AdditionalIssuersReasonValid          = "AdditionalIssuersValid"
+	AdditionalIssuersReasonKeyIDCollision = "KeyIDCollision"
+	AdditionalIssuersReasonUnavailable    = "AdditionalIssuersKeysUnavailable"
+	AdditionalIssuersReasonNotConfigured  = "AdditionalIssuersNotConfigured"
+
+	// maxAdditionalIssuers is the maximum number of additional issuers, each served by its own
+	// container and Route
+	maxAdditionalIssuers = 4
+
+	// The additional issuers are served on the ports following those of the jwtIssuer
+	additionalIssuerHTTPSPortBase  = 8444
+	additionalIssuerHealthPortBase = 8009
+
+	// jwtSVIDKeyUse is the use of the JWT signing keys in a SPIFFE bundle
+	jwtSVIDKeyUse = "jwt-svid"
+)
+
+// additionalIssuerName returns the name of the container and Route serving the additional issuer
+// at the given index
+func additionalIssuerName(index int) string {
+	return fmt.Sprintf("spire-oidc-discovery-provider-issuer-%d", index)
+}
+
+// additionalIssuerConfigKey returns the key of the configuration of the additional issuer at the
+// given index in the ConfigMap
+func additionalIssuerConfigKey(index int) string {
+	return fmt.Sprintf("oidc-discovery-provider-issuer-%d.conf", index)
+}
+
+// additionalIssuerPortName returns the name of the port serving the additional issuer at the
+// given index
+func additionalIssuerPortName(index int) string {
+	return fmt.Sprintf("https-issuer-%d", index)
+}
+
+// validateAdditionalIssuers checks the additional issuers are served from their own host and
+// from distinct trust domains other than the trust domain of the cluster
+func validateAdditionalIssuers(issuers []v1alpha2.OIDCAdditionalIssuer, jwtIssuer, trustDomain string) error {
+	if len(issuers) > maxAdditionalIssuers {
+		return fmt.Errorf("at most %d additional issuers are supported, got %d", maxAdditionalIssuers, len(issuers))
+	}
+	issuerHost, err := utils.StripProtocolFromJWTIssuer(jwtIssuer)
+	if err != nil {
+		return err
+	}
+	hosts := map[string]bool{issuerHost: true}
+	trustDomains := map[string]bool{trustDomain: true}
+	for _, issuer := range issuers {
+		if err := utils.IsValidURL(issuer.Issuer); err != nil {
+			return fmt.Errorf("invalid additional issuer %s: %w", issuer.Issuer, err)
+		}
+		host, err := utils.StripProtocolFromJWTIssuer(issuer.Issuer)
+		if err != nil {
+			return fmt.Errorf("invalid additional issuer %s: %w", issuer.Issuer, err)
+		}
+		if hosts[host] {
+			return fmt.Errorf("additional issuer %s is served from host %s, which serves another issuer", issuer.Issuer, host)
+		}
+		hosts[host] = true
+		if trustDomains[issuer.TrustDomain] {
+			if issuer.TrustDomain == trustDomain {
+				return fmt.Errorf("additional issuer %s serves the keys of trust domain %s, which are served for the jwtIssuer", issuer.Issuer, issuer.TrustDomain)
+			}
+			return fmt.Errorf("trust domain %s is served by several additional issuers", issuer.TrustDomain)
+		}
+		trustDomains[issuer.TrustDomain] = true
+	}
+	return nil
+}
+
+// additionalIssuerTrustDomains returns the trust domains of the additional issuers, which the OIDC
+// discovery provider federates with to receive their bundles from the Workload API
+func additionalIssuerTrustDomains(issuers []v1alpha2.OIDCAdditionalIssuer) []string {
+	var trustDomains []string
+	for _, issuer := range issuers {
+		trustDomains = append(trustDomains, issuer.TrustDomain)
+	}
+	return trustDomains
+}
+
+// additionalIssuerConfig returns the configuration of the provider serving the additional issuer
+// at the given index, derived from the configuration of the jwtIssuer
+func additionalIssuerConfig(base map[string]interface{}, index int, issuer v1alpha2.OIDCAdditionalIssuer) (map[string]interface{}, error) {
+	host, err := utils.StripProtocolFromJWTIssuer(issuer.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid additional issuer %s: %w", issuer.Issuer, err)
+	}
+	config := make(map[string]interface{}, len(base))
+	for key, value := range base {
+		config[key] = value
+	}
+	config["domains"] = []string{host}
+	config["health_checks"] = map[string]string{
+		"bind_port":  fmt.Sprintf("%d", additionalIssuerHealthPortBase+index),
+		"live_path":  "/live",
+		"ready_path": "/ready",
+	}
+	servingCert := map[string]string{}
+	for key, value := range base["serving_cert_file"].(map[string]string) {
+		servingCert[key] = value
+	}
+	servingCert["addr"] = fmt.Sprintf(":%d", additionalIssuerHTTPSPortBase+index)
+	config["serving_cert_file"] = servingCert
+	workloadAPI := map[string]string{}
+	for key, value := range base["workload_api"].(map[string]string) {
+		workloadAPI[key] = value
+	}
+	workloadAPI["trust_domain"] = issuer.TrustDomain
+	config["workload_api"] = workloadAPI
+	return config, nil
+}
+
+// addAdditionalIssuerContainers adds a provider container per additional issuer to the pod, each
+// serving the keys of its trust domain on its own ports
+func addAdditionalIssuerContainers(podSpec *corev1.PodSpec, issuers []v1alpha2.OIDCAdditionalIssuer) {
+	if len(podSpec.Containers) == 0 {
+		return
+	}
+	for index := range issuers {
+		container := podSpec.Containers[0].DeepCopy()
+		container.Name = additionalIssuerName(index)
+		configPath := "/run/spire/oidc/config/" + additionalIssuerConfigKey(index)
+		container.Args = []string{"-config", configPath}
+		healthPortName := fmt.Sprintf("healthz-%d", index)
+		container.Ports = []corev1.ContainerPort{
+			{Name: healthPortName, ContainerPort: int32(additionalIssuerHealthPortBase + index), Protocol: corev1.ProtocolTCP},
+			{Name: additionalIssuerPortName(index), ContainerPort: int32(additionalIssuerHTTPSPortBase + index), Protocol: corev1.ProtocolTCP},
+		}
+		for i := range container.VolumeMounts {
+			if container.VolumeMounts[i].Name == "spire-oidc-config" {
+				container.VolumeMounts[i].MountPath = configPath
+				container.VolumeMounts[i].SubPath = additionalIssuerConfigKey(index)
+			}
+		}
+		for _, probe := range []*corev1.Probe{container.ReadinessProbe, container.LivenessProbe} {
+			if probe != nil && probe.HTTPGet != nil {
+				probe.HTTPGet.Port = intstr.FromString(healthPortName)
+			}
+		}
+		podSpec.Containers = append(podSpec.Containers, *container)
+	}
+}
+
+// addAdditionalIssuerPorts exposes the ports of the additional issuers on the Service
+func addAdditionalIssuerPorts(svc *corev1.Service, issuers []v1alpha2.OIDCAdditionalIssuer) {
+	for index := range issuers {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+			Name:       additionalIssuerPortName(index),
+			Port:       int32(additionalIssuerHTTPSPortBase + index),
+			TargetPort: intstr.FromString(additionalIssuerPortName(index)),
+			Protocol:   corev1.ProtocolTCP,
+		})
+	}
+}
+
+// reconcileAdditionalIssuerRoutes creates a Route per additional issuer, and deletes the Routes of
+// the issuers that were removed
+func (r *SpireOidcDiscoveryProviderReconciler) reconcileAdditionalIssuerRoutes(ctx context.Context, oidc *v1alpha2.SpireOIDCDiscoveryProvider, statusMgr *status.Manager, createOnlyMode bool) error {
+	if !utils.HasCapability(utils.CapabilityRoute) {
+		return nil
+	}
+
+	var hosts []string
+	if ptr.Deref(oidc.Spec.ManagedRoute, false) {
+		for _, issuer := range oidc.Spec.AdditionalIssuers {
+			host, err := utils.StripProtocolFromJWTIssuer(issuer.Issuer)
+			if err != nil {
+				return r.additionalIssuerRouteFailed(statusMgr, err)
+			}
+			hosts = append(hosts, host)
+		}
+	}
+	if err := r.reconcileHostRoutes(ctx, oidc, hosts, maxAdditionalIssuers, additionalIssuerName, additionalIssuerPortName, createOnlyMode); err != nil {
+		return r.additionalIssuerRouteFailed(statusMgr, err)
+	}
+	return nil
+}
+
+func (r *SpireOidcDiscoveryProviderReconciler) additionalIssuerRouteFailed(statusMgr *status.Manager, err error) error {
+	r.log.Error(err, "Failed to reconcile additional issuer routes")
+	statusMgr.AddCondition(RouteAvailable, "AdditionalIssuerRouteFailed",
+		fmt.Sprintf("Failed to reconcile additional issuer routes: %v", err),
+		metav1.ConditionFalse)
+	return err
+}
+
+// spiffeBundle is the part of a bundle in the SPIFFE format holding its keys
+type spiffeBundle struct {
+	Keys []struct {
+		Use string `json:"use"`
+		Kid string `json:"kid"`
+	} `json:"keys"`
+}
+
+// jwtKeyIDs returns the IDs of the JWT signing keys of a bundle in the SPIFFE format
+func jwtKeyIDs(bundle []byte) ([]string, error) {
+	var decoded spiffeBundle
+	if err := json.Unmarshal(bundle, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode the bundle: %w", err)
+	}
+	var keyIDs []string
+	for _, key := range decoded.Keys {
+		if key.Use == jwtSVIDKeyUse && key.Kid != "" {
+			keyIDs = append(keyIDs, key.Kid)
+		}
+	}
+	return keyIDs, nil
+}
+
+// keyIDCollisions returns the key IDs served by more than one trust domain, with the trust
+// domains serving them, sorted by key ID
+func keyIDCollisions(keyIDs map[string][]string) []string {
+	owners := map[string][]string{}
+	for trustDomain, ids := range keyIDs {
+		for _, id := range ids {
+			owners[id] = append(owners[id], trustDomain)
+		}
+	}
+	var collisions []string
+	for id, trustDomains := range owners {
+		if len(trustDomains) > 1 {
+			sort.Strings(trustDomains)
+			collisions = append(collisions, fmt.Sprintf("%s (%s)", id, strings.Join(trustDomains, ", ")))
+		}
+	}
+	sort.Strings(collisions)
+	return collisions
+}
+
+// reconcileAdditionalIssuerKeys checks the JWT signing keys served for the jwtIssuer and the
+// additional issuers do not share key IDs, which relying parties trusting several of the issuers
+// cannot tell apart. The bundles are read from the SPIRE server; the check is best effort and
+// reported as Unknown while the server cannot be reached.
+func (r *SpireOidcDiscoveryProviderReconciler) reconcileAdditionalIssuerKeys(ctx context.Context, oidc *v1alpha2.SpireOIDCDiscoveryProvider, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager) {
+	if len(oidc.Spec.AdditionalIssuers) == 0 {
+		// Only clear the condition if it was previously reported
+		if apimeta.FindStatusCondition(oidc.Status.Conditions, AdditionalIssuersValid) != nil {
+			statusMgr.AddCondition(AdditionalIssuersValid, AdditionalIssuersReasonNotConfigured,
+				"No additional issuers are configured",
+				metav1.ConditionTrue)
+		}
+		return
+	}
+	if r.cli == nil {
+		return
+	}
+
+	keyIDs := map[string][]string{}
+	trustDomains := append([]string{utils.TrustDomain(ztwim)}, additionalIssuerTrustDomains(oidc.Spec.AdditionalIssuers)...)
+	for i, trustDomain := range trustDomains {
+		command := []string{"spire-server", "bundle", "show", "-format", "spiffe"}
+		if i > 0 {
+			command = []string{"spire-server", "bundle", "list", "-id", "spiffe://" + trustDomain, "-format", "spiffe"}
+		}
+		output, err := r.cli.Run(ctx, utils.GetOperatorNamespace(), command)
+		if err == nil {
+			keyIDs[trustDomain], err = jwtKeyIDs(output)
+		}
+		if err != nil {
+			r.log.V(1).Info("JWT signing keys not available", "trustDomain", trustDomain, "reason", err.Error())
+			statusMgr.AddCondition(AdditionalIssuersValid, AdditionalIssuersReasonUnavailable,
+				fmt.Sprintf("The JWT signing keys of trust domain %s are not available: %v", trustDomain, err),
+				metav1.ConditionUnknown)
+			return
+		}
+	}
+
+	if collisions := keyIDCollisions(keyIDs); len(collisions) > 0 {
+		message := fmt.Sprintf("JWT signing keys of several served trust domains share key IDs: %s", strings.Join(collisions, ", "))
+		if !apimeta.IsStatusConditionFalse(oidc.Status.Conditions, AdditionalIssuersValid) {
+			r.eventRecorder.Event(oidc, corev1.EventTypeWarning, AdditionalIssuersReasonKeyIDCollision, utils.WithRunbook(AdditionalIssuersReasonKeyIDCollision, message))
+		}
+		statusMgr.AddCondition(AdditionalIssuersValid, AdditionalIssuersReasonKeyIDCollision, message, metav1.ConditionFalse)
+		return
+	}
+	statusMgr.AddCondition(AdditionalIssuersValid, AdditionalIssuersReasonValid,
+		fmt.Sprintf("The JWT signing keys of the %d additional issuers have distinct key IDs", len(oidc.Spec.AdditionalIssuers)),
+		metav1.ConditionTrue)
+}
@@ -0,0 +1,247 @@
+package spire_oidc_discovery_provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+)
+
+// bundleCLI answers the bundle commands with the bundle of the trust domain they name
+type bundleCLI struct {
+	bundles map[string]string
+}
+
+func (f *bundleCLI) Run(_ context.Context, _ string, command []string) ([]byte, error) {
+	trustDomain := "example.org"
+	for i, arg := range command {
+		if arg == "-id" && i+1 < len(command) {
+			trustDomain = strings.TrimPrefix(command[i+1], "spiffe://")
+		}
+	}
+	bundle, ok := f.bundles[trustDomain]
+	if !ok {
+		return nil, errors.New("no bundle for " + trustDomain)
+	}
+	return []byte(bundle), nil
+}
+
+func jwtBundle(keyIDs ...string) string {
+	keys := []string{`{"use":"x509-svid","kty":"EC"}`}
+	for _, id := range keyIDs {
+		keys = append(keys, `{"use":"jwt-svid","kty":"EC","kid":"`+id+`"}`)
+	}
+	return `{"keys":[` + strings.Join(keys, ",") + `]}`
+}
+
+func TestValidateAdditionalIssuers(t *testing.T) {
+	tests := []struct {
+		name    string
+		issuers []v1alpha2.OIDCAdditionalIssuer
+		wantErr string
+	}{
+		{
+			name:    "distinct issuers",
+			issuers: []v1alpha2.OIDCAdditionalIssuer{{Issuer: "https://oidc.partner.com", TrustDomain: "partner.org"}},
+		},
+		{
+			name:    "host of the jwtIssuer",
+			issuers: []v1alpha2.OIDCAdditionalIssuer{{Issuer: "https://oidc.example.com", TrustDomain: "partner.org"}},
+			wantErr: "serves another issuer",
+		},
+		{
+			name:    "trust domain of the cluster",
+			issuers: []v1alpha2.OIDCAdditionalIssuer{{Issuer: "https://oidc.partner.com", TrustDomain: "example.org"}},
+			wantErr: "served for the jwtIssuer",
+		},
+		{
+			name: "trust domain served twice",
+			issuers: []v1alpha2.OIDCAdditionalIssuer{
+				{Issuer: "https://oidc.partner.com", TrustDomain: "partner.org"},
+				{Issuer: "https://oidc2.partner.com", TrustDomain: "partner.org"},
+			},
+			wantErr: "several additional issuers",
+		},
+		{
+			name:    "invalid issuer",
+			issuers: []v1alpha2.OIDCAdditionalIssuer{{Issuer: "not a url", TrustDomain: "partner.org"}},
+			wantErr: "invalid additional issuer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAdditionalIssuers(tt.issuers, "https://oidc.example.com", "example.org")
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestGenerateOIDCConfigMapFromCR_AdditionalIssuers(t *testing.T) {
+	oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
+			JwtIssuer:         "https://oidc.example.com",
+			AdditionalIssuers: []v1alpha2.OIDCAdditionalIssuer{{Issuer: "https://oidc.partner.com", TrustDomain: "partner.org"}},
+		},
+	}
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.org"}}
+
+	cm, err := generateOIDCConfigMapFromCR(oidc, ztwim, nil)
+	require.NoError(t, err)
+	var config struct {
+		Domains      []string          `json:"domains"`
+		HealthChecks map[string]string `json:"health_checks"`
+		ServingCert  map[string]string `json:"serving_cert_file"`
+		WorkloadAPI  map[string]string `json:"workload_api"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(cm.Data[additionalIssuerConfigKey(0)]), &config))
+	assert.Equal(t, []string{"oidc.partner.com"}, config.Domains)
+	assert.Equal(t, "8009", config.HealthChecks["bind_port"])
+	assert.Equal(t, ":8444", config.ServingCert["addr"])
+	assert.Equal(t, "partner.org", config.WorkloadAPI["trust_domain"])
+
+	// The configuration of the jwtIssuer is left as is
+	require.NoError(t, json.Unmarshal([]byte(cm.Data["oidc-discovery-provider.conf"]), &config))
+	assert.Equal(t, "example.org", config.WorkloadAPI["trust_domain"])
+	assert.Equal(t, ":8443", config.ServingCert["addr"])
+}
+
+func TestGenerateDeployment_AdditionalIssuers(t *testing.T) {
+	oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
+			JwtIssuer:         "https://oidc.example.com",
+			AdditionalIssuers: []v1alpha2.OIDCAdditionalIssuer{{Issuer: "https://oidc.partner.com", TrustDomain: "partner.org"}},
+		},
+	}
+
+	deployment := generateDeployment(oidc, "hash")
+	containers := deployment.Spec.Template.Spec.Containers
+	require.Len(t, containers, 2)
+	issuer := containers[1]
+	assert.Equal(t, additionalIssuerName(0), issuer.Name)
+	assert.Equal(t, []string{"-config", "/run/spire/oidc/config/" + additionalIssuerConfigKey(0)}, issuer.Args)
+	assert.Equal(t, int32(8444), issuer.Ports[1].ContainerPort)
+	assert.Equal(t, "healthz-0", issuer.ReadinessProbe.HTTPGet.Port.StrVal)
+	assert.Equal(t, "healthz", containers[0].ReadinessProbe.HTTPGet.Port.StrVal, "the jwtIssuer container is left as is")
+	for _, mount := range issuer.VolumeMounts {
+		if mount.Name == "spire-oidc-config" {
+			assert.Equal(t, additionalIssuerConfigKey(0), mount.SubPath)
+		}
+	}
+}
+
+func TestKeyIDCollisions(t *testing.T) {
+	collisions := keyIDCollisions(map[string][]string{
+		"example.org": {"a", "b"},
+		"partner.org": {"b", "c"},
+		"other.org":   {"d"},
+	})
+	assert.Equal(t, []string{"b (example.org, partner.org)"}, collisions)
+	assert.Empty(t, keyIDCollisions(map[string][]string{"example.org": {"a"}, "partner.org": {"c"}}))
+}
+
+func TestReconcileAdditionalIssuerKeys(t *testing.T) {
+	tests := []struct {
+		name         string
+		issuers      []v1alpha2.OIDCAdditionalIssuer
+		bundles      map[string]string
+		existing     *metav1.Condition
+		expectFound  bool
+		expectStatus metav1.ConditionStatus
+		expectReason string
+		expectEvent  bool
+	}{
+		{
+			name:    "distinct key IDs",
+			issuers: []v1alpha2.OIDCAdditionalIssuer{{Issuer: "https://oidc.partner.com", TrustDomain: "partner.org"}},
+			bundles: map[string]string{
+				"example.org": jwtBundle("a"),
+				"partner.org": jwtBundle("b"),
+			},
+			expectFound:  true,
+			expectStatus: metav1.ConditionTrue,
+			expectReason: AdditionalIssuersReasonValid,
+		},
+		{
+			name:    "key ID collision",
+			issuers: []v1alpha2.OIDCAdditionalIssuer{{Issuer: "https://oidc.partner.com", TrustDomain: "partner.org"}},
+			bundles: map[string]string{
+				"example.org": jwtBundle("a"),
+				"partner.org": jwtBundle("a"),
+			},
+			expectFound:  true,
+			expectStatus: metav1.ConditionFalse,
+			expectReason: AdditionalIssuersReasonKeyIDCollision,
+			expectEvent:  true,
+		},
+		{
+			name:    "bundle not federated yet",
+			issuers: []v1alpha2.OIDCAdditionalIssuer{{Issuer: "https://oidc.partner.com", TrustDomain: "partner.org"}},
+			bundles: map[string]string{
+				"example.org": jwtBundle("a"),
+			},
+			expectFound:  true,
+			expectStatus: metav1.ConditionUnknown,
+			expectReason: AdditionalIssuersReasonUnavailable,
+		},
+		{
+			name: "not configured",
+		},
+		{
+			name:         "removed",
+			existing:     &metav1.Condition{Type: AdditionalIssuersValid, Status: metav1.ConditionFalse},
+			expectFound:  true,
+			expectStatus: metav1.ConditionTrue,
+			expectReason: AdditionalIssuersReasonNotConfigured,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			reconciler := newTestReconciler(fakeClient)
+			reconciler.cli = &bundleCLI{bundles: tt.bundles}
+			recorder := record.NewFakeRecorder(10)
+			reconciler.eventRecorder = recorder
+			oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
+					JwtIssuer:         "https://oidc.example.com",
+					AdditionalIssuers: tt.issuers,
+				},
+			}
+			if tt.existing != nil {
+				oidc.Status.Conditions = []metav1.Condition{*tt.existing}
+			}
+			ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.org"}}
+			statusMgr := status.NewManager(fakeClient)
+
+			reconciler.reconcileAdditionalIssuerKeys(context.Background(), oidc, statusMgr, ztwim)
+
+			cond, found := statusMgr.GetCondition(AdditionalIssuersValid)
+			require.Equal(t, tt.expectFound, found)
+			if found {
+				assert.Equal(t, tt.expectStatus, cond.Status, cond.Message)
+				assert.Equal(t, tt.expectReason, cond.Reason)
+			}
+			if events := len(recorder.Events); (events == 1) != tt.expectEvent {
+				t.Errorf("Expected event %v, got %d events", tt.expectEvent, events)
+			}
+		})
+	}
+}
@@ -7,18 +7,22 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 	spiffev1alpha1 "github.com/spiffe/spire-controller-manager/api/v1alpha1"
 )
 
 // reconcileClusterSpiffeIDs reconciles the ClusterSpiffeID resources
-func (r *SpireOidcDiscoveryProviderReconciler) reconcileClusterSpiffeIDs(ctx context.Context, oidc *v1alpha1.SpireOIDCDiscoveryProvider, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireOidcDiscoveryProviderReconciler) reconcileClusterSpiffeIDs(ctx context.Context, oidc *v1alpha2.SpireOIDCDiscoveryProvider, statusMgr *status.Manager, createOnlyMode bool) error {
 	// Reconcile OIDC Discovery Provider ClusterSPIFFEID
 	desiredOIDC := generateSpireIODCDiscoveryProviderSpiffeID(oidc.Spec.Labels)
+	// The bundles of the additional issuers are received through the Workload API once federated
+	desiredOIDC.Spec.FederatesWith = additionalIssuerTrustDomains(oidc.Spec.AdditionalIssuers)
 	if err := controllerutil.SetControllerReference(oidc, desiredOIDC, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference for OIDC ClusterSPIFFEID")
 		statusMgr.AddCondition(ClusterSPIFFEIDAvailable, "SpireClusterSpiffeIDGenerationFailed",
@@ -42,7 +46,7 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileClusterSpiffeIDs(ctx con
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desiredOIDC); err != nil {
+		if err := r.ctrlClient.Create(ctx, desiredOIDC, customClient.AdoptExisting(ptr.Deref(oidc.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "Failed to create oidc cluster spiffe id")
 			statusMgr.AddCondition(ClusterSPIFFEIDAvailable, "SpireClusterSpiffeIDCreationFailed",
 				err.Error(),
@@ -73,8 +77,24 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileClusterSpiffeIDs(ctx con
 		}
 	}
 
+	// Remove the Default Fallback ClusterSPIFFEID when registration is opt-in only
+	if !fallbackClusterSPIFFEIDEnabled(oidc) {
+		if err := r.deleteDefaultFallbackClusterSPIFFEID(ctx, oidc); err != nil {
+			r.log.Error(err, "Failed to delete Default ClusterSPIFFEID")
+			statusMgr.AddCondition(ClusterSPIFFEIDAvailable, "SpireClusterSpiffeIDDeletionFailed",
+				fmt.Sprintf("Failed to delete Default ClusterSPIFFEID: %v", err),
+				metav1.ConditionFalse)
+			return err
+		}
+		statusMgr.AddCondition(ClusterSPIFFEIDAvailable, "SpireClusterSpiffeIDResourcesReady",
+			"Spire OIDC ClusterSpiffeID resource is ready, the default fallback ClusterSpiffeID is disabled",
+			metav1.ConditionTrue)
+		return nil
+	}
+
 	// Reconcile Default Fallback ClusterSPIFFEID
 	desiredDefault := generateDefaultFallbackClusterSPIFFEID(oidc.Spec.Labels)
+	applyNamespaceRegistrationPolicy(desiredDefault, oidc.Spec.NamespaceRegistrationPolicy)
 	if err = controllerutil.SetControllerReference(oidc, desiredDefault, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference for default ClusterSPIFFEID")
 		statusMgr.AddCondition(ClusterSPIFFEIDAvailable, "SpireClusterSpiffeIDGenerationFailed",
@@ -98,7 +118,7 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileClusterSpiffeIDs(ctx con
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desiredDefault); err != nil {
+		if err := r.ctrlClient.Create(ctx, desiredDefault, customClient.AdoptExisting(ptr.Deref(oidc.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "Failed to create DefaultFallbackClusterSPIFFEID")
 			statusMgr.AddCondition(ClusterSPIFFEIDAvailable, "SpireClusterSpiffeIDCreationFailed",
 				err.Error(),
@@ -135,6 +155,36 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileClusterSpiffeIDs(ctx con
 	return nil
 }
 
+// fallbackClusterSPIFFEIDEnabled reports whether the default fallback ClusterSPIFFEID is
+// maintained. It is unless explicitly disabled, as resources created before the field existed
+// rely on it.
+func fallbackClusterSPIFFEIDEnabled(oidc *v1alpha2.SpireOIDCDiscoveryProvider) bool {
+	return ptr.Deref(oidc.Spec.FallbackClusterSPIFFEIDEnabled, true)
+}
+
+// deleteDefaultFallbackClusterSPIFFEID deletes the default fallback ClusterSPIFFEID created by the
+// operator. A ClusterSPIFFEID of the same name which is not controlled by the
+// SpireOIDCDiscoveryProvider is left alone.
+func (r *SpireOidcDiscoveryProviderReconciler) deleteDefaultFallbackClusterSPIFFEID(ctx context.Context, oidc *v1alpha2.SpireOIDCDiscoveryProvider) error {
+	existing := &spiffev1alpha1.ClusterSPIFFEID{}
+	err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: defaultFallbackClusterSPIFFEIDName}, existing)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(existing, oidc) {
+		r.log.Info("Default ClusterSPIFFEID is not controlled by the operator, leaving it", "name", existing.Name)
+		return nil
+	}
+	if err := r.ctrlClient.Delete(ctx, existing); err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	r.log.Info("Deleted Default ClusterSPIFFEID", "name", existing.Name)
+	return nil
+}
+
 func generateSpireIODCDiscoveryProviderSpiffeID(customLabels map[string]string) *spiffev1alpha1.ClusterSPIFFEID {
 	clusterSpiffeID := &spiffev1alpha1.ClusterSPIFFEID{
 		ObjectMeta: metav1.ObjectMeta{
@@ -172,10 +222,33 @@ func generateSpireIODCDiscoveryProviderSpiffeID(customLabels map[string]string)
 	return clusterSpiffeID
 }
 
+// applyNamespaceRegistrationPolicy restricts the namespaces selected by the ClusterSPIFFEID
+// according to their spiffe.openshift.io/enabled label: with OptIn only the namespaces labelled
+// true are selected, otherwise all but those labelled false are
+func applyNamespaceRegistrationPolicy(csid *spiffev1alpha1.ClusterSPIFFEID, policy v1alpha2.NamespaceRegistrationPolicy) {
+	requirement := metav1.LabelSelectorRequirement{
+		Key:      utils.NamespaceRegistrationLabel,
+		Operator: metav1.LabelSelectorOpNotIn,
+		Values:   []string{"false"},
+	}
+	if policy == v1alpha2.NamespaceRegistrationOptIn {
+		requirement.Operator = metav1.LabelSelectorOpIn
+		requirement.Values = []string{"true"}
+	}
+	if csid.Spec.NamespaceSelector == nil {
+		csid.Spec.NamespaceSelector = &metav1.LabelSelector{}
+	}
+	csid.Spec.NamespaceSelector.MatchExpressions = append(csid.Spec.NamespaceSelector.MatchExpressions, requirement)
+}
+
+// defaultFallbackClusterSPIFFEIDName is the default fallback ClusterSPIFFEID registering the pods
+// not matched by another ClusterSPIFFEID
+const defaultFallbackClusterSPIFFEIDName = "zero-trust-workload-identity-manager-spire-default"
+
 func generateDefaultFallbackClusterSPIFFEID(customLabels map[string]string) *spiffev1alpha1.ClusterSPIFFEID {
 	clusterSpiffeID := &spiffev1alpha1.ClusterSPIFFEID{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:   "zero-trust-workload-identity-manager-spire-default",
+			Name:   defaultFallbackClusterSPIFFEIDName,
 			Labels: utils.SpireOIDCDiscoveryProviderLabels(customLabels),
 		},
 		Spec: spiffev1alpha1.ClusterSPIFFEIDSpec{
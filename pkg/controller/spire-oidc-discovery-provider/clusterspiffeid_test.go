@@ -6,15 +6,17 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 	spiffev1alpha1 "github.com/spiffe/spire-controller-manager/api/v1alpha1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -180,7 +182,7 @@ func TestGenerateClusterSPIFFEIDs(t *testing.T) {
 
 func newClusterSpiffeIDTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireOidcDiscoveryProviderReconciler {
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = spiffev1alpha1.AddToScheme(scheme)
 	return &SpireOidcDiscoveryProviderReconciler{
 		ctrlClient:    fakeClient,
@@ -191,14 +193,97 @@ func newClusterSpiffeIDTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *S
 	}
 }
 
-func createClusterSpiffeIDTestOIDCCR() *v1alpha1.SpireOIDCDiscoveryProvider {
-	return &v1alpha1.SpireOIDCDiscoveryProvider{
+func createClusterSpiffeIDTestOIDCCR() *v1alpha2.SpireOIDCDiscoveryProvider {
+	return &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
 		},
-		Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 			JwtIssuer: "https://oidc.example.org",
 		},
 	}
 }
+
+func TestReconcileClusterSpiffeIDs_FallbackDisabled(t *testing.T) {
+	tests := []struct {
+		name         string
+		controlled   bool
+		notFound     bool
+		expectDelete int
+	}{
+		{name: "deletes the default", controlled: true, expectDelete: 1},
+		{name: "already deleted", notFound: true},
+		{name: "not controlled by the operator", controlled: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			reconciler := newClusterSpiffeIDTestReconciler(fakeClient)
+			oidc := createClusterSpiffeIDTestOIDCCR()
+			oidc.Spec.FallbackClusterSPIFFEIDEnabled = ptr.To(false)
+			statusMgr := status.NewManager(fakeClient)
+
+			fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				if key.Name != defaultFallbackClusterSPIFFEIDName {
+					return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+				}
+				if tt.notFound {
+					return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+				}
+				csid := obj.(*spiffev1alpha1.ClusterSPIFFEID)
+				csid.Name = key.Name
+				if tt.controlled {
+					csid.OwnerReferences = []metav1.OwnerReference{{Kind: "SpireOIDCDiscoveryProvider", Name: oidc.Name, UID: oidc.UID, Controller: ptr.To(true)}}
+				}
+				return nil
+			}
+
+			if err := reconciler.reconcileClusterSpiffeIDs(context.Background(), oidc, statusMgr, false); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if fakeClient.CreateCallCount() != 1 {
+				t.Errorf("Expected only the OIDC ClusterSPIFFEID to be created, got %d creates", fakeClient.CreateCallCount())
+			}
+			if fakeClient.DeleteCallCount() != tt.expectDelete {
+				t.Errorf("Expected %d deletes, got %d", tt.expectDelete, fakeClient.DeleteCallCount())
+			}
+			cond, _ := statusMgr.GetCondition(ClusterSPIFFEIDAvailable)
+			if cond.Status != metav1.ConditionTrue {
+				t.Errorf("Expected %s to be True, got %s: %s", ClusterSPIFFEIDAvailable, cond.Status, cond.Message)
+			}
+		})
+	}
+}
+
+func TestApplyNamespaceRegistrationPolicy(t *testing.T) {
+	tests := []struct {
+		policy         v1alpha2.NamespaceRegistrationPolicy
+		expectOperator metav1.LabelSelectorOperator
+		expectValue    string
+	}{
+		{policy: "", expectOperator: metav1.LabelSelectorOpNotIn, expectValue: "false"},
+		{policy: v1alpha2.NamespaceRegistrationOptOut, expectOperator: metav1.LabelSelectorOpNotIn, expectValue: "false"},
+		{policy: v1alpha2.NamespaceRegistrationOptIn, expectOperator: metav1.LabelSelectorOpIn, expectValue: "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			csid := generateDefaultFallbackClusterSPIFFEID(nil)
+			applyNamespaceRegistrationPolicy(csid, tt.policy)
+
+			expressions := csid.Spec.NamespaceSelector.MatchExpressions
+			if len(expressions) != 2 {
+				t.Fatalf("Expected the operator namespace and the policy requirements, got %v", expressions)
+			}
+			if expressions[0].Key != "kubernetes.io/metadata.name" {
+				t.Errorf("Expected the operator namespace to stay excluded, got %v", expressions[0])
+			}
+			policy := expressions[1]
+			if policy.Key != utils.NamespaceRegistrationLabel || policy.Operator != tt.expectOperator || len(policy.Values) != 1 || policy.Values[0] != tt.expectValue {
+				t.Errorf("Expected %s %s [%s], got %v", utils.NamespaceRegistrationLabel, tt.expectOperator, tt.expectValue, policy)
+			}
+		})
+	}
+}
@@ -9,19 +9,30 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/confighistory"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 )
 
 // reconcileConfigMap reconciles the OIDC Discovery Provider ConfigMap
-func (r *SpireOidcDiscoveryProviderReconciler) reconcileConfigMap(ctx context.Context, oidc *v1alpha1.SpireOIDCDiscoveryProvider, statusMgr *status.Manager, ztwim *v1alpha1.ZeroTrustWorkloadIdentityManager, createOnlyMode bool) (string, error) {
-	cm, err := generateOIDCConfigMapFromCR(oidc, ztwim)
+func (r *SpireOidcDiscoveryProviderReconciler) reconcileConfigMap(ctx context.Context, oidc *v1alpha2.SpireOIDCDiscoveryProvider, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, createOnlyMode bool) (string, error) {
+	aliases, err := r.jwtIssuerAliases(ctx)
+	if err != nil {
+		r.log.Error(err, "failed to get JWT issuer aliases")
+		statusMgr.AddCondition(ConfigMapAvailable, "SpireOIDCConfigMapCreationFailed",
+			err.Error(),
+			metav1.ConditionFalse)
+		return "", err
+	}
+	cm, err := generateOIDCConfigMapFromCR(oidc, ztwim, aliases)
 	if err != nil {
 		r.log.Error(err, "failed to generate OIDC ConfigMap from CR")
 		statusMgr.AddCondition(ConfigMapAvailable, "SpireOIDCConfigMapCreationFailed",
@@ -30,6 +41,8 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileConfigMap(ctx context.Co
 		return "", err
 	}
 
+	_, rolledBack := confighistory.ApplyRollback(ctx, r.ctrlClient, oidc, oidc.Status.Conditions, cm, statusMgr)
+
 	if err = controllerutil.SetControllerReference(oidc, cm, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference")
 		statusMgr.AddCondition(ConfigMapAvailable, "SpireOIDCConfigMapCreationFailed",
@@ -41,7 +54,7 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileConfigMap(ctx context.Co
 	var existingOidcCm corev1.ConfigMap
 	err = r.ctrlClient.Get(ctx, types.NamespacedName{Name: cm.Name, Namespace: cm.Namespace}, &existingOidcCm)
 	if err != nil && kerrors.IsNotFound(err) {
-		if err = r.ctrlClient.Create(ctx, cm); err != nil {
+		if err = r.ctrlClient.Create(ctx, cm, customClient.AdoptExisting(ptr.Deref(oidc.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "Failed to create ConfigMap")
 			statusMgr.AddCondition(ConfigMapAvailable, "SpireOIDCConfigMapCreationFailed",
 				err.Error(),
@@ -76,11 +89,19 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileConfigMap(ctx context.Co
 		"Spire OIDC ConfigMap created",
 		metav1.ConditionTrue)
 
+	// A newly created ConfigMap is recorded on the reconcile triggered by its creation
+	if !rolledBack && !createOnlyMode && existingOidcCm.Name != "" {
+		if err = confighistory.RecordRevision(ctx, r.ctrlClient, r.scheme, oidc, cm); err != nil {
+			r.log.Error(err, "Failed to record config revision", "Namespace", cm.Namespace, "Name", cm.Name)
+		}
+	}
+
 	return utils.GenerateMapHash(cm.Data), nil
 }
 
-// generateOIDCConfigMapFromCR creates a ConfigMap for the spire oidc discovery provider from the CR spec
-func generateOIDCConfigMapFromCR(dp *v1alpha1.SpireOIDCDiscoveryProvider, ztwim *v1alpha1.ZeroTrustWorkloadIdentityManager) (*corev1.ConfigMap, error) {
+// generateOIDCConfigMapFromCR creates a ConfigMap for the spire oidc discovery provider from the CR spec.
+// The hosts of the JWT issuer aliases are served as well, so tokens of a previous issuer keep validating.
+func generateOIDCConfigMapFromCR(dp *v1alpha2.SpireOIDCDiscoveryProvider, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, jwtIssuerAliases []string) (*corev1.ConfigMap, error) {
 	if dp == nil {
 		return nil, errors.New("spire OIDC Discovery Provider Config is nil")
 	}
@@ -90,23 +111,27 @@ func generateOIDCConfigMapFromCR(dp *v1alpha1.SpireOIDCDiscoveryProvider, ztwim
 	const agentSocketName = "spire-agent.sock"
 
 	// Determine trust domain
-	trustDomain := ztwim.Spec.TrustDomain
+	trustDomain := utils.TrustDomain(ztwim)
 
 	// JWT Issuer validation and normalization
 	jwtIssuer, err := utils.StripProtocolFromJWTIssuer(dp.Spec.JwtIssuer)
 	if err != nil {
 		return nil, fmt.Errorf("invalid JWT issuer URL: %w", err)
 	}
+	aliasHosts, err := jwtIssuerAliasHosts(dp.Spec.JwtIssuer, jwtIssuerAliases)
+	if err != nil {
+		return nil, err
+	}
 	// OIDC config map data
 	oidcDefaultDomain := "spire-spiffe-oidc-discovery-provider." + utils.GetOperatorNamespace()
 	oidcSVCDomain := "spire-spiffe-oidc-discovery-provider." + utils.GetOperatorNamespace() + ".svc.cluster.local"
 	oidcConfig := map[string]interface{}{
-		"domains": []string{
+		"domains": append([]string{
 			"spire-spiffe-oidc-discovery-provider",
 			oidcDefaultDomain,
 			oidcSVCDomain,
 			jwtIssuer,
-		},
+		}, aliasHosts...),
 		"health_checks": map[string]string{
 			"bind_port":  "8008",
 			"live_path":  "/live",
@@ -130,15 +155,29 @@ func generateOIDCConfigMapFromCR(dp *v1alpha1.SpireOIDCDiscoveryProvider, ztwim
 		return nil, fmt.Errorf("failed to marshal OIDC config: %w", err)
 	}
 
+	data := map[string]string{
+		"oidc-discovery-provider.conf": string(oidcJSON),
+	}
+	// Each additional issuer is served by its own provider, with the keys of its trust domain
+	for index, issuer := range dp.Spec.AdditionalIssuers {
+		issuerConfig, err := additionalIssuerConfig(oidcConfig, index, issuer)
+		if err != nil {
+			return nil, err
+		}
+		issuerJSON, err := json.MarshalIndent(issuerConfig, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal OIDC config of additional issuer %s: %w", issuer.Issuer, err)
+		}
+		data[additionalIssuerConfigKey(index)] = string(issuerJSON)
+	}
+
 	configMap := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "spire-spiffe-oidc-discovery-provider",
 			Namespace: utils.GetOperatorNamespace(),
 			Labels:    utils.SpireOIDCDiscoveryProviderLabels(dp.Spec.Labels),
 		},
-		Data: map[string]string{
-			"oidc-discovery-provider.conf": string(oidcJSON),
-		},
+		Data: data,
 	}
 
 	return configMap, nil
@@ -9,7 +9,7 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
@@ -223,7 +223,7 @@ func TestReconcileConfigMap(t *testing.T) {
 		fakeClient := &fakes.FakeCustomCtrlClient{}
 		reconciler := newConfigMapTestReconciler(fakeClient)
 
-		var oidc *v1alpha1.SpireOIDCDiscoveryProvider = nil
+		var oidc *v1alpha2.SpireOIDCDiscoveryProvider = nil
 		ztwim := createOIDCTestZTWIM()
 		statusMgr := status.NewManager(fakeClient)
 
@@ -239,7 +239,7 @@ func TestReconcileConfigMap(t *testing.T) {
 func TestGenerateOIDCConfigMapFromCR_NilConfig(t *testing.T) {
 	ztwim := createOIDCTestZTWIM()
 
-	_, err := generateOIDCConfigMapFromCR(nil, ztwim)
+	_, err := generateOIDCConfigMapFromCR(nil, ztwim, nil)
 
 	if err == nil {
 		t.Error("Expected error when config is nil")
@@ -249,7 +249,7 @@ func TestGenerateOIDCConfigMapFromCR_NilConfig(t *testing.T) {
 // newConfigMapTestReconciler creates a reconciler for ConfigMap tests
 func newConfigMapTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireOidcDiscoveryProviderReconciler {
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 	return &SpireOidcDiscoveryProviderReconciler{
 		ctrlClient:    fakeClient,
@@ -261,25 +261,25 @@ func newConfigMapTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireOi
 }
 
 // createOIDCTestCR creates a test SpireOIDCDiscoveryProvider for tests
-func createOIDCTestCR() *v1alpha1.SpireOIDCDiscoveryProvider {
-	return &v1alpha1.SpireOIDCDiscoveryProvider{
+func createOIDCTestCR() *v1alpha2.SpireOIDCDiscoveryProvider {
+	return &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
 		},
-		Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 			JwtIssuer: "https://oidc.example.org",
 		},
 	}
 }
 
 // createOIDCTestZTWIM creates a test ZeroTrustWorkloadIdentityManager for tests
-func createOIDCTestZTWIM() *v1alpha1.ZeroTrustWorkloadIdentityManager {
-	return &v1alpha1.ZeroTrustWorkloadIdentityManager{
+func createOIDCTestZTWIM() *v1alpha2.ZeroTrustWorkloadIdentityManager {
+	return &v1alpha2.ZeroTrustWorkloadIdentityManager{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 		},
-		Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 			TrustDomain:     "example.org",
 			ClusterName:     "test-cluster",
 			BundleConfigMap: "spire-bundle",
@@ -290,21 +290,21 @@ func createOIDCTestZTWIM() *v1alpha1.ZeroTrustWorkloadIdentityManager {
 func TestGenerateOIDCConfigMapFromCR(t *testing.T) {
 	t.Run("should generate ConfigMap with all default values", func(t *testing.T) {
 		// Arrange
-		cr := &v1alpha1.SpireOIDCDiscoveryProvider{
-			Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+		cr := &v1alpha2.SpireOIDCDiscoveryProvider{
+			Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 				JwtIssuer: "https://oidc-discovery.example.org",
 			},
 		}
 
-		ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
-			Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+		ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+			Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 				TrustDomain:     "example.org",
 				BundleConfigMap: "spire-bundle",
 			},
 		}
 
 		// Act
-		result, err := generateOIDCConfigMapFromCR(cr, ztwim)
+		result, err := generateOIDCConfigMapFromCR(cr, ztwim, nil)
 
 		// Assert
 		require.NoError(t, err)
@@ -349,24 +349,24 @@ func TestGenerateOIDCConfigMapFromCR(t *testing.T) {
 			"app":     "spire-oidc",
 			"version": "v1.0",
 		}
-		cr := &v1alpha1.SpireOIDCDiscoveryProvider{
-			Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+		cr := &v1alpha2.SpireOIDCDiscoveryProvider{
+			Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 				JwtIssuer: "https://custom-jwt-issuer.example.com",
-				CommonConfig: v1alpha1.CommonConfig{
+				CommonConfig: v1alpha2.CommonConfig{
 					Labels: customLabels,
 				},
 			},
 		}
 
-		ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
-			Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+		ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+			Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 				TrustDomain:     "custom.domain.com",
 				BundleConfigMap: "spire-bundle",
 			},
 		}
 
 		// Act
-		result, err := generateOIDCConfigMapFromCR(cr, ztwim)
+		result, err := generateOIDCConfigMapFromCR(cr, ztwim, nil)
 
 		// Assert
 		require.NoError(t, err)
@@ -404,18 +404,18 @@ func TestGenerateOIDCConfigMapFromCR(t *testing.T) {
 
 	t.Run("should generate valid OIDC config structure", func(t *testing.T) {
 		// Arrange
-		cr := &v1alpha1.SpireOIDCDiscoveryProvider{
-			Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{},
+		cr := &v1alpha2.SpireOIDCDiscoveryProvider{
+			Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{},
 		}
 
-		ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
-			Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+		ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+			Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 				TrustDomain: "example.org",
 			},
 		}
 
 		// Act
-		result, err := generateOIDCConfigMapFromCR(cr, ztwim)
+		result, err := generateOIDCConfigMapFromCR(cr, ztwim, nil)
 
 		// Assert
 		require.NoError(t, err)
@@ -452,18 +452,18 @@ func TestGenerateOIDCConfigMapFromCR(t *testing.T) {
 
 // Test to verify JSON formatting
 func TestOIDCConfigJSONFormatting(t *testing.T) {
-	cr := &v1alpha1.SpireOIDCDiscoveryProvider{
-		Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{},
+	cr := &v1alpha2.SpireOIDCDiscoveryProvider{
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{},
 	}
 
-	ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
-		Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 			TrustDomain:     "example.org",
 			BundleConfigMap: "spire-bundle",
 		},
 	}
 
-	result, err := generateOIDCConfigMapFromCR(cr, ztwim)
+	result, err := generateOIDCConfigMapFromCR(cr, ztwim, nil)
 	require.NoError(t, err)
 
 	oidcJSON := result.Data["oidc-discovery-provider.conf"]
@@ -11,6 +11,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -23,14 +24,23 @@ import (
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	configv1 "github.com/openshift/api/config/v1"
 	routev1 "github.com/openshift/api/route/v1"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/audit"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/breaker"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/dependencies"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/pipeline"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/spirecli"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/topology"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 )
 
@@ -49,11 +59,13 @@ const (
 
 // SpireOidcDiscoveryProviderReconciler reconciles a SpireOidcDiscoveryProvider object
 type SpireOidcDiscoveryProviderReconciler struct {
-	ctrlClient    customClient.CustomCtrlClient
-	ctx           context.Context
-	eventRecorder record.EventRecorder
-	log           logr.Logger
-	scheme        *runtime.Scheme
+	ctrlClient     customClient.CustomCtrlClient
+	ctx            context.Context
+	eventRecorder  record.EventRecorder
+	log            logr.Logger
+	scheme         *runtime.Scheme
+	failureBreaker *breaker.Breaker
+	cli            spirecli.Runner
 }
 
 // New returns a new Reconciler instance.
@@ -62,121 +74,204 @@ func New(mgr ctrl.Manager) (*SpireOidcDiscoveryProviderReconciler, error) {
 	if err != nil {
 		return nil, err
 	}
+	cli, err := spirecli.NewPodExecRunner(mgr.GetConfig())
+	if err != nil {
+		return nil, err
+	}
 	return &SpireOidcDiscoveryProviderReconciler{
-		ctrlClient:    c,
-		ctx:           context.Background(),
-		eventRecorder: mgr.GetEventRecorderFor(utils.ZeroTrustWorkloadIdentityManagerSpireOIDCDiscoveryProviderControllerName),
-		log:           ctrl.Log.WithName(utils.ZeroTrustWorkloadIdentityManagerSpireOIDCDiscoveryProviderControllerName),
-		scheme:        mgr.GetScheme(),
+		ctrlClient:     c,
+		ctx:            context.Background(),
+		eventRecorder:  mgr.GetEventRecorderFor(utils.ZeroTrustWorkloadIdentityManagerSpireOIDCDiscoveryProviderControllerName),
+		log:            ctrl.Log.WithName(utils.ZeroTrustWorkloadIdentityManagerSpireOIDCDiscoveryProviderControllerName),
+		scheme:         mgr.GetScheme(),
+		failureBreaker: breaker.New(),
+		cli:            cli,
 	}, nil
 }
 
-func (r *SpireOidcDiscoveryProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	r.log.Info(fmt.Sprintf("reconciling %s", utils.ZeroTrustWorkloadIdentityManagerSpireOIDCDiscoveryProviderControllerName))
+func (r *SpireOidcDiscoveryProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, reconcileErr error) {
+	traced := *r
+	ctx, traced.log, traced.eventRecorder = utils.TraceReconcile(ctx, r.log, r.eventRecorder)
+	r = &traced
+	r.log.Info("reconciling", "controller", utils.ZeroTrustWorkloadIdentityManagerSpireOIDCDiscoveryProviderControllerName, "name", req.Name)
 
-	var oidcDiscoveryProviderConfig v1alpha1.SpireOIDCDiscoveryProvider
+	var oidcDiscoveryProviderConfig v1alpha2.SpireOIDCDiscoveryProvider
 	if err := r.ctrlClient.Get(ctx, req.NamespacedName, &oidcDiscoveryProviderConfig); err != nil {
 		if kerrors.IsNotFound(err) {
 			r.log.Info("SpireOidcDiscoveryProvider resource not found. Ignoring since object must be deleted or not been created.")
+			status.Forget(&oidcDiscoveryProviderConfig, req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
-		return ctrl.Result{}, err
+		return utils.ReconcileResult(err)
 	}
 
 	// Set Ready to false at the start of reconciliation
-	status.SetInitialReconciliationStatus(ctx, r.ctrlClient, &oidcDiscoveryProviderConfig, func() *v1alpha1.ConditionalStatus {
+	status.SetInitialReconciliationStatus(ctx, r.ctrlClient, &oidcDiscoveryProviderConfig, func() *v1alpha2.ConditionalStatus {
 		return &oidcDiscoveryProviderConfig.Status.ConditionalStatus
 	}, "SpireOIDCDiscoveryProvider")
 
 	statusMgr := status.NewManager(r.ctrlClient)
 	defer func() {
-		if err := statusMgr.ApplyStatus(ctx, &oidcDiscoveryProviderConfig, func() *v1alpha1.ConditionalStatus {
+		statusMgr.ReportWorkloadVersion(ctx, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "spire-spiffe-oidc-discovery-provider", Namespace: utils.GetOperatorNamespace()}})
+		if err := statusMgr.ApplyStatus(ctx, &oidcDiscoveryProviderConfig, func() *v1alpha2.ConditionalStatus {
 			return &oidcDiscoveryProviderConfig.Status.ConditionalStatus
 		}); err != nil {
 			r.log.Error(err, "failed to update status")
 		}
+		res = statusMgr.RequeueHeldBack(res, reconcileErr)
 	}()
 
-	var ztwim v1alpha1.ZeroTrustWorkloadIdentityManager
+	var ztwim v1alpha2.ZeroTrustWorkloadIdentityManager
 	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: "cluster"}, &ztwim); err != nil {
 		if kerrors.IsNotFound(err) {
 			r.log.Error(err, "failed to get ZeroTrustWorkloadIdentityManager")
-			statusMgr.AddCondition(v1alpha1.Ready, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(v1alpha2.Ready, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to retrieve ZeroTrustWorkloadIdentityManager from cluster"),
 				metav1.ConditionFalse)
 			return ctrl.Result{}, nil
 		}
-		return ctrl.Result{}, err
+		return utils.ReconcileResult(err)
 	}
+	statusMgr.SetGracePeriod(ztwim.Spec.StatusGracePeriod)
 
 	// Set ZTWIM as the owner of SpireOidcDiscoveryProvider only if needed
 	if utils.NeedsOwnerReferenceUpdate(&oidcDiscoveryProviderConfig, &ztwim) {
 		if err := controllerutil.SetControllerReference(&ztwim, &oidcDiscoveryProviderConfig, r.scheme); err != nil {
 			r.log.Error(err, "failed to set controller reference on SpireOidcDiscoveryProvider")
-			statusMgr.AddCondition(v1alpha1.Ready, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(v1alpha2.Ready, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to set owner reference on SpireOidcDiscoveryProvider: %v", err),
 				metav1.ConditionFalse)
-			return ctrl.Result{}, err
+			return utils.ReconcileResult(err)
 		}
 
 		// Persist the owner reference to the cluster
 		if err := r.ctrlClient.Update(ctx, &oidcDiscoveryProviderConfig); err != nil {
 			r.log.Error(err, "failed to update SpireOIDCDiscoveryProvider with owner reference")
-			statusMgr.AddCondition(v1alpha1.Ready, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(v1alpha2.Ready, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to update SpireOIDCDiscoveryProvider with owner reference: %v", err),
 				metav1.ConditionFalse)
-			return ctrl.Result{}, err
+			return utils.ReconcileResult(err)
 		}
 	}
 
+	// Fill in the settings left unset from the sizing and topology profiles, in memory only
+	topologyProfile, err := topology.Resolve(ctx, r.ctrlClient, &ztwim)
+	if err != nil {
+		r.log.Error(err, "failed to resolve the topology profile")
+		return utils.ReconcileResult(err)
+	}
+	topology.ApplyToOperand(topologyProfile, ztwim.Spec.SizingProfile, utils.ResourceKindSpireOIDCDiscoveryProvider, &oidcDiscoveryProviderConfig.Spec.CommonConfig)
+	utils.ApplyImagePullSecrets(ztwim.Spec.ImagePullSecrets, &oidcDiscoveryProviderConfig.Spec.CommonConfig)
+
 	// Handle create-only mode
 	createOnlyMode := r.handleCreateOnlyMode(&oidcDiscoveryProviderConfig, statusMgr)
 
 	// Validate configuration
-	if err := r.validateConfiguration(ctx, &oidcDiscoveryProviderConfig, statusMgr); err != nil {
+	if err := r.validateConfiguration(ctx, &oidcDiscoveryProviderConfig, statusMgr, &ztwim); err != nil {
+		statusMgr.SetDegradedCondition(utils.NewInvalidConfigurationError(err, "configuration validation failed"), oidcDiscoveryProviderConfig.Status.Conditions)
 		return ctrl.Result{}, nil
 	}
 
-	// Reconcile static resources (ServiceAccount, Service)
-	if err := r.reconcileServiceAccount(ctx, &oidcDiscoveryProviderConfig, statusMgr, createOnlyMode); err != nil {
-		return ctrl.Result{}, err
-	}
-
-	if err := r.reconcileService(ctx, &oidcDiscoveryProviderConfig, statusMgr, createOnlyMode); err != nil {
-		return ctrl.Result{}, err
-	}
-
-	// Reconcile ClusterSpiffeIDs
-	if err := r.reconcileClusterSpiffeIDs(ctx, &oidcDiscoveryProviderConfig, statusMgr, createOnlyMode); err != nil {
-		return ctrl.Result{}, err
+	// Refuse to render a configuration whose trust domain and JWT issuers disagree
+	if err := statusMgr.CheckConfigurationConflict(ctx, utils.TrustDomain(&ztwim), oidcDiscoveryProviderConfig.Status.Conditions); err != nil {
+		if utils.ClassifyError(err) == utils.InvalidConfigurationError {
+			statusMgr.AddCondition(ConfigurationValid, utils.ConfigurationConflictStatusType, err.Error(), metav1.ConditionFalse)
+		}
+		statusMgr.SetDegradedCondition(err, oidcDiscoveryProviderConfig.Status.Conditions)
+		return utils.ReconcileResult(err)
 	}
 
-	// Reconcile ConfigMap
-	configHash, err := r.reconcileConfigMap(ctx, &oidcDiscoveryProviderConfig, statusMgr, &ztwim, createOnlyMode)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
+	// Kinds of generated resources left to the user
+	unmanagedKinds := utils.UnmanagedKinds(oidcDiscoveryProviderConfig.Spec.ManagedResources)
 
-	// Reconcile Deployment
-	if err := r.reconcileDeployment(ctx, &oidcDiscoveryProviderConfig, statusMgr, createOnlyMode, configHash); err != nil {
-		return ctrl.Result{}, err
+	// Compute the pending changes without applying them when the dry-run annotation is set
+	if utils.IsDryRunRequested(&oidcDiscoveryProviderConfig) {
+		dryRunClient := customClient.NewDryRunClient(r.ctrlClient)
+		dryRunReconciler := *r
+		dryRunReconciler.ctrlClient = customClient.NewUnmanagedClient(dryRunClient, unmanagedKinds)
+		err := dryRunReconciler.reconcileResources(ctx, &oidcDiscoveryProviderConfig, status.NewManager(dryRunClient), &ztwim, topologyProfile, createOnlyMode)
+		statusMgr.ReportDryRun(dryRunClient.Changes(), err)
+		return ctrl.Result{}, nil
 	}
-
-	// Reconcile RBAC for external certificate access BEFORE Route (if externalSecretRef is configured)
-	// This ensures the router serviceaccount has permissions before the Route is created/updated
-	if err := r.reconcileExternalCertRBAC(ctx, &oidcDiscoveryProviderConfig, statusMgr, createOnlyMode); err != nil {
-		return ctrl.Result{}, err
+	statusMgr.ClearDryRun(oidcDiscoveryProviderConfig.Status.Conditions)
+
+	// Record the resources changed for this generation in the audit trail, leaving the
+	// resources of unmanaged kinds alone
+	recordingClient := customClient.NewRecordingClient(r.ctrlClient)
+	unmanagedClient := customClient.NewUnmanagedClient(recordingClient, unmanagedKinds)
+	auditedReconciler := *r
+	auditedReconciler.ctrlClient = unmanagedClient
+	err = auditedReconciler.reconcileResources(ctx, &oidcDiscoveryProviderConfig, statusMgr, &ztwim, topologyProfile, createOnlyMode)
+	if auditErr := audit.Append(ctx, r.ctrlClient, r.scheme, r.eventRecorder, &oidcDiscoveryProviderConfig, oidcDiscoveryProviderConfig.Spec, recordingClient.Changes()); auditErr != nil {
+		r.log.Error(auditErr, "failed to record audit trail")
 	}
-
-	// Reconcile Route (if enabled)
-	if err := r.reconcileRoute(ctx, &oidcDiscoveryProviderConfig, statusMgr, createOnlyMode); err != nil {
-		return ctrl.Result{}, err
+	statusMgr.ReportUnmanagedResources(unmanagedKinds, unmanagedClient.Skipped(), oidcDiscoveryProviderConfig.Status.Conditions)
+	// Fail closed on the resources missing in create-only mode rather than reporting stale conditions
+	if createOnlyMode {
+		statusMgr.CheckRequiredResources(ctx, r.eventRecorder, &oidcDiscoveryProviderConfig, oidcDiscoveryProviderConfig.Status.Conditions, requiredResources()...)
 	}
+	statusMgr.SetDegradedCondition(err, oidcDiscoveryProviderConfig.Status.Conditions)
+	return r.failureBreaker.Result(r.eventRecorder, &oidcDiscoveryProviderConfig, statusMgr, recordingClient.Failure(), err)
+}
 
-	return ctrl.Result{}, nil
+// reconcileResources reconciles all resources managed for the SpireOIDCDiscoveryProvider
+func (r *SpireOidcDiscoveryProviderReconciler) reconcileResources(ctx context.Context, oidcDiscoveryProviderConfig *v1alpha2.SpireOIDCDiscoveryProvider, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, profile topology.Profile, createOnlyMode bool) error {
+	var configHash string
+	return pipeline.Run(
+		// Reconcile static resources (ServiceAccount, Service)
+		pipeline.Step{Resource: "ServiceAccount", Run: func() error {
+			return r.reconcileServiceAccount(ctx, oidcDiscoveryProviderConfig, statusMgr, createOnlyMode)
+		}},
+		pipeline.Step{Resource: "Service", Run: func() error {
+			return r.reconcileService(ctx, oidcDiscoveryProviderConfig, statusMgr, createOnlyMode)
+		}},
+		// Reconcile ClusterSpiffeIDs
+		pipeline.Step{Resource: "ClusterSPIFFEIDs", Run: func() error {
+			return r.reconcileClusterSpiffeIDs(ctx, oidcDiscoveryProviderConfig, statusMgr, createOnlyMode)
+		}},
+		// Reconcile ConfigMap
+		pipeline.Step{Resource: "ConfigMap", Run: func() (err error) {
+			configHash, err = r.reconcileConfigMap(ctx, oidcDiscoveryProviderConfig, statusMgr, ztwim, createOnlyMode)
+			return err
+		}},
+		// Reconcile Deployment, spread across nodes depending on the topology profile
+		pipeline.Step{Resource: "Deployment", After: []string{"ConfigMap"}, Run: func() error {
+			return r.reconcileDeployment(ctx, oidcDiscoveryProviderConfig, statusMgr, createOnlyMode, configHash, profile)
+		}},
+		// Reconcile the PodDisruptionBudget of the Deployment
+		pipeline.Step{Resource: "PodDisruptionBudget", After: []string{"Deployment"}, Run: func() error {
+			return r.reconcilePodDisruptionBudget(ctx, oidcDiscoveryProviderConfig, statusMgr, generateDeployment(oidcDiscoveryProviderConfig, configHash), profile, createOnlyMode)
+		}},
+		// Reconcile RBAC for external certificate access BEFORE Route (if externalSecretRef is configured)
+		// This ensures the router serviceaccount has permissions before the Route is created/updated
+		pipeline.Step{Resource: "ExternalCertRBAC", Run: func() error {
+			return r.reconcileExternalCertRBAC(ctx, oidcDiscoveryProviderConfig, statusMgr, createOnlyMode)
+		}},
+		// Reconcile Route (if enabled)
+		pipeline.Step{Resource: "Route", After: []string{"ExternalCertRBAC"}, Run: func() error {
+			return r.reconcileRoute(ctx, oidcDiscoveryProviderConfig, statusMgr, createOnlyMode)
+		}},
+		// Reconcile the Routes of the JWT issuer aliases of the SpireServer
+		pipeline.Step{Resource: "AliasRoutes", After: []string{"ExternalCertRBAC"}, Run: func() error {
+			return r.reconcileAliasRoutes(ctx, oidcDiscoveryProviderConfig, statusMgr, createOnlyMode)
+		}},
+		// Reconcile the Routes of the additional issuers
+		pipeline.Step{Resource: "AdditionalIssuerRoutes", After: []string{"ExternalCertRBAC"}, Run: func() error {
+			return r.reconcileAdditionalIssuerRoutes(ctx, oidcDiscoveryProviderConfig, statusMgr, createOnlyMode)
+		}},
+		// Reconcile the reverse proxies validating the JWT-SVIDs of the requests to legacy services
+		pipeline.Step{Resource: "JWTValidatingProxies", Run: func() error {
+			return r.reconcileJWTValidatingProxies(ctx, oidcDiscoveryProviderConfig, statusMgr, createOnlyMode)
+		}},
+		// Check the keys served for the additional issuers can be told apart
+		pipeline.Step{Resource: "AdditionalIssuerKeys", After: []string{"ClusterSPIFFEIDs"}, Run: func() error {
+			r.reconcileAdditionalIssuerKeys(ctx, oidcDiscoveryProviderConfig, statusMgr, ztwim)
+			return nil
+		}},
+	)
 }
 
-func (r *SpireOidcDiscoveryProviderReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *SpireOidcDiscoveryProviderReconciler) SetupWithManager(mgr ctrl.Manager, dependencyCache cache.Cache) error {
 	// Always enqueue the "cluster" CR for reconciliation
 	mapFunc := func(ctx context.Context, _ client.Object) []reconcile.Request {
 		return []reconcile.Request{
@@ -191,27 +286,53 @@ func (r *SpireOidcDiscoveryProviderReconciler) SetupWithManager(mgr ctrl.Manager
 	// Use component-specific predicate to only reconcile for discovery component resources
 	controllerManagedResourcePredicates := builder.WithPredicates(utils.ControllerManagedResourcesForComponent(utils.ComponentDiscovery))
 
-	err := ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.SpireOIDCDiscoveryProvider{}, builder.WithPredicates(utils.GenerationOrOwnerReferenceChangedPredicate)).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha2.SpireOIDCDiscoveryProvider{}, builder.WithPredicates(predicate.Or(utils.GenerationOrOwnerReferenceChangedPredicate, predicate.AnnotationChangedPredicate{}))).
 		Named(utils.ZeroTrustWorkloadIdentityManagerSpireOIDCDiscoveryProviderControllerName).
 		Watches(&appsv1.Deployment{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
+		Watches(&policyv1.PodDisruptionBudget{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
 		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
 		Watches(&corev1.ServiceAccount{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
 		Watches(&corev1.Service{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
-		Watches(&routev1.Route{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
 		Watches(&rbacv1.Role{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
 		Watches(&rbacv1.RoleBinding{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
 		Watches(&spiffev1alpha1.ClusterSPIFFEID{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
-		Watches(&v1alpha1.ZeroTrustWorkloadIdentityManager{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(utils.ZTWIMSpecChangedPredicate)).
-		Complete(r)
+		// Re-evaluate deferred upgrades once the SPIFFE CSI driver finishes rolling out
+		Watches(&appsv1.DaemonSet{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(utils.ControllerManagedResourcesForComponent(utils.ComponentCSI))).
+		Watches(&v1alpha2.ZeroTrustWorkloadIdentityManager{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(utils.ZTWIMSpecChangedPredicate)).
+		// The JWT issuer aliases of the SpireServer are served by the OIDC discovery provider
+		Watches(&v1alpha2.SpireServer{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	if utils.HasCapability(utils.CapabilityRoute) {
+		controllerBuilder = controllerBuilder.Watches(&routev1.Route{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates)
+	}
+	if utils.HasCapability(utils.CapabilityInfrastructure) {
+		// Re-evaluate the topology defaults when the cluster changes between single-node and highly available
+		controllerBuilder = controllerBuilder.Watches(&configv1.Infrastructure{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(topology.ChangedPredicate))
+	}
+
+	// Roll the discovery provider when the trusted CA bundle or the objects mounted by its pods change
+	err := dependencies.Watch(controllerBuilder, dependencyCache, func(ctx context.Context) []dependencies.Reference {
+		deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "spire-spiffe-oidc-discovery-provider", Namespace: utils.GetOperatorNamespace()}}
+		return append(dependencies.TrustedCABundle(), dependencies.FromWorkload(ctx, r.ctrlClient, deployment)...)
+	}).Complete(r)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// requiredResources returns the resources the OIDC discovery provider cannot run without
+func requiredResources() []client.Object {
+	namespace := utils.GetOperatorNamespace()
+	return []client.Object{
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "spire-spiffe-oidc-discovery-provider", Namespace: namespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "spire-spiffe-oidc-discovery-provider", Namespace: namespace}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "spire-spiffe-oidc-discovery-provider", Namespace: namespace}},
+	}
+}
+
 // handleCreateOnlyMode checks and updates the create-only mode status
-func (r *SpireOidcDiscoveryProviderReconciler) handleCreateOnlyMode(oidc *v1alpha1.SpireOIDCDiscoveryProvider, statusMgr *status.Manager) bool {
+func (r *SpireOidcDiscoveryProviderReconciler) handleCreateOnlyMode(oidc *v1alpha2.SpireOIDCDiscoveryProvider, statusMgr *status.Manager) bool {
 	createOnlyMode := utils.IsInCreateOnlyMode()
 	if createOnlyMode {
 		r.log.Info("Running in create-only mode - will create resources if they don't exist but skip updates")
@@ -230,7 +351,7 @@ func (r *SpireOidcDiscoveryProviderReconciler) handleCreateOnlyMode(oidc *v1alph
 }
 
 // validateConfiguration validates the SpireOIDCDiscoveryProvider configuration
-func (r *SpireOidcDiscoveryProviderReconciler) validateConfiguration(ctx context.Context, oidc *v1alpha1.SpireOIDCDiscoveryProvider, statusMgr *status.Manager) error {
+func (r *SpireOidcDiscoveryProviderReconciler) validateConfiguration(ctx context.Context, oidc *v1alpha2.SpireOIDCDiscoveryProvider, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager) error {
 	// Validate common configuration
 	if err := r.validateCommonConfig(oidc, statusMgr); err != nil {
 		return err
@@ -250,10 +371,28 @@ func (r *SpireOidcDiscoveryProviderReconciler) validateConfiguration(ctx context
 		return err
 	}
 
+	// Validate the additional issuers are served apart from the jwtIssuer
+	if err := validateAdditionalIssuers(oidc.Spec.AdditionalIssuers, oidc.Spec.JwtIssuer, utils.TrustDomain(ztwim)); err != nil {
+		r.log.Error(err, "Invalid additional issuers in SpireOIDCDiscoveryProvider configuration")
+		statusMgr.AddCondition(ConfigurationValid, "InvalidAdditionalIssuers",
+			fmt.Sprintf("Additional issuers validation failed: %v", err),
+			metav1.ConditionFalse)
+		return err
+	}
+
+	// Validate the upstreams of the JWT validating proxies
+	if err := validateJWTValidatingProxies(oidc.Spec.JWTValidatingProxies); err != nil {
+		r.log.Error(err, "Invalid JWT validating proxies in SpireOIDCDiscoveryProvider configuration")
+		statusMgr.AddCondition(ConfigurationValid, "InvalidJWTValidatingProxies",
+			fmt.Sprintf("JWT validating proxies validation failed: %v", err),
+			metav1.ConditionFalse)
+		return err
+	}
+
 	// Only set to true if the condition previously existed as false
 	existingCondition := apimeta.FindStatusCondition(oidc.Status.ConditionalStatus.Conditions, ConfigurationValid)
 	if existingCondition != nil && existingCondition.Status == metav1.ConditionFalse {
-		statusMgr.AddCondition(ConfigurationValid, v1alpha1.ReasonReady,
+		statusMgr.AddCondition(ConfigurationValid, v1alpha2.ReasonReady,
 			"Configuration validation passed",
 			metav1.ConditionTrue)
 	}
@@ -265,15 +404,22 @@ func (r *SpireOidcDiscoveryProviderReconciler) validateProxyConfiguration(status
 	result := utils.ValidateProxyConfiguration()
 
 	if !result.Valid {
-		r.log.Error(errors.New(result.Reason), result.Message)
+		r.log.Error(errors.New(result.Reason), "invalid proxy configuration", "message", result.Message)
 		statusMgr.AddCondition(ConfigurationValid, result.Reason, result.Message, metav1.ConditionFalse)
 		return fmt.Errorf("proxy configuration invalid: %s", result.Message)
 	}
 	return nil
 }
 
-// validateCommonConfig validates common configuration fields (affinity, tolerations, nodeSelector, resources, labels)
-func (r *SpireOidcDiscoveryProviderReconciler) validateCommonConfig(oidc *v1alpha1.SpireOIDCDiscoveryProvider, statusMgr *status.Manager) error {
+// validateCommonConfig validates common configuration fields (DNS, affinity, tolerations, nodeSelector, resources, labels)
+func (r *SpireOidcDiscoveryProviderReconciler) validateCommonConfig(oidc *v1alpha2.SpireOIDCDiscoveryProvider, statusMgr *status.Manager) error {
+	if err := utils.ValidateCommonConfigDNS(oidc.Spec.DNSPolicy, oidc.Spec.DNSConfig); err != nil {
+		r.log.Error(err, "invalid DNS configuration")
+		statusMgr.AddCondition(utils.ConditionTypeConfigurationValid, utils.ConditionReasonInvalidDNSConfig,
+			fmt.Sprintf("DNS configuration validation failed: %v", err), metav1.ConditionFalse)
+		return err
+	}
+
 	return utils.ValidateAndUpdateStatus(
 		r.log,
 		statusMgr,
@@ -292,5 +438,8 @@ func needsUpdate(current, desired appsv1.Deployment) bool {
 	if current.Spec.Template.Annotations[spireOidcDeploymentSpireOidcConfigHashAnnotationKey] != desired.Spec.Template.Annotations[spireOidcDeploymentSpireOidcConfigHashAnnotationKey] {
 		return true
 	}
+	if current.Spec.Template.Annotations[utils.DependenciesHashAnnotationKey] != desired.Spec.Template.Annotations[utils.DependenciesHashAnnotationKey] {
+		return true
+	}
 	return utils.ResourceNeedsUpdate(&current, &desired)
 }
@@ -6,7 +6,7 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	appsv1 "k8s.io/api/apps/v1"
@@ -85,7 +85,7 @@ func TestReconcile_ZTWIMNotFound(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	oidcProvider := &v1alpha1.SpireOIDCDiscoveryProvider{
+	oidcProvider := &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 		},
@@ -96,7 +96,7 @@ func TestReconcile_ZTWIMNotFound(t *testing.T) {
 		callCount++
 		switch callCount {
 		case 1: // First call: Get SpireOIDCDiscoveryProvider
-			if oidc, ok := obj.(*v1alpha1.SpireOIDCDiscoveryProvider); ok {
+			if oidc, ok := obj.(*v1alpha2.SpireOIDCDiscoveryProvider); ok {
 				*oidc = *oidcProvider
 			}
 			return nil
@@ -124,7 +124,7 @@ func TestReconcile_ZTWIMGetError(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	oidcProvider := &v1alpha1.SpireOIDCDiscoveryProvider{
+	oidcProvider := &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 		},
@@ -136,7 +136,7 @@ func TestReconcile_ZTWIMGetError(t *testing.T) {
 		callCount++
 		switch callCount {
 		case 1: // First call: Get SpireOIDCDiscoveryProvider
-			if oidc, ok := obj.(*v1alpha1.SpireOIDCDiscoveryProvider); ok {
+			if oidc, ok := obj.(*v1alpha2.SpireOIDCDiscoveryProvider); ok {
 				*oidc = *oidcProvider
 			}
 			return nil
@@ -169,18 +169,18 @@ func TestReconcile_OwnerReferenceUpdateError(t *testing.T) {
 
 	// Register types in scheme for SetControllerReference
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	reconciler.scheme = scheme
 
 	// SpireOIDCDiscoveryProvider without owner reference (needs update)
-	oidcProvider := &v1alpha1.SpireOIDCDiscoveryProvider{
+	oidcProvider := &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 		},
 	}
 
 	// ZTWIM with proper metadata
-	ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
@@ -192,12 +192,12 @@ func TestReconcile_OwnerReferenceUpdateError(t *testing.T) {
 		callCount++
 		switch callCount {
 		case 1: // Get SpireOIDCDiscoveryProvider
-			if oidc, ok := obj.(*v1alpha1.SpireOIDCDiscoveryProvider); ok {
+			if oidc, ok := obj.(*v1alpha2.SpireOIDCDiscoveryProvider); ok {
 				*oidc = *oidcProvider
 			}
 			return nil
 		case 2: // Get ZTWIM
-			if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+			if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 				*z = *ztwim
 			}
 			return nil
@@ -227,7 +227,7 @@ func TestHandleCreateOnlyMode_Enabled(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+	oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
 	}
 
@@ -248,7 +248,7 @@ func TestHandleCreateOnlyMode_Disabled(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+	oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
 	}
 
@@ -270,10 +270,10 @@ func TestHandleCreateOnlyMode_DisabledWithPreviouslyEnabled(t *testing.T) {
 	reconciler := newTestReconciler(fakeClient)
 
 	// OIDC provider with existing CreateOnlyMode condition set to True
-	oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+	oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Status: v1alpha1.SpireOIDCDiscoveryProviderStatus{
-			ConditionalStatus: v1alpha1.ConditionalStatus{
+		Status: v1alpha2.SpireOIDCDiscoveryProviderStatus{
+			ConditionalStatus: v1alpha2.ConditionalStatus{
 				Conditions: []metav1.Condition{
 					{
 						Type:   "CreateOnlyMode",
@@ -345,15 +345,15 @@ func TestValidateConfiguration_ValidConfig(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+	oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 			JwtIssuer: "https://example.com",
 		},
 	}
 
 	statusMgr := status.NewManager(fakeClient)
-	err := reconciler.validateConfiguration(context.Background(), oidc, statusMgr)
+	err := reconciler.validateConfiguration(context.Background(), oidc, statusMgr, &v1alpha2.ZeroTrustWorkloadIdentityManager{})
 
 	// Assert: validation should pass with valid configuration
 	if err != nil {
@@ -366,15 +366,15 @@ func TestValidateConfiguration_InvalidJWTIssuer(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+	oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 			JwtIssuer: "not-a-valid-url",
 		},
 	}
 
 	statusMgr := status.NewManager(fakeClient)
-	err := reconciler.validateConfiguration(context.Background(), oidc, statusMgr)
+	err := reconciler.validateConfiguration(context.Background(), oidc, statusMgr, &v1alpha2.ZeroTrustWorkloadIdentityManager{})
 
 	// Assert: validation should fail with invalid JWT issuer
 	if err == nil {
@@ -448,9 +448,9 @@ func TestValidateCommonConfig_Valid(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+	oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 			JwtIssuer: "https://example.com",
 		},
 	}
@@ -468,11 +468,11 @@ func TestValidateCommonConfig_InvalidAffinity(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+	oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 			JwtIssuer: "https://example.com",
-			CommonConfig: v1alpha1.CommonConfig{
+			CommonConfig: v1alpha2.CommonConfig{
 				Affinity: &corev1.Affinity{
 					NodeAffinity: &corev1.NodeAffinity{
 						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
@@ -526,9 +526,9 @@ func TestValidateConfiguration_ConditionUpdate(t *testing.T) {
 			reconciler := newTestReconciler(fakeClient)
 			statusMgr := status.NewManager(fakeClient)
 
-			oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 					JwtIssuer: "https://example.com",
 				},
 			}
@@ -543,7 +543,7 @@ func TestValidateConfiguration_ConditionUpdate(t *testing.T) {
 				}
 			}
 
-			err := reconciler.validateConfiguration(context.Background(), oidc, statusMgr)
+			err := reconciler.validateConfiguration(context.Background(), oidc, statusMgr, &v1alpha2.ZeroTrustWorkloadIdentityManager{})
 			// validateConfiguration should succeed regardless of existing condition state
 			if err != nil {
 				t.Fatalf("Unexpected error: %v", err)
@@ -575,7 +575,7 @@ func TestHandleCreateOnlyMode_NotSet(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+	oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
 	}
 
@@ -638,7 +638,7 @@ func TestReconcile_FullFlow(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &SpireOidcDiscoveryProviderReconciler{
 		ctrlClient:    fakeClient,
@@ -648,7 +648,7 @@ func TestReconcile_FullFlow(t *testing.T) {
 		eventRecorder: record.NewFakeRecorder(100),
 	}
 
-	oidcProvider := &v1alpha1.SpireOIDCDiscoveryProvider{
+	oidcProvider := &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			OwnerReferences: []metav1.OwnerReference{
@@ -660,27 +660,27 @@ func TestReconcile_FullFlow(t *testing.T) {
 				},
 			},
 		},
-		Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 			JwtIssuer: "https://example.com",
 		},
 	}
 
-	ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
 		},
-		Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 			TrustDomain: "example.org",
 		},
 	}
 
 	fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 		switch v := obj.(type) {
-		case *v1alpha1.SpireOIDCDiscoveryProvider:
+		case *v1alpha2.SpireOIDCDiscoveryProvider:
 			*v = *oidcProvider
 			return nil
-		case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+		case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 			*v = *ztwim
 			return nil
 		default:
@@ -709,13 +709,13 @@ func TestValidateConfiguration_ConfigurationValidPreviouslyFalse(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+	oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 			JwtIssuer: "https://example.com",
 		},
-		Status: v1alpha1.SpireOIDCDiscoveryProviderStatus{
-			ConditionalStatus: v1alpha1.ConditionalStatus{
+		Status: v1alpha2.SpireOIDCDiscoveryProviderStatus{
+			ConditionalStatus: v1alpha2.ConditionalStatus{
 				Conditions: []metav1.Condition{
 					{
 						Type:   ConfigurationValid,
@@ -728,7 +728,7 @@ func TestValidateConfiguration_ConfigurationValidPreviouslyFalse(t *testing.T) {
 	}
 
 	statusMgr := status.NewManager(fakeClient)
-	err := reconciler.validateConfiguration(context.Background(), oidc, statusMgr)
+	err := reconciler.validateConfiguration(context.Background(), oidc, statusMgr, &v1alpha2.ZeroTrustWorkloadIdentityManager{})
 
 	if err != nil {
 		t.Errorf("Expected no error for valid configuration, got: %v", err)
@@ -767,7 +767,7 @@ func TestReconcile_ErrorScenarios(t *testing.T) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					callCount++
 					if callCount == 1 {
-						if oidc, ok := obj.(*v1alpha1.SpireOIDCDiscoveryProvider); ok {
+						if oidc, ok := obj.(*v1alpha2.SpireOIDCDiscoveryProvider); ok {
 							oidc.Name = "cluster"
 						}
 						return nil
@@ -785,7 +785,7 @@ func TestReconcile_ErrorScenarios(t *testing.T) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					callCount++
 					if callCount == 1 {
-						if oidc, ok := obj.(*v1alpha1.SpireOIDCDiscoveryProvider); ok {
+						if oidc, ok := obj.(*v1alpha2.SpireOIDCDiscoveryProvider); ok {
 							oidc.Name = "cluster"
 						}
 						return nil
@@ -804,12 +804,12 @@ func TestReconcile_ErrorScenarios(t *testing.T) {
 					callCount++
 					switch callCount {
 					case 1:
-						if oidc, ok := obj.(*v1alpha1.SpireOIDCDiscoveryProvider); ok {
+						if oidc, ok := obj.(*v1alpha2.SpireOIDCDiscoveryProvider); ok {
 							oidc.Name = "cluster"
 						}
 						return nil
 					case 2:
-						if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+						if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 							z.Name = "cluster"
 							z.UID = "test-uid"
 						}
@@ -821,7 +821,7 @@ func TestReconcile_ErrorScenarios(t *testing.T) {
 			},
 			setupReconciler: func(r *SpireOidcDiscoveryProviderReconciler) {
 				scheme := runtime.NewScheme()
-				_ = v1alpha1.AddToScheme(scheme)
+				_ = v1alpha2.AddToScheme(scheme)
 				r.scheme = scheme
 			},
 			expectError:   true,
@@ -940,7 +940,7 @@ func TestHandleCreateOnlyMode_AllScenarios(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			reconciler := newTestReconciler(fakeClient)
 
-			oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
 			}
 			if tt.existingCond != nil {
@@ -961,14 +961,14 @@ func TestHandleCreateOnlyMode_AllScenarios(t *testing.T) {
 func TestValidateConfiguration_AllScenarios(t *testing.T) {
 	tests := []struct {
 		name        string
-		oidc        *v1alpha1.SpireOIDCDiscoveryProvider
+		oidc        *v1alpha2.SpireOIDCDiscoveryProvider
 		expectError bool
 	}{
 		{
 			name: "valid configuration",
-			oidc: &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc: &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 					JwtIssuer: "https://example.com",
 				},
 			},
@@ -976,9 +976,9 @@ func TestValidateConfiguration_AllScenarios(t *testing.T) {
 		},
 		{
 			name: "invalid JWT issuer URL",
-			oidc: &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc: &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 					JwtIssuer: "not-a-valid-url",
 				},
 			},
@@ -986,11 +986,11 @@ func TestValidateConfiguration_AllScenarios(t *testing.T) {
 		},
 		{
 			name: "invalid affinity with empty node selector terms",
-			oidc: &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc: &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 					JwtIssuer: "https://example.com",
-					CommonConfig: v1alpha1.CommonConfig{
+					CommonConfig: v1alpha2.CommonConfig{
 						Affinity: &corev1.Affinity{
 							NodeAffinity: &corev1.NodeAffinity{
 								RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
@@ -1011,7 +1011,7 @@ func TestValidateConfiguration_AllScenarios(t *testing.T) {
 			reconciler := newTestReconciler(fakeClient)
 			statusMgr := status.NewManager(fakeClient)
 
-			err := reconciler.validateConfiguration(context.Background(), tt.oidc, statusMgr)
+			err := reconciler.validateConfiguration(context.Background(), tt.oidc, statusMgr, &v1alpha2.ZeroTrustWorkloadIdentityManager{})
 
 			if tt.expectError && err == nil {
 				t.Fatal("Expected error but got nil")
@@ -1052,7 +1052,7 @@ func TestReconcileClusterSpiffeIDs_AllScenarios(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 
 			reconciler := &SpireOidcDiscoveryProviderReconciler{
 				ctrlClient:    fakeClient,
@@ -1062,12 +1062,12 @@ func TestReconcileClusterSpiffeIDs_AllScenarios(t *testing.T) {
 				eventRecorder: record.NewFakeRecorder(100),
 			}
 
-			oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "cluster",
 					UID:  "test-uid",
 				},
-				Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 					JwtIssuer: "https://example.com",
 				},
 			}
@@ -1282,7 +1282,7 @@ func TestReconcileServiceAccount_ErrorPropagation(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 
 			reconciler := &SpireOidcDiscoveryProviderReconciler{
 				ctrlClient:    fakeClient,
@@ -1299,7 +1299,7 @@ func TestReconcileServiceAccount_ErrorPropagation(t *testing.T) {
 				fakeClient.CreateReturns(nil)
 			}
 
-			oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			}
 			statusMgr := status.NewManager(fakeClient)
@@ -1341,7 +1341,7 @@ func TestReconcileService_ErrorPropagation(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 
 			reconciler := &SpireOidcDiscoveryProviderReconciler{
 				ctrlClient:    fakeClient,
@@ -1356,7 +1356,7 @@ func TestReconcileService_ErrorPropagation(t *testing.T) {
 				fakeClient.CreateReturns(tt.createErr)
 			}
 
-			oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			}
 			statusMgr := status.NewManager(fakeClient)
@@ -1388,7 +1388,7 @@ func TestReconcile_AllErrorPaths(t *testing.T) {
 					callCount++
 					switch callCount {
 					case 1:
-						if o, ok := obj.(*v1alpha1.SpireOIDCDiscoveryProvider); ok {
+						if o, ok := obj.(*v1alpha2.SpireOIDCDiscoveryProvider); ok {
 							o.Name = "cluster"
 							o.UID = "test-uid"
 							o.Spec.JwtIssuer = "https://example.com"
@@ -1401,7 +1401,7 @@ func TestReconcile_AllErrorPaths(t *testing.T) {
 						}
 						return nil
 					case 2:
-						if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+						if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 							z.Name = "cluster"
 							z.UID = "ztwim-uid"
 							z.Spec.TrustDomain = "example.org"
@@ -1422,7 +1422,7 @@ func TestReconcile_AllErrorPaths(t *testing.T) {
 					callCount++
 					switch callCount {
 					case 1:
-						if o, ok := obj.(*v1alpha1.SpireOIDCDiscoveryProvider); ok {
+						if o, ok := obj.(*v1alpha2.SpireOIDCDiscoveryProvider); ok {
 							o.Name = "cluster"
 							o.UID = "test-uid"
 							o.Spec.JwtIssuer = "https://example.com"
@@ -1435,7 +1435,7 @@ func TestReconcile_AllErrorPaths(t *testing.T) {
 						}
 						return nil
 					case 2:
-						if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+						if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 							z.Name = "cluster"
 							z.UID = "ztwim-uid"
 							z.Spec.TrustDomain = "example.org"
@@ -1458,7 +1458,7 @@ func TestReconcile_AllErrorPaths(t *testing.T) {
 					callCount++
 					switch callCount {
 					case 1:
-						if o, ok := obj.(*v1alpha1.SpireOIDCDiscoveryProvider); ok {
+						if o, ok := obj.(*v1alpha2.SpireOIDCDiscoveryProvider); ok {
 							o.Name = "cluster"
 							o.UID = "test-uid"
 							o.Spec.JwtIssuer = "https://example.com"
@@ -1471,7 +1471,7 @@ func TestReconcile_AllErrorPaths(t *testing.T) {
 						}
 						return nil
 					case 2:
-						if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+						if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 							z.Name = "cluster"
 							z.UID = "ztwim-uid"
 							z.Spec.TrustDomain = "example.org"
@@ -1492,7 +1492,7 @@ func TestReconcile_AllErrorPaths(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 
 			reconciler := &SpireOidcDiscoveryProviderReconciler{
 				ctrlClient:    fakeClient,
@@ -1534,7 +1534,7 @@ func TestReconcile_AllErrorPaths(t *testing.T) {
 func TestReconcile_SuccessfulPath_NoRequeue(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 
 	reconciler := &SpireOidcDiscoveryProviderReconciler{
@@ -1550,7 +1550,7 @@ func TestReconcile_SuccessfulPath_NoRequeue(t *testing.T) {
 		callCount++
 		switch callCount {
 		case 1: // SpireOIDCDiscoveryProvider
-			if o, ok := obj.(*v1alpha1.SpireOIDCDiscoveryProvider); ok {
+			if o, ok := obj.(*v1alpha2.SpireOIDCDiscoveryProvider); ok {
 				o.Name = "cluster"
 				o.UID = "test-uid"
 				o.Spec.JwtIssuer = "https://example.com"
@@ -1563,7 +1563,7 @@ func TestReconcile_SuccessfulPath_NoRequeue(t *testing.T) {
 			}
 			return nil
 		case 2: // ZTWIM
-			if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+			if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 				z.Name = "cluster"
 				z.UID = "ztwim-uid"
 				z.Spec.TrustDomain = "example.org"
@@ -1678,7 +1678,7 @@ func TestReconcile_ReconciliationStepErrors_MutationKillers(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 
 			reconciler := &SpireOidcDiscoveryProviderReconciler{
 				ctrlClient:    fakeClient,
@@ -1693,7 +1693,7 @@ func TestReconcile_ReconciliationStepErrors_MutationKillers(t *testing.T) {
 				callCount++
 				switch callCount {
 				case 1:
-					if o, ok := obj.(*v1alpha1.SpireOIDCDiscoveryProvider); ok {
+					if o, ok := obj.(*v1alpha2.SpireOIDCDiscoveryProvider); ok {
 						o.Name = "cluster"
 						o.UID = "test-uid"
 						o.Spec.JwtIssuer = "https://example.com"
@@ -1706,7 +1706,7 @@ func TestReconcile_ReconciliationStepErrors_MutationKillers(t *testing.T) {
 					}
 					return nil
 				case 2:
-					if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+					if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 						z.Name = "cluster"
 						z.UID = "ztwim-uid"
 						z.Spec.TrustDomain = "example.org"
@@ -3,8 +3,11 @@ package spire_oidc_discovery_provider
 import (
 	"context"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/dependencies"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/topology"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -17,8 +20,16 @@ import (
 )
 
 // reconcileDeployment reconciles the OIDC Discovery Provider Deployment
-func (r *SpireOidcDiscoveryProviderReconciler) reconcileDeployment(ctx context.Context, oidc *v1alpha1.SpireOIDCDiscoveryProvider, statusMgr *status.Manager, createOnlyMode bool, configHash string) error {
+func (r *SpireOidcDiscoveryProviderReconciler) reconcileDeployment(ctx context.Context, oidc *v1alpha2.SpireOIDCDiscoveryProvider, statusMgr *status.Manager, createOnlyMode bool, configHash string, profile topology.Profile) error {
 	deployment := generateDeployment(oidc, configHash)
+	applyTopologyProfile(deployment, profile)
+	if err := dependencies.Annotate(ctx, r.ctrlClient, &deployment.Spec.Template); err != nil {
+		r.log.Error(err, "failed to hash oidc discovery provider dependencies")
+		statusMgr.AddCondition(DeploymentAvailable, "SpireOIDCDeploymentCreationFailed",
+			err.Error(),
+			metav1.ConditionFalse)
+		return err
+	}
 	if err := controllerutil.SetControllerReference(oidc, deployment, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference")
 		statusMgr.AddCondition(DeploymentAvailable, "SpireOIDCDeploymentCreationFailed",
@@ -33,7 +44,7 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileDeployment(ctx context.C
 		Namespace: deployment.Namespace,
 	}, &existingSpireOidcDeployment)
 	if err != nil && kerrors.IsNotFound(err) {
-		if err = r.ctrlClient.Create(ctx, deployment); err != nil {
+		if err = r.ctrlClient.Create(ctx, deployment, customClient.AdoptExisting(ptr.Deref(oidc.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "Failed to create spire oidc discovery provider deployment")
 			statusMgr.AddCondition(DeploymentAvailable, "SpireOIDCDeploymentCreationFailed",
 				err.Error(),
@@ -42,8 +53,12 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileDeployment(ctx context.C
 		}
 		r.log.Info("Created spire oidc discovery provider deployment")
 	} else if err == nil && needsUpdate(existingSpireOidcDeployment, *deployment) {
+		versionChange := status.IsOperandVersionChange(existingSpireOidcDeployment.Labels, deployment.Labels,
+			&existingSpireOidcDeployment.Spec.Template.Spec, &deployment.Spec.Template.Spec, "spiffe-oidc-discovery-provider")
 		if createOnlyMode {
 			r.log.Info("Skipping Deployment update due to create-only mode")
+		} else if !statusMgr.CheckUpgradeOrder(ctx, utils.ResourceKindSpireOIDCDiscoveryProvider, oidc.Status.ConditionalStatus.Conditions, versionChange) {
+			r.log.Info("Deferring spire oidc discovery provider deployment upgrade until prerequisite operands are upgraded")
 		} else {
 			deployment.ResourceVersion = existingSpireOidcDeployment.ResourceVersion
 			if err = r.ctrlClient.Update(ctx, deployment); err != nil {
@@ -70,7 +85,7 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileDeployment(ctx context.C
 }
 
 // generateDeployment generates and return the deployment manifest based on configuration provided via SpireOIDCDiscoveryProvider spec.
-func generateDeployment(config *v1alpha1.SpireOIDCDiscoveryProvider, spireOidcConfigMapHash string) *appsv1.Deployment {
+func generateDeployment(config *v1alpha2.SpireOIDCDiscoveryProvider, spireOidcConfigMapHash string) *appsv1.Deployment {
 
 	// Generate standardized labels once and reuse them
 	labels := utils.SpireOIDCDiscoveryProviderLabels(config.Spec.Labels)
@@ -190,14 +205,19 @@ func generateDeployment(config *v1alpha1.SpireOIDCDiscoveryProvider, spireOidcCo
 							Resources: utils.DerefResourceRequirements(config.Spec.Resources),
 						},
 					},
-					Affinity:     config.Spec.Affinity,
-					NodeSelector: utils.DerefNodeSelector(config.Spec.NodeSelector),
-					Tolerations:  utils.DerefTolerations(config.Spec.Tolerations),
+					Affinity:         config.Spec.Affinity,
+					NodeSelector:     utils.DerefNodeSelector(config.Spec.NodeSelector),
+					Tolerations:      utils.DerefTolerations(config.Spec.Tolerations),
+					DNSPolicy:        utils.DerefDNSPolicy(config.Spec.DNSPolicy),
+					DNSConfig:        config.Spec.DNSConfig.DeepCopy(),
+					ImagePullSecrets: config.Spec.ImagePullSecrets,
 				},
 			},
 		},
 	}
 
+	addAdditionalIssuerContainers(&deployment.Spec.Template.Spec, config.Spec.AdditionalIssuers)
+
 	// Add proxy configuration if enabled
 	utils.AddProxyConfigToPod(&deployment.Spec.Template.Spec)
 
@@ -6,9 +6,10 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/topology"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -27,14 +28,14 @@ import (
 func TestBuildDeployment(t *testing.T) {
 	tests := []struct {
 		name     string
-		config   *v1alpha1.SpireOIDCDiscoveryProvider
+		config   *v1alpha2.SpireOIDCDiscoveryProvider
 		hash     string
 		expected func(*appsv1.Deployment)
 	}{
 		{
 			name: "basic deployment with default settings",
-			config: &v1alpha1.SpireOIDCDiscoveryProvider{
-				Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{},
+			config: &v1alpha2.SpireOIDCDiscoveryProvider{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{},
 			},
 			hash: "test-hash-123",
 			expected: func(deployment *appsv1.Deployment) {
@@ -50,8 +51,8 @@ func TestBuildDeployment(t *testing.T) {
 		},
 		{
 			name: "deployment with custom replica count",
-			config: &v1alpha1.SpireOIDCDiscoveryProvider{
-				Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+			config: &v1alpha2.SpireOIDCDiscoveryProvider{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 					ReplicaCount: 3,
 				},
 			},
@@ -62,8 +63,8 @@ func TestBuildDeployment(t *testing.T) {
 		},
 		{
 			name: "config hash annotation is placed on pod template only",
-			config: &v1alpha1.SpireOIDCDiscoveryProvider{
-				Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{},
+			config: &v1alpha2.SpireOIDCDiscoveryProvider{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{},
 			},
 			hash: "config-hash-xyz",
 			expected: func(deployment *appsv1.Deployment) {
@@ -83,9 +84,9 @@ func TestBuildDeployment(t *testing.T) {
 		},
 		{
 			name: "deployment with custom labels",
-			config: &v1alpha1.SpireOIDCDiscoveryProvider{
-				Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+			config: &v1alpha2.SpireOIDCDiscoveryProvider{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{
 							"custom-label":           "custom-value",
 							"environment":            "test",
@@ -110,9 +111,9 @@ func TestBuildDeployment(t *testing.T) {
 		},
 		{
 			name: "deployment with node selector",
-			config: &v1alpha1.SpireOIDCDiscoveryProvider{
-				Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+			config: &v1alpha2.SpireOIDCDiscoveryProvider{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						NodeSelector: map[string]string{
 							"node-type": "compute",
 							"zone":      "us-west-1a",
@@ -130,9 +131,9 @@ func TestBuildDeployment(t *testing.T) {
 		},
 		{
 			name: "deployment with affinity",
-			config: &v1alpha1.SpireOIDCDiscoveryProvider{
-				Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+			config: &v1alpha2.SpireOIDCDiscoveryProvider{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Affinity: &corev1.Affinity{
 							NodeAffinity: &corev1.NodeAffinity{
 								RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
@@ -161,9 +162,9 @@ func TestBuildDeployment(t *testing.T) {
 		},
 		{
 			name: "deployment with tolerations",
-			config: &v1alpha1.SpireOIDCDiscoveryProvider{
-				Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+			config: &v1alpha2.SpireOIDCDiscoveryProvider{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Tolerations: []*corev1.Toleration{
 							{
 								Key:      "node-role",
@@ -194,9 +195,9 @@ func TestBuildDeployment(t *testing.T) {
 		},
 		{
 			name: "deployment with resource requirements",
-			config: &v1alpha1.SpireOIDCDiscoveryProvider{
-				Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+			config: &v1alpha2.SpireOIDCDiscoveryProvider{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Resources: &corev1.ResourceRequirements{
 							Requests: corev1.ResourceList{
 								corev1.ResourceCPU:    resource.MustParse("100m"),
@@ -217,8 +218,8 @@ func TestBuildDeployment(t *testing.T) {
 		},
 		{
 			name: "deployment with custom CSI driver name",
-			config: &v1alpha1.SpireOIDCDiscoveryProvider{
-				Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+			config: &v1alpha2.SpireOIDCDiscoveryProvider{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 					CSIDriverName: "csi.example.com",
 				},
 			},
@@ -240,8 +241,8 @@ func TestBuildDeployment(t *testing.T) {
 		},
 		{
 			name: "deployment uses default CSI driver name when not specified",
-			config: &v1alpha1.SpireOIDCDiscoveryProvider{
-				Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+			config: &v1alpha2.SpireOIDCDiscoveryProvider{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 					// CSIDriverName not specified, should use default
 				},
 			},
@@ -342,7 +343,7 @@ func TestReconcileDeployment(t *testing.T) {
 		fakeClient.GetReturns(kerrors.NewNotFound(schema.GroupResource{}, "spire-spiffe-oidc-discovery-provider"))
 		fakeClient.CreateReturns(nil)
 
-		err := reconciler.reconcileDeployment(context.Background(), oidc, statusMgr, false, "test-hash")
+		err := reconciler.reconcileDeployment(context.Background(), oidc, statusMgr, false, "test-hash", topology.ProfileHighlyAvailable)
 
 		if err != nil {
 			t.Errorf("Expected no error, got: %v", err)
@@ -362,7 +363,7 @@ func TestReconcileDeployment(t *testing.T) {
 		fakeClient.GetReturns(kerrors.NewNotFound(schema.GroupResource{}, "spire-spiffe-oidc-discovery-provider"))
 		fakeClient.CreateReturns(errors.New("create failed"))
 
-		err := reconciler.reconcileDeployment(context.Background(), oidc, statusMgr, false, "test-hash")
+		err := reconciler.reconcileDeployment(context.Background(), oidc, statusMgr, false, "test-hash", topology.ProfileHighlyAvailable)
 
 		if err == nil {
 			t.Error("Expected error when Create fails")
@@ -378,7 +379,7 @@ func TestReconcileDeployment(t *testing.T) {
 
 		fakeClient.GetReturns(errors.New("connection refused"))
 
-		err := reconciler.reconcileDeployment(context.Background(), oidc, statusMgr, false, "test-hash")
+		err := reconciler.reconcileDeployment(context.Background(), oidc, statusMgr, false, "test-hash", topology.ProfileHighlyAvailable)
 
 		if err == nil {
 			t.Error("Expected error when Get fails")
@@ -413,7 +414,7 @@ func TestReconcileDeployment(t *testing.T) {
 		}
 		fakeClient.UpdateReturns(nil)
 
-		err := reconciler.reconcileDeployment(context.Background(), oidc, statusMgr, false, "new-hash")
+		err := reconciler.reconcileDeployment(context.Background(), oidc, statusMgr, false, "new-hash", topology.ProfileHighlyAvailable)
 
 		if err != nil {
 			t.Errorf("Expected no error, got: %v", err)
@@ -451,7 +452,7 @@ func TestReconcileDeployment(t *testing.T) {
 		}
 		fakeClient.UpdateReturns(errors.New("update conflict"))
 
-		err := reconciler.reconcileDeployment(context.Background(), oidc, statusMgr, false, "new-hash")
+		err := reconciler.reconcileDeployment(context.Background(), oidc, statusMgr, false, "new-hash", topology.ProfileHighlyAvailable)
 
 		if err == nil {
 			t.Error("Expected error when Update fails")
@@ -485,7 +486,7 @@ func TestReconcileDeployment(t *testing.T) {
 			return nil
 		}
 
-		err := reconciler.reconcileDeployment(context.Background(), oidc, statusMgr, true, "new-hash")
+		err := reconciler.reconcileDeployment(context.Background(), oidc, statusMgr, true, "new-hash", topology.ProfileHighlyAvailable)
 
 		if err != nil {
 			t.Errorf("Expected no error, got: %v", err)
@@ -508,7 +509,7 @@ func TestReconcileDeployment(t *testing.T) {
 		oidc := createDeploymentTestOIDCCR()
 		statusMgr := status.NewManager(fakeClient)
 
-		err := reconciler.reconcileDeployment(context.Background(), oidc, statusMgr, false, "test-hash")
+		err := reconciler.reconcileDeployment(context.Background(), oidc, statusMgr, false, "test-hash", topology.ProfileHighlyAvailable)
 
 		if err == nil {
 			t.Error("Expected error when SetControllerReference fails")
@@ -519,7 +520,7 @@ func TestReconcileDeployment(t *testing.T) {
 // newDeploymentTestReconciler creates a reconciler for Deployment tests
 func newDeploymentTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireOidcDiscoveryProviderReconciler {
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = appsv1.AddToScheme(scheme)
 	return &SpireOidcDiscoveryProviderReconciler{
 		ctrlClient:    fakeClient,
@@ -531,13 +532,13 @@ func newDeploymentTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireO
 }
 
 // createDeploymentTestOIDCCR creates a test SpireOIDCDiscoveryProvider for Deployment tests
-func createDeploymentTestOIDCCR() *v1alpha1.SpireOIDCDiscoveryProvider {
-	return &v1alpha1.SpireOIDCDiscoveryProvider{
+func createDeploymentTestOIDCCR() *v1alpha2.SpireOIDCDiscoveryProvider {
+	return &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
 		},
-		Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 			JwtIssuer:    "https://oidc.example.org",
 			ReplicaCount: 1,
 		},
@@ -0,0 +1,142 @@
+package spire_oidc_discovery_provider
+
+import (
+	"context"
+	"fmt"
+
+	routev1 "github.com/openshift/api/route/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// maxJWTIssuerAliases is the maximum number of JWT issuer aliases of the SpireServer, each
+// served through its own Route
+const maxJWTIssuerAliases = 4
+
+// aliasRouteName returns the name of the Route serving the JWT issuer alias at the given index
+func aliasRouteName(index int) string {
+	return fmt.Sprintf("spire-oidc-discovery-provider-alias-%d", index)
+}
+
+// jwtIssuerAliases returns the JWT issuer aliases of the SpireServer, which the OIDC discovery
+// provider keeps serving during an issuer migration
+func (r *SpireOidcDiscoveryProviderReconciler) jwtIssuerAliases(ctx context.Context) ([]string, error) {
+	var server v1alpha2.SpireServer
+	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: "cluster"}, &server); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get SpireServer for the JWT issuer aliases: %w", err)
+	}
+	return server.Spec.JwtIssuerAliases, nil
+}
+
+// jwtIssuerAliasHosts returns the hosts of the JWT issuer aliases. The host of the jwtIssuer is
+// left out, as it is served already.
+func jwtIssuerAliasHosts(jwtIssuer string, aliases []string) ([]string, error) {
+	issuerHost, err := utils.StripProtocolFromJWTIssuer(jwtIssuer)
+	if err != nil {
+		return nil, err
+	}
+	var hosts []string
+	for _, alias := range aliases {
+		host, err := utils.StripProtocolFromJWTIssuer(alias)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWT issuer alias %s: %w", alias, err)
+		}
+		if host != issuerHost && len(hosts) < maxJWTIssuerAliases {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts, nil
+}
+
+// reconcileAliasRoutes creates a Route per JWT issuer alias, next to the Route of the jwtIssuer,
+// and deletes the Routes of the aliases that were removed
+func (r *SpireOidcDiscoveryProviderReconciler) reconcileAliasRoutes(ctx context.Context, oidc *v1alpha2.SpireOIDCDiscoveryProvider, statusMgr *status.Manager, createOnlyMode bool) error {
+	// The aliases are only served through Routes
+	if !utils.HasCapability(utils.CapabilityRoute) {
+		return nil
+	}
+
+	var hosts []string
+	if ptr.Deref(oidc.Spec.ManagedRoute, false) {
+		aliases, err := r.jwtIssuerAliases(ctx)
+		if err == nil {
+			hosts, err = jwtIssuerAliasHosts(oidc.Spec.JwtIssuer, aliases)
+		}
+		if err != nil {
+			return r.aliasRouteFailed(statusMgr, err)
+		}
+	}
+
+	if err := r.reconcileHostRoutes(ctx, oidc, hosts, maxJWTIssuerAliases, aliasRouteName, nil, createOnlyMode); err != nil {
+		return r.aliasRouteFailed(statusMgr, err)
+	}
+	return nil
+}
+
+// reconcileHostRoutes creates or updates a Route per host, named by routeName from its index, and
+// deletes the Routes left over up to maxRoutes. The Routes target the port named by targetPort, or
+// the port of the jwtIssuer when it is nil.
+func (r *SpireOidcDiscoveryProviderReconciler) reconcileHostRoutes(ctx context.Context, oidc *v1alpha2.SpireOIDCDiscoveryProvider, hosts []string, maxRoutes int,
+	routeName, targetPort func(int) string, createOnlyMode bool) error {
+	for index := 0; index < maxRoutes; index++ {
+		var existing routev1.Route
+		err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: routeName(index), Namespace: utils.GetOperatorNamespace()}, &existing)
+		if err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+		exists := err == nil
+
+		if index >= len(hosts) {
+			if exists {
+				if err := r.ctrlClient.Delete(ctx, &existing); err != nil && !kerrors.IsNotFound(err) {
+					return err
+				}
+				r.log.Info("Deleted route", "Name", existing.Name)
+			}
+			continue
+		}
+
+		route, err := generateOIDCDiscoveryProviderRoute(oidc)
+		if err != nil {
+			return err
+		}
+		route.Name = routeName(index)
+		route.Spec.Host = hosts[index]
+		if targetPort != nil {
+			route.Spec.Port.TargetPort = intstr.FromString(targetPort(index))
+		}
+
+		if !exists {
+			if err := r.ctrlClient.Create(ctx, route, customClient.AdoptExisting(ptr.Deref(oidc.Spec.AdoptExistingResources, false))); err != nil {
+				return err
+			}
+			r.log.Info("Created route", "Name", route.Name, "Host", route.Spec.Host)
+		} else if checkRouteConflict(&existing, route) && !createOnlyMode {
+			route.ResourceVersion = existing.ResourceVersion
+			if err := r.ctrlClient.Update(ctx, route); err != nil {
+				return err
+			}
+			r.log.Info("Updated route", "Name", route.Name, "Host", route.Spec.Host)
+		}
+	}
+	return nil
+}
+
+func (r *SpireOidcDiscoveryProviderReconciler) aliasRouteFailed(statusMgr *status.Manager, err error) error {
+	r.log.Error(err, "Failed to reconcile JWT issuer alias routes")
+	statusMgr.AddCondition(RouteAvailable, "JWTIssuerAliasRouteFailed",
+		fmt.Sprintf("Failed to reconcile JWT issuer alias routes: %v", err),
+		metav1.ConditionFalse)
+	return err
+}
@@ -0,0 +1,88 @@
+package spire_oidc_discovery_provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+)
+
+func TestJWTIssuerAliasHosts(t *testing.T) {
+	hosts, err := jwtIssuerAliasHosts("https://oidc.new.example.com", []string{
+		"https://oidc.old.example.com",
+		"https://oidc.new.example.com",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"oidc.old.example.com"}, hosts, "the host of the jwtIssuer is served already")
+
+	_, err = jwtIssuerAliasHosts("https://oidc.new.example.com", []string{"not a url"})
+	assert.Error(t, err)
+}
+
+func TestGenerateOIDCConfigMapFromCR_JWTIssuerAliases(t *testing.T) {
+	oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{JwtIssuer: "https://oidc.new.example.com"},
+	}
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.org"}}
+
+	cm, err := generateOIDCConfigMapFromCR(oidc, ztwim, []string{"https://oidc.old.example.com"})
+	require.NoError(t, err)
+	var config struct {
+		Domains []string `json:"domains"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(cm.Data["oidc-discovery-provider.conf"]), &config))
+	assert.Contains(t, config.Domains, "oidc.new.example.com")
+	assert.Contains(t, config.Domains, "oidc.old.example.com")
+}
+
+func TestReconcileAliasRoutes(t *testing.T) {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	reconciler := newTestReconciler(fakeClient)
+	oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "oidc-uid"},
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
+			JwtIssuer:    "https://oidc.new.example.com",
+			ManagedRoute: ptr.To(true),
+		},
+	}
+
+	// One alias left, the Route of a second one removed since
+	fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		switch o := obj.(type) {
+		case *v1alpha2.SpireServer:
+			o.Spec.JwtIssuerAliases = []string{"https://oidc.old.example.com"}
+			return nil
+		case *routev1.Route:
+			if key.Name == aliasRouteName(1) {
+				o.Name = key.Name
+				o.Namespace = key.Namespace
+				return nil
+			}
+		}
+		return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+	}
+
+	require.NoError(t, reconciler.reconcileAliasRoutes(context.Background(), oidc, status.NewManager(fakeClient), false))
+
+	require.Equal(t, 1, fakeClient.CreateCallCount())
+	_, created, _ := fakeClient.CreateArgsForCall(0)
+	route := created.(*routev1.Route)
+	assert.Equal(t, aliasRouteName(0), route.Name)
+	assert.Equal(t, "oidc.old.example.com", route.Spec.Host)
+
+	require.Equal(t, 1, fakeClient.DeleteCallCount())
+	_, deleted, _ := fakeClient.DeleteArgsForCall(0)
+	assert.Equal(t, aliasRouteName(1), deleted.GetName())
+}
@@ -0,0 +1,576 @@
+package spire_oidc_discovery_provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/version"
+)
+
+const (
+	// JWTValidatingProxiesAvailable reports the reverse proxies validating the JWT-SVIDs of the
+	// requests to legacy services
+	JWTValidatingProxiesAvailable = "JWTValidatingProxiesAvailable"
+
+	JWTValidatingProxiesReasonReady         = "JWTValidatingProxiesReady"
+	JWTValidatingProxiesReasonNotReady      = "JWTValidatingProxiesNotReady"
+	JWTValidatingProxiesReasonFailed        = "JWTValidatingProxiesFailed"
+	JWTValidatingProxiesReasonImageNotSet   = "JWTValidatingProxyImageNotSet"
+	JWTValidatingProxiesReasonNotConfigured = "JWTValidatingProxiesNotConfigured"
+
+	// jwtValidatingProxyLabel holds the name of the proxy on the objects deployed for it
+	jwtValidatingProxyLabel = "ztwim.openshift.io/jwt-validating-proxy"
+
+	// jwtValidatingProxyConfigHashAnnotationKey rolls the proxy when its configuration changes
+	jwtValidatingProxyConfigHashAnnotationKey = "ztwim.openshift.io/jwt-validating-proxy-config-hash"
+
+	jwtValidatingProxyConfigKey  = "envoy.json"
+	jwtValidatingProxyConfigPath = "/etc/jwt-validating-proxy"
+
+	// serviceCABundleConfigMap is published in every namespace with the certificate of the
+	// OpenShift service CA, which issues the serving certificate of the OIDC discovery provider
+	serviceCABundleConfigMap = "openshift-service-ca.crt"
+	serviceCABundlePath      = "/etc/jwt-validating-proxy/service-ca"
+	serviceCABundleKey       = "service-ca.crt"
+
+	jwtValidatingProxyPortName = "http"
+)
+
+// The names of the proxies are chosen by the administrator, so their Deployments and Services
+// cannot be listed by name
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;update;delete,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;update;delete,namespace=zero-trust-workload-identity-manager
+
+// jwtValidatingProxyName returns the name of the Deployment, Service and ConfigMap of the proxy
+func jwtValidatingProxyName(proxy v1alpha2.JWTValidatingProxy) string {
+	return "jwt-proxy-" + proxy.Name
+}
+
+// jwtValidatingProxyPort returns the port the proxy listens on
+func jwtValidatingProxyPort(proxy v1alpha2.JWTValidatingProxy) int32 {
+	if proxy.Port == 0 {
+		return 8080
+	}
+	return proxy.Port
+}
+
+// jwtValidatingProxyLabels returns the labels of the objects of the proxy, and the labels
+// selecting its pods
+func jwtValidatingProxyLabels(oidc *v1alpha2.SpireOIDCDiscoveryProvider, proxy v1alpha2.JWTValidatingProxy) (map[string]string, map[string]string) {
+	labels := utils.StandardizedLabels("jwt-validating-proxy", utils.ComponentDiscovery, version.SpireOIDCDiscoveryProviderVersion, oidc.Spec.Labels)
+	labels[jwtValidatingProxyLabel] = proxy.Name
+	selector := map[string]string{
+		"app.kubernetes.io/name":      labels["app.kubernetes.io/name"],
+		"app.kubernetes.io/instance":  labels["app.kubernetes.io/instance"],
+		"app.kubernetes.io/component": labels["app.kubernetes.io/component"],
+		jwtValidatingProxyLabel:       proxy.Name,
+	}
+	return labels, selector
+}
+
+// jwtValidatingProxyCluster returns the Envoy cluster of an upstream reached over HTTP, or over
+// TLS with a certificate issued by the OpenShift service CA
+func jwtValidatingProxyCluster(name, host string, port int, tls bool) map[string]interface{} {
+	cluster := map[string]interface{}{
+		"name":            name,
+		"type":            "LOGICAL_DNS",
+		"connect_timeout": "5s",
+		"load_assignment": map[string]interface{}{
+			"cluster_name": name,
+			"endpoints": []interface{}{map[string]interface{}{
+				"lb_endpoints": []interface{}{map[string]interface{}{
+					"endpoint": map[string]interface{}{
+						"address": map[string]interface{}{
+							"socket_address": map[string]interface{}{"address": host, "port_value": port},
+						},
+					},
+				}},
+			}},
+		},
+	}
+	if tls {
+		cluster["transport_socket"] = map[string]interface{}{
+			"name": "envoy.transport_sockets.tls",
+			"typed_config": map[string]interface{}{
+				"@type": "type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.UpstreamTlsContext",
+				"sni":   host,
+				"common_tls_context": map[string]interface{}{
+					"validation_context": map[string]interface{}{
+						"trusted_ca": map[string]interface{}{"filename": serviceCABundlePath + "/" + serviceCABundleKey},
+						"match_typed_subject_alt_names": []interface{}{map[string]interface{}{
+							"san_type": "DNS",
+							"matcher":  map[string]interface{}{"exact": host},
+						}},
+					},
+				},
+			},
+		}
+	}
+	return cluster
+}
+
+// parseUpstream returns the host, port and scheme of the upstream URL of a proxy
+func parseUpstream(upstream string) (string, int, bool, error) {
+	u, err := url.Parse(upstream)
+	if err != nil {
+		return "", 0, false, fmt.Errorf("invalid upstream %s: %w", upstream, err)
+	}
+	tls := u.Scheme == "https"
+	if !tls && u.Scheme != "http" {
+		return "", 0, false, fmt.Errorf("invalid upstream %s: the scheme must be http or https", upstream)
+	}
+	port := 80
+	if tls {
+		port = 443
+	}
+	if u.Port() != "" {
+		port, err = strconv.Atoi(u.Port())
+		if err != nil || port < 1 || port > 65535 {
+			return "", 0, false, fmt.Errorf("invalid upstream %s: invalid port %s", upstream, u.Port())
+		}
+	}
+	if u.Hostname() == "" {
+		return "", 0, false, fmt.Errorf("invalid upstream %s: the host is missing", upstream)
+	}
+	return u.Hostname(), port, tls, nil
+}
+
+// generateJWTValidatingProxyConfig returns the Envoy bootstrap configuration of the proxy. The
+// jwt_authn filter rejects the requests without a JWT-SVID issued by the jwtIssuer for one of the
+// audiences of the proxy, whose signature is checked against the keys served by the OIDC
+// discovery provider Service. The validated token is forwarded to the upstream.
+func generateJWTValidatingProxyConfig(proxy v1alpha2.JWTValidatingProxy, jwtIssuer string) (string, error) {
+	upstreamHost, upstreamPort, upstreamTLS, err := parseUpstream(proxy.Upstream)
+	if err != nil {
+		return "", err
+	}
+	oidcHost := "spire-spiffe-oidc-discovery-provider." + utils.GetOperatorNamespace() + ".svc"
+
+	config := map[string]interface{}{
+		"static_resources": map[string]interface{}{
+			"listeners": []interface{}{map[string]interface{}{
+				"name": "jwt-validating-proxy",
+				"address": map[string]interface{}{
+					"socket_address": map[string]interface{}{"address": "0.0.0.0", "port_value": jwtValidatingProxyPort(proxy)},
+				},
+				"filter_chains": []interface{}{map[string]interface{}{
+					"filters": []interface{}{map[string]interface{}{
+						"name": "envoy.filters.network.http_connection_manager",
+						"typed_config": map[string]interface{}{
+							"@type":       "type.googleapis.com/envoy.extensions.filters.network.http_connection_manager.v3.HttpConnectionManager",
+							"stat_prefix": "jwt_validating_proxy",
+							"route_config": map[string]interface{}{
+								"virtual_hosts": []interface{}{map[string]interface{}{
+									"name":    "upstream",
+									"domains": []string{"*"},
+									"routes": []interface{}{map[string]interface{}{
+										"match": map[string]interface{}{"prefix": "/"},
+										"route": map[string]interface{}{"cluster": "upstream"},
+									}},
+								}},
+							},
+							"http_filters": []interface{}{
+								map[string]interface{}{
+									"name": "envoy.filters.http.jwt_authn",
+									"typed_config": map[string]interface{}{
+										"@type": "type.googleapis.com/envoy.extensions.filters.http.jwt_authn.v3.JwtAuthentication",
+										"providers": map[string]interface{}{
+											"spiffe": map[string]interface{}{
+												"issuer":    jwtIssuer,
+												"audiences": proxy.Audiences,
+												"forward":   true,
+												"remote_jwks": map[string]interface{}{
+													"http_uri": map[string]interface{}{
+														"uri":     "https://" + oidcHost + "/keys",
+														"cluster": "oidc-discovery-provider",
+														"timeout": "5s",
+													},
+													"cache_duration": "300s",
+												},
+											},
+										},
+										"rules": []interface{}{map[string]interface{}{
+											"match":    map[string]interface{}{"prefix": "/"},
+											"requires": map[string]interface{}{"provider_name": "spiffe"},
+										}},
+									},
+								},
+								map[string]interface{}{
+									"name": "envoy.filters.http.router",
+									"typed_config": map[string]interface{}{
+										"@type": "type.googleapis.com/envoy.extensions.filters.http.router.v3.Router",
+									},
+								},
+							},
+						},
+					}},
+				}},
+			}},
+			"clusters": []interface{}{
+				jwtValidatingProxyCluster("upstream", upstreamHost, upstreamPort, upstreamTLS),
+				jwtValidatingProxyCluster("oidc-discovery-provider", oidcHost, 443, true),
+			},
+		},
+	}
+
+	configJSON, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the configuration of JWT validating proxy %s: %w", proxy.Name, err)
+	}
+	return string(configJSON), nil
+}
+
+// generateJWTValidatingProxyConfigMap returns the ConfigMap holding the configuration of the proxy
+func generateJWTValidatingProxyConfigMap(oidc *v1alpha2.SpireOIDCDiscoveryProvider, proxy v1alpha2.JWTValidatingProxy) (*corev1.ConfigMap, error) {
+	config, err := generateJWTValidatingProxyConfig(proxy, oidc.Spec.JwtIssuer)
+	if err != nil {
+		return nil, err
+	}
+	labels, _ := jwtValidatingProxyLabels(oidc, proxy)
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jwtValidatingProxyName(proxy),
+			Namespace: utils.GetOperatorNamespace(),
+			Labels:    labels,
+		},
+		Data: map[string]string{jwtValidatingProxyConfigKey: config},
+	}, nil
+}
+
+// generateJWTValidatingProxyService returns the Service the clients of the legacy service call
+// instead of the legacy service
+func generateJWTValidatingProxyService(oidc *v1alpha2.SpireOIDCDiscoveryProvider, proxy v1alpha2.JWTValidatingProxy) *corev1.Service {
+	labels, selector := jwtValidatingProxyLabels(oidc, proxy)
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jwtValidatingProxyName(proxy),
+			Namespace: utils.GetOperatorNamespace(),
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       jwtValidatingProxyPortName,
+					Port:       jwtValidatingProxyPort(proxy),
+					TargetPort: intstr.FromString(jwtValidatingProxyPortName),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+			Selector: selector,
+		},
+	}
+}
+
+// generateJWTValidatingProxyDeployment returns the Deployment of the proxy, rolled out again when
+// its configuration changes
+func generateJWTValidatingProxyDeployment(oidc *v1alpha2.SpireOIDCDiscoveryProvider, proxy v1alpha2.JWTValidatingProxy, configMap *corev1.ConfigMap) *appsv1.Deployment {
+	labels, selector := jwtValidatingProxyLabels(oidc, proxy)
+	replicas := proxy.Replicas
+	if replicas == 0 {
+		replicas = 1
+	}
+	hash := sha256.Sum256([]byte(configMap.Data[jwtValidatingProxyConfigKey]))
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jwtValidatingProxyName(proxy),
+			Namespace: utils.GetOperatorNamespace(),
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+					Annotations: map[string]string{
+						jwtValidatingProxyConfigHashAnnotationKey: hex.EncodeToString(hash[:]),
+					},
+				},
+				Spec: corev1.PodSpec{
+					AutomountServiceAccountToken: ptr.To(false),
+					Volumes: []corev1.Volume{
+						{
+							Name: "config",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: configMap.Name},
+								},
+							},
+						},
+						{
+							Name: "service-ca",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: serviceCABundleConfigMap},
+								},
+							},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:            "jwt-validating-proxy",
+							Image:           utils.GetJWTValidatingProxyImage(),
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Command:         []string{"envoy"},
+							Args: []string{
+								"--config-path", jwtValidatingProxyConfigPath + "/" + jwtValidatingProxyConfigKey,
+								"--log-level", envoyLogLevel(oidc.Spec.LogLevel),
+							},
+							Ports: []corev1.ContainerPort{
+								{Name: jwtValidatingProxyPortName, ContainerPort: jwtValidatingProxyPort(proxy), Protocol: corev1.ProtocolTCP},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "config", MountPath: jwtValidatingProxyConfigPath + "/" + jwtValidatingProxyConfigKey, SubPath: jwtValidatingProxyConfigKey, ReadOnly: true},
+								{Name: "service-ca", MountPath: serviceCABundlePath, ReadOnly: true},
+							},
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromString(jwtValidatingProxyPortName)},
+								},
+								InitialDelaySeconds: 5,
+								PeriodSeconds:       5,
+							},
+							Resources: utils.DerefResourceRequirements(proxy.Resources),
+							SecurityContext: &corev1.SecurityContext{
+								AllowPrivilegeEscalation: ptr.To(false),
+								Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+								ReadOnlyRootFilesystem:   ptr.To(true),
+								RunAsNonRoot:             ptr.To(true),
+							},
+						},
+					},
+					Affinity:         oidc.Spec.Affinity,
+					NodeSelector:     utils.DerefNodeSelector(oidc.Spec.NodeSelector),
+					Tolerations:      utils.DerefTolerations(oidc.Spec.Tolerations),
+					ImagePullSecrets: oidc.Spec.ImagePullSecrets,
+				},
+			},
+		},
+	}
+	// Requests to HTTPS upstreams outside the cluster go through the cluster-wide proxy
+	utils.AddProxyConfigToPod(&deployment.Spec.Template.Spec)
+	return deployment
+}
+
+// envoyLogLevel returns the Envoy log level matching the log level of the operand
+func envoyLogLevel(logLevel string) string {
+	switch logLevel {
+	case "debug", "warn", "error":
+		return logLevel
+	default:
+		return "info"
+	}
+}
+
+// reconcileJWTValidatingProxies deploys the configured JWT validating proxies, and deletes the
+// objects of the proxies removed from the spec
+func (r *SpireOidcDiscoveryProviderReconciler) reconcileJWTValidatingProxies(ctx context.Context, oidc *v1alpha2.SpireOIDCDiscoveryProvider, statusMgr *status.Manager, createOnlyMode bool) error {
+	proxies := oidc.Spec.JWTValidatingProxies
+	if len(proxies) == 0 {
+		// Only clean up if proxies were previously deployed
+		if apimeta.FindStatusCondition(oidc.Status.Conditions, JWTValidatingProxiesAvailable) == nil {
+			return nil
+		}
+		if err := r.deleteJWTValidatingProxies(ctx, oidc, nil); err != nil {
+			r.log.Error(err, "failed to delete JWT validating proxies")
+			statusMgr.AddCondition(JWTValidatingProxiesAvailable, JWTValidatingProxiesReasonFailed,
+				fmt.Sprintf("Failed to delete the JWT validating proxies: %v", err),
+				metav1.ConditionFalse)
+			return err
+		}
+		statusMgr.AddCondition(JWTValidatingProxiesAvailable, JWTValidatingProxiesReasonNotConfigured,
+			"No JWT validating proxy is configured",
+			metav1.ConditionTrue)
+		return nil
+	}
+
+	if utils.GetJWTValidatingProxyImage() == "" {
+		statusMgr.AddCondition(JWTValidatingProxiesAvailable, JWTValidatingProxiesReasonImageNotSet,
+			fmt.Sprintf("The JWT validating proxies cannot be deployed: %s is not set on the operator", utils.JWTValidatingProxyImageEnv),
+			metav1.ConditionFalse)
+		return nil
+	}
+
+	adopt := ptr.Deref(oidc.Spec.AdoptExistingResources, false)
+	names := map[string]bool{}
+	for _, proxy := range proxies {
+		names[proxy.Name] = true
+		if err := r.applyJWTValidatingProxy(ctx, oidc, proxy, createOnlyMode, adopt); err != nil {
+			r.log.Error(err, "failed to apply JWT validating proxy", "proxy", proxy.Name)
+			statusMgr.AddCondition(JWTValidatingProxiesAvailable, JWTValidatingProxiesReasonFailed,
+				fmt.Sprintf("Failed to deploy JWT validating proxy %s: %v", proxy.Name, err),
+				metav1.ConditionFalse)
+			return err
+		}
+	}
+	if err := r.deleteJWTValidatingProxies(ctx, oidc, names); err != nil {
+		r.log.Error(err, "failed to delete removed JWT validating proxies")
+		statusMgr.AddCondition(JWTValidatingProxiesAvailable, JWTValidatingProxiesReasonFailed,
+			fmt.Sprintf("Failed to delete the removed JWT validating proxies: %v", err),
+			metav1.ConditionFalse)
+		return err
+	}
+
+	var notReady []string
+	for _, proxy := range proxies {
+		var deployment appsv1.Deployment
+		key := client.ObjectKey{Name: jwtValidatingProxyName(proxy), Namespace: utils.GetOperatorNamespace()}
+		if err := r.ctrlClient.Get(ctx, key, &deployment); err != nil || !status.IsDeploymentHealthy(&deployment) {
+			notReady = append(notReady, proxy.Name)
+		}
+	}
+	if len(notReady) > 0 {
+		statusMgr.AddCondition(JWTValidatingProxiesAvailable, JWTValidatingProxiesReasonNotReady,
+			fmt.Sprintf("JWT validating proxies not ready: %s", strings.Join(notReady, ", ")),
+			metav1.ConditionFalse)
+		return nil
+	}
+	statusMgr.AddCondition(JWTValidatingProxiesAvailable, JWTValidatingProxiesReasonReady,
+		fmt.Sprintf("%d JWT validating proxies ready", len(proxies)),
+		metav1.ConditionTrue)
+	return nil
+}
+
+// applyJWTValidatingProxy creates or updates the ConfigMap, Service and Deployment of the proxy
+func (r *SpireOidcDiscoveryProviderReconciler) applyJWTValidatingProxy(ctx context.Context, oidc *v1alpha2.SpireOIDCDiscoveryProvider, proxy v1alpha2.JWTValidatingProxy, createOnlyMode, adopt bool) error {
+	configMap, err := generateJWTValidatingProxyConfigMap(oidc, proxy)
+	if err != nil {
+		return err
+	}
+	existingConfigMap := &corev1.ConfigMap{}
+	if err := r.applyJWTValidatingProxyObject(ctx, oidc, configMap, existingConfigMap, func() bool {
+		return utils.ResourceNeedsUpdate(existingConfigMap, configMap) || !reflect.DeepEqual(existingConfigMap.Data, configMap.Data)
+	}, createOnlyMode, adopt); err != nil {
+		return fmt.Errorf("failed to apply ConfigMap %s: %w", configMap.Name, err)
+	}
+
+	service := generateJWTValidatingProxyService(oidc, proxy)
+	existingService := &corev1.Service{}
+	if err := r.applyJWTValidatingProxyObject(ctx, oidc, service, existingService, func() bool {
+		// Preserve the fields set by Kubernetes before the comparison
+		service.Spec.ClusterIP = existingService.Spec.ClusterIP
+		service.Spec.ClusterIPs = existingService.Spec.ClusterIPs
+		service.Spec.IPFamilies = existingService.Spec.IPFamilies
+		service.Spec.IPFamilyPolicy = existingService.Spec.IPFamilyPolicy
+		service.Spec.InternalTrafficPolicy = existingService.Spec.InternalTrafficPolicy
+		service.Spec.SessionAffinity = existingService.Spec.SessionAffinity
+		return utils.ResourceNeedsUpdate(existingService, service)
+	}, createOnlyMode, adopt); err != nil {
+		return fmt.Errorf("failed to apply Service %s: %w", service.Name, err)
+	}
+
+	deployment := generateJWTValidatingProxyDeployment(oidc, proxy, configMap)
+	existingDeployment := &appsv1.Deployment{}
+	if err := r.applyJWTValidatingProxyObject(ctx, oidc, deployment, existingDeployment, func() bool {
+		return needsUpdate(*existingDeployment, *deployment)
+	}, createOnlyMode, adopt); err != nil {
+		return fmt.Errorf("failed to apply Deployment %s: %w", deployment.Name, err)
+	}
+	return nil
+}
+
+// applyJWTValidatingProxyObject creates the object, or updates it when needsUpdate reports the
+// existing object differs
+func (r *SpireOidcDiscoveryProviderReconciler) applyJWTValidatingProxyObject(ctx context.Context, oidc *v1alpha2.SpireOIDCDiscoveryProvider, desired, existing client.Object, needsUpdate func() bool, createOnlyMode, adopt bool) error {
+	if err := controllerutil.SetControllerReference(oidc, desired, r.scheme); err != nil {
+		return fmt.Errorf("failed to set the owner: %w", err)
+	}
+	if err := r.ctrlClient.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return err
+		}
+		return r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(adopt))
+	}
+	if createOnlyMode || !needsUpdate() {
+		return nil
+	}
+	desired.SetResourceVersion(existing.GetResourceVersion())
+	return r.ctrlClient.Update(ctx, desired)
+}
+
+// deleteJWTValidatingProxies deletes the objects of the proxies controlled by the
+// SpireOIDCDiscoveryProvider which are not kept
+func (r *SpireOidcDiscoveryProviderReconciler) deleteJWTValidatingProxies(ctx context.Context, oidc *v1alpha2.SpireOIDCDiscoveryProvider, keep map[string]bool) error {
+	opts := []client.ListOption{client.InNamespace(utils.GetOperatorNamespace()), client.HasLabels{jwtValidatingProxyLabel}}
+	var deployments appsv1.DeploymentList
+	if err := r.ctrlClient.List(ctx, &deployments, opts...); err != nil {
+		return err
+	}
+	var services corev1.ServiceList
+	if err := r.ctrlClient.List(ctx, &services, opts...); err != nil {
+		return err
+	}
+	var configMaps corev1.ConfigMapList
+	if err := r.ctrlClient.List(ctx, &configMaps, opts...); err != nil {
+		return err
+	}
+
+	var objects []client.Object
+	for i := range deployments.Items {
+		objects = append(objects, &deployments.Items[i])
+	}
+	for i := range services.Items {
+		objects = append(objects, &services.Items[i])
+	}
+	for i := range configMaps.Items {
+		objects = append(objects, &configMaps.Items[i])
+	}
+	var removed []string
+	for _, obj := range objects {
+		if keep[obj.GetLabels()[jwtValidatingProxyLabel]] || !metav1.IsControlledBy(obj, oidc) {
+			continue
+		}
+		if err := r.ctrlClient.Delete(ctx, obj); err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+		removed = append(removed, obj.GetName())
+	}
+	if len(removed) > 0 {
+		sort.Strings(removed)
+		r.log.Info("Deleted objects of removed JWT validating proxies", "objects", removed)
+	}
+	return nil
+}
+
+// validateJWTValidatingProxies checks the upstreams of the proxies can be reached
+func validateJWTValidatingProxies(proxies []v1alpha2.JWTValidatingProxy) error {
+	for _, proxy := range proxies {
+		host, _, _, err := parseUpstream(proxy.Upstream)
+		if err != nil {
+			return fmt.Errorf("JWT validating proxy %s: %w", proxy.Name, err)
+		}
+		// An upstream resolving to the proxy itself would forward the requests in a loop
+		if host == jwtValidatingProxyName(proxy) || strings.HasPrefix(host, jwtValidatingProxyName(proxy)+"."+utils.GetOperatorNamespace()+".") {
+			return fmt.Errorf("JWT validating proxy %s forwards the requests to itself", proxy.Name)
+		}
+		if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+			return fmt.Errorf("JWT validating proxy %s: upstream %s is the loopback address of the proxy pod", proxy.Name, proxy.Upstream)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package spire_oidc_discovery_provider
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func oidcWithJWTValidatingProxies(proxies ...v1alpha2.JWTValidatingProxy) *v1alpha2.SpireOIDCDiscoveryProvider {
+	return &v1alpha2.SpireOIDCDiscoveryProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "oidc-uid"},
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
+			JwtIssuer:            "https://oidc.example.org",
+			JWTValidatingProxies: proxies,
+		},
+	}
+}
+
+var legacyProxy = v1alpha2.JWTValidatingProxy{
+	Name:      "legacy",
+	Audiences: []string{"legacy-app"},
+	Upstream:  "https://legacy-app.legacy.svc:8443",
+}
+
+func TestGenerateJWTValidatingProxyConfig(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+	config, err := generateJWTValidatingProxyConfig(legacyProxy, "https://oidc.example.org")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(config), &parsed); err != nil {
+		t.Fatalf("Expected a JSON configuration: %v", err)
+	}
+	for _, expected := range []string{
+		`"issuer": "https://oidc.example.org"`,
+		`"legacy-app"`,
+		`"uri": "https://spire-spiffe-oidc-discovery-provider.test-ns.svc/keys"`,
+		`"address": "legacy-app.legacy.svc"`,
+		`"port_value": 8443`,
+		`"port_value": 8080`,
+		`"sni": "legacy-app.legacy.svc"`,
+	} {
+		if !strings.Contains(config, expected) {
+			t.Errorf("Expected the configuration to contain %s", expected)
+		}
+	}
+
+	plain := legacyProxy
+	plain.Upstream = "http://legacy-app.legacy.svc"
+	config, err = generateJWTValidatingProxyConfig(plain, "https://oidc.example.org")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(config, `"port_value": 80`) || strings.Contains(config, `"sni": "legacy-app.legacy.svc"`) {
+		t.Error("Expected a plain HTTP upstream on port 80")
+	}
+}
+
+func TestValidateJWTValidatingProxies(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+	tests := []struct {
+		name      string
+		upstream  string
+		expectErr bool
+	}{
+		{name: "service upstream", upstream: "http://legacy-app.legacy.svc:8080"},
+		{name: "proxy itself", upstream: "http://jwt-proxy-legacy.test-ns.svc:8080", expectErr: true},
+		{name: "loopback", upstream: "http://127.0.0.1:8080", expectErr: true},
+		{name: "invalid scheme", upstream: "ftp://legacy-app", expectErr: true},
+		{name: "invalid port", upstream: "http://legacy-app:99999", expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxy := legacyProxy
+			proxy.Upstream = tt.upstream
+			if err := validateJWTValidatingProxies([]v1alpha2.JWTValidatingProxy{proxy}); (err != nil) != tt.expectErr {
+				t.Errorf("Expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestGenerateJWTValidatingProxyDeployment(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+	t.Setenv(utils.JWTValidatingProxyImageEnv, "envoy:test")
+	oidc := oidcWithJWTValidatingProxies(legacyProxy)
+	configMap, err := generateJWTValidatingProxyConfigMap(oidc, legacyProxy)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	deployment := generateJWTValidatingProxyDeployment(oidc, legacyProxy, configMap)
+	if deployment.Name != "jwt-proxy-legacy" || *deployment.Spec.Replicas != 1 {
+		t.Errorf("Expected one replica of jwt-proxy-legacy, got %s with %d", deployment.Name, *deployment.Spec.Replicas)
+	}
+	if deployment.Spec.Selector.MatchLabels[jwtValidatingProxyLabel] != "legacy" {
+		t.Error("Expected the pods of the proxy to be selected by its name")
+	}
+	container := deployment.Spec.Template.Spec.Containers[0]
+	if container.Image != "envoy:test" || container.Ports[0].ContainerPort != 8080 {
+		t.Errorf("Expected the proxy image listening on 8080, got %s on %d", container.Image, container.Ports[0].ContainerPort)
+	}
+	hash := deployment.Spec.Template.Annotations[jwtValidatingProxyConfigHashAnnotationKey]
+
+	// A change of audiences rolls the proxy
+	changed := legacyProxy
+	changed.Audiences = []string{"other-app"}
+	configMap, _ = generateJWTValidatingProxyConfigMap(oidc, changed)
+	if generateJWTValidatingProxyDeployment(oidc, changed, configMap).Spec.Template.Annotations[jwtValidatingProxyConfigHashAnnotationKey] == hash {
+		t.Error("Expected the configuration hash to change with the audiences")
+	}
+
+	service := generateJWTValidatingProxyService(oidc, legacyProxy)
+	if service.Name != "jwt-proxy-legacy" || service.Spec.Ports[0].Port != 8080 || service.Spec.Selector[jwtValidatingProxyLabel] != "legacy" {
+		t.Errorf("Expected the Service to expose the proxy on 8080, got %+v", service.Spec)
+	}
+}
+
+func TestReconcileJWTValidatingProxies(t *testing.T) {
+	notFound := kerrors.NewNotFound(schema.GroupResource{}, "jwt-proxy-legacy")
+	controlledByOIDC := []metav1.OwnerReference{{
+		APIVersion: "operator.openshift.io/v1alpha2", Kind: "SpireOIDCDiscoveryProvider", Name: "cluster", UID: "oidc-uid", Controller: ptr.To(true),
+	}}
+
+	t.Run("deploys the proxies", func(t *testing.T) {
+		t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+		t.Setenv(utils.JWTValidatingProxyImageEnv, "envoy:test")
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		fakeClient.GetReturns(notFound)
+		reconciler := newTestReconciler(fakeClient)
+		_ = v1alpha2.AddToScheme(reconciler.scheme)
+		statusMgr := status.NewManager(fakeClient)
+
+		if err := reconciler.reconcileJWTValidatingProxies(context.Background(), oidcWithJWTValidatingProxies(legacyProxy), statusMgr, false); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fakeClient.CreateCallCount() != 3 {
+			t.Fatalf("Expected the ConfigMap, Service and Deployment to be created, got %d creates", fakeClient.CreateCallCount())
+		}
+		if _, obj, _ := fakeClient.CreateArgsForCall(2); !metav1.IsControlledBy(obj, &metav1.ObjectMeta{UID: "oidc-uid"}) {
+			t.Error("Expected the Deployment to be controlled by the SpireOIDCDiscoveryProvider")
+		}
+		// The Deployment is not found yet
+		if cond, _ := statusMgr.GetCondition(JWTValidatingProxiesAvailable); cond.Reason != JWTValidatingProxiesReasonNotReady {
+			t.Errorf("Expected the proxies not to be ready yet, got %s", cond.Reason)
+		}
+	})
+
+	t.Run("image not set", func(t *testing.T) {
+		t.Setenv(utils.JWTValidatingProxyImageEnv, "")
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		statusMgr := status.NewManager(fakeClient)
+
+		if err := newTestReconciler(fakeClient).reconcileJWTValidatingProxies(context.Background(), oidcWithJWTValidatingProxies(legacyProxy), statusMgr, false); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if cond, _ := statusMgr.GetCondition(JWTValidatingProxiesAvailable); cond.Reason != JWTValidatingProxiesReasonImageNotSet || cond.Status != metav1.ConditionFalse {
+			t.Errorf("Expected the missing image to be reported, got %s/%s", cond.Status, cond.Reason)
+		}
+		if fakeClient.CreateCallCount() != 0 {
+			t.Error("Expected nothing to be deployed")
+		}
+	})
+
+	t.Run("deletes the removed proxies", func(t *testing.T) {
+		t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			switch l := list.(type) {
+			case *appsv1.DeploymentList:
+				l.Items = []appsv1.Deployment{
+					{ObjectMeta: metav1.ObjectMeta{Name: "jwt-proxy-legacy", Labels: map[string]string{jwtValidatingProxyLabel: "legacy"}, OwnerReferences: controlledByOIDC}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "jwt-proxy-foreign", Labels: map[string]string{jwtValidatingProxyLabel: "foreign"}}},
+				}
+			case *corev1.ServiceList:
+				l.Items = []corev1.Service{{ObjectMeta: metav1.ObjectMeta{Name: "jwt-proxy-legacy", Labels: map[string]string{jwtValidatingProxyLabel: "legacy"}, OwnerReferences: controlledByOIDC}}}
+			}
+			return nil
+		}
+		oidc := oidcWithJWTValidatingProxies()
+		oidc.Status.Conditions = []metav1.Condition{{Type: JWTValidatingProxiesAvailable, Status: metav1.ConditionTrue}}
+		statusMgr := status.NewManager(fakeClient)
+
+		if err := newTestReconciler(fakeClient).reconcileJWTValidatingProxies(context.Background(), oidc, statusMgr, false); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fakeClient.DeleteCallCount() != 2 {
+			t.Fatalf("Expected the Deployment and Service of the removed proxy to be deleted, got %d deletes", fakeClient.DeleteCallCount())
+		}
+		if cond, _ := statusMgr.GetCondition(JWTValidatingProxiesAvailable); cond.Reason != JWTValidatingProxiesReasonNotConfigured {
+			t.Errorf("Expected no proxy to be reported, got %s", cond.Reason)
+		}
+	})
+
+	t.Run("never configured", func(t *testing.T) {
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		statusMgr := status.NewManager(fakeClient)
+		if err := newTestReconciler(fakeClient).reconcileJWTValidatingProxies(context.Background(), oidcWithJWTValidatingProxies(), statusMgr, false); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fakeClient.ListCallCount() != 0 {
+			t.Error("Expected no lookup when no proxy was ever deployed")
+		}
+		if _, ok := statusMgr.GetCondition(JWTValidatingProxiesAvailable); ok {
+			t.Error("Expected no condition")
+		}
+	})
+}
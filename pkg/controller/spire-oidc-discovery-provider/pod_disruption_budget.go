@@ -0,0 +1,139 @@
+package spire_oidc_discovery_provider
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/topology"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// PodDisruptionBudgetAvailable is the condition of the PodDisruptionBudget of the discovery provider
+const PodDisruptionBudgetAvailable = "PodDisruptionBudgetAvailable"
+
+// hostnameTopologyKey spreads the replicas across nodes
+const hostnameTopologyKey = "kubernetes.io/hostname"
+
+// isHighlyAvailable reports whether the replicas of the deployment are spread and protected by a
+// PodDisruptionBudget: only with several replicas on a highly available cluster
+func isHighlyAvailable(deployment *appsv1.Deployment, profile topology.Profile) bool {
+	return profile == topology.ProfileHighlyAvailable && deployment.Spec.Replicas != nil && *deployment.Spec.Replicas > 1
+}
+
+// applyTopologyProfile spreads the replicas of the deployment across nodes on highly available
+// clusters. The spread is best effort so the replicas still schedule when nodes are scarce.
+func applyTopologyProfile(deployment *appsv1.Deployment, profile topology.Profile) {
+	if !isHighlyAvailable(deployment, profile) {
+		return
+	}
+	deployment.Spec.Template.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       hostnameTopologyKey,
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector:     deployment.Spec.Selector.DeepCopy(),
+	}}
+}
+
+// generatePodDisruptionBudget returns the PodDisruptionBudget of the deployment, nil when the
+// deployment is not highly available
+func generatePodDisruptionBudget(deployment *appsv1.Deployment, profile topology.Profile) *policyv1.PodDisruptionBudget {
+	if !isHighlyAvailable(deployment, profile) {
+		return nil
+	}
+	maxUnavailable := intstr.FromInt32(1)
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.Name,
+			Namespace: deployment.Namespace,
+			Labels:    deployment.Labels,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector:       deployment.Spec.Selector.DeepCopy(),
+		},
+	}
+}
+
+// reconcilePodDisruptionBudget creates the PodDisruptionBudget of the deployment on highly
+// available clusters and deletes it otherwise, e.g. once the cluster is single-node
+func (r *SpireOidcDiscoveryProviderReconciler) reconcilePodDisruptionBudget(ctx context.Context, oidc *v1alpha2.SpireOIDCDiscoveryProvider, statusMgr *status.Manager, deployment *appsv1.Deployment, profile topology.Profile, createOnlyMode bool) error {
+	desired := generatePodDisruptionBudget(deployment, profile)
+
+	var existing policyv1.PodDisruptionBudget
+	err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: deployment.Name, Namespace: deployment.Namespace}, &existing)
+	if err != nil && !kerrors.IsNotFound(err) {
+		r.log.Error(err, "failed to get pod disruption budget")
+		statusMgr.AddCondition(PodDisruptionBudgetAvailable, v1alpha2.ReasonFailed,
+			fmt.Sprintf("Failed to get PodDisruptionBudget: %v", err),
+			metav1.ConditionFalse)
+		return err
+	}
+	exists := err == nil
+
+	if desired == nil {
+		if exists {
+			if err := r.ctrlClient.Delete(ctx, &existing); err != nil && !kerrors.IsNotFound(err) {
+				r.log.Error(err, "failed to delete pod disruption budget")
+				statusMgr.AddCondition(PodDisruptionBudgetAvailable, v1alpha2.ReasonFailed,
+					fmt.Sprintf("Failed to delete PodDisruptionBudget: %v", err),
+					metav1.ConditionFalse)
+				return err
+			}
+			r.log.Info("Deleted PodDisruptionBudget", "name", existing.Name, "profile", profile)
+		}
+		statusMgr.AddCondition(PodDisruptionBudgetAvailable, "PodDisruptionBudgetNotRequired",
+			fmt.Sprintf("No PodDisruptionBudget is required with the %s topology profile and %d replica(s)", profile, *deployment.Spec.Replicas),
+			metav1.ConditionTrue)
+		return nil
+	}
+
+	if err := controllerutil.SetControllerReference(oidc, desired, r.scheme); err != nil {
+		r.log.Error(err, "failed to set controller reference on pod disruption budget")
+		statusMgr.AddCondition(PodDisruptionBudgetAvailable, v1alpha2.ReasonFailed,
+			fmt.Sprintf("Failed to set owner reference on PodDisruptionBudget: %v", err),
+			metav1.ConditionFalse)
+		return err
+	}
+
+	if !exists {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(oidc.Spec.AdoptExistingResources, false))); err != nil {
+			r.log.Error(err, "failed to create pod disruption budget")
+			statusMgr.AddCondition(PodDisruptionBudgetAvailable, v1alpha2.ReasonFailed,
+				fmt.Sprintf("Failed to create PodDisruptionBudget: %v", err),
+				metav1.ConditionFalse)
+			return err
+		}
+		r.log.Info("Created PodDisruptionBudget", "name", desired.Name)
+	} else if !createOnlyMode && (!equality.Semantic.DeepEqual(existing.Spec.MaxUnavailable, desired.Spec.MaxUnavailable) ||
+		!equality.Semantic.DeepEqual(existing.Spec.Selector, desired.Spec.Selector) ||
+		!utils.LabelsMatch(existing.Labels, desired.Labels)) {
+		desired.ResourceVersion = existing.ResourceVersion
+		if err := r.ctrlClient.Update(ctx, desired); err != nil {
+			r.log.Error(err, "failed to update pod disruption budget")
+			statusMgr.AddCondition(PodDisruptionBudgetAvailable, v1alpha2.ReasonFailed,
+				fmt.Sprintf("Failed to update PodDisruptionBudget: %v", err),
+				metav1.ConditionFalse)
+			return err
+		}
+		r.log.Info("Updated PodDisruptionBudget", "name", desired.Name)
+	}
+
+	statusMgr.AddCondition(PodDisruptionBudgetAvailable, v1alpha2.ReasonReady,
+		"PodDisruptionBudget available",
+		metav1.ConditionTrue)
+	return nil
+}
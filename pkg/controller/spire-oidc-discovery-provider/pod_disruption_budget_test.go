@@ -0,0 +1,105 @@
+package spire_oidc_discovery_provider
+
+import (
+	"context"
+	"testing"
+
+	policyv1 "k8s.io/api/policy/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/topology"
+)
+
+func oidcWithReplicas(replicas int) *v1alpha2.SpireOIDCDiscoveryProvider {
+	return &v1alpha2.SpireOIDCDiscoveryProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Spec:       v1alpha2.SpireOIDCDiscoveryProviderSpec{ReplicaCount: replicas},
+	}
+}
+
+func TestApplyTopologyProfile(t *testing.T) {
+	deployment := generateDeployment(oidcWithReplicas(3), "hash")
+	applyTopologyProfile(deployment, topology.ProfileHighlyAvailable)
+	constraints := deployment.Spec.Template.Spec.TopologySpreadConstraints
+	if len(constraints) != 1 || constraints[0].TopologyKey != hostnameTopologyKey {
+		t.Fatalf("Expected the replicas to be spread across nodes, got %+v", constraints)
+	}
+
+	deployment = generateDeployment(oidcWithReplicas(3), "hash")
+	applyTopologyProfile(deployment, topology.ProfileSingleNode)
+	if len(deployment.Spec.Template.Spec.TopologySpreadConstraints) != 0 {
+		t.Error("Expected no spread on a single node cluster")
+	}
+
+	deployment = generateDeployment(oidcWithReplicas(1), "hash")
+	applyTopologyProfile(deployment, topology.ProfileHighlyAvailable)
+	if len(deployment.Spec.Template.Spec.TopologySpreadConstraints) != 0 {
+		t.Error("Expected no spread with a single replica")
+	}
+}
+
+func TestGeneratePodDisruptionBudget(t *testing.T) {
+	deployment := generateDeployment(oidcWithReplicas(2), "hash")
+	pdb := generatePodDisruptionBudget(deployment, topology.ProfileHighlyAvailable)
+	if pdb == nil || pdb.Name != deployment.Name || pdb.Spec.MaxUnavailable.IntValue() != 1 {
+		t.Fatalf("Expected a PodDisruptionBudget allowing one unavailable replica, got %+v", pdb)
+	}
+	if generatePodDisruptionBudget(deployment, topology.ProfileSingleNode) != nil {
+		t.Error("Expected no PodDisruptionBudget on a single node cluster")
+	}
+	if generatePodDisruptionBudget(generateDeployment(oidcWithReplicas(1), "hash"), topology.ProfileHighlyAvailable) != nil {
+		t.Error("Expected no PodDisruptionBudget with a single replica")
+	}
+}
+
+func TestReconcilePodDisruptionBudget(t *testing.T) {
+	notFound := kerrors.NewNotFound(schema.GroupResource{Group: "policy", Resource: "poddisruptionbudgets"}, "spire-spiffe-oidc-discovery-provider")
+
+	t.Run("creates on highly available clusters", func(t *testing.T) {
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		fakeClient.GetReturns(notFound)
+		reconciler := newTestReconciler(fakeClient)
+		oidc := oidcWithReplicas(2)
+		reconciler.scheme = runtime.NewScheme()
+		_ = v1alpha2.AddToScheme(reconciler.scheme)
+		statusMgr := status.NewManager(fakeClient)
+
+		if err := reconciler.reconcilePodDisruptionBudget(context.Background(), oidc, statusMgr, generateDeployment(oidc, "hash"), topology.ProfileHighlyAvailable, false); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fakeClient.CreateCallCount() != 1 {
+			t.Fatalf("Expected the PodDisruptionBudget to be created, got %d creates", fakeClient.CreateCallCount())
+		}
+		if _, obj, _ := fakeClient.CreateArgsForCall(0); obj.(*policyv1.PodDisruptionBudget).Spec.Selector == nil {
+			t.Error("Expected the PodDisruptionBudget to select the discovery provider pods")
+		}
+	})
+
+	t.Run("deletes on single node clusters", func(t *testing.T) {
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			*obj.(*policyv1.PodDisruptionBudget) = policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}}
+			return nil
+		}
+		reconciler := newTestReconciler(fakeClient)
+		oidc := oidcWithReplicas(2)
+		statusMgr := status.NewManager(fakeClient)
+
+		if err := reconciler.reconcilePodDisruptionBudget(context.Background(), oidc, statusMgr, generateDeployment(oidc, "hash"), topology.ProfileSingleNode, false); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fakeClient.DeleteCallCount() != 1 || fakeClient.CreateCallCount() != 0 {
+			t.Errorf("Expected the PodDisruptionBudget to be deleted, got %d deletes and %d creates", fakeClient.DeleteCallCount(), fakeClient.CreateCallCount())
+		}
+		if condition, ok := statusMgr.GetCondition(PodDisruptionBudgetAvailable); !ok || condition.Reason != "PodDisruptionBudgetNotRequired" {
+			t.Errorf("Expected the PodDisruptionBudget to be reported as not required, got %+v", condition)
+		}
+	})
+}
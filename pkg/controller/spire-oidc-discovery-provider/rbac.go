@@ -8,16 +8,18 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/operator/assets"
 )
 
 // reconcileExternalCertRBAC reconciles RBAC resources for router access to external certificate secret
-func (r *SpireOidcDiscoveryProviderReconciler) reconcileExternalCertRBAC(ctx context.Context, oidc *v1alpha1.SpireOIDCDiscoveryProvider, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireOidcDiscoveryProviderReconciler) reconcileExternalCertRBAC(ctx context.Context, oidc *v1alpha2.SpireOIDCDiscoveryProvider, statusMgr *status.Manager, createOnlyMode bool) error {
 	// Only create RBAC if externalSecretRef is configured
 	if oidc.Spec.ExternalSecretRef == "" {
 		return nil
@@ -33,7 +35,7 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileExternalCertRBAC(ctx con
 		return err
 	}
 
-	statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonReady,
+	statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonReady,
 		"RBAC resources available",
 		metav1.ConditionTrue)
 
@@ -41,7 +43,7 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileExternalCertRBAC(ctx con
 }
 
 // reconcileExternalCertRole reconciles the Role for router to read external certificate secret
-func (r *SpireOidcDiscoveryProviderReconciler) reconcileExternalCertRole(ctx context.Context, oidc *v1alpha1.SpireOIDCDiscoveryProvider, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireOidcDiscoveryProviderReconciler) reconcileExternalCertRole(ctx context.Context, oidc *v1alpha2.SpireOIDCDiscoveryProvider, statusMgr *status.Manager, createOnlyMode bool) error {
 	desired := getExternalCertRole(oidc.Spec.Labels)
 
 	// Set the specific secret name in resourceNames
@@ -49,7 +51,7 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileExternalCertRole(ctx con
 
 	if err := controllerutil.SetControllerReference(oidc, desired, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference on external cert role")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to set owner reference on external cert Role: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -63,16 +65,16 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileExternalCertRole(ctx con
 		if !kerrors.IsNotFound(err) {
 			// Unexpected error
 			r.log.Error(err, "failed to get external cert role")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to get external cert Role: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(oidc.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create external cert role")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to create external cert Role: %v", err),
 				metav1.ConditionFalse)
 			return err
@@ -98,7 +100,7 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileExternalCertRole(ctx con
 	desired.ResourceVersion = existing.ResourceVersion
 	if err := r.ctrlClient.Update(ctx, desired); err != nil {
 		r.log.Error(err, "failed to update external cert role")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to update external cert Role: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -109,12 +111,12 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileExternalCertRole(ctx con
 }
 
 // reconcileExternalCertRoleBinding reconciles the RoleBinding for router to read external certificate secret
-func (r *SpireOidcDiscoveryProviderReconciler) reconcileExternalCertRoleBinding(ctx context.Context, oidc *v1alpha1.SpireOIDCDiscoveryProvider, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireOidcDiscoveryProviderReconciler) reconcileExternalCertRoleBinding(ctx context.Context, oidc *v1alpha2.SpireOIDCDiscoveryProvider, statusMgr *status.Manager, createOnlyMode bool) error {
 	desired := getExternalCertRoleBinding(oidc.Spec.Labels)
 
 	if err := controllerutil.SetControllerReference(oidc, desired, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference on external cert role binding")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to set owner reference on external cert RoleBinding: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -128,16 +130,16 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileExternalCertRoleBinding(
 		if !kerrors.IsNotFound(err) {
 			// Unexpected error
 			r.log.Error(err, "failed to get external cert role binding")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to get external cert RoleBinding: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(oidc.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create external cert role binding")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to create external cert RoleBinding: %v", err),
 				metav1.ConditionFalse)
 			return err
@@ -163,7 +165,7 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileExternalCertRoleBinding(
 	desired.ResourceVersion = existing.ResourceVersion
 	if err := r.ctrlClient.Update(ctx, desired); err != nil {
 		r.log.Error(err, "failed to update external cert role binding")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to update external cert RoleBinding: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -16,7 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
@@ -274,7 +274,7 @@ func TestReconcileExternalCertRole(t *testing.T) {
 			},
 			setupScheme: func() *runtime.Scheme {
 				scheme := runtime.NewScheme()
-				_ = v1alpha1.AddToScheme(scheme)
+				_ = v1alpha2.AddToScheme(scheme)
 				_ = rbacv1.AddToScheme(scheme)
 				return scheme
 			},
@@ -318,7 +318,7 @@ func TestReconcileExternalCertRole(t *testing.T) {
 			},
 			setupScheme: func() *runtime.Scheme {
 				scheme := runtime.NewScheme()
-				_ = v1alpha1.AddToScheme(scheme)
+				_ = v1alpha2.AddToScheme(scheme)
 				_ = rbacv1.AddToScheme(scheme)
 				return scheme
 			},
@@ -369,7 +369,7 @@ func TestReconcileExternalCertRole(t *testing.T) {
 			},
 			setupScheme: func() *runtime.Scheme {
 				scheme := runtime.NewScheme()
-				_ = v1alpha1.AddToScheme(scheme)
+				_ = v1alpha2.AddToScheme(scheme)
 				_ = rbacv1.AddToScheme(scheme)
 				return scheme
 			},
@@ -408,7 +408,7 @@ func TestReconcileExternalCertRole(t *testing.T) {
 			},
 			setupScheme: func() *runtime.Scheme {
 				scheme := runtime.NewScheme()
-				_ = v1alpha1.AddToScheme(scheme)
+				_ = v1alpha2.AddToScheme(scheme)
 				_ = rbacv1.AddToScheme(scheme)
 				return scheme
 			},
@@ -447,7 +447,7 @@ func TestReconcileExternalCertRole(t *testing.T) {
 			setupObjects: func() []client.Object { return []client.Object{} },
 			setupScheme: func() *runtime.Scheme {
 				scheme := runtime.NewScheme()
-				_ = v1alpha1.AddToScheme(scheme)
+				_ = v1alpha2.AddToScheme(scheme)
 				_ = rbacv1.AddToScheme(scheme)
 				return scheme
 			},
@@ -465,7 +465,7 @@ func TestReconcileExternalCertRole(t *testing.T) {
 			setupObjects: func() []client.Object { return []client.Object{} },
 			setupScheme: func() *runtime.Scheme {
 				scheme := runtime.NewScheme()
-				_ = v1alpha1.AddToScheme(scheme)
+				_ = v1alpha2.AddToScheme(scheme)
 				_ = rbacv1.AddToScheme(scheme)
 				return scheme
 			},
@@ -501,7 +501,7 @@ func TestReconcileExternalCertRole(t *testing.T) {
 			},
 			setupScheme: func() *runtime.Scheme {
 				scheme := runtime.NewScheme()
-				_ = v1alpha1.AddToScheme(scheme)
+				_ = v1alpha2.AddToScheme(scheme)
 				_ = rbacv1.AddToScheme(scheme)
 				return scheme
 			},
@@ -553,12 +553,12 @@ func TestReconcileExternalCertRole(t *testing.T) {
 				log:        logr.Discard(),
 			}
 
-			oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test-oidc",
 					UID:  "test-uid",
 				},
-				Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 					ExternalSecretRef: "test-secret",
 				},
 			}
@@ -599,7 +599,7 @@ func TestReconcileExternalCertRoleBinding(t *testing.T) {
 			setupObjects: func() []client.Object { return []client.Object{} },
 			setupScheme: func() *runtime.Scheme {
 				scheme := runtime.NewScheme()
-				_ = v1alpha1.AddToScheme(scheme)
+				_ = v1alpha2.AddToScheme(scheme)
 				_ = rbacv1.AddToScheme(scheme)
 				return scheme
 			},
@@ -636,7 +636,7 @@ func TestReconcileExternalCertRoleBinding(t *testing.T) {
 			},
 			setupScheme: func() *runtime.Scheme {
 				scheme := runtime.NewScheme()
-				_ = v1alpha1.AddToScheme(scheme)
+				_ = v1alpha2.AddToScheme(scheme)
 				_ = rbacv1.AddToScheme(scheme)
 				return scheme
 			},
@@ -680,7 +680,7 @@ func TestReconcileExternalCertRoleBinding(t *testing.T) {
 			},
 			setupScheme: func() *runtime.Scheme {
 				scheme := runtime.NewScheme()
-				_ = v1alpha1.AddToScheme(scheme)
+				_ = v1alpha2.AddToScheme(scheme)
 				_ = rbacv1.AddToScheme(scheme)
 				return scheme
 			},
@@ -718,7 +718,7 @@ func TestReconcileExternalCertRoleBinding(t *testing.T) {
 			},
 			setupScheme: func() *runtime.Scheme {
 				scheme := runtime.NewScheme()
-				_ = v1alpha1.AddToScheme(scheme)
+				_ = v1alpha2.AddToScheme(scheme)
 				_ = rbacv1.AddToScheme(scheme)
 				return scheme
 			},
@@ -754,7 +754,7 @@ func TestReconcileExternalCertRoleBinding(t *testing.T) {
 			setupObjects: func() []client.Object { return []client.Object{} },
 			setupScheme: func() *runtime.Scheme {
 				scheme := runtime.NewScheme()
-				_ = v1alpha1.AddToScheme(scheme)
+				_ = v1alpha2.AddToScheme(scheme)
 				_ = rbacv1.AddToScheme(scheme)
 				return scheme
 			},
@@ -772,7 +772,7 @@ func TestReconcileExternalCertRoleBinding(t *testing.T) {
 			setupObjects: func() []client.Object { return []client.Object{} },
 			setupScheme: func() *runtime.Scheme {
 				scheme := runtime.NewScheme()
-				_ = v1alpha1.AddToScheme(scheme)
+				_ = v1alpha2.AddToScheme(scheme)
 				_ = rbacv1.AddToScheme(scheme)
 				return scheme
 			},
@@ -803,7 +803,7 @@ func TestReconcileExternalCertRoleBinding(t *testing.T) {
 			},
 			setupScheme: func() *runtime.Scheme {
 				scheme := runtime.NewScheme()
-				_ = v1alpha1.AddToScheme(scheme)
+				_ = v1alpha2.AddToScheme(scheme)
 				_ = rbacv1.AddToScheme(scheme)
 				return scheme
 			},
@@ -851,12 +851,12 @@ func TestReconcileExternalCertRoleBinding(t *testing.T) {
 				log:        logr.Discard(),
 			}
 
-			oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test-oidc",
 					UID:  "test-uid",
 				},
-				Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 					ExternalSecretRef: "test-secret",
 				},
 			}
@@ -1023,7 +1023,7 @@ func TestReconcileExternalCertRBAC(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Setup
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 			_ = rbacv1.AddToScheme(scheme)
 
 			store := newTestStore()
@@ -1039,12 +1039,12 @@ func TestReconcileExternalCertRBAC(t *testing.T) {
 				log:        logr.Discard(),
 			}
 
-			oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "test-oidc",
 					UID:  "test-uid",
 				},
-				Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 					ExternalSecretRef: tt.externalSecret,
 				},
 			}
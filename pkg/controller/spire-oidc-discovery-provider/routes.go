@@ -8,19 +8,28 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	routev1 "github.com/openshift/api/route/v1"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 )
 
 // reconcileRoute reconciles the OIDC Discovery Provider Route
-func (r *SpireOidcDiscoveryProviderReconciler) reconcileRoute(ctx context.Context, oidc *v1alpha1.SpireOIDCDiscoveryProvider, statusMgr *status.Manager, createOnlyMode bool) error {
-	if utils.StringToBool(oidc.Spec.ManagedRoute) {
+func (r *SpireOidcDiscoveryProviderReconciler) reconcileRoute(ctx context.Context, oidc *v1alpha2.SpireOIDCDiscoveryProvider, statusMgr *status.Manager, createOnlyMode bool) error {
+	if !utils.HasCapability(utils.CapabilityRoute) {
+		statusMgr.AddCondition(RouteAvailable, utils.ReasonAPINotServed,
+			"Routes are not served by the cluster, expose the spire-spiffe-oidc-discovery-provider Service instead",
+			metav1.ConditionTrue)
+		return nil
+	}
+
+	if ptr.Deref(oidc.Spec.ManagedRoute, false) {
 		// Create Route for OIDC Discovery Provider
 		route, err := generateOIDCDiscoveryProviderRoute(oidc)
 		if err != nil {
@@ -38,7 +47,7 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileRoute(ctx context.Contex
 		}, &existingRoute)
 		if err != nil {
 			if kerrors.IsNotFound(err) {
-				if err = r.ctrlClient.Create(ctx, route); err != nil {
+				if err = r.ctrlClient.Create(ctx, route, customClient.AdoptExisting(ptr.Deref(oidc.Spec.AdoptExistingResources, false))); err != nil {
 					r.log.Error(err, "Failed to create route")
 					statusMgr.AddCondition(RouteAvailable, "ManagedRouteCreationFailed",
 						err.Error(),
@@ -107,7 +116,7 @@ func checkRouteConflict(current, desired *routev1.Route) bool {
 }
 
 // generateOIDCDiscoveryProviderRoute creates an OpenShift Route resource for the SPIRE OIDC Discovery Provider
-func generateOIDCDiscoveryProviderRoute(config *v1alpha1.SpireOIDCDiscoveryProvider) (*routev1.Route, error) {
+func generateOIDCDiscoveryProviderRoute(config *v1alpha2.SpireOIDCDiscoveryProvider) (*routev1.Route, error) {
 	labels := utils.SpireOIDCDiscoveryProviderLabels(config.Spec.Labels)
 
 	// JWT Issuer validation and normalization
@@ -146,5 +155,30 @@ func generateOIDCDiscoveryProviderRoute(config *v1alpha1.SpireOIDCDiscoveryProvi
 		}
 	}
 
+	if config.Spec.Caching != nil {
+		route.Spec.HTTPHeaders = &routev1.RouteHTTPHeaders{
+			Actions: routev1.RouteHTTPHeaderActions{
+				Response: []routev1.RouteHTTPHeader{
+					{
+						Name: "Cache-Control",
+						Action: routev1.RouteHTTPHeaderActionUnion{
+							Type: routev1.Set,
+							Set:  &routev1.RouteSetHTTPHeader{Value: cacheControlHeaderValue(config.Spec.Caching)},
+						},
+					},
+				},
+			},
+		}
+	}
+
 	return route, nil
 }
+
+// cacheControlHeaderValue returns the Cache-Control header value for the caching configuration
+func cacheControlHeaderValue(caching *v1alpha2.OIDCCachingConfig) string {
+	value := fmt.Sprintf("public, max-age=%d", int64(caching.MaxAge.Seconds()))
+	if caching.StaleWhileRevalidate.Duration > 0 {
+		value += fmt.Sprintf(", stale-while-revalidate=%d", int64(caching.StaleWhileRevalidate.Seconds()))
+	}
+	return value
+}
@@ -4,13 +4,14 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	routev1 "github.com/openshift/api/route/v1"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
@@ -20,14 +21,15 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestGenerateOIDCDiscoveryProviderRoute(t *testing.T) {
 	t.Run("should generate Route with basic configuration", func(t *testing.T) {
 		// Arrange
-		config := &v1alpha1.SpireOIDCDiscoveryProvider{
-			Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+		config := &v1alpha2.SpireOIDCDiscoveryProvider{
+			Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 				JwtIssuer: "https://oidc-discovery.apps.example.com",
 			},
 		}
@@ -78,10 +80,10 @@ func TestGenerateOIDCDiscoveryProviderRoute(t *testing.T) {
 			"app.kubernetes.io/part-of": "custom-part-of", // This should override the default
 			"custom-key":                "custom-value",
 		}
-		config := &v1alpha1.SpireOIDCDiscoveryProvider{
-			Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+		config := &v1alpha2.SpireOIDCDiscoveryProvider{
+			Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 				JwtIssuer: "https://test.apps.cluster.com",
-				CommonConfig: v1alpha1.CommonConfig{
+				CommonConfig: v1alpha2.CommonConfig{
 					Labels: customLabels,
 				},
 			},
@@ -111,8 +113,8 @@ func TestGenerateOIDCDiscoveryProviderRoute(t *testing.T) {
 
 	t.Run("should handle empty JwtIssuer", func(t *testing.T) {
 		// Arrange
-		config := &v1alpha1.SpireOIDCDiscoveryProvider{
-			Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+		config := &v1alpha2.SpireOIDCDiscoveryProvider{
+			Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 				JwtIssuer: "", // Empty
 			},
 		}
@@ -128,10 +130,10 @@ func TestGenerateOIDCDiscoveryProviderRoute(t *testing.T) {
 
 	t.Run("should handle nil labels gracefully", func(t *testing.T) {
 		// Arrange
-		config := &v1alpha1.SpireOIDCDiscoveryProvider{
-			Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+		config := &v1alpha2.SpireOIDCDiscoveryProvider{
+			Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 				JwtIssuer: "https://nil-labels.example.com",
-				CommonConfig: v1alpha1.CommonConfig{
+				CommonConfig: v1alpha2.CommonConfig{
 					Labels: nil,
 				},
 			},
@@ -151,10 +153,10 @@ func TestGenerateOIDCDiscoveryProviderRoute(t *testing.T) {
 
 	t.Run("should use standardized managed-by label despite custom value", func(t *testing.T) {
 		// Arrange
-		config := &v1alpha1.SpireOIDCDiscoveryProvider{
-			Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+		config := &v1alpha2.SpireOIDCDiscoveryProvider{
+			Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 				JwtIssuer: "https://test.example.com",
-				CommonConfig: v1alpha1.CommonConfig{
+				CommonConfig: v1alpha2.CommonConfig{
 					Labels: map[string]string{
 						utils.AppManagedByLabelKey: "different-value",
 						"other-label":              "other-value",
@@ -175,8 +177,8 @@ func TestGenerateOIDCDiscoveryProviderRoute(t *testing.T) {
 
 	t.Run("should return consistent results across multiple calls", func(t *testing.T) {
 		// Arrange
-		config := &v1alpha1.SpireOIDCDiscoveryProvider{
-			Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+		config := &v1alpha2.SpireOIDCDiscoveryProvider{
+			Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 				JwtIssuer: "https://consistent.example.com",
 			},
 		}
@@ -198,8 +200,8 @@ func TestGenerateOIDCDiscoveryProviderRoute(t *testing.T) {
 
 	t.Run("should verify route spec structure", func(t *testing.T) {
 		// Arrange
-		config := &v1alpha1.SpireOIDCDiscoveryProvider{
-			Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+		config := &v1alpha2.SpireOIDCDiscoveryProvider{
+			Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 				JwtIssuer: "https://structure-test.example.com",
 			},
 		}
@@ -262,8 +264,8 @@ func TestGenerateOIDCDiscoveryProviderRoute_HostScenarios(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			config := &v1alpha1.SpireOIDCDiscoveryProvider{
-				Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+			config := &v1alpha2.SpireOIDCDiscoveryProvider{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 					JwtIssuer: tc.jwtIssuer,
 				},
 			}
@@ -277,15 +279,67 @@ func TestGenerateOIDCDiscoveryProviderRoute_HostScenarios(t *testing.T) {
 	}
 }
 
+func TestGenerateOIDCDiscoveryProviderRoute_Caching(t *testing.T) {
+	testCases := []struct {
+		name          string
+		caching       *v1alpha2.OIDCCachingConfig
+		expectedValue string
+	}{
+		{
+			name:    "no caching configured",
+			caching: nil,
+		},
+		{
+			name:          "max age only",
+			caching:       &v1alpha2.OIDCCachingConfig{MaxAge: metav1.Duration{Duration: 5 * time.Minute}},
+			expectedValue: "public, max-age=300",
+		},
+		{
+			name: "max age with stale while revalidate",
+			caching: &v1alpha2.OIDCCachingConfig{
+				MaxAge:               metav1.Duration{Duration: time.Minute},
+				StaleWhileRevalidate: metav1.Duration{Duration: 30 * time.Second},
+			},
+			expectedValue: "public, max-age=60, stale-while-revalidate=30",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &v1alpha2.SpireOIDCDiscoveryProvider{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
+					JwtIssuer: "https://oidc.example.com",
+					Caching:   tc.caching,
+				},
+			}
+
+			result, err := generateOIDCDiscoveryProviderRoute(config)
+
+			require.NoError(t, err)
+			if tc.expectedValue == "" {
+				assert.Nil(t, result.Spec.HTTPHeaders)
+				return
+			}
+			require.NotNil(t, result.Spec.HTTPHeaders)
+			require.Len(t, result.Spec.HTTPHeaders.Actions.Response, 1)
+			header := result.Spec.HTTPHeaders.Actions.Response[0]
+			assert.Equal(t, "Cache-Control", header.Name)
+			assert.Equal(t, routev1.Set, header.Action.Type)
+			require.NotNil(t, header.Action.Set)
+			assert.Equal(t, tc.expectedValue, header.Action.Set.Value)
+		})
+	}
+}
+
 // Test to ensure no mutation of input config
 func TestGenerateOIDCDiscoveryProviderRoute_NoMutation(t *testing.T) {
 	originalLabels := map[string]string{
 		"original": "value",
 	}
-	config := &v1alpha1.SpireOIDCDiscoveryProvider{
-		Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
+	config := &v1alpha2.SpireOIDCDiscoveryProvider{
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
 			JwtIssuer: "https://test.example.com",
-			CommonConfig: v1alpha1.CommonConfig{
+			CommonConfig: v1alpha2.CommonConfig{
 				Labels: originalLabels,
 			},
 		},
@@ -792,7 +846,7 @@ func TestCheckRouteConflict_TableDriven(t *testing.T) {
 // newRouteTestReconciler creates a reconciler for Route tests
 func newRouteTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireOidcDiscoveryProviderReconciler {
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = routev1.AddToScheme(scheme)
 	return &SpireOidcDiscoveryProviderReconciler{
 		ctrlClient:    fakeClient,
@@ -808,13 +862,13 @@ func TestReconcileRoute_ManagedRouteDisabled(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newRouteTestReconciler(fakeClient)
 
-	oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+	oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
 		},
-		Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
-			ManagedRoute: "false", // Disabled
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
+			ManagedRoute: ptr.To(false), // Disabled
 			JwtIssuer:    "https://test.example.com",
 		},
 	}
@@ -836,13 +890,13 @@ func TestReconcileRoute_CreateSuccess(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newRouteTestReconciler(fakeClient)
 
-	oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+	oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
 		},
-		Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
-			ManagedRoute: "true",
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
+			ManagedRoute: ptr.To(true),
 			JwtIssuer:    "https://test.example.com",
 		},
 	}
@@ -866,13 +920,13 @@ func TestReconcileRoute_CreateError(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newRouteTestReconciler(fakeClient)
 
-	oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+	oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
 		},
-		Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
-			ManagedRoute: "true",
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
+			ManagedRoute: ptr.To(true),
 			JwtIssuer:    "https://test.example.com",
 		},
 	}
@@ -894,13 +948,13 @@ func TestReconcileRoute_GetError(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newRouteTestReconciler(fakeClient)
 
-	oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+	oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
 		},
-		Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
-			ManagedRoute: "true",
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
+			ManagedRoute: ptr.To(true),
 			JwtIssuer:    "https://test.example.com",
 		},
 	}
@@ -921,13 +975,13 @@ func TestReconcileRoute_UpdateSuccess(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newRouteTestReconciler(fakeClient)
 
-	oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+	oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
 		},
-		Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
-			ManagedRoute: "true",
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
+			ManagedRoute: ptr.To(true),
 			JwtIssuer:    "https://test.example.com",
 		},
 	}
@@ -967,13 +1021,13 @@ func TestReconcileRoute_UpdateError(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newRouteTestReconciler(fakeClient)
 
-	oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+	oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
 		},
-		Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
-			ManagedRoute: "true",
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
+			ManagedRoute: ptr.To(true),
 			JwtIssuer:    "https://test.example.com",
 		},
 	}
@@ -1011,13 +1065,13 @@ func TestReconcileRoute_CreateOnlyMode(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newRouteTestReconciler(fakeClient)
 
-	oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+	oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
 		},
-		Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
-			ManagedRoute: "true",
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
+			ManagedRoute: ptr.To(true),
 			JwtIssuer:    "https://test.example.com",
 		},
 	}
@@ -1056,13 +1110,13 @@ func TestReconcileRoute_ExternalSecretRef(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newRouteTestReconciler(fakeClient)
 
-	oidc := &v1alpha1.SpireOIDCDiscoveryProvider{
+	oidc := &v1alpha2.SpireOIDCDiscoveryProvider{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
 		},
-		Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
-			ManagedRoute:      "true",
+		Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
+			ManagedRoute:      ptr.To(true),
 			JwtIssuer:         "https://test.example.com",
 			ExternalSecretRef: "custom-tls-secret",
 		},
@@ -8,9 +8,11 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/operator/assets"
@@ -19,12 +21,20 @@ import (
 // Constants for status conditions are defined in controller.go
 
 // reconcileService reconciles the Spire OIDC Discovery Provider Service
-func (r *SpireOidcDiscoveryProviderReconciler) reconcileService(ctx context.Context, oidc *v1alpha1.SpireOIDCDiscoveryProvider, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireOidcDiscoveryProviderReconciler) reconcileService(ctx context.Context, oidc *v1alpha2.SpireOIDCDiscoveryProvider, statusMgr *status.Manager, createOnlyMode bool) error {
 	desired := getSpireOIDCDiscoveryProviderService(oidc.Spec.Labels)
+	addAdditionalIssuerPorts(desired, oidc.Spec.AdditionalIssuers)
+	if err := utils.ApplyServiceConfig(desired, oidc.Spec.Service); err != nil {
+		r.log.Error(err, "invalid service configuration")
+		statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonFailed,
+			fmt.Sprintf("Invalid Service configuration: %v", err),
+			metav1.ConditionFalse)
+		return err
+	}
 
 	if err := controllerutil.SetControllerReference(oidc, desired, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference on service")
-		statusMgr.AddCondition(ServiceAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to set owner reference on Service: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -38,23 +48,23 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileService(ctx context.Cont
 		if !kerrors.IsNotFound(err) {
 			// Unexpected error
 			r.log.Error(err, "failed to get service")
-			statusMgr.AddCondition(ServiceAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to get Service: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(oidc.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create service")
-			statusMgr.AddCondition(ServiceAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to create Service: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		r.log.Info("Created Service", "name", desired.Name, "namespace", desired.Namespace)
-		statusMgr.AddCondition(ServiceAvailable, v1alpha1.ReasonReady,
+		statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonReady,
 			"All Service resources available",
 			metav1.ConditionTrue)
 		return nil
@@ -63,7 +73,7 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileService(ctx context.Cont
 	// Resource exists, check if we need to update
 	if createOnlyMode {
 		r.log.V(1).Info("Service exists, skipping update due to create-only mode", "name", desired.Name)
-		statusMgr.AddCondition(ServiceAvailable, v1alpha1.ReasonReady,
+		statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonReady,
 			"All Service resources available",
 			metav1.ConditionTrue)
 		return nil
@@ -80,6 +90,7 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileService(ctx context.Cont
 	if existing.Spec.HealthCheckNodePort != 0 {
 		desired.Spec.HealthCheckNodePort = existing.Spec.HealthCheckNodePort
 	}
+	utils.PreserveServiceNodePorts(existing, desired)
 
 	// Normalize ports - set default protocol to TCP if not specified
 	for i := range desired.Spec.Ports {
@@ -91,7 +102,7 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileService(ctx context.Cont
 	// Check if update is needed
 	if !utils.ResourceNeedsUpdate(existing, desired) {
 		r.log.V(1).Info("Service is up to date", "name", desired.Name)
-		statusMgr.AddCondition(ServiceAvailable, v1alpha1.ReasonReady,
+		statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonReady,
 			"All Service resources available",
 			metav1.ConditionTrue)
 		return nil
@@ -100,14 +111,14 @@ func (r *SpireOidcDiscoveryProviderReconciler) reconcileService(ctx context.Cont
 	// Update the resource
 	if err := r.ctrlClient.Update(ctx, desired); err != nil {
 		r.log.Error(err, "failed to update service")
-		statusMgr.AddCondition(ServiceAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to update Service: %v", err),
 			metav1.ConditionFalse)
 		return err
 	}
 
 	r.log.Info("Updated Service", "name", desired.Name, "namespace", desired.Namespace)
-	statusMgr.AddCondition(ServiceAvailable, v1alpha1.ReasonReady,
+	statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonReady,
 		"All Service resources available",
 		metav1.ConditionTrue)
 	return nil
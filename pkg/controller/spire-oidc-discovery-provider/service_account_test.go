@@ -6,7 +6,7 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
@@ -112,7 +112,7 @@ func TestGetSpireOIDCDiscoveryProviderServiceAccount(t *testing.T) {
 // newSATestReconciler creates a reconciler for ServiceAccount tests
 func newSATestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireOidcDiscoveryProviderReconciler {
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 	return &SpireOidcDiscoveryProviderReconciler{
 		ctrlClient:    fakeClient,
@@ -127,7 +127,7 @@ func newSATestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireOidcDisco
 func TestReconcileServiceAccount(t *testing.T) {
 	tests := []struct {
 		name           string
-		oidc           *v1alpha1.SpireOIDCDiscoveryProvider
+		oidc           *v1alpha2.SpireOIDCDiscoveryProvider
 		setupClient    func(*fakes.FakeCustomCtrlClient)
 		createOnlyMode bool
 		useEmptyScheme bool
@@ -137,7 +137,7 @@ func TestReconcileServiceAccount(t *testing.T) {
 	}{
 		{
 			name: "create success",
-			oidc: &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc: &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -148,7 +148,7 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "create error",
-			oidc: &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc: &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -159,7 +159,7 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "get error",
-			oidc: &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc: &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -169,7 +169,7 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "create only mode skips update",
-			oidc: &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc: &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -191,10 +191,10 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "update success",
-			oidc: &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc: &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -220,10 +220,10 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "update error",
-			oidc: &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc: &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -250,7 +250,7 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "set controller ref error",
-			oidc: &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc: &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient:    func(fc *fakes.FakeCustomCtrlClient) {},
@@ -6,7 +6,7 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
@@ -125,7 +125,7 @@ func TestGetSpireOIDCDiscoveryProviderService(t *testing.T) {
 // newSvcTestReconciler creates a reconciler for Service tests
 func newSvcTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireOidcDiscoveryProviderReconciler {
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 	return &SpireOidcDiscoveryProviderReconciler{
 		ctrlClient:    fakeClient,
@@ -140,7 +140,7 @@ func newSvcTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireOidcDisc
 func TestReconcileService(t *testing.T) {
 	tests := []struct {
 		name           string
-		oidc           *v1alpha1.SpireOIDCDiscoveryProvider
+		oidc           *v1alpha2.SpireOIDCDiscoveryProvider
 		setupClient    func(*fakes.FakeCustomCtrlClient)
 		createOnlyMode bool
 		useEmptyScheme bool
@@ -150,7 +150,7 @@ func TestReconcileService(t *testing.T) {
 	}{
 		{
 			name: "create success",
-			oidc: &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc: &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -161,7 +161,7 @@ func TestReconcileService(t *testing.T) {
 		},
 		{
 			name: "create error",
-			oidc: &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc: &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -172,7 +172,7 @@ func TestReconcileService(t *testing.T) {
 		},
 		{
 			name: "get error",
-			oidc: &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc: &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -182,7 +182,7 @@ func TestReconcileService(t *testing.T) {
 		},
 		{
 			name: "create only mode skips update",
-			oidc: &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc: &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -204,10 +204,10 @@ func TestReconcileService(t *testing.T) {
 		},
 		{
 			name: "update success",
-			oidc: &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc: &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -234,10 +234,10 @@ func TestReconcileService(t *testing.T) {
 		},
 		{
 			name: "update error",
-			oidc: &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc: &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireOIDCDiscoveryProviderSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireOIDCDiscoveryProviderSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -265,7 +265,7 @@ func TestReconcileService(t *testing.T) {
 		},
 		{
 			name: "set controller ref error",
-			oidc: &v1alpha1.SpireOIDCDiscoveryProvider{
+			oidc: &v1alpha2.SpireOIDCDiscoveryProvider{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient:    func(fc *fakes.FakeCustomCtrlClient) {},
@@ -0,0 +1,67 @@
+package spire_server
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+// benchmarkServerSpec returns a SpireServer spec with the defaults applied by the API server
+func benchmarkServerSpec() *v1alpha2.SpireServerSpec {
+	return &v1alpha2.SpireServerSpec{
+		LogLevel:            "info",
+		LogFormat:           "text",
+		JwtIssuer:           "https://oidc-discovery.example.org",
+		CAValidity:          metav1.Duration{Duration: 24 * time.Hour},
+		DefaultX509Validity: metav1.Duration{Duration: time.Hour},
+		DefaultJWTValidity:  metav1.Duration{Duration: 5 * time.Minute},
+		Persistence:         v1alpha2.Persistence{Size: "1Gi", AccessMode: "ReadWriteOnce"},
+		Datastore:           v1alpha2.DataStore{DatabaseType: "sqlite3", ConnectionString: "/run/spire/data/datastore.sqlite3", MaxOpenConns: 100, MaxIdleConns: 2},
+		CommonConfig:        v1alpha2.CommonConfig{Labels: map[string]string{"team": "platform"}},
+	}
+}
+
+func BenchmarkGenerateSpireServerConfigMap(b *testing.B) {
+	spec := benchmarkServerSpec()
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.org", ClusterName: "cluster", BundleConfigMap: "spire-bundle"},
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := generateSpireServerConfigMap(spec, ztwim); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGenerateSpireServerStatefulSet(b *testing.B) {
+	spec := benchmarkServerSpec()
+	b.ReportAllocs()
+	for b.Loop() {
+		GenerateSpireServerStatefulSet(spec, "server-config-hash", "controller-manager-config-hash")
+	}
+}
+
+// BenchmarkSpireServerStaticResources renders the resources built from the static assets at
+// each reconcile
+func BenchmarkSpireServerStaticResources(b *testing.B) {
+	spec := benchmarkServerSpec()
+	b.ReportAllocs()
+	for b.Loop() {
+		getSpireServerClusterRole(spec.Labels)
+		getSpireServerClusterRoleBinding(spec.Labels)
+		getSpireBundleRole(spec.Labels, "spire-bundle")
+		getSpireBundleRoleBinding(spec.Labels)
+		getSpireControllerManagerClusterRole(spec.Labels)
+		getSpireControllerManagerClusterRoleBinding(spec.Labels)
+		getSpireControllerManagerLeaderElectionRole(spec.Labels)
+		getSpireControllerManagerLeaderElectionRoleBinding(spec.Labels)
+		getSpireServerService(spec)
+		getSpireControllerManagerWebhookService(spec.Labels)
+		getSpireServerServiceAccount(spec.Labels)
+		getSpireControllerManagerValidatingWebhookConfiguration(spec.Labels)
+	}
+}
@@ -0,0 +1,251 @@
+package spire_server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	// defaultBreakGlassTTL and maxBreakGlassTTL bound the validity of the break-glass identity
+	defaultBreakGlassTTL = time.Hour
+	maxBreakGlassTTL     = 4 * time.Hour
+
+	// breakGlassRetryInterval is how often a failed issuance or revocation is retried
+	breakGlassRetryInterval = time.Minute
+
+	// Break-glass reasons
+	BreakGlassReasonIssued  = "BreakGlassIssued"
+	BreakGlassReasonExpired = "BreakGlassExpired"
+	BreakGlassReasonRevoked = "BreakGlassRevoked"
+	BreakGlassReasonInvalid = "BreakGlassInvalid"
+	BreakGlassReasonFailed  = "BreakGlassFailed"
+)
+
+// breakGlassRequest is the identity requested by the break-glass annotations
+type breakGlassRequest struct {
+	namespace      string
+	serviceAccount string
+	ttl            time.Duration
+}
+
+// parseBreakGlassRequest parses the break-glass annotations of the SpireServer
+func parseBreakGlassRequest(annotations map[string]string) (breakGlassRequest, error) {
+	subject := annotations[utils.BreakGlassAdminAnnotation]
+	namespace, serviceAccount, ok := strings.Cut(subject, "/")
+	if !ok || len(validation.IsDNS1123Label(namespace)) > 0 || len(validation.IsDNS1123Subdomain(serviceAccount)) > 0 {
+		return breakGlassRequest{}, fmt.Errorf("%s must be <namespace>/<serviceAccount>, got %q", utils.BreakGlassAdminAnnotation, subject)
+	}
+	request := breakGlassRequest{namespace: namespace, serviceAccount: serviceAccount, ttl: defaultBreakGlassTTL}
+	if value, ok := annotations[utils.BreakGlassTTLAnnotation]; ok {
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl <= 0 || ttl > maxBreakGlassTTL {
+			return breakGlassRequest{}, fmt.Errorf("%s must be a duration of at most %s, got %q", utils.BreakGlassTTLAnnotation, maxBreakGlassTTL, value)
+		}
+		request.ttl = ttl
+	}
+	return request, nil
+}
+
+// breakGlassAgentsID is the SPIFFE ID aliasing all the agents of the cluster for the break-glass entry
+func breakGlassAgentsID(ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager) string {
+	return fmt.Sprintf("spiffe://%s/ztwim/break-glass/agents", utils.TrustDomain(ztwim))
+}
+
+// breakGlassSPIFFEID is the SPIFFE ID of the break-glass identity
+func breakGlassSPIFFEID(ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, request breakGlassRequest) string {
+	return fmt.Sprintf("spiffe://%s/ztwim/break-glass/ns/%s/sa/%s", utils.TrustDomain(ztwim), request.namespace, request.serviceAccount)
+}
+
+// breakGlassCommands returns the spire-server commands creating the registration entries of the
+// break-glass identity: an alias of all the agents of the cluster, and the admin entry of the
+// service account parented to it. Both expire on their own, so the identity cannot outlive its
+// TTL even if the operator is unavailable.
+func breakGlassCommands(ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, request breakGlassRequest, expiresAt time.Time) [][]string {
+	expiry := strconv.FormatInt(expiresAt.Unix(), 10)
+	return [][]string{
+		{"spire-server", "entry", "create", "-output", "json", "-node",
+			"-spiffeID", breakGlassAgentsID(ztwim),
+			"-selector", "k8s_psat:cluster:" + ztwim.Spec.ClusterName,
+			"-entryExpiry", expiry},
+		{"spire-server", "entry", "create", "-output", "json", "-admin",
+			"-parentID", breakGlassAgentsID(ztwim),
+			"-spiffeID", breakGlassSPIFFEID(ztwim, request),
+			"-selector", "k8s:ns:" + request.namespace,
+			"-selector", "k8s:sa:" + request.serviceAccount,
+			"-x509SVIDTTL", strconv.Itoa(int(request.ttl.Seconds())),
+			"-entryExpiry", expiry},
+	}
+}
+
+// createdEntryID returns the ID of the entry created by `spire-server entry create -output json`
+func createdEntryID(output []byte) (string, error) {
+	var created struct {
+		Results []struct {
+			Status struct {
+				Code    int    `json:"code"`
+				Message string `json:"message"`
+			} `json:"status"`
+			Entry struct {
+				ID string `json:"id"`
+			} `json:"entry"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(output, &created); err != nil {
+		return "", fmt.Errorf("unexpected output of the entry create command: %w", err)
+	}
+	if len(created.Results) != 1 {
+		return "", fmt.Errorf("unexpected output of the entry create command: %d results", len(created.Results))
+	}
+	if result := created.Results[0]; result.Status.Code != 0 || result.Entry.ID == "" {
+		return "", fmt.Errorf("failed to create the registration entry: %s", result.Status.Message)
+	}
+	return created.Results[0].Entry.ID, nil
+}
+
+// annotationManager returns the field manager which set the annotation, for the audit Events
+func annotationManager(obj metav1.Object, annotation string) string {
+	for _, entry := range obj.GetManagedFields() {
+		if entry.FieldsV1 != nil && strings.Contains(string(entry.FieldsV1.Raw), `"f:`+annotation+`"`) {
+			return entry.Manager
+		}
+	}
+	return "unknown"
+}
+
+// reconcileBreakGlass issues the emergency admin identity requested by the break-glass annotation
+// of the SpireServer, for disaster recovery when the OIDC or the spire-controller-manager
+// registration paths are broken. The registration entries are created through the spire-server
+// CLI, bypassing spire-controller-manager, and expire with the identity. It is issued once per
+// annotation value; removing the annotation revokes it. Every step is recorded as an Event.
+func (r *SpireServerReconciler) reconcileBreakGlass(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager) {
+	if r.cli == nil {
+		return
+	}
+	subject := server.Annotations[utils.BreakGlassAdminAnnotation]
+	issued := server.Status.BreakGlass
+	now := time.Now()
+
+	// Revoke the identity no longer requested, or requested for another subject
+	if issued != nil && issued.Subject != subject {
+		if now.Before(issued.ExpiresAt.Time) {
+			if err := r.revokeBreakGlass(ctx, issued); err != nil {
+				message := fmt.Sprintf("Failed to revoke the break-glass admin identity of %s: %v", issued.Subject, err)
+				statusMgr.AddCondition(utils.BreakGlassActiveStatusType, BreakGlassReasonFailed, message, metav1.ConditionTrue)
+				r.eventRecorder.Event(server, corev1.EventTypeWarning, BreakGlassReasonFailed, message)
+				return
+			}
+		}
+		message := fmt.Sprintf("Break-glass admin identity %s of %s revoked", issued.SPIFFEID, issued.Subject)
+		r.eventRecorder.Event(server, corev1.EventTypeNormal, BreakGlassReasonRevoked, message)
+		server.Status.BreakGlass = nil
+		issued = nil
+		if subject == "" {
+			statusMgr.AddCondition(utils.BreakGlassActiveStatusType, BreakGlassReasonRevoked, message, metav1.ConditionFalse)
+			return
+		}
+	}
+
+	if subject == "" {
+		// Only report the end of a break-glass request which was previously reported
+		if existing := apimeta.FindStatusCondition(server.Status.Conditions, utils.BreakGlassActiveStatusType); existing != nil && existing.Status != metav1.ConditionFalse {
+			statusMgr.AddCondition(utils.BreakGlassActiveStatusType, BreakGlassReasonRevoked, "No break-glass admin identity requested", metav1.ConditionFalse)
+		}
+		return
+	}
+
+	if issued != nil {
+		if now.Before(issued.ExpiresAt.Time) {
+			statusMgr.AddCondition(utils.BreakGlassActiveStatusType, BreakGlassReasonIssued,
+				fmt.Sprintf("Break-glass admin identity %s issued to %s until %s", issued.SPIFFEID, issued.Subject, issued.ExpiresAt.UTC().Format(time.RFC3339)),
+				metav1.ConditionTrue)
+			return
+		}
+		if existing := apimeta.FindStatusCondition(server.Status.Conditions, utils.BreakGlassActiveStatusType); existing == nil || existing.Reason != BreakGlassReasonExpired {
+			r.eventRecorder.Event(server, corev1.EventTypeNormal, BreakGlassReasonExpired,
+				fmt.Sprintf("Break-glass admin identity %s of %s expired", issued.SPIFFEID, issued.Subject))
+		}
+		statusMgr.AddCondition(utils.BreakGlassActiveStatusType, BreakGlassReasonExpired,
+			fmt.Sprintf("Break-glass admin identity %s of %s expired at %s; remove the %s annotation, and set it again to issue a new one",
+				issued.SPIFFEID, issued.Subject, issued.ExpiresAt.UTC().Format(time.RFC3339), utils.BreakGlassAdminAnnotation),
+			metav1.ConditionFalse)
+		return
+	}
+
+	request, err := parseBreakGlassRequest(server.Annotations)
+	if err != nil {
+		if existing := apimeta.FindStatusCondition(server.Status.Conditions, utils.BreakGlassActiveStatusType); existing == nil || existing.Reason != BreakGlassReasonInvalid {
+			r.eventRecorder.Event(server, corev1.EventTypeWarning, BreakGlassReasonInvalid, err.Error())
+		}
+		statusMgr.AddCondition(utils.BreakGlassActiveStatusType, BreakGlassReasonInvalid, err.Error(), metav1.ConditionFalse)
+		return
+	}
+
+	expiresAt := now.Add(request.ttl).Truncate(time.Second)
+	breakGlass := &v1alpha2.BreakGlassStatus{
+		Subject:   subject,
+		SPIFFEID:  breakGlassSPIFFEID(ztwim, request),
+		IssuedBy:  annotationManager(server, utils.BreakGlassAdminAnnotation),
+		ExpiresAt: metav1.NewTime(expiresAt),
+	}
+	for _, command := range breakGlassCommands(ztwim, request, expiresAt) {
+		output, err := r.cli.Run(ctx, utils.GetOperatorNamespace(), command)
+		var entryID string
+		if err == nil {
+			entryID, err = createdEntryID(output)
+		}
+		if err != nil {
+			// Roll back the entries already created, they expire on their own otherwise
+			if revokeErr := r.revokeBreakGlass(ctx, breakGlass); revokeErr != nil {
+				r.log.Error(revokeErr, "failed to roll back the break-glass registration entries")
+			}
+			message := fmt.Sprintf("Failed to issue the break-glass admin identity of %s: %v", subject, err)
+			statusMgr.AddCondition(utils.BreakGlassActiveStatusType, BreakGlassReasonFailed, message, metav1.ConditionFalse)
+			r.eventRecorder.Event(server, corev1.EventTypeWarning, BreakGlassReasonFailed, message)
+			return
+		}
+		breakGlass.EntryIDs = append(breakGlass.EntryIDs, entryID)
+	}
+
+	server.Status.BreakGlass = breakGlass
+	message := fmt.Sprintf("Break-glass admin identity %s issued to %s until %s, requested by %s",
+		breakGlass.SPIFFEID, subject, expiresAt.UTC().Format(time.RFC3339), breakGlass.IssuedBy)
+	statusMgr.AddCondition(utils.BreakGlassActiveStatusType, BreakGlassReasonIssued, message, metav1.ConditionTrue)
+	r.eventRecorder.Event(server, corev1.EventTypeWarning, BreakGlassReasonIssued, message)
+	r.log.Info("issued break-glass admin identity", "spiffeID", breakGlass.SPIFFEID, "subject", subject, "expiresAt", expiresAt, "issuedBy", breakGlass.IssuedBy)
+}
+
+// revokeBreakGlass deletes the registration entries of the break-glass identity
+func (r *SpireServerReconciler) revokeBreakGlass(ctx context.Context, breakGlass *v1alpha2.BreakGlassStatus) error {
+	// The admin entry is parented to the alias, so it is deleted first
+	for i := len(breakGlass.EntryIDs) - 1; i >= 0; i-- {
+		if _, err := r.cli.Run(ctx, utils.GetOperatorNamespace(), []string{"spire-server", "entry", "delete", "-entryID", breakGlass.EntryIDs[i]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// breakGlassRequeueAfter returns when the break-glass identity must be reconciled again: at its
+// expiry while it is valid, or to retry a failed issuance or revocation
+func breakGlassRequeueAfter(server *v1alpha2.SpireServer, statusMgr *status.Manager, now time.Time) time.Duration {
+	if cond, ok := statusMgr.GetCondition(utils.BreakGlassActiveStatusType); ok && cond.Reason == BreakGlassReasonFailed {
+		return breakGlassRetryInterval
+	}
+	if breakGlass := server.Status.BreakGlass; breakGlass != nil && now.Before(breakGlass.ExpiresAt.Time) {
+		return breakGlass.ExpiresAt.Sub(now) + time.Second
+	}
+	return 0
+}
@@ -0,0 +1,243 @@
+package spire_server
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// recordingCLI records the commands it runs and creates entries with sequential IDs
+type recordingCLI struct {
+	commands [][]string
+	err      error
+}
+
+func (f *recordingCLI) Run(_ context.Context, _ string, command []string) ([]byte, error) {
+	f.commands = append(f.commands, command)
+	if f.err != nil {
+		return nil, f.err
+	}
+	if slices.Contains(command, "create") {
+		return []byte(`{"results":[{"status":{"code":0,"message":"OK"},"entry":{"id":"entry-` + strconv.Itoa(len(f.commands)) + `"}}]}`), nil
+	}
+	return nil, nil
+}
+
+func TestParseBreakGlassRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expectTTL   time.Duration
+		expectErr   bool
+	}{
+		{name: "default ttl", annotations: map[string]string{utils.BreakGlassAdminAnnotation: "recovery/admin"}, expectTTL: time.Hour},
+		{name: "custom ttl", annotations: map[string]string{utils.BreakGlassAdminAnnotation: "recovery/admin", utils.BreakGlassTTLAnnotation: "15m"}, expectTTL: 15 * time.Minute},
+		{name: "ttl above maximum", annotations: map[string]string{utils.BreakGlassAdminAnnotation: "recovery/admin", utils.BreakGlassTTLAnnotation: "24h"}, expectErr: true},
+		{name: "missing service account", annotations: map[string]string{utils.BreakGlassAdminAnnotation: "recovery"}, expectErr: true},
+		{name: "invalid namespace", annotations: map[string]string{utils.BreakGlassAdminAnnotation: "Recovery/admin"}, expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := parseBreakGlassRequest(tt.annotations)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("Expected error %v, got %v", tt.expectErr, err)
+			}
+			if !tt.expectErr && request.ttl != tt.expectTTL {
+				t.Errorf("Expected TTL %s, got %s", tt.expectTTL, request.ttl)
+			}
+		})
+	}
+}
+
+func TestReconcileBreakGlass(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.org", ClusterName: "cluster"},
+	}
+	active := &v1alpha2.BreakGlassStatus{
+		Subject:   "recovery/admin",
+		SPIFFEID:  "spiffe://example.org/ztwim/break-glass/ns/recovery/sa/admin",
+		EntryIDs:  []string{"alias", "admin"},
+		ExpiresAt: metav1.NewTime(time.Now().Add(time.Hour)),
+	}
+	expired := active.DeepCopy()
+	expired.ExpiresAt = metav1.NewTime(time.Now().Add(-time.Minute))
+
+	tests := []struct {
+		name           string
+		annotations    map[string]string
+		issued         *v1alpha2.BreakGlassStatus
+		cli            *recordingCLI
+		expectReason   string
+		expectStatus   metav1.ConditionStatus
+		expectIssued   bool
+		expectCommands []string
+	}{
+		{
+			name:           "issued on request",
+			annotations:    map[string]string{utils.BreakGlassAdminAnnotation: "recovery/admin"},
+			cli:            &recordingCLI{},
+			expectReason:   BreakGlassReasonIssued,
+			expectStatus:   metav1.ConditionTrue,
+			expectIssued:   true,
+			expectCommands: []string{"create", "create"},
+		},
+		{
+			name:         "invalid request",
+			annotations:  map[string]string{utils.BreakGlassAdminAnnotation: "recovery"},
+			cli:          &recordingCLI{},
+			expectReason: BreakGlassReasonInvalid,
+			expectStatus: metav1.ConditionFalse,
+		},
+		{
+			name:         "server not reachable",
+			annotations:  map[string]string{utils.BreakGlassAdminAnnotation: "recovery/admin"},
+			cli:          &recordingCLI{err: errors.New("pod spire-server-0 not found")},
+			expectReason: BreakGlassReasonFailed,
+			expectStatus: metav1.ConditionFalse,
+			// The rollback has no entry to delete
+			expectCommands: []string{"create"},
+		},
+		{
+			name:         "active identity not issued again",
+			annotations:  map[string]string{utils.BreakGlassAdminAnnotation: "recovery/admin"},
+			issued:       active,
+			cli:          &recordingCLI{},
+			expectReason: BreakGlassReasonIssued,
+			expectStatus: metav1.ConditionTrue,
+			expectIssued: true,
+		},
+		{
+			name:           "revoked when the annotation is removed",
+			issued:         active,
+			cli:            &recordingCLI{},
+			expectReason:   BreakGlassReasonRevoked,
+			expectStatus:   metav1.ConditionFalse,
+			expectCommands: []string{"delete", "delete"},
+		},
+		{
+			name:         "expired identity not issued again",
+			annotations:  map[string]string{utils.BreakGlassAdminAnnotation: "recovery/admin"},
+			issued:       expired,
+			cli:          &recordingCLI{},
+			expectReason: BreakGlassReasonExpired,
+			expectStatus: metav1.ConditionFalse,
+			expectIssued: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			r := newTestReconciler(fakeClient)
+			r.cli = tt.cli
+			statusMgr := status.NewManager(fakeClient)
+			server := &v1alpha2.SpireServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster", Annotations: tt.annotations},
+				Status:     v1alpha2.SpireServerStatus{BreakGlass: tt.issued.DeepCopy()},
+			}
+
+			r.reconcileBreakGlass(context.Background(), server, statusMgr, ztwim)
+
+			cond, ok := statusMgr.GetCondition(utils.BreakGlassActiveStatusType)
+			if !ok || cond.Reason != tt.expectReason || cond.Status != tt.expectStatus {
+				t.Errorf("Expected %s/%s, got %v", tt.expectStatus, tt.expectReason, cond)
+			}
+			if (server.Status.BreakGlass != nil) != tt.expectIssued {
+				t.Errorf("Expected issued %v, got %v", tt.expectIssued, server.Status.BreakGlass)
+			}
+			var verbs []string
+			for _, command := range tt.cli.commands {
+				verbs = append(verbs, command[2])
+			}
+			if !slices.Equal(verbs, tt.expectCommands) {
+				t.Errorf("Expected commands %v, got %v", tt.expectCommands, tt.cli.commands)
+			}
+			if tt.name == "issued on request" {
+				admin := strings.Join(tt.cli.commands[1], " ")
+				if !strings.Contains(admin, "-admin") || !strings.Contains(admin, "-selector k8s:sa:admin") || !strings.Contains(admin, "-x509SVIDTTL 3600") {
+					t.Errorf("Unexpected admin entry command %q", admin)
+				}
+				if len(server.Status.BreakGlass.EntryIDs) != 2 {
+					t.Errorf("Expected both entries recorded, got %v", server.Status.BreakGlass.EntryIDs)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcile_DryRunSkipsBreakGlass(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	r := newRBACTestReconciler(fakeClient)
+	cli := &recordingCLI{}
+	r.cli = cli
+	recorder := record.NewFakeRecorder(10)
+	r.eventRecorder = recorder
+
+	server := &v1alpha2.SpireServer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+			Annotations: map[string]string{
+				utils.DryRunAnnotation:          "true",
+				utils.BreakGlassAdminAnnotation: "recovery/admin",
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "operator.openshift.io/v1alpha1",
+				Kind:       "ZeroTrustWorkloadIdentityManager",
+				Name:       "cluster",
+				UID:        "ztwim-uid",
+				Controller: ptr.To(true),
+			}},
+		},
+		Spec: *createValidConfig(),
+	}
+	server.Spec.JwtIssuer = "https://oidc.example.org"
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "ztwim-uid"},
+		Spec:       v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.org", ClusterName: "cluster"},
+	}
+	fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		switch o := obj.(type) {
+		case *v1alpha2.SpireServer:
+			server.DeepCopyInto(o)
+			return nil
+		case *v1alpha2.ZeroTrustWorkloadIdentityManager:
+			ztwim.DeepCopyInto(o)
+			return nil
+		}
+		return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+	}
+	fakeClient.GetUncachedReturns(kerrors.NewNotFound(schema.GroupResource{}, ""))
+
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "cluster"}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(cli.commands) != 0 {
+		t.Errorf("Expected no spire-server command in a dry run, got %v", cli.commands)
+	}
+	if len(recorder.Events) != 0 {
+		t.Errorf("Expected no Event in a dry run, got %d", len(recorder.Events))
+	}
+	if fakeClient.CreateCallCount() != 0 {
+		t.Errorf("Expected no object created in a dry run, got %d creates", fakeClient.CreateCallCount())
+	}
+}
@@ -0,0 +1,206 @@
+package spire_server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	// bundleWebhooksRBACName grants the server access to the webhook configurations whose CA
+	// bundle is maintained by the k8sbundle notifier
+	bundleWebhooksRBACName = "spire-server-bundle-webhooks"
+	// bundleConfigMapsRBACName is the RoleBinding granting the server access to the additional
+	// bundle ConfigMaps, created in the namespace of each ConfigMap only. It was also the name of
+	// the ClusterRole rendered by earlier versions of the operator, replaced by
+	// bundleConfigMapsClusterRoleName.
+	bundleConfigMapsRBACName = "spire-server-bundle-configmaps"
+	// bundleConfigMapsClusterRoleName is the ClusterRole shipped with the operator granting access
+	// to the ConfigMaps of a namespace. The operator can bind it but not change it.
+	bundleConfigMapsClusterRoleName = "zero-trust-workload-identity-manager-bundle-configmaps"
+)
+
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=bind,resourceNames=zero-trust-workload-identity-manager-bundle-configmaps
+
+// bundleConfigMapNamespaces returns the namespaces of the additional bundle ConfigMaps, sorted
+func bundleConfigMapNamespaces(notifier *v1alpha2.BundleNotifierConfig) []string {
+	if notifier == nil {
+		return nil
+	}
+	var namespaces []string
+	for _, target := range notifier.ConfigMaps {
+		namespaces = append(namespaces, target.Namespace)
+	}
+	slices.Sort(namespaces)
+	return slices.Compact(namespaces)
+}
+
+func generateBundleWebhooksClusterRole(customLabels map[string]string) *rbacv1.ClusterRole {
+	return &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   bundleWebhooksRBACName,
+			Labels: utils.SpireServerLabels(customLabels),
+		},
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{"admissionregistration.k8s.io"},
+			Resources: []string{"mutatingwebhookconfigurations", "validatingwebhookconfigurations"},
+			Verbs:     []string{"get", "list", "watch", "patch"},
+		}},
+	}
+}
+
+func bundleNotifierSubjects() []rbacv1.Subject {
+	return []rbacv1.Subject{{
+		Kind:      rbacv1.ServiceAccountKind,
+		Name:      "spire-server",
+		Namespace: utils.GetOperatorNamespace(),
+	}}
+}
+
+func generateBundleWebhooksClusterRoleBinding(customLabels map[string]string) *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   bundleWebhooksRBACName,
+			Labels: utils.SpireServerLabels(customLabels),
+		},
+		RoleRef:  rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: bundleWebhooksRBACName},
+		Subjects: bundleNotifierSubjects(),
+	}
+}
+
+func generateBundleConfigMapsRoleBinding(customLabels map[string]string, namespace string) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      bundleConfigMapsRBACName,
+			Namespace: namespace,
+			Labels:    utils.SpireServerLabels(customLabels),
+		},
+		RoleRef:  rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: bundleConfigMapsClusterRoleName},
+		Subjects: bundleNotifierSubjects(),
+	}
+}
+
+// reconcileBundleNotifierRBAC grants the server access to the targets of the k8sbundle notifier
+// configured in spec.bundleNotifier, and revokes it once they are removed
+func (r *SpireServerReconciler) reconcileBundleNotifierRBAC(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
+	notifier := server.Spec.BundleNotifier
+	namespaces := bundleConfigMapNamespaces(notifier)
+
+	var desired, removed []client.Object
+	if notifier != nil && notifier.WebhookLabel != "" {
+		desired = append(desired, generateBundleWebhooksClusterRole(server.Spec.Labels), generateBundleWebhooksClusterRoleBinding(server.Spec.Labels))
+	} else {
+		removed = append(removed, &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: bundleWebhooksRBACName}},
+			&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: bundleWebhooksRBACName}})
+	}
+	for _, namespace := range namespaces {
+		desired = append(desired, generateBundleConfigMapsRoleBinding(server.Spec.Labels, namespace))
+	}
+	// The ClusterRole rendered by earlier versions of the operator
+	removed = append(removed, &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: bundleConfigMapsRBACName}})
+
+	for _, obj := range desired {
+		if err := r.applyBundleNotifierRBAC(ctx, server, obj, createOnlyMode); err != nil {
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
+				fmt.Sprintf("Failed to reconcile bundle notifier %T %s: %v", obj, obj.GetName(), err),
+				metav1.ConditionFalse)
+			return err
+		}
+	}
+
+	// RoleBindings left in namespaces no longer holding a bundle ConfigMap. The RoleBindings outside
+	// the operator namespace are not cached, they are read from the API server.
+	var bindings rbacv1.RoleBindingList
+	if err := r.ctrlClient.ListUncached(ctx, &bindings, client.MatchingLabels{utils.AppManagedByLabelKey: utils.AppManagedByLabelValue}); err != nil {
+		r.log.Error(err, "failed to list bundle notifier role bindings")
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
+			fmt.Sprintf("Failed to list bundle notifier RoleBindings: %v", err),
+			metav1.ConditionFalse)
+		return err
+	}
+	for i := range bindings.Items {
+		binding := &bindings.Items[i]
+		if binding.Name == bundleConfigMapsRBACName && metav1.IsControlledBy(binding, server) && !slices.Contains(namespaces, binding.Namespace) {
+			removed = append(removed, binding)
+		}
+	}
+
+	for _, obj := range removed {
+		if err := r.ctrlClient.Delete(ctx, obj); err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			r.log.Error(err, "failed to delete bundle notifier RBAC", "name", obj.GetName(), "namespace", obj.GetNamespace())
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
+				fmt.Sprintf("Failed to delete bundle notifier %T %s: %v", obj, obj.GetName(), err),
+				metav1.ConditionFalse)
+			return err
+		}
+		r.log.Info("Deleted bundle notifier RBAC", "name", obj.GetName(), "namespace", obj.GetNamespace())
+	}
+	return nil
+}
+
+// applyBundleNotifierRBAC creates the RBAC object, or updates it when it drifted
+func (r *SpireServerReconciler) applyBundleNotifierRBAC(ctx context.Context, server *v1alpha2.SpireServer, desired client.Object, createOnlyMode bool) error {
+	if err := controllerutil.SetControllerReference(server, desired, r.scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference: %w", err)
+	}
+
+	var existing client.Object
+	get := r.ctrlClient.Get
+	switch desired.(type) {
+	case *rbacv1.ClusterRole:
+		existing = &rbacv1.ClusterRole{}
+	case *rbacv1.ClusterRoleBinding:
+		existing = &rbacv1.ClusterRoleBinding{}
+	case *rbacv1.RoleBinding:
+		existing = &rbacv1.RoleBinding{}
+		get = r.ctrlClient.GetUncached
+	}
+	err := get(ctx, types.NamespacedName{Name: desired.GetName(), Namespace: desired.GetNamespace()}, existing)
+	if kerrors.IsNotFound(err) {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false))); err != nil {
+			return err
+		}
+		r.log.Info("Created bundle notifier RBAC", "name", desired.GetName(), "namespace", desired.GetNamespace())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if createOnlyMode || !utils.ResourceNeedsUpdate(existing, desired) {
+		return nil
+	}
+	// The role of a RoleBinding is immutable: the RoleBindings of the ClusterRole rendered by
+	// earlier versions of the operator are recreated
+	if binding, ok := existing.(*rbacv1.RoleBinding); ok && binding.RoleRef != desired.(*rbacv1.RoleBinding).RoleRef {
+		if err := r.ctrlClient.Delete(ctx, binding); err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+			return err
+		}
+		r.log.Info("Recreated bundle notifier RBAC", "name", desired.GetName(), "namespace", desired.GetNamespace())
+		return nil
+	}
+	desired.SetResourceVersion(existing.GetResourceVersion())
+	if err := r.ctrlClient.Update(ctx, desired); err != nil {
+		return err
+	}
+	r.log.Info("Updated bundle notifier RBAC", "name", desired.GetName(), "namespace", desired.GetNamespace())
+	return nil
+}
@@ -0,0 +1,182 @@
+package spire_server
+
+import (
+	"context"
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func TestBuildBundleNotifierPluginData(t *testing.T) {
+	pluginData := buildBundleNotifierPluginData(nil, "spire-bundle")
+	if len(pluginData) != 2 || pluginData["config_map"] != "spire-bundle" || pluginData["namespace"] != utils.GetOperatorNamespace() {
+		t.Errorf("Expected only the operator bundle ConfigMap, got %v", pluginData)
+	}
+
+	pluginData = buildBundleNotifierPluginData(&v1alpha2.BundleNotifierConfig{
+		WebhookLabel: "spiffe.io/webhook",
+		ConfigMaps: []v1alpha2.BundleConfigMapTarget{
+			{Namespace: "team-a", Name: "trust-bundle", Key: "ca.crt"},
+			{Namespace: "team-b", Name: "trust-bundle"},
+		},
+	}, "spire-bundle")
+	if pluginData["webhook_label"] != "spiffe.io/webhook" {
+		t.Errorf("Expected webhook_label, got %v", pluginData["webhook_label"])
+	}
+	clusters, ok := pluginData["clusters"].([]map[string]interface{})
+	if !ok || len(clusters) != 2 {
+		t.Fatalf("Expected 2 clusters, got %v", pluginData["clusters"])
+	}
+	if clusters[0]["namespace"] != "team-a" || clusters[0]["config_map"] != "trust-bundle" || clusters[0]["config_map_key"] != "ca.crt" {
+		t.Errorf("Unexpected first cluster %v", clusters[0])
+	}
+	if _, ok := clusters[1]["config_map_key"]; ok {
+		t.Errorf("Expected no config_map_key without a key, got %v", clusters[1])
+	}
+}
+
+func TestReconcileBundleNotifierRBAC(t *testing.T) {
+	tests := []struct {
+		name           string
+		notifier       *v1alpha2.BundleNotifierConfig
+		existing       []rbacv1.RoleBinding
+		expectCreated  []string
+		expectDeleted  []string
+		expectBindings []string
+	}{
+		{
+			name:          "not configured",
+			expectDeleted: []string{"ClusterRoleBinding/" + bundleWebhooksRBACName, "ClusterRole/" + bundleWebhooksRBACName, "ClusterRole/" + bundleConfigMapsRBACName},
+		},
+		{
+			name: "webhooks and configmaps",
+			notifier: &v1alpha2.BundleNotifierConfig{
+				WebhookLabel: "spiffe.io/webhook",
+				ConfigMaps: []v1alpha2.BundleConfigMapTarget{
+					{Namespace: "team-b", Name: "trust-bundle"},
+					{Namespace: "team-a", Name: "trust-bundle"},
+					{Namespace: "team-a", Name: "ca-bundle"},
+				},
+			},
+			expectCreated: []string{
+				"ClusterRole/" + bundleWebhooksRBACName, "ClusterRoleBinding/" + bundleWebhooksRBACName,
+				"RoleBinding/team-a/" + bundleConfigMapsRBACName, "RoleBinding/team-b/" + bundleConfigMapsRBACName,
+			},
+			expectDeleted: []string{"ClusterRole/" + bundleConfigMapsRBACName},
+		},
+		{
+			name: "configmap namespace removed",
+			notifier: &v1alpha2.BundleNotifierConfig{
+				ConfigMaps: []v1alpha2.BundleConfigMapTarget{{Namespace: "team-a", Name: "trust-bundle"}},
+			},
+			existing: []rbacv1.RoleBinding{
+				{ObjectMeta: metav1.ObjectMeta{Name: bundleConfigMapsRBACName, Namespace: "team-a"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: bundleConfigMapsRBACName, Namespace: "team-b"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "team-c"}},
+			},
+			expectCreated: []string{"RoleBinding/team-a/" + bundleConfigMapsRBACName},
+			expectDeleted: []string{"ClusterRoleBinding/" + bundleWebhooksRBACName, "ClusterRole/" + bundleWebhooksRBACName, "ClusterRole/" + bundleConfigMapsRBACName, "RoleBinding/team-b/" + bundleConfigMapsRBACName},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			reconciler := newRBACTestReconciler(fakeClient)
+			server := createRBACTestServer()
+			server.Spec.BundleNotifier = tt.notifier
+			ownerRef := metav1.OwnerReference{APIVersion: "operator.openshift.io/v1alpha1", Kind: "SpireServer", Name: server.Name, UID: server.UID, Controller: ptr.To(true)}
+
+			fakeClient.GetReturns(kerrors.NewNotFound(schema.GroupResource{}, ""))
+			fakeClient.GetUncachedReturns(kerrors.NewNotFound(schema.GroupResource{}, ""))
+			fakeClient.DeleteReturns(nil)
+			fakeClient.ListUncachedStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				bindings := list.(*rbacv1.RoleBindingList)
+				for _, binding := range tt.existing {
+					binding.OwnerReferences = []metav1.OwnerReference{ownerRef}
+					bindings.Items = append(bindings.Items, binding)
+				}
+				return nil
+			}
+
+			if err := reconciler.reconcileBundleNotifierRBAC(context.Background(), server, status.NewManager(fakeClient), false); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			var created, deleted []string
+			for i := 0; i < fakeClient.CreateCallCount(); i++ {
+				_, obj, _ := fakeClient.CreateArgsForCall(i)
+				created = append(created, objectKey(obj))
+			}
+			for i := 0; i < fakeClient.DeleteCallCount(); i++ {
+				_, obj, _ := fakeClient.DeleteArgsForCall(i)
+				deleted = append(deleted, objectKey(obj))
+			}
+			assertKeys(t, "created", tt.expectCreated, created)
+			assertKeys(t, "deleted", tt.expectDeleted, deleted)
+		})
+	}
+}
+
+func TestApplyBundleNotifierRBAC_RecreatesRenderedClusterRoleBindings(t *testing.T) {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	reconciler := newRBACTestReconciler(fakeClient)
+	server := createRBACTestServer()
+	fakeClient.GetUncachedStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		binding := generateBundleConfigMapsRoleBinding(nil, key.Namespace)
+		binding.RoleRef.Name = bundleConfigMapsRBACName
+		binding.DeepCopyInto(obj.(*rbacv1.RoleBinding))
+		return nil
+	}
+
+	if err := reconciler.applyBundleNotifierRBAC(context.Background(), server, generateBundleConfigMapsRoleBinding(nil, "team-a"), false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fakeClient.DeleteCallCount() != 1 || fakeClient.CreateCallCount() != 1 || fakeClient.UpdateCallCount() != 0 {
+		t.Fatalf("Expected the RoleBinding recreated, got %d deletes, %d creates and %d updates",
+			fakeClient.DeleteCallCount(), fakeClient.CreateCallCount(), fakeClient.UpdateCallCount())
+	}
+	if _, obj, _ := fakeClient.CreateArgsForCall(0); obj.(*rbacv1.RoleBinding).RoleRef.Name != bundleConfigMapsClusterRoleName {
+		t.Errorf("Expected the RoleBinding to bind %s, got %v", bundleConfigMapsClusterRoleName, obj.(*rbacv1.RoleBinding).RoleRef)
+	}
+}
+
+func objectKey(obj client.Object) string {
+	kind := ""
+	switch obj.(type) {
+	case *rbacv1.ClusterRole:
+		kind = "ClusterRole"
+	case *rbacv1.ClusterRoleBinding:
+		kind = "ClusterRoleBinding"
+	case *rbacv1.RoleBinding:
+		kind = "RoleBinding"
+	}
+	if obj.GetNamespace() != "" {
+		return kind + "/" + obj.GetNamespace() + "/" + obj.GetName()
+	}
+	return kind + "/" + obj.GetName()
+}
+
+func assertKeys(t *testing.T, action string, expected, got []string) {
+	t.Helper()
+	if len(expected) != len(got) {
+		t.Errorf("Expected %s %v, got %v", action, expected, got)
+		return
+	}
+	for i := range expected {
+		if expected[i] != got[i] {
+			t.Errorf("Expected %s %v, got %v", action, expected, got)
+			return
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package spire_server
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
@@ -14,12 +15,17 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/yaml"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/confighistory"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/telemetry"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 	spiffev1alpha "github.com/spiffe/spire-controller-manager/api/v1alpha1"
 )
@@ -34,7 +40,7 @@ type ControllerManagerConfigYAML struct {
 }
 
 // reconcileSpireServerConfigMap reconciles the Spire Server ConfigMap
-func (r *SpireServerReconciler) reconcileSpireServerConfigMap(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager, ztwim *v1alpha1.ZeroTrustWorkloadIdentityManager, createOnlyMode bool) (string, error) {
+func (r *SpireServerReconciler) reconcileSpireServerConfigMap(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, createOnlyMode bool) (string, error) {
 	spireServerConfigMap, err := generateSpireServerConfigMap(&server.Spec, ztwim)
 	if err != nil {
 		r.log.Error(err, "failed to generate spire server config map")
@@ -44,6 +50,8 @@ func (r *SpireServerReconciler) reconcileSpireServerConfigMap(ctx context.Contex
 		return "", err
 	}
 
+	rollbackHash, rolledBack := confighistory.ApplyRollback(ctx, r.ctrlClient, server, server.Status.Conditions, spireServerConfigMap, statusMgr)
+
 	if err = controllerutil.SetControllerReference(server, spireServerConfigMap, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference")
 		statusMgr.AddCondition(ServerConfigMapAvailable, "SpireServerConfigMapGenerationFailed",
@@ -55,14 +63,14 @@ func (r *SpireServerReconciler) reconcileSpireServerConfigMap(ctx context.Contex
 	var existingSpireServerCM corev1.ConfigMap
 	err = r.ctrlClient.Get(ctx, types.NamespacedName{Name: spireServerConfigMap.Name, Namespace: spireServerConfigMap.Namespace}, &existingSpireServerCM)
 	if err != nil && kerrors.IsNotFound(err) {
-		if err = r.ctrlClient.Create(ctx, spireServerConfigMap); err != nil {
+		if err = r.ctrlClient.Create(ctx, spireServerConfigMap, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false))); err != nil {
 			statusMgr.AddCondition(ServerConfigMapAvailable, "SpireServerConfigMapGenerationFailed",
 				err.Error(),
 				metav1.ConditionFalse)
 			return "", fmt.Errorf("failed to create ConfigMap: %w", err)
 		}
 		r.log.Info("Created spire server ConfigMap")
-	} else if err == nil && (existingSpireServerCM.Data["server.conf"] != spireServerConfigMap.Data["server.conf"] ||
+	} else if err == nil && (!equality.Semantic.DeepEqual(existingSpireServerCM.Data, spireServerConfigMap.Data) ||
 		!equality.Semantic.DeepEqual(existingSpireServerCM.Labels, spireServerConfigMap.Labels)) {
 		if createOnlyMode {
 			r.log.Info("Skipping ConfigMap update due to create-only mode")
@@ -87,6 +95,16 @@ func (r *SpireServerReconciler) reconcileSpireServerConfigMap(ctx context.Contex
 		"SpireServer config map resources applied",
 		metav1.ConditionTrue)
 
+	if rolledBack {
+		return rollbackHash, nil
+	}
+	// A newly created ConfigMap is recorded on the reconcile triggered by its creation
+	if !createOnlyMode && existingSpireServerCM.Name != "" {
+		if err = confighistory.RecordRevision(ctx, r.ctrlClient, r.scheme, server, spireServerConfigMap); err != nil {
+			r.log.Error(err, "failed to record spire server config revision")
+		}
+	}
+
 	// Generate config hash
 	spireServerConfJSON, err := marshalToJSON(generateServerConfMap(&server.Spec, ztwim))
 	if err != nil {
@@ -94,11 +112,12 @@ func (r *SpireServerReconciler) reconcileSpireServerConfigMap(ctx context.Contex
 		return "", err
 	}
 
-	return generateConfigHash(spireServerConfJSON), nil
+	hashInput := append(spireServerConfJSON, externalPluginConfigHashInput(server.Spec.ExternalPlugins)...)
+	return generateConfigHash(append(hashInput, hostAliasesConfigHashInput(server.Spec.HostAliases)...)), nil
 }
 
 // reconcileSpireControllerManagerConfigMap reconciles the Spire Controller Manager ConfigMap
-func (r *SpireServerReconciler) reconcileSpireControllerManagerConfigMap(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager, ztwim *v1alpha1.ZeroTrustWorkloadIdentityManager, createOnlyMode bool) (string, error) {
+func (r *SpireServerReconciler) reconcileSpireControllerManagerConfigMap(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, createOnlyMode bool) (string, error) {
 	spireControllerManagerConfig, err := generateSpireControllerManagerConfigYaml(&server.Spec, ztwim)
 	if err != nil {
 		r.log.Error(err, "Failed to generate spire controller manager config")
@@ -109,6 +128,7 @@ func (r *SpireServerReconciler) reconcileSpireControllerManagerConfigMap(ctx con
 	}
 
 	spireControllerManagerConfigMap := generateControllerManagerConfigMap(spireControllerManagerConfig)
+	rollbackHash, rolledBack := confighistory.ApplyRollback(ctx, r.ctrlClient, server, server.Status.Conditions, spireControllerManagerConfigMap, statusMgr)
 	if err = controllerutil.SetControllerReference(server, spireControllerManagerConfigMap, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference on spire controller manager config")
 		statusMgr.AddCondition(ControllerManagerConfigAvailable, "SpireControllerManagerConfigMapGenerationFailed",
@@ -120,7 +140,7 @@ func (r *SpireServerReconciler) reconcileSpireControllerManagerConfigMap(ctx con
 	var existingSpireControllerManagerCM corev1.ConfigMap
 	err = r.ctrlClient.Get(ctx, types.NamespacedName{Name: spireControllerManagerConfigMap.Name, Namespace: spireControllerManagerConfigMap.Namespace}, &existingSpireControllerManagerCM)
 	if err != nil && kerrors.IsNotFound(err) {
-		if err = r.ctrlClient.Create(ctx, spireControllerManagerConfigMap); err != nil {
+		if err = r.ctrlClient.Create(ctx, spireControllerManagerConfigMap, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create spire controller manager config map")
 			statusMgr.AddCondition(ControllerManagerConfigAvailable, "SpireControllerManagerConfigMapGenerationFailed",
 				err.Error(),
@@ -151,11 +171,21 @@ func (r *SpireServerReconciler) reconcileSpireControllerManagerConfigMap(ctx con
 		"spire controller manager config map resources applied",
 		metav1.ConditionTrue)
 
+	if rolledBack {
+		return rollbackHash, nil
+	}
+	// A newly created ConfigMap is recorded on the reconcile triggered by its creation
+	if !createOnlyMode && existingSpireControllerManagerCM.Name != "" {
+		if err = confighistory.RecordRevision(ctx, r.ctrlClient, r.scheme, server, spireControllerManagerConfigMap); err != nil {
+			r.log.Error(err, "failed to record spire controller manager config revision")
+		}
+	}
+
 	return generateConfigHashFromString(spireControllerManagerConfig), nil
 }
 
 // reconcileSpireBundleConfigMap reconciles the Spire Bundle ConfigMap
-func (r *SpireServerReconciler) reconcileSpireBundleConfigMap(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager, ztwim *v1alpha1.ZeroTrustWorkloadIdentityManager) error {
+func (r *SpireServerReconciler) reconcileSpireBundleConfigMap(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager) error {
 	spireBundleCM, err := generateSpireBundleConfigMap(&server.Spec, ztwim)
 	if err != nil {
 		r.log.Error(err, "failed to generate spire bundle config map")
@@ -173,6 +203,16 @@ func (r *SpireServerReconciler) reconcileSpireBundleConfigMap(ctx context.Contex
 		return err
 	}
 
+	// Seed a renamed bundle ConfigMap with the current bundle, so agents moved to it keep
+	// trusting the server until the server publishes the bundle there itself
+	if err := r.seedSpireBundleConfigMap(ctx, spireBundleCM); err != nil {
+		r.log.Error(err, "failed to seed spire bundle config map")
+		statusMgr.AddCondition(BundleConfigAvailable, "SpireBundleConfigMapGenerationFailed",
+			err.Error(),
+			metav1.ConditionFalse)
+		return err
+	}
+
 	err = r.ctrlClient.Create(ctx, spireBundleCM)
 	if err != nil && !kerrors.IsAlreadyExists(err) {
 		r.log.Error(err, "failed to create spire bundle config map")
@@ -181,6 +221,15 @@ func (r *SpireServerReconciler) reconcileSpireBundleConfigMap(ctx context.Contex
 			metav1.ConditionFalse)
 		return fmt.Errorf("failed to create spire-bundle ConfigMap: %w", err)
 	}
+	if kerrors.IsAlreadyExists(err) {
+		if err := r.labelSpireBundleConfigMap(ctx, spireBundleCM.Name); err != nil {
+			r.log.Error(err, "failed to label spire bundle config map")
+			statusMgr.AddCondition(BundleConfigAvailable, "SpireBundleConfigMapGenerationFailed",
+				err.Error(),
+				metav1.ConditionFalse)
+			return err
+		}
+	}
 
 	statusMgr.AddCondition(BundleConfigAvailable, "SpireBundleConfigMapCreated",
 		"spire bundle config map resources applied",
@@ -188,12 +237,47 @@ func (r *SpireServerReconciler) reconcileSpireBundleConfigMap(ctx context.Contex
 	return nil
 }
 
+// seedSpireBundleConfigMap copies the bundle of a previous bundle ConfigMap into the desired one
+func (r *SpireServerReconciler) seedSpireBundleConfigMap(ctx context.Context, desired *corev1.ConfigMap) error {
+	var bundles corev1.ConfigMapList
+	if err := r.ctrlClient.List(ctx, &bundles, client.InNamespace(desired.Namespace), client.MatchingLabels{utils.TrustBundleLabel: "true"}); err != nil {
+		return fmt.Errorf("failed to list bundle ConfigMaps: %w", err)
+	}
+	for _, previous := range bundles.Items {
+		if previous.Name != desired.Name && len(previous.Data) > 0 {
+			desired.Data = previous.Data
+			return nil
+		}
+	}
+	return nil
+}
+
+// labelSpireBundleConfigMap adds the trust bundle label to a bundle ConfigMap created before
+// the label was introduced
+func (r *SpireServerReconciler) labelSpireBundleConfigMap(ctx context.Context, name string) error {
+	existing := &corev1.ConfigMap{}
+	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: name, Namespace: utils.GetOperatorNamespace()}, existing); err != nil {
+		return fmt.Errorf("failed to get bundle ConfigMap %s: %w", name, err)
+	}
+	if existing.Labels[utils.TrustBundleLabel] == "true" {
+		return nil
+	}
+	if existing.Labels == nil {
+		existing.Labels = map[string]string{}
+	}
+	existing.Labels[utils.TrustBundleLabel] = "true"
+	if err := r.ctrlClient.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to label bundle ConfigMap %s: %w", name, err)
+	}
+	return nil
+}
+
 // generateSpireServerConfigMap generates the spire-server ConfigMap
-func generateSpireServerConfigMap(config *v1alpha1.SpireServerSpec, ztwim *v1alpha1.ZeroTrustWorkloadIdentityManager) (*corev1.ConfigMap, error) {
+func generateSpireServerConfigMap(config *v1alpha2.SpireServerSpec, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager) (*corev1.ConfigMap, error) {
 	if config == nil {
 		return nil, fmt.Errorf("config is nil")
 	}
-	if ztwim.Spec.TrustDomain == "" {
+	if utils.TrustDomain(ztwim) == "" {
 		return nil, fmt.Errorf("trust_domain is empty")
 	}
 	if ztwim.Spec.BundleConfigMap == "" {
@@ -215,12 +299,15 @@ func generateSpireServerConfigMap(config *v1alpha1.SpireServerSpec, ztwim *v1alp
 			"server.conf": string(confJSON),
 		},
 	}
+	for key, data := range externalPluginConfigFiles(config.ExternalPlugins) {
+		cm.Data[key] = data
+	}
 
 	return cm, nil
 }
 
 // generateServerConfMap builds the server.conf structure as a Go map
-func generateServerConfMap(config *v1alpha1.SpireServerSpec, ztwim *v1alpha1.ZeroTrustWorkloadIdentityManager) map[string]interface{} {
+func generateServerConfMap(config *v1alpha2.SpireServerSpec, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager) map[string]interface{} {
 	// Build the server config
 	serverConfig := map[string]interface{}{
 		"audit_log_enabled": false,
@@ -241,7 +328,17 @@ func generateServerConfMap(config *v1alpha1.SpireServerSpec, ztwim *v1alpha1.Zer
 		"jwt_issuer":            config.JwtIssuer,
 		"log_level":             utils.GetLogLevelFromString(config.LogLevel),
 		"log_format":            utils.GetLogFormatFromString(config.LogFormat),
-		"trust_domain":          ztwim.Spec.TrustDomain,
+		"trust_domain":          utils.TrustDomain(ztwim),
+	}
+
+	// Issue the agent SVIDs with their own TTL, e.g. to ride out long disconnections of edge nodes
+	if config.AgentTTL != nil {
+		serverConfig["agent_ttl"] = config.AgentTTL
+	}
+
+	// Grant the admin APIs to the callers presenting one of the admin IDs
+	if len(config.AdminIDs) > 0 {
+		serverConfig["admin_ids"] = config.AdminIDs
 	}
 
 	// Only add jwt_key_type if it's explicitly set
@@ -249,6 +346,19 @@ func generateServerConfMap(config *v1alpha1.SpireServerSpec, ztwim *v1alpha1.Zer
 		serverConfig["jwt_key_type"] = config.JWTKeyType
 	}
 
+	// Turn off the rate limits of the server, e.g. for nodes running bursts of pods
+	if config.RateLimit != nil {
+		serverConfig["ratelimit"] = map[string]interface{}{
+			"attestation": ptr.Deref(config.RateLimit.Attestation, true),
+			"signing":     ptr.Deref(config.RateLimit.Signing, true),
+		}
+	}
+
+	// Experimental options are only rendered when enabled as an unsupported feature
+	if experimental := utils.ExperimentalConfig(config.ExperimentalFlags, utils.SpireServerExperimentalFlags); experimental != nil {
+		serverConfig["experimental"] = experimental
+	}
+
 	configMap := map[string]interface{}{
 		"health_checks": map[string]interface{}{
 			"bind_address":     "0.0.0.0",
@@ -281,12 +391,10 @@ func generateServerConfMap(config *v1alpha1.SpireServerSpec, ztwim *v1alpha1.Zer
 							"clusters": []map[string]interface{}{
 								{
 									ztwim.Spec.ClusterName: map[string]interface{}{
-										"allowed_node_label_keys": []string{},
-										"allowed_pod_label_keys":  []string{},
-										"audience":                []string{"spire-server"},
-										"service_account_allow_list": []string{
-											fmt.Sprintf("%s:spire-agent", utils.GetOperatorNamespace()),
-										},
+										"allowed_node_label_keys":    utils.PSATNodeLabelKeys(config),
+										"allowed_pod_label_keys":     []string{},
+										"audience":                   utils.PSATAudiences(config),
+										"service_account_allow_list": utils.PSATServiceAccountAllowList(config),
 									},
 								},
 							},
@@ -297,10 +405,7 @@ func generateServerConfMap(config *v1alpha1.SpireServerSpec, ztwim *v1alpha1.Zer
 			"Notifier": []map[string]interface{}{
 				{
 					"k8sbundle": map[string]interface{}{
-						"plugin_data": map[string]interface{}{
-							"config_map": ztwim.Spec.BundleConfigMap,
-							"namespace":  utils.GetOperatorNamespace(),
-						},
+						"plugin_data": buildBundleNotifierPluginData(config.BundleNotifier, ztwim.Spec.BundleConfigMap),
 					},
 				},
 			},
@@ -320,11 +425,54 @@ func generateServerConfMap(config *v1alpha1.SpireServerSpec, ztwim *v1alpha1.Zer
 		serverSection["federation"] = generateFederationConfig(config.Federation)
 	}
 
+	// Allow agents bootstrapping with a join token alongside k8s_psat agents
+	if ptr.Deref(config.JoinTokenAttestationEnabled, false) {
+		plugins := configMap["plugins"].(map[string]interface{})
+		plugins["NodeAttestor"] = append(plugins["NodeAttestor"].([]map[string]interface{}),
+			map[string]interface{}{"join_token": map[string]interface{}{"plugin_data": map[string]interface{}{}}})
+	}
+
+	addExternalPluginsToConfig(configMap["plugins"].(map[string]interface{}), config.ExternalPlugins)
+
+	// Send the telemetry to the statsd bridge, if any
+	telemetry.ConfigureSPIRE(configMap["telemetry"].(map[string]interface{}), config.Telemetry)
+
 	return configMap
 }
 
+// buildBundleNotifierPluginData builds the k8sbundle plugin data. The trust bundle ConfigMap of
+// the operator namespace is always maintained; the additional ConfigMaps are rendered as
+// clusters, which use the in-cluster credentials of the server.
+func buildBundleNotifierPluginData(notifier *v1alpha2.BundleNotifierConfig, bundleConfigMap string) map[string]interface{} {
+	pluginData := map[string]interface{}{
+		"config_map": bundleConfigMap,
+		"namespace":  utils.GetOperatorNamespace(),
+	}
+	if notifier == nil {
+		return pluginData
+	}
+	if notifier.WebhookLabel != "" {
+		pluginData["webhook_label"] = notifier.WebhookLabel
+	}
+	if len(notifier.ConfigMaps) > 0 {
+		clusters := make([]map[string]interface{}, 0, len(notifier.ConfigMaps))
+		for _, target := range notifier.ConfigMaps {
+			cluster := map[string]interface{}{
+				"config_map": target.Name,
+				"namespace":  target.Namespace,
+			}
+			if target.Key != "" {
+				cluster["config_map_key"] = target.Key
+			}
+			clusters = append(clusters, cluster)
+		}
+		pluginData["clusters"] = clusters
+	}
+	return pluginData
+}
+
 // generateFederationConfig generates the federation configuration for SPIRE server
-func generateFederationConfig(federation *v1alpha1.FederationConfig) map[string]interface{} {
+func generateFederationConfig(federation *v1alpha2.FederationConfig) map[string]interface{} {
 	federationConf := map[string]interface{}{
 		"bundle_endpoint": generateBundleEndpointConfig(&federation.BundleEndpoint),
 	}
@@ -339,13 +487,13 @@ func generateFederationConfig(federation *v1alpha1.FederationConfig) map[string]
 
 			// Add bundle endpoint profile configuration
 			switch fedTrust.BundleEndpointProfile {
-			case v1alpha1.HttpsSpiffeProfile:
+			case v1alpha2.HttpsSpiffeProfile:
 				trustConfig["bundle_endpoint_profile"] = map[string]interface{}{
 					"https_spiffe": map[string]interface{}{
 						"endpoint_spiffe_id": fedTrust.EndpointSpiffeId,
 					},
 				}
-			case v1alpha1.HttpsWebProfile:
+			case v1alpha2.HttpsWebProfile:
 				trustConfig["bundle_endpoint_profile"] = map[string]interface{}{
 					"https_web": map[string]interface{}{},
 				}
@@ -360,7 +508,7 @@ func generateFederationConfig(federation *v1alpha1.FederationConfig) map[string]
 }
 
 // generateBundleEndpointConfig generates the bundle endpoint configuration
-func generateBundleEndpointConfig(bundleEndpoint *v1alpha1.BundleEndpointConfig) map[string]interface{} {
+func generateBundleEndpointConfig(bundleEndpoint *v1alpha2.BundleEndpointConfig) map[string]interface{} {
 	endpointConfig := map[string]interface{}{
 		"address": "0.0.0.0",
 		"port":    8443,
@@ -376,13 +524,13 @@ func generateBundleEndpointConfig(bundleEndpoint *v1alpha1.BundleEndpointConfig)
 	// Configure profile-specific settings
 	// According to SPIRE docs, profile-specific config should be nested under profile blocks
 	switch bundleEndpoint.Profile {
-	case v1alpha1.HttpsSpiffeProfile:
+	case v1alpha2.HttpsSpiffeProfile:
 		// For https_spiffe, SPIRE uses its own SVID for TLS authentication
 		// profile "https_spiffe" { }
 		endpointConfig["profile"] = map[string]interface{}{
 			"https_spiffe": map[string]interface{}{},
 		}
-	case v1alpha1.HttpsWebProfile:
+	case v1alpha2.HttpsWebProfile:
 		// Configure https_web profile
 		// profile "https_web" { acme { ... } or serving_cert_file { ... } }
 		httpsWebProfile := map[string]interface{}{}
@@ -393,7 +541,7 @@ func generateBundleEndpointConfig(bundleEndpoint *v1alpha1.BundleEndpointConfig)
 					"directory_url": bundleEndpoint.HttpsWeb.Acme.DirectoryUrl,
 					"domain_name":   bundleEndpoint.HttpsWeb.Acme.DomainName,
 					"email":         bundleEndpoint.HttpsWeb.Acme.Email,
-					"tos_accepted":  utils.StringToBool(bundleEndpoint.HttpsWeb.Acme.TosAccepted),
+					"tos_accepted":  ptr.Deref(bundleEndpoint.HttpsWeb.Acme.TosAccepted, false),
 				}
 			} else if bundleEndpoint.HttpsWeb.ServingCert != nil {
 				// Default fileSyncInterval to 3600 seconds if not specified
@@ -430,14 +578,13 @@ func marshalToJSON(data map[string]interface{}) ([]byte, error) {
 
 // generateConfigHash returns a SHA256 hex string of the trimmed input string
 func generateConfigHashFromString(data string) string {
-	normalized := strings.TrimSpace(data) // Removes leading/trailing whitespace and newlines
-	return generateConfigHash([]byte(normalized))
+	hash := sha256.Sum256([]byte(strings.TrimSpace(data)))
+	return hex.EncodeToString(hash[:])
 }
 
 // generateConfigHash returns a SHA256 hex string of the trimmed input bytes
 func generateConfigHash(data []byte) string {
-	normalized := strings.TrimSpace(string(data)) // Convert to string, trim, convert back to bytes
-	hash := sha256.Sum256([]byte(normalized))
+	hash := sha256.Sum256(bytes.TrimSpace(data))
 	return hex.EncodeToString(hash[:])
 }
 
@@ -450,7 +597,7 @@ func getCAKeyType(keyType string) string {
 }
 
 // buildDataStorePluginData builds the plugin_data map for the DataStore plugin
-func buildDataStorePluginData(datastore v1alpha1.DataStore) map[string]interface{} {
+func buildDataStorePluginData(datastore v1alpha2.DataStore) map[string]interface{} {
 	pluginData := map[string]interface{}{
 		"connection_string": datastore.ConnectionString,
 		"database_type":     datastore.DatabaseType,
@@ -463,8 +610,8 @@ func buildDataStorePluginData(datastore v1alpha1.DataStore) map[string]interface
 	if datastore.MaxIdleConns > 0 {
 		pluginData["max_idle_conns"] = datastore.MaxIdleConns
 	}
-	if datastore.DisableMigration != "" {
-		pluginData["disable_migration"] = utils.StringToBool(datastore.DisableMigration)
+	if datastore.DisableMigration != nil {
+		pluginData["disable_migration"] = ptr.Deref(datastore.DisableMigration, false)
 	}
 
 	if datastore.ConnMaxLifetime > 0 {
@@ -473,8 +620,8 @@ func buildDataStorePluginData(datastore v1alpha1.DataStore) map[string]interface
 	return pluginData
 }
 
-func generateControllerManagerConfig(config *v1alpha1.SpireServerSpec, ztwim *v1alpha1.ZeroTrustWorkloadIdentityManager) (*ControllerManagerConfigYAML, error) {
-	if ztwim.Spec.TrustDomain == "" {
+func generateControllerManagerConfig(config *v1alpha2.SpireServerSpec, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager) (*ControllerManagerConfigYAML, error) {
+	if utils.TrustDomain(ztwim) == "" {
 		return nil, errors.New("trust_domain is empty")
 	}
 	if ztwim.Spec.ClusterName == "" {
@@ -490,7 +637,7 @@ func generateControllerManagerConfig(config *v1alpha1.SpireServerSpec, ztwim *v1
 		},
 		ControllerManagerConfig: spiffev1alpha.ControllerManagerConfig{
 			ClusterName: ztwim.Spec.ClusterName,
-			TrustDomain: ztwim.Spec.TrustDomain,
+			TrustDomain: utils.TrustDomain(ztwim),
 			ControllerManagerConfigurationSpec: spiffev1alpha.ControllerManagerConfigurationSpec{
 				Metrics: spiffev1alpha.ControllerMetrics{
 					BindAddress: "0.0.0.0:8082",
@@ -498,9 +645,10 @@ func generateControllerManagerConfig(config *v1alpha1.SpireServerSpec, ztwim *v1
 				Health: spiffev1alpha.ControllerHealth{
 					HealthProbeBindAddress: "0.0.0.0:8083",
 				},
+				CacheNamespaces:  controllerManagerCacheNamespaces(config.ControllerManagerNamespaces),
 				EntryIDPrefix:    ztwim.Spec.ClusterName,
 				WatchClassless:   false,
-				ClassName:        "zero-trust-workload-identity-manager-spire",
+				ClassName:        spireControllerManagerClassName,
 				ParentIDTemplate: "spiffe://{{ .TrustDomain }}/spire/agent/k8s_psat/{{ .ClusterName }}/{{ .NodeMeta.UID }}",
 				Reconcile: &spiffev1alpha.ReconcileConfig{
 					ClusterSPIFFEIDs:             true,
@@ -510,17 +658,12 @@ func generateControllerManagerConfig(config *v1alpha1.SpireServerSpec, ztwim *v1
 			},
 			ValidatingWebhookConfigurationName: "spire-controller-manager-webhook",
 			SPIREServerSocketPath:              "/tmp/spire-server/private/api.sock",
-			IgnoreNamespaces: []string{
-				"kube-system",
-				"kube-public",
-				"local-path-storage",
-				"openshift-*",
-			},
+			IgnoreNamespaces:                   controllerManagerIgnoredNamespaces(config.ControllerManagerNamespaces),
 		},
 	}, nil
 }
 
-func generateSpireControllerManagerConfigYaml(config *v1alpha1.SpireServerSpec, ztwim *v1alpha1.ZeroTrustWorkloadIdentityManager) (string, error) {
+func generateSpireControllerManagerConfigYaml(config *v1alpha2.SpireServerSpec, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager) (string, error) {
 	controllerManagerConfig, err := generateControllerManagerConfig(config, ztwim)
 	if err != nil {
 		return "", err
@@ -545,15 +688,17 @@ func generateControllerManagerConfigMap(configYAML string) *corev1.ConfigMap {
 	}
 }
 
-func generateSpireBundleConfigMap(config *v1alpha1.SpireServerSpec, ztwim *v1alpha1.ZeroTrustWorkloadIdentityManager) (*corev1.ConfigMap, error) {
+func generateSpireBundleConfigMap(config *v1alpha2.SpireServerSpec, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager) (*corev1.ConfigMap, error) {
 	if ztwim.Spec.BundleConfigMap == "" {
 		return nil, errors.New("bundle ConfigMap is empty")
 	}
+	labels := utils.SpireServerLabels(config.Labels)
+	labels[utils.TrustBundleLabel] = "true"
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      ztwim.Spec.BundleConfigMap,
 			Namespace: utils.GetOperatorNamespace(),
-			Labels:    utils.SpireServerLabels(config.Labels),
+			Labels:    labels,
 		},
 	}, nil
 }
@@ -4,15 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 
@@ -21,16 +24,26 @@ import (
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/go-logr/logr"
 
+	configv1 "github.com/openshift/api/config/v1"
 	routev1 "github.com/openshift/api/route/v1"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/audit"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/breaker"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/dependencies"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/maintenance"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/pipeline"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/spirecli"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/topology"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 )
 
@@ -47,15 +60,30 @@ const (
 	RBACAvailable                    = "RBACAvailable"
 	ValidatingWebhookAvailable       = "ValidatingWebhookAvailable"
 	RouteAvailable                   = "RouteAvailable"
+	DatastoreBackupVerified          = "DatastoreBackupVerified"
+	ExternalAgentEndpointAvailable   = "ExternalAgentEndpointAvailable"
+	RegistrationEntriesWithinLimit   = "RegistrationEntriesWithinLimit"
+	NodeAliasesAvailable             = "NodeAliasesAvailable"
 )
 
 // SpireServerReconciler reconciles a SpireServer object
 type SpireServerReconciler struct {
-	ctrlClient    customClient.CustomCtrlClient
-	ctx           context.Context
-	eventRecorder record.EventRecorder
-	log           logr.Logger
-	scheme        *runtime.Scheme
+	ctrlClient          customClient.CustomCtrlClient
+	ctx                 context.Context
+	eventRecorder       record.EventRecorder
+	log                 logr.Logger
+	scheme              *runtime.Scheme
+	failureBreaker      *breaker.Breaker
+	nodeStats           nodeStatsReader
+	serverHealth        serverHealthReader
+	serverAPIHealth     serverAPIHealthChecker
+	serverLogs          serverLogReader
+	logCursor           *logCursor
+	webhookCerts        webhookCertReader
+	cli                 spirecli.Runner
+	federationEndpoints bundleEndpointFetcher
+	serverMetrics       serverMetricsReader
+	loadHistory         *loadHistory
 }
 
 // New returns a new Reconciler instance.
@@ -64,136 +92,355 @@ func New(mgr ctrl.Manager) (*SpireServerReconciler, error) {
 	if err != nil {
 		return nil, err
 	}
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+	cli, err := spirecli.NewPodExecRunner(mgr.GetConfig())
+	if err != nil {
+		return nil, err
+	}
 	return &SpireServerReconciler{
-		ctrlClient:    c,
-		ctx:           context.Background(),
-		eventRecorder: mgr.GetEventRecorderFor(utils.ZeroTrustWorkloadIdentityManagerSpireServerControllerName),
-		log:           ctrl.Log.WithName(utils.ZeroTrustWorkloadIdentityManagerSpireServerControllerName),
-		scheme:        mgr.GetScheme(),
+		ctrlClient:          c,
+		ctx:                 context.Background(),
+		eventRecorder:       mgr.GetEventRecorderFor(utils.ZeroTrustWorkloadIdentityManagerSpireServerControllerName),
+		log:                 ctrl.Log.WithName(utils.ZeroTrustWorkloadIdentityManagerSpireServerControllerName),
+		scheme:              mgr.GetScheme(),
+		failureBreaker:      breaker.New(),
+		nodeStats:           &kubeletStatsReader{restClient: clientset.CoreV1().RESTClient()},
+		serverHealth:        &podProxyHealthReader{restClient: clientset.CoreV1().RESTClient()},
+		serverAPIHealth:     grpcHealthChecker{},
+		serverLogs:          &podLogReader{pods: clientset.CoreV1()},
+		logCursor:           &logCursor{},
+		webhookCerts:        tlsWebhookCertReader{},
+		cli:                 cli,
+		federationEndpoints: httpsBundleEndpointFetcher{},
+		serverMetrics:       &podProxyMetricsReader{restClient: clientset.CoreV1().RESTClient()},
+		loadHistory:         &loadHistory{},
 	}, nil
 }
 
-func (r *SpireServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	r.log.Info(fmt.Sprintf("reconciling %s", utils.ZeroTrustWorkloadIdentityManagerSpireServerControllerName))
-	var server v1alpha1.SpireServer
+func (r *SpireServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, reconcileErr error) {
+	traced := *r
+	ctx, traced.log, traced.eventRecorder = utils.TraceReconcile(ctx, r.log, r.eventRecorder)
+	r = &traced
+	r.log.Info("reconciling", "controller", utils.ZeroTrustWorkloadIdentityManagerSpireServerControllerName, "name", req.Name)
+	var server v1alpha2.SpireServer
 	if err := r.ctrlClient.Get(ctx, req.NamespacedName, &server); err != nil {
 		if kerrors.IsNotFound(err) {
 			r.log.Info("SpireServer resource not found. Ignoring since object must be deleted or not been created.")
+			status.Forget(&server, req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
-		return ctrl.Result{}, err
+		return utils.ReconcileResult(err)
 	}
 
 	// Set Ready to false at the start of reconciliation
-	status.SetInitialReconciliationStatus(ctx, r.ctrlClient, &server, func() *v1alpha1.ConditionalStatus {
+	status.SetInitialReconciliationStatus(ctx, r.ctrlClient, &server, func() *v1alpha2.ConditionalStatus {
 		return &server.Status.ConditionalStatus
 	}, "SpireServer")
 
 	statusMgr := status.NewManager(r.ctrlClient)
 	defer func() {
-		if err := statusMgr.ApplyStatus(ctx, &server, func() *v1alpha1.ConditionalStatus {
+		statusMgr.ReportWorkloadVersion(ctx, &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "spire-server", Namespace: utils.GetOperatorNamespace()}})
+		if err := statusMgr.ApplyStatus(ctx, &server, func() *v1alpha2.ConditionalStatus {
 			return &server.Status.ConditionalStatus
 		}); err != nil {
 			r.log.Error(err, "failed to update status")
 		}
+		res = statusMgr.RequeueHeldBack(res, reconcileErr)
 	}()
 
-	var ztwim v1alpha1.ZeroTrustWorkloadIdentityManager
+	var ztwim v1alpha2.ZeroTrustWorkloadIdentityManager
 	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: "cluster"}, &ztwim); err != nil {
 		if kerrors.IsNotFound(err) {
 			r.log.Error(err, "failed to get ZeroTrustWorkloadIdentityManager")
-			statusMgr.AddCondition(v1alpha1.Ready, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(v1alpha2.Ready, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to retrieve ZeroTrustWorkloadIdentityManager from cluster"),
 				metav1.ConditionFalse)
 			return ctrl.Result{}, nil
 		}
-		return ctrl.Result{}, err
+		return utils.ReconcileResult(err)
 	}
+	statusMgr.SetGracePeriod(ztwim.Spec.StatusGracePeriod)
 
 	// Set ZTWIM as the owner of SpireServer only if needed
 	if utils.NeedsOwnerReferenceUpdate(&server, &ztwim) {
 		if err := controllerutil.SetControllerReference(&ztwim, &server, r.scheme); err != nil {
 			r.log.Error(err, "failed to set controller reference on SpireServer")
-			statusMgr.AddCondition(v1alpha1.Ready, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(v1alpha2.Ready, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to set owner reference on SpireServer: %v", err),
 				metav1.ConditionFalse)
-			return ctrl.Result{}, err
+			return utils.ReconcileResult(err)
 		}
 
 		// Persist the owner reference to the cluster
 		if err := r.ctrlClient.Update(ctx, &server); err != nil {
 			r.log.Error(err, "failed to update SpireServer with owner reference")
-			statusMgr.AddCondition(v1alpha1.Ready, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(v1alpha2.Ready, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to update SpireServer with owner reference: %v", err),
 				metav1.ConditionFalse)
-			return ctrl.Result{}, err
+			return utils.ReconcileResult(err)
 		}
 	}
 
+	// Fill in the settings left unset from the sizing and topology profiles, in memory only
+	topologyProfile, err := topology.Resolve(ctx, r.ctrlClient, &ztwim)
+	if err != nil {
+		r.log.Error(err, "failed to resolve the topology profile")
+		return utils.ReconcileResult(err)
+	}
+	topology.ApplyToOperand(topologyProfile, ztwim.Spec.SizingProfile, utils.ResourceKindSpireServer, &server.Spec.CommonConfig)
+	utils.ApplyImagePullSecrets(ztwim.Spec.ImagePullSecrets, &server.Spec.CommonConfig)
+	utils.ApplySizingProfileToDatastore(topology.SizingProfile(topologyProfile, ztwim.Spec.SizingProfile), &server.Spec.Datastore)
+
 	// Handle create-only mode
 	createOnlyMode := r.handleCreateOnlyMode(&server, statusMgr)
 
 	// Validate configuration
 	if err := r.validateConfiguration(ctx, &server, statusMgr, &ztwim); err != nil {
+		statusMgr.SetDegradedCondition(utils.NewInvalidConfigurationError(err, "configuration validation failed"), server.Status.Conditions)
 		return ctrl.Result{}, nil
 	}
 
+	// Refuse to render a configuration whose trust domain and JWT issuers disagree
+	if err := statusMgr.CheckConfigurationConflict(ctx, utils.TrustDomain(&ztwim), server.Status.Conditions); err != nil {
+		if utils.ClassifyError(err) == utils.InvalidConfigurationError {
+			statusMgr.AddCondition(ConfigurationValid, utils.ConfigurationConflictStatusType, err.Error(), metav1.ConditionFalse)
+		}
+		statusMgr.SetDegradedCondition(err, server.Status.Conditions)
+		return utils.ReconcileResult(err)
+	}
+
 	// Perform TTL validation
 	if err := r.handleTTLValidation(ctx, &server, statusMgr); err != nil {
+		statusMgr.SetDegradedCondition(utils.NewInvalidConfigurationError(err, "configuration validation failed"), server.Status.Conditions)
 		return ctrl.Result{}, nil
 	}
 
-	// Reconcile ServiceAccount
-	if err := r.reconcileServiceAccount(ctx, &server, statusMgr, createOnlyMode); err != nil {
-		return ctrl.Result{}, err
+	// Validate the rate limits against the datastore
+	if err := validateRateLimit(server.Spec.RateLimit, &server.Spec.Datastore); err != nil {
+		r.log.Error(err, "Invalid rate limits")
+		statusMgr.AddCondition(ConfigurationValid, "InvalidRateLimit", err.Error(), metav1.ConditionFalse)
+		statusMgr.SetDegradedCondition(utils.NewInvalidConfigurationError(err, "configuration validation failed"), server.Status.Conditions)
+		return ctrl.Result{}, nil
 	}
 
-	// Reconcile Services (spire-server and controller-manager)
-	if err := r.reconcileService(ctx, &server, statusMgr, createOnlyMode); err != nil {
-		return ctrl.Result{}, err
+	// Validate the maintenance windows deferring the StatefulSet updates
+	if err := maintenance.Validate(ztwim.Spec.MaintenanceWindows); err != nil {
+		r.log.Error(err, "Invalid maintenance windows")
+		statusMgr.AddCondition(ConfigurationValid, maintenance.ReasonInvalidWindow, err.Error(), metav1.ConditionFalse)
+		statusMgr.SetDegradedCondition(utils.NewInvalidConfigurationError(err, "configuration validation failed"), server.Status.Conditions)
+		return ctrl.Result{}, nil
 	}
 
-	// Reconcile RBAC (spire-server, bundle, and controller-manager)
-	if err := r.reconcileRBAC(ctx, &server, statusMgr, createOnlyMode); err != nil {
-		return ctrl.Result{}, err
+	// Kinds of generated resources left to the user
+	unmanagedKinds := utils.UnmanagedKinds(server.Spec.ManagedResources)
+
+	// Report the SPIRE servers deployed outside of the operator
+	r.reportEnvironmentConflicts(ctx, &server, statusMgr)
+
+	// Compute the pending changes without applying them when the dry-run annotation is set
+	if utils.IsDryRunRequested(&server) {
+		dryRunClient := customClient.NewDryRunClient(r.ctrlClient)
+		dryRunReconciler := *r
+		dryRunReconciler.ctrlClient = customClient.NewUnmanagedClient(dryRunClient, unmanagedKinds)
+		// A dry run only reports the pending changes: the Events of the checks are discarded
+		dryRunReconciler.eventRecorder = &record.FakeRecorder{}
+		err := dryRunReconciler.reconcileResources(ctx, &server, status.NewManager(dryRunClient), &ztwim, createOnlyMode)
+		statusMgr.ReportDryRun(dryRunClient.Changes(), err)
+		return ctrl.Result{}, nil
 	}
-
-	// Reconcile Webhook
-	if err := r.reconcileWebhook(ctx, &server, statusMgr, createOnlyMode); err != nil {
-		return ctrl.Result{}, err
+	statusMgr.ClearDryRun(server.Status.Conditions)
+
+	// Record the resources changed for this generation in the audit trail, leaving the
+	// resources of unmanaged kinds alone
+	recordingClient := customClient.NewRecordingClient(r.ctrlClient)
+	unmanagedClient := customClient.NewUnmanagedClient(recordingClient, unmanagedKinds)
+	auditedReconciler := *r
+	auditedReconciler.ctrlClient = unmanagedClient
+	err = auditedReconciler.reconcileResources(ctx, &server, statusMgr, &ztwim, createOnlyMode)
+	if auditErr := audit.Append(ctx, r.ctrlClient, r.scheme, r.eventRecorder, &server, server.Spec, recordingClient.Changes()); auditErr != nil {
+		r.log.Error(auditErr, "failed to record audit trail")
 	}
-
-	// Reconcile ConfigMaps
-	spireServerConfigMapHash, err := r.reconcileSpireServerConfigMap(ctx, &server, statusMgr, &ztwim, createOnlyMode)
-	if err != nil {
-		return ctrl.Result{}, err
+	statusMgr.ReportUnmanagedResources(unmanagedKinds, unmanagedClient.Skipped(), server.Status.Conditions)
+	// Fail closed on the resources missing in create-only mode rather than reporting stale conditions
+	if createOnlyMode {
+		statusMgr.CheckRequiredResources(ctx, r.eventRecorder, &server, server.Status.Conditions, requiredResources(&ztwim)...)
 	}
-
-	// Reconcile Spire Controller Manager ConfigMap
-	spireControllerManagerConfigMapHash, err := r.reconcileSpireControllerManagerConfigMap(ctx, &server, statusMgr, &ztwim, createOnlyMode)
-	if err != nil {
-		return ctrl.Result{}, err
+	statusMgr.SetDegradedCondition(err, server.Status.Conditions)
+	if err == nil {
+		reportServerAPIOutage(&server, statusMgr, time.Now())
 	}
-
-	// Reconcile Spire Bundle ConfigMap
-	if err := r.reconcileSpireBundleConfigMap(ctx, &server, statusMgr, &ztwim); err != nil {
-		return ctrl.Result{}, err
+	result, err := r.failureBreaker.Result(r.eventRecorder, &server, statusMgr, recordingClient.Failure(), err)
+	if err == nil && result.RequeueAfter == 0 && server.Spec.Limits != nil {
+		// Registration entries are not watched, count them again periodically
+		result.RequeueAfter = registrationEntriesCheckInterval
 	}
-
-	// Reconcile StatefulSet
-	if err := r.reconcileStatefulSet(ctx, &server, statusMgr, createOnlyMode, spireServerConfigMapHash, spireControllerManagerConfigMapHash); err != nil {
-		return ctrl.Result{}, err
+	if err == nil && result.RequeueAfter == 0 && len(utils.PSATNodeLabelSelectors(&server.Spec)) > 0 {
+		// Nor are the labels of the nodes the agent aliases are created for
+		result.RequeueAfter = nodeAliasesCheckInterval
+	}
+	if err == nil && result.RequeueAfter == 0 && isSQLiteDatastore(&server.Spec.Datastore) {
+		// Nor is the datastore volume usage
+		result.RequeueAfter = datastoreDiskUsageCheckInterval
+	}
+	if err == nil && result.RequeueAfter == 0 && sizingRecommendationsEnabled(&server.Spec) {
+		// Nor is the load of the server, sampled for the sizing recommendations
+		result.RequeueAfter = sizingRecommendationInterval
+	}
+	if err == nil && result.RequeueAfter == 0 && r.serverHealth != nil {
+		// Nor is the health of the server plugins
+		result.RequeueAfter = pluginHealthCheckInterval
+	}
+	if err == nil && result.RequeueAfter == 0 && r.webhookCerts != nil {
+		// Nor is the rotation of the webhook serving certificate
+		result.RequeueAfter = webhookCertCheckInterval
 	}
+	if err == nil && r.serverAPIHealth != nil && (result.RequeueAfter == 0 || serverAPIHealthCheckInterval < result.RequeueAfter) {
+		// Nor is the health of the server API, checked more often to detect outages
+		result.RequeueAfter = serverAPIHealthCheckInterval
+	}
+	if err == nil {
+		// Report the expiry of the break-glass identity, or retry its issuance or revocation
+		if after := breakGlassRequeueAfter(&server, statusMgr, time.Now()); after > 0 && (result.RequeueAfter == 0 || after < result.RequeueAfter) {
+			result.RequeueAfter = after
+		}
+	}
+	if err == nil {
+		// Apply the deferred changes when the next maintenance window opens
+		result.RequeueAfter = maintenance.RequeueAfter(statusMgr, ztwim.Spec.MaintenanceWindows, result.RequeueAfter, time.Now())
+	}
+	return result, err
+}
 
-	// reconcile Route if enabled
-	if err := r.reconcileRoute(ctx, &server, statusMgr, &ztwim, createOnlyMode); err != nil {
-		return ctrl.Result{}, err
+// reconcileResources reconciles all resources managed for the SpireServer
+func (r *SpireServerReconciler) reconcileResources(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, createOnlyMode bool) error {
+	var spireServerConfigMapHash, spireControllerManagerConfigMapHash string
+	err := pipeline.Run(
+		// Reconcile ServiceAccount
+		pipeline.Step{Resource: "ServiceAccount", Run: func() error {
+			return r.reconcileServiceAccount(ctx, server, statusMgr, createOnlyMode)
+		}},
+		// Reconcile Services (spire-server and controller-manager)
+		pipeline.Step{Resource: "Service", Run: func() error {
+			return r.reconcileService(ctx, server, statusMgr, createOnlyMode)
+		}},
+		// Reconcile RBAC (spire-server, bundle, and controller-manager)
+		pipeline.Step{Resource: "RBAC", Run: func() error {
+			return r.reconcileRBAC(ctx, server, statusMgr, ztwim, createOnlyMode)
+		}},
+		// Reconcile Webhook
+		pipeline.Step{Resource: "Webhook", Run: func() error {
+			return r.reconcileWebhook(ctx, server, statusMgr, createOnlyMode)
+		}},
+		// Reconcile ConfigMaps
+		pipeline.Step{Resource: "ConfigMap spire-server", Run: func() (err error) {
+			spireServerConfigMapHash, err = r.reconcileSpireServerConfigMap(ctx, server, statusMgr, ztwim, createOnlyMode)
+			return err
+		}},
+		// Reconcile Spire Controller Manager ConfigMap
+		pipeline.Step{Resource: "ConfigMap spire-controller-manager", Run: func() (err error) {
+			spireControllerManagerConfigMapHash, err = r.reconcileSpireControllerManagerConfigMap(ctx, server, statusMgr, ztwim, createOnlyMode)
+			return err
+		}},
+		// Reconcile Spire Bundle ConfigMap
+		pipeline.Step{Resource: "ConfigMap spire-bundle", Run: func() error {
+			return r.reconcileSpireBundleConfigMap(ctx, server, statusMgr, ztwim)
+		}},
+		// Reconcile StatefulSet, rolled from the hashes of the ConfigMaps
+		pipeline.Step{Resource: "StatefulSet", After: []string{"ConfigMap spire-server", "ConfigMap spire-controller-manager"}, Run: func() error {
+			return r.reconcileStatefulSet(ctx, server, statusMgr, ztwim, createOnlyMode, spireServerConfigMapHash, spireControllerManagerConfigMapHash)
+		}},
+		// Verify the rollout of the StatefulSet once complete, if enabled
+		pipeline.Step{Resource: "RolloutVerification", After: []string{"StatefulSet"}, Run: func() error {
+			return r.reconcileRolloutVerification(ctx, server, statusMgr, ztwim, createOnlyMode)
+		}},
+		// Schedule the datastore compaction if configured
+		pipeline.Step{Resource: "DatastoreCompaction", Run: func() error {
+			return r.reconcileDatastoreCompaction(ctx, server, statusMgr, createOnlyMode)
+		}},
+		// reconcile Route if enabled
+		pipeline.Step{Resource: "Route", Run: func() error {
+			return r.reconcileRoute(ctx, server, statusMgr, ztwim, createOnlyMode)
+		}},
+		// Allow the egress to the bundle endpoints of the federation partners if configured
+		pipeline.Step{Resource: "FederationEgress", Run: func() error {
+			return r.reconcileFederationEgress(ctx, server, statusMgr, createOnlyMode)
+		}},
+		// Expose the metrics of the statsd telemetry bridge if enabled
+		pipeline.Step{Resource: "TelemetryBridge", Run: func() error {
+			return r.reconcileTelemetryBridge(ctx, server, statusMgr, createOnlyMode)
+		}},
+		// Reconcile external agent exposure if configured
+		pipeline.Step{Resource: "ExternalAgents", Run: func() error {
+			return r.reconcileExternalAgents(ctx, server, statusMgr, ztwim, createOnlyMode)
+		}},
+		// Alias the agents by the node labels exposed as selectors, if any
+		pipeline.Step{Resource: "NodeAliases", Run: func() error {
+			return r.reconcileNodeAliases(ctx, server, statusMgr, ztwim, createOnlyMode)
+		}},
+		// Check the registration entries against the limits, if any
+		pipeline.Step{Resource: "RegistrationLimits", Run: func() error {
+			return r.reconcileRegistrationLimits(ctx, server, statusMgr)
+		}},
+	)
+
+	// The checks below run the spire-server CLI, call the server or emit Events: a dry run, which
+	// only computes the pending changes of the resources, skips them
+	if utils.IsDryRunRequested(server) {
+		return err
 	}
 
-	return ctrl.Result{}, nil
+	// Check the datastore volume is not filling up
+	r.reconcileDatastoreDiskUsage(ctx, server, statusMgr)
+
+	// Report the health of each server plugin
+	r.reconcilePluginHealth(ctx, server, statusMgr, ztwim)
+
+	// Re-emit the significant entries of the server logs as Events
+	r.reconcileLogEvents(ctx, server)
+
+	// Check the server API answers, and track its outages
+	r.reconcileServerAPIHealth(ctx, server, statusMgr, ztwim, time.Now())
+
+	// Track the rotation and expiry of the webhook serving certificate
+	r.reconcileWebhookCert(ctx, server, statusMgr)
+
+	// Issue, expire or revoke the break-glass admin identity requested by annotation
+	r.reconcileBreakGlass(ctx, server, statusMgr, ztwim)
+
+	// Check the bundle endpoints of the federation partners when requested by annotation
+	r.reconcileFederationConformance(ctx, server, statusMgr)
+
+	// Sample the load of the server and recommend its sizing, if enabled
+	r.reconcileSizingRecommendation(ctx, server, statusMgr, time.Now())
+
+	// Check the agents attest with a token the server accepts
+	r.reportPSATConsistency(ctx, server, statusMgr)
+
+	return err
 }
 
-func (r *SpireServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+// reportPSATConsistency sets PSATAttestationConsistent from the PSAT configuration of the
+// SpireServer and the SpireAgent. It is not reported until the SpireAgent exists.
+func (r *SpireServerReconciler) reportPSATConsistency(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager) {
+	var agent v1alpha2.SpireAgent
+	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: "cluster"}, &agent); err != nil {
+		if !kerrors.IsNotFound(err) {
+			r.log.Error(err, "failed to get SpireAgent for the PSAT consistency check")
+		}
+		return
+	}
+	reason, message, consistent := utils.CheckPSATConsistency(&server.Spec, &agent.Spec)
+	conditionStatus := metav1.ConditionTrue
+	if !consistent {
+		conditionStatus = metav1.ConditionFalse
+	}
+	statusMgr.AddCondition(utils.PSATAttestationConsistentStatusType, reason, message, conditionStatus)
+}
+
+func (r *SpireServerReconciler) SetupWithManager(mgr ctrl.Manager, dependencyCache cache.Cache) error {
 	// Always enqueue the "cluster" CR for reconciliation
 	mapFunc := func(ctx context.Context, _ client.Object) []reconcile.Request {
 		return []reconcile.Request{
@@ -208,10 +455,14 @@ func (r *SpireServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// Use component-specific predicate to only reconcile for control-plane component resources
 	controllerManagedResourcePredicates := builder.WithPredicates(utils.ControllerManagedResourcesForComponent(utils.ComponentControlPlane))
 
-	err := ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.SpireServer{}, builder.WithPredicates(utils.GenerationOrOwnerReferenceChangedPredicate)).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		// Annotation changes are watched so that recording a datastore backup resumes a deferred upgrade,
+		// that a break-glass admin identity is issued or revoked, and that the federation partners are checked
+		For(&v1alpha2.SpireServer{}, builder.WithPredicates(predicate.Or(utils.GenerationOrOwnerReferenceChangedPredicate, predicate.AnnotationChangedPredicate{}))).
 		Named(utils.ZeroTrustWorkloadIdentityManagerSpireServerControllerName).
 		Watches(&appsv1.StatefulSet{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
+		Watches(&batchv1.CronJob{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
+		Watches(&batchv1.Job{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
 		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
 		Watches(&corev1.ServiceAccount{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
 		Watches(&corev1.Service{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
@@ -220,17 +471,48 @@ func (r *SpireServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&rbacv1.Role{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
 		Watches(&rbacv1.RoleBinding{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
 		Watches(&admissionregistrationv1.ValidatingWebhookConfiguration{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
-		Watches(&v1alpha1.ZeroTrustWorkloadIdentityManager{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(utils.ZTWIMSpecChangedPredicate)).
-		Watches(&routev1.Route{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates).
-		Complete(r)
+		Watches(&v1alpha2.ZeroTrustWorkloadIdentityManager{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(utils.ZTWIMSpecChangedPredicate)).
+		// The PSAT configuration of the agents is checked against the server
+		Watches(&v1alpha2.SpireAgent{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// The JWT issuer is checked against the OIDC discovery provider
+		Watches(&v1alpha2.SpireOIDCDiscoveryProvider{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	if utils.HasCapability(utils.CapabilityRoute) {
+		controllerBuilder = controllerBuilder.Watches(&routev1.Route{}, handler.EnqueueRequestsFromMapFunc(mapFunc), controllerManagedResourcePredicates)
+	}
+	if utils.HasCapability(utils.CapabilityInfrastructure) {
+		// Re-evaluate the topology defaults when the cluster changes between single-node and highly available
+		controllerBuilder = controllerBuilder.Watches(&configv1.Infrastructure{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(topology.ChangedPredicate))
+	}
+
+	// Roll the spire server when the Secrets and ConfigMaps it references or its pods mount change
+	err := dependencies.Watch(controllerBuilder, dependencyCache, func(ctx context.Context) []dependencies.Reference {
+		deployed := dependencies.FromWorkload(ctx, r.ctrlClient, &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "spire-server", Namespace: utils.GetOperatorNamespace()}})
+		var server v1alpha2.SpireServer
+		if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: "cluster"}, &server); err != nil {
+			return deployed
+		}
+		return append(spireServerDependencies(&server.Spec), deployed...)
+	}).Complete(r)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// requiredResources returns the resources the server cannot run without
+func requiredResources(ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager) []client.Object {
+	namespace := utils.GetOperatorNamespace()
+	return []client.Object{
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "spire-server", Namespace: namespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "spire-server", Namespace: namespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "spire-controller-manager", Namespace: namespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: ztwim.Spec.BundleConfigMap, Namespace: namespace}},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "spire-server", Namespace: namespace}},
+	}
+}
+
 // handleCreateOnlyMode checks and updates the create-only mode status
-func (r *SpireServerReconciler) handleCreateOnlyMode(server *v1alpha1.SpireServer, statusMgr *status.Manager) bool {
+func (r *SpireServerReconciler) handleCreateOnlyMode(server *v1alpha2.SpireServer, statusMgr *status.Manager) bool {
 	createOnlyMode := utils.IsInCreateOnlyMode()
 	if createOnlyMode {
 		r.log.Info("Running in create-only mode - will create resources if they don't exist but skip updates")
@@ -249,7 +531,7 @@ func (r *SpireServerReconciler) handleCreateOnlyMode(server *v1alpha1.SpireServe
 }
 
 // validateConfiguration validates the SpireServer configuration
-func (r *SpireServerReconciler) validateConfiguration(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager, ztwim *v1alpha1.ZeroTrustWorkloadIdentityManager) error {
+func (r *SpireServerReconciler) validateConfiguration(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager) error {
 	// Validate common configuration (affinity, tolerations, node selector, resources, labels)
 	if err := r.validateCommonConfig(server, statusMgr); err != nil {
 		return err
@@ -269,9 +551,17 @@ func (r *SpireServerReconciler) validateConfiguration(ctx context.Context, serve
 		return err
 	}
 
+	if err := utils.ValidateJWTIssuerAliases(server.Spec.JwtIssuer, server.Spec.JwtIssuerAliases); err != nil {
+		r.log.Error(err, "Invalid JWT issuer aliases in SpireServer configuration")
+		statusMgr.AddCondition(ConfigurationValid, "InvalidJWTIssuerAliases",
+			err.Error(),
+			metav1.ConditionFalse)
+		return err
+	}
+
 	if server.Spec.Federation != nil {
-		if err := validateFederationConfig(server.Spec.Federation, ztwim.Spec.TrustDomain); err != nil {
-			r.log.Error(err, "Invalid federation configuration", "trustDomain", ztwim.Spec.TrustDomain)
+		if err := validateFederationConfig(server.Spec.Federation, utils.TrustDomain(ztwim)); err != nil {
+			r.log.Error(err, "Invalid federation configuration", "trustDomain", utils.TrustDomain(ztwim))
 			statusMgr.AddCondition(ConfigurationValid, "InvalidFederationConfiguration",
 				fmt.Sprintf("Federation configuration validation failed: %v", err),
 				metav1.ConditionFalse)
@@ -279,18 +569,62 @@ func (r *SpireServerReconciler) validateConfiguration(ctx context.Context, serve
 		}
 	}
 
+	if err := validateAdminIDs(server.Spec.AdminIDs, utils.TrustDomain(ztwim), server.Spec.Federation); err != nil {
+		r.log.Error(err, "Invalid admin IDs")
+		statusMgr.AddCondition(ConfigurationValid, "InvalidAdminIDs", err.Error(), metav1.ConditionFalse)
+		return err
+	}
+
+	if err := validateHostAliases(server.Spec.HostAliases); err != nil {
+		r.log.Error(err, "Invalid host aliases")
+		statusMgr.AddCondition(ConfigurationValid, "InvalidHostAliases", err.Error(), metav1.ConditionFalse)
+		return err
+	}
+
+	if err := validateControllerManagerNamespaces(server.Spec.ControllerManagerNamespaces); err != nil {
+		r.log.Error(err, "Invalid controller manager namespaces")
+		statusMgr.AddCondition(ConfigurationValid, "InvalidControllerManagerNamespaces", err.Error(), metav1.ConditionFalse)
+		return err
+	}
+
+	if err := validateExternalPlugins(server.Spec.ExternalPlugins); err != nil {
+		r.log.Error(err, "Invalid external plugins")
+		statusMgr.AddCondition(ConfigurationValid, "InvalidExternalPlugins", err.Error(), metav1.ConditionFalse)
+		return err
+	}
+
+	if err := validateDatastoreCompaction(server); err != nil {
+		r.log.Error(err, "Invalid datastore compaction")
+		statusMgr.AddCondition(ConfigurationValid, "InvalidDatastoreCompaction", err.Error(), metav1.ConditionFalse)
+		return err
+	}
+
+	if err := utils.ValidateExperimentalFlags(server.Spec.ExperimentalFlags, utils.SpireServerExperimentalFlags); err != nil {
+		r.log.Error(err, "Invalid experimental flags")
+		statusMgr.AddCondition(ConfigurationValid, "InvalidExperimentalFlags", err.Error(), metav1.ConditionFalse)
+		return err
+	}
+	statusMgr.ReportExperimentalFlags(server.Spec.ExperimentalFlags, server.Status.Conditions)
+
 	// Only set to true if the condition previously existed as false
 	existingCondition := apimeta.FindStatusCondition(server.Status.ConditionalStatus.Conditions, ConfigurationValid)
 	if existingCondition != nil && existingCondition.Status == metav1.ConditionFalse {
-		statusMgr.AddCondition(ConfigurationValid, v1alpha1.ReasonReady,
+		statusMgr.AddCondition(ConfigurationValid, v1alpha2.ReasonReady,
 			"Configuration validation passed",
 			metav1.ConditionTrue)
 	}
 	return nil
 }
 
-// validateCommonConfig validates common configuration fields (affinity, tolerations, nodeSelector, resources, labels)
-func (r *SpireServerReconciler) validateCommonConfig(server *v1alpha1.SpireServer, statusMgr *status.Manager) error {
+// validateCommonConfig validates common configuration fields (DNS, affinity, tolerations, nodeSelector, resources, labels)
+func (r *SpireServerReconciler) validateCommonConfig(server *v1alpha2.SpireServer, statusMgr *status.Manager) error {
+	if err := utils.ValidateCommonConfigDNS(server.Spec.DNSPolicy, server.Spec.DNSConfig); err != nil {
+		r.log.Error(err, "invalid DNS configuration")
+		statusMgr.AddCondition(utils.ConditionTypeConfigurationValid, utils.ConditionReasonInvalidDNSConfig,
+			fmt.Sprintf("DNS configuration validation failed: %v", err), metav1.ConditionFalse)
+		return err
+	}
+
 	return utils.ValidateAndUpdateStatus(
 		r.log,
 		statusMgr,
@@ -308,7 +642,7 @@ func (r *SpireServerReconciler) validateCommonConfig(server *v1alpha1.SpireServe
 func (r *SpireServerReconciler) validateProxyConfiguration(statusMgr *status.Manager) error {
 	result := utils.ValidateProxyConfiguration()
 	if !result.Valid {
-		r.log.Error(errors.New(result.Reason), result.Message)
+		r.log.Error(errors.New(result.Reason), "invalid proxy configuration", "message", result.Message)
 		statusMgr.AddCondition(ConfigurationValid, result.Reason, result.Message, metav1.ConditionFalse)
 		return fmt.Errorf("proxy configuration invalid: %s", result.Message)
 	}
@@ -321,12 +655,14 @@ func needsUpdate(current, desired appsv1.StatefulSet) bool {
 		return true
 	} else if current.Spec.Template.Annotations[spireServerStatefulSetSpireControllerManagerConfigHashAnnotationKey] != desired.Spec.Template.Annotations[spireServerStatefulSetSpireControllerManagerConfigHashAnnotationKey] {
 		return true
+	} else if current.Spec.Template.Annotations[utils.DependenciesHashAnnotationKey] != desired.Spec.Template.Annotations[utils.DependenciesHashAnnotationKey] {
+		return true
 	}
 	return utils.ResourceNeedsUpdate(&current, &desired)
 }
 
 // handleTTLValidation performs TTL validation and handles warnings, events, and status updates
-func (r *SpireServerReconciler) handleTTLValidation(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager) error {
+func (r *SpireServerReconciler) handleTTLValidation(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager) error {
 	ttlValidationResult := validateTTLDurationsWithWarnings(&server.Spec)
 
 	if ttlValidationResult.Error != nil {
@@ -346,7 +682,7 @@ func (r *SpireServerReconciler) handleTTLValidation(ctx context.Context, server
 
 		// Record events for each warning
 		for _, warning := range ttlValidationResult.Warnings {
-			r.eventRecorder.Event(server, corev1.EventTypeWarning, "TTLConfigurationWarning", warning)
+			r.eventRecorder.Event(server, corev1.EventTypeWarning, "TTLConfigurationWarning", utils.WithRunbook("TTLConfigurationWarning", warning))
 		}
 
 		// Set status condition with warning
@@ -0,0 +1,70 @@
+package spire_server
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	spiffev1alpha "github.com/spiffe/spire-controller-manager/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// defaultIgnoredNamespaces are the namespaces the spire-controller-manager never watches
+var defaultIgnoredNamespaces = []string{
+	"kube-system",
+	"kube-public",
+	"local-path-storage",
+	"openshift-*",
+}
+
+// validateControllerManagerNamespaces checks the watched namespaces are namespace names, and the
+// ignored ones are regular expressions which leave the operator namespace watched
+func validateControllerManagerNamespaces(namespaces *v1alpha2.ControllerManagerNamespaces) error {
+	if namespaces == nil {
+		return nil
+	}
+	for i, namespace := range namespaces.Watch {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return fmt.Errorf("controllerManagerNamespaces.watch[%d]: invalid namespace %q: %s", i, namespace, strings.Join(errs, "; "))
+		}
+	}
+	operatorNamespace := utils.GetOperatorNamespace()
+	for i, pattern := range namespaces.Ignore {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("controllerManagerNamespaces.ignore[%d]: invalid regular expression %q: %w", i, pattern, err)
+		}
+		if operatorNamespace != "" && re.MatchString(operatorNamespace) {
+			return fmt.Errorf("controllerManagerNamespaces.ignore[%d]: %q matches the operator namespace %s, whose operands get their identities from ClusterSPIFFEIDs", i, pattern, operatorNamespace)
+		}
+	}
+	return nil
+}
+
+// controllerManagerIgnoredNamespaces returns the namespaces ignored by the spire-controller-manager
+func controllerManagerIgnoredNamespaces(namespaces *v1alpha2.ControllerManagerNamespaces) []string {
+	ignored := append([]string{}, defaultIgnoredNamespaces...)
+	if namespaces != nil {
+		ignored = append(ignored, namespaces.Ignore...)
+	}
+	return ignored
+}
+
+// controllerManagerCacheNamespaces returns the namespaces the cache of the spire-controller-manager
+// is restricted to along with the operator namespace, none when all the namespaces are watched
+func controllerManagerCacheNamespaces(namespaces *v1alpha2.ControllerManagerNamespaces) map[string]*spiffev1alpha.NamespaceConfig {
+	if namespaces == nil || len(namespaces.Watch) == 0 {
+		return nil
+	}
+	cacheNamespaces := map[string]*spiffev1alpha.NamespaceConfig{}
+	for _, namespace := range namespaces.Watch {
+		cacheNamespaces[namespace] = &spiffev1alpha.NamespaceConfig{}
+	}
+	if operatorNamespace := utils.GetOperatorNamespace(); operatorNamespace != "" {
+		cacheNamespaces[operatorNamespace] = &spiffev1alpha.NamespaceConfig{}
+	}
+	return cacheNamespaces
+}
@@ -0,0 +1,83 @@
+package spire_server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+func TestValidateControllerManagerNamespaces(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "zero-trust-workload-identity-manager")
+
+	tests := []struct {
+		name       string
+		namespaces *v1alpha2.ControllerManagerNamespaces
+		expectErr  bool
+	}{
+		{name: "none"},
+		{name: "valid", namespaces: &v1alpha2.ControllerManagerNamespaces{Watch: []string{"payments", "checkout"}, Ignore: []string{"^sandbox-.*$"}}},
+		{name: "invalid namespace", namespaces: &v1alpha2.ControllerManagerNamespaces{Watch: []string{"Payments"}}, expectErr: true},
+		{name: "invalid regular expression", namespaces: &v1alpha2.ControllerManagerNamespaces{Ignore: []string{"sandbox-("}}, expectErr: true},
+		{name: "ignores the operator namespace", namespaces: &v1alpha2.ControllerManagerNamespaces{Ignore: []string{"^zero-trust-.*"}}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateControllerManagerNamespaces(tt.namespaces)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestControllerManagerConfigNamespaces(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "zero-trust-workload-identity-manager")
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.org", ClusterName: "test-cluster"},
+	}
+
+	t.Run("all namespaces", func(t *testing.T) {
+		config, err := generateControllerManagerConfig(&v1alpha2.SpireServerSpec{}, ztwim)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if config.CacheNamespaces != nil {
+			t.Errorf("Expected the cache not to be restricted, got %v", config.CacheNamespaces)
+		}
+		if strings.Join(config.IgnoreNamespaces, ",") != strings.Join(defaultIgnoredNamespaces, ",") {
+			t.Errorf("Expected the default ignored namespaces, got %v", config.IgnoreNamespaces)
+		}
+	})
+
+	t.Run("restricted", func(t *testing.T) {
+		spec := &v1alpha2.SpireServerSpec{ControllerManagerNamespaces: &v1alpha2.ControllerManagerNamespaces{
+			Watch:  []string{"payments", "checkout"},
+			Ignore: []string{"^sandbox-.*$"},
+		}}
+		config, err := generateControllerManagerConfig(spec, ztwim)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, namespace := range []string{"payments", "checkout", "zero-trust-workload-identity-manager"} {
+			if _, ok := config.CacheNamespaces[namespace]; !ok {
+				t.Errorf("Expected namespace %s to be watched, got %v", namespace, config.CacheNamespaces)
+			}
+		}
+		if len(config.CacheNamespaces) != 3 {
+			t.Errorf("Expected 3 watched namespaces, got %v", config.CacheNamespaces)
+		}
+		if last := config.IgnoreNamespaces[len(config.IgnoreNamespaces)-1]; len(config.IgnoreNamespaces) != len(defaultIgnoredNamespaces)+1 || last != "^sandbox-.*$" {
+			t.Errorf("Expected the ignored namespaces to extend the defaults, got %v", config.IgnoreNamespaces)
+		}
+
+		yamlStr, err := generateSpireControllerManagerConfigYaml(spec, ztwim)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !strings.Contains(yamlStr, "cacheNamespaces:") || !strings.Contains(yamlStr, "payments: {}") {
+			t.Errorf("Expected the watched namespaces in the config, got:\n%s", yamlStr)
+		}
+	})
+}
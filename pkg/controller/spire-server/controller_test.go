@@ -7,7 +7,7 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	appsv1 "k8s.io/api/apps/v1"
@@ -86,7 +86,7 @@ func TestReconcile_ZTWIMNotFound(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	spireServer := &v1alpha1.SpireServer{
+	spireServer := &v1alpha2.SpireServer{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 		},
@@ -97,7 +97,7 @@ func TestReconcile_ZTWIMNotFound(t *testing.T) {
 		callCount++
 		switch callCount {
 		case 1: // First call: Get SpireServer
-			if ss, ok := obj.(*v1alpha1.SpireServer); ok {
+			if ss, ok := obj.(*v1alpha2.SpireServer); ok {
 				*ss = *spireServer
 			}
 			return nil
@@ -125,7 +125,7 @@ func TestReconcile_ZTWIMGetError(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	spireServer := &v1alpha1.SpireServer{
+	spireServer := &v1alpha2.SpireServer{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 		},
@@ -137,7 +137,7 @@ func TestReconcile_ZTWIMGetError(t *testing.T) {
 		callCount++
 		switch callCount {
 		case 1: // First call: Get SpireServer
-			if ss, ok := obj.(*v1alpha1.SpireServer); ok {
+			if ss, ok := obj.(*v1alpha2.SpireServer); ok {
 				*ss = *spireServer
 			}
 			return nil
@@ -170,18 +170,18 @@ func TestReconcile_OwnerReferenceUpdateError(t *testing.T) {
 
 	// Register types in scheme for SetControllerReference
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	reconciler.scheme = scheme
 
 	// SpireServer without owner reference (needs update)
-	spireServer := &v1alpha1.SpireServer{
+	spireServer := &v1alpha2.SpireServer{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 		},
 	}
 
 	// ZTWIM with proper metadata
-	ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
@@ -193,12 +193,12 @@ func TestReconcile_OwnerReferenceUpdateError(t *testing.T) {
 		callCount++
 		switch callCount {
 		case 1: // Get SpireServer
-			if ss, ok := obj.(*v1alpha1.SpireServer); ok {
+			if ss, ok := obj.(*v1alpha2.SpireServer); ok {
 				*ss = *spireServer
 			}
 			return nil
 		case 2: // Get ZTWIM
-			if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+			if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 				*z = *ztwim
 			}
 			return nil
@@ -228,7 +228,7 @@ func TestHandleCreateOnlyMode_Enabled(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	server := &v1alpha1.SpireServer{
+	server := &v1alpha2.SpireServer{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
 	}
 
@@ -249,7 +249,7 @@ func TestHandleCreateOnlyMode_Disabled(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	server := &v1alpha1.SpireServer{
+	server := &v1alpha2.SpireServer{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
 	}
 
@@ -271,10 +271,10 @@ func TestHandleCreateOnlyMode_DisabledWithPreviouslyEnabled(t *testing.T) {
 	reconciler := newTestReconciler(fakeClient)
 
 	// Server with existing CreateOnlyMode condition set to True
-	server := &v1alpha1.SpireServer{
+	server := &v1alpha2.SpireServer{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Status: v1alpha1.SpireServerStatus{
-			ConditionalStatus: v1alpha1.ConditionalStatus{
+		Status: v1alpha2.SpireServerStatus{
+			ConditionalStatus: v1alpha2.ConditionalStatus{
 				Conditions: []metav1.Condition{
 					{
 						Type:   "CreateOnlyMode",
@@ -364,16 +364,16 @@ func TestValidateConfiguration_ValidConfig(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	server := &v1alpha1.SpireServer{
+	server := &v1alpha2.SpireServer{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec: v1alpha1.SpireServerSpec{
+		Spec: v1alpha2.SpireServerSpec{
 			JwtIssuer: "https://example.com",
 		},
 	}
 
-	ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 			TrustDomain: "example.com",
 		},
 	}
@@ -392,16 +392,16 @@ func TestValidateConfiguration_InvalidJWTIssuer(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	server := &v1alpha1.SpireServer{
+	server := &v1alpha2.SpireServer{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec: v1alpha1.SpireServerSpec{
+		Spec: v1alpha2.SpireServerSpec{
 			JwtIssuer: "not-a-valid-url",
 		},
 	}
 
-	ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 			TrustDomain: "example.com",
 		},
 	}
@@ -420,9 +420,9 @@ func TestHandleTTLValidation_ValidTTL(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	server := &v1alpha1.SpireServer{
+	server := &v1alpha2.SpireServer{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec: v1alpha1.SpireServerSpec{
+		Spec: v1alpha2.SpireServerSpec{
 			// Provide valid TTL values - CA validity must be > X509 and JWT validity
 			CAValidity:          metav1.Duration{Duration: 24 * time.Hour},
 			DefaultX509Validity: metav1.Duration{Duration: 1 * time.Hour},
@@ -460,9 +460,9 @@ func TestHandleTTLValidation_InvalidCAValidity(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	server := &v1alpha1.SpireServer{
+	server := &v1alpha2.SpireServer{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec: v1alpha1.SpireServerSpec{
+		Spec: v1alpha2.SpireServerSpec{
 			// Zero/invalid CA validity
 			CAValidity:          metav1.Duration{Duration: 0},
 			DefaultX509Validity: metav1.Duration{Duration: 1 * time.Hour},
@@ -484,9 +484,9 @@ func TestHandleTTLValidation_CAValiditySmallerThanSVID(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	server := &v1alpha1.SpireServer{
+	server := &v1alpha2.SpireServer{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec: v1alpha1.SpireServerSpec{
+		Spec: v1alpha2.SpireServerSpec{
 			// CA validity smaller than X509 validity - invalid
 			CAValidity:          metav1.Duration{Duration: 30 * time.Minute},
 			DefaultX509Validity: metav1.Duration{Duration: 1 * time.Hour},
@@ -508,9 +508,9 @@ func TestValidateCommonConfig_Valid(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	server := &v1alpha1.SpireServer{
+	server := &v1alpha2.SpireServer{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec:       v1alpha1.SpireServerSpec{},
+		Spec:       v1alpha2.SpireServerSpec{},
 	}
 
 	statusMgr := status.NewManager(fakeClient)
@@ -526,10 +526,10 @@ func TestValidateCommonConfig_InvalidAffinity(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	server := &v1alpha1.SpireServer{
+	server := &v1alpha2.SpireServer{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec: v1alpha1.SpireServerSpec{
-			CommonConfig: v1alpha1.CommonConfig{
+		Spec: v1alpha2.SpireServerSpec{
+			CommonConfig: v1alpha2.CommonConfig{
 				Affinity: &corev1.Affinity{
 					NodeAffinity: &corev1.NodeAffinity{
 						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
@@ -670,7 +670,7 @@ func TestHandleCreateOnlyMode_NotSet(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	server := &v1alpha1.SpireServer{
+	server := &v1alpha2.SpireServer{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
 	}
 
@@ -745,22 +745,22 @@ func TestValidateConfiguration_WithFederation(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	reconciler := newTestReconciler(fakeClient)
 
-	server := &v1alpha1.SpireServer{
+	server := &v1alpha2.SpireServer{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec: v1alpha1.SpireServerSpec{
+		Spec: v1alpha2.SpireServerSpec{
 			JwtIssuer: "https://example.com",
-			Federation: &v1alpha1.FederationConfig{
-				BundleEndpoint: v1alpha1.BundleEndpointConfig{
-					Profile:     v1alpha1.HttpsSpiffeProfile,
+			Federation: &v1alpha2.FederationConfig{
+				BundleEndpoint: v1alpha2.BundleEndpointConfig{
+					Profile:     v1alpha2.HttpsSpiffeProfile,
 					RefreshHint: 300,
 				},
 			},
 		},
 	}
 
-	ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 			TrustDomain: "example.com",
 		},
 	}
@@ -779,7 +779,7 @@ func TestReconcile_FullFlow(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &SpireServerReconciler{
 		ctrlClient:    fakeClient,
@@ -789,7 +789,7 @@ func TestReconcile_FullFlow(t *testing.T) {
 		eventRecorder: record.NewFakeRecorder(100),
 	}
 
-	spireServer := &v1alpha1.SpireServer{
+	spireServer := &v1alpha2.SpireServer{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			OwnerReferences: []metav1.OwnerReference{
@@ -801,7 +801,7 @@ func TestReconcile_FullFlow(t *testing.T) {
 				},
 			},
 		},
-		Spec: v1alpha1.SpireServerSpec{
+		Spec: v1alpha2.SpireServerSpec{
 			JwtIssuer:           "https://example.com",
 			CAValidity:          metav1.Duration{Duration: 24 * time.Hour},
 			DefaultX509Validity: metav1.Duration{Duration: 1 * time.Hour},
@@ -809,22 +809,22 @@ func TestReconcile_FullFlow(t *testing.T) {
 		},
 	}
 
-	ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
 		},
-		Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 			TrustDomain: "example.org",
 		},
 	}
 
 	fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 		switch v := obj.(type) {
-		case *v1alpha1.SpireServer:
+		case *v1alpha2.SpireServer:
 			*v = *spireServer
 			return nil
-		case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+		case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 			*v = *ztwim
 			return nil
 		default:
@@ -879,7 +879,7 @@ func TestReconcile_ErrorScenarios(t *testing.T) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					callCount++
 					if callCount == 1 {
-						if ss, ok := obj.(*v1alpha1.SpireServer); ok {
+						if ss, ok := obj.(*v1alpha2.SpireServer); ok {
 							ss.Name = "cluster"
 						}
 						return nil
@@ -897,7 +897,7 @@ func TestReconcile_ErrorScenarios(t *testing.T) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					callCount++
 					if callCount == 1 {
-						if ss, ok := obj.(*v1alpha1.SpireServer); ok {
+						if ss, ok := obj.(*v1alpha2.SpireServer); ok {
 							ss.Name = "cluster"
 						}
 						return nil
@@ -916,12 +916,12 @@ func TestReconcile_ErrorScenarios(t *testing.T) {
 					callCount++
 					switch callCount {
 					case 1:
-						if ss, ok := obj.(*v1alpha1.SpireServer); ok {
+						if ss, ok := obj.(*v1alpha2.SpireServer); ok {
 							ss.Name = "cluster"
 						}
 						return nil
 					case 2:
-						if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+						if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 							z.Name = "cluster"
 							z.UID = "test-uid"
 						}
@@ -933,7 +933,7 @@ func TestReconcile_ErrorScenarios(t *testing.T) {
 			},
 			setupReconciler: func(r *SpireServerReconciler) {
 				scheme := runtime.NewScheme()
-				_ = v1alpha1.AddToScheme(scheme)
+				_ = v1alpha2.AddToScheme(scheme)
 				r.scheme = scheme
 			},
 			expectError:   true,
@@ -1058,7 +1058,7 @@ func TestHandleCreateOnlyMode_AllScenarios(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			reconciler := newTestReconciler(fakeClient)
 
-			server := &v1alpha1.SpireServer{
+			server := &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
 			}
 			if tt.existingCond != nil {
@@ -1173,45 +1173,45 @@ func TestNeedsUpdate_AllScenarios(t *testing.T) {
 func TestValidateConfiguration_AllScenarios(t *testing.T) {
 	tests := []struct {
 		name        string
-		server      *v1alpha1.SpireServer
-		ztwim       *v1alpha1.ZeroTrustWorkloadIdentityManager
+		server      *v1alpha2.SpireServer
+		ztwim       *v1alpha2.ZeroTrustWorkloadIdentityManager
 		expectError bool
 	}{
 		{
 			name: "valid config with valid JWT issuer",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec: v1alpha1.SpireServerSpec{
+				Spec: v1alpha2.SpireServerSpec{
 					JwtIssuer: "https://example.com",
 				},
 			},
-			ztwim: &v1alpha1.ZeroTrustWorkloadIdentityManager{
+			ztwim: &v1alpha2.ZeroTrustWorkloadIdentityManager{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec:       v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.com"},
+				Spec:       v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.com"},
 			},
 			expectError: false,
 		},
 		{
 			name: "invalid JWT issuer URL",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec: v1alpha1.SpireServerSpec{
+				Spec: v1alpha2.SpireServerSpec{
 					JwtIssuer: "not-a-valid-url",
 				},
 			},
-			ztwim: &v1alpha1.ZeroTrustWorkloadIdentityManager{
+			ztwim: &v1alpha2.ZeroTrustWorkloadIdentityManager{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec:       v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.com"},
+				Spec:       v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.com"},
 			},
 			expectError: true,
 		},
 		{
 			name: "invalid affinity with empty node selector terms",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec: v1alpha1.SpireServerSpec{
+				Spec: v1alpha2.SpireServerSpec{
 					JwtIssuer: "https://example.com",
-					CommonConfig: v1alpha1.CommonConfig{
+					CommonConfig: v1alpha2.CommonConfig{
 						Affinity: &corev1.Affinity{
 							NodeAffinity: &corev1.NodeAffinity{
 								RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
@@ -1222,114 +1222,114 @@ func TestValidateConfiguration_AllScenarios(t *testing.T) {
 					},
 				},
 			},
-			ztwim: &v1alpha1.ZeroTrustWorkloadIdentityManager{
+			ztwim: &v1alpha2.ZeroTrustWorkloadIdentityManager{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec:       v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.com"},
+				Spec:       v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.com"},
 			},
 			expectError: true,
 		},
 		{
 			name: "valid config with federation",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec: v1alpha1.SpireServerSpec{
+				Spec: v1alpha2.SpireServerSpec{
 					JwtIssuer: "https://example.com",
-					Federation: &v1alpha1.FederationConfig{
-						BundleEndpoint: v1alpha1.BundleEndpointConfig{
-							Profile:     v1alpha1.HttpsSpiffeProfile,
+					Federation: &v1alpha2.FederationConfig{
+						BundleEndpoint: v1alpha2.BundleEndpointConfig{
+							Profile:     v1alpha2.HttpsSpiffeProfile,
 							RefreshHint: 300,
 						},
 					},
 				},
 			},
-			ztwim: &v1alpha1.ZeroTrustWorkloadIdentityManager{
+			ztwim: &v1alpha2.ZeroTrustWorkloadIdentityManager{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec:       v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.com"},
+				Spec:       v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.com"},
 			},
 			expectError: false,
 		},
 		{
 			name: "empty JWT issuer returns error",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec: v1alpha1.SpireServerSpec{
+				Spec: v1alpha2.SpireServerSpec{
 					JwtIssuer: "",
 				},
 			},
-			ztwim: &v1alpha1.ZeroTrustWorkloadIdentityManager{
+			ztwim: &v1alpha2.ZeroTrustWorkloadIdentityManager{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec:       v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.com"},
+				Spec:       v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.com"},
 			},
 			expectError: true,
 		},
 		{
 			name: "URL with query parameters returns error",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec: v1alpha1.SpireServerSpec{
+				Spec: v1alpha2.SpireServerSpec{
 					JwtIssuer: "https://example.com?query=value",
 				},
 			},
-			ztwim: &v1alpha1.ZeroTrustWorkloadIdentityManager{
+			ztwim: &v1alpha2.ZeroTrustWorkloadIdentityManager{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec:       v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.com"},
+				Spec:       v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.com"},
 			},
 			expectError: true,
 		},
 		{
 			name: "JWT issuer with http scheme is valid",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec: v1alpha1.SpireServerSpec{
+				Spec: v1alpha2.SpireServerSpec{
 					JwtIssuer: "http://example.com",
 				},
 			},
-			ztwim: &v1alpha1.ZeroTrustWorkloadIdentityManager{
+			ztwim: &v1alpha2.ZeroTrustWorkloadIdentityManager{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec:       v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.com"},
+				Spec:       v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.com"},
 			},
 			expectError: false,
 		},
 		{
 			name: "nil federation config is valid",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec: v1alpha1.SpireServerSpec{
+				Spec: v1alpha2.SpireServerSpec{
 					JwtIssuer:  "https://example.com",
 					Federation: nil,
 				},
 			},
-			ztwim: &v1alpha1.ZeroTrustWorkloadIdentityManager{
+			ztwim: &v1alpha2.ZeroTrustWorkloadIdentityManager{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec:       v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.com"},
+				Spec:       v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.com"},
 			},
 			expectError: false,
 		},
 		{
 			name: "invalid federation config with self-federation",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec: v1alpha1.SpireServerSpec{
+				Spec: v1alpha2.SpireServerSpec{
 					JwtIssuer: "https://example.com",
-					Federation: &v1alpha1.FederationConfig{
-						BundleEndpoint: v1alpha1.BundleEndpointConfig{
-							Profile:     v1alpha1.HttpsSpiffeProfile,
+					Federation: &v1alpha2.FederationConfig{
+						BundleEndpoint: v1alpha2.BundleEndpointConfig{
+							Profile:     v1alpha2.HttpsSpiffeProfile,
 							RefreshHint: 300,
 						},
-						FederatesWith: []v1alpha1.FederatesWithConfig{
+						FederatesWith: []v1alpha2.FederatesWithConfig{
 							{
 								TrustDomain:           "example.com",
 								BundleEndpointUrl:     "https://example.com:8443",
-								BundleEndpointProfile: v1alpha1.HttpsSpiffeProfile,
+								BundleEndpointProfile: v1alpha2.HttpsSpiffeProfile,
 								EndpointSpiffeId:      "spiffe://example.com/spire/server",
 							},
 						},
 					},
 				},
 			},
-			ztwim: &v1alpha1.ZeroTrustWorkloadIdentityManager{
+			ztwim: &v1alpha2.ZeroTrustWorkloadIdentityManager{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec:       v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.com"},
+				Spec:       v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.com"},
 			},
 			expectError: true,
 		},
@@ -1387,9 +1387,9 @@ func TestValidateConfiguration_ConditionUpdate(t *testing.T) {
 			reconciler := newTestReconciler(fakeClient)
 			statusMgr := status.NewManager(fakeClient)
 
-			server := &v1alpha1.SpireServer{
+			server := &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec: v1alpha1.SpireServerSpec{
+				Spec: v1alpha2.SpireServerSpec{
 					JwtIssuer: "https://example.com",
 				},
 			}
@@ -1404,9 +1404,9 @@ func TestValidateConfiguration_ConditionUpdate(t *testing.T) {
 				}
 			}
 
-			ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
+			ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec:       v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.com"},
+				Spec:       v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.com"},
 			}
 
 			err := reconciler.validateConfiguration(context.Background(), server, statusMgr, ztwim)
@@ -1483,9 +1483,9 @@ func TestHandleTTLValidation_AllScenarios(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			reconciler := newTestReconciler(fakeClient)
 
-			server := &v1alpha1.SpireServer{
+			server := &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec: v1alpha1.SpireServerSpec{
+				Spec: v1alpha2.SpireServerSpec{
 					CAValidity:          metav1.Duration{Duration: tt.caValidity},
 					DefaultX509Validity: metav1.Duration{Duration: tt.x509Validity},
 					DefaultJWTValidity:  metav1.Duration{Duration: tt.jwtValidity},
@@ -1552,7 +1552,7 @@ func TestReconcileSpireServerConfigMap_ErrorScenarios(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 			_ = corev1.AddToScheme(scheme)
 
 			reconciler := &SpireServerReconciler{
@@ -1563,12 +1563,12 @@ func TestReconcileSpireServerConfigMap_ErrorScenarios(t *testing.T) {
 				eventRecorder: record.NewFakeRecorder(100),
 			}
 
-			server := &v1alpha1.SpireServer{
+			server := &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "cluster",
 					UID:  "test-uid",
 				},
-				Spec: v1alpha1.SpireServerSpec{
+				Spec: v1alpha2.SpireServerSpec{
 					JwtIssuer:           "https://example.com",
 					CAValidity:          metav1.Duration{Duration: 24 * time.Hour},
 					DefaultX509Validity: metav1.Duration{Duration: 1 * time.Hour},
@@ -1576,9 +1576,9 @@ func TestReconcileSpireServerConfigMap_ErrorScenarios(t *testing.T) {
 				},
 			}
 
-			ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
+			ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+				Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 					TrustDomain: "example.org",
 				},
 			}
@@ -1637,7 +1637,7 @@ func TestReconcileRBAC_AllScenarios(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 
 			reconciler := &SpireServerReconciler{
 				ctrlClient:    fakeClient,
@@ -1647,7 +1647,7 @@ func TestReconcileRBAC_AllScenarios(t *testing.T) {
 				eventRecorder: record.NewFakeRecorder(100),
 			}
 
-			server := &v1alpha1.SpireServer{
+			server := &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "cluster",
 					UID:  "test-uid",
@@ -1669,7 +1669,7 @@ func TestReconcileRBAC_AllScenarios(t *testing.T) {
 			}
 
 			statusMgr := status.NewManager(fakeClient)
-			err := reconciler.reconcileRBAC(context.Background(), server, statusMgr, tt.createOnlyMode)
+			err := reconciler.reconcileRBAC(context.Background(), server, statusMgr, &v1alpha2.ZeroTrustWorkloadIdentityManager{}, tt.createOnlyMode)
 
 			if tt.expectError && err == nil {
 				t.Fatal("Expected error but got nil")
@@ -1699,7 +1699,7 @@ func TestReconcileBundleConfigMap_ErrorScenarios(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 			_ = corev1.AddToScheme(scheme)
 
 			reconciler := &SpireServerReconciler{
@@ -1710,19 +1710,19 @@ func TestReconcileBundleConfigMap_ErrorScenarios(t *testing.T) {
 				eventRecorder: record.NewFakeRecorder(100),
 			}
 
-			server := &v1alpha1.SpireServer{
+			server := &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "cluster",
 					UID:  "test-uid",
 				},
-				Spec: v1alpha1.SpireServerSpec{
+				Spec: v1alpha2.SpireServerSpec{
 					JwtIssuer: "https://example.com",
 				},
 			}
 
-			ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
+			ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-				Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+				Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 					TrustDomain: "example.org",
 				},
 			}
@@ -1762,7 +1762,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 					callCount++
 					switch callCount {
 					case 1:
-						if s, ok := obj.(*v1alpha1.SpireServer); ok {
+						if s, ok := obj.(*v1alpha2.SpireServer); ok {
 							s.Name = "cluster"
 							s.Spec.JwtIssuer = "https://example.com"
 							s.Spec.CAValidity = metav1.Duration{Duration: 24 * time.Hour}
@@ -1777,7 +1777,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 						}
 						return nil
 					case 2:
-						if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+						if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 							z.Name = "cluster"
 							z.UID = "test-uid"
 							z.Spec.TrustDomain = "example.org"
@@ -1798,7 +1798,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 					callCount++
 					switch callCount {
 					case 1:
-						if s, ok := obj.(*v1alpha1.SpireServer); ok {
+						if s, ok := obj.(*v1alpha2.SpireServer); ok {
 							s.Name = "cluster"
 							s.Spec.JwtIssuer = "https://example.com"
 							s.Spec.CAValidity = metav1.Duration{Duration: 24 * time.Hour}
@@ -1813,7 +1813,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 						}
 						return nil
 					case 2:
-						if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+						if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 							z.Name = "cluster"
 							z.UID = "test-uid"
 							z.Spec.TrustDomain = "example.org"
@@ -1836,7 +1836,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 					callCount++
 					switch callCount {
 					case 1:
-						if s, ok := obj.(*v1alpha1.SpireServer); ok {
+						if s, ok := obj.(*v1alpha2.SpireServer); ok {
 							s.Name = "cluster"
 							s.Spec.JwtIssuer = "https://example.com"
 							s.Spec.CAValidity = metav1.Duration{Duration: 24 * time.Hour}
@@ -1851,7 +1851,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 						}
 						return nil
 					case 2:
-						if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+						if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 							z.Name = "cluster"
 							z.UID = "test-uid"
 							z.Spec.TrustDomain = "example.org"
@@ -1874,7 +1874,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 					callCount++
 					switch callCount {
 					case 1:
-						if s, ok := obj.(*v1alpha1.SpireServer); ok {
+						if s, ok := obj.(*v1alpha2.SpireServer); ok {
 							s.Name = "cluster"
 							s.Spec.JwtIssuer = "https://example.com"
 							s.Spec.CAValidity = metav1.Duration{Duration: 24 * time.Hour}
@@ -1889,7 +1889,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 						}
 						return nil
 					case 2:
-						if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+						if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 							z.Name = "cluster"
 							z.UID = "test-uid"
 							z.Spec.TrustDomain = "example.org"
@@ -1910,7 +1910,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 
 			reconciler := &SpireServerReconciler{
 				ctrlClient:    fakeClient,
@@ -1952,7 +1952,7 @@ func TestReconcile_ErrorPropagation(t *testing.T) {
 func TestReconcile_SuccessfulPath_NoRequeue(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 
 	reconciler := &SpireServerReconciler{
@@ -1968,7 +1968,7 @@ func TestReconcile_SuccessfulPath_NoRequeue(t *testing.T) {
 		callCount++
 		switch callCount {
 		case 1: // SpireServer
-			if s, ok := obj.(*v1alpha1.SpireServer); ok {
+			if s, ok := obj.(*v1alpha2.SpireServer); ok {
 				s.Name = "cluster"
 				s.UID = "test-uid"
 				s.Spec.JwtIssuer = "https://example.com"
@@ -1984,7 +1984,7 @@ func TestReconcile_SuccessfulPath_NoRequeue(t *testing.T) {
 			}
 			return nil
 		case 2: // ZTWIM
-			if z, ok := obj.(*v1alpha1.ZeroTrustWorkloadIdentityManager); ok {
+			if z, ok := obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager); ok {
 				z.Name = "cluster"
 				z.UID = "ztwim-uid"
 				z.Spec.TrustDomain = "example.org"
@@ -2101,9 +2101,9 @@ func TestHandleTTLValidation_Warnings_MutationKiller(t *testing.T) {
 
 	// Configure TTL values that generate warnings but not errors
 	// X509 validity that is too high relative to CA validity generates a warning
-	server := &v1alpha1.SpireServer{
+	server := &v1alpha2.SpireServer{
 		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
-		Spec: v1alpha1.SpireServerSpec{
+		Spec: v1alpha2.SpireServerSpec{
 			// CA validity of 25 hours with X509 validity of 24 hours triggers warning
 			// (X509 should be much smaller than CA for proper rotation)
 			CAValidity:          metav1.Duration{Duration: 25 * time.Hour},
@@ -2158,7 +2158,7 @@ func TestReconcile_ReconciliationStepErrors_MutationKillers(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 
 			reconciler := &SpireServerReconciler{
 				ctrlClient:    fakeClient,
@@ -2170,7 +2170,7 @@ func TestReconcile_ReconciliationStepErrors_MutationKillers(t *testing.T) {
 
 			fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 				switch o := obj.(type) {
-				case *v1alpha1.SpireServer:
+				case *v1alpha2.SpireServer:
 					o.Name = "cluster"
 					o.UID = "test-uid"
 					o.Spec.JwtIssuer = "https://example.com"
@@ -2184,7 +2184,7 @@ func TestReconcile_ReconciliationStepErrors_MutationKillers(t *testing.T) {
 						UID:        "ztwim-uid",
 					}}
 					return nil
-				case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+				case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 					o.Name = "cluster"
 					o.UID = "ztwim-uid"
 					o.Spec.TrustDomain = "example.org"
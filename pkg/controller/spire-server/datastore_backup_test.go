@@ -0,0 +1,184 @@
+package spire_server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func newBackupCheckServer(enabled bool, maxAge, lastBackup string) *v1alpha2.SpireServer {
+	server := &v1alpha2.SpireServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Spec: v1alpha2.SpireServerSpec{
+			Datastore: v1alpha2.DataStore{
+				UpgradeBackupCheck: &v1alpha2.DatastoreBackupCheck{
+					Enabled:      ptr.To(enabled),
+					MaxBackupAge: maxAge,
+				},
+			},
+		},
+	}
+	if lastBackup != "" {
+		server.Annotations = map[string]string{lastDatastoreBackupAnnotationKey: lastBackup}
+	}
+	return server
+}
+
+func TestVerifyDatastoreBackup(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		maxAge         string
+		lastBackup     string
+		expectedOK     bool
+		expectedReason string
+	}{
+		{
+			name:           "recent backup with default max age",
+			lastBackup:     now.Add(-time.Hour).Format(time.RFC3339),
+			expectedOK:     true,
+			expectedReason: "DatastoreBackupRecent",
+		},
+		{
+			name:           "backup older than default max age",
+			lastBackup:     now.Add(-25 * time.Hour).Format(time.RFC3339),
+			expectedOK:     false,
+			expectedReason: "DatastoreBackupExpired",
+		},
+		{
+			name:           "backup within custom max age",
+			maxAge:         "72h",
+			lastBackup:     now.Add(-48 * time.Hour).Format(time.RFC3339),
+			expectedOK:     true,
+			expectedReason: "DatastoreBackupRecent",
+		},
+		{
+			name:           "no backup recorded",
+			expectedOK:     false,
+			expectedReason: "DatastoreBackupMissing",
+		},
+		{
+			name:           "invalid timestamp",
+			lastBackup:     "yesterday",
+			expectedOK:     false,
+			expectedReason: "InvalidDatastoreBackupTimestamp",
+		},
+		{
+			name:           "invalid max age",
+			maxAge:         "one day",
+			lastBackup:     now.Format(time.RFC3339),
+			expectedOK:     false,
+			expectedReason: "InvalidDatastoreBackupCheck",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newBackupCheckServer(true, tt.maxAge, tt.lastBackup)
+			reason, message, ok := verifyDatastoreBackup(server, server.Spec.Datastore.UpgradeBackupCheck, now)
+			if ok != tt.expectedOK {
+				t.Errorf("Expected ok %v, got %v (%s)", tt.expectedOK, ok, message)
+			}
+			if reason != tt.expectedReason {
+				t.Errorf("Expected reason %s, got %s", tt.expectedReason, reason)
+			}
+		})
+	}
+}
+
+func TestCheckDatastoreBackup(t *testing.T) {
+	tests := []struct {
+		name            string
+		server          *v1alpha2.SpireServer
+		versionChange   bool
+		expectedAllowed bool
+		expectedStatus  metav1.ConditionStatus
+	}{
+		{
+			name:            "check disabled allows upgrade",
+			server:          newBackupCheckServer(false, "", ""),
+			versionChange:   true,
+			expectedAllowed: true,
+		},
+		{
+			name:            "no version change allows update without backup",
+			server:          newBackupCheckServer(true, "", ""),
+			versionChange:   false,
+			expectedAllowed: true,
+		},
+		{
+			name:            "missing backup pauses upgrade",
+			server:          newBackupCheckServer(true, "", ""),
+			versionChange:   true,
+			expectedAllowed: false,
+			expectedStatus:  metav1.ConditionFalse,
+		},
+		{
+			name:            "recent backup allows upgrade",
+			server:          newBackupCheckServer(true, "", time.Now().Add(-time.Minute).Format(time.RFC3339)),
+			versionChange:   true,
+			expectedAllowed: true,
+			expectedStatus:  metav1.ConditionTrue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			reconciler := newTestReconciler(fakeClient)
+			statusMgr := status.NewManager(fakeClient)
+
+			allowed := reconciler.checkDatastoreBackup(tt.server, statusMgr, tt.versionChange)
+			if allowed != tt.expectedAllowed {
+				t.Errorf("Expected allowed %v, got %v", tt.expectedAllowed, allowed)
+			}
+
+			if err := statusMgr.ApplyStatus(t.Context(), tt.server, func() *v1alpha2.ConditionalStatus {
+				return &tt.server.Status.ConditionalStatus
+			}); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			condition := apimeta.FindStatusCondition(tt.server.Status.Conditions, DatastoreBackupVerified)
+			if tt.expectedStatus == "" {
+				if condition != nil {
+					t.Errorf("Expected no %s condition, got %+v", DatastoreBackupVerified, condition)
+				}
+				return
+			}
+			if condition == nil || condition.Status != tt.expectedStatus {
+				t.Errorf("Expected %s condition with status %s, got %+v", DatastoreBackupVerified, tt.expectedStatus, condition)
+			}
+		})
+	}
+}
+
+func TestCheckDatastoreBackup_ClearsFailedConditionWhenDisabled(t *testing.T) {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	reconciler := newTestReconciler(fakeClient)
+	statusMgr := status.NewManager(fakeClient)
+
+	server := newBackupCheckServer(false, "", "")
+	server.Status.Conditions = []metav1.Condition{
+		{Type: DatastoreBackupVerified, Status: metav1.ConditionFalse, Reason: "DatastoreBackupMissing"},
+	}
+
+	if !reconciler.checkDatastoreBackup(server, statusMgr, true) {
+		t.Error("Expected upgrade to be allowed when the check is disabled")
+	}
+	if err := statusMgr.ApplyStatus(t.Context(), server, func() *v1alpha2.ConditionalStatus {
+		return &server.Status.ConditionalStatus
+	}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	condition := apimeta.FindStatusCondition(server.Status.Conditions, DatastoreBackupVerified)
+	if condition == nil || condition.Status != metav1.ConditionTrue {
+		t.Errorf("Expected %s condition to be cleared, got %+v", DatastoreBackupVerified, condition)
+	}
+}
@@ -0,0 +1,401 @@
+package spire_server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/version"
+)
+
+const (
+	// DatastoreCompactionAvailable is the condition of the CronJob compacting the sqlite3 datastore
+	DatastoreCompactionAvailable = "DatastoreCompactionAvailable"
+
+	// datastoreDiskUsageCheckInterval is how often the datastore volume usage is read, as the
+	// kubelet volume statistics are not watched
+	datastoreDiskUsageCheckInterval = 5 * time.Minute
+
+	// defaultDatastorePressureThresholdPercent applies when diskUsage is not configured
+	defaultDatastorePressureThresholdPercent = 80
+
+	// defaultDatastoreCompactionWindow applies when the compaction window is not set
+	defaultDatastoreCompactionWindow = time.Hour
+
+	// spireServerPodName is the pod of the single SPIRE server replica, and spireDataClaimName
+	// its datastore volume claim, from the spire-data volume claim template
+	spireServerPodName  = "spire-server-0"
+	spireDataVolumeName = "spire-data"
+	spireDataClaimName  = spireDataVolumeName + "-" + spireServerPodName
+	spireDataMountPath  = "/run/spire/data"
+
+	sqliteDatabaseType      = "sqlite3"
+	datastoreCompactionName = "spire-server-datastore-compaction"
+
+	// Datastore disk usage reasons
+	DatastorePressureReasonNotMonitored = "DiskUsageNotMonitored"
+	DatastorePressureReasonUnavailable  = "DiskUsageUnavailable"
+	DatastorePressureReasonWithinLimit  = "DiskUsageWithinThreshold"
+	DatastorePressureReasonHigh         = "DatastoreDiskUsageHigh"
+)
+
+var (
+	datastoreVolumeUsedBytesGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "ztwim_spire_server_datastore_volume_used_bytes",
+		Help: "Bytes used on the volume of the sqlite3 datastore, 0 when not monitored.",
+	})
+	datastoreVolumeCapacityBytesGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "ztwim_spire_server_datastore_volume_capacity_bytes",
+		Help: "Capacity of the volume of the sqlite3 datastore in bytes, 0 when not monitored.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(datastoreVolumeUsedBytesGauge, datastoreVolumeCapacityBytesGauge)
+}
+
+// nodeStatsReader reads the summary of the volume statistics of a node from its kubelet
+type nodeStatsReader interface {
+	StatsSummary(ctx context.Context, nodeName string) ([]byte, error)
+}
+
+// kubeletStatsReader reads the kubelet stats summary through the nodes/proxy subresource
+type kubeletStatsReader struct {
+	restClient rest.Interface
+}
+
+func (k *kubeletStatsReader) StatsSummary(ctx context.Context, nodeName string) ([]byte, error) {
+	return k.restClient.Get().Resource("nodes").Name(nodeName).SubResource("proxy", "stats", "summary").DoRaw(ctx)
+}
+
+// statsSummary holds the fields of the kubelet stats summary read for the datastore volume
+type statsSummary struct {
+	Pods []struct {
+		PodRef struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"podRef"`
+		Volumes []struct {
+			Name          string  `json:"name"`
+			CapacityBytes *uint64 `json:"capacityBytes,omitempty"`
+			UsedBytes     *uint64 `json:"usedBytes,omitempty"`
+		} `json:"volume,omitempty"`
+	} `json:"pods"`
+}
+
+// datastoreVolumeUsage returns the used and capacity bytes of the datastore volume of the pod
+func datastoreVolumeUsage(data []byte, namespace string) (uint64, uint64, error) {
+	var summary statsSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return 0, 0, fmt.Errorf("failed to decode the kubelet stats summary: %w", err)
+	}
+	for _, pod := range summary.Pods {
+		if pod.PodRef.Name != spireServerPodName || pod.PodRef.Namespace != namespace {
+			continue
+		}
+		for _, volume := range pod.Volumes {
+			if volume.Name != spireDataVolumeName {
+				continue
+			}
+			if volume.UsedBytes == nil || volume.CapacityBytes == nil || *volume.CapacityBytes == 0 {
+				return 0, 0, fmt.Errorf("the kubelet reports no usage for the %s volume", spireDataVolumeName)
+			}
+			return *volume.UsedBytes, *volume.CapacityBytes, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("the kubelet reports no %s volume for pod %s", spireDataVolumeName, spireServerPodName)
+}
+
+// isSQLiteDatastore reports whether the datastore is a sqlite3 database
+func isSQLiteDatastore(datastore *v1alpha2.DataStore) bool {
+	return datastore.DatabaseType == sqliteDatabaseType
+}
+
+// sqliteDatabasePath returns the path of the sqlite3 database from the connection string, which
+// may be a file URI with query parameters
+func sqliteDatabasePath(connectionString string) string {
+	databasePath := strings.TrimPrefix(connectionString, "file:")
+	if i := strings.Index(databasePath, "?"); i >= 0 {
+		databasePath = databasePath[:i]
+	}
+	return path.Clean(databasePath)
+}
+
+// datastorePressureThreshold returns the usage percentage from which the datastore is under pressure
+func datastorePressureThreshold(diskUsage *v1alpha2.DatastoreDiskUsage) int32 {
+	if diskUsage == nil || diskUsage.PressureThresholdPercent <= 0 || diskUsage.PressureThresholdPercent > 100 {
+		return defaultDatastorePressureThresholdPercent
+	}
+	return diskUsage.PressureThresholdPercent
+}
+
+// validateDatastoreCompaction checks the compaction schedule and window, and that the compaction
+// job can mount the datastore volume alongside the server
+func validateDatastoreCompaction(server *v1alpha2.SpireServer) error {
+	diskUsage := server.Spec.Datastore.DiskUsage
+	if diskUsage == nil || diskUsage.Compaction == nil {
+		return nil
+	}
+	if !isSQLiteDatastore(&server.Spec.Datastore) {
+		return fmt.Errorf("datastore compaction requires the %s database type", sqliteDatabaseType)
+	}
+	if !strings.HasPrefix(sqliteDatabasePath(server.Spec.Datastore.ConnectionString), spireDataMountPath+"/") {
+		return fmt.Errorf("datastore compaction requires the sqlite3 database to be stored under %s", spireDataMountPath)
+	}
+	if server.Spec.Persistence.AccessMode == string(corev1.ReadWriteOncePod) {
+		return fmt.Errorf("datastore compaction cannot mount a %s datastore volume alongside the server", corev1.ReadWriteOncePod)
+	}
+	if _, err := cron.ParseStandard(diskUsage.Compaction.Schedule); err != nil {
+		return fmt.Errorf("invalid datastore compaction schedule %q: %w", diskUsage.Compaction.Schedule, err)
+	}
+	if _, err := datastoreCompactionWindow(diskUsage.Compaction); err != nil {
+		return err
+	}
+	return nil
+}
+
+// datastoreCompactionWindow returns how long the compaction may start and run after its schedule
+func datastoreCompactionWindow(compaction *v1alpha2.DatastoreCompaction) (time.Duration, error) {
+	if compaction.Window == "" {
+		return defaultDatastoreCompactionWindow, nil
+	}
+	window, err := time.ParseDuration(compaction.Window)
+	if err != nil {
+		return 0, fmt.Errorf("invalid datastore compaction window %q: %w", compaction.Window, err)
+	}
+	if window < time.Minute {
+		return 0, fmt.Errorf("datastore compaction window %q must be at least 1m", compaction.Window)
+	}
+	return window, nil
+}
+
+// reconcileDatastoreDiskUsage reads the usage of the sqlite3 datastore volume from the kubelet and
+// sets DatastorePressure when it crosses the threshold, emitting a warning Event once. The check
+// is best effort: failing to read the usage is reported but does not fail the reconcile.
+func (r *SpireServerReconciler) reconcileDatastoreDiskUsage(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager) {
+	if !isSQLiteDatastore(&server.Spec.Datastore) || r.nodeStats == nil {
+		datastoreVolumeUsedBytesGauge.Set(0)
+		datastoreVolumeCapacityBytesGauge.Set(0)
+		// Only report if the datastore was previously monitored
+		if apimeta.FindStatusCondition(server.Status.Conditions, utils.DatastorePressureStatusType) != nil {
+			statusMgr.AddCondition(utils.DatastorePressureStatusType, DatastorePressureReasonNotMonitored,
+				fmt.Sprintf("Disk usage is only monitored for the %s datastore", sqliteDatabaseType),
+				metav1.ConditionFalse)
+		}
+		return
+	}
+
+	used, capacity, err := r.readDatastoreVolumeUsage(ctx)
+	if err != nil {
+		r.log.V(1).Info("datastore disk usage not available", "reason", err.Error())
+		statusMgr.AddCondition(utils.DatastorePressureStatusType, DatastorePressureReasonUnavailable,
+			fmt.Sprintf("Datastore disk usage is not available: %v", err),
+			metav1.ConditionUnknown)
+		return
+	}
+	datastoreVolumeUsedBytesGauge.Set(float64(used))
+	datastoreVolumeCapacityBytesGauge.Set(float64(capacity))
+
+	threshold := datastorePressureThreshold(server.Spec.Datastore.DiskUsage)
+	percent := used * 100 / capacity
+	if percent < uint64(threshold) {
+		statusMgr.AddCondition(utils.DatastorePressureStatusType, DatastorePressureReasonWithinLimit,
+			fmt.Sprintf("Datastore volume is %d%% used, threshold is %d%%", percent, threshold),
+			metav1.ConditionFalse)
+		return
+	}
+
+	message := fmt.Sprintf("Datastore volume is %d%% used (%d of %d bytes), threshold is %d%%: expand the volume, or compact the datastore with spec.datastore.diskUsage.compaction",
+		percent, used, capacity, threshold)
+	// Warn once when the threshold is crossed, not on every check
+	existing := apimeta.FindStatusCondition(server.Status.Conditions, utils.DatastorePressureStatusType)
+	if existing == nil || existing.Status != metav1.ConditionTrue {
+		r.eventRecorder.Event(server, corev1.EventTypeWarning, DatastorePressureReasonHigh, utils.WithRunbook(DatastorePressureReasonHigh, message))
+	}
+	statusMgr.AddCondition(utils.DatastorePressureStatusType, DatastorePressureReasonHigh, message, metav1.ConditionTrue)
+}
+
+// readDatastoreVolumeUsage returns the used and capacity bytes of the datastore volume of the
+// server pod, from the kubelet of its node. Pods are not labelled as managed by the operator, so
+// the pod is read from the API server.
+func (r *SpireServerReconciler) readDatastoreVolumeUsage(ctx context.Context) (uint64, uint64, error) {
+	var pod corev1.Pod
+	if err := r.ctrlClient.GetUncached(ctx, types.NamespacedName{Name: spireServerPodName, Namespace: utils.GetOperatorNamespace()}, &pod); err != nil {
+		return 0, 0, fmt.Errorf("failed to get pod %s: %w", spireServerPodName, err)
+	}
+	if pod.Spec.NodeName == "" {
+		return 0, 0, fmt.Errorf("pod %s is not scheduled", spireServerPodName)
+	}
+	data, err := r.nodeStats.StatsSummary(ctx, pod.Spec.NodeName)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read the stats of node %s: %w", pod.Spec.NodeName, err)
+	}
+	return datastoreVolumeUsage(data, pod.Namespace)
+}
+
+// generateDatastoreCompactionCronJob returns the CronJob running VACUUM on the sqlite3 datastore
+// in the compaction window. The job mounts the datastore volume of the server, so it is scheduled
+// on the node of the server pod.
+func generateDatastoreCompactionCronJob(server *v1alpha2.SpireServer) (*batchv1.CronJob, error) {
+	compaction := server.Spec.Datastore.DiskUsage.Compaction
+	window, err := datastoreCompactionWindow(compaction)
+	if err != nil {
+		return nil, err
+	}
+	windowSeconds := int64(window.Seconds())
+	// The job pods must not match the selector of the server StatefulSet or Service
+	labels := utils.StandardizedLabels(datastoreCompactionName, utils.ComponentControlPlane, version.SpireServerVersion, server.Spec.Labels)
+	serverLabels := utils.SpireServerLabels(nil)
+
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      datastoreCompactionName,
+			Namespace: utils.GetOperatorNamespace(),
+			Labels:    labels,
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:                   compaction.Schedule,
+			StartingDeadlineSeconds:    ptr.To(windowSeconds),
+			ConcurrencyPolicy:          batchv1.ForbidConcurrent,
+			SuccessfulJobsHistoryLimit: ptr.To(int32(1)),
+			FailedJobsHistoryLimit:     ptr.To(int32(1)),
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: batchv1.JobSpec{
+					ActiveDeadlineSeconds: ptr.To(windowSeconds),
+					BackoffLimit:          ptr.To(int32(0)),
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: labels},
+						Spec: corev1.PodSpec{
+							RestartPolicy:    corev1.RestartPolicyNever,
+							ImagePullSecrets: server.Spec.ImagePullSecrets,
+							Containers: []corev1.Container{{
+								Name:            "compaction",
+								Image:           compaction.Image,
+								ImagePullPolicy: corev1.PullIfNotPresent,
+								// Wait for the server to release its write lock rather than failing
+								Command: []string{"sqlite3", sqliteDatabasePath(server.Spec.Datastore.ConnectionString), "PRAGMA busy_timeout = 60000; VACUUM;"},
+								Env: []corev1.EnvVar{
+									{Name: "SQLITE_TMPDIR", Value: "/tmp"},
+								},
+								SecurityContext: &corev1.SecurityContext{
+									ReadOnlyRootFilesystem:   ptr.To(true),
+									AllowPrivilegeEscalation: ptr.To(false),
+								},
+								VolumeMounts: []corev1.VolumeMount{
+									{Name: spireDataVolumeName, MountPath: spireDataMountPath},
+									{Name: "tmp", MountPath: "/tmp"},
+								},
+							}},
+							Volumes: []corev1.Volume{
+								{
+									Name: spireDataVolumeName,
+									VolumeSource: corev1.VolumeSource{
+										PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: spireDataClaimName},
+									},
+								},
+								{
+									Name:         "tmp",
+									VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+								},
+							},
+							Affinity: &corev1.Affinity{
+								PodAffinity: &corev1.PodAffinity{
+									RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+										LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{
+											"app.kubernetes.io/name":      serverLabels["app.kubernetes.io/name"],
+											"app.kubernetes.io/instance":  serverLabels["app.kubernetes.io/instance"],
+											"app.kubernetes.io/component": serverLabels["app.kubernetes.io/component"],
+										}},
+										TopologyKey: "kubernetes.io/hostname",
+									}},
+								},
+							},
+							NodeSelector: utils.DerefNodeSelector(server.Spec.NodeSelector),
+							Tolerations:  utils.DerefTolerations(server.Spec.Tolerations),
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// reconcileDatastoreCompaction creates the datastore compaction CronJob when compaction is
+// configured, and deletes it otherwise
+func (r *SpireServerReconciler) reconcileDatastoreCompaction(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
+	diskUsage := server.Spec.Datastore.DiskUsage
+	var existing batchv1.CronJob
+	err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: datastoreCompactionName, Namespace: utils.GetOperatorNamespace()}, &existing)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return r.datastoreCompactionFailed(statusMgr, fmt.Errorf("failed to get CronJob %s: %w", datastoreCompactionName, err))
+	}
+	exists := err == nil
+
+	if diskUsage == nil || diskUsage.Compaction == nil {
+		if !exists {
+			return nil
+		}
+		if err := r.ctrlClient.Delete(ctx, &existing); err != nil && !kerrors.IsNotFound(err) {
+			return r.datastoreCompactionFailed(statusMgr, fmt.Errorf("failed to delete CronJob %s: %w", datastoreCompactionName, err))
+		}
+		r.log.Info("Deleted datastore compaction CronJob", "name", datastoreCompactionName)
+		statusMgr.AddCondition(DatastoreCompactionAvailable, "DatastoreCompactionNotConfigured",
+			"Datastore compaction is not configured",
+			metav1.ConditionTrue)
+		return nil
+	}
+
+	desired, err := generateDatastoreCompactionCronJob(server)
+	if err != nil {
+		return r.datastoreCompactionFailed(statusMgr, err)
+	}
+	if err := controllerutil.SetControllerReference(server, desired, r.scheme); err != nil {
+		return r.datastoreCompactionFailed(statusMgr, fmt.Errorf("failed to set owner reference on CronJob %s: %w", datastoreCompactionName, err))
+	}
+
+	if !exists {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false))); err != nil {
+			return r.datastoreCompactionFailed(statusMgr, fmt.Errorf("failed to create CronJob %s: %w", datastoreCompactionName, err))
+		}
+		r.log.Info("Created datastore compaction CronJob", "name", datastoreCompactionName, "schedule", desired.Spec.Schedule)
+	} else if !createOnlyMode && (!equality.Semantic.DeepDerivative(desired.Spec, existing.Spec) || !utils.LabelsMatch(existing.Labels, desired.Labels)) {
+		desired.ResourceVersion = existing.ResourceVersion
+		if err := r.ctrlClient.Update(ctx, desired); err != nil {
+			return r.datastoreCompactionFailed(statusMgr, fmt.Errorf("failed to update CronJob %s: %w", datastoreCompactionName, err))
+		}
+		r.log.Info("Updated datastore compaction CronJob", "name", datastoreCompactionName, "schedule", desired.Spec.Schedule)
+	}
+
+	statusMgr.AddCondition(DatastoreCompactionAvailable, v1alpha2.ReasonReady,
+		fmt.Sprintf("Datastore compaction scheduled at %q", desired.Spec.Schedule),
+		metav1.ConditionTrue)
+	return nil
+}
+
+func (r *SpireServerReconciler) datastoreCompactionFailed(statusMgr *status.Manager, err error) error {
+	r.log.Error(err, "failed to reconcile the datastore compaction")
+	statusMgr.AddCondition(DatastoreCompactionAvailable, v1alpha2.ReasonFailed, err.Error(), metav1.ConditionFalse)
+	return err
+}
@@ -0,0 +1,233 @@
+package spire_server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// fakeNodeStats serves a stats summary with the datastore volume of the server pod
+type fakeNodeStats struct {
+	used, capacity uint64
+	err            error
+}
+
+func (f *fakeNodeStats) StatsSummary(_ context.Context, nodeName string) ([]byte, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []byte(fmt.Sprintf(`{"node":{"nodeName":%q},"pods":[{"podRef":{"name":"spire-server-0","namespace":%q},"volume":[{"name":"spire-data","usedBytes":%d,"capacityBytes":%d},{"name":"spire-config"}]}]}`,
+		nodeName, utils.GetOperatorNamespace(), f.used, f.capacity)), nil
+}
+
+func TestReconcileDatastoreDiskUsage(t *testing.T) {
+	tests := []struct {
+		name           string
+		databaseType   string
+		stats          *fakeNodeStats
+		existing       []metav1.Condition
+		expectReason   string
+		expectStatus   metav1.ConditionStatus
+		expectWarnings int
+	}{
+		{
+			name:         "not sqlite",
+			databaseType: "postgres",
+			stats:        &fakeNodeStats{used: 90, capacity: 100},
+		},
+		{
+			name:         "no longer sqlite",
+			databaseType: "postgres",
+			stats:        &fakeNodeStats{used: 90, capacity: 100},
+			existing:     []metav1.Condition{{Type: utils.DatastorePressureStatusType, Reason: DatastorePressureReasonHigh, Status: metav1.ConditionTrue}},
+			expectReason: DatastorePressureReasonNotMonitored,
+			expectStatus: metav1.ConditionFalse,
+		},
+		{
+			name:         "within threshold",
+			databaseType: sqliteDatabaseType,
+			stats:        &fakeNodeStats{used: 79, capacity: 100},
+			expectReason: DatastorePressureReasonWithinLimit,
+			expectStatus: metav1.ConditionFalse,
+		},
+		{
+			name:           "threshold crossed",
+			databaseType:   sqliteDatabaseType,
+			stats:          &fakeNodeStats{used: 80, capacity: 100},
+			expectReason:   DatastorePressureReasonHigh,
+			expectStatus:   metav1.ConditionTrue,
+			expectWarnings: 1,
+		},
+		{
+			name:         "pressure already reported",
+			databaseType: sqliteDatabaseType,
+			stats:        &fakeNodeStats{used: 95, capacity: 100},
+			existing:     []metav1.Condition{{Type: utils.DatastorePressureStatusType, Reason: DatastorePressureReasonHigh, Status: metav1.ConditionTrue}},
+			expectReason: DatastorePressureReasonHigh,
+			expectStatus: metav1.ConditionTrue,
+		},
+		{
+			name:         "stats unavailable",
+			databaseType: sqliteDatabaseType,
+			stats:        &fakeNodeStats{err: errors.New("forbidden")},
+			expectReason: DatastorePressureReasonUnavailable,
+			expectStatus: metav1.ConditionUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.GetUncachedStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				pod, ok := obj.(*corev1.Pod)
+				if !ok || key.Name != spireServerPodName {
+					return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+				}
+				pod.Name, pod.Namespace = key.Name, key.Namespace
+				pod.Spec.NodeName = "node-1"
+				return nil
+			}
+			recorder := record.NewFakeRecorder(10)
+			reconciler := &SpireServerReconciler{
+				ctrlClient:    fakeClient,
+				ctx:           context.Background(),
+				log:           logr.Discard(),
+				eventRecorder: recorder,
+				nodeStats:     tt.stats,
+			}
+			server := &v1alpha2.SpireServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec:       v1alpha2.SpireServerSpec{Datastore: v1alpha2.DataStore{DatabaseType: tt.databaseType}},
+				Status:     v1alpha2.SpireServerStatus{ConditionalStatus: v1alpha2.ConditionalStatus{Conditions: tt.existing}},
+			}
+			statusMgr := status.NewManager(fakeClient)
+
+			reconciler.reconcileDatastoreDiskUsage(context.Background(), server, statusMgr)
+			cond, ok := statusMgr.GetCondition(utils.DatastorePressureStatusType)
+			if tt.expectReason == "" {
+				if ok {
+					t.Errorf("Expected no condition, got %v", cond)
+				}
+				return
+			}
+			if cond.Reason != tt.expectReason || cond.Status != tt.expectStatus {
+				t.Errorf("Expected %s/%s, got %s/%s: %s", tt.expectStatus, tt.expectReason, cond.Status, cond.Reason, cond.Message)
+			}
+			if len(recorder.Events) != tt.expectWarnings {
+				t.Errorf("Expected %d warning Events, got %d", tt.expectWarnings, len(recorder.Events))
+			}
+		})
+	}
+}
+
+func TestSqliteDatabasePath(t *testing.T) {
+	for connectionString, expected := range map[string]string{
+		"/run/spire/data/datastore.sqlite3":                         "/run/spire/data/datastore.sqlite3",
+		"file:/run/spire/data/datastore.sqlite3?_busy_timeout=5000": "/run/spire/data/datastore.sqlite3",
+	} {
+		if got := sqliteDatabasePath(connectionString); got != expected {
+			t.Errorf("Expected %s for %q, got %s", expected, connectionString, got)
+		}
+	}
+}
+
+func TestValidateDatastoreCompaction(t *testing.T) {
+	newServer := func(mutate func(*v1alpha2.SpireServer)) *v1alpha2.SpireServer {
+		server := &v1alpha2.SpireServer{Spec: v1alpha2.SpireServerSpec{
+			Persistence: v1alpha2.Persistence{AccessMode: "ReadWriteOnce"},
+			Datastore: v1alpha2.DataStore{
+				DatabaseType:     sqliteDatabaseType,
+				ConnectionString: "/run/spire/data/datastore.sqlite3",
+				DiskUsage: &v1alpha2.DatastoreDiskUsage{Compaction: &v1alpha2.DatastoreCompaction{
+					Schedule: "0 3 * * 0", Window: "2h", Image: "registry.example.com/sqlite:latest",
+				}},
+			},
+		}}
+		mutate(server)
+		return server
+	}
+
+	if err := validateDatastoreCompaction(newServer(func(*v1alpha2.SpireServer) {})); err != nil {
+		t.Errorf("Expected a valid compaction, got: %v", err)
+	}
+	invalid := map[string]func(*v1alpha2.SpireServer){
+		"postgres":           func(s *v1alpha2.SpireServer) { s.Spec.Datastore.DatabaseType = "postgres" },
+		"outside the volume": func(s *v1alpha2.SpireServer) { s.Spec.Datastore.ConnectionString = "/tmp/datastore.sqlite3" },
+		"read write once pod": func(s *v1alpha2.SpireServer) {
+			s.Spec.Persistence.AccessMode = "ReadWriteOncePod"
+		},
+		"schedule":     func(s *v1alpha2.SpireServer) { s.Spec.Datastore.DiskUsage.Compaction.Schedule = "every sunday" },
+		"short window": func(s *v1alpha2.SpireServer) { s.Spec.Datastore.DiskUsage.Compaction.Window = "30s" },
+	}
+	for name, mutate := range invalid {
+		if err := validateDatastoreCompaction(newServer(mutate)); err == nil {
+			t.Errorf("Expected an invalid compaction with %s", name)
+		}
+	}
+}
+
+func TestReconcileDatastoreCompaction(t *testing.T) {
+	server := &v1alpha2.SpireServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
+		Spec: v1alpha2.SpireServerSpec{Datastore: v1alpha2.DataStore{
+			DatabaseType:     sqliteDatabaseType,
+			ConnectionString: "/run/spire/data/datastore.sqlite3",
+			DiskUsage: &v1alpha2.DatastoreDiskUsage{Compaction: &v1alpha2.DatastoreCompaction{
+				Schedule: "0 3 * * 0", Window: "2h", Image: "registry.example.com/sqlite:latest",
+			}},
+		}},
+	}
+
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	fakeClient.GetReturns(kerrors.NewNotFound(schema.GroupResource{}, datastoreCompactionName))
+	reconciler := newRBACTestReconciler(fakeClient)
+	statusMgr := status.NewManager(fakeClient)
+	if err := reconciler.reconcileDatastoreCompaction(context.Background(), server, statusMgr, false); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if fakeClient.CreateCallCount() != 1 {
+		t.Fatalf("Expected the CronJob to be created, got %d creates", fakeClient.CreateCallCount())
+	}
+	_, obj, _ := fakeClient.CreateArgsForCall(0)
+	cronJob := obj.(*batchv1.CronJob)
+	if *cronJob.Spec.StartingDeadlineSeconds != 7200 || *cronJob.Spec.JobTemplate.Spec.ActiveDeadlineSeconds != 7200 {
+		t.Errorf("Expected the compaction to be bounded by the window")
+	}
+	podSpec := cronJob.Spec.JobTemplate.Spec.Template.Spec
+	if podSpec.Volumes[0].PersistentVolumeClaim.ClaimName != spireDataClaimName {
+		t.Errorf("Expected the datastore volume claim to be mounted, got %v", podSpec.Volumes[0])
+	}
+	if podSpec.Affinity == nil || podSpec.Affinity.PodAffinity == nil {
+		t.Error("Expected the job to be scheduled next to the server")
+	}
+	if labels := cronJob.Spec.JobTemplate.Spec.Template.Labels; labels["app.kubernetes.io/name"] == "spire-server" {
+		t.Error("Expected the job pods not to match the server selector")
+	}
+
+	// Removing the compaction deletes the CronJob
+	server.Spec.Datastore.DiskUsage = nil
+	fakeClient = &fakes.FakeCustomCtrlClient{}
+	reconciler = newRBACTestReconciler(fakeClient)
+	statusMgr = status.NewManager(fakeClient)
+	if err := reconciler.reconcileDatastoreCompaction(context.Background(), server, statusMgr, false); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if fakeClient.DeleteCallCount() != 1 {
+		t.Errorf("Expected the CronJob to be deleted, got %d deletes", fakeClient.DeleteCallCount())
+	}
+}
@@ -0,0 +1,26 @@
+package spire_server
+
+import (
+	"context"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/environment"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+)
+
+// reportEnvironmentConflicts reports the SPIRE servers deployed outside of the operator, e.g. by
+// the upstream Helm charts, in the EnvironmentConflict condition. They do not share resources with
+// the operand, so the server is still reconciled. The check is best effort: failing to list the
+// workloads is logged and does not fail the reconcile.
+func (r *SpireServerReconciler) reportEnvironmentConflicts(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager) {
+	conflicts, err := environment.ForeignSpireServers(ctx, r.ctrlClient)
+	if err != nil {
+		r.log.Error(err, "failed to look for SPIRE servers deployed outside of the operator")
+		return
+	}
+	if len(conflicts) > 0 {
+		r.log.Info("SPIRE servers deployed outside of the operator found", "count", len(conflicts))
+	}
+	// Foreign servers are not blocking conflicts, so no error is returned
+	_ = environment.Report(statusMgr, conflicts, server.Status.Conditions)
+}
@@ -0,0 +1,358 @@
+package spire_server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	// externalAgentsResourceName is the name of the Route or LoadBalancer Service exposing the
+	// server API outside the cluster
+	externalAgentsResourceName = "spire-server-external"
+	// externalAgentsBootstrapConfigMapName holds the material external agents need to bootstrap
+	externalAgentsBootstrapConfigMapName = "spire-external-agent-bootstrap"
+
+	externalAgentsExposureLoadBalancer = "LoadBalancer"
+
+	externalAgentsPort        = 443
+	externalAgentsDialTimeout = 5 * time.Second
+)
+
+// dialExternalEndpoint checks that the external endpoint accepts connections. It is a variable
+// so tests can verify reachability reporting without network access.
+var dialExternalEndpoint = func(address string) error {
+	conn, err := net.DialTimeout("tcp", address, externalAgentsDialTimeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// reconcileExternalAgents exposes the server API for agents outside the cluster, publishes the
+// bootstrap material for them and verifies the external endpoint is reachable
+func (r *SpireServerReconciler) reconcileExternalAgents(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, createOnlyMode bool) error {
+	if server.Spec.ExternalAgents == nil {
+		// Only clean up if external agents were previously configured
+		if apimeta.FindStatusCondition(server.Status.Conditions, ExternalAgentEndpointAvailable) == nil {
+			return nil
+		}
+		for _, obj := range []client.Object{&routev1.Route{}, &corev1.Service{}} {
+			if err := r.deleteExternalAgentsResource(ctx, server, externalAgentsResourceName, obj); err != nil {
+				return err
+			}
+		}
+		if err := r.deleteExternalAgentsResource(ctx, server, externalAgentsBootstrapConfigMapName, &corev1.ConfigMap{}); err != nil {
+			return err
+		}
+		statusMgr.AddCondition(ExternalAgentEndpointAvailable, "ExternalAgentsNotConfigured",
+			"External agents are not configured",
+			metav1.ConditionTrue)
+		return nil
+	}
+
+	if server.Spec.ExternalAgents.Exposure != externalAgentsExposureLoadBalancer && !utils.HasCapability(utils.CapabilityRoute) {
+		statusMgr.AddCondition(ExternalAgentEndpointAvailable, utils.ReasonAPINotServed,
+			fmt.Sprintf("Routes are not served by the cluster, use the %s exposure instead", externalAgentsExposureLoadBalancer),
+			metav1.ConditionFalse)
+		return nil
+	}
+
+	var host string
+	var err error
+	if server.Spec.ExternalAgents.Exposure == externalAgentsExposureLoadBalancer {
+		if err = r.deleteExternalAgentsResource(ctx, server, externalAgentsResourceName, &routev1.Route{}); err == nil {
+			host, err = r.reconcileExternalAgentsService(ctx, server, createOnlyMode)
+		}
+	} else {
+		if err = r.deleteExternalAgentsResource(ctx, server, externalAgentsResourceName, &corev1.Service{}); err == nil {
+			host, err = r.reconcileExternalAgentsRoute(ctx, server, createOnlyMode)
+		}
+	}
+	if err != nil {
+		statusMgr.AddCondition(ExternalAgentEndpointAvailable, "ExternalEndpointFailed",
+			err.Error(),
+			metav1.ConditionFalse)
+		return err
+	}
+
+	if err := r.reconcileExternalAgentsBootstrapConfigMap(ctx, server, ztwim, host, createOnlyMode); err != nil {
+		statusMgr.AddCondition(ExternalAgentEndpointAvailable, "ExternalAgentBootstrapFailed",
+			err.Error(),
+			metav1.ConditionFalse)
+		return err
+	}
+
+	if host == "" {
+		statusMgr.AddCondition(ExternalAgentEndpointAvailable, "ExternalEndpointPending",
+			fmt.Sprintf("Waiting for the external %s to be assigned an address", server.Spec.ExternalAgents.Exposure),
+			metav1.ConditionFalse)
+		return nil
+	}
+
+	address := net.JoinHostPort(host, strconv.Itoa(externalAgentsPort))
+	if err := dialExternalEndpoint(address); err != nil {
+		statusMgr.AddCondition(ExternalAgentEndpointAvailable, "ExternalEndpointUnreachable",
+			fmt.Sprintf("External endpoint %s is not reachable: %v", address, err),
+			metav1.ConditionFalse)
+		return nil
+	}
+
+	statusMgr.AddCondition(ExternalAgentEndpointAvailable, "ExternalEndpointReachable",
+		fmt.Sprintf("External agents can connect to %s", address),
+		metav1.ConditionTrue)
+	return nil
+}
+
+// reconcileExternalAgentsService reconciles the LoadBalancer Service and returns its external
+// address, or an empty string while the load balancer is being provisioned
+func (r *SpireServerReconciler) reconcileExternalAgentsService(ctx context.Context, server *v1alpha2.SpireServer, createOnlyMode bool) (string, error) {
+	desired := generateExternalAgentsService(server)
+	if err := controllerutil.SetControllerReference(server, desired, r.scheme); err != nil {
+		return "", fmt.Errorf("failed to set controller reference on external Service: %w", err)
+	}
+
+	existing := &corev1.Service{}
+	err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get external Service: %w", err)
+		}
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false))); err != nil {
+			return "", fmt.Errorf("failed to create external Service: %w", err)
+		}
+		r.log.Info("Created external Service", "name", desired.Name, "namespace", desired.Namespace)
+		return "", nil
+	}
+
+	if !createOnlyMode {
+		desired.ResourceVersion = existing.ResourceVersion
+		desired.Spec.ClusterIP = existing.Spec.ClusterIP
+		desired.Spec.ClusterIPs = existing.Spec.ClusterIPs
+		desired.Spec.IPFamilies = existing.Spec.IPFamilies
+		desired.Spec.IPFamilyPolicy = existing.Spec.IPFamilyPolicy
+		desired.Spec.InternalTrafficPolicy = existing.Spec.InternalTrafficPolicy
+		desired.Spec.SessionAffinity = existing.Spec.SessionAffinity
+		desired.Spec.HealthCheckNodePort = existing.Spec.HealthCheckNodePort
+		utils.PreserveServiceNodePorts(existing, desired)
+		if utils.ResourceNeedsUpdate(existing, desired) {
+			if err := r.ctrlClient.Update(ctx, desired); err != nil {
+				return "", fmt.Errorf("failed to update external Service: %w", err)
+			}
+			r.log.Info("Updated external Service", "name", desired.Name, "namespace", desired.Namespace)
+		}
+	}
+
+	for _, ingress := range existing.Status.LoadBalancer.Ingress {
+		if ingress.Hostname != "" {
+			return ingress.Hostname, nil
+		}
+		if ingress.IP != "" {
+			return ingress.IP, nil
+		}
+	}
+	return "", nil
+}
+
+// reconcileExternalAgentsRoute reconciles the passthrough Route and returns its host once the
+// Route has been admitted by a router
+func (r *SpireServerReconciler) reconcileExternalAgentsRoute(ctx context.Context, server *v1alpha2.SpireServer, createOnlyMode bool) (string, error) {
+	desired := generateExternalAgentsRoute(server)
+	if err := controllerutil.SetControllerReference(server, desired, r.scheme); err != nil {
+		return "", fmt.Errorf("failed to set controller reference on external Route: %w", err)
+	}
+
+	existing := &routev1.Route{}
+	err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get external Route: %w", err)
+		}
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false))); err != nil {
+			return "", fmt.Errorf("failed to create external Route: %w", err)
+		}
+		r.log.Info("Created external Route", "name", desired.Name, "namespace", desired.Namespace)
+		return "", nil
+	}
+
+	// Keep the host generated by the router when none is configured
+	if desired.Spec.Host == "" {
+		desired.Spec.Host = existing.Spec.Host
+	}
+	if !createOnlyMode && (checkFederationRouteConflict(existing, desired) || !utils.AnnotationsMatch(existing.Annotations, desired.Annotations)) {
+		desired.ResourceVersion = existing.ResourceVersion
+		if err := r.ctrlClient.Update(ctx, desired); err != nil {
+			return "", fmt.Errorf("failed to update external Route: %w", err)
+		}
+		r.log.Info("Updated external Route", "name", desired.Name, "namespace", desired.Namespace)
+	}
+
+	for _, ingress := range existing.Status.Ingress {
+		for _, cond := range ingress.Conditions {
+			if cond.Type == routev1.RouteAdmitted && cond.Status == corev1.ConditionTrue {
+				return ingress.Host, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// reconcileExternalAgentsBootstrapConfigMap publishes the server address, trust domain, trust
+// bundle and bundle endpoint external agents are configured with. Join tokens are not part of
+// the published material and are distributed to the agents separately.
+func (r *SpireServerReconciler) reconcileExternalAgentsBootstrapConfigMap(ctx context.Context, server *v1alpha2.SpireServer, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, host string, createOnlyMode bool) error {
+	bundle := &corev1.ConfigMap{}
+	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: ztwim.Spec.BundleConfigMap, Namespace: utils.GetOperatorNamespace()}, bundle); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get bundle ConfigMap %s: %w", ztwim.Spec.BundleConfigMap, err)
+	}
+
+	desired := generateExternalAgentsBootstrapConfigMap(server, ztwim, host, bundle.Data["bundle.crt"])
+	if err := controllerutil.SetControllerReference(server, desired, r.scheme); err != nil {
+		return fmt.Errorf("failed to set controller reference on external agent bootstrap ConfigMap: %w", err)
+	}
+
+	existing := &corev1.ConfigMap{}
+	err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get external agent bootstrap ConfigMap: %w", err)
+		}
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false))); err != nil {
+			return fmt.Errorf("failed to create external agent bootstrap ConfigMap: %w", err)
+		}
+		r.log.Info("Created external agent bootstrap ConfigMap", "name", desired.Name, "namespace", desired.Namespace)
+		return nil
+	}
+
+	if createOnlyMode || (equality.Semantic.DeepEqual(existing.Data, desired.Data) && utils.LabelsMatch(existing.Labels, desired.Labels)) {
+		return nil
+	}
+	desired.ResourceVersion = existing.ResourceVersion
+	if err := r.ctrlClient.Update(ctx, desired); err != nil {
+		return fmt.Errorf("failed to update external agent bootstrap ConfigMap: %w", err)
+	}
+	r.log.Info("Updated external agent bootstrap ConfigMap", "name", desired.Name, "namespace", desired.Namespace)
+	return nil
+}
+
+// deleteExternalAgentsResource deletes a resource of the server no longer needed for the
+// configured exposure. Resources not controlled by the server are left untouched.
+func (r *SpireServerReconciler) deleteExternalAgentsResource(ctx context.Context, server *v1alpha2.SpireServer, name string, obj client.Object) error {
+	if _, route := obj.(*routev1.Route); route && !utils.HasCapability(utils.CapabilityRoute) {
+		return nil
+	}
+	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: name, Namespace: utils.GetOperatorNamespace()}, obj); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get %s: %w", name, err)
+	}
+	if !metav1.IsControlledBy(obj, server) {
+		return nil
+	}
+	if err := r.ctrlClient.Delete(ctx, obj); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete %s: %w", name, err)
+	}
+	r.log.Info("Deleted external agents resource", "name", name)
+	return nil
+}
+
+// generateExternalAgentsService returns the LoadBalancer Service exposing the server API
+func generateExternalAgentsService(server *v1alpha2.SpireServer) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        externalAgentsResourceName,
+			Namespace:   utils.GetOperatorNamespace(),
+			Labels:      utils.SpireServerLabels(server.Spec.Labels),
+			Annotations: server.Spec.ExternalAgents.Annotations,
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeLoadBalancer,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "grpc",
+					Port:       externalAgentsPort,
+					TargetPort: intstr.FromString("grpc"),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+			Selector: map[string]string{
+				"app.kubernetes.io/name":     "spire-server",
+				"app.kubernetes.io/instance": utils.StandardInstance,
+			},
+		},
+	}
+}
+
+// generateExternalAgentsRoute returns the passthrough Route exposing the server API. The server
+// authenticates agents with mTLS, so TLS must not be terminated by the router.
+func generateExternalAgentsRoute(server *v1alpha2.SpireServer) *routev1.Route {
+	return &routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        externalAgentsResourceName,
+			Namespace:   utils.GetOperatorNamespace(),
+			Labels:      utils.SpireServerLabels(server.Spec.Labels),
+			Annotations: server.Spec.ExternalAgents.Annotations,
+		},
+		Spec: routev1.RouteSpec{
+			Host: server.Spec.ExternalAgents.Host,
+			To: routev1.RouteTargetReference{
+				Kind:   "Service",
+				Name:   "spire-server",
+				Weight: ptr.To(int32(100)),
+			},
+			Port: &routev1.RoutePort{
+				TargetPort: intstr.FromString("grpc"),
+			},
+			TLS: &routev1.TLSConfig{
+				Termination:                   routev1.TLSTerminationPassthrough,
+				InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyNone,
+			},
+			WildcardPolicy: routev1.WildcardPolicyNone,
+		},
+	}
+}
+
+// generateExternalAgentsBootstrapConfigMap returns the bootstrap material for external agents
+func generateExternalAgentsBootstrapConfigMap(server *v1alpha2.SpireServer, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, host, bundle string) *corev1.ConfigMap {
+	data := map[string]string{
+		"trust_domain": utils.TrustDomain(ztwim),
+		"server_port":  strconv.Itoa(externalAgentsPort),
+	}
+	if host != "" {
+		data["server_address"] = host
+	}
+	if bundle != "" {
+		data["bundle.crt"] = bundle
+	}
+	if server.Spec.Federation != nil && ptr.Deref(server.Spec.Federation.ManagedRoute, false) {
+		data["bundle_endpoint_url"] = "https://federation." + utils.TrustDomain(ztwim)
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      externalAgentsBootstrapConfigMapName,
+			Namespace: utils.GetOperatorNamespace(),
+			Labels:    utils.SpireServerLabels(server.Spec.Labels),
+		},
+		Data: data,
+	}
+}
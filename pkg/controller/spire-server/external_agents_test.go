@@ -0,0 +1,228 @@
+package spire_server
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+)
+
+func newExternalAgentsServer(config *v1alpha2.ExternalAgentsConfig) *v1alpha2.SpireServer {
+	return &v1alpha2.SpireServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "server-uid"},
+		Spec:       v1alpha2.SpireServerSpec{ExternalAgents: config},
+	}
+}
+
+// externalAgentsClient serves the given objects by name and kind, and NotFound for anything else
+func externalAgentsClient(objects ...client.Object) *fakes.FakeCustomCtrlClient {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		for _, o := range objects {
+			if o.GetName() != key.Name {
+				continue
+			}
+			switch src := o.(type) {
+			case *corev1.Service:
+				if dst, ok := obj.(*corev1.Service); ok {
+					*dst = *src.DeepCopy()
+					return nil
+				}
+			case *routev1.Route:
+				if dst, ok := obj.(*routev1.Route); ok {
+					*dst = *src.DeepCopy()
+					return nil
+				}
+			case *corev1.ConfigMap:
+				if dst, ok := obj.(*corev1.ConfigMap); ok {
+					*dst = *src.DeepCopy()
+					return nil
+				}
+			}
+		}
+		return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+	}
+	return fakeClient
+}
+
+func stubDialExternalEndpoint(t *testing.T, err error) *[]string {
+	t.Helper()
+	var dialed []string
+	previous := dialExternalEndpoint
+	dialExternalEndpoint = func(address string) error {
+		dialed = append(dialed, address)
+		return err
+	}
+	t.Cleanup(func() { dialExternalEndpoint = previous })
+	return &dialed
+}
+
+func createdConfigMap(t *testing.T, fakeClient *fakes.FakeCustomCtrlClient) *corev1.ConfigMap {
+	t.Helper()
+	for i := 0; i < fakeClient.CreateCallCount(); i++ {
+		_, obj, _ := fakeClient.CreateArgsForCall(i)
+		if cm, ok := obj.(*corev1.ConfigMap); ok && cm.Name == externalAgentsBootstrapConfigMapName {
+			return cm
+		}
+	}
+	t.Fatal("Expected the external agent bootstrap ConfigMap to be created")
+	return nil
+}
+
+func TestReconcileExternalAgents_NotConfigured(t *testing.T) {
+	t.Run("never configured", func(t *testing.T) {
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		statusMgr := status.NewManager(fakeClient)
+		server := newExternalAgentsServer(nil)
+
+		if err := newRouteTestReconciler(fakeClient).reconcileExternalAgents(context.Background(), server, statusMgr, &v1alpha2.ZeroTrustWorkloadIdentityManager{}, false); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fakeClient.GetCallCount() != 0 || fakeClient.DeleteCallCount() != 0 {
+			t.Error("Expected no external agents resources to be touched")
+		}
+		if _, ok := statusMgr.GetCondition(ExternalAgentEndpointAvailable); ok {
+			t.Errorf("Expected no %s condition", ExternalAgentEndpointAvailable)
+		}
+	})
+
+	t.Run("previously configured resources are removed", func(t *testing.T) {
+		server := newExternalAgentsServer(nil)
+		server.Status.Conditions = []metav1.Condition{{Type: ExternalAgentEndpointAvailable, Status: metav1.ConditionTrue}}
+		owner := []metav1.OwnerReference{{Kind: "SpireServer", Name: "cluster", UID: "server-uid", Controller: ptr.To(true)}}
+		fakeClient := externalAgentsClient(
+			&routev1.Route{ObjectMeta: metav1.ObjectMeta{Name: externalAgentsResourceName, OwnerReferences: owner}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: externalAgentsBootstrapConfigMapName}},
+		)
+		statusMgr := status.NewManager(fakeClient)
+
+		if err := newRouteTestReconciler(fakeClient).reconcileExternalAgents(context.Background(), server, statusMgr, &v1alpha2.ZeroTrustWorkloadIdentityManager{}, false); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fakeClient.DeleteCallCount() != 1 {
+			t.Fatalf("Expected only the controlled Route to be deleted, got %d deletes", fakeClient.DeleteCallCount())
+		}
+		if _, obj, _ := fakeClient.DeleteArgsForCall(0); obj.GetName() != externalAgentsResourceName {
+			t.Errorf("Expected %s to be deleted, got %s", externalAgentsResourceName, obj.GetName())
+		}
+		cond, ok := statusMgr.GetCondition(ExternalAgentEndpointAvailable)
+		if !ok || cond.Reason != "ExternalAgentsNotConfigured" {
+			t.Errorf("Expected ExternalAgentsNotConfigured, got %v", cond)
+		}
+	})
+}
+
+func TestReconcileExternalAgents_LoadBalancer(t *testing.T) {
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.org", BundleConfigMap: "spire-bundle"}}
+	server := newExternalAgentsServer(&v1alpha2.ExternalAgentsConfig{
+		Exposure:    externalAgentsExposureLoadBalancer,
+		Annotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-internal": "true"},
+	})
+	bundle := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "spire-bundle"}, Data: map[string]string{"bundle.crt": "-----BEGIN CERTIFICATE-----"}}
+
+	t.Run("pending load balancer", func(t *testing.T) {
+		dialed := stubDialExternalEndpoint(t, nil)
+		fakeClient := externalAgentsClient(bundle)
+		statusMgr := status.NewManager(fakeClient)
+
+		if err := newRouteTestReconciler(fakeClient).reconcileExternalAgents(context.Background(), server, statusMgr, ztwim, false); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		_, obj, _ := fakeClient.CreateArgsForCall(0)
+		svc, ok := obj.(*corev1.Service)
+		if !ok || svc.Spec.Type != corev1.ServiceTypeLoadBalancer || svc.Annotations["service.beta.kubernetes.io/aws-load-balancer-internal"] != "true" {
+			t.Fatalf("Expected an annotated LoadBalancer Service to be created first, got %v", obj)
+		}
+		cm := createdConfigMap(t, fakeClient)
+		if cm.Data["trust_domain"] != "example.org" || cm.Data["bundle.crt"] == "" {
+			t.Errorf("Expected trust domain and bundle to be published, got %v", cm.Data)
+		}
+		if _, ok := cm.Data["server_address"]; ok {
+			t.Errorf("Expected no server address before the load balancer is provisioned, got %v", cm.Data)
+		}
+		if len(*dialed) != 0 {
+			t.Errorf("Expected no reachability check without an address, got %v", *dialed)
+		}
+		cond, ok := statusMgr.GetCondition(ExternalAgentEndpointAvailable)
+		if !ok || cond.Status != metav1.ConditionFalse || cond.Reason != "ExternalEndpointPending" {
+			t.Errorf("Expected ExternalEndpointPending, got %v", cond)
+		}
+	})
+
+	t.Run("provisioned load balancer is verified", func(t *testing.T) {
+		dialed := stubDialExternalEndpoint(t, nil)
+		existing := generateExternalAgentsService(server)
+		existing.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "203.0.113.10"}}
+		fakeClient := externalAgentsClient(bundle, existing)
+		statusMgr := status.NewManager(fakeClient)
+
+		if err := newRouteTestReconciler(fakeClient).reconcileExternalAgents(context.Background(), server, statusMgr, ztwim, false); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fakeClient.UpdateCallCount() != 0 {
+			t.Errorf("Expected an up to date Service not to be updated, got %d updates", fakeClient.UpdateCallCount())
+		}
+		if cm := createdConfigMap(t, fakeClient); cm.Data["server_address"] != "203.0.113.10" || cm.Data["server_port"] != "443" {
+			t.Errorf("Expected the load balancer address to be published, got %v", cm.Data)
+		}
+		if len(*dialed) != 1 || (*dialed)[0] != "203.0.113.10:443" {
+			t.Errorf("Expected the load balancer address to be dialed, got %v", *dialed)
+		}
+		cond, ok := statusMgr.GetCondition(ExternalAgentEndpointAvailable)
+		if !ok || cond.Status != metav1.ConditionTrue || cond.Reason != "ExternalEndpointReachable" {
+			t.Errorf("Expected ExternalEndpointReachable, got %v", cond)
+		}
+	})
+}
+
+func TestReconcileExternalAgents_RouteUnreachable(t *testing.T) {
+	stubDialExternalEndpoint(t, errors.New("i/o timeout"))
+	server := newExternalAgentsServer(&v1alpha2.ExternalAgentsConfig{Exposure: "Route"})
+	existing := generateExternalAgentsRoute(server)
+	existing.Spec.Host = "spire-server-external.apps.example.org"
+	existing.Status.Ingress = []routev1.RouteIngress{{
+		Host:       "spire-server-external.apps.example.org",
+		Conditions: []routev1.RouteIngressCondition{{Type: routev1.RouteAdmitted, Status: corev1.ConditionTrue}},
+	}}
+	fakeClient := externalAgentsClient(existing)
+	statusMgr := status.NewManager(fakeClient)
+
+	if err := newRouteTestReconciler(fakeClient).reconcileExternalAgents(context.Background(), server, statusMgr, &v1alpha2.ZeroTrustWorkloadIdentityManager{}, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fakeClient.UpdateCallCount() != 0 {
+		t.Errorf("Expected the router-assigned host to be kept without an update, got %d updates", fakeClient.UpdateCallCount())
+	}
+	cond, ok := statusMgr.GetCondition(ExternalAgentEndpointAvailable)
+	if !ok || cond.Status != metav1.ConditionFalse || cond.Reason != "ExternalEndpointUnreachable" {
+		t.Fatalf("Expected ExternalEndpointUnreachable, got %v", cond)
+	}
+	if !strings.Contains(cond.Message, "spire-server-external.apps.example.org:443") {
+		t.Errorf("Expected the unreachable address in the message, got %q", cond.Message)
+	}
+}
+
+func TestGenerateExternalAgentsRoute(t *testing.T) {
+	route := generateExternalAgentsRoute(newExternalAgentsServer(&v1alpha2.ExternalAgentsConfig{Host: "spire.example.org"}))
+	if route.Spec.Host != "spire.example.org" {
+		t.Errorf("Expected configured host, got %q", route.Spec.Host)
+	}
+	if route.Spec.TLS == nil || route.Spec.TLS.Termination != routev1.TLSTerminationPassthrough {
+		t.Errorf("Expected passthrough TLS so agents authenticate to the server with mTLS, got %v", route.Spec.TLS)
+	}
+	if route.Spec.To.Name != "spire-server" || route.Spec.Port.TargetPort.StrVal != "grpc" {
+		t.Errorf("Expected the Route to target the spire-server grpc port, got %v %v", route.Spec.To, route.Spec.Port)
+	}
+}
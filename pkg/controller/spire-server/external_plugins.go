@@ -0,0 +1,133 @@
+package spire_server
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+const (
+	// externalPluginsVolumeName is the volume the init containers copy the plugin binaries to
+	externalPluginsVolumeName = "spire-plugins"
+	externalPluginsMountPath  = "/run/spire/plugins"
+
+	// spireServerConfigMountPath is where the spire-server ConfigMap, holding the plugin
+	// configuration files, is mounted
+	spireServerConfigMountPath = "/run/spire/config"
+)
+
+// reservedPluginNames are the plugins configured by the operator, which external plugins cannot replace
+var reservedPluginNames = map[string][]string{
+	"NodeAttestor": {"k8s_psat", "join_token"},
+	"Notifier":     {"k8sbundle"},
+}
+
+// validateExternalPlugins checks the external plugins do not conflict with the plugins configured by the operator
+func validateExternalPlugins(plugins []v1alpha2.ExternalPlugin) error {
+	upstreamAuthorities := 0
+	for _, plugin := range plugins {
+		for _, reserved := range reservedPluginNames[plugin.Type] {
+			if plugin.Name == reserved {
+				return fmt.Errorf("external %s plugin %s conflicts with the plugin configured by the operator", plugin.Type, plugin.Name)
+			}
+		}
+		if plugin.Type == "UpstreamAuthority" {
+			upstreamAuthorities++
+		}
+	}
+	if upstreamAuthorities > 1 {
+		return fmt.Errorf("at most one UpstreamAuthority plugin can be configured, got %d", upstreamAuthorities)
+	}
+	return nil
+}
+
+// externalPluginFileName is the name of the plugin binary in the plugins volume
+func externalPluginFileName(plugin v1alpha2.ExternalPlugin) string {
+	return strings.ToLower(plugin.Type) + "-" + plugin.Name
+}
+
+// externalPluginConfigKey is the key of the plugin configuration in the spire-server ConfigMap
+func externalPluginConfigKey(plugin v1alpha2.ExternalPlugin) string {
+	return "plugin-" + externalPluginFileName(plugin) + ".conf"
+}
+
+// addExternalPluginsToConfig adds the external plugins to the plugins section of server.conf.
+// Their configuration is HCL, so it is read from a file rather than rendered into the JSON config.
+func addExternalPluginsToConfig(plugins map[string]interface{}, externalPlugins []v1alpha2.ExternalPlugin) {
+	for _, plugin := range externalPlugins {
+		pluginConfig := map[string]interface{}{
+			"plugin_cmd":      path.Join(externalPluginsMountPath, externalPluginFileName(plugin)),
+			"plugin_checksum": plugin.Checksum,
+		}
+		if plugin.PluginData != "" {
+			pluginConfig["plugin_data_file"] = path.Join(spireServerConfigMountPath, externalPluginConfigKey(plugin))
+		}
+		existing, _ := plugins[plugin.Type].([]map[string]interface{})
+		plugins[plugin.Type] = append(existing, map[string]interface{}{plugin.Name: pluginConfig})
+	}
+}
+
+// externalPluginConfigFiles returns the configuration files of the external plugins, by ConfigMap key
+func externalPluginConfigFiles(externalPlugins []v1alpha2.ExternalPlugin) map[string]string {
+	files := map[string]string{}
+	for _, plugin := range externalPlugins {
+		if plugin.PluginData != "" {
+			files[externalPluginConfigKey(plugin)] = plugin.PluginData
+		}
+	}
+	return files
+}
+
+// externalPluginConfigHashInput returns the plugin configuration files to include in the config
+// hash, so the server is rolled when they change. It is empty without plugin configuration, which
+// keeps the hash of existing servers.
+func externalPluginConfigHashInput(externalPlugins []v1alpha2.ExternalPlugin) []byte {
+	files := externalPluginConfigFiles(externalPlugins)
+	if len(files) == 0 {
+		return nil
+	}
+	// Map keys are marshalled sorted, so the input is stable
+	data, _ := json.Marshal(files)
+	return data
+}
+
+// addExternalPluginsToStatefulSet adds an init container per external plugin, copying its binary
+// to a volume mounted in the spire-server container
+func addExternalPluginsToStatefulSet(sts *appsv1.StatefulSet, externalPlugins []v1alpha2.ExternalPlugin) {
+	if len(externalPlugins) == 0 {
+		return
+	}
+	podSpec := &sts.Spec.Template.Spec
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name:         externalPluginsVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	})
+	// spire-server is the first container
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      externalPluginsVolumeName,
+		MountPath: externalPluginsMountPath,
+		ReadOnly:  true,
+	})
+	for _, plugin := range externalPlugins {
+		fileName := externalPluginFileName(plugin)
+		podSpec.InitContainers = append(podSpec.InitContainers, corev1.Container{
+			Name:            "plugin-" + strings.ReplaceAll(fileName, "_", "-"),
+			Image:           plugin.Image,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command:         []string{"cp", plugin.Path, path.Join(externalPluginsMountPath, fileName)},
+			SecurityContext: &corev1.SecurityContext{
+				ReadOnlyRootFilesystem: ptr.To(true),
+			},
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: externalPluginsVolumeName, MountPath: externalPluginsMountPath},
+			},
+		})
+	}
+}
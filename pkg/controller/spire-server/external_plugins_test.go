@@ -0,0 +1,105 @@
+package spire_server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+func newExternalPlugin(pluginType, name, pluginData string) v1alpha2.ExternalPlugin {
+	return v1alpha2.ExternalPlugin{
+		Type:       pluginType,
+		Name:       name,
+		Image:      "registry.example.com/spire-plugins/" + name + ":v1",
+		Path:       "/plugins/" + name,
+		Checksum:   strings.Repeat("a", 64),
+		PluginData: pluginData,
+	}
+}
+
+func TestValidateExternalPlugins(t *testing.T) {
+	tests := []struct {
+		name      string
+		plugins   []v1alpha2.ExternalPlugin
+		expectErr string
+	}{
+		{
+			name:    "custom node attestor and upstream authority",
+			plugins: []v1alpha2.ExternalPlugin{newExternalPlugin("NodeAttestor", "tpm", ""), newExternalPlugin("UpstreamAuthority", "vault", "")},
+		},
+		{
+			name:      "replaces an operator plugin",
+			plugins:   []v1alpha2.ExternalPlugin{newExternalPlugin("NodeAttestor", "k8s_psat", "")},
+			expectErr: "conflicts with the plugin configured by the operator",
+		},
+		{
+			name:      "two upstream authorities",
+			plugins:   []v1alpha2.ExternalPlugin{newExternalPlugin("UpstreamAuthority", "vault", ""), newExternalPlugin("UpstreamAuthority", "awspca", "")},
+			expectErr: "at most one UpstreamAuthority",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateExternalPlugins(tt.plugins)
+			if tt.expectErr == "" && err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if tt.expectErr != "" && (err == nil || !strings.Contains(err.Error(), tt.expectErr)) {
+				t.Fatalf("Expected error containing %q, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestExternalPluginsConfig(t *testing.T) {
+	server := createTestSpireServer()
+	server.Spec.ExternalPlugins = []v1alpha2.ExternalPlugin{
+		newExternalPlugin("NodeAttestor", "tpm_devid", `devid_ca_path = "/run/spire/devid/ca.pem"`),
+		newExternalPlugin("UpstreamAuthority", "vault", ""),
+	}
+
+	cm, err := generateSpireServerConfigMap(&server.Spec, createTestZTWIM())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if cm.Data["plugin-nodeattestor-tpm_devid.conf"] != server.Spec.ExternalPlugins[0].PluginData {
+		t.Errorf("Expected the plugin configuration file in the ConfigMap, got keys %v", cm.Data)
+	}
+	if _, ok := cm.Data["plugin-upstreamauthority-vault.conf"]; ok {
+		t.Error("Expected no configuration file for a plugin without pluginData")
+	}
+
+	plugins := generateServerConfMap(&server.Spec, createTestZTWIM())["plugins"].(map[string]interface{})
+	nodeAttestors := plugins["NodeAttestor"].([]map[string]interface{})
+	if len(nodeAttestors) != 2 {
+		t.Fatalf("Expected k8s_psat and the external NodeAttestor, got %v", nodeAttestors)
+	}
+	tpm := nodeAttestors[1]["tpm_devid"].(map[string]interface{})
+	if tpm["plugin_cmd"] != "/run/spire/plugins/nodeattestor-tpm_devid" ||
+		tpm["plugin_checksum"] != server.Spec.ExternalPlugins[0].Checksum ||
+		tpm["plugin_data_file"] != "/run/spire/config/plugin-nodeattestor-tpm_devid.conf" {
+		t.Errorf("Unexpected external plugin configuration %v", tpm)
+	}
+	vault := plugins["UpstreamAuthority"].([]map[string]interface{})[0]["vault"].(map[string]interface{})
+	if _, ok := vault["plugin_data_file"]; ok {
+		t.Errorf("Expected no plugin_data_file without pluginData, got %v", vault)
+	}
+
+	server.Spec.Persistence = v1alpha2.Persistence{Size: "1Gi", AccessMode: "ReadWriteOnce"}
+	sts := GenerateSpireServerStatefulSet(&server.Spec, "", "")
+	initContainers := sts.Spec.Template.Spec.InitContainers
+	if len(initContainers) != 2 || initContainers[0].Name != "plugin-nodeattestor-tpm-devid" {
+		t.Fatalf("Expected an init container per external plugin, got %v", initContainers)
+	}
+	if got := strings.Join(initContainers[0].Command, " "); got != "cp /plugins/tpm_devid /run/spire/plugins/nodeattestor-tpm_devid" {
+		t.Errorf("Unexpected init container command %q", got)
+	}
+	mounted := false
+	for _, mount := range sts.Spec.Template.Spec.Containers[0].VolumeMounts {
+		mounted = mounted || (mount.Name == externalPluginsVolumeName && mount.ReadOnly)
+	}
+	if !mounted {
+		t.Error("Expected the plugins volume to be mounted read-only in the spire-server container")
+	}
+}
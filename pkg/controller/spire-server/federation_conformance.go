@@ -0,0 +1,323 @@
+package spire_server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// Conditions of the conformance check of a federation partner
+const (
+	PartnerEndpointReachable = "EndpointReachable"
+	PartnerProfileCompatible = "ProfileCompatible"
+	PartnerCertificateValid  = "CertificateValid"
+	PartnerBundleValid       = "BundleValid"
+	PartnerRefreshHintValid  = "RefreshHintValid"
+)
+
+// Reasons of the FederationConformant condition
+const (
+	FederationConformantReasonConformant    = "PartnersConformant"
+	FederationConformantReasonNonConformant = "PartnersNonConformant"
+	FederationConformantReasonNoPartners    = "NoFederationPartners"
+)
+
+const (
+	// federationCheckTimeout bounds the fetch of the bundle of a federation partner
+	federationCheckTimeout = 10 * time.Second
+
+	// maxFederationBundleSize bounds the bundle read from a federation partner
+	maxFederationBundleSize = 1 << 20
+)
+
+// bundleEndpointResponse is what a bundle endpoint served
+type bundleEndpointResponse struct {
+	certs []*x509.Certificate
+	body  []byte
+}
+
+// bundleEndpointFetcher fetches the bundle served by the bundle endpoint of a federation partner
+type bundleEndpointFetcher interface {
+	// Fetch returns the certificates presented by the endpoint and the bundle it serves
+	Fetch(ctx context.Context, endpointURL string) (bundleEndpointResponse, error)
+}
+
+// httpsBundleEndpointFetcher fetches the bundle over HTTPS, through the proxy of the operator if any
+type httpsBundleEndpointFetcher struct{}
+
+func (httpsBundleEndpointFetcher) Fetch(ctx context.Context, endpointURL string) (bundleEndpointResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, federationCheckTimeout)
+	defer cancel()
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		TLSClientConfig: &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			// The certificates are verified against the profile of the partner by the caller, so
+			// an untrusted certificate is reported rather than failing the handshake
+			InsecureSkipVerify: true,
+		},
+	}
+	defer transport.CloseIdleConnections()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpointURL, nil)
+	if err != nil {
+		return bundleEndpointResponse{}, err
+	}
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		return bundleEndpointResponse{}, err
+	}
+	defer resp.Body.Close()
+	if resp.TLS == nil {
+		return bundleEndpointResponse{}, errors.New("the bundle is not served over TLS")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return bundleEndpointResponse{}, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxFederationBundleSize))
+	if err != nil {
+		return bundleEndpointResponse{}, err
+	}
+	return bundleEndpointResponse{certs: resp.TLS.PeerCertificates, body: body}, nil
+}
+
+// partnerCheck is the result of a check of a federation partner
+type partnerCheck struct {
+	status  metav1.ConditionStatus
+	reason  string
+	message string
+}
+
+func passed(reason, message string) partnerCheck {
+	return partnerCheck{status: metav1.ConditionTrue, reason: reason, message: message}
+}
+
+func failed(reason, message string) partnerCheck {
+	return partnerCheck{status: metav1.ConditionFalse, reason: reason, message: message}
+}
+
+// checkFederationPartner checks what the bundle endpoint of a partner served against its
+// configuration. The conditions of the previous check keep their transition time when unchanged.
+// webRoots verify the https_web endpoints, the system roots when nil.
+func checkFederationPartner(partner v1alpha2.FederatesWithConfig, previous []metav1.Condition, response bundleEndpointResponse, fetchErr error, webRoots *x509.CertPool, now time.Time) v1alpha2.FederationPartnerStatus {
+	result := v1alpha2.FederationPartnerStatus{TrustDomain: partner.TrustDomain, Conditions: append([]metav1.Condition{}, previous...)}
+	set := func(condType string, check partnerCheck) {
+		apimeta.SetStatusCondition(&result.Conditions, metav1.Condition{
+			Type:               condType,
+			Status:             check.status,
+			Reason:             check.reason,
+			Message:            check.message,
+			LastTransitionTime: metav1.NewTime(now),
+		})
+	}
+
+	if fetchErr == nil && len(response.certs) == 0 {
+		fetchErr = errors.New("no certificate presented")
+	}
+	if fetchErr != nil {
+		set(PartnerEndpointReachable, failed("EndpointUnreachable", fmt.Sprintf("Failed to fetch the bundle from %s: %v", partner.BundleEndpointUrl, fetchErr)))
+		for _, condType := range []string{PartnerProfileCompatible, PartnerCertificateValid, PartnerBundleValid, PartnerRefreshHintValid} {
+			set(condType, partnerCheck{status: metav1.ConditionUnknown, reason: "EndpointUnreachable", message: "The bundle endpoint could not be fetched"})
+		}
+		return result
+	}
+	set(PartnerEndpointReachable, passed("EndpointReachable", fmt.Sprintf("The bundle was fetched from %s", partner.BundleEndpointUrl)))
+
+	bundle, bundleCheck := checkPartnerBundle(partner.TrustDomain, response.body)
+	set(PartnerBundleValid, bundleCheck)
+	refreshHint, refreshHintCheck := checkRefreshHint(bundle, now)
+	result.RefreshHint = int64(refreshHint / time.Second)
+	set(PartnerRefreshHintValid, refreshHintCheck)
+	set(PartnerProfileCompatible, checkEndpointProfile(partner, response.certs[0]))
+	set(PartnerCertificateValid, checkEndpointCertificate(partner, response.certs, bundle, webRoots, now))
+	return result
+}
+
+// checkPartnerBundle parses the bundle served for the trust domain, nil when it is invalid
+func checkPartnerBundle(trustDomain string, body []byte) (*spiffebundle.Bundle, partnerCheck) {
+	td, err := spiffeid.TrustDomainFromString(trustDomain)
+	if err != nil {
+		return nil, failed("BundleInvalid", fmt.Sprintf("Invalid trust domain %q: %v", trustDomain, err))
+	}
+	bundle, err := spiffebundle.Parse(td, body)
+	if err != nil {
+		return nil, failed("BundleInvalid", fmt.Sprintf("The served bundle is not a SPIFFE bundle: %v", err))
+	}
+	if len(bundle.X509Authorities()) == 0 {
+		return nil, failed("BundleInvalid", "The served bundle holds no X.509 authority")
+	}
+	return bundle, passed("BundleValid", fmt.Sprintf("The served bundle holds %d X.509 and %d JWT authorities",
+		len(bundle.X509Authorities()), len(bundle.JWTAuthorities())))
+}
+
+// checkRefreshHint checks the bundle is refreshed before its X.509 authority expiring first
+func checkRefreshHint(bundle *spiffebundle.Bundle, now time.Time) (time.Duration, partnerCheck) {
+	if bundle == nil {
+		return 0, partnerCheck{status: metav1.ConditionUnknown, reason: "BundleInvalid", message: "The served bundle is invalid"}
+	}
+	refreshHint, ok := bundle.RefreshHint()
+	if !ok || refreshHint <= 0 {
+		return 0, failed("RefreshHintMissing", "The served bundle sets no refresh hint, it is refreshed at the default interval of the server")
+	}
+	var firstExpiry time.Time
+	for _, authority := range bundle.X509Authorities() {
+		if firstExpiry.IsZero() || authority.NotAfter.Before(firstExpiry) {
+			firstExpiry = authority.NotAfter
+		}
+	}
+	if remaining := firstExpiry.Sub(now); refreshHint >= remaining {
+		return refreshHint, failed("RefreshHintTooLong", fmt.Sprintf("The refresh hint %s exceeds the remaining lifetime %s of an X.509 authority of the bundle, which may expire before the bundle is refreshed",
+			refreshHint, remaining.Truncate(time.Second)))
+	}
+	return refreshHint, passed("RefreshHintValid", fmt.Sprintf("The bundle is refreshed every %s", refreshHint))
+}
+
+// endpointSPIFFEID returns the SPIFFE ID of an X509-SVID, empty for other certificates
+func endpointSPIFFEID(cert *x509.Certificate) string {
+	for _, uri := range cert.URIs {
+		if uri.Scheme == "spiffe" {
+			return uri.String()
+		}
+	}
+	return ""
+}
+
+// checkEndpointProfile checks the certificate presented by the endpoint matches its profile:
+// an X509-SVID of the endpoint SPIFFE ID for https_spiffe, a Web PKI certificate for https_web
+func checkEndpointProfile(partner v1alpha2.FederatesWithConfig, leaf *x509.Certificate) partnerCheck {
+	id := endpointSPIFFEID(leaf)
+	if partner.BundleEndpointProfile == v1alpha2.HttpsWebProfile {
+		if id != "" && len(leaf.DNSNames) == 0 {
+			return failed("ProfileMismatch", fmt.Sprintf("The endpoint presents the X509-SVID of %s rather than a Web PKI certificate, its profile is likely %s", id, v1alpha2.HttpsSpiffeProfile))
+		}
+		return passed("ProfileCompatible", "The endpoint presents a Web PKI certificate")
+	}
+	switch id {
+	case "":
+		return failed("ProfileMismatch", fmt.Sprintf("The endpoint presents a Web PKI certificate rather than an X509-SVID, its profile is likely %s", v1alpha2.HttpsWebProfile))
+	case partner.EndpointSpiffeId:
+		return passed("ProfileCompatible", fmt.Sprintf("The endpoint presents the X509-SVID of %s", id))
+	default:
+		return failed("EndpointSPIFFEIDMismatch", fmt.Sprintf("The endpoint presents the X509-SVID of %s rather than %s", id, partner.EndpointSpiffeId))
+	}
+}
+
+// checkEndpointCertificate checks the validity of the certificate presented by the endpoint and
+// its chain: to the web roots for https_web, and to the served bundle for https_spiffe, which
+// stands in for the bundle of the partner the server holds when the endpoint SPIFFE ID is of
+// the partner trust domain
+func checkEndpointCertificate(partner v1alpha2.FederatesWithConfig, certs []*x509.Certificate, bundle *spiffebundle.Bundle, webRoots *x509.CertPool, now time.Time) partnerCheck {
+	leaf := certs[0]
+	if now.Before(leaf.NotBefore) {
+		return failed("CertificateNotYetValid", fmt.Sprintf("The certificate of the endpoint is not valid before %s", leaf.NotBefore.UTC().Format(time.RFC3339)))
+	}
+	if now.After(leaf.NotAfter) {
+		return failed("CertificateExpired", fmt.Sprintf("The certificate of the endpoint expired at %s", leaf.NotAfter.UTC().Format(time.RFC3339)))
+	}
+	validUntil := leaf.NotAfter.UTC().Format(time.RFC3339)
+
+	opts := x509.VerifyOptions{Intermediates: x509.NewCertPool(), CurrentTime: now}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	if partner.BundleEndpointProfile == v1alpha2.HttpsWebProfile {
+		endpoint, err := url.Parse(partner.BundleEndpointUrl)
+		if err != nil {
+			return failed("CertificateUntrusted", fmt.Sprintf("Invalid bundle endpoint URL: %v", err))
+		}
+		opts.Roots, opts.DNSName = webRoots, endpoint.Hostname()
+	} else {
+		if bundle == nil || !strings.HasPrefix(endpointSPIFFEID(leaf), "spiffe://"+partner.TrustDomain+"/") {
+			return passed("CertificateValid", fmt.Sprintf("The certificate of the endpoint is valid until %s, its chain is not verified without a bundle of its trust domain", validUntil))
+		}
+		opts.Roots = x509.NewCertPool()
+		for _, authority := range bundle.X509Authorities() {
+			opts.Roots.AddCert(authority)
+		}
+		opts.KeyUsages = []x509.ExtKeyUsage{x509.ExtKeyUsageAny}
+	}
+	if _, err := leaf.Verify(opts); err != nil {
+		return failed("CertificateUntrusted", fmt.Sprintf("The certificate of the endpoint is not trusted: %v", err))
+	}
+	return passed("CertificateValid", fmt.Sprintf("The certificate of the endpoint is valid until %s", validUntil))
+}
+
+// reconcileFederationConformance checks the bundle endpoints of the federation partners when
+// requested by the ztwim.openshift.io/check-federation annotation, once per value of the
+// annotation. The result of each partner is reported in status.federation.
+func (r *SpireServerReconciler) reconcileFederationConformance(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager) {
+	request := server.Annotations[utils.CheckFederationAnnotation]
+	if r.federationEndpoints == nil || request == "" {
+		return
+	}
+	previous := server.Status.Federation
+	if previous != nil && previous.CheckRequest == request {
+		return
+	}
+	var partners []v1alpha2.FederatesWithConfig
+	if server.Spec.Federation != nil {
+		partners = server.Spec.Federation.FederatesWith
+	}
+	if len(partners) == 0 {
+		server.Status.Federation = &v1alpha2.FederationStatus{CheckRequest: request, LastCheckTime: &metav1.Time{Time: time.Now()}}
+		statusMgr.AddCondition(utils.FederationConformantStatusType, FederationConformantReasonNoPartners,
+			"No federation partner is configured", metav1.ConditionTrue)
+		return
+	}
+
+	previousConditions := map[string][]metav1.Condition{}
+	if previous != nil {
+		for _, partner := range previous.Partners {
+			previousConditions[partner.TrustDomain] = partner.Conditions
+		}
+	}
+	now := time.Now()
+	results := make([]v1alpha2.FederationPartnerStatus, len(partners))
+	var wg sync.WaitGroup
+	for i, partner := range partners {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response, err := r.federationEndpoints.Fetch(ctx, partner.BundleEndpointUrl)
+			results[i] = checkFederationPartner(partner, previousConditions[partner.TrustDomain], response, err, nil, now)
+		}()
+	}
+	wg.Wait()
+	server.Status.Federation = &v1alpha2.FederationStatus{CheckRequest: request, LastCheckTime: &metav1.Time{Time: now}, Partners: results}
+
+	var nonConformant []string
+	for _, result := range results {
+		for _, cond := range result.Conditions {
+			if cond.Status != metav1.ConditionTrue {
+				nonConformant = append(nonConformant, result.TrustDomain)
+				break
+			}
+		}
+	}
+	if len(nonConformant) > 0 {
+		message := fmt.Sprintf("Federation partners failing the conformance check, see status.federation: %s", strings.Join(nonConformant, ", "))
+		statusMgr.AddCondition(utils.FederationConformantStatusType, FederationConformantReasonNonConformant, message, metav1.ConditionFalse)
+		r.eventRecorder.Event(server, corev1.EventTypeWarning, FederationConformantReasonNonConformant,
+			utils.WithRunbook(FederationConformantReasonNonConformant, message))
+		return
+	}
+	statusMgr.AddCondition(utils.FederationConformantStatusType, FederationConformantReasonConformant,
+		fmt.Sprintf("The %d federation partners passed the conformance check", len(partners)), metav1.ConditionTrue)
+}
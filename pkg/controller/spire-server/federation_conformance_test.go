@@ -0,0 +1,353 @@
+package spire_server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// fakeBundleEndpoints serves the responses of the bundle endpoints by URL
+type fakeBundleEndpoints struct {
+	responses map[string]bundleEndpointResponse
+	calls     int
+}
+
+func (f *fakeBundleEndpoints) Fetch(_ context.Context, endpointURL string) (bundleEndpointResponse, error) {
+	f.calls++
+	response, ok := f.responses[endpointURL]
+	if !ok {
+		return bundleEndpointResponse{}, errors.New("connection refused")
+	}
+	return response, nil
+}
+
+// newTestEndpointChain returns a CA valid until caNotAfter and an endpoint certificate it signed
+// for the SPIFFE ID or DNS name
+func newTestEndpointChain(t *testing.T, caNotAfter time.Time, spiffeID, dnsName string) (*x509.Certificate, *x509.Certificate) {
+	t.Helper()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              caNotAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, _ := x509.ParseCertificate(caDER)
+
+	leafTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	if spiffeID != "" {
+		uri, _ := url.Parse(spiffeID)
+		leafTemplate.URIs = []*url.URL{uri}
+	}
+	if dnsName != "" {
+		leafTemplate.DNSNames = []string{dnsName}
+	}
+	leafKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, ca, &leafKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, _ := x509.ParseCertificate(leafDER)
+	return ca, leaf
+}
+
+// newTestPartnerBundle returns the SPIFFE bundle of the trust domain holding the authority
+func newTestPartnerBundle(t *testing.T, trustDomain string, authority *x509.Certificate, refreshHint time.Duration) []byte {
+	t.Helper()
+	bundle := spiffebundle.New(spiffeid.RequireTrustDomainFromString(trustDomain))
+	bundle.AddX509Authority(authority)
+	if refreshHint > 0 {
+		bundle.SetRefreshHint(refreshHint)
+	}
+	data, err := bundle.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func conditionReasons(conditions []metav1.Condition) map[string]string {
+	reasons := map[string]string{}
+	for _, cond := range conditions {
+		reasons[cond.Type] = cond.Reason
+	}
+	return reasons
+}
+
+func TestCheckFederationPartner(t *testing.T) {
+	now := time.Now()
+	spiffePartner := v1alpha2.FederatesWithConfig{
+		TrustDomain:           "partner.example.com",
+		BundleEndpointUrl:     "https://spire.partner.example.com:8443",
+		BundleEndpointProfile: v1alpha2.HttpsSpiffeProfile,
+		EndpointSpiffeId:      "spiffe://partner.example.com/spire/server",
+	}
+	webPartner := v1alpha2.FederatesWithConfig{
+		TrustDomain:           "web.example.com",
+		BundleEndpointUrl:     "https://bundle.web.example.com",
+		BundleEndpointProfile: v1alpha2.HttpsWebProfile,
+	}
+
+	svidCA, svid := newTestEndpointChain(t, now.Add(24*time.Hour), spiffePartner.EndpointSpiffeId, "")
+	otherCA, _ := newTestEndpointChain(t, now.Add(24*time.Hour), spiffePartner.EndpointSpiffeId, "")
+	webCA, webCert := newTestEndpointChain(t, now.Add(24*time.Hour), "", "bundle.web.example.com")
+	shortCA, shortSVID := newTestEndpointChain(t, now.Add(2*time.Minute), spiffePartner.EndpointSpiffeId, "")
+	webRoots := x509.NewCertPool()
+	webRoots.AddCert(webCA)
+
+	tests := []struct {
+		name     string
+		partner  v1alpha2.FederatesWithConfig
+		response bundleEndpointResponse
+		fetchErr error
+		want     map[string]string
+	}{
+		{
+			name:     "conformant https_spiffe",
+			partner:  spiffePartner,
+			response: bundleEndpointResponse{certs: []*x509.Certificate{svid}, body: newTestPartnerBundle(t, "partner.example.com", svidCA, 5*time.Minute)},
+			want: map[string]string{
+				PartnerEndpointReachable: "EndpointReachable",
+				PartnerProfileCompatible: "ProfileCompatible",
+				PartnerCertificateValid:  "CertificateValid",
+				PartnerBundleValid:       "BundleValid",
+				PartnerRefreshHintValid:  "RefreshHintValid",
+			},
+		},
+		{
+			name:     "conformant https_web",
+			partner:  webPartner,
+			response: bundleEndpointResponse{certs: []*x509.Certificate{webCert}, body: newTestPartnerBundle(t, "web.example.com", svidCA, 5*time.Minute)},
+			want: map[string]string{
+				PartnerProfileCompatible: "ProfileCompatible",
+				PartnerCertificateValid:  "CertificateValid",
+			},
+		},
+		{
+			name:     "unreachable",
+			partner:  spiffePartner,
+			fetchErr: errors.New("connection refused"),
+			want: map[string]string{
+				PartnerEndpointReachable: "EndpointUnreachable",
+				PartnerCertificateValid:  "EndpointUnreachable",
+			},
+		},
+		{
+			name:     "https_web partner serving an X509-SVID",
+			partner:  webPartner,
+			response: bundleEndpointResponse{certs: []*x509.Certificate{svid}, body: newTestPartnerBundle(t, "web.example.com", svidCA, 5*time.Minute)},
+			want: map[string]string{
+				PartnerProfileCompatible: "ProfileMismatch",
+				PartnerCertificateValid:  "CertificateUntrusted",
+			},
+		},
+		{
+			name:     "https_spiffe partner serving a Web PKI certificate",
+			partner:  spiffePartner,
+			response: bundleEndpointResponse{certs: []*x509.Certificate{webCert}, body: newTestPartnerBundle(t, "partner.example.com", svidCA, 5*time.Minute)},
+			want:     map[string]string{PartnerProfileCompatible: "ProfileMismatch"},
+		},
+		{
+			name: "another endpoint SPIFFE ID",
+			partner: func() v1alpha2.FederatesWithConfig {
+				p := spiffePartner
+				p.EndpointSpiffeId = "spiffe://partner.example.com/other"
+				return p
+			}(),
+			response: bundleEndpointResponse{certs: []*x509.Certificate{svid}, body: newTestPartnerBundle(t, "partner.example.com", svidCA, 5*time.Minute)},
+			want:     map[string]string{PartnerProfileCompatible: "EndpointSPIFFEIDMismatch"},
+		},
+		{
+			name:     "SVID not signed by the served bundle",
+			partner:  spiffePartner,
+			response: bundleEndpointResponse{certs: []*x509.Certificate{svid}, body: newTestPartnerBundle(t, "partner.example.com", otherCA, 5*time.Minute)},
+			want:     map[string]string{PartnerCertificateValid: "CertificateUntrusted"},
+		},
+		{
+			name:     "invalid bundle",
+			partner:  spiffePartner,
+			response: bundleEndpointResponse{certs: []*x509.Certificate{svid}, body: []byte("<html>not found</html>")},
+			want: map[string]string{
+				PartnerBundleValid:      "BundleInvalid",
+				PartnerRefreshHintValid: "BundleInvalid",
+				PartnerCertificateValid: "CertificateValid",
+			},
+		},
+		{
+			name:     "no refresh hint",
+			partner:  spiffePartner,
+			response: bundleEndpointResponse{certs: []*x509.Certificate{svid}, body: newTestPartnerBundle(t, "partner.example.com", svidCA, 0)},
+			want:     map[string]string{PartnerRefreshHintValid: "RefreshHintMissing"},
+		},
+		{
+			name:     "refresh hint outlasting an authority",
+			partner:  spiffePartner,
+			response: bundleEndpointResponse{certs: []*x509.Certificate{shortSVID}, body: newTestPartnerBundle(t, "partner.example.com", shortCA, 5*time.Minute)},
+			want:     map[string]string{PartnerRefreshHintValid: "RefreshHintTooLong"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkFederationPartner(tt.partner, nil, tt.response, tt.fetchErr, webRoots, now)
+			if result.TrustDomain != tt.partner.TrustDomain {
+				t.Errorf("Expected trust domain %s, got %s", tt.partner.TrustDomain, result.TrustDomain)
+			}
+			if len(result.Conditions) != 5 {
+				t.Errorf("Expected 5 conditions, got %v", result.Conditions)
+			}
+			reasons := conditionReasons(result.Conditions)
+			for condType, reason := range tt.want {
+				if reasons[condType] != reason {
+					t.Errorf("Expected %s reason %s, got %s", condType, reason, reasons[condType])
+				}
+			}
+		})
+	}
+
+	t.Run("refresh hint reported", func(t *testing.T) {
+		response := bundleEndpointResponse{certs: []*x509.Certificate{svid}, body: newTestPartnerBundle(t, "partner.example.com", svidCA, 5*time.Minute)}
+		if result := checkFederationPartner(spiffePartner, nil, response, nil, nil, now); result.RefreshHint != 300 {
+			t.Errorf("Expected refresh hint 300, got %d", result.RefreshHint)
+		}
+	})
+
+	t.Run("keeps the transition time of unchanged conditions", func(t *testing.T) {
+		response := bundleEndpointResponse{certs: []*x509.Certificate{svid}, body: newTestPartnerBundle(t, "partner.example.com", svidCA, 5*time.Minute)}
+		earlier := checkFederationPartner(spiffePartner, nil, response, nil, nil, now.Add(-time.Hour))
+		result := checkFederationPartner(spiffePartner, earlier.Conditions, response, nil, nil, now)
+		cond := apimeta.FindStatusCondition(result.Conditions, PartnerBundleValid)
+		if cond == nil || !cond.LastTransitionTime.Time.Equal(now.Add(-time.Hour)) {
+			t.Errorf("Expected the transition time of the previous check, got %+v", cond)
+		}
+	})
+}
+
+func TestReconcileFederationConformance(t *testing.T) {
+	ca, svid := newTestEndpointChain(t, time.Now().Add(24*time.Hour), "spiffe://partner.example.com/spire/server", "")
+	partner := v1alpha2.FederatesWithConfig{
+		TrustDomain:           "partner.example.com",
+		BundleEndpointUrl:     "https://spire.partner.example.com:8443",
+		BundleEndpointProfile: v1alpha2.HttpsSpiffeProfile,
+		EndpointSpiffeId:      "spiffe://partner.example.com/spire/server",
+	}
+	unreachable := v1alpha2.FederatesWithConfig{
+		TrustDomain:           "down.example.com",
+		BundleEndpointUrl:     "https://spire.down.example.com:8443",
+		BundleEndpointProfile: v1alpha2.HttpsWebProfile,
+	}
+	newServer := func(request string, partners ...v1alpha2.FederatesWithConfig) *v1alpha2.SpireServer {
+		server := &v1alpha2.SpireServer{Spec: v1alpha2.SpireServerSpec{Federation: &v1alpha2.FederationConfig{FederatesWith: partners}}}
+		if request != "" {
+			server.Annotations = map[string]string{utils.CheckFederationAnnotation: request}
+		}
+		return server
+	}
+	newReconciler := func(endpoints *fakeBundleEndpoints) *SpireServerReconciler {
+		r := newTestReconciler(&fakes.FakeCustomCtrlClient{})
+		r.federationEndpoints = endpoints
+		return r
+	}
+	conformant := map[string]bundleEndpointResponse{
+		partner.BundleEndpointUrl: {certs: []*x509.Certificate{svid}, body: newTestPartnerBundle(t, partner.TrustDomain, ca, 5*time.Minute)},
+	}
+
+	t.Run("not requested", func(t *testing.T) {
+		endpoints := &fakeBundleEndpoints{responses: conformant}
+		server := newServer("", partner)
+		statusMgr := status.NewManager(&fakes.FakeCustomCtrlClient{})
+		newReconciler(endpoints).reconcileFederationConformance(context.Background(), server, statusMgr)
+		if endpoints.calls != 0 || server.Status.Federation != nil {
+			t.Error("Expected no check without the annotation")
+		}
+		if _, ok := statusMgr.GetCondition(utils.FederationConformantStatusType); ok {
+			t.Error("Expected no FederationConformant condition without the annotation")
+		}
+	})
+
+	t.Run("conformant", func(t *testing.T) {
+		endpoints := &fakeBundleEndpoints{responses: conformant}
+		server := newServer("1", partner)
+		statusMgr := status.NewManager(&fakes.FakeCustomCtrlClient{})
+		newReconciler(endpoints).reconcileFederationConformance(context.Background(), server, statusMgr)
+		if server.Status.Federation == nil || server.Status.Federation.CheckRequest != "1" || len(server.Status.Federation.Partners) != 1 {
+			t.Fatalf("Expected the check to be recorded, got %+v", server.Status.Federation)
+		}
+		cond, ok := statusMgr.GetCondition(utils.FederationConformantStatusType)
+		if !ok || cond.Status != metav1.ConditionTrue || cond.Reason != FederationConformantReasonConformant {
+			t.Errorf("Expected FederationConformant True, got %+v", cond)
+		}
+
+		// The check is run once per request
+		newReconciler(endpoints).reconcileFederationConformance(context.Background(), server, status.NewManager(&fakes.FakeCustomCtrlClient{}))
+		if endpoints.calls != 1 {
+			t.Errorf("Expected the check to run once for the request, got %d fetches", endpoints.calls)
+		}
+	})
+
+	t.Run("non-conformant partner", func(t *testing.T) {
+		endpoints := &fakeBundleEndpoints{responses: conformant}
+		server := newServer("2", partner, unreachable)
+		statusMgr := status.NewManager(&fakes.FakeCustomCtrlClient{})
+		r := newReconciler(endpoints)
+		r.reconcileFederationConformance(context.Background(), server, statusMgr)
+		cond, ok := statusMgr.GetCondition(utils.FederationConformantStatusType)
+		if !ok || cond.Status != metav1.ConditionFalse || !strings.Contains(cond.Message, unreachable.TrustDomain) || strings.Contains(cond.Message, partner.TrustDomain) {
+			t.Errorf("Expected FederationConformant False naming %s only, got %+v", unreachable.TrustDomain, cond)
+		}
+		if partners := server.Status.Federation.Partners; len(partners) != 2 || partners[1].TrustDomain != unreachable.TrustDomain {
+			t.Errorf("Expected the partners in their configured order, got %+v", partners)
+		}
+		select {
+		case event := <-r.eventRecorder.(*record.FakeRecorder).Events:
+			if !strings.Contains(event, FederationConformantReasonNonConformant) {
+				t.Errorf("Expected a %s Event, got %q", FederationConformantReasonNonConformant, event)
+			}
+		default:
+			t.Error("Expected a Warning Event")
+		}
+	})
+
+	t.Run("no partner", func(t *testing.T) {
+		server := newServer("3")
+		statusMgr := status.NewManager(&fakes.FakeCustomCtrlClient{})
+		newReconciler(&fakeBundleEndpoints{}).reconcileFederationConformance(context.Background(), server, statusMgr)
+		if cond, ok := statusMgr.GetCondition(utils.FederationConformantStatusType); !ok || cond.Reason != FederationConformantReasonNoPartners {
+			t.Errorf("Expected FederationConformant %s, got %+v", FederationConformantReasonNoPartners, cond)
+		}
+	})
+}
@@ -0,0 +1,437 @@
+package spire_server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;create,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=update;delete,resourceNames=spire-server-federation-egress,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups=k8s.ovn.org,resources=egressfirewalls,verbs=get;update,resourceNames=default,namespace=zero-trust-workload-identity-manager
+
+const (
+	// FederationEgressAllowed reports whether the SPIRE server is allowed to reach the bundle
+	// endpoints of the federation partners
+	FederationEgressAllowed = "FederationEgressAllowed"
+
+	FederationEgressReasonAllowed       = "FederationEgressAllowed"
+	FederationEgressReasonNotRestricted = "EgressNotRestricted"
+	FederationEgressReasonFailed        = "FederationEgressFailed"
+	FederationEgressReasonNotConfigured = "FederationEgressNotConfigured"
+
+	// federationEgressPolicyName is the name of the NetworkPolicy allowing the egress
+	federationEgressPolicyName = "spire-server-federation-egress"
+
+	// egressFirewallName is the name of the only EgressFirewall OVN-Kubernetes applies in a namespace
+	egressFirewallName = "default"
+
+	// federationEgressRulesAnnotation holds the EgressFirewall rules added by the operator, removed
+	// again before the rules of the current partners are added
+	federationEgressRulesAnnotation = "ztwim.openshift.io/federation-egress-rules"
+
+	federationEgressLookupTimeout = 5 * time.Second
+)
+
+// egressFirewallGVK is the OVN-Kubernetes EgressFirewall, read as unstructured so the operator
+// runs on clusters with other network plugins
+var egressFirewallGVK = schema.GroupVersionKind{Group: "k8s.ovn.org", Version: "v1", Kind: "EgressFirewall"}
+
+// lookupEndpointHost resolves the host of a bundle endpoint. It is a variable so tests can
+// generate NetworkPolicies without DNS.
+var lookupEndpointHost = func(ctx context.Context, host string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, federationEgressLookupTimeout)
+	defer cancel()
+	return net.DefaultResolver.LookupHost(ctx, host)
+}
+
+// federationEndpoint is the host and port of the bundle endpoint of a federation partner
+type federationEndpoint struct {
+	host string
+	port int32
+}
+
+// federationEndpoints returns the distinct hosts and ports of the bundle endpoints of the partners
+func federationEndpoints(partners []v1alpha2.FederatesWithConfig) ([]federationEndpoint, error) {
+	seen := map[federationEndpoint]bool{}
+	var endpoints []federationEndpoint
+	for _, partner := range partners {
+		u, err := url.Parse(partner.BundleEndpointUrl)
+		if err != nil || u.Hostname() == "" {
+			return nil, fmt.Errorf("invalid bundle endpoint URL %q of trust domain %s", partner.BundleEndpointUrl, partner.TrustDomain)
+		}
+		port := 443
+		if u.Port() != "" {
+			if port, err = strconv.Atoi(u.Port()); err != nil || port < 1 || port > 65535 {
+				return nil, fmt.Errorf("invalid port in bundle endpoint URL %q of trust domain %s", partner.BundleEndpointUrl, partner.TrustDomain)
+			}
+		}
+		endpoint := federationEndpoint{host: strings.ToLower(u.Hostname()), port: int32(port)}
+		if !seen[endpoint] {
+			seen[endpoint] = true
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].host != endpoints[j].host {
+			return endpoints[i].host < endpoints[j].host
+		}
+		return endpoints[i].port < endpoints[j].port
+	})
+	return endpoints, nil
+}
+
+// hostCIDR returns the single address CIDR of an IP address
+func hostCIDR(ip net.IP) string {
+	if ip.To4() != nil {
+		return ip.String() + "/32"
+	}
+	return ip.String() + "/128"
+}
+
+// reconcileFederationEgress allows the SPIRE server to reach the bundle endpoints of the
+// federation partners, through a NetworkPolicy or the EgressFirewall of the operator namespace
+func (r *SpireServerReconciler) reconcileFederationEgress(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
+	policy := v1alpha2.FederationEgressPolicyNone
+	var partners []v1alpha2.FederatesWithConfig
+	if server.Spec.Federation != nil {
+		partners = server.Spec.Federation.FederatesWith
+		if server.Spec.Federation.EgressPolicy != "" {
+			policy = server.Spec.Federation.EgressPolicy
+		}
+	}
+	previouslyConfigured := apimeta.FindStatusCondition(server.Status.Conditions, FederationEgressAllowed) != nil
+
+	// Withdraw the allowances of the policy not in use
+	if policy != v1alpha2.FederationEgressPolicyNetworkPolicy && previouslyConfigured {
+		if err := r.deleteFederationEgressPolicy(ctx, server); err != nil {
+			statusMgr.AddCondition(FederationEgressAllowed, FederationEgressReasonFailed,
+				fmt.Sprintf("Failed to delete NetworkPolicy %s: %v", federationEgressPolicyName, err),
+				metav1.ConditionFalse)
+			return err
+		}
+	}
+	if policy != v1alpha2.FederationEgressPolicyEgressFirewall && previouslyConfigured {
+		if _, err := r.reconcileEgressFirewallRules(ctx, nil, createOnlyMode); err != nil {
+			statusMgr.AddCondition(FederationEgressAllowed, FederationEgressReasonFailed,
+				fmt.Sprintf("Failed to remove the federation rules from EgressFirewall %s: %v", egressFirewallName, err),
+				metav1.ConditionFalse)
+			return err
+		}
+	}
+
+	if policy == v1alpha2.FederationEgressPolicyNone || len(partners) == 0 {
+		if previouslyConfigured {
+			statusMgr.AddCondition(FederationEgressAllowed, FederationEgressReasonNotConfigured,
+				"No egress allowance is generated for the federation partners",
+				metav1.ConditionTrue)
+		}
+		return nil
+	}
+
+	endpoints, err := federationEndpoints(partners)
+	if err != nil {
+		statusMgr.AddCondition(FederationEgressAllowed, FederationEgressReasonFailed, err.Error(), metav1.ConditionFalse)
+		return utils.NewInvalidConfigurationError(err, "invalid federation partners")
+	}
+
+	switch policy {
+	case v1alpha2.FederationEgressPolicyNetworkPolicy:
+		return r.reconcileFederationEgressPolicy(ctx, server, statusMgr, endpoints, createOnlyMode)
+	default:
+		restricted, err := r.reconcileEgressFirewallRules(ctx, endpoints, createOnlyMode)
+		if err != nil {
+			statusMgr.AddCondition(FederationEgressAllowed, FederationEgressReasonFailed,
+				fmt.Sprintf("Failed to add the federation rules to EgressFirewall %s: %v", egressFirewallName, err),
+				metav1.ConditionFalse)
+			return err
+		}
+		if !restricted {
+			statusMgr.AddCondition(FederationEgressAllowed, FederationEgressReasonNotRestricted,
+				fmt.Sprintf("The operator namespace has no EgressFirewall %s, the egress to the bundle endpoints is not restricted", egressFirewallName),
+				metav1.ConditionTrue)
+			return nil
+		}
+		statusMgr.AddCondition(FederationEgressAllowed, FederationEgressReasonAllowed,
+			fmt.Sprintf("EgressFirewall %s allows the bundle endpoints of %d federation partners", egressFirewallName, len(partners)),
+			metav1.ConditionTrue)
+		return nil
+	}
+}
+
+// reconcileFederationEgressPolicy creates the NetworkPolicy allowing the SPIRE server pods to reach
+// the bundle endpoints once another NetworkPolicy restricts their egress. Creating it before would
+// restrict the egress of the server to the bundle endpoints.
+func (r *SpireServerReconciler) reconcileFederationEgressPolicy(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, endpoints []federationEndpoint, createOnlyMode bool) error {
+	restricted, err := r.serverEgressRestricted(ctx, server)
+	if err != nil {
+		statusMgr.AddCondition(FederationEgressAllowed, FederationEgressReasonFailed,
+			fmt.Sprintf("Failed to list the NetworkPolicies of the operator namespace: %v", err),
+			metav1.ConditionFalse)
+		return err
+	}
+	if !restricted {
+		if err := r.deleteFederationEgressPolicy(ctx, server); err != nil {
+			statusMgr.AddCondition(FederationEgressAllowed, FederationEgressReasonFailed,
+				fmt.Sprintf("Failed to delete NetworkPolicy %s: %v", federationEgressPolicyName, err),
+				metav1.ConditionFalse)
+			return err
+		}
+		statusMgr.AddCondition(FederationEgressAllowed, FederationEgressReasonNotRestricted,
+			"No NetworkPolicy restricts the egress of the SPIRE server, the egress to the bundle endpoints is not restricted",
+			metav1.ConditionTrue)
+		return nil
+	}
+
+	desired, unresolved := generateFederationEgressPolicy(ctx, server, endpoints)
+	if err := controllerutil.SetControllerReference(server, desired, r.scheme); err != nil {
+		statusMgr.AddCondition(FederationEgressAllowed, FederationEgressReasonFailed,
+			fmt.Sprintf("Failed to set owner reference on NetworkPolicy: %v", err),
+			metav1.ConditionFalse)
+		return err
+	}
+	existing := &networkingv1.NetworkPolicy{}
+	err = r.ctrlClient.GetUncached(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	switch {
+	case kerrors.IsNotFound(err):
+		err = r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false)))
+	case err == nil && !createOnlyMode && (!utils.LabelsMatch(existing.Labels, desired.Labels) || !reflect.DeepEqual(existing.Spec, desired.Spec)):
+		desired.ResourceVersion = existing.ResourceVersion
+		err = r.ctrlClient.Update(ctx, desired)
+	}
+	if err != nil {
+		r.log.Error(err, "failed to apply federation egress NetworkPolicy")
+		statusMgr.AddCondition(FederationEgressAllowed, FederationEgressReasonFailed,
+			fmt.Sprintf("Failed to apply NetworkPolicy %s: %v", federationEgressPolicyName, err),
+			metav1.ConditionFalse)
+		return err
+	}
+
+	if len(unresolved) > 0 {
+		statusMgr.AddCondition(FederationEgressAllowed, FederationEgressReasonFailed,
+			fmt.Sprintf("NetworkPolicy %s cannot allow the bundle endpoints whose host does not resolve: %s", federationEgressPolicyName, strings.Join(unresolved, ", ")),
+			metav1.ConditionFalse)
+		return nil
+	}
+	statusMgr.AddCondition(FederationEgressAllowed, FederationEgressReasonAllowed,
+		fmt.Sprintf("NetworkPolicy %s allows the bundle endpoints of %d hosts", federationEgressPolicyName, len(endpoints)),
+		metav1.ConditionTrue)
+	return nil
+}
+
+// generateFederationEgressPolicy returns the NetworkPolicy allowing the SPIRE server pods to reach
+// the addresses the bundle endpoint hosts resolve to, and the hosts which did not resolve.
+// NetworkPolicies only match addresses, so the hosts are resolved again at every reconciliation.
+func generateFederationEgressPolicy(ctx context.Context, server *v1alpha2.SpireServer, endpoints []federationEndpoint) (*networkingv1.NetworkPolicy, []string) {
+	serverLabels := utils.SpireServerLabels(server.Spec.Labels)
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      federationEgressPolicyName,
+			Namespace: utils.GetOperatorNamespace(),
+			Labels:    utils.SpireServerLabels(server.Spec.Labels),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{
+				"app.kubernetes.io/name":      serverLabels["app.kubernetes.io/name"],
+				"app.kubernetes.io/instance":  serverLabels["app.kubernetes.io/instance"],
+				"app.kubernetes.io/component": serverLabels["app.kubernetes.io/component"],
+			}},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			// An empty list of rules denies all egress, until the hosts resolve
+			Egress: []networkingv1.NetworkPolicyEgressRule{},
+		},
+	}
+
+	var unresolved []string
+	for _, endpoint := range endpoints {
+		var addresses []string
+		if ip := net.ParseIP(endpoint.host); ip != nil {
+			addresses = []string{endpoint.host}
+		} else {
+			resolved, err := lookupEndpointHost(ctx, endpoint.host)
+			if err != nil || len(resolved) == 0 {
+				unresolved = append(unresolved, endpoint.host)
+				continue
+			}
+			addresses = resolved
+		}
+		sort.Strings(addresses)
+		rule := networkingv1.NetworkPolicyEgressRule{
+			Ports: []networkingv1.NetworkPolicyPort{{
+				Protocol: ptr.To(corev1.ProtocolTCP),
+				Port:     ptr.To(intstr.FromInt32(endpoint.port)),
+			}},
+		}
+		for _, address := range addresses {
+			if ip := net.ParseIP(address); ip != nil {
+				rule.To = append(rule.To, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: hostCIDR(ip)}})
+			}
+		}
+		policy.Spec.Egress = append(policy.Spec.Egress, rule)
+	}
+	return policy, unresolved
+}
+
+// serverEgressRestricted reports whether a NetworkPolicy other than the one of the operator
+// restricts the egress of the SPIRE server pods
+func (r *SpireServerReconciler) serverEgressRestricted(ctx context.Context, server *v1alpha2.SpireServer) (bool, error) {
+	var policies networkingv1.NetworkPolicyList
+	if err := r.ctrlClient.ListUncached(ctx, &policies, client.InNamespace(utils.GetOperatorNamespace())); err != nil {
+		return false, err
+	}
+	podLabels := labels.Set(utils.SpireServerLabels(server.Spec.Labels))
+	for _, policy := range policies.Items {
+		if policy.Name == federationEgressPolicyName {
+			continue
+		}
+		egress := len(policy.Spec.Egress) > 0
+		for _, policyType := range policy.Spec.PolicyTypes {
+			if policyType == networkingv1.PolicyTypeEgress {
+				egress = true
+			}
+		}
+		if !egress {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(podLabels) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// deleteFederationEgressPolicy deletes the NetworkPolicy allowing the egress if controlled by the
+// SpireServer
+func (r *SpireServerReconciler) deleteFederationEgressPolicy(ctx context.Context, server *v1alpha2.SpireServer) error {
+	existing := &networkingv1.NetworkPolicy{}
+	if err := r.ctrlClient.GetUncached(ctx, types.NamespacedName{Name: federationEgressPolicyName, Namespace: utils.GetOperatorNamespace()}, existing); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(existing, server) {
+		return nil
+	}
+	if err := r.ctrlClient.Delete(ctx, existing); err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// egressFirewallRules returns the EgressFirewall rules allowing the bundle endpoints
+func egressFirewallRules(endpoints []federationEndpoint) []interface{} {
+	var rules []interface{}
+	for _, endpoint := range endpoints {
+		to := map[string]interface{}{"dnsName": endpoint.host}
+		if ip := net.ParseIP(endpoint.host); ip != nil {
+			to = map[string]interface{}{"cidrSelector": hostCIDR(ip)}
+		}
+		rules = append(rules, map[string]interface{}{
+			"type":  "Allow",
+			"to":    to,
+			"ports": []interface{}{map[string]interface{}{"protocol": "TCP", "port": int64(endpoint.port)}},
+		})
+	}
+	return rules
+}
+
+// reconcileEgressFirewallRules replaces the rules previously added by the operator ahead of the
+// rules of the default EgressFirewall of the operator namespace with the rules allowing the
+// endpoints. The EgressFirewall is left to the administrator, who restricts the egress with it:
+// it reports false when there is none, or when the cluster does not serve EgressFirewalls.
+func (r *SpireServerReconciler) reconcileEgressFirewallRules(ctx context.Context, endpoints []federationEndpoint, createOnlyMode bool) (bool, error) {
+	firewall := &unstructured.Unstructured{}
+	firewall.SetGroupVersionKind(egressFirewallGVK)
+	if err := r.ctrlClient.GetUncached(ctx, types.NamespacedName{Name: egressFirewallName, Namespace: utils.GetOperatorNamespace()}, firewall); err != nil {
+		if kerrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if createOnlyMode {
+		return true, nil
+	}
+
+	rules, _, err := unstructured.NestedSlice(firewall.Object, "spec", "egress")
+	if err != nil {
+		return true, fmt.Errorf("invalid rules: %w", err)
+	}
+	// Drop the rules added at the previous reconciliation
+	var previous []interface{}
+	if annotation := firewall.GetAnnotations()[federationEgressRulesAnnotation]; annotation != "" {
+		if err := json.Unmarshal([]byte(annotation), &previous); err != nil {
+			return true, fmt.Errorf("invalid annotation %s: %w", federationEgressRulesAnnotation, err)
+		}
+	}
+	remaining := rules
+	if len(previous) <= len(rules) && jsonEqual(rules[:len(previous)], previous) {
+		remaining = rules[len(previous):]
+	}
+
+	added := egressFirewallRules(endpoints)
+	desired := append(append([]interface{}{}, added...), remaining...)
+	if jsonEqual(rules, desired) {
+		return true, nil
+	}
+	if err := unstructured.SetNestedSlice(firewall.Object, desired, "spec", "egress"); err != nil {
+		return true, err
+	}
+	annotations := firewall.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if len(added) == 0 {
+		delete(annotations, federationEgressRulesAnnotation)
+	} else {
+		addedJSON, err := json.Marshal(added)
+		if err != nil {
+			return true, err
+		}
+		annotations[federationEgressRulesAnnotation] = string(addedJSON)
+	}
+	firewall.SetAnnotations(annotations)
+	if err := r.ctrlClient.Update(ctx, firewall); err != nil {
+		return true, err
+	}
+	r.log.Info("Updated the federation rules of the EgressFirewall", "name", egressFirewallName, "rules", len(added))
+	return true, nil
+}
+
+// jsonEqual reports whether two values have the same JSON encoding, the EgressFirewall rules read
+// from the API holding numbers of other types than those generated
+func jsonEqual(a, b interface{}) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(aJSON) == string(bJSON)
+}
@@ -0,0 +1,258 @@
+package spire_server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func serverWithFederationEgress(policy v1alpha2.FederationEgressPolicy) *v1alpha2.SpireServer {
+	return &v1alpha2.SpireServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "server-uid"},
+		Spec: v1alpha2.SpireServerSpec{
+			Federation: &v1alpha2.FederationConfig{
+				EgressPolicy: policy,
+				FederatesWith: []v1alpha2.FederatesWithConfig{
+					{TrustDomain: "partner.org", BundleEndpointUrl: "https://bundle.partner.org:8443"},
+					{TrustDomain: "other.org", BundleEndpointUrl: "https://192.0.2.10"},
+				},
+			},
+		},
+	}
+}
+
+func newFederationEgressReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireServerReconciler {
+	scheme := runtime.NewScheme()
+	_ = v1alpha2.AddToScheme(scheme)
+	return &SpireServerReconciler{ctrlClient: fakeClient, log: logr.Discard(), scheme: scheme}
+}
+
+func TestFederationEndpoints(t *testing.T) {
+	endpoints, err := federationEndpoints([]v1alpha2.FederatesWithConfig{
+		{TrustDomain: "b.org", BundleEndpointUrl: "https://Bundle.b.org"},
+		{TrustDomain: "a.org", BundleEndpointUrl: "https://bundle.a.org:8443/bundle"},
+		{TrustDomain: "c.org", BundleEndpointUrl: "https://bundle.b.org:443"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []federationEndpoint{{host: "bundle.a.org", port: 8443}, {host: "bundle.b.org", port: 443}}
+	if len(endpoints) != len(expected) || endpoints[0] != expected[0] || endpoints[1] != expected[1] {
+		t.Errorf("Expected %v, got %v", expected, endpoints)
+	}
+
+	if _, err := federationEndpoints([]v1alpha2.FederatesWithConfig{{TrustDomain: "a.org", BundleEndpointUrl: "https://"}}); err == nil {
+		t.Error("Expected an error for an URL without host")
+	}
+}
+
+func TestGenerateFederationEgressPolicy(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+	original := lookupEndpointHost
+	defer func() { lookupEndpointHost = original }()
+	lookupEndpointHost = func(_ context.Context, host string) ([]string, error) {
+		if host == "bundle.partner.org" {
+			return []string{"198.51.100.2", "2001:db8::2"}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	server := serverWithFederationEgress(v1alpha2.FederationEgressPolicyNetworkPolicy)
+	endpoints, _ := federationEndpoints(append(server.Spec.Federation.FederatesWith,
+		v1alpha2.FederatesWithConfig{TrustDomain: "gone.org", BundleEndpointUrl: "https://bundle.gone.org"}))
+	policy, unresolved := generateFederationEgressPolicy(context.Background(), server, endpoints)
+
+	if len(unresolved) != 1 || unresolved[0] != "bundle.gone.org" {
+		t.Errorf("Expected bundle.gone.org not to resolve, got %v", unresolved)
+	}
+	if policy.Name != federationEgressPolicyName || policy.Namespace != "test-ns" {
+		t.Errorf("Expected %s in test-ns, got %s/%s", federationEgressPolicyName, policy.Namespace, policy.Name)
+	}
+	if policy.Spec.PodSelector.MatchLabels["app.kubernetes.io/component"] != utils.SpireServerLabels(nil)["app.kubernetes.io/component"] {
+		t.Errorf("Expected the SPIRE server pods to be selected, got %v", policy.Spec.PodSelector.MatchLabels)
+	}
+	if len(policy.Spec.Egress) != 2 {
+		t.Fatalf("Expected a rule per resolved endpoint, got %d", len(policy.Spec.Egress))
+	}
+	ipRule := policy.Spec.Egress[0]
+	if ipRule.To[0].IPBlock.CIDR != "192.0.2.10/32" || ipRule.Ports[0].Port.IntValue() != 443 {
+		t.Errorf("Expected 192.0.2.10/32 on 443, got %v on %v", ipRule.To[0].IPBlock, ipRule.Ports[0].Port)
+	}
+	hostRule := policy.Spec.Egress[1]
+	if len(hostRule.To) != 2 || hostRule.To[0].IPBlock.CIDR != "198.51.100.2/32" || hostRule.To[1].IPBlock.CIDR != "2001:db8::2/128" || hostRule.Ports[0].Port.IntValue() != 8443 {
+		t.Errorf("Expected the resolved addresses on 8443, got %+v", hostRule)
+	}
+}
+
+func TestReconcileFederationEgressNetworkPolicy(t *testing.T) {
+	original := lookupEndpointHost
+	defer func() { lookupEndpointHost = original }()
+	lookupEndpointHost = func(context.Context, string) ([]string, error) {
+		return []string{"198.51.100.2"}, nil
+	}
+	denyAll := networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "deny-all"},
+		Spec:       networkingv1.NetworkPolicySpec{PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress}},
+	}
+	ingressOnly := networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "ingress-only"},
+		Spec:       networkingv1.NetworkPolicySpec{PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}},
+	}
+
+	tests := []struct {
+		name         string
+		policies     []networkingv1.NetworkPolicy
+		expectCreate bool
+		expectReason string
+	}{
+		{name: "egress restricted", policies: []networkingv1.NetworkPolicy{denyAll}, expectCreate: true, expectReason: FederationEgressReasonAllowed},
+		{name: "egress not restricted", policies: []networkingv1.NetworkPolicy{ingressOnly}, expectReason: FederationEgressReasonNotRestricted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.ListUncachedStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				list.(*networkingv1.NetworkPolicyList).Items = tt.policies
+				return nil
+			}
+			fakeClient.GetUncachedReturns(kerrors.NewNotFound(schema.GroupResource{}, federationEgressPolicyName))
+			statusMgr := status.NewManager(fakeClient)
+
+			server := serverWithFederationEgress(v1alpha2.FederationEgressPolicyNetworkPolicy)
+			if err := newFederationEgressReconciler(fakeClient).reconcileFederationEgress(context.Background(), server, statusMgr, false); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if (fakeClient.CreateCallCount() == 1) != tt.expectCreate {
+				t.Errorf("Expected the NetworkPolicy to be created %v, got %d creates", tt.expectCreate, fakeClient.CreateCallCount())
+			}
+			if tt.expectCreate {
+				if _, obj, _ := fakeClient.CreateArgsForCall(0); !metav1.IsControlledBy(obj, server) {
+					t.Error("Expected the NetworkPolicy to be controlled by the SpireServer")
+				}
+			}
+			if cond, _ := statusMgr.GetCondition(FederationEgressAllowed); cond.Reason != tt.expectReason || cond.Status != metav1.ConditionTrue {
+				t.Errorf("Expected True/%s, got %s/%s", tt.expectReason, cond.Status, cond.Reason)
+			}
+		})
+	}
+}
+
+func TestReconcileFederationEgressFirewall(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+	denyRule := map[string]interface{}{"type": "Deny", "to": map[string]interface{}{"cidrSelector": "0.0.0.0/0"}}
+
+	t.Run("adds the rules ahead of the existing ones", func(t *testing.T) {
+		stale, _ := json.Marshal([]interface{}{map[string]interface{}{"type": "Allow", "to": map[string]interface{}{"dnsName": "old.partner.org"}}})
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		fakeClient.GetUncachedStub = func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			firewall := obj.(*unstructured.Unstructured)
+			firewall.SetName(egressFirewallName)
+			firewall.SetAnnotations(map[string]string{federationEgressRulesAnnotation: string(stale)})
+			return unstructured.SetNestedSlice(firewall.Object, []interface{}{
+				map[string]interface{}{"type": "Allow", "to": map[string]interface{}{"dnsName": "old.partner.org"}},
+				denyRule,
+			}, "spec", "egress")
+		}
+		statusMgr := status.NewManager(fakeClient)
+
+		if err := newFederationEgressReconciler(fakeClient).reconcileFederationEgress(context.Background(), serverWithFederationEgress(v1alpha2.FederationEgressPolicyEgressFirewall), statusMgr, false); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fakeClient.UpdateCallCount() != 1 {
+			t.Fatalf("Expected the EgressFirewall to be updated, got %d updates", fakeClient.UpdateCallCount())
+		}
+		_, obj, _ := fakeClient.UpdateArgsForCall(0)
+		rules, _, _ := unstructured.NestedSlice(obj.(*unstructured.Unstructured).Object, "spec", "egress")
+		if len(rules) != 3 {
+			t.Fatalf("Expected the stale rule to be replaced by the two partner rules, got %v", rules)
+		}
+		if to := rules[0].(map[string]interface{})["to"].(map[string]interface{}); to["cidrSelector"] != "192.0.2.10/32" {
+			t.Errorf("Expected the IP endpoint first, got %v", to)
+		}
+		if to := rules[1].(map[string]interface{})["to"].(map[string]interface{}); to["dnsName"] != "bundle.partner.org" {
+			t.Errorf("Expected the DNS endpoint second, got %v", to)
+		}
+		if !jsonEqual(rules[2], denyRule) {
+			t.Errorf("Expected the rules of the administrator to be kept last, got %v", rules[2])
+		}
+		if cond, _ := statusMgr.GetCondition(FederationEgressAllowed); cond.Reason != FederationEgressReasonAllowed {
+			t.Errorf("Expected the egress to be allowed, got %s", cond.Reason)
+		}
+	})
+
+	t.Run("no EgressFirewall", func(t *testing.T) {
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		fakeClient.GetUncachedReturns(kerrors.NewNotFound(schema.GroupResource{}, egressFirewallName))
+		statusMgr := status.NewManager(fakeClient)
+
+		if err := newFederationEgressReconciler(fakeClient).reconcileFederationEgress(context.Background(), serverWithFederationEgress(v1alpha2.FederationEgressPolicyEgressFirewall), statusMgr, false); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fakeClient.UpdateCallCount() != 0 {
+			t.Error("Expected no update")
+		}
+		if cond, _ := statusMgr.GetCondition(FederationEgressAllowed); cond.Reason != FederationEgressReasonNotRestricted {
+			t.Errorf("Expected the egress not to be restricted, got %s", cond.Reason)
+		}
+	})
+}
+
+func TestReconcileFederationEgressNotConfigured(t *testing.T) {
+	t.Run("never configured", func(t *testing.T) {
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		statusMgr := status.NewManager(fakeClient)
+		if err := newFederationEgressReconciler(fakeClient).reconcileFederationEgress(context.Background(), serverWithFederationEgress(v1alpha2.FederationEgressPolicyNone), statusMgr, false); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fakeClient.GetUncachedCallCount() != 0 {
+			t.Error("Expected no lookup when no allowance was ever generated")
+		}
+		if _, ok := statusMgr.GetCondition(FederationEgressAllowed); ok {
+			t.Error("Expected no condition")
+		}
+	})
+
+	t.Run("deletes the NetworkPolicy", func(t *testing.T) {
+		t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		fakeClient.GetUncachedStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			policy, ok := obj.(*networkingv1.NetworkPolicy)
+			if !ok {
+				return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+			}
+			policy.Name = key.Name
+			policy.OwnerReferences = []metav1.OwnerReference{{UID: "server-uid", Controller: ptr.To(true)}}
+			return nil
+		}
+		server := serverWithFederationEgress(v1alpha2.FederationEgressPolicyNone)
+		server.Status.Conditions = []metav1.Condition{{Type: FederationEgressAllowed, Status: metav1.ConditionTrue}}
+		statusMgr := status.NewManager(fakeClient)
+
+		if err := newFederationEgressReconciler(fakeClient).reconcileFederationEgress(context.Background(), server, statusMgr, false); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fakeClient.DeleteCallCount() != 1 {
+			t.Errorf("Expected the NetworkPolicy to be deleted, got %d deletes", fakeClient.DeleteCallCount())
+		}
+		if cond, _ := statusMgr.GetCondition(FederationEgressAllowed); cond.Reason != FederationEgressReasonNotConfigured {
+			t.Errorf("Expected no allowance to be reported, got %s", cond.Reason)
+		}
+	})
+}
@@ -0,0 +1,184 @@
+package spire_server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	// ServerAPIHealthy reports the gRPC health service of the SPIRE server API
+	ServerAPIHealthy = "ServerAPIHealthy"
+
+	ServerAPIReasonServing     = "ServerAPIServing"
+	ServerAPIReasonFailing     = "ServerAPIHealthCheckFailing"
+	ServerAPIReasonOutage      = "ServerAPIOutage"
+	ServerAPIReasonUnavailable = "ServerAPIHealthUnavailable"
+
+	// serverAPIHealthCheckInterval is how often the server API is checked, as its health is not
+	// watched
+	serverAPIHealthCheckInterval = time.Minute
+
+	// serverAPIOutageThreshold is how long the health checks must fail before the outage is
+	// reported, so that a rollout of the server is not reported as an outage
+	serverAPIOutageThreshold = 3 * time.Minute
+
+	// serverAPIHealthCheckTimeout bounds the connection to the server and the health check
+	serverAPIHealthCheckTimeout = 5 * time.Second
+)
+
+// serverAPIHealthChecker checks the health of the SPIRE server API
+type serverAPIHealthChecker interface {
+	// Check returns an error unless the server at the address, authenticated as serverID by
+	// the roots, reports it is serving
+	Check(ctx context.Context, address string, roots *x509.CertPool, serverID string) error
+}
+
+// grpcHealthChecker calls the gRPC health service of the server through its Service
+type grpcHealthChecker struct{}
+
+func (grpcHealthChecker) Check(ctx context.Context, address string, roots *x509.CertPool, serverID string) error {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// X509-SVIDs carry no DNS name of the Service: the chain and the SPIFFE ID of the server
+		// are verified instead
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return verifyServerSVID(rawCerts, roots, serverID, time.Now())
+		},
+	}
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, serverAPIHealthCheckTimeout)
+	defer cancel()
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return err
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("server reports %s", resp.GetStatus())
+	}
+	return nil
+}
+
+// verifyServerSVID verifies the certificate chain presented by the server against the roots of
+// the trust bundle, and that it is the X509-SVID of the server
+func verifyServerSVID(rawCerts [][]byte, roots *x509.CertPool, serverID string, now time.Time) error {
+	if len(rawCerts) == 0 {
+		return errors.New("no certificate presented")
+	}
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+	for _, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("failed to parse the server certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}); err != nil {
+		return fmt.Errorf("the server certificate is not trusted by the bundle: %w", err)
+	}
+	for _, uri := range certs[0].URIs {
+		if uri.String() == serverID {
+			return nil
+		}
+	}
+	return fmt.Errorf("the server certificate is not the X509-SVID of %s", serverID)
+}
+
+// reconcileServerAPIHealth calls the gRPC health service of the server API through its Service
+// and sets the ServerAPIHealthy condition, catching a server whose pods are running but whose API
+// is wedged. Failures are tracked from status.serverAPIOutageSince and reported as an outage once
+// they last longer than serverAPIOutageThreshold.
+func (r *SpireServerReconciler) reconcileServerAPIHealth(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, now time.Time) {
+	if r.serverAPIHealth == nil {
+		return
+	}
+
+	// The server is authenticated by the bundle it publishes, which is missing until it served once
+	bundle := &corev1.ConfigMap{}
+	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: ztwim.Spec.BundleConfigMap, Namespace: utils.GetOperatorNamespace()}, bundle); err != nil && !kerrors.IsNotFound(err) {
+		statusMgr.AddCondition(ServerAPIHealthy, ServerAPIReasonUnavailable,
+			fmt.Sprintf("Failed to get bundle ConfigMap %s: %v", ztwim.Spec.BundleConfigMap, err),
+			metav1.ConditionUnknown)
+		return
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM([]byte(bundle.Data["bundle.crt"])) {
+		statusMgr.AddCondition(ServerAPIHealthy, ServerAPIReasonUnavailable,
+			"Waiting for the SPIRE server to publish its trust bundle",
+			metav1.ConditionUnknown)
+		return
+	}
+
+	serverID := fmt.Sprintf("spiffe://%s/spire/server", utils.TrustDomain(ztwim))
+	address := net.JoinHostPort(fmt.Sprintf("spire-server.%s.svc", utils.GetOperatorNamespace()), "443")
+	err := r.serverAPIHealth.Check(ctx, address, roots, serverID)
+	if err == nil {
+		server.Status.ServerAPIOutageSince = nil
+		statusMgr.AddCondition(ServerAPIHealthy, ServerAPIReasonServing,
+			"The SPIRE server API is serving",
+			metav1.ConditionTrue)
+		return
+	}
+
+	r.log.V(1).Info("SPIRE server API health check failed", "reason", err.Error())
+	if server.Status.ServerAPIOutageSince == nil {
+		server.Status.ServerAPIOutageSince = &metav1.Time{Time: now}
+	}
+	since := server.Status.ServerAPIOutageSince.UTC().Format(time.RFC3339)
+	if now.Sub(server.Status.ServerAPIOutageSince.Time) < serverAPIOutageThreshold {
+		statusMgr.AddCondition(ServerAPIHealthy, ServerAPIReasonFailing,
+			fmt.Sprintf("The health checks of the SPIRE server API are failing since %s: %v", since, err),
+			metav1.ConditionUnknown)
+		return
+	}
+	message := fmt.Sprintf("The SPIRE server API is not serving since %s: %v", since, err)
+	if !apimeta.IsStatusConditionFalse(server.Status.Conditions, ServerAPIHealthy) {
+		r.eventRecorder.Event(server, corev1.EventTypeWarning, ServerAPIReasonOutage, utils.WithRunbook(ServerAPIReasonOutage, message))
+	}
+	statusMgr.AddCondition(ServerAPIHealthy, ServerAPIReasonOutage, message, metav1.ConditionFalse)
+}
+
+// reportServerAPIOutage sets the Degraded condition with the duration of an ongoing outage of the
+// server API, which does not fail the reconciliation itself
+func reportServerAPIOutage(server *v1alpha2.SpireServer, statusMgr *status.Manager, now time.Time) {
+	if cond, found := statusMgr.GetCondition(ServerAPIHealthy); !found || cond.Reason != ServerAPIReasonOutage || server.Status.ServerAPIOutageSince == nil {
+		return
+	}
+	duration := now.Sub(server.Status.ServerAPIOutageSince.Time).Truncate(time.Minute)
+	statusMgr.AddCondition(v1alpha2.Degraded, utils.DegradedReasonServerAPIOutage,
+		fmt.Sprintf("The SPIRE server API has been down for %s", duration),
+		metav1.ConditionTrue)
+}
@@ -0,0 +1,217 @@
+package spire_server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// fakeServerAPIHealth answers the health checks with the given error
+type fakeServerAPIHealth struct {
+	err      error
+	serverID string
+}
+
+func (f *fakeServerAPIHealth) Check(_ context.Context, _ string, _ *x509.CertPool, serverID string) error {
+	f.serverID = serverID
+	return f.err
+}
+
+// newTestServerSVID returns a CA in PEM and the DER of an X509-SVID it signed for the SPIFFE ID
+func newTestServerSVID(t *testing.T, spiffeID string) ([]byte, []byte) {
+	t.Helper()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, _ := x509.ParseCertificate(caDER)
+
+	uri, _ := url.Parse(spiffeID)
+	leafKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	leafDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		URIs:         []*url.URL{uri},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, &leafKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}), leafDER
+}
+
+func TestVerifyServerSVID(t *testing.T) {
+	caPEM, leaf := newTestServerSVID(t, "spiffe://example.org/spire/server")
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(caPEM)
+	otherPEM, _ := newTestServerSVID(t, "spiffe://example.org/spire/server")
+	otherRoots := x509.NewCertPool()
+	otherRoots.AppendCertsFromPEM(otherPEM)
+
+	if err := verifyServerSVID([][]byte{leaf}, roots, "spiffe://example.org/spire/server", time.Now()); err != nil {
+		t.Errorf("Expected the server SVID to be trusted, got %v", err)
+	}
+	if err := verifyServerSVID([][]byte{leaf}, roots, "spiffe://other.org/spire/server", time.Now()); err == nil {
+		t.Error("Expected an SVID of another SPIFFE ID to be refused")
+	}
+	if err := verifyServerSVID([][]byte{leaf}, otherRoots, "spiffe://example.org/spire/server", time.Now()); err == nil {
+		t.Error("Expected an SVID not signed by the bundle to be refused")
+	}
+	if err := verifyServerSVID(nil, roots, "spiffe://example.org/spire/server", time.Now()); err == nil {
+		t.Error("Expected an error without certificate")
+	}
+}
+
+func TestReconcileServerAPIHealth(t *testing.T) {
+	caPEM, _ := newTestServerSVID(t, "spiffe://example.org/spire/server")
+	now := time.Now()
+
+	tests := []struct {
+		name           string
+		health         *fakeServerAPIHealth
+		bundle         string
+		outageSince    *metav1.Time
+		existingStatus metav1.ConditionStatus
+		expectReason   string
+		expectStatus   metav1.ConditionStatus
+		expectOutage   bool
+		expectEvent    bool
+		expectDegraded bool
+	}{
+		{
+			name:         "serving",
+			health:       &fakeServerAPIHealth{},
+			bundle:       string(caPEM),
+			outageSince:  &metav1.Time{Time: now.Add(-10 * time.Minute)},
+			expectReason: ServerAPIReasonServing,
+			expectStatus: metav1.ConditionTrue,
+		},
+		{
+			name:         "bundle not published",
+			health:       &fakeServerAPIHealth{},
+			expectReason: ServerAPIReasonUnavailable,
+			expectStatus: metav1.ConditionUnknown,
+		},
+		{
+			name:         "first failure",
+			health:       &fakeServerAPIHealth{err: errors.New("deadline exceeded")},
+			bundle:       string(caPEM),
+			expectReason: ServerAPIReasonFailing,
+			expectStatus: metav1.ConditionUnknown,
+			expectOutage: true,
+		},
+		{
+			name:           "sustained failures",
+			health:         &fakeServerAPIHealth{err: errors.New("deadline exceeded")},
+			bundle:         string(caPEM),
+			outageSince:    &metav1.Time{Time: now.Add(-5 * time.Minute)},
+			existingStatus: metav1.ConditionUnknown,
+			expectReason:   ServerAPIReasonOutage,
+			expectStatus:   metav1.ConditionFalse,
+			expectOutage:   true,
+			expectEvent:    true,
+			expectDegraded: true,
+		},
+		{
+			name:           "ongoing outage",
+			health:         &fakeServerAPIHealth{err: errors.New("deadline exceeded")},
+			bundle:         string(caPEM),
+			outageSince:    &metav1.Time{Time: now.Add(-10 * time.Minute)},
+			existingStatus: metav1.ConditionFalse,
+			expectReason:   ServerAPIReasonOutage,
+			expectStatus:   metav1.ConditionFalse,
+			expectOutage:   true,
+			expectDegraded: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				if tt.bundle != "" {
+					obj.(*corev1.ConfigMap).Data = map[string]string{"bundle.crt": tt.bundle}
+				}
+				return nil
+			}
+			recorder := record.NewFakeRecorder(10)
+			reconciler := &SpireServerReconciler{
+				ctrlClient:      fakeClient,
+				log:             logr.Discard(),
+				eventRecorder:   recorder,
+				serverAPIHealth: tt.health,
+			}
+			server := &v1alpha2.SpireServer{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}}
+			server.Status.ServerAPIOutageSince = tt.outageSince
+			if tt.existingStatus != "" {
+				server.Status.Conditions = []metav1.Condition{{Type: ServerAPIHealthy, Status: tt.existingStatus}}
+			}
+			ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+				Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.org", BundleConfigMap: "spire-bundle"},
+			}
+			statusMgr := status.NewManager(fakeClient)
+
+			reconciler.reconcileServerAPIHealth(context.Background(), server, statusMgr, ztwim, now)
+			reportServerAPIOutage(server, statusMgr, now)
+
+			cond, _ := statusMgr.GetCondition(ServerAPIHealthy)
+			if cond.Reason != tt.expectReason || cond.Status != tt.expectStatus {
+				t.Errorf("Expected %s/%s, got %s/%s: %s", tt.expectStatus, tt.expectReason, cond.Status, cond.Reason, cond.Message)
+			}
+			if outage := server.Status.ServerAPIOutageSince != nil; outage != tt.expectOutage {
+				t.Errorf("Expected outage tracked %v, got %v", tt.expectOutage, server.Status.ServerAPIOutageSince)
+			}
+			if tt.outageSince != nil && tt.expectOutage && !server.Status.ServerAPIOutageSince.Equal(tt.outageSince) {
+				t.Errorf("Expected the outage start kept, got %v", server.Status.ServerAPIOutageSince)
+			}
+			if events := len(recorder.Events); (events == 1) != tt.expectEvent {
+				t.Errorf("Expected event %v, got %d events", tt.expectEvent, events)
+			}
+			degraded, found := statusMgr.GetCondition(v1alpha2.Degraded)
+			if found != tt.expectDegraded {
+				t.Fatalf("Expected Degraded %v, got %v", tt.expectDegraded, degraded)
+			}
+			if found && (degraded.Reason != utils.DegradedReasonServerAPIOutage || !strings.Contains(degraded.Message, "down for")) {
+				t.Errorf("Expected the outage duration in Degraded, got %v", degraded)
+			}
+			if tt.expectReason != ServerAPIReasonUnavailable && tt.health.serverID != "spiffe://example.org/spire/server" {
+				t.Errorf("Expected the server authenticated as its SPIFFE ID, got %q", tt.health.serverID)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package spire_server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// validateHostAliases checks every host alias maps a valid IP address to at least one valid hostname
+func validateHostAliases(hostAliases []corev1.HostAlias) error {
+	for i, alias := range hostAliases {
+		if _, err := netip.ParseAddr(alias.IP); err != nil {
+			return fmt.Errorf("hostAliases[%d]: invalid IP address %q", i, alias.IP)
+		}
+		if len(alias.Hostnames) == 0 {
+			return fmt.Errorf("hostAliases[%d]: at least one hostname is required for %s", i, alias.IP)
+		}
+		for _, hostname := range alias.Hostnames {
+			if errs := validation.IsDNS1123Subdomain(hostname); len(errs) > 0 {
+				return fmt.Errorf("hostAliases[%d]: invalid hostname %q: %s", i, hostname, strings.Join(errs, "; "))
+			}
+		}
+	}
+	return nil
+}
+
+// hostAliasesConfigHashInput returns the host aliases to include in the config hash, so the server
+// is rolled when they change. It is empty without host aliases, which keeps the hash of existing
+// servers.
+func hostAliasesConfigHashInput(hostAliases []corev1.HostAlias) []byte {
+	if len(hostAliases) == 0 {
+		return nil
+	}
+	data, _ := json.Marshal(hostAliases)
+	return data
+}
@@ -0,0 +1,53 @@
+package spire_server
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+func TestValidateHostAliases(t *testing.T) {
+	tests := []struct {
+		name        string
+		hostAliases []corev1.HostAlias
+		expectErr   bool
+	}{
+		{name: "none"},
+		{name: "valid", hostAliases: []corev1.HostAlias{{IP: "192.0.2.10", Hostnames: []string{"spire.partner.example.com"}}, {IP: "2001:db8::1", Hostnames: []string{"bundle.lab"}}}},
+		{name: "invalid IP", hostAliases: []corev1.HostAlias{{IP: "spire.partner.example.com", Hostnames: []string{"bundle.lab"}}}, expectErr: true},
+		{name: "no hostname", hostAliases: []corev1.HostAlias{{IP: "192.0.2.10"}}, expectErr: true},
+		{name: "invalid hostname", hostAliases: []corev1.HostAlias{{IP: "192.0.2.10", Hostnames: []string{"Bundle_Endpoint"}}}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateHostAliases(tt.hostAliases)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestHostAliasesRollServer(t *testing.T) {
+	if input := hostAliasesConfigHashInput(nil); input != nil {
+		t.Errorf("Expected no hash input without host aliases, got %s", input)
+	}
+
+	hostAliases := []corev1.HostAlias{{IP: "192.0.2.10", Hostnames: []string{"spire.partner.example.com"}}}
+	if input := hostAliasesConfigHashInput(hostAliases); len(input) == 0 {
+		t.Error("Expected the host aliases in the hash input")
+	}
+
+	spec := &v1alpha2.SpireServerSpec{
+		Persistence: v1alpha2.Persistence{Size: "1Gi", AccessMode: "ReadWriteOnce"},
+		HostAliases: hostAliases,
+	}
+	sts := GenerateSpireServerStatefulSet(spec, "", "")
+	if !equality.Semantic.DeepEqual(sts.Spec.Template.Spec.HostAliases, hostAliases) {
+		t.Errorf("Expected the host aliases on the server pod, got %v", sts.Spec.Template.Spec.HostAliases)
+	}
+}
@@ -0,0 +1,265 @@
+package spire_server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// Reasons of the Events re-emitted from the server logs
+const (
+	LogEventReasonX509CAActivated = "X509CAActivated"
+	LogEventReasonJWTKeyActivated = "JWTKeyActivated"
+	LogEventReasonCAManagerError  = "CAManagerError"
+	LogEventReasonDatastoreError  = "DatastoreError"
+	LogEventReasonPluginError     = "PluginError"
+)
+
+const (
+	// serverContainerName is the container of the server pod whose logs are read
+	serverContainerName = "spire-server"
+
+	// logEventsLookback is how far back the logs are read after the operator starts
+	logEventsLookback = time.Minute
+
+	// maxLogEventsPerRead bounds the Events emitted for the entries of a single read
+	maxLogEventsPerRead = 20
+
+	// maxLogBytesPerRead bounds the logs read at once
+	maxLogBytesPerRead int64 = 1 << 20
+)
+
+// logEventFields are the fields of a log entry rendered into the Event, in this order
+var logEventFields = []string{"error", "plugin_name", "local_authority_id", "expiration"}
+
+// serverLogReader reads the logs of a container of a SPIRE server pod
+type serverLogReader interface {
+	// Logs returns the log lines prefixed with their timestamp, since the given time or the
+	// start of the container when nil
+	Logs(ctx context.Context, namespace, podName string, since *metav1.Time) ([]byte, error)
+}
+
+// podLogReader reads the logs through the pods/log subresource
+type podLogReader struct {
+	pods corev1client.PodsGetter
+}
+
+func (p *podLogReader) Logs(ctx context.Context, namespace, podName string, since *metav1.Time) ([]byte, error) {
+	limitBytes := maxLogBytesPerRead
+	return p.pods.Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{
+		Container:  serverContainerName,
+		Timestamps: true,
+		SinceTime:  since,
+		LimitBytes: &limitBytes,
+	}).DoRaw(ctx)
+}
+
+// logCursor remembers the last log entry read from the server pod, so entries are emitted once
+type logCursor struct {
+	mu     sync.Mutex
+	podUID types.UID
+	last   time.Time
+}
+
+// serverLogEntry is an entry of the server logs, in the text or json format
+type serverLogEntry struct {
+	time    time.Time
+	level   string
+	message string
+	fields  map[string]string
+}
+
+// logEvent is an Event re-emitted from a log entry
+type logEvent struct {
+	eventType string
+	reason    string
+	message   string
+}
+
+// parseServerLogLine parses a log line prefixed with its timestamp by the pods/log API
+func parseServerLogLine(line string) (serverLogEntry, bool) {
+	timestamp, rest, ok := strings.Cut(strings.TrimSpace(line), " ")
+	if !ok {
+		return serverLogEntry{}, false
+	}
+	ts, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return serverLogEntry{}, false
+	}
+	var fields map[string]string
+	if strings.HasPrefix(rest, "{") {
+		fields, ok = parseJSONFields(rest)
+	} else {
+		fields, ok = parseLogfmtFields(rest)
+	}
+	if !ok {
+		return serverLogEntry{}, false
+	}
+	entry := serverLogEntry{time: ts, level: fields["level"], message: fields["msg"], fields: fields}
+	delete(fields, "level")
+	delete(fields, "msg")
+	delete(fields, "time")
+	return entry, true
+}
+
+func parseJSONFields(line string) (map[string]string, bool) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &raw); err != nil {
+		return nil, false
+	}
+	fields := make(map[string]string, len(raw))
+	for key, value := range raw {
+		if s, ok := value.(string); ok {
+			fields[key] = s
+			continue
+		}
+		fields[key] = fmt.Sprint(value)
+	}
+	return fields, true
+}
+
+// parseLogfmtFields parses the key=value pairs of the text format, whose values are quoted when
+// they contain spaces
+func parseLogfmtFields(line string) (map[string]string, bool) {
+	fields := map[string]string{}
+	for line = strings.TrimSpace(line); line != ""; line = strings.TrimSpace(line) {
+		key, rest, ok := strings.Cut(line, "=")
+		if !ok || key == "" || strings.Contains(key, " ") {
+			return nil, false
+		}
+		if !strings.HasPrefix(rest, `"`) {
+			value, remaining, _ := strings.Cut(rest, " ")
+			fields[key], line = value, remaining
+			continue
+		}
+		end := 1
+		for end < len(rest) && rest[end] != '"' {
+			if rest[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(rest) {
+			return nil, false
+		}
+		value, err := strconv.Unquote(rest[:end+1])
+		if err != nil {
+			return nil, false
+		}
+		fields[key], line = value, rest[end+1:]
+	}
+	return fields, true
+}
+
+// classifyLogEntry returns the Event re-emitted for a significant log entry
+func classifyLogEntry(entry serverLogEntry) (logEvent, bool) {
+	subsystem := entry.fields["subsystem_name"]
+	isError := entry.level == "error" || entry.level == "fatal"
+	var event logEvent
+	switch {
+	case entry.message == "X509 CA activated":
+		event = logEvent{eventType: corev1.EventTypeNormal, reason: LogEventReasonX509CAActivated}
+	case entry.message == "JWT key activated":
+		event = logEvent{eventType: corev1.EventTypeNormal, reason: LogEventReasonJWTKeyActivated}
+	case !isError:
+		return logEvent{}, false
+	case entry.fields["plugin_type"] == "DataStore" || strings.Contains(subsystem, "sql") || strings.Contains(subsystem, "datastore"):
+		event = logEvent{eventType: corev1.EventTypeWarning, reason: LogEventReasonDatastoreError}
+	case subsystem == "ca_manager" || subsystem == "ca":
+		event = logEvent{eventType: corev1.EventTypeWarning, reason: LogEventReasonCAManagerError}
+	case entry.fields["plugin_name"] != "":
+		event = logEvent{eventType: corev1.EventTypeWarning, reason: LogEventReasonPluginError}
+	default:
+		return logEvent{}, false
+	}
+	message := entry.message
+	for _, key := range logEventFields {
+		if value := entry.fields[key]; value != "" {
+			message += fmt.Sprintf(", %s=%s", key, value)
+		}
+	}
+	if len(message) > maxPluginMessageLength {
+		message = message[:maxPluginMessageLength]
+	}
+	event.message = message
+	return event, true
+}
+
+// reconcileLogEvents re-emits the significant entries logged by the server since the last read
+// as Events on the SpireServer. Entries are read from the start of a new server pod, or from
+// logEventsLookback ago after the operator starts. The check is best effort.
+func (r *SpireServerReconciler) reconcileLogEvents(ctx context.Context, server *v1alpha2.SpireServer) {
+	if r.serverLogs == nil || r.logCursor == nil || !ptr.Deref(server.Spec.LogEvents, false) {
+		return
+	}
+	var pod corev1.Pod
+	if err := r.ctrlClient.GetUncached(ctx, types.NamespacedName{Name: spireServerPodName, Namespace: utils.GetOperatorNamespace()}, &pod); err != nil {
+		r.log.V(1).Info("server logs not available", "reason", err.Error())
+		return
+	}
+
+	r.logCursor.mu.Lock()
+	defer r.logCursor.mu.Unlock()
+	var since *metav1.Time
+	switch {
+	case r.logCursor.podUID == "":
+		since = &metav1.Time{Time: time.Now().Add(-logEventsLookback)}
+	case r.logCursor.podUID == pod.UID && !r.logCursor.last.IsZero():
+		since = &metav1.Time{Time: r.logCursor.last}
+	}
+	logs, err := r.serverLogs.Logs(ctx, pod.Namespace, pod.Name, since)
+	if err != nil {
+		r.log.V(1).Info("server logs not available", "reason", err.Error())
+		return
+	}
+	if r.logCursor.podUID != pod.UID {
+		r.logCursor.podUID, r.logCursor.last = pod.UID, time.Time{}
+		if since != nil {
+			r.logCursor.last = since.Time
+		}
+	}
+
+	emitted, dropped := 0, 0
+	scanner := bufio.NewScanner(bytes.NewReader(logs))
+	scanner.Buffer(make([]byte, 64*1024), int(maxLogBytesPerRead))
+	for scanner.Scan() {
+		entry, ok := parseServerLogLine(scanner.Text())
+		// The logs are read from the second of the last entry, skip the entries already read
+		if !ok || !entry.time.After(r.logCursor.last) {
+			continue
+		}
+		r.logCursor.last = entry.time
+		event, significant := classifyLogEntry(entry)
+		if !significant {
+			continue
+		}
+		if emitted == maxLogEventsPerRead {
+			dropped++
+			continue
+		}
+		message := event.message
+		if event.eventType == corev1.EventTypeWarning {
+			message = utils.WithRunbook(event.reason, message)
+		}
+		r.eventRecorder.Event(server, event.eventType, event.reason, message)
+		emitted++
+	}
+	if dropped > 0 {
+		r.log.Info("too many significant server log entries, not all re-emitted as Events", "dropped", dropped)
+	}
+}
@@ -0,0 +1,167 @@
+package spire_server
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+)
+
+// fakeServerLogs serves the given logs, recording the time they were read since
+type fakeServerLogs struct {
+	logs  string
+	since []*metav1.Time
+}
+
+func (f *fakeServerLogs) Logs(_ context.Context, _, _ string, since *metav1.Time) ([]byte, error) {
+	f.since = append(f.since, since)
+	return []byte(f.logs), nil
+}
+
+func TestParseServerLogLine(t *testing.T) {
+	text := `2025-03-01T10:00:00.5Z time="2025-03-01T10:00:00Z" level=info msg="X509 CA activated" expiration="2025-03-02 10:00:00 +0000 UTC" local_authority_id=ab12 subsystem_name=ca_manager`
+	entry, ok := parseServerLogLine(text)
+	if !ok {
+		t.Fatalf("Expected the text line to be parsed")
+	}
+	if entry.level != "info" || entry.message != "X509 CA activated" || entry.fields["expiration"] != "2025-03-02 10:00:00 +0000 UTC" || entry.fields["subsystem_name"] != "ca_manager" {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+	if !entry.time.Equal(time.Date(2025, 3, 1, 10, 0, 0, 500000000, time.UTC)) {
+		t.Errorf("Expected the timestamp of the pods/log API, got %s", entry.time)
+	}
+
+	jsonLine := `2025-03-01T10:00:01Z {"level":"error","msg":"Failed to prune","error":"database is locked","plugin_type":"DataStore","attempt":3}`
+	entry, ok = parseServerLogLine(jsonLine)
+	if !ok || entry.level != "error" || entry.fields["error"] != "database is locked" || entry.fields["attempt"] != "3" {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+
+	for _, line := range []string{"", "not a timestamp", `2025-03-01T10:00:01Z msg="unterminated`, "2025-03-01T10:00:01Z {broken"} {
+		if _, ok := parseServerLogLine(line); ok {
+			t.Errorf("Expected %q not to be parsed", line)
+		}
+	}
+}
+
+func TestClassifyLogEntry(t *testing.T) {
+	tests := []struct {
+		name          string
+		entry         serverLogEntry
+		expectReason  string
+		expectMessage string
+	}{
+		{
+			name:          "X509 CA activation",
+			entry:         serverLogEntry{level: "info", message: "X509 CA activated", fields: map[string]string{"local_authority_id": "ab12"}},
+			expectReason:  LogEventReasonX509CAActivated,
+			expectMessage: "X509 CA activated, local_authority_id=ab12",
+		},
+		{
+			name:         "JWT key activation",
+			entry:        serverLogEntry{level: "info", message: "JWT key activated", fields: map[string]string{}},
+			expectReason: LogEventReasonJWTKeyActivated,
+		},
+		{
+			name:          "datastore error",
+			entry:         serverLogEntry{level: "error", message: "Failed to prune", fields: map[string]string{"plugin_type": "DataStore", "plugin_name": "sql", "error": "database is locked"}},
+			expectReason:  LogEventReasonDatastoreError,
+			expectMessage: "Failed to prune, error=database is locked, plugin_name=sql",
+		},
+		{
+			name:         "CA manager error",
+			entry:        serverLogEntry{level: "error", message: "Unable to prepare X509 CA", fields: map[string]string{"subsystem_name": "ca_manager"}},
+			expectReason: LogEventReasonCAManagerError,
+		},
+		{
+			name:         "plugin error",
+			entry:        serverLogEntry{level: "error", message: "Failed to notify", fields: map[string]string{"plugin_name": "k8sbundle"}},
+			expectReason: LogEventReasonPluginError,
+		},
+		{
+			name:  "insignificant error",
+			entry: serverLogEntry{level: "error", message: "Failed to attest", fields: map[string]string{"subsystem_name": "api"}},
+		},
+		{
+			name:  "insignificant warning",
+			entry: serverLogEntry{level: "warning", message: "Plugin slow", fields: map[string]string{"plugin_name": "k8sbundle"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, ok := classifyLogEntry(tt.entry)
+			if ok != (tt.expectReason != "") || event.reason != tt.expectReason {
+				t.Fatalf("Expected reason %q, got %+v", tt.expectReason, event)
+			}
+			if tt.expectMessage != "" && event.message != tt.expectMessage {
+				t.Errorf("Expected message %q, got %q", tt.expectMessage, event.message)
+			}
+		})
+	}
+}
+
+func TestReconcileLogEvents(t *testing.T) {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	fakeClient.GetUncachedStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		pod := obj.(*corev1.Pod)
+		pod.Name, pod.Namespace, pod.UID = key.Name, key.Namespace, "server-pod-uid"
+		return nil
+	}
+	start := time.Now().UTC().Truncate(time.Second)
+	at := func(offset time.Duration) string { return start.Add(offset).Format(time.RFC3339Nano) }
+	logs := &fakeServerLogs{logs: strings.Join([]string{
+		at(0) + ` level=info msg="X509 CA activated" subsystem_name=ca_manager`,
+		at(time.Second) + ` level=info msg="Agent attestation request completed"`,
+		at(2*time.Second) + ` level=error msg="Failed to prune" error="database is locked" plugin_type=DataStore`,
+	}, "\n")}
+	recorder := record.NewFakeRecorder(10)
+	reconciler := &SpireServerReconciler{
+		ctrlClient:    fakeClient,
+		log:           logr.Discard(),
+		eventRecorder: recorder,
+		serverLogs:    logs,
+		logCursor:     &logCursor{},
+	}
+	server := &v1alpha2.SpireServer{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}, Spec: v1alpha2.SpireServerSpec{LogEvents: ptr.To(true)}}
+
+	reconciler.reconcileLogEvents(context.Background(), server)
+	if len(recorder.Events) != 2 {
+		t.Fatalf("Expected 2 Events, got %d", len(recorder.Events))
+	}
+	if event := <-recorder.Events; !strings.HasPrefix(event, "Normal X509CAActivated X509 CA activated") {
+		t.Errorf("Unexpected Event %q", event)
+	}
+	if event := <-recorder.Events; !strings.HasPrefix(event, "Warning DatastoreError Failed to prune, error=database is locked") {
+		t.Errorf("Unexpected Event %q", event)
+	}
+	if logs.since[0] == nil {
+		t.Errorf("Expected the logs read from the lookback after the operator starts")
+	}
+
+	// The entries already read are not emitted again
+	reconciler.reconcileLogEvents(context.Background(), server)
+	if len(recorder.Events) != 0 {
+		t.Errorf("Expected no Event for the entries already read, got %d", len(recorder.Events))
+	}
+	if expected := start.Add(2 * time.Second); logs.since[1] == nil || !logs.since[1].Time.Equal(expected) {
+		t.Errorf("Expected the logs read since %s, got %v", expected, logs.since[1])
+	}
+
+	// Disabled by default
+	server.Spec.LogEvents = nil
+	reconciler.reconcileLogEvents(context.Background(), server)
+	if len(logs.since) != 2 {
+		t.Errorf("Expected the logs not read when disabled, got %d reads", len(logs.since))
+	}
+}
@@ -0,0 +1,168 @@
+package spire_server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	spiffev1alpha1 "github.com/spiffe/spire-controller-manager/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	// nodeAliasLabelKey labels the ClusterStaticEntries of the agent aliases with their alias name
+	nodeAliasLabelKey = "ztwim.openshift.io/node-alias"
+
+	// nodeAliasesCheckInterval is how often the node labels are read again while node label
+	// selectors are set, as the nodes are not watched
+	nodeAliasesCheckInterval = 10 * time.Minute
+
+	// Node alias reasons
+	NodeAliasesReasonNotConfigured = "NodeLabelSelectorsNotConfigured"
+	NodeAliasesReasonReady         = "NodeAliasesReady"
+	NodeAliasesReasonFailed        = "NodeAliasesFailed"
+)
+
+// reconcileNodeAliases maintains a ClusterStaticEntry aliasing the agents of the nodes sharing
+// the value of each node label selector, rendered into SPIRE by spire-controller-manager. The
+// aliases are matched on the k8s_psat:agent_node_label selectors the server adds to the agents.
+func (r *SpireServerReconciler) reconcileNodeAliases(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, createOnlyMode bool) error {
+	selectors := utils.PSATNodeLabelSelectors(&server.Spec)
+
+	var desired []*spiffev1alpha1.ClusterStaticEntry
+	if len(selectors) > 0 {
+		nodes := &metav1.PartialObjectMetadataList{}
+		nodes.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("NodeList"))
+		if err := r.ctrlClient.ListUncached(ctx, nodes); err != nil {
+			r.log.Error(err, "failed to list nodes for the agent aliases")
+			statusMgr.AddCondition(NodeAliasesAvailable, NodeAliasesReasonFailed,
+				fmt.Sprintf("Failed to list nodes: %v", err),
+				metav1.ConditionFalse)
+			return fmt.Errorf("failed to list nodes: %w", err)
+		}
+		desired = generateNodeAliases(selectors, nodes.Items, server.Spec.Labels, ztwim)
+	}
+
+	var existing spiffev1alpha1.ClusterStaticEntryList
+	if err := r.ctrlClient.ListUncached(ctx, &existing, client.HasLabels{nodeAliasLabelKey}, client.MatchingLabels{utils.AppManagedByLabelKey: utils.AppManagedByLabelValue}); err != nil {
+		r.log.Error(err, "failed to list the agent aliases")
+		statusMgr.AddCondition(NodeAliasesAvailable, NodeAliasesReasonFailed,
+			fmt.Sprintf("Failed to list ClusterStaticEntries: %v", err),
+			metav1.ConditionFalse)
+		return fmt.Errorf("failed to list ClusterStaticEntries: %w", err)
+	}
+	existingByName := map[string]*spiffev1alpha1.ClusterStaticEntry{}
+	for i := range existing.Items {
+		existingByName[existing.Items[i].Name] = &existing.Items[i]
+	}
+
+	for _, entry := range desired {
+		if err := controllerutil.SetControllerReference(server, entry, r.scheme); err != nil {
+			r.log.Error(err, "failed to set controller reference on agent alias", "name", entry.Name)
+			statusMgr.AddCondition(NodeAliasesAvailable, NodeAliasesReasonFailed, err.Error(), metav1.ConditionFalse)
+			return err
+		}
+		current, ok := existingByName[entry.Name]
+		delete(existingByName, entry.Name)
+		switch {
+		case !ok:
+			if err := r.ctrlClient.Create(ctx, entry); err != nil {
+				r.log.Error(err, "failed to create agent alias", "name", entry.Name)
+				statusMgr.AddCondition(NodeAliasesAvailable, NodeAliasesReasonFailed,
+					fmt.Sprintf("Failed to create agent alias %s: %v", entry.Spec.SPIFFEID, err),
+					metav1.ConditionFalse)
+				return fmt.Errorf("failed to create ClusterStaticEntry %s: %w", entry.Name, err)
+			}
+			r.log.Info("Created agent alias", "name", entry.Name, "spiffeID", entry.Spec.SPIFFEID)
+		case createOnlyMode:
+			r.log.Info("Skipping agent alias update due to create-only mode", "name", entry.Name)
+		case utils.ResourceNeedsUpdate(current, entry):
+			entry.ResourceVersion = current.ResourceVersion
+			if err := r.ctrlClient.Update(ctx, entry); err != nil {
+				r.log.Error(err, "failed to update agent alias", "name", entry.Name)
+				statusMgr.AddCondition(NodeAliasesAvailable, NodeAliasesReasonFailed,
+					fmt.Sprintf("Failed to update agent alias %s: %v", entry.Spec.SPIFFEID, err),
+					metav1.ConditionFalse)
+				return fmt.Errorf("failed to update ClusterStaticEntry %s: %w", entry.Name, err)
+			}
+			r.log.Info("Updated agent alias", "name", entry.Name)
+		}
+	}
+
+	// Remove the aliases of the values no node carries anymore, and of removed selectors
+	for _, stale := range existingByName {
+		if err := r.ctrlClient.Delete(ctx, stale); err != nil && !kerrors.IsNotFound(err) {
+			r.log.Error(err, "failed to delete agent alias", "name", stale.Name)
+			statusMgr.AddCondition(NodeAliasesAvailable, NodeAliasesReasonFailed,
+				fmt.Sprintf("Failed to delete agent alias %s: %v", stale.Spec.SPIFFEID, err),
+				metav1.ConditionFalse)
+			return fmt.Errorf("failed to delete ClusterStaticEntry %s: %w", stale.Name, err)
+		}
+		r.log.Info("Deleted agent alias", "name", stale.Name)
+	}
+
+	if len(selectors) == 0 {
+		// Only report if node label selectors were previously configured
+		if apimeta.FindStatusCondition(server.Status.Conditions, NodeAliasesAvailable) != nil {
+			statusMgr.AddCondition(NodeAliasesAvailable, NodeAliasesReasonNotConfigured,
+				"No node label is exposed as agent selector",
+				metav1.ConditionTrue)
+		}
+		return nil
+	}
+	statusMgr.AddCondition(NodeAliasesAvailable, NodeAliasesReasonReady,
+		fmt.Sprintf("%d agent aliases for the node labels %v", len(desired), utils.PSATNodeLabelKeys(&server.Spec)),
+		metav1.ConditionTrue)
+	return nil
+}
+
+// generateNodeAliases returns a ClusterStaticEntry per value of each node label selector found
+// on the nodes, sorted by name
+func generateNodeAliases(selectors []v1alpha2.NodeLabelSelector, nodes []metav1.PartialObjectMetadata, customLabels map[string]string, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager) []*spiffev1alpha1.ClusterStaticEntry {
+	var entries []*spiffev1alpha1.ClusterStaticEntry
+	for _, selector := range selectors {
+		values := map[string]bool{}
+		for _, node := range nodes {
+			if value := node.Labels[selector.LabelKey]; value != "" {
+				values[value] = true
+			}
+		}
+		for value := range values {
+			labels := utils.SpireServerLabels(customLabels)
+			labels[nodeAliasLabelKey] = selector.AliasName
+			entries = append(entries, &spiffev1alpha1.ClusterStaticEntry{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   nodeAliasName(selector.AliasName, value),
+					Labels: labels,
+				},
+				Spec: spiffev1alpha1.ClusterStaticEntrySpec{
+					SPIFFEID: fmt.Sprintf("spiffe://%s/spire/agent/k8s_psat/%s/%s/%s", utils.TrustDomain(ztwim), ztwim.Spec.ClusterName, selector.AliasName, value),
+					ParentID: fmt.Sprintf("spiffe://%s/spire/server", utils.TrustDomain(ztwim)),
+					Selectors: []string{
+						"k8s_psat:cluster:" + ztwim.Spec.ClusterName,
+						fmt.Sprintf("k8s_psat:agent_node_label:%s:%s", selector.LabelKey, value),
+					},
+					ClassName: spireControllerManagerClassName,
+				},
+			})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries
+}
+
+// nodeAliasName returns the name of the ClusterStaticEntry of an alias. Label values may hold
+// upper case letters and underscores, so the value is hashed rather than used in the name.
+func nodeAliasName(aliasName, value string) string {
+	return fmt.Sprintf("zero-trust-workload-identity-manager-node-alias-%s-%s", aliasName, utils.GenerateMapHash(map[string]string{aliasName: value})[:10])
+}
@@ -0,0 +1,156 @@
+package spire_server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	spiffev1alpha1 "github.com/spiffe/spire-controller-manager/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+)
+
+var zoneSelector = v1alpha2.NodeLabelSelector{LabelKey: "topology.kubernetes.io/zone", AliasName: "zone"}
+
+// nodeAliasesClient lists nodes in the given zones and the existing agent aliases
+func nodeAliasesClient(zones []string, existing []spiffev1alpha1.ClusterStaticEntry) *fakes.FakeCustomCtrlClient {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	fakeClient.ListUncachedStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+		switch l := list.(type) {
+		case *metav1.PartialObjectMetadataList:
+			for i, zone := range zones {
+				node := metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "node-" + string(rune('a'+i)), Labels: map[string]string{zoneSelector.LabelKey: zone}}}
+				l.Items = append(l.Items, node)
+			}
+		case *spiffev1alpha1.ClusterStaticEntryList:
+			l.Items = existing
+		}
+		return nil
+	}
+	return fakeClient
+}
+
+func TestGenerateNodeAliases(t *testing.T) {
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.org", ClusterName: "prod"}}
+	nodes := []metav1.PartialObjectMetadata{
+		{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{zoneSelector.LabelKey: "us-east-1a"}}},
+		{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{zoneSelector.LabelKey: "us-east-1a"}}},
+		{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"kubernetes.io/hostname": "unzoned"}}},
+	}
+
+	entries := generateNodeAliases([]v1alpha2.NodeLabelSelector{zoneSelector}, nodes, nil, ztwim)
+	if len(entries) != 1 {
+		t.Fatalf("Expected one alias per zone, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry.Spec.SPIFFEID != "spiffe://example.org/spire/agent/k8s_psat/prod/zone/us-east-1a" {
+		t.Errorf("Unexpected alias SPIFFE ID %s", entry.Spec.SPIFFEID)
+	}
+	if entry.Spec.ParentID != "spiffe://example.org/spire/server" || entry.Spec.ClassName != spireControllerManagerClassName {
+		t.Errorf("Expected a node alias of the operator's class, got parent %s and class %s", entry.Spec.ParentID, entry.Spec.ClassName)
+	}
+	expectedSelectors := []string{"k8s_psat:cluster:prod", "k8s_psat:agent_node_label:topology.kubernetes.io/zone:us-east-1a"}
+	if len(entry.Spec.Selectors) != 2 || entry.Spec.Selectors[0] != expectedSelectors[0] || entry.Spec.Selectors[1] != expectedSelectors[1] {
+		t.Errorf("Expected selectors %v, got %v", expectedSelectors, entry.Spec.Selectors)
+	}
+	if entry.Labels[nodeAliasLabelKey] != "zone" {
+		t.Errorf("Expected the alias label, got %v", entry.Labels)
+	}
+}
+
+func TestReconcileNodeAliases(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1alpha2.AddToScheme(scheme)
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.org", ClusterName: "prod"}}
+	staleAlias := spiffev1alpha1.ClusterStaticEntry{ObjectMeta: metav1.ObjectMeta{Name: nodeAliasName("zone", "us-east-1c")}}
+	currentAlias := generateNodeAliases([]v1alpha2.NodeLabelSelector{zoneSelector},
+		[]metav1.PartialObjectMetadata{{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{zoneSelector.LabelKey: "us-east-1a"}}}}, nil, ztwim)[0]
+
+	tests := []struct {
+		name         string
+		selectors    []v1alpha2.NodeLabelSelector
+		zones        []string
+		existing     []spiffev1alpha1.ClusterStaticEntry
+		conditions   []metav1.Condition
+		expectCreate int
+		expectDelete int
+		expectReason string
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name:         "selectors removed",
+			existing:     []spiffev1alpha1.ClusterStaticEntry{staleAlias},
+			conditions:   []metav1.Condition{{Type: NodeAliasesAvailable, Status: metav1.ConditionTrue}},
+			expectDelete: 1,
+			expectReason: NodeAliasesReasonNotConfigured,
+		},
+		{
+			name:         "aliases created per zone",
+			selectors:    []v1alpha2.NodeLabelSelector{zoneSelector},
+			zones:        []string{"us-east-1a", "us-east-1b", "us-east-1a"},
+			expectCreate: 2,
+			expectReason: NodeAliasesReasonReady,
+		},
+		{
+			name:         "alias of a drained zone deleted",
+			selectors:    []v1alpha2.NodeLabelSelector{zoneSelector},
+			zones:        []string{"us-east-1a"},
+			existing:     []spiffev1alpha1.ClusterStaticEntry{*currentAlias, staleAlias},
+			expectDelete: 1,
+			expectReason: NodeAliasesReasonReady,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := nodeAliasesClient(tt.zones, tt.existing)
+			reconciler := &SpireServerReconciler{ctrlClient: fakeClient, ctx: context.Background(), log: logr.Discard(), scheme: scheme}
+			server := &v1alpha2.SpireServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec:       v1alpha2.SpireServerSpec{PSAT: &v1alpha2.PSATAttestationConfig{NodeLabelSelectors: tt.selectors}},
+				Status:     v1alpha2.SpireServerStatus{ConditionalStatus: v1alpha2.ConditionalStatus{Conditions: tt.conditions}},
+			}
+			statusMgr := status.NewManager(fakeClient)
+
+			if err := reconciler.reconcileNodeAliases(context.Background(), server, statusMgr, ztwim, false); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if fakeClient.CreateCallCount() != tt.expectCreate || fakeClient.DeleteCallCount() != tt.expectDelete {
+				t.Errorf("Expected %d creates and %d deletes, got %d and %d", tt.expectCreate, tt.expectDelete, fakeClient.CreateCallCount(), fakeClient.DeleteCallCount())
+			}
+			if fakeClient.UpdateCallCount() != 0 {
+				t.Errorf("Expected the current alias not to be updated, got %d updates", fakeClient.UpdateCallCount())
+			}
+			cond, ok := statusMgr.GetCondition(NodeAliasesAvailable)
+			if tt.expectReason == "" {
+				if ok {
+					t.Errorf("Expected no condition, got %v", cond)
+				}
+				return
+			}
+			if cond.Reason != tt.expectReason || cond.Status != metav1.ConditionTrue {
+				t.Errorf("Expected True/%s, got %s/%s: %s", tt.expectReason, cond.Status, cond.Reason, cond.Message)
+			}
+		})
+	}
+}
+
+func TestGenerateServerConfMap_NodeLabelKeys(t *testing.T) {
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.org", ClusterName: "prod"}}
+	config := &v1alpha2.SpireServerSpec{PSAT: &v1alpha2.PSATAttestationConfig{NodeLabelSelectors: []v1alpha2.NodeLabelSelector{zoneSelector}}}
+
+	confMap := generateServerConfMap(config, ztwim)
+	psat := confMap["plugins"].(map[string]interface{})["NodeAttestor"].([]map[string]interface{})[0]["k8s_psat"].(map[string]interface{})
+	cluster := psat["plugin_data"].(map[string]interface{})["clusters"].([]map[string]interface{})[0]["prod"].(map[string]interface{})
+	keys := cluster["allowed_node_label_keys"].([]string)
+	if len(keys) != 1 || keys[0] != zoneSelector.LabelKey {
+		t.Errorf("Expected the node label keys to be allowed, got %v", keys)
+	}
+}
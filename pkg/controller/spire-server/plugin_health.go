@@ -0,0 +1,206 @@
+package spire_server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	// PluginsHealthy is the condition summarizing the status of the server plugins
+	PluginsHealthy = "PluginsHealthy"
+
+	PluginsHealthyReasonHealthy     = "PluginsHealthy"
+	PluginsHealthyReasonUnhealthy   = "PluginUnhealthy"
+	PluginsHealthyReasonUnavailable = "PluginHealthUnavailable"
+
+	// serverHealthPort and serverReadyPath are the health listener of server.conf
+	serverHealthPort = "8080"
+	serverReadyPath  = "ready"
+
+	// pluginHealthCheckInterval is how often the health of the plugins is read again, as it is
+	// not watched
+	pluginHealthCheckInterval = 5 * time.Minute
+
+	// maxPluginMessageLength is the maximum length of the message of a plugin status
+	maxPluginMessageLength = 1024
+)
+
+// pluginHealthSubsystems are the health subsystems of the SPIRE server exercising each plugin
+// type. Plugins of the other types have no health check: the server exits when they fail to
+// load, so they are healthy as long as the server answers.
+var pluginHealthSubsystems = map[string]string{
+	"DataStore":         "catalog.datastore",
+	"KeyManager":        "server.ca",
+	"UpstreamAuthority": "server.ca.manager",
+}
+
+// serverHealthReader reads the readiness details of a SPIRE server pod
+type serverHealthReader interface {
+	// Readiness returns the health details served on the ready endpoint, ready or not
+	Readiness(ctx context.Context, namespace, podName string) ([]byte, error)
+}
+
+// podProxyHealthReader reads the health endpoint through the pods/proxy subresource, so the
+// operator does not need network access to the server pod
+type podProxyHealthReader struct {
+	restClient rest.Interface
+}
+
+func (p *podProxyHealthReader) Readiness(ctx context.Context, namespace, podName string) ([]byte, error) {
+	result := p.restClient.Get().Namespace(namespace).Resource("pods").Name(podName + ":" + serverHealthPort).
+		SubResource("proxy").Suffix(serverReadyPath).Do(ctx)
+	var code int
+	result.StatusCode(&code)
+	body, err := result.Raw()
+	// The server answers 500 with the failing subsystems when it is not ready
+	if code != http.StatusOK && code != http.StatusInternalServerError {
+		if err == nil {
+			err = fmt.Errorf("unexpected status code %d", code)
+		}
+		return nil, err
+	}
+	return body, nil
+}
+
+// configuredPlugins returns the plugins of the server configuration, sorted by type and name
+func configuredPlugins(confMap map[string]interface{}) []v1alpha2.PluginStatus {
+	var plugins []v1alpha2.PluginStatus
+	pluginTypes, _ := confMap["plugins"].(map[string]interface{})
+	for pluginType, entries := range pluginTypes {
+		list, _ := entries.([]map[string]interface{})
+		for _, entry := range list {
+			for name := range entry {
+				plugins = append(plugins, v1alpha2.PluginStatus{Type: pluginType, Name: name})
+			}
+		}
+	}
+	sort.Slice(plugins, func(i, j int) bool {
+		if plugins[i].Type != plugins[j].Type {
+			return plugins[i].Type < plugins[j].Type
+		}
+		return plugins[i].Name < plugins[j].Name
+	})
+	return plugins
+}
+
+// subsystemError returns the error reported in the health details of a subsystem, if any. The
+// details differ per subsystem, but errors are reported in keys ending with err or error.
+func subsystemError(details map[string]interface{}) string {
+	keys := make([]string, 0, len(details))
+	for key := range details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var errs []string
+	for _, key := range keys {
+		value, ok := details[key].(string)
+		if !ok || value == "" {
+			continue
+		}
+		lower := strings.ToLower(key)
+		if strings.HasSuffix(lower, "err") || strings.HasSuffix(lower, "error") {
+			errs = append(errs, value)
+		}
+	}
+	return strings.Join(errs, "; ")
+}
+
+// pluginStatuses sets the status of each plugin from the readiness details of the server
+func pluginStatuses(plugins []v1alpha2.PluginStatus, body []byte) ([]v1alpha2.PluginStatus, error) {
+	var details map[string]map[string]interface{}
+	if err := json.Unmarshal(body, &details); err != nil {
+		return nil, fmt.Errorf("failed to decode the server health details: %w", err)
+	}
+	statuses := make([]v1alpha2.PluginStatus, len(plugins))
+	for i, plugin := range plugins {
+		statuses[i] = plugin
+		subsystem, checked := pluginHealthSubsystems[plugin.Type]
+		if !checked {
+			statuses[i].Status = v1alpha2.PluginHealthy
+			statuses[i].Message = "Loaded"
+			continue
+		}
+		if errMessage := subsystemError(details[subsystem]); errMessage != "" {
+			statuses[i].Status = v1alpha2.PluginUnhealthy
+			statuses[i].Message = fmt.Sprintf("%s health check failed: %s", subsystem, errMessage)
+			if len(statuses[i].Message) > maxPluginMessageLength {
+				statuses[i].Message = statuses[i].Message[:maxPluginMessageLength]
+			}
+			continue
+		}
+		statuses[i].Status = v1alpha2.PluginHealthy
+		statuses[i].Message = fmt.Sprintf("%s health check passing", subsystem)
+	}
+	return statuses, nil
+}
+
+// reconcilePluginHealth reports the status of each server plugin in the SpireServer status, so
+// a failing plugin is visible without reading the server logs. The check is best effort: the
+// plugins are Unknown while the server cannot be reached.
+func (r *SpireServerReconciler) reconcilePluginHealth(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager) {
+	if r.serverHealth == nil {
+		return
+	}
+	plugins := configuredPlugins(generateServerConfMap(&server.Spec, ztwim))
+
+	statuses, err := r.readPluginStatuses(ctx, plugins)
+	if err != nil {
+		r.log.V(1).Info("plugin health not available", "reason", err.Error())
+		for i := range plugins {
+			plugins[i].Status = v1alpha2.PluginUnknown
+		}
+		server.Status.Plugins = plugins
+		statusMgr.AddCondition(PluginsHealthy, PluginsHealthyReasonUnavailable,
+			fmt.Sprintf("Plugin health is not available: %v", err),
+			metav1.ConditionUnknown)
+		return
+	}
+	server.Status.Plugins = statuses
+
+	var unhealthy []string
+	for _, plugin := range statuses {
+		if plugin.Status == v1alpha2.PluginUnhealthy {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s %s: %s", plugin.Type, plugin.Name, plugin.Message))
+		}
+	}
+	if len(unhealthy) > 0 {
+		statusMgr.AddCondition(PluginsHealthy, PluginsHealthyReasonUnhealthy,
+			fmt.Sprintf("Unhealthy plugins: %s", strings.Join(unhealthy, ", ")),
+			metav1.ConditionFalse)
+		return
+	}
+	statusMgr.AddCondition(PluginsHealthy, PluginsHealthyReasonHealthy,
+		fmt.Sprintf("All %d plugins are healthy", len(statuses)),
+		metav1.ConditionTrue)
+}
+
+// readPluginStatuses reads the readiness details of the server pod. Pods are not labelled as
+// managed by the operator, so the pod is read from the API server.
+func (r *SpireServerReconciler) readPluginStatuses(ctx context.Context, plugins []v1alpha2.PluginStatus) ([]v1alpha2.PluginStatus, error) {
+	var pod corev1.Pod
+	if err := r.ctrlClient.GetUncached(ctx, types.NamespacedName{Name: spireServerPodName, Namespace: utils.GetOperatorNamespace()}, &pod); err != nil {
+		return nil, fmt.Errorf("failed to get pod %s: %w", spireServerPodName, err)
+	}
+	if pod.Status.PodIP == "" {
+		return nil, fmt.Errorf("pod %s has no IP", spireServerPodName)
+	}
+	body, err := r.serverHealth.Readiness(ctx, pod.Namespace, pod.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the health of pod %s: %w", spireServerPodName, err)
+	}
+	return pluginStatuses(plugins, body)
+}
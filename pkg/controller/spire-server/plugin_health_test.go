@@ -0,0 +1,146 @@
+package spire_server
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+)
+
+// fakeServerHealth serves the given readiness details
+type fakeServerHealth struct {
+	body string
+	err  error
+}
+
+func (f *fakeServerHealth) Readiness(_ context.Context, _, _ string) ([]byte, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return []byte(f.body), nil
+}
+
+func TestConfiguredPlugins(t *testing.T) {
+	spec := &v1alpha2.SpireServerSpec{
+		ExternalPlugins: []v1alpha2.ExternalPlugin{{Type: "UpstreamAuthority", Name: "vault"}},
+	}
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{}
+
+	plugins := configuredPlugins(generateServerConfMap(spec, ztwim))
+	var got []string
+	for _, plugin := range plugins {
+		got = append(got, plugin.Type+"/"+plugin.Name)
+	}
+	expected := "DataStore/sql,KeyManager/disk,NodeAttestor/k8s_psat,Notifier/k8sbundle,UpstreamAuthority/vault"
+	if strings.Join(got, ",") != expected {
+		t.Errorf("Expected %s, got %s", expected, strings.Join(got, ","))
+	}
+}
+
+func TestReconcilePluginHealth(t *testing.T) {
+	tests := []struct {
+		name          string
+		health        *fakeServerHealth
+		podIP         string
+		expectReason  string
+		expectStatus  metav1.ConditionStatus
+		expectPlugins map[string]v1alpha2.PluginHealth
+	}{
+		{
+			name:         "all healthy",
+			health:       &fakeServerHealth{body: `{"catalog.datastore":{},"server.ca":{},"server.ca.manager":{}}`},
+			podIP:        "10.0.0.1",
+			expectReason: PluginsHealthyReasonHealthy,
+			expectStatus: metav1.ConditionTrue,
+			expectPlugins: map[string]v1alpha2.PluginHealth{
+				"DataStore": v1alpha2.PluginHealthy, "KeyManager": v1alpha2.PluginHealthy, "Notifier": v1alpha2.PluginHealthy,
+			},
+		},
+		{
+			name:         "datastore failing",
+			health:       &fakeServerHealth{body: `{"catalog.datastore":{"read_err":"database is locked"},"server.ca":{}}`},
+			podIP:        "10.0.0.1",
+			expectReason: PluginsHealthyReasonUnhealthy,
+			expectStatus: metav1.ConditionFalse,
+			expectPlugins: map[string]v1alpha2.PluginHealth{
+				"DataStore": v1alpha2.PluginUnhealthy, "KeyManager": v1alpha2.PluginHealthy,
+			},
+		},
+		{
+			name:         "server unreachable",
+			health:       &fakeServerHealth{err: errors.New("connection refused")},
+			podIP:        "10.0.0.1",
+			expectReason: PluginsHealthyReasonUnavailable,
+			expectStatus: metav1.ConditionUnknown,
+			expectPlugins: map[string]v1alpha2.PluginHealth{
+				"DataStore": v1alpha2.PluginUnknown, "Notifier": v1alpha2.PluginUnknown,
+			},
+		},
+		{
+			name:         "pod not started",
+			health:       &fakeServerHealth{body: `{}`},
+			expectReason: PluginsHealthyReasonUnavailable,
+			expectStatus: metav1.ConditionUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.GetUncachedStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				pod, ok := obj.(*corev1.Pod)
+				if !ok || key.Name != spireServerPodName {
+					return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+				}
+				pod.Name, pod.Namespace = key.Name, key.Namespace
+				pod.Status.PodIP = tt.podIP
+				return nil
+			}
+			reconciler := &SpireServerReconciler{
+				ctrlClient:   fakeClient,
+				ctx:          context.Background(),
+				log:          logr.Discard(),
+				serverHealth: tt.health,
+			}
+			server := &v1alpha2.SpireServer{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}}
+			statusMgr := status.NewManager(fakeClient)
+
+			reconciler.reconcilePluginHealth(context.Background(), server, statusMgr, &v1alpha2.ZeroTrustWorkloadIdentityManager{})
+			cond, _ := statusMgr.GetCondition(PluginsHealthy)
+			if cond.Reason != tt.expectReason || cond.Status != tt.expectStatus {
+				t.Errorf("Expected %s/%s, got %s/%s: %s", tt.expectStatus, tt.expectReason, cond.Status, cond.Reason, cond.Message)
+			}
+			for _, plugin := range server.Status.Plugins {
+				if expected, ok := tt.expectPlugins[plugin.Type]; ok && plugin.Status != expected {
+					t.Errorf("Expected plugin %s/%s to be %s, got %s: %s", plugin.Type, plugin.Name, expected, plugin.Status, plugin.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcilePluginHealth_NotConfigured(t *testing.T) {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	reconciler := &SpireServerReconciler{ctrlClient: fakeClient, log: logr.Discard()}
+	server := &v1alpha2.SpireServer{}
+	statusMgr := status.NewManager(fakeClient)
+
+	reconciler.reconcilePluginHealth(context.Background(), server, statusMgr, &v1alpha2.ZeroTrustWorkloadIdentityManager{})
+	if _, ok := statusMgr.GetCondition(PluginsHealthy); ok {
+		t.Error("Expected no condition without a health reader")
+	}
+	if fakeClient.GetUncachedCallCount() != 0 {
+		t.Error("Expected the server pod not to be read")
+	}
+}
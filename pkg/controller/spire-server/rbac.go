@@ -8,9 +8,11 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/operator/assets"
@@ -19,7 +21,7 @@ import (
 // Constants for status conditions are defined in controller.go
 
 // reconcileRBAC reconciles all RBAC resources (spire-server, bundle, and controller-manager)
-func (r *SpireServerReconciler) reconcileRBAC(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireServerReconciler) reconcileRBAC(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, createOnlyMode bool) error {
 	// Spire Server RBAC
 	if err := r.reconcileClusterRole(ctx, server, statusMgr, createOnlyMode); err != nil {
 		return err
@@ -30,7 +32,7 @@ func (r *SpireServerReconciler) reconcileRBAC(ctx context.Context, server *v1alp
 	}
 
 	// Spire Bundle RBAC
-	if err := r.reconcileSpireBundleRole(ctx, server, statusMgr, createOnlyMode); err != nil {
+	if err := r.reconcileSpireBundleRole(ctx, server, statusMgr, ztwim, createOnlyMode); err != nil {
 		return err
 	}
 
@@ -60,7 +62,12 @@ func (r *SpireServerReconciler) reconcileRBAC(ctx context.Context, server *v1alp
 		return err
 	}
 
-	statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonReady,
+	// Bundle notifier RBAC (for the targets of spec.bundleNotifier)
+	if err := r.reconcileBundleNotifierRBAC(ctx, server, statusMgr, createOnlyMode); err != nil {
+		return err
+	}
+
+	statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonReady,
 		"All RBAC resources available",
 		metav1.ConditionTrue)
 
@@ -68,12 +75,12 @@ func (r *SpireServerReconciler) reconcileRBAC(ctx context.Context, server *v1alp
 }
 
 // reconcileClusterRole reconciles the Spire Server ClusterRole
-func (r *SpireServerReconciler) reconcileClusterRole(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireServerReconciler) reconcileClusterRole(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
 	desired := getSpireServerClusterRole(server.Spec.Labels)
 
 	if err := controllerutil.SetControllerReference(server, desired, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference on cluster role")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to set owner reference on ClusterRole: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -87,16 +94,16 @@ func (r *SpireServerReconciler) reconcileClusterRole(ctx context.Context, server
 		if !kerrors.IsNotFound(err) {
 			// Unexpected error
 			r.log.Error(err, "failed to get cluster role")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to get ClusterRole: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create cluster role")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to create ClusterRole: %v", err),
 				metav1.ConditionFalse)
 			return err
@@ -122,7 +129,7 @@ func (r *SpireServerReconciler) reconcileClusterRole(ctx context.Context, server
 	desired.ResourceVersion = existing.ResourceVersion
 	if err := r.ctrlClient.Update(ctx, desired); err != nil {
 		r.log.Error(err, "failed to update cluster role")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to update ClusterRole: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -133,12 +140,12 @@ func (r *SpireServerReconciler) reconcileClusterRole(ctx context.Context, server
 }
 
 // reconcileClusterRoleBinding reconciles the Spire Server ClusterRoleBinding
-func (r *SpireServerReconciler) reconcileClusterRoleBinding(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireServerReconciler) reconcileClusterRoleBinding(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
 	desired := getSpireServerClusterRoleBinding(server.Spec.Labels)
 
 	if err := controllerutil.SetControllerReference(server, desired, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference on cluster role binding")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to set owner reference on ClusterRoleBinding: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -152,16 +159,16 @@ func (r *SpireServerReconciler) reconcileClusterRoleBinding(ctx context.Context,
 		if !kerrors.IsNotFound(err) {
 			// Unexpected error
 			r.log.Error(err, "failed to get cluster role binding")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to get ClusterRoleBinding: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create cluster role binding")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to create ClusterRoleBinding: %v", err),
 				metav1.ConditionFalse)
 			return err
@@ -187,7 +194,7 @@ func (r *SpireServerReconciler) reconcileClusterRoleBinding(ctx context.Context,
 	desired.ResourceVersion = existing.ResourceVersion
 	if err := r.ctrlClient.Update(ctx, desired); err != nil {
 		r.log.Error(err, "failed to update cluster role binding")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to update ClusterRoleBinding: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -198,12 +205,12 @@ func (r *SpireServerReconciler) reconcileClusterRoleBinding(ctx context.Context,
 }
 
 // reconcileSpireBundleRole reconciles the Spire Bundle Role
-func (r *SpireServerReconciler) reconcileSpireBundleRole(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
-	desired := getSpireBundleRole(server.Spec.Labels)
+func (r *SpireServerReconciler) reconcileSpireBundleRole(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, createOnlyMode bool) error {
+	desired := getSpireBundleRole(server.Spec.Labels, ztwim.Spec.BundleConfigMap)
 
 	if err := controllerutil.SetControllerReference(server, desired, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference on spire-bundle role")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to set owner reference on Bundle Role: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -217,16 +224,16 @@ func (r *SpireServerReconciler) reconcileSpireBundleRole(ctx context.Context, se
 		if !kerrors.IsNotFound(err) {
 			// Unexpected error
 			r.log.Error(err, "failed to get spire-bundle role")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to get Bundle Role: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create spire-bundle role")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to create Bundle Role: %v", err),
 				metav1.ConditionFalse)
 			return err
@@ -252,7 +259,7 @@ func (r *SpireServerReconciler) reconcileSpireBundleRole(ctx context.Context, se
 	desired.ResourceVersion = existing.ResourceVersion
 	if err := r.ctrlClient.Update(ctx, desired); err != nil {
 		r.log.Error(err, "failed to update spire-bundle role")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to update Bundle Role: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -263,12 +270,12 @@ func (r *SpireServerReconciler) reconcileSpireBundleRole(ctx context.Context, se
 }
 
 // reconcileSpireBundleRoleBinding reconciles the Spire Bundle RoleBinding
-func (r *SpireServerReconciler) reconcileSpireBundleRoleBinding(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireServerReconciler) reconcileSpireBundleRoleBinding(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
 	desired := getSpireBundleRoleBinding(server.Spec.Labels)
 
 	if err := controllerutil.SetControllerReference(server, desired, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference on spire-bundle role binding")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to set owner reference on Bundle RoleBinding: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -282,16 +289,16 @@ func (r *SpireServerReconciler) reconcileSpireBundleRoleBinding(ctx context.Cont
 		if !kerrors.IsNotFound(err) {
 			// Unexpected error
 			r.log.Error(err, "failed to get spire-bundle role binding")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to get Bundle RoleBinding: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create spire-bundle role binding")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to create Bundle RoleBinding: %v", err),
 				metav1.ConditionFalse)
 			return err
@@ -317,7 +324,7 @@ func (r *SpireServerReconciler) reconcileSpireBundleRoleBinding(ctx context.Cont
 	desired.ResourceVersion = existing.ResourceVersion
 	if err := r.ctrlClient.Update(ctx, desired); err != nil {
 		r.log.Error(err, "failed to update spire-bundle role binding")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to update Bundle RoleBinding: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -328,12 +335,12 @@ func (r *SpireServerReconciler) reconcileSpireBundleRoleBinding(ctx context.Cont
 }
 
 // reconcileControllerManagerClusterRole reconciles the Controller Manager ClusterRole
-func (r *SpireServerReconciler) reconcileControllerManagerClusterRole(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireServerReconciler) reconcileControllerManagerClusterRole(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
 	desired := getSpireControllerManagerClusterRole(server.Spec.Labels)
 
 	if err := controllerutil.SetControllerReference(server, desired, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference on controller manager cluster role")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to set owner reference on Controller Manager ClusterRole: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -347,16 +354,16 @@ func (r *SpireServerReconciler) reconcileControllerManagerClusterRole(ctx contex
 		if !kerrors.IsNotFound(err) {
 			// Unexpected error
 			r.log.Error(err, "failed to get controller manager cluster role")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to get Controller Manager ClusterRole: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create controller manager cluster role")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to create Controller Manager ClusterRole: %v", err),
 				metav1.ConditionFalse)
 			return err
@@ -382,7 +389,7 @@ func (r *SpireServerReconciler) reconcileControllerManagerClusterRole(ctx contex
 	desired.ResourceVersion = existing.ResourceVersion
 	if err := r.ctrlClient.Update(ctx, desired); err != nil {
 		r.log.Error(err, "failed to update controller manager cluster role")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to update Controller Manager ClusterRole: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -393,12 +400,12 @@ func (r *SpireServerReconciler) reconcileControllerManagerClusterRole(ctx contex
 }
 
 // reconcileControllerManagerClusterRoleBinding reconciles the Controller Manager ClusterRoleBinding
-func (r *SpireServerReconciler) reconcileControllerManagerClusterRoleBinding(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireServerReconciler) reconcileControllerManagerClusterRoleBinding(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
 	desired := getSpireControllerManagerClusterRoleBinding(server.Spec.Labels)
 
 	if err := controllerutil.SetControllerReference(server, desired, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference on controller manager cluster role binding")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to set owner reference on Controller Manager ClusterRoleBinding: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -412,16 +419,16 @@ func (r *SpireServerReconciler) reconcileControllerManagerClusterRoleBinding(ctx
 		if !kerrors.IsNotFound(err) {
 			// Unexpected error
 			r.log.Error(err, "failed to get controller manager cluster role binding")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to get Controller Manager ClusterRoleBinding: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create controller manager cluster role binding")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to create Controller Manager ClusterRoleBinding: %v", err),
 				metav1.ConditionFalse)
 			return err
@@ -447,7 +454,7 @@ func (r *SpireServerReconciler) reconcileControllerManagerClusterRoleBinding(ctx
 	desired.ResourceVersion = existing.ResourceVersion
 	if err := r.ctrlClient.Update(ctx, desired); err != nil {
 		r.log.Error(err, "failed to update controller manager cluster role binding")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to update Controller Manager ClusterRoleBinding: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -458,12 +465,12 @@ func (r *SpireServerReconciler) reconcileControllerManagerClusterRoleBinding(ctx
 }
 
 // reconcileLeaderElectionRole reconciles the Leader Election Role
-func (r *SpireServerReconciler) reconcileLeaderElectionRole(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireServerReconciler) reconcileLeaderElectionRole(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
 	desired := getSpireControllerManagerLeaderElectionRole(server.Spec.Labels)
 
 	if err := controllerutil.SetControllerReference(server, desired, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference on leader election role")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to set owner reference on Leader Election Role: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -477,16 +484,16 @@ func (r *SpireServerReconciler) reconcileLeaderElectionRole(ctx context.Context,
 		if !kerrors.IsNotFound(err) {
 			// Unexpected error
 			r.log.Error(err, "failed to get leader election role")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to get Leader Election Role: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create leader election role")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to create Leader Election Role: %v", err),
 				metav1.ConditionFalse)
 			return err
@@ -512,7 +519,7 @@ func (r *SpireServerReconciler) reconcileLeaderElectionRole(ctx context.Context,
 	desired.ResourceVersion = existing.ResourceVersion
 	if err := r.ctrlClient.Update(ctx, desired); err != nil {
 		r.log.Error(err, "failed to update leader election role")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to update Leader Election Role: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -523,12 +530,12 @@ func (r *SpireServerReconciler) reconcileLeaderElectionRole(ctx context.Context,
 }
 
 // reconcileLeaderElectionRoleBinding reconciles the Leader Election RoleBinding
-func (r *SpireServerReconciler) reconcileLeaderElectionRoleBinding(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireServerReconciler) reconcileLeaderElectionRoleBinding(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
 	desired := getSpireControllerManagerLeaderElectionRoleBinding(server.Spec.Labels)
 
 	if err := controllerutil.SetControllerReference(server, desired, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference on leader election role binding")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to set owner reference on Leader Election RoleBinding: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -542,16 +549,16 @@ func (r *SpireServerReconciler) reconcileLeaderElectionRoleBinding(ctx context.C
 		if !kerrors.IsNotFound(err) {
 			// Unexpected error
 			r.log.Error(err, "failed to get leader election role binding")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to get Leader Election RoleBinding: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create leader election role binding")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to create Leader Election RoleBinding: %v", err),
 				metav1.ConditionFalse)
 			return err
@@ -577,7 +584,7 @@ func (r *SpireServerReconciler) reconcileLeaderElectionRoleBinding(ctx context.C
 	desired.ResourceVersion = existing.ResourceVersion
 	if err := r.ctrlClient.Update(ctx, desired); err != nil {
 		r.log.Error(err, "failed to update leader election role binding")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to update Leader Election RoleBinding: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -605,10 +612,18 @@ func getSpireServerClusterRoleBinding(customLabels map[string]string) *rbacv1.Cl
 	return crb
 }
 
-func getSpireBundleRole(customLabels map[string]string) *rbacv1.Role {
+func getSpireBundleRole(customLabels map[string]string, bundleConfigMap string) *rbacv1.Role {
 	role := utils.DecodeRoleObjBytes(assets.MustAsset(utils.SpireBundleRoleAssetName))
 	role.Labels = utils.SpireServerLabels(customLabels)
 	role.Namespace = utils.GetOperatorNamespace()
+	// Scope the bundle notifier to the configured bundle ConfigMap
+	if bundleConfigMap != "" {
+		for i := range role.Rules {
+			if len(role.Rules[i].ResourceNames) > 0 {
+				role.Rules[i].ResourceNames = []string{bundleConfigMap}
+			}
+		}
+	}
 	return role
 }
 
@@ -658,7 +673,7 @@ func getSpireControllerManagerLeaderElectionRoleBinding(customLabels map[string]
 }
 
 // reconcileExternalCertRBAC reconciles RBAC resources for router access to external certificate secret
-func (r *SpireServerReconciler) reconcileExternalCertRBAC(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireServerReconciler) reconcileExternalCertRBAC(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
 	// Only create RBAC if federation is enabled with https_web profile and externalSecretRef is configured
 	externalSecretRef := getExternalSecretRefFromServer(server)
 	if externalSecretRef == "" {
@@ -679,7 +694,7 @@ func (r *SpireServerReconciler) reconcileExternalCertRBAC(ctx context.Context, s
 }
 
 // reconcileExternalCertRole reconciles the Role for router to read external certificate secret
-func (r *SpireServerReconciler) reconcileExternalCertRole(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager, createOnlyMode bool, externalSecretRef string) error {
+func (r *SpireServerReconciler) reconcileExternalCertRole(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, createOnlyMode bool, externalSecretRef string) error {
 	desired := getSpireServerExternalCertRole(server.Spec.Labels)
 
 	// Set the specific secret name in resourceNames
@@ -687,7 +702,7 @@ func (r *SpireServerReconciler) reconcileExternalCertRole(ctx context.Context, s
 
 	if err := controllerutil.SetControllerReference(server, desired, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference on external cert role")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to set owner reference on external cert Role: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -701,16 +716,16 @@ func (r *SpireServerReconciler) reconcileExternalCertRole(ctx context.Context, s
 		if !kerrors.IsNotFound(err) {
 			// Unexpected error
 			r.log.Error(err, "failed to get external cert role")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to get external cert Role: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create external cert role")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to create external cert Role: %v", err),
 				metav1.ConditionFalse)
 			return err
@@ -736,7 +751,7 @@ func (r *SpireServerReconciler) reconcileExternalCertRole(ctx context.Context, s
 	desired.ResourceVersion = existing.ResourceVersion
 	if err := r.ctrlClient.Update(ctx, desired); err != nil {
 		r.log.Error(err, "failed to update external cert role")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to update external cert Role: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -747,12 +762,12 @@ func (r *SpireServerReconciler) reconcileExternalCertRole(ctx context.Context, s
 }
 
 // reconcileExternalCertRoleBinding reconciles the RoleBinding for router to read external certificate secret
-func (r *SpireServerReconciler) reconcileExternalCertRoleBinding(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireServerReconciler) reconcileExternalCertRoleBinding(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
 	desired := getSpireServerExternalCertRoleBinding(server.Spec.Labels)
 
 	if err := controllerutil.SetControllerReference(server, desired, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference on external cert role binding")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to set owner reference on external cert RoleBinding: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -766,16 +781,16 @@ func (r *SpireServerReconciler) reconcileExternalCertRoleBinding(ctx context.Con
 		if !kerrors.IsNotFound(err) {
 			// Unexpected error
 			r.log.Error(err, "failed to get external cert role binding")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to get external cert RoleBinding: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create external cert role binding")
-			statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to create external cert RoleBinding: %v", err),
 				metav1.ConditionFalse)
 			return err
@@ -801,7 +816,7 @@ func (r *SpireServerReconciler) reconcileExternalCertRoleBinding(ctx context.Con
 	desired.ResourceVersion = existing.ResourceVersion
 	if err := r.ctrlClient.Update(ctx, desired); err != nil {
 		r.log.Error(err, "failed to update external cert role binding")
-		statusMgr.AddCondition(RBACAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(RBACAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to update external cert RoleBinding: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -812,7 +827,7 @@ func (r *SpireServerReconciler) reconcileExternalCertRoleBinding(ctx context.Con
 }
 
 // getExternalSecretRefFromServer extracts the externalSecretRef from SpireServer spec
-func getExternalSecretRefFromServer(server *v1alpha1.SpireServer) string {
+func getExternalSecretRefFromServer(server *v1alpha2.SpireServer) string {
 	if server.Spec.Federation == nil {
 		return ""
 	}
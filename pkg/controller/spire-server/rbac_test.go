@@ -3,6 +3,9 @@ package spire_server
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -13,13 +16,17 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/component-helpers/auth/rbac/validation"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/operator/assets"
 )
 
 var (
@@ -269,7 +276,7 @@ func TestGetSpireServerClusterRole(t *testing.T) {
 				name = crb.Name
 				labels = crb.Labels
 			case "spireBundleRole":
-				role := getSpireBundleRole(tt.customLabels)
+				role := getSpireBundleRole(tt.customLabels, "")
 				if role == nil {
 					t.Fatal("Expected Role, got nil")
 				}
@@ -367,8 +374,8 @@ func TestLabelPreservation(t *testing.T) {
 				labelsWithoutCustom = getSpireServerClusterRole(nil).Labels
 				labelsWithCustom = getSpireServerClusterRole(customLabels).Labels
 			case "spireBundleRole":
-				labelsWithoutCustom = getSpireBundleRole(nil).Labels
-				labelsWithCustom = getSpireBundleRole(customLabels).Labels
+				labelsWithoutCustom = getSpireBundleRole(nil, "").Labels
+				labelsWithCustom = getSpireBundleRole(customLabels, "").Labels
 			case "controllerManagerClusterRole":
 				labelsWithoutCustom = getSpireControllerManagerClusterRole(nil).Labels
 				labelsWithCustom = getSpireControllerManagerClusterRole(customLabels).Labels
@@ -392,7 +399,7 @@ func TestLabelPreservation(t *testing.T) {
 // newRBACTestReconciler creates a reconciler for RBAC tests
 func newRBACTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireServerReconciler {
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = rbacv1.AddToScheme(scheme)
 	return &SpireServerReconciler{
 		ctrlClient:    fakeClient,
@@ -404,8 +411,8 @@ func newRBACTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireServerR
 }
 
 // createRBACTestServer creates a test server for RBAC tests
-func createRBACTestServer() *v1alpha1.SpireServer {
-	return &v1alpha1.SpireServer{
+func createRBACTestServer() *v1alpha2.SpireServer {
+	return &v1alpha2.SpireServer{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
@@ -416,7 +423,7 @@ func createRBACTestServer() *v1alpha1.SpireServer {
 func TestReconcileClusterRole(t *testing.T) {
 	tests := []struct {
 		name           string
-		server         *v1alpha1.SpireServer
+		server         *v1alpha2.SpireServer
 		setupClient    func(*fakes.FakeCustomCtrlClient)
 		createOnlyMode bool
 		useEmptyScheme bool
@@ -471,10 +478,10 @@ func TestReconcileClusterRole(t *testing.T) {
 		},
 		{
 			name: "update error",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					CommonConfig: v1alpha1.CommonConfig{Labels: map[string]string{"new-label": "new-value"}},
+				Spec: v1alpha2.SpireServerSpec{
+					CommonConfig: v1alpha2.CommonConfig{Labels: map[string]string{"new-label": "new-value"}},
 				},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -547,7 +554,7 @@ func TestReconcileClusterRole(t *testing.T) {
 func TestReconcileClusterRoleBinding(t *testing.T) {
 	tests := []struct {
 		name           string
-		server         *v1alpha1.SpireServer
+		server         *v1alpha2.SpireServer
 		setupClient    func(*fakes.FakeCustomCtrlClient)
 		createOnlyMode bool
 		useEmptyScheme bool
@@ -584,10 +591,10 @@ func TestReconcileClusterRoleBinding(t *testing.T) {
 		},
 		{
 			name: "update success",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					CommonConfig: v1alpha1.CommonConfig{Labels: map[string]string{"new": "label"}},
+				Spec: v1alpha2.SpireServerSpec{
+					CommonConfig: v1alpha2.CommonConfig{Labels: map[string]string{"new": "label"}},
 				},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -611,10 +618,10 @@ func TestReconcileClusterRoleBinding(t *testing.T) {
 		},
 		{
 			name: "update error",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					CommonConfig: v1alpha1.CommonConfig{Labels: map[string]string{"new": "label"}},
+				Spec: v1alpha2.SpireServerSpec{
+					CommonConfig: v1alpha2.CommonConfig{Labels: map[string]string{"new": "label"}},
 				},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -638,10 +645,10 @@ func TestReconcileClusterRoleBinding(t *testing.T) {
 		},
 		{
 			name: "create only mode skips update",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					CommonConfig: v1alpha1.CommonConfig{Labels: map[string]string{"new": "label"}},
+				Spec: v1alpha2.SpireServerSpec{
+					CommonConfig: v1alpha2.CommonConfig{Labels: map[string]string{"new": "label"}},
 				},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -714,7 +721,7 @@ func TestReconcileClusterRoleBinding(t *testing.T) {
 func TestReconcileSpireBundleRole(t *testing.T) {
 	tests := []struct {
 		name           string
-		server         *v1alpha1.SpireServer
+		server         *v1alpha2.SpireServer
 		setupClient    func(*fakes.FakeCustomCtrlClient)
 		useEmptyScheme bool
 		expectError    bool
@@ -750,10 +757,10 @@ func TestReconcileSpireBundleRole(t *testing.T) {
 		},
 		{
 			name: "update success",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					CommonConfig: v1alpha1.CommonConfig{Labels: map[string]string{"new": "label"}},
+				Spec: v1alpha2.SpireServerSpec{
+					CommonConfig: v1alpha2.CommonConfig{Labels: map[string]string{"new": "label"}},
 				},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -803,7 +810,7 @@ func TestReconcileSpireBundleRole(t *testing.T) {
 			tt.setupClient(fakeClient)
 
 			statusMgr := status.NewManager(fakeClient)
-			err := reconciler.reconcileSpireBundleRole(context.Background(), tt.server, statusMgr, false)
+			err := reconciler.reconcileSpireBundleRole(context.Background(), tt.server, statusMgr, &v1alpha2.ZeroTrustWorkloadIdentityManager{}, false)
 
 			if tt.expectError && err == nil {
 				t.Error("Expected error but got none")
@@ -824,7 +831,7 @@ func TestReconcileSpireBundleRole(t *testing.T) {
 func TestReconcileSpireBundleRoleBinding(t *testing.T) {
 	tests := []struct {
 		name           string
-		server         *v1alpha1.SpireServer
+		server         *v1alpha2.SpireServer
 		setupClient    func(*fakes.FakeCustomCtrlClient)
 		useEmptyScheme bool
 		expectError    bool
@@ -852,10 +859,10 @@ func TestReconcileSpireBundleRoleBinding(t *testing.T) {
 		},
 		{
 			name: "update success",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					CommonConfig: v1alpha1.CommonConfig{Labels: map[string]string{"new": "label"}},
+				Spec: v1alpha2.SpireServerSpec{
+					CommonConfig: v1alpha2.CommonConfig{Labels: map[string]string{"new": "label"}},
 				},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -953,7 +960,7 @@ func TestReconcileRBAC(t *testing.T) {
 			tt.setupClient(fakeClient)
 
 			statusMgr := status.NewManager(fakeClient)
-			err := reconciler.reconcileRBAC(context.Background(), createRBACTestServer(), statusMgr, false)
+			err := reconciler.reconcileRBAC(context.Background(), createRBACTestServer(), statusMgr, &v1alpha2.ZeroTrustWorkloadIdentityManager{}, false)
 
 			if tt.expectError && err == nil {
 				t.Error("Expected error but got none")
@@ -968,7 +975,7 @@ func TestReconcileRBAC(t *testing.T) {
 func TestReconcileControllerManagerClusterRole(t *testing.T) {
 	tests := []struct {
 		name           string
-		server         *v1alpha1.SpireServer
+		server         *v1alpha2.SpireServer
 		setupClient    func(*fakes.FakeCustomCtrlClient)
 		createOnlyMode bool
 		useEmptyScheme bool
@@ -1069,7 +1076,7 @@ func TestReconcileControllerManagerClusterRole(t *testing.T) {
 func TestReconcileControllerManagerClusterRoleBinding(t *testing.T) {
 	tests := []struct {
 		name           string
-		server         *v1alpha1.SpireServer
+		server         *v1alpha2.SpireServer
 		setupClient    func(*fakes.FakeCustomCtrlClient)
 		useEmptyScheme bool
 		expectError    bool
@@ -1105,10 +1112,10 @@ func TestReconcileControllerManagerClusterRoleBinding(t *testing.T) {
 		},
 		{
 			name: "update success",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					CommonConfig: v1alpha1.CommonConfig{Labels: map[string]string{"new": "label"}},
+				Spec: v1alpha2.SpireServerSpec{
+					CommonConfig: v1alpha2.CommonConfig{Labels: map[string]string{"new": "label"}},
 				},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -1132,10 +1139,10 @@ func TestReconcileControllerManagerClusterRoleBinding(t *testing.T) {
 		},
 		{
 			name: "update error",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					CommonConfig: v1alpha1.CommonConfig{Labels: map[string]string{"new": "label"}},
+				Spec: v1alpha2.SpireServerSpec{
+					CommonConfig: v1alpha2.CommonConfig{Labels: map[string]string{"new": "label"}},
 				},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -1204,7 +1211,7 @@ func TestReconcileControllerManagerClusterRoleBinding(t *testing.T) {
 func TestReconcileLeaderElectionRole(t *testing.T) {
 	tests := []struct {
 		name           string
-		server         *v1alpha1.SpireServer
+		server         *v1alpha2.SpireServer
 		setupClient    func(*fakes.FakeCustomCtrlClient)
 		useEmptyScheme bool
 		expectError    bool
@@ -1240,10 +1247,10 @@ func TestReconcileLeaderElectionRole(t *testing.T) {
 		},
 		{
 			name: "update success",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					CommonConfig: v1alpha1.CommonConfig{Labels: map[string]string{"new": "label"}},
+				Spec: v1alpha2.SpireServerSpec{
+					CommonConfig: v1alpha2.CommonConfig{Labels: map[string]string{"new": "label"}},
 				},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -1268,10 +1275,10 @@ func TestReconcileLeaderElectionRole(t *testing.T) {
 		},
 		{
 			name: "update error",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					CommonConfig: v1alpha1.CommonConfig{Labels: map[string]string{"new": "label"}},
+				Spec: v1alpha2.SpireServerSpec{
+					CommonConfig: v1alpha2.CommonConfig{Labels: map[string]string{"new": "label"}},
 				},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -1341,7 +1348,7 @@ func TestReconcileLeaderElectionRole(t *testing.T) {
 func TestReconcileLeaderElectionRoleBinding(t *testing.T) {
 	tests := []struct {
 		name           string
-		server         *v1alpha1.SpireServer
+		server         *v1alpha2.SpireServer
 		setupClient    func(*fakes.FakeCustomCtrlClient)
 		useEmptyScheme bool
 		expectError    bool
@@ -1377,10 +1384,10 @@ func TestReconcileLeaderElectionRoleBinding(t *testing.T) {
 		},
 		{
 			name: "update success",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					CommonConfig: v1alpha1.CommonConfig{Labels: map[string]string{"new": "label"}},
+				Spec: v1alpha2.SpireServerSpec{
+					CommonConfig: v1alpha2.CommonConfig{Labels: map[string]string{"new": "label"}},
 				},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -1405,10 +1412,10 @@ func TestReconcileLeaderElectionRoleBinding(t *testing.T) {
 		},
 		{
 			name: "update error",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					CommonConfig: v1alpha1.CommonConfig{Labels: map[string]string{"new": "label"}},
+				Spec: v1alpha2.SpireServerSpec{
+					CommonConfig: v1alpha2.CommonConfig{Labels: map[string]string{"new": "label"}},
 				},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -1592,17 +1599,17 @@ func TestGetSpireServerExternalCertRoleBinding(t *testing.T) {
 func TestGetExternalSecretRefFromServer(t *testing.T) {
 	tests := []struct {
 		name           string
-		server         *v1alpha1.SpireServer
+		server         *v1alpha2.SpireServer
 		expectedSecret string
 	}{
 		{
 			name: "returns secret when federation with https_web and externalSecretRef is configured",
-			server: &v1alpha1.SpireServer{
-				Spec: v1alpha1.SpireServerSpec{
-					Federation: &v1alpha1.FederationConfig{
-						BundleEndpoint: v1alpha1.BundleEndpointConfig{
-							HttpsWeb: &v1alpha1.HttpsWebConfig{
-								ServingCert: &v1alpha1.ServingCertConfig{
+			server: &v1alpha2.SpireServer{
+				Spec: v1alpha2.SpireServerSpec{
+					Federation: &v1alpha2.FederationConfig{
+						BundleEndpoint: v1alpha2.BundleEndpointConfig{
+							HttpsWeb: &v1alpha2.HttpsWebConfig{
+								ServingCert: &v1alpha2.ServingCertConfig{
 									ExternalSecretRef: "test-secret",
 								},
 							},
@@ -1614,8 +1621,8 @@ func TestGetExternalSecretRefFromServer(t *testing.T) {
 		},
 		{
 			name: "returns empty string when federation is nil",
-			server: &v1alpha1.SpireServer{
-				Spec: v1alpha1.SpireServerSpec{
+			server: &v1alpha2.SpireServer{
+				Spec: v1alpha2.SpireServerSpec{
 					Federation: nil,
 				},
 			},
@@ -1623,10 +1630,10 @@ func TestGetExternalSecretRefFromServer(t *testing.T) {
 		},
 		{
 			name: "returns empty string when HttpsWeb is nil",
-			server: &v1alpha1.SpireServer{
-				Spec: v1alpha1.SpireServerSpec{
-					Federation: &v1alpha1.FederationConfig{
-						BundleEndpoint: v1alpha1.BundleEndpointConfig{
+			server: &v1alpha2.SpireServer{
+				Spec: v1alpha2.SpireServerSpec{
+					Federation: &v1alpha2.FederationConfig{
+						BundleEndpoint: v1alpha2.BundleEndpointConfig{
 							HttpsWeb: nil,
 						},
 					},
@@ -1636,11 +1643,11 @@ func TestGetExternalSecretRefFromServer(t *testing.T) {
 		},
 		{
 			name: "returns empty string when ServingCert is nil",
-			server: &v1alpha1.SpireServer{
-				Spec: v1alpha1.SpireServerSpec{
-					Federation: &v1alpha1.FederationConfig{
-						BundleEndpoint: v1alpha1.BundleEndpointConfig{
-							HttpsWeb: &v1alpha1.HttpsWebConfig{
+			server: &v1alpha2.SpireServer{
+				Spec: v1alpha2.SpireServerSpec{
+					Federation: &v1alpha2.FederationConfig{
+						BundleEndpoint: v1alpha2.BundleEndpointConfig{
+							HttpsWeb: &v1alpha2.HttpsWebConfig{
 								ServingCert: nil,
 							},
 						},
@@ -1651,12 +1658,12 @@ func TestGetExternalSecretRefFromServer(t *testing.T) {
 		},
 		{
 			name: "returns empty string when ExternalSecretRef is empty",
-			server: &v1alpha1.SpireServer{
-				Spec: v1alpha1.SpireServerSpec{
-					Federation: &v1alpha1.FederationConfig{
-						BundleEndpoint: v1alpha1.BundleEndpointConfig{
-							HttpsWeb: &v1alpha1.HttpsWebConfig{
-								ServingCert: &v1alpha1.ServingCertConfig{
+			server: &v1alpha2.SpireServer{
+				Spec: v1alpha2.SpireServerSpec{
+					Federation: &v1alpha2.FederationConfig{
+						BundleEndpoint: v1alpha2.BundleEndpointConfig{
+							HttpsWeb: &v1alpha2.HttpsWebConfig{
+								ServingCert: &v1alpha2.ServingCertConfig{
 									ExternalSecretRef: "",
 								},
 							},
@@ -1915,7 +1922,7 @@ func TestReconcileExternalCertRole(t *testing.T) {
 				log:        logr.Discard(),
 			}
 
-			server := &v1alpha1.SpireServer{
+			server := &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "test-server", UID: "test-uid"},
 			}
 
@@ -2132,7 +2139,7 @@ func TestReconcileExternalCertRoleBinding(t *testing.T) {
 				log:        logr.Discard(),
 			}
 
-			server := &v1alpha1.SpireServer{
+			server := &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "test-server", UID: "test-uid"},
 			}
 
@@ -2156,7 +2163,7 @@ func TestReconcileExternalCertRBAC(t *testing.T) {
 
 	tests := []struct {
 		name           string
-		server         *v1alpha1.SpireServer
+		server         *v1alpha2.SpireServer
 		setupObjects   func() []client.Object
 		setupClient    func(store *testStore) customClient.CustomCtrlClient
 		expectError    bool
@@ -2164,13 +2171,13 @@ func TestReconcileExternalCertRBAC(t *testing.T) {
 	}{
 		{
 			name: "creates RBAC resources when externalSecretRef is configured",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "test-server", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					Federation: &v1alpha1.FederationConfig{
-						BundleEndpoint: v1alpha1.BundleEndpointConfig{
-							HttpsWeb: &v1alpha1.HttpsWebConfig{
-								ServingCert: &v1alpha1.ServingCertConfig{
+				Spec: v1alpha2.SpireServerSpec{
+					Federation: &v1alpha2.FederationConfig{
+						BundleEndpoint: v1alpha2.BundleEndpointConfig{
+							HttpsWeb: &v1alpha2.HttpsWebConfig{
+								ServingCert: &v1alpha2.ServingCertConfig{
 									ExternalSecretRef: "test-secret",
 								},
 							},
@@ -2203,9 +2210,9 @@ func TestReconcileExternalCertRBAC(t *testing.T) {
 		},
 		{
 			name: "does not create RBAC when externalSecretRef is empty from start",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "test-server", UID: "test-uid"},
-				Spec:       v1alpha1.SpireServerSpec{Federation: nil},
+				Spec:       v1alpha2.SpireServerSpec{Federation: nil},
 			},
 			setupObjects: func() []client.Object { return []client.Object{} },
 			setupClient:  func(store *testStore) customClient.CustomCtrlClient { return newFakeClient(store) },
@@ -2232,9 +2239,9 @@ func TestReconcileExternalCertRBAC(t *testing.T) {
 		},
 		{
 			name: "does not delete RBAC when externalSecretRef is unset after being set",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "test-server", UID: "test-uid"},
-				Spec:       v1alpha1.SpireServerSpec{Federation: nil},
+				Spec:       v1alpha2.SpireServerSpec{Federation: nil},
 			},
 			setupObjects: func() []client.Object {
 				return []client.Object{
@@ -2276,13 +2283,13 @@ func TestReconcileExternalCertRBAC(t *testing.T) {
 		},
 		{
 			name: "fails when reconcileExternalCertRole returns error",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "test-server", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					Federation: &v1alpha1.FederationConfig{
-						BundleEndpoint: v1alpha1.BundleEndpointConfig{
-							HttpsWeb: &v1alpha1.HttpsWebConfig{
-								ServingCert: &v1alpha1.ServingCertConfig{
+				Spec: v1alpha2.SpireServerSpec{
+					Federation: &v1alpha2.FederationConfig{
+						BundleEndpoint: v1alpha2.BundleEndpointConfig{
+							HttpsWeb: &v1alpha2.HttpsWebConfig{
+								ServingCert: &v1alpha2.ServingCertConfig{
 									ExternalSecretRef: "test-secret",
 								},
 							},
@@ -2301,13 +2308,13 @@ func TestReconcileExternalCertRBAC(t *testing.T) {
 		},
 		{
 			name: "fails when reconcileExternalCertRoleBinding returns error",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "test-server", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					Federation: &v1alpha1.FederationConfig{
-						BundleEndpoint: v1alpha1.BundleEndpointConfig{
-							HttpsWeb: &v1alpha1.HttpsWebConfig{
-								ServingCert: &v1alpha1.ServingCertConfig{
+				Spec: v1alpha2.SpireServerSpec{
+					Federation: &v1alpha2.FederationConfig{
+						BundleEndpoint: v1alpha2.BundleEndpointConfig{
+							HttpsWeb: &v1alpha2.HttpsWebConfig{
+								ServingCert: &v1alpha2.ServingCertConfig{
 									ExternalSecretRef: "test-secret",
 								},
 							},
@@ -2371,7 +2378,7 @@ func TestReconcileExternalCertRBAC(t *testing.T) {
 // Helper functions
 func newTestScheme() *runtime.Scheme {
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = rbacv1.AddToScheme(scheme)
 	return scheme
 }
@@ -2384,3 +2391,68 @@ func contains(slice []string, item string) bool {
 	}
 	return false
 }
+
+func TestGetSpireBundleRole_BundleConfigMap(t *testing.T) {
+	role := getSpireBundleRole(nil, "trust-bundle")
+	if len(role.Rules) == 0 || len(role.Rules[0].ResourceNames) != 1 || role.Rules[0].ResourceNames[0] != "trust-bundle" {
+		t.Errorf("Expected the role to be scoped to trust-bundle, got %v", role.Rules)
+	}
+	if role := getSpireBundleRole(nil, ""); role.Rules[0].ResourceNames[0] != "spire-bundle" {
+		t.Errorf("Expected the default resource name without a configured bundle ConfigMap, got %v", role.Rules)
+	}
+}
+
+// shippedClusterRoles returns the ClusterRoles of the operator manifests by name, before the name
+// prefix of the default kustomization
+func shippedClusterRoles(t *testing.T) map[string]rbacv1.ClusterRole {
+	t.Helper()
+	files, err := filepath.Glob("../../../config/rbac/*.yaml")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("Failed to find the operator RBAC manifests: %v", err)
+	}
+	roles := map[string]rbacv1.ClusterRole{}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", file, err)
+		}
+		for _, document := range strings.Split(string(data), "\n---") {
+			var role rbacv1.ClusterRole
+			if err := yaml.Unmarshal([]byte(document), &role); err != nil {
+				t.Fatalf("Failed to decode %s: %v", file, err)
+			}
+			if role.Kind == "ClusterRole" {
+				roles[role.Name] = role
+			}
+		}
+	}
+	return roles
+}
+
+// TestRenderedClusterRolesCoveredByOperator checks that the ClusterRoles rendered at runtime only
+// grant rules the operator holds itself: the operator cannot escalate its privileges, the API
+// server refuses to write them otherwise
+func TestRenderedClusterRolesCoveredByOperator(t *testing.T) {
+	shipped := shippedClusterRoles(t)
+	operator, ok := shipped["manager-role"]
+	if !ok {
+		t.Fatalf("Expected the manager-role ClusterRole in the operator manifests")
+	}
+
+	rendered := []*rbacv1.ClusterRole{
+		getSpireServerClusterRole(nil),
+		getSpireControllerManagerClusterRole(nil),
+		utils.DecodeClusterRoleObjBytes(assets.MustAsset(utils.SpireAgentClusterRoleAssetName)),
+		generateBundleWebhooksClusterRole(nil),
+	}
+	for _, role := range rendered {
+		if covered, missing := validation.Covers(operator.Rules, role.Rules); !covered {
+			t.Errorf("Expected the rules of ClusterRole %s to be held by the operator, missing %v", role.Name, missing)
+		}
+	}
+
+	// The ClusterRole bound in the namespaces of the bundle ConfigMaps is shipped, not rendered
+	if _, ok := shipped[strings.TrimPrefix(bundleConfigMapsClusterRoleName, "zero-trust-workload-identity-manager-")]; !ok {
+		t.Errorf("Expected the ClusterRole %s in the operator manifests", bundleConfigMapsClusterRoleName)
+	}
+}
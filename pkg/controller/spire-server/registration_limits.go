@@ -0,0 +1,140 @@
+package spire_server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	spiffev1alpha1 "github.com/spiffe/spire-controller-manager/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	// spireControllerManagerClassName is the class of the ClusterSPIFFEIDs and ClusterStaticEntries
+	// reconciled by spire-controller-manager
+	spireControllerManagerClassName = "zero-trust-workload-identity-manager-spire"
+
+	// registrationEntriesCheckInterval is how often the registration entries are counted while
+	// limits are set, as they are created by spire-controller-manager without the operator noticing
+	registrationEntriesCheckInterval = 5 * time.Minute
+
+	// Registration entry limit reasons
+	RegistrationLimitReasonNotConfigured = "RegistrationLimitNotConfigured"
+	RegistrationLimitReasonWithinLimit   = "WithinRegistrationEntryLimit"
+	RegistrationLimitReasonApproaching   = "RegistrationEntryLimitApproaching"
+	RegistrationLimitReasonExceeded      = "RegistrationEntryLimitExceeded"
+)
+
+var (
+	registrationEntriesGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "ztwim_spire_server_registration_entries",
+		Help: "Number of registration entries set by spire-controller-manager.",
+	})
+	registrationEntriesLimitGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "ztwim_spire_server_registration_entries_limit",
+		Help: "Maximum number of registration entries of the SpireServer, 0 when not limited.",
+	})
+	registrationEntriesWarningGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "ztwim_spire_server_registration_entries_warning_threshold",
+		Help: "Number of registration entries from which a warning is emitted, 0 when not limited.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(registrationEntriesGauge, registrationEntriesLimitGauge, registrationEntriesWarningGauge)
+}
+
+// reconcileRegistrationLimits counts the registration entries against the limits of the
+// SpireServer. Crossing the warning threshold emits a warning Event, exceeding the maximum
+// sets RegistrationEntriesWithinLimit to False, which marks the SpireServer as not ready.
+func (r *SpireServerReconciler) reconcileRegistrationLimits(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager) error {
+	limits := server.Spec.Limits
+	if limits == nil {
+		registrationEntriesLimitGauge.Set(0)
+		registrationEntriesWarningGauge.Set(0)
+		// Only report if limits were previously configured
+		if apimeta.FindStatusCondition(server.Status.Conditions, RegistrationEntriesWithinLimit) != nil {
+			statusMgr.AddCondition(RegistrationEntriesWithinLimit, RegistrationLimitReasonNotConfigured,
+				"Registration entries are not limited",
+				metav1.ConditionTrue)
+		}
+		return nil
+	}
+
+	entries, err := r.countRegistrationEntries(ctx)
+	if err != nil {
+		statusMgr.AddCondition(RegistrationEntriesWithinLimit, "RegistrationEntryCountFailed",
+			err.Error(),
+			metav1.ConditionFalse)
+		return err
+	}
+	warning := warningThreshold(limits)
+	registrationEntriesGauge.Set(float64(entries))
+	registrationEntriesLimitGauge.Set(float64(limits.MaxRegistrationEntries))
+	registrationEntriesWarningGauge.Set(float64(warning))
+
+	reason, message, conditionStatus := RegistrationLimitReasonWithinLimit,
+		fmt.Sprintf("%d registration entries, limit is %d", entries, limits.MaxRegistrationEntries), metav1.ConditionTrue
+	switch {
+	case entries > int(limits.MaxRegistrationEntries):
+		reason, conditionStatus = RegistrationLimitReasonExceeded, metav1.ConditionFalse
+		message = fmt.Sprintf("%d registration entries exceed the limit of %d: narrow the ClusterSPIFFEID selectors or raise spec.limits.maxRegistrationEntries",
+			entries, limits.MaxRegistrationEntries)
+	case entries >= warning:
+		reason = RegistrationLimitReasonApproaching
+		message = fmt.Sprintf("%d registration entries reach the warning threshold of %d, limit is %d",
+			entries, warning, limits.MaxRegistrationEntries)
+	}
+
+	// Warn once when a threshold is crossed, not on every check
+	existing := apimeta.FindStatusCondition(server.Status.Conditions, RegistrationEntriesWithinLimit)
+	if reason != RegistrationLimitReasonWithinLimit && (existing == nil || existing.Reason != reason) {
+		r.eventRecorder.Event(server, corev1.EventTypeWarning, reason, utils.WithRunbook(reason, message))
+	}
+	statusMgr.AddCondition(RegistrationEntriesWithinLimit, reason, message, conditionStatus)
+	return nil
+}
+
+// warningThreshold returns the number of entries from which a warning is emitted
+func warningThreshold(limits *v1alpha2.RegistrationLimits) int {
+	percent := limits.WarningThresholdPercent
+	if percent <= 0 || percent > 100 {
+		percent = 80
+	}
+	return int((int64(limits.MaxRegistrationEntries)*int64(percent) + 99) / 100)
+}
+
+// countRegistrationEntries returns the number of registration entries spire-controller-manager
+// sets, from the status of the ClusterSPIFFEIDs and ClusterStaticEntries of its class. They are
+// mostly created by users, so they are listed from the API server rather than the cache.
+func (r *SpireServerReconciler) countRegistrationEntries(ctx context.Context) (int, error) {
+	var clusterSPIFFEIDs spiffev1alpha1.ClusterSPIFFEIDList
+	if err := r.ctrlClient.ListUncached(ctx, &clusterSPIFFEIDs); err != nil {
+		return 0, fmt.Errorf("failed to list ClusterSPIFFEIDs: %w", err)
+	}
+	var staticEntries spiffev1alpha1.ClusterStaticEntryList
+	if err := r.ctrlClient.ListUncached(ctx, &staticEntries); err != nil {
+		return 0, fmt.Errorf("failed to list ClusterStaticEntries: %w", err)
+	}
+
+	entries := 0
+	for _, clusterSPIFFEID := range clusterSPIFFEIDs.Items {
+		if clusterSPIFFEID.Spec.ClassName == spireControllerManagerClassName {
+			entries += clusterSPIFFEID.Status.Stats.EntriesToSet
+		}
+	}
+	for _, staticEntry := range staticEntries.Items {
+		if staticEntry.Spec.ClassName == spireControllerManagerClassName && staticEntry.Status.Rendered && !staticEntry.Status.Masked {
+			entries++
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,126 @@
+package spire_server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	spiffev1alpha1 "github.com/spiffe/spire-controller-manager/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+)
+
+// registrationEntriesClient lists a ClusterSPIFFEID of the operator's class setting the given
+// number of entries, one of another class and a ClusterStaticEntry
+func registrationEntriesClient(entries int) *fakes.FakeCustomCtrlClient {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	fakeClient.ListUncachedStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+		switch l := list.(type) {
+		case *spiffev1alpha1.ClusterSPIFFEIDList:
+			managed := spiffev1alpha1.ClusterSPIFFEID{Spec: spiffev1alpha1.ClusterSPIFFEIDSpec{ClassName: spireControllerManagerClassName}}
+			managed.Status.Stats.EntriesToSet = entries - 1
+			other := spiffev1alpha1.ClusterSPIFFEID{Spec: spiffev1alpha1.ClusterSPIFFEIDSpec{ClassName: "other"}}
+			other.Status.Stats.EntriesToSet = 1000
+			l.Items = []spiffev1alpha1.ClusterSPIFFEID{managed, other}
+		case *spiffev1alpha1.ClusterStaticEntryList:
+			static := spiffev1alpha1.ClusterStaticEntry{Spec: spiffev1alpha1.ClusterStaticEntrySpec{ClassName: spireControllerManagerClassName}}
+			static.Status.Rendered = true
+			l.Items = []spiffev1alpha1.ClusterStaticEntry{static}
+		}
+		return nil
+	}
+	return fakeClient
+}
+
+func TestReconcileRegistrationLimits(t *testing.T) {
+	tests := []struct {
+		name           string
+		limits         *v1alpha2.RegistrationLimits
+		entries        int
+		existing       []metav1.Condition
+		expectReason   string
+		expectStatus   metav1.ConditionStatus
+		expectWarnings int
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name:         "limits removed",
+			existing:     []metav1.Condition{{Type: RegistrationEntriesWithinLimit, Reason: RegistrationLimitReasonExceeded, Status: metav1.ConditionFalse}},
+			expectReason: RegistrationLimitReasonNotConfigured,
+			expectStatus: metav1.ConditionTrue,
+		},
+		{
+			name:         "within limit",
+			limits:       &v1alpha2.RegistrationLimits{MaxRegistrationEntries: 100, WarningThresholdPercent: 80},
+			entries:      79,
+			expectReason: RegistrationLimitReasonWithinLimit,
+			expectStatus: metav1.ConditionTrue,
+		},
+		{
+			name:           "warning threshold crossed",
+			limits:         &v1alpha2.RegistrationLimits{MaxRegistrationEntries: 100, WarningThresholdPercent: 80},
+			entries:        80,
+			expectReason:   RegistrationLimitReasonApproaching,
+			expectStatus:   metav1.ConditionTrue,
+			expectWarnings: 1,
+		},
+		{
+			name:         "warning already reported",
+			limits:       &v1alpha2.RegistrationLimits{MaxRegistrationEntries: 100, WarningThresholdPercent: 80},
+			entries:      90,
+			existing:     []metav1.Condition{{Type: RegistrationEntriesWithinLimit, Reason: RegistrationLimitReasonApproaching, Status: metav1.ConditionTrue}},
+			expectReason: RegistrationLimitReasonApproaching,
+			expectStatus: metav1.ConditionTrue,
+		},
+		{
+			name:           "limit exceeded",
+			limits:         &v1alpha2.RegistrationLimits{MaxRegistrationEntries: 100, WarningThresholdPercent: 80},
+			entries:        101,
+			expectReason:   RegistrationLimitReasonExceeded,
+			expectStatus:   metav1.ConditionFalse,
+			expectWarnings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			reconciler := &SpireServerReconciler{
+				ctrlClient:    registrationEntriesClient(tt.entries),
+				ctx:           context.Background(),
+				log:           logr.Discard(),
+				eventRecorder: recorder,
+			}
+			server := &v1alpha2.SpireServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec:       v1alpha2.SpireServerSpec{Limits: tt.limits},
+				Status:     v1alpha2.SpireServerStatus{ConditionalStatus: v1alpha2.ConditionalStatus{Conditions: tt.existing}},
+			}
+			statusMgr := status.NewManager(reconciler.ctrlClient)
+
+			if err := reconciler.reconcileRegistrationLimits(context.Background(), server, statusMgr); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			cond, ok := statusMgr.GetCondition(RegistrationEntriesWithinLimit)
+			if tt.expectReason == "" {
+				if ok {
+					t.Errorf("Expected no condition, got %v", cond)
+				}
+				return
+			}
+			if cond.Reason != tt.expectReason || cond.Status != tt.expectStatus {
+				t.Errorf("Expected %s/%s, got %s/%s: %s", tt.expectStatus, tt.expectReason, cond.Status, cond.Reason, cond.Message)
+			}
+			if len(recorder.Events) != tt.expectWarnings {
+				t.Errorf("Expected %d warning Events, got %d", tt.expectWarnings, len(recorder.Events))
+			}
+		})
+	}
+}
@@ -0,0 +1,465 @@
+package spire_server
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	spiffev1alpha1 "github.com/spiffe/spire-controller-manager/api/v1alpha1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/version"
+)
+
+const (
+	// RolloutVerified reports the verification of the last rollout of the SPIRE server
+	RolloutVerified = "RolloutVerified"
+
+	RolloutVerifiedReasonSucceeded  = "RolloutVerified"
+	RolloutVerifiedReasonInProgress = "RolloutVerificationInProgress"
+	RolloutVerifiedReasonFailed     = "RolloutVerificationFailed"
+	RolloutVerifiedReasonError      = "RolloutVerificationReconcileFailed"
+	RolloutVerifiedReasonDisabled   = "RolloutVerificationDisabled"
+
+	rolloutVerificationName                = "spire-server-rollout-verification"
+	rolloutVerificationClusterSPIFFEIDName = "zero-trust-workload-identity-manager-spire-server-rollout-verification"
+	rolloutVerificationSocketDir           = "/spiffe-workload-api"
+	rolloutVerificationSVIDDir             = "/svid"
+	rolloutVerificationCSIDriverName       = "csi.spiffe.io"
+
+	// rolloutVerificationRevisionAnnotationKey records the StatefulSet revision verified by the Job
+	rolloutVerificationRevisionAnnotationKey = "ztwim.openshift.io/rollout-revision"
+
+	defaultRolloutVerificationTimeout   = 5 * time.Minute
+	defaultRolloutVerificationBundleTTL = time.Hour
+
+	// maxRolloutVerificationMessageLength bounds the failure message read from the Job pods
+	maxRolloutVerificationMessageLength = 512
+)
+
+// rolloutVerificationScript checks the chain of the X.509-SVID written by spire-agent against the
+// trust bundle, and that a CA of the bundle remains valid long enough. Failures are written to
+// the termination message of the container, read back by the operator.
+const rolloutVerificationScript = `set -u
+fail() { echo "$1" | tee /dev/termination-log >&2; exit 1; }
+cd ` + rolloutVerificationSVIDDir + `
+[ -s svid.0.pem ] && [ -s bundle.0.pem ] || fail "no X.509-SVID was fetched"
+out=$(openssl verify -CAfile bundle.0.pem -untrusted svid.0.pem svid.0.pem 2>&1) || fail "X.509-SVID chain does not verify against the trust bundle: $out"
+openssl x509 -in svid.0.pem -noout -ext subjectAltName | grep -q "URI:spiffe://$TRUST_DOMAIN/" || fail "X.509-SVID is not in trust domain $TRUST_DOMAIN"
+awk -v dir="$PWD" '/BEGIN CERTIFICATE/ {n++} n {print > (dir "/ca." n ".pem")}' bundle.0.pem
+for ca in ca.*.pem; do
+  if openssl x509 -in "$ca" -noout -checkend "$MIN_BUNDLE_VALIDITY_SECONDS" >/dev/null; then
+    echo "X.509-SVID chain verified, trust bundle fresh"
+    exit 0
+  fi
+done
+fail "no CA of the trust bundle remains valid for ${MIN_BUNDLE_VALIDITY_SECONDS}s"
+`
+
+// isRolloutVerificationEnabled reports whether the rollouts of the SPIRE server are verified
+func isRolloutVerificationEnabled(server *v1alpha2.SpireServer) bool {
+	return server.Spec.RolloutVerification != nil && ptr.Deref(server.Spec.RolloutVerification.Enabled, false)
+}
+
+// reconcileRolloutVerification runs the verification Job once the rollout of the current
+// StatefulSet revision completes, and reports its result. A revision is verified once: the
+// result is kept in the status until the StatefulSet rolls again.
+func (r *SpireServerReconciler) reconcileRolloutVerification(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, createOnlyMode bool) error {
+	if !isRolloutVerificationEnabled(server) {
+		return r.removeRolloutVerification(ctx, server, statusMgr)
+	}
+
+	var sts appsv1.StatefulSet
+	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: "spire-server", Namespace: utils.GetOperatorNamespace()}, &sts); err != nil {
+		return r.rolloutVerificationFailed(statusMgr, "StatefulSet", err)
+	}
+	revision := sts.Status.UpdateRevision
+	if !status.IsStatefulSetHealthy(&sts) || revision == "" {
+		statusMgr.AddCondition(RolloutVerified, RolloutVerifiedReasonInProgress,
+			"Waiting for the rollout of the SPIRE server StatefulSet to complete",
+			metav1.ConditionFalse)
+		return nil
+	}
+
+	// The revision was verified already
+	if verified := server.Status.RolloutVerification; verified != nil && verified.Revision == revision && verified.Result != v1alpha2.RolloutVerificationRunning {
+		reportRolloutVerification(statusMgr, verified)
+		return nil
+	}
+
+	if err := r.reconcileRolloutVerificationServiceAccount(ctx, server); err != nil {
+		return r.rolloutVerificationFailed(statusMgr, "ServiceAccount", err)
+	}
+	if err := r.reconcileRolloutVerificationClusterSPIFFEID(ctx, server, createOnlyMode); err != nil {
+		return r.rolloutVerificationFailed(statusMgr, "ClusterSPIFFEID", err)
+	}
+	job, err := r.reconcileRolloutVerificationJob(ctx, server, ztwim, revision)
+	if err != nil {
+		return r.rolloutVerificationFailed(statusMgr, "Job", err)
+	}
+
+	result := v1alpha2.RolloutVerificationStatus{Revision: revision, Result: v1alpha2.RolloutVerificationRunning}
+	if finished := rolloutVerificationJobFinished(job); finished != nil {
+		result.Result = v1alpha2.RolloutVerificationSucceeded
+		result.Message = finished.Message
+		result.CompletionTime = ptr.To(finished.LastTransitionTime)
+		if finished.Type == batchv1.JobFailed {
+			result.Result = v1alpha2.RolloutVerificationFailed
+			if result.Message == "" {
+				result.Message = finished.Reason
+			}
+			if podMessage := r.rolloutVerificationFailureMessage(ctx, job); podMessage != "" {
+				result.Message = podMessage
+			}
+			// The result of a revision is reported once, the next passes keep it from the status
+			r.eventRecorder.Event(server, corev1.EventTypeWarning, RolloutVerifiedReasonFailed,
+				utils.WithRunbook(RolloutVerifiedReasonFailed, rolloutVerificationMessage(&result)))
+		}
+	}
+	server.Status.RolloutVerification = &result
+	reportRolloutVerification(statusMgr, &result)
+	return nil
+}
+
+// reportRolloutVerification sets RolloutVerified from the result of the verification. The
+// revision is named in the message, so that the status is written for each rollout.
+func reportRolloutVerification(statusMgr *status.Manager, result *v1alpha2.RolloutVerificationStatus) {
+	switch result.Result {
+	case v1alpha2.RolloutVerificationSucceeded:
+		statusMgr.AddCondition(RolloutVerified, RolloutVerifiedReasonSucceeded, rolloutVerificationMessage(result), metav1.ConditionTrue)
+	case v1alpha2.RolloutVerificationFailed:
+		statusMgr.AddCondition(RolloutVerified, RolloutVerifiedReasonFailed, rolloutVerificationMessage(result), metav1.ConditionFalse)
+	default:
+		statusMgr.AddCondition(RolloutVerified, RolloutVerifiedReasonInProgress, rolloutVerificationMessage(result), metav1.ConditionFalse)
+	}
+}
+
+func rolloutVerificationMessage(result *v1alpha2.RolloutVerificationStatus) string {
+	switch result.Result {
+	case v1alpha2.RolloutVerificationSucceeded:
+		return fmt.Sprintf("Rollout of revision %s verified: an X.509-SVID was fetched, its chain verified and the trust bundle is fresh", result.Revision)
+	case v1alpha2.RolloutVerificationFailed:
+		return fmt.Sprintf("Rollout of revision %s failed verification: %s", result.Revision, result.Message)
+	}
+	return fmt.Sprintf("Verifying the rollout of revision %s", result.Revision)
+}
+
+func (r *SpireServerReconciler) rolloutVerificationFailed(statusMgr *status.Manager, kind string, err error) error {
+	r.log.Error(err, "failed to reconcile the rollout verification", "kind", kind)
+	statusMgr.AddCondition(RolloutVerified, RolloutVerifiedReasonError,
+		fmt.Sprintf("Failed to reconcile rollout verification %s: %v", kind, err),
+		metav1.ConditionFalse)
+	return err
+}
+
+// rolloutVerificationJobFinished returns the Complete or Failed condition of the Job, nil while
+// it runs
+func rolloutVerificationJobFinished(job *batchv1.Job) *batchv1.JobCondition {
+	for i, cond := range job.Status.Conditions {
+		if cond.Status == corev1.ConditionTrue && (cond.Type == batchv1.JobComplete || cond.Type == batchv1.JobFailed) {
+			return &job.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// rolloutVerificationFailureMessage returns the termination message of the last failed container
+// of the Job pods, which tells why the verification failed. Job pods are not labelled as managed
+// by the operator, so they are read from the API server.
+func (r *SpireServerReconciler) rolloutVerificationFailureMessage(ctx context.Context, job *batchv1.Job) string {
+	if job.Spec.Selector == nil {
+		return ""
+	}
+	var pods corev1.PodList
+	if err := r.ctrlClient.ListUncached(ctx, &pods, client.InNamespace(job.Namespace), client.MatchingLabels(job.Spec.Selector.MatchLabels)); err != nil {
+		r.log.Error(err, "failed to list the rollout verification pods")
+		return ""
+	}
+	var message string
+	var finishedAt time.Time
+	for _, pod := range pods.Items {
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, containerStatus := range statuses {
+			terminated := containerStatus.State.Terminated
+			if terminated == nil || terminated.ExitCode == 0 || terminated.Message == "" || terminated.FinishedAt.Time.Before(finishedAt) {
+				continue
+			}
+			message = strings.TrimSpace(terminated.Message)
+			finishedAt = terminated.FinishedAt.Time
+		}
+	}
+	if len(message) > maxRolloutVerificationMessageLength {
+		message = message[:maxRolloutVerificationMessageLength] + "..."
+	}
+	return message
+}
+
+func (r *SpireServerReconciler) reconcileRolloutVerificationServiceAccount(ctx context.Context, server *v1alpha2.SpireServer) error {
+	desired := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rolloutVerificationName,
+			Namespace: utils.GetOperatorNamespace(),
+			Labels:    rolloutVerificationLabels(server.Spec.Labels),
+		},
+	}
+	if err := controllerutil.SetControllerReference(server, desired, r.scheme); err != nil {
+		return err
+	}
+
+	var existing corev1.ServiceAccount
+	err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, &existing)
+	if kerrors.IsNotFound(err) {
+		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+			return err
+		}
+		r.log.Info("Created rollout verification ServiceAccount", "name", desired.Name)
+		return nil
+	}
+	return err
+}
+
+func (r *SpireServerReconciler) reconcileRolloutVerificationClusterSPIFFEID(ctx context.Context, server *v1alpha2.SpireServer, createOnlyMode bool) error {
+	desired := generateRolloutVerificationClusterSPIFFEID(server.Spec.Labels)
+	if err := controllerutil.SetControllerReference(server, desired, r.scheme); err != nil {
+		return err
+	}
+
+	var existing spiffev1alpha1.ClusterSPIFFEID
+	err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: desired.Name}, &existing)
+	if kerrors.IsNotFound(err) {
+		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+			return err
+		}
+		r.log.Info("Created rollout verification ClusterSPIFFEID", "name", desired.Name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if createOnlyMode || !utils.ResourceNeedsUpdate(&existing, desired) {
+		return nil
+	}
+	desired.ResourceVersion = existing.ResourceVersion
+	if err := r.ctrlClient.Update(ctx, desired); err != nil {
+		return err
+	}
+	r.log.Info("Updated rollout verification ClusterSPIFFEID", "name", desired.Name)
+	return nil
+}
+
+// reconcileRolloutVerificationJob returns the verification Job of the revision, replacing the
+// Job of a previous revision
+func (r *SpireServerReconciler) reconcileRolloutVerificationJob(ctx context.Context, server *v1alpha2.SpireServer, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, revision string) (*batchv1.Job, error) {
+	var existing batchv1.Job
+	err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: rolloutVerificationName, Namespace: utils.GetOperatorNamespace()}, &existing)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil {
+		if existing.Annotations[rolloutVerificationRevisionAnnotationKey] == revision {
+			return &existing, nil
+		}
+		// Jobs are immutable: the Job of the previous revision is deleted with its pods
+		if err := r.ctrlClient.Delete(ctx, &existing, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !kerrors.IsNotFound(err) {
+			return nil, err
+		}
+		r.log.Info("Deleted rollout verification Job of a previous revision", "name", existing.Name, "revision", existing.Annotations[rolloutVerificationRevisionAnnotationKey])
+	}
+
+	desired := generateRolloutVerificationJob(server, utils.TrustDomain(ztwim), revision)
+	if err := controllerutil.SetControllerReference(server, desired, r.scheme); err != nil {
+		return nil, err
+	}
+	if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		return nil, err
+	}
+	r.log.Info("Created rollout verification Job", "name", desired.Name, "revision", revision)
+	return desired, nil
+}
+
+// removeRolloutVerification deletes the rollout verification resources left behind once the
+// verification is disabled
+func (r *SpireServerReconciler) removeRolloutVerification(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager) error {
+	namespace := utils.GetOperatorNamespace()
+	objects := []client.Object{
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: rolloutVerificationName, Namespace: namespace}},
+		&spiffev1alpha1.ClusterSPIFFEID{ObjectMeta: metav1.ObjectMeta{Name: rolloutVerificationClusterSPIFFEIDName}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: rolloutVerificationName, Namespace: namespace}},
+	}
+	for _, obj := range objects {
+		if err := r.ctrlClient.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			return r.rolloutVerificationFailed(statusMgr, fmt.Sprintf("%T", obj), err)
+		}
+		if err := r.ctrlClient.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !kerrors.IsNotFound(err) {
+			return r.rolloutVerificationFailed(statusMgr, fmt.Sprintf("%T", obj), err)
+		}
+		r.log.Info("Deleted rollout verification resource", "kind", fmt.Sprintf("%T", obj), "name", obj.GetName())
+	}
+	server.Status.RolloutVerification = nil
+	if apimeta.FindStatusCondition(server.Status.Conditions, RolloutVerified) != nil {
+		statusMgr.AddCondition(RolloutVerified, RolloutVerifiedReasonDisabled,
+			"Rollout verification is disabled",
+			metav1.ConditionUnknown)
+	}
+	return nil
+}
+
+func rolloutVerificationLabels(customLabels map[string]string) map[string]string {
+	return utils.StandardizedLabels(rolloutVerificationName, utils.ComponentControlPlane, version.SpireServerVersion, customLabels)
+}
+
+func generateRolloutVerificationClusterSPIFFEID(customLabels map[string]string) *spiffev1alpha1.ClusterSPIFFEID {
+	return &spiffev1alpha1.ClusterSPIFFEID{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   rolloutVerificationClusterSPIFFEIDName,
+			Labels: rolloutVerificationLabels(customLabels),
+		},
+		Spec: spiffev1alpha1.ClusterSPIFFEIDSpec{
+			ClassName:        "zero-trust-workload-identity-manager-spire",
+			Hint:             "rollout-verification",
+			SPIFFEIDTemplate: "spiffe://{{ .TrustDomain }}/ns/{{ .PodMeta.Namespace }}/sa/{{ .PodSpec.ServiceAccountName }}",
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name":      rolloutVerificationName,
+					"app.kubernetes.io/instance":  utils.StandardInstance,
+					"app.kubernetes.io/component": utils.ComponentControlPlane,
+				},
+			},
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      "kubernetes.io/metadata.name",
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{utils.GetOperatorNamespace()},
+					},
+				},
+			},
+		},
+	}
+}
+
+// generateRolloutVerificationJob returns the Job verifying the revision. spire-agent fetches the
+// X.509-SVID of the pod through the Workload API into a memory volume, from which the chain and
+// the trust bundle are checked with openssl.
+func generateRolloutVerificationJob(server *v1alpha2.SpireServer, trustDomain, revision string) *batchv1.Job {
+	config := server.Spec.RolloutVerification
+	timeout := config.Timeout.Duration
+	if timeout <= 0 {
+		timeout = defaultRolloutVerificationTimeout
+	}
+	minBundleValidity := config.MinBundleValidity.Duration
+	if minBundleValidity <= 0 {
+		minBundleValidity = defaultRolloutVerificationBundleTTL
+	}
+	// The job pods must not match the selector of the server StatefulSet or Service
+	labels := rolloutVerificationLabels(server.Spec.Labels)
+	securityContext := &corev1.SecurityContext{
+		AllowPrivilegeEscalation: ptr.To(false),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+		ReadOnlyRootFilesystem: ptr.To(true),
+	}
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("5m"),
+			corev1.ResourceMemory: resource.MustParse("16Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		},
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rolloutVerificationName,
+			Namespace: utils.GetOperatorNamespace(),
+			Labels:    labels,
+			Annotations: map[string]string{
+				rolloutVerificationRevisionAnnotationKey: revision,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			ActiveDeadlineSeconds: ptr.To(int64(timeout.Seconds())),
+			// The entry of the pod may take a few seconds to reach the agent of its node
+			BackoffLimit: ptr.To(int32(3)),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: rolloutVerificationName,
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ImagePullSecrets:   server.Spec.ImagePullSecrets,
+					InitContainers: []corev1.Container{{
+						Name:                     "fetch-svid",
+						Image:                    utils.GetSpireAgentImage(),
+						ImagePullPolicy:          corev1.PullIfNotPresent,
+						Command:                  []string{"/opt/spire/bin/spire-agent", "api", "fetch", "x509", "-socketPath", rolloutVerificationSocketDir + "/spire-agent.sock", "-timeout", "30s", "-write", rolloutVerificationSVIDDir},
+						TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "spiffe-workload-api", MountPath: rolloutVerificationSocketDir, ReadOnly: true},
+							{Name: "svid", MountPath: rolloutVerificationSVIDDir},
+						},
+						Resources:       resources,
+						SecurityContext: securityContext,
+					}},
+					Containers: []corev1.Container{{
+						Name:            "verify",
+						Image:           utils.GetSpiffeCsiInitContainerImage(),
+						ImagePullPolicy: corev1.PullIfNotPresent,
+						Command:         []string{"/bin/bash", "-c", rolloutVerificationScript},
+						Env: []corev1.EnvVar{
+							{Name: "TRUST_DOMAIN", Value: trustDomain},
+							{Name: "MIN_BUNDLE_VALIDITY_SECONDS", Value: strconv.FormatInt(int64(minBundleValidity.Seconds()), 10)},
+						},
+						TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "svid", MountPath: rolloutVerificationSVIDDir},
+						},
+						Resources:       resources,
+						SecurityContext: securityContext,
+					}},
+					Volumes: []corev1.Volume{
+						{
+							Name: "spiffe-workload-api",
+							VolumeSource: corev1.VolumeSource{
+								CSI: &corev1.CSIVolumeSource{
+									Driver:   rolloutVerificationCSIDriverName,
+									ReadOnly: ptr.To(true),
+								},
+							},
+						},
+						{
+							// The private key of the SVID is never written to disk
+							Name: "svid",
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory},
+							},
+						},
+					},
+					NodeSelector: utils.DerefNodeSelector(server.Spec.NodeSelector),
+					Tolerations:  utils.DerefTolerations(server.Spec.Tolerations),
+				},
+			},
+		},
+	}
+}
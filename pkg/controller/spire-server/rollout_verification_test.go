@@ -0,0 +1,239 @@
+package spire_server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+)
+
+func newRolloutVerificationTestServer() *v1alpha2.SpireServer {
+	return &v1alpha2.SpireServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
+		Spec: v1alpha2.SpireServerSpec{
+			RolloutVerification: &v1alpha2.RolloutVerificationConfig{Enabled: ptr.To(true)},
+		},
+	}
+}
+
+func newRolloutVerificationTestStatefulSet(ready int32) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "spire-server", Generation: 2},
+		Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(1))},
+		Status: appsv1.StatefulSetStatus{
+			ObservedGeneration: 2,
+			ReadyReplicas:      ready,
+			UpdatedReplicas:    1,
+			UpdateRevision:     "spire-server-7d9f",
+		},
+	}
+}
+
+func TestGenerateRolloutVerificationJob(t *testing.T) {
+	server := newRolloutVerificationTestServer()
+	job := generateRolloutVerificationJob(server, "example.org", "spire-server-7d9f")
+
+	if job.Annotations[rolloutVerificationRevisionAnnotationKey] != "spire-server-7d9f" {
+		t.Errorf("Expected the revision annotated, got %v", job.Annotations)
+	}
+	if *job.Spec.ActiveDeadlineSeconds != int64(defaultRolloutVerificationTimeout.Seconds()) {
+		t.Errorf("Expected the default timeout, got %d", *job.Spec.ActiveDeadlineSeconds)
+	}
+	if labels := job.Spec.Template.Labels; labels["app.kubernetes.io/name"] == "spire-server" {
+		t.Error("Expected the job pods not to match the server selector")
+	}
+	podSpec := job.Spec.Template.Spec
+	if podSpec.Volumes[0].CSI == nil || podSpec.Volumes[0].CSI.Driver != rolloutVerificationCSIDriverName {
+		t.Errorf("Expected the Workload API mounted through the CSI driver, got %v", podSpec.Volumes[0])
+	}
+	if podSpec.Volumes[1].EmptyDir == nil || podSpec.Volumes[1].EmptyDir.Medium != corev1.StorageMediumMemory {
+		t.Errorf("Expected the SVID written to memory, got %v", podSpec.Volumes[1])
+	}
+	env := map[string]string{}
+	for _, e := range podSpec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	if env["TRUST_DOMAIN"] != "example.org" || env["MIN_BUNDLE_VALIDITY_SECONDS"] != "3600" {
+		t.Errorf("Unexpected verification environment %v", env)
+	}
+}
+
+func TestReconcileRolloutVerification(t *testing.T) {
+	tests := []struct {
+		name           string
+		ready          int32
+		existingJob    *batchv1.Job
+		verified       *v1alpha2.RolloutVerificationStatus
+		expectCreate   bool
+		expectDelete   bool
+		expectReason   string
+		expectResult   v1alpha2.RolloutVerificationResult
+		expectEvent    bool
+		expectContains string
+	}{
+		{
+			name:         "rollout in progress",
+			ready:        0,
+			expectReason: RolloutVerifiedReasonInProgress,
+		},
+		{
+			name:         "job created for the revision",
+			ready:        1,
+			expectCreate: true,
+			expectReason: RolloutVerifiedReasonInProgress,
+			expectResult: v1alpha2.RolloutVerificationRunning,
+		},
+		{
+			name:  "job of a previous revision replaced",
+			ready: 1,
+			existingJob: &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+				Name:        rolloutVerificationName,
+				Annotations: map[string]string{rolloutVerificationRevisionAnnotationKey: "spire-server-5c1a"},
+			}},
+			expectCreate: true,
+			expectDelete: true,
+			expectReason: RolloutVerifiedReasonInProgress,
+			expectResult: v1alpha2.RolloutVerificationRunning,
+		},
+		{
+			name:  "job succeeded",
+			ready: 1,
+			existingJob: &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        rolloutVerificationName,
+					Annotations: map[string]string{rolloutVerificationRevisionAnnotationKey: "spire-server-7d9f"},
+				},
+				Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}},
+			},
+			expectReason: RolloutVerifiedReasonSucceeded,
+			expectResult: v1alpha2.RolloutVerificationSucceeded,
+		},
+		{
+			name:  "job failed",
+			ready: 1,
+			existingJob: &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        rolloutVerificationName,
+					Annotations: map[string]string{rolloutVerificationRevisionAnnotationKey: "spire-server-7d9f"},
+				},
+				Spec: batchv1.JobSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"batch.kubernetes.io/controller-uid": "job-uid"}}},
+				Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{{
+					Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded",
+				}}},
+			},
+			expectReason:   RolloutVerifiedReasonFailed,
+			expectResult:   v1alpha2.RolloutVerificationFailed,
+			expectEvent:    true,
+			expectContains: "X.509-SVID chain does not verify against the trust bundle",
+		},
+		{
+			name:         "revision verified already",
+			ready:        1,
+			verified:     &v1alpha2.RolloutVerificationStatus{Revision: "spire-server-7d9f", Result: v1alpha2.RolloutVerificationSucceeded},
+			expectReason: RolloutVerifiedReasonSucceeded,
+			expectResult: v1alpha2.RolloutVerificationSucceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newRolloutVerificationTestServer()
+			server.Status.RolloutVerification = tt.verified
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				switch o := obj.(type) {
+				case *appsv1.StatefulSet:
+					newRolloutVerificationTestStatefulSet(tt.ready).DeepCopyInto(o)
+					return nil
+				case *batchv1.Job:
+					if tt.existingJob != nil {
+						tt.existingJob.DeepCopyInto(o)
+						return nil
+					}
+				}
+				return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+			}
+			fakeClient.ListUncachedStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				list.(*corev1.PodList).Items = []corev1.Pod{{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+					Name: "verify",
+					State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 1,
+						Message:  "X.509-SVID chain does not verify against the trust bundle: unable to get local issuer certificate\n",
+					}},
+				}}}}}
+				return nil
+			}
+			reconciler := newRBACTestReconciler(fakeClient)
+			recorder := record.NewFakeRecorder(10)
+			reconciler.eventRecorder = recorder
+			statusMgr := status.NewManager(fakeClient)
+
+			if err := reconciler.reconcileRolloutVerification(context.Background(), server, statusMgr, &v1alpha2.ZeroTrustWorkloadIdentityManager{}, false); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			var jobsCreated int
+			for i := 0; i < fakeClient.CreateCallCount(); i++ {
+				_, obj, _ := fakeClient.CreateArgsForCall(i)
+				if _, ok := obj.(*batchv1.Job); ok {
+					jobsCreated++
+				}
+			}
+			if (jobsCreated == 1) != tt.expectCreate {
+				t.Errorf("Expected Job created %v, got %d creates", tt.expectCreate, jobsCreated)
+			}
+			if (fakeClient.DeleteCallCount() == 1) != tt.expectDelete {
+				t.Errorf("Expected Job deleted %v, got %d deletes", tt.expectDelete, fakeClient.DeleteCallCount())
+			}
+			cond, ok := statusMgr.GetCondition(RolloutVerified)
+			if !ok || cond.Reason != tt.expectReason {
+				t.Fatalf("Expected %s reason %s, got %v", RolloutVerified, tt.expectReason, cond)
+			}
+			if tt.expectContains != "" && !strings.Contains(cond.Message, tt.expectContains) {
+				t.Errorf("Expected the message to contain %q, got %q", tt.expectContains, cond.Message)
+			}
+			if tt.expectResult != "" && (server.Status.RolloutVerification == nil || server.Status.RolloutVerification.Result != tt.expectResult) {
+				t.Errorf("Expected result %s, got %v", tt.expectResult, server.Status.RolloutVerification)
+			}
+			if events := len(recorder.Events); (events == 1) != tt.expectEvent {
+				t.Errorf("Expected event %v, got %d events", tt.expectEvent, events)
+			}
+		})
+	}
+}
+
+func TestReconcileRolloutVerification_Disabled(t *testing.T) {
+	server := newRolloutVerificationTestServer()
+	server.Spec.RolloutVerification = nil
+	server.Status.RolloutVerification = &v1alpha2.RolloutVerificationStatus{Revision: "spire-server-7d9f", Result: v1alpha2.RolloutVerificationSucceeded}
+	server.Status.Conditions = []metav1.Condition{{Type: RolloutVerified, Status: metav1.ConditionTrue, Reason: RolloutVerifiedReasonSucceeded}}
+
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	reconciler := newRBACTestReconciler(fakeClient)
+	statusMgr := status.NewManager(fakeClient)
+	if err := reconciler.reconcileRolloutVerification(context.Background(), server, statusMgr, &v1alpha2.ZeroTrustWorkloadIdentityManager{}, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fakeClient.DeleteCallCount() != 3 {
+		t.Errorf("Expected the Job, ClusterSPIFFEID and ServiceAccount deleted, got %d deletes", fakeClient.DeleteCallCount())
+	}
+	if server.Status.RolloutVerification != nil {
+		t.Errorf("Expected the verification status cleared, got %v", server.Status.RolloutVerification)
+	}
+	if cond, ok := statusMgr.GetCondition(RolloutVerified); !ok || cond.Reason != RolloutVerifiedReasonDisabled {
+		t.Errorf("Expected %s reason %s, got %v", RolloutVerified, RolloutVerifiedReasonDisabled, cond)
+	}
+}
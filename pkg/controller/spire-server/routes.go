@@ -12,17 +12,18 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 )
 
 // generateFederationRoute creates an OpenShift Route resource for the SPIRE federation endpoint
-func generateFederationRoute(server *v1alpha1.SpireServer, ztwim *v1alpha1.ZeroTrustWorkloadIdentityManager) *routev1.Route {
+func generateFederationRoute(server *v1alpha2.SpireServer, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager) *routev1.Route {
 	labels := utils.SpireServerLabels(server.Spec.Labels)
 
 	// Construct federation host using trust domain
-	federationHost := "federation." + ztwim.Spec.TrustDomain
+	federationHost := "federation." + utils.TrustDomain(ztwim)
 
 	route := &routev1.Route{
 		ObjectMeta: metav1.ObjectMeta{
@@ -46,13 +47,13 @@ func generateFederationRoute(server *v1alpha1.SpireServer, ztwim *v1alpha1.ZeroT
 
 	// Configure TLS based on profile
 	switch server.Spec.Federation.BundleEndpoint.Profile {
-	case v1alpha1.HttpsSpiffeProfile:
+	case v1alpha2.HttpsSpiffeProfile:
 		// https_spiffe profile uses passthrough TLS
 		route.Spec.TLS = &routev1.TLSConfig{
 			Termination:                   routev1.TLSTerminationPassthrough,
 			InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
 		}
-	case v1alpha1.HttpsWebProfile:
+	case v1alpha2.HttpsWebProfile:
 		// https_web profile: termination depends on ACME vs ServingCert
 		if server.Spec.Federation.BundleEndpoint.HttpsWeb != nil &&
 			server.Spec.Federation.BundleEndpoint.HttpsWeb.Acme != nil {
@@ -89,14 +90,21 @@ func checkFederationRouteConflict(current, desired *routev1.Route) bool {
 }
 
 // reconcileRoute creates/updates route when managedRoute is enabled else sets status to disabled
-func (r *SpireServerReconciler) reconcileRoute(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager, ztwim *v1alpha1.ZeroTrustWorkloadIdentityManager, createOnlyMode bool) error {
+func (r *SpireServerReconciler) reconcileRoute(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, createOnlyMode bool) error {
 	// Check if federation is configured
 	if server.Spec.Federation == nil {
 		// No federation configured - don't manage route, don't set status
 		return nil
 	}
 
-	if utils.StringToBool(server.Spec.Federation.ManagedRoute) {
+	if !utils.HasCapability(utils.CapabilityRoute) {
+		statusMgr.AddCondition(RouteAvailable, utils.ReasonAPINotServed,
+			"Routes are not served by the cluster, expose the federation port of the spire-server Service instead",
+			metav1.ConditionTrue)
+		return nil
+	}
+
+	if ptr.Deref(server.Spec.Federation.ManagedRoute, false) {
 		// Create Route for federation endpoint
 		route := generateFederationRoute(server, ztwim)
 
@@ -107,7 +115,7 @@ func (r *SpireServerReconciler) reconcileRoute(ctx context.Context, server *v1al
 		}, &existingRoute)
 		if err != nil {
 			if kerrors.IsNotFound(err) {
-				if err = r.ctrlClient.Create(ctx, route); err != nil {
+				if err = r.ctrlClient.Create(ctx, route, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false))); err != nil {
 					r.log.Error(err, "Failed to create federation route")
 					statusMgr.AddCondition(RouteAvailable, "FederationRouteCreationFailed",
 						err.Error(),
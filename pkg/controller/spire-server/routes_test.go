@@ -7,7 +7,7 @@ import (
 
 	"github.com/go-logr/logr"
 	routev1 "github.com/openshift/api/route/v1"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
@@ -17,24 +17,25 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestGenerateFederationRoute(t *testing.T) {
 	tests := []struct {
 		name                   string
-		server                 *v1alpha1.SpireServer
+		server                 *v1alpha2.SpireServer
 		expectedHost           string
 		expectedTLSTermination routev1.TLSTerminationType
 		expectExternalCert     bool
 	}{
 		{
 			name: "https_spiffe profile with passthrough TLS",
-			server: &v1alpha1.SpireServer{
-				Spec: v1alpha1.SpireServerSpec{
-					Federation: &v1alpha1.FederationConfig{
-						BundleEndpoint: v1alpha1.BundleEndpointConfig{
-							Profile: v1alpha1.HttpsSpiffeProfile,
+			server: &v1alpha2.SpireServer{
+				Spec: v1alpha2.SpireServerSpec{
+					Federation: &v1alpha2.FederationConfig{
+						BundleEndpoint: v1alpha2.BundleEndpointConfig{
+							Profile: v1alpha2.HttpsSpiffeProfile,
 						},
 					},
 				},
@@ -45,13 +46,13 @@ func TestGenerateFederationRoute(t *testing.T) {
 		},
 		{
 			name: "https_web profile with ACME uses passthrough TLS",
-			server: &v1alpha1.SpireServer{
-				Spec: v1alpha1.SpireServerSpec{
-					Federation: &v1alpha1.FederationConfig{
-						BundleEndpoint: v1alpha1.BundleEndpointConfig{
-							Profile: v1alpha1.HttpsWebProfile,
-							HttpsWeb: &v1alpha1.HttpsWebConfig{
-								Acme: &v1alpha1.AcmeConfig{
+			server: &v1alpha2.SpireServer{
+				Spec: v1alpha2.SpireServerSpec{
+					Federation: &v1alpha2.FederationConfig{
+						BundleEndpoint: v1alpha2.BundleEndpointConfig{
+							Profile: v1alpha2.HttpsWebProfile,
+							HttpsWeb: &v1alpha2.HttpsWebConfig{
+								Acme: &v1alpha2.AcmeConfig{
 									DirectoryUrl: "https://acme.example.com/directory",
 									DomainName:   "federation.example.org",
 									Email:        "admin@example.org",
@@ -67,13 +68,13 @@ func TestGenerateFederationRoute(t *testing.T) {
 		},
 		{
 			name: "https_web profile with ServingCert uses re-encrypt TLS",
-			server: &v1alpha1.SpireServer{
-				Spec: v1alpha1.SpireServerSpec{
-					Federation: &v1alpha1.FederationConfig{
-						BundleEndpoint: v1alpha1.BundleEndpointConfig{
-							Profile: v1alpha1.HttpsWebProfile,
-							HttpsWeb: &v1alpha1.HttpsWebConfig{
-								ServingCert: &v1alpha1.ServingCertConfig{},
+			server: &v1alpha2.SpireServer{
+				Spec: v1alpha2.SpireServerSpec{
+					Federation: &v1alpha2.FederationConfig{
+						BundleEndpoint: v1alpha2.BundleEndpointConfig{
+							Profile: v1alpha2.HttpsWebProfile,
+							HttpsWeb: &v1alpha2.HttpsWebConfig{
+								ServingCert: &v1alpha2.ServingCertConfig{},
 							},
 						},
 					},
@@ -85,13 +86,13 @@ func TestGenerateFederationRoute(t *testing.T) {
 		},
 		{
 			name: "https_web profile with external certificate",
-			server: &v1alpha1.SpireServer{
-				Spec: v1alpha1.SpireServerSpec{
-					Federation: &v1alpha1.FederationConfig{
-						BundleEndpoint: v1alpha1.BundleEndpointConfig{
-							Profile: v1alpha1.HttpsWebProfile,
-							HttpsWeb: &v1alpha1.HttpsWebConfig{
-								ServingCert: &v1alpha1.ServingCertConfig{
+			server: &v1alpha2.SpireServer{
+				Spec: v1alpha2.SpireServerSpec{
+					Federation: &v1alpha2.FederationConfig{
+						BundleEndpoint: v1alpha2.BundleEndpointConfig{
+							Profile: v1alpha2.HttpsWebProfile,
+							HttpsWeb: &v1alpha2.HttpsWebConfig{
+								ServingCert: &v1alpha2.ServingCertConfig{
 									ExternalSecretRef: "external-cert-secret",
 								},
 							},
@@ -107,8 +108,8 @@ func TestGenerateFederationRoute(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
-				Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+			ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+				Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 					TrustDomain:     "example.org",
 					ClusterName:     "test-cluster",
 					BundleConfigMap: "spire-bundle",
@@ -269,18 +270,18 @@ func TestGenerateFederationRouteWithDifferentTrustDomains(t *testing.T) {
 
 	for _, td := range trustDomains {
 		t.Run(td, func(t *testing.T) {
-			server := &v1alpha1.SpireServer{
-				Spec: v1alpha1.SpireServerSpec{
-					Federation: &v1alpha1.FederationConfig{
-						BundleEndpoint: v1alpha1.BundleEndpointConfig{
-							Profile: v1alpha1.HttpsSpiffeProfile,
+			server := &v1alpha2.SpireServer{
+				Spec: v1alpha2.SpireServerSpec{
+					Federation: &v1alpha2.FederationConfig{
+						BundleEndpoint: v1alpha2.BundleEndpointConfig{
+							Profile: v1alpha2.HttpsSpiffeProfile,
 						},
 					},
 				},
 			}
 
-			ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
-				Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+			ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+				Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 					TrustDomain:     td,
 					ClusterName:     "test-cluster",
 					BundleConfigMap: "spire-bundle",
@@ -303,21 +304,21 @@ func TestGenerateFederationRouteLabels(t *testing.T) {
 		"custom-label-2": "value2",
 	}
 
-	server := &v1alpha1.SpireServer{
-		Spec: v1alpha1.SpireServerSpec{
-			Federation: &v1alpha1.FederationConfig{
-				BundleEndpoint: v1alpha1.BundleEndpointConfig{
-					Profile: v1alpha1.HttpsSpiffeProfile,
+	server := &v1alpha2.SpireServer{
+		Spec: v1alpha2.SpireServerSpec{
+			Federation: &v1alpha2.FederationConfig{
+				BundleEndpoint: v1alpha2.BundleEndpointConfig{
+					Profile: v1alpha2.HttpsSpiffeProfile,
 				},
 			},
-			CommonConfig: v1alpha1.CommonConfig{
+			CommonConfig: v1alpha2.CommonConfig{
 				Labels: customLabels,
 			},
 		},
 	}
 
-	ztwim := &v1alpha1.ZeroTrustWorkloadIdentityManager{
-		Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 			TrustDomain:     "example.org",
 			ClusterName:     "test-cluster",
 			BundleConfigMap: "spire-bundle",
@@ -349,7 +350,7 @@ func TestGenerateFederationRouteLabels(t *testing.T) {
 // newRouteTestReconciler creates a reconciler for route tests
 func newRouteTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireServerReconciler {
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = routev1.AddToScheme(scheme)
 	return &SpireServerReconciler{
 		ctrlClient:    fakeClient,
@@ -361,17 +362,17 @@ func newRouteTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireServer
 }
 
 // createRouteTestServer creates a test server for route tests
-func createRouteTestServer() *v1alpha1.SpireServer {
-	return &v1alpha1.SpireServer{
+func createRouteTestServer() *v1alpha2.SpireServer {
+	return &v1alpha2.SpireServer{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 			UID:  "test-uid",
 		},
-		Spec: v1alpha1.SpireServerSpec{
-			Federation: &v1alpha1.FederationConfig{
-				ManagedRoute: "true",
-				BundleEndpoint: v1alpha1.BundleEndpointConfig{
-					Profile: v1alpha1.HttpsSpiffeProfile,
+		Spec: v1alpha2.SpireServerSpec{
+			Federation: &v1alpha2.FederationConfig{
+				ManagedRoute: ptr.To(true),
+				BundleEndpoint: v1alpha2.BundleEndpointConfig{
+					Profile: v1alpha2.HttpsSpiffeProfile,
 				},
 			},
 		},
@@ -379,12 +380,12 @@ func createRouteTestServer() *v1alpha1.SpireServer {
 }
 
 // createRouteTestZTWIM creates a test ZTWIM for route tests
-func createRouteTestZTWIM() *v1alpha1.ZeroTrustWorkloadIdentityManager {
-	return &v1alpha1.ZeroTrustWorkloadIdentityManager{
+func createRouteTestZTWIM() *v1alpha2.ZeroTrustWorkloadIdentityManager {
+	return &v1alpha2.ZeroTrustWorkloadIdentityManager{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 		},
-		Spec: v1alpha1.ZeroTrustWorkloadIdentityManagerSpec{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
 			TrustDomain:     "example.org",
 			ClusterName:     "test-cluster",
 			BundleConfigMap: "spire-bundle",
@@ -395,7 +396,7 @@ func createRouteTestZTWIM() *v1alpha1.ZeroTrustWorkloadIdentityManager {
 func TestReconcileRoute(t *testing.T) {
 	tests := []struct {
 		name           string
-		server         *v1alpha1.SpireServer
+		server         *v1alpha2.SpireServer
 		setupClient    func(*fakes.FakeCustomCtrlClient)
 		createOnlyMode bool
 		expectError    bool
@@ -405,9 +406,9 @@ func TestReconcileRoute(t *testing.T) {
 	}{
 		{
 			name: "no federation configured - returns nil",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec:       v1alpha1.SpireServerSpec{Federation: nil},
+				Spec:       v1alpha2.SpireServerSpec{Federation: nil},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {},
 			expectError: false,
@@ -415,13 +416,13 @@ func TestReconcileRoute(t *testing.T) {
 		},
 		{
 			name: "managed route disabled - does not create route",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					Federation: &v1alpha1.FederationConfig{
-						ManagedRoute: "false",
-						BundleEndpoint: v1alpha1.BundleEndpointConfig{
-							Profile: v1alpha1.HttpsSpiffeProfile,
+				Spec: v1alpha2.SpireServerSpec{
+					Federation: &v1alpha2.FederationConfig{
+						ManagedRoute: ptr.To(false),
+						BundleEndpoint: v1alpha2.BundleEndpointConfig{
+							Profile: v1alpha2.HttpsSpiffeProfile,
 						},
 					},
 				},
@@ -459,7 +460,7 @@ func TestReconcileRoute(t *testing.T) {
 		},
 		{
 			name: "update route success",
-			server: func() *v1alpha1.SpireServer {
+			server: func() *v1alpha2.SpireServer {
 				s := createRouteTestServer()
 				s.Spec.Labels = map[string]string{"new": "label"}
 				return s
@@ -487,7 +488,7 @@ func TestReconcileRoute(t *testing.T) {
 		},
 		{
 			name: "update route error",
-			server: func() *v1alpha1.SpireServer {
+			server: func() *v1alpha2.SpireServer {
 				s := createRouteTestServer()
 				s.Spec.Labels = map[string]string{"new": "label"}
 				return s
@@ -515,7 +516,7 @@ func TestReconcileRoute(t *testing.T) {
 		},
 		{
 			name: "create only mode skips update",
-			server: func() *v1alpha1.SpireServer {
+			server: func() *v1alpha2.SpireServer {
 				s := createRouteTestServer()
 				s.Spec.Labels = map[string]string{"new": "label"}
 				return s
@@ -9,16 +9,18 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/operator/assets"
 )
 
 // reconcileService reconciles all Services (spire-server and controller-manager)
-func (r *SpireServerReconciler) reconcileService(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireServerReconciler) reconcileService(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
 	// Spire Server Service
 	if err := r.reconcileSpireServerService(ctx, server, statusMgr, createOnlyMode); err != nil {
 		return err
@@ -29,7 +31,7 @@ func (r *SpireServerReconciler) reconcileService(ctx context.Context, server *v1
 		return err
 	}
 
-	statusMgr.AddCondition(ServiceAvailable, v1alpha1.ReasonReady,
+	statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonReady,
 		"All Service resources available",
 		metav1.ConditionTrue)
 
@@ -37,12 +39,19 @@ func (r *SpireServerReconciler) reconcileService(ctx context.Context, server *v1
 }
 
 // reconcileSpireServerService reconciles the Spire Server Service
-func (r *SpireServerReconciler) reconcileSpireServerService(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireServerReconciler) reconcileSpireServerService(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
 	desired := getSpireServerService(&server.Spec)
+	if err := utils.ApplyServiceConfig(desired, server.Spec.Service); err != nil {
+		r.log.Error(err, "invalid service configuration")
+		statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonFailed,
+			fmt.Sprintf("Invalid Service configuration: %v", err),
+			metav1.ConditionFalse)
+		return err
+	}
 
 	if err := controllerutil.SetControllerReference(server, desired, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference on service")
-		statusMgr.AddCondition(ServiceAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to set owner reference on Service: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -56,16 +65,16 @@ func (r *SpireServerReconciler) reconcileSpireServerService(ctx context.Context,
 		if !kerrors.IsNotFound(err) {
 			// Unexpected error
 			r.log.Error(err, "failed to get service")
-			statusMgr.AddCondition(ServiceAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to get Service: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create service")
-			statusMgr.AddCondition(ServiceAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to create Service: %v", err),
 				metav1.ConditionFalse)
 			return err
@@ -92,6 +101,7 @@ func (r *SpireServerReconciler) reconcileSpireServerService(ctx context.Context,
 	if existing.Spec.HealthCheckNodePort != 0 {
 		desired.Spec.HealthCheckNodePort = existing.Spec.HealthCheckNodePort
 	}
+	utils.PreserveServiceNodePorts(existing, desired)
 
 	// Normalize ports - set default protocol to TCP if not specified
 	for i := range desired.Spec.Ports {
@@ -109,7 +119,7 @@ func (r *SpireServerReconciler) reconcileSpireServerService(ctx context.Context,
 	// Update the resource
 	if err := r.ctrlClient.Update(ctx, desired); err != nil {
 		r.log.Error(err, "failed to update service")
-		statusMgr.AddCondition(ServiceAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to update Service: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -120,12 +130,12 @@ func (r *SpireServerReconciler) reconcileSpireServerService(ctx context.Context,
 }
 
 // reconcileSpireControllerManagerService reconciles the Controller Manager webhook Service
-func (r *SpireServerReconciler) reconcileSpireControllerManagerService(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireServerReconciler) reconcileSpireControllerManagerService(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
 	desired := getSpireControllerManagerWebhookService(server.Spec.Labels)
 
 	if err := controllerutil.SetControllerReference(server, desired, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference on controller manager service")
-		statusMgr.AddCondition(ServiceAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to set owner reference on Controller Manager Service: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -139,16 +149,16 @@ func (r *SpireServerReconciler) reconcileSpireControllerManagerService(ctx conte
 		if !kerrors.IsNotFound(err) {
 			// Unexpected error
 			r.log.Error(err, "failed to get controller manager service")
-			statusMgr.AddCondition(ServiceAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to get Controller Manager Service: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create controller manager service")
-			statusMgr.AddCondition(ServiceAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to create Controller Manager Service: %v", err),
 				metav1.ConditionFalse)
 			return err
@@ -192,7 +202,7 @@ func (r *SpireServerReconciler) reconcileSpireControllerManagerService(ctx conte
 	// Update the resource
 	if err := r.ctrlClient.Update(ctx, desired); err != nil {
 		r.log.Error(err, "failed to update controller manager service")
-		statusMgr.AddCondition(ServiceAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(ServiceAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to update Controller Manager Service: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -203,7 +213,7 @@ func (r *SpireServerReconciler) reconcileSpireControllerManagerService(ctx conte
 }
 
 // getSpireServerService returns the Spire Server Service with proper labels, selectors, and conditional federation support
-func getSpireServerService(config *v1alpha1.SpireServerSpec) *corev1.Service {
+func getSpireServerService(config *v1alpha2.SpireServerSpec) *corev1.Service {
 	svc := utils.DecodeServiceObjBytes(assets.MustAsset(utils.SpireServerServiceAssetName))
 	svc.Labels = utils.SpireServerLabels(config.Labels)
 	svc.Namespace = utils.GetOperatorNamespace()
@@ -8,9 +8,11 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/operator/assets"
@@ -19,12 +21,12 @@ import (
 // Constants for status conditions are defined in controller.go
 
 // reconcileServiceAccount reconciles the Spire Server ServiceAccount
-func (r *SpireServerReconciler) reconcileServiceAccount(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireServerReconciler) reconcileServiceAccount(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
 	desired := getSpireServerServiceAccount(server.Spec.Labels)
 
 	if err := controllerutil.SetControllerReference(server, desired, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference on service account")
-		statusMgr.AddCondition(ServiceAccountAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(ServiceAccountAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to set owner reference on ServiceAccount: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -38,23 +40,23 @@ func (r *SpireServerReconciler) reconcileServiceAccount(ctx context.Context, ser
 		if !kerrors.IsNotFound(err) {
 			// Unexpected error
 			r.log.Error(err, "failed to get service account")
-			statusMgr.AddCondition(ServiceAccountAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(ServiceAccountAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to get ServiceAccount: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create service account")
-			statusMgr.AddCondition(ServiceAccountAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(ServiceAccountAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to create ServiceAccount: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		r.log.Info("Created ServiceAccount", "name", desired.Name, "namespace", desired.Namespace)
-		statusMgr.AddCondition(ServiceAccountAvailable, v1alpha1.ReasonReady,
+		statusMgr.AddCondition(ServiceAccountAvailable, v1alpha2.ReasonReady,
 			"All ServiceAccount resources available",
 			metav1.ConditionTrue)
 		return nil
@@ -63,7 +65,7 @@ func (r *SpireServerReconciler) reconcileServiceAccount(ctx context.Context, ser
 	// Resource exists, check if we need to update
 	if createOnlyMode {
 		r.log.V(1).Info("ServiceAccount exists, skipping update due to create-only mode", "name", desired.Name)
-		statusMgr.AddCondition(ServiceAccountAvailable, v1alpha1.ReasonReady,
+		statusMgr.AddCondition(ServiceAccountAvailable, v1alpha2.ReasonReady,
 			"All ServiceAccount resources available",
 			metav1.ConditionTrue)
 		return nil
@@ -72,7 +74,7 @@ func (r *SpireServerReconciler) reconcileServiceAccount(ctx context.Context, ser
 	// Check if update is needed
 	if !utils.ResourceNeedsUpdate(existing, desired) {
 		r.log.V(1).Info("ServiceAccount is up to date", "name", desired.Name)
-		statusMgr.AddCondition(ServiceAccountAvailable, v1alpha1.ReasonReady,
+		statusMgr.AddCondition(ServiceAccountAvailable, v1alpha2.ReasonReady,
 			"All ServiceAccount resources available",
 			metav1.ConditionTrue)
 		return nil
@@ -82,14 +84,14 @@ func (r *SpireServerReconciler) reconcileServiceAccount(ctx context.Context, ser
 	desired.ResourceVersion = existing.ResourceVersion
 	if err := r.ctrlClient.Update(ctx, desired); err != nil {
 		r.log.Error(err, "failed to update service account")
-		statusMgr.AddCondition(ServiceAccountAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(ServiceAccountAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to update ServiceAccount: %v", err),
 			metav1.ConditionFalse)
 		return err
 	}
 
 	r.log.Info("Updated ServiceAccount", "name", desired.Name, "namespace", desired.Namespace)
-	statusMgr.AddCondition(ServiceAccountAvailable, v1alpha1.ReasonReady,
+	statusMgr.AddCondition(ServiceAccountAvailable, v1alpha2.ReasonReady,
 		"All ServiceAccount resources available",
 		metav1.ConditionTrue)
 	return nil
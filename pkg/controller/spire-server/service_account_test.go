@@ -6,7 +6,7 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
@@ -101,7 +101,7 @@ func TestGetSpireServerServiceAccount(t *testing.T) {
 // newSATestReconciler creates a reconciler for ServiceAccount tests
 func newSATestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireServerReconciler {
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 	return &SpireServerReconciler{
 		ctrlClient:    fakeClient,
@@ -115,7 +115,7 @@ func newSATestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireServerRec
 func TestReconcileServiceAccount(t *testing.T) {
 	tests := []struct {
 		name           string
-		server         *v1alpha1.SpireServer
+		server         *v1alpha2.SpireServer
 		setupClient    func(*fakes.FakeCustomCtrlClient)
 		createOnlyMode bool
 		useEmptyScheme bool
@@ -125,7 +125,7 @@ func TestReconcileServiceAccount(t *testing.T) {
 	}{
 		{
 			name: "create success",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -137,7 +137,7 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "create error",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -148,7 +148,7 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "get error",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -158,7 +158,7 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "create only mode skips update",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -182,10 +182,10 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "update success",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireServerSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -212,10 +212,10 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "update error",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireServerSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -242,7 +242,7 @@ func TestReconcileServiceAccount(t *testing.T) {
 		},
 		{
 			name: "set controller ref error",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient:    func(fc *fakes.FakeCustomCtrlClient) {},
@@ -6,7 +6,7 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
@@ -22,7 +22,7 @@ import (
 func TestGetSpireServerService(t *testing.T) {
 	tests := []struct {
 		name                      string
-		config                    *v1alpha1.SpireServerSpec
+		config                    *v1alpha2.SpireServerSpec
 		expectServiceName         string
 		expectFederationPort      bool
 		expectServiceCAAnnotation bool
@@ -30,17 +30,17 @@ func TestGetSpireServerService(t *testing.T) {
 	}{
 		{
 			name:                      "without custom labels and without federation",
-			config:                    &v1alpha1.SpireServerSpec{},
+			config:                    &v1alpha2.SpireServerSpec{},
 			expectServiceName:         "spire-server",
 			expectFederationPort:      false,
 			expectServiceCAAnnotation: false,
 		},
 		{
 			name: "with federation enabled",
-			config: &v1alpha1.SpireServerSpec{
-				Federation: &v1alpha1.FederationConfig{
-					BundleEndpoint: v1alpha1.BundleEndpointConfig{
-						Profile: v1alpha1.HttpsSpiffeProfile,
+			config: &v1alpha2.SpireServerSpec{
+				Federation: &v1alpha2.FederationConfig{
+					BundleEndpoint: v1alpha2.BundleEndpointConfig{
+						Profile: v1alpha2.HttpsSpiffeProfile,
 					},
 				},
 			},
@@ -50,8 +50,8 @@ func TestGetSpireServerService(t *testing.T) {
 		},
 		{
 			name: "with custom labels",
-			config: &v1alpha1.SpireServerSpec{
-				CommonConfig: v1alpha1.CommonConfig{
+			config: &v1alpha2.SpireServerSpec{
+				CommonConfig: v1alpha2.CommonConfig{
 					Labels: map[string]string{
 						"service-type": "control-plane",
 						"priority":     "critical",
@@ -149,7 +149,7 @@ func TestGetSpireServerService(t *testing.T) {
 
 	// Test that asset labels are preserved when custom labels are added
 	t.Run("preserves all asset labels", func(t *testing.T) {
-		configWithoutCustom := &v1alpha1.SpireServerSpec{}
+		configWithoutCustom := &v1alpha2.SpireServerSpec{}
 		svcWithoutCustom := getSpireServerService(configWithoutCustom)
 		assetLabels := make(map[string]string)
 		for k, v := range svcWithoutCustom.Labels {
@@ -157,8 +157,8 @@ func TestGetSpireServerService(t *testing.T) {
 		}
 
 		customLabels := map[string]string{"cluster": "production"}
-		configWithCustom := &v1alpha1.SpireServerSpec{
-			CommonConfig: v1alpha1.CommonConfig{
+		configWithCustom := &v1alpha2.SpireServerSpec{
+			CommonConfig: v1alpha2.CommonConfig{
 				Labels: customLabels,
 			},
 		}
@@ -255,7 +255,7 @@ func TestGetSpireControllerManagerWebhookService(t *testing.T) {
 // newServiceTestReconciler creates a reconciler for Service tests
 func newServiceTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireServerReconciler {
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
 	return &SpireServerReconciler{
 		ctrlClient:    fakeClient,
@@ -269,7 +269,7 @@ func newServiceTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireServ
 func TestReconcileSpireServerService(t *testing.T) {
 	tests := []struct {
 		name           string
-		server         *v1alpha1.SpireServer
+		server         *v1alpha2.SpireServer
 		setupClient    func(*fakes.FakeCustomCtrlClient)
 		createOnlyMode bool
 		useEmptyScheme bool
@@ -279,7 +279,7 @@ func TestReconcileSpireServerService(t *testing.T) {
 	}{
 		{
 			name: "create success",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -291,7 +291,7 @@ func TestReconcileSpireServerService(t *testing.T) {
 		},
 		{
 			name: "create error",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -302,7 +302,7 @@ func TestReconcileSpireServerService(t *testing.T) {
 		},
 		{
 			name: "get error",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -312,10 +312,10 @@ func TestReconcileSpireServerService(t *testing.T) {
 		},
 		{
 			name: "update success",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireServerSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -343,10 +343,10 @@ func TestReconcileSpireServerService(t *testing.T) {
 		},
 		{
 			name: "update error",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireServerSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -374,10 +374,10 @@ func TestReconcileSpireServerService(t *testing.T) {
 		},
 		{
 			name: "create only mode skips update",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireServerSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -405,7 +405,7 @@ func TestReconcileSpireServerService(t *testing.T) {
 		},
 		{
 			name: "set controller ref error",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient:    func(fc *fakes.FakeCustomCtrlClient) {},
@@ -456,7 +456,7 @@ func TestReconcileSpireServerService(t *testing.T) {
 func TestReconcileSpireControllerManagerService(t *testing.T) {
 	tests := []struct {
 		name           string
-		server         *v1alpha1.SpireServer
+		server         *v1alpha2.SpireServer
 		setupClient    func(*fakes.FakeCustomCtrlClient)
 		createOnlyMode bool
 		useEmptyScheme bool
@@ -466,7 +466,7 @@ func TestReconcileSpireControllerManagerService(t *testing.T) {
 	}{
 		{
 			name: "create success",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -478,7 +478,7 @@ func TestReconcileSpireControllerManagerService(t *testing.T) {
 		},
 		{
 			name: "get error",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -488,7 +488,7 @@ func TestReconcileSpireControllerManagerService(t *testing.T) {
 		},
 		{
 			name: "create error",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
@@ -499,10 +499,10 @@ func TestReconcileSpireControllerManagerService(t *testing.T) {
 		},
 		{
 			name: "update success",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireServerSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -530,10 +530,10 @@ func TestReconcileSpireControllerManagerService(t *testing.T) {
 		},
 		{
 			name: "create only mode skips update",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					CommonConfig: v1alpha1.CommonConfig{
+				Spec: v1alpha2.SpireServerSpec{
+					CommonConfig: v1alpha2.CommonConfig{
 						Labels: map[string]string{"new-label": "new-value"},
 					},
 				},
@@ -561,7 +561,7 @@ func TestReconcileSpireControllerManagerService(t *testing.T) {
 		},
 		{
 			name: "set controller ref error",
-			server: &v1alpha1.SpireServer{
+			server: &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
 			},
 			setupClient:    func(fc *fakes.FakeCustomCtrlClient) {},
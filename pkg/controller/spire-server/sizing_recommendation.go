@@ -0,0 +1,331 @@
+package spire_server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	// RightSized reasons
+	RightSizedReasonRightSized    = "RightSized"
+	RightSizedReasonUnderSized    = "UnderSized"
+	RightSizedReasonNotEnoughData = "NotEnoughSamples"
+	RightSizedReasonUnavailable   = "SizingRecommendationUnavailable"
+	RightSizedReasonNotConfigured = "SizingRecommendationsNotConfigured"
+
+	// serverMetricsPort and serverMetricsPath are the Prometheus endpoint of server.conf
+	serverMetricsPort = "9402"
+	serverMetricsPath = "metrics"
+
+	// sizingRecommendationInterval is how often the load of the server is sampled, as it is not
+	// watched. defaultSizingRecommendationWindow is the window of the samples when unset.
+	sizingRecommendationInterval      = 5 * time.Minute
+	defaultSizingRecommendationWindow = 24 * time.Hour
+
+	// x509SVIDSignMetric and jwtSVIDSignMetric count the SVIDs signed by the server CA. The
+	// latency summaries of the RPCs the agents sync their authorized entries with, in
+	// milliseconds, are named with agentSyncLatencyMetricPrefix and agentSyncLatencyMetricSuffix.
+	x509SVIDSignMetric           = "spire_server_ca_sign_x509_svid"
+	jwtSVIDSignMetric            = "spire_server_ca_sign_jwt_svid"
+	agentSyncLatencyMetricPrefix = "spire_server_rpc_entry_v1_entry_"
+	agentSyncLatencyMetricSuffix = "_authorized_entries_elapsed_time"
+)
+
+// sizingThresholds are the highest SVID issuance rate, X509 and JWT together, each sizing profile
+// handles, from the smallest. Beyond the last one the large profile is recommended.
+var sizingThresholds = []struct {
+	profile        v1alpha2.SizingProfile
+	svidsPerMinute int64
+}{
+	{v1alpha2.SizingProfileSmall, 1000},
+	{v1alpha2.SizingProfileMedium, 10000},
+}
+
+// agentSyncLatencyThreshold is the 99th percentile of the agent sync latency beyond which the
+// server is considered saturated, and the next larger profile recommended
+const agentSyncLatencyThreshold = time.Second
+
+// serverMetricsReader reads the Prometheus metrics of a SPIRE server pod
+type serverMetricsReader interface {
+	Metrics(ctx context.Context, namespace, podName string) ([]byte, error)
+}
+
+// podProxyMetricsReader reads the metrics endpoint through the pods/proxy subresource, so the
+// operator does not need network access to the server pod
+type podProxyMetricsReader struct {
+	restClient rest.Interface
+}
+
+func (p *podProxyMetricsReader) Metrics(ctx context.Context, namespace, podName string) ([]byte, error) {
+	return p.restClient.Get().Namespace(namespace).Resource("pods").Name(podName + ":" + serverMetricsPort).
+		SubResource("proxy").Suffix(serverMetricsPath).DoRaw(ctx)
+}
+
+// serverLoad is the load read from the metrics of the server: the SVIDs signed since the
+// server started, and the current 99th percentile of the agent sync latency
+type serverLoad struct {
+	x509SVIDs        float64
+	jwtSVIDs         float64
+	agentSyncLatency time.Duration
+}
+
+// loadSample is the load of the server between two reads of its metrics
+type loadSample struct {
+	time               time.Time
+	x509SVIDsPerMinute int64
+	jwtSVIDsPerMinute  int64
+	agentSyncLatency   time.Duration
+}
+
+// loadHistory keeps the load samples of the server over the window, in memory. The counters of
+// the previous read are kept to compute the rates, and dropped when the server pod changes.
+type loadHistory struct {
+	mu       sync.Mutex
+	podUID   types.UID
+	lastRead time.Time
+	last     serverLoad
+	samples  []loadSample
+}
+
+// record adds the sample of the load read at now and drops the samples older than the window
+func (h *loadHistory) record(podUID types.UID, load serverLoad, now time.Time, window time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	// Counters restart with the server pod
+	restarted := podUID != h.podUID || load.x509SVIDs < h.last.x509SVIDs || load.jwtSVIDs < h.last.jwtSVIDs
+	if !restarted && !h.lastRead.IsZero() && now.After(h.lastRead) {
+		minutes := now.Sub(h.lastRead).Minutes()
+		h.samples = append(h.samples, loadSample{
+			time:               now,
+			x509SVIDsPerMinute: int64(math.Ceil((load.x509SVIDs - h.last.x509SVIDs) / minutes)),
+			jwtSVIDsPerMinute:  int64(math.Ceil((load.jwtSVIDs - h.last.jwtSVIDs) / minutes)),
+			agentSyncLatency:   load.agentSyncLatency,
+		})
+	}
+	h.podUID, h.lastRead, h.last = podUID, now, load
+
+	kept := h.samples[:0]
+	for _, sample := range h.samples {
+		if now.Sub(sample.time) <= window {
+			kept = append(kept, sample)
+		}
+	}
+	h.samples = kept
+}
+
+// peak returns the highest load of the samples, and when the oldest one was taken
+func (h *loadHistory) peak() (loadSample, time.Time, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.samples) == 0 {
+		return loadSample{}, time.Time{}, false
+	}
+	var peak loadSample
+	for _, sample := range h.samples {
+		peak.x509SVIDsPerMinute = max(peak.x509SVIDsPerMinute, sample.x509SVIDsPerMinute)
+		peak.jwtSVIDsPerMinute = max(peak.jwtSVIDsPerMinute, sample.jwtSVIDsPerMinute)
+		peak.agentSyncLatency = max(peak.agentSyncLatency, sample.agentSyncLatency)
+	}
+	return peak, h.samples[0].time, true
+}
+
+// parseServerLoad reads the SVIDs signed and the agent sync latency from the metrics of the
+// server. Series are summed across their labels; metrics not reported yet, e.g. before the
+// first SVID is signed, are zero.
+func parseServerLoad(body []byte) (serverLoad, error) {
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(body))
+	if err != nil {
+		return serverLoad{}, fmt.Errorf("failed to parse the server metrics: %w", err)
+	}
+	var load serverLoad
+	for name, family := range families {
+		switch {
+		case name == x509SVIDSignMetric || name == x509SVIDSignMetric+"_total":
+			load.x509SVIDs += sumCounters(family)
+		case name == jwtSVIDSignMetric || name == jwtSVIDSignMetric+"_total":
+			load.jwtSVIDs += sumCounters(family)
+		case strings.HasPrefix(name, agentSyncLatencyMetricPrefix) && strings.HasSuffix(name, agentSyncLatencyMetricSuffix):
+			load.agentSyncLatency = max(load.agentSyncLatency, p99Latency(family))
+		}
+	}
+	return load, nil
+}
+
+func sumCounters(family *dto.MetricFamily) float64 {
+	var sum float64
+	for _, metric := range family.GetMetric() {
+		switch {
+		case metric.GetCounter() != nil:
+			sum += metric.GetCounter().GetValue()
+		case metric.GetUntyped() != nil:
+			sum += metric.GetUntyped().GetValue()
+		}
+	}
+	return sum
+}
+
+// p99Latency returns the highest 99th percentile of the summaries of the family, in milliseconds
+func p99Latency(family *dto.MetricFamily) time.Duration {
+	var latency time.Duration
+	for _, metric := range family.GetMetric() {
+		for _, quantile := range metric.GetSummary().GetQuantile() {
+			value := quantile.GetValue()
+			if quantile.GetQuantile() != 0.99 || math.IsNaN(value) {
+				continue
+			}
+			latency = max(latency, time.Duration(value*float64(time.Millisecond)))
+		}
+	}
+	return latency
+}
+
+// recommendSizing returns the smallest sizing profile handling the peak load, one larger when
+// the agent syncs are slow, along with the settings of the server it implies
+func recommendSizing(peak loadSample, datastore *v1alpha2.DataStore) *v1alpha2.SizingRecommendation {
+	index := len(sizingThresholds)
+	for i, threshold := range sizingThresholds {
+		if peak.x509SVIDsPerMinute+peak.jwtSVIDsPerMinute <= threshold.svidsPerMinute {
+			index = i
+			break
+		}
+	}
+	if peak.agentSyncLatency > agentSyncLatencyThreshold {
+		index++
+	}
+	profile := v1alpha2.SizingProfileLarge
+	if index < len(sizingThresholds) {
+		profile = sizingThresholds[index].profile
+	}
+
+	recommendation := &v1alpha2.SizingRecommendation{
+		SizingProfile:          profile,
+		Resources:              utils.SizingProfileResources(profile, utils.ResourceKindSpireServer),
+		PeakX509SVIDsPerMinute: peak.x509SVIDsPerMinute,
+		PeakJWTSVIDsPerMinute:  peak.jwtSVIDsPerMinute,
+	}
+	if peak.agentSyncLatency > 0 {
+		recommendation.PeakAgentSyncLatency = &metav1.Duration{Duration: peak.agentSyncLatency}
+	}
+	if !isSQLiteDatastore(datastore) {
+		maxOpenConns, maxIdleConns := utils.SizingProfileDatastorePool(profile)
+		recommendation.MaxOpenConns, recommendation.MaxIdleConns = int32(maxOpenConns), int32(maxIdleConns)
+	}
+	return recommendation
+}
+
+// underSized returns the settings of the server below the recommendation. Resources left unset
+// are not limited, so they are not reported.
+func underSized(spec *v1alpha2.SpireServerSpec, recommendation *v1alpha2.SizingRecommendation) []string {
+	var below []string
+	if spec.Resources != nil && recommendation.Resources != nil {
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			recommended := recommendation.Resources.Requests[name]
+			if request, ok := spec.Resources.Requests[name]; ok && request.Cmp(recommended) < 0 {
+				below = append(below, fmt.Sprintf("%s request %s, recommended %s", name, request.String(), recommended.String()))
+			}
+		}
+	}
+	if recommendation.MaxOpenConns > 0 && int32(spec.Datastore.MaxOpenConns) < recommendation.MaxOpenConns {
+		below = append(below, fmt.Sprintf("datastore maxOpenConns %d, recommended %d", spec.Datastore.MaxOpenConns, recommendation.MaxOpenConns))
+	}
+	return below
+}
+
+// sizingRecommendationsEnabled reports whether the load of the server is sampled
+func sizingRecommendationsEnabled(spec *v1alpha2.SpireServerSpec) bool {
+	return spec.SizingRecommendations != nil && ptr.Deref(spec.SizingRecommendations.Enabled, false)
+}
+
+// reconcileSizingRecommendation samples the load of the server from its metrics and reports the
+// recommended sizing in the status, and whether the server is below it through RightSized. The
+// settings of the server are only read, after the sizing profile was applied to them.
+func (r *SpireServerReconciler) reconcileSizingRecommendation(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, now time.Time) {
+	if r.serverMetrics == nil || r.loadHistory == nil || !sizingRecommendationsEnabled(&server.Spec) {
+		server.Status.SizingRecommendation = nil
+		// Only report if recommendations were previously enabled
+		if apimeta.FindStatusCondition(server.Status.Conditions, utils.RightSizedStatusType) != nil {
+			statusMgr.AddCondition(utils.RightSizedStatusType, RightSizedReasonNotConfigured,
+				"Sizing recommendations are not enabled", metav1.ConditionTrue)
+		}
+		return
+	}
+	window := server.Spec.SizingRecommendations.Window.Duration
+	if window <= 0 {
+		window = defaultSizingRecommendationWindow
+	}
+
+	if err := r.sampleServerLoad(ctx, now, window); err != nil {
+		r.log.V(1).Info("server load not available", "reason", err.Error())
+		// The recommendation of the samples already taken is kept
+		if server.Status.SizingRecommendation == nil {
+			statusMgr.AddCondition(utils.RightSizedStatusType, RightSizedReasonUnavailable,
+				fmt.Sprintf("The load of the server is not available: %v", err), metav1.ConditionUnknown)
+			return
+		}
+	}
+
+	peak, since, ok := r.loadHistory.peak()
+	if !ok {
+		if server.Status.SizingRecommendation == nil {
+			statusMgr.AddCondition(utils.RightSizedStatusType, RightSizedReasonNotEnoughData,
+				"Waiting for a second sample of the load of the server", metav1.ConditionUnknown)
+		}
+		return
+	}
+	recommendation := recommendSizing(peak, &server.Spec.Datastore)
+	recommendation.ObservedSince = &metav1.Time{Time: since}
+	server.Status.SizingRecommendation = recommendation
+
+	summary := fmt.Sprintf("peak of %d X509-SVIDs and %d JWT-SVIDs per minute", peak.x509SVIDsPerMinute, peak.jwtSVIDsPerMinute)
+	if below := underSized(&server.Spec, recommendation); len(below) > 0 {
+		statusMgr.AddCondition(utils.RightSizedStatusType, RightSizedReasonUnderSized,
+			fmt.Sprintf("The %s sizing profile is recommended for a %s: %s", recommendation.SizingProfile, summary, strings.Join(below, ", ")),
+			metav1.ConditionFalse)
+		return
+	}
+	statusMgr.AddCondition(utils.RightSizedStatusType, RightSizedReasonRightSized,
+		fmt.Sprintf("The server is sized for a %s, the %s sizing profile is recommended", summary, recommendation.SizingProfile),
+		metav1.ConditionTrue)
+}
+
+// sampleServerLoad reads the metrics of the server pod and records them in the load history.
+// Pods are not labelled as managed by the operator, so the pod is read from the API server.
+func (r *SpireServerReconciler) sampleServerLoad(ctx context.Context, now time.Time, window time.Duration) error {
+	var pod corev1.Pod
+	if err := r.ctrlClient.GetUncached(ctx, types.NamespacedName{Name: spireServerPodName, Namespace: utils.GetOperatorNamespace()}, &pod); err != nil {
+		return fmt.Errorf("failed to get pod %s: %w", spireServerPodName, err)
+	}
+	if pod.Status.PodIP == "" {
+		return fmt.Errorf("pod %s has no IP", spireServerPodName)
+	}
+	body, err := r.serverMetrics.Metrics(ctx, pod.Namespace, pod.Name)
+	if err != nil {
+		return fmt.Errorf("failed to read the metrics of pod %s: %w", spireServerPodName, err)
+	}
+	load, err := parseServerLoad(body)
+	if err != nil {
+		return err
+	}
+	r.loadHistory.record(pod.UID, load, now, window)
+	return nil
+}
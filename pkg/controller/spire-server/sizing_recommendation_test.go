@@ -0,0 +1,251 @@
+package spire_server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+type fakeServerMetrics struct {
+	bodies []string
+	err    error
+}
+
+func (f *fakeServerMetrics) Metrics(context.Context, string, string) ([]byte, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	body := f.bodies[0]
+	if len(f.bodies) > 1 {
+		f.bodies = f.bodies[1:]
+	}
+	return []byte(body), nil
+}
+
+func serverMetricsBody(x509SVIDs, jwtSVIDs int, syncLatencyMs float64) string {
+	return fmt.Sprintf(`# TYPE spire_server_ca_sign_x509_svid counter
+spire_server_ca_sign_x509_svid{trust_domain_id="example.org"} %d
+# TYPE spire_server_ca_sign_jwt_svid counter
+spire_server_ca_sign_jwt_svid{trust_domain_id="example.org"} %d
+# TYPE spire_server_rpc_entry_v1_entry_sync_authorized_entries_elapsed_time summary
+spire_server_rpc_entry_v1_entry_sync_authorized_entries_elapsed_time{status="OK",quantile="0.5"} 2
+spire_server_rpc_entry_v1_entry_sync_authorized_entries_elapsed_time{status="OK",quantile="0.99"} %g
+spire_server_rpc_entry_v1_entry_sync_authorized_entries_elapsed_time_sum{status="OK"} 100
+spire_server_rpc_entry_v1_entry_sync_authorized_entries_elapsed_time_count{status="OK"} 50
+`, x509SVIDs, jwtSVIDs, syncLatencyMs)
+}
+
+func TestParseServerLoad(t *testing.T) {
+	load, err := parseServerLoad([]byte(serverMetricsBody(1200, 300, 250) +
+		"# TYPE spire_server_ca_sign_x509_svid_total counter\nspire_server_ca_sign_x509_svid_total 100\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if load.x509SVIDs != 1300 || load.jwtSVIDs != 300 {
+		t.Errorf("Expected 1300 X509-SVIDs and 300 JWT-SVIDs, got %v and %v", load.x509SVIDs, load.jwtSVIDs)
+	}
+	if load.agentSyncLatency != 250*time.Millisecond {
+		t.Errorf("Expected an agent sync latency of 250ms, got %v", load.agentSyncLatency)
+	}
+
+	if load, err := parseServerLoad([]byte("# TYPE go_goroutines gauge\ngo_goroutines 12\n")); err != nil || load != (serverLoad{}) {
+		t.Errorf("Expected no load before the first SVID is signed, got %+v, %v", load, err)
+	}
+	if _, err := parseServerLoad([]byte("not metrics {")); err == nil {
+		t.Error("Expected an error for malformed metrics")
+	}
+}
+
+func TestLoadHistory(t *testing.T) {
+	now := time.Now()
+	history := &loadHistory{}
+
+	history.record("pod-1", serverLoad{x509SVIDs: 100}, now, time.Hour)
+	if _, _, ok := history.peak(); ok {
+		t.Fatal("Expected no sample after the first read")
+	}
+
+	history.record("pod-1", serverLoad{x509SVIDs: 1100, jwtSVIDs: 50, agentSyncLatency: time.Second}, now.Add(5*time.Minute), time.Hour)
+	history.record("pod-1", serverLoad{x509SVIDs: 1200, jwtSVIDs: 100}, now.Add(10*time.Minute), time.Hour)
+	peak, since, ok := history.peak()
+	if !ok || peak.x509SVIDsPerMinute != 200 || peak.jwtSVIDsPerMinute != 10 || peak.agentSyncLatency != time.Second {
+		t.Errorf("Expected a peak of 200 X509-SVIDs and 10 JWT-SVIDs per minute and 1s, got %+v", peak)
+	}
+	if !since.Equal(now.Add(5 * time.Minute)) {
+		t.Errorf("Expected the window to start with the first sample, got %v", since)
+	}
+
+	// The counters restart with the pod: no sample until the next read
+	history.record("pod-2", serverLoad{x509SVIDs: 10}, now.Add(15*time.Minute), time.Hour)
+	if len(history.samples) != 2 {
+		t.Errorf("Expected no sample across pods, got %d samples", len(history.samples))
+	}
+
+	// Samples older than the window are dropped
+	history.record("pod-2", serverLoad{x509SVIDs: 20}, now.Add(70*time.Minute), time.Hour)
+	peak, _, _ = history.peak()
+	if len(history.samples) != 2 || peak.x509SVIDsPerMinute != 20 {
+		t.Errorf("Expected the first sample to leave the window, got %+v", history.samples)
+	}
+}
+
+func TestRecommendSizing(t *testing.T) {
+	postgres := &v1alpha2.DataStore{DatabaseType: "postgres"}
+	tests := []struct {
+		name          string
+		peak          loadSample
+		datastore     *v1alpha2.DataStore
+		expectProfile v1alpha2.SizingProfile
+		expectPool    bool
+	}{
+		{name: "idle", datastore: postgres, expectProfile: v1alpha2.SizingProfileSmall, expectPool: true},
+		{name: "medium load", peak: loadSample{x509SVIDsPerMinute: 4000, jwtSVIDsPerMinute: 2000}, datastore: postgres, expectProfile: v1alpha2.SizingProfileMedium, expectPool: true},
+		{name: "heavy load", peak: loadSample{x509SVIDsPerMinute: 20000}, datastore: postgres, expectProfile: v1alpha2.SizingProfileLarge, expectPool: true},
+		{name: "slow agent syncs", peak: loadSample{x509SVIDsPerMinute: 500, agentSyncLatency: 2 * time.Second}, datastore: postgres, expectProfile: v1alpha2.SizingProfileMedium, expectPool: true},
+		{name: "sqlite3", peak: loadSample{x509SVIDsPerMinute: 500}, datastore: &v1alpha2.DataStore{DatabaseType: "sqlite3"}, expectProfile: v1alpha2.SizingProfileSmall},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recommendation := recommendSizing(tt.peak, tt.datastore)
+			if recommendation.SizingProfile != tt.expectProfile {
+				t.Errorf("Expected profile %s, got %s", tt.expectProfile, recommendation.SizingProfile)
+			}
+			if recommendation.Resources == nil {
+				t.Error("Expected the resources of the profile")
+			}
+			if (recommendation.MaxOpenConns > 0) != tt.expectPool {
+				t.Errorf("Expected pool settings %v, got %d", tt.expectPool, recommendation.MaxOpenConns)
+			}
+		})
+	}
+}
+
+func TestReconcileSizingRecommendation(t *testing.T) {
+	enabled := &v1alpha2.SizingRecommendationsConfig{Enabled: ptr.To(true), Window: metav1.Duration{Duration: time.Hour}}
+	smallResources := &corev1.ResourceRequirements{Requests: corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("100m"),
+		corev1.ResourceMemory: resource.MustParse("256Mi"),
+	}}
+	tests := []struct {
+		name          string
+		config        *v1alpha2.SizingRecommendationsConfig
+		metrics       *fakeServerMetrics
+		resources     *corev1.ResourceRequirements
+		podIP         string
+		expectReason  string
+		expectStatus  metav1.ConditionStatus
+		expectProfile v1alpha2.SizingProfile
+	}{
+		{
+			name:    "disabled",
+			metrics: &fakeServerMetrics{bodies: []string{serverMetricsBody(0, 0, 0)}},
+			podIP:   "10.0.0.1",
+		},
+		{
+			name:          "right sized",
+			config:        enabled,
+			metrics:       &fakeServerMetrics{bodies: []string{serverMetricsBody(0, 0, 0), serverMetricsBody(2500, 0, 20)}},
+			resources:     smallResources,
+			podIP:         "10.0.0.1",
+			expectReason:  RightSizedReasonRightSized,
+			expectStatus:  metav1.ConditionTrue,
+			expectProfile: v1alpha2.SizingProfileSmall,
+		},
+		{
+			name:          "under sized",
+			config:        enabled,
+			metrics:       &fakeServerMetrics{bodies: []string{serverMetricsBody(0, 0, 0), serverMetricsBody(25000, 5000, 20)}},
+			resources:     smallResources,
+			podIP:         "10.0.0.1",
+			expectReason:  RightSizedReasonUnderSized,
+			expectStatus:  metav1.ConditionFalse,
+			expectProfile: v1alpha2.SizingProfileMedium,
+		},
+		{
+			name:         "metrics unavailable",
+			config:       enabled,
+			metrics:      &fakeServerMetrics{err: errors.New("connection refused")},
+			podIP:        "10.0.0.1",
+			expectReason: RightSizedReasonUnavailable,
+			expectStatus: metav1.ConditionUnknown,
+		},
+		{
+			name:         "pod not started",
+			config:       enabled,
+			metrics:      &fakeServerMetrics{bodies: []string{serverMetricsBody(0, 0, 0)}},
+			expectReason: RightSizedReasonUnavailable,
+			expectStatus: metav1.ConditionUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.GetUncachedStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				pod, ok := obj.(*corev1.Pod)
+				if !ok || key.Name != spireServerPodName {
+					return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+				}
+				pod.Name, pod.Namespace, pod.UID = key.Name, key.Namespace, "pod-uid"
+				pod.Status.PodIP = tt.podIP
+				return nil
+			}
+			reconciler := &SpireServerReconciler{
+				ctrlClient:    fakeClient,
+				log:           logr.Discard(),
+				serverMetrics: tt.metrics,
+				loadHistory:   &loadHistory{},
+			}
+			server := &v1alpha2.SpireServer{Spec: v1alpha2.SpireServerSpec{
+				SizingRecommendations: tt.config,
+				Datastore:             v1alpha2.DataStore{DatabaseType: "sqlite3"},
+			}}
+			server.Spec.Resources = tt.resources
+
+			now := time.Now()
+			var statusMgr *status.Manager
+			for i := 0; i < 2; i++ {
+				statusMgr = status.NewManager(fakeClient)
+				reconciler.reconcileSizingRecommendation(context.Background(), server, statusMgr, now.Add(time.Duration(i)*5*time.Minute))
+			}
+
+			cond, ok := statusMgr.GetCondition(utils.RightSizedStatusType)
+			if tt.expectReason == "" {
+				if ok || server.Status.SizingRecommendation != nil {
+					t.Errorf("Expected no recommendation, got %v and %v", cond, server.Status.SizingRecommendation)
+				}
+				return
+			}
+			if cond.Reason != tt.expectReason || cond.Status != tt.expectStatus {
+				t.Errorf("Expected %s/%s, got %s/%s: %s", tt.expectStatus, tt.expectReason, cond.Status, cond.Reason, cond.Message)
+			}
+			if tt.expectProfile == "" {
+				return
+			}
+			if server.Status.SizingRecommendation == nil || server.Status.SizingRecommendation.SizingProfile != tt.expectProfile {
+				t.Fatalf("Expected the %s profile to be recommended, got %+v", tt.expectProfile, server.Status.SizingRecommendation)
+			}
+			if server.Status.SizingRecommendation.MaxOpenConns != 0 {
+				t.Errorf("Expected no pool settings for sqlite3, got %d", server.Status.SizingRecommendation.MaxOpenConns)
+			}
+		})
+	}
+}
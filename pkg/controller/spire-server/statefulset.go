@@ -3,6 +3,7 @@ package spire_server
 import (
 	"context"
 	"fmt"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -17,8 +18,12 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/dependencies"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/maintenance"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/telemetry"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 )
 
@@ -30,8 +35,15 @@ const (
 )
 
 // reconcileStatefulSet reconciles the Spire Server StatefulSet
-func (r *SpireServerReconciler) reconcileStatefulSet(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager, createOnlyMode bool, spireServerConfigMapHash, spireControllerManagerConfigMapHash string) error {
+func (r *SpireServerReconciler) reconcileStatefulSet(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, createOnlyMode bool, spireServerConfigMapHash, spireControllerManagerConfigMapHash string) error {
 	sts := GenerateSpireServerStatefulSet(&server.Spec, spireServerConfigMapHash, spireControllerManagerConfigMapHash)
+	if err := dependencies.Annotate(ctx, r.ctrlClient, &sts.Spec.Template); err != nil {
+		r.log.Error(err, "failed to hash spire server dependencies")
+		statusMgr.AddCondition(StatefulSetAvailable, "SpireServerStatefulSetGenerationFailed",
+			err.Error(),
+			metav1.ConditionFalse)
+		return err
+	}
 	if err := controllerutil.SetControllerReference(server, sts, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference on spire server stateful set resource")
 		statusMgr.AddCondition(StatefulSetAvailable, "SpireServerStatefulSetGenerationFailed",
@@ -43,7 +55,7 @@ func (r *SpireServerReconciler) reconcileStatefulSet(ctx context.Context, server
 	var existingSTS appsv1.StatefulSet
 	err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: sts.Name, Namespace: sts.Namespace}, &existingSTS)
 	if err != nil && kerrors.IsNotFound(err) {
-		if err = r.ctrlClient.Create(ctx, sts); err != nil {
+		if err = r.ctrlClient.Create(ctx, sts, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false))); err != nil {
 			statusMgr.AddCondition(StatefulSetAvailable, "SpireServerStatefulSetCreationFailed",
 				err.Error(),
 				metav1.ConditionFalse)
@@ -51,8 +63,14 @@ func (r *SpireServerReconciler) reconcileStatefulSet(ctx context.Context, server
 		}
 		r.log.Info("Created spire server StatefulSet")
 	} else if err == nil && needsUpdate(existingSTS, *sts) {
+		versionChange := status.IsOperandVersionChange(existingSTS.Labels, sts.Labels,
+			&existingSTS.Spec.Template.Spec, &sts.Spec.Template.Spec, "spire-server")
 		if createOnlyMode {
 			r.log.Info("Skipping StatefulSet update due to create-only mode")
+		} else if !r.checkDatastoreBackup(server, statusMgr, versionChange) {
+			r.log.Info("Deferring spire server StatefulSet upgrade until a recent datastore backup is recorded")
+		} else if !maintenance.Allows(statusMgr, ztwim.Spec.MaintenanceWindows, "StatefulSet "+sts.Name, server.Status.Conditions, time.Now()) {
+			r.log.Info("Deferring spire server StatefulSet update until the next maintenance window")
 		} else {
 			sts.ResourceVersion = existingSTS.ResourceVersion
 			if err = r.ctrlClient.Update(ctx, sts); err != nil {
@@ -69,6 +87,8 @@ func (r *SpireServerReconciler) reconcileStatefulSet(ctx context.Context, server
 			err.Error(),
 			metav1.ConditionFalse)
 		return err
+	} else {
+		maintenance.Settle(statusMgr, server.Status.Conditions)
 	}
 
 	// Check StatefulSet health/readiness
@@ -77,12 +97,22 @@ func (r *SpireServerReconciler) reconcileStatefulSet(ctx context.Context, server
 	return nil
 }
 
+// spireServerDependencies returns the Secrets and ConfigMaps mounted by the spire server pods
+// that are not managed by the operator
+func spireServerDependencies(config *v1alpha2.SpireServerSpec) []dependencies.Reference {
+	refs := dependencies.TrustedCABundle()
+	if config.Datastore.TLSSecretName != "" {
+		refs = append(refs, dependencies.Secret(config.Datastore.TLSSecretName))
+	}
+	return refs
+}
+
 const (
 	// DBTLSMountPath is the fixed mount path for database TLS certificates
 	DBTLSMountPath = "/run/spire/db/certs"
 )
 
-func GenerateSpireServerStatefulSet(config *v1alpha1.SpireServerSpec,
+func GenerateSpireServerStatefulSet(config *v1alpha2.SpireServerSpec,
 	spireServerConfigMapHash string,
 	SpireControllerManagerConfigMapHash string) *appsv1.StatefulSet {
 
@@ -224,10 +254,14 @@ func GenerateSpireServerStatefulSet(config *v1alpha1.SpireServerSpec,
 							Resources: utils.DerefResourceRequirements(config.Resources),
 						},
 					},
-					Volumes:      volumes,
-					Affinity:     config.Affinity,
-					NodeSelector: utils.DerefNodeSelector(config.NodeSelector),
-					Tolerations:  utils.DerefTolerations(config.Tolerations),
+					Volumes:          volumes,
+					Affinity:         config.Affinity,
+					NodeSelector:     utils.DerefNodeSelector(config.NodeSelector),
+					Tolerations:      utils.DerefTolerations(config.Tolerations),
+					DNSPolicy:        utils.DerefDNSPolicy(config.DNSPolicy),
+					DNSConfig:        config.DNSConfig.DeepCopy(),
+					ImagePullSecrets: config.ImagePullSecrets,
+					HostAliases:      config.HostAliases,
 				},
 			},
 			VolumeClaimTemplates: []corev1.PersistentVolumeClaim{
@@ -247,6 +281,9 @@ func GenerateSpireServerStatefulSet(config *v1alpha1.SpireServerSpec,
 		},
 	}
 
+	// Add the init containers delivering the external plugins, if any
+	addExternalPluginsToStatefulSet(sts, config.ExternalPlugins)
+
 	// Add proxy configuration if enabled
 	utils.AddProxyConfigToPod(&sts.Spec.Template.Spec)
 
@@ -255,11 +292,14 @@ func GenerateSpireServerStatefulSet(config *v1alpha1.SpireServerSpec,
 		addFederationConfigurationToStatefulSet(sts, config.Federation)
 	}
 
+	// Add the statsd telemetry bridge if enabled
+	telemetry.AddBridge(&sts.Spec.Template.Spec, config.Telemetry)
+
 	return sts
 }
 
 // addFederationConfigurationToStatefulSet adds federation port, volume and mount to the StatefulSet
-func addFederationConfigurationToStatefulSet(sts *appsv1.StatefulSet, federation *v1alpha1.FederationConfig) {
+func addFederationConfigurationToStatefulSet(sts *appsv1.StatefulSet, federation *v1alpha2.FederationConfig) {
 	// Add federation port to spire-server container (first container)
 	sts.Spec.Template.Spec.Containers[0].Ports = append(
 		sts.Spec.Template.Spec.Containers[0].Ports,
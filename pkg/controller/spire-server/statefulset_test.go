@@ -7,8 +7,9 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/dependencies"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 	appsv1 "k8s.io/api/apps/v1"
@@ -26,13 +27,13 @@ import (
 
 func TestGenerateSpireServerStatefulSet(t *testing.T) {
 	// Setup test inputs with required Persistence field (now a value type, not pointer)
-	config := &v1alpha1.SpireServerSpec{
-		Persistence: v1alpha1.Persistence{
+	config := &v1alpha2.SpireServerSpec{
+		Persistence: v1alpha2.Persistence{
 			Size:         "1Gi",
 			AccessMode:   "ReadWriteOnce",
 			StorageClass: "",
 		},
-		CommonConfig: v1alpha1.CommonConfig{
+		CommonConfig: v1alpha2.CommonConfig{
 			Labels: map[string]string{
 				"custom-label": "test-value",
 			},
@@ -234,8 +235,8 @@ func TestGenerateSpireServerStatefulSet(t *testing.T) {
 	// Test custom persistence settings (AccessMode and StorageClass)
 	t.Run("Validates custom persistence settings", func(t *testing.T) {
 		customStorageClass := "fast-ssd"
-		configWithPersistence := &v1alpha1.SpireServerSpec{
-			Persistence: v1alpha1.Persistence{
+		configWithPersistence := &v1alpha2.SpireServerSpec{
+			Persistence: v1alpha2.Persistence{
 				Size:         "10Gi",
 				AccessMode:   "ReadWriteOncePod",
 				StorageClass: customStorageClass,
@@ -269,8 +270,8 @@ func TestGenerateSpireServerStatefulSet(t *testing.T) {
 
 	// Test ReadWriteMany access mode
 	t.Run("Validates ReadWriteMany access mode", func(t *testing.T) {
-		configWithRWX := &v1alpha1.SpireServerSpec{
-			Persistence: v1alpha1.Persistence{
+		configWithRWX := &v1alpha2.SpireServerSpec{
+			Persistence: v1alpha2.Persistence{
 				Size:       "1Gi",
 				AccessMode: "ReadWriteMany",
 			},
@@ -287,8 +288,8 @@ func TestGenerateSpireServerStatefulSet(t *testing.T) {
 	// Test persistence with custom StorageClass
 	t.Run("Validates persistence with custom StorageClass", func(t *testing.T) {
 		customStorageClass := "premium-storage"
-		configWithCustomStorageClass := &v1alpha1.SpireServerSpec{
-			Persistence: v1alpha1.Persistence{
+		configWithCustomStorageClass := &v1alpha2.SpireServerSpec{
+			Persistence: v1alpha2.Persistence{
 				Size:         "1Gi",
 				AccessMode:   "ReadWriteOnce",
 				StorageClass: customStorageClass,
@@ -315,12 +316,12 @@ func TestGenerateSpireServerStatefulSet(t *testing.T) {
 
 	// Test with nil labels
 	t.Run("Handles nil labels gracefully", func(t *testing.T) {
-		configWithNilLabels := &v1alpha1.SpireServerSpec{
-			Persistence: v1alpha1.Persistence{
+		configWithNilLabels := &v1alpha2.SpireServerSpec{
+			Persistence: v1alpha2.Persistence{
 				Size:       "1Gi",
 				AccessMode: "ReadWriteOnce",
 			},
-			CommonConfig: v1alpha1.CommonConfig{
+			CommonConfig: v1alpha2.CommonConfig{
 				Labels: nil,
 			},
 		}
@@ -339,12 +340,12 @@ func TestGenerateSpireServerStatefulSet(t *testing.T) {
 
 	// Test with empty labels map
 	t.Run("Handles empty labels map gracefully", func(t *testing.T) {
-		configWithEmptyLabels := &v1alpha1.SpireServerSpec{
-			Persistence: v1alpha1.Persistence{
+		configWithEmptyLabels := &v1alpha2.SpireServerSpec{
+			Persistence: v1alpha2.Persistence{
 				Size:       "1Gi",
 				AccessMode: "ReadWriteOnce",
 			},
-			CommonConfig: v1alpha1.CommonConfig{
+			CommonConfig: v1alpha2.CommonConfig{
 				Labels: map[string]string{},
 			},
 		}
@@ -385,11 +386,11 @@ func TestGenerateSpireServerStatefulSetWithTLSSecret(t *testing.T) {
 	controllerConfigHash := "test-controller-hash"
 
 	t.Run("Adds TLS Secret volume and mount at fixed path", func(t *testing.T) {
-		config := &v1alpha1.SpireServerSpec{
-			Persistence: v1alpha1.Persistence{
+		config := &v1alpha2.SpireServerSpec{
+			Persistence: v1alpha2.Persistence{
 				Size: "1Gi",
 			},
-			Datastore: v1alpha1.DataStore{
+			Datastore: v1alpha2.DataStore{
 				DatabaseType:     "postgres",
 				ConnectionString: "dbname=spire user=spire host=postgres.example.com sslmode=verify-full sslrootcert=/run/spire/db/certs/ca.crt",
 				TLSSecretName:    "postgres-tls-certs",
@@ -458,11 +459,11 @@ func TestGenerateSpireServerStatefulSetWithTLSSecret(t *testing.T) {
 	})
 
 	t.Run("Does not add TLS volume when TLSSecretName is empty", func(t *testing.T) {
-		config := &v1alpha1.SpireServerSpec{
-			Persistence: v1alpha1.Persistence{
+		config := &v1alpha2.SpireServerSpec{
+			Persistence: v1alpha2.Persistence{
 				Size: "1Gi",
 			},
-			Datastore: v1alpha1.DataStore{
+			Datastore: v1alpha2.DataStore{
 				DatabaseType:     "postgres",
 				ConnectionString: "dbname=spire user=spire host=postgres.example.com sslmode=disable",
 				TLSSecretName:    "",
@@ -499,7 +500,7 @@ func findContainerByName(containers []corev1.Container, name string) *corev1.Con
 
 // Helper function creating a reference implementation of the expected StatefulSet
 // This is essentially a copy of the function being tested, used to detect regressions
-func createReferenceStatefulSet(config *v1alpha1.SpireServerSpec, spireServerConfigMapHash string,
+func createReferenceStatefulSet(config *v1alpha2.SpireServerSpec, spireServerConfigMapHash string,
 	SpireControllerManagerConfigMapHash string) *appsv1.StatefulSet {
 	// Use the same standardized labeling as the actual implementation
 	labels := utils.SpireServerLabels(config.Labels)
@@ -628,18 +629,18 @@ func createReferenceStatefulSet(config *v1alpha1.SpireServerSpec, spireServerCon
 func TestAddFederationConfigurationToStatefulSet(t *testing.T) {
 	tests := []struct {
 		name               string
-		federation         *v1alpha1.FederationConfig
+		federation         *v1alpha2.FederationConfig
 		expectVolume       bool
 		expectVolumeMount  bool
 		expectedSecretName string
 	}{
 		{
 			name: "Federation with ServingCert using service CA certificate",
-			federation: &v1alpha1.FederationConfig{
-				BundleEndpoint: v1alpha1.BundleEndpointConfig{
-					Profile: v1alpha1.HttpsWebProfile,
-					HttpsWeb: &v1alpha1.HttpsWebConfig{
-						ServingCert: &v1alpha1.ServingCertConfig{},
+			federation: &v1alpha2.FederationConfig{
+				BundleEndpoint: v1alpha2.BundleEndpointConfig{
+					Profile: v1alpha2.HttpsWebProfile,
+					HttpsWeb: &v1alpha2.HttpsWebConfig{
+						ServingCert: &v1alpha2.ServingCertConfig{},
 					},
 				},
 			},
@@ -649,15 +650,15 @@ func TestAddFederationConfigurationToStatefulSet(t *testing.T) {
 		},
 		{
 			name: "Federation with ACME (no volume needed)",
-			federation: &v1alpha1.FederationConfig{
-				BundleEndpoint: v1alpha1.BundleEndpointConfig{
-					Profile: v1alpha1.HttpsWebProfile,
-					HttpsWeb: &v1alpha1.HttpsWebConfig{
-						Acme: &v1alpha1.AcmeConfig{
+			federation: &v1alpha2.FederationConfig{
+				BundleEndpoint: v1alpha2.BundleEndpointConfig{
+					Profile: v1alpha2.HttpsWebProfile,
+					HttpsWeb: &v1alpha2.HttpsWebConfig{
+						Acme: &v1alpha2.AcmeConfig{
 							DirectoryUrl: "https://acme-v02.api.letsencrypt.org/directory",
 							DomainName:   "example.org",
 							Email:        "admin@example.org",
-							TosAccepted:  "true",
+							TosAccepted:  ptr.To(true),
 						},
 					},
 				},
@@ -667,9 +668,9 @@ func TestAddFederationConfigurationToStatefulSet(t *testing.T) {
 		},
 		{
 			name: "Federation with https_spiffe (no volume needed)",
-			federation: &v1alpha1.FederationConfig{
-				BundleEndpoint: v1alpha1.BundleEndpointConfig{
-					Profile: v1alpha1.HttpsSpiffeProfile,
+			federation: &v1alpha2.FederationConfig{
+				BundleEndpoint: v1alpha2.BundleEndpointConfig{
+					Profile: v1alpha2.HttpsSpiffeProfile,
 				},
 			},
 			expectVolume:      false,
@@ -756,7 +757,7 @@ func TestAddFederationConfigurationToStatefulSet(t *testing.T) {
 func TestGenerateSpireServerStatefulSetWithFederation(t *testing.T) {
 	tests := []struct {
 		name                 string
-		federation           *v1alpha1.FederationConfig
+		federation           *v1alpha2.FederationConfig
 		expectedVolumeCount  int
 		expectedPortCount    int
 		expectTLSVolume      bool
@@ -772,9 +773,9 @@ func TestGenerateSpireServerStatefulSetWithFederation(t *testing.T) {
 		},
 		{
 			name: "With https_spiffe federation",
-			federation: &v1alpha1.FederationConfig{
-				BundleEndpoint: v1alpha1.BundleEndpointConfig{
-					Profile: v1alpha1.HttpsSpiffeProfile,
+			federation: &v1alpha2.FederationConfig{
+				BundleEndpoint: v1alpha2.BundleEndpointConfig{
+					Profile: v1alpha2.HttpsSpiffeProfile,
 				},
 			},
 			expectedVolumeCount:  5, // No additional volume needed for https_spiffe
@@ -784,11 +785,11 @@ func TestGenerateSpireServerStatefulSetWithFederation(t *testing.T) {
 		},
 		{
 			name: "With https_web federation and ServingCert",
-			federation: &v1alpha1.FederationConfig{
-				BundleEndpoint: v1alpha1.BundleEndpointConfig{
-					Profile: v1alpha1.HttpsWebProfile,
-					HttpsWeb: &v1alpha1.HttpsWebConfig{
-						ServingCert: &v1alpha1.ServingCertConfig{},
+			federation: &v1alpha2.FederationConfig{
+				BundleEndpoint: v1alpha2.BundleEndpointConfig{
+					Profile: v1alpha2.HttpsWebProfile,
+					HttpsWeb: &v1alpha2.HttpsWebConfig{
+						ServingCert: &v1alpha2.ServingCertConfig{},
 					},
 				},
 			},
@@ -801,8 +802,8 @@ func TestGenerateSpireServerStatefulSetWithFederation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			config := &v1alpha1.SpireServerSpec{
-				Persistence: v1alpha1.Persistence{
+			config := &v1alpha2.SpireServerSpec{
+				Persistence: v1alpha2.Persistence{
 					Size:         "1Gi",
 					AccessMode:   "ReadWriteOnce",
 					StorageClass: "",
@@ -864,7 +865,7 @@ func TestGenerateSpireServerStatefulSetWithFederation(t *testing.T) {
 // newStatefulSetTestReconciler creates a reconciler for StatefulSet tests
 func newStatefulSetTestReconciler(fakeClient *fakes.FakeCustomCtrlClient) *SpireServerReconciler {
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 	_ = appsv1.AddToScheme(scheme)
 	return &SpireServerReconciler{
 		ctrlClient:    fakeClient,
@@ -913,11 +914,11 @@ func TestReconcileStatefulSet(t *testing.T) {
 				reconciler = newStatefulSetTestReconciler(fakeClient)
 			}
 
-			server := &v1alpha1.SpireServer{
+			server := &v1alpha2.SpireServer{
 				ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "test-uid"},
-				Spec: v1alpha1.SpireServerSpec{
-					Persistence:  v1alpha1.Persistence{Size: "1Gi", AccessMode: "ReadWriteOnce"},
-					CommonConfig: v1alpha1.CommonConfig{Labels: map[string]string{"new": "label"}},
+				Spec: v1alpha2.SpireServerSpec{
+					Persistence:  v1alpha2.Persistence{Size: "1Gi", AccessMode: "ReadWriteOnce"},
+					CommonConfig: v1alpha2.CommonConfig{Labels: map[string]string{"new": "label"}},
 				},
 			}
 
@@ -944,7 +945,7 @@ func TestReconcileStatefulSet(t *testing.T) {
 			fakeClient.UpdateReturns(tt.updateError)
 
 			statusMgr := status.NewManager(fakeClient)
-			err := reconciler.reconcileStatefulSet(context.Background(), server, statusMgr, tt.createOnlyMode, "server-hash", "controller-hash")
+			err := reconciler.reconcileStatefulSet(context.Background(), server, statusMgr, &v1alpha2.ZeroTrustWorkloadIdentityManager{}, tt.createOnlyMode, "server-hash", "controller-hash")
 
 			if tt.expectError && err == nil {
 				t.Error("Expected error but got none")
@@ -964,3 +965,22 @@ func TestReconcileStatefulSet(t *testing.T) {
 		})
 	}
 }
+
+func TestSpireServerDependencies(t *testing.T) {
+	t.Setenv(utils.TrustedCABundleConfigMapEnvVar, "trusted-ca")
+	spec := &v1alpha2.SpireServerSpec{Datastore: v1alpha2.DataStore{TLSSecretName: "db-tls"}}
+
+	refs := spireServerDependencies(spec)
+	if len(refs) != 2 || refs[0].Name != "trusted-ca" || refs[1] != dependencies.Secret("db-tls") {
+		t.Errorf("Expected the trusted CA bundle and the datastore TLS Secret, got %v", refs)
+	}
+
+	current := appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{utils.DependenciesHashAnnotationKey: "before"},
+	}}}}
+	desired := *current.DeepCopy()
+	desired.Spec.Template.Annotations[utils.DependenciesHashAnnotationKey] = "after"
+	if !needsUpdate(current, desired) {
+		t.Error("Expected a rotated dependency to roll the StatefulSet")
+	}
+}
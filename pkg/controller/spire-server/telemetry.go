@@ -0,0 +1,26 @@
+package spire_server
+
+import (
+	"context"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/telemetry"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// reconcileTelemetryBridge exposes the metrics of the statsd telemetry bridge of the servers
+func (r *SpireServerReconciler) reconcileTelemetryBridge(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
+	desired := telemetry.GenerateService("spire-server", utils.SpireServerLabels(server.Spec.Labels), map[string]string{
+		"app.kubernetes.io/name":     "spire-server",
+		"app.kubernetes.io/instance": utils.StandardInstance,
+	}, server.Spec.Telemetry)
+	if err := telemetry.ReconcileService(ctx, r.ctrlClient, r.scheme, server, desired, server.Spec.Telemetry, server.Status.Conditions,
+		statusMgr, createOnlyMode, ptr.Deref(server.Spec.AdoptExistingResources, false)); err != nil {
+		r.log.Error(err, "failed to reconcile the telemetry bridge Service")
+		return err
+	}
+	return nil
+}
@@ -5,7 +5,9 @@ import (
 	"strings"
 	"time"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 )
 
@@ -69,7 +71,7 @@ func printMinCATTL(svidTTL time.Duration) string {
 }
 
 // validateTTLDurationsWithWarnings validates TTL values using upstream SPIRE logic
-func validateTTLDurationsWithWarnings(config *v1alpha1.SpireServerSpec) TTLValidationResult {
+func validateTTLDurationsWithWarnings(config *v1alpha2.SpireServerSpec) TTLValidationResult {
 	var result TTLValidationResult
 	var warningMessages []string
 
@@ -94,6 +96,16 @@ func validateTTLDurationsWithWarnings(config *v1alpha1.SpireServerSpec) TTLValid
 		result.Error = fmt.Errorf("ca_validity must be greater than default_ca_ttl")
 		return result
 	}
+	if config.AgentTTL != nil {
+		if config.AgentTTL.Duration <= 0 {
+			result.Error = fmt.Errorf("agent_ttl must be a positive duration")
+			return result
+		}
+		if config.CAValidity.Duration < config.AgentTTL.Duration {
+			result.Error = fmt.Errorf("ca_validity must be greater than agent_ttl")
+			return result
+		}
+	}
 
 	ttlChecks := []struct {
 		name string
@@ -108,6 +120,12 @@ func validateTTLDurationsWithWarnings(config *v1alpha1.SpireServerSpec) TTLValid
 			ttl:  config.DefaultJWTValidity.Duration,
 		},
 	}
+	if config.AgentTTL != nil {
+		ttlChecks = append(ttlChecks, struct {
+			name string
+			ttl  time.Duration
+		}{name: "agent_ttl", ttl: config.AgentTTL.Duration})
+	}
 
 	for _, ttlCheck := range ttlChecks {
 		if !hasCompatibleTTL(config.CAValidity.Duration, ttlCheck.ttl) {
@@ -158,7 +176,7 @@ func validateTTLDurationsWithWarnings(config *v1alpha1.SpireServerSpec) TTLValid
 }
 
 // validateFederationConfig validates the federation configuration
-func validateFederationConfig(federation *v1alpha1.FederationConfig, trustDomain string) error {
+func validateFederationConfig(federation *v1alpha2.FederationConfig, trustDomain string) error {
 	if federation == nil {
 		return nil
 	}
@@ -196,9 +214,9 @@ func validateFederationConfig(federation *v1alpha1.FederationConfig, trustDomain
 }
 
 // validateBundleEndpoint validates the bundle endpoint configuration
-func validateBundleEndpoint(bundleEndpoint *v1alpha1.BundleEndpointConfig) error {
+func validateBundleEndpoint(bundleEndpoint *v1alpha2.BundleEndpointConfig) error {
 	// Validate profile-specific configuration
-	if bundleEndpoint.Profile == v1alpha1.HttpsWebProfile {
+	if bundleEndpoint.Profile == v1alpha2.HttpsWebProfile {
 		if bundleEndpoint.HttpsWeb == nil {
 			return fmt.Errorf("httpsWeb configuration is required when profile is https_web")
 		}
@@ -238,7 +256,7 @@ func validateBundleEndpoint(bundleEndpoint *v1alpha1.BundleEndpointConfig) error
 }
 
 // validateAcmeConfig validates ACME configuration
-func validateAcmeConfig(acme *v1alpha1.AcmeConfig) error {
+func validateAcmeConfig(acme *v1alpha2.AcmeConfig) error {
 	if acme == nil {
 		return nil
 	}
@@ -255,7 +273,7 @@ func validateAcmeConfig(acme *v1alpha1.AcmeConfig) error {
 		return fmt.Errorf("email is required")
 	}
 
-	if !utils.StringToBool(acme.TosAccepted) {
+	if !ptr.Deref(acme.TosAccepted, false) {
 		return fmt.Errorf("tosAccepted must be true to use ACME")
 	}
 
@@ -264,7 +282,7 @@ func validateAcmeConfig(acme *v1alpha1.AcmeConfig) error {
 
 // validateServingCertConfig validates ServingCert configuration
 // The service CA certificate is always used for internal communication (Route to Pod)
-func validateServingCertConfig(servingCert *v1alpha1.ServingCertConfig) error {
+func validateServingCertConfig(servingCert *v1alpha2.ServingCertConfig) error {
 	if servingCert == nil {
 		return nil
 	}
@@ -277,7 +295,7 @@ func validateServingCertConfig(servingCert *v1alpha1.ServingCertConfig) error {
 }
 
 // validateFederatedTrustDomain validates a single federated trust domain configuration
-func validateFederatedTrustDomain(fedTrust *v1alpha1.FederatesWithConfig, index int) error {
+func validateFederatedTrustDomain(fedTrust *v1alpha2.FederatesWithConfig, index int) error {
 	// Validate trust domain format
 	if fedTrust.TrustDomain == "" {
 		return fmt.Errorf("federatesWith[%d]: trustDomain is required", index)
@@ -289,7 +307,7 @@ func validateFederatedTrustDomain(fedTrust *v1alpha1.FederatesWithConfig, index
 	}
 
 	// Validate https_spiffe requires endpointSpiffeId
-	if fedTrust.BundleEndpointProfile == v1alpha1.HttpsSpiffeProfile {
+	if fedTrust.BundleEndpointProfile == v1alpha2.HttpsSpiffeProfile {
 		if fedTrust.EndpointSpiffeId == "" {
 			return fmt.Errorf("federatesWith[%d]: endpointSpiffeId is required for https_spiffe profile", index)
 		}
@@ -300,3 +318,36 @@ func validateFederatedTrustDomain(fedTrust *v1alpha1.FederatesWithConfig, index
 
 	return nil
 }
+
+// validateAdminIDs checks the admin IDs are SPIFFE IDs of the trust domain of the server or of a
+// federated one, whose bundle the server needs to authenticate the callers
+func validateAdminIDs(adminIDs []string, trustDomain string, federation *v1alpha2.FederationConfig) error {
+	federated := map[string]bool{}
+	if federation != nil {
+		for _, fedTrust := range federation.FederatesWith {
+			federated[fedTrust.TrustDomain] = true
+		}
+	}
+	for i, id := range adminIDs {
+		idTrustDomain, err := utils.ParseSPIFFEID(id)
+		if err != nil {
+			return fmt.Errorf("adminIDs[%d]: %w", i, err)
+		}
+		if idTrustDomain != trustDomain && !federated[idTrustDomain] {
+			return fmt.Errorf("adminIDs[%d]: trust domain %s of %s is neither the trust domain of the server nor federated", i, idTrustDomain, id)
+		}
+	}
+	return nil
+}
+
+// validateRateLimit rejects disabling the attestation rate limit with a sqlite3 datastore: a wave
+// of node attestations would hold its single writer lock
+func validateRateLimit(rateLimit *v1alpha2.RateLimitConfig, datastore *v1alpha2.DataStore) error {
+	if rateLimit == nil || ptr.Deref(rateLimit.Attestation, true) {
+		return nil
+	}
+	if isSQLiteDatastore(datastore) {
+		return fmt.Errorf("rateLimit.attestation cannot be disabled with the %s datastore", sqliteDatabaseType)
+	}
+	return nil
+}
@@ -5,8 +5,9 @@ import (
 	"testing"
 	"time"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func TestMaxSVIDTTL(t *testing.T) {
@@ -122,7 +123,7 @@ func TestHasCompatibleTTL(t *testing.T) {
 func TestValidateTTLDurationsWithWarnings(t *testing.T) {
 	tests := []struct {
 		name            string
-		config          *v1alpha1.SpireServerSpec
+		config          *v1alpha2.SpireServerSpec
 		expectError     bool
 		expectWarnings  int
 		warningContains []string
@@ -130,7 +131,7 @@ func TestValidateTTLDurationsWithWarnings(t *testing.T) {
 	}{
 		{
 			name: "valid configuration - no warnings",
-			config: &v1alpha1.SpireServerSpec{
+			config: &v1alpha2.SpireServerSpec{
 				CAValidity:          metav1.Duration{Duration: 24 * time.Hour}, // 24h / 6 = 4h max SVID TTL
 				DefaultX509Validity: metav1.Duration{Duration: 2 * time.Hour},  // 2h < 4h (compatible)
 				DefaultJWTValidity:  metav1.Duration{Duration: 1 * time.Hour},  // 1h < 4h (compatible)
@@ -140,7 +141,7 @@ func TestValidateTTLDurationsWithWarnings(t *testing.T) {
 		},
 		{
 			name: "incompatible X509 SVID TTL - generates warning",
-			config: &v1alpha1.SpireServerSpec{
+			config: &v1alpha2.SpireServerSpec{
 				CAValidity:          metav1.Duration{Duration: 12 * time.Hour},   // 12h / 6 = 2h max SVID TTL
 				DefaultX509Validity: metav1.Duration{Duration: 4 * time.Hour},    // 4h > 2h (incompatible)
 				DefaultJWTValidity:  metav1.Duration{Duration: 30 * time.Minute}, // 30m < 2h (compatible)
@@ -154,7 +155,7 @@ func TestValidateTTLDurationsWithWarnings(t *testing.T) {
 		},
 		{
 			name: "incompatible JWT SVID TTL - generates warning",
-			config: &v1alpha1.SpireServerSpec{
+			config: &v1alpha2.SpireServerSpec{
 				CAValidity:          metav1.Duration{Duration: 6 * time.Hour},    // 6h / 6 = 1h max SVID TTL
 				DefaultX509Validity: metav1.Duration{Duration: 30 * time.Minute}, // 30m < 1h (compatible)
 				DefaultJWTValidity:  metav1.Duration{Duration: 2 * time.Hour},    // 2h > 1h (incompatible)
@@ -168,7 +169,7 @@ func TestValidateTTLDurationsWithWarnings(t *testing.T) {
 		},
 		{
 			name: "multiple incompatible TTLs - generates multiple warnings",
-			config: &v1alpha1.SpireServerSpec{
+			config: &v1alpha2.SpireServerSpec{
 				CAValidity:          metav1.Duration{Duration: 6 * time.Hour}, // 6h / 6 = 1h max SVID TTL
 				DefaultX509Validity: metav1.Duration{Duration: 3 * time.Hour}, // 3h > 1h (incompatible)
 				DefaultJWTValidity:  metav1.Duration{Duration: 2 * time.Hour}, // 2h > 1h (incompatible)
@@ -181,9 +182,20 @@ func TestValidateTTLDurationsWithWarnings(t *testing.T) {
 			},
 			statusMessage: "TTL configuration warnings: 2 issues found",
 		},
+		{
+			name: "error - agent TTL outliving the CA TTL",
+			config: &v1alpha2.SpireServerSpec{
+				CAValidity:          metav1.Duration{Duration: 24 * time.Hour},
+				DefaultX509Validity: metav1.Duration{Duration: 1 * time.Hour},
+				DefaultJWTValidity:  metav1.Duration{Duration: 30 * time.Minute},
+				AgentTTL:            &metav1.Duration{Duration: 48 * time.Hour},
+			},
+			expectError:    true,
+			expectWarnings: 0,
+		},
 		{
 			name: "error - zero CA TTL",
-			config: &v1alpha1.SpireServerSpec{
+			config: &v1alpha2.SpireServerSpec{
 				CAValidity:          metav1.Duration{Duration: 0},
 				DefaultX509Validity: metav1.Duration{Duration: 1 * time.Hour},
 				DefaultJWTValidity:  metav1.Duration{Duration: 30 * time.Minute},
@@ -241,7 +253,7 @@ func containsString(s, substr string) bool {
 func TestValidateFederationConfig(t *testing.T) {
 	tests := []struct {
 		name        string
-		federation  *v1alpha1.FederationConfig
+		federation  *v1alpha2.FederationConfig
 		trustDomain string
 		expectError bool
 		errorMsg    string
@@ -254,16 +266,16 @@ func TestValidateFederationConfig(t *testing.T) {
 		},
 		{
 			name: "Valid https_spiffe federation",
-			federation: &v1alpha1.FederationConfig{
-				BundleEndpoint: v1alpha1.BundleEndpointConfig{
-					Profile:     v1alpha1.HttpsSpiffeProfile,
+			federation: &v1alpha2.FederationConfig{
+				BundleEndpoint: v1alpha2.BundleEndpointConfig{
+					Profile:     v1alpha2.HttpsSpiffeProfile,
 					RefreshHint: 300,
 				},
-				FederatesWith: []v1alpha1.FederatesWithConfig{
+				FederatesWith: []v1alpha2.FederatesWithConfig{
 					{
 						TrustDomain:           "remote.org",
 						BundleEndpointUrl:     "https://remote.org:8443",
-						BundleEndpointProfile: v1alpha1.HttpsSpiffeProfile,
+						BundleEndpointProfile: v1alpha2.HttpsSpiffeProfile,
 						EndpointSpiffeId:      "spiffe://remote.org/spire/server",
 					},
 				},
@@ -273,24 +285,24 @@ func TestValidateFederationConfig(t *testing.T) {
 		},
 		{
 			name: "Valid https_web federation with ACME",
-			federation: &v1alpha1.FederationConfig{
-				BundleEndpoint: v1alpha1.BundleEndpointConfig{
-					Profile:     v1alpha1.HttpsWebProfile,
+			federation: &v1alpha2.FederationConfig{
+				BundleEndpoint: v1alpha2.BundleEndpointConfig{
+					Profile:     v1alpha2.HttpsWebProfile,
 					RefreshHint: 300,
-					HttpsWeb: &v1alpha1.HttpsWebConfig{
-						Acme: &v1alpha1.AcmeConfig{
+					HttpsWeb: &v1alpha2.HttpsWebConfig{
+						Acme: &v1alpha2.AcmeConfig{
 							DirectoryUrl: "https://acme-v02.api.letsencrypt.org/directory",
 							DomainName:   "federation.example.org",
 							Email:        "admin@example.org",
-							TosAccepted:  "true",
+							TosAccepted:  ptr.To(true),
 						},
 					},
 				},
-				FederatesWith: []v1alpha1.FederatesWithConfig{
+				FederatesWith: []v1alpha2.FederatesWithConfig{
 					{
 						TrustDomain:           "remote.org",
 						BundleEndpointUrl:     "https://remote.org",
-						BundleEndpointProfile: v1alpha1.HttpsWebProfile,
+						BundleEndpointProfile: v1alpha2.HttpsWebProfile,
 					},
 				},
 			},
@@ -299,12 +311,12 @@ func TestValidateFederationConfig(t *testing.T) {
 		},
 		{
 			name: "Valid https_web federation with ServingCert",
-			federation: &v1alpha1.FederationConfig{
-				BundleEndpoint: v1alpha1.BundleEndpointConfig{
-					Profile:     v1alpha1.HttpsWebProfile,
+			federation: &v1alpha2.FederationConfig{
+				BundleEndpoint: v1alpha2.BundleEndpointConfig{
+					Profile:     v1alpha2.HttpsWebProfile,
 					RefreshHint: 300,
-					HttpsWeb: &v1alpha1.HttpsWebConfig{
-						ServingCert: &v1alpha1.ServingCertConfig{
+					HttpsWeb: &v1alpha2.HttpsWebConfig{
+						ServingCert: &v1alpha2.ServingCertConfig{
 							FileSyncInterval: 3600,
 						},
 					},
@@ -315,16 +327,16 @@ func TestValidateFederationConfig(t *testing.T) {
 		},
 		{
 			name: "Self-federation error",
-			federation: &v1alpha1.FederationConfig{
-				BundleEndpoint: v1alpha1.BundleEndpointConfig{
-					Profile:     v1alpha1.HttpsSpiffeProfile,
+			federation: &v1alpha2.FederationConfig{
+				BundleEndpoint: v1alpha2.BundleEndpointConfig{
+					Profile:     v1alpha2.HttpsSpiffeProfile,
 					RefreshHint: 300,
 				},
-				FederatesWith: []v1alpha1.FederatesWithConfig{
+				FederatesWith: []v1alpha2.FederatesWithConfig{
 					{
 						TrustDomain:           "example.org",
 						BundleEndpointUrl:     "https://example.org:8443",
-						BundleEndpointProfile: v1alpha1.HttpsSpiffeProfile,
+						BundleEndpointProfile: v1alpha2.HttpsSpiffeProfile,
 						EndpointSpiffeId:      "spiffe://example.org/spire/server",
 					},
 				},
@@ -335,22 +347,22 @@ func TestValidateFederationConfig(t *testing.T) {
 		},
 		{
 			name: "Duplicate trust domains",
-			federation: &v1alpha1.FederationConfig{
-				BundleEndpoint: v1alpha1.BundleEndpointConfig{
-					Profile:     v1alpha1.HttpsSpiffeProfile,
+			federation: &v1alpha2.FederationConfig{
+				BundleEndpoint: v1alpha2.BundleEndpointConfig{
+					Profile:     v1alpha2.HttpsSpiffeProfile,
 					RefreshHint: 300,
 				},
-				FederatesWith: []v1alpha1.FederatesWithConfig{
+				FederatesWith: []v1alpha2.FederatesWithConfig{
 					{
 						TrustDomain:           "remote.org",
 						BundleEndpointUrl:     "https://remote1.org:8443",
-						BundleEndpointProfile: v1alpha1.HttpsSpiffeProfile,
+						BundleEndpointProfile: v1alpha2.HttpsSpiffeProfile,
 						EndpointSpiffeId:      "spiffe://remote.org/spire/server",
 					},
 					{
 						TrustDomain:           "remote.org",
 						BundleEndpointUrl:     "https://remote2.org:8443",
-						BundleEndpointProfile: v1alpha1.HttpsSpiffeProfile,
+						BundleEndpointProfile: v1alpha2.HttpsSpiffeProfile,
 						EndpointSpiffeId:      "spiffe://remote.org/spire/server",
 					},
 				},
@@ -361,11 +373,11 @@ func TestValidateFederationConfig(t *testing.T) {
 		},
 		{
 			name: "Too many federatesWith entries",
-			federation: &v1alpha1.FederationConfig{
-				BundleEndpoint: v1alpha1.BundleEndpointConfig{
-					Profile: v1alpha1.HttpsSpiffeProfile,
+			federation: &v1alpha2.FederationConfig{
+				BundleEndpoint: v1alpha2.BundleEndpointConfig{
+					Profile: v1alpha2.HttpsSpiffeProfile,
 				},
-				FederatesWith: make([]v1alpha1.FederatesWithConfig, 51),
+				FederatesWith: make([]v1alpha2.FederatesWithConfig, 51),
 			},
 			trustDomain: "example.org",
 			expectError: true,
@@ -373,9 +385,9 @@ func TestValidateFederationConfig(t *testing.T) {
 		},
 		{
 			name: "Invalid refresh hint - too low",
-			federation: &v1alpha1.FederationConfig{
-				BundleEndpoint: v1alpha1.BundleEndpointConfig{
-					Profile:     v1alpha1.HttpsSpiffeProfile,
+			federation: &v1alpha2.FederationConfig{
+				BundleEndpoint: v1alpha2.BundleEndpointConfig{
+					Profile:     v1alpha2.HttpsSpiffeProfile,
 					RefreshHint: 30,
 				},
 			},
@@ -385,9 +397,9 @@ func TestValidateFederationConfig(t *testing.T) {
 		},
 		{
 			name: "Invalid refresh hint - too high",
-			federation: &v1alpha1.FederationConfig{
-				BundleEndpoint: v1alpha1.BundleEndpointConfig{
-					Profile:     v1alpha1.HttpsSpiffeProfile,
+			federation: &v1alpha2.FederationConfig{
+				BundleEndpoint: v1alpha2.BundleEndpointConfig{
+					Profile:     v1alpha2.HttpsSpiffeProfile,
 					RefreshHint: 4000,
 				},
 			},
@@ -416,29 +428,29 @@ func TestValidateFederationConfig(t *testing.T) {
 func TestValidateBundleEndpoint(t *testing.T) {
 	tests := []struct {
 		name           string
-		bundleEndpoint *v1alpha1.BundleEndpointConfig
+		bundleEndpoint *v1alpha2.BundleEndpointConfig
 		expectError    bool
 		errorMsg       string
 	}{
 		{
 			name: "Valid https_spiffe profile",
-			bundleEndpoint: &v1alpha1.BundleEndpointConfig{
-				Profile:     v1alpha1.HttpsSpiffeProfile,
+			bundleEndpoint: &v1alpha2.BundleEndpointConfig{
+				Profile:     v1alpha2.HttpsSpiffeProfile,
 				RefreshHint: 300,
 			},
 			expectError: false,
 		},
 		{
 			name: "Valid https_web with ACME",
-			bundleEndpoint: &v1alpha1.BundleEndpointConfig{
-				Profile:     v1alpha1.HttpsWebProfile,
+			bundleEndpoint: &v1alpha2.BundleEndpointConfig{
+				Profile:     v1alpha2.HttpsWebProfile,
 				RefreshHint: 300,
-				HttpsWeb: &v1alpha1.HttpsWebConfig{
-					Acme: &v1alpha1.AcmeConfig{
+				HttpsWeb: &v1alpha2.HttpsWebConfig{
+					Acme: &v1alpha2.AcmeConfig{
 						DirectoryUrl: "https://acme-v02.api.letsencrypt.org/directory",
 						DomainName:   "example.org",
 						Email:        "admin@example.org",
-						TosAccepted:  "true",
+						TosAccepted:  ptr.To(true),
 					},
 				},
 			},
@@ -446,11 +458,11 @@ func TestValidateBundleEndpoint(t *testing.T) {
 		},
 		{
 			name: "Valid https_web with ServingCert",
-			bundleEndpoint: &v1alpha1.BundleEndpointConfig{
-				Profile:     v1alpha1.HttpsWebProfile,
+			bundleEndpoint: &v1alpha2.BundleEndpointConfig{
+				Profile:     v1alpha2.HttpsWebProfile,
 				RefreshHint: 300,
-				HttpsWeb: &v1alpha1.HttpsWebConfig{
-					ServingCert: &v1alpha1.ServingCertConfig{
+				HttpsWeb: &v1alpha2.HttpsWebConfig{
+					ServingCert: &v1alpha2.ServingCertConfig{
 						FileSyncInterval: 3600,
 					},
 				},
@@ -459,8 +471,8 @@ func TestValidateBundleEndpoint(t *testing.T) {
 		},
 		{
 			name: "https_web without HttpsWeb config",
-			bundleEndpoint: &v1alpha1.BundleEndpointConfig{
-				Profile:     v1alpha1.HttpsWebProfile,
+			bundleEndpoint: &v1alpha2.BundleEndpointConfig{
+				Profile:     v1alpha2.HttpsWebProfile,
 				RefreshHint: 300,
 			},
 			expectError: true,
@@ -468,17 +480,17 @@ func TestValidateBundleEndpoint(t *testing.T) {
 		},
 		{
 			name: "https_web with both ACME and ServingCert",
-			bundleEndpoint: &v1alpha1.BundleEndpointConfig{
-				Profile:     v1alpha1.HttpsWebProfile,
+			bundleEndpoint: &v1alpha2.BundleEndpointConfig{
+				Profile:     v1alpha2.HttpsWebProfile,
 				RefreshHint: 300,
-				HttpsWeb: &v1alpha1.HttpsWebConfig{
-					Acme: &v1alpha1.AcmeConfig{
+				HttpsWeb: &v1alpha2.HttpsWebConfig{
+					Acme: &v1alpha2.AcmeConfig{
 						DirectoryUrl: "https://acme-v02.api.letsencrypt.org/directory",
 						DomainName:   "example.org",
 						Email:        "admin@example.org",
-						TosAccepted:  "true",
+						TosAccepted:  ptr.To(true),
 					},
-					ServingCert: &v1alpha1.ServingCertConfig{},
+					ServingCert: &v1alpha2.ServingCertConfig{},
 				},
 			},
 			expectError: true,
@@ -486,10 +498,10 @@ func TestValidateBundleEndpoint(t *testing.T) {
 		},
 		{
 			name: "https_web with neither ACME nor ServingCert",
-			bundleEndpoint: &v1alpha1.BundleEndpointConfig{
-				Profile:     v1alpha1.HttpsWebProfile,
+			bundleEndpoint: &v1alpha2.BundleEndpointConfig{
+				Profile:     v1alpha2.HttpsWebProfile,
 				RefreshHint: 300,
-				HttpsWeb:    &v1alpha1.HttpsWebConfig{},
+				HttpsWeb:    &v1alpha2.HttpsWebConfig{},
 			},
 			expectError: true,
 			errorMsg:    "either acme or servingCert must be set for https_web profile",
@@ -515,7 +527,7 @@ func TestValidateBundleEndpoint(t *testing.T) {
 func TestValidateAcmeConfig(t *testing.T) {
 	tests := []struct {
 		name        string
-		acme        *v1alpha1.AcmeConfig
+		acme        *v1alpha2.AcmeConfig
 		expectError bool
 		errorMsg    string
 	}{
@@ -526,74 +538,74 @@ func TestValidateAcmeConfig(t *testing.T) {
 		},
 		{
 			name: "Valid ACME config",
-			acme: &v1alpha1.AcmeConfig{
+			acme: &v1alpha2.AcmeConfig{
 				DirectoryUrl: "https://acme-v02.api.letsencrypt.org/directory",
 				DomainName:   "example.org",
 				Email:        "admin@example.org",
-				TosAccepted:  "true",
+				TosAccepted:  ptr.To(true),
 			},
 			expectError: false,
 		},
 		{
 			name: "Valid ACME config with complex email",
-			acme: &v1alpha1.AcmeConfig{
+			acme: &v1alpha2.AcmeConfig{
 				DirectoryUrl: "https://acme-v02.api.letsencrypt.org/directory",
 				DomainName:   "example.org",
 				Email:        "user.name+tag@sub-domain.example.org",
-				TosAccepted:  "true",
+				TosAccepted:  ptr.To(true),
 			},
 			expectError: false,
 		},
 		{
 			name: "Valid ACME config with numbered email",
-			acme: &v1alpha1.AcmeConfig{
+			acme: &v1alpha2.AcmeConfig{
 				DirectoryUrl: "https://acme-v02.api.letsencrypt.org/directory",
 				DomainName:   "example.org",
 				Email:        "admin123@example456.com",
-				TosAccepted:  "true",
+				TosAccepted:  ptr.To(true),
 			},
 			expectError: false,
 		},
 		{
 			name: "Invalid directory URL - not https",
-			acme: &v1alpha1.AcmeConfig{
+			acme: &v1alpha2.AcmeConfig{
 				DirectoryUrl: "http://acme.example.org/directory",
 				DomainName:   "example.org",
 				Email:        "admin@example.org",
-				TosAccepted:  "true",
+				TosAccepted:  ptr.To(true),
 			},
 			expectError: true,
 			errorMsg:    "directoryUrl must use https://",
 		},
 		{
 			name: "Missing domain name",
-			acme: &v1alpha1.AcmeConfig{
+			acme: &v1alpha2.AcmeConfig{
 				DirectoryUrl: "https://acme-v02.api.letsencrypt.org/directory",
 				DomainName:   "",
 				Email:        "admin@example.org",
-				TosAccepted:  "true",
+				TosAccepted:  ptr.To(true),
 			},
 			expectError: true,
 			errorMsg:    "domainName is required",
 		},
 		{
 			name: "Missing email",
-			acme: &v1alpha1.AcmeConfig{
+			acme: &v1alpha2.AcmeConfig{
 				DirectoryUrl: "https://acme-v02.api.letsencrypt.org/directory",
 				DomainName:   "example.org",
 				Email:        "",
-				TosAccepted:  "true",
+				TosAccepted:  ptr.To(true),
 			},
 			expectError: true,
 			errorMsg:    "email is required",
 		},
 		{
 			name: "TOS not accepted",
-			acme: &v1alpha1.AcmeConfig{
+			acme: &v1alpha2.AcmeConfig{
 				DirectoryUrl: "https://acme-v02.api.letsencrypt.org/directory",
 				DomainName:   "example.org",
 				Email:        "admin@example.org",
-				TosAccepted:  "false",
+				TosAccepted:  ptr.To(false),
 			},
 			expectError: true,
 			errorMsg:    "tosAccepted must be true to use ACME",
@@ -619,7 +631,7 @@ func TestValidateAcmeConfig(t *testing.T) {
 func TestValidateServingCertConfig(t *testing.T) {
 	tests := []struct {
 		name        string
-		servingCert *v1alpha1.ServingCertConfig
+		servingCert *v1alpha2.ServingCertConfig
 		expectError bool
 		errorMsg    string
 	}{
@@ -630,14 +642,14 @@ func TestValidateServingCertConfig(t *testing.T) {
 		},
 		{
 			name: "Valid ServingCert config",
-			servingCert: &v1alpha1.ServingCertConfig{
+			servingCert: &v1alpha2.ServingCertConfig{
 				FileSyncInterval: 3600,
 			},
 			expectError: false,
 		},
 		{
 			name: "Valid ServingCert with service CA certificate",
-			servingCert: &v1alpha1.ServingCertConfig{
+			servingCert: &v1alpha2.ServingCertConfig{
 				FileSyncInterval:  3600,
 				ExternalSecretRef: "federation-tls-cert",
 			},
@@ -645,21 +657,21 @@ func TestValidateServingCertConfig(t *testing.T) {
 		},
 		{
 			name: "Valid FileSyncInterval at minimum",
-			servingCert: &v1alpha1.ServingCertConfig{
+			servingCert: &v1alpha2.ServingCertConfig{
 				FileSyncInterval: 3600,
 			},
 			expectError: false,
 		},
 		{
 			name: "Valid FileSyncInterval at maximum",
-			servingCert: &v1alpha1.ServingCertConfig{
+			servingCert: &v1alpha2.ServingCertConfig{
 				FileSyncInterval: 7776000,
 			},
 			expectError: false,
 		},
 		{
 			name: "Invalid FileSyncInterval - too low",
-			servingCert: &v1alpha1.ServingCertConfig{
+			servingCert: &v1alpha2.ServingCertConfig{
 				FileSyncInterval: 3599,
 			},
 			expectError: true,
@@ -667,7 +679,7 @@ func TestValidateServingCertConfig(t *testing.T) {
 		},
 		{
 			name: "Invalid FileSyncInterval - too high",
-			servingCert: &v1alpha1.ServingCertConfig{
+			servingCert: &v1alpha2.ServingCertConfig{
 				FileSyncInterval: 7776001,
 			},
 			expectError: true,
@@ -694,17 +706,17 @@ func TestValidateServingCertConfig(t *testing.T) {
 func TestValidateFederatedTrustDomain(t *testing.T) {
 	tests := []struct {
 		name        string
-		fedTrust    *v1alpha1.FederatesWithConfig
+		fedTrust    *v1alpha2.FederatesWithConfig
 		index       int
 		expectError bool
 		errorMsg    string
 	}{
 		{
 			name: "Valid https_spiffe trust domain",
-			fedTrust: &v1alpha1.FederatesWithConfig{
+			fedTrust: &v1alpha2.FederatesWithConfig{
 				TrustDomain:           "remote.org",
 				BundleEndpointUrl:     "https://remote.org:8443",
-				BundleEndpointProfile: v1alpha1.HttpsSpiffeProfile,
+				BundleEndpointProfile: v1alpha2.HttpsSpiffeProfile,
 				EndpointSpiffeId:      "spiffe://remote.org/spire/server",
 			},
 			index:       0,
@@ -712,20 +724,20 @@ func TestValidateFederatedTrustDomain(t *testing.T) {
 		},
 		{
 			name: "Valid https_web trust domain",
-			fedTrust: &v1alpha1.FederatesWithConfig{
+			fedTrust: &v1alpha2.FederatesWithConfig{
 				TrustDomain:           "remote.org",
 				BundleEndpointUrl:     "https://remote.org",
-				BundleEndpointProfile: v1alpha1.HttpsWebProfile,
+				BundleEndpointProfile: v1alpha2.HttpsWebProfile,
 			},
 			index:       0,
 			expectError: false,
 		},
 		{
 			name: "Empty trust domain",
-			fedTrust: &v1alpha1.FederatesWithConfig{
+			fedTrust: &v1alpha2.FederatesWithConfig{
 				TrustDomain:           "",
 				BundleEndpointUrl:     "https://remote.org:8443",
-				BundleEndpointProfile: v1alpha1.HttpsSpiffeProfile,
+				BundleEndpointProfile: v1alpha2.HttpsSpiffeProfile,
 				EndpointSpiffeId:      "spiffe://remote.org/spire/server",
 			},
 			index:       0,
@@ -734,10 +746,10 @@ func TestValidateFederatedTrustDomain(t *testing.T) {
 		},
 		{
 			name: "Invalid URL - not https",
-			fedTrust: &v1alpha1.FederatesWithConfig{
+			fedTrust: &v1alpha2.FederatesWithConfig{
 				TrustDomain:           "remote.org",
 				BundleEndpointUrl:     "http://remote.org:8443",
-				BundleEndpointProfile: v1alpha1.HttpsSpiffeProfile,
+				BundleEndpointProfile: v1alpha2.HttpsSpiffeProfile,
 				EndpointSpiffeId:      "spiffe://remote.org/spire/server",
 			},
 			index:       0,
@@ -746,10 +758,10 @@ func TestValidateFederatedTrustDomain(t *testing.T) {
 		},
 		{
 			name: "https_spiffe without endpointSpiffeId",
-			fedTrust: &v1alpha1.FederatesWithConfig{
+			fedTrust: &v1alpha2.FederatesWithConfig{
 				TrustDomain:           "remote.org",
 				BundleEndpointUrl:     "https://remote.org:8443",
-				BundleEndpointProfile: v1alpha1.HttpsSpiffeProfile,
+				BundleEndpointProfile: v1alpha2.HttpsSpiffeProfile,
 				EndpointSpiffeId:      "",
 			},
 			index:       0,
@@ -758,10 +770,10 @@ func TestValidateFederatedTrustDomain(t *testing.T) {
 		},
 		{
 			name: "https_spiffe with invalid endpointSpiffeId",
-			fedTrust: &v1alpha1.FederatesWithConfig{
+			fedTrust: &v1alpha2.FederatesWithConfig{
 				TrustDomain:           "remote.org",
 				BundleEndpointUrl:     "https://remote.org:8443",
-				BundleEndpointProfile: v1alpha1.HttpsSpiffeProfile,
+				BundleEndpointProfile: v1alpha2.HttpsSpiffeProfile,
 				EndpointSpiffeId:      "invalid://remote.org/spire/server",
 			},
 			index:       0,
@@ -785,3 +797,60 @@ func TestValidateFederatedTrustDomain(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAdminIDs(t *testing.T) {
+	federation := &v1alpha2.FederationConfig{FederatesWith: []v1alpha2.FederatesWithConfig{{TrustDomain: "partner.org"}}}
+	tests := []struct {
+		name       string
+		adminIDs   []string
+		federation *v1alpha2.FederationConfig
+		expectErr  string
+	}{
+		{name: "none"},
+		{name: "own trust domain", adminIDs: []string{"spiffe://example.org/ns/ops/sa/registrar"}},
+		{name: "federated trust domain", adminIDs: []string{"spiffe://partner.org/admin"}, federation: federation},
+		{name: "foreign trust domain", adminIDs: []string{"spiffe://partner.org/admin"}, expectErr: "neither the trust domain of the server nor federated"},
+		{name: "not a SPIFFE ID", adminIDs: []string{"spiffe://example.org"}, expectErr: "adminIDs[0]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAdminIDs(tt.adminIDs, "example.org", tt.federation)
+			if tt.expectErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateRateLimit(t *testing.T) {
+	sqlite := &v1alpha2.DataStore{DatabaseType: "sqlite3"}
+	postgres := &v1alpha2.DataStore{DatabaseType: "postgres"}
+	tests := []struct {
+		name      string
+		rateLimit *v1alpha2.RateLimitConfig
+		datastore *v1alpha2.DataStore
+		expectErr bool
+	}{
+		{name: "unset", datastore: sqlite},
+		{name: "signing disabled", rateLimit: &v1alpha2.RateLimitConfig{Signing: ptr.To(false)}, datastore: sqlite},
+		{name: "attestation disabled with postgres", rateLimit: &v1alpha2.RateLimitConfig{Attestation: ptr.To(false)}, datastore: postgres},
+		{name: "attestation disabled with sqlite3", rateLimit: &v1alpha2.RateLimitConfig{Attestation: ptr.To(false)}, datastore: sqlite, expectErr: true},
+		{name: "attestation enabled with sqlite3", rateLimit: &v1alpha2.RateLimitConfig{Attestation: ptr.To(true)}, datastore: sqlite},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRateLimit(tt.rateLimit, tt.datastore)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
@@ -8,9 +8,11 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/operator/assets"
@@ -19,12 +21,16 @@ import (
 // Constants for status conditions are defined in controller.go
 
 // reconcileWebhook reconciles the ValidatingWebhookConfiguration for Controller Manager
-func (r *SpireServerReconciler) reconcileWebhook(ctx context.Context, server *v1alpha1.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
+func (r *SpireServerReconciler) reconcileWebhook(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager, createOnlyMode bool) error {
 	desired := getSpireControllerManagerValidatingWebhookConfiguration(server.Spec.Labels)
+	failurePolicy := controllerManagerWebhookFailurePolicy(server.Spec.ControllerManagerWebhook)
+	for i := range desired.Webhooks {
+		desired.Webhooks[i].FailurePolicy = &failurePolicy
+	}
 
 	if err := controllerutil.SetControllerReference(server, desired, r.scheme); err != nil {
 		r.log.Error(err, "failed to set controller reference on validating webhook")
-		statusMgr.AddCondition(ValidatingWebhookAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(ValidatingWebhookAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to set owner reference on ValidatingWebhookConfiguration: %v", err),
 			metav1.ConditionFalse)
 		return err
@@ -38,23 +44,23 @@ func (r *SpireServerReconciler) reconcileWebhook(ctx context.Context, server *v1
 		if !kerrors.IsNotFound(err) {
 			// Unexpected error
 			r.log.Error(err, "failed to get validating webhook")
-			statusMgr.AddCondition(ValidatingWebhookAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(ValidatingWebhookAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to get ValidatingWebhookConfiguration: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		// Resource doesn't exist, create it
-		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+		if err := r.ctrlClient.Create(ctx, desired, customClient.AdoptExisting(ptr.Deref(server.Spec.AdoptExistingResources, false))); err != nil {
 			r.log.Error(err, "failed to create validating webhook")
-			statusMgr.AddCondition(ValidatingWebhookAvailable, v1alpha1.ReasonFailed,
+			statusMgr.AddCondition(ValidatingWebhookAvailable, v1alpha2.ReasonFailed,
 				fmt.Sprintf("Failed to create ValidatingWebhookConfiguration: %v", err),
 				metav1.ConditionFalse)
 			return err
 		}
 
 		r.log.Info("Created ValidatingWebhookConfiguration", "name", desired.Name)
-		statusMgr.AddCondition(ValidatingWebhookAvailable, v1alpha1.ReasonReady,
+		statusMgr.AddCondition(ValidatingWebhookAvailable, v1alpha2.ReasonReady,
 			"All ValidatingWebhookConfiguration resources available",
 			metav1.ConditionTrue)
 		return nil
@@ -63,7 +69,7 @@ func (r *SpireServerReconciler) reconcileWebhook(ctx context.Context, server *v1
 	// Resource exists, check if we need to update
 	if createOnlyMode {
 		r.log.V(1).Info("ValidatingWebhookConfiguration exists, skipping update due to create-only mode", "name", desired.Name)
-		statusMgr.AddCondition(ValidatingWebhookAvailable, v1alpha1.ReasonReady,
+		statusMgr.AddCondition(ValidatingWebhookAvailable, v1alpha2.ReasonReady,
 			"All ValidatingWebhookConfiguration resources available",
 			metav1.ConditionTrue)
 		return nil
@@ -109,7 +115,7 @@ func (r *SpireServerReconciler) reconcileWebhook(ctx context.Context, server *v1
 	// Check if update is needed
 	if !utils.ResourceNeedsUpdate(existing, desired) {
 		r.log.V(1).Info("ValidatingWebhookConfiguration is up to date", "name", desired.Name)
-		statusMgr.AddCondition(ValidatingWebhookAvailable, v1alpha1.ReasonReady,
+		statusMgr.AddCondition(ValidatingWebhookAvailable, v1alpha2.ReasonReady,
 			"All ValidatingWebhookConfiguration resources available",
 			metav1.ConditionTrue)
 		return nil
@@ -118,14 +124,14 @@ func (r *SpireServerReconciler) reconcileWebhook(ctx context.Context, server *v1
 	// Update the resource
 	if err := r.ctrlClient.Update(ctx, desired); err != nil {
 		r.log.Error(err, "failed to update validating webhook")
-		statusMgr.AddCondition(ValidatingWebhookAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(ValidatingWebhookAvailable, v1alpha2.ReasonFailed,
 			fmt.Sprintf("Failed to update ValidatingWebhookConfiguration: %v", err),
 			metav1.ConditionFalse)
 		return err
 	}
 
 	r.log.Info("Updated ValidatingWebhookConfiguration", "name", desired.Name)
-	statusMgr.AddCondition(ValidatingWebhookAvailable, v1alpha1.ReasonReady,
+	statusMgr.AddCondition(ValidatingWebhookAvailable, v1alpha2.ReasonReady,
 		"All ValidatingWebhookConfiguration resources available",
 		metav1.ConditionTrue)
 	return nil
@@ -0,0 +1,174 @@
+package spire_server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	// WebhookCertValid reports the serving certificate of the spire-controller-manager webhook
+	WebhookCertValid = "WebhookCertValid"
+
+	WebhookCertReasonValid       = "WebhookCertValid"
+	WebhookCertReasonExpiring    = "WebhookCertExpiring"
+	WebhookCertReasonExpired     = "WebhookCertExpired"
+	WebhookCertReasonUntrusted   = "WebhookCertUntrusted"
+	WebhookCertReasonUnavailable = "WebhookCertUnavailable"
+
+	// controllerManagerWebhookName is the ValidatingWebhookConfiguration of the spire-controller-manager
+	controllerManagerWebhookName = "spire-controller-manager-webhook"
+
+	// webhookCertCheckInterval is how often the serving certificate is read again, as it is
+	// rotated by the spire-controller-manager without the operator being notified
+	webhookCertCheckInterval = 5 * time.Minute
+
+	// webhookCertDialTimeout bounds the TLS handshake with the webhook
+	webhookCertDialTimeout = 5 * time.Second
+
+	// defaultWebhookCertExpiryWarning is used when spec.controllerManagerWebhook is not set
+	defaultWebhookCertExpiryWarning = time.Hour
+)
+
+// webhookCertReader reads the certificate chain served by a webhook
+type webhookCertReader interface {
+	// ServingCertificates returns the certificates presented by the server at the address
+	ServingCertificates(ctx context.Context, address, serverName string) ([]*x509.Certificate, error)
+}
+
+// tlsWebhookCertReader completes a TLS handshake with the webhook Service to read its certificates
+type tlsWebhookCertReader struct{}
+
+func (tlsWebhookCertReader) ServingCertificates(ctx context.Context, address, serverName string) ([]*x509.Certificate, error) {
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: webhookCertDialTimeout},
+		Config: &tls.Config{
+			ServerName: serverName,
+			MinVersion: tls.VersionTLS12,
+			// The chain is verified against the caBundle of the webhook by the caller, so an
+			// untrusted certificate is reported rather than failing the handshake
+			InsecureSkipVerify: true,
+		},
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	return conn.(*tls.Conn).ConnectionState().PeerCertificates, nil
+}
+
+// controllerManagerWebhookFailurePolicy returns the failure policy of the webhooks from spec.controllerManagerWebhook
+func controllerManagerWebhookFailurePolicy(config *v1alpha2.ControllerManagerWebhookConfig) admissionregistrationv1.FailurePolicyType {
+	if config != nil && config.FailurePolicy == string(admissionregistrationv1.Fail) {
+		return admissionregistrationv1.Fail
+	}
+	return admissionregistrationv1.Ignore
+}
+
+// webhookCertExpiryWarning returns how long before its expiry the serving certificate is reported as expiring
+func webhookCertExpiryWarning(config *v1alpha2.ControllerManagerWebhookConfig) time.Duration {
+	if config == nil || config.CertificateExpiryWarning.Duration <= 0 {
+		return defaultWebhookCertExpiryWarning
+	}
+	return config.CertificateExpiryWarning.Duration
+}
+
+// reconcileWebhookCert checks the certificate served by the spire-controller-manager webhook is
+// trusted by the caBundle of its ValidatingWebhookConfiguration and not about to expire, records
+// its expiry in status, and sets the WebhookCertValid condition. A certificate which cannot be
+// read yet, e.g. while the server is rolling out, is reported as Unknown.
+func (r *SpireServerReconciler) reconcileWebhookCert(ctx context.Context, server *v1alpha2.SpireServer, statusMgr *status.Manager) {
+	if r.webhookCerts == nil {
+		return
+	}
+
+	var webhook admissionregistrationv1.ValidatingWebhookConfiguration
+	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: controllerManagerWebhookName}, &webhook); err != nil {
+		statusMgr.AddCondition(WebhookCertValid, WebhookCertReasonUnavailable,
+			fmt.Sprintf("Failed to get ValidatingWebhookConfiguration %s: %v", controllerManagerWebhookName, err),
+			metav1.ConditionUnknown)
+		return
+	}
+	if len(webhook.Webhooks) == 0 || len(webhook.Webhooks[0].ClientConfig.CABundle) == 0 {
+		statusMgr.AddCondition(WebhookCertValid, WebhookCertReasonUnavailable,
+			"Waiting for the spire-controller-manager to publish the caBundle of its webhook",
+			metav1.ConditionUnknown)
+		return
+	}
+
+	serverName := fmt.Sprintf("%s.%s.svc", controllerManagerWebhookName, utils.GetOperatorNamespace())
+	certs, err := r.webhookCerts.ServingCertificates(ctx, net.JoinHostPort(serverName, "443"), serverName)
+	if err == nil && len(certs) == 0 {
+		err = errors.New("no certificate presented")
+	}
+	if err != nil {
+		r.log.V(1).Info("failed to read the webhook serving certificate", "error", err.Error())
+		statusMgr.AddCondition(WebhookCertValid, WebhookCertReasonUnavailable,
+			fmt.Sprintf("Failed to read the serving certificate of the webhook: %v", err),
+			metav1.ConditionUnknown)
+		return
+	}
+
+	leaf := certs[0]
+	server.Status.WebhookCertificateExpiry = &metav1.Time{Time: leaf.NotAfter}
+	reason, message, conditionStatus := checkWebhookCert(certs, webhook.Webhooks[0].ClientConfig.CABundle, serverName,
+		webhookCertExpiryWarning(server.Spec.ControllerManagerWebhook), time.Now())
+	if reason == WebhookCertReasonExpiring {
+		r.eventRecorder.Event(server, corev1.EventTypeWarning, reason, utils.WithRunbook(reason, message))
+	}
+	statusMgr.AddCondition(WebhookCertValid, reason, message, conditionStatus)
+}
+
+// checkWebhookCert verifies the served certificate chain against the caBundle at the given time
+func checkWebhookCert(certs []*x509.Certificate, caBundle []byte, serverName string, expiryWarning time.Duration, now time.Time) (string, string, metav1.ConditionStatus) {
+	leaf := certs[0]
+	expiry := leaf.NotAfter.UTC().Format(time.RFC3339)
+	if now.After(leaf.NotAfter) {
+		return WebhookCertReasonExpired,
+			fmt.Sprintf("The serving certificate of the webhook expired at %s and was not rotated", expiry),
+			metav1.ConditionFalse
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caBundle) {
+		return WebhookCertReasonUntrusted, "The caBundle of the webhook holds no valid certificate", metav1.ConditionFalse
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		DNSName:       serverName,
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+	}); err != nil {
+		// Typically a CA rotation which was not propagated to the caBundle
+		return WebhookCertReasonUntrusted,
+			fmt.Sprintf("The serving certificate of the webhook is not trusted by its caBundle: %v", err),
+			metav1.ConditionFalse
+	}
+
+	if leaf.NotAfter.Sub(now) < expiryWarning {
+		return WebhookCertReasonExpiring,
+			fmt.Sprintf("The serving certificate of the webhook expires at %s and was not rotated yet", expiry),
+			metav1.ConditionTrue
+	}
+	return WebhookCertReasonValid,
+		fmt.Sprintf("The serving certificate of the webhook is trusted and valid until %s", expiry),
+		metav1.ConditionTrue
+}
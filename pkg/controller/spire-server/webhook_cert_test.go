@@ -0,0 +1,172 @@
+package spire_server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+)
+
+const testWebhookServerName = "spire-controller-manager-webhook.test-ns.svc"
+
+// fakeWebhookCerts serves the given certificate chain
+type fakeWebhookCerts struct {
+	certs []*x509.Certificate
+	err   error
+}
+
+func (f *fakeWebhookCerts) ServingCertificates(_ context.Context, _, _ string) ([]*x509.Certificate, error) {
+	return f.certs, f.err
+}
+
+// newTestWebhookChain returns a CA in PEM and a serving certificate it signed, valid until notAfter
+func newTestWebhookChain(t *testing.T, notAfter time.Time) ([]byte, *x509.Certificate) {
+	t.Helper()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, _ := x509.ParseCertificate(caDER)
+
+	leafKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	leafDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		DNSNames:     []string{testWebhookServerName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, &leafKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, _ := x509.ParseCertificate(leafDER)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}), leaf
+}
+
+func TestCheckWebhookCert(t *testing.T) {
+	now := time.Now()
+	caBundle, leaf := newTestWebhookChain(t, now.Add(12*time.Hour))
+	otherCABundle, _ := newTestWebhookChain(t, now.Add(12*time.Hour))
+
+	tests := []struct {
+		name         string
+		caBundle     []byte
+		at           time.Time
+		expectReason string
+		expectStatus metav1.ConditionStatus
+	}{
+		{name: "valid", caBundle: caBundle, at: now, expectReason: WebhookCertReasonValid, expectStatus: metav1.ConditionTrue},
+		{name: "expiring", caBundle: caBundle, at: now.Add(11*time.Hour + 30*time.Minute), expectReason: WebhookCertReasonExpiring, expectStatus: metav1.ConditionTrue},
+		{name: "expired", caBundle: caBundle, at: now.Add(13 * time.Hour), expectReason: WebhookCertReasonExpired, expectStatus: metav1.ConditionFalse},
+		{name: "ca rotated", caBundle: otherCABundle, at: now, expectReason: WebhookCertReasonUntrusted, expectStatus: metav1.ConditionFalse},
+		{name: "invalid ca bundle", caBundle: []byte("not a certificate"), at: now, expectReason: WebhookCertReasonUntrusted, expectStatus: metav1.ConditionFalse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, message, conditionStatus := checkWebhookCert([]*x509.Certificate{leaf}, tt.caBundle, testWebhookServerName, time.Hour, tt.at)
+			if reason != tt.expectReason || conditionStatus != tt.expectStatus {
+				t.Errorf("Expected %s/%s, got %s/%s: %s", tt.expectStatus, tt.expectReason, conditionStatus, reason, message)
+			}
+		})
+	}
+}
+
+func TestReconcileWebhookCert(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "test-ns")
+	notAfter := time.Now().Add(12 * time.Hour).Truncate(time.Second)
+	caBundle, leaf := newTestWebhookChain(t, notAfter)
+
+	tests := []struct {
+		name         string
+		caBundle     []byte
+		certs        *fakeWebhookCerts
+		expectReason string
+		expectStatus metav1.ConditionStatus
+		expectExpiry bool
+	}{
+		{
+			name:         "caBundle not published",
+			certs:        &fakeWebhookCerts{certs: []*x509.Certificate{leaf}},
+			expectReason: WebhookCertReasonUnavailable,
+			expectStatus: metav1.ConditionUnknown,
+		},
+		{
+			name:         "webhook unreachable",
+			caBundle:     caBundle,
+			certs:        &fakeWebhookCerts{err: errors.New("connection refused")},
+			expectReason: WebhookCertReasonUnavailable,
+			expectStatus: metav1.ConditionUnknown,
+		},
+		{
+			name:         "valid",
+			caBundle:     caBundle,
+			certs:        &fakeWebhookCerts{certs: []*x509.Certificate{leaf}},
+			expectReason: WebhookCertReasonValid,
+			expectStatus: metav1.ConditionTrue,
+			expectExpiry: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.GetStub = func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				obj.(*admissionregistrationv1.ValidatingWebhookConfiguration).Webhooks = []admissionregistrationv1.ValidatingWebhook{
+					{ClientConfig: admissionregistrationv1.WebhookClientConfig{CABundle: tt.caBundle}},
+				}
+				return nil
+			}
+			reconciler := newTestReconciler(fakeClient)
+			reconciler.webhookCerts = tt.certs
+			server := &v1alpha2.SpireServer{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}}
+			statusMgr := status.NewManager(fakeClient)
+
+			reconciler.reconcileWebhookCert(context.Background(), server, statusMgr)
+			cond, _ := statusMgr.GetCondition(WebhookCertValid)
+			if cond.Reason != tt.expectReason || cond.Status != tt.expectStatus {
+				t.Errorf("Expected %s/%s, got %s/%s: %s", tt.expectStatus, tt.expectReason, cond.Status, cond.Reason, cond.Message)
+			}
+			if tt.expectExpiry && (server.Status.WebhookCertificateExpiry == nil || !server.Status.WebhookCertificateExpiry.Time.Equal(notAfter)) {
+				t.Errorf("Expected the expiry %s to be recorded, got %v", notAfter, server.Status.WebhookCertificateExpiry)
+			}
+		})
+	}
+}
+
+func TestControllerManagerWebhookFailurePolicy(t *testing.T) {
+	if policy := controllerManagerWebhookFailurePolicy(nil); policy != admissionregistrationv1.Ignore {
+		t.Errorf("Expected Ignore by default, got %s", policy)
+	}
+	if policy := controllerManagerWebhookFailurePolicy(&v1alpha2.ControllerManagerWebhookConfig{FailurePolicy: "Fail"}); policy != admissionregistrationv1.Fail {
+		t.Errorf("Expected Fail, got %s", policy)
+	}
+}
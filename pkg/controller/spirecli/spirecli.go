@@ -0,0 +1,89 @@
+// Package spirecli runs the spire-server CLI in the SPIRE server pod. The CLI reaches the admin
+// API of the server over its socket, which is only available inside the pod, so the operator
+// runs it through the pods/exec subresource.
+package spirecli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+const (
+	// runTimeout bounds a single command, including the exec session
+	runTimeout = 30 * time.Second
+
+	serverPodName       = "spire-server-0"
+	serverContainerName = "spire-server"
+)
+
+// Runner runs spire-server CLI commands
+type Runner interface {
+	// Run runs the command in the SPIRE server of the namespace and returns its standard output
+	Run(ctx context.Context, namespace string, command []string) ([]byte, error)
+}
+
+// podExecRunner runs the commands in the SPIRE server container through pods/exec
+type podExecRunner struct {
+	restConfig *rest.Config
+	restClient rest.Interface
+}
+
+// NewPodExecRunner returns a Runner executing the commands in the SPIRE server pod
+func NewPodExecRunner(config *rest.Config) (Runner, error) {
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &podExecRunner{restConfig: config, restClient: clientset.CoreV1().RESTClient()}, nil
+}
+
+func (p *podExecRunner) Run(ctx context.Context, namespace string, command []string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, runTimeout)
+	defer cancel()
+
+	execURL := p.restClient.Post().Namespace(namespace).Resource("pods").Name(serverPodName).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: serverContainerName,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec).URL()
+	executor, err := newExecutor(p.restConfig, execURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exec in pod %s: %w", serverPodName, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: &stdout, Stderr: &stderr}); err != nil {
+		return nil, fmt.Errorf("%s failed: %w %s", strings.Join(command[:min(len(command), 3)], " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// newExecutor returns the executor of the exec session, over websockets and falling back to SPDY
+// when the API server does not upgrade the connection to websockets, as kubectl does
+func newExecutor(config *rest.Config, execURL *url.URL) (remotecommand.Executor, error) {
+	websocketExecutor, err := remotecommand.NewWebSocketExecutor(config, "GET", execURL.String())
+	if err != nil {
+		return nil, err
+	}
+	spdyExecutor, err := remotecommand.NewSPDYExecutor(config, "POST", execURL)
+	if err != nil {
+		return nil, err
+	}
+	return remotecommand.NewFallbackExecutor(websocketExecutor, spdyExecutor, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+}
@@ -0,0 +1,30 @@
+package status
+
+import (
+	"fmt"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// ReportSkippedArchitectures sets the ArchitecturesSkipped condition to True while pods are not
+// scheduled on some architectures because no operand image is available for them, and back to
+// False once every architecture is supported again. Nothing is reported when none was skipped.
+// Skipped architectures do not affect Ready: the pods run on every supported node.
+func (m *Manager) ReportSkippedArchitectures(skipped []string, existingConditions []metav1.Condition) {
+	if len(skipped) > 0 {
+		m.AddCondition(utils.ArchitecturesSkippedStatusType, utils.ArchitecturesReasonUnsupported,
+			fmt.Sprintf("Pods are not scheduled on nodes with architecture %s: no operand image is available", strings.Join(skipped, ", ")),
+			metav1.ConditionTrue)
+		return
+	}
+	existing := apimeta.FindStatusCondition(existingConditions, utils.ArchitecturesSkippedStatusType)
+	if existing != nil && existing.Status == metav1.ConditionTrue {
+		m.AddCondition(utils.ArchitecturesSkippedStatusType, utils.ArchitecturesReasonAllSupported,
+			"Pods are scheduled on every requested architecture",
+			metav1.ConditionFalse)
+	}
+}
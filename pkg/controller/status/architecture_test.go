@@ -0,0 +1,40 @@
+package status
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReportSkippedArchitectures(t *testing.T) {
+	m := NewManager(&fakes.FakeCustomCtrlClient{})
+	m.ReportSkippedArchitectures(nil, nil)
+	if _, ok := m.conditions[utils.ArchitecturesSkippedStatusType]; ok {
+		t.Error("Expected no ArchitecturesSkipped condition when no architecture was ever skipped")
+	}
+
+	m.ReportSkippedArchitectures([]string{"s390x"}, nil)
+	cond, ok := m.conditions[utils.ArchitecturesSkippedStatusType]
+	if !ok || cond.Status != metav1.ConditionTrue || cond.Reason != utils.ArchitecturesReasonUnsupported || !strings.Contains(cond.Message, "s390x") {
+		t.Errorf("Expected s390x to be reported as skipped, got %v", cond)
+	}
+	m.SetReadyCondition()
+	if m.conditions[v1alpha2.Ready].Status != metav1.ConditionTrue {
+		t.Error("Expected skipped architectures not to affect Ready")
+	}
+
+	m = NewManager(&fakes.FakeCustomCtrlClient{})
+	m.ReportSkippedArchitectures(nil, []metav1.Condition{{Type: utils.ArchitecturesSkippedStatusType, Status: metav1.ConditionTrue}})
+	cond, ok = m.conditions[utils.ArchitecturesSkippedStatusType]
+	if !ok || cond.Status != metav1.ConditionFalse || cond.Reason != utils.ArchitecturesReasonAllSupported {
+		t.Errorf("Expected ArchitecturesSkipped to be cleared, got %v", cond)
+	}
+	m.SetReadyCondition()
+	if m.conditions[v1alpha2.Ready].Status != metav1.ConditionTrue {
+		t.Error("Expected ArchitecturesSkipped=False not to affect Ready")
+	}
+}
@@ -0,0 +1,43 @@
+package status
+
+import (
+	"context"
+	"fmt"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// CheckConfigurationConflict cross-validates the trust domain of the ZeroTrustWorkloadIdentityManager
+// with the JWT issuers of the SpireServer and SpireOIDCDiscoveryProvider, and sets the
+// ConfigurationConflict condition to True while they are inconsistent. A conflict is returned as an
+// invalid configuration error, so the caller refuses to render its operand. The condition is only
+// cleared when it was previously set, and does not affect Ready.
+func (m *Manager) CheckConfigurationConflict(ctx context.Context, trustDomain string, existingConditions []metav1.Condition) error {
+	var server v1alpha2.SpireServer
+	if err := m.customClient.Get(ctx, types.NamespacedName{Name: "cluster"}, &server); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get SpireServer: %w", err)
+	}
+	var oidc v1alpha2.SpireOIDCDiscoveryProvider
+	if err := m.customClient.Get(ctx, types.NamespacedName{Name: "cluster"}, &oidc); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get SpireOIDCDiscoveryProvider: %w", err)
+	}
+
+	if err := utils.ValidateIdentityConsistency(trustDomain, server.Spec.JwtIssuer, oidc.Spec.JwtIssuer); err != nil {
+		m.AddCondition(utils.ConfigurationConflictStatusType, utils.ConfigurationConflictReasonDetected,
+			err.Error(),
+			metav1.ConditionTrue)
+		return utils.NewInvalidConfigurationError(err, "trust domain and JWT issuers conflict: %v", err)
+	}
+	if apimeta.FindStatusCondition(existingConditions, utils.ConfigurationConflictStatusType) != nil {
+		m.AddCondition(utils.ConfigurationConflictStatusType, utils.ConfigurationConflictReasonResolved,
+			"The trust domain and the JWT issuers are consistent",
+			metav1.ConditionFalse)
+	}
+	return nil
+}
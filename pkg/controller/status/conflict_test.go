@@ -0,0 +1,62 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func TestCheckConfigurationConflict(t *testing.T) {
+	tests := []struct {
+		name           string
+		oidcIssuer     string
+		existing       []metav1.Condition
+		expectConflict bool
+		expectedReason string
+	}{
+		{name: "consistent", oidcIssuer: "https://oidc.apps.example.com"},
+		{name: "mismatched issuers", oidcIssuer: "https://oidc.apps.other.com", expectConflict: true, expectedReason: utils.ConfigurationConflictReasonDetected},
+		{name: "resolved", oidcIssuer: "https://oidc.apps.example.com",
+			existing:       []metav1.Condition{{Type: utils.ConfigurationConflictStatusType, Status: metav1.ConditionTrue}},
+			expectedReason: utils.ConfigurationConflictReasonResolved},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.GetStub = func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				switch o := obj.(type) {
+				case *v1alpha2.SpireServer:
+					o.Spec.JwtIssuer = "https://oidc.apps.example.com"
+				case *v1alpha2.SpireOIDCDiscoveryProvider:
+					o.Spec.JwtIssuer = tt.oidcIssuer
+				}
+				return nil
+			}
+			mgr := NewManager(fakeClient)
+
+			err := mgr.CheckConfigurationConflict(context.Background(), "example.org", tt.existing)
+			if tt.expectConflict != (err != nil) {
+				t.Fatalf("Expected conflict %v, got %v", tt.expectConflict, err)
+			}
+			if err != nil && utils.ClassifyError(err) != utils.InvalidConfigurationError {
+				t.Errorf("Expected an invalid configuration error, got %v", err)
+			}
+			cond, ok := mgr.GetCondition(utils.ConfigurationConflictStatusType)
+			if tt.expectedReason == "" {
+				if ok {
+					t.Errorf("Expected no condition, got %v", cond)
+				}
+				return
+			}
+			if cond.Reason != tt.expectedReason {
+				t.Errorf("Expected reason %s, got %s", tt.expectedReason, cond.Reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package status
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+// progressMessageInterval is how long a status change limited to the message of a progressing
+// condition, e.g. "DaemonSet has 3/5 pods ready", is held back after the last status write
+const progressMessageInterval = 30 * time.Second
+
+// writeTracker remembers the status writes made for each object, so that reconciles triggered by
+// flapping operands do not each write the status
+type writeTracker struct {
+	mu sync.Mutex
+	// initialized is the generation the initial reconciliation status was written for
+	initialized map[types.UID]int64
+	// lastWrite is when the status was last written
+	lastWrite map[types.UID]time.Time
+	// uids is the UID tracked for each object by kind and name, to forget the object once it is
+	// deleted or replaced by an object of the same name
+	uids map[string]types.UID
+}
+
+var writes = newWriteTracker()
+
+func newWriteTracker() *writeTracker {
+	return &writeTracker{
+		initialized: map[types.UID]int64{},
+		lastWrite:   map[types.UID]time.Time{},
+		uids:        map[string]types.UID{},
+	}
+}
+
+// trackingKey identifies an object by kind and name
+func trackingKey(obj client.Object, key types.NamespacedName) string {
+	return fmt.Sprintf("%T/%s", obj, key)
+}
+
+// track records the UID of obj, forgetting the object it replaces. The caller holds the lock.
+func (w *writeTracker) track(obj client.Object) {
+	key := trackingKey(obj, client.ObjectKeyFromObject(obj))
+	if previous, ok := w.uids[key]; ok && previous != obj.GetUID() {
+		w.forgetUID(previous)
+	}
+	w.uids[key] = obj.GetUID()
+}
+
+// forgetUID drops the writes and failures tracked for an object. The caller holds the lock.
+func (w *writeTracker) forgetUID(uid types.UID) {
+	delete(w.initialized, uid)
+	delete(w.lastWrite, uid)
+	failures.forget(uid)
+}
+
+// Forget drops the status writes and failures tracked for the object of the kind of obj named
+// key, to be called once the object is not found or is being deleted
+func Forget(obj client.Object, key types.NamespacedName) {
+	writes.mu.Lock()
+	defer writes.mu.Unlock()
+	trackedKey := trackingKey(obj, key)
+	if uid, ok := writes.uids[trackedKey]; ok {
+		writes.forgetUID(uid)
+		delete(writes.uids, trackedKey)
+	}
+}
+
+// needsInitialStatus reports whether the initial reconciliation status is still to be written for
+// the generation of obj. It is written once per generation, and once after the operator starts.
+func (w *writeTracker) needsInitialStatus(obj client.Object) bool {
+	if obj.GetUID() == "" {
+		return true
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	generation, ok := w.initialized[obj.GetUID()]
+	return !ok || generation != obj.GetGeneration()
+}
+
+func (w *writeTracker) initialStatusWritten(obj client.Object) {
+	if obj.GetUID() == "" {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.track(obj)
+	w.initialized[obj.GetUID()] = obj.GetGeneration()
+}
+
+// holdBackFor returns how long a status change limited to progress messages can still wait, the
+// status of obj having been written less than progressMessageInterval ago
+func (w *writeTracker) holdBackFor(obj client.Object, now time.Time) time.Duration {
+	if obj.GetUID() == "" {
+		return 0
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	last, ok := w.lastWrite[obj.GetUID()]
+	if !ok || now.Sub(last) >= progressMessageInterval {
+		return 0
+	}
+	return progressMessageInterval - now.Sub(last)
+}
+
+func (w *writeTracker) written(obj client.Object, now time.Time) {
+	if obj.GetUID() == "" {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.track(obj)
+	w.lastWrite[obj.GetUID()] = now
+}
+
+// onlyProgressMessagesChanged reports whether the statuses differ only in the message of
+// progressing conditions: the version, and the type, status and reason of every condition are
+// unchanged
+func onlyProgressMessagesChanged(original, updated *v1alpha2.ConditionalStatus) bool {
+	if len(original.Conditions) != len(updated.Conditions) || original.Version != updated.Version {
+		return false
+	}
+	existing := make(map[string]metav1.Condition, len(original.Conditions))
+	for _, cond := range original.Conditions {
+		existing[cond.Type] = cond
+	}
+	for _, cond := range updated.Conditions {
+		prev, ok := existing[cond.Type]
+		if !ok || prev.Status != cond.Status || prev.Reason != cond.Reason || prev.ObservedGeneration != cond.ObservedGeneration {
+			return false
+		}
+		if prev.Message != cond.Message && !isProgressing(cond) {
+			return false
+		}
+	}
+	return true
+}
+
+// isProgressing tells whether the condition reports a rollout in progress
+func isProgressing(cond metav1.Condition) bool {
+	return cond.Status == metav1.ConditionFalse && (progressingReasons[cond.Reason] || cond.Reason == v1alpha2.ReasonInProgress)
+}
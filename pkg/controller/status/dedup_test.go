@@ -0,0 +1,157 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+)
+
+// resetWrites gives the test a tracker without recorded writes
+func resetWrites(t *testing.T) {
+	t.Helper()
+	previous := writes
+	writes = newWriteTracker()
+	t.Cleanup(func() { writes = previous })
+}
+
+func TestSetInitialReconciliationStatus_OncePerGeneration(t *testing.T) {
+	resetWrites(t)
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	obj := &v1alpha2.SpireAgent{ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "initial-status-uid", Generation: 1}}
+	getStatus := func() *v1alpha2.ConditionalStatus { return &obj.Status.ConditionalStatus }
+	markReady := func() {
+		obj.Status.Conditions = []metav1.Condition{{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady}}
+	}
+
+	SetInitialReconciliationStatus(context.Background(), fakeClient, obj, getStatus, "SpireAgent")
+	markReady()
+	SetInitialReconciliationStatus(context.Background(), fakeClient, obj, getStatus, "SpireAgent")
+	if fakeClient.StatusUpdateWithRetryCallCount() != 1 {
+		t.Errorf("Expected the initial status to be written once for a generation, got %d writes", fakeClient.StatusUpdateWithRetryCallCount())
+	}
+
+	obj.Generation = 2
+	SetInitialReconciliationStatus(context.Background(), fakeClient, obj, getStatus, "SpireAgent")
+	if fakeClient.StatusUpdateWithRetryCallCount() != 2 {
+		t.Errorf("Expected the initial status to be written for a new generation, got %d writes", fakeClient.StatusUpdateWithRetryCallCount())
+	}
+}
+
+func TestApplyStatus_HoldsBackProgressMessages(t *testing.T) {
+	resetWrites(t)
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	obj := &v1alpha2.SpireAgent{ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "progress-uid"}}
+	getStatus := func() *v1alpha2.ConditionalStatus { return &obj.Status.ConditionalStatus }
+	apply := func(reason, message string, status metav1.ConditionStatus) *Manager {
+		mgr := NewManager(fakeClient)
+		mgr.AddCondition("DaemonSetAvailable", reason, message, status)
+		if err := mgr.ApplyStatus(context.Background(), obj, getStatus); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return mgr
+	}
+
+	if result := apply("DaemonSetNotReady", "DaemonSet has 3/5 pods ready", metav1.ConditionFalse).RequeueHeldBack(ctrl.Result{}, nil); result.RequeueAfter != 0 {
+		t.Errorf("Expected no requeue after a status write, got %v", result.RequeueAfter)
+	}
+	mgr := apply("DaemonSetNotReady", "DaemonSet has 4/5 pods ready", metav1.ConditionFalse)
+	if fakeClient.StatusUpdateWithRetryCallCount() != 1 {
+		t.Errorf("Expected a progress message change right after a write to be held back, got %d writes", fakeClient.StatusUpdateWithRetryCallCount())
+	}
+	if result := mgr.RequeueHeldBack(ctrl.Result{}, nil); result.RequeueAfter <= 0 || result.RequeueAfter > progressMessageInterval {
+		t.Errorf("Expected a requeue once the hold-back window ends, got %v", result.RequeueAfter)
+	}
+	if result := mgr.RequeueHeldBack(ctrl.Result{RequeueAfter: time.Second}, nil); result.RequeueAfter != time.Second {
+		t.Errorf("Expected an earlier requeue to be kept, got %v", result.RequeueAfter)
+	}
+	if result := mgr.RequeueHeldBack(ctrl.Result{}, errors.New("failed")); result.RequeueAfter != 0 {
+		t.Errorf("Expected no requeue along with an error, got %v", result.RequeueAfter)
+	}
+	if cond := apimeta.FindStatusCondition(obj.Status.Conditions, "DaemonSetAvailable"); cond.Message != "DaemonSet has 3/5 pods ready" {
+		t.Errorf("Expected the held back status to be left as written, got %q", cond.Message)
+	}
+
+	apply("DaemonSetReady", "DaemonSet is healthy", metav1.ConditionTrue)
+	if fakeClient.StatusUpdateWithRetryCallCount() != 2 {
+		t.Errorf("Expected a status change to be written right away, got %d writes", fakeClient.StatusUpdateWithRetryCallCount())
+	}
+}
+
+func TestOnlyProgressMessagesChanged(t *testing.T) {
+	progressing := func(message string) *v1alpha2.ConditionalStatus {
+		return &v1alpha2.ConditionalStatus{Conditions: []metav1.Condition{
+			{Type: "DaemonSetAvailable", Status: metav1.ConditionFalse, Reason: "DaemonSetNotReady", Message: message},
+		}}
+	}
+	failed := func(message string) *v1alpha2.ConditionalStatus {
+		return &v1alpha2.ConditionalStatus{Conditions: []metav1.Condition{
+			{Type: "DaemonSetAvailable", Status: metav1.ConditionFalse, Reason: "DaemonSetNotFound", Message: message},
+		}}
+	}
+
+	if !onlyProgressMessagesChanged(progressing("3/5"), progressing("4/5")) {
+		t.Error("Expected a progress message change to be detected")
+	}
+	if onlyProgressMessagesChanged(failed("a"), failed("b")) {
+		t.Error("Expected a failure message change to be written")
+	}
+	if onlyProgressMessagesChanged(progressing("3/5"), failed("3/5")) {
+		t.Error("Expected a reason change to be written")
+	}
+}
+
+func TestWriteTracker_HoldBack(t *testing.T) {
+	resetWrites(t)
+	obj := &v1alpha2.SpireAgent{ObjectMeta: metav1.ObjectMeta{UID: "hold-back-uid"}}
+	now := time.Now()
+	if writes.holdBackFor(obj, now) != 0 {
+		t.Error("Expected nothing to be held back before the first write")
+	}
+	writes.written(obj, now)
+	if held := writes.holdBackFor(obj, now.Add(progressMessageInterval/3)); held != progressMessageInterval*2/3 {
+		t.Errorf("Expected progress messages to be held back for the rest of the interval, got %v", held)
+	}
+	if writes.holdBackFor(obj, now.Add(progressMessageInterval)) != 0 {
+		t.Error("Expected progress messages to be written once the interval elapsed")
+	}
+}
+
+func TestForget(t *testing.T) {
+	resetWrites(t)
+	now := time.Now()
+	obj := &v1alpha2.SpireAgent{ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "forget-uid", Generation: 1}}
+	writes.initialStatusWritten(obj)
+	writes.written(obj, now)
+
+	// An object recreated under the same name replaces the tracked one
+	recreated := obj.DeepCopy()
+	recreated.UID = "recreated-uid"
+	writes.written(recreated, now)
+	if _, ok := writes.lastWrite[obj.UID]; ok {
+		t.Error("Expected the writes of the replaced object to be forgotten")
+	}
+	if _, ok := writes.initialized[obj.UID]; ok {
+		t.Error("Expected the initial status of the replaced object to be forgotten")
+	}
+
+	Forget(&v1alpha2.SpireAgent{}, types.NamespacedName{Name: "cluster"})
+	if len(writes.lastWrite) != 0 || len(writes.initialized) != 0 || len(writes.uids) != 0 {
+		t.Errorf("Expected the deleted object to be forgotten, got %v %v %v", writes.lastWrite, writes.initialized, writes.uids)
+	}
+
+	// Objects of other kinds with the same name are kept
+	writes.written(obj, now)
+	Forget(&v1alpha2.SpireServer{}, types.NamespacedName{Name: "cluster"})
+	if _, ok := writes.lastWrite[obj.UID]; !ok {
+		t.Error("Expected the writes of an object of another kind to be kept")
+	}
+}
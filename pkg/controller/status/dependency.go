@@ -0,0 +1,76 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// WaitForSpireServer decides whether the creation of the workload of an operand depending on the
+// SPIRE server must wait for the SpireServer to be Ready, e.g. so the agents do not crash-loop
+// against a server which does not exist yet during the initial install. Once the workload exists
+// it is reconciled regardless of the server, so an outage of the server does not hold back
+// changes to the operand. While waiting, the WaitingForDependency condition reports what the
+// operand waits for and Ready is False. It returns true when the reconcile must wait.
+func (m *Manager) WaitForSpireServer(ctx context.Context, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager, workload client.Object, existingConditions []metav1.Condition) (bool, error) {
+	if !ptr.Deref(ztwim.Spec.WaitForSpireServer, true) {
+		m.clearWaitingForDependency(existingConditions, utils.WaitingForDependencyReasonGateDisabled,
+			"Waiting for the SpireServer is disabled")
+		return false, nil
+	}
+
+	kind := reflect.Indirect(reflect.ValueOf(workload)).Type().Name()
+	if err := m.customClient.Get(ctx, client.ObjectKeyFromObject(workload), workload); err == nil {
+		m.clearWaitingForDependency(existingConditions, utils.WaitingForDependencyReasonReady,
+			fmt.Sprintf("%s %s exists", kind, workload.GetName()))
+		return false, nil
+	} else if !kerrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get %s %s: %w", kind, workload.GetName(), err)
+	}
+
+	var server v1alpha2.SpireServer
+	if err := m.customClient.Get(ctx, types.NamespacedName{Name: "cluster"}, &server); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get SpireServer: %w", err)
+		}
+		m.waitForDependency(utils.WaitingForDependencyReasonNotFound,
+			fmt.Sprintf("Waiting for the SpireServer to be created before creating %s %s", kind, workload.GetName()))
+		return true, nil
+	}
+	ready := apimeta.FindStatusCondition(server.Status.Conditions, v1alpha2.Ready)
+	if ready == nil || ready.Status != metav1.ConditionTrue {
+		message := fmt.Sprintf("Waiting for the SpireServer to be Ready before creating %s %s", kind, workload.GetName())
+		if ready != nil && ready.Message != "" {
+			message = fmt.Sprintf("%s: %s", message, ready.Message)
+		}
+		m.waitForDependency(utils.WaitingForDependencyReasonNotReady, message)
+		return true, nil
+	}
+
+	m.clearWaitingForDependency(existingConditions, utils.WaitingForDependencyReasonReady, "SpireServer is Ready")
+	return false, nil
+}
+
+// waitForDependency sets WaitingForDependency, and Ready to False as the operand is not deployed
+func (m *Manager) waitForDependency(reason, message string) {
+	m.AddCondition(utils.WaitingForDependencyStatusType, reason, message, metav1.ConditionTrue)
+	m.AddCondition(v1alpha2.Ready, utils.WaitingForDependencyStatusType, message, metav1.ConditionFalse)
+}
+
+// clearWaitingForDependency only reports the end of the wait if the operand previously waited
+func (m *Manager) clearWaitingForDependency(existingConditions []metav1.Condition, reason, message string) {
+	existingCondition := apimeta.FindStatusCondition(existingConditions, utils.WaitingForDependencyStatusType)
+	if existingCondition != nil && existingCondition.Status == metav1.ConditionTrue {
+		m.AddCondition(utils.WaitingForDependencyStatusType, reason, message, metav1.ConditionFalse)
+	}
+}
@@ -0,0 +1,113 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func TestWaitForSpireServer(t *testing.T) {
+	waiting := []metav1.Condition{{Type: utils.WaitingForDependencyStatusType, Status: metav1.ConditionTrue}}
+	tests := []struct {
+		name           string
+		gate           *bool
+		workloadExists bool
+		server         *v1alpha2.SpireServer
+		existing       []metav1.Condition
+		expectWait     bool
+		expectReason   string
+		expectStatus   metav1.ConditionStatus
+	}{
+		{
+			name:         "server not created",
+			expectWait:   true,
+			expectReason: utils.WaitingForDependencyReasonNotFound,
+			expectStatus: metav1.ConditionTrue,
+		},
+		{
+			name:         "server not ready",
+			server:       &v1alpha2.SpireServer{},
+			expectWait:   true,
+			expectReason: utils.WaitingForDependencyReasonNotReady,
+			expectStatus: metav1.ConditionTrue,
+		},
+		{
+			name: "server ready",
+			server: &v1alpha2.SpireServer{Status: v1alpha2.SpireServerStatus{ConditionalStatus: v1alpha2.ConditionalStatus{
+				Conditions: []metav1.Condition{{Type: v1alpha2.Ready, Status: metav1.ConditionTrue}},
+			}}},
+			existing:     waiting,
+			expectReason: utils.WaitingForDependencyReasonReady,
+			expectStatus: metav1.ConditionFalse,
+		},
+		{
+			name:           "workload already created",
+			workloadExists: true,
+		},
+		{
+			name:         "gate disabled",
+			gate:         ptr.To(false),
+			existing:     waiting,
+			expectReason: utils.WaitingForDependencyReasonGateDisabled,
+			expectStatus: metav1.ConditionFalse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				switch o := obj.(type) {
+				case *appsv1.DaemonSet:
+					if tt.workloadExists {
+						return nil
+					}
+					return kerrors.NewNotFound(appsv1.Resource("daemonsets"), key.Name)
+				case *v1alpha2.SpireServer:
+					if tt.server == nil {
+						return kerrors.NewNotFound(v1alpha2.GroupVersion.WithResource("spireservers").GroupResource(), key.Name)
+					}
+					tt.server.DeepCopyInto(o)
+				}
+				return nil
+			}
+			ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{WaitForSpireServer: tt.gate}}
+			workload := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "spire-agent", Namespace: "test-ns"}}
+
+			mgr := NewManager(fakeClient)
+			wait, err := mgr.WaitForSpireServer(context.Background(), ztwim, workload, tt.existing)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if wait != tt.expectWait {
+				t.Errorf("Expected wait %v, got %v", tt.expectWait, wait)
+			}
+			cond, ok := mgr.GetCondition(utils.WaitingForDependencyStatusType)
+			if tt.expectReason == "" {
+				if ok {
+					t.Errorf("Expected no condition, got %v", cond)
+				}
+				return
+			}
+			if cond.Reason != tt.expectReason || cond.Status != tt.expectStatus {
+				t.Errorf("Expected %s/%s, got %s/%s", tt.expectStatus, tt.expectReason, cond.Status, cond.Reason)
+			}
+			ready, readySet := mgr.GetCondition(v1alpha2.Ready)
+			if tt.expectWait && (ready.Status != metav1.ConditionFalse || ready.Reason != utils.WaitingForDependencyStatusType) {
+				t.Errorf("Expected Ready False while waiting, got %v", ready)
+			}
+			if !tt.expectWait && readySet {
+				t.Errorf("Expected Ready to be left to the other conditions, got %v", ready)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package status
+
+import (
+	"fmt"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// maxDryRunMessageLength keeps the DryRun condition message within the condition message limit
+const maxDryRunMessageLength = 32768
+
+// ReportDryRun publishes the result of a dry-run reconcile: the changes that would have been
+// applied, or the error that stopped the computation. Ready is held at Progressing while changes
+// are pending, since the desired state has not been applied.
+func (m *Manager) ReportDryRun(changes []string, err error) {
+	switch {
+	case err != nil:
+		m.AddCondition(utils.DryRunStatusType, utils.DryRunReasonFailed,
+			fmt.Sprintf("Failed to compute pending changes: %v", err),
+			metav1.ConditionTrue)
+		m.AddCondition(v1alpha2.Ready, v1alpha2.ReasonFailed,
+			fmt.Sprintf("Dry run failed: %v", err),
+			metav1.ConditionFalse)
+	case len(changes) == 0:
+		m.AddCondition(utils.DryRunStatusType, utils.DryRunReasonNoChanges,
+			"Dry run: no pending changes",
+			metav1.ConditionTrue)
+	default:
+		message := fmt.Sprintf("Dry run: %d pending changes: %s", len(changes), strings.Join(changes, "; "))
+		if len(message) > maxDryRunMessageLength {
+			message = message[:maxDryRunMessageLength-3] + "..."
+		}
+		m.AddCondition(utils.DryRunStatusType, utils.DryRunReasonPending, message, metav1.ConditionTrue)
+		m.AddCondition(v1alpha2.Ready, v1alpha2.ReasonInProgress,
+			fmt.Sprintf("Dry run: %d pending changes are not applied", len(changes)),
+			metav1.ConditionFalse)
+	}
+}
+
+// ClearDryRun sets the DryRun condition to False once the dry-run annotation has been removed.
+// Nothing is reported when dry-run was never enabled.
+func (m *Manager) ClearDryRun(existingConditions []metav1.Condition) {
+	existing := apimeta.FindStatusCondition(existingConditions, utils.DryRunStatusType)
+	if existing != nil && existing.Status == metav1.ConditionTrue {
+		m.AddCondition(utils.DryRunStatusType, utils.DryRunReasonDisabled,
+			"Dry run is disabled: changes are applied",
+			metav1.ConditionFalse)
+	}
+}
@@ -0,0 +1,91 @@
+package status
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReportDryRun(t *testing.T) {
+	tests := []struct {
+		name          string
+		changes       []string
+		err           error
+		expectReason  string
+		expectReady   *metav1.ConditionStatus
+		expectMessage string
+	}{
+		{
+			name:         "no pending changes",
+			expectReason: utils.DryRunReasonNoChanges,
+		},
+		{
+			name:          "pending changes",
+			changes:       []string{"create ConfigMap ns/spire-agent", "update DaemonSet ns/spire-agent"},
+			expectReason:  utils.DryRunReasonPending,
+			expectReady:   ptrTo(metav1.ConditionFalse),
+			expectMessage: "2 pending changes: create ConfigMap ns/spire-agent; update DaemonSet ns/spire-agent",
+		},
+		{
+			name:          "dry run failed",
+			err:           errors.New("boom"),
+			expectReason:  utils.DryRunReasonFailed,
+			expectReady:   ptrTo(metav1.ConditionFalse),
+			expectMessage: "boom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewManager(&fakes.FakeCustomCtrlClient{})
+			m.ReportDryRun(tt.changes, tt.err)
+
+			cond, ok := m.conditions[utils.DryRunStatusType]
+			if !ok {
+				t.Fatal("Expected DryRun condition to be set")
+			}
+			if cond.Status != metav1.ConditionTrue || cond.Reason != tt.expectReason {
+				t.Errorf("Expected True/%s, got %s/%s", tt.expectReason, cond.Status, cond.Reason)
+			}
+			if !strings.Contains(cond.Message, tt.expectMessage) {
+				t.Errorf("Expected message to contain %q, got %q", tt.expectMessage, cond.Message)
+			}
+			ready, ok := m.conditions[v1alpha2.Ready]
+			if tt.expectReady == nil {
+				if ok {
+					t.Errorf("Expected Ready to be left to aggregation, got %v", ready)
+				}
+			} else if !ok || ready.Status != *tt.expectReady {
+				t.Errorf("Expected Ready %s, got %v", *tt.expectReady, ready)
+			}
+		})
+	}
+}
+
+func TestClearDryRun(t *testing.T) {
+	m := NewManager(&fakes.FakeCustomCtrlClient{})
+	m.ClearDryRun(nil)
+	if _, ok := m.conditions[utils.DryRunStatusType]; ok {
+		t.Error("Expected no DryRun condition when dry run was never enabled")
+	}
+
+	m.ClearDryRun([]metav1.Condition{{Type: utils.DryRunStatusType, Status: metav1.ConditionTrue}})
+	cond, ok := m.conditions[utils.DryRunStatusType]
+	if !ok || cond.Status != metav1.ConditionFalse || cond.Reason != utils.DryRunReasonDisabled {
+		t.Errorf("Expected DryRun to be cleared, got %v", cond)
+	}
+
+	m.SetReadyCondition()
+	if m.conditions[v1alpha2.Ready].Status != metav1.ConditionTrue {
+		t.Error("Expected DryRun=False not to affect Ready")
+	}
+}
+
+func ptrTo(s metav1.ConditionStatus) *metav1.ConditionStatus {
+	return &s
+}
@@ -0,0 +1,36 @@
+package status
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// ReportExperimentalFlags sets the UnsupportedConfiguration condition to True while experimental
+// SPIRE options are applied, and to False when they are requested without the ExperimentalFlags
+// unsupported feature enabled or once they are removed. Nothing is reported when none was ever set.
+// The condition does not affect Ready.
+func (m *Manager) ReportExperimentalFlags(flags map[string]string, existingConditions []metav1.Condition) {
+	names := strings.Join(slices.Sorted(maps.Keys(flags)), ", ")
+	switch {
+	case len(flags) > 0 && utils.IsUnsupportedFeatureEnabled(utils.UnsupportedFeatureExperimentalFlags):
+		m.AddCondition(utils.UnsupportedConfigurationStatusType, utils.UnsupportedConfigurationReasonApplied,
+			fmt.Sprintf("Experimental options %s are applied: this configuration is not supported", names),
+			metav1.ConditionTrue)
+	case len(flags) > 0:
+		m.AddCondition(utils.UnsupportedConfigurationStatusType, utils.UnsupportedConfigurationReasonNotEnabled,
+			fmt.Sprintf("Experimental options %s are ignored: %s is not listed in %s", names,
+				utils.UnsupportedFeatureExperimentalFlags, utils.UnsupportedAddonFeaturesEnv),
+			metav1.ConditionFalse)
+	case apimeta.FindStatusCondition(existingConditions, utils.UnsupportedConfigurationStatusType) != nil:
+		m.AddCondition(utils.UnsupportedConfigurationStatusType, utils.UnsupportedConfigurationReasonNoneRequested,
+			"No experimental options are set",
+			metav1.ConditionFalse)
+	}
+}
@@ -0,0 +1,52 @@
+package status
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func TestReportExperimentalFlags(t *testing.T) {
+	flags := map[string]string{"events_based_cache": "true"}
+	tests := []struct {
+		name           string
+		flags          map[string]string
+		features       string
+		existing       []metav1.Condition
+		expectedReason string
+	}{
+		{name: "applied", flags: flags, features: utils.UnsupportedFeatureExperimentalFlags, expectedReason: utils.UnsupportedConfigurationReasonApplied},
+		{name: "not enabled", flags: flags, expectedReason: utils.UnsupportedConfigurationReasonNotEnabled},
+		{name: "removed", existing: []metav1.Condition{{Type: utils.UnsupportedConfigurationStatusType, Status: metav1.ConditionTrue}},
+			expectedReason: utils.UnsupportedConfigurationReasonNoneRequested},
+		{name: "never set"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(utils.UnsupportedAddonFeaturesEnv, tt.features)
+			mgr := NewManager(&fakes.FakeCustomCtrlClient{})
+			mgr.ReportExperimentalFlags(tt.flags, tt.existing)
+
+			cond, ok := mgr.GetCondition(utils.UnsupportedConfigurationStatusType)
+			if tt.expectedReason == "" {
+				if ok {
+					t.Errorf("Expected no condition, got %v", cond)
+				}
+				return
+			}
+			if cond.Reason != tt.expectedReason {
+				t.Errorf("Expected reason %s, got %s", tt.expectedReason, cond.Reason)
+			}
+
+			// The condition is informational and never fails Ready
+			mgr.SetReadyCondition()
+			if ready, _ := mgr.GetCondition(v1alpha2.Ready); ready.Status != metav1.ConditionTrue {
+				t.Errorf("Expected Ready to stay True, got %v", ready)
+			}
+		})
+	}
+}
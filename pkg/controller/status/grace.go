@@ -0,0 +1,94 @@
+package status
+
+import (
+	"sync"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// transientReasons are the reasons of a failed condition that an unreachable API server may
+// cause, held back during the status grace period
+var transientReasons = map[string]bool{
+	utils.DegradedReasonTransientError: true,
+	"StatefulSetNotFound":              true,
+	"DaemonSetNotFound":                true,
+	"DeploymentNotFound":               true,
+	"SpireServerStatefulSetGetFailed":  true,
+	"SpireAgentDaemonSetGetFailed":     true,
+	"SpiffeCSIDaemonSetGetFailed":      true,
+	"SpireOIDCDeploymentGetFailed":     true,
+	"ImageCheckFailed":                 true,
+}
+
+// failureTracker remembers since when each condition of an object has failed for a transient reason
+type failureTracker struct {
+	mu    sync.Mutex
+	since map[types.UID]map[string]time.Time
+}
+
+var failures = &failureTracker{since: map[types.UID]map[string]time.Time{}}
+
+// track records the conditions of obj failing for a transient reason, forgetting the others,
+// and returns since when each of them has failed
+func (f *failureTracker) track(obj client.Object, failing []string, now time.Time) map[string]time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	since := make(map[string]time.Time, len(failing))
+	for _, condType := range failing {
+		since[condType] = now
+		if started, ok := f.since[obj.GetUID()][condType]; ok {
+			since[condType] = started
+		}
+	}
+	f.since[obj.GetUID()] = since
+	return since
+}
+
+// forget drops the failures tracked for the object of the UID
+func (f *failureTracker) forget(uid types.UID) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.since, uid)
+}
+
+// SetGracePeriod sets how long transient failures of conditions which were healthy are held
+// back, none when nil
+func (m *Manager) SetGracePeriod(gracePeriod *metav1.Duration) {
+	if gracePeriod != nil {
+		m.gracePeriod = gracePeriod.Duration
+	}
+}
+
+// holdTransientFailures keeps the healthy conditions of the status in place of the transient
+// failures collected during this reconcile, until the failures outlast the grace period. A
+// Degraded condition absent from the status counts as healthy.
+func (m *Manager) holdTransientFailures(obj client.Object, existingConditions []metav1.Condition, now time.Time) {
+	if m.gracePeriod <= 0 || obj.GetUID() == "" {
+		return
+	}
+	var failing []string
+	for condType, cond := range m.conditions {
+		if isFailure(cond) && transientReasons[cond.Reason] {
+			failing = append(failing, condType)
+		}
+	}
+	for condType, since := range failures.track(obj, failing, now) {
+		if now.Sub(since) >= m.gracePeriod {
+			continue
+		}
+		existing := apimeta.FindStatusCondition(existingConditions, condType)
+		switch {
+		case existing == nil && condType == v1alpha2.Degraded:
+			delete(m.conditions, condType)
+		case existing != nil && !isFailure(Condition{Type: existing.Type, Status: existing.Status, Reason: existing.Reason}):
+			m.conditions[condType] = Condition{Type: existing.Type, Status: existing.Status, Reason: existing.Reason, Message: existing.Message}
+		}
+	}
+}
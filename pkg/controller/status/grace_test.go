@@ -0,0 +1,62 @@
+package status
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func TestHoldTransientFailures(t *testing.T) {
+	previous := failures
+	failures = &failureTracker{since: map[types.UID]map[string]time.Time{}}
+	t.Cleanup(func() { failures = previous })
+
+	obj := &v1alpha2.SpireAgent{ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "grace-uid"}}
+	existing := []metav1.Condition{{Type: "DaemonSetAvailable", Status: metav1.ConditionTrue, Reason: "DaemonSetReady", Message: "DaemonSet is healthy"}}
+	start := time.Now()
+	hold := func(now time.Time) *Manager {
+		mgr := NewManager(&fakes.FakeCustomCtrlClient{})
+		mgr.SetGracePeriod(&metav1.Duration{Duration: 5 * time.Minute})
+		mgr.AddCondition("DaemonSetAvailable", "DaemonSetNotFound", "Failed to get DaemonSet: connection refused", metav1.ConditionFalse)
+		mgr.AddCondition("ConfigurationValid", "InvalidAvailability", "availability target too short", metav1.ConditionFalse)
+		mgr.SetDegradedCondition(utils.NewRetryRequiredError(errors.New("connection refused"), "failed to apply"), existing)
+		mgr.holdTransientFailures(obj, existing, now)
+		return mgr
+	}
+
+	mgr := hold(start)
+	if cond, _ := mgr.GetCondition("DaemonSetAvailable"); cond.Status != metav1.ConditionTrue {
+		t.Errorf("Expected the healthy condition kept within the grace period, got %v", cond)
+	}
+	if cond, ok := mgr.GetCondition(v1alpha2.Degraded); ok {
+		t.Errorf("Expected the transient Degraded condition held back, got %v", cond)
+	}
+	if cond, _ := mgr.GetCondition("ConfigurationValid"); cond.Status != metav1.ConditionFalse {
+		t.Errorf("Expected a configuration failure reported immediately, got %v", cond)
+	}
+
+	mgr = hold(start.Add(5 * time.Minute))
+	if cond, _ := mgr.GetCondition("DaemonSetAvailable"); cond.Reason != "DaemonSetNotFound" {
+		t.Errorf("Expected the failure reported once it outlasted the grace period, got %v", cond)
+	}
+	if cond, ok := mgr.GetCondition(v1alpha2.Degraded); !ok || cond.Reason != utils.DegradedReasonTransientError {
+		t.Errorf("Expected the Degraded condition reported once it outlasted the grace period, got %v", cond)
+	}
+
+	// A recovery restarts the grace period
+	mgr = NewManager(&fakes.FakeCustomCtrlClient{})
+	mgr.SetGracePeriod(&metav1.Duration{Duration: 5 * time.Minute})
+	mgr.AddCondition("DaemonSetAvailable", "DaemonSetReady", "DaemonSet is healthy", metav1.ConditionTrue)
+	mgr.holdTransientFailures(obj, existing, start.Add(6*time.Minute))
+	mgr = hold(start.Add(7 * time.Minute))
+	if cond, _ := mgr.GetCondition("DaemonSetAvailable"); cond.Status != metav1.ConditionTrue {
+		t.Errorf("Expected a new failure held back after a recovery, got %v", cond)
+	}
+}
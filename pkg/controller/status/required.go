@@ -0,0 +1,48 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// CheckRequiredResources fails Ready when a resource the operand cannot run without does not
+// exist, e.g. a ConfigMap deleted by hand while the cache still held it. It is meant for
+// create-only mode, where the conditions of the resources are not refreshed by updates and would
+// otherwise report Ready. The resources are read from the API server, and a Warning event is
+// emitted on the owner when the missing resources change. It returns the missing resources.
+func (m *Manager) CheckRequiredResources(ctx context.Context, recorder record.EventRecorder, owner client.Object, existingConditions []metav1.Condition, required ...client.Object) []string {
+	var missing []string
+	for _, obj := range required {
+		kind := reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
+		if err := m.customClient.GetUncached(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			if !kerrors.IsNotFound(err) {
+				log.FromContext(ctx).Error(err, "failed to check required resource", "kind", kind, "name", obj.GetName())
+				continue
+			}
+			missing = append(missing, kind+" "+obj.GetName())
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	message := fmt.Sprintf("Required resources missing in create-only mode: %s", strings.Join(missing, ", "))
+	m.AddCondition(v1alpha2.Ready, utils.RequiredResourcesMissing, message, metav1.ConditionFalse)
+	if existing := apimeta.FindStatusCondition(existingConditions, v1alpha2.Ready); existing == nil || existing.Reason != utils.RequiredResourcesMissing || existing.Message != message {
+		recorder.Event(owner, corev1.EventTypeWarning, utils.RequiredResourcesMissing, message)
+	}
+	return missing
+}
@@ -0,0 +1,70 @@
+package status
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func TestCheckRequiredResources(t *testing.T) {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	fakeClient.GetUncachedStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		if _, ok := obj.(*corev1.ConfigMap); ok && key.Name == "spire-agent" {
+			return kerrors.NewNotFound(corev1.Resource("configmaps"), key.Name)
+		}
+		return nil
+	}
+	owner := &v1alpha2.SpireAgent{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}}
+	required := func() []client.Object {
+		return []client.Object{
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "spire-agent", Namespace: "test-ns"}},
+			&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "spire-agent", Namespace: "test-ns"}},
+		}
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	mgr := NewManager(fakeClient)
+	mgr.AddCondition("DaemonSetAvailable", "DaemonSetReady", "ready", metav1.ConditionTrue)
+	missing := mgr.CheckRequiredResources(context.Background(), recorder, owner, nil, required()...)
+	if len(missing) != 1 || missing[0] != "ConfigMap spire-agent" {
+		t.Fatalf("Expected the ConfigMap to be missing, got %v", missing)
+	}
+	ready, _ := mgr.GetCondition(v1alpha2.Ready)
+	if ready.Status != metav1.ConditionFalse || ready.Reason != utils.RequiredResourcesMissing || !strings.Contains(ready.Message, "ConfigMap spire-agent") {
+		t.Errorf("Expected Ready to fail on the missing ConfigMap, got %v", ready)
+	}
+	if len(recorder.Events) != 1 {
+		t.Fatalf("Expected a Warning event, got %d events", len(recorder.Events))
+	}
+	if event := <-recorder.Events; !strings.HasPrefix(event, corev1.EventTypeWarning+" "+utils.RequiredResourcesMissing) {
+		t.Errorf("Unexpected event %q", event)
+	}
+
+	// The event is not repeated while the same resources stay missing
+	existing := []metav1.Condition{{Type: v1alpha2.Ready, Status: metav1.ConditionFalse, Reason: ready.Reason, Message: ready.Message}}
+	NewManager(fakeClient).CheckRequiredResources(context.Background(), recorder, owner, existing, required()...)
+	if len(recorder.Events) != 0 {
+		t.Errorf("Expected no new event, got %d", len(recorder.Events))
+	}
+
+	fakeClient.GetUncachedReturns(nil)
+	fakeClient.GetUncachedStub = nil
+	mgr = NewManager(fakeClient)
+	if missing := mgr.CheckRequiredResources(context.Background(), recorder, owner, existing, required()...); missing != nil {
+		t.Errorf("Expected no missing resource, got %v", missing)
+	}
+	if ready, ok := mgr.GetCondition(v1alpha2.Ready); ok {
+		t.Errorf("Expected Ready to be left to the other conditions, got %v", ready)
+	}
+}
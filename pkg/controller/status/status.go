@@ -3,15 +3,19 @@ package status
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
 )
 
@@ -21,12 +25,20 @@ type Condition struct {
 	Status  metav1.ConditionStatus
 	Reason  string
 	Message string
+	// Runbook is the reason whose runbook is linked from a failure message, Reason when empty
+	Runbook string
 }
 
 // Manager handles status updates for operand resources
 type Manager struct {
 	customClient customClient.CustomCtrlClient
 	conditions   map[string]Condition
+	// version is the version summarized in the status, left unchanged when nil
+	version *string
+	// gracePeriod is how long transient failures of healthy conditions are held back
+	gracePeriod time.Duration
+	// heldBackFor is how long the status change limited to progress messages is held back
+	heldBackFor time.Duration
 }
 
 // NewManager creates a new status manager
@@ -39,15 +51,21 @@ func NewManager(customClient customClient.CustomCtrlClient) *Manager {
 
 // SetInitialReconciliationStatus sets the Ready condition to false at the start of reconciliation.
 // This ensures that during operator upgrades, the status correctly reflects that reconciliation
-// is in progress. The status is applied immediately before any reconciliation logic runs.
-func SetInitialReconciliationStatus(ctx context.Context, customClient customClient.CustomCtrlClient, obj client.Object, getStatus func() *v1alpha1.ConditionalStatus, resourceName string) {
+// is in progress. The status is applied immediately before any reconciliation logic runs, once
+// per generation of the object, so that reconciles of an unchanged object do not flip Ready.
+func SetInitialReconciliationStatus(ctx context.Context, customClient customClient.CustomCtrlClient, obj client.Object, getStatus func() *v1alpha2.ConditionalStatus, resourceName string) {
+	if !writes.needsInitialStatus(obj) {
+		return
+	}
 	initialStatusMgr := NewManager(customClient)
-	initialStatusMgr.AddCondition(v1alpha1.Ready, v1alpha1.ReasonInProgress,
+	initialStatusMgr.AddCondition(v1alpha2.Ready, v1alpha2.ReasonInProgress,
 		fmt.Sprintf("Reconciling %s", resourceName),
 		metav1.ConditionFalse)
 	if err := initialStatusMgr.ApplyStatus(ctx, obj, getStatus); err != nil {
-		fmt.Printf("cannot apply the initial status %v", err)
+		log.FromContext(ctx).Error(err, "cannot apply the initial status", "resource", resourceName)
+		return
 	}
+	writes.initialStatusWritten(obj)
 }
 
 // AddCondition adds or updates a condition
@@ -60,26 +78,76 @@ func (m *Manager) AddCondition(conditionType, reason, message string, status met
 	}
 }
 
+// GetCondition returns the condition of the given type collected during this reconcile
+func (m *Manager) GetCondition(conditionType string) (Condition, bool) {
+	cond, ok := m.conditions[conditionType]
+	return cond, ok
+}
+
+// Conditions returns the conditions collected during this reconcile, sorted by type
+func (m *Manager) Conditions() []Condition {
+	conditions := make([]Condition, 0, len(m.conditions))
+	for _, cond := range m.conditions {
+		conditions = append(conditions, cond)
+	}
+	sort.Slice(conditions, func(i, j int) bool { return conditions[i].Type < conditions[j].Type })
+	return conditions
+}
+
+// progressingReasons are the reasons of a False condition that indicate normal progress, not a failure
+var progressingReasons = map[string]bool{
+	"StatefulSetNotReady": true,
+	"DaemonSetNotReady":   true,
+	"DeploymentNotReady":  true,
+	// External endpoints wait for a load balancer or router to assign an address
+	"ExternalEndpointPending": true,
+	// The SPIRE server is verified once its rollout completes
+	"RolloutVerificationInProgress": true,
+}
+
+// reportsHealth tells whether a False condition of the given type indicates operational health.
+// CreateOnlyMode=False, UpgradeInProgress=False, DryRun=False, ConfigRollback=False,
+// ArchitecturesSkipped=False, UnsupportedConfiguration=False, UnmanagedResources=False,
+// DatastorePressure=False, ConfigurationConflict=False, ClockSkew=False, EnvironmentConflict=False,
+// ChangesPending=False, WaitingForDependency=False, ReattestationPending=False,
+// BreakGlassActive=False, FederationConformant=False and RightSized=False are normal states, not
+// failures.
+func reportsHealth(condType string) bool {
+	switch condType {
+	case v1alpha2.Ready, v1alpha2.Degraded, utils.CreateOnlyModeStatusType, utils.UpgradeInProgressStatusType,
+		utils.DryRunStatusType, utils.ConfigRollbackStatusType, utils.ArchitecturesSkippedStatusType,
+		utils.UnsupportedConfigurationStatusType, utils.UnmanagedResourcesStatusType, utils.DatastorePressureStatusType,
+		utils.ConfigurationConflictStatusType, utils.ClockSkewStatusType, utils.EnvironmentConflictStatusType,
+		utils.ChangesPendingStatusType, utils.WaitingForDependencyStatusType, utils.ReattestationPendingStatusType,
+		utils.BreakGlassActiveStatusType, utils.FederationConformantStatusType, utils.RightSizedStatusType:
+		return false
+	}
+	return true
+}
+
+// isFailure tells whether the condition reports a failure, whose message links to a runbook
+func isFailure(cond Condition) bool {
+	switch cond.Type {
+	case v1alpha2.Degraded:
+		return cond.Status == metav1.ConditionTrue
+	case v1alpha2.Ready:
+		return cond.Status == metav1.ConditionFalse && cond.Reason == v1alpha2.ReasonFailed
+	}
+	return reportsHealth(cond.Type) && cond.Status == metav1.ConditionFalse && !progressingReasons[cond.Reason]
+}
+
 // SetReadyCondition sets the Ready condition based on all other conditions
 // Distinguishes between "Progressing" (normal startup/rollout) and "Failed" (actual errors)
 func (m *Manager) SetReadyCondition() {
-	// Check if any condition (except Ready, Degraded, and CreateOnlyMode) is False
-	// Note: CreateOnlyMode=False is normal (disabled state), not a failure
 	hasProgressing := false
 	hasFailure := false
 	failureMessages := []string{}
+	failureReasons := []string{}
 	progressingMessages := []string{}
 
-	// Reasons that indicate normal progress (not actual failures)
-	progressingReasons := map[string]bool{
-		"StatefulSetNotReady": true,
-		"DaemonSetNotReady":   true,
-		"DeploymentNotReady":  true,
-	}
-
 	for condType, cond := range m.conditions {
 		// Skip conditions that don't indicate operational health
-		if condType == v1alpha1.Ready || condType == v1alpha1.Degraded || condType == utils.CreateOnlyModeStatusType {
+		if !reportsHealth(condType) {
 			continue
 		}
 		if cond.Status == metav1.ConditionFalse {
@@ -90,31 +158,48 @@ func (m *Manager) SetReadyCondition() {
 			} else {
 				hasFailure = true
 				failureMessages = append(failureMessages, fmt.Sprintf("%s: %s", condType, cond.Message))
+				failureReasons = append(failureReasons, cond.Reason)
 			}
 		}
 	}
 
 	if hasFailure {
-		// Actual failure - use Failed reason
+		// Actual failure - use Failed reason, linking to the runbook of the failure shown
 		message := "One or more components are not ready"
 		if len(failureMessages) > 0 {
 			message = failureMessages[0] // Show the first failure
 		}
-		m.AddCondition(v1alpha1.Ready, v1alpha1.ReasonFailed, message, metav1.ConditionFalse)
+		m.AddCondition(v1alpha2.Ready, v1alpha2.ReasonFailed, message, metav1.ConditionFalse)
+		ready := m.conditions[v1alpha2.Ready]
+		ready.Runbook = failureReasons[0]
+		m.conditions[v1alpha2.Ready] = ready
 	} else if hasProgressing {
 		// Normal startup/rollout - use Progressing reason
 		message := "Components are starting up or rolling out"
 		if len(progressingMessages) > 0 {
 			message = progressingMessages[0] // Show the first progressing component
 		}
-		m.AddCondition(v1alpha1.Ready, v1alpha1.ReasonInProgress, message, metav1.ConditionFalse)
+		m.AddCondition(v1alpha2.Ready, v1alpha2.ReasonInProgress, message, metav1.ConditionFalse)
 	} else {
-		m.AddCondition(v1alpha1.Ready, v1alpha1.ReasonReady, "All components are ready", metav1.ConditionTrue)
+		m.AddCondition(v1alpha2.Ready, v1alpha2.ReasonReady, "All components are ready", metav1.ConditionTrue)
+	}
+}
+
+// SetDegradedCondition sets the Degraded condition from the error that ended the reconciliation,
+// with a reason telling whether the error is transient, terminal or caused by the configuration.
+// A successful reconciliation only clears a Degraded condition that was previously set.
+func (m *Manager) SetDegradedCondition(err error, existingConditions []metav1.Condition) {
+	if err != nil {
+		m.AddCondition(v1alpha2.Degraded, utils.DegradedReason(err), err.Error(), metav1.ConditionTrue)
+		return
+	}
+	if apimeta.IsStatusConditionTrue(existingConditions, v1alpha2.Degraded) {
+		m.AddCondition(v1alpha2.Degraded, v1alpha2.ReasonReady, "Reconciliation succeeded", metav1.ConditionFalse)
 	}
 }
 
 // ApplyStatus applies all collected conditions to the given resource status
-func (m *Manager) ApplyStatus(ctx context.Context, obj client.Object, getStatus func() *v1alpha1.ConditionalStatus) error {
+func (m *Manager) ApplyStatus(ctx context.Context, obj client.Object, getStatus func() *v1alpha2.ConditionalStatus) error {
 	status := getStatus()
 	originalStatus := status.DeepCopy()
 
@@ -122,36 +207,82 @@ func (m *Manager) ApplyStatus(ctx context.Context, obj client.Object, getStatus
 		status.Conditions = []metav1.Condition{}
 	}
 
+	// Keep reporting the healthy conditions through transient failures, e.g. API server outages
+	now := time.Now()
+	m.holdTransientFailures(obj, status.Conditions, now)
+
 	// Only auto-set Ready condition if it hasn't been manually set
 	// Check if Ready condition was explicitly added by the controller
-	_, readyExplicitlySet := m.conditions[v1alpha1.Ready]
+	_, readyExplicitlySet := m.conditions[v1alpha2.Ready]
 	if !readyExplicitlySet {
 		// Set the Ready condition based on all other conditions
 		m.SetReadyCondition()
 	}
 
-	// Apply all conditions
+	// Apply all conditions, with the runbook of failures rendered into their message
 	for _, cond := range m.conditions {
 		newCondition := metav1.Condition{
 			Type:               cond.Type,
 			Status:             cond.Status,
 			Reason:             cond.Reason,
-			Message:            cond.Message,
+			Message:            renderMessage(cond),
 			LastTransitionTime: metav1.Now(),
 		}
 		apimeta.SetStatusCondition(&status.Conditions, newCondition)
 	}
 
-	// Only update if status has changed
-	if !equality.Semantic.DeepEqual(originalStatus, status) {
-		if err := m.customClient.StatusUpdateWithRetry(ctx, obj); err != nil {
-			return fmt.Errorf("failed to update status: %w", err)
-		}
+	// Summarize the Ready condition and the version for display
+	if ready := apimeta.FindStatusCondition(status.Conditions, v1alpha2.Ready); ready != nil {
+		status.Ready = ready.Status
+		status.Message = ready.Message
+	}
+	if m.version != nil {
+		status.Version = *m.version
 	}
 
+	// Only update if status has changed, recording the reconcile pass that changed it. Changes
+	// limited to progress messages are held back while the status was written recently.
+	if equality.Semantic.DeepEqual(originalStatus, status) {
+		return nil
+	}
+	if onlyProgressMessagesChanged(originalStatus, status) {
+		if m.heldBackFor = writes.holdBackFor(obj, now); m.heldBackFor > 0 {
+			*status = *originalStatus
+			return nil
+		}
+	}
+	if traceID := utils.TraceIDFromContext(ctx); traceID != "" {
+		status.LastTraceID = traceID
+	}
+	if err := m.customClient.StatusUpdateWithRetry(ctx, obj); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+	writes.written(obj, now)
 	return nil
 }
 
+// RequeueHeldBack returns the result of a successful reconciliation requeued for when the hold-back
+// window of a status change held back by ApplyStatus ends, so that the change is written without
+// waiting for another event
+func (m *Manager) RequeueHeldBack(result ctrl.Result, err error) ctrl.Result {
+	if err == nil && m.heldBackFor > 0 && (result.RequeueAfter == 0 || result.RequeueAfter > m.heldBackFor) {
+		result.RequeueAfter = m.heldBackFor
+	}
+	return result
+}
+
+// renderMessage returns the message of the condition, with the reason code and runbook link of a failure
+func renderMessage(cond Condition) string {
+	if !isFailure(cond) {
+		return cond.Message
+	}
+	runbook := cond.Runbook
+	if runbook == "" {
+		runbook = cond.Reason
+	}
+	return utils.WithRunbook(runbook, cond.Message)
+}
+
 // CheckStatefulSetHealth checks the health of a StatefulSet and adds conditions
 func (m *Manager) CheckStatefulSetHealth(ctx context.Context, name, namespace, conditionType string) {
 	var sts appsv1.StatefulSet
@@ -6,8 +6,10 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
@@ -57,7 +59,7 @@ func TestSetReadyCondition(t *testing.T) {
 				"Component2": {Type: "Component2", Status: metav1.ConditionTrue, Reason: "OK"},
 			},
 			expectedStatus: metav1.ConditionTrue,
-			expectedReason: v1alpha1.ReasonReady,
+			expectedReason: v1alpha2.ReasonReady,
 		},
 		{
 			name: "One condition false - Failed",
@@ -66,7 +68,7 @@ func TestSetReadyCondition(t *testing.T) {
 				"Component2": {Type: "Component2", Status: metav1.ConditionFalse, Reason: "Failed"},
 			},
 			expectedStatus: metav1.ConditionFalse,
-			expectedReason: v1alpha1.ReasonFailed,
+			expectedReason: v1alpha2.ReasonFailed,
 		},
 		{
 			name: "StatefulSet starting - Progressing",
@@ -74,7 +76,7 @@ func TestSetReadyCondition(t *testing.T) {
 				"StatefulSetAvailable": {Type: "StatefulSetAvailable", Status: metav1.ConditionFalse, Reason: "StatefulSetNotReady", Message: "StatefulSet has 0/1 replicas ready"},
 			},
 			expectedStatus: metav1.ConditionFalse,
-			expectedReason: v1alpha1.ReasonInProgress,
+			expectedReason: v1alpha2.ReasonInProgress,
 		},
 		{
 			name: "DaemonSet starting - Progressing",
@@ -82,7 +84,7 @@ func TestSetReadyCondition(t *testing.T) {
 				"DaemonSetAvailable": {Type: "DaemonSetAvailable", Status: metav1.ConditionFalse, Reason: "DaemonSetNotReady", Message: "DaemonSet has 0/3 pods ready"},
 			},
 			expectedStatus: metav1.ConditionFalse,
-			expectedReason: v1alpha1.ReasonInProgress,
+			expectedReason: v1alpha2.ReasonInProgress,
 		},
 		{
 			name: "Deployment rolling - Progressing",
@@ -90,7 +92,7 @@ func TestSetReadyCondition(t *testing.T) {
 				"DeploymentAvailable": {Type: "DeploymentAvailable", Status: metav1.ConditionFalse, Reason: "DeploymentNotReady", Message: "Deployment has 1/3 replicas ready"},
 			},
 			expectedStatus: metav1.ConditionFalse,
-			expectedReason: v1alpha1.ReasonInProgress,
+			expectedReason: v1alpha2.ReasonInProgress,
 		},
 		{
 			name: "Failure takes precedence over progressing",
@@ -99,13 +101,13 @@ func TestSetReadyCondition(t *testing.T) {
 				"ConfigValid":          {Type: "ConfigValid", Status: metav1.ConditionFalse, Reason: "InvalidConfig"},
 			},
 			expectedStatus: metav1.ConditionFalse,
-			expectedReason: v1alpha1.ReasonFailed,
+			expectedReason: v1alpha2.ReasonFailed,
 		},
 		{
 			name:               "No conditions - Ready",
 			existingConditions: map[string]Condition{},
 			expectedStatus:     metav1.ConditionTrue,
-			expectedReason:     v1alpha1.ReasonReady,
+			expectedReason:     v1alpha2.ReasonReady,
 		},
 	}
 
@@ -114,7 +116,7 @@ func TestSetReadyCondition(t *testing.T) {
 			mgr := &Manager{conditions: tt.existingConditions}
 			mgr.SetReadyCondition()
 
-			readyCond, exists := mgr.conditions[v1alpha1.Ready]
+			readyCond, exists := mgr.conditions[v1alpha2.Ready]
 			if !exists {
 				t.Fatal("Expected Ready condition to be set")
 			}
@@ -395,8 +397,8 @@ func TestSetInitialReconciliationStatus(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			fakeClient.StatusUpdateWithRetryReturns(tt.updateError)
 
-			obj := &v1alpha1.SpireServer{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}}
-			SetInitialReconciliationStatus(context.Background(), fakeClient, obj, func() *v1alpha1.ConditionalStatus {
+			obj := &v1alpha2.SpireServer{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}}
+			SetInitialReconciliationStatus(context.Background(), fakeClient, obj, func() *v1alpha2.ConditionalStatus {
 				return &obj.Status.ConditionalStatus
 			}, "SpireServer")
 		})
@@ -421,8 +423,8 @@ func TestApplyStatus(t *testing.T) {
 			mgr := NewManager(fakeClient)
 			mgr.AddCondition("TestCondition", "TestReason", "Test message", metav1.ConditionTrue)
 
-			obj := &v1alpha1.SpireServer{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}}
-			err := mgr.ApplyStatus(context.Background(), obj, func() *v1alpha1.ConditionalStatus {
+			obj := &v1alpha2.SpireServer{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}}
+			err := mgr.ApplyStatus(context.Background(), obj, func() *v1alpha2.ConditionalStatus {
 				return &obj.Status.ConditionalStatus
 			})
 
@@ -821,3 +823,122 @@ func TestGetStatefulSetStatusMessage_AllScenarios(t *testing.T) {
 		})
 	}
 }
+
+func TestSetDegradedCondition(t *testing.T) {
+	degraded := []metav1.Condition{{Type: v1alpha2.Degraded, Status: metav1.ConditionTrue}}
+
+	t.Run("error sets Degraded with its class", func(t *testing.T) {
+		mgr := NewManager(&fakes.FakeCustomCtrlClient{})
+		mgr.SetDegradedCondition(errors.New("connection refused"), nil)
+		cond, ok := mgr.GetCondition(v1alpha2.Degraded)
+		if !ok || cond.Status != metav1.ConditionTrue || cond.Reason != "TransientError" || cond.Message != "connection refused" {
+			t.Errorf("Expected a transient Degraded condition, got %v", cond)
+		}
+	})
+
+	t.Run("success clears a previous Degraded condition", func(t *testing.T) {
+		mgr := NewManager(&fakes.FakeCustomCtrlClient{})
+		mgr.SetDegradedCondition(nil, degraded)
+		cond, ok := mgr.GetCondition(v1alpha2.Degraded)
+		if !ok || cond.Status != metav1.ConditionFalse {
+			t.Errorf("Expected Degraded to be cleared, got %v", cond)
+		}
+	})
+
+	t.Run("success without previous Degraded condition adds nothing", func(t *testing.T) {
+		mgr := NewManager(&fakes.FakeCustomCtrlClient{})
+		mgr.SetDegradedCondition(nil, nil)
+		if _, ok := mgr.GetCondition(v1alpha2.Degraded); ok {
+			t.Error("Expected no Degraded condition")
+		}
+	})
+}
+
+func TestApplyStatus_RunbookLinks(t *testing.T) {
+	t.Setenv(utils.RunbookBaseURLEnv, "https://runbooks.example.com")
+	mgr := NewManager(&fakes.FakeCustomCtrlClient{})
+	mgr.AddCondition("ServerStatefulSetAvailable", "StatefulSetNotFound", "statefulset missing", metav1.ConditionFalse)
+	mgr.AddCondition("AgentDaemonSetAvailable", "DaemonSetNotReady", "1/3 pods ready", metav1.ConditionFalse)
+
+	obj := &v1alpha2.SpireServer{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}}
+	if err := mgr.ApplyStatus(context.Background(), obj, func() *v1alpha2.ConditionalStatus {
+		return &obj.Status.ConditionalStatus
+	}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	messages := map[string]string{}
+	for _, cond := range obj.Status.Conditions {
+		messages[cond.Type] = cond.Message
+	}
+	link := "(reason: StatefulSetNotFound, runbook: https://runbooks.example.com/StatefulSetNotFound.md)"
+	if !strings.HasSuffix(messages["ServerStatefulSetAvailable"], link) {
+		t.Errorf("Expected the failure to link its runbook, got %q", messages["ServerStatefulSetAvailable"])
+	}
+	if !strings.HasSuffix(messages[v1alpha2.Ready], link) {
+		t.Errorf("Expected Ready to link the runbook of the failure it shows, got %q", messages[v1alpha2.Ready])
+	}
+	if messages["AgentDaemonSetAvailable"] != "1/3 pods ready" {
+		t.Errorf("Expected no runbook for a rollout in progress, got %q", messages["AgentDaemonSetAvailable"])
+	}
+	if cond, _ := mgr.GetCondition("ServerStatefulSetAvailable"); cond.Message != "statefulset missing" {
+		t.Errorf("Expected the collected message to be left unrendered, got %q", cond.Message)
+	}
+}
+
+func TestApplyStatus_TraceID(t *testing.T) {
+	ctx, _, traceID := utils.WithTraceID(context.Background(), logr.Discard())
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	mgr := NewManager(fakeClient)
+	mgr.AddCondition("TestCondition", "TestReason", "Test message", metav1.ConditionTrue)
+
+	obj := &v1alpha2.SpireServer{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}}
+	getStatus := func() *v1alpha2.ConditionalStatus { return &obj.Status.ConditionalStatus }
+	if err := mgr.ApplyStatus(ctx, obj, getStatus); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if obj.Status.LastTraceID != traceID {
+		t.Errorf("Expected the status update to record trace ID %q, got %q", traceID, obj.Status.LastTraceID)
+	}
+
+	// An unchanged status is not updated, so it keeps the trace ID of the pass that changed it
+	if err := mgr.ApplyStatus(context.Background(), obj, getStatus); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fakeClient.StatusUpdateWithRetryCallCount() != 1 || obj.Status.LastTraceID != traceID {
+		t.Errorf("Expected no status update without changes, got %d updates and trace ID %q",
+			fakeClient.StatusUpdateWithRetryCallCount(), obj.Status.LastTraceID)
+	}
+}
+
+func TestApplyStatus_Summary(t *testing.T) {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	fakeClient.GetStub = func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+		obj.SetLabels(map[string]string{versionLabelKey: "1.13.3"})
+		return nil
+	}
+	mgr := NewManager(fakeClient)
+	mgr.AddCondition("ServerStatefulSetAvailable", "StatefulSetNotReady", "1/3 pods ready", metav1.ConditionFalse)
+	mgr.ReportWorkloadVersion(context.Background(), &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "spire-server", Namespace: "test-ns"}})
+
+	obj := &v1alpha2.SpireServer{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}}
+	getStatus := func() *v1alpha2.ConditionalStatus { return &obj.Status.ConditionalStatus }
+	if err := mgr.ApplyStatus(context.Background(), obj, getStatus); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if obj.Status.Ready != metav1.ConditionFalse || !strings.Contains(obj.Status.Message, "1/3 pods ready") || obj.Status.Version != "1.13.3" {
+		t.Errorf("Expected the summary of the Ready condition and the version, got ready %q, message %q, version %q",
+			obj.Status.Ready, obj.Status.Message, obj.Status.Version)
+	}
+
+	// The version is left unchanged when it is not reported
+	mgr = NewManager(fakeClient)
+	mgr.AddCondition("ServerStatefulSetAvailable", "StatefulSetReady", "3/3 pods ready", metav1.ConditionTrue)
+	if err := mgr.ApplyStatus(context.Background(), obj, getStatus); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if obj.Status.Ready != metav1.ConditionTrue || obj.Status.Message != "All components are ready" || obj.Status.Version != "1.13.3" {
+		t.Errorf("Expected the summary of the Ready condition and the version, got ready %q, message %q, version %q",
+			obj.Status.Ready, obj.Status.Message, obj.Status.Version)
+	}
+}
@@ -0,0 +1,33 @@
+package status
+
+import (
+	"fmt"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// ReportUnmanagedResources publishes the kinds of resources left to the user and the writes the
+// operator skipped for them. The condition is set to False once every kind is managed again, and
+// nothing is reported when no kind was ever unmanaged.
+func (m *Manager) ReportUnmanagedResources(kinds, skipped []string, existingConditions []metav1.Condition) {
+	if len(kinds) == 0 {
+		if apimeta.IsStatusConditionTrue(existingConditions, utils.UnmanagedResourcesStatusType) {
+			m.AddCondition(utils.UnmanagedResourcesStatusType, utils.UnmanagedResourcesReasonAllManaged,
+				"All resources are managed by the operator",
+				metav1.ConditionFalse)
+		}
+		return
+	}
+	message := fmt.Sprintf("Not managed by the operator: %s", strings.Join(kinds, ", "))
+	if len(skipped) > 0 {
+		message += fmt.Sprintf("; skipped changes: %s", strings.Join(skipped, "; "))
+	}
+	if len(message) > maxDryRunMessageLength {
+		message = message[:maxDryRunMessageLength-3] + "..."
+	}
+	m.AddCondition(utils.UnmanagedResourcesStatusType, utils.UnmanagedResourcesReasonUnmanaged, message, metav1.ConditionTrue)
+}
@@ -0,0 +1,37 @@
+package status
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func TestReportUnmanagedResources(t *testing.T) {
+	mgr := NewManager(&fakes.FakeCustomCtrlClient{})
+	mgr.ReportUnmanagedResources(nil, nil, nil)
+	if cond, ok := mgr.GetCondition(utils.UnmanagedResourcesStatusType); ok {
+		t.Errorf("Expected no condition when every kind was always managed, got %v", cond)
+	}
+
+	mgr.ReportUnmanagedResources([]string{"SecurityContextConstraints"}, []string{"update SecurityContextConstraints spire-agent"}, nil)
+	cond, _ := mgr.GetCondition(utils.UnmanagedResourcesStatusType)
+	if cond.Status != metav1.ConditionTrue || !strings.Contains(cond.Message, "update SecurityContextConstraints spire-agent") {
+		t.Errorf("Expected the unmanaged kinds and skipped changes to be reported, got %v", cond)
+	}
+	mgr.SetReadyCondition()
+	if ready, _ := mgr.GetCondition(v1alpha2.Ready); ready.Status != metav1.ConditionTrue {
+		t.Errorf("Expected unmanaged resources not to fail Ready, got %v", ready)
+	}
+
+	existing := []metav1.Condition{{Type: utils.UnmanagedResourcesStatusType, Status: metav1.ConditionTrue}}
+	mgr = NewManager(&fakes.FakeCustomCtrlClient{})
+	mgr.ReportUnmanagedResources(nil, nil, existing)
+	if cond, _ := mgr.GetCondition(utils.UnmanagedResourcesStatusType); cond.Reason != utils.UnmanagedResourcesReasonAllManaged {
+		t.Errorf("Expected the condition to be cleared once every kind is managed, got %v", cond)
+	}
+}
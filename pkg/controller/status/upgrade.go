@@ -0,0 +1,292 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/version"
+)
+
+const versionLabelKey = "app.kubernetes.io/version"
+
+// OperandUpgradeOrder is the order in which operand workloads are rolled to a new version.
+// The server must be upgraded before the agents talking to it, and the agents before the
+// CSI driver and OIDC discovery provider that consume the Workload API.
+var OperandUpgradeOrder = []string{
+	utils.ResourceKindSpireServer,
+	utils.ResourceKindSpireAgent,
+	utils.ResourceKindSpiffeCSIDriver,
+	utils.ResourceKindSpireOIDCDiscoveryProvider,
+}
+
+// operandWorkload describes the workloads which carry the version of an operand. They are selected
+// by the standardized labels of the operand, so that every SPIRE agent pool is upgraded before the
+// operands following the agents.
+type operandWorkload struct {
+	labels          map[string]string
+	containerName   string
+	expectedVersion string
+	expectedImage   func() string
+	newList         func() client.ObjectList
+}
+
+var operandWorkloads = map[string]operandWorkload{
+	utils.ResourceKindSpireServer: {
+		labels:          utils.SpireServerLabels(nil),
+		containerName:   "spire-server",
+		expectedVersion: version.SpireServerVersion,
+		expectedImage:   utils.GetSpireServerImage,
+		newList:         func() client.ObjectList { return &appsv1.StatefulSetList{} },
+	},
+	utils.ResourceKindSpireAgent: {
+		labels:          utils.SpireAgentLabels(nil),
+		containerName:   "spire-agent",
+		expectedVersion: version.SpireAgentVersion,
+		expectedImage:   utils.GetSpireAgentImage,
+		newList:         func() client.ObjectList { return &appsv1.DaemonSetList{} },
+	},
+	utils.ResourceKindSpiffeCSIDriver: {
+		labels:          utils.SpiffeCSIDriverLabels(nil),
+		containerName:   "spiffe-csi-driver",
+		expectedVersion: version.SpiffeCsiVersion,
+		expectedImage:   utils.GetSpiffeCSIDriverImage,
+		newList:         func() client.ObjectList { return &appsv1.DaemonSetList{} },
+	},
+	utils.ResourceKindSpireOIDCDiscoveryProvider: {
+		labels:          utils.SpireOIDCDiscoveryProviderLabels(nil),
+		containerName:   "spiffe-oidc-discovery-provider",
+		expectedVersion: version.SpireOIDCDiscoveryProviderVersion,
+		expectedImage:   utils.GetSpireOIDCDiscoveryProviderImage,
+		newList:         func() client.ObjectList { return &appsv1.DeploymentList{} },
+	},
+}
+
+// OperandVersionStatus describes the deployed version of an operand workload
+// compared to the version expected by the running operator
+type OperandVersionStatus struct {
+	Kind            string
+	Deployed        bool
+	CurrentVersion  string
+	ExpectedVersion string
+	// SpecUpToDate is true when the workload spec already references the expected version and image
+	SpecUpToDate bool
+	// RolledOut is true when SpecUpToDate is true and all pods run the updated spec
+	RolledOut bool
+}
+
+// InSkew returns true if the operand is deployed but its workload has not been updated
+// to the version expected by the operator yet
+func (s OperandVersionStatus) InSkew() bool {
+	return s.Deployed && !s.SpecUpToDate
+}
+
+// String returns a short human readable progress summary for the operand
+func (s OperandVersionStatus) String() string {
+	switch {
+	case !s.Deployed:
+		return fmt.Sprintf("%s: not deployed", s.Kind)
+	case !s.SpecUpToDate:
+		return fmt.Sprintf("%s: pending (%s -> %s)", s.Kind, displayVersion(s.CurrentVersion), s.ExpectedVersion)
+	case !s.RolledOut:
+		return fmt.Sprintf("%s: rolling out %s", s.Kind, s.ExpectedVersion)
+	default:
+		return fmt.Sprintf("%s: upgraded to %s", s.Kind, s.ExpectedVersion)
+	}
+}
+
+func displayVersion(v string) string {
+	if v == "" {
+		return "unknown"
+	}
+	return v
+}
+
+// GetOperandVersionStatus reads the workloads of the given operand kind and compares their
+// version label and main container image with the values expected by the operator. The operand is
+// up to date, and rolled out, once all of its workloads are.
+func GetOperandVersionStatus(ctx context.Context, c customClient.CustomCtrlClient, kind string) (OperandVersionStatus, error) {
+	workload, ok := operandWorkloads[kind]
+	if !ok {
+		return OperandVersionStatus{}, fmt.Errorf("unknown operand kind %q", kind)
+	}
+
+	result := OperandVersionStatus{
+		Kind:            kind,
+		ExpectedVersion: workload.expectedVersion,
+	}
+
+	list := workload.newList()
+	if err := c.List(ctx, list, client.InNamespace(utils.GetOperatorNamespace()), utils.WorkloadSelector(workload.labels)); err != nil {
+		return result, fmt.Errorf("failed to list %s workloads: %w", kind, err)
+	}
+	objects, err := apimeta.ExtractList(list)
+	if err != nil {
+		return result, err
+	}
+	if len(objects) == 0 {
+		return result, nil
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].(client.Object).GetName() < objects[j].(client.Object).GetName()
+	})
+
+	result.Deployed = true
+	result.SpecUpToDate = true
+	result.RolledOut = true
+	for _, obj := range objects {
+		currentVersion, upToDate, rolledOut := workloadVersionStatus(obj.(client.Object), workload)
+		// The version reported is that of the first workload still to upgrade
+		if result.CurrentVersion == "" || (result.SpecUpToDate && !upToDate) {
+			result.CurrentVersion = currentVersion
+		}
+		result.SpecUpToDate = result.SpecUpToDate && upToDate
+		result.RolledOut = result.RolledOut && rolledOut
+	}
+	return result, nil
+}
+
+// workloadVersionStatus returns the version labelled on a workload of the operand, whether its spec
+// references the expected version and image, and whether its pods all run that spec
+func workloadVersionStatus(obj client.Object, workload operandWorkload) (string, bool, bool) {
+	currentVersion := obj.GetLabels()[versionLabelKey]
+
+	var podSpec *corev1.PodSpec
+	var healthy bool
+	switch o := obj.(type) {
+	case *appsv1.StatefulSet:
+		podSpec = &o.Spec.Template.Spec
+		healthy = IsStatefulSetHealthy(o)
+	case *appsv1.DaemonSet:
+		podSpec = &o.Spec.Template.Spec
+		healthy = IsDaemonSetHealthy(o)
+	case *appsv1.Deployment:
+		podSpec = &o.Spec.Template.Spec
+		healthy = IsDeploymentHealthy(o)
+	}
+
+	// A missing version label means the workload predates version tracking, only a
+	// conflicting label is treated as skew
+	versionMatches := currentVersion == "" || currentVersion == workload.expectedVersion
+	expectedImage := workload.expectedImage()
+	imageMatches := expectedImage == "" || containerImage(podSpec, workload.containerName) == expectedImage
+
+	upToDate := versionMatches && imageMatches
+	return currentVersion, upToDate, upToDate && healthy
+}
+
+// GetOperandVersionStatuses returns the version status of all operands in upgrade order
+func GetOperandVersionStatuses(ctx context.Context, c customClient.CustomCtrlClient) ([]OperandVersionStatus, error) {
+	statuses := make([]OperandVersionStatus, 0, len(OperandUpgradeOrder))
+	for _, kind := range OperandUpgradeOrder {
+		s, err := GetOperandVersionStatus(ctx, c, kind)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses, nil
+}
+
+// UpgradePrerequisitesMet checks whether every operand preceding kind in OperandUpgradeOrder
+// has been fully rolled out at the expected version. Operands which are not deployed do not
+// block the upgrade. The returned message lists the operands that are still pending.
+func UpgradePrerequisitesMet(ctx context.Context, c customClient.CustomCtrlClient, kind string) (bool, string, error) {
+	var pending []string
+	for _, predecessor := range OperandUpgradeOrder {
+		if predecessor == kind {
+			break
+		}
+		s, err := GetOperandVersionStatus(ctx, c, predecessor)
+		if err != nil {
+			return false, "", err
+		}
+		if s.Deployed && !s.RolledOut {
+			pending = append(pending, s.String())
+		}
+	}
+
+	if len(pending) > 0 {
+		return false, fmt.Sprintf("Waiting for %s", strings.Join(pending, ", ")), nil
+	}
+	return true, "", nil
+}
+
+// IsOperandVersionChange reports whether replacing the current workload with the desired one
+// changes the operand version label or the image of the operand's main container
+func IsOperandVersionChange(currentLabels, desiredLabels map[string]string, current, desired *corev1.PodSpec, containerName string) bool {
+	currentVersion := currentLabels[versionLabelKey]
+	if currentVersion != "" && currentVersion != desiredLabels[versionLabelKey] {
+		return true
+	}
+	currentImage := containerImage(current, containerName)
+	return currentImage != "" && currentImage != containerImage(desired, containerName)
+}
+
+// CheckUpgradeOrder decides whether an operand workload update may be applied now. Updates which
+// do not change the operand version are always allowed. Version changes are held back until all
+// preceding operands in OperandUpgradeOrder are rolled out, and the UpgradeInProgress condition
+// reports what the operand is waiting for.
+func (m *Manager) CheckUpgradeOrder(ctx context.Context, kind string, existingConditions []metav1.Condition, versionChange bool) bool {
+	if versionChange {
+		met, message, err := UpgradePrerequisitesMet(ctx, m.customClient, kind)
+		if err != nil {
+			message = fmt.Sprintf("Failed to check upgrade prerequisites: %v", err)
+		}
+		if !met {
+			m.AddCondition(utils.UpgradeInProgressStatusType, utils.UpgradeReasonWaitingForPrerequisiteOperands,
+				message,
+				metav1.ConditionTrue)
+			return false
+		}
+	}
+
+	// Only report completion if an upgrade was previously held back
+	existingCondition := apimeta.FindStatusCondition(existingConditions, utils.UpgradeInProgressStatusType)
+	if existingCondition != nil && existingCondition.Status == metav1.ConditionTrue {
+		m.AddCondition(utils.UpgradeInProgressStatusType, utils.UpgradeReasonComplete,
+			"Upgrade prerequisites are met",
+			metav1.ConditionFalse)
+	}
+	return true
+}
+
+// SetVersion sets the version summarized in the status
+func (m *Manager) SetVersion(version string) {
+	m.version = &version
+}
+
+// ReportWorkloadVersion summarizes the version labelled on the workload of the operand in the
+// status, none while the workload does not exist. The version is left unchanged when the
+// workload cannot be read.
+func (m *Manager) ReportWorkloadVersion(ctx context.Context, workload client.Object) {
+	if err := m.customClient.Get(ctx, client.ObjectKeyFromObject(workload), workload); err != nil {
+		if kerrors.IsNotFound(err) {
+			m.SetVersion("")
+		}
+		return
+	}
+	m.SetVersion(workload.GetLabels()[versionLabelKey])
+}
+
+func containerImage(podSpec *corev1.PodSpec, containerName string) string {
+	if podSpec == nil {
+		return ""
+	}
+	for _, c := range podSpec.Containers {
+		if c.Name == containerName {
+			return c.Image
+		}
+	}
+	return ""
+}
@@ -0,0 +1,302 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/version"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func podSpecWithImage(containerName, image string) *corev1.PodSpec {
+	return &corev1.PodSpec{Containers: []corev1.Container{{Name: containerName, Image: image}}}
+}
+
+func TestIsOperandVersionChange(t *testing.T) {
+	tests := []struct {
+		name          string
+		currentLabels map[string]string
+		desiredLabels map[string]string
+		current       *corev1.PodSpec
+		desired       *corev1.PodSpec
+		expected      bool
+	}{
+		{
+			name:          "same version and image",
+			currentLabels: map[string]string{versionLabelKey: "1.0.0"},
+			desiredLabels: map[string]string{versionLabelKey: "1.0.0"},
+			current:       podSpecWithImage("spire-agent", "agent:1"),
+			desired:       podSpecWithImage("spire-agent", "agent:1"),
+			expected:      false,
+		},
+		{
+			name:          "version label changed",
+			currentLabels: map[string]string{versionLabelKey: "1.0.0"},
+			desiredLabels: map[string]string{versionLabelKey: "1.1.0"},
+			current:       podSpecWithImage("spire-agent", "agent:1"),
+			desired:       podSpecWithImage("spire-agent", "agent:1"),
+			expected:      true,
+		},
+		{
+			name:          "image changed",
+			currentLabels: map[string]string{versionLabelKey: "1.0.0"},
+			desiredLabels: map[string]string{versionLabelKey: "1.0.0"},
+			current:       podSpecWithImage("spire-agent", "agent:1"),
+			desired:       podSpecWithImage("spire-agent", "agent:2"),
+			expected:      true,
+		},
+		{
+			name:          "missing current version label is not a version change",
+			currentLabels: map[string]string{},
+			desiredLabels: map[string]string{versionLabelKey: "1.1.0"},
+			current:       podSpecWithImage("spire-agent", "agent:1"),
+			desired:       podSpecWithImage("spire-agent", "agent:1"),
+			expected:      false,
+		},
+		{
+			name:          "other container image change is ignored",
+			currentLabels: map[string]string{versionLabelKey: "1.0.0"},
+			desiredLabels: map[string]string{versionLabelKey: "1.0.0"},
+			current:       podSpecWithImage("sidecar", "sidecar:1"),
+			desired:       podSpecWithImage("sidecar", "sidecar:2"),
+			expected:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsOperandVersionChange(tt.currentLabels, tt.desiredLabels, tt.current, tt.desired, "spire-agent")
+			if got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// serverStatefulSet returns the SPIRE server StatefulSet labelled with version, running image
+func serverStatefulSet(version, image string, ready int32) appsv1.StatefulSet {
+	sts := appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "spire-server", Labels: map[string]string{versionLabelKey: version}}}
+	sts.Spec.Replicas = pointer.Int32(1)
+	sts.Spec.Template.Spec = *podSpecWithImage("spire-server", image)
+	sts.Status.ReadyReplicas = ready
+	sts.Status.UpdatedReplicas = ready
+	return sts
+}
+
+// agentDaemonSet returns an agent pool DaemonSet labelled with version, running image
+func agentDaemonSet(name, version, image string) appsv1.DaemonSet {
+	ds := appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{versionLabelKey: version}}}
+	ds.Spec.Template.Spec = *podSpecWithImage("spire-agent", image)
+	ds.Status.DesiredNumberScheduled = 2
+	ds.Status.NumberReady = 2
+	ds.Status.NumberAvailable = 2
+	ds.Status.UpdatedNumberScheduled = 2
+	return ds
+}
+
+// listWorkloads returns a List stub serving the workloads, the StatefulSets and DaemonSets
+// selected by the labels of their operand
+func listWorkloads(statefulSets []appsv1.StatefulSet, daemonSets []appsv1.DaemonSet) func(context.Context, client.ObjectList, ...client.ListOption) error {
+	return func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+		listOpts := &client.ListOptions{}
+		listOpts.ApplyOptions(opts)
+		switch l := list.(type) {
+		case *appsv1.StatefulSetList:
+			if listOpts.LabelSelector.Matches(labels.Set(utils.SpireServerLabels(nil))) {
+				l.Items = statefulSets
+			}
+		case *appsv1.DaemonSetList:
+			if listOpts.LabelSelector.Matches(labels.Set(utils.SpireAgentLabels(nil))) {
+				l.Items = daemonSets
+			}
+		}
+		return nil
+	}
+}
+
+func TestGetOperandVersionStatus(t *testing.T) {
+	t.Setenv(utils.SpireServerImageEnv, "server:new")
+
+	tests := []struct {
+		name         string
+		statefulSets []appsv1.StatefulSet
+		listErr      error
+		expectErr    bool
+		expected     OperandVersionStatus
+		expectSkew   bool
+	}{
+		{
+			name:     "workload not found",
+			expected: OperandVersionStatus{Kind: utils.ResourceKindSpireServer, ExpectedVersion: version.SpireServerVersion},
+		},
+		{
+			name:      "list error",
+			listErr:   errors.New("boom"),
+			expectErr: true,
+		},
+		{
+			name:         "old image is skewed",
+			statefulSets: []appsv1.StatefulSet{serverStatefulSet("0.0.1", "server:old", 0)},
+			expected: OperandVersionStatus{
+				Kind:            utils.ResourceKindSpireServer,
+				Deployed:        true,
+				CurrentVersion:  "0.0.1",
+				ExpectedVersion: version.SpireServerVersion,
+			},
+			expectSkew: true,
+		},
+		{
+			name:         "updated and healthy is rolled out",
+			statefulSets: []appsv1.StatefulSet{serverStatefulSet(version.SpireServerVersion, "server:new", 1)},
+			expected: OperandVersionStatus{
+				Kind:            utils.ResourceKindSpireServer,
+				Deployed:        true,
+				CurrentVersion:  version.SpireServerVersion,
+				ExpectedVersion: version.SpireServerVersion,
+				SpecUpToDate:    true,
+				RolledOut:       true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.ListStub = listWorkloads(tt.statefulSets, nil)
+			if tt.listErr != nil {
+				fakeClient.ListReturns(tt.listErr)
+			}
+
+			got, err := GetOperandVersionStatus(context.Background(), fakeClient, utils.ResourceKindSpireServer)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, got)
+			}
+			if got.InSkew() != tt.expectSkew {
+				t.Errorf("Expected InSkew %v, got %v", tt.expectSkew, got.InSkew())
+			}
+		})
+	}
+}
+
+func TestGetOperandVersionStatus_AgentPools(t *testing.T) {
+	t.Setenv(utils.SpireAgentImageEnv, "agent:new")
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	fakeClient.ListStub = listWorkloads(nil, []appsv1.DaemonSet{
+		agentDaemonSet("spire-agent", version.SpireAgentVersion, "agent:new"),
+		agentDaemonSet("spire-agent-gpu", "0.0.1", "agent:old"),
+	})
+
+	got, err := GetOperandVersionStatus(context.Background(), fakeClient, utils.ResourceKindSpireAgent)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !got.Deployed || got.SpecUpToDate || got.RolledOut || got.CurrentVersion != "0.0.1" {
+		t.Errorf("Expected the agents to be skewed until every pool is upgraded, got %+v", got)
+	}
+}
+
+func TestGetOperandVersionStatus_UnknownKind(t *testing.T) {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	if _, err := GetOperandVersionStatus(context.Background(), fakeClient, "Unknown"); err == nil {
+		t.Error("Expected error for unknown operand kind")
+	}
+}
+
+func TestUpgradePrerequisitesMet(t *testing.T) {
+	t.Setenv(utils.SpireServerImageEnv, "server:new")
+
+	rollingServer := listWorkloads([]appsv1.StatefulSet{serverStatefulSet("", "server:new", 0)}, nil)
+
+	t.Run("first operand has no prerequisites", func(t *testing.T) {
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		met, _, err := UpgradePrerequisitesMet(context.Background(), fakeClient, utils.ResourceKindSpireServer)
+		if err != nil || !met {
+			t.Errorf("Expected prerequisites met without error, got met=%v err=%v", met, err)
+		}
+		if fakeClient.ListCallCount() != 0 {
+			t.Errorf("Expected no List calls, got %d", fakeClient.ListCallCount())
+		}
+	})
+
+	t.Run("agent waits for rolling server", func(t *testing.T) {
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		fakeClient.ListStub = rollingServer
+		met, message, err := UpgradePrerequisitesMet(context.Background(), fakeClient, utils.ResourceKindSpireAgent)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if met {
+			t.Error("Expected prerequisites not met while server is rolling out")
+		}
+		if message == "" {
+			t.Error("Expected message describing pending operands")
+		}
+	})
+
+	t.Run("operands not deployed do not block", func(t *testing.T) {
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		met, _, err := UpgradePrerequisitesMet(context.Background(), fakeClient, utils.ResourceKindSpireOIDCDiscoveryProvider)
+		if err != nil || !met {
+			t.Errorf("Expected prerequisites met without error, got met=%v err=%v", met, err)
+		}
+		if fakeClient.ListCallCount() != 3 {
+			t.Errorf("Expected 3 List calls, got %d", fakeClient.ListCallCount())
+		}
+	})
+}
+
+func TestCheckUpgradeOrder(t *testing.T) {
+	t.Run("no version change is always allowed", func(t *testing.T) {
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		mgr := NewManager(fakeClient)
+		if !mgr.CheckUpgradeOrder(context.Background(), utils.ResourceKindSpireAgent, nil, false) {
+			t.Error("Expected update to be allowed")
+		}
+		if _, exists := mgr.conditions[utils.UpgradeInProgressStatusType]; exists {
+			t.Error("Expected no UpgradeInProgress condition")
+		}
+	})
+
+	t.Run("blocked version change sets condition", func(t *testing.T) {
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		fakeClient.ListReturns(errors.New("boom"))
+		mgr := NewManager(fakeClient)
+		if mgr.CheckUpgradeOrder(context.Background(), utils.ResourceKindSpireAgent, nil, true) {
+			t.Error("Expected update to be deferred")
+		}
+		cond := mgr.conditions[utils.UpgradeInProgressStatusType]
+		if cond.Status != metav1.ConditionTrue || cond.Reason != utils.UpgradeReasonWaitingForPrerequisiteOperands {
+			t.Errorf("Unexpected condition: %+v", cond)
+		}
+	})
+
+	t.Run("previously deferred upgrade is reported complete", func(t *testing.T) {
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		mgr := NewManager(fakeClient)
+		existing := []metav1.Condition{{Type: utils.UpgradeInProgressStatusType, Status: metav1.ConditionTrue}}
+		if !mgr.CheckUpgradeOrder(context.Background(), utils.ResourceKindSpireAgent, existing, true) {
+			t.Error("Expected update to be allowed")
+		}
+		cond := mgr.conditions[utils.UpgradeInProgressStatusType]
+		if cond.Status != metav1.ConditionFalse || cond.Reason != utils.UpgradeReasonComplete {
+			t.Errorf("Unexpected condition: %+v", cond)
+		}
+	})
+}
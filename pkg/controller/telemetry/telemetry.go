@@ -0,0 +1,208 @@
+// Package telemetry manages the statsd to Prometheus bridge of the SPIRE components. SPIRE sends its
+// DogStatsD telemetry to a statsd_exporter sidecar listening on the loopback interface of the pod,
+// whose translated Prometheus metrics are exposed through a dedicated Service.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	// BridgeAvailable reports the Service exposing the metrics of the statsd bridge
+	BridgeAvailable = "TelemetryBridgeAvailable"
+
+	// ContainerName is the name of the bridge sidecar
+	ContainerName = "statsd-bridge"
+
+	// statsdAddress is the address the bridge receives the DogStatsD telemetry on, only reachable
+	// from the containers of the pod
+	statsdAddress = "127.0.0.1:9125"
+
+	metricsPortName    = "bridge-metrics"
+	defaultMetricsPort = 9102
+)
+
+// BridgeEnabled reports whether the statsd bridge is requested
+func BridgeEnabled(config *v1alpha2.TelemetryConfig) bool {
+	return config != nil && config.StatsdBridge != nil
+}
+
+// metricsPort returns the port the bridge serves the metrics on
+func metricsPort(config *v1alpha2.StatsdBridgeConfig) int32 {
+	if config.MetricsPort == 0 {
+		return defaultMetricsPort
+	}
+	return config.MetricsPort
+}
+
+// ConfigureSPIRE adds the DogStatsD sink of the bridge to the telemetry section of the SPIRE
+// configuration when the bridge is enabled
+func ConfigureSPIRE(telemetry map[string]interface{}, config *v1alpha2.TelemetryConfig) {
+	if !BridgeEnabled(config) {
+		return
+	}
+	telemetry["DogStatsd"] = []map[string]interface{}{{"address": statsdAddress}}
+}
+
+// AddBridge adds the bridge sidecar to the pod when the bridge is enabled
+func AddBridge(podSpec *corev1.PodSpec, config *v1alpha2.TelemetryConfig) {
+	if !BridgeEnabled(config) {
+		return
+	}
+	port := metricsPort(config.StatsdBridge)
+	podSpec.Containers = append(podSpec.Containers, corev1.Container{
+		Name:            ContainerName,
+		Image:           utils.GetStatsdExporterImage(),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Args: []string{
+			"--statsd.listen-udp=" + statsdAddress,
+			// SPIRE only sends over UDP
+			"--statsd.listen-tcp=",
+			"--web.listen-address=:" + strconv.Itoa(int(port)),
+		},
+		Ports: []corev1.ContainerPort{
+			{Name: metricsPortName, ContainerPort: port, Protocol: corev1.ProtocolTCP},
+		},
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{Path: "/metrics", Port: intstr.FromString(metricsPortName)},
+			},
+		},
+		Resources: utils.DerefResourceRequirements(config.StatsdBridge.Resources),
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: ptr.To(false),
+			Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+			ReadOnlyRootFilesystem:   ptr.To(true),
+			RunAsNonRoot:             ptr.To(true),
+		},
+	})
+}
+
+// GenerateService returns the Service exposing the metrics of the bridges of the SPIRE component
+func GenerateService(component string, labels, selector map[string]string, config *v1alpha2.TelemetryConfig) *corev1.Service {
+	port := int32(defaultMetricsPort)
+	if BridgeEnabled(config) {
+		port = metricsPort(config.StatsdBridge)
+	}
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      component + "-telemetry",
+			Namespace: utils.GetOperatorNamespace(),
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       metricsPortName,
+					Port:       port,
+					TargetPort: intstr.FromString(metricsPortName),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+			Selector: selector,
+		},
+	}
+}
+
+// ReconcileService creates or updates the Service exposing the metrics of the bridge while it is
+// enabled, and deletes it once the bridge is disabled. Services not controlled by the owner are
+// left untouched.
+func ReconcileService(ctx context.Context, c customClient.CustomCtrlClient, scheme *runtime.Scheme, owner client.Object, desired *corev1.Service,
+	config *v1alpha2.TelemetryConfig, existingConditions []metav1.Condition, statusMgr *status.Manager, createOnlyMode, adopt bool) error {
+	name := fmt.Sprintf("%s/%s", desired.Namespace, desired.Name)
+	if !BridgeEnabled(config) {
+		// Only clean up if the bridge was previously enabled
+		if apimeta.FindStatusCondition(existingConditions, BridgeAvailable) == nil {
+			return nil
+		}
+		if err := deleteService(ctx, c, owner, desired); err != nil {
+			statusMgr.AddCondition(BridgeAvailable, "TelemetryBridgeDeletionFailed",
+				fmt.Sprintf("Failed to delete Service %s: %v", name, err),
+				metav1.ConditionFalse)
+			return err
+		}
+		statusMgr.AddCondition(BridgeAvailable, "TelemetryBridgeNotConfigured",
+			"The statsd telemetry bridge is not configured",
+			metav1.ConditionTrue)
+		return nil
+	}
+
+	if err := applyService(ctx, c, scheme, owner, desired, createOnlyMode, adopt); err != nil {
+		statusMgr.AddCondition(BridgeAvailable, "TelemetryBridgeServiceFailed",
+			fmt.Sprintf("Failed to apply Service %s: %v", name, err),
+			metav1.ConditionFalse)
+		return err
+	}
+	statusMgr.AddCondition(BridgeAvailable, v1alpha2.ReasonReady,
+		fmt.Sprintf("The metrics of the statsd telemetry bridge are exposed through Service %s on port %d", name, desired.Spec.Ports[0].Port),
+		metav1.ConditionTrue)
+	return nil
+}
+
+// applyService creates or updates the Service of the bridge
+func applyService(ctx context.Context, c customClient.CustomCtrlClient, scheme *runtime.Scheme, owner client.Object, desired *corev1.Service, createOnlyMode, adopt bool) error {
+	if err := controllerutil.SetControllerReference(owner, desired, scheme); err != nil {
+		return fmt.Errorf("failed to set the owner: %w", err)
+	}
+
+	existing := &corev1.Service{}
+	if err := c.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return err
+		}
+		return c.Create(ctx, desired, customClient.AdoptExisting(adopt))
+	}
+	if createOnlyMode {
+		return nil
+	}
+
+	// Preserve the fields set by Kubernetes before the comparison
+	desired.ResourceVersion = existing.ResourceVersion
+	desired.Spec.ClusterIP = existing.Spec.ClusterIP
+	desired.Spec.ClusterIPs = existing.Spec.ClusterIPs
+	desired.Spec.IPFamilies = existing.Spec.IPFamilies
+	desired.Spec.IPFamilyPolicy = existing.Spec.IPFamilyPolicy
+	desired.Spec.InternalTrafficPolicy = existing.Spec.InternalTrafficPolicy
+	desired.Spec.SessionAffinity = existing.Spec.SessionAffinity
+	if !utils.ResourceNeedsUpdate(existing, desired) {
+		return nil
+	}
+	return c.Update(ctx, desired)
+}
+
+// deleteService deletes the Service of the bridge if controlled by the owner
+func deleteService(ctx context.Context, c customClient.CustomCtrlClient, owner client.Object, desired *corev1.Service) error {
+	existing := &corev1.Service{}
+	if err := c.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(existing, owner) {
+		return nil
+	}
+	if err := c.Delete(ctx, existing); err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+)
+
+func TestConfigureSPIRE(t *testing.T) {
+	telemetry := map[string]interface{}{"Prometheus": map[string]string{"port": "9402"}}
+	ConfigureSPIRE(telemetry, nil)
+	if _, ok := telemetry["DogStatsd"]; ok {
+		t.Fatalf("Expected no DogStatsd sink without bridge, got %v", telemetry)
+	}
+
+	ConfigureSPIRE(telemetry, &v1alpha2.TelemetryConfig{StatsdBridge: &v1alpha2.StatsdBridgeConfig{}})
+	sinks, ok := telemetry["DogStatsd"].([]map[string]interface{})
+	if !ok || len(sinks) != 1 || sinks[0]["address"] != "127.0.0.1:9125" {
+		t.Errorf("Expected the DogStatsd sink of the bridge, got %v", telemetry["DogStatsd"])
+	}
+	if _, ok := telemetry["Prometheus"]; !ok {
+		t.Error("Expected the Prometheus sink kept")
+	}
+}
+
+func TestAddBridge(t *testing.T) {
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "spire-server"}}}
+	AddBridge(podSpec, &v1alpha2.TelemetryConfig{})
+	if len(podSpec.Containers) != 1 {
+		t.Fatalf("Expected no bridge without statsdBridge, got %v", podSpec.Containers)
+	}
+
+	AddBridge(podSpec, &v1alpha2.TelemetryConfig{StatsdBridge: &v1alpha2.StatsdBridgeConfig{MetricsPort: 9200}})
+	if len(podSpec.Containers) != 2 || podSpec.Containers[1].Name != ContainerName {
+		t.Fatalf("Expected the bridge sidecar, got %v", podSpec.Containers)
+	}
+	bridge := podSpec.Containers[1]
+	if bridge.Ports[0].ContainerPort != 9200 {
+		t.Errorf("Expected the metrics served on port 9200, got %v", bridge.Ports)
+	}
+	expectedArgs := []string{"--statsd.listen-udp=127.0.0.1:9125", "--statsd.listen-tcp=", "--web.listen-address=:9200"}
+	if len(bridge.Args) != len(expectedArgs) {
+		t.Fatalf("Expected args %v, got %v", expectedArgs, bridge.Args)
+	}
+	for i := range expectedArgs {
+		if bridge.Args[i] != expectedArgs[i] {
+			t.Errorf("Expected arg %q, got %q", expectedArgs[i], bridge.Args[i])
+		}
+	}
+}
+
+func TestReconcileService(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1alpha2.AddToScheme(scheme)
+	owner := &v1alpha2.SpireServer{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha2.GroupVersion.String(), Kind: "SpireServer"},
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "server-uid"},
+	}
+	enabled := &v1alpha2.TelemetryConfig{StatsdBridge: &v1alpha2.StatsdBridgeConfig{}}
+	controlled := GenerateService("spire-server", nil, nil, enabled)
+	controlled.OwnerReferences = []metav1.OwnerReference{{UID: "server-uid", Controller: ptr.To(true)}}
+	previouslyEnabled := []metav1.Condition{{Type: BridgeAvailable, Status: metav1.ConditionTrue}}
+
+	tests := []struct {
+		name               string
+		config             *v1alpha2.TelemetryConfig
+		existing           *corev1.Service
+		existingConditions []metav1.Condition
+		expectCreate       bool
+		expectDelete       bool
+		expectCondition    bool
+	}{
+		{
+			name:            "Service created",
+			config:          enabled,
+			expectCreate:    true,
+			expectCondition: true,
+		},
+		{
+			name:            "Service up to date",
+			config:          enabled,
+			existing:        controlled,
+			expectCondition: true,
+		},
+		{
+			name: "bridge never enabled",
+		},
+		{
+			name:               "Service deleted once disabled",
+			existing:           controlled,
+			existingConditions: previouslyEnabled,
+			expectDelete:       true,
+			expectCondition:    true,
+		},
+		{
+			name:               "Service not controlled left untouched",
+			existing:           GenerateService("spire-server", nil, nil, enabled),
+			existingConditions: previouslyEnabled,
+			expectCondition:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				if tt.existing == nil {
+					return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+				}
+				tt.existing.DeepCopyInto(obj.(*corev1.Service))
+				return nil
+			}
+			statusMgr := status.NewManager(fakeClient)
+
+			desired := GenerateService("spire-server", nil, nil, tt.config)
+			err := ReconcileService(context.Background(), fakeClient, scheme, owner, desired, tt.config, tt.existingConditions, statusMgr, false, false)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if created := fakeClient.CreateCallCount() == 1; created != tt.expectCreate {
+				t.Errorf("Expected create %v, got %v", tt.expectCreate, created)
+			}
+			if deleted := fakeClient.DeleteCallCount() == 1; deleted != tt.expectDelete {
+				t.Errorf("Expected delete %v, got %v", tt.expectDelete, deleted)
+			}
+			if fakeClient.UpdateCallCount() != 0 {
+				t.Errorf("Expected no update, got %d", fakeClient.UpdateCallCount())
+			}
+			cond, found := statusMgr.GetCondition(BridgeAvailable)
+			if found != tt.expectCondition {
+				t.Fatalf("Expected the %s condition %v, got %v", BridgeAvailable, tt.expectCondition, cond)
+			}
+			if found && cond.Status != metav1.ConditionTrue {
+				t.Errorf("Expected %s True, got %v", BridgeAvailable, cond)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Package topology derives the topology profile of the cluster from the OpenShift Infrastructure
+// config. The operand defaults which depend on it, such as PodDisruptionBudgets and topology
+// spread, follow the profile, so they are re-evaluated when the cluster changes between a single
+// node and a highly available control plane.
+package topology
+
+import (
+	"context"
+	"fmt"
+
+	configv1 "github.com/openshift/api/config/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// Profile is the set of defaults applied for the topology of the cluster
+type Profile = v1alpha2.TopologyProfile
+
+const (
+	// ProfileHighlyAvailable spreads the replicas of the operands and protects them with
+	// PodDisruptionBudgets. It is used when the topology is not known.
+	ProfileHighlyAvailable = v1alpha2.TopologyProfileHighlyAvailable
+	// ProfileSingleNode is used on single-node clusters, where spreading, anti-affinity and
+	// disruption budgets would only block scheduling and node drains. Operands also default to
+	// the singleNode sizing profile.
+	ProfileSingleNode = v1alpha2.TopologyProfileSingleNode
+
+	// ConditionType is the condition of the ZeroTrustWorkloadIdentityManager noting the active profile
+	ConditionType = "TopologyProfile"
+
+	// infrastructureName is the name of the cluster Infrastructure config
+	infrastructureName = "cluster"
+)
+
+// Detect returns the topology profile of the cluster and the control plane topology it was derived
+// from. Clusters without an Infrastructure config, e.g. MicroShift, keep the highly available
+// defaults unless the profile is set on the ZeroTrustWorkloadIdentityManager.
+func Detect(ctx context.Context, c customClient.CustomCtrlClient) (Profile, configv1.TopologyMode, error) {
+	if !utils.HasCapability(utils.CapabilityInfrastructure) {
+		return ProfileHighlyAvailable, "", nil
+	}
+	var infra configv1.Infrastructure
+	err := c.Get(ctx, types.NamespacedName{Name: infrastructureName}, &infra)
+	if kerrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+		return ProfileHighlyAvailable, "", nil
+	}
+	if err != nil {
+		return ProfileHighlyAvailable, "", fmt.Errorf("failed to get Infrastructure %s: %w", infrastructureName, err)
+	}
+	return FromInfrastructure(&infra), infra.Status.ControlPlaneTopology, nil
+}
+
+// Resolve returns the topology profile set on the ZeroTrustWorkloadIdentityManager, or detects it
+// from the Infrastructure config when unset
+func Resolve(ctx context.Context, c customClient.CustomCtrlClient, ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager) (Profile, error) {
+	if ztwim.Spec.TopologyProfile != "" {
+		return ztwim.Spec.TopologyProfile, nil
+	}
+	profile, _, err := Detect(ctx, c)
+	return profile, err
+}
+
+// SizingProfile returns the sizing profile of the operands: the one set on the
+// ZeroTrustWorkloadIdentityManager, or singleNode on single-node clusters
+func SizingProfile(profile Profile, sizingProfile v1alpha2.SizingProfile) v1alpha2.SizingProfile {
+	if sizingProfile == "" && profile == ProfileSingleNode {
+		return v1alpha2.SizingProfileSingleNode
+	}
+	return sizingProfile
+}
+
+// ApplyToOperand fills in the resources left unset on an operand of the given kind from the
+// sizing profile, and drops its pod anti-affinity on single-node clusters, where it can only
+// keep replicas from scheduling. The CR is only changed in memory.
+func ApplyToOperand(profile Profile, sizingProfile v1alpha2.SizingProfile, kind string, config *v1alpha2.CommonConfig) {
+	utils.ApplySizingProfile(SizingProfile(profile, sizingProfile), kind, config)
+	if profile == ProfileSingleNode && config.Affinity != nil && config.Affinity.PodAntiAffinity != nil {
+		affinity := config.Affinity.DeepCopy()
+		affinity.PodAntiAffinity = nil
+		config.Affinity = affinity
+	}
+}
+
+// FromInfrastructure returns the topology profile of the Infrastructure config. With an external
+// control plane, e.g. hosted control planes, the operands run on the workers, so the topology of
+// the infrastructure nodes is used instead.
+func FromInfrastructure(infra *configv1.Infrastructure) Profile {
+	topology := infra.Status.ControlPlaneTopology
+	if topology == configv1.ExternalTopologyMode {
+		topology = infra.Status.InfrastructureTopology
+	}
+	if topology == configv1.SingleReplicaTopologyMode {
+		return ProfileSingleNode
+	}
+	return ProfileHighlyAvailable
+}
+
+// ChangedPredicate passes the Infrastructure events changing the topology profile. The topology
+// is set in the status, so generation changes do not catch it.
+var ChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldInfra, ok := e.ObjectOld.(*configv1.Infrastructure)
+		if !ok {
+			return false
+		}
+		newInfra, ok := e.ObjectNew.(*configv1.Infrastructure)
+		if !ok {
+			return false
+		}
+		return FromInfrastructure(oldInfra) != FromInfrastructure(newInfra)
+	},
+}
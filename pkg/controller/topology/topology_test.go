@@ -0,0 +1,154 @@
+package topology
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func infrastructure(controlPlane, infra configv1.TopologyMode) *configv1.Infrastructure {
+	return &configv1.Infrastructure{
+		ObjectMeta: metav1.ObjectMeta{Name: infrastructureName},
+		Status: configv1.InfrastructureStatus{
+			ControlPlaneTopology:   controlPlane,
+			InfrastructureTopology: infra,
+		},
+	}
+}
+
+func TestFromInfrastructure(t *testing.T) {
+	tests := []struct {
+		name         string
+		controlPlane configv1.TopologyMode
+		infra        configv1.TopologyMode
+		expected     Profile
+	}{
+		{"highly available", configv1.HighlyAvailableTopologyMode, configv1.HighlyAvailableTopologyMode, ProfileHighlyAvailable},
+		{"single node", configv1.SingleReplicaTopologyMode, configv1.SingleReplicaTopologyMode, ProfileSingleNode},
+		{"arbiter", configv1.HighlyAvailableArbiterMode, configv1.HighlyAvailableTopologyMode, ProfileHighlyAvailable},
+		{"external control plane, single worker", configv1.ExternalTopologyMode, configv1.SingleReplicaTopologyMode, ProfileSingleNode},
+		{"external control plane", configv1.ExternalTopologyMode, configv1.HighlyAvailableTopologyMode, ProfileHighlyAvailable},
+		{"not set", "", "", ProfileHighlyAvailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromInfrastructure(infrastructure(tt.controlPlane, tt.infra)); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	t.Run("single node", func(t *testing.T) {
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		fakeClient.GetStub = func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			*obj.(*configv1.Infrastructure) = *infrastructure(configv1.SingleReplicaTopologyMode, configv1.SingleReplicaTopologyMode)
+			return nil
+		}
+		profile, controlPlane, err := Detect(context.Background(), fakeClient)
+		if err != nil || profile != ProfileSingleNode || controlPlane != configv1.SingleReplicaTopologyMode {
+			t.Errorf("Expected the single node profile, got %s %s %v", profile, controlPlane, err)
+		}
+	})
+
+	t.Run("no Infrastructure", func(t *testing.T) {
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		fakeClient.GetReturns(kerrors.NewNotFound(schema.GroupResource{Group: "config.openshift.io", Resource: "infrastructures"}, infrastructureName))
+		profile, _, err := Detect(context.Background(), fakeClient)
+		if err != nil || profile != ProfileHighlyAvailable {
+			t.Errorf("Expected the highly available profile, got %s %v", profile, err)
+		}
+	})
+
+	t.Run("get error", func(t *testing.T) {
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		fakeClient.GetReturns(errors.New("connection refused"))
+		if _, _, err := Detect(context.Background(), fakeClient); err == nil {
+			t.Error("Expected an error")
+		}
+	})
+}
+
+func TestChangedPredicate(t *testing.T) {
+	ha := infrastructure(configv1.HighlyAvailableTopologyMode, configv1.HighlyAvailableTopologyMode)
+	sno := infrastructure(configv1.SingleReplicaTopologyMode, configv1.SingleReplicaTopologyMode)
+
+	if !ChangedPredicate.Update(event.UpdateEvent{ObjectOld: sno, ObjectNew: ha}) {
+		t.Error("Expected a topology change to pass")
+	}
+	if ChangedPredicate.Update(event.UpdateEvent{ObjectOld: ha, ObjectNew: ha.DeepCopy()}) {
+		t.Error("Expected an update without topology change to be filtered")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	fakeClient.GetStub = func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+		*obj.(*configv1.Infrastructure) = *infrastructure(configv1.HighlyAvailableTopologyMode, configv1.HighlyAvailableTopologyMode)
+		return nil
+	}
+
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{}
+	if profile, err := Resolve(context.Background(), fakeClient, ztwim); err != nil || profile != ProfileHighlyAvailable {
+		t.Errorf("Expected the detected profile, got %s %v", profile, err)
+	}
+
+	ztwim.Spec.TopologyProfile = v1alpha2.TopologyProfileSingleNode
+	if profile, err := Resolve(context.Background(), fakeClient, ztwim); err != nil || profile != ProfileSingleNode {
+		t.Errorf("Expected the profile set on the ZeroTrustWorkloadIdentityManager, got %s %v", profile, err)
+	}
+	if fakeClient.GetCallCount() != 1 {
+		t.Errorf("Expected the Infrastructure not to be read when the profile is set, got %d reads", fakeClient.GetCallCount())
+	}
+}
+
+func TestSizingProfile(t *testing.T) {
+	if got := SizingProfile(ProfileSingleNode, ""); got != v1alpha2.SizingProfileSingleNode {
+		t.Errorf("Expected the singleNode sizing profile on single-node clusters, got %q", got)
+	}
+	if got := SizingProfile(ProfileSingleNode, v1alpha2.SizingProfileMedium); got != v1alpha2.SizingProfileMedium {
+		t.Errorf("Expected the configured sizing profile to take precedence, got %q", got)
+	}
+	if got := SizingProfile(ProfileHighlyAvailable, ""); got != "" {
+		t.Errorf("Expected no sizing profile on highly available clusters, got %q", got)
+	}
+}
+
+func TestApplyToOperand(t *testing.T) {
+	antiAffinity := &corev1.Affinity{
+		NodeAffinity:    &corev1.NodeAffinity{},
+		PodAntiAffinity: &corev1.PodAntiAffinity{RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{TopologyKey: "kubernetes.io/hostname"}}},
+	}
+
+	config := &v1alpha2.CommonConfig{Affinity: antiAffinity}
+	ApplyToOperand(ProfileSingleNode, "", utils.ResourceKindSpireServer, config)
+	if config.Affinity.PodAntiAffinity != nil || config.Affinity.NodeAffinity == nil {
+		t.Errorf("Expected only the pod anti-affinity to be dropped, got %+v", config.Affinity)
+	}
+	if antiAffinity.PodAntiAffinity == nil {
+		t.Error("Expected the original affinity to be left alone")
+	}
+	if config.Resources == nil || !config.Resources.Requests.Cpu().Equal(resource.MustParse("50m")) {
+		t.Errorf("Expected the singleNode resources, got %+v", config.Resources)
+	}
+
+	config = &v1alpha2.CommonConfig{Affinity: antiAffinity}
+	ApplyToOperand(ProfileHighlyAvailable, "", utils.ResourceKindSpireServer, config)
+	if config.Affinity.PodAntiAffinity == nil || config.Resources != nil {
+		t.Errorf("Expected the operand to be left alone on highly available clusters, got %+v", config)
+	}
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+// MinAgentAvailabilityTarget is the shortest availability target SPIRE accepts
+const MinAgentAvailabilityTarget = 24 * time.Hour
+
+// AgentSVIDTTL returns the lifetime of the agent SVIDs issued by the server
+func AgentSVIDTTL(server *v1alpha2.SpireServerSpec) time.Duration {
+	if server.AgentTTL != nil {
+		return server.AgentTTL.Duration
+	}
+	return server.DefaultX509Validity.Duration
+}
+
+// ValidateAgentAvailability checks the availability settings of the agent, and when the server
+// is known, that its agent SVIDs outlive the availability target
+func ValidateAgentAvailability(server *v1alpha2.SpireServerSpec, agent *v1alpha2.SpireAgentSpec) error {
+	availability := agent.Availability
+	if availability == nil {
+		return nil
+	}
+	if availability.RebootstrapMode != "" && availability.RebootstrapMode != v1alpha2.RebootstrapModeNever &&
+		agent.NodeAttestor != nil && agent.NodeAttestor.JoinToken != nil {
+		return fmt.Errorf("rebootstrapMode %s requires the k8s_psat node attestor, join tokens cannot be reused to re-attest", availability.RebootstrapMode)
+	}
+	if availability.Target == nil {
+		return nil
+	}
+	target := availability.Target.Duration
+	if target < MinAgentAvailabilityTarget {
+		return fmt.Errorf("availability target %s must be at least %s", target, MinAgentAvailabilityTarget)
+	}
+	if server != nil {
+		if ttl := AgentSVIDTTL(server); ttl <= target {
+			return fmt.Errorf("availability target %s must be shorter than the lifetime of the agent SVIDs, %s, set by the agentTTL of the SpireServer", target, ttl)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+func TestValidateAgentAvailability(t *testing.T) {
+	duration := func(d time.Duration) *metav1.Duration { return &metav1.Duration{Duration: d} }
+	server := &v1alpha2.SpireServerSpec{DefaultX509Validity: metav1.Duration{Duration: time.Hour}, AgentTTL: duration(72 * time.Hour)}
+
+	tests := []struct {
+		name          string
+		server        *v1alpha2.SpireServerSpec
+		agent         v1alpha2.SpireAgentSpec
+		expectedError string
+	}{
+		{
+			name:  "no availability",
+			agent: v1alpha2.SpireAgentSpec{},
+		},
+		{
+			name:   "target shorter than the agent TTL",
+			server: server,
+			agent:  v1alpha2.SpireAgentSpec{Availability: &v1alpha2.AgentAvailabilityConfig{Target: duration(48 * time.Hour)}},
+		},
+		{
+			name:  "server not created yet",
+			agent: v1alpha2.SpireAgentSpec{Availability: &v1alpha2.AgentAvailabilityConfig{Target: duration(48 * time.Hour)}},
+		},
+		{
+			name:          "target under the minimum",
+			agent:         v1alpha2.SpireAgentSpec{Availability: &v1alpha2.AgentAvailabilityConfig{Target: duration(12 * time.Hour)}},
+			expectedError: "must be at least 24h0m0s",
+		},
+		{
+			name:          "target outliving the agent TTL",
+			server:        server,
+			agent:         v1alpha2.SpireAgentSpec{Availability: &v1alpha2.AgentAvailabilityConfig{Target: duration(72 * time.Hour)}},
+			expectedError: "must be shorter than the lifetime of the agent SVIDs, 72h0m0s",
+		},
+		{
+			name:          "target outliving the default X509 validity",
+			server:        &v1alpha2.SpireServerSpec{DefaultX509Validity: metav1.Duration{Duration: time.Hour}},
+			agent:         v1alpha2.SpireAgentSpec{Availability: &v1alpha2.AgentAvailabilityConfig{Target: duration(24 * time.Hour)}},
+			expectedError: "must be shorter than the lifetime of the agent SVIDs, 1h0m0s",
+		},
+		{
+			name: "rebootstrap with join tokens",
+			agent: v1alpha2.SpireAgentSpec{
+				NodeAttestor: &v1alpha2.NodeAttestor{JoinToken: &v1alpha2.JoinTokenConfig{}},
+				Availability: &v1alpha2.AgentAvailabilityConfig{RebootstrapMode: v1alpha2.RebootstrapModeAuto},
+			},
+			expectedError: "requires the k8s_psat node attestor",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAgentAvailability(tt.server, &tt.agent)
+			if tt.expectedError == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+				t.Errorf("Expected an error containing %q, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+const (
+	// SupportedArchitecturesEnv lists, comma separated, the CPU architectures the operand images
+	// are published for. It defaults to all the architectures the operator supports.
+	SupportedArchitecturesEnv = "SUPPORTED_ARCHITECTURES"
+
+	// Architecture condition type and reasons. ArchitecturesSkipped is True while nodes are left
+	// without agents because no operand image is available for their architecture.
+	ArchitecturesSkippedStatusType     = "ArchitecturesSkipped"
+	ArchitecturesReasonUnsupported     = "UnsupportedArchitectures"
+	ArchitecturesReasonAllSupported    = "AllArchitecturesSupported"
+	ArchitecturesReasonNoneSchedulable = "NoSupportedArchitecture"
+)
+
+// defaultArchitectures are the architectures listed in the operator's CSV
+var defaultArchitectures = []string{"amd64", "arm64", "ppc64le", "s390x"}
+
+// GetSupportedArchitectures returns the architectures the operand images are published for
+func GetSupportedArchitectures() []string {
+	value := os.Getenv(SupportedArchitecturesEnv)
+	if value == "" {
+		return defaultArchitectures
+	}
+	var arches []string
+	for _, arch := range strings.Split(value, ",") {
+		if arch = strings.TrimSpace(arch); arch != "" && !slices.Contains(arches, arch) {
+			arches = append(arches, arch)
+		}
+	}
+	slices.Sort(arches)
+	return arches
+}
+
+// ArchitecturePlacement is where the node agents are scheduled
+type ArchitecturePlacement struct {
+	// Allowed are the architectures the pods may be scheduled on, nil when unconstrained
+	Allowed []string
+	// Skipped are the requested or node architectures without an operand image
+	Skipped []string
+}
+
+// ValidateArchitectures checks that at least one of the requested architectures is supported
+func ValidateArchitectures(requested []v1alpha2.Architecture, supported []string) error {
+	if len(requested) == 0 {
+		return nil
+	}
+	names := architectureNames(requested)
+	for _, arch := range names {
+		if slices.Contains(supported, arch) {
+			return nil
+		}
+	}
+	return fmt.Errorf("none of the requested architectures %s is supported, supported architectures are %s",
+		strings.Join(names, ", "), strings.Join(supported, ", "))
+}
+
+// ResolveArchitectures resolves the architectures the node agents run on from the architectures
+// requested in the CR, the architectures of the cluster nodes and the supported architectures.
+// The placement is left unconstrained when nothing is requested and every node is supported, so
+// existing pods are not rolled. The requested architectures must have passed ValidateArchitectures.
+func ResolveArchitectures(requested []v1alpha2.Architecture, nodeArchitectures, supported []string) ArchitecturePlacement {
+	candidates := architectureNames(requested)
+	if len(candidates) == 0 {
+		candidates = nodeArchitectures
+	}
+
+	var placement ArchitecturePlacement
+	for _, arch := range candidates {
+		if slices.Contains(supported, arch) {
+			placement.Allowed = append(placement.Allowed, arch)
+		} else {
+			placement.Skipped = append(placement.Skipped, arch)
+		}
+	}
+	slices.Sort(placement.Allowed)
+	slices.Sort(placement.Skipped)
+	placement.Allowed = slices.Compact(placement.Allowed)
+	placement.Skipped = slices.Compact(placement.Skipped)
+
+	if len(requested) == 0 && len(placement.Skipped) == 0 {
+		placement.Allowed = nil
+	}
+	return placement
+}
+
+func architectureNames(arches []v1alpha2.Architecture) []string {
+	var names []string
+	for _, arch := range arches {
+		names = append(names, string(arch))
+	}
+	return names
+}
+
+// nodeLister lists objects from the API server, e.g. the operator's CustomCtrlClient
+type nodeLister interface {
+	ListUncached(context.Context, client.ObjectList, ...client.ListOption) error
+}
+
+// GetNodeArchitectures returns the sorted, distinct architectures of the cluster nodes. Only the
+// node metadata is listed, as the architecture is read from the kubernetes.io/arch label.
+func GetNodeArchitectures(ctx context.Context, c nodeLister) ([]string, error) {
+	nodes := &metav1.PartialObjectMetadataList{}
+	nodes.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("NodeList"))
+	if err := c.ListUncached(ctx, nodes); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	var arches []string
+	for _, node := range nodes.Items {
+		if arch := node.Labels[corev1.LabelArchStable]; arch != "" && !slices.Contains(arches, arch) {
+			arches = append(arches, arch)
+		}
+	}
+	slices.Sort(arches)
+	return arches, nil
+}
+
+// WithArchitectureAffinity returns a copy of affinity that additionally requires one of the given
+// architectures. The requirement is added to every required node selector term, as the terms are
+// ORed. affinity is returned unchanged when architectures is empty.
+func WithArchitectureAffinity(affinity *corev1.Affinity, architectures []string) *corev1.Affinity {
+	if len(architectures) == 0 {
+		return affinity
+	}
+	requirement := corev1.NodeSelectorRequirement{
+		Key:      corev1.LabelArchStable,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   slices.Clone(architectures),
+	}
+
+	result := affinity.DeepCopy()
+	if result == nil {
+		result = &corev1.Affinity{}
+	}
+	if result.NodeAffinity == nil {
+		result.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	if result.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		result.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	selector := result.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(selector.NodeSelectorTerms) == 0 {
+		selector.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+	for i := range selector.NodeSelectorTerms {
+		selector.NodeSelectorTerms[i].MatchExpressions = append(selector.NodeSelectorTerms[i].MatchExpressions, requirement)
+	}
+	return result
+}
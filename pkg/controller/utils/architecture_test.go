@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+func TestGetSupportedArchitectures(t *testing.T) {
+	t.Setenv(SupportedArchitecturesEnv, "")
+	if got := GetSupportedArchitectures(); !reflect.DeepEqual(got, []string{"amd64", "arm64", "ppc64le", "s390x"}) {
+		t.Errorf("Expected every architecture by default, got %v", got)
+	}
+
+	t.Setenv(SupportedArchitecturesEnv, " arm64,amd64,,arm64 ")
+	if got := GetSupportedArchitectures(); !reflect.DeepEqual(got, []string{"amd64", "arm64"}) {
+		t.Errorf("Expected the configured architectures, got %v", got)
+	}
+}
+
+func TestValidateArchitectures(t *testing.T) {
+	supported := []string{"amd64", "arm64"}
+	if err := ValidateArchitectures(nil, supported); err != nil {
+		t.Errorf("Expected no architectures to be valid, got %v", err)
+	}
+	if err := ValidateArchitectures([]v1alpha2.Architecture{"s390x", "arm64"}, supported); err != nil {
+		t.Errorf("Expected a supported architecture to be valid, got %v", err)
+	}
+	if err := ValidateArchitectures([]v1alpha2.Architecture{"s390x"}, supported); err == nil {
+		t.Error("Expected an error when no requested architecture is supported")
+	}
+}
+
+func TestResolveArchitectures(t *testing.T) {
+	supported := []string{"amd64", "arm64", "ppc64le"}
+	tests := []struct {
+		name          string
+		requested     []v1alpha2.Architecture
+		nodes         []string
+		expectAllowed []string
+		expectSkipped []string
+	}{
+		{
+			name:  "every node supported",
+			nodes: []string{"amd64", "arm64"},
+		},
+		{
+			name:  "node architectures unknown",
+			nodes: nil,
+		},
+		{
+			name:          "unsupported node architecture",
+			nodes:         []string{"amd64", "s390x"},
+			expectAllowed: []string{"amd64"},
+			expectSkipped: []string{"s390x"},
+		},
+		{
+			name:          "requested architectures",
+			requested:     []v1alpha2.Architecture{"arm64", "amd64"},
+			nodes:         []string{"amd64", "arm64", "ppc64le"},
+			expectAllowed: []string{"amd64", "arm64"},
+		},
+		{
+			name:          "requested unsupported architecture",
+			requested:     []v1alpha2.Architecture{"amd64", "s390x"},
+			nodes:         []string{"amd64"},
+			expectAllowed: []string{"amd64"},
+			expectSkipped: []string{"s390x"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			placement := ResolveArchitectures(tt.requested, tt.nodes, supported)
+			if !reflect.DeepEqual(placement.Allowed, tt.expectAllowed) {
+				t.Errorf("Expected allowed %v, got %v", tt.expectAllowed, placement.Allowed)
+			}
+			if !reflect.DeepEqual(placement.Skipped, tt.expectSkipped) {
+				t.Errorf("Expected skipped %v, got %v", tt.expectSkipped, placement.Skipped)
+			}
+		})
+	}
+}
+
+type fakeNodeLister struct {
+	nodes []metav1.PartialObjectMetadata
+	err   error
+}
+
+func (f fakeNodeLister) ListUncached(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+	list.(*metav1.PartialObjectMetadataList).Items = f.nodes
+	return f.err
+}
+
+func TestGetNodeArchitectures(t *testing.T) {
+	node := func(arch string) metav1.PartialObjectMetadata {
+		return metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{corev1.LabelArchStable: arch}}}
+	}
+	arches, err := GetNodeArchitectures(context.Background(), fakeNodeLister{
+		nodes: []metav1.PartialObjectMetadata{node("s390x"), node("amd64"), node("s390x"), {}},
+	})
+	if err != nil || !reflect.DeepEqual(arches, []string{"amd64", "s390x"}) {
+		t.Errorf("Expected the distinct node architectures, got %v %v", arches, err)
+	}
+
+	if _, err := GetNodeArchitectures(context.Background(), fakeNodeLister{err: errors.New("forbidden")}); err == nil {
+		t.Error("Expected an error when nodes cannot be listed")
+	}
+}
+
+func TestWithArchitectureAffinity(t *testing.T) {
+	archRequirement := corev1.NodeSelectorRequirement{Key: corev1.LabelArchStable, Operator: corev1.NodeSelectorOpIn, Values: []string{"amd64"}}
+
+	if got := WithArchitectureAffinity(nil, nil); got != nil {
+		t.Errorf("Expected no affinity without architectures, got %v", got)
+	}
+
+	got := WithArchitectureAffinity(nil, []string{"amd64"})
+	terms := got.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if len(terms) != 1 || !reflect.DeepEqual(terms[0].MatchExpressions, []corev1.NodeSelectorRequirement{archRequirement}) {
+		t.Errorf("Expected a single architecture term, got %v", terms)
+	}
+
+	zoneRequirement := corev1.NodeSelectorRequirement{Key: corev1.LabelTopologyZone, Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}}
+	userAffinity := &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{
+			{MatchExpressions: []corev1.NodeSelectorRequirement{zoneRequirement}},
+			{MatchFields: []corev1.NodeSelectorRequirement{{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"node-1"}}}},
+		}},
+	}}
+	got = WithArchitectureAffinity(userAffinity, []string{"amd64"})
+	terms = got.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if !reflect.DeepEqual(terms[0].MatchExpressions, []corev1.NodeSelectorRequirement{zoneRequirement, archRequirement}) ||
+		!reflect.DeepEqual(terms[1].MatchExpressions, []corev1.NodeSelectorRequirement{archRequirement}) {
+		t.Errorf("Expected the architecture to be required by every term, got %v", terms)
+	}
+	if len(userAffinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions) != 1 {
+		t.Error("Expected the user affinity not to be modified")
+	}
+}
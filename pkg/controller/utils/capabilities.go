@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// Capability is an optional API the operator integrates with. Full OpenShift serves all of them,
+// while MicroShift and similar distributions only serve some.
+type Capability string
+
+const (
+	// CapabilityRoute is route.openshift.io, used to expose the server and the discovery provider
+	CapabilityRoute Capability = "Route"
+	// CapabilitySecurityContextConstraints is security.openshift.io, used to admit the node agents
+	CapabilitySecurityContextConstraints Capability = "SecurityContextConstraints"
+	// CapabilityOperatorCondition is the OLM OperatorCondition, used to report upgradeability
+	CapabilityOperatorCondition Capability = "OperatorCondition"
+	// CapabilityInfrastructure is the config.openshift.io Infrastructure, used to detect the topology
+	CapabilityInfrastructure Capability = "Infrastructure"
+	// CapabilityImageDigestMirrorSet is the config.openshift.io ImageDigestMirrorSet, used to check
+	// the operand images are pulled through the mirrors
+	CapabilityImageDigestMirrorSet Capability = "ImageDigestMirrorSet"
+
+	// ReasonAPINotServed notes a resource was skipped because the cluster does not serve its API
+	ReasonAPINotServed = "APINotServed"
+)
+
+// capabilityResources are the group versions and resources which must be served for each capability
+var capabilityResources = map[Capability]schema.GroupVersionResource{
+	CapabilityRoute:                      {Group: "route.openshift.io", Version: "v1", Resource: "routes"},
+	CapabilitySecurityContextConstraints: {Group: "security.openshift.io", Version: "v1", Resource: "securitycontextconstraints"},
+	CapabilityOperatorCondition:          {Group: "operators.coreos.com", Version: "v1", Resource: "operatorconditions"},
+	CapabilityInfrastructure:             {Group: "config.openshift.io", Version: "v1", Resource: "infrastructures"},
+	CapabilityImageDigestMirrorSet:       {Group: "config.openshift.io", Version: "v1", Resource: "imagedigestmirrorsets"},
+}
+
+// Capabilities is the set of optional APIs served by the cluster
+type Capabilities map[Capability]bool
+
+// AllCapabilities returns the capabilities of a full OpenShift cluster
+func AllCapabilities() Capabilities {
+	caps := Capabilities{}
+	for capability := range capabilityResources {
+		caps[capability] = true
+	}
+	return caps
+}
+
+// DetectCapabilities returns the optional APIs served by the cluster
+func DetectCapabilities(client discovery.DiscoveryInterface) (Capabilities, error) {
+	caps := Capabilities{}
+	for capability, gvr := range capabilityResources {
+		resources, err := client.ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+		if kerrors.IsNotFound(err) {
+			caps[capability] = false
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover %s: %w", gvr.GroupVersion(), err)
+		}
+		caps[capability] = false
+		for _, resource := range resources.APIResources {
+			if resource.Name == gvr.Resource {
+				caps[capability] = true
+				break
+			}
+		}
+	}
+	return caps, nil
+}
+
+// Missing returns the capabilities not served by the cluster
+func (c Capabilities) Missing() []string {
+	var missing []string
+	for capability := range capabilityResources {
+		if !c[capability] {
+			missing = append(missing, string(capability))
+		}
+	}
+	slices.Sort(missing)
+	return missing
+}
+
+var (
+	capabilitiesMu sync.RWMutex
+	// capabilities are detected once at startup. They default to those of a full OpenShift cluster.
+	capabilities = AllCapabilities()
+)
+
+// SetCapabilities records the capabilities detected at startup
+func SetCapabilities(caps Capabilities) {
+	capabilitiesMu.Lock()
+	defer capabilitiesMu.Unlock()
+	capabilities = caps
+}
+
+// HasCapability reports whether the cluster serves the optional API
+func HasCapability(capability Capability) bool {
+	capabilitiesMu.RLock()
+	defer capabilitiesMu.RUnlock()
+	return capabilities[capability]
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// fakeDiscovery serves the resources of the listed group versions
+type fakeDiscovery struct {
+	discovery.DiscoveryInterface
+	resources map[string][]string
+	err       error
+}
+
+func (f *fakeDiscovery) ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	names, ok := f.resources[groupVersion]
+	if !ok {
+		return nil, kerrors.NewNotFound(schema.GroupResource{}, groupVersion)
+	}
+	list := &metav1.APIResourceList{GroupVersion: groupVersion}
+	for _, name := range names {
+		list.APIResources = append(list.APIResources, metav1.APIResource{Name: name})
+	}
+	return list, nil
+}
+
+func TestDetectCapabilities(t *testing.T) {
+	// MicroShift serves Routes and SecurityContextConstraints, but neither OLM nor the cluster config
+	caps, err := DetectCapabilities(&fakeDiscovery{resources: map[string][]string{
+		"route.openshift.io/v1":    {"routes", "routes/status"},
+		"security.openshift.io/v1": {"securitycontextconstraints"},
+		"config.openshift.io/v1":   {"proxies"},
+	}})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !caps[CapabilityRoute] || !caps[CapabilitySecurityContextConstraints] {
+		t.Errorf("Expected the served capabilities to be detected, got %v", caps)
+	}
+	if got := caps.Missing(); !reflect.DeepEqual(got, []string{"ImageDigestMirrorSet", "Infrastructure", "OperatorCondition"}) {
+		t.Errorf("Expected ImageDigestMirrorSet, Infrastructure and OperatorCondition to be missing, got %v", got)
+	}
+
+	if _, err := DetectCapabilities(&fakeDiscovery{err: errors.New("connection refused")}); err == nil {
+		t.Error("Expected discovery errors to be returned")
+	}
+}
+
+func TestHasCapability(t *testing.T) {
+	if !HasCapability(CapabilityRoute) {
+		t.Error("Expected every capability to be available by default")
+	}
+	SetCapabilities(Capabilities{CapabilityRoute: false})
+	t.Cleanup(func() { SetCapabilities(AllCapabilities()) })
+	if HasCapability(CapabilityRoute) {
+		t.Error("Expected the detected capabilities to be used")
+	}
+}
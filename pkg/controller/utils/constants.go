@@ -8,6 +8,9 @@ const (
 	ZeroTrustWorkloadIdentityManagerSpireAgentControllerName                 = "zero-trust-workload-identity-manager-spire-agent-controller"
 	ZeroTrustWorkloadIdentityManagerSpiffeCsiDriverControllerName            = "zero-trust-workload-identity-manager-spiffe-csi-driver-controller"
 	ZeroTrustWorkloadIdentityManagerSpireOIDCDiscoveryProviderControllerName = "zero-trust-workload-identity-manager-spire-oidc-discovery-provider-controller"
+	ZeroTrustWorkloadIdentityManagerMintSVIDRequestControllerName            = "zero-trust-workload-identity-manager-mint-svid-request-controller"
+	ZeroTrustWorkloadIdentityManagerSpiffeHelperControllerName               = "zero-trust-workload-identity-manager-spiffe-helper-controller"
+	ZeroTrustWorkloadIdentityManagerSPIFFEIDPolicyControllerName             = "zero-trust-workload-identity-manager-spiffe-id-policy-controller"
 
 	OperatorNamespace = "zero-trust-workload-identity-manager"
 
@@ -56,10 +59,14 @@ const (
 	SpireServerImageEnv                = "RELATED_IMAGE_SPIRE_SERVER"
 	SpireAgentImageEnv                 = "RELATED_IMAGE_SPIRE_AGENT"
 	SpiffeCSIDriverImageEnv            = "RELATED_IMAGE_SPIFFE_CSI_DRIVER"
+	SpiffeCSIDriverSVIDFilesImageEnv   = "RELATED_IMAGE_SPIFFE_CSI_DRIVER_SVID_FILES"
 	SpireOIDCDiscoveryProviderImageEnv = "RELATED_IMAGE_SPIRE_OIDC_DISCOVERY_PROVIDER"
 	SpireControllerManagerImageEnv     = "RELATED_IMAGE_SPIRE_CONTROLLER_MANAGER"
 	NodeDriverRegistrarImageEnv        = "RELATED_IMAGE_NODE_DRIVER_REGISTRAR"
 	SpiffeCSIInitContainerImageEnv     = "RELATED_IMAGE_SPIFFE_CSI_INIT_CONTAINER"
+	SpiffeHelperImageEnv               = "RELATED_IMAGE_SPIFFE_HELPER"
+	StatsdExporterImageEnv             = "RELATED_IMAGE_STATSD_EXPORTER"
+	JWTValidatingProxyImageEnv         = "RELATED_IMAGE_JWT_VALIDATING_PROXY"
 
 	// Resource Kinds - used for validation and logging
 	ResourceKindSpireServer                = "SpireServer"
@@ -77,6 +84,143 @@ const (
 	ConditionReasonInvalidNodeSelector = "InvalidNodeSelector"
 	ConditionReasonInvalidResources    = "InvalidResources"
 	ConditionReasonInvalidLabels       = "InvalidLabels"
+	ConditionReasonInvalidDNSConfig    = "InvalidDNSConfig"
+
+	// Upgrade Condition Types
+	UpgradeInProgressStatusType = "UpgradeInProgress"
+
+	// Upgrade Condition Reasons
+	UpgradeReasonOperandVersionSkew             = "OperandVersionSkew"
+	UpgradeReasonWaitingForPrerequisiteOperands = "WaitingForPrerequisiteOperands"
+	UpgradeReasonComplete                       = "UpgradeComplete"
+
+	// Dry-run annotation, condition type and reasons
+	DryRunAnnotation      = "ztwim.openshift.io/dry-run"
+	DryRunStatusType      = "DryRun"
+	DryRunReasonPending   = "DryRunChangesPending"
+	DryRunReasonNoChanges = "DryRunNoChanges"
+	DryRunReasonFailed    = "DryRunFailed"
+	DryRunReasonDisabled  = "DryRunDisabled"
+
+	// Unmanaged resources condition type and reasons
+	UnmanagedResourcesStatusType       = "UnmanagedResources"
+	UnmanagedResourcesReasonUnmanaged  = "ResourcesUnmanaged"
+	UnmanagedResourcesReasonAllManaged = "AllResourcesManaged"
+
+	// Configuration conflict condition type and reasons. The condition is True while the trust
+	// domain and the JWT issuers of the SpireServer and SpireOIDCDiscoveryProvider are inconsistent.
+	ConfigurationConflictStatusType     = "ConfigurationConflict"
+	ConfigurationConflictReasonDetected = "IdentityConfigurationConflict"
+	ConfigurationConflictReasonResolved = "NoConflict"
+
+	// Environment conflict condition type and reasons. The condition is True while resources not
+	// managed by the operator, e.g. of another SPIRE install, conflict with the operand.
+	EnvironmentConflictStatusType     = "EnvironmentConflict"
+	EnvironmentConflictReasonDetected = "ConflictingResourcesFound"
+	EnvironmentConflictReasonResolved = "NoConflict"
+
+	// Changes pending condition type and reasons. The condition is True while disruptive changes
+	// to the operand are deferred until the next maintenance window.
+	ChangesPendingStatusType      = "ChangesPending"
+	ChangesPendingReasonDeferred  = "MaintenanceWindowClosed"
+	ChangesPendingReasonApplied   = "ChangesApplied"
+	ChangesPendingReasonNoChanges = "NoChangesPending"
+
+	// DatastorePressureStatusType is True while the sqlite3 datastore volume usage is above the
+	// pressure threshold
+	DatastorePressureStatusType = "DatastorePressure"
+
+	// RightSizedStatusType is False while the resources or the datastore pool of the SPIRE server
+	// are below the sizing recommended from its observed load, an advice rather than a failure
+	RightSizedStatusType = "RightSized"
+
+	// ClockSkewStatusType is True while the clock of a node running an agent drifted from the
+	// clock of the SPIRE server beyond the tolerated skew
+	ClockSkewStatusType = "ClockSkew"
+
+	// AgentSocketExposedStatusType is True while pods not allowed to mount the agent socket
+	// directory through a hostPath volume do
+	AgentSocketExposedStatusType = "AgentSocketExposed"
+
+	// NodesCompatibleStatusType is False while nodes selected for the SPIFFE CSI driver run a
+	// kubelet or container runtime the driver does not support
+	NodesCompatibleStatusType = "NodesCompatible"
+
+	// ReattestationPendingStatusType is True while agents attested to the SPIRE server hold an
+	// expired SVID and must attest again
+	ReattestationPendingStatusType = "ReattestationPending"
+
+	// Waiting for dependency condition type and reasons. The condition is True while the
+	// creation of the operand workload waits for the SpireServer to be Ready.
+	WaitingForDependencyStatusType         = "WaitingForDependency"
+	WaitingForDependencyReasonNotFound     = "SpireServerNotFound"
+	WaitingForDependencyReasonNotReady     = "SpireServerNotReady"
+	WaitingForDependencyReasonReady        = "DependenciesReady"
+	WaitingForDependencyReasonGateDisabled = "DependencyGateDisabled"
+
+	// Config revision history labels, annotations, condition type and reasons
+	ConfigRevisionOfLabel        = "ztwim.openshift.io/config-revision-of"
+	ConfigRevisionAnnotation     = "ztwim.openshift.io/config-revision"
+	ConfigRollbackAnnotation     = "ztwim.openshift.io/config-rollback-revision"
+	ConfigRevisionHistoryLimit   = 5
+	ConfigRollbackStatusType     = "ConfigRollback"
+	ConfigRollbackReasonActive   = "ConfigRolledBack"
+	ConfigRollbackReasonNotFound = "ConfigRevisionNotFound"
+	ConfigRollbackReasonInvalid  = "InvalidConfigRollbackRevision"
+	ConfigRollbackReasonDisabled = "ConfigRollbackDisabled"
+
+	// TrustBundleLabel marks the ConfigMaps holding the SPIRE trust bundle, so a previous bundle
+	// ConfigMap can be found after bundleConfigMap is changed
+	TrustBundleLabel = "ztwim.openshift.io/trust-bundle"
+
+	// NamespaceRegistrationLabel opts a namespace in (true) or out (false) of the registration of
+	// its pods by the default fallback ClusterSPIFFEID
+	NamespaceRegistrationLabel = "spiffe.openshift.io/enabled"
+
+	// InjectCALabel marks the ValidatingWebhookConfigurations whose caBundle is kept in sync with
+	// the SPIRE trust bundle, when set to "true"
+	InjectCALabel = "ztwim.openshift.io/inject-ca"
+
+	// Audit trail label, event annotations and reasons
+	AuditTrailOfLabel              = "ztwim.openshift.io/audit-trail-of"
+	AuditRecordAnnotation          = "ztwim.openshift.io/audit-record"
+	AuditGenerationAnnotation      = "ztwim.openshift.io/audit-generation"
+	AuditFieldManagerAnnotation    = "ztwim.openshift.io/audit-field-manager"
+	AuditRecordLimit               = 100
+	AuditReasonSpecChangeApplied   = "SpecChangeApplied"
+	AuditReasonResourcesReconciled = "ResourcesReconciled"
+
+	// ConditionHistoryRecordLimit bounds the condition transitions kept in the condition history
+	// ConfigMap of the ZeroTrustWorkloadIdentityManager
+	ConditionHistoryRecordLimit = 200
+
+	// DefaultAgentPool is the SpireAgent of the default agent pool, which also manages the resources
+	// shared by the pools. AgentPoolLabel names the pool of the resources of the other pools.
+	DefaultAgentPool = "cluster"
+	AgentPoolLabel   = "ztwim.openshift.io/agent-pool"
+
+	// ForceDeleteAnnotation allows deleting a SpireServer while agents are still attested
+	ForceDeleteAnnotation = "ztwim.openshift.io/force-delete"
+
+	// BreakGlassAdminAnnotation requests an emergency admin SVID for the <namespace>/<serviceAccount>
+	// it names, valid for BreakGlassTTLAnnotation. BreakGlassActiveStatusType is True while it is valid.
+	BreakGlassAdminAnnotation  = "ztwim.openshift.io/break-glass-admin"
+	BreakGlassTTLAnnotation    = "ztwim.openshift.io/break-glass-ttl"
+	BreakGlassActiveStatusType = "BreakGlassActive"
+
+	// CheckFederationAnnotation requests a conformance check of the bundle endpoints of the
+	// federation partners, run again when its value changes. FederationConformantStatusType
+	// reports its result, which is a diagnostic rather than a failure of the server.
+	CheckFederationAnnotation      = "ztwim.openshift.io/check-federation"
+	FederationConformantStatusType = "FederationConformant"
+
+	// SpiffeHelperLabel opts a pod in the injection of the spiffe-helper sidecar configured by the
+	// SpiffeHelperConfig of its namespace it names
+	SpiffeHelperLabel = "ztwim.openshift.io/spiffe-helper"
+
+	// DependenciesHashAnnotationKey holds the hash of the Secrets and ConfigMaps referenced by an operand
+	// CR on its pod template, so the pods roll when their data changes
+	DependenciesHashAnnotationKey = "ztwim.openshift.io/dependencies-hash"
 
 	// Workload Attestor Verification Types
 	WorkloadAttestorVerificationTypeSkip     = "skip"
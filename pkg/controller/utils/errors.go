@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 type ErrorReason string
@@ -15,6 +17,24 @@ const (
 	RetryRequiredError ErrorReason = "RetryRequiredError"
 
 	MultipleInstanceError ErrorReason = "MultipleInstanceError"
+
+	// InvalidConfigurationError is an error caused by the user-provided configuration, which
+	// cannot succeed until the configuration is changed
+	InvalidConfigurationError ErrorReason = "InvalidConfigurationError"
+)
+
+// Reasons of the Degraded condition set on the operand CRs for a failed reconciliation
+const (
+	DegradedReasonTransientError       = "TransientError"
+	DegradedReasonTerminalError        = "TerminalError"
+	DegradedReasonInvalidConfiguration = "InvalidConfiguration"
+	DegradedReasonMultipleInstances    = "MultipleInstances"
+	// DegradedReasonRepeatedFailures is set once a managed resource failed to apply too many
+	// times in a row and its reconciliation is retried at a reduced rate
+	DegradedReasonRepeatedFailures = "RepeatedFailures"
+	// DegradedReasonServerAPIOutage is set while the SPIRE server API fails its health checks for
+	// longer than the outage threshold, although its pods may be running
+	DegradedReasonServerAPIOutage = "ServerAPIOutage"
 )
 
 type ReconcileError struct {
@@ -47,6 +67,17 @@ func NewMultipleInstanceError(err error) *ReconcileError {
 	}
 }
 
+func NewInvalidConfigurationError(err error, message string, args ...any) *ReconcileError {
+	if err == nil {
+		return nil
+	}
+	return &ReconcileError{
+		Reason:  InvalidConfigurationError,
+		Message: fmt.Sprintf(message, args...),
+		Err:     err,
+	}
+}
+
 func NewRetryRequiredError(err error, message string, args ...any) *ReconcileError {
 	if err == nil {
 		return nil
@@ -101,6 +132,63 @@ func IsMultipleInstanceError(err error) bool {
 	return false
 }
 
+func IsInvalidConfigurationError(err error) bool {
+	if rerr, ok := err.(*ReconcileError); ok || errors.As(err, &rerr) {
+		return rerr.Reason == InvalidConfigurationError
+	}
+	return false
+}
+
+// ClassifyError returns the class of an error returned by a reconciliation. Errors not created
+// through this package are classified from the API error they wrap: authorization failures are
+// terminal, objects rejected by the API server were built from an invalid configuration, and
+// anything else is assumed to be transient.
+func ClassifyError(err error) ErrorReason {
+	var rerr *ReconcileError
+	if errors.As(err, &rerr) {
+		return rerr.Reason
+	}
+	switch {
+	case apierrors.IsUnauthorized(err) || apierrors.IsForbidden(err):
+		return IrrecoverableError
+	case apierrors.IsInvalid(err) || apierrors.IsBadRequest(err):
+		return InvalidConfigurationError
+	default:
+		return RetryRequiredError
+	}
+}
+
+// DegradedReason returns the reason of the Degraded condition for a reconciliation error
+func DegradedReason(err error) string {
+	switch ClassifyError(err) {
+	case IrrecoverableError:
+		return DegradedReasonTerminalError
+	case InvalidConfigurationError:
+		return DegradedReasonInvalidConfiguration
+	case MultipleInstanceError:
+		return DegradedReasonMultipleInstances
+	default:
+		return DegradedReasonTransientError
+	}
+}
+
+// ReconcileResult returns the result of a reconciliation that failed with the given error.
+// Transient errors are returned to be retried with backoff, terminal errors are reported
+// without being retried, and configuration errors wait for the configuration to change.
+func ReconcileResult(err error) (ctrl.Result, error) {
+	if err == nil {
+		return ctrl.Result{}, nil
+	}
+	switch ClassifyError(err) {
+	case IrrecoverableError:
+		return ctrl.Result{}, reconcile.TerminalError(err)
+	case InvalidConfigurationError, MultipleInstanceError:
+		return ctrl.Result{}, nil
+	default:
+		return ctrl.Result{}, err
+	}
+}
+
 // ReconcileError implements the ReconcileError interface.
 func (e *ReconcileError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Message, e.Err)
@@ -2,10 +2,12 @@ package utils
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 func TestErrorCreation(t *testing.T) {
@@ -182,3 +184,83 @@ func TestReconcileErrorError(t *testing.T) {
 		t.Errorf("Expected '%s', got '%s'", expected, err.Error())
 	}
 }
+
+func TestClassifyError(t *testing.T) {
+	gr := schema.GroupResource{Group: "apps", Resource: "daemonsets"}
+	tests := []struct {
+		name             string
+		err              error
+		expectedReason   ErrorReason
+		expectedDegraded string
+		expectReturned   bool
+		expectTerminal   bool
+	}{
+		{
+			name:             "unclassified error is transient",
+			err:              errors.New("connection reset"),
+			expectedReason:   RetryRequiredError,
+			expectedDegraded: DegradedReasonTransientError,
+			expectReturned:   true,
+		},
+		{
+			name:             "wrapped conflict is transient",
+			err:              fmt.Errorf("failed to update DaemonSet: %w", kerrors.NewConflict(gr, "spire-agent", errors.New("modified"))),
+			expectedReason:   RetryRequiredError,
+			expectedDegraded: DegradedReasonTransientError,
+			expectReturned:   true,
+		},
+		{
+			name:             "wrapped forbidden is terminal",
+			err:              fmt.Errorf("failed to create DaemonSet: %w", kerrors.NewForbidden(gr, "spire-agent", errors.New("denied"))),
+			expectedReason:   IrrecoverableError,
+			expectedDegraded: DegradedReasonTerminalError,
+			expectReturned:   true,
+			expectTerminal:   true,
+		},
+		{
+			name:             "rejected object is a configuration error",
+			err:              fmt.Errorf("failed to create DaemonSet: %w", kerrors.NewBadRequest("invalid toleration")),
+			expectedReason:   InvalidConfigurationError,
+			expectedDegraded: DegradedReasonInvalidConfiguration,
+		},
+		{
+			name:             "explicit classification wins",
+			err:              fmt.Errorf("validation: %w", NewInvalidConfigurationError(errors.New("bad issuer"), "invalid JWT issuer")),
+			expectedReason:   InvalidConfigurationError,
+			expectedDegraded: DegradedReasonInvalidConfiguration,
+		},
+		{
+			name:             "multiple instances are not retried",
+			err:              NewMultipleInstanceError(errors.New("only cluster is allowed")),
+			expectedReason:   MultipleInstanceError,
+			expectedDegraded: DegradedReasonMultipleInstances,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyError(tt.err); got != tt.expectedReason {
+				t.Errorf("Expected class %s, got %s", tt.expectedReason, got)
+			}
+			if got := DegradedReason(tt.err); got != tt.expectedDegraded {
+				t.Errorf("Expected Degraded reason %s, got %s", tt.expectedDegraded, got)
+			}
+			result, err := ReconcileResult(tt.err)
+			if result.RequeueAfter != 0 {
+				t.Errorf("Expected no explicit requeue, got %v", result)
+			}
+			if (err != nil) != tt.expectReturned {
+				t.Errorf("Expected error returned %v, got %v", tt.expectReturned, err)
+			}
+			if errors.Is(err, reconcile.TerminalError(nil)) != tt.expectTerminal {
+				t.Errorf("Expected terminal %v, got %v", tt.expectTerminal, err)
+			}
+		})
+	}
+}
+
+func TestReconcileResultNil(t *testing.T) {
+	if _, err := ReconcileResult(nil); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// UnsupportedAddonFeaturesEnv lists, comma separated, the unsupported features enabled on the
+	// operator subscription. Clusters running with any of them enabled are not supported.
+	UnsupportedAddonFeaturesEnv = "UNSUPPORTED_ADDON_FEATURES"
+	// UnsupportedFeatureExperimentalFlags passes spec.experimentalFlags into the SPIRE configs
+	UnsupportedFeatureExperimentalFlags = "ExperimentalFlags"
+
+	// UnsupportedConfiguration condition type and reasons. The condition is True while
+	// experimental SPIRE options are rendered into the operand configuration.
+	UnsupportedConfigurationStatusType          = "UnsupportedConfiguration"
+	UnsupportedConfigurationReasonApplied       = "ExperimentalFlagsApplied"
+	UnsupportedConfigurationReasonNotEnabled    = "ExperimentalFlagsNotEnabled"
+	UnsupportedConfigurationReasonNoneRequested = "NoExperimentalFlags"
+)
+
+// ExperimentalFlagKind is the type of the value of an experimental SPIRE option
+type ExperimentalFlagKind int
+
+const (
+	ExperimentalFlagBool ExperimentalFlagKind = iota
+	ExperimentalFlagDuration
+)
+
+// SpireServerExperimentalFlags are the experimental options of the SPIRE server that can be set
+var SpireServerExperimentalFlags = map[string]ExperimentalFlagKind{
+	"cache_reload_interval":   ExperimentalFlagDuration,
+	"events_based_cache":      ExperimentalFlagBool,
+	"prune_events_older_than": ExperimentalFlagDuration,
+	"sql_transaction_timeout": ExperimentalFlagDuration,
+	"require_pq_kem":          ExperimentalFlagBool,
+}
+
+// SpireAgentExperimentalFlags are the experimental options of the SPIRE agent that can be set
+var SpireAgentExperimentalFlags = map[string]ExperimentalFlagKind{
+	"sync_interval":               ExperimentalFlagDuration,
+	"use_sync_authorized_entries": ExperimentalFlagBool,
+	"require_pq_kem":              ExperimentalFlagBool,
+}
+
+// IsUnsupportedFeatureEnabled reports whether the unsupported feature is listed in UNSUPPORTED_ADDON_FEATURES
+func IsUnsupportedFeatureEnabled(feature string) bool {
+	for _, name := range strings.Split(os.Getenv(UnsupportedAddonFeaturesEnv), ",") {
+		if strings.EqualFold(strings.TrimSpace(name), feature) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateExperimentalFlags checks that every flag is a known experimental option with a valid value
+func ValidateExperimentalFlags(flags map[string]string, allowed map[string]ExperimentalFlagKind) error {
+	for _, name := range slices.Sorted(maps.Keys(flags)) {
+		kind, ok := allowed[name]
+		if !ok {
+			return fmt.Errorf("unknown experimental flag %q, supported flags are %s", name, strings.Join(slices.Sorted(maps.Keys(allowed)), ", "))
+		}
+		if _, err := parseExperimentalFlag(kind, flags[name]); err != nil {
+			return fmt.Errorf("invalid value %q for experimental flag %q: %w", flags[name], name, err)
+		}
+	}
+	return nil
+}
+
+// ExperimentalConfig returns the "experimental" section of a SPIRE config for the flags, or nil
+// when there are none or the ExperimentalFlags unsupported feature is not enabled. The flags must
+// have passed ValidateExperimentalFlags.
+func ExperimentalConfig(flags map[string]string, allowed map[string]ExperimentalFlagKind) map[string]interface{} {
+	if len(flags) == 0 || !IsUnsupportedFeatureEnabled(UnsupportedFeatureExperimentalFlags) {
+		return nil
+	}
+	experimental := map[string]interface{}{}
+	for name, value := range flags {
+		kind, ok := allowed[name]
+		if !ok {
+			continue
+		}
+		if parsed, err := parseExperimentalFlag(kind, value); err == nil {
+			experimental[name] = parsed
+		}
+	}
+	return experimental
+}
+
+func parseExperimentalFlag(kind ExperimentalFlagKind, value string) (interface{}, error) {
+	switch kind {
+	case ExperimentalFlagBool:
+		return strconv.ParseBool(value)
+	default:
+		if _, err := time.ParseDuration(value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+}
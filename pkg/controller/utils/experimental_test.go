@@ -0,0 +1,40 @@
+package utils
+
+import "testing"
+
+func TestValidateExperimentalFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		wantErr bool
+	}{
+		{name: "no flags", flags: nil},
+		{name: "valid flags", flags: map[string]string{"events_based_cache": "true", "cache_reload_interval": "5s"}},
+		{name: "unknown flag", flags: map[string]string{"named_pipe_name": "spire"}, wantErr: true},
+		{name: "invalid bool", flags: map[string]string{"events_based_cache": "yes please"}, wantErr: true},
+		{name: "invalid duration", flags: map[string]string{"cache_reload_interval": "5"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateExperimentalFlags(tt.flags, SpireServerExperimentalFlags)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestExperimentalConfig(t *testing.T) {
+	flags := map[string]string{"events_based_cache": "true", "cache_reload_interval": "5s"}
+
+	t.Setenv(UnsupportedAddonFeaturesEnv, "")
+	if config := ExperimentalConfig(flags, SpireServerExperimentalFlags); config != nil {
+		t.Errorf("Expected no experimental section without the unsupported feature, got %v", config)
+	}
+
+	t.Setenv(UnsupportedAddonFeaturesEnv, "SomethingElse, ExperimentalFlags")
+	config := ExperimentalConfig(flags, SpireServerExperimentalFlags)
+	if config["events_based_cache"] != true || config["cache_reload_interval"] != "5s" {
+		t.Errorf("Expected typed experimental options, got %v", config)
+	}
+}
@@ -0,0 +1,72 @@
+package utils
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+const (
+	// guardrailMaxReplicas is the maximum number of replicas of the server and of the OIDC
+	// discovery provider allowed by their CRDs
+	guardrailMaxReplicas = 5
+	// guardrailHeadroom multiplies the expected usage, leaving room for rollouts and jobs
+	guardrailHeadroom = 2
+)
+
+// guardrailNodes is the number of nodes of the largest cluster each sizing profile is meant for
+var guardrailNodes = map[v1alpha2.SizingProfile]int64{
+	v1alpha2.SizingProfileSmall:      50,
+	v1alpha2.SizingProfileMedium:     250,
+	v1alpha2.SizingProfileLarge:      1000,
+	v1alpha2.SizingProfileSingleNode: 1,
+}
+
+// operatorRequests are the resource requests of the operator pod, which runs in the same namespace
+var operatorRequests = resourceRequirements("100m", "256Mi", "100m", "256Mi").Requests
+
+// guardrailPreset returns the sizing preset the guardrails derive from, medium when no profile is used
+func guardrailPreset(profile v1alpha2.SizingProfile) (v1alpha2.SizingProfile, sizingPreset) {
+	if preset, ok := sizingPresets[profile]; ok {
+		return profile, preset
+	}
+	return v1alpha2.SizingProfileMedium, sizingPresets[v1alpha2.SizingProfileMedium]
+}
+
+// NamespaceGuardrailQuota returns the hard limits of the ResourceQuota of the operator namespace:
+// the number of pods and the resource requests of the operands on the largest cluster of the
+// sizing profile, with the node agents on every node and the server and OIDC discovery provider
+// at their maximum replicas, times the headroom
+func NamespaceGuardrailQuota(profile v1alpha2.SizingProfile) corev1.ResourceList {
+	profile, preset := guardrailPreset(profile)
+	nodes := guardrailNodes[profile]
+
+	cpu := operatorRequests.Cpu().DeepCopy()
+	memory := operatorRequests.Memory().DeepCopy()
+	add := func(kind string, count int64) {
+		requests := preset.resources[kind].Requests
+		for i := int64(0); i < count; i++ {
+			cpu.Add(*requests.Cpu())
+			memory.Add(*requests.Memory())
+		}
+	}
+	add(ResourceKindSpireAgent, nodes)
+	add(ResourceKindSpiffeCSIDriver, nodes)
+	add(ResourceKindSpireServer, guardrailMaxReplicas)
+	add(ResourceKindSpireOIDCDiscoveryProvider, guardrailMaxReplicas)
+	pods := 2*nodes + 2*guardrailMaxReplicas + 1
+
+	return corev1.ResourceList{
+		corev1.ResourcePods:           *resource.NewQuantity(pods*guardrailHeadroom, resource.DecimalSI),
+		corev1.ResourceRequestsCPU:    *resource.NewMilliQuantity(cpu.MilliValue()*guardrailHeadroom, resource.DecimalSI),
+		corev1.ResourceRequestsMemory: *resource.NewQuantity(memory.Value()*guardrailHeadroom, resource.BinarySI),
+	}
+}
+
+// NamespaceGuardrailDefaultRequests returns the default resource requests of the LimitRange of the
+// operator namespace: the smallest requests of the sizing profile, those of the CSI driver
+func NamespaceGuardrailDefaultRequests(profile v1alpha2.SizingProfile) corev1.ResourceList {
+	_, preset := guardrailPreset(profile)
+	return preset.resources[ResourceKindSpiffeCSIDriver].Requests.DeepCopy()
+}
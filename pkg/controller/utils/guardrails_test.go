@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+func TestNamespaceGuardrailQuota(t *testing.T) {
+	tests := []struct {
+		profile      v1alpha2.SizingProfile
+		expectPods   int64
+		expectCPU    string
+		expectMemory string
+	}{
+		// (1 agent + 1 CSI driver + 5 servers + 5 OIDC discovery providers + operator) x 2
+		{profile: v1alpha2.SizingProfileSingleNode, expectPods: 26, expectCPU: "850m", expectMemory: "2240Mi"},
+		{profile: v1alpha2.SizingProfileSmall, expectPods: 222, expectCPU: "7450m", expectMemory: "13Gi"},
+		{profile: "", expectPods: 1022, expectCPU: "63200m", expectMemory: "102912Mi"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.profile), func(t *testing.T) {
+			hard := NamespaceGuardrailQuota(tt.profile)
+			if pods := hard[corev1.ResourcePods]; pods.Value() != tt.expectPods {
+				t.Errorf("Expected %d pods, got %s", tt.expectPods, pods.String())
+			}
+			if cpu := hard[corev1.ResourceRequestsCPU]; cpu.String() != tt.expectCPU {
+				t.Errorf("Expected %s CPU, got %s", tt.expectCPU, cpu.String())
+			}
+			if memory := hard[corev1.ResourceRequestsMemory]; memory.String() != tt.expectMemory {
+				t.Errorf("Expected %s memory, got %s", tt.expectMemory, memory.String())
+			}
+		})
+	}
+}
+
+func TestNamespaceGuardrailDefaultRequests(t *testing.T) {
+	requests := NamespaceGuardrailDefaultRequests(v1alpha2.SizingProfileLarge)
+	if requests.Cpu().String() != "50m" || requests.Memory().String() != "64Mi" {
+		t.Errorf("Expected the CSI driver requests of the large profile, got %v", requests)
+	}
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ValidateIdentityConsistency cross-checks the trust domain with the JWT issuers of the SpireServer
+// and the SpireOIDCDiscoveryProvider. An empty or invalid issuer is not checked here, as it is
+// either not created yet or reported by the validation of its own resource.
+func ValidateIdentityConsistency(trustDomain, serverIssuer, oidcIssuer string) error {
+	var conflicts []error
+
+	// The federation bundle endpoint is served on federation.<trust domain>, a JWT issuer on the
+	// same host would have its discovery document shadowed by the bundle endpoint Route
+	federationHost := "federation." + trustDomain
+	for _, issuer := range []struct{ kind, url string }{
+		{ResourceKindSpireServer, serverIssuer},
+		{ResourceKindSpireOIDCDiscoveryProvider, oidcIssuer},
+	} {
+		host, err := StripProtocolFromJWTIssuer(issuer.url)
+		if err != nil || host == "" {
+			continue
+		}
+		if trustDomain != "" && host == federationHost {
+			conflicts = append(conflicts, fmt.Errorf("%s jwtIssuer %s is the federation bundle endpoint of trust domain %s", issuer.kind, issuer.url, trustDomain))
+		}
+	}
+
+	if serverIssuer != "" && oidcIssuer != "" {
+		normalizedServer, serverErr := NormalizeURL(serverIssuer)
+		normalizedOIDC, oidcErr := NormalizeURL(oidcIssuer)
+		if serverErr == nil && oidcErr == nil && normalizedServer != normalizedOIDC {
+			conflicts = append(conflicts, fmt.Errorf("%s jwtIssuer %s differs from %s jwtIssuer %s: the discovery document would not match the issuer of the JWT-SVIDs",
+				ResourceKindSpireServer, serverIssuer, ResourceKindSpireOIDCDiscoveryProvider, oidcIssuer))
+		}
+	}
+	return errors.Join(conflicts...)
+}
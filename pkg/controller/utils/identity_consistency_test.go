@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateIdentityConsistency(t *testing.T) {
+	tests := []struct {
+		name         string
+		serverIssuer string
+		oidcIssuer   string
+		expectErr    string
+	}{
+		{
+			name:         "matching issuers",
+			serverIssuer: "https://oidc.apps.example.com",
+			oidcIssuer:   "https://OIDC.apps.example.com/",
+		},
+		{
+			name:         "oidc provider not created yet",
+			serverIssuer: "https://oidc.apps.example.com",
+		},
+		{
+			name:         "invalid issuer left to its resource validation",
+			serverIssuer: "not-a-url",
+			oidcIssuer:   "https://oidc.apps.example.com",
+		},
+		{
+			name:         "mismatched issuers",
+			serverIssuer: "https://oidc.apps.example.com",
+			oidcIssuer:   "https://oidc.apps.other.com",
+			expectErr:    "SpireServer jwtIssuer https://oidc.apps.example.com differs from SpireOIDCDiscoveryProvider jwtIssuer https://oidc.apps.other.com",
+		},
+		{
+			name:         "issuer on the federation endpoint",
+			serverIssuer: "https://federation.example.org",
+			oidcIssuer:   "https://federation.example.org",
+			expectErr:    "SpireServer jwtIssuer https://federation.example.org is the federation bundle endpoint of trust domain example.org",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateIdentityConsistency("example.org", tt.serverIssuer, tt.oidcIssuer)
+			if tt.expectErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.expectErr)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/distribution/reference"
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+// relatedImageEnvs are the environment variables holding the operand images
+var relatedImageEnvs = []string{
+	SpireServerImageEnv,
+	SpireAgentImageEnv,
+	SpiffeCSIDriverImageEnv,
+	SpiffeCSIDriverSVIDFilesImageEnv,
+	SpireOIDCDiscoveryProviderImageEnv,
+	SpireControllerManagerImageEnv,
+	NodeDriverRegistrarImageEnv,
+	SpiffeCSIInitContainerImageEnv,
+	SpiffeHelperImageEnv,
+	StatsdExporterImageEnv,
+	JWTValidatingProxyImageEnv,
+}
+
+// imagePullFailureReasons are the waiting reasons of a container whose image cannot be pulled
+var imagePullFailureReasons = []string{"ErrImagePull", "ImagePullBackOff", "InvalidImageName", "ErrImageNeverPull"}
+
+// ApplyImagePullSecrets sets the cluster-wide pull secrets on an operand which sets none of its own
+func ApplyImagePullSecrets(defaults []corev1.LocalObjectReference, config *v1alpha2.CommonConfig) {
+	if len(config.ImagePullSecrets) == 0 && len(defaults) > 0 {
+		config.ImagePullSecrets = slices.Clone(defaults)
+	}
+}
+
+// RelatedImages returns the operand images set on the operator, keyed by environment variable
+func RelatedImages() map[string]string {
+	images := map[string]string{}
+	for _, env := range relatedImageEnvs {
+		if image := os.Getenv(env); image != "" {
+			images[env] = image
+		}
+	}
+	return images
+}
+
+// CheckImageMirrors returns the operand images the mirrors of the ImageDigestMirrorSets do not
+// apply to: the mirrors are only used for images referenced by digest, so an image referenced
+// by tag from a mirrored repository is pulled from its source, which a disconnected cluster
+// cannot reach. Invalid image references are returned as well.
+func CheckImageMirrors(images map[string]string, mirrorSets []configv1.ImageDigestMirrorSet) []string {
+	var problems []string
+	for env, image := range images {
+		named, err := reference.ParseNormalizedNamed(image)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s %q is not a valid image reference: %v", env, image, err))
+			continue
+		}
+		if _, ok := named.(reference.Canonical); ok {
+			continue
+		}
+		for _, mirrorSet := range mirrorSets {
+			if source, ok := mirroredSource(named, mirrorSet); ok {
+				problems = append(problems, fmt.Sprintf("%s %q is not referenced by digest, so the mirrors of %s in ImageDigestMirrorSet %s do not apply",
+					env, image, source, mirrorSet.Name))
+				break
+			}
+		}
+	}
+	slices.Sort(problems)
+	return problems
+}
+
+// mirroredSource returns the source of the ImageDigestMirrorSet matching the repository of the image
+func mirroredSource(named reference.Named, mirrorSet configv1.ImageDigestMirrorSet) (string, bool) {
+	repository := named.Name()
+	domain := reference.Domain(named)
+	for _, mirrors := range mirrorSet.Spec.ImageDigestMirrors {
+		source := mirrors.Source
+		if wildcard, ok := strings.CutPrefix(source, "*."); ok {
+			if strings.HasSuffix(domain, "."+wildcard) {
+				return source, true
+			}
+			continue
+		}
+		if repository == source || strings.HasPrefix(repository, source+"/") {
+			return source, true
+		}
+	}
+	return "", false
+}
+
+// ImagePullFailures returns the containers of the pods waiting on an image which cannot be pulled
+func ImagePullFailures(pods []corev1.Pod) []string {
+	var failures []string
+	for _, pod := range pods {
+		statuses := append(slices.Clone(pod.Status.InitContainerStatuses), pod.Status.ContainerStatuses...)
+		for _, containerStatus := range statuses {
+			waiting := containerStatus.State.Waiting
+			if waiting == nil || !slices.Contains(imagePullFailureReasons, waiting.Reason) {
+				continue
+			}
+			failure := fmt.Sprintf("%s/%s cannot pull %s: %s", pod.Name, containerStatus.Name, containerStatus.Image, waiting.Reason)
+			if waiting.Message != "" {
+				failure += ": " + waiting.Message
+			}
+			failures = append(failures, failure)
+		}
+	}
+	slices.Sort(failures)
+	return failures
+}
@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+func TestApplyImagePullSecrets(t *testing.T) {
+	defaults := []corev1.LocalObjectReference{{Name: "cluster-pull-secret"}}
+
+	config := &v1alpha2.CommonConfig{}
+	ApplyImagePullSecrets(defaults, config)
+	if !reflect.DeepEqual(config.ImagePullSecrets, defaults) {
+		t.Errorf("Expected the cluster-wide pull secrets, got %v", config.ImagePullSecrets)
+	}
+
+	own := []corev1.LocalObjectReference{{Name: "operand-pull-secret"}}
+	config = &v1alpha2.CommonConfig{ImagePullSecrets: own}
+	ApplyImagePullSecrets(defaults, config)
+	if !reflect.DeepEqual(config.ImagePullSecrets, own) {
+		t.Errorf("Expected the pull secrets of the operand kept, got %v", config.ImagePullSecrets)
+	}
+}
+
+func TestCheckImageMirrors(t *testing.T) {
+	mirrorSets := []configv1.ImageDigestMirrorSet{{
+		ObjectMeta: metav1.ObjectMeta{Name: "disconnected"},
+		Spec: configv1.ImageDigestMirrorSetSpec{ImageDigestMirrors: []configv1.ImageDigestMirrors{
+			{Source: "registry.redhat.io/zero-trust-workload-identity-manager"},
+			{Source: "*.quay.io"},
+		}},
+	}}
+
+	tests := []struct {
+		name          string
+		image         string
+		expectProblem string
+	}{
+		{
+			name:  "mirrored by digest",
+			image: "registry.redhat.io/zero-trust-workload-identity-manager/spire-server-rhel9@sha256:" + strings.Repeat("a", 64),
+		},
+		{
+			name:          "mirrored by tag",
+			image:         "registry.redhat.io/zero-trust-workload-identity-manager/spire-server-rhel9:v1.12",
+			expectProblem: "mirrors of registry.redhat.io/zero-trust-workload-identity-manager in ImageDigestMirrorSet disconnected do not apply",
+		},
+		{
+			name:          "mirrored by wildcard",
+			image:         "mirror.quay.io/spiffe/spire-server:1.12",
+			expectProblem: "mirrors of *.quay.io",
+		},
+		{
+			name:  "repository prefix of another",
+			image: "registry.redhat.io/zero-trust-workload-identity-manager-tech-preview/spire-server:v1.12",
+		},
+		{
+			name:  "not mirrored",
+			image: "ghcr.io/spiffe/spire-server:1.12",
+		},
+		{
+			name:          "invalid reference",
+			image:         "ghcr.io/spiffe/SPIRE-server:1.12",
+			expectProblem: "is not a valid image reference",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := CheckImageMirrors(map[string]string{SpireServerImageEnv: tt.image}, mirrorSets)
+			if tt.expectProblem == "" {
+				if len(problems) > 0 {
+					t.Errorf("Expected no problem, got %v", problems)
+				}
+				return
+			}
+			if len(problems) != 1 || !strings.Contains(problems[0], tt.expectProblem) {
+				t.Errorf("Expected a problem containing %q, got %v", tt.expectProblem, problems)
+			}
+		})
+	}
+}
+
+func TestImagePullFailures(t *testing.T) {
+	pods := []corev1.Pod{{
+		ObjectMeta: metav1.ObjectMeta{Name: "spire-agent-x7k2p"},
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "init",
+				Image: "registry.example.com/init:1",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ErrImagePull", Message: "manifest unknown"}},
+			}},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:  "spire-agent",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"}},
+				},
+				{
+					Name:  "statsd-exporter",
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				},
+			},
+		},
+	}}
+
+	failures := ImagePullFailures(pods)
+	expected := []string{"spire-agent-x7k2p/init cannot pull registry.example.com/init:1: ErrImagePull: manifest unknown"}
+	if !reflect.DeepEqual(failures, expected) {
+		t.Errorf("Expected %v, got %v", expected, failures)
+	}
+}
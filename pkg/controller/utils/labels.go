@@ -64,6 +64,18 @@ func SpireControllerManagerLabels(customLabels map[string]string) map[string]str
 	return StandardizedLabels("spire-controller-manager", ComponentControlPlane, version.SpireControllerManagerVersion, customLabels)
 }
 
+// WorkloadSelector returns the labels selecting the workloads with the standardized labels of an
+// operand, whatever their name: the DaemonSets of every SPIRE agent pool share the labels of the
+// default one
+func WorkloadSelector(labels map[string]string) client.MatchingLabels {
+	return client.MatchingLabels{
+		"app.kubernetes.io/name":       labels["app.kubernetes.io/name"],
+		"app.kubernetes.io/instance":   labels["app.kubernetes.io/instance"],
+		AppComponentLabelKey:           labels[AppComponentLabelKey],
+		"app.kubernetes.io/managed-by": labels["app.kubernetes.io/managed-by"],
+	}
+}
+
 // hasControllerManagedLabelWithComponent checks if an object has both the managed-by label
 // and the specified component label
 func hasControllerManagedLabelWithComponent(obj client.Object, component string) bool {
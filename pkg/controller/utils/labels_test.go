@@ -5,6 +5,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 )
 
@@ -175,3 +176,22 @@ func TestControllerManagedResourcesForComponent(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkloadSelector(t *testing.T) {
+	selector := labels.SelectorFromSet(labels.Set(WorkloadSelector(SpireAgentLabels(nil))))
+	tests := []struct {
+		name     string
+		labels   map[string]string
+		expected bool
+	}{
+		{name: "default agent pool", labels: SpireAgentLabels(nil), expected: true},
+		{name: "other agent pool", labels: SpireAgentLabels(map[string]string{AgentPoolLabel: "gpu"}), expected: true},
+		{name: "other node agent", labels: StandardizedLabels("spire-agent-health-probe", ComponentNodeAgent, "v1", nil)},
+		{name: "other operand", labels: SpiffeCSIDriverLabels(nil)},
+	}
+	for _, tt := range tests {
+		if got := selector.Matches(labels.Set(tt.labels)); got != tt.expected {
+			t.Errorf("%s: expected match %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+}
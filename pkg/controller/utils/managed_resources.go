@@ -0,0 +1,29 @@
+package utils
+
+import "github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+
+// UnmanagedKinds returns the kinds of the generated resources left to the user, named by their Go
+// type as expected by the UnmanagedClient
+func UnmanagedKinds(managed *v1alpha2.ManagedResources) []string {
+	if managed == nil {
+		return nil
+	}
+	var kinds []string
+	for _, override := range []struct {
+		state v1alpha2.ManagementState
+		kinds []string
+	}{
+		{managed.ServiceAccount, []string{"ServiceAccount"}},
+		{managed.Service, []string{"Service"}},
+		{managed.ConfigMap, []string{"ConfigMap"}},
+		{managed.RBAC, []string{"ClusterRole", "ClusterRoleBinding", "Role", "RoleBinding"}},
+		{managed.Workload, []string{"StatefulSet", "DaemonSet", "Deployment"}},
+		{managed.SecurityContextConstraints, []string{"SecurityContextConstraints"}},
+		{managed.Route, []string{"Route"}},
+	} {
+		if override.state == v1alpha2.ManagementStateUnmanaged {
+			kinds = append(kinds, override.kinds...)
+		}
+	}
+	return kinds
+}
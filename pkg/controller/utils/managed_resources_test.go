@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+func TestUnmanagedKinds(t *testing.T) {
+	if kinds := UnmanagedKinds(nil); kinds != nil {
+		t.Errorf("Expected every kind to be managed by default, got %v", kinds)
+	}
+
+	kinds := UnmanagedKinds(&v1alpha2.ManagedResources{
+		ConfigMap:                  v1alpha2.ManagementStateManaged,
+		RBAC:                       v1alpha2.ManagementStateUnmanaged,
+		SecurityContextConstraints: v1alpha2.ManagementStateUnmanaged,
+	})
+	expected := []string{"ClusterRole", "ClusterRoleBinding", "Role", "RoleBinding", "SecurityContextConstraints"}
+	if !slices.Equal(kinds, expected) {
+		t.Errorf("Expected %v, got %v", expected, kinds)
+	}
+}
@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+const (
+	// DefaultPSATAudience is the audience of the agent tokens when none is configured
+	DefaultPSATAudience = "spire-server"
+
+	// DefaultPSATTokenExpirationSeconds is the lifetime of the agent tokens when none is configured
+	DefaultPSATTokenExpirationSeconds = 7200
+
+	// PSAT consistency condition type and reasons. The condition is set on both the SpireServer
+	// and the SpireAgent, as either side can be fixed.
+	PSATAttestationConsistentStatusType = "PSATAttestationConsistent"
+	PSATReasonConsistent                = "PSATConfigurationConsistent"
+	PSATReasonAudienceMismatch          = "PSATAudienceMismatch"
+	PSATReasonServiceAccountNotAllowed  = "PSATServiceAccountNotAllowed"
+	PSATReasonProjectedTokenDrift       = "PSATProjectedTokenDrift"
+)
+
+// PSATAudiences returns the audiences the server accepts for the agent tokens
+func PSATAudiences(server *v1alpha2.SpireServerSpec) []string {
+	if server.PSAT == nil || len(server.PSAT.Audiences) == 0 {
+		return []string{DefaultPSATAudience}
+	}
+	return server.PSAT.Audiences
+}
+
+// PSATServiceAccountAllowList returns the service accounts the server allows to attest
+func PSATServiceAccountAllowList(server *v1alpha2.SpireServerSpec) []string {
+	if server.PSAT == nil || len(server.PSAT.ServiceAccountAllowList) == 0 {
+		return []string{agentServiceAccount()}
+	}
+	return server.PSAT.ServiceAccountAllowList
+}
+
+// PSATNodeLabelSelectors returns the node labels exposed as selectors of the agents
+func PSATNodeLabelSelectors(server *v1alpha2.SpireServerSpec) []v1alpha2.NodeLabelSelector {
+	if server.PSAT == nil {
+		return nil
+	}
+	return server.PSAT.NodeLabelSelectors
+}
+
+// PSATNodeLabelKeys returns the node label keys the server adds to the selectors of the agents
+func PSATNodeLabelKeys(server *v1alpha2.SpireServerSpec) []string {
+	keys := []string{}
+	for _, selector := range PSATNodeLabelSelectors(server) {
+		keys = append(keys, selector.LabelKey)
+	}
+	return keys
+}
+
+// PSATAgentAudience returns the audience of the agent tokens
+func PSATAgentAudience(agent *v1alpha2.SpireAgentSpec) string {
+	if agent.NodeAttestor == nil || agent.NodeAttestor.PSATAudience == "" {
+		return DefaultPSATAudience
+	}
+	return agent.NodeAttestor.PSATAudience
+}
+
+// PSATAgentTokenExpirationSeconds returns the requested lifetime of the agent tokens
+func PSATAgentTokenExpirationSeconds(agent *v1alpha2.SpireAgentSpec) int64 {
+	if agent.NodeAttestor == nil || agent.NodeAttestor.PSATTokenExpirationSeconds <= 0 {
+		return DefaultPSATTokenExpirationSeconds
+	}
+	return agent.NodeAttestor.PSATTokenExpirationSeconds
+}
+
+// agentServiceAccount is the service account of the SPIRE agents, as namespace:name
+func agentServiceAccount() string {
+	return GetOperatorNamespace() + ":spire-agent"
+}
+
+// CheckPSATConsistency checks the agents attest with a token the server accepts. It returns the
+// reason and message of the PSATAttestationConsistent condition, and whether they are consistent.
+// Agents not attesting with k8s_psat are always consistent.
+func CheckPSATConsistency(server *v1alpha2.SpireServerSpec, agent *v1alpha2.SpireAgentSpec) (string, string, bool) {
+	if agent.NodeAttestor == nil || !ptr.Deref(agent.NodeAttestor.K8sPSATEnabled, false) {
+		return PSATReasonConsistent, "SPIRE agents do not attest with k8s_psat", true
+	}
+	audience := PSATAgentAudience(agent)
+	if audiences := PSATAudiences(server); !slices.Contains(audiences, audience) {
+		return PSATReasonAudienceMismatch,
+			fmt.Sprintf("SPIRE agent token audience %q is not accepted by the SPIRE server, accepted audiences are %v: agents will fail node attestation", audience, audiences),
+			false
+	}
+	if allowList := PSATServiceAccountAllowList(server); !slices.Contains(allowList, agentServiceAccount()) {
+		return PSATReasonServiceAccountNotAllowed,
+			fmt.Sprintf("SPIRE agent service account %s is not in the SPIRE server service account allow list %v: agents will fail node attestation", agentServiceAccount(), allowList),
+			false
+	}
+	return PSATReasonConsistent, "SPIRE agent tokens are accepted by the SPIRE server", true
+}
+
+// ProjectedTokenFromPodSpec returns the token projected into the volume of the pod spec at the
+// path, nil if none
+func ProjectedTokenFromPodSpec(podSpec *corev1.PodSpec, serviceAccount, path string) *v1alpha2.ProjectedTokenStatus {
+	for _, volume := range podSpec.Volumes {
+		if volume.Projected == nil {
+			continue
+		}
+		for _, source := range volume.Projected.Sources {
+			if source.ServiceAccountToken == nil || source.ServiceAccountToken.Path != path {
+				continue
+			}
+			token := &v1alpha2.ProjectedTokenStatus{
+				ServiceAccount: serviceAccount,
+				Audience:       source.ServiceAccountToken.Audience,
+			}
+			if source.ServiceAccountToken.ExpirationSeconds != nil {
+				token.ExpirationSeconds = *source.ServiceAccountToken.ExpirationSeconds
+			}
+			return token
+		}
+	}
+	return nil
+}
+
+// CheckPSATProjection checks the token projected into the deployed agent pods, which lags the
+// spec of the SpireAgent while a DaemonSet update is held back, e.g. in create-only mode or
+// outside the maintenance windows. It returns the reason and message of the
+// PSATAttestationConsistent condition, and whether the agents still attest: a token whose
+// expiration drifted from the spec is reported without failing the check.
+func CheckPSATProjection(server *v1alpha2.SpireServerSpec, agent *v1alpha2.SpireAgentSpec, deployed *v1alpha2.ProjectedTokenStatus) (string, string, bool) {
+	if agent.NodeAttestor == nil || !ptr.Deref(agent.NodeAttestor.K8sPSATEnabled, false) {
+		return PSATReasonConsistent, "SPIRE agents do not attest with k8s_psat", true
+	}
+	if deployed == nil {
+		return PSATReasonProjectedTokenDrift, "SPIRE agent DaemonSet projects no service account token: agents will fail node attestation", false
+	}
+	if audiences := PSATAudiences(server); !slices.Contains(audiences, deployed.Audience) {
+		return PSATReasonProjectedTokenDrift,
+			fmt.Sprintf("SPIRE agent DaemonSet projects tokens for audience %q, not accepted by the SPIRE server, accepted audiences are %v: restarted agents will fail node attestation until the DaemonSet is updated", deployed.Audience, audiences),
+			false
+	}
+	audience, expirationSeconds := PSATAgentAudience(agent), PSATAgentTokenExpirationSeconds(agent)
+	if deployed.Audience != audience || deployed.ExpirationSeconds != expirationSeconds {
+		return PSATReasonConsistent,
+			fmt.Sprintf("SPIRE agent tokens are accepted by the SPIRE server; the DaemonSet projects tokens for audience %q expiring after %ds, pending the update to audience %q expiring after %ds",
+				deployed.Audience, deployed.ExpirationSeconds, audience, expirationSeconds),
+			true
+	}
+	return PSATReasonConsistent,
+		fmt.Sprintf("SPIRE agent tokens for audience %q expiring after %ds are accepted by the SPIRE server", deployed.Audience, deployed.ExpirationSeconds),
+		true
+}
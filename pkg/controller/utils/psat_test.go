@@ -0,0 +1,145 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+func TestCheckPSATConsistency(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "zero-trust-workload-identity-manager")
+
+	psatAgent := func(audience string) *v1alpha2.SpireAgentSpec {
+		return &v1alpha2.SpireAgentSpec{NodeAttestor: &v1alpha2.NodeAttestor{K8sPSATEnabled: ptr.To(true), PSATAudience: audience}}
+	}
+	tests := []struct {
+		name           string
+		server         *v1alpha2.SpireServerSpec
+		agent          *v1alpha2.SpireAgentSpec
+		wantReason     string
+		wantConsistent bool
+	}{
+		{
+			name:           "defaults",
+			server:         &v1alpha2.SpireServerSpec{},
+			agent:          psatAgent(""),
+			wantReason:     PSATReasonConsistent,
+			wantConsistent: true,
+		},
+		{
+			name:           "custom audience accepted",
+			server:         &v1alpha2.SpireServerSpec{PSAT: &v1alpha2.PSATAttestationConfig{Audiences: []string{"spire-server", "spire-edge"}}},
+			agent:          psatAgent("spire-edge"),
+			wantReason:     PSATReasonConsistent,
+			wantConsistent: true,
+		},
+		{
+			name:           "audience not accepted",
+			server:         &v1alpha2.SpireServerSpec{},
+			agent:          psatAgent("spire-edge"),
+			wantReason:     PSATReasonAudienceMismatch,
+			wantConsistent: false,
+		},
+		{
+			name: "agent service account not allowed",
+			server: &v1alpha2.SpireServerSpec{PSAT: &v1alpha2.PSATAttestationConfig{
+				ServiceAccountAllowList: []string{"other:spire-agent"},
+			}},
+			agent:          psatAgent(""),
+			wantReason:     PSATReasonServiceAccountNotAllowed,
+			wantConsistent: false,
+		},
+		{
+			name:           "psat disabled",
+			server:         &v1alpha2.SpireServerSpec{PSAT: &v1alpha2.PSATAttestationConfig{Audiences: []string{"other"}}},
+			agent:          &v1alpha2.SpireAgentSpec{NodeAttestor: &v1alpha2.NodeAttestor{K8sPSATEnabled: ptr.To(false)}},
+			wantReason:     PSATReasonConsistent,
+			wantConsistent: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, message, consistent := CheckPSATConsistency(tt.server, tt.agent)
+			if reason != tt.wantReason || consistent != tt.wantConsistent {
+				t.Errorf("Expected %s (consistent %v), got %s (consistent %v): %s", tt.wantReason, tt.wantConsistent, reason, consistent, message)
+			}
+		})
+	}
+}
+
+func TestCheckPSATProjection(t *testing.T) {
+	psatAgent := &v1alpha2.SpireAgentSpec{NodeAttestor: &v1alpha2.NodeAttestor{K8sPSATEnabled: ptr.To(true), PSATAudience: "spire-edge", PSATTokenExpirationSeconds: 3600}}
+	server := &v1alpha2.SpireServerSpec{PSAT: &v1alpha2.PSATAttestationConfig{Audiences: []string{"spire-server", "spire-edge"}}}
+	tests := []struct {
+		name           string
+		server         *v1alpha2.SpireServerSpec
+		agent          *v1alpha2.SpireAgentSpec
+		deployed       *v1alpha2.ProjectedTokenStatus
+		wantReason     string
+		wantConsistent bool
+		wantMessage    string
+	}{
+		{
+			name:           "deployed as specified",
+			server:         server,
+			agent:          psatAgent,
+			deployed:       &v1alpha2.ProjectedTokenStatus{Audience: "spire-edge", ExpirationSeconds: 3600},
+			wantReason:     PSATReasonConsistent,
+			wantConsistent: true,
+		},
+		{
+			name:           "update pending with an accepted audience",
+			server:         server,
+			agent:          psatAgent,
+			deployed:       &v1alpha2.ProjectedTokenStatus{Audience: "spire-server", ExpirationSeconds: 7200},
+			wantReason:     PSATReasonConsistent,
+			wantConsistent: true,
+			wantMessage:    "pending the update",
+		},
+		{
+			name:           "deployed audience not accepted",
+			server:         &v1alpha2.SpireServerSpec{PSAT: &v1alpha2.PSATAttestationConfig{Audiences: []string{"spire-edge"}}},
+			agent:          psatAgent,
+			deployed:       &v1alpha2.ProjectedTokenStatus{Audience: "spire-server", ExpirationSeconds: 7200},
+			wantReason:     PSATReasonProjectedTokenDrift,
+			wantConsistent: false,
+		},
+		{
+			name:           "no token projected",
+			server:         server,
+			agent:          psatAgent,
+			wantReason:     PSATReasonProjectedTokenDrift,
+			wantConsistent: false,
+		},
+		{
+			name:           "psat disabled",
+			server:         server,
+			agent:          &v1alpha2.SpireAgentSpec{NodeAttestor: &v1alpha2.NodeAttestor{K8sPSATEnabled: ptr.To(false)}},
+			wantReason:     PSATReasonConsistent,
+			wantConsistent: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, message, consistent := CheckPSATProjection(tt.server, tt.agent, tt.deployed)
+			if reason != tt.wantReason || consistent != tt.wantConsistent {
+				t.Errorf("Expected %s (consistent %v), got %s (consistent %v): %s", tt.wantReason, tt.wantConsistent, reason, consistent, message)
+			}
+			if !strings.Contains(message, tt.wantMessage) {
+				t.Errorf("Expected the message to contain %q, got %q", tt.wantMessage, message)
+			}
+		})
+	}
+}
+
+func TestPSATServiceAccountAllowListDefault(t *testing.T) {
+	t.Setenv("OPERATOR_NAMESPACE", "zero-trust-workload-identity-manager")
+
+	allowList := PSATServiceAccountAllowList(&v1alpha2.SpireServerSpec{})
+	if len(allowList) != 1 || allowList[0] != "zero-trust-workload-identity-manager:spire-agent" {
+		t.Errorf("Expected the spire-agent service account to be allowed by default, got %v", allowList)
+	}
+}
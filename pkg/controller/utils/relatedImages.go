@@ -26,6 +26,14 @@ func GetSpiffeCSIDriverImage() string {
 	return spiffeCSIDriverImage
 }
 
+func GetSpiffeCSIDriverSVIDFilesImage() string {
+	spiffeCSIDriverSVIDFilesImage := os.Getenv(SpiffeCSIDriverSVIDFilesImageEnv)
+	if spiffeCSIDriverSVIDFilesImage == "" {
+		return ""
+	}
+	return spiffeCSIDriverSVIDFilesImage
+}
+
 func GetSpireControllerManagerImage() string {
 	spireControllerManagerImage := os.Getenv(SpireControllerManagerImageEnv)
 	if spireControllerManagerImage == "" {
@@ -57,3 +65,27 @@ func GetSpiffeCsiInitContainerImage() string {
 	}
 	return containerImage
 }
+
+func GetSpiffeHelperImage() string {
+	spiffeHelperImage := os.Getenv(SpiffeHelperImageEnv)
+	if spiffeHelperImage == "" {
+		return ""
+	}
+	return spiffeHelperImage
+}
+
+func GetStatsdExporterImage() string {
+	statsdExporterImage := os.Getenv(StatsdExporterImageEnv)
+	if statsdExporterImage == "" {
+		return ""
+	}
+	return statsdExporterImage
+}
+
+func GetJWTValidatingProxyImage() string {
+	jwtValidatingProxyImage := os.Getenv(JWTValidatingProxyImageEnv)
+	if jwtValidatingProxyImage == "" {
+		return ""
+	}
+	return jwtValidatingProxyImage
+}
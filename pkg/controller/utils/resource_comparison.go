@@ -58,6 +58,8 @@ func ResourceNeedsUpdate(existing, desired client.Object) bool {
 		typeSpecificResult = SecurityContextConstraintsNeedsUpdate(existingTyped, desired.(*securityv1.SecurityContextConstraints))
 	case *spiffev1alpha1.ClusterSPIFFEID:
 		typeSpecificResult = ClusterSPIFFEIDNeedsUpdate(existingTyped, desired.(*spiffev1alpha1.ClusterSPIFFEID))
+	case *spiffev1alpha1.ClusterStaticEntry:
+		typeSpecificResult = ClusterStaticEntryNeedsUpdate(existingTyped, desired.(*spiffev1alpha1.ClusterStaticEntry))
 	case *appsv1.StatefulSet:
 		typeSpecificResult = StatefulSetNeedsUpdate(existingTyped, desired.(*appsv1.StatefulSet))
 	case *appsv1.Deployment:
@@ -255,6 +257,9 @@ func CSIDriverNeedsUpdate(existing, desired *storagev1.CSIDriver) bool {
 	if !boolPtrsEqual(existing.Spec.PodInfoOnMount, desired.Spec.PodInfoOnMount) {
 		return true
 	}
+	if !boolPtrsEqual(existing.Spec.RequiresRepublish, desired.Spec.RequiresRepublish) {
+		return true
+	}
 	// FSGroupPolicy is also a pointer
 	if !fsGroupPolicyPtrsEqual(existing.Spec.FSGroupPolicy, desired.Spec.FSGroupPolicy) {
 		return true
@@ -395,8 +400,9 @@ func ClusterSPIFFEIDNeedsUpdate(existing, desired *spiffev1alpha1.ClusterSPIFFEI
 		existing.Spec.AutoPopulateDNSNames != desired.Spec.AutoPopulateDNSNames {
 		return true
 	}
-	// Compare DNS name templates
-	if !stringSlicesEqual(existing.Spec.DNSNameTemplates, desired.Spec.DNSNameTemplates) {
+	// Compare DNS name templates and federated trust domains
+	if !stringSlicesEqual(existing.Spec.DNSNameTemplates, desired.Spec.DNSNameTemplates) ||
+		!stringSlicesEqual(existing.Spec.FederatesWith, desired.Spec.FederatesWith) {
 		return true
 	}
 	// Compare selectors using Semantic.DeepEqual for Kubernetes types
@@ -408,6 +414,21 @@ func ClusterSPIFFEIDNeedsUpdate(existing, desired *spiffev1alpha1.ClusterSPIFFEI
 	return false
 }
 
+// ClusterStaticEntryNeedsUpdate checks if a ClusterStaticEntry needs updating
+func ClusterStaticEntryNeedsUpdate(existing, desired *spiffev1alpha1.ClusterStaticEntry) bool {
+	if existing.Spec.ClassName != desired.Spec.ClassName ||
+		existing.Spec.SPIFFEID != desired.Spec.SPIFFEID ||
+		existing.Spec.ParentID != desired.Spec.ParentID ||
+		existing.Spec.Hint != desired.Spec.Hint ||
+		existing.Spec.Admin != desired.Spec.Admin ||
+		existing.Spec.Downstream != desired.Spec.Downstream {
+		return true
+	}
+	return !stringSlicesEqual(existing.Spec.Selectors, desired.Spec.Selectors) ||
+		!stringSlicesEqual(existing.Spec.DNSNames, desired.Spec.DNSNames) ||
+		!stringSlicesEqual(existing.Spec.FederatesWith, desired.Spec.FederatesWith)
+}
+
 // volumesEqual compares two volume slices for equality
 func volumesEqual(fetched, desired []corev1.Volume) bool {
 	if len(desired) == 0 && len(fetched) == 0 {
@@ -641,6 +662,12 @@ func StatefulSetNeedsUpdate(fetched, desired *appsv1.StatefulSet) bool {
 	if dPod.DNSPolicy != "" && dPod.DNSPolicy != fPod.DNSPolicy {
 		return true
 	}
+	if !equality.Semantic.DeepEqual(dPod.DNSConfig, fPod.DNSConfig) {
+		return true
+	}
+	if !localObjectReferencesEqual(fPod.ImagePullSecrets, dPod.ImagePullSecrets) {
+		return true
+	}
 	if len(dPod.NodeSelector) != len(fPod.NodeSelector) {
 		return true
 	}
@@ -751,6 +778,12 @@ func DeploymentNeedsUpdate(fetched, desired *appsv1.Deployment) bool {
 	if dPod.DNSPolicy != "" && dPod.DNSPolicy != fPod.DNSPolicy {
 		return true
 	}
+	if !equality.Semantic.DeepEqual(dPod.DNSConfig, fPod.DNSConfig) {
+		return true
+	}
+	if !localObjectReferencesEqual(fPod.ImagePullSecrets, dPod.ImagePullSecrets) {
+		return true
+	}
 	if len(dPod.NodeSelector) != len(fPod.NodeSelector) {
 		return true
 	}
@@ -760,6 +793,9 @@ func DeploymentNeedsUpdate(fetched, desired *appsv1.Deployment) bool {
 	if !equality.Semantic.DeepEqual(dPod.Affinity, fPod.Affinity) {
 		return true
 	}
+	if !equality.Semantic.DeepEqual(dPod.TopologySpreadConstraints, fPod.TopologySpreadConstraints) {
+		return true
+	}
 	if len(dPod.Tolerations) != len(fPod.Tolerations) {
 		return true
 	}
@@ -840,6 +876,12 @@ func DaemonSetNeedsUpdate(fetched, desired *appsv1.DaemonSet) bool {
 	if dPod.DNSPolicy != "" && dPod.DNSPolicy != fPod.DNSPolicy {
 		return true
 	}
+	if !equality.Semantic.DeepEqual(dPod.DNSConfig, fPod.DNSConfig) {
+		return true
+	}
+	if !localObjectReferencesEqual(fPod.ImagePullSecrets, dPod.ImagePullSecrets) {
+		return true
+	}
 	if len(dPod.NodeSelector) != len(fPod.NodeSelector) {
 		return true
 	}
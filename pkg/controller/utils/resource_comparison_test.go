@@ -1885,6 +1885,19 @@ func TestCSIDriverNeedsUpdate(t *testing.T) {
 		}
 	})
 
+	t.Run("different RequiresRepublish needs update", func(t *testing.T) {
+		trueVal := true
+		current := &storagev1.CSIDriver{}
+		desired := &storagev1.CSIDriver{
+			Spec: storagev1.CSIDriverSpec{
+				RequiresRepublish: &trueVal,
+			},
+		}
+		if !CSIDriverNeedsUpdate(current, desired) {
+			t.Error("Expected true when RequiresRepublish differs")
+		}
+	})
+
 	t.Run("different PodInfoOnMount needs update", func(t *testing.T) {
 		trueVal := true
 		falseVal := false
@@ -2012,6 +2025,23 @@ func TestClusterSPIFFEIDNeedsUpdate(t *testing.T) {
 			t.Error("Expected true when SPIFFEIDTemplate differs")
 		}
 	})
+
+	t.Run("different FederatesWith needs update", func(t *testing.T) {
+		current := &spiffev1alpha1.ClusterSPIFFEID{
+			Spec: spiffev1alpha1.ClusterSPIFFEIDSpec{
+				SPIFFEIDTemplate: "spiffe://example.org/test",
+			},
+		}
+		desired := &spiffev1alpha1.ClusterSPIFFEID{
+			Spec: spiffev1alpha1.ClusterSPIFFEIDSpec{
+				SPIFFEIDTemplate: "spiffe://example.org/test",
+				FederatesWith:    []string{"partner.org"},
+			},
+		}
+		if !ClusterSPIFFEIDNeedsUpdate(current, desired) {
+			t.Error("Expected true when FederatesWith differs")
+		}
+	})
 }
 
 // TestResourceNeedsUpdate_AllScenarios tests ResourceNeedsUpdate with table-driven tests
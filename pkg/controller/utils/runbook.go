@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// RunbookBaseURLEnv overrides the base URL of the runbooks linked from failure conditions and
+	// Events, e.g. to point to an internal mirror. Setting it to "none" disables the links.
+	RunbookBaseURLEnv = "RUNBOOK_BASE_URL"
+
+	// DefaultRunbookBaseURL is where the runbooks are published, one page per reason
+	DefaultRunbookBaseURL = "https://github.com/openshift/runbooks/blob/master/alerts/zero-trust-workload-identity-manager"
+)
+
+// RunbookURL returns the runbook link for a condition or Event reason, empty when the links are disabled
+func RunbookURL(reason string) string {
+	base := strings.TrimSpace(os.Getenv(RunbookBaseURLEnv))
+	switch {
+	case strings.EqualFold(base, "none"):
+		return ""
+	case base == "":
+		base = DefaultRunbookBaseURL
+	}
+	return fmt.Sprintf("%s/%s.md", strings.TrimSuffix(base, "/"), reason)
+}
+
+// WithRunbook renders the reason code and its runbook link into a failure message, so that the
+// message alone is enough to triage from a console or an Event listing
+func WithRunbook(reason, message string) string {
+	url := RunbookURL(reason)
+	if reason == "" || url == "" {
+		return message
+	}
+	return fmt.Sprintf("%s (reason: %s, runbook: %s)", message, reason, url)
+}
@@ -0,0 +1,23 @@
+package utils
+
+import "testing"
+
+func TestWithRunbook(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseURL  string
+		expected string
+	}{
+		{name: "default base URL", baseURL: "", expected: "failed (reason: TransientError, runbook: " + DefaultRunbookBaseURL + "/TransientError.md)"},
+		{name: "custom base URL", baseURL: "https://mirror.example.com/runbooks/", expected: "failed (reason: TransientError, runbook: https://mirror.example.com/runbooks/TransientError.md)"},
+		{name: "disabled", baseURL: "none", expected: "failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(RunbookBaseURLEnv, tt.baseURL)
+			if message := WithRunbook("TransientError", "failed"); message != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, message)
+			}
+		})
+	}
+}
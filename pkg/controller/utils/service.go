@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+// ApplyServiceConfig applies the user customization of an operand Service to the desired Service.
+// User annotations never override annotations set by the operator, and port overrides must
+// name an existing port of the Service.
+func ApplyServiceConfig(svc *corev1.Service, config *v1alpha2.ServiceConfig) error {
+	if config == nil {
+		return nil
+	}
+
+	if config.Type != "" {
+		svc.Spec.Type = config.Type
+	}
+
+	if len(config.Annotations) > 0 && svc.Annotations == nil {
+		svc.Annotations = make(map[string]string, len(config.Annotations))
+	}
+	for key, value := range config.Annotations {
+		if _, ok := svc.Annotations[key]; !ok {
+			svc.Annotations[key] = value
+		}
+	}
+
+	for _, override := range config.Ports {
+		found := false
+		for i := range svc.Spec.Ports {
+			if svc.Spec.Ports[i].Name != override.Name {
+				continue
+			}
+			found = true
+			if override.Port != 0 {
+				svc.Spec.Ports[i].Port = override.Port
+			}
+			if override.NodePort != 0 {
+				svc.Spec.Ports[i].NodePort = override.NodePort
+			}
+		}
+		if !found {
+			return fmt.Errorf("service %s has no port named %q", svc.Name, override.Name)
+		}
+	}
+
+	return nil
+}
+
+// PreserveServiceNodePorts keeps the node ports allocated by Kubernetes on the existing Service
+// for the ports of the desired Service without an explicit node port, so allocated node ports
+// are not reported as drift. Node ports are only kept while the Service type still uses them.
+func PreserveServiceNodePorts(existing, desired *corev1.Service) {
+	if desired.Spec.Type != corev1.ServiceTypeNodePort && desired.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		return
+	}
+	for i := range desired.Spec.Ports {
+		if desired.Spec.Ports[i].NodePort != 0 {
+			continue
+		}
+		for _, port := range existing.Spec.Ports {
+			if port.Name == desired.Spec.Ports[i].Name {
+				desired.Spec.Ports[i].NodePort = port.NodePort
+			}
+		}
+	}
+}
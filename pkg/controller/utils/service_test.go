@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+func newTestService() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "spire-server",
+			Annotations: map[string]string{ServiceCAAnnotationKey: "spire-server-serving-cert"},
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{Name: "grpc", Port: 443},
+				{Name: "metrics", Port: 9402},
+			},
+		},
+	}
+}
+
+func TestApplyServiceConfig(t *testing.T) {
+	t.Run("nil config leaves the Service unchanged", func(t *testing.T) {
+		svc := newTestService()
+		if err := ApplyServiceConfig(svc, nil); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if svc.Spec.Type != corev1.ServiceTypeClusterIP || svc.Spec.Ports[0].Port != 443 {
+			t.Errorf("Expected the Service to be unchanged, got %v", svc.Spec)
+		}
+	})
+
+	t.Run("type, annotations and ports are applied", func(t *testing.T) {
+		svc := newTestService()
+		err := ApplyServiceConfig(svc, &v1alpha2.ServiceConfig{
+			Type: corev1.ServiceTypeLoadBalancer,
+			Annotations: map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-internal": "true",
+				ServiceCAAnnotationKey: "user-value",
+			},
+			Ports: []v1alpha2.ServicePortConfig{{Name: "grpc", Port: 8081, NodePort: 30081}},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			t.Errorf("Expected type LoadBalancer, got %s", svc.Spec.Type)
+		}
+		if svc.Annotations["service.beta.kubernetes.io/aws-load-balancer-internal"] != "true" {
+			t.Errorf("Expected user annotation to be added, got %v", svc.Annotations)
+		}
+		if svc.Annotations[ServiceCAAnnotationKey] != "spire-server-serving-cert" {
+			t.Errorf("Expected operator annotation to take precedence, got %v", svc.Annotations)
+		}
+		if svc.Spec.Ports[0].Port != 8081 || svc.Spec.Ports[0].NodePort != 30081 {
+			t.Errorf("Expected grpc port override, got %v", svc.Spec.Ports[0])
+		}
+		if svc.Spec.Ports[1].Port != 9402 {
+			t.Errorf("Expected metrics port to be unchanged, got %v", svc.Spec.Ports[1])
+		}
+	})
+
+	t.Run("unknown port name is rejected", func(t *testing.T) {
+		svc := newTestService()
+		err := ApplyServiceConfig(svc, &v1alpha2.ServiceConfig{Ports: []v1alpha2.ServicePortConfig{{Name: "https", Port: 8443}}})
+		if err == nil {
+			t.Error("Expected an error for a port override not matching any Service port")
+		}
+	})
+}
+
+func TestPreserveServiceNodePorts(t *testing.T) {
+	existing := newTestService()
+	existing.Spec.Ports[0].NodePort = 31000
+	existing.Spec.Ports[1].NodePort = 31001
+
+	t.Run("allocated node ports are kept", func(t *testing.T) {
+		desired := newTestService()
+		desired.Spec.Type = corev1.ServiceTypeLoadBalancer
+		desired.Spec.Ports[1].NodePort = 32000
+		PreserveServiceNodePorts(existing, desired)
+		if desired.Spec.Ports[0].NodePort != 31000 {
+			t.Errorf("Expected allocated node port to be kept, got %d", desired.Spec.Ports[0].NodePort)
+		}
+		if desired.Spec.Ports[1].NodePort != 32000 {
+			t.Errorf("Expected explicit node port to win, got %d", desired.Spec.Ports[1].NodePort)
+		}
+	})
+
+	t.Run("node ports are dropped for ClusterIP", func(t *testing.T) {
+		desired := newTestService()
+		PreserveServiceNodePorts(existing, desired)
+		if desired.Spec.Ports[0].NodePort != 0 {
+			t.Errorf("Expected no node port for ClusterIP, got %d", desired.Spec.Ports[0].NodePort)
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package utils
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+// Datastore connection pool defaults of the SpireServer CRD. Pool settings still at their
+// default are considered unset and replaced by the sizing profile.
+const (
+	defaultDatastoreMaxOpenConns    = 100
+	defaultDatastoreMaxIdleConns    = 2
+	defaultDatastoreConnMaxLifetime = 0
+)
+
+// sizingPreset holds the resources of each operand kind and the datastore pool settings of a profile
+type sizingPreset struct {
+	resources       map[string]corev1.ResourceRequirements
+	maxOpenConns    int
+	maxIdleConns    int
+	connMaxLifetime int
+}
+
+func resourceRequirements(requestCPU, requestMemory, limitCPU, limitMemory string) corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(requestCPU),
+			corev1.ResourceMemory: resource.MustParse(requestMemory),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(limitCPU),
+			corev1.ResourceMemory: resource.MustParse(limitMemory),
+		},
+	}
+}
+
+var sizingPresets = map[v1alpha2.SizingProfile]sizingPreset{
+	v1alpha2.SizingProfileSmall: {
+		resources: map[string]corev1.ResourceRequirements{
+			ResourceKindSpireServer:                resourceRequirements("100m", "256Mi", "500m", "512Mi"),
+			ResourceKindSpireAgent:                 resourceRequirements("50m", "64Mi", "250m", "256Mi"),
+			ResourceKindSpiffeCSIDriver:            resourceRequirements("10m", "32Mi", "100m", "64Mi"),
+			ResourceKindSpireOIDCDiscoveryProvider: resourceRequirements("25m", "64Mi", "200m", "128Mi"),
+		},
+		maxOpenConns:    20,
+		maxIdleConns:    2,
+		connMaxLifetime: 3600,
+	},
+	v1alpha2.SizingProfileMedium: {
+		resources: map[string]corev1.ResourceRequirements{
+			ResourceKindSpireServer:                resourceRequirements("250m", "512Mi", "1", "1Gi"),
+			ResourceKindSpireAgent:                 resourceRequirements("100m", "128Mi", "500m", "512Mi"),
+			ResourceKindSpiffeCSIDriver:            resourceRequirements("20m", "64Mi", "200m", "128Mi"),
+			ResourceKindSpireOIDCDiscoveryProvider: resourceRequirements("50m", "128Mi", "500m", "256Mi"),
+		},
+		maxOpenConns:    100,
+		maxIdleConns:    10,
+		connMaxLifetime: 3600,
+	},
+	v1alpha2.SizingProfileLarge: {
+		resources: map[string]corev1.ResourceRequirements{
+			ResourceKindSpireServer:                resourceRequirements("1", "2Gi", "4", "4Gi"),
+			ResourceKindSpireAgent:                 resourceRequirements("200m", "256Mi", "1", "1Gi"),
+			ResourceKindSpiffeCSIDriver:            resourceRequirements("50m", "64Mi", "500m", "256Mi"),
+			ResourceKindSpireOIDCDiscoveryProvider: resourceRequirements("100m", "256Mi", "1", "512Mi"),
+		},
+		maxOpenConns:    400,
+		maxIdleConns:    50,
+		connMaxLifetime: 3600,
+	},
+	// Requests are kept minimal so the identity stack fits next to the control plane of a
+	// single node; limits still allow bursts at startup
+	v1alpha2.SizingProfileSingleNode: {
+		resources: map[string]corev1.ResourceRequirements{
+			ResourceKindSpireServer:                resourceRequirements("50m", "128Mi", "500m", "512Mi"),
+			ResourceKindSpireAgent:                 resourceRequirements("20m", "48Mi", "250m", "256Mi"),
+			ResourceKindSpiffeCSIDriver:            resourceRequirements("5m", "16Mi", "100m", "64Mi"),
+			ResourceKindSpireOIDCDiscoveryProvider: resourceRequirements("10m", "32Mi", "200m", "128Mi"),
+		},
+		maxOpenConns:    10,
+		maxIdleConns:    2,
+		connMaxLifetime: 3600,
+	},
+}
+
+// ApplySizingProfile fills in the resources of an operand of the given kind from the sizing
+// profile, unless resources are set on the operand CR. The CR is only changed in memory.
+func ApplySizingProfile(profile v1alpha2.SizingProfile, kind string, config *v1alpha2.CommonConfig) {
+	preset, ok := sizingPresets[profile]
+	if !ok || config.Resources != nil {
+		return
+	}
+	if resources, ok := preset.resources[kind]; ok {
+		config.Resources = resources.DeepCopy()
+	}
+}
+
+// ApplySizingProfileToDatastore replaces the datastore pool settings still at their default by
+// the ones of the sizing profile. sqlite3 is left alone, as it does not benefit from a larger pool.
+func ApplySizingProfileToDatastore(profile v1alpha2.SizingProfile, datastore *v1alpha2.DataStore) {
+	preset, ok := sizingPresets[profile]
+	if !ok || datastore.DatabaseType == "sqlite3" {
+		return
+	}
+	if datastore.MaxOpenConns == defaultDatastoreMaxOpenConns {
+		datastore.MaxOpenConns = preset.maxOpenConns
+	}
+	if datastore.MaxIdleConns == defaultDatastoreMaxIdleConns {
+		datastore.MaxIdleConns = preset.maxIdleConns
+	}
+	if datastore.ConnMaxLifetime == defaultDatastoreConnMaxLifetime {
+		datastore.ConnMaxLifetime = preset.connMaxLifetime
+	}
+}
+
+// SizingProfileResources returns the resources of an operand of the given kind under the sizing
+// profile, nil for an unknown profile
+func SizingProfileResources(profile v1alpha2.SizingProfile, kind string) *corev1.ResourceRequirements {
+	resources, ok := sizingPresets[profile].resources[kind]
+	if !ok {
+		return nil
+	}
+	return resources.DeepCopy()
+}
+
+// SizingProfileDatastorePool returns the datastore pool settings of the sizing profile
+func SizingProfileDatastorePool(profile v1alpha2.SizingProfile) (maxOpenConns, maxIdleConns int) {
+	preset := sizingPresets[profile]
+	return preset.maxOpenConns, preset.maxIdleConns
+}
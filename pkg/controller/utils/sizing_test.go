@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+func TestApplySizingProfile(t *testing.T) {
+	t.Run("no profile", func(t *testing.T) {
+		config := v1alpha2.CommonConfig{}
+		ApplySizingProfile("", ResourceKindSpireServer, &config)
+		if config.Resources != nil {
+			t.Errorf("Expected no resources without a profile, got %v", config.Resources)
+		}
+	})
+
+	t.Run("profile fills in resources", func(t *testing.T) {
+		config := v1alpha2.CommonConfig{}
+		ApplySizingProfile(v1alpha2.SizingProfileLarge, ResourceKindSpireServer, &config)
+		if config.Resources == nil || !config.Resources.Requests.Memory().Equal(resource.MustParse("2Gi")) {
+			t.Errorf("Expected the large server resources, got %v", config.Resources)
+		}
+	})
+
+	t.Run("profiles scale with the cluster size", func(t *testing.T) {
+		var previous *corev1.ResourceRequirements
+		for _, profile := range []v1alpha2.SizingProfile{v1alpha2.SizingProfileSmall, v1alpha2.SizingProfileMedium, v1alpha2.SizingProfileLarge} {
+			for _, kind := range []string{ResourceKindSpireServer, ResourceKindSpireAgent, ResourceKindSpiffeCSIDriver, ResourceKindSpireOIDCDiscoveryProvider} {
+				config := v1alpha2.CommonConfig{}
+				ApplySizingProfile(profile, kind, &config)
+				if config.Resources == nil {
+					t.Fatalf("Expected %s resources for %s", profile, kind)
+				}
+				if config.Resources.Requests.Cpu().Cmp(*config.Resources.Limits.Cpu()) > 0 ||
+					config.Resources.Requests.Memory().Cmp(*config.Resources.Limits.Memory()) > 0 {
+					t.Errorf("Expected %s requests of %s within the limits, got %v", profile, kind, config.Resources)
+				}
+			}
+			config := v1alpha2.CommonConfig{}
+			ApplySizingProfile(profile, ResourceKindSpireServer, &config)
+			if previous != nil && config.Resources.Requests.Memory().Cmp(*previous.Requests.Memory()) <= 0 {
+				t.Errorf("Expected %s to request more memory than the smaller profile", profile)
+			}
+			previous = config.Resources
+		}
+	})
+
+	t.Run("explicit resources take precedence", func(t *testing.T) {
+		explicit := &corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("3Gi")}}
+		config := v1alpha2.CommonConfig{Resources: explicit}
+		ApplySizingProfile(v1alpha2.SizingProfileSmall, ResourceKindSpireServer, &config)
+		if config.Resources != explicit {
+			t.Errorf("Expected the explicit resources to be kept, got %v", config.Resources)
+		}
+	})
+}
+
+func TestApplySizingProfileToDatastore(t *testing.T) {
+	defaults := func(databaseType string) v1alpha2.DataStore {
+		return v1alpha2.DataStore{DatabaseType: databaseType, MaxOpenConns: 100, MaxIdleConns: 2}
+	}
+
+	datastore := defaults("postgres")
+	ApplySizingProfileToDatastore(v1alpha2.SizingProfileLarge, &datastore)
+	if datastore.MaxOpenConns != 400 || datastore.MaxIdleConns != 50 || datastore.ConnMaxLifetime != 3600 {
+		t.Errorf("Expected the large pool settings, got %+v", datastore)
+	}
+
+	datastore = defaults("postgres")
+	datastore.MaxOpenConns = 30
+	ApplySizingProfileToDatastore(v1alpha2.SizingProfileLarge, &datastore)
+	if datastore.MaxOpenConns != 30 || datastore.MaxIdleConns != 50 {
+		t.Errorf("Expected the explicit maxOpenConns to be kept, got %+v", datastore)
+	}
+
+	datastore = defaults("sqlite3")
+	ApplySizingProfileToDatastore(v1alpha2.SizingProfileLarge, &datastore)
+	if datastore != defaults("sqlite3") {
+		t.Errorf("Expected sqlite3 pool settings to be left alone, got %+v", datastore)
+	}
+
+	datastore = defaults("postgres")
+	ApplySizingProfileToDatastore("", &datastore)
+	if datastore != defaults("postgres") {
+		t.Errorf("Expected no change without a profile, got %+v", datastore)
+	}
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+const spiffeIDScheme = "spiffe://"
+
+// ParseSPIFFEID checks id is a SPIFFE ID as accepted by SPIRE, with a path, and returns its
+// trust domain
+func ParseSPIFFEID(id string) (string, error) {
+	if !strings.HasPrefix(id, spiffeIDScheme) {
+		return "", fmt.Errorf("SPIFFE ID %q must start with %s", id, spiffeIDScheme)
+	}
+	trustDomain, path, _ := strings.Cut(strings.TrimPrefix(id, spiffeIDScheme), "/")
+	if trustDomain == "" {
+		return "", fmt.Errorf("SPIFFE ID %q has no trust domain", id)
+	}
+	for _, c := range trustDomain {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '.' || c == '-' || c == '_') {
+			return "", fmt.Errorf("SPIFFE ID %q: trust domain may only hold lower case letters, digits, '.', '-' and '_'", id)
+		}
+	}
+	if path == "" {
+		return "", fmt.Errorf("SPIFFE ID %q has no path", id)
+	}
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return "", fmt.Errorf("SPIFFE ID %q: path segments may not be empty, '.' or '..'", id)
+		}
+		for _, c := range segment {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '.' || c == '-' || c == '_') {
+				return "", fmt.Errorf("SPIFFE ID %q: path may only hold letters, digits, '.', '-' and '_'", id)
+			}
+		}
+	}
+	return trustDomain, nil
+}
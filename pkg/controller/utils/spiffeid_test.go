@@ -0,0 +1,34 @@
+package utils
+
+import "testing"
+
+func TestParseSPIFFEID(t *testing.T) {
+	tests := []struct {
+		id                string
+		expectTrustDomain string
+		expectErr         bool
+	}{
+		{id: "spiffe://example.org/ns/mesh/sa/node-proxy", expectTrustDomain: "example.org"},
+		{id: "spiffe://example.org/Admin_1", expectTrustDomain: "example.org"},
+		{id: "https://example.org/admin", expectErr: true},
+		{id: "spiffe:///admin", expectErr: true},
+		{id: "spiffe://Example.org/admin", expectErr: true},
+		{id: "spiffe://example.org", expectErr: true},
+		{id: "spiffe://example.org/", expectErr: true},
+		{id: "spiffe://example.org/ns//sa", expectErr: true},
+		{id: "spiffe://example.org/ns/../admin", expectErr: true},
+		{id: "spiffe://example.org/admin?x=1", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			trustDomain, err := ParseSPIFFEID(tt.id)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("Expected error %v, got %v", tt.expectErr, err)
+			}
+			if trustDomain != tt.expectTrustDomain {
+				t.Errorf("Expected trust domain %q, got %q", tt.expectTrustDomain, trustDomain)
+			}
+		})
+	}
+}
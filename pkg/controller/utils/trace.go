@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"context"
+	"maps"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// TraceIDLogKey is the log key of the trace ID of a reconcile pass
+	TraceIDLogKey = "traceID"
+	// TraceIDAnnotation carries the trace ID of the reconcile pass that recorded an Event
+	TraceIDAnnotation = "operator.openshift.io/trace-id"
+)
+
+type traceIDKey struct{}
+
+// WithTraceID returns a context carrying the trace ID of a reconcile pass, and a logger with the
+// trace ID attached. The trace ID is the reconcile ID controller-runtime logs for the pass, so
+// both correlate; a new one is generated outside a reconcile, e.g. in tests.
+func WithTraceID(ctx context.Context, logger logr.Logger) (context.Context, logr.Logger, string) {
+	traceID := string(controller.ReconcileIDFromContext(ctx))
+	if traceID == "" {
+		traceID = string(uuid.NewUUID())
+	}
+	logger = logger.WithValues(TraceIDLogKey, traceID)
+	ctx = context.WithValue(log.IntoContext(ctx, logger), traceIDKey{}, traceID)
+	return ctx, logger, traceID
+}
+
+// TraceReconcile returns the context, logger and EventRecorder of a reconcile pass, whose logs,
+// Events and status updates carry the trace ID of the pass
+func TraceReconcile(ctx context.Context, logger logr.Logger, recorder record.EventRecorder) (context.Context, logr.Logger, record.EventRecorder) {
+	ctx, logger, traceID := WithTraceID(ctx, logger)
+	return ctx, logger, NewTracedEventRecorder(recorder, traceID)
+}
+
+// TraceIDFromContext returns the trace ID of the reconcile pass, empty outside a traced reconcile
+func TraceIDFromContext(ctx context.Context) string {
+	traceID, _ := ctx.Value(traceIDKey{}).(string)
+	return traceID
+}
+
+// tracedEventRecorder annotates every Event with the trace ID of a reconcile pass
+type tracedEventRecorder struct {
+	recorder record.EventRecorder
+	traceID  string
+}
+
+// NewTracedEventRecorder returns an EventRecorder annotating the Events with the trace ID
+func NewTracedEventRecorder(recorder record.EventRecorder, traceID string) record.EventRecorder {
+	if recorder == nil {
+		return nil
+	}
+	return &tracedEventRecorder{recorder: recorder, traceID: traceID}
+}
+
+func (t *tracedEventRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	t.recorder.AnnotatedEventf(object, t.annotations(nil), eventtype, reason, "%s", message)
+}
+
+func (t *tracedEventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	t.recorder.AnnotatedEventf(object, t.annotations(nil), eventtype, reason, messageFmt, args...)
+}
+
+func (t *tracedEventRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	t.recorder.AnnotatedEventf(object, t.annotations(annotations), eventtype, reason, messageFmt, args...)
+}
+
+func (t *tracedEventRecorder) annotations(annotations map[string]string) map[string]string {
+	result := maps.Clone(annotations)
+	if result == nil {
+		result = map[string]string{}
+	}
+	result[TraceIDAnnotation] = t.traceID
+	return result
+}
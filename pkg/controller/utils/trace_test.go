@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// annotationRecorder captures the annotations of the last Event
+type annotationRecorder struct {
+	record.FakeRecorder
+	annotations map[string]string
+}
+
+func (a *annotationRecorder) AnnotatedEventf(_ runtime.Object, annotations map[string]string, _, _, _ string, _ ...interface{}) {
+	a.annotations = annotations
+}
+
+func TestWithTraceID(t *testing.T) {
+	ctx, _, traceID := WithTraceID(context.Background(), logr.Discard())
+	if traceID == "" {
+		t.Fatal("Expected a trace ID to be generated outside a reconcile")
+	}
+	if got := TraceIDFromContext(ctx); got != traceID {
+		t.Errorf("Expected the context to carry trace ID %q, got %q", traceID, got)
+	}
+	if _, _, other := WithTraceID(context.Background(), logr.Discard()); other == traceID {
+		t.Error("Expected each reconcile pass to get its own trace ID")
+	}
+	if got := TraceIDFromContext(context.Background()); got != "" {
+		t.Errorf("Expected no trace ID outside a traced reconcile, got %q", got)
+	}
+}
+
+func TestTraceReconcile(t *testing.T) {
+	recorder := &annotationRecorder{}
+	ctx, _, traced := TraceReconcile(context.Background(), logr.Discard(), recorder)
+	traced.Eventf(&corev1.Pod{}, corev1.EventTypeNormal, "Reason", "%s", "message")
+	if traceID := TraceIDFromContext(ctx); traceID == "" || recorder.annotations[TraceIDAnnotation] != traceID {
+		t.Errorf("Expected the Events to carry the trace ID %q of the context, got %v", traceID, recorder.annotations)
+	}
+	if _, _, traced := TraceReconcile(context.Background(), logr.Discard(), nil); traced != nil {
+		t.Error("Expected no recorder to wrap a nil recorder")
+	}
+}
+
+func TestTracedEventRecorder(t *testing.T) {
+	if NewTracedEventRecorder(nil, "trace") != nil {
+		t.Error("Expected no recorder to wrap a nil recorder")
+	}
+
+	recorder := &annotationRecorder{}
+	traced := NewTracedEventRecorder(recorder, "trace")
+	traced.Event(&corev1.Pod{}, corev1.EventTypeWarning, "Reason", "message")
+	if recorder.annotations[TraceIDAnnotation] != "trace" {
+		t.Errorf("Expected the Event to carry the trace ID, got %v", recorder.annotations)
+	}
+
+	annotations := map[string]string{"audit": "true"}
+	traced.AnnotatedEventf(&corev1.Pod{}, annotations, corev1.EventTypeNormal, "Reason", "message")
+	if recorder.annotations["audit"] != "true" || recorder.annotations[TraceIDAnnotation] != "trace" {
+		t.Errorf("Expected the trace ID to be added to the Event annotations, got %v", recorder.annotations)
+	}
+	if _, ok := annotations[TraceIDAnnotation]; ok {
+		t.Error("Expected the caller's annotations to be left unchanged")
+	}
+}
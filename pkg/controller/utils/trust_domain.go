@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+// TrustDomain returns the trust domain the operands run in: the newTrustDomain of the trust
+// domain migration of the trustDomain once it is cut over, the trustDomain otherwise
+func TrustDomain(ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager) string {
+	if migration := ActiveTrustDomainMigration(ztwim); migration != nil && migration.Stage != v1alpha2.TrustDomainMigrationStagePrepare && migration.Stage != "" {
+		return migration.NewTrustDomain
+	}
+	return ztwim.Spec.TrustDomain
+}
+
+// ActiveTrustDomainMigration returns the trust domain migration of the trustDomain, nil when none
+// is configured or the trustDomain was already set to its newTrustDomain
+func ActiveTrustDomainMigration(ztwim *v1alpha2.ZeroTrustWorkloadIdentityManager) *v1alpha2.TrustDomainMigrationConfig {
+	migration := ztwim.Spec.TrustDomainMigration
+	if migration == nil || migration.OldTrustDomain != ztwim.Spec.TrustDomain {
+		return nil
+	}
+	return migration
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+func TestTrustDomain(t *testing.T) {
+	tests := []struct {
+		name        string
+		trustDomain string
+		stage       v1alpha2.TrustDomainMigrationStage
+		expected    string
+	}{
+		{name: "no migration", trustDomain: "old.example.org", expected: "old.example.org"},
+		{name: "prepare", trustDomain: "old.example.org", stage: v1alpha2.TrustDomainMigrationStagePrepare, expected: "old.example.org"},
+		{name: "cutover", trustDomain: "old.example.org", stage: v1alpha2.TrustDomainMigrationStageCutover, expected: "new.example.org"},
+		{name: "complete", trustDomain: "old.example.org", stage: v1alpha2.TrustDomainMigrationStageComplete, expected: "new.example.org"},
+		{name: "trust domain moved", trustDomain: "new.example.org", stage: v1alpha2.TrustDomainMigrationStageComplete, expected: "new.example.org"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: tt.trustDomain}}
+			if tt.stage != "" {
+				ztwim.Spec.TrustDomainMigration = &v1alpha2.TrustDomainMigrationConfig{
+					OldTrustDomain: "old.example.org",
+					NewTrustDomain: "new.example.org",
+					Stage:          tt.stage,
+				}
+			}
+			if trustDomain := TrustDomain(ztwim); trustDomain != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, trustDomain)
+			}
+		})
+	}
+}
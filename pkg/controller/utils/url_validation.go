@@ -109,3 +109,24 @@ func removeTrailingSlash(s string) string {
 
 	return trimmed
 }
+
+// ValidateJWTIssuerAliases checks the JWT issuer aliases are valid URLs whose hosts differ from
+// each other and from the JWT issuer, as each is served by its own Route
+func ValidateJWTIssuerAliases(issuer string, aliases []string) error {
+	issuerHost, err := StripProtocolFromJWTIssuer(issuer)
+	if err != nil {
+		return err
+	}
+	seen := map[string]bool{issuerHost: true}
+	for _, alias := range aliases {
+		host, err := StripProtocolFromJWTIssuer(alias)
+		if err != nil {
+			return fmt.Errorf("JWT issuer alias %s: %w", alias, err)
+		}
+		if seen[host] {
+			return fmt.Errorf("JWT issuer alias %s duplicates the JWT issuer or another alias", alias)
+		}
+		seen[host] = true
+	}
+	return nil
+}
@@ -327,3 +327,25 @@ func TestStripProtocolFromJWTIssuer(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateJWTIssuerAliases(t *testing.T) {
+	tests := []struct {
+		name    string
+		aliases []string
+		wantErr bool
+	}{
+		{name: "no aliases"},
+		{name: "previous issuer", aliases: []string{"https://oidc.old.example.com"}},
+		{name: "same host as the issuer", aliases: []string{"https://oidc.example.com/"}, wantErr: true},
+		{name: "duplicate aliases", aliases: []string{"https://oidc.old.example.com", "https://OIDC.old.example.com"}, wantErr: true},
+		{name: "invalid alias", aliases: []string{"oidc.old.example.com"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateJWTIssuerAliases("https://oidc.example.com", tt.aliases)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
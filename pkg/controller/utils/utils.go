@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
 	"os"
@@ -21,8 +22,12 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	storagev1 "k8s.io/api/storage/v1"
 
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 )
 
 // logInvalidCreateOnlyModeOnce ensures we only log the warning once
@@ -38,6 +43,9 @@ const (
 	CreateOnlyModeStatusType = "CreateOnlyMode"
 	CreateOnlyModeEnabled    = "CreateOnlyModeEnabled"
 	CreateOnlyModeDisabled   = "CreateOnlyModeDisabled"
+	// RequiredResourcesMissing is the reason of Ready when a resource the operand cannot run
+	// without does not exist in create-only mode
+	RequiredResourcesMissing = "RequiredResourcesMissing"
 )
 
 func init() {
@@ -66,68 +74,68 @@ func GetOperatorNamespace() string {
 	return os.Getenv("OPERATOR_NAMESPACE")
 }
 
-func DecodeClusterRoleObjBytes(objBytes []byte) *rbacv1.ClusterRole {
-	obj, err := runtime.Decode(codecs.UniversalDecoder(rbacv1.SchemeGroupVersion), objBytes)
-	if err != nil {
-		panic(err)
+// GetOperatorServiceAccount returns the service account the operator runs as, read from the
+// OPERATOR_SERVICE_ACCOUNT environment variable. Returns an empty string when the operator runs
+// outside the cluster.
+func GetOperatorServiceAccount() string {
+	return os.Getenv("OPERATOR_SERVICE_ACCOUNT")
+}
+
+// decodedAssets caches the objects decoded from the static assets, keyed by the asset bytes. The
+// assets are immutable, so each is only parsed once rather than at every reconcile.
+var decodedAssets = struct {
+	sync.RWMutex
+	objects map[string]runtime.Object
+}{objects: map[string]runtime.Object{}}
+
+// decodeAsset returns a copy of the object decoded from the asset bytes, which may be modified
+func decodeAsset(objBytes []byte, gv schema.GroupVersion) runtime.Object {
+	decodedAssets.RLock()
+	obj, ok := decodedAssets.objects[string(objBytes)]
+	decodedAssets.RUnlock()
+	if !ok {
+		var err error
+		obj, err = runtime.Decode(codecs.UniversalDecoder(gv), objBytes)
+		if err != nil {
+			panic(err)
+		}
+		decodedAssets.Lock()
+		decodedAssets.objects[string(objBytes)] = obj
+		decodedAssets.Unlock()
 	}
-	return obj.(*rbacv1.ClusterRole)
+	return obj.DeepCopyObject()
+}
+
+func DecodeClusterRoleObjBytes(objBytes []byte) *rbacv1.ClusterRole {
+	return decodeAsset(objBytes, rbacv1.SchemeGroupVersion).(*rbacv1.ClusterRole)
 }
 
 func DecodeClusterRoleBindingObjBytes(objBytes []byte) *rbacv1.ClusterRoleBinding {
-	obj, err := runtime.Decode(codecs.UniversalDecoder(rbacv1.SchemeGroupVersion), objBytes)
-	if err != nil {
-		panic(err)
-	}
-	return obj.(*rbacv1.ClusterRoleBinding)
+	return decodeAsset(objBytes, rbacv1.SchemeGroupVersion).(*rbacv1.ClusterRoleBinding)
 }
 
 func DecodeRoleObjBytes(objBytes []byte) *rbacv1.Role {
-	obj, err := runtime.Decode(codecs.UniversalDecoder(rbacv1.SchemeGroupVersion), objBytes)
-	if err != nil {
-		panic(err)
-	}
-	return obj.(*rbacv1.Role)
+	return decodeAsset(objBytes, rbacv1.SchemeGroupVersion).(*rbacv1.Role)
 }
 
 func DecodeRoleBindingObjBytes(objBytes []byte) *rbacv1.RoleBinding {
-	obj, err := runtime.Decode(codecs.UniversalDecoder(rbacv1.SchemeGroupVersion), objBytes)
-	if err != nil {
-		panic(err)
-	}
-	return obj.(*rbacv1.RoleBinding)
+	return decodeAsset(objBytes, rbacv1.SchemeGroupVersion).(*rbacv1.RoleBinding)
 }
 
 func DecodeServiceObjBytes(objBytes []byte) *corev1.Service {
-	obj, err := runtime.Decode(codecs.UniversalDecoder(corev1.SchemeGroupVersion), objBytes)
-	if err != nil {
-		panic(err)
-	}
-	return obj.(*corev1.Service)
+	return decodeAsset(objBytes, corev1.SchemeGroupVersion).(*corev1.Service)
 }
 
 func DecodeServiceAccountObjBytes(objBytes []byte) *corev1.ServiceAccount {
-	obj, err := runtime.Decode(codecs.UniversalDecoder(corev1.SchemeGroupVersion), objBytes)
-	if err != nil {
-		panic(err)
-	}
-	return obj.(*corev1.ServiceAccount)
+	return decodeAsset(objBytes, corev1.SchemeGroupVersion).(*corev1.ServiceAccount)
 }
 
 func DecodeCsiDriverObjBytes(objBytes []byte) *storagev1.CSIDriver {
-	obj, err := runtime.Decode(codecs.UniversalDecoder(storagev1.SchemeGroupVersion), objBytes)
-	if err != nil {
-		panic(err)
-	}
-	return obj.(*storagev1.CSIDriver)
+	return decodeAsset(objBytes, storagev1.SchemeGroupVersion).(*storagev1.CSIDriver)
 }
 
 func DecodeValidatingWebhookConfigurationByBytes(objBytes []byte) *admissionregistrationv1.ValidatingWebhookConfiguration {
-	obj, err := runtime.Decode(codecs.UniversalDecoder(admissionregistrationv1.SchemeGroupVersion), objBytes)
-	if err != nil {
-		panic(err)
-	}
-	return obj.(*admissionregistrationv1.ValidatingWebhookConfiguration)
+	return decodeAsset(objBytes, admissionregistrationv1.SchemeGroupVersion).(*admissionregistrationv1.ValidatingWebhookConfiguration)
 }
 
 // SetLabel sets a label key/value on the given object metadata labels map.
@@ -142,14 +150,13 @@ func SetLabel(labels map[string]string, key, value string) map[string]string {
 
 // GenerateConfigHashFromString returns a SHA256 hex string of the trimmed input string
 func GenerateConfigHashFromString(data string) string {
-	normalized := strings.TrimSpace(data) // Removes leading/trailing whitespace and newlines
-	return GenerateConfigHash([]byte(normalized))
+	hash := sha256.Sum256([]byte(strings.TrimSpace(data)))
+	return hex.EncodeToString(hash[:])
 }
 
 // GenerateConfigHash returns a SHA256 hex string of the trimmed input bytes
 func GenerateConfigHash(data []byte) string {
-	normalized := strings.TrimSpace(string(data)) // Convert to string, trim, convert back to bytes
-	hash := sha256.Sum256([]byte(normalized))
+	hash := sha256.Sum256(bytes.TrimSpace(data))
 	return hex.EncodeToString(hash[:])
 }
 
@@ -216,6 +223,14 @@ func DerefNodeSelector(selector map[string]string) map[string]string {
 	return result
 }
 
+// DerefDNSPolicy returns the DNS policy of the operand pods, ClusterFirst when unset
+func DerefDNSPolicy(policy corev1.DNSPolicy) corev1.DNSPolicy {
+	if policy == "" {
+		return corev1.DNSClusterFirst
+	}
+	return policy
+}
+
 func GetLogLevelFromString(logLevel string) string {
 	if logLevel == "" {
 		return LogLevelInfo
@@ -230,6 +245,13 @@ func GetLogFormatFromString(logFormat string) string {
 	return logFormat
 }
 
+// IsDryRunRequested reports whether the dry-run annotation is set to "true" on the operand CR.
+// In dry-run mode the controller computes the desired state and reports the pending changes
+// in status without applying them.
+func IsDryRunRequested(obj client.Object) bool {
+	return StringToBool(obj.GetAnnotations()[DryRunAnnotation])
+}
+
 // IsInCreateOnlyMode checks if create-only mode is enabled.
 // It accepts case-insensitive values:
 //   - "true", "TRUE", "True" -> returns true (enabled)
@@ -254,15 +276,38 @@ func IsInCreateOnlyMode() bool {
 	}
 }
 
-// ZTWIMSpecChangedPredicate triggers reconciliation when ZTWIM spec is created
-// while avoiding unnecessary reconciliations when only non-critical fields change
+// ZTWIMSpecChangedPredicate triggers reconciliation when ZTWIM spec is created or changed,
+// e.g. its sizingProfile, while avoiding unnecessary reconciliations on status or metadata changes
 var ZTWIMSpecChangedPredicate = predicate.Funcs{
 	CreateFunc: func(e event.CreateEvent) bool {
 		return true
 	},
 	UpdateFunc: func(e event.UpdateEvent) bool {
+		return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration()
+	},
+	DeleteFunc: func(e event.DeleteEvent) bool {
+		return true
+	},
+	GenericFunc: func(e event.GenericEvent) bool {
 		return false
 	},
+}
+
+// ReadyConditionChangedPredicate triggers reconciliation when the Ready condition of the
+// SpireServer changes, e.g. so the operands waiting for the server resume once it is Ready
+var ReadyConditionChangedPredicate = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		return true
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldServer, okOld := e.ObjectOld.(*v1alpha2.SpireServer)
+		newServer, okNew := e.ObjectNew.(*v1alpha2.SpireServer)
+		if !okOld || !okNew {
+			return true
+		}
+		return apimeta.IsStatusConditionTrue(oldServer.Status.Conditions, v1alpha2.Ready) !=
+			apimeta.IsStatusConditionTrue(newServer.Status.Conditions, v1alpha2.Ready)
+	},
 	DeleteFunc: func(e event.DeleteEvent) bool {
 		return true
 	},
@@ -893,6 +893,29 @@ func TestGenerateConfigHash(t *testing.T) {
 			t.Error("Expected different hash for different input")
 		}
 	})
+
+	t.Run("surrounding whitespace is ignored", func(t *testing.T) {
+		// The hashes are annotated on the workloads, a changed hash would roll out the operands
+		expected := "ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb"
+		if hash := GenerateConfigHash([]byte(" a\n")); hash != expected {
+			t.Errorf("Expected %s, got %s", expected, hash)
+		}
+		if hash := GenerateConfigHashFromString("\ta "); hash != expected {
+			t.Errorf("Expected %s, got %s", expected, hash)
+		}
+	})
+}
+
+func TestDecodeAssetReturnsCopies(t *testing.T) {
+	asset := []byte("apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: spire-agent\n")
+	first := DecodeServiceAccountObjBytes(asset)
+	first.Name = "modified"
+	first.Labels = map[string]string{"modified": "true"}
+
+	second := DecodeServiceAccountObjBytes(asset)
+	if second.Name != "spire-agent" || second.Labels != nil {
+		t.Errorf("Expected an unmodified copy of the decoded asset, got %s %v", second.Name, second.Labels)
+	}
 }
 
 // TestGenerateMapHash tests the GenerateMapHash function
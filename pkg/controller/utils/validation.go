@@ -212,6 +212,26 @@ func ValidateCommonConfigLabels(labels map[string]string) error {
 	return nil
 }
 
+// ValidateCommonConfigDNS validates the DNS policy and DNS configuration of the operand pods using
+// Kubernetes validation functions.
+func ValidateCommonConfigDNS(dnsPolicy corev1.DNSPolicy, dnsConfig *corev1.PodDNSConfig) error {
+	if dnsPolicy == "" && dnsConfig == nil {
+		return nil
+	}
+
+	internalPolicy := core.DNSPolicy(dnsPolicy)
+	internalConfig := (*core.PodDNSConfig)(unsafe.Pointer(dnsConfig))
+
+	fldPath := field.NewPath("dnsConfig")
+	errs := ValidatePodDNSConfig(internalConfig, &internalPolicy, fldPath)
+
+	if len(errs) > 0 {
+		return fieldErrorListToError(errs)
+	}
+
+	return nil
+}
+
 // ValidateCommonConfig validates all common configuration fields
 func ValidateCommonConfig(affinity *corev1.Affinity, tolerations []*corev1.Toleration, nodeSelector map[string]string, resources *corev1.ResourceRequirements, labels map[string]string) error {
 	// Validate affinity
@@ -17,6 +17,9 @@ limitations under the License.
 package utils
 
 import (
+	"fmt"
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -212,3 +215,48 @@ func validateLabelKeys(fldPath *field.Path, labelKeys []string, labelSelector *m
 
 	return allErrs
 }
+
+// ValidatePodDNSConfig checks the DNS configuration is valid for the DNS policy, with the strict
+// validation of the search paths which every supported API server applies
+func ValidatePodDNSConfig(dnsConfig *core.PodDNSConfig, dnsPolicy *core.DNSPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// Validate DNSNone case. Must provide at least one DNS name server.
+	if dnsPolicy != nil && *dnsPolicy == core.DNSNone {
+		if dnsConfig == nil {
+			return append(allErrs, field.Required(fldPath, fmt.Sprintf("must provide `dnsConfig` when `dnsPolicy` is %s", core.DNSNone)))
+		}
+		if len(dnsConfig.Nameservers) == 0 {
+			return append(allErrs, field.Required(fldPath.Child("nameservers"), fmt.Sprintf("must provide at least one DNS nameserver when `dnsPolicy` is %s", core.DNSNone)))
+		}
+	}
+
+	if dnsConfig != nil {
+		// Validate nameservers.
+		if len(dnsConfig.Nameservers) > corevalidation.MaxDNSNameservers {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("nameservers"), dnsConfig.Nameservers, fmt.Sprintf("must not have more than %v nameservers", corevalidation.MaxDNSNameservers)))
+		}
+		for i, ns := range dnsConfig.Nameservers {
+			allErrs = append(allErrs, corevalidation.IsValidIPForLegacyField(fldPath.Child("nameservers").Index(i), ns, nil)...)
+		}
+		// Validate searches.
+		if len(dnsConfig.Searches) > corevalidation.MaxDNSSearchPaths {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("searches"), dnsConfig.Searches, fmt.Sprintf("must not have more than %v search paths", corevalidation.MaxDNSSearchPaths)))
+		}
+		// Include the space between search paths.
+		if len(strings.Join(dnsConfig.Searches, " ")) > corevalidation.MaxDNSSearchListChars {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("searches"), dnsConfig.Searches, fmt.Sprintf("must not have more than %v characters (including spaces) in the search list", corevalidation.MaxDNSSearchListChars)))
+		}
+		for i, search := range dnsConfig.Searches {
+			search = strings.TrimSuffix(search, ".")
+			allErrs = append(allErrs, corevalidation.ValidateDNS1123Subdomain(search, fldPath.Child("searches").Index(i))...)
+		}
+		// Validate options.
+		for i, option := range dnsConfig.Options {
+			if len(option.Name) == 0 {
+				allErrs = append(allErrs, field.Required(fldPath.Child("options").Index(i), "must not be empty"))
+			}
+		}
+	}
+	return allErrs
+}
@@ -7,6 +7,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2/textlogger"
+	"k8s.io/utils/ptr"
 )
 
 func TestValidateCommonConfigAffinity(t *testing.T) {
@@ -371,6 +372,65 @@ func TestValidateCommonConfigNodeSelector(t *testing.T) {
 	}
 }
 
+func TestValidateCommonConfigDNS(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    corev1.DNSPolicy
+		dnsConfig *corev1.PodDNSConfig
+		wantError bool
+	}{
+		{
+			name:      "unset DNS configuration is valid",
+			wantError: false,
+		},
+		{
+			name:   "nameservers and searches added to ClusterFirst",
+			policy: corev1.DNSClusterFirst,
+			dnsConfig: &corev1.PodDNSConfig{
+				Nameservers: []string{"10.0.0.53"},
+				Searches:    []string{"corp.example.com."},
+			},
+			wantError: false,
+		},
+		{
+			name:      "None without dnsConfig",
+			policy:    corev1.DNSNone,
+			wantError: true,
+		},
+		{
+			name:      "None without nameservers",
+			policy:    corev1.DNSNone,
+			dnsConfig: &corev1.PodDNSConfig{Searches: []string{"corp.example.com"}},
+			wantError: true,
+		},
+		{
+			name:      "invalid nameserver",
+			dnsConfig: &corev1.PodDNSConfig{Nameservers: []string{"dns.corp.example.com"}},
+			wantError: true,
+		},
+		{
+			name:      "too many nameservers",
+			policy:    corev1.DNSNone,
+			dnsConfig: &corev1.PodDNSConfig{Nameservers: []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4"}},
+			wantError: true,
+		},
+		{
+			name:      "empty option name",
+			dnsConfig: &corev1.PodDNSConfig{Options: []corev1.PodDNSConfigOption{{Value: ptr.To("2")}}},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCommonConfigDNS(tt.policy, tt.dnsConfig)
+			if (err != nil) != tt.wantError {
+				t.Errorf("ValidateCommonConfigDNS() error = %v, wantError %v", err, tt.wantError)
+			}
+		})
+	}
+}
+
 func TestValidateCommonConfigResources(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -0,0 +1,203 @@
+package zero_trust_workload_identity_manager
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/bundle/spiffebundle"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// Condition type and reasons for the additional formats of the trust bundle
+const (
+	BundleFormatsPublished              = "BundleFormatsPublished"
+	BundleFormatsReasonPublished        = "BundleFormatsPublished"
+	BundleFormatsReasonNotConfigured    = "BundleFormatsNotConfigured"
+	BundleFormatsReasonBundleNotReady   = "TrustBundleNotPublished"
+	BundleFormatsReasonFailed           = "BundleFormatsFailed"
+	BundleFormatsReasonInvalidPEMBundle = "InvalidPEMBundle"
+
+	// bundleFormatKeysAnnotation lists the keys of the bundle ConfigMap derived from the PEM
+	// bundle, so they are removed once they are no longer configured
+	bundleFormatKeysAnnotation = "ztwim.openshift.io/bundle-format-keys"
+
+	// bundleFormatsRetryInterval is how soon a failed publication is retried
+	bundleFormatsRetryInterval = 30 * time.Second
+)
+
+// reconcileBundleFormats publishes the trust bundle under the additional keys of
+// spec.bundleFormats of the bundle ConfigMap. The keys are derived from the PEM bundle written by
+// the k8sbundle notifier of the server, and updated whenever it changes. It returns true when the
+// publication failed and should be retried.
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) reconcileBundleFormats(ctx context.Context, config *v1alpha2.ZeroTrustWorkloadIdentityManager, statusMgr *status.Manager) bool {
+	formats := config.Spec.BundleFormats
+	configured := formats != nil && (len(formats.PEMKeys) > 0 || formats.SPIFFEKey != "")
+
+	var bundle corev1.ConfigMap
+	err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: config.Spec.BundleConfigMap, Namespace: utils.GetOperatorNamespace()}, &bundle)
+	if err != nil && !apierror.IsNotFound(err) {
+		r.log.Error(err, "failed to get trust bundle ConfigMap", "name", config.Spec.BundleConfigMap)
+		statusMgr.AddCondition(BundleFormatsPublished, BundleFormatsReasonFailed,
+			fmt.Sprintf("Failed to get trust bundle ConfigMap %s: %v", config.Spec.BundleConfigMap, err),
+			metav1.ConditionFalse)
+		return true
+	}
+	pemBundle := bundle.Data[spireBundleDataKey]
+	if configured && pemBundle == "" {
+		// The bundle ConfigMap is watched, so the publication resumes once it is published
+		statusMgr.AddCondition(BundleFormatsPublished, BundleFormatsReasonBundleNotReady,
+			fmt.Sprintf("Waiting for the server to publish the trust bundle to ConfigMap %s", config.Spec.BundleConfigMap),
+			metav1.ConditionFalse)
+		return false
+	}
+
+	var desired map[string]string
+	if configured {
+		desired, err = generateBundleFormats(formats, utils.TrustDomain(config), pemBundle)
+		if err != nil {
+			r.log.Error(err, "failed to convert the trust bundle", "name", config.Spec.BundleConfigMap)
+			statusMgr.AddCondition(BundleFormatsPublished, BundleFormatsReasonInvalidPEMBundle,
+				fmt.Sprintf("Failed to convert the trust bundle of ConfigMap %s: %v", config.Spec.BundleConfigMap, err),
+				metav1.ConditionFalse)
+			return false
+		}
+	}
+
+	if bundle.Name != "" {
+		updated, err := r.applyBundleFormats(ctx, &bundle, desired)
+		if err != nil {
+			r.log.Error(err, "failed to publish the trust bundle formats", "name", bundle.Name)
+			statusMgr.AddCondition(BundleFormatsPublished, BundleFormatsReasonFailed,
+				fmt.Sprintf("Failed to publish the trust bundle formats to ConfigMap %s: %v", bundle.Name, err),
+				metav1.ConditionFalse)
+			return true
+		}
+		if updated {
+			r.log.Info("Published trust bundle formats", "name", bundle.Name, "keys", sortedKeys(desired))
+		}
+	}
+
+	if !configured {
+		// Only report if bundle formats were previously configured
+		if apimeta.FindStatusCondition(config.Status.Conditions, BundleFormatsPublished) != nil {
+			statusMgr.AddCondition(BundleFormatsPublished, BundleFormatsReasonNotConfigured,
+				fmt.Sprintf("The trust bundle is only published under %s", spireBundleDataKey),
+				metav1.ConditionTrue)
+		}
+		return false
+	}
+	statusMgr.AddCondition(BundleFormatsPublished, BundleFormatsReasonPublished,
+		fmt.Sprintf("Trust bundle published to ConfigMap %s under: %s", bundle.Name, strings.Join(sortedKeys(desired), ", ")),
+		metav1.ConditionTrue)
+	return false
+}
+
+// applyBundleFormats patches the derived keys of the bundle ConfigMap to the desired ones,
+// removing the keys previously derived and no longer desired. It returns whether the ConfigMap
+// was patched.
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) applyBundleFormats(ctx context.Context, bundle *corev1.ConfigMap, desired map[string]string) (bool, error) {
+	original := bundle.DeepCopy()
+	for _, key := range strings.Split(bundle.Annotations[bundleFormatKeysAnnotation], ",") {
+		if _, ok := desired[key]; !ok && key != spireBundleDataKey {
+			delete(bundle.Data, key)
+		}
+	}
+	for key, value := range desired {
+		if bundle.Data == nil {
+			bundle.Data = map[string]string{}
+		}
+		bundle.Data[key] = value
+	}
+	if len(desired) > 0 {
+		if bundle.Annotations == nil {
+			bundle.Annotations = map[string]string{}
+		}
+		bundle.Annotations[bundleFormatKeysAnnotation] = strings.Join(sortedKeys(desired), ",")
+	} else {
+		delete(bundle.Annotations, bundleFormatKeysAnnotation)
+	}
+	if equality.Semantic.DeepEqual(original.Data, bundle.Data) && equality.Semantic.DeepEqual(original.Annotations, bundle.Annotations) {
+		return false, nil
+	}
+	// Fail on concurrent changes, so the keys are not derived from a replaced PEM bundle
+	if err := r.ctrlClient.Patch(ctx, bundle, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// generateBundleFormats returns the additional keys of the bundle ConfigMap holding the PEM
+// bundle in the formats configured
+func generateBundleFormats(formats *v1alpha2.TrustBundleFormats, trustDomain, pemBundle string) (map[string]string, error) {
+	data := map[string]string{}
+	for _, key := range formats.PEMKeys {
+		data[key] = pemBundle
+	}
+	if formats.SPIFFEKey != "" {
+		spiffeBundle, err := pemToSPIFFEBundle(trustDomain, pemBundle)
+		if err != nil {
+			return nil, err
+		}
+		data[formats.SPIFFEKey] = spiffeBundle
+	}
+	return data, nil
+}
+
+// pemToSPIFFEBundle converts the PEM bundle into a SPIFFE bundle holding its certificates as the
+// X.509 authorities of the trust domain
+func pemToSPIFFEBundle(trustDomain, pemBundle string) (string, error) {
+	td, err := spiffeid.TrustDomainFromString(trustDomain)
+	if err != nil {
+		return "", fmt.Errorf("invalid trust domain %q: %w", trustDomain, err)
+	}
+	spiffeBundle := spiffebundle.New(td)
+	rest := []byte(pemBundle)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		spiffeBundle.AddX509Authority(cert)
+	}
+	if len(spiffeBundle.X509Authorities()) == 0 {
+		return "", fmt.Errorf("no certificate found in %s", spireBundleDataKey)
+	}
+	out, err := spiffeBundle.Marshal()
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal SPIFFE bundle: %w", err)
+	}
+	return string(out), nil
+}
+
+func sortedKeys(data map[string]string) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,176 @@
+package zero_trust_workload_identity_manager
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+)
+
+// testCACertificate returns a self-signed CA certificate in PEM
+func testCACertificate(t *testing.T) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestGenerateBundleFormats(t *testing.T) {
+	caCert := testCACertificate(t)
+	data, err := generateBundleFormats(&v1alpha2.TrustBundleFormats{PEMKeys: []string{"ca.crt"}, SPIFFEKey: "bundle.spiffe"}, "example.org", caCert)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if data["ca.crt"] != caCert {
+		t.Errorf("Expected the PEM bundle under ca.crt, got %q", data["ca.crt"])
+	}
+	var jwks struct {
+		Keys []struct {
+			Use string   `json:"use"`
+			X5c []string `json:"x5c"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal([]byte(data["bundle.spiffe"]), &jwks); err != nil {
+		t.Fatalf("Expected a JWK set under bundle.spiffe, got %v", err)
+	}
+	if len(jwks.Keys) != 1 || jwks.Keys[0].Use != "x509-svid" || len(jwks.Keys[0].X5c) != 1 {
+		t.Errorf("Expected the CA as X.509 authority, got %+v", jwks.Keys)
+	}
+
+	if _, err := generateBundleFormats(&v1alpha2.TrustBundleFormats{SPIFFEKey: "bundle.spiffe"}, "example.org", testTrustBundle); err == nil {
+		t.Error("Expected an error for an unparsable certificate")
+	}
+}
+
+func TestReconcileBundleFormats(t *testing.T) {
+	caCert := testCACertificate(t)
+	tests := []struct {
+		name         string
+		formats      *v1alpha2.TrustBundleFormats
+		bundle       *corev1.ConfigMap
+		expectReason string
+		expectData   map[string]string
+		expectPatch  bool
+	}{
+		{
+			name:   "not configured",
+			bundle: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "spire-bundle"}, Data: map[string]string{spireBundleDataKey: caCert}},
+		},
+		{
+			name:         "bundle not published",
+			formats:      &v1alpha2.TrustBundleFormats{PEMKeys: []string{"ca.crt"}},
+			bundle:       &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "spire-bundle"}},
+			expectReason: BundleFormatsReasonBundleNotReady,
+		},
+		{
+			name:         "publishes the PEM bundle under the additional keys",
+			formats:      &v1alpha2.TrustBundleFormats{PEMKeys: []string{"ca.crt"}},
+			bundle:       &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "spire-bundle"}, Data: map[string]string{spireBundleDataKey: caCert}},
+			expectReason: BundleFormatsReasonPublished,
+			expectData:   map[string]string{spireBundleDataKey: caCert, "ca.crt": caCert},
+			expectPatch:  true,
+		},
+		{
+			name:    "up to date",
+			formats: &v1alpha2.TrustBundleFormats{PEMKeys: []string{"ca.crt"}},
+			bundle: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "spire-bundle", Annotations: map[string]string{bundleFormatKeysAnnotation: "ca.crt"}},
+				Data:       map[string]string{spireBundleDataKey: caCert, "ca.crt": caCert},
+			},
+			expectReason: BundleFormatsReasonPublished,
+		},
+		{
+			name:    "removes the keys no longer configured",
+			formats: &v1alpha2.TrustBundleFormats{PEMKeys: []string{"tls-ca.crt"}},
+			bundle: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "spire-bundle", Annotations: map[string]string{bundleFormatKeysAnnotation: "ca.crt"}},
+				Data:       map[string]string{spireBundleDataKey: caCert, "ca.crt": caCert, "other": "kept"},
+			},
+			expectReason: BundleFormatsReasonPublished,
+			expectData:   map[string]string{spireBundleDataKey: caCert, "tls-ca.crt": caCert, "other": "kept"},
+			expectPatch:  true,
+		},
+		{
+			name: "removes the keys once unset",
+			bundle: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "spire-bundle", Annotations: map[string]string{bundleFormatKeysAnnotation: "ca.crt"}},
+				Data:       map[string]string{spireBundleDataKey: caCert, "ca.crt": caCert},
+			},
+			expectData:  map[string]string{spireBundleDataKey: caCert},
+			expectPatch: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &v1alpha2.ZeroTrustWorkloadIdentityManager{Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
+				TrustDomain:     "example.org",
+				BundleConfigMap: "spire-bundle",
+				BundleFormats:   tt.formats,
+			}}
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.GetStub = func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				tt.bundle.DeepCopyInto(obj.(*corev1.ConfigMap))
+				return nil
+			}
+			statusMgr := status.NewManager(fakeClient)
+
+			if retry := newTestReconciler(fakeClient).reconcileBundleFormats(context.Background(), config, statusMgr); retry {
+				t.Error("Expected no retry")
+			}
+			cond, ok := statusMgr.GetCondition(BundleFormatsPublished)
+			if tt.expectReason == "" {
+				if ok {
+					t.Errorf("Expected no condition, got %v", cond)
+				}
+			} else if cond.Reason != tt.expectReason {
+				t.Errorf("Expected reason %s, got %s: %s", tt.expectReason, cond.Reason, cond.Message)
+			}
+			if (fakeClient.PatchCallCount() == 1) != tt.expectPatch {
+				t.Fatalf("Expected patch %v, got %d patches", tt.expectPatch, fakeClient.PatchCallCount())
+			}
+			if tt.expectPatch {
+				_, obj, _, _ := fakeClient.PatchArgsForCall(0)
+				patched := obj.(*corev1.ConfigMap)
+				if len(patched.Data) != len(tt.expectData) {
+					t.Errorf("Expected data keys %v, got %v", tt.expectData, patched.Data)
+				}
+				for key, value := range tt.expectData {
+					if patched.Data[key] != value {
+						t.Errorf("Expected %s to be %q, got %q", key, value, patched.Data[key])
+					}
+				}
+			}
+		})
+	}
+}
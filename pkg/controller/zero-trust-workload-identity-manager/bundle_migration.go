@@ -0,0 +1,165 @@
+package zero_trust_workload_identity_manager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// Reasons for the BundleConfigMapMigration condition
+const (
+	BundleConfigMapMigrationReasonProgressing = "BundleConfigMapMigrationProgressing"
+	BundleConfigMapMigrationReasonComplete    = "BundleConfigMapMigrationComplete"
+	BundleConfigMapMigrationReasonFailed      = "BundleConfigMapMigrationFailed"
+)
+
+const (
+	spireServerResourceName = "spire-server"
+	spireAgentResourceName  = "spire-agent"
+	spireBundleVolumeName   = "spire-bundle"
+	spireBundleDataKey      = "bundle.crt"
+)
+
+// reconcileBundleConfigMapMigration completes a change of spec.bundleConfigMap. The previous bundle
+// ConfigMaps are kept until the server publishes the bundle to the new ConfigMap and the server and
+// agents have rolled out with it, and are deleted afterwards. Progress is reported through the
+// BundleConfigMapMigration condition. It returns true while the migration is still in progress.
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) reconcileBundleConfigMapMigration(ctx context.Context, config *v1alpha2.ZeroTrustWorkloadIdentityManager, statusMgr *status.Manager) bool {
+	var bundles corev1.ConfigMapList
+	if err := r.ctrlClient.List(ctx, &bundles, client.InNamespace(utils.GetOperatorNamespace()), client.MatchingLabels{utils.TrustBundleLabel: "true"}); err != nil {
+		r.log.Error(err, "failed to list trust bundle ConfigMaps")
+		statusMgr.AddCondition(BundleConfigMapMigration, BundleConfigMapMigrationReasonFailed,
+			fmt.Sprintf("Failed to list trust bundle ConfigMaps: %v", err),
+			metav1.ConditionFalse)
+		return false
+	}
+
+	var stale []corev1.ConfigMap
+	for _, bundle := range bundles.Items {
+		if bundle.Name != config.Spec.BundleConfigMap {
+			stale = append(stale, bundle)
+		}
+	}
+	if len(stale) == 0 {
+		return false
+	}
+
+	pending, err := r.bundleConfigMapMigrationPending(ctx, config.Spec.BundleConfigMap)
+	if err != nil {
+		r.log.Error(err, "failed to check trust bundle ConfigMap migration")
+		statusMgr.AddCondition(BundleConfigMapMigration, BundleConfigMapMigrationReasonFailed,
+			fmt.Sprintf("Failed to check trust bundle migration: %v", err),
+			metav1.ConditionFalse)
+		return false
+	}
+	if pending != "" {
+		statusMgr.AddCondition(BundleConfigMapMigration, BundleConfigMapMigrationReasonProgressing,
+			fmt.Sprintf("Migrating trust bundle to ConfigMap %s: %s", config.Spec.BundleConfigMap, pending),
+			metav1.ConditionFalse)
+		return true
+	}
+
+	var deleted []string
+	for i := range stale {
+		if err := r.ctrlClient.Delete(ctx, &stale[i]); err != nil && !apierror.IsNotFound(err) {
+			r.log.Error(err, "failed to delete previous trust bundle ConfigMap", "name", stale[i].Name)
+			statusMgr.AddCondition(BundleConfigMapMigration, BundleConfigMapMigrationReasonFailed,
+				fmt.Sprintf("Failed to delete previous trust bundle ConfigMap %s: %v", stale[i].Name, err),
+				metav1.ConditionFalse)
+			return false
+		}
+		deleted = append(deleted, stale[i].Name)
+	}
+
+	r.log.Info("trust bundle ConfigMap migration complete", "bundleConfigMap", config.Spec.BundleConfigMap, "deleted", deleted)
+	statusMgr.AddCondition(BundleConfigMapMigration, BundleConfigMapMigrationReasonComplete,
+		fmt.Sprintf("Trust bundle migrated to ConfigMap %s, removed %s", config.Spec.BundleConfigMap, strings.Join(deleted, ", ")),
+		metav1.ConditionTrue)
+	return false
+}
+
+// bundleConfigMapMigrationPending returns what the migration to the given bundle ConfigMap is still
+// waiting for, or an empty string once the bundle is published there and consumed by the operands
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) bundleConfigMapMigrationPending(ctx context.Context, bundleConfigMap string) (string, error) {
+	namespace := utils.GetOperatorNamespace()
+
+	bundle := &corev1.ConfigMap{}
+	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: bundleConfigMap, Namespace: namespace}, bundle); err != nil {
+		if apierror.IsNotFound(err) {
+			return fmt.Sprintf("waiting for ConfigMap %s to be created", bundleConfigMap), nil
+		}
+		return "", err
+	}
+	if bundle.Data[spireBundleDataKey] == "" {
+		return fmt.Sprintf("waiting for the server to publish the bundle to ConfigMap %s", bundleConfigMap), nil
+	}
+
+	serverConfig := &corev1.ConfigMap{}
+	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: spireServerResourceName, Namespace: namespace}, serverConfig); err != nil && !apierror.IsNotFound(err) {
+		return "", err
+	}
+	if serverConfig.Name != "" && !strings.Contains(serverConfig.Data["server.conf"], fmt.Sprintf("%q: %q", "config_map", bundleConfigMap)) {
+		return "waiting for the server configuration to be updated", nil
+	}
+
+	statefulSet := &appsv1.StatefulSet{}
+	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: spireServerResourceName, Namespace: namespace}, statefulSet); err != nil && !apierror.IsNotFound(err) {
+		return "", err
+	}
+	if statefulSet.Name != "" && !statefulSetRolledOut(statefulSet) {
+		return "waiting for the server to roll out", nil
+	}
+
+	daemonSet := &appsv1.DaemonSet{}
+	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: spireAgentResourceName, Namespace: namespace}, daemonSet); err != nil && !apierror.IsNotFound(err) {
+		return "", err
+	}
+	if daemonSet.Name != "" {
+		if !daemonSetMountsBundle(daemonSet, bundleConfigMap) {
+			return "waiting for the agent DaemonSet to be updated", nil
+		}
+		if !daemonSetRolledOut(daemonSet) {
+			return "waiting for the agents to roll out", nil
+		}
+	}
+
+	return "", nil
+}
+
+func statefulSetRolledOut(sts *appsv1.StatefulSet) bool {
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	return sts.Status.ObservedGeneration >= sts.Generation &&
+		sts.Status.UpdatedReplicas == replicas &&
+		sts.Status.ReadyReplicas == replicas &&
+		sts.Status.CurrentRevision == sts.Status.UpdateRevision
+}
+
+func daemonSetRolledOut(ds *appsv1.DaemonSet) bool {
+	return ds.Status.ObservedGeneration >= ds.Generation &&
+		ds.Status.UpdatedNumberScheduled == ds.Status.DesiredNumberScheduled &&
+		ds.Status.NumberAvailable == ds.Status.DesiredNumberScheduled
+}
+
+func daemonSetMountsBundle(ds *appsv1.DaemonSet, bundleConfigMap string) bool {
+	for _, volume := range ds.Spec.Template.Spec.Volumes {
+		if volume.Name == spireBundleVolumeName && volume.ConfigMap != nil {
+			return volume.ConfigMap.Name == bundleConfigMap
+		}
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+package zero_trust_workload_identity_manager
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func trustBundleConfigMap(name, bundle string) corev1.ConfigMap {
+	cm := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: utils.GetOperatorNamespace(),
+			Labels:    map[string]string{utils.TrustBundleLabel: "true"},
+		},
+	}
+	if bundle != "" {
+		cm.Data = map[string]string{spireBundleDataKey: bundle}
+	}
+	return cm
+}
+
+func rolledOutAgentDaemonSet(bundleConfigMap string) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: spireAgentResourceName, Generation: 2},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{{
+						Name: spireBundleVolumeName,
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: bundleConfigMap}},
+						},
+					}},
+				},
+			},
+		},
+		Status: appsv1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 3},
+	}
+}
+
+func rolledOutServerStatefulSet() *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: spireServerResourceName, Generation: 4},
+		Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(1))},
+		Status: appsv1.StatefulSetStatus{
+			ObservedGeneration: 4,
+			UpdatedReplicas:    1,
+			ReadyReplicas:      1,
+			CurrentRevision:    "spire-server-2",
+			UpdateRevision:     "spire-server-2",
+		},
+	}
+}
+
+// bundleMigrationClient lists the given bundle ConfigMaps and serves the given objects by name and kind
+func bundleMigrationClient(bundles []corev1.ConfigMap, objects ...client.Object) *fakes.FakeCustomCtrlClient {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+		list.(*corev1.ConfigMapList).Items = bundles
+		return nil
+	}
+	fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		for _, o := range objects {
+			if o.GetName() != key.Name {
+				continue
+			}
+			switch src := o.(type) {
+			case *corev1.ConfigMap:
+				if dst, ok := obj.(*corev1.ConfigMap); ok {
+					*dst = *src.DeepCopy()
+					return nil
+				}
+			case *appsv1.StatefulSet:
+				if dst, ok := obj.(*appsv1.StatefulSet); ok {
+					*dst = *src.DeepCopy()
+					return nil
+				}
+			case *appsv1.DaemonSet:
+				if dst, ok := obj.(*appsv1.DaemonSet); ok {
+					*dst = *src.DeepCopy()
+					return nil
+				}
+			}
+		}
+		return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+	}
+	return fakeClient
+}
+
+func TestReconcileBundleConfigMapMigration(t *testing.T) {
+	config := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+		Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{BundleConfigMap: "trust-bundle"},
+	}
+	newBundle := trustBundleConfigMap("trust-bundle", "new-bundle")
+	oldBundle := trustBundleConfigMap("spire-bundle", "old-bundle")
+	serverConfig := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: spireServerResourceName},
+		Data:       map[string]string{"server.conf": "{\n  \"config_map\": \"trust-bundle\"\n}"},
+	}
+	staleServerConfig := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: spireServerResourceName},
+		Data:       map[string]string{"server.conf": "{\n  \"config_map\": \"spire-bundle\"\n}"},
+	}
+	rollingAgents := rolledOutAgentDaemonSet("trust-bundle")
+	rollingAgents.Status.UpdatedNumberScheduled = 1
+
+	tests := []struct {
+		name             string
+		bundles          []corev1.ConfigMap
+		objects          []client.Object
+		expectInProgress bool
+		expectReason     string
+		expectDeletes    int
+	}{
+		{
+			name:    "no previous bundle ConfigMap",
+			bundles: []corev1.ConfigMap{newBundle},
+		},
+		{
+			name:             "server has not published the bundle yet",
+			bundles:          []corev1.ConfigMap{oldBundle, trustBundleConfigMap("trust-bundle", "")},
+			objects:          []client.Object{ptr.To(trustBundleConfigMap("trust-bundle", ""))},
+			expectInProgress: true,
+			expectReason:     BundleConfigMapMigrationReasonProgressing,
+		},
+		{
+			name:             "server configuration not updated",
+			bundles:          []corev1.ConfigMap{oldBundle, newBundle},
+			objects:          []client.Object{&newBundle, staleServerConfig},
+			expectInProgress: true,
+			expectReason:     BundleConfigMapMigrationReasonProgressing,
+		},
+		{
+			name:             "agents still rolling out",
+			bundles:          []corev1.ConfigMap{oldBundle, newBundle},
+			objects:          []client.Object{&newBundle, serverConfig, rolledOutServerStatefulSet(), rollingAgents},
+			expectInProgress: true,
+			expectReason:     BundleConfigMapMigrationReasonProgressing,
+		},
+		{
+			name:             "agents still mount the previous bundle",
+			bundles:          []corev1.ConfigMap{oldBundle, newBundle},
+			objects:          []client.Object{&newBundle, serverConfig, rolledOutServerStatefulSet(), rolledOutAgentDaemonSet("spire-bundle")},
+			expectInProgress: true,
+			expectReason:     BundleConfigMapMigrationReasonProgressing,
+		},
+		{
+			name:          "propagated bundle removes the previous ConfigMap",
+			bundles:       []corev1.ConfigMap{oldBundle, newBundle},
+			objects:       []client.Object{&newBundle, serverConfig, rolledOutServerStatefulSet(), rolledOutAgentDaemonSet("trust-bundle")},
+			expectReason:  BundleConfigMapMigrationReasonComplete,
+			expectDeletes: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := bundleMigrationClient(tt.bundles, tt.objects...)
+			statusMgr := status.NewManager(fakeClient)
+
+			inProgress := newTestReconciler(fakeClient).reconcileBundleConfigMapMigration(context.Background(), config, statusMgr)
+			if inProgress != tt.expectInProgress {
+				t.Errorf("Expected in progress %v, got %v", tt.expectInProgress, inProgress)
+			}
+			if fakeClient.DeleteCallCount() != tt.expectDeletes {
+				t.Fatalf("Expected %d deletes, got %d", tt.expectDeletes, fakeClient.DeleteCallCount())
+			}
+			if tt.expectDeletes > 0 {
+				if _, obj, _ := fakeClient.DeleteArgsForCall(0); obj.GetName() != "spire-bundle" {
+					t.Errorf("Expected spire-bundle to be deleted, got %s", obj.GetName())
+				}
+			}
+			cond, ok := statusMgr.GetCondition(BundleConfigMapMigration)
+			if tt.expectReason == "" {
+				if ok {
+					t.Errorf("Expected no %s condition, got %v", BundleConfigMapMigration, cond)
+				}
+				return
+			}
+			if !ok || cond.Reason != tt.expectReason {
+				t.Errorf("Expected reason %s, got %v", tt.expectReason, cond)
+			}
+		})
+	}
+}
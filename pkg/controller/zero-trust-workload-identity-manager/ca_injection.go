@@ -0,0 +1,152 @@
+package zero_trust_workload_identity_manager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// Condition type and reasons for the CA bundle injection into labelled webhooks
+const (
+	CABundleInjection                     = "CABundleInjection"
+	CABundleInjectionReasonInjected       = "CABundleInjected"
+	CABundleInjectionReasonNoWebhooks     = "NoWebhooksLabelled"
+	CABundleInjectionReasonBundleNotReady = "TrustBundleNotPublished"
+	CABundleInjectionReasonFailed         = "CABundleInjectionFailed"
+
+	// caInjectionRetryInterval is how soon a failed injection is retried
+	caInjectionRetryInterval = 30 * time.Second
+)
+
+// reconcileCABundleInjection sets the caBundle of every webhook of the ValidatingWebhookConfigurations
+// labelled with ztwim.openshift.io/inject-ca=true to the SPIRE trust bundle, so webhooks serving
+// SPIRE-issued certificates stay trusted across CA rotations. It returns true when the injection
+// failed and should be retried.
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) reconcileCABundleInjection(ctx context.Context, config *v1alpha2.ZeroTrustWorkloadIdentityManager, statusMgr *status.Manager) bool {
+	var webhooks admissionregistrationv1.ValidatingWebhookConfigurationList
+	if err := r.ctrlClient.List(ctx, &webhooks, client.MatchingLabels{utils.InjectCALabel: "true"}); err != nil {
+		r.log.Error(err, "failed to list webhook configurations labelled for CA injection")
+		statusMgr.AddCondition(CABundleInjection, CABundleInjectionReasonFailed,
+			fmt.Sprintf("Failed to list ValidatingWebhookConfigurations labelled %s=true: %v", utils.InjectCALabel, err),
+			metav1.ConditionFalse)
+		return true
+	}
+	if len(webhooks.Items) == 0 {
+		// Only report once webhooks were labelled
+		if apimeta.FindStatusCondition(config.Status.Conditions, CABundleInjection) != nil {
+			statusMgr.AddCondition(CABundleInjection, CABundleInjectionReasonNoWebhooks,
+				fmt.Sprintf("No ValidatingWebhookConfiguration is labelled %s=true", utils.InjectCALabel),
+				metav1.ConditionFalse)
+		}
+		return false
+	}
+
+	var bundle corev1.ConfigMap
+	err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: config.Spec.BundleConfigMap, Namespace: utils.GetOperatorNamespace()}, &bundle)
+	if err != nil && !apierror.IsNotFound(err) {
+		r.log.Error(err, "failed to get trust bundle ConfigMap", "name", config.Spec.BundleConfigMap)
+		statusMgr.AddCondition(CABundleInjection, CABundleInjectionReasonFailed,
+			fmt.Sprintf("Failed to get trust bundle ConfigMap %s: %v", config.Spec.BundleConfigMap, err),
+			metav1.ConditionFalse)
+		return true
+	}
+	caBundle := []byte(bundle.Data[spireBundleDataKey])
+	if len(caBundle) == 0 {
+		// The bundle ConfigMap is watched, so the injection resumes once it is published
+		statusMgr.AddCondition(CABundleInjection, CABundleInjectionReasonBundleNotReady,
+			fmt.Sprintf("Waiting for the server to publish the trust bundle to ConfigMap %s", config.Spec.BundleConfigMap),
+			metav1.ConditionFalse)
+		return false
+	}
+
+	names := make([]string, 0, len(webhooks.Items))
+	for i := range webhooks.Items {
+		webhook := &webhooks.Items[i]
+		names = append(names, webhook.Name)
+		updated, err := r.injectCABundle(ctx, webhook, caBundle)
+		if err != nil {
+			r.log.Error(err, "failed to inject CA bundle", "webhook", webhook.Name)
+			statusMgr.AddCondition(CABundleInjection, CABundleInjectionReasonFailed,
+				fmt.Sprintf("Failed to inject the trust bundle into ValidatingWebhookConfiguration %s: %v", webhook.Name, err),
+				metav1.ConditionFalse)
+			return true
+		}
+		if updated {
+			r.log.Info("Injected trust bundle into ValidatingWebhookConfiguration", "name", webhook.Name)
+			r.eventRecorder.Eventf(config, corev1.EventTypeNormal, CABundleInjectionReasonInjected,
+				"Injected the trust bundle into ValidatingWebhookConfiguration %s", webhook.Name)
+		}
+	}
+
+	statusMgr.AddCondition(CABundleInjection, CABundleInjectionReasonInjected,
+		fmt.Sprintf("Trust bundle injected into ValidatingWebhookConfiguration(s): %s", strings.Join(names, ", ")),
+		metav1.ConditionTrue)
+	return false
+}
+
+// injectCABundle patches the caBundle of the webhooks of the configuration which differ from the
+// trust bundle. It returns whether the configuration was patched.
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) injectCABundle(ctx context.Context, webhook *admissionregistrationv1.ValidatingWebhookConfiguration, caBundle []byte) (bool, error) {
+	original := webhook.DeepCopy()
+	updated := false
+	for i := range webhook.Webhooks {
+		if !bytes.Equal(webhook.Webhooks[i].ClientConfig.CABundle, caBundle) {
+			webhook.Webhooks[i].ClientConfig.CABundle = caBundle
+			updated = true
+		}
+	}
+	if !updated {
+		return false, nil
+	}
+	// The webhooks are replaced as a whole by the merge patch, so fail on concurrent changes
+	if err := r.ctrlClient.Patch(ctx, webhook, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// caInjectionWebhookPredicate passes the events of the ValidatingWebhookConfigurations labelled
+// for CA injection, including when the label is added
+var caInjectionWebhookPredicate = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	return obj.GetLabels()[utils.InjectCALabel] == "true"
+})
+
+// trustBundleChangedPredicate passes the changes of the trust bundle, e.g. on CA rotation
+var trustBundleChangedPredicate = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		return e.Object.GetLabels()[utils.TrustBundleLabel] == "true"
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldBundle, ok := e.ObjectOld.(*corev1.ConfigMap)
+		if !ok {
+			return false
+		}
+		newBundle, ok := e.ObjectNew.(*corev1.ConfigMap)
+		if !ok {
+			return false
+		}
+		return newBundle.Labels[utils.TrustBundleLabel] == "true" && oldBundle.Data[spireBundleDataKey] != newBundle.Data[spireBundleDataKey]
+	},
+	DeleteFunc: func(event.DeleteEvent) bool {
+		return false
+	},
+	GenericFunc: func(event.GenericEvent) bool {
+		return false
+	},
+}
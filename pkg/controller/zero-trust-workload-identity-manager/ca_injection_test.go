@@ -0,0 +1,130 @@
+package zero_trust_workload_identity_manager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const testTrustBundle = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+
+func labelledWebhook(name string, caBundles ...string) admissionregistrationv1.ValidatingWebhookConfiguration {
+	webhook := admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{utils.InjectCALabel: "true"}},
+	}
+	for _, caBundle := range caBundles {
+		webhook.Webhooks = append(webhook.Webhooks, admissionregistrationv1.ValidatingWebhook{
+			ClientConfig: admissionregistrationv1.WebhookClientConfig{CABundle: []byte(caBundle)},
+		})
+	}
+	return webhook
+}
+
+func TestReconcileCABundleInjection(t *testing.T) {
+	config := &v1alpha2.ZeroTrustWorkloadIdentityManager{Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{BundleConfigMap: "spire-bundle"}}
+	tests := []struct {
+		name         string
+		webhooks     []admissionregistrationv1.ValidatingWebhookConfiguration
+		bundle       string
+		patchErr     error
+		expectReason string
+		expectPatch  int
+		expectRetry  bool
+	}{
+		{
+			name: "no labelled webhooks",
+		},
+		{
+			name:         "bundle not published",
+			webhooks:     []admissionregistrationv1.ValidatingWebhookConfiguration{labelledWebhook("team-a", "")},
+			expectReason: CABundleInjectionReasonBundleNotReady,
+		},
+		{
+			name:         "injects into the stale webhooks only",
+			webhooks:     []admissionregistrationv1.ValidatingWebhookConfiguration{labelledWebhook("team-a", "", testTrustBundle), labelledWebhook("team-b", testTrustBundle)},
+			bundle:       testTrustBundle,
+			expectReason: CABundleInjectionReasonInjected,
+			expectPatch:  1,
+		},
+		{
+			name:         "patch fails",
+			webhooks:     []admissionregistrationv1.ValidatingWebhookConfiguration{labelledWebhook("team-a", "old")},
+			bundle:       testTrustBundle,
+			patchErr:     errors.New("conflict"),
+			expectReason: CABundleInjectionReasonFailed,
+			expectPatch:  1,
+			expectRetry:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				list.(*admissionregistrationv1.ValidatingWebhookConfigurationList).Items = tt.webhooks
+				return nil
+			}
+			fakeClient.GetStub = func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				obj.(*corev1.ConfigMap).Data = map[string]string{spireBundleDataKey: tt.bundle}
+				return nil
+			}
+			fakeClient.PatchReturns(tt.patchErr)
+			statusMgr := status.NewManager(fakeClient)
+
+			retry := newTestReconciler(fakeClient).reconcileCABundleInjection(context.Background(), config, statusMgr)
+			if retry != tt.expectRetry {
+				t.Errorf("Expected retry %v, got %v", tt.expectRetry, retry)
+			}
+			cond, ok := statusMgr.GetCondition(CABundleInjection)
+			if tt.expectReason == "" {
+				if ok {
+					t.Errorf("Expected no condition, got %v", cond)
+				}
+			} else if cond.Reason != tt.expectReason {
+				t.Errorf("Expected reason %s, got %s: %s", tt.expectReason, cond.Reason, cond.Message)
+			}
+			if fakeClient.PatchCallCount() != tt.expectPatch {
+				t.Fatalf("Expected %d patches, got %d", tt.expectPatch, fakeClient.PatchCallCount())
+			}
+			for i := 0; i < fakeClient.PatchCallCount(); i++ {
+				_, obj, _, _ := fakeClient.PatchArgsForCall(i)
+				for _, webhook := range obj.(*admissionregistrationv1.ValidatingWebhookConfiguration).Webhooks {
+					if string(webhook.ClientConfig.CABundle) != tt.bundle {
+						t.Errorf("Expected the trust bundle to be injected, got %q", webhook.ClientConfig.CABundle)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTrustBundleChangedPredicate(t *testing.T) {
+	bundle := func(data string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "spire-bundle", Labels: map[string]string{utils.TrustBundleLabel: "true"}},
+			Data:       map[string]string{spireBundleDataKey: data},
+		}
+	}
+	if !trustBundleChangedPredicate.Update(event.UpdateEvent{ObjectOld: bundle("a"), ObjectNew: bundle("b")}) {
+		t.Error("Expected a bundle rotation to pass")
+	}
+	if trustBundleChangedPredicate.Update(event.UpdateEvent{ObjectOld: bundle("a"), ObjectNew: bundle("a")}) {
+		t.Error("Expected an unchanged bundle to be filtered")
+	}
+	other := bundle("b")
+	other.Labels = nil
+	if trustBundleChangedPredicate.Update(event.UpdateEvent{ObjectOld: bundle("a"), ObjectNew: other}) {
+		t.Error("Expected ConfigMaps other than the trust bundle to be filtered")
+	}
+}
@@ -0,0 +1,174 @@
+package zero_trust_workload_identity_manager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// MulticlusterExportEnv enables the multicluster addon mode: the identity status of the cluster is
+// published as ClusterClaims, which the ACM klusterlet reports to the hub in the ManagedCluster
+// status and ManagedClusterInfo, for fleet-wide dashboards and hub-driven federation setup
+const MulticlusterExportEnv = "MULTICLUSTER_ADDON_MODE"
+
+// Condition type and reasons for the multicluster export
+const (
+	MulticlusterExport                  = "MulticlusterExport"
+	MulticlusterExportReasonPublished   = "ClusterClaimsPublished"
+	MulticlusterExportReasonUnavailable = "ClusterClaimsUnavailable"
+	MulticlusterExportReasonFailed      = "ClusterClaimsFailed"
+	MulticlusterExportReasonDisabled    = "MulticlusterExportDisabled"
+)
+
+// Names of the published ClusterClaims
+const (
+	clusterClaimTrustDomain           = "trustdomain.ztwim.openshift.io"
+	clusterClaimBundleEndpoint        = "bundleendpoint.ztwim.openshift.io"
+	clusterClaimBundleEndpointProfile = "bundleendpointprofile.ztwim.openshift.io"
+	clusterClaimHealth                = "health.ztwim.openshift.io"
+	clusterClaimMaxValueLength        = 1024
+)
+
+// +kubebuilder:rbac:groups=cluster.open-cluster-management.io,resources=clusterclaims,verbs=create
+// +kubebuilder:rbac:groups=cluster.open-cluster-management.io,resources=clusterclaims,verbs=get;update;delete,resourceNames=trustdomain.ztwim.openshift.io;bundleendpoint.ztwim.openshift.io;bundleendpointprofile.ztwim.openshift.io;health.ztwim.openshift.io
+
+var clusterClaimGVK = schema.GroupVersionKind{Group: "cluster.open-cluster-management.io", Version: "v1alpha1", Kind: "ClusterClaim"}
+
+// isMulticlusterExportEnabled reports whether MULTICLUSTER_ADDON_MODE is set to true
+func isMulticlusterExportEnabled() bool {
+	return strings.EqualFold(strings.TrimSpace(os.Getenv(MulticlusterExportEnv)), "true")
+}
+
+// reconcileClusterClaims publishes the trust domain, the federation bundle endpoint and the health
+// of the cluster as ClusterClaims when the multicluster addon mode is enabled, and removes them
+// once it is disabled. It is best effort: clusters not managed by ACM have no ClusterClaim CRD,
+// which is reported through the MulticlusterExport condition without failing the reconciliation.
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) reconcileClusterClaims(ctx context.Context, config *v1alpha2.ZeroTrustWorkloadIdentityManager, statusMgr *status.Manager) {
+	if !isMulticlusterExportEnabled() {
+		existing := apimeta.FindStatusCondition(config.Status.Conditions, MulticlusterExport)
+		if existing == nil || existing.Status != metav1.ConditionTrue {
+			return
+		}
+		for name := range r.desiredClusterClaims(ctx, config, statusMgr) {
+			if err := r.deleteClusterClaim(ctx, name); err != nil {
+				r.log.Error(err, "failed to delete ClusterClaim", "name", name)
+				statusMgr.AddCondition(MulticlusterExport, MulticlusterExportReasonFailed,
+					fmt.Sprintf("Failed to delete ClusterClaim %s: %v", name, err),
+					metav1.ConditionTrue)
+				return
+			}
+		}
+		statusMgr.AddCondition(MulticlusterExport, MulticlusterExportReasonDisabled,
+			"Multicluster export is disabled: ClusterClaims are removed",
+			metav1.ConditionFalse)
+		return
+	}
+
+	for name, value := range r.desiredClusterClaims(ctx, config, statusMgr) {
+		var err error
+		if value == "" {
+			err = r.deleteClusterClaim(ctx, name)
+		} else {
+			err = r.applyClusterClaim(ctx, name, value)
+		}
+		if apimeta.IsNoMatchError(err) {
+			statusMgr.AddCondition(MulticlusterExport, MulticlusterExportReasonUnavailable,
+				"ClusterClaims are not available: the cluster is not managed by ACM",
+				metav1.ConditionFalse)
+			return
+		}
+		if err != nil {
+			r.log.Error(err, "failed to publish ClusterClaim", "name", name)
+			statusMgr.AddCondition(MulticlusterExport, MulticlusterExportReasonFailed,
+				fmt.Sprintf("Failed to publish ClusterClaim %s: %v", name, err),
+				metav1.ConditionFalse)
+			return
+		}
+	}
+	statusMgr.AddCondition(MulticlusterExport, MulticlusterExportReasonPublished,
+		"Trust domain, bundle endpoint and health are published as ClusterClaims",
+		metav1.ConditionTrue)
+}
+
+// desiredClusterClaims returns the value of each ClusterClaim, empty when the claim does not apply
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) desiredClusterClaims(ctx context.Context, config *v1alpha2.ZeroTrustWorkloadIdentityManager, statusMgr *status.Manager) map[string]string {
+	claims := map[string]string{
+		clusterClaimTrustDomain:           utils.TrustDomain(config),
+		clusterClaimBundleEndpoint:        "",
+		clusterClaimBundleEndpointProfile: "",
+		clusterClaimHealth:                v1alpha2.ReasonInProgress,
+	}
+	if ready, ok := statusMgr.GetCondition(v1alpha2.Ready); ok {
+		claims[clusterClaimHealth] = ready.Reason
+	}
+
+	var server v1alpha2.SpireServer
+	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: "cluster"}, &server); err != nil {
+		if !apierror.IsNotFound(err) {
+			r.log.Error(err, "failed to get SpireServer for the bundle endpoint ClusterClaim")
+		}
+		return claims
+	}
+	if server.Spec.Federation != nil {
+		// The federation Route exposes the bundle endpoint on the host derived from the trust domain
+		claims[clusterClaimBundleEndpoint] = "https://federation." + utils.TrustDomain(config)
+		claims[clusterClaimBundleEndpointProfile] = string(server.Spec.Federation.BundleEndpoint.Profile)
+	}
+	return claims
+}
+
+func newClusterClaim(name string) *unstructured.Unstructured {
+	claim := &unstructured.Unstructured{}
+	claim.SetGroupVersionKind(clusterClaimGVK)
+	claim.SetName(name)
+	return claim
+}
+
+// applyClusterClaim creates the ClusterClaim or updates its value. ClusterClaims are not labelled
+// as managed by the operator and not cached, so they are read from the API server.
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) applyClusterClaim(ctx context.Context, name, value string) error {
+	if len(value) > clusterClaimMaxValueLength {
+		value = value[:clusterClaimMaxValueLength]
+	}
+	existing := newClusterClaim(name)
+	err := r.ctrlClient.GetUncached(ctx, types.NamespacedName{Name: name}, existing)
+	if apierror.IsNotFound(err) {
+		claim := newClusterClaim(name)
+		claim.SetLabels(map[string]string{utils.AppManagedByLabelKey: utils.AppManagedByLabelValue})
+		if err := unstructured.SetNestedField(claim.Object, value, "spec", "value"); err != nil {
+			return err
+		}
+		return r.ctrlClient.Create(ctx, claim)
+	}
+	if err != nil {
+		return err
+	}
+	if current, _, _ := unstructured.NestedString(existing.Object, "spec", "value"); current == value {
+		return nil
+	}
+	if err := unstructured.SetNestedField(existing.Object, value, "spec", "value"); err != nil {
+		return err
+	}
+	return r.ctrlClient.Update(ctx, existing)
+}
+
+// deleteClusterClaim deletes the ClusterClaim, if any. A missing ClusterClaim CRD means there is nothing to delete.
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) deleteClusterClaim(ctx context.Context, name string) error {
+	err := r.ctrlClient.Delete(ctx, newClusterClaim(name))
+	if err != nil && !apierror.IsNotFound(err) && !apimeta.IsNoMatchError(err) {
+		return err
+	}
+	return nil
+}
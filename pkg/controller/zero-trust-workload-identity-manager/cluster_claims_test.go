@@ -0,0 +1,133 @@
+package zero_trust_workload_identity_manager
+
+import (
+	"context"
+	"testing"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+)
+
+// clusterClaimValues returns the value of each ClusterClaim created or updated through the fake client
+func clusterClaimValues(fakeClient *fakes.FakeCustomCtrlClient) map[string]string {
+	values := map[string]string{}
+	record := func(obj client.Object) {
+		claim := obj.(*unstructured.Unstructured)
+		values[claim.GetName()], _, _ = unstructured.NestedString(claim.Object, "spec", "value")
+	}
+	for i := 0; i < fakeClient.CreateCallCount(); i++ {
+		_, obj, _ := fakeClient.CreateArgsForCall(i)
+		record(obj)
+	}
+	for i := 0; i < fakeClient.UpdateCallCount(); i++ {
+		_, obj, _ := fakeClient.UpdateArgsForCall(i)
+		record(obj)
+	}
+	return values
+}
+
+func TestReconcileClusterClaims(t *testing.T) {
+	config := &v1alpha2.ZeroTrustWorkloadIdentityManager{Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: "example.org"}}
+	federatedServer := func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+		obj.(*v1alpha2.SpireServer).Spec.Federation = &v1alpha2.FederationConfig{
+			BundleEndpoint: v1alpha2.BundleEndpointConfig{Profile: v1alpha2.HttpsSpiffeProfile},
+		}
+		return nil
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Setenv(MulticlusterExportEnv, "")
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		statusMgr := status.NewManager(fakeClient)
+		newTestReconciler(fakeClient).reconcileClusterClaims(context.Background(), config, statusMgr)
+		if fakeClient.CreateCallCount()+fakeClient.DeleteCallCount() != 0 {
+			t.Error("Expected no ClusterClaim to be touched when disabled")
+		}
+		if _, ok := statusMgr.GetCondition(MulticlusterExport); ok {
+			t.Error("Expected no MulticlusterExport condition when never enabled")
+		}
+	})
+
+	t.Run("publishes the claims", func(t *testing.T) {
+		t.Setenv(MulticlusterExportEnv, "true")
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		fakeClient.GetStub = federatedServer
+		fakeClient.GetUncachedReturns(kerrors.NewNotFound(schema.GroupResource{}, "claim"))
+		statusMgr := status.NewManager(fakeClient)
+		statusMgr.AddCondition(v1alpha2.Ready, v1alpha2.ReasonReady, "All components are ready", metav1.ConditionTrue)
+
+		newTestReconciler(fakeClient).reconcileClusterClaims(context.Background(), config, statusMgr)
+
+		values := clusterClaimValues(fakeClient)
+		expected := map[string]string{
+			clusterClaimTrustDomain:           "example.org",
+			clusterClaimBundleEndpoint:        "https://federation.example.org",
+			clusterClaimBundleEndpointProfile: string(v1alpha2.HttpsSpiffeProfile),
+			clusterClaimHealth:                v1alpha2.ReasonReady,
+		}
+		for name, value := range expected {
+			if values[name] != value {
+				t.Errorf("Expected ClusterClaim %s to be %q, got %q", name, value, values[name])
+			}
+		}
+		if cond, ok := statusMgr.GetCondition(MulticlusterExport); !ok || cond.Reason != MulticlusterExportReasonPublished {
+			t.Errorf("Expected the claims to be reported as published, got %v", cond)
+		}
+	})
+
+	t.Run("removes the bundle endpoint without federation", func(t *testing.T) {
+		t.Setenv(MulticlusterExportEnv, "true")
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		fakeClient.GetUncachedReturns(kerrors.NewNotFound(schema.GroupResource{}, "claim"))
+		statusMgr := status.NewManager(fakeClient)
+
+		newTestReconciler(fakeClient).reconcileClusterClaims(context.Background(), config, statusMgr)
+
+		if fakeClient.DeleteCallCount() != 2 {
+			t.Errorf("Expected both bundle endpoint claims to be deleted, got %d deletes", fakeClient.DeleteCallCount())
+		}
+		if values := clusterClaimValues(fakeClient); values[clusterClaimHealth] != v1alpha2.ReasonInProgress {
+			t.Errorf("Expected health to default to %s, got %q", v1alpha2.ReasonInProgress, values[clusterClaimHealth])
+		}
+	})
+
+	t.Run("cluster not managed by ACM", func(t *testing.T) {
+		t.Setenv(MulticlusterExportEnv, "true")
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		fakeClient.GetStub = federatedServer
+		fakeClient.GetUncachedReturns(&apimeta.NoKindMatchError{GroupKind: clusterClaimGVK.GroupKind()})
+		statusMgr := status.NewManager(fakeClient)
+
+		newTestReconciler(fakeClient).reconcileClusterClaims(context.Background(), config, statusMgr)
+
+		cond, ok := statusMgr.GetCondition(MulticlusterExport)
+		if !ok || cond.Status != metav1.ConditionFalse || cond.Reason != MulticlusterExportReasonUnavailable {
+			t.Errorf("Expected the claims to be reported as unavailable, got %v", cond)
+		}
+	})
+
+	t.Run("disabling removes the claims", func(t *testing.T) {
+		t.Setenv(MulticlusterExportEnv, "false")
+		published := config.DeepCopy()
+		published.Status.Conditions = []metav1.Condition{{Type: MulticlusterExport, Status: metav1.ConditionTrue}}
+		fakeClient := &fakes.FakeCustomCtrlClient{}
+		statusMgr := status.NewManager(fakeClient)
+
+		newTestReconciler(fakeClient).reconcileClusterClaims(context.Background(), published, statusMgr)
+
+		if fakeClient.DeleteCallCount() != 4 {
+			t.Errorf("Expected every ClusterClaim to be deleted, got %d deletes", fakeClient.DeleteCallCount())
+		}
+		if cond, ok := statusMgr.GetCondition(MulticlusterExport); !ok || cond.Reason != MulticlusterExportReasonDisabled {
+			t.Errorf("Expected the export to be reported as disabled, got %v", cond)
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package zero_trust_workload_identity_manager
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/conditionhistory"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+)
+
+// recordConditionHistory appends the condition transitions of the ZeroTrustWorkloadIdentityManager
+// and of the operands it aggregates to the condition history. The history is best effort: failing
+// to write it is logged and does not fail the reconcile.
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) recordConditionHistory(ctx context.Context, config *v1alpha2.ZeroTrustWorkloadIdentityManager, statusMgr *status.Manager) {
+	var conditions []metav1.Condition
+	for _, cond := range statusMgr.Conditions() {
+		conditions = append(conditions, metav1.Condition{Type: cond.Type, Status: cond.Status, Reason: cond.Reason, Message: cond.Message})
+	}
+	observed := map[string][]metav1.Condition{"ZeroTrustWorkloadIdentityManager/" + config.Name: conditions}
+	for _, operand := range config.Status.Operands {
+		observed[operand.Kind+"/"+operand.Name] = operand.Conditions
+	}
+
+	if err := conditionhistory.Record(ctx, r.ctrlClient, r.scheme, config, observed, time.Now()); err != nil {
+		r.log.Error(err, "failed to record condition history")
+	}
+}
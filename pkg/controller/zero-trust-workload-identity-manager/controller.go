@@ -6,9 +6,13 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"slices"
 	"strings"
 
+	configv1 "github.com/openshift/api/config/v1"
 	operatorv1 "github.com/operator-framework/api/pkg/operators/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierror "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
@@ -28,16 +32,21 @@ import (
 
 	"github.com/go-logr/logr"
 
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/topology"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/operator/assets"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/version"
 )
 
 const (
 	// Condition types for ZTWIM
-	OperandsAvailable = "OperandsAvailable"
-	CreateOnlyMode    = "CreateOnlyMode"
+	OperandsAvailable        = "OperandsAvailable"
+	CreateOnlyMode           = "CreateOnlyMode"
+	HelmMigration            = "HelmMigration"
+	BundleConfigMapMigration = "BundleConfigMapMigration"
 )
 
 // Operand state constants for structured state tracking
@@ -66,8 +75,8 @@ const (
 
 // classifyOperandState determines whether an operand is progressing, failed, or ready
 // based on structured state (Condition.Reason) with fallback to message substring matching
-func classifyOperandState(operand v1alpha1.OperandStatus, readyCondition *metav1.Condition) operandStateClassification {
-	if utils.StringToBool(operand.Ready) {
+func classifyOperandState(operand v1alpha2.OperandStatus, readyCondition *metav1.Condition) operandStateClassification {
+	if operand.Ready {
 		return operandReady
 	}
 
@@ -75,17 +84,17 @@ func classifyOperandState(operand v1alpha1.OperandStatus, readyCondition *metav1
 	if readyCondition != nil && readyCondition.Reason != "" {
 		switch readyCondition.Reason {
 		// Progressing states - map known reasons to progressing
-		case v1alpha1.ReasonInProgress,
+		case v1alpha2.ReasonInProgress,
 			OperandStateNotFound,
 			OperandStateInitialReconcile,
 			OperandStateReconciling:
 			return operandProgressing
 		// Failed states - map known failure reasons to failed
-		case v1alpha1.ReasonFailed,
+		case v1alpha2.ReasonFailed,
 			OperandStateUnhealthy:
 			return operandFailed
 		// Ready state (should be caught above, but included for completeness)
-		case v1alpha1.ReasonReady:
+		case v1alpha2.ReasonReady:
 			return operandReady
 		}
 	}
@@ -125,10 +134,14 @@ type ZeroTrustWorkloadIdentityManagerReconciler struct {
 	operatorConditionName string
 }
 
+// The permissions on the namespaced operand objects are only held in the operator namespace, through
+// the Role of the operator. RoleBindings are created in other namespaces for the bundle notifier and
+// the access granted on demand by the namespaceaccess package.
 // +kubebuilder:rbac:groups=operator.openshift.io,resources=zerotrustworkloadidentitymanagers,verbs=list;watch
 // +kubebuilder:rbac:groups=operator.openshift.io,resources=zerotrustworkloadidentitymanagers,verbs=get;update,resourceNames=cluster
 // +kubebuilder:rbac:groups=operator.openshift.io,resources=zerotrustworkloadidentitymanagers/status,verbs=update,resourceNames=cluster
 // +kubebuilder:rbac:groups=operator.openshift.io,resources=zerotrustworkloadidentitymanagers/finalizers,verbs=update,resourceNames=cluster
+// +kubebuilder:rbac:groups=operator.openshift.io,resources=spiffecsidrivers;spireagents;spireoidcdiscoveryproviders;spireservers,verbs=create
 // +kubebuilder:rbac:groups=operator.openshift.io,resources=spiffecsidrivers,verbs=list;watch
 // +kubebuilder:rbac:groups=operator.openshift.io,resources=spiffecsidrivers,verbs=get;update;delete,resourceNames=cluster
 // +kubebuilder:rbac:groups=operator.openshift.io,resources=spiffecsidrivers/status,verbs=update,resourceNames=cluster
@@ -146,26 +159,36 @@ type ZeroTrustWorkloadIdentityManagerReconciler struct {
 // +kubebuilder:rbac:groups=operator.openshift.io,resources=spireservers/status,verbs=update,resourceNames=cluster
 // +kubebuilder:rbac:groups=operator.openshift.io,resources=spireservers/finalizers,verbs=update,resourceNames=cluster
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=list;watch;create
-// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=get;update;delete,resourceNames=spire-server;spire-agent;spire-controller-manager
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=get;update;delete,resourceNames=spire-server;spire-agent;spire-controller-manager;spire-server-bundle-webhooks
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=delete,resourceNames=spire-server-bundle-configmaps
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings,verbs=list;watch;create
-// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings,verbs=get;update;delete,resourceNames=spire-server;spire-agent;spire-controller-manager
-// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=list;watch;create
-// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;update;delete,resourceNames=spire-bundle;spire-controller-manager-leader-election;spire-server-external-cert-reader;spire-oidc-external-cert-reader
-// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=list;watch;create
-// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;update;delete,resourceNames=spire-bundle;spire-controller-manager-leader-election;spire-server-external-cert-reader;spire-oidc-external-cert-reader
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings,verbs=get;update;delete,resourceNames=spire-server;spire-agent;spire-controller-manager;spire-server-bundle-webhooks
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=list;watch;create,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;update;delete,resourceNames=spire-bundle;spire-controller-manager-leader-election;spire-server-external-cert-reader;spire-oidc-external-cert-reader,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=list;create
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;update;delete,resourceNames=spire-server-bundle-configmaps;zero-trust-workload-identity-manager-namespace-access
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=watch,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;update;delete,resourceNames=spire-bundle;spire-controller-manager-leader-election;spire-server-external-cert-reader;spire-oidc-external-cert-reader,namespace=zero-trust-workload-identity-manager
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=get;list;watch;create;patch
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=update;delete,resourceNames=spire-controller-manager-webhook
-// +kubebuilder:rbac:groups="",resources=services,verbs=list;watch;create
-// +kubebuilder:rbac:groups="",resources=services,verbs=get;update;delete,resourceNames=spire-server;spire-controller-manager-webhook;spire-agent;spire-spiffe-oidc-discovery-provider
-// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=list;watch;create
-// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;update;delete,resourceNames=spire-server;spire-agent;spire-spiffe-csi-driver;spire-spiffe-oidc-discovery-provider
-// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=services,verbs=list;watch;create,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;update;delete,resourceNames=spire-server;spire-controller-manager-webhook;spire-agent;spire-spiffe-oidc-discovery-provider,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=list;watch;create,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;update;delete,resourceNames=spire-server;spire-agent;spire-spiffe-csi-driver;spire-spiffe-oidc-discovery-provider;spire-agent-health-probe;spire-server-rollout-verification,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete,namespace=zero-trust-workload-identity-manager
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;update;patch
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=nodes/proxy,verbs=get
+// +kubebuilder:rbac:groups="",resources=pods/proxy,verbs=get,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups="",resources=pods,verbs=patch;delete,namespace=zero-trust-workload-identity-manager
 // +kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=resourcequotas;limitranges,verbs=create,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups="",resources=resourcequotas;limitranges,verbs=get;update;delete,resourceNames=zero-trust-workload-identity-manager-guardrails,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups=batch,resources=cronjobs;jobs,verbs=get;list;watch;create;update;delete,namespace=zero-trust-workload-identity-manager
 // +kubebuilder:rbac:groups=storage.k8s.io,resources=csidrivers,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=get;list;watch;create
 // +kubebuilder:rbac:groups=spire.spiffe.io,resources=clusterfederatedtrustdomains,verbs=get;list;watch;create;update;patch;delete
@@ -177,19 +200,23 @@ type ZeroTrustWorkloadIdentityManagerReconciler struct {
 // +kubebuilder:rbac:groups=spire.spiffe.io,resources=clusterstaticentries,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=spire.spiffe.io,resources=clusterstaticentries/finalizers,verbs=update
 // +kubebuilder:rbac:groups=spire.spiffe.io,resources=clusterstaticentries/status,verbs=get;patch;update
-// +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=list;watch;create
-// +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;update;delete,resourceNames=spire-agent;spire-spiffe-csi-driver
-// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=list;watch;create
-// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;update;delete,resourceNames=spire-spiffe-oidc-discovery-provider
-// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=list;watch;create
-// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;update;delete,resourceNames=spire-server
+// +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=list;watch;create,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;update;delete,resourceNames=spire-agent;spire-spiffe-csi-driver;spire-agent-health-probe,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=list;watch;create,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;update;delete,resourceNames=spire-spiffe-oidc-discovery-provider,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=list;watch;create,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;update;delete,resourceNames=spire-server,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=list;watch;create,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;update;delete,resourceNames=spire-spiffe-oidc-discovery-provider,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups=config.openshift.io,resources=infrastructures,verbs=get;list;watch
+// +kubebuilder:rbac:groups=config.openshift.io,resources=imagedigestmirrorsets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=security.openshift.io,resources=securitycontextconstraints,verbs=list;watch;create
 // +kubebuilder:rbac:groups=security.openshift.io,resources=securitycontextconstraints,verbs=get;update;delete,resourceNames=spire-agent;spire-spiffe-csi-driver
-// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=list;watch;create
-// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;update;delete,resourceNames=spire-server-federation;spire-oidc-discovery-provider
-// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
-// +kubebuilder:rbac:groups=route.openshift.io,resources=routes/custom-host,verbs=create;update
+// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update;patch;delete,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=list;watch;create,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;update;delete,resourceNames=spire-server-federation;spire-oidc-discovery-provider;spire-oidc-discovery-provider-alias-0;spire-oidc-discovery-provider-alias-1;spire-oidc-discovery-provider-alias-2;spire-oidc-discovery-provider-alias-3,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch,namespace=zero-trust-workload-identity-manager
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes/custom-host,verbs=create;update,namespace=zero-trust-workload-identity-manager
 // +kubebuilder:rbac:groups=operators.coreos.com,resources=operatorconditions,verbs=get;list;watch
 // +kubebuilder:rbac:groups=operators.coreos.com,resources=operatorconditions/status,verbs=update
 
@@ -234,34 +261,42 @@ func setCreateOnlyModeCondition(statusMgr *status.Manager, existingConditions []
 
 // Reconcile ensures the ZeroTrustWorkloadIdentityManager 'cluster' instance exists
 // and aggregates status from all managed operand CRs
-func (r *ZeroTrustWorkloadIdentityManagerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	r.log.Info(fmt.Sprintf("reconciling %s", utils.ZeroTrustWorkloadIdentityManagerControllerName))
-	var config v1alpha1.ZeroTrustWorkloadIdentityManager
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, reconcileErr error) {
+	traced := *r
+	ctx, traced.log, traced.eventRecorder = utils.TraceReconcile(ctx, r.log, r.eventRecorder)
+	r = &traced
+	r.log.Info("reconciling", "controller", utils.ZeroTrustWorkloadIdentityManagerControllerName, "name", req.Name)
+	var config v1alpha2.ZeroTrustWorkloadIdentityManager
 	err := r.ctrlClient.Get(ctx, req.NamespacedName, &config)
 	if err != nil {
 		if apierror.IsNotFound(err) {
 			// Update OperatorCondition for OLM integration (best effort - don't fail reconciliation if it fails)
 			// Upgradeable condition is only set on OperatorCondition, not on ZTWIM CR
-			if err := r.updateOperatorCondition(ctx, utils.IsInCreateOnlyMode(), []v1alpha1.OperandStatus{}); err != nil {
+			if err := r.updateOperatorCondition(ctx, utils.IsInCreateOnlyMode(), []v1alpha2.OperandStatus{}, nil); err != nil {
 				r.log.Error(err, "failed to update OperatorCondition, continuing (operator may be running outside OLM)")
 			}
+			status.Forget(&config, req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
-		return ctrl.Result{}, err
+		return utils.ReconcileResult(err)
 	}
 	// Set Ready to false at the start of reconciliation
-	status.SetInitialReconciliationStatus(ctx, r.ctrlClient, &config, func() *v1alpha1.ConditionalStatus {
+	status.SetInitialReconciliationStatus(ctx, r.ctrlClient, &config, func() *v1alpha2.ConditionalStatus {
 		return &config.Status.ConditionalStatus
 	}, "ZeroTrustWorkloadIdentityManager")
 
 	statusMgr := status.NewManager(r.ctrlClient)
 
+	statusMgr.SetVersion(version.OperatorVersion)
+	statusMgr.SetGracePeriod(config.Spec.StatusGracePeriod)
 	defer func() {
-		if err := statusMgr.ApplyStatus(ctx, &config, func() *v1alpha1.ConditionalStatus {
+		r.recordConditionHistory(ctx, &config, statusMgr)
+		if err := statusMgr.ApplyStatus(ctx, &config, func() *v1alpha2.ConditionalStatus {
 			return &config.Status.ConditionalStatus
 		}); err != nil {
 			r.log.Error(err, "failed to update status")
 		}
+		res = statusMgr.RequeueHeldBack(res, reconcileErr)
 	}()
 
 	// Aggregate status from all operand CRs
@@ -271,11 +306,11 @@ func (r *ZeroTrustWorkloadIdentityManagerReconciler) Reconcile(ctx context.Conte
 	// Set operands availability condition and manually control Ready condition
 	if result.allReady {
 		// All operands ready
-		statusMgr.AddCondition(OperandsAvailable, v1alpha1.ReasonReady,
+		statusMgr.AddCondition(OperandsAvailable, v1alpha2.ReasonReady,
 			"All operand CRs are ready",
 			metav1.ConditionTrue)
 		// Manually set Ready (don't let status manager auto-aggregate)
-		statusMgr.AddCondition(v1alpha1.Ready, v1alpha1.ReasonReady,
+		statusMgr.AddCondition(v1alpha2.Ready, v1alpha2.ReasonReady,
 			"All components are ready",
 			metav1.ConditionTrue)
 	} else if result.notCreatedCount > 0 && result.failedCount == 0 {
@@ -283,24 +318,24 @@ func (r *ZeroTrustWorkloadIdentityManagerReconciler) Reconcile(ctx context.Conte
 		var pendingOperands []string
 		for _, operand := range result.operandStatuses {
 			// Use structured state classification instead of exact string matching
-			readyCondition := apimeta.FindStatusCondition(operand.Conditions, v1alpha1.Ready)
+			readyCondition := apimeta.FindStatusCondition(operand.Conditions, v1alpha2.Ready)
 			classification := classifyOperandState(operand, readyCondition)
 
 			if classification == operandProgressing {
 				// Differentiate between not created vs reconciling based on message
 				if operand.Message == OperandMessageCRNotFound {
-					pendingOperands = append(pendingOperands, fmt.Sprintf("%s(not created)", operand.Kind))
+					pendingOperands = append(pendingOperands, fmt.Sprintf("%s(not created)", operandDisplayName(operand)))
 				} else {
-					pendingOperands = append(pendingOperands, fmt.Sprintf("%s(reconciling)", operand.Kind))
+					pendingOperands = append(pendingOperands, fmt.Sprintf("%s(reconciling)", operandDisplayName(operand)))
 				}
 			}
 		}
 		message := fmt.Sprintf("Waiting for operands: %v", pendingOperands)
-		statusMgr.AddCondition(OperandsAvailable, v1alpha1.ReasonInProgress,
+		statusMgr.AddCondition(OperandsAvailable, v1alpha2.ReasonInProgress,
 			message,
 			metav1.ConditionFalse)
 		// Manually set Ready with Progressing (waiting for user/reconciliation)
-		statusMgr.AddCondition(v1alpha1.Ready, v1alpha1.ReasonInProgress,
+		statusMgr.AddCondition(v1alpha2.Ready, v1alpha2.ReasonInProgress,
 			message,
 			metav1.ConditionFalse)
 	} else {
@@ -308,7 +343,7 @@ func (r *ZeroTrustWorkloadIdentityManagerReconciler) Reconcile(ctx context.Conte
 		var unhealthyOperands []string
 		for _, operand := range result.operandStatuses {
 			// Use structured state classification instead of exact string matching
-			readyCondition := apimeta.FindStatusCondition(operand.Conditions, v1alpha1.Ready)
+			readyCondition := apimeta.FindStatusCondition(operand.Conditions, v1alpha2.Ready)
 			classification := classifyOperandState(operand, readyCondition)
 
 			if classification == operandFailed {
@@ -317,31 +352,115 @@ func (r *ZeroTrustWorkloadIdentityManagerReconciler) Reconcile(ctx context.Conte
 		}
 		// Always set conditions when we have unhealthy operands
 		message := fmt.Sprintf("Some operands not ready: %v", unhealthyOperands)
-		statusMgr.AddCondition(OperandsAvailable, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(OperandsAvailable, v1alpha2.ReasonFailed,
 			message,
 			metav1.ConditionFalse)
 		// Manually set Ready with Failed (actual failure)
-		statusMgr.AddCondition(v1alpha1.Ready, v1alpha1.ReasonFailed,
+		statusMgr.AddCondition(v1alpha2.Ready, v1alpha2.ReasonFailed,
 			message,
 			metav1.ConditionFalse)
 	}
 
+	// Detect version skew between the operator and the deployed operand workloads
+	versionStatuses := r.checkOperandVersionSkew(ctx, statusMgr)
+
 	// Set CreateOnlyMode condition based on environment variable (simpler than aggregating from operands)
 	setCreateOnlyModeCondition(statusMgr, config.Status.ConditionalStatus.Conditions)
 
+	// Adopt a helm-deployed SPIRE stack when requested
+	helmMigrationInProgress := r.reconcileHelmMigration(ctx, &config, statusMgr)
+
+	// Remove the previous trust bundle ConfigMap once a bundleConfigMap change has propagated
+	bundleMigrationInProgress := r.reconcileBundleConfigMapMigration(ctx, &config, statusMgr)
+
+	// Keep the trust bundle of the old trust domain published through a trust domain migration
+	trustDomainMigrationInProgress := r.reconcileTrustDomainMigration(ctx, &config, statusMgr)
+
+	// Note the topology profile the operand defaults follow
+	r.reportTopologyProfile(ctx, &config, statusMgr)
+
+	// Flag a trust domain inconsistent with the JWT issuers, which the operands refuse to render
+	if err := statusMgr.CheckConfigurationConflict(ctx, utils.TrustDomain(&config), config.Status.Conditions); err != nil && utils.ClassifyError(err) != utils.InvalidConfigurationError {
+		r.log.Error(err, "failed to check the identity configuration for conflicts")
+	}
+
+	// Report the version and the integrity of the embedded static assets
+	reportStaticAssets(&config, statusMgr, assets.Verify())
+
+	// Bound the resource usage of the operator namespace
+	r.reconcileNamespaceGuardrails(ctx, &config, statusMgr)
+
+	// Publish the identity status to the ACM hub in multicluster addon mode
+	r.reconcileClusterClaims(ctx, &config, statusMgr)
+
+	// Keep the caBundle of the webhooks labelled for injection in sync with the trust bundle
+	caInjectionFailed := r.reconcileCABundleInjection(ctx, &config, statusMgr)
+
+	// Keep the additional formats of the trust bundle in sync with the PEM bundle
+	bundleFormatsFailed := r.reconcileBundleFormats(ctx, &config, statusMgr)
+
+	// Check the operand images can be pulled, e.g. through the mirrors of a disconnected cluster
+	imagesUnavailable := r.reconcileImagesAvailable(ctx, &config, statusMgr)
+
 	// Check create-only mode from environment variable for logging and OLM update
 	createOnlyModeEnabled := utils.IsInCreateOnlyMode()
 	r.log.Info("Aggregated operand status", "allReady", result.allReady, "notCreated", result.notCreatedCount, "failed", result.failedCount, "createOnlyModeEnabled", createOnlyModeEnabled, "anyOperandExists", result.anyOperandExists)
 
 	// Update OperatorCondition for OLM integration (best effort - don't fail reconciliation if it fails)
 	// Upgradeable condition is only set on OperatorCondition, not on ZTWIM CR
-	if err := r.updateOperatorCondition(ctx, createOnlyModeEnabled, result.operandStatuses); err != nil {
+	if err := r.updateOperatorCondition(ctx, createOnlyModeEnabled, result.operandStatuses, versionStatuses); err != nil {
 		r.log.Error(err, "failed to update OperatorCondition, continuing (operator may be running outside OLM)")
 	}
 
+	if helmMigrationInProgress || bundleMigrationInProgress || trustDomainMigrationInProgress {
+		return ctrl.Result{RequeueAfter: helmMigrationRequeueInterval}, nil
+	}
+	if caInjectionFailed {
+		return ctrl.Result{RequeueAfter: caInjectionRetryInterval}, nil
+	}
+	if bundleFormatsFailed {
+		return ctrl.Result{RequeueAfter: bundleFormatsRetryInterval}, nil
+	}
+	if imagesUnavailable {
+		return ctrl.Result{RequeueAfter: imagesRetryInterval}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
+// checkOperandVersionSkew compares the deployed operand workloads with the versions expected by
+// the running operator and sets the UpgradeInProgress condition with per-component progress
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) checkOperandVersionSkew(ctx context.Context, statusMgr *status.Manager) []status.OperandVersionStatus {
+	versionStatuses, err := status.GetOperandVersionStatuses(ctx, r.ctrlClient)
+	if err != nil {
+		r.log.Error(err, "failed to determine operand versions")
+		return nil
+	}
+
+	var progress []string
+	inSkew := false
+	for _, versionStatus := range versionStatuses {
+		if !versionStatus.Deployed {
+			continue
+		}
+		progress = append(progress, versionStatus.String())
+		if versionStatus.InSkew() {
+			inSkew = true
+		}
+	}
+
+	if inSkew {
+		statusMgr.AddCondition(utils.UpgradeInProgressStatusType, utils.UpgradeReasonOperandVersionSkew,
+			fmt.Sprintf("Upgrading operands: %s", strings.Join(progress, "; ")),
+			metav1.ConditionTrue)
+	} else {
+		statusMgr.AddCondition(utils.UpgradeInProgressStatusType, utils.UpgradeReasonComplete,
+			"All deployed operands match the operator version",
+			metav1.ConditionFalse)
+	}
+
+	return versionStatuses
+}
+
 // operandAggregateState holds the aggregate state tracked across all operands
 type operandAggregateState struct {
 	allReady         bool
@@ -352,7 +471,7 @@ type operandAggregateState struct {
 
 // operandAggregateResult holds the result of aggregating operand statuses
 type operandAggregateResult struct {
-	operandStatuses  []v1alpha1.OperandStatus
+	operandStatuses  []v1alpha2.OperandStatus
 	allReady         bool
 	notCreatedCount  int
 	failedCount      int
@@ -360,17 +479,17 @@ type operandAggregateResult struct {
 }
 
 // processOperandStatus processes a single operand's status and updates aggregate state
-func processOperandStatus(operand v1alpha1.OperandStatus, state *operandAggregateState) {
+func processOperandStatus(operand v1alpha2.OperandStatus, state *operandAggregateState) {
 	// Check if operand exists
 	if operand.Message != OperandMessageCRNotFound {
 		state.anyOperandExists = true
 	}
 
 	// Check if operand is ready
-	if !utils.StringToBool(operand.Ready) {
+	if !operand.Ready {
 		state.allReady = false
 		// Use structured state classification
-		readyCondition := apimeta.FindStatusCondition(operand.Conditions, v1alpha1.Ready)
+		readyCondition := apimeta.FindStatusCondition(operand.Conditions, v1alpha2.Ready)
 		classification := classifyOperandState(operand, readyCondition)
 		if classification == operandProgressing {
 			state.notCreatedCount++
@@ -387,13 +506,13 @@ func (r *ZeroTrustWorkloadIdentityManagerReconciler) aggregateOperandStatus(ctx
 		allReady: true,
 	}
 
-	// Collect status from all operands
-	operandStatuses := []v1alpha1.OperandStatus{
-		r.getSpireServerStatus(ctx),
-		r.getSpireAgentStatus(ctx),
+	// Collect status from all operands, every SpireAgent pool included
+	operandStatuses := []v1alpha2.OperandStatus{r.getSpireServerStatus(ctx)}
+	operandStatuses = append(operandStatuses, r.getSpireAgentStatuses(ctx)...)
+	operandStatuses = append(operandStatuses,
 		r.getSpiffeCSIDriverStatus(ctx),
 		r.getSpireOIDCDiscoveryProviderStatus(ctx),
-	}
+	)
 
 	// Process each operand status
 	for _, operand := range operandStatuses {
@@ -412,28 +531,33 @@ func (r *ZeroTrustWorkloadIdentityManagerReconciler) aggregateOperandStatus(ctx
 // operandStatusGetter defines the interface for types that have conditional status
 type operandStatusGetter interface {
 	client.Object
-	GetConditionalStatus() v1alpha1.ConditionalStatus
+	GetConditionalStatus() v1alpha2.ConditionalStatus
 }
 
 // getOperandStatus is a generic helper that retrieves and summarizes operand status for any CR type
-func getOperandStatus[T operandStatusGetter](ctx context.Context, r *ZeroTrustWorkloadIdentityManagerReconciler, kind string) v1alpha1.OperandStatus {
+func getOperandStatus[T operandStatusGetter](ctx context.Context, r *ZeroTrustWorkloadIdentityManagerReconciler, kind string) v1alpha2.OperandStatus {
+	return getNamedOperandStatus[T](ctx, r, kind, "cluster")
+}
+
+// getNamedOperandStatus retrieves and summarizes the status of the named operand CR
+func getNamedOperandStatus[T operandStatusGetter](ctx context.Context, r *ZeroTrustWorkloadIdentityManagerReconciler, kind, name string) v1alpha2.OperandStatus {
 	var obj T
 	// Since T is a pointer type, create a new instance of the underlying type
 	objValue := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(T)
-	err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: "cluster"}, objValue)
+	err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: name}, objValue)
 
-	operandStatus := v1alpha1.OperandStatus{
-		Name: "cluster",
+	operandStatus := v1alpha2.OperandStatus{
+		Name: name,
 		Kind: kind,
 	}
 
 	if err != nil {
 		if apierror.IsNotFound(err) {
-			operandStatus.Ready = "false"
+			operandStatus.Ready = false
 			operandStatus.Message = OperandMessageCRNotFound
 			return operandStatus
 		}
-		operandStatus.Ready = "false"
+		operandStatus.Ready = false
 		operandStatus.Message = fmt.Sprintf("Failed to get CR: %v", err)
 		return operandStatus
 	}
@@ -444,18 +568,18 @@ func getOperandStatus[T operandStatusGetter](ctx context.Context, r *ZeroTrustWo
 
 	// Check if operand has been reconciled (has at least one condition)
 	if len(conditions) == 0 {
-		operandStatus.Ready = "false"
+		operandStatus.Ready = false
 		operandStatus.Message = OperandMessageWaitingInitialRecon
 		return operandStatus
 	}
 
 	// Check if Ready condition exists and is True
-	readyCondition := apimeta.FindStatusCondition(conditions, v1alpha1.Ready)
+	readyCondition := apimeta.FindStatusCondition(conditions, v1alpha2.Ready)
 	if readyCondition != nil && readyCondition.Status == metav1.ConditionTrue {
-		operandStatus.Ready = "true"
+		operandStatus.Ready = true
 		operandStatus.Message = "Ready"
 	} else {
-		operandStatus.Ready = "false"
+		operandStatus.Ready = false
 		if readyCondition != nil {
 			operandStatus.Message = readyCondition.Message
 		} else {
@@ -464,29 +588,60 @@ func getOperandStatus[T operandStatusGetter](ctx context.Context, r *ZeroTrustWo
 	}
 
 	// Include only failed conditions (reduces clutter)
-	operandStatus.Conditions = extractKeyConditions(conditions, utils.StringToBool(operandStatus.Ready))
+	operandStatus.Conditions = extractKeyConditions(conditions, operandStatus.Ready)
 
 	return operandStatus
 }
 
 // getSpireServerStatus retrieves and summarizes SpireServer status
-func (r *ZeroTrustWorkloadIdentityManagerReconciler) getSpireServerStatus(ctx context.Context) v1alpha1.OperandStatus {
-	return getOperandStatus[*v1alpha1.SpireServer](ctx, r, "SpireServer")
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) getSpireServerStatus(ctx context.Context) v1alpha2.OperandStatus {
+	return getOperandStatus[*v1alpha2.SpireServer](ctx, r, "SpireServer")
 }
 
 // getSpireAgentStatus retrieves and summarizes SpireAgent status
-func (r *ZeroTrustWorkloadIdentityManagerReconciler) getSpireAgentStatus(ctx context.Context) v1alpha1.OperandStatus {
-	return getOperandStatus[*v1alpha1.SpireAgent](ctx, r, "SpireAgent")
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) getSpireAgentStatus(ctx context.Context) v1alpha2.OperandStatus {
+	return getOperandStatus[*v1alpha2.SpireAgent](ctx, r, "SpireAgent")
+}
+
+// getSpireAgentStatuses retrieves and summarizes the status of the default SpireAgent, followed
+// by the other agent pools sorted by name
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) getSpireAgentStatuses(ctx context.Context) []v1alpha2.OperandStatus {
+	statuses := []v1alpha2.OperandStatus{r.getSpireAgentStatus(ctx)}
+	var agents v1alpha2.SpireAgentList
+	if err := r.ctrlClient.List(ctx, &agents); err != nil {
+		r.log.Error(err, "failed to list SpireAgent pools")
+		return statuses
+	}
+	var pools []string
+	for _, agent := range agents.Items {
+		if agent.Name != utils.DefaultAgentPool {
+			pools = append(pools, agent.Name)
+		}
+	}
+	slices.Sort(pools)
+	for _, pool := range pools {
+		statuses = append(statuses, getNamedOperandStatus[*v1alpha2.SpireAgent](ctx, r, "SpireAgent", pool))
+	}
+	return statuses
+}
+
+// operandDisplayName names the operand in messages: by kind, and by kind and name for the
+// SpireAgent pools other than "cluster"
+func operandDisplayName(operand v1alpha2.OperandStatus) string {
+	if operand.Name == "" || operand.Name == "cluster" {
+		return operand.Kind
+	}
+	return operand.Kind + "/" + operand.Name
 }
 
 // getSpiffeCSIDriverStatus retrieves and summarizes SpiffeCSIDriver status
-func (r *ZeroTrustWorkloadIdentityManagerReconciler) getSpiffeCSIDriverStatus(ctx context.Context) v1alpha1.OperandStatus {
-	return getOperandStatus[*v1alpha1.SpiffeCSIDriver](ctx, r, "SpiffeCSIDriver")
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) getSpiffeCSIDriverStatus(ctx context.Context) v1alpha2.OperandStatus {
+	return getOperandStatus[*v1alpha2.SpiffeCSIDriver](ctx, r, "SpiffeCSIDriver")
 }
 
 // getSpireOIDCDiscoveryProviderStatus retrieves and summarizes SpireOIDCDiscoveryProvider status
-func (r *ZeroTrustWorkloadIdentityManagerReconciler) getSpireOIDCDiscoveryProviderStatus(ctx context.Context) v1alpha1.OperandStatus {
-	return getOperandStatus[*v1alpha1.SpireOIDCDiscoveryProvider](ctx, r, "SpireOIDCDiscoveryProvider")
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) getSpireOIDCDiscoveryProviderStatus(ctx context.Context) v1alpha2.OperandStatus {
+	return getOperandStatus[*v1alpha2.SpireOIDCDiscoveryProvider](ctx, r, "SpireOIDCDiscoveryProvider")
 }
 
 // extractKeyConditions extracts key conditions from operand status
@@ -508,7 +663,7 @@ func extractKeyConditions(conditions []metav1.Condition, isReady bool) []metav1.
 	}
 
 	// If operand is not ready, include the Ready condition for structured state classification
-	readyCondition := apimeta.FindStatusCondition(conditions, v1alpha1.Ready)
+	readyCondition := apimeta.FindStatusCondition(conditions, v1alpha2.Ready)
 	if readyCondition != nil {
 		keyConditions = append(keyConditions, *readyCondition)
 	}
@@ -516,7 +671,7 @@ func extractKeyConditions(conditions []metav1.Condition, isReady bool) []metav1.
 	// Also include other failed conditions to show what's wrong
 	for _, cond := range conditions {
 		// Skip conditions we've already checked
-		if cond.Type == v1alpha1.Ready || cond.Type == utils.CreateOnlyModeStatusType {
+		if cond.Type == v1alpha2.Ready || cond.Type == utils.CreateOnlyModeStatusType {
 			continue
 		}
 
@@ -532,7 +687,7 @@ func extractKeyConditions(conditions []metav1.Condition, isReady bool) []metav1.
 // recreateClusterInstance recreates the cluster instance if it was deleted
 func (r *ZeroTrustWorkloadIdentityManagerReconciler) recreateClusterInstance(ctx context.Context, name string) (ctrl.Result, error) {
 	r.log.Info("Recreating ZeroTrustWorkloadIdentityManager 'cluster' as it was deleted")
-	newConfig := &v1alpha1.ZeroTrustWorkloadIdentityManager{
+	newConfig := &v1alpha2.ZeroTrustWorkloadIdentityManager{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: name,
 		},
@@ -572,9 +727,15 @@ var operandStatusChangedPredicate = predicate.Funcs{
 	},
 }
 
-// updateOperatorCondition syncs the Upgradeable condition to the OperatorCondition resource for OLM
+// updateOperatorCondition syncs the Upgradeable, Degraded and OperandsAvailable conditions to the
+// OperatorCondition resource for OLM
 // The Upgradeable condition is only set on OperatorCondition, not on the ZTWIM CR
-func (r *ZeroTrustWorkloadIdentityManagerReconciler) updateOperatorCondition(ctx context.Context, anyCreateOnlyModeEnabled bool, operandStatuses []v1alpha1.OperandStatus) error {
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) updateOperatorCondition(ctx context.Context, anyCreateOnlyModeEnabled bool, operandStatuses []v1alpha2.OperandStatus, versionStatuses []status.OperandVersionStatus) error {
+	if !utils.HasCapability(utils.CapabilityOperatorCondition) {
+		r.log.V(1).Info("OperatorConditions are not served by the cluster, skipping update")
+		return nil
+	}
+
 	// Find the OperatorCondition resource created by OLM
 	operatorCondition, err := r.findOperatorCondition(ctx)
 	if err != nil {
@@ -587,54 +748,146 @@ func (r *ZeroTrustWorkloadIdentityManagerReconciler) updateOperatorCondition(ctx
 		return nil
 	}
 
-	upgradeableStatus := metav1.ConditionTrue
-	upgradeableReason := v1alpha1.ReasonReady
-	upgradeableMessage := "Operator is Upgradeable"
+	conditions := []metav1.Condition{
+		buildUpgradeableCondition(anyCreateOnlyModeEnabled, operandStatuses, versionStatuses),
+		buildDegradedCondition(operandStatuses),
+		buildOperandsSummaryCondition(operandStatuses),
+	}
+	for _, condition := range conditions {
+		condition.LastTransitionTime = metav1.Now()
+		condition.ObservedGeneration = operatorCondition.Generation
+		apimeta.SetStatusCondition(&operatorCondition.Status.Conditions, condition)
+	}
+
+	// Update the OperatorCondition status using the status subresource
+	if err = r.ctrlClient.StatusUpdateWithRetry(ctx, operatorCondition); err != nil {
+		return fmt.Errorf("failed to update OperatorCondition status: %w", err)
+	}
+
+	r.log.Info("Successfully updated OperatorCondition", "name", operatorCondition.Name,
+		"upgradeable", conditions[0].Status, "degraded", conditions[1].Status, "operandsAvailable", conditions[2].Status)
+	return nil
+}
+
+// buildUpgradeableCondition returns the Upgradeable condition for the OperatorCondition
+// Upgrade is blocked when create-only mode is enabled, an operand upgrade is still in progress
+// or any existing operand is not ready
+func buildUpgradeableCondition(anyCreateOnlyModeEnabled bool, operandStatuses []v1alpha2.OperandStatus, versionStatuses []status.OperandVersionStatus) metav1.Condition {
+	condition := metav1.Condition{
+		Type:    v1alpha2.Upgradeable,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1alpha2.ReasonReady,
+		Message: "Operator is Upgradeable",
+	}
 
 	if anyCreateOnlyModeEnabled {
 		// CreateOnlyMode prevents updates - not safe to upgrade
-		upgradeableStatus = metav1.ConditionFalse
-		upgradeableReason = v1alpha1.ReasonOperandsNotReady
-		upgradeableMessage = "Not safe to upgrade - create-only mode is enabled on one or more operands"
-	} else {
-		// Check if any operands exist but are not ready
-		// CRs that don't exist (CR not found) are OK for upgrade
-		var notReadyOperands []string
-		for _, operand := range operandStatuses {
-			// Only count operands that exist but are not ready
-			// If operand exists (not CR not found) and is not ready, it blocks upgrade
-			if !utils.StringToBool(operand.Ready) && operand.Message != OperandMessageCRNotFound {
-				notReadyOperands = append(notReadyOperands, fmt.Sprintf("%s", operand.Kind))
-			}
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = v1alpha2.ReasonOperandsNotReady
+		condition.Message = "Not safe to upgrade - create-only mode is enabled on one or more operands"
+		return condition
+	}
+
+	// Operands still running a version other than the one expected by this operator
+	// must be rolled forward before another operator upgrade is attempted
+	var skewedOperands []string
+	for _, versionStatus := range versionStatuses {
+		if versionStatus.InSkew() {
+			skewedOperands = append(skewedOperands, versionStatus.Kind)
 		}
+	}
+	if len(skewedOperands) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = utils.UpgradeReasonOperandVersionSkew
+		condition.Message = fmt.Sprintf("Not safe to upgrade - operand upgrade in progress: %v", skewedOperands)
+		return condition
+	}
 
-		if len(notReadyOperands) > 0 {
-			// Some operands exist but are not ready - not safe to upgrade
-			upgradeableStatus = metav1.ConditionFalse
-			upgradeableReason = v1alpha1.ReasonOperandsNotReady
-			upgradeableMessage = fmt.Sprintf("Not safe to upgrade - existing operands are not ready: %v", notReadyOperands)
+	// Check if any operands exist but are not ready
+	// CRs that don't exist (CR not found) are OK for upgrade
+	var notReadyOperands []string
+	for _, operand := range operandStatuses {
+		// Only count operands that exist but are not ready
+		// If operand exists (not CR not found) and is not ready, it blocks upgrade
+		if !operand.Ready && operand.Message != OperandMessageCRNotFound {
+			notReadyOperands = append(notReadyOperands, operandDisplayName(operand))
 		}
 	}
 
-	// Update the OperatorCondition with the Upgradeable status
+	if len(notReadyOperands) > 0 {
+		// Some operands exist but are not ready - not safe to upgrade
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = v1alpha2.ReasonOperandsNotReady
+		condition.Message = fmt.Sprintf("Not safe to upgrade - existing operands are not ready: %v", notReadyOperands)
+	}
+
+	return condition
+}
+
+// buildDegradedCondition returns the Degraded condition for the OperatorCondition
+// Only operands classified as failed mark the operator degraded; operands that are
+// still progressing or not yet created do not
+func buildDegradedCondition(operandStatuses []v1alpha2.OperandStatus) metav1.Condition {
+	var failedOperands []string
+	for _, operand := range operandStatuses {
+		readyCondition := apimeta.FindStatusCondition(operand.Conditions, v1alpha2.Ready)
+		if classifyOperandState(operand, readyCondition) == operandFailed {
+			failedOperands = append(failedOperands, fmt.Sprintf("%s(%s)", operandDisplayName(operand), operand.Message))
+		}
+	}
+
+	if len(failedOperands) > 0 {
+		return metav1.Condition{
+			Type:    v1alpha2.Degraded,
+			Status:  metav1.ConditionTrue,
+			Reason:  v1alpha2.ReasonFailed,
+			Message: fmt.Sprintf("Operands are degraded: %s", strings.Join(failedOperands, ", ")),
+		}
+	}
+
+	return metav1.Condition{
+		Type:    v1alpha2.Degraded,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1alpha2.ReasonReady,
+		Message: "No operands are degraded",
+	}
+}
+
+// buildOperandsSummaryCondition returns the OperandsAvailable condition for the OperatorCondition
+// The message summarizes the state of every operand so cluster admins can see at a glance
+// which component is holding back an upgrade
+func buildOperandsSummaryCondition(operandStatuses []v1alpha2.OperandStatus) metav1.Condition {
 	condition := metav1.Condition{
-		Type:               v1alpha1.Upgradeable,
-		Status:             upgradeableStatus,
-		Reason:             upgradeableReason,
-		Message:            upgradeableMessage,
-		LastTransitionTime: metav1.Now(),
-		ObservedGeneration: operatorCondition.Generation,
+		Type:   OperandsAvailable,
+		Status: metav1.ConditionTrue,
+		Reason: v1alpha2.ReasonReady,
 	}
 
-	apimeta.SetStatusCondition(&operatorCondition.Status.Conditions, condition)
+	summary := make([]string, 0, len(operandStatuses))
+	for _, operand := range operandStatuses {
+		readyCondition := apimeta.FindStatusCondition(operand.Conditions, v1alpha2.Ready)
+		switch classifyOperandState(operand, readyCondition) {
+		case operandReady:
+			summary = append(summary, fmt.Sprintf("%s: Ready", operandDisplayName(operand)))
+			continue
+		case operandFailed:
+			condition.Reason = v1alpha2.ReasonFailed
+		case operandProgressing:
+			if condition.Reason != v1alpha2.ReasonFailed {
+				condition.Reason = v1alpha2.ReasonInProgress
+			}
+		}
+		condition.Status = metav1.ConditionFalse
+		summary = append(summary, fmt.Sprintf("%s: %s", operandDisplayName(operand), operand.Message))
+	}
 
-	// Update the OperatorCondition status using the status subresource
-	if err = r.ctrlClient.StatusUpdateWithRetry(ctx, operatorCondition); err != nil {
-		return fmt.Errorf("failed to update OperatorCondition status: %w", err)
+	if len(summary) == 0 {
+		condition.Message = "No operands are deployed"
+	} else {
+		condition.Message = strings.Join(summary, "; ")
 	}
 
-	r.log.Info("Successfully updated OperatorCondition", "name", operatorCondition.Name, "upgradeable", upgradeableStatus)
-	return nil
+	return condition
 }
 
 // findOperatorCondition finds the OperatorCondition resource created by OLM
@@ -677,15 +930,22 @@ func (r *ZeroTrustWorkloadIdentityManagerReconciler) SetupWithManager(mgr ctrl.M
 
 	// Watch ZTWIM CR and all operand CRs to aggregate their status
 	// Reconcile on operand creation and status changes
-	err := ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.ZeroTrustWorkloadIdentityManager{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha2.ZeroTrustWorkloadIdentityManager{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Named(utils.ZeroTrustWorkloadIdentityManagerControllerName).
-		Watches(&operatorv1.OperatorCondition{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(operandStatusChangedPredicate)).
-		Watches(&v1alpha1.SpireServer{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(operandStatusChangedPredicate)).
-		Watches(&v1alpha1.SpireAgent{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(operandStatusChangedPredicate)).
-		Watches(&v1alpha1.SpiffeCSIDriver{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(operandStatusChangedPredicate)).
-		Watches(&v1alpha1.SpireOIDCDiscoveryProvider{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(operandStatusChangedPredicate)).
-		Complete(r)
+		Watches(&v1alpha2.SpireServer{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(operandStatusChangedPredicate)).
+		Watches(&v1alpha2.SpireAgent{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(operandStatusChangedPredicate)).
+		Watches(&v1alpha2.SpiffeCSIDriver{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(operandStatusChangedPredicate)).
+		Watches(&v1alpha2.SpireOIDCDiscoveryProvider{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(operandStatusChangedPredicate)).
+		Watches(&admissionregistrationv1.ValidatingWebhookConfiguration{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(caInjectionWebhookPredicate)).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(trustBundleChangedPredicate))
+	if utils.HasCapability(utils.CapabilityOperatorCondition) {
+		controllerBuilder = controllerBuilder.Watches(&operatorv1.OperatorCondition{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(operandStatusChangedPredicate))
+	}
+	if utils.HasCapability(utils.CapabilityInfrastructure) {
+		controllerBuilder = controllerBuilder.Watches(&configv1.Infrastructure{}, handler.EnqueueRequestsFromMapFunc(mapFunc), builder.WithPredicates(topology.ChangedPredicate))
+	}
+	err := controllerBuilder.Complete(r)
 	if err != nil {
 		return err
 	}
@@ -7,12 +7,17 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
-	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha1"
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
 	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+	operatorv1 "github.com/operator-framework/api/pkg/operators/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -88,14 +93,14 @@ func TestReconcile_ZTWIMGetError(t *testing.T) {
 
 // TestClassifyOperandState_Ready tests classifyOperandState returns ready for ready operand
 func TestClassifyOperandState_Ready(t *testing.T) {
-	operand := v1alpha1.OperandStatus{
-		Ready:   "true",
+	operand := v1alpha2.OperandStatus{
+		Ready:   true,
 		Message: "Ready",
 	}
 	readyCondition := &metav1.Condition{
-		Type:   v1alpha1.Ready,
+		Type:   v1alpha2.Ready,
 		Status: metav1.ConditionTrue,
-		Reason: v1alpha1.ReasonReady,
+		Reason: v1alpha2.ReasonReady,
 	}
 
 	result := classifyOperandState(operand, readyCondition)
@@ -108,55 +113,55 @@ func TestClassifyOperandState_Ready(t *testing.T) {
 func TestClassifyOperandState_Progressing(t *testing.T) {
 	tests := []struct {
 		name      string
-		operand   v1alpha1.OperandStatus
+		operand   v1alpha2.OperandStatus
 		condition *metav1.Condition
 	}{
 		{
 			name: "NotFound reason",
-			operand: v1alpha1.OperandStatus{
-				Ready:   "false",
+			operand: v1alpha2.OperandStatus{
+				Ready:   false,
 				Message: "CR not found",
 			},
 			condition: &metav1.Condition{
-				Type:   v1alpha1.Ready,
+				Type:   v1alpha2.Ready,
 				Status: metav1.ConditionFalse,
 				Reason: OperandStateNotFound,
 			},
 		},
 		{
 			name: "InitialReconcile reason",
-			operand: v1alpha1.OperandStatus{
-				Ready:   "false",
+			operand: v1alpha2.OperandStatus{
+				Ready:   false,
 				Message: "Waiting for initial reconciliation",
 			},
 			condition: &metav1.Condition{
-				Type:   v1alpha1.Ready,
+				Type:   v1alpha2.Ready,
 				Status: metav1.ConditionFalse,
 				Reason: OperandStateInitialReconcile,
 			},
 		},
 		{
 			name: "Reconciling reason",
-			operand: v1alpha1.OperandStatus{
-				Ready:   "false",
+			operand: v1alpha2.OperandStatus{
+				Ready:   false,
 				Message: "Reconciling",
 			},
 			condition: &metav1.Condition{
-				Type:   v1alpha1.Ready,
+				Type:   v1alpha2.Ready,
 				Status: metav1.ConditionFalse,
 				Reason: OperandStateReconciling,
 			},
 		},
 		{
 			name: "InProgress reason",
-			operand: v1alpha1.OperandStatus{
-				Ready:   "false",
+			operand: v1alpha2.OperandStatus{
+				Ready:   false,
 				Message: "Reconciling",
 			},
 			condition: &metav1.Condition{
-				Type:   v1alpha1.Ready,
+				Type:   v1alpha2.Ready,
 				Status: metav1.ConditionFalse,
-				Reason: v1alpha1.ReasonInProgress,
+				Reason: v1alpha2.ReasonInProgress,
 			},
 		},
 	}
@@ -175,29 +180,29 @@ func TestClassifyOperandState_Progressing(t *testing.T) {
 func TestClassifyOperandState_Failed(t *testing.T) {
 	tests := []struct {
 		name      string
-		operand   v1alpha1.OperandStatus
+		operand   v1alpha2.OperandStatus
 		condition *metav1.Condition
 	}{
 		{
 			name: "Failed reason",
-			operand: v1alpha1.OperandStatus{
-				Ready:   "false",
+			operand: v1alpha2.OperandStatus{
+				Ready:   false,
 				Message: "Failed to reconcile",
 			},
 			condition: &metav1.Condition{
-				Type:   v1alpha1.Ready,
+				Type:   v1alpha2.Ready,
 				Status: metav1.ConditionFalse,
-				Reason: v1alpha1.ReasonFailed,
+				Reason: v1alpha2.ReasonFailed,
 			},
 		},
 		{
 			name: "Unhealthy reason",
-			operand: v1alpha1.OperandStatus{
-				Ready:   "false",
+			operand: v1alpha2.OperandStatus{
+				Ready:   false,
 				Message: "Component is unhealthy",
 			},
 			condition: &metav1.Condition{
-				Type:   v1alpha1.Ready,
+				Type:   v1alpha2.Ready,
 				Status: metav1.ConditionFalse,
 				Reason: OperandStateUnhealthy,
 			},
@@ -218,29 +223,29 @@ func TestClassifyOperandState_Failed(t *testing.T) {
 func TestClassifyOperandState_MessageFallback(t *testing.T) {
 	tests := []struct {
 		name     string
-		operand  v1alpha1.OperandStatus
+		operand  v1alpha2.OperandStatus
 		expected operandStateClassification
 	}{
 		{
 			name: "CR not found message - progressing",
-			operand: v1alpha1.OperandStatus{
-				Ready:   "false",
+			operand: v1alpha2.OperandStatus{
+				Ready:   false,
 				Message: OperandMessageCRNotFound,
 			},
 			expected: operandProgressing,
 		},
 		{
 			name: "Waiting initial recon message - progressing",
-			operand: v1alpha1.OperandStatus{
-				Ready:   "false",
+			operand: v1alpha2.OperandStatus{
+				Ready:   false,
 				Message: OperandMessageWaitingInitialRecon,
 			},
 			expected: operandProgressing,
 		},
 		{
 			name: "Reconciling message - progressing",
-			operand: v1alpha1.OperandStatus{
-				Ready:   "false",
+			operand: v1alpha2.OperandStatus{
+				Ready:   false,
 				Message: OperandMessageReconciling,
 			},
 			expected: operandProgressing,
@@ -261,45 +266,45 @@ func TestClassifyOperandState_MessageFallback(t *testing.T) {
 func TestClassifyOperandState_SubstringFallback(t *testing.T) {
 	tests := []struct {
 		name     string
-		operand  v1alpha1.OperandStatus
+		operand  v1alpha2.OperandStatus
 		expected operandStateClassification
 	}{
 		{
 			name: "Contains 'not found' - progressing",
-			operand: v1alpha1.OperandStatus{
-				Ready:   "false",
+			operand: v1alpha2.OperandStatus{
+				Ready:   false,
 				Message: "Resource not found in cluster",
 			},
 			expected: operandProgressing,
 		},
 		{
 			name: "Contains 'initial' - progressing",
-			operand: v1alpha1.OperandStatus{
-				Ready:   "false",
+			operand: v1alpha2.OperandStatus{
+				Ready:   false,
 				Message: "Initial setup in progress",
 			},
 			expected: operandProgressing,
 		},
 		{
 			name: "Contains 'reconciling' - progressing",
-			operand: v1alpha1.OperandStatus{
-				Ready:   "false",
+			operand: v1alpha2.OperandStatus{
+				Ready:   false,
 				Message: "Component is still reconciling",
 			},
 			expected: operandProgressing,
 		},
 		{
 			name: "Contains 'progressing' - progressing",
-			operand: v1alpha1.OperandStatus{
-				Ready:   "false",
+			operand: v1alpha2.OperandStatus{
+				Ready:   false,
 				Message: "Work is progressing",
 			},
 			expected: operandProgressing,
 		},
 		{
 			name: "Unknown message - failed",
-			operand: v1alpha1.OperandStatus{
-				Ready:   "false",
+			operand: v1alpha2.OperandStatus{
+				Ready:   false,
 				Message: "Some other error occurred",
 			},
 			expected: operandFailed,
@@ -431,15 +436,15 @@ func TestSetCreateOnlyModeCondition(t *testing.T) {
 func TestProcessOperandStatus(t *testing.T) {
 	tests := []struct {
 		name                     string
-		operand                  v1alpha1.OperandStatus
+		operand                  v1alpha2.OperandStatus
 		expectedAnyOperandExists bool
 		expectedNotCreatedCount  int
 		expectedFailedCount      int
 	}{
 		{
 			name: "Ready operand",
-			operand: v1alpha1.OperandStatus{
-				Ready:   "true",
+			operand: v1alpha2.OperandStatus{
+				Ready:   true,
 				Message: "Ready",
 			},
 			expectedAnyOperandExists: true,
@@ -448,8 +453,8 @@ func TestProcessOperandStatus(t *testing.T) {
 		},
 		{
 			name: "CR not found - progressing",
-			operand: v1alpha1.OperandStatus{
-				Ready:   "false",
+			operand: v1alpha2.OperandStatus{
+				Ready:   false,
 				Message: OperandMessageCRNotFound,
 			},
 			expectedAnyOperandExists: false,
@@ -458,8 +463,8 @@ func TestProcessOperandStatus(t *testing.T) {
 		},
 		{
 			name: "Reconciling - progressing",
-			operand: v1alpha1.OperandStatus{
-				Ready:   "false",
+			operand: v1alpha2.OperandStatus{
+				Ready:   false,
 				Message: OperandMessageReconciling,
 			},
 			expectedAnyOperandExists: true,
@@ -468,8 +473,8 @@ func TestProcessOperandStatus(t *testing.T) {
 		},
 		{
 			name: "Failed operand",
-			operand: v1alpha1.OperandStatus{
-				Ready:   "false",
+			operand: v1alpha2.OperandStatus{
+				Ready:   false,
 				Message: "Failed to start",
 			},
 			expectedAnyOperandExists: true,
@@ -533,21 +538,21 @@ func TestExtractKeyConditions(t *testing.T) {
 		{
 			name: "Not ready operand - include Ready condition",
 			conditions: []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionFalse, Reason: v1alpha1.ReasonFailed},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionFalse, Reason: v1alpha2.ReasonFailed},
 			},
 			isReady:       false,
 			expectedCount: 1,
-			expectedTypes: []string{v1alpha1.Ready},
+			expectedTypes: []string{v1alpha2.Ready},
 		},
 		{
 			name: "Not ready operand with failed condition - include both",
 			conditions: []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionFalse, Reason: v1alpha1.ReasonFailed},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionFalse, Reason: v1alpha2.ReasonFailed},
 				{Type: "SomeComponent", Status: metav1.ConditionFalse},
 			},
 			isReady:       false,
 			expectedCount: 2,
-			expectedTypes: []string{v1alpha1.Ready, "SomeComponent"},
+			expectedTypes: []string{v1alpha2.Ready, "SomeComponent"},
 		},
 	}
 
@@ -704,7 +709,7 @@ func TestUpdateOperatorCondition_NoOperatorCondition(t *testing.T) {
 	// Mock findOperatorCondition to return not found error
 	fakeClient.GetReturns(kerrors.NewNotFound(schema.GroupResource{}, "test"))
 
-	err := reconciler.updateOperatorCondition(context.Background(), false, []v1alpha1.OperandStatus{})
+	err := reconciler.updateOperatorCondition(context.Background(), false, []v1alpha2.OperandStatus{}, nil)
 
 	// Should return error when OperatorCondition not found
 	if err == nil {
@@ -722,7 +727,7 @@ func TestUpdateOperatorCondition_CreateOnlyModeEnabled(t *testing.T) {
 	// Mock successful StatusUpdateWithRetry
 	fakeClient.StatusUpdateWithRetryReturns(nil)
 
-	err := reconciler.updateOperatorCondition(context.Background(), true, []v1alpha1.OperandStatus{})
+	err := reconciler.updateOperatorCondition(context.Background(), true, []v1alpha2.OperandStatus{}, nil)
 
 	// Should not return error
 	if err != nil {
@@ -745,16 +750,16 @@ func TestUpdateOperatorCondition_OperandsNotReady(t *testing.T) {
 	// Mock successful StatusUpdateWithRetry
 	fakeClient.StatusUpdateWithRetryReturns(nil)
 
-	operandStatuses := []v1alpha1.OperandStatus{
+	operandStatuses := []v1alpha2.OperandStatus{
 		{
 			Kind:    "SpireServer",
 			Name:    "cluster",
-			Ready:   "false",
+			Ready:   false,
 			Message: "Failed to reconcile",
 		},
 	}
 
-	err := reconciler.updateOperatorCondition(context.Background(), false, operandStatuses)
+	err := reconciler.updateOperatorCondition(context.Background(), false, operandStatuses, nil)
 
 	// Should not return error
 	if err != nil {
@@ -772,22 +777,22 @@ func TestUpdateOperatorCondition_AllOperandsReady(t *testing.T) {
 	// Mock successful StatusUpdateWithRetry
 	fakeClient.StatusUpdateWithRetryReturns(nil)
 
-	operandStatuses := []v1alpha1.OperandStatus{
+	operandStatuses := []v1alpha2.OperandStatus{
 		{
 			Kind:    "SpireServer",
 			Name:    "cluster",
-			Ready:   "true",
+			Ready:   true,
 			Message: "Ready",
 		},
 		{
 			Kind:    "SpireAgent",
 			Name:    "cluster",
-			Ready:   "true",
+			Ready:   true,
 			Message: "Ready",
 		},
 	}
 
-	err := reconciler.updateOperatorCondition(context.Background(), false, operandStatuses)
+	err := reconciler.updateOperatorCondition(context.Background(), false, operandStatuses, nil)
 
 	// Should not return error
 	if err != nil {
@@ -805,7 +810,7 @@ func TestUpdateOperatorCondition_StatusUpdateError(t *testing.T) {
 	// Mock StatusUpdateWithRetry error
 	fakeClient.StatusUpdateWithRetryReturns(errors.New("status update failed"))
 
-	err := reconciler.updateOperatorCondition(context.Background(), false, []v1alpha1.OperandStatus{})
+	err := reconciler.updateOperatorCondition(context.Background(), false, []v1alpha2.OperandStatus{}, nil)
 
 	// Should return error
 	if err == nil {
@@ -824,16 +829,16 @@ func TestUpdateOperatorCondition_CRNotFoundExcluded(t *testing.T) {
 	fakeClient.StatusUpdateWithRetryReturns(nil)
 
 	// Operand is not ready but CR not found - should not block upgrade
-	operandStatuses := []v1alpha1.OperandStatus{
+	operandStatuses := []v1alpha2.OperandStatus{
 		{
 			Kind:    "SpireServer",
 			Name:    "cluster",
-			Ready:   "false",
+			Ready:   false,
 			Message: OperandMessageCRNotFound,
 		},
 	}
 
-	err := reconciler.updateOperatorCondition(context.Background(), false, operandStatuses)
+	err := reconciler.updateOperatorCondition(context.Background(), false, operandStatuses, nil)
 
 	// Should not return error
 	if err != nil {
@@ -845,57 +850,57 @@ func TestUpdateOperatorCondition_CRNotFoundExcluded(t *testing.T) {
 func TestUpdateOperatorCondition_MutationKillers(t *testing.T) {
 	tests := []struct {
 		name              string
-		operandStatuses   []v1alpha1.OperandStatus
+		operandStatuses   []v1alpha2.OperandStatus
 		expectUpgradeable bool
 	}{
 		{
 			name: "operand not ready with non-CRNotFound message blocks upgrade",
-			operandStatuses: []v1alpha1.OperandStatus{
-				{Kind: "SpireServer", Name: "cluster", Ready: "false", Message: "Failed to reconcile"},
+			operandStatuses: []v1alpha2.OperandStatus{
+				{Kind: "SpireServer", Name: "cluster", Ready: false, Message: "Failed to reconcile"},
 			},
 			expectUpgradeable: false,
 		},
 		{
 			name: "operand ready with any message allows upgrade",
-			operandStatuses: []v1alpha1.OperandStatus{
-				{Kind: "SpireServer", Name: "cluster", Ready: "true", Message: "Failed to reconcile"},
+			operandStatuses: []v1alpha2.OperandStatus{
+				{Kind: "SpireServer", Name: "cluster", Ready: true, Message: "Failed to reconcile"},
 			},
 			expectUpgradeable: true,
 		},
 		{
 			name: "operand not ready with CRNotFound message allows upgrade",
-			operandStatuses: []v1alpha1.OperandStatus{
-				{Kind: "SpireServer", Name: "cluster", Ready: "false", Message: OperandMessageCRNotFound},
+			operandStatuses: []v1alpha2.OperandStatus{
+				{Kind: "SpireServer", Name: "cluster", Ready: false, Message: OperandMessageCRNotFound},
 			},
 			expectUpgradeable: true,
 		},
 		{
 			name:              "empty operand list allows upgrade",
-			operandStatuses:   []v1alpha1.OperandStatus{},
+			operandStatuses:   []v1alpha2.OperandStatus{},
 			expectUpgradeable: true,
 		},
 		{
 			name: "one not ready operand blocks upgrade",
-			operandStatuses: []v1alpha1.OperandStatus{
-				{Kind: "SpireServer", Name: "cluster", Ready: "false", Message: "Reconciling"},
+			operandStatuses: []v1alpha2.OperandStatus{
+				{Kind: "SpireServer", Name: "cluster", Ready: false, Message: "Reconciling"},
 			},
 			expectUpgradeable: false,
 		},
 		{
 			// Multiple not ready operands
 			name: "multiple not ready operands block upgrade",
-			operandStatuses: []v1alpha1.OperandStatus{
-				{Kind: "SpireServer", Name: "cluster", Ready: "false", Message: "Reconciling"},
-				{Kind: "SpireAgent", Name: "cluster", Ready: "false", Message: "Unhealthy"},
+			operandStatuses: []v1alpha2.OperandStatus{
+				{Kind: "SpireServer", Name: "cluster", Ready: false, Message: "Reconciling"},
+				{Kind: "SpireAgent", Name: "cluster", Ready: false, Message: "Unhealthy"},
 			},
 			expectUpgradeable: false,
 		},
 		{
 			// Mix of ready and not ready (with CRNotFound)
 			name: "mix of ready and CRNotFound allows upgrade",
-			operandStatuses: []v1alpha1.OperandStatus{
-				{Kind: "SpireServer", Name: "cluster", Ready: "true", Message: "Ready"},
-				{Kind: "SpireAgent", Name: "cluster", Ready: "false", Message: OperandMessageCRNotFound},
+			operandStatuses: []v1alpha2.OperandStatus{
+				{Kind: "SpireServer", Name: "cluster", Ready: true, Message: "Ready"},
+				{Kind: "SpireAgent", Name: "cluster", Ready: false, Message: OperandMessageCRNotFound},
 			},
 			expectUpgradeable: true,
 		},
@@ -911,7 +916,7 @@ func TestUpdateOperatorCondition_MutationKillers(t *testing.T) {
 			// Mock successful StatusUpdateWithRetry
 			fakeClient.StatusUpdateWithRetryReturns(nil)
 
-			err := reconciler.updateOperatorCondition(context.Background(), false, tt.operandStatuses)
+			err := reconciler.updateOperatorCondition(context.Background(), false, tt.operandStatuses, nil)
 
 			if err != nil {
 				t.Errorf("Expected no error, got: %v", err)
@@ -925,6 +930,268 @@ func TestUpdateOperatorCondition_MutationKillers(t *testing.T) {
 	}
 }
 
+// TestUpdateOperatorCondition_PublishesAllConditions tests that Upgradeable, Degraded and
+// OperandsAvailable are all written to the OperatorCondition
+func TestUpdateOperatorCondition_PublishesAllConditions(t *testing.T) {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	reconciler := newTestReconciler(fakeClient)
+
+	fakeClient.GetReturns(nil)
+	fakeClient.StatusUpdateWithRetryReturns(nil)
+
+	operandStatuses := []v1alpha2.OperandStatus{
+		{Kind: "SpireServer", Name: "cluster", Ready: true, Message: "Ready"},
+		{Kind: "SpireAgent", Name: "cluster", Ready: false, Message: "DaemonSet unhealthy"},
+	}
+
+	if err := reconciler.updateOperatorCondition(context.Background(), false, operandStatuses, nil); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	_, obj, _ := fakeClient.StatusUpdateWithRetryArgsForCall(0)
+	operatorCondition, ok := obj.(*operatorv1.OperatorCondition)
+	if !ok {
+		t.Fatalf("Expected *OperatorCondition, got %T", obj)
+	}
+
+	expected := map[string]metav1.ConditionStatus{
+		v1alpha2.Upgradeable: metav1.ConditionFalse,
+		v1alpha2.Degraded:    metav1.ConditionTrue,
+		OperandsAvailable:    metav1.ConditionFalse,
+	}
+	for conditionType, expectedStatus := range expected {
+		condition := apimeta.FindStatusCondition(operatorCondition.Status.Conditions, conditionType)
+		if condition == nil {
+			t.Errorf("Expected %s condition to be set on OperatorCondition", conditionType)
+			continue
+		}
+		if condition.Status != expectedStatus {
+			t.Errorf("Expected %s status %s, got %s", conditionType, expectedStatus, condition.Status)
+		}
+	}
+}
+
+// TestBuildUpgradeableCondition_VersionSkew tests that operands in version skew block upgrades
+func TestBuildUpgradeableCondition_VersionSkew(t *testing.T) {
+	operandStatuses := []v1alpha2.OperandStatus{
+		{Kind: "SpireServer", Name: "cluster", Ready: true, Message: "Ready"},
+		{Kind: "SpireAgent", Name: "cluster", Ready: true, Message: "Ready"},
+	}
+	versionStatuses := []status.OperandVersionStatus{
+		{Kind: "SpireServer", Deployed: true, SpecUpToDate: true, RolledOut: true},
+		{Kind: "SpireAgent", Deployed: true, CurrentVersion: "0.0.1", ExpectedVersion: "0.0.2"},
+	}
+
+	condition := buildUpgradeableCondition(false, operandStatuses, versionStatuses)
+	if condition.Status != metav1.ConditionFalse {
+		t.Errorf("Expected Upgradeable False during version skew, got %s", condition.Status)
+	}
+	if condition.Reason != utils.UpgradeReasonOperandVersionSkew {
+		t.Errorf("Expected reason %s, got %s", utils.UpgradeReasonOperandVersionSkew, condition.Reason)
+	}
+
+	versionStatuses[1].SpecUpToDate = true
+	condition = buildUpgradeableCondition(false, operandStatuses, versionStatuses)
+	if condition.Status != metav1.ConditionTrue {
+		t.Errorf("Expected Upgradeable True without version skew, got %s", condition.Status)
+	}
+}
+
+// TestCheckOperandVersionSkew tests the UpgradeInProgress condition set on the ZTWIM CR
+func TestCheckOperandVersionSkew(t *testing.T) {
+	t.Setenv(utils.SpireAgentImageEnv, "agent:new")
+
+	tests := []struct {
+		name           string
+		agentImage     string
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "agent running old image",
+			agentImage:     "agent:old",
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: utils.UpgradeReasonOperandVersionSkew,
+		},
+		{
+			name:           "agent running expected image",
+			agentImage:     "agent:new",
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: utils.UpgradeReasonComplete,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			reconciler := newTestReconciler(fakeClient)
+			fakeClient.ListStub = func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+				listOpts := &client.ListOptions{}
+				listOpts.ApplyOptions(opts)
+				if dsList, ok := list.(*appsv1.DaemonSetList); ok && listOpts.LabelSelector.Matches(labels.Set(utils.SpireAgentLabels(nil))) {
+					ds := appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "spire-agent"}}
+					ds.Spec.Template.Spec.Containers = []corev1.Container{{Name: "spire-agent", Image: tt.agentImage}}
+					dsList.Items = []appsv1.DaemonSet{ds}
+				}
+				return nil
+			}
+
+			statusMgr := status.NewManager(fakeClient)
+			versionStatuses := reconciler.checkOperandVersionSkew(context.Background(), statusMgr)
+			if len(versionStatuses) != len(status.OperandUpgradeOrder) {
+				t.Fatalf("Expected %d version statuses, got %d", len(status.OperandUpgradeOrder), len(versionStatuses))
+			}
+
+			ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{}
+			if err := statusMgr.ApplyStatus(context.Background(), ztwim, func() *v1alpha2.ConditionalStatus {
+				return &ztwim.Status.ConditionalStatus
+			}); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			condition := apimeta.FindStatusCondition(ztwim.Status.Conditions, utils.UpgradeInProgressStatusType)
+			if condition == nil {
+				t.Fatal("Expected UpgradeInProgress condition to be set")
+			}
+			if condition.Status != tt.expectedStatus || condition.Reason != tt.expectedReason {
+				t.Errorf("Expected %s/%s, got %s/%s", tt.expectedStatus, tt.expectedReason, condition.Status, condition.Reason)
+			}
+		})
+	}
+}
+
+// TestBuildDegradedCondition tests the Degraded condition published to the OperatorCondition
+func TestBuildDegradedCondition(t *testing.T) {
+	tests := []struct {
+		name            string
+		operandStatuses []v1alpha2.OperandStatus
+		expectedStatus  metav1.ConditionStatus
+		expectedReason  string
+	}{
+		{
+			name:            "no operands",
+			operandStatuses: []v1alpha2.OperandStatus{},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1alpha2.ReasonReady,
+		},
+		{
+			name: "all operands ready",
+			operandStatuses: []v1alpha2.OperandStatus{
+				{Kind: "SpireServer", Ready: true, Message: "Ready"},
+				{Kind: "SpireAgent", Ready: true, Message: "Ready"},
+			},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: v1alpha2.ReasonReady,
+		},
+		{
+			name: "progressing operands are not degraded",
+			operandStatuses: []v1alpha2.OperandStatus{
+				{Kind: "SpireServer", Ready: false, Message: OperandMessageCRNotFound},
+				{Kind: "SpireAgent", Ready: false, Message: OperandMessageReconciling},
+			},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: v1alpha2.ReasonReady,
+		},
+		{
+			name: "failed operand is degraded",
+			operandStatuses: []v1alpha2.OperandStatus{
+				{Kind: "SpireServer", Ready: true, Message: "Ready"},
+				{
+					Kind:    "SpireAgent",
+					Ready:   false,
+					Message: "DaemonSet unhealthy",
+					Conditions: []metav1.Condition{
+						{Type: v1alpha2.Ready, Status: metav1.ConditionFalse, Reason: v1alpha2.ReasonFailed},
+					},
+				},
+			},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: v1alpha2.ReasonFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := buildDegradedCondition(tt.operandStatuses)
+			if condition.Type != v1alpha2.Degraded {
+				t.Errorf("Expected condition type %s, got %s", v1alpha2.Degraded, condition.Type)
+			}
+			if condition.Status != tt.expectedStatus {
+				t.Errorf("Expected status %s, got %s", tt.expectedStatus, condition.Status)
+			}
+			if condition.Reason != tt.expectedReason {
+				t.Errorf("Expected reason %s, got %s", tt.expectedReason, condition.Reason)
+			}
+		})
+	}
+}
+
+// TestBuildOperandsSummaryCondition tests the component summary published to the OperatorCondition
+func TestBuildOperandsSummaryCondition(t *testing.T) {
+	tests := []struct {
+		name            string
+		operandStatuses []v1alpha2.OperandStatus
+		expectedStatus  metav1.ConditionStatus
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name: "all operands ready",
+			operandStatuses: []v1alpha2.OperandStatus{
+				{Kind: "SpireServer", Ready: true, Message: "Ready"},
+				{Kind: "SpireAgent", Ready: true, Message: "Ready"},
+			},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  v1alpha2.ReasonReady,
+			expectedMessage: "SpireServer: Ready; SpireAgent: Ready",
+		},
+		{
+			name: "operand not created is progressing",
+			operandStatuses: []v1alpha2.OperandStatus{
+				{Kind: "SpireServer", Ready: true, Message: "Ready"},
+				{Kind: "SpireAgent", Ready: false, Message: OperandMessageCRNotFound},
+			},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1alpha2.ReasonInProgress,
+			expectedMessage: "SpireServer: Ready; SpireAgent: CR not found",
+		},
+		{
+			name: "failed operand takes precedence over progressing",
+			operandStatuses: []v1alpha2.OperandStatus{
+				{Kind: "SpireServer", Ready: false, Message: "StatefulSet unhealthy"},
+				{Kind: "SpireAgent", Ready: false, Message: OperandMessageCRNotFound},
+			},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1alpha2.ReasonFailed,
+			expectedMessage: "SpireServer: StatefulSet unhealthy; SpireAgent: CR not found",
+		},
+		{
+			name:            "no operands",
+			operandStatuses: []v1alpha2.OperandStatus{},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  v1alpha2.ReasonReady,
+			expectedMessage: "No operands are deployed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := buildOperandsSummaryCondition(tt.operandStatuses)
+			if condition.Type != OperandsAvailable {
+				t.Errorf("Expected condition type %s, got %s", OperandsAvailable, condition.Type)
+			}
+			if condition.Status != tt.expectedStatus {
+				t.Errorf("Expected status %s, got %s", tt.expectedStatus, condition.Status)
+			}
+			if condition.Reason != tt.expectedReason {
+				t.Errorf("Expected reason %s, got %s", tt.expectedReason, condition.Reason)
+			}
+			if condition.Message != tt.expectedMessage {
+				t.Errorf("Expected message %q, got %q", tt.expectedMessage, condition.Message)
+			}
+		})
+	}
+}
+
 // TestOperandAggregateState tests operandAggregateState fields
 func TestOperandAggregateState(t *testing.T) {
 	state := &operandAggregateState{
@@ -962,8 +1229,8 @@ func TestOperandAggregateState(t *testing.T) {
 
 // TestOperandAggregateResult tests operandAggregateResult fields
 func TestOperandAggregateResult(t *testing.T) {
-	operandStatuses := []v1alpha1.OperandStatus{
-		{Kind: "SpireServer", Name: "cluster", Ready: "true"},
+	operandStatuses := []v1alpha2.OperandStatus{
+		{Kind: "SpireServer", Name: "cluster", Ready: true},
 	}
 
 	result := operandAggregateResult{
@@ -1007,29 +1274,29 @@ func TestOperandStateClassification(t *testing.T) {
 func TestClassifyOperandState_NilCondition(t *testing.T) {
 	tests := []struct {
 		name     string
-		operand  v1alpha1.OperandStatus
+		operand  v1alpha2.OperandStatus
 		expected operandStateClassification
 	}{
 		{
 			name: "Ready operand with nil condition",
-			operand: v1alpha1.OperandStatus{
-				Ready:   "true",
+			operand: v1alpha2.OperandStatus{
+				Ready:   true,
 				Message: "Ready",
 			},
 			expected: operandReady,
 		},
 		{
 			name: "Not ready with CR not found message",
-			operand: v1alpha1.OperandStatus{
-				Ready:   "false",
+			operand: v1alpha2.OperandStatus{
+				Ready:   false,
 				Message: OperandMessageCRNotFound,
 			},
 			expected: operandProgressing,
 		},
 		{
 			name: "Not ready with unknown message",
-			operand: v1alpha1.OperandStatus{
-				Ready:   "false",
+			operand: v1alpha2.OperandStatus{
+				Ready:   false,
 				Message: "Unknown error",
 			},
 			expected: operandFailed,
@@ -1050,14 +1317,14 @@ func TestClassifyOperandState_NilCondition(t *testing.T) {
 // When operand.Ready is "false" but condition.Reason is ReasonReady, the function
 // returns operandReady because ReasonReady in the switch statement returns operandReady
 func TestClassifyOperandState_ReasonReady(t *testing.T) {
-	operand := v1alpha1.OperandStatus{
-		Ready:   "false",
+	operand := v1alpha2.OperandStatus{
+		Ready:   false,
 		Message: "Ready",
 	}
 	condition := &metav1.Condition{
-		Type:   v1alpha1.Ready,
+		Type:   v1alpha2.Ready,
 		Status: metav1.ConditionTrue,
-		Reason: v1alpha1.ReasonReady,
+		Reason: v1alpha2.ReasonReady,
 	}
 
 	result := classifyOperandState(operand, condition)
@@ -1072,8 +1339,8 @@ func TestClassifyOperandState_ReasonReady(t *testing.T) {
 
 // TestProcessOperandStatus_NotReady tests processOperandStatus with not ready operand
 func TestProcessOperandStatus_NotReady(t *testing.T) {
-	operand := v1alpha1.OperandStatus{
-		Ready:   "false",
+	operand := v1alpha2.OperandStatus{
+		Ready:   false,
 		Message: "Some error",
 	}
 
@@ -1088,7 +1355,7 @@ func TestProcessOperandStatus_NotReady(t *testing.T) {
 // TestExtractKeyConditions_MultipleConditions tests extractKeyConditions with multiple conditions
 func TestExtractKeyConditions_MultipleConditions(t *testing.T) {
 	conditions := []metav1.Condition{
-		{Type: v1alpha1.Ready, Status: metav1.ConditionFalse, Reason: v1alpha1.ReasonFailed},
+		{Type: v1alpha2.Ready, Status: metav1.ConditionFalse, Reason: v1alpha2.ReasonFailed},
 		{Type: utils.CreateOnlyModeStatusType, Status: metav1.ConditionTrue},
 		{Type: "ServiceAvailable", Status: metav1.ConditionFalse},
 		{Type: "ConfigMapAvailable", Status: metav1.ConditionTrue}, // Should be excluded (not failed)
@@ -1105,7 +1372,7 @@ func TestExtractKeyConditions_MultipleConditions(t *testing.T) {
 // TestExtractKeyConditions_OnlyCreateOnlyMode tests extractKeyConditions returns only CreateOnlyMode when ready
 func TestExtractKeyConditions_OnlyCreateOnlyMode(t *testing.T) {
 	conditions := []metav1.Condition{
-		{Type: v1alpha1.Ready, Status: metav1.ConditionTrue},
+		{Type: v1alpha2.Ready, Status: metav1.ConditionTrue},
 		{Type: utils.CreateOnlyModeStatusType, Status: metav1.ConditionTrue},
 		{Type: "ServiceAvailable", Status: metav1.ConditionTrue},
 	}
@@ -1123,11 +1390,11 @@ func TestExtractKeyConditions_OnlyCreateOnlyMode(t *testing.T) {
 
 // TestHandleNonExistentCR_AllOperandsNotFound tests logic when all operands are not found
 func TestHandleNonExistentCR_AllOperandsNotFound(t *testing.T) {
-	operandStatuses := []v1alpha1.OperandStatus{
-		{Kind: "SpireServer", Name: "cluster", Ready: "false", Message: OperandMessageCRNotFound},
-		{Kind: "SpireAgent", Name: "cluster", Ready: "false", Message: OperandMessageCRNotFound},
-		{Kind: "SpiffeCSIDriver", Name: "cluster", Ready: "false", Message: OperandMessageCRNotFound},
-		{Kind: "SpireOIDCDiscoveryProvider", Name: "cluster", Ready: "false", Message: OperandMessageCRNotFound},
+	operandStatuses := []v1alpha2.OperandStatus{
+		{Kind: "SpireServer", Name: "cluster", Ready: false, Message: OperandMessageCRNotFound},
+		{Kind: "SpireAgent", Name: "cluster", Ready: false, Message: OperandMessageCRNotFound},
+		{Kind: "SpiffeCSIDriver", Name: "cluster", Ready: false, Message: OperandMessageCRNotFound},
+		{Kind: "SpireOIDCDiscoveryProvider", Name: "cluster", Ready: false, Message: OperandMessageCRNotFound},
 	}
 
 	result := operandAggregateResult{
@@ -1149,9 +1416,9 @@ func TestHandleNonExistentCR_AllOperandsNotFound(t *testing.T) {
 
 // TestHandleNonExistentCR_SomeOperandsExist tests logic when some operands exist
 func TestHandleNonExistentCR_SomeOperandsExist(t *testing.T) {
-	operandStatuses := []v1alpha1.OperandStatus{
-		{Kind: "SpireServer", Name: "cluster", Ready: "true", Message: "Ready"},
-		{Kind: "SpireAgent", Name: "cluster", Ready: "false", Message: OperandMessageCRNotFound},
+	operandStatuses := []v1alpha2.OperandStatus{
+		{Kind: "SpireServer", Name: "cluster", Ready: true, Message: "Ready"},
+		{Kind: "SpireAgent", Name: "cluster", Ready: false, Message: OperandMessageCRNotFound},
 	}
 
 	state := &operandAggregateState{allReady: true}
@@ -1167,9 +1434,9 @@ func TestHandleNonExistentCR_SomeOperandsExist(t *testing.T) {
 
 // TestHandleNonExistentCR_AllOperandsReady tests logic when all operands are ready
 func TestHandleNonExistentCR_AllOperandsReady(t *testing.T) {
-	operandStatuses := []v1alpha1.OperandStatus{
-		{Kind: "SpireServer", Name: "cluster", Ready: "true", Message: "Ready"},
-		{Kind: "SpireAgent", Name: "cluster", Ready: "true", Message: "Ready"},
+	operandStatuses := []v1alpha2.OperandStatus{
+		{Kind: "SpireServer", Name: "cluster", Ready: true, Message: "Ready"},
+		{Kind: "SpireAgent", Name: "cluster", Ready: true, Message: "Ready"},
 	}
 
 	state := &operandAggregateState{allReady: true}
@@ -1229,9 +1496,9 @@ func TestOperandsAvailableCondition_SomeFailed(t *testing.T) {
 		notCreatedCount:  0,
 		failedCount:      2, // Some operands failed
 		anyOperandExists: true,
-		operandStatuses: []v1alpha1.OperandStatus{
-			{Kind: "SpireServer", Name: "cluster", Ready: "false", Message: "Failed"},
-			{Kind: "SpireAgent", Name: "cluster", Ready: "false", Message: "Failed"},
+		operandStatuses: []v1alpha2.OperandStatus{
+			{Kind: "SpireServer", Name: "cluster", Ready: false, Message: "Failed"},
+			{Kind: "SpireAgent", Name: "cluster", Ready: false, Message: "Failed"},
 		},
 	}
 
@@ -1251,9 +1518,9 @@ func TestOperandsAvailableCondition_MixedState(t *testing.T) {
 		notCreatedCount:  1, // Some progressing
 		failedCount:      1, // Some failed
 		anyOperandExists: true,
-		operandStatuses: []v1alpha1.OperandStatus{
-			{Kind: "SpireServer", Name: "cluster", Ready: "false", Message: "Failed"},
-			{Kind: "SpireAgent", Name: "cluster", Ready: "false", Message: OperandMessageCRNotFound},
+		operandStatuses: []v1alpha2.OperandStatus{
+			{Kind: "SpireServer", Name: "cluster", Ready: false, Message: "Failed"},
+			{Kind: "SpireAgent", Name: "cluster", Ready: false, Message: OperandMessageCRNotFound},
 		},
 	}
 
@@ -1284,38 +1551,38 @@ func TestNewReconciler_Initialization(t *testing.T) {
 
 // TestBuildOperandStatus_Ready tests buildOperandStatus for ready operand
 func TestBuildOperandStatus_Ready(t *testing.T) {
-	status := v1alpha1.OperandStatus{
+	status := v1alpha2.OperandStatus{
 		Kind:    "SpireServer",
 		Name:    "cluster",
-		Ready:   "true",
+		Ready:   true,
 		Message: "Ready",
 		Conditions: []metav1.Condition{
-			{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+			{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 		},
 	}
 
 	if status.Kind != "SpireServer" {
 		t.Errorf("Expected Kind SpireServer, got %s", status.Kind)
 	}
-	if status.Ready != "true" {
-		t.Errorf("Expected Ready true, got %s", status.Ready)
+	if !status.Ready {
+		t.Errorf("Expected Ready true, got %t", status.Ready)
 	}
 }
 
 // TestBuildOperandStatus_NotReady tests buildOperandStatus for not ready operand
 func TestBuildOperandStatus_NotReady(t *testing.T) {
-	status := v1alpha1.OperandStatus{
+	status := v1alpha2.OperandStatus{
 		Kind:    "SpireAgent",
 		Name:    "cluster",
-		Ready:   "false",
+		Ready:   false,
 		Message: "Component unhealthy",
 		Conditions: []metav1.Condition{
-			{Type: v1alpha1.Ready, Status: metav1.ConditionFalse, Reason: v1alpha1.ReasonFailed},
+			{Type: v1alpha2.Ready, Status: metav1.ConditionFalse, Reason: v1alpha2.ReasonFailed},
 		},
 	}
 
-	if status.Ready != "false" {
-		t.Errorf("Expected Ready false, got %s", status.Ready)
+	if status.Ready {
+		t.Errorf("Expected Ready false, got %t", status.Ready)
 	}
 	if status.Message != "Component unhealthy" {
 		t.Errorf("Expected message 'Component unhealthy', got %s", status.Message)
@@ -1324,10 +1591,10 @@ func TestBuildOperandStatus_NotReady(t *testing.T) {
 
 // TestGetOperandStatusFromCRNotFound tests getOperandStatus behavior when CR not found
 func TestGetOperandStatusFromCRNotFound(t *testing.T) {
-	operand := v1alpha1.OperandStatus{
+	operand := v1alpha2.OperandStatus{
 		Kind:    "SpireServer",
 		Name:    "cluster",
-		Ready:   "false",
+		Ready:   false,
 		Message: OperandMessageCRNotFound,
 	}
 
@@ -1349,19 +1616,19 @@ func TestGetOperandStatus_AllConditionStates(t *testing.T) {
 		{
 			name: "Ready condition is True",
 			serverConditions: []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady, Message: "Ready"},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady, Message: "Ready"},
 			},
 		},
 		{
 			name: "Ready condition is False",
 			serverConditions: []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionFalse, Reason: v1alpha1.ReasonFailed, Message: "Failed"},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionFalse, Reason: v1alpha2.ReasonFailed, Message: "Failed"},
 			},
 		},
 		{
 			name: "Ready condition is Unknown",
 			serverConditions: []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionUnknown, Reason: "Unknown", Message: "Unknown state"},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionUnknown, Reason: "Unknown", Message: "Unknown state"},
 			},
 		},
 		{
@@ -1376,7 +1643,7 @@ func TestGetOperandStatus_AllConditionStates(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 
 			reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 				ctrlClient:            fakeClient,
@@ -1389,10 +1656,10 @@ func TestGetOperandStatus_AllConditionStates(t *testing.T) {
 
 			fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 				switch o := obj.(type) {
-				case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+				case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 					o.Name = "cluster"
 					o.Spec.TrustDomain = "example.org"
-				case *v1alpha1.SpireServer:
+				case *v1alpha2.SpireServer:
 					o.Name = "cluster"
 					o.Status.ConditionalStatus.Conditions = tt.serverConditions
 				default:
@@ -1448,7 +1715,7 @@ func TestExtractKeyConditions_AllStates(t *testing.T) {
 		{
 			name: "not ready with nil create-only condition",
 			conditions: []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionFalse},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionFalse},
 			},
 			isReady:     false,
 			expectCount: 1, // Just Ready condition
@@ -1457,7 +1724,7 @@ func TestExtractKeyConditions_AllStates(t *testing.T) {
 			name: "not ready with create-only True",
 			conditions: []metav1.Condition{
 				{Type: utils.CreateOnlyModeStatusType, Status: metav1.ConditionTrue},
-				{Type: v1alpha1.Ready, Status: metav1.ConditionFalse},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionFalse},
 			},
 			isReady:     false,
 			expectCount: 2, // CreateOnly + Ready
@@ -1478,11 +1745,11 @@ func TestExtractKeyConditions_AllStates(t *testing.T) {
 // TestAggregateOperandStatus_Integration tests the full aggregation flow
 func TestAggregateOperandStatus_Integration(t *testing.T) {
 	// Create operand statuses representing various states
-	operandStatuses := []v1alpha1.OperandStatus{
-		{Kind: "SpireServer", Name: "cluster", Ready: "true", Message: "Ready"},
-		{Kind: "SpireAgent", Name: "cluster", Ready: "false", Message: OperandMessageCRNotFound},
-		{Kind: "SpiffeCSIDriver", Name: "cluster", Ready: "true", Message: "Ready"},
-		{Kind: "SpireOIDCDiscoveryProvider", Name: "cluster", Ready: "false", Message: "Failed"},
+	operandStatuses := []v1alpha2.OperandStatus{
+		{Kind: "SpireServer", Name: "cluster", Ready: true, Message: "Ready"},
+		{Kind: "SpireAgent", Name: "cluster", Ready: false, Message: OperandMessageCRNotFound},
+		{Kind: "SpiffeCSIDriver", Name: "cluster", Ready: true, Message: "Ready"},
+		{Kind: "SpireOIDCDiscoveryProvider", Name: "cluster", Ready: false, Message: "Failed"},
 	}
 
 	state := &operandAggregateState{allReady: true}
@@ -1524,12 +1791,12 @@ func TestReconcile_SuccessfulReconciliation(t *testing.T) {
 
 // TestClassifyOperandState_UnknownReason tests classifyOperandState with unknown reason
 func TestClassifyOperandState_UnknownReason(t *testing.T) {
-	operand := v1alpha1.OperandStatus{
-		Ready:   "false",
+	operand := v1alpha2.OperandStatus{
+		Ready:   false,
 		Message: "Unknown state",
 	}
 	condition := &metav1.Condition{
-		Type:   v1alpha1.Ready,
+		Type:   v1alpha2.Ready,
 		Status: metav1.ConditionFalse,
 		Reason: "UnknownReason",
 	}
@@ -1546,7 +1813,7 @@ func TestClassifyOperandState_UnknownReason(t *testing.T) {
 func TestAggregateOperandStatus_AllNotFound(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 		ctrlClient:            fakeClient,
@@ -1588,11 +1855,55 @@ func TestAggregateOperandStatus_AllNotFound(t *testing.T) {
 	}
 }
 
+// TestAggregateOperandStatus_AgentPools tests every SpireAgent pool is aggregated after the default one
+func TestAggregateOperandStatus_AgentPools(t *testing.T) {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	reconciler := newTestReconciler(fakeClient)
+
+	fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+		if agents, ok := list.(*v1alpha2.SpireAgentList); ok {
+			agents.Items = []v1alpha2.SpireAgent{
+				{ObjectMeta: metav1.ObjectMeta{Name: "gpu"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
+			}
+		}
+		return nil
+	}
+	fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		agent, ok := obj.(*v1alpha2.SpireAgent)
+		if !ok {
+			return nil
+		}
+		readyStatus := metav1.ConditionTrue
+		if key.Name == "gpu" {
+			readyStatus = metav1.ConditionFalse
+		}
+		agent.Status.Conditions = []metav1.Condition{{Type: v1alpha2.Ready, Status: readyStatus, Reason: v1alpha2.ReasonFailed, Message: "DaemonSet not available"}}
+		return nil
+	}
+
+	result := reconciler.aggregateOperandStatus(context.Background())
+
+	if len(result.operandStatuses) != 5 {
+		t.Fatalf("Expected 5 operand statuses, got %d", len(result.operandStatuses))
+	}
+	agentPool := result.operandStatuses[2]
+	if agentPool.Kind != "SpireAgent" || agentPool.Name != "gpu" || agentPool.Ready {
+		t.Errorf("Expected the not ready gpu SpireAgent pool after the default one, got %+v", agentPool)
+	}
+	if result.allReady || result.failedCount != 1 {
+		t.Errorf("Expected the gpu pool to fail the aggregate, got allReady %v and %d failed", result.allReady, result.failedCount)
+	}
+	if name := operandDisplayName(agentPool); name != "SpireAgent/gpu" {
+		t.Errorf("Expected the pool to be named SpireAgent/gpu in messages, got %s", name)
+	}
+}
+
 // TestAggregateOperandStatus_AllReady tests aggregateOperandStatus when all CRs are ready
 func TestAggregateOperandStatus_AllReady(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 		ctrlClient:            fakeClient,
@@ -1609,25 +1920,25 @@ func TestAggregateOperandStatus_AllReady(t *testing.T) {
 		getCallCount++
 
 		switch cr := obj.(type) {
-		case *v1alpha1.SpireServer:
+		case *v1alpha2.SpireServer:
 			cr.Name = "cluster"
 			cr.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 			}
-		case *v1alpha1.SpireAgent:
+		case *v1alpha2.SpireAgent:
 			cr.Name = "cluster"
 			cr.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 			}
-		case *v1alpha1.SpiffeCSIDriver:
+		case *v1alpha2.SpiffeCSIDriver:
 			cr.Name = "cluster"
 			cr.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 			}
-		case *v1alpha1.SpireOIDCDiscoveryProvider:
+		case *v1alpha2.SpireOIDCDiscoveryProvider:
 			cr.Name = "cluster"
 			cr.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 			}
 		}
 		return nil
@@ -1658,7 +1969,7 @@ func TestAggregateOperandStatus_AllReady(t *testing.T) {
 func TestAggregateOperandStatus_MixedStates(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 		ctrlClient:            fakeClient,
@@ -1675,22 +1986,22 @@ func TestAggregateOperandStatus_MixedStates(t *testing.T) {
 		getCallCount++
 
 		switch cr := obj.(type) {
-		case *v1alpha1.SpireServer:
+		case *v1alpha2.SpireServer:
 			// Ready
 			cr.Name = "cluster"
 			cr.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 			}
-		case *v1alpha1.SpireAgent:
+		case *v1alpha2.SpireAgent:
 			// Not found
 			return kerrors.NewNotFound(schema.GroupResource{}, "cluster")
-		case *v1alpha1.SpiffeCSIDriver:
+		case *v1alpha2.SpiffeCSIDriver:
 			// Failed
 			cr.Name = "cluster"
 			cr.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionFalse, Reason: v1alpha1.ReasonFailed, Message: "Failed"},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionFalse, Reason: v1alpha2.ReasonFailed, Message: "Failed"},
 			}
-		case *v1alpha1.SpireOIDCDiscoveryProvider:
+		case *v1alpha2.SpireOIDCDiscoveryProvider:
 			// Reconciling (no conditions)
 			cr.Name = "cluster"
 			cr.Status.ConditionalStatus.Conditions = []metav1.Condition{}
@@ -1723,7 +2034,7 @@ func TestAggregateOperandStatus_MixedStates(t *testing.T) {
 func TestAggregateOperandStatus_WithCreateOnlyMode(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 		ctrlClient:            fakeClient,
@@ -1737,26 +2048,26 @@ func TestAggregateOperandStatus_WithCreateOnlyMode(t *testing.T) {
 	// Return CRs with CreateOnlyMode condition
 	fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 		switch cr := obj.(type) {
-		case *v1alpha1.SpireServer:
+		case *v1alpha2.SpireServer:
 			cr.Name = "cluster"
 			cr.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 				{Type: utils.CreateOnlyModeStatusType, Status: metav1.ConditionTrue},
 			}
-		case *v1alpha1.SpireAgent:
+		case *v1alpha2.SpireAgent:
 			cr.Name = "cluster"
 			cr.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 			}
-		case *v1alpha1.SpiffeCSIDriver:
+		case *v1alpha2.SpiffeCSIDriver:
 			cr.Name = "cluster"
 			cr.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 			}
-		case *v1alpha1.SpireOIDCDiscoveryProvider:
+		case *v1alpha2.SpireOIDCDiscoveryProvider:
 			cr.Name = "cluster"
 			cr.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 			}
 		}
 		return nil
@@ -1780,7 +2091,7 @@ func TestAggregateOperandStatus_WithCreateOnlyMode(t *testing.T) {
 func TestGetSpireServerStatus_Ready(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 		ctrlClient:            fakeClient,
@@ -1792,10 +2103,10 @@ func TestGetSpireServerStatus_Ready(t *testing.T) {
 	}
 
 	fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
-		if cr, ok := obj.(*v1alpha1.SpireServer); ok {
+		if cr, ok := obj.(*v1alpha2.SpireServer); ok {
 			cr.Name = "cluster"
 			cr.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 			}
 		}
 		return nil
@@ -1806,8 +2117,8 @@ func TestGetSpireServerStatus_Ready(t *testing.T) {
 	if status.Kind != "SpireServer" {
 		t.Errorf("Expected kind SpireServer, got %s", status.Kind)
 	}
-	if status.Ready != "true" {
-		t.Errorf("Expected ready true, got %s", status.Ready)
+	if !status.Ready {
+		t.Errorf("Expected ready true, got %t", status.Ready)
 	}
 }
 
@@ -1815,7 +2126,7 @@ func TestGetSpireServerStatus_Ready(t *testing.T) {
 func TestGetSpireServerStatus_NotFound(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 		ctrlClient:            fakeClient,
@@ -1833,8 +2144,8 @@ func TestGetSpireServerStatus_NotFound(t *testing.T) {
 	if status.Kind != "SpireServer" {
 		t.Errorf("Expected kind SpireServer, got %s", status.Kind)
 	}
-	if status.Ready != "false" {
-		t.Errorf("Expected ready false, got %s", status.Ready)
+	if status.Ready {
+		t.Errorf("Expected ready false, got %t", status.Ready)
 	}
 	if status.Message != OperandMessageCRNotFound {
 		t.Errorf("Expected message %s, got %s", OperandMessageCRNotFound, status.Message)
@@ -1845,7 +2156,7 @@ func TestGetSpireServerStatus_NotFound(t *testing.T) {
 func TestGetSpireServerStatus_GetError(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 		ctrlClient:            fakeClient,
@@ -1860,8 +2171,8 @@ func TestGetSpireServerStatus_GetError(t *testing.T) {
 
 	status := reconciler.getSpireServerStatus(context.Background())
 
-	if status.Ready != "false" {
-		t.Errorf("Expected ready false, got %s", status.Ready)
+	if status.Ready {
+		t.Errorf("Expected ready false, got %t", status.Ready)
 	}
 	if status.Message == "" {
 		t.Error("Expected error message, got empty")
@@ -1872,7 +2183,7 @@ func TestGetSpireServerStatus_GetError(t *testing.T) {
 func TestGetSpireServerStatus_NoConditions(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 		ctrlClient:            fakeClient,
@@ -1884,7 +2195,7 @@ func TestGetSpireServerStatus_NoConditions(t *testing.T) {
 	}
 
 	fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
-		if cr, ok := obj.(*v1alpha1.SpireServer); ok {
+		if cr, ok := obj.(*v1alpha2.SpireServer); ok {
 			cr.Name = "cluster"
 			cr.Status.ConditionalStatus.Conditions = []metav1.Condition{} // No conditions
 		}
@@ -1893,8 +2204,8 @@ func TestGetSpireServerStatus_NoConditions(t *testing.T) {
 
 	status := reconciler.getSpireServerStatus(context.Background())
 
-	if status.Ready != "false" {
-		t.Errorf("Expected ready false, got %s", status.Ready)
+	if status.Ready {
+		t.Errorf("Expected ready false, got %t", status.Ready)
 	}
 	if status.Message != OperandMessageWaitingInitialRecon {
 		t.Errorf("Expected message %s, got %s", OperandMessageWaitingInitialRecon, status.Message)
@@ -1905,7 +2216,7 @@ func TestGetSpireServerStatus_NoConditions(t *testing.T) {
 func TestGetSpireAgentStatus_Ready(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 		ctrlClient:            fakeClient,
@@ -1917,10 +2228,10 @@ func TestGetSpireAgentStatus_Ready(t *testing.T) {
 	}
 
 	fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
-		if cr, ok := obj.(*v1alpha1.SpireAgent); ok {
+		if cr, ok := obj.(*v1alpha2.SpireAgent); ok {
 			cr.Name = "cluster"
 			cr.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 			}
 		}
 		return nil
@@ -1931,8 +2242,8 @@ func TestGetSpireAgentStatus_Ready(t *testing.T) {
 	if status.Kind != "SpireAgent" {
 		t.Errorf("Expected kind SpireAgent, got %s", status.Kind)
 	}
-	if status.Ready != "true" {
-		t.Errorf("Expected ready true, got %s", status.Ready)
+	if !status.Ready {
+		t.Errorf("Expected ready true, got %t", status.Ready)
 	}
 }
 
@@ -1940,7 +2251,7 @@ func TestGetSpireAgentStatus_Ready(t *testing.T) {
 func TestGetSpiffeCSIDriverStatus_Ready(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 		ctrlClient:            fakeClient,
@@ -1952,10 +2263,10 @@ func TestGetSpiffeCSIDriverStatus_Ready(t *testing.T) {
 	}
 
 	fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
-		if cr, ok := obj.(*v1alpha1.SpiffeCSIDriver); ok {
+		if cr, ok := obj.(*v1alpha2.SpiffeCSIDriver); ok {
 			cr.Name = "cluster"
 			cr.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 			}
 		}
 		return nil
@@ -1966,8 +2277,8 @@ func TestGetSpiffeCSIDriverStatus_Ready(t *testing.T) {
 	if status.Kind != "SpiffeCSIDriver" {
 		t.Errorf("Expected kind SpiffeCSIDriver, got %s", status.Kind)
 	}
-	if status.Ready != "true" {
-		t.Errorf("Expected ready true, got %s", status.Ready)
+	if !status.Ready {
+		t.Errorf("Expected ready true, got %t", status.Ready)
 	}
 }
 
@@ -1975,7 +2286,7 @@ func TestGetSpiffeCSIDriverStatus_Ready(t *testing.T) {
 func TestGetSpireOIDCDiscoveryProviderStatus_Ready(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 		ctrlClient:            fakeClient,
@@ -1987,10 +2298,10 @@ func TestGetSpireOIDCDiscoveryProviderStatus_Ready(t *testing.T) {
 	}
 
 	fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
-		if cr, ok := obj.(*v1alpha1.SpireOIDCDiscoveryProvider); ok {
+		if cr, ok := obj.(*v1alpha2.SpireOIDCDiscoveryProvider); ok {
 			cr.Name = "cluster"
 			cr.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 			}
 		}
 		return nil
@@ -2001,8 +2312,8 @@ func TestGetSpireOIDCDiscoveryProviderStatus_Ready(t *testing.T) {
 	if status.Kind != "SpireOIDCDiscoveryProvider" {
 		t.Errorf("Expected kind SpireOIDCDiscoveryProvider, got %s", status.Kind)
 	}
-	if status.Ready != "true" {
-		t.Errorf("Expected ready true, got %s", status.Ready)
+	if !status.Ready {
+		t.Errorf("Expected ready true, got %t", status.Ready)
 	}
 }
 
@@ -2010,7 +2321,7 @@ func TestGetSpireOIDCDiscoveryProviderStatus_Ready(t *testing.T) {
 func TestGetSpireServerStatus_NotReady(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 		ctrlClient:            fakeClient,
@@ -2022,10 +2333,10 @@ func TestGetSpireServerStatus_NotReady(t *testing.T) {
 	}
 
 	fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
-		if cr, ok := obj.(*v1alpha1.SpireServer); ok {
+		if cr, ok := obj.(*v1alpha2.SpireServer); ok {
 			cr.Name = "cluster"
 			cr.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionFalse, Reason: v1alpha1.ReasonFailed, Message: "Failed to reconcile"},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionFalse, Reason: v1alpha2.ReasonFailed, Message: "Failed to reconcile"},
 			}
 		}
 		return nil
@@ -2033,8 +2344,8 @@ func TestGetSpireServerStatus_NotReady(t *testing.T) {
 
 	status := reconciler.getSpireServerStatus(context.Background())
 
-	if status.Ready != "false" {
-		t.Errorf("Expected ready false, got %s", status.Ready)
+	if status.Ready {
+		t.Errorf("Expected ready false, got %t", status.Ready)
 	}
 	if status.Message != "Failed to reconcile" {
 		t.Errorf("Expected message 'Failed to reconcile', got %s", status.Message)
@@ -2045,7 +2356,7 @@ func TestGetSpireServerStatus_NotReady(t *testing.T) {
 func TestGetSpireServerStatus_ReadyConditionNil(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 		ctrlClient:            fakeClient,
@@ -2057,7 +2368,7 @@ func TestGetSpireServerStatus_ReadyConditionNil(t *testing.T) {
 	}
 
 	fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
-		if cr, ok := obj.(*v1alpha1.SpireServer); ok {
+		if cr, ok := obj.(*v1alpha2.SpireServer); ok {
 			cr.Name = "cluster"
 			// Has conditions but no Ready condition
 			cr.Status.ConditionalStatus.Conditions = []metav1.Condition{
@@ -2069,8 +2380,8 @@ func TestGetSpireServerStatus_ReadyConditionNil(t *testing.T) {
 
 	status := reconciler.getSpireServerStatus(context.Background())
 
-	if status.Ready != "false" {
-		t.Errorf("Expected ready false when Ready condition is nil, got %s", status.Ready)
+	if status.Ready {
+		t.Errorf("Expected ready false when Ready condition is nil, got %t", status.Ready)
 	}
 	if status.Message != OperandMessageReconciling {
 		t.Errorf("Expected message %s, got %s", OperandMessageReconciling, status.Message)
@@ -2081,7 +2392,7 @@ func TestGetSpireServerStatus_ReadyConditionNil(t *testing.T) {
 func TestReconcile_AllOperandsReady(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 		ctrlClient:            fakeClient,
@@ -2096,28 +2407,28 @@ func TestReconcile_AllOperandsReady(t *testing.T) {
 	fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 		callCount++
 		switch o := obj.(type) {
-		case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+		case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 			o.Name = "cluster"
 			o.Spec.TrustDomain = "example.org"
-		case *v1alpha1.SpireServer:
+		case *v1alpha2.SpireServer:
 			o.Name = "cluster"
 			o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady, Message: "Ready"},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady, Message: "Ready"},
 			}
-		case *v1alpha1.SpireAgent:
+		case *v1alpha2.SpireAgent:
 			o.Name = "cluster"
 			o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady, Message: "Ready"},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady, Message: "Ready"},
 			}
-		case *v1alpha1.SpiffeCSIDriver:
+		case *v1alpha2.SpiffeCSIDriver:
 			o.Name = "cluster"
 			o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady, Message: "Ready"},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady, Message: "Ready"},
 			}
-		case *v1alpha1.SpireOIDCDiscoveryProvider:
+		case *v1alpha2.SpireOIDCDiscoveryProvider:
 			o.Name = "cluster"
 			o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady, Message: "Ready"},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady, Message: "Ready"},
 			}
 		}
 		return nil
@@ -2138,7 +2449,7 @@ func TestReconcile_AllOperandsReady(t *testing.T) {
 func TestReconcile_SomeOperandsNotCreated(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 		ctrlClient:            fakeClient,
@@ -2151,13 +2462,13 @@ func TestReconcile_SomeOperandsNotCreated(t *testing.T) {
 
 	fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 		switch o := obj.(type) {
-		case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+		case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 			o.Name = "cluster"
 			o.Spec.TrustDomain = "example.org"
-		case *v1alpha1.SpireServer:
+		case *v1alpha2.SpireServer:
 			o.Name = "cluster"
 			o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady, Message: "Ready"},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady, Message: "Ready"},
 			}
 		default:
 			return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
@@ -2180,7 +2491,7 @@ func TestReconcile_SomeOperandsNotCreated(t *testing.T) {
 func TestReconcile_SomeOperandsFailed(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 		ctrlClient:            fakeClient,
@@ -2193,28 +2504,28 @@ func TestReconcile_SomeOperandsFailed(t *testing.T) {
 
 	fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 		switch o := obj.(type) {
-		case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+		case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 			o.Name = "cluster"
 			o.Spec.TrustDomain = "example.org"
-		case *v1alpha1.SpireServer:
+		case *v1alpha2.SpireServer:
 			o.Name = "cluster"
 			o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionFalse, Reason: v1alpha1.ReasonFailed, Message: "Failed"},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionFalse, Reason: v1alpha2.ReasonFailed, Message: "Failed"},
 			}
-		case *v1alpha1.SpireAgent:
+		case *v1alpha2.SpireAgent:
 			o.Name = "cluster"
 			o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady, Message: "Ready"},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady, Message: "Ready"},
 			}
-		case *v1alpha1.SpiffeCSIDriver:
+		case *v1alpha2.SpiffeCSIDriver:
 			o.Name = "cluster"
 			o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady, Message: "Ready"},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady, Message: "Ready"},
 			}
-		case *v1alpha1.SpireOIDCDiscoveryProvider:
+		case *v1alpha2.SpireOIDCDiscoveryProvider:
 			o.Name = "cluster"
 			o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady, Message: "Ready"},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady, Message: "Ready"},
 			}
 		}
 		return nil
@@ -2235,7 +2546,7 @@ func TestReconcile_SomeOperandsFailed(t *testing.T) {
 func TestReconcile_WithCreateOnlyMode(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 		ctrlClient:            fakeClient,
@@ -2248,29 +2559,29 @@ func TestReconcile_WithCreateOnlyMode(t *testing.T) {
 
 	fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 		switch o := obj.(type) {
-		case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+		case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 			o.Name = "cluster"
 			o.Spec.TrustDomain = "example.org"
-		case *v1alpha1.SpireServer:
+		case *v1alpha2.SpireServer:
 			o.Name = "cluster"
 			o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady, Message: "Ready"},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady, Message: "Ready"},
 				{Type: "CreateOnlyMode", Status: metav1.ConditionTrue, Reason: "CreateOnlyModeEnabled", Message: "Create-only mode is enabled"},
 			}
-		case *v1alpha1.SpireAgent:
+		case *v1alpha2.SpireAgent:
 			o.Name = "cluster"
 			o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady, Message: "Ready"},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady, Message: "Ready"},
 			}
-		case *v1alpha1.SpiffeCSIDriver:
+		case *v1alpha2.SpiffeCSIDriver:
 			o.Name = "cluster"
 			o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady, Message: "Ready"},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady, Message: "Ready"},
 			}
-		case *v1alpha1.SpireOIDCDiscoveryProvider:
+		case *v1alpha2.SpireOIDCDiscoveryProvider:
 			o.Name = "cluster"
 			o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady, Message: "Ready"},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady, Message: "Ready"},
 			}
 		}
 		return nil
@@ -2298,30 +2609,30 @@ func TestReconcile_OperandConditionBranches(t *testing.T) {
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					switch o := obj.(type) {
-					case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+					case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 						o.Name = "cluster"
 						o.Spec.TrustDomain = "example.org"
-					case *v1alpha1.SpireServer, *v1alpha1.SpireAgent, *v1alpha1.SpiffeCSIDriver, *v1alpha1.SpireOIDCDiscoveryProvider:
+					case *v1alpha2.SpireServer, *v1alpha2.SpireAgent, *v1alpha2.SpiffeCSIDriver, *v1alpha2.SpireOIDCDiscoveryProvider:
 						// Set Ready condition for all operands
-						if s, ok := o.(*v1alpha1.SpireServer); ok {
+						if s, ok := o.(*v1alpha2.SpireServer); ok {
 							s.Name = "cluster"
 							s.Status.ConditionalStatus.Conditions = []metav1.Condition{
-								{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+								{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 							}
-						} else if a, ok := o.(*v1alpha1.SpireAgent); ok {
+						} else if a, ok := o.(*v1alpha2.SpireAgent); ok {
 							a.Name = "cluster"
 							a.Status.ConditionalStatus.Conditions = []metav1.Condition{
-								{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+								{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 							}
-						} else if c, ok := o.(*v1alpha1.SpiffeCSIDriver); ok {
+						} else if c, ok := o.(*v1alpha2.SpiffeCSIDriver); ok {
 							c.Name = "cluster"
 							c.Status.ConditionalStatus.Conditions = []metav1.Condition{
-								{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+								{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 							}
-						} else if p, ok := o.(*v1alpha1.SpireOIDCDiscoveryProvider); ok {
+						} else if p, ok := o.(*v1alpha2.SpireOIDCDiscoveryProvider); ok {
 							p.Name = "cluster"
 							p.Status.ConditionalStatus.Conditions = []metav1.Condition{
-								{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+								{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 							}
 						}
 					}
@@ -2334,13 +2645,13 @@ func TestReconcile_OperandConditionBranches(t *testing.T) {
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					switch o := obj.(type) {
-					case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+					case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 						o.Name = "cluster"
 						o.Spec.TrustDomain = "example.org"
-					case *v1alpha1.SpireServer:
+					case *v1alpha2.SpireServer:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
 					default:
 						// Other operands not found
@@ -2355,13 +2666,13 @@ func TestReconcile_OperandConditionBranches(t *testing.T) {
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					switch o := obj.(type) {
-					case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+					case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 						o.Name = "cluster"
 						o.Spec.TrustDomain = "example.org"
-					case *v1alpha1.SpireServer:
+					case *v1alpha2.SpireServer:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionFalse, Reason: v1alpha1.ReasonFailed, Message: "Failed"},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionFalse, Reason: v1alpha2.ReasonFailed, Message: "Failed"},
 						}
 					default:
 						return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
@@ -2375,28 +2686,28 @@ func TestReconcile_OperandConditionBranches(t *testing.T) {
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					switch o := obj.(type) {
-					case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+					case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 						o.Name = "cluster"
 						o.Spec.TrustDomain = "example.org"
-					case *v1alpha1.SpireServer:
+					case *v1alpha2.SpireServer:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionFalse, Reason: v1alpha1.ReasonFailed, Message: "Failed"},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionFalse, Reason: v1alpha2.ReasonFailed, Message: "Failed"},
 						}
-					case *v1alpha1.SpireAgent:
+					case *v1alpha2.SpireAgent:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
-					case *v1alpha1.SpiffeCSIDriver:
+					case *v1alpha2.SpiffeCSIDriver:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
-					case *v1alpha1.SpireOIDCDiscoveryProvider:
+					case *v1alpha2.SpireOIDCDiscoveryProvider:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
 					}
 					return nil
@@ -2408,28 +2719,28 @@ func TestReconcile_OperandConditionBranches(t *testing.T) {
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					switch o := obj.(type) {
-					case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+					case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 						o.Name = "cluster"
 						o.Spec.TrustDomain = "example.org"
-					case *v1alpha1.SpireServer:
+					case *v1alpha2.SpireServer:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionFalse, Reason: v1alpha1.ReasonFailed, Message: "Server failed"},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionFalse, Reason: v1alpha2.ReasonFailed, Message: "Server failed"},
 						}
-					case *v1alpha1.SpireAgent:
+					case *v1alpha2.SpireAgent:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionFalse, Reason: v1alpha1.ReasonFailed, Message: "Agent failed"},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionFalse, Reason: v1alpha2.ReasonFailed, Message: "Agent failed"},
 						}
-					case *v1alpha1.SpiffeCSIDriver:
+					case *v1alpha2.SpiffeCSIDriver:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionFalse, Reason: v1alpha1.ReasonFailed, Message: "CSI failed"},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionFalse, Reason: v1alpha2.ReasonFailed, Message: "CSI failed"},
 						}
-					case *v1alpha1.SpireOIDCDiscoveryProvider:
+					case *v1alpha2.SpireOIDCDiscoveryProvider:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionFalse, Reason: v1alpha1.ReasonFailed, Message: "OIDC failed"},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionFalse, Reason: v1alpha2.ReasonFailed, Message: "OIDC failed"},
 						}
 					}
 					return nil
@@ -2441,27 +2752,27 @@ func TestReconcile_OperandConditionBranches(t *testing.T) {
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					switch o := obj.(type) {
-					case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+					case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 						o.Name = "cluster"
 						o.Spec.TrustDomain = "example.org"
-					case *v1alpha1.SpireServer:
+					case *v1alpha2.SpireServer:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
-					case *v1alpha1.SpireAgent:
+					case *v1alpha2.SpireAgent:
 						// Progressing - waiting for initial reconciliation
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{}
-					case *v1alpha1.SpiffeCSIDriver:
+					case *v1alpha2.SpiffeCSIDriver:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
-					case *v1alpha1.SpireOIDCDiscoveryProvider:
+					case *v1alpha2.SpireOIDCDiscoveryProvider:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
 					}
 					return nil
@@ -2473,27 +2784,27 @@ func TestReconcile_OperandConditionBranches(t *testing.T) {
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					switch o := obj.(type) {
-					case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+					case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 						o.Name = "cluster"
 						o.Spec.TrustDomain = "example.org"
-					case *v1alpha1.SpireServer:
+					case *v1alpha2.SpireServer:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
-					case *v1alpha1.SpireAgent:
+					case *v1alpha2.SpireAgent:
 						// Not found - progressing with CR not found message
 						return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
-					case *v1alpha1.SpiffeCSIDriver:
+					case *v1alpha2.SpiffeCSIDriver:
 						// Reconciling - progressing with reconciling message
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionFalse, Reason: v1alpha1.ReasonInProgress, Message: "Reconciling"},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionFalse, Reason: v1alpha2.ReasonInProgress, Message: "Reconciling"},
 						}
-					case *v1alpha1.SpireOIDCDiscoveryProvider:
+					case *v1alpha2.SpireOIDCDiscoveryProvider:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
 					}
 					return nil
@@ -2505,28 +2816,28 @@ func TestReconcile_OperandConditionBranches(t *testing.T) {
 			setupClient: func(fc *fakes.FakeCustomCtrlClient) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					switch o := obj.(type) {
-					case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+					case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 						o.Name = "cluster"
 						o.Spec.TrustDomain = "example.org"
-					case *v1alpha1.SpireServer:
+					case *v1alpha2.SpireServer:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionFalse, Reason: OperandStateUnhealthy, Message: "Unhealthy state"},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionFalse, Reason: OperandStateUnhealthy, Message: "Unhealthy state"},
 						}
-					case *v1alpha1.SpireAgent:
+					case *v1alpha2.SpireAgent:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
-					case *v1alpha1.SpiffeCSIDriver:
+					case *v1alpha2.SpiffeCSIDriver:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
-					case *v1alpha1.SpireOIDCDiscoveryProvider:
+					case *v1alpha2.SpireOIDCDiscoveryProvider:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
 					}
 					return nil
@@ -2539,7 +2850,7 @@ func TestReconcile_OperandConditionBranches(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 
 			reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 				ctrlClient:            fakeClient,
@@ -2573,7 +2884,7 @@ func TestReconcile_OperandConditionBranches(t *testing.T) {
 func TestAggregateOperandStatus(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 		ctrlClient:            fakeClient,
@@ -2587,25 +2898,25 @@ func TestAggregateOperandStatus(t *testing.T) {
 	t.Run("all operands ready", func(t *testing.T) {
 		fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 			switch o := obj.(type) {
-			case *v1alpha1.SpireServer:
+			case *v1alpha2.SpireServer:
 				o.Name = "cluster"
 				o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-					{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady, Message: "Ready"},
+					{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady, Message: "Ready"},
 				}
-			case *v1alpha1.SpireAgent:
+			case *v1alpha2.SpireAgent:
 				o.Name = "cluster"
 				o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-					{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady, Message: "Ready"},
+					{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady, Message: "Ready"},
 				}
-			case *v1alpha1.SpiffeCSIDriver:
+			case *v1alpha2.SpiffeCSIDriver:
 				o.Name = "cluster"
 				o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-					{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady, Message: "Ready"},
+					{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady, Message: "Ready"},
 				}
-			case *v1alpha1.SpireOIDCDiscoveryProvider:
+			case *v1alpha2.SpireOIDCDiscoveryProvider:
 				o.Name = "cluster"
 				o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-					{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady, Message: "Ready"},
+					{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady, Message: "Ready"},
 				}
 			}
 			return nil
@@ -2627,10 +2938,10 @@ func TestAggregateOperandStatus(t *testing.T) {
 	t.Run("some operands not created", func(t *testing.T) {
 		fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 			switch o := obj.(type) {
-			case *v1alpha1.SpireServer:
+			case *v1alpha2.SpireServer:
 				o.Name = "cluster"
 				o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-					{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady, Message: "Ready"},
+					{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady, Message: "Ready"},
 				}
 			default:
 				return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
@@ -2653,7 +2964,7 @@ func TestAggregateOperandStatus(t *testing.T) {
 func TestReconcile_SuccessfulPath_NoRequeue(t *testing.T) {
 	fakeClient := &fakes.FakeCustomCtrlClient{}
 	scheme := runtime.NewScheme()
-	_ = v1alpha1.AddToScheme(scheme)
+	_ = v1alpha2.AddToScheme(scheme)
 
 	reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 		ctrlClient:            fakeClient,
@@ -2666,28 +2977,28 @@ func TestReconcile_SuccessfulPath_NoRequeue(t *testing.T) {
 
 	fakeClient.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 		switch o := obj.(type) {
-		case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+		case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 			o.Name = "cluster"
 			o.Spec.TrustDomain = "example.org"
-		case *v1alpha1.SpireServer:
+		case *v1alpha2.SpireServer:
 			o.Name = "cluster"
 			o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady, Message: "Ready"},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady, Message: "Ready"},
 			}
-		case *v1alpha1.SpireAgent:
+		case *v1alpha2.SpireAgent:
 			o.Name = "cluster"
 			o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady, Message: "Ready"},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady, Message: "Ready"},
 			}
-		case *v1alpha1.SpiffeCSIDriver:
+		case *v1alpha2.SpiffeCSIDriver:
 			o.Name = "cluster"
 			o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady, Message: "Ready"},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady, Message: "Ready"},
 			}
-		case *v1alpha1.SpireOIDCDiscoveryProvider:
+		case *v1alpha2.SpireOIDCDiscoveryProvider:
 			o.Name = "cluster"
 			o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-				{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady, Message: "Ready"},
+				{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady, Message: "Ready"},
 			}
 		}
 		return nil
@@ -2730,11 +3041,11 @@ func TestOperandStatusChangedPredicate_MutationKillers(t *testing.T) {
 			// Same status -> no update needed
 			name: "both objects have GetStatus - status same",
 			oldObj: &mockStatusObject{
-				Object: &v1alpha1.SpireServer{},
+				Object: &v1alpha2.SpireServer{},
 				status: map[string]string{"key": "value"},
 			},
 			newObj: &mockStatusObject{
-				Object: &v1alpha1.SpireServer{},
+				Object: &v1alpha2.SpireServer{},
 				status: map[string]string{"key": "value"},
 			},
 			expected: false, // No status change
@@ -2743,11 +3054,11 @@ func TestOperandStatusChangedPredicate_MutationKillers(t *testing.T) {
 			// Both objects can get status - status changed
 			name: "both objects have GetStatus - status different",
 			oldObj: &mockStatusObject{
-				Object: &v1alpha1.SpireServer{},
+				Object: &v1alpha2.SpireServer{},
 				status: map[string]string{"key": "old"},
 			},
 			newObj: &mockStatusObject{
-				Object: &v1alpha1.SpireServer{},
+				Object: &v1alpha2.SpireServer{},
 				status: map[string]string{"key": "new"},
 			},
 			expected: true, // Status changed
@@ -2757,18 +3068,18 @@ func TestOperandStatusChangedPredicate_MutationKillers(t *testing.T) {
 			// This tests the || vs && mutation on line 551
 			name: "only old object has GetStatus - reconcile to be safe",
 			oldObj: &mockStatusObject{
-				Object: &v1alpha1.SpireServer{},
+				Object: &v1alpha2.SpireServer{},
 				status: "old",
 			},
-			newObj:   &v1alpha1.SpireServer{}, // Doesn't implement GetStatus() interface{}
+			newObj:   &v1alpha2.SpireServer{}, // Doesn't implement GetStatus() interface{}
 			expected: true,                    // Can't compare, reconcile to be safe
 		},
 		{
 			// Only new object can get status - should reconcile to be safe
 			name:   "only new object has GetStatus - reconcile to be safe",
-			oldObj: &v1alpha1.SpireServer{}, // Doesn't implement GetStatus() interface{}
+			oldObj: &v1alpha2.SpireServer{}, // Doesn't implement GetStatus() interface{}
 			newObj: &mockStatusObject{
-				Object: &v1alpha1.SpireServer{},
+				Object: &v1alpha2.SpireServer{},
 				status: "new",
 			},
 			expected: true, // Can't compare, reconcile to be safe
@@ -2776,8 +3087,8 @@ func TestOperandStatusChangedPredicate_MutationKillers(t *testing.T) {
 		{
 			// Neither object can get status - should reconcile to be safe
 			name:     "neither object has GetStatus - reconcile to be safe",
-			oldObj:   &v1alpha1.SpireServer{},
-			newObj:   &v1alpha1.SpireServer{},
+			oldObj:   &v1alpha2.SpireServer{},
+			newObj:   &v1alpha2.SpireServer{},
 			expected: true, // Can't compare, reconcile to be safe
 		},
 	}
@@ -2802,7 +3113,7 @@ func TestOperandStatusChangedPredicate_MutationKillers(t *testing.T) {
 func TestOperandStatusChangedPredicate_CreateDelete(t *testing.T) {
 	// Create event should always return true
 	createEvent := event.CreateEvent{
-		Object: &v1alpha1.SpireServer{},
+		Object: &v1alpha2.SpireServer{},
 	}
 	if !operandStatusChangedPredicate.Create(createEvent) {
 		t.Error("Expected Create to return true")
@@ -2810,7 +3121,7 @@ func TestOperandStatusChangedPredicate_CreateDelete(t *testing.T) {
 
 	// Delete event should always return true
 	deleteEvent := event.DeleteEvent{
-		Object: &v1alpha1.SpireServer{},
+		Object: &v1alpha2.SpireServer{},
 	}
 	if !operandStatusChangedPredicate.Delete(deleteEvent) {
 		t.Error("Expected Delete to return true")
@@ -2818,7 +3129,7 @@ func TestOperandStatusChangedPredicate_CreateDelete(t *testing.T) {
 
 	// Generic event should return false
 	genericEvent := event.GenericEvent{
-		Object: &v1alpha1.SpireServer{},
+		Object: &v1alpha2.SpireServer{},
 	}
 	if operandStatusChangedPredicate.Generic(genericEvent) {
 		t.Error("Expected Generic to return false")
@@ -2841,32 +3152,32 @@ func TestReconcile_UpdateOperatorConditionError(t *testing.T) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					getCallCount++
 					switch o := obj.(type) {
-					case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+					case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 						o.Name = "cluster"
 						o.Spec.TrustDomain = "example.org"
 						return nil
-					case *v1alpha1.SpireServer:
+					case *v1alpha2.SpireServer:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
 						return nil
-					case *v1alpha1.SpireAgent:
+					case *v1alpha2.SpireAgent:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
 						return nil
-					case *v1alpha1.SpiffeCSIDriver:
+					case *v1alpha2.SpiffeCSIDriver:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
 						return nil
-					case *v1alpha1.SpireOIDCDiscoveryProvider:
+					case *v1alpha2.SpireOIDCDiscoveryProvider:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
 						return nil
 					default:
@@ -2883,7 +3194,7 @@ func TestReconcile_UpdateOperatorConditionError(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 
 			reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 				ctrlClient:            fakeClient,
@@ -2915,35 +3226,35 @@ func TestReconcile_UpdateOperatorConditionError(t *testing.T) {
 func TestClassifyOperandState_AllReasons(t *testing.T) {
 	tests := []struct {
 		name      string
-		operand   v1alpha1.OperandStatus
+		operand   v1alpha2.OperandStatus
 		condition *metav1.Condition
 		expected  operandStateClassification
 	}{
 		{
 			name:    "Ready true returns operandReady",
-			operand: v1alpha1.OperandStatus{Ready: "true", Message: "Ready"},
+			operand: v1alpha2.OperandStatus{Ready: true, Message: "Ready"},
 			condition: &metav1.Condition{
-				Type:   v1alpha1.Ready,
+				Type:   v1alpha2.Ready,
 				Status: metav1.ConditionTrue,
-				Reason: v1alpha1.ReasonReady,
+				Reason: v1alpha2.ReasonReady,
 			},
 			expected: operandReady,
 		},
 		{
 			name:    "ReasonInProgress returns operandProgressing",
-			operand: v1alpha1.OperandStatus{Ready: "false", Message: "In progress"},
+			operand: v1alpha2.OperandStatus{Ready: false, Message: "In progress"},
 			condition: &metav1.Condition{
-				Type:   v1alpha1.Ready,
+				Type:   v1alpha2.Ready,
 				Status: metav1.ConditionFalse,
-				Reason: v1alpha1.ReasonInProgress,
+				Reason: v1alpha2.ReasonInProgress,
 			},
 			expected: operandProgressing,
 		},
 		{
 			name:    "OperandStateNotFound returns operandProgressing",
-			operand: v1alpha1.OperandStatus{Ready: "false", Message: "Not found"},
+			operand: v1alpha2.OperandStatus{Ready: false, Message: "Not found"},
 			condition: &metav1.Condition{
-				Type:   v1alpha1.Ready,
+				Type:   v1alpha2.Ready,
 				Status: metav1.ConditionFalse,
 				Reason: OperandStateNotFound,
 			},
@@ -2951,9 +3262,9 @@ func TestClassifyOperandState_AllReasons(t *testing.T) {
 		},
 		{
 			name:    "OperandStateInitialReconcile returns operandProgressing",
-			operand: v1alpha1.OperandStatus{Ready: "false", Message: "Initial reconcile"},
+			operand: v1alpha2.OperandStatus{Ready: false, Message: "Initial reconcile"},
 			condition: &metav1.Condition{
-				Type:   v1alpha1.Ready,
+				Type:   v1alpha2.Ready,
 				Status: metav1.ConditionFalse,
 				Reason: OperandStateInitialReconcile,
 			},
@@ -2961,9 +3272,9 @@ func TestClassifyOperandState_AllReasons(t *testing.T) {
 		},
 		{
 			name:    "OperandStateReconciling returns operandProgressing",
-			operand: v1alpha1.OperandStatus{Ready: "false", Message: "Reconciling"},
+			operand: v1alpha2.OperandStatus{Ready: false, Message: "Reconciling"},
 			condition: &metav1.Condition{
-				Type:   v1alpha1.Ready,
+				Type:   v1alpha2.Ready,
 				Status: metav1.ConditionFalse,
 				Reason: OperandStateReconciling,
 			},
@@ -2971,19 +3282,19 @@ func TestClassifyOperandState_AllReasons(t *testing.T) {
 		},
 		{
 			name:    "ReasonFailed returns operandFailed",
-			operand: v1alpha1.OperandStatus{Ready: "false", Message: "Failed"},
+			operand: v1alpha2.OperandStatus{Ready: false, Message: "Failed"},
 			condition: &metav1.Condition{
-				Type:   v1alpha1.Ready,
+				Type:   v1alpha2.Ready,
 				Status: metav1.ConditionFalse,
-				Reason: v1alpha1.ReasonFailed,
+				Reason: v1alpha2.ReasonFailed,
 			},
 			expected: operandFailed,
 		},
 		{
 			name:    "OperandStateUnhealthy returns operandFailed",
-			operand: v1alpha1.OperandStatus{Ready: "false", Message: "Unhealthy"},
+			operand: v1alpha2.OperandStatus{Ready: false, Message: "Unhealthy"},
 			condition: &metav1.Condition{
-				Type:   v1alpha1.Ready,
+				Type:   v1alpha2.Ready,
 				Status: metav1.ConditionFalse,
 				Reason: OperandStateUnhealthy,
 			},
@@ -2991,11 +3302,11 @@ func TestClassifyOperandState_AllReasons(t *testing.T) {
 		},
 		{
 			name:    "ReasonReady with false operand returns operandReady due to Reason",
-			operand: v1alpha1.OperandStatus{Ready: "false", Message: "Should be ready"},
+			operand: v1alpha2.OperandStatus{Ready: false, Message: "Should be ready"},
 			condition: &metav1.Condition{
-				Type:   v1alpha1.Ready,
+				Type:   v1alpha2.Ready,
 				Status: metav1.ConditionFalse,
-				Reason: v1alpha1.ReasonReady,
+				Reason: v1alpha2.ReasonReady,
 			},
 			expected: operandReady,
 		},
@@ -3129,9 +3440,9 @@ func TestReconcile_ProgressingBranch(t *testing.T) {
 			setupOperands: func(fc *fakes.FakeCustomCtrlClient) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					switch o := obj.(type) {
-					case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+					case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 						o.Name = "cluster"
-					case *v1alpha1.SpireServer, *v1alpha1.SpireAgent, *v1alpha1.SpiffeCSIDriver, *v1alpha1.SpireOIDCDiscoveryProvider:
+					case *v1alpha2.SpireServer, *v1alpha2.SpireAgent, *v1alpha2.SpiffeCSIDriver, *v1alpha2.SpireOIDCDiscoveryProvider:
 						setAllReady(o)
 					}
 					return nil
@@ -3147,12 +3458,12 @@ func TestReconcile_ProgressingBranch(t *testing.T) {
 			setupOperands: func(fc *fakes.FakeCustomCtrlClient) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					switch o := obj.(type) {
-					case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+					case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 						o.Name = "cluster"
-					case *v1alpha1.SpireServer:
+					case *v1alpha2.SpireServer:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
 					default:
 						return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
@@ -3169,27 +3480,27 @@ func TestReconcile_ProgressingBranch(t *testing.T) {
 			setupOperands: func(fc *fakes.FakeCustomCtrlClient) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					switch o := obj.(type) {
-					case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+					case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 						o.Name = "cluster"
-					case *v1alpha1.SpireServer:
+					case *v1alpha2.SpireServer:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionFalse, Reason: v1alpha1.ReasonFailed, Message: "Failed"},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionFalse, Reason: v1alpha2.ReasonFailed, Message: "Failed"},
 						}
-					case *v1alpha1.SpireAgent:
+					case *v1alpha2.SpireAgent:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
-					case *v1alpha1.SpiffeCSIDriver:
+					case *v1alpha2.SpiffeCSIDriver:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
-					case *v1alpha1.SpireOIDCDiscoveryProvider:
+					case *v1alpha2.SpireOIDCDiscoveryProvider:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
 					}
 					return nil
@@ -3204,24 +3515,24 @@ func TestReconcile_ProgressingBranch(t *testing.T) {
 			setupOperands: func(fc *fakes.FakeCustomCtrlClient) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					switch o := obj.(type) {
-					case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+					case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 						o.Name = "cluster"
-					case *v1alpha1.SpireServer:
+					case *v1alpha2.SpireServer:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionFalse, Reason: v1alpha1.ReasonFailed, Message: "Failed"},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionFalse, Reason: v1alpha2.ReasonFailed, Message: "Failed"},
 						}
-					case *v1alpha1.SpireAgent:
+					case *v1alpha2.SpireAgent:
 						return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
-					case *v1alpha1.SpiffeCSIDriver:
+					case *v1alpha2.SpiffeCSIDriver:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
-					case *v1alpha1.SpireOIDCDiscoveryProvider:
+					case *v1alpha2.SpireOIDCDiscoveryProvider:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
 					}
 					return nil
@@ -3237,7 +3548,7 @@ func TestReconcile_ProgressingBranch(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 
 			reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 				ctrlClient:            fakeClient,
@@ -3276,25 +3587,25 @@ func TestReconcile_ProgressingBranch(t *testing.T) {
 // setAllReady is a helper to set all operand types to ready state
 func setAllReady(obj client.Object) {
 	switch o := obj.(type) {
-	case *v1alpha1.SpireServer:
+	case *v1alpha2.SpireServer:
 		o.Name = "cluster"
 		o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-			{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+			{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 		}
-	case *v1alpha1.SpireAgent:
+	case *v1alpha2.SpireAgent:
 		o.Name = "cluster"
 		o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-			{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+			{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 		}
-	case *v1alpha1.SpiffeCSIDriver:
+	case *v1alpha2.SpiffeCSIDriver:
 		o.Name = "cluster"
 		o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-			{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+			{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 		}
-	case *v1alpha1.SpireOIDCDiscoveryProvider:
+	case *v1alpha2.SpireOIDCDiscoveryProvider:
 		o.Name = "cluster"
 		o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-			{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+			{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 		}
 	}
 }
@@ -3313,25 +3624,25 @@ func TestReconcile_ClassificationBranches(t *testing.T) {
 			setupOperands: func(fc *fakes.FakeCustomCtrlClient) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					switch o := obj.(type) {
-					case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+					case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 						o.Name = "cluster"
-					case *v1alpha1.SpireServer:
+					case *v1alpha2.SpireServer:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
-					case *v1alpha1.SpireAgent:
+					case *v1alpha2.SpireAgent:
 						// Not found - will get OperandMessageCRNotFound
 						return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
-					case *v1alpha1.SpiffeCSIDriver:
+					case *v1alpha2.SpiffeCSIDriver:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
-					case *v1alpha1.SpireOIDCDiscoveryProvider:
+					case *v1alpha2.SpireOIDCDiscoveryProvider:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
 					}
 					return nil
@@ -3346,26 +3657,26 @@ func TestReconcile_ClassificationBranches(t *testing.T) {
 			setupOperands: func(fc *fakes.FakeCustomCtrlClient) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					switch o := obj.(type) {
-					case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+					case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 						o.Name = "cluster"
-					case *v1alpha1.SpireServer:
+					case *v1alpha2.SpireServer:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
-					case *v1alpha1.SpireAgent:
+					case *v1alpha2.SpireAgent:
 						o.Name = "cluster"
 						// No conditions - waiting for initial reconciliation
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{}
-					case *v1alpha1.SpiffeCSIDriver:
+					case *v1alpha2.SpiffeCSIDriver:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
-					case *v1alpha1.SpireOIDCDiscoveryProvider:
+					case *v1alpha2.SpireOIDCDiscoveryProvider:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
 					}
 					return nil
@@ -3380,27 +3691,27 @@ func TestReconcile_ClassificationBranches(t *testing.T) {
 			setupOperands: func(fc *fakes.FakeCustomCtrlClient) {
 				fc.GetStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
 					switch o := obj.(type) {
-					case *v1alpha1.ZeroTrustWorkloadIdentityManager:
+					case *v1alpha2.ZeroTrustWorkloadIdentityManager:
 						o.Name = "cluster"
-					case *v1alpha1.SpireServer:
+					case *v1alpha2.SpireServer:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionFalse, Reason: v1alpha1.ReasonFailed, Message: "Failed"},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionFalse, Reason: v1alpha2.ReasonFailed, Message: "Failed"},
 						}
-					case *v1alpha1.SpireAgent:
+					case *v1alpha2.SpireAgent:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
-					case *v1alpha1.SpiffeCSIDriver:
+					case *v1alpha2.SpiffeCSIDriver:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
-					case *v1alpha1.SpireOIDCDiscoveryProvider:
+					case *v1alpha2.SpireOIDCDiscoveryProvider:
 						o.Name = "cluster"
 						o.Status.ConditionalStatus.Conditions = []metav1.Condition{
-							{Type: v1alpha1.Ready, Status: metav1.ConditionTrue, Reason: v1alpha1.ReasonReady},
+							{Type: v1alpha2.Ready, Status: metav1.ConditionTrue, Reason: v1alpha2.ReasonReady},
 						}
 					}
 					return nil
@@ -3415,7 +3726,7 @@ func TestReconcile_ClassificationBranches(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := &fakes.FakeCustomCtrlClient{}
 			scheme := runtime.NewScheme()
-			_ = v1alpha1.AddToScheme(scheme)
+			_ = v1alpha2.AddToScheme(scheme)
 
 			reconciler := &ZeroTrustWorkloadIdentityManagerReconciler{
 				ctrlClient:            fakeClient,
@@ -3449,7 +3760,7 @@ func TestReconcile_ClassificationBranches(t *testing.T) {
 func TestProcessOperandStatus_MessageVariations(t *testing.T) {
 	tests := []struct {
 		name                   string
-		operand                v1alpha1.OperandStatus
+		operand                v1alpha2.OperandStatus
 		expectAnyOperandExists bool
 		expectNotCreatedCount  int
 		expectFailedCount      int
@@ -3457,10 +3768,10 @@ func TestProcessOperandStatus_MessageVariations(t *testing.T) {
 		{
 			// Message == OperandMessageCRNotFound - operand doesn't exist
 			name: "CR not found message - operand does not exist",
-			operand: v1alpha1.OperandStatus{
+			operand: v1alpha2.OperandStatus{
 				Kind:    "SpireServer",
 				Name:    "cluster",
-				Ready:   "false",
+				Ready:   false,
 				Message: OperandMessageCRNotFound,
 			},
 			expectAnyOperandExists: false,
@@ -3470,10 +3781,10 @@ func TestProcessOperandStatus_MessageVariations(t *testing.T) {
 		{
 			// Message != OperandMessageCRNotFound but progressing
 			name: "reconciling message - operand exists but progressing",
-			operand: v1alpha1.OperandStatus{
+			operand: v1alpha2.OperandStatus{
 				Kind:    "SpireServer",
 				Name:    "cluster",
-				Ready:   "false",
+				Ready:   false,
 				Message: OperandMessageReconciling,
 			},
 			expectAnyOperandExists: true,
@@ -3483,10 +3794,10 @@ func TestProcessOperandStatus_MessageVariations(t *testing.T) {
 		{
 			// Message is some failure - operand exists and failed
 			name: "failure message - operand exists but failed",
-			operand: v1alpha1.OperandStatus{
+			operand: v1alpha2.OperandStatus{
 				Kind:    "SpireServer",
 				Name:    "cluster",
-				Ready:   "false",
+				Ready:   false,
 				Message: "Some failure occurred",
 			},
 			expectAnyOperandExists: true,
@@ -3496,10 +3807,10 @@ func TestProcessOperandStatus_MessageVariations(t *testing.T) {
 		{
 			// Message is waiting for initial reconciliation
 			name: "waiting for initial recon - progressing",
-			operand: v1alpha1.OperandStatus{
+			operand: v1alpha2.OperandStatus{
 				Kind:    "SpireServer",
 				Name:    "cluster",
-				Ready:   "false",
+				Ready:   false,
 				Message: OperandMessageWaitingInitialRecon,
 			},
 			expectAnyOperandExists: true,
@@ -3595,7 +3906,7 @@ func TestUpdateOperatorCondition_OperatorConditionNil(t *testing.T) {
 	// Return NotFound which will make findOperatorCondition return error
 	fakeClient.GetReturns(kerrors.NewNotFound(schema.GroupResource{}, "test"))
 
-	err := reconciler.updateOperatorCondition(context.Background(), false, []v1alpha1.OperandStatus{})
+	err := reconciler.updateOperatorCondition(context.Background(), false, []v1alpha2.OperandStatus{}, nil)
 
 	// Should return error when findOperatorCondition fails
 	if err == nil {
@@ -3613,7 +3924,7 @@ func TestUpdateOperatorCondition_StatusUpdateFails(t *testing.T) {
 	// StatusUpdateWithRetry fails
 	fakeClient.StatusUpdateWithRetryReturns(errors.New("status update failed"))
 
-	err := reconciler.updateOperatorCondition(context.Background(), false, []v1alpha1.OperandStatus{})
+	err := reconciler.updateOperatorCondition(context.Background(), false, []v1alpha2.OperandStatus{}, nil)
 
 	// Should return error when StatusUpdateWithRetry fails
 	if err == nil {
@@ -0,0 +1,661 @@
+package zero_trust_workload_identity_manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+const (
+	// Helm labels and annotations used to detect helm-managed resources
+	helmManagedByValue             = "Helm"
+	helmReleaseNameAnnotation      = "meta.helm.sh/release-name"
+	helmReleaseNamespaceAnnotation = "meta.helm.sh/release-namespace"
+
+	// helmReplacedPodLabel marks the pods left running by a helm workload deleted for replacement,
+	// with the workload name as value. They are removed once the operator's workload exists.
+	helmReplacedPodLabel = "ztwim.openshift.io/helm-replaced-by"
+
+	// helmMigrationRequeueInterval is how often the migration is re-evaluated while in progress
+	helmMigrationRequeueInterval = 15 * time.Second
+)
+
+// Reasons for the HelmMigration condition
+const (
+	HelmMigrationReasonInProgress = "HelmMigrationInProgress"
+	HelmMigrationReasonComplete   = "HelmMigrationComplete"
+	HelmMigrationReasonFailed     = "HelmMigrationFailed"
+)
+
+// helmMigrationResource describes a resource deployed by the SPIRE helm chart that the
+// operator takes over, and the operand CR that owns it after adoption
+type helmMigrationResource struct {
+	operandKind string
+	name        string
+	newObject   func() client.Object
+	labels      func(map[string]string) map[string]string
+}
+
+// helmMigrationResources lists the resources adopted from a helm release. Only resources whose
+// names match the ones managed by the operator are adopted, everything else is left to helm.
+var helmMigrationResources = []helmMigrationResource{
+	{operandKind: "SpireServer", name: "spire-server", newObject: func() client.Object { return &appsv1.StatefulSet{} }, labels: utils.SpireServerLabels},
+	{operandKind: "SpireServer", name: "spire-server", newObject: func() client.Object { return &corev1.ConfigMap{} }, labels: utils.SpireServerLabels},
+	{operandKind: "SpireServer", name: "spire-server", newObject: func() client.Object { return &corev1.Service{} }, labels: utils.SpireServerLabels},
+	{operandKind: "SpireServer", name: "spire-server", newObject: func() client.Object { return &corev1.ServiceAccount{} }, labels: utils.SpireServerLabels},
+	{operandKind: "SpireServer", name: "spire-controller-manager", newObject: func() client.Object { return &corev1.ConfigMap{} }, labels: utils.SpireControllerManagerLabels},
+	{operandKind: "SpireServer", name: "spire-controller-manager-webhook", newObject: func() client.Object { return &corev1.Service{} }, labels: utils.SpireControllerManagerLabels},
+	{operandKind: "SpireAgent", name: "spire-agent", newObject: func() client.Object { return &appsv1.DaemonSet{} }, labels: utils.SpireAgentLabels},
+	{operandKind: "SpireAgent", name: "spire-agent", newObject: func() client.Object { return &corev1.ConfigMap{} }, labels: utils.SpireAgentLabels},
+	{operandKind: "SpireAgent", name: "spire-agent", newObject: func() client.Object { return &corev1.Service{} }, labels: utils.SpireAgentLabels},
+	{operandKind: "SpireAgent", name: "spire-agent", newObject: func() client.Object { return &corev1.ServiceAccount{} }, labels: utils.SpireAgentLabels},
+	{operandKind: "SpiffeCSIDriver", name: "spire-spiffe-csi-driver", newObject: func() client.Object { return &appsv1.DaemonSet{} }, labels: utils.SpiffeCSIDriverLabels},
+	{operandKind: "SpiffeCSIDriver", name: "spire-spiffe-csi-driver", newObject: func() client.Object { return &corev1.ServiceAccount{} }, labels: utils.SpiffeCSIDriverLabels},
+	{operandKind: "SpireOIDCDiscoveryProvider", name: "spire-spiffe-oidc-discovery-provider", newObject: func() client.Object { return &appsv1.Deployment{} }, labels: utils.SpireOIDCDiscoveryProviderLabels},
+	{operandKind: "SpireOIDCDiscoveryProvider", name: "spire-spiffe-oidc-discovery-provider", newObject: func() client.Object { return &corev1.ConfigMap{} }, labels: utils.SpireOIDCDiscoveryProviderLabels},
+	{operandKind: "SpireOIDCDiscoveryProvider", name: "spire-spiffe-oidc-discovery-provider", newObject: func() client.Object { return &corev1.Service{} }, labels: utils.SpireOIDCDiscoveryProviderLabels},
+	{operandKind: "SpireOIDCDiscoveryProvider", name: "spire-spiffe-oidc-discovery-provider", newObject: func() client.Object { return &corev1.ServiceAccount{} }, labels: utils.SpireOIDCDiscoveryProviderLabels},
+}
+
+// detectedHelmResource is a helm-managed resource found in the operator namespace
+type detectedHelmResource struct {
+	helmMigrationResource
+	obj client.Object
+}
+
+// isHelmManaged reports whether the object was deployed by helm, optionally by the given release
+func isHelmManaged(obj client.Object, releaseName string) bool {
+	if obj.GetLabels()[utils.AppManagedByLabelKey] != helmManagedByValue {
+		return false
+	}
+	release, ok := obj.GetAnnotations()[helmReleaseNameAnnotation]
+	if !ok {
+		return false
+	}
+	return releaseName == "" || release == releaseName
+}
+
+// reconcileHelmMigration detects a helm-deployed SPIRE stack in the operator namespace, imports its
+// configuration into the operand CRs and takes ownership of its resources. Progress is reported
+// through the HelmMigration condition. It returns true while the migration is still in progress.
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) reconcileHelmMigration(ctx context.Context, config *v1alpha2.ZeroTrustWorkloadIdentityManager, statusMgr *status.Manager) bool {
+	migration := config.Spec.HelmMigration
+	if migration == nil || !ptr.Deref(migration.Enabled, false) {
+		return false
+	}
+
+	detected, err := r.detectHelmResources(ctx, migration.ReleaseName)
+	if err != nil {
+		r.log.Error(err, "failed to detect helm-managed SPIRE resources")
+		statusMgr.AddCondition(HelmMigration, HelmMigrationReasonFailed,
+			fmt.Sprintf("Failed to detect helm-managed SPIRE resources: %v", err),
+			metav1.ConditionFalse)
+		return false
+	}
+	replacedPods, err := r.deleteReplacedHelmPods(ctx)
+	if err != nil {
+		r.log.Error(err, "failed to remove the pods of replaced helm workloads")
+		statusMgr.AddCondition(HelmMigration, HelmMigrationReasonFailed,
+			fmt.Sprintf("Failed to remove the pods of replaced helm workloads: %v", err),
+			metav1.ConditionFalse)
+		return false
+	}
+	if len(detected) == 0 && replacedPods == 0 {
+		statusMgr.AddCondition(HelmMigration, HelmMigrationReasonComplete,
+			fmt.Sprintf("No helm-managed SPIRE resources remain in namespace %s", utils.GetOperatorNamespace()),
+			metav1.ConditionTrue)
+		return false
+	}
+	if len(detected) == 0 {
+		statusMgr.AddCondition(HelmMigration, HelmMigrationReasonInProgress,
+			fmt.Sprintf("Waiting for the operator workloads to replace %d pods of helm workloads", replacedPods),
+			metav1.ConditionFalse)
+		return true
+	}
+
+	if err := r.importHelmConfiguration(ctx, detected); err != nil {
+		r.log.Error(err, "failed to import helm SPIRE configuration")
+		statusMgr.AddCondition(HelmMigration, HelmMigrationReasonFailed,
+			fmt.Sprintf("Failed to import helm configuration: %v", err),
+			metav1.ConditionFalse)
+		return false
+	}
+
+	adopted, err := r.adoptHelmResources(ctx, detected)
+	if err != nil {
+		r.log.Error(err, "failed to adopt helm-managed SPIRE resources")
+		statusMgr.AddCondition(HelmMigration, HelmMigrationReasonFailed,
+			fmt.Sprintf("Adopted %d of %d helm-managed resources: %v", adopted, len(detected), err),
+			metav1.ConditionFalse)
+		return false
+	}
+
+	statusMgr.AddCondition(HelmMigration, HelmMigrationReasonInProgress,
+		fmt.Sprintf("Adopted %d of %d helm-managed resources", adopted, len(detected)),
+		metav1.ConditionFalse)
+	return true
+}
+
+// detectHelmResources looks up the resources listed in helmMigrationResources directly from the
+// API server, since helm-managed resources are not part of the operator's label-filtered cache
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) detectHelmResources(ctx context.Context, releaseName string) ([]detectedHelmResource, error) {
+	var detected []detectedHelmResource
+	for _, res := range helmMigrationResources {
+		obj := res.newObject()
+		err := r.ctrlClient.GetUncached(ctx, types.NamespacedName{Name: res.name, Namespace: utils.GetOperatorNamespace()}, obj)
+		if err != nil {
+			if apierror.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get %T %s: %w", obj, res.name, err)
+		}
+		if isHelmManaged(obj, releaseName) {
+			detected = append(detected, detectedHelmResource{helmMigrationResource: res, obj: obj})
+		}
+	}
+	return detected, nil
+}
+
+// importHelmConfiguration creates the operand CRs that do not exist yet, with a spec imported from
+// the configuration of the helm release. Existing operand CRs are left untouched.
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) importHelmConfiguration(ctx context.Context, detected []detectedHelmResource) error {
+	kinds := map[string]bool{}
+	for _, res := range detected {
+		kinds[res.operandKind] = true
+	}
+
+	var serverSpec *v1alpha2.SpireServerSpec
+	if kinds["SpireServer"] || kinds["SpireOIDCDiscoveryProvider"] {
+		cm := findDetectedConfigMap(detected, "spire-server")
+		if cm == nil {
+			return fmt.Errorf("helm release has no spire-server ConfigMap to import the server configuration from")
+		}
+		spec, err := importSpireServerSpec(cm)
+		if err != nil {
+			return err
+		}
+		if sts := findDetectedStatefulSet(detected, "spire-server"); sts != nil {
+			importSpireServerPersistence(sts, spec)
+		}
+		serverSpec = spec
+	}
+
+	var operands []client.Object
+	if kinds["SpireServer"] {
+		operands = append(operands, &v1alpha2.SpireServer{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}, Spec: *serverSpec})
+	}
+	if kinds["SpireAgent"] {
+		cm := findDetectedConfigMap(detected, "spire-agent")
+		if cm == nil {
+			return fmt.Errorf("helm release has no spire-agent ConfigMap to import the agent configuration from")
+		}
+		spec, err := importSpireAgentSpec(cm)
+		if err != nil {
+			return err
+		}
+		operands = append(operands, &v1alpha2.SpireAgent{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}, Spec: *spec})
+	}
+	if kinds["SpiffeCSIDriver"] {
+		operands = append(operands, &v1alpha2.SpiffeCSIDriver{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}})
+	}
+	if kinds["SpireOIDCDiscoveryProvider"] {
+		operands = append(operands, &v1alpha2.SpireOIDCDiscoveryProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+			Spec:       v1alpha2.SpireOIDCDiscoveryProviderSpec{JwtIssuer: serverSpec.JwtIssuer},
+		})
+	}
+
+	for _, operand := range operands {
+		exists, err := r.ctrlClient.Exists(ctx, types.NamespacedName{Name: operand.GetName()}, operand.DeepCopyObject().(client.Object))
+		if err != nil {
+			return fmt.Errorf("failed to check if %T exists: %w", operand, err)
+		}
+		if exists {
+			continue
+		}
+		if err := r.ctrlClient.Create(ctx, operand); err != nil {
+			// The operand CR created by a previous pass may not have reached the cache yet
+			if apierror.IsAlreadyExists(err) {
+				continue
+			}
+			return fmt.Errorf("failed to create %T from helm configuration: %w", operand, err)
+		}
+		r.log.Info("Created operand CR from helm configuration", "kind", fmt.Sprintf("%T", operand))
+	}
+	return nil
+}
+
+// adoptHelmResources hands the detected resources over to the operator: helm ownership metadata is
+// removed, the operator's labels are applied and the owning operand CR is set as controller. The
+// operand controllers then reconcile the adopted resources to their desired state. Workloads whose
+// immutable fields differ from the operator's are deleted instead and recreated by the operand
+// controllers, see replaceHelmWorkload.
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) adoptHelmResources(ctx context.Context, detected []detectedHelmResource) (int, error) {
+	adopted := 0
+	for _, res := range detected {
+		if needsReplacement(res) {
+			if err := r.replaceHelmWorkload(ctx, res); err != nil {
+				return adopted, err
+			}
+			adopted++
+			continue
+		}
+
+		owner, err := r.getOperandCR(ctx, res.operandKind)
+		if err != nil {
+			return adopted, err
+		}
+
+		obj := res.obj
+		labels := obj.GetLabels()
+		for key, value := range res.labels(nil) {
+			// Keep the version deployed by helm so that the version skew check stays accurate
+			if key == "app.kubernetes.io/version" {
+				continue
+			}
+			labels[key] = value
+		}
+		obj.SetLabels(labels)
+
+		annotations := obj.GetAnnotations()
+		delete(annotations, helmReleaseNameAnnotation)
+		delete(annotations, helmReleaseNamespaceAnnotation)
+		obj.SetAnnotations(annotations)
+
+		if err := controllerutil.SetControllerReference(owner, obj, r.scheme); err != nil {
+			return adopted, fmt.Errorf("failed to set controller reference on %T %s: %w", obj, res.name, err)
+		}
+		if err := r.ctrlClient.Update(ctx, obj, client.FieldOwner(utils.ZeroTrustWorkloadIdentityManagerControllerName)); err != nil {
+			return adopted, fmt.Errorf("failed to adopt %T %s: %w", obj, res.name, err)
+		}
+		r.log.Info("Adopted helm-managed resource", "kind", fmt.Sprintf("%T", obj), "name", res.name, "owner", res.operandKind)
+		adopted++
+	}
+	return adopted, nil
+}
+
+// workloadSelector returns the pod selector of a StatefulSet, DaemonSet or Deployment. The second
+// value is false for other resources.
+func workloadSelector(obj client.Object) (*metav1.LabelSelector, bool) {
+	switch o := obj.(type) {
+	case *appsv1.StatefulSet:
+		return o.Spec.Selector, true
+	case *appsv1.DaemonSet:
+		return o.Spec.Selector, true
+	case *appsv1.Deployment:
+		return o.Spec.Selector, true
+	}
+	return nil, false
+}
+
+// needsReplacement reports whether a helm workload cannot be updated in place to the operator's
+// desired state. The pod selector of workloads is immutable and so are the volumeClaimTemplates of
+// StatefulSets, which the operator renders differently from the helm chart.
+func needsReplacement(res detectedHelmResource) bool {
+	selector, ok := workloadSelector(res.obj)
+	if !ok {
+		return false
+	}
+	if _, ok := res.obj.(*appsv1.StatefulSet); ok {
+		return true
+	}
+	labels := res.labels(nil)
+	operatorSelector := map[string]string{
+		"app.kubernetes.io/name":      labels["app.kubernetes.io/name"],
+		"app.kubernetes.io/instance":  labels["app.kubernetes.io/instance"],
+		"app.kubernetes.io/component": labels["app.kubernetes.io/component"],
+	}
+	return selector == nil || len(selector.MatchExpressions) > 0 || !maps.Equal(selector.MatchLabels, operatorSelector)
+}
+
+// replaceHelmWorkload deletes a helm workload with its pods orphaned, so that they keep serving
+// until the operand controller has created the operator's workload under the same name. The pods
+// are labelled to be removed by deleteReplacedHelmPods once that workload exists. The PVCs of a
+// StatefulSet are orphaned as well and reused by the new StatefulSet, which uses the same
+// volumeClaimTemplate name as the helm chart.
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) replaceHelmWorkload(ctx context.Context, res detectedHelmResource) error {
+	selector, _ := workloadSelector(res.obj)
+	if selector != nil && (len(selector.MatchLabels) > 0 || len(selector.MatchExpressions) > 0) {
+		podSelector, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return fmt.Errorf("invalid selector on %T %s: %w", res.obj, res.name, err)
+		}
+		pods := &corev1.PodList{}
+		if err := r.ctrlClient.ListUncached(ctx, pods, client.InNamespace(utils.GetOperatorNamespace()), client.MatchingLabelsSelector{Selector: podSelector}); err != nil {
+			return fmt.Errorf("failed to list the pods of %T %s: %w", res.obj, res.name, err)
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if pod.Labels[helmReplacedPodLabel] == res.name {
+				continue
+			}
+			patch := client.MergeFrom(pod.DeepCopy())
+			pod.Labels[helmReplacedPodLabel] = res.name
+			if err := r.ctrlClient.Patch(ctx, pod, patch); err != nil {
+				return fmt.Errorf("failed to label pod %s of %T %s: %w", pod.Name, res.obj, res.name, err)
+			}
+		}
+	}
+
+	if err := r.ctrlClient.Delete(ctx, res.obj, client.PropagationPolicy(metav1.DeletePropagationOrphan)); err != nil && !apierror.IsNotFound(err) {
+		return fmt.Errorf("failed to delete %T %s for replacement: %w", res.obj, res.name, err)
+	}
+	r.log.Info("Deleted helm-managed workload for replacement by the operator", "kind", fmt.Sprintf("%T", res.obj), "name", res.name, "owner", res.operandKind)
+	return nil
+}
+
+// deleteReplacedHelmPods removes the pods of replaced helm workloads whose operator workload has
+// been created. It returns the number of pods still waiting for their replacement.
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) deleteReplacedHelmPods(ctx context.Context) (int, error) {
+	pods := &corev1.PodList{}
+	if err := r.ctrlClient.ListUncached(ctx, pods, client.InNamespace(utils.GetOperatorNamespace()), client.HasLabels{helmReplacedPodLabel}); err != nil {
+		return 0, fmt.Errorf("failed to list the pods of replaced helm workloads: %w", err)
+	}
+
+	remaining := 0
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		replaced, err := r.helmWorkloadReplaced(ctx, pod.Labels[helmReplacedPodLabel])
+		if err != nil {
+			return 0, err
+		}
+		if !replaced {
+			remaining++
+			continue
+		}
+		if err := r.ctrlClient.Delete(ctx, pod); err != nil && !apierror.IsNotFound(err) {
+			return 0, fmt.Errorf("failed to delete pod %s of a replaced helm workload: %w", pod.Name, err)
+		}
+		r.log.Info("Deleted pod of a replaced helm workload", "pod", pod.Name)
+	}
+	return remaining, nil
+}
+
+// helmWorkloadReplaced reports whether the operator's workload with the given name exists
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) helmWorkloadReplaced(ctx context.Context, name string) (bool, error) {
+	for _, res := range helmMigrationResources {
+		obj := res.newObject()
+		if _, ok := workloadSelector(obj); !ok || res.name != name {
+			continue
+		}
+		exists, err := r.ctrlClient.Exists(ctx, types.NamespacedName{Name: name, Namespace: utils.GetOperatorNamespace()}, obj)
+		if err != nil {
+			return false, fmt.Errorf("failed to check if %T %s exists: %w", obj, name, err)
+		}
+		return exists, nil
+	}
+	// Not a workload of the helm chart, nothing will replace the pod
+	return true, nil
+}
+
+// getOperandCR returns the "cluster" instance of the given operand kind. It is read from the API
+// server since the operand CR may have just been created by importHelmConfiguration and not be
+// in the cache yet.
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) getOperandCR(ctx context.Context, kind string) (client.Object, error) {
+	var obj client.Object
+	switch kind {
+	case "SpireServer":
+		obj = &v1alpha2.SpireServer{}
+	case "SpireAgent":
+		obj = &v1alpha2.SpireAgent{}
+	case "SpiffeCSIDriver":
+		obj = &v1alpha2.SpiffeCSIDriver{}
+	case "SpireOIDCDiscoveryProvider":
+		obj = &v1alpha2.SpireOIDCDiscoveryProvider{}
+	default:
+		return nil, fmt.Errorf("unknown operand kind %q", kind)
+	}
+	if err := r.ctrlClient.GetUncached(ctx, types.NamespacedName{Name: "cluster"}, obj); err != nil {
+		return nil, fmt.Errorf("failed to get %s 'cluster': %w", kind, err)
+	}
+	return obj, nil
+}
+
+func findDetectedConfigMap(detected []detectedHelmResource, name string) *corev1.ConfigMap {
+	for _, res := range detected {
+		if cm, ok := res.obj.(*corev1.ConfigMap); ok && res.name == name {
+			return cm
+		}
+	}
+	return nil
+}
+
+func findDetectedStatefulSet(detected []detectedHelmResource, name string) *appsv1.StatefulSet {
+	for _, res := range detected {
+		if sts, ok := res.obj.(*appsv1.StatefulSet); ok && res.name == name {
+			return sts
+		}
+	}
+	return nil
+}
+
+// helmServerConfig is the subset of the helm-rendered server.conf imported into the SpireServer spec
+type helmServerConfig struct {
+	Server struct {
+		LogLevel           string `json:"log_level"`
+		LogFormat          string `json:"log_format"`
+		JwtIssuer          string `json:"jwt_issuer"`
+		CATTL              string `json:"ca_ttl"`
+		DefaultX509SVIDTTL string `json:"default_x509_svid_ttl"`
+		DefaultJWTSVIDTTL  string `json:"default_jwt_svid_ttl"`
+		CAKeyType          string `json:"ca_key_type"`
+		JWTKeyType         string `json:"jwt_key_type"`
+		CASubject          []struct {
+			Country      []string `json:"country"`
+			Organization []string `json:"organization"`
+			CommonName   string   `json:"common_name"`
+		} `json:"ca_subject"`
+	} `json:"server"`
+	Plugins map[string]json.RawMessage `json:"plugins"`
+}
+
+// helmAgentConfig is the subset of the helm-rendered agent.conf imported into the SpireAgent spec
+type helmAgentConfig struct {
+	Agent struct {
+		LogLevel  string `json:"log_level"`
+		LogFormat string `json:"log_format"`
+	} `json:"agent"`
+	Plugins map[string]json.RawMessage `json:"plugins"`
+}
+
+// importSpireServerSpec builds a SpireServer spec from the helm spire-server ConfigMap. Fields that
+// are not set in the helm configuration get the API defaults.
+func importSpireServerSpec(cm *corev1.ConfigMap) (*v1alpha2.SpireServerSpec, error) {
+	raw, ok := cm.Data["server.conf"]
+	if !ok {
+		return nil, fmt.Errorf("ConfigMap %s has no server.conf key", cm.Name)
+	}
+	var cfg helmServerConfig
+	if err := json.Unmarshal([]byte(raw), &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse server.conf, only JSON configuration can be imported: %w", err)
+	}
+	if cfg.Server.JwtIssuer == "" {
+		return nil, fmt.Errorf("server.conf does not set jwt_issuer, create the SpireServer CR manually")
+	}
+
+	spec := &v1alpha2.SpireServerSpec{
+		LogLevel:   strings.ToLower(cfg.Server.LogLevel),
+		LogFormat:  strings.ToLower(cfg.Server.LogFormat),
+		JwtIssuer:  cfg.Server.JwtIssuer,
+		CAKeyType:  cfg.Server.CAKeyType,
+		JWTKeyType: cfg.Server.JWTKeyType,
+		Datastore: v1alpha2.DataStore{
+			DatabaseType:     "sqlite3",
+			ConnectionString: "/run/spire/data/datastore.sqlite3",
+			MaxOpenConns:     100,
+			MaxIdleConns:     2,
+		},
+		Persistence: v1alpha2.Persistence{
+			Size:       "1Gi",
+			AccessMode: string(corev1.ReadWriteOnce),
+		},
+	}
+
+	var err error
+	if spec.CAValidity, err = parseHelmDuration(cfg.Server.CATTL, 24*time.Hour); err != nil {
+		return nil, fmt.Errorf("invalid ca_ttl: %w", err)
+	}
+	if spec.DefaultX509Validity, err = parseHelmDuration(cfg.Server.DefaultX509SVIDTTL, time.Hour); err != nil {
+		return nil, fmt.Errorf("invalid default_x509_svid_ttl: %w", err)
+	}
+	if spec.DefaultJWTValidity, err = parseHelmDuration(cfg.Server.DefaultJWTSVIDTTL, 5*time.Minute); err != nil {
+		return nil, fmt.Errorf("invalid default_jwt_svid_ttl: %w", err)
+	}
+
+	if len(cfg.Server.CASubject) > 0 {
+		subject := cfg.Server.CASubject[0]
+		spec.CASubject.CommonName = subject.CommonName
+		if len(subject.Country) > 0 {
+			spec.CASubject.Country = subject.Country[0]
+		}
+		if len(subject.Organization) > 0 {
+			spec.CASubject.Organization = subject.Organization[0]
+		}
+	}
+
+	if data, ok := helmPluginData(cfg.Plugins, "DataStore", "sql"); ok {
+		var sql struct {
+			DatabaseType     string `json:"database_type"`
+			ConnectionString string `json:"connection_string"`
+		}
+		if err := json.Unmarshal(data, &sql); err != nil {
+			return nil, fmt.Errorf("failed to parse the sql DataStore plugin data: %w", err)
+		}
+		if sql.DatabaseType != "" {
+			spec.Datastore.DatabaseType = sql.DatabaseType
+		}
+		if sql.ConnectionString != "" {
+			spec.Datastore.ConnectionString = sql.ConnectionString
+		}
+	}
+
+	return spec, nil
+}
+
+// importSpireServerPersistence copies the volume claim settings of the helm spire-server StatefulSet
+func importSpireServerPersistence(sts *appsv1.StatefulSet, spec *v1alpha2.SpireServerSpec) {
+	if len(sts.Spec.VolumeClaimTemplates) == 0 {
+		return
+	}
+	pvc := sts.Spec.VolumeClaimTemplates[0]
+	if size, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+		// The API only accepts whole Gi sizes
+		if bytes := size.Value(); bytes > 0 && bytes%(1<<30) == 0 {
+			spec.Persistence.Size = fmt.Sprintf("%dGi", bytes>>30)
+		}
+	}
+	if len(pvc.Spec.AccessModes) > 0 {
+		spec.Persistence.AccessMode = string(pvc.Spec.AccessModes[0])
+	}
+	if pvc.Spec.StorageClassName != nil {
+		spec.Persistence.StorageClass = *pvc.Spec.StorageClassName
+	}
+}
+
+// importSpireAgentSpec builds a SpireAgent spec from the helm spire-agent ConfigMap
+func importSpireAgentSpec(cm *corev1.ConfigMap) (*v1alpha2.SpireAgentSpec, error) {
+	raw, ok := cm.Data["agent.conf"]
+	if !ok {
+		return nil, fmt.Errorf("ConfigMap %s has no agent.conf key", cm.Name)
+	}
+	var cfg helmAgentConfig
+	if err := json.Unmarshal([]byte(raw), &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse agent.conf, only JSON configuration can be imported: %w", err)
+	}
+
+	spec := &v1alpha2.SpireAgentSpec{
+		LogLevel:  strings.ToLower(cfg.Agent.LogLevel),
+		LogFormat: strings.ToLower(cfg.Agent.LogFormat),
+		NodeAttestor: &v1alpha2.NodeAttestor{
+			K8sPSATEnabled: ptr.To(false),
+		},
+		WorkloadAttestors: &v1alpha2.WorkloadAttestors{
+			K8sEnabled: ptr.To(false),
+		},
+	}
+
+	if _, ok := helmPluginData(cfg.Plugins, "NodeAttestor", "k8s_psat"); ok {
+		spec.NodeAttestor.K8sPSATEnabled = ptr.To(true)
+	}
+
+	if data, ok := helmPluginData(cfg.Plugins, "WorkloadAttestor", "k8s"); ok {
+		var k8s struct {
+			DisableContainerSelectors *bool  `json:"disable_container_selectors"`
+			UseNewContainerLocator    *bool  `json:"use_new_container_locator"`
+			SkipKubeletVerification   *bool  `json:"skip_kubelet_verification"`
+			KubeletCAPath             string `json:"kubelet_ca_path"`
+		}
+		if err := json.Unmarshal(data, &k8s); err != nil {
+			return nil, fmt.Errorf("failed to parse the k8s WorkloadAttestor plugin data: %w", err)
+		}
+		spec.WorkloadAttestors.K8sEnabled = ptr.To(true)
+		spec.WorkloadAttestors.DisableContainerSelectors = k8s.DisableContainerSelectors
+		spec.WorkloadAttestors.UseNewContainerLocator = k8s.UseNewContainerLocator
+		if k8s.SkipKubeletVerification != nil && *k8s.SkipKubeletVerification {
+			spec.WorkloadAttestors.WorkloadAttestorsVerification = &v1alpha2.WorkloadAttestorsVerification{
+				Type: utils.WorkloadAttestorVerificationTypeSkip,
+			}
+		}
+	}
+
+	return spec, nil
+}
+
+// helmPluginData returns the plugin_data of the named plugin. SPIRE accepts plugin sections both as
+// a map keyed by plugin name (as rendered by the helm chart) and as a list of such maps.
+func helmPluginData(plugins map[string]json.RawMessage, pluginType, pluginName string) (json.RawMessage, bool) {
+	raw, ok := plugins[pluginType]
+	if !ok {
+		return nil, false
+	}
+
+	type plugin struct {
+		PluginData json.RawMessage `json:"plugin_data"`
+	}
+	var sections []map[string]plugin
+	var single map[string]plugin
+	if err := json.Unmarshal(raw, &single); err == nil {
+		sections = append(sections, single)
+	} else if err := json.Unmarshal(raw, &sections); err != nil {
+		return nil, false
+	}
+
+	for _, section := range sections {
+		if p, ok := section[pluginName]; ok {
+			if len(p.PluginData) == 0 {
+				return json.RawMessage("{}"), true
+			}
+			return p.PluginData, true
+		}
+	}
+	return nil, false
+}
+
+// parseHelmDuration parses a SPIRE duration, falling back to the given default when unset
+func parseHelmDuration(value string, defaultValue time.Duration) (metav1.Duration, error) {
+	if value == "" {
+		return metav1.Duration{Duration: defaultValue}, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return metav1.Duration{}, err
+	}
+	return metav1.Duration{Duration: d}, nil
+}
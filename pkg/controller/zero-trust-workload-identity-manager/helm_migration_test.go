@@ -0,0 +1,437 @@
+package zero_trust_workload_identity_manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const helmServerConf = `{
+  "server": {
+    "log_level": "DEBUG",
+    "log_format": "json",
+    "jwt_issuer": "https://oidc.example.com",
+    "ca_ttl": "48h",
+    "default_x509_svid_ttl": "2h",
+    "ca_key_type": "ec-p256",
+    "ca_subject": [{"country": ["US"], "organization": ["Example"], "common_name": "example.org"}]
+  },
+  "plugins": {
+    "DataStore": {
+      "sql": {"plugin_data": {"database_type": "postgres", "connection_string": "dbname=spire"}}
+    }
+  }
+}`
+
+const helmAgentConf = `{
+  "agent": {"log_level": "INFO"},
+  "plugins": {
+    "NodeAttestor": [{"k8s_psat": {"plugin_data": {"cluster": "example"}}}],
+    "WorkloadAttestor": [{"k8s": {"plugin_data": {"disable_container_selectors": true, "skip_kubelet_verification": true}}}]
+  }
+}`
+
+func helmManagedMeta(name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: utils.GetOperatorNamespace(),
+		Labels: map[string]string{
+			utils.AppManagedByLabelKey:  helmManagedByValue,
+			"app.kubernetes.io/version": "1.9.6",
+		},
+		Annotations: map[string]string{
+			helmReleaseNameAnnotation:      "spire",
+			helmReleaseNamespaceAnnotation: utils.GetOperatorNamespace(),
+		},
+	}
+}
+
+// TestIsHelmManaged tests detection of helm-managed resources
+func TestIsHelmManaged(t *testing.T) {
+	helmObj := &corev1.ConfigMap{ObjectMeta: helmManagedMeta("spire-server")}
+	unmanaged := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "spire-server"}}
+
+	if !isHelmManaged(helmObj, "") {
+		t.Error("Expected helm resource to be detected without a release filter")
+	}
+	if !isHelmManaged(helmObj, "spire") {
+		t.Error("Expected helm resource to be detected for its own release")
+	}
+	if isHelmManaged(helmObj, "other") {
+		t.Error("Expected helm resource of another release to be ignored")
+	}
+	if isHelmManaged(unmanaged, "") {
+		t.Error("Expected resource without helm labels to be ignored")
+	}
+}
+
+// TestImportSpireServerSpec tests importing the helm server configuration
+func TestImportSpireServerSpec(t *testing.T) {
+	cm := &corev1.ConfigMap{Data: map[string]string{"server.conf": helmServerConf}}
+	spec, err := importSpireServerSpec(cm)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if spec.LogLevel != "debug" || spec.LogFormat != "json" {
+		t.Errorf("Expected debug/json logging, got %s/%s", spec.LogLevel, spec.LogFormat)
+	}
+	if spec.JwtIssuer != "https://oidc.example.com" {
+		t.Errorf("Expected jwt issuer to be imported, got %q", spec.JwtIssuer)
+	}
+	if spec.CAValidity.Duration != 48*time.Hour || spec.DefaultX509Validity.Duration != 2*time.Hour {
+		t.Errorf("Expected imported TTLs, got %s/%s", spec.CAValidity.Duration, spec.DefaultX509Validity.Duration)
+	}
+	if spec.DefaultJWTValidity.Duration != 5*time.Minute {
+		t.Errorf("Expected default JWT validity, got %s", spec.DefaultJWTValidity.Duration)
+	}
+	if spec.CASubject.Country != "US" || spec.CASubject.Organization != "Example" || spec.CASubject.CommonName != "example.org" {
+		t.Errorf("Unexpected CA subject: %+v", spec.CASubject)
+	}
+	if spec.Datastore.DatabaseType != "postgres" || spec.Datastore.ConnectionString != "dbname=spire" {
+		t.Errorf("Unexpected datastore: %+v", spec.Datastore)
+	}
+	if spec.Datastore.MaxOpenConns != 100 || spec.Persistence.Size != "1Gi" {
+		t.Errorf("Expected API defaults for unset fields, got %+v %+v", spec.Datastore, spec.Persistence)
+	}
+
+	if _, err := importSpireServerSpec(&corev1.ConfigMap{Data: map[string]string{"server.conf": `server { trust_domain = "x" }`}}); err == nil {
+		t.Error("Expected error for HCL configuration")
+	}
+	if _, err := importSpireServerSpec(&corev1.ConfigMap{Data: map[string]string{"server.conf": `{"server": {}}`}}); err == nil {
+		t.Error("Expected error when jwt_issuer is not set")
+	}
+}
+
+// TestImportSpireServerPersistence tests importing the volume claim settings of the helm StatefulSet
+func TestImportSpireServerPersistence(t *testing.T) {
+	storageClass := "fast"
+	sts := &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{
+		VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOncePod},
+			StorageClassName: &storageClass,
+			Resources: corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse("5Gi"),
+			}},
+		}}},
+	}}
+	spec := &v1alpha2.SpireServerSpec{}
+	importSpireServerPersistence(sts, spec)
+	if spec.Persistence.Size != "5Gi" || spec.Persistence.AccessMode != "ReadWriteOncePod" || spec.Persistence.StorageClass != "fast" {
+		t.Errorf("Unexpected persistence: %+v", spec.Persistence)
+	}
+}
+
+// TestImportSpireAgentSpec tests importing the helm agent configuration
+func TestImportSpireAgentSpec(t *testing.T) {
+	spec, err := importSpireAgentSpec(&corev1.ConfigMap{Data: map[string]string{"agent.conf": helmAgentConf}})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if spec.LogLevel != "info" {
+		t.Errorf("Expected info log level, got %q", spec.LogLevel)
+	}
+	if !ptr.Deref(spec.NodeAttestor.K8sPSATEnabled, false) {
+		t.Error("Expected k8s_psat node attestor to be enabled")
+	}
+	if !ptr.Deref(spec.WorkloadAttestors.K8sEnabled, false) || !ptr.Deref(spec.WorkloadAttestors.DisableContainerSelectors, false) {
+		t.Errorf("Unexpected workload attestors: %+v", spec.WorkloadAttestors)
+	}
+	if spec.WorkloadAttestors.WorkloadAttestorsVerification == nil ||
+		spec.WorkloadAttestors.WorkloadAttestorsVerification.Type != utils.WorkloadAttestorVerificationTypeSkip {
+		t.Error("Expected kubelet verification to be skipped")
+	}
+}
+
+// TestReconcileHelmMigration tests detection, import and adoption of a helm release
+func TestReconcileHelmMigration(t *testing.T) {
+	enabled := &v1alpha2.HelmMigrationConfig{Enabled: ptr.To(true)}
+
+	tests := []struct {
+		name             string
+		migration        *v1alpha2.HelmMigrationConfig
+		helmResources    bool
+		createErr        error
+		expectInProgress bool
+		expectReason     string
+		expectCreates    int
+		expectUpdates    int
+		expectDeletes    int
+	}{
+		{
+			name:      "migration disabled",
+			migration: nil,
+		},
+		{
+			name:          "no helm resources",
+			migration:     enabled,
+			helmResources: false,
+			expectReason:  HelmMigrationReasonComplete,
+		},
+		{
+			name:             "helm spire-server is adopted",
+			migration:        enabled,
+			helmResources:    true,
+			expectInProgress: true,
+			expectReason:     HelmMigrationReasonInProgress,
+			expectCreates:    1,
+			expectUpdates:    1,
+			expectDeletes:    1,
+		},
+		{
+			name:             "operand CR created by a previous pass",
+			migration:        enabled,
+			helmResources:    true,
+			createErr:        kerrors.NewAlreadyExists(schema.GroupResource{}, "cluster"),
+			expectInProgress: true,
+			expectReason:     HelmMigrationReasonInProgress,
+			expectCreates:    1,
+			expectUpdates:    1,
+			expectDeletes:    1,
+		},
+		{
+			name:          "other helm release is ignored",
+			migration:     &v1alpha2.HelmMigrationConfig{Enabled: ptr.To(true), ReleaseName: "other"},
+			helmResources: true,
+			expectReason:  HelmMigrationReasonComplete,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			reconciler := newTestReconciler(fakeClient)
+			if err := v1alpha2.AddToScheme(reconciler.scheme); err != nil {
+				t.Fatalf("failed to add scheme: %v", err)
+			}
+
+			fakeClient.GetUncachedStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+				if server, ok := obj.(*v1alpha2.SpireServer); ok {
+					server.Name = "cluster"
+					server.UID = "server-uid"
+					return nil
+				}
+				if !tt.helmResources || key.Name != "spire-server" {
+					return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+				}
+				switch o := obj.(type) {
+				case *appsv1.StatefulSet:
+					o.ObjectMeta = helmManagedMeta(key.Name)
+					return nil
+				case *corev1.ConfigMap:
+					o.ObjectMeta = helmManagedMeta(key.Name)
+					o.Data = map[string]string{"server.conf": helmServerConf}
+					return nil
+				}
+				return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+			}
+			fakeClient.ExistsReturns(false, nil)
+			fakeClient.CreateReturns(tt.createErr)
+			// The operand CR just created is not in the cache yet
+			fakeClient.GetReturns(kerrors.NewNotFound(schema.GroupResource{}, "cluster"))
+
+			ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{HelmMigration: tt.migration}}
+			statusMgr := status.NewManager(fakeClient)
+			inProgress := reconciler.reconcileHelmMigration(context.Background(), ztwim, statusMgr)
+			if inProgress != tt.expectInProgress {
+				t.Errorf("Expected in progress %v, got %v", tt.expectInProgress, inProgress)
+			}
+			if fakeClient.CreateCallCount() != tt.expectCreates {
+				t.Errorf("Expected %d creates, got %d", tt.expectCreates, fakeClient.CreateCallCount())
+			}
+			if fakeClient.UpdateCallCount() != tt.expectUpdates {
+				t.Errorf("Expected %d updates, got %d", tt.expectUpdates, fakeClient.UpdateCallCount())
+			}
+			if fakeClient.DeleteCallCount() != tt.expectDeletes {
+				t.Errorf("Expected %d deletes, got %d", tt.expectDeletes, fakeClient.DeleteCallCount())
+			}
+
+			for i := 0; i < fakeClient.UpdateCallCount(); i++ {
+				_, obj, _ := fakeClient.UpdateArgsForCall(i)
+				if _, ok := obj.GetAnnotations()[helmReleaseNameAnnotation]; ok {
+					t.Errorf("Expected helm release annotation to be removed from %s", obj.GetName())
+				}
+				if obj.GetLabels()[utils.AppManagedByLabelKey] != utils.AppManagedByLabelValue {
+					t.Errorf("Expected %s to be labelled as managed by the operator", obj.GetName())
+				}
+				if obj.GetLabels()["app.kubernetes.io/version"] != "1.9.6" {
+					t.Errorf("Expected helm version label to be kept on %s", obj.GetName())
+				}
+				if refs := obj.GetOwnerReferences(); len(refs) != 1 || refs[0].UID != "server-uid" {
+					t.Errorf("Expected SpireServer owner reference on %s, got %v", obj.GetName(), refs)
+				}
+			}
+
+			if err := statusMgr.ApplyStatus(context.Background(), ztwim, func() *v1alpha2.ConditionalStatus {
+				return &ztwim.Status.ConditionalStatus
+			}); err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			condition := apimeta.FindStatusCondition(ztwim.Status.Conditions, HelmMigration)
+			if tt.expectReason == "" {
+				if condition != nil {
+					t.Errorf("Expected no HelmMigration condition, got %v", condition)
+				}
+				return
+			}
+			if condition == nil || condition.Reason != tt.expectReason {
+				t.Errorf("Expected HelmMigration reason %s, got %v", tt.expectReason, condition)
+			}
+		})
+	}
+}
+
+// TestReconcileHelmMigration_ReplacesWorkloads tests that a helm StatefulSet, whose selector and
+// volumeClaimTemplates cannot be updated, is deleted with its pods orphaned and that the pods are
+// removed once the operator has recreated the StatefulSet
+func TestReconcileHelmMigration_ReplacesWorkloads(t *testing.T) {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	reconciler := newTestReconciler(fakeClient)
+	if err := v1alpha2.AddToScheme(reconciler.scheme); err != nil {
+		t.Fatalf("failed to add scheme: %v", err)
+	}
+
+	helmSelector := map[string]string{"app.kubernetes.io/name": "server", "app.kubernetes.io/instance": "spire"}
+	pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      "spire-server-0",
+		Namespace: utils.GetOperatorNamespace(),
+		Labels:    map[string]string{"app.kubernetes.io/name": "server", "app.kubernetes.io/instance": "spire"},
+	}}
+	helmStatefulSetExists := true
+	helmConfigMapExists := true
+	operatorStatefulSetExists := false
+
+	fakeClient.GetUncachedStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+		if server, ok := obj.(*v1alpha2.SpireServer); ok {
+			server.Name = "cluster"
+			server.UID = "server-uid"
+			return nil
+		}
+		if key.Name != "spire-server" {
+			return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+		}
+		switch o := obj.(type) {
+		case *appsv1.StatefulSet:
+			if !helmStatefulSetExists {
+				return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+			}
+			o.ObjectMeta = helmManagedMeta(key.Name)
+			o.Spec.Selector = &metav1.LabelSelector{MatchLabels: helmSelector}
+			o.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: "spire-data"}}}
+			return nil
+		case *corev1.ConfigMap:
+			if !helmConfigMapExists {
+				return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+			}
+			o.ObjectMeta = helmManagedMeta(key.Name)
+			o.Data = map[string]string{"server.conf": helmServerConf}
+			return nil
+		}
+		return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+	}
+	fakeClient.UpdateStub = func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+		if _, ok := obj.(*corev1.ConfigMap); ok {
+			helmConfigMapExists = false
+		}
+		return nil
+	}
+	fakeClient.ListUncachedStub = func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+		listOpts := &client.ListOptions{}
+		listOpts.ApplyOptions(opts)
+		if listOpts.LabelSelector.Matches(k8slabels.Set(pod.Labels)) {
+			list.(*corev1.PodList).Items = []corev1.Pod{*pod.DeepCopy()}
+		}
+		return nil
+	}
+	fakeClient.PatchStub = func(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+		pod.Labels = obj.GetLabels()
+		return nil
+	}
+	fakeClient.DeleteStub = func(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+		if _, ok := obj.(*appsv1.StatefulSet); ok {
+			helmStatefulSetExists = false
+		}
+		return nil
+	}
+	fakeClient.ExistsStub = func(ctx context.Context, key client.ObjectKey, obj client.Object) (bool, error) {
+		if _, ok := obj.(*appsv1.StatefulSet); ok {
+			return operatorStatefulSetExists, nil
+		}
+		return false, nil
+	}
+	fakeClient.GetReturns(kerrors.NewNotFound(schema.GroupResource{}, "cluster"))
+
+	ztwim := &v1alpha2.ZeroTrustWorkloadIdentityManager{Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{
+		HelmMigration: &v1alpha2.HelmMigrationConfig{Enabled: ptr.To(true)},
+	}}
+
+	// First pass: the StatefulSet is deleted with its pods orphaned instead of being updated
+	if !reconciler.reconcileHelmMigration(context.Background(), ztwim, status.NewManager(fakeClient)) {
+		t.Fatal("Expected the migration to be in progress")
+	}
+	if fakeClient.DeleteCallCount() != 1 {
+		t.Fatalf("Expected the helm StatefulSet to be deleted, got %d deletes", fakeClient.DeleteCallCount())
+	}
+	_, deleted, deleteOpts := fakeClient.DeleteArgsForCall(0)
+	if _, ok := deleted.(*appsv1.StatefulSet); !ok {
+		t.Fatalf("Expected the StatefulSet to be deleted, got %T", deleted)
+	}
+	options := &client.DeleteOptions{}
+	options.ApplyOptions(deleteOpts)
+	if options.PropagationPolicy == nil || *options.PropagationPolicy != metav1.DeletePropagationOrphan {
+		t.Errorf("Expected the StatefulSet to be deleted with its pods and PVCs orphaned, got %v", options.PropagationPolicy)
+	}
+	for i := 0; i < fakeClient.UpdateCallCount(); i++ {
+		if _, obj, _ := fakeClient.UpdateArgsForCall(i); obj.GetName() == "spire-server" {
+			if _, ok := obj.(*appsv1.StatefulSet); ok {
+				t.Error("Expected the helm StatefulSet not to be updated in place")
+			}
+		}
+	}
+	if pod.Labels[helmReplacedPodLabel] != "spire-server" {
+		t.Errorf("Expected the orphaned pod to be labelled for removal, got %v", pod.Labels)
+	}
+
+	// Second pass: the pod keeps running until the operator's StatefulSet exists
+	if !reconciler.reconcileHelmMigration(context.Background(), ztwim, status.NewManager(fakeClient)) {
+		t.Fatal("Expected the migration to wait for the replacement StatefulSet")
+	}
+	if fakeClient.DeleteCallCount() != 1 {
+		t.Errorf("Expected the orphaned pod to be kept, got %d deletes", fakeClient.DeleteCallCount())
+	}
+
+	// Third pass: the SpireServer controller has recreated the StatefulSet, the pod is removed
+	operatorStatefulSetExists = true
+	statusMgr := status.NewManager(fakeClient)
+	if reconciler.reconcileHelmMigration(context.Background(), ztwim, statusMgr) {
+		t.Error("Expected the migration to be complete")
+	}
+	if fakeClient.DeleteCallCount() != 2 {
+		t.Fatalf("Expected the orphaned pod to be deleted, got %d deletes", fakeClient.DeleteCallCount())
+	}
+	if _, obj, _ := fakeClient.DeleteArgsForCall(1); obj.GetName() != "spire-server-0" {
+		t.Errorf("Expected pod spire-server-0 to be deleted, got %s", obj.GetName())
+	}
+	if err := statusMgr.ApplyStatus(context.Background(), ztwim, func() *v1alpha2.ConditionalStatus {
+		return &ztwim.Status.ConditionalStatus
+	}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if condition := apimeta.FindStatusCondition(ztwim.Status.Conditions, HelmMigration); condition == nil || condition.Reason != HelmMigrationReasonComplete {
+		t.Errorf("Expected HelmMigration to be complete, got %v", condition)
+	}
+}
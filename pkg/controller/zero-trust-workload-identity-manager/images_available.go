@@ -0,0 +1,101 @@
+package zero_trust_workload_identity_manager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// Condition type and reasons for the availability of the operand images
+const (
+	ImagesAvailable                         = "ImagesAvailable"
+	ImagesAvailableReasonAvailable          = "ImagesAvailable"
+	ImagesAvailableReasonPullFailed         = "ImagePullFailed"
+	ImagesAvailableReasonPullSecretNotFound = "ImagePullSecretNotFound"
+	ImagesAvailableReasonNotPinnedByDigest  = "ImageNotPinnedByDigest"
+	ImagesAvailableReasonCheckFailed        = "ImageCheckFailed"
+
+	// imagesRetryInterval is how often the images are checked again while they are unavailable
+	imagesRetryInterval = time.Minute
+)
+
+// reconcileImagesAvailable checks the operand images can be pulled: the operand pods pulling
+// no image in vain, the pull secrets existing, and the images of repositories mirrored by an
+// ImageDigestMirrorSet being referenced by digest, which the mirrors require. The pods, the
+// pull secrets and the ImageDigestMirrorSets are not labelled for the cache, so they are read
+// from the API server. Returns true when the images are unavailable, to check them again.
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) reconcileImagesAvailable(ctx context.Context, config *v1alpha2.ZeroTrustWorkloadIdentityManager, statusMgr *status.Manager) bool {
+	var pods corev1.PodList
+	if err := r.ctrlClient.ListUncached(ctx, &pods, client.InNamespace(utils.GetOperatorNamespace()),
+		client.MatchingLabels{utils.AppManagedByLabelKey: utils.AppManagedByLabelValue}); err != nil {
+		return r.imagesCheckFailed(statusMgr, fmt.Errorf("failed to list the operand pods: %w", err))
+	}
+	if failures := utils.ImagePullFailures(pods.Items); len(failures) > 0 {
+		return r.imagesUnavailable(config, statusMgr, ImagesAvailableReasonPullFailed,
+			fmt.Sprintf("Operand images cannot be pulled: %s", strings.Join(failures, "; ")))
+	}
+
+	var missing []string
+	for _, secret := range config.Spec.ImagePullSecrets {
+		key := types.NamespacedName{Namespace: utils.GetOperatorNamespace(), Name: secret.Name}
+		if err := r.ctrlClient.GetUncached(ctx, key, &corev1.Secret{}); err != nil {
+			if !apierror.IsNotFound(err) {
+				return r.imagesCheckFailed(statusMgr, fmt.Errorf("failed to get the image pull secret %s: %w", secret.Name, err))
+			}
+			missing = append(missing, secret.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return r.imagesUnavailable(config, statusMgr, ImagesAvailableReasonPullSecretNotFound,
+			fmt.Sprintf("Image pull secrets not found in namespace %s: %s", utils.GetOperatorNamespace(), strings.Join(missing, ", ")))
+	}
+
+	if utils.HasCapability(utils.CapabilityImageDigestMirrorSet) {
+		var mirrorSets configv1.ImageDigestMirrorSetList
+		if err := r.ctrlClient.ListUncached(ctx, &mirrorSets); err != nil {
+			return r.imagesCheckFailed(statusMgr, fmt.Errorf("failed to list the ImageDigestMirrorSets: %w", err))
+		}
+		if problems := utils.CheckImageMirrors(utils.RelatedImages(), mirrorSets.Items); len(problems) > 0 {
+			return r.imagesUnavailable(config, statusMgr, ImagesAvailableReasonNotPinnedByDigest,
+				fmt.Sprintf("Operand images may not be pullable through the mirrors: %s", strings.Join(problems, "; ")))
+		}
+	}
+
+	statusMgr.AddCondition(ImagesAvailable, ImagesAvailableReasonAvailable,
+		"The operand images are available",
+		metav1.ConditionTrue)
+	return false
+}
+
+// imagesUnavailable sets the ImagesAvailable condition to False, with a Warning Event when the
+// images become unavailable or unavailable for another reason
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) imagesUnavailable(config *v1alpha2.ZeroTrustWorkloadIdentityManager, statusMgr *status.Manager, reason, message string) bool {
+	existing := apimeta.FindStatusCondition(config.Status.Conditions, ImagesAvailable)
+	if existing == nil || existing.Status != metav1.ConditionFalse || existing.Reason != reason {
+		r.eventRecorder.Event(config, corev1.EventTypeWarning, reason, utils.WithRunbook(reason, message))
+	}
+	statusMgr.AddCondition(ImagesAvailable, reason, message, metav1.ConditionFalse)
+	return true
+}
+
+// imagesCheckFailed sets the ImagesAvailable condition to False when the images could not be checked
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) imagesCheckFailed(statusMgr *status.Manager, err error) bool {
+	r.log.Error(err, "failed to check the operand images")
+	statusMgr.AddCondition(ImagesAvailable, ImagesAvailableReasonCheckFailed,
+		fmt.Sprintf("Failed to check the operand images: %v", err),
+		metav1.ConditionFalse)
+	return true
+}
@@ -0,0 +1,133 @@
+package zero_trust_workload_identity_manager
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func TestReconcileImagesAvailable(t *testing.T) {
+	pullFailure := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "spire-server-0"},
+		Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  "spire-server",
+			Image: "registry.example.com/spire-server@sha256:0123",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "Back-off pulling image"}},
+		}}},
+	}
+	mirrorSet := configv1.ImageDigestMirrorSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "disconnected"},
+		Spec: configv1.ImageDigestMirrorSetSpec{ImageDigestMirrors: []configv1.ImageDigestMirrors{{
+			Source:  "registry.redhat.io/zero-trust-workload-identity-manager",
+			Mirrors: []configv1.ImageMirror{"mirror.example.com/ztwim"},
+		}}},
+	}
+
+	tests := []struct {
+		name          string
+		pods          []corev1.Pod
+		pullSecrets   []corev1.LocalObjectReference
+		missingSecret bool
+		mirrorSets    []configv1.ImageDigestMirrorSet
+		serverImage   string
+		existing      []metav1.Condition
+		expectReason  string
+		expectMessage string
+		expectEvent   bool
+	}{
+		{
+			name:         "available",
+			pullSecrets:  []corev1.LocalObjectReference{{Name: "mirror-pull-secret"}},
+			mirrorSets:   []configv1.ImageDigestMirrorSet{mirrorSet},
+			serverImage:  "registry.redhat.io/zero-trust-workload-identity-manager/spire-server-rhel9@sha256:" + strings.Repeat("4b2e", 16),
+			expectReason: ImagesAvailableReasonAvailable,
+		},
+		{
+			name:          "pull failure",
+			pods:          []corev1.Pod{pullFailure},
+			expectReason:  ImagesAvailableReasonPullFailed,
+			expectMessage: "spire-server-0/spire-server cannot pull registry.example.com/spire-server@sha256:0123: ImagePullBackOff",
+			expectEvent:   true,
+		},
+		{
+			name:          "ongoing pull failure",
+			pods:          []corev1.Pod{pullFailure},
+			existing:      []metav1.Condition{{Type: ImagesAvailable, Status: metav1.ConditionFalse, Reason: ImagesAvailableReasonPullFailed}},
+			expectReason:  ImagesAvailableReasonPullFailed,
+			expectMessage: "ImagePullBackOff",
+		},
+		{
+			name:          "pull secret not found",
+			pullSecrets:   []corev1.LocalObjectReference{{Name: "mirror-pull-secret"}},
+			missingSecret: true,
+			expectReason:  ImagesAvailableReasonPullSecretNotFound,
+			expectMessage: "mirror-pull-secret",
+			expectEvent:   true,
+		},
+		{
+			name:          "mirrored image referenced by tag",
+			mirrorSets:    []configv1.ImageDigestMirrorSet{mirrorSet},
+			serverImage:   "registry.redhat.io/zero-trust-workload-identity-manager/spire-server-rhel9:v1.12",
+			expectReason:  ImagesAvailableReasonNotPinnedByDigest,
+			expectMessage: "ImageDigestMirrorSet disconnected",
+			expectEvent:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(utils.SpireServerImageEnv, tt.serverImage)
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.ListUncachedStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				switch l := list.(type) {
+				case *corev1.PodList:
+					l.Items = tt.pods
+				case *configv1.ImageDigestMirrorSetList:
+					l.Items = tt.mirrorSets
+				}
+				return nil
+			}
+			if tt.missingSecret {
+				fakeClient.GetUncachedReturns(kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "mirror-pull-secret"))
+			}
+			reconciler := newTestReconciler(fakeClient)
+			recorder := record.NewFakeRecorder(10)
+			reconciler.eventRecorder = recorder
+			config := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec:       v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{ImagePullSecrets: tt.pullSecrets},
+				Status:     v1alpha2.ZeroTrustWorkloadIdentityManagerStatus{ConditionalStatus: v1alpha2.ConditionalStatus{Conditions: tt.existing}},
+			}
+			statusMgr := status.NewManager(fakeClient)
+
+			unavailable := reconciler.reconcileImagesAvailable(context.Background(), config, statusMgr)
+
+			cond, ok := statusMgr.GetCondition(ImagesAvailable)
+			if !ok || cond.Reason != tt.expectReason {
+				t.Fatalf("Expected %s reason %s, got %v", ImagesAvailable, tt.expectReason, cond)
+			}
+			if unavailable != (tt.expectReason != ImagesAvailableReasonAvailable) {
+				t.Errorf("Expected unavailable %v, got %v", !unavailable, unavailable)
+			}
+			if !strings.Contains(cond.Message, tt.expectMessage) {
+				t.Errorf("Expected the message to contain %q, got %q", tt.expectMessage, cond.Message)
+			}
+			if events := len(recorder.Events); (events == 1) != tt.expectEvent {
+				t.Errorf("Expected event %v, got %d events", tt.expectEvent, events)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package zero_trust_workload_identity_manager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/topology"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// Condition type and reasons for the guardrails of the operator namespace
+const (
+	NamespaceGuardrails                    = "NamespaceGuardrails"
+	NamespaceGuardrailsReasonApplied       = "GuardrailsApplied"
+	NamespaceGuardrailsReasonNotConfigured = "GuardrailsNotConfigured"
+	NamespaceGuardrailsReasonFailed        = "GuardrailsFailed"
+
+	// namespaceGuardrailsName is the name of both the ResourceQuota and the LimitRange
+	namespaceGuardrailsName = "zero-trust-workload-identity-manager-guardrails"
+)
+
+// reconcileNamespaceGuardrails creates the ResourceQuota and LimitRange of the operator namespace
+// selected in spec.namespaceGuardrails, sized from the sizing profile, and removes the ones no
+// longer selected. They are not labelled for the cache, so they are read from the API server.
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) reconcileNamespaceGuardrails(ctx context.Context, config *v1alpha2.ZeroTrustWorkloadIdentityManager, statusMgr *status.Manager) {
+	guardrails := config.Spec.NamespaceGuardrails
+	quotaEnabled := guardrails != nil && ptr.Deref(guardrails.ResourceQuota, true)
+	limitRangeEnabled := guardrails != nil && ptr.Deref(guardrails.LimitRange, true)
+
+	if !quotaEnabled && !limitRangeEnabled {
+		for _, obj := range []client.Object{newGuardrailsResourceQuota(), newGuardrailsLimitRange()} {
+			if err := r.ctrlClient.Delete(ctx, obj); err != nil && !apierror.IsNotFound(err) {
+				r.log.Error(err, "failed to delete namespace guardrail", "name", obj.GetName())
+				statusMgr.AddCondition(NamespaceGuardrails, NamespaceGuardrailsReasonFailed,
+					fmt.Sprintf("Failed to delete namespace guardrail %s: %v", obj.GetName(), err),
+					metav1.ConditionFalse)
+				return
+			}
+		}
+		// Only report once guardrails were configured
+		if apimeta.FindStatusCondition(config.Status.Conditions, NamespaceGuardrails) != nil {
+			statusMgr.AddCondition(NamespaceGuardrails, NamespaceGuardrailsReasonNotConfigured,
+				"No guardrails are configured for the operator namespace",
+				metav1.ConditionFalse)
+		}
+		return
+	}
+
+	profile, err := topology.Resolve(ctx, r.ctrlClient, config)
+	if err != nil {
+		r.log.Error(err, "failed to resolve the topology profile")
+		statusMgr.AddCondition(NamespaceGuardrails, NamespaceGuardrailsReasonFailed,
+			fmt.Sprintf("Failed to resolve the topology profile: %v", err),
+			metav1.ConditionFalse)
+		return
+	}
+	sizingProfile := topology.SizingProfile(profile, config.Spec.SizingProfile)
+
+	quota := newGuardrailsResourceQuota()
+	quota.Spec.Hard = utils.NamespaceGuardrailQuota(sizingProfile)
+	limitRange := newGuardrailsLimitRange()
+	limitRange.Spec.Limits = []corev1.LimitRangeItem{{
+		Type:           corev1.LimitTypeContainer,
+		DefaultRequest: utils.NamespaceGuardrailDefaultRequests(sizingProfile),
+	}}
+
+	var applied []string
+	for _, guardrail := range []struct {
+		kind    string
+		enabled bool
+		obj     client.Object
+	}{{"ResourceQuota", quotaEnabled, quota}, {"LimitRange", limitRangeEnabled, limitRange}} {
+		if guardrail.enabled {
+			err = r.applyNamespaceGuardrail(ctx, config, guardrail.obj)
+			applied = append(applied, guardrail.kind)
+		} else {
+			err = r.ctrlClient.Delete(ctx, guardrail.obj)
+			if apierror.IsNotFound(err) {
+				err = nil
+			}
+		}
+		if err != nil {
+			r.log.Error(err, "failed to reconcile namespace guardrail", "name", guardrail.obj.GetName())
+			statusMgr.AddCondition(NamespaceGuardrails, NamespaceGuardrailsReasonFailed,
+				fmt.Sprintf("Failed to reconcile %s %s: %v", guardrail.kind, guardrail.obj.GetName(), err),
+				metav1.ConditionFalse)
+			return
+		}
+	}
+
+	sizedFor := string(sizingProfile)
+	if sizedFor == "" {
+		sizedFor = string(v1alpha2.SizingProfileMedium)
+	}
+	statusMgr.AddCondition(NamespaceGuardrails, NamespaceGuardrailsReasonApplied,
+		fmt.Sprintf("%s %s sized for the %s sizing profile", strings.Join(applied, " and "), namespaceGuardrailsName, sizedFor),
+		metav1.ConditionTrue)
+}
+
+// applyNamespaceGuardrail creates the guardrail, or updates its spec when it drifted
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) applyNamespaceGuardrail(ctx context.Context, config *v1alpha2.ZeroTrustWorkloadIdentityManager, desired client.Object) error {
+	if err := controllerutil.SetControllerReference(config, desired, r.scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference: %w", err)
+	}
+
+	var existing client.Object
+	switch desired.(type) {
+	case *corev1.ResourceQuota:
+		existing = &corev1.ResourceQuota{}
+	case *corev1.LimitRange:
+		existing = &corev1.LimitRange{}
+	}
+	err := r.ctrlClient.GetUncached(ctx, types.NamespacedName{Name: desired.GetName(), Namespace: desired.GetNamespace()}, existing)
+	if apierror.IsNotFound(err) {
+		if err := r.ctrlClient.Create(ctx, desired); err != nil {
+			return err
+		}
+		r.log.Info("Created namespace guardrail", "name", desired.GetName())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if utils.IsInCreateOnlyMode() || guardrailSpecEqual(existing, desired) {
+		return nil
+	}
+	desired.SetResourceVersion(existing.GetResourceVersion())
+	if err := r.ctrlClient.Update(ctx, desired); err != nil {
+		return err
+	}
+	r.log.Info("Updated namespace guardrail", "name", desired.GetName())
+	return nil
+}
+
+// guardrailSpecEqual compares the specs of two guardrails of the same kind
+func guardrailSpecEqual(existing, desired client.Object) bool {
+	switch desired := desired.(type) {
+	case *corev1.ResourceQuota:
+		return equality.Semantic.DeepEqual(existing.(*corev1.ResourceQuota).Spec.Hard, desired.Spec.Hard)
+	case *corev1.LimitRange:
+		return equality.Semantic.DeepEqual(existing.(*corev1.LimitRange).Spec.Limits, desired.Spec.Limits)
+	}
+	return false
+}
+
+func newGuardrailsResourceQuota() *corev1.ResourceQuota {
+	return &corev1.ResourceQuota{ObjectMeta: guardrailsObjectMeta()}
+}
+
+func newGuardrailsLimitRange() *corev1.LimitRange {
+	return &corev1.LimitRange{ObjectMeta: guardrailsObjectMeta()}
+}
+
+func guardrailsObjectMeta() metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      namespaceGuardrailsName,
+		Namespace: utils.GetOperatorNamespace(),
+		Labels:    map[string]string{utils.AppManagedByLabelKey: utils.AppManagedByLabelValue},
+	}
+}
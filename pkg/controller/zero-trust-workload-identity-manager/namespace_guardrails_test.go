@@ -0,0 +1,119 @@
+package zero_trust_workload_identity_manager
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+)
+
+func TestReconcileNamespaceGuardrails(t *testing.T) {
+	tests := []struct {
+		name          string
+		guardrails    *v1alpha2.NamespaceGuardrailsConfig
+		existing      []metav1.Condition
+		expectCreated []string
+		expectDeleted int
+		expectReason  string
+	}{
+		{
+			name:          "not configured",
+			expectDeleted: 2,
+		},
+		{
+			name:          "removed",
+			existing:      []metav1.Condition{{Type: NamespaceGuardrails, Status: metav1.ConditionTrue}},
+			expectDeleted: 2,
+			expectReason:  NamespaceGuardrailsReasonNotConfigured,
+		},
+		{
+			name:          "quota and limit range",
+			guardrails:    &v1alpha2.NamespaceGuardrailsConfig{},
+			expectCreated: []string{"ResourceQuota", "LimitRange"},
+			expectReason:  NamespaceGuardrailsReasonApplied,
+		},
+		{
+			name:          "limit range only",
+			guardrails:    &v1alpha2.NamespaceGuardrailsConfig{ResourceQuota: ptr.To(false), LimitRange: ptr.To(true)},
+			expectCreated: []string{"LimitRange"},
+			expectDeleted: 1,
+			expectReason:  NamespaceGuardrailsReasonApplied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.GetUncachedReturns(kerrors.NewNotFound(schema.GroupResource{}, namespaceGuardrailsName))
+			fakeClient.DeleteReturns(kerrors.NewNotFound(schema.GroupResource{}, namespaceGuardrailsName))
+			reconciler := newTestReconciler(fakeClient)
+			_ = v1alpha2.AddToScheme(reconciler.scheme)
+			config := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec:       v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{NamespaceGuardrails: tt.guardrails, SizingProfile: v1alpha2.SizingProfileSmall},
+				Status:     v1alpha2.ZeroTrustWorkloadIdentityManagerStatus{ConditionalStatus: v1alpha2.ConditionalStatus{Conditions: tt.existing}},
+			}
+			statusMgr := status.NewManager(fakeClient)
+
+			reconciler.reconcileNamespaceGuardrails(context.Background(), config, statusMgr)
+
+			var created []string
+			for i := 0; i < fakeClient.CreateCallCount(); i++ {
+				_, obj, _ := fakeClient.CreateArgsForCall(i)
+				switch obj := obj.(type) {
+				case *corev1.ResourceQuota:
+					created = append(created, "ResourceQuota")
+					if len(obj.Spec.Hard) == 0 {
+						t.Error("Expected the quota to be sized")
+					}
+				case *corev1.LimitRange:
+					created = append(created, "LimitRange")
+				}
+				if !metav1.IsControlledBy(obj, config) {
+					t.Errorf("Expected %s to be owned by the ZeroTrustWorkloadIdentityManager", obj.GetName())
+				}
+			}
+			if len(created) != len(tt.expectCreated) {
+				t.Fatalf("Expected created %v, got %v", tt.expectCreated, created)
+			}
+			for i := range created {
+				if created[i] != tt.expectCreated[i] {
+					t.Errorf("Expected created %v, got %v", tt.expectCreated, created)
+				}
+			}
+			if fakeClient.DeleteCallCount() != tt.expectDeleted {
+				t.Errorf("Expected %d deletions, got %d", tt.expectDeleted, fakeClient.DeleteCallCount())
+			}
+			cond, ok := statusMgr.GetCondition(NamespaceGuardrails)
+			if tt.expectReason == "" {
+				if ok {
+					t.Errorf("Expected no condition, got %v", cond)
+				}
+			} else if cond.Reason != tt.expectReason {
+				t.Errorf("Expected reason %s, got %s: %s", tt.expectReason, cond.Reason, cond.Message)
+			}
+		})
+	}
+}
+
+func TestGuardrailSpecEqual(t *testing.T) {
+	desired := newGuardrailsResourceQuota()
+	desired.Spec.Hard = corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10")}
+	existing := desired.DeepCopy()
+	if !guardrailSpecEqual(existing, desired) {
+		t.Error("Expected identical quotas to be equal")
+	}
+	existing.Spec.Hard[corev1.ResourcePods] = resource.MustParse("20")
+	if guardrailSpecEqual(existing, desired) {
+		t.Error("Expected a changed quota to differ")
+	}
+}
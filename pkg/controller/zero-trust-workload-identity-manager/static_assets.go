@@ -0,0 +1,47 @@
+package zero_trust_workload_identity_manager
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/operator/assets"
+)
+
+// Condition type and reasons for the integrity of the static assets embedded in the operator
+const (
+	StaticAssetsValid                = "StaticAssetsValid"
+	StaticAssetsValidReasonVerified  = "StaticAssetsVerified"
+	StaticAssetsValidReasonCorrupted = "StaticAssetsCorrupted"
+)
+
+var staticAssetsInfo = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "ztwim_static_assets_info",
+	Help: "Checksum of the static assets embedded in the operator, valid when they passed the integrity check.",
+}, []string{"checksum", "valid"})
+
+func init() {
+	metrics.Registry.MustRegister(staticAssetsInfo)
+}
+
+// reportStaticAssets reports the checksum of the embedded static assets and the result of their
+// integrity check, which fails when the operator is started with --asset-integrity=warn
+func reportStaticAssets(config *v1alpha2.ZeroTrustWorkloadIdentityManager, statusMgr *status.Manager, verifyErr error) {
+	config.Status.StaticAssetsChecksum = assets.ManifestChecksum
+	staticAssetsInfo.Reset()
+	if verifyErr != nil {
+		staticAssetsInfo.WithLabelValues(assets.ManifestChecksum, "false").Set(1)
+		statusMgr.AddCondition(StaticAssetsValid, StaticAssetsValidReasonCorrupted,
+			fmt.Sprintf("The static assets embedded in the operator are corrupted, the operator image should be replaced: %v", verifyErr),
+			metav1.ConditionFalse)
+		return
+	}
+	staticAssetsInfo.WithLabelValues(assets.ManifestChecksum, "true").Set(1)
+	statusMgr.AddCondition(StaticAssetsValid, StaticAssetsValidReasonVerified,
+		fmt.Sprintf("The static assets embedded in the operator passed the integrity check, checksum %s", assets.ManifestChecksum),
+		metav1.ConditionTrue)
+}
@@ -0,0 +1,71 @@
+package zero_trust_workload_identity_manager
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/operator/assets"
+)
+
+func TestReportStaticAssets(t *testing.T) {
+	tests := []struct {
+		name       string
+		verifyErr  error
+		wantStatus metav1.ConditionStatus
+		wantReason string
+		wantValid  string
+	}{
+		{
+			name:       "verified",
+			wantStatus: metav1.ConditionTrue,
+			wantReason: StaticAssetsValidReasonVerified,
+			wantValid:  "true",
+		},
+		{
+			name:       "corrupted",
+			verifyErr:  errors.New("static assets failed the integrity check: spire-agent/spire-agent-service.yaml has no recorded checksum"),
+			wantStatus: metav1.ConditionFalse,
+			wantReason: StaticAssetsValidReasonCorrupted,
+			wantValid:  "false",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &v1alpha2.ZeroTrustWorkloadIdentityManager{}
+			statusMgr := status.NewManager(&fakes.FakeCustomCtrlClient{})
+			reportStaticAssets(config, statusMgr, tt.verifyErr)
+
+			if config.Status.StaticAssetsChecksum != assets.ManifestChecksum {
+				t.Errorf("Expected the checksum %s in the status, got %q", assets.ManifestChecksum, config.Status.StaticAssetsChecksum)
+			}
+			cond, ok := statusMgr.GetCondition(StaticAssetsValid)
+			if !ok || cond.Status != tt.wantStatus || cond.Reason != tt.wantReason {
+				t.Errorf("Expected the StaticAssetsValid condition %s/%s, got %+v", tt.wantStatus, tt.wantReason, cond)
+			}
+			series := make(chan prometheus.Metric, 2)
+			staticAssetsInfo.Collect(series)
+			close(series)
+			if len(series) != 1 {
+				t.Fatalf("Expected a single info series, got %d", len(series))
+			}
+			var metric dto.Metric
+			if err := (<-series).Write(&metric); err != nil {
+				t.Fatalf("Failed to read the info metric: %v", err)
+			}
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["checksum"] != assets.ManifestChecksum || labels["valid"] != tt.wantValid || metric.GetGauge().GetValue() != 1 {
+				t.Errorf("Expected the info metric with valid=%s, got %v", tt.wantValid, labels)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package zero_trust_workload_identity_manager
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/topology"
+)
+
+// reportTopologyProfile notes the topology profile the operand defaults follow in the
+// TopologyProfile condition, and emits an Event when it changes, e.g. when a single-node cluster
+// is expanded. A profile set on the ZeroTrustWorkloadIdentityManager takes precedence over the
+// detected one. The operand controllers watch the Infrastructure config and re-evaluate their
+// defaults on their own.
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) reportTopologyProfile(ctx context.Context, config *v1alpha2.ZeroTrustWorkloadIdentityManager, statusMgr *status.Manager) {
+	profile := config.Spec.TopologyProfile
+	message := fmt.Sprintf("Topology profile is set to %s", profile)
+	if profile == "" {
+		detected, controlPlaneTopology, err := topology.Detect(ctx, r.ctrlClient)
+		if err != nil {
+			r.log.Error(err, "failed to detect the cluster topology")
+			return
+		}
+		profile = detected
+		message = fmt.Sprintf("Control plane topology is %s", controlPlaneTopology)
+		if controlPlaneTopology == "" {
+			message = "Control plane topology is not known"
+		}
+	}
+	switch profile {
+	case topology.ProfileSingleNode:
+		message += ": replicas are not spread, PodDisruptionBudgets are not created, pod anti-affinity is dropped"
+		if config.Spec.SizingProfile == "" {
+			message += " and the singleNode sizing profile is used"
+		}
+	default:
+		message += ": replicas are spread across nodes and protected by PodDisruptionBudgets"
+	}
+
+	existing := apimeta.FindStatusCondition(config.Status.Conditions, topology.ConditionType)
+	if existing != nil && existing.Reason != string(profile) {
+		r.eventRecorder.Event(config, corev1.EventTypeNormal, "TopologyProfileChanged",
+			fmt.Sprintf("Topology profile changed from %s to %s", existing.Reason, profile))
+	}
+	statusMgr.AddCondition(topology.ConditionType, string(profile), message, metav1.ConditionTrue)
+}
@@ -0,0 +1,66 @@
+package zero_trust_workload_identity_manager
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/topology"
+)
+
+func TestReportTopologyProfile(t *testing.T) {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	fakeClient.GetStub = func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+		obj.(*configv1.Infrastructure).Status.ControlPlaneTopology = configv1.SingleReplicaTopologyMode
+		return nil
+	}
+	reconciler := newTestReconciler(fakeClient)
+	config := &v1alpha2.ZeroTrustWorkloadIdentityManager{}
+	config.Status.Conditions = []metav1.Condition{{
+		Type:   topology.ConditionType,
+		Status: metav1.ConditionTrue,
+		Reason: string(topology.ProfileHighlyAvailable),
+	}}
+	statusMgr := status.NewManager(fakeClient)
+
+	reconciler.reportTopologyProfile(context.Background(), config, statusMgr)
+
+	condition, ok := statusMgr.GetCondition(topology.ConditionType)
+	if !ok || condition.Reason != string(topology.ProfileSingleNode) || !strings.Contains(condition.Message, "SingleReplica") {
+		t.Errorf("Expected the single node profile, got %+v", condition)
+	}
+	select {
+	case event := <-reconciler.eventRecorder.(*record.FakeRecorder).Events:
+		if !strings.Contains(event, "TopologyProfileChanged") {
+			t.Errorf("Expected a TopologyProfileChanged event, got %s", event)
+		}
+	default:
+		t.Error("Expected an event for the profile change")
+	}
+}
+
+func TestReportTopologyProfile_SetOnCR(t *testing.T) {
+	fakeClient := &fakes.FakeCustomCtrlClient{}
+	reconciler := newTestReconciler(fakeClient)
+	config := &v1alpha2.ZeroTrustWorkloadIdentityManager{}
+	config.Spec.TopologyProfile = v1alpha2.TopologyProfileSingleNode
+	statusMgr := status.NewManager(fakeClient)
+
+	reconciler.reportTopologyProfile(context.Background(), config, statusMgr)
+
+	condition, ok := statusMgr.GetCondition(topology.ConditionType)
+	if !ok || condition.Reason != string(topology.ProfileSingleNode) || !strings.Contains(condition.Message, "singleNode sizing profile") {
+		t.Errorf("Expected the profile set on the CR, got %+v", condition)
+	}
+	if fakeClient.GetCallCount() != 0 {
+		t.Errorf("Expected the Infrastructure not to be read, got %d reads", fakeClient.GetCallCount())
+	}
+}
@@ -0,0 +1,259 @@
+package zero_trust_workload_identity_manager
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	spiffev1alpha1 "github.com/spiffe/spire-controller-manager/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// Condition type and reasons for the trust domain migration
+const (
+	TrustDomainMigration                    = "TrustDomainMigration"
+	TrustDomainMigrationReasonPreparing     = "TrustDomainMigrationPreparing"
+	TrustDomainMigrationReasonPrepared      = "TrustDomainMigrationPrepared"
+	TrustDomainMigrationReasonCuttingOver   = "TrustDomainMigrationCuttingOver"
+	TrustDomainMigrationReasonCutOver       = "TrustDomainMigrationCutOver"
+	TrustDomainMigrationReasonComplete      = "TrustDomainMigrationComplete"
+	TrustDomainMigrationReasonFailed        = "TrustDomainMigrationFailed"
+	TrustDomainMigrationReasonNotConfigured = "TrustDomainMigrationNotConfigured"
+
+	// previousBundleDataKey holds the trust bundle of the old trust domain in the bundle
+	// ConfigMap during a trust domain migration
+	previousBundleDataKey = "previous-bundle.crt"
+	// previousTrustDomainAnnotation records the trust domain of the previous bundle
+	previousTrustDomainAnnotation = "ztwim.openshift.io/previous-trust-domain"
+)
+
+// reconcileTrustDomainMigration drives the stages of spec.trustDomainMigration. The operands
+// follow the trust domain returned by utils.TrustDomain on their own; this keeps the trust bundle
+// of the old trust domain published alongside the bundle of the new one until the migration is
+// complete, and reports its progress through the TrustDomainMigration condition. It returns true
+// while the migration waits on the operands.
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) reconcileTrustDomainMigration(ctx context.Context, config *v1alpha2.ZeroTrustWorkloadIdentityManager, statusMgr *status.Manager) bool {
+	var bundle corev1.ConfigMap
+	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: config.Spec.BundleConfigMap, Namespace: utils.GetOperatorNamespace()}, &bundle); err != nil && !apierror.IsNotFound(err) {
+		r.log.Error(err, "failed to get trust bundle ConfigMap", "name", config.Spec.BundleConfigMap)
+		statusMgr.AddCondition(TrustDomainMigration, TrustDomainMigrationReasonFailed,
+			fmt.Sprintf("Failed to get trust bundle ConfigMap %s: %v", config.Spec.BundleConfigMap, err),
+			metav1.ConditionFalse)
+		return true
+	}
+
+	migration := utils.ActiveTrustDomainMigration(config)
+	if migration == nil {
+		// Drop the bundle of the old trust domain once the trustDomain is moved or the migration removed
+		if err := r.setPreviousBundle(ctx, &bundle, "", ""); err != nil {
+			r.log.Error(err, "failed to remove the previous trust bundle", "name", bundle.Name)
+			statusMgr.AddCondition(TrustDomainMigration, TrustDomainMigrationReasonFailed,
+				fmt.Sprintf("Failed to remove the previous trust bundle from ConfigMap %s: %v", bundle.Name, err),
+				metav1.ConditionFalse)
+			return true
+		}
+		// Only report if a migration was previously configured
+		if apimeta.FindStatusCondition(config.Status.Conditions, TrustDomainMigration) == nil {
+			return false
+		}
+		if config.Spec.TrustDomainMigration != nil {
+			statusMgr.AddCondition(TrustDomainMigration, TrustDomainMigrationReasonComplete,
+				fmt.Sprintf("Migrated to trust domain %s, spec.trustDomainMigration can be removed", config.Spec.TrustDomain),
+				metav1.ConditionTrue)
+			return false
+		}
+		statusMgr.AddCondition(TrustDomainMigration, TrustDomainMigrationReasonNotConfigured,
+			fmt.Sprintf("No trust domain migration, the operands run in trust domain %s", config.Spec.TrustDomain),
+			metav1.ConditionTrue)
+		return false
+	}
+
+	switch migration.Stage {
+	case v1alpha2.TrustDomainMigrationStageCutover:
+		if bundle.Data[previousBundleDataKey] == "" {
+			statusMgr.AddCondition(TrustDomainMigration, TrustDomainMigrationReasonFailed,
+				fmt.Sprintf("The trust bundle of %s was not preserved before the cutover, peers of %s are not trusted: set the Prepare stage to roll back", migration.OldTrustDomain, migration.OldTrustDomain),
+				metav1.ConditionFalse)
+			return false
+		}
+		pending, err := r.trustDomainMigrationPending(ctx, migration.NewTrustDomain)
+		if err != nil {
+			r.log.Error(err, "failed to check trust domain migration")
+			statusMgr.AddCondition(TrustDomainMigration, TrustDomainMigrationReasonFailed,
+				fmt.Sprintf("Failed to check the trust domain migration: %v", err),
+				metav1.ConditionFalse)
+			return true
+		}
+		if pending != "" {
+			statusMgr.AddCondition(TrustDomainMigration, TrustDomainMigrationReasonCuttingOver,
+				fmt.Sprintf("Moving the operands to trust domain %s: %s", migration.NewTrustDomain, pending),
+				metav1.ConditionFalse)
+			return true
+		}
+		statusMgr.AddCondition(TrustDomainMigration, TrustDomainMigrationReasonCutOver,
+			fmt.Sprintf("The operands run in trust domain %s and the workloads are registered in it, the trust bundle of %s stays published under %s: set the Complete stage once the peers trust %s",
+				migration.NewTrustDomain, migration.OldTrustDomain, previousBundleDataKey, migration.NewTrustDomain),
+			metav1.ConditionTrue)
+		return false
+
+	case v1alpha2.TrustDomainMigrationStageComplete:
+		if err := r.setPreviousBundle(ctx, &bundle, "", ""); err != nil {
+			r.log.Error(err, "failed to remove the previous trust bundle", "name", bundle.Name)
+			statusMgr.AddCondition(TrustDomainMigration, TrustDomainMigrationReasonFailed,
+				fmt.Sprintf("Failed to remove the previous trust bundle from ConfigMap %s: %v", bundle.Name, err),
+				metav1.ConditionFalse)
+			return true
+		}
+		statusMgr.AddCondition(TrustDomainMigration, TrustDomainMigrationReasonComplete,
+			fmt.Sprintf("Migrated to trust domain %s, the trust bundle of %s is no longer published: set spec.trustDomain to %s and remove spec.trustDomainMigration",
+				migration.NewTrustDomain, migration.OldTrustDomain, migration.NewTrustDomain),
+			metav1.ConditionTrue)
+		return false
+	}
+
+	// Prepare: preserve the bundle of the old trust domain, unless already preserved
+	if bundle.Data[previousBundleDataKey] == "" {
+		pemBundle := bundle.Data[spireBundleDataKey]
+		if pemBundle == "" {
+			statusMgr.AddCondition(TrustDomainMigration, TrustDomainMigrationReasonPreparing,
+				fmt.Sprintf("Waiting for the server to publish the trust bundle of %s to ConfigMap %s", migration.OldTrustDomain, config.Spec.BundleConfigMap),
+				metav1.ConditionFalse)
+			return true
+		}
+		if trustDomain := bundleTrustDomain(pemBundle); trustDomain != "" && trustDomain != migration.OldTrustDomain {
+			statusMgr.AddCondition(TrustDomainMigration, TrustDomainMigrationReasonPreparing,
+				fmt.Sprintf("Waiting for the server to publish the trust bundle of %s, ConfigMap %s holds the bundle of %s", migration.OldTrustDomain, config.Spec.BundleConfigMap, trustDomain),
+				metav1.ConditionFalse)
+			return true
+		}
+		if err := r.setPreviousBundle(ctx, &bundle, pemBundle, migration.OldTrustDomain); err != nil {
+			r.log.Error(err, "failed to preserve the trust bundle", "name", bundle.Name)
+			statusMgr.AddCondition(TrustDomainMigration, TrustDomainMigrationReasonFailed,
+				fmt.Sprintf("Failed to preserve the trust bundle of %s in ConfigMap %s: %v", migration.OldTrustDomain, bundle.Name, err),
+				metav1.ConditionFalse)
+			return true
+		}
+		r.log.Info("Preserved the trust bundle of the old trust domain", "trustDomain", migration.OldTrustDomain, "name", bundle.Name)
+	}
+
+	message := fmt.Sprintf("The trust bundle of %s is published under %s of ConfigMap %s: set the Cutover stage to move the operands to %s",
+		migration.OldTrustDomain, previousBundleDataKey, config.Spec.BundleConfigMap, migration.NewTrustDomain)
+	hardcoded, err := r.clusterSPIFFEIDsInTrustDomain(ctx, migration.OldTrustDomain)
+	if err != nil {
+		r.log.Error(err, "failed to list ClusterSPIFFEIDs")
+	} else if len(hardcoded) > 0 {
+		message += fmt.Sprintf(". The spiffeIDTemplate of the ClusterSPIFFEIDs %s hardcodes %s and must use {{ .TrustDomain }} before the cutover",
+			strings.Join(hardcoded, ", "), migration.OldTrustDomain)
+	}
+	statusMgr.AddCondition(TrustDomainMigration, TrustDomainMigrationReasonPrepared, message, metav1.ConditionTrue)
+	return false
+}
+
+// setPreviousBundle publishes the trust bundle of the previous trust domain in the bundle
+// ConfigMap, or removes it when pemBundle is empty
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) setPreviousBundle(ctx context.Context, bundle *corev1.ConfigMap, pemBundle, trustDomain string) error {
+	if bundle.Name == "" || (pemBundle == "" && bundle.Data[previousBundleDataKey] == "" && bundle.Annotations[previousTrustDomainAnnotation] == "") {
+		return nil
+	}
+	original := bundle.DeepCopy()
+	if pemBundle == "" {
+		delete(bundle.Data, previousBundleDataKey)
+		delete(bundle.Annotations, previousTrustDomainAnnotation)
+	} else {
+		if bundle.Annotations == nil {
+			bundle.Annotations = map[string]string{}
+		}
+		bundle.Data[previousBundleDataKey] = pemBundle
+		bundle.Annotations[previousTrustDomainAnnotation] = trustDomain
+	}
+	// Fail on concurrent changes, so the preserved bundle is not taken from a replaced one
+	return r.ctrlClient.Patch(ctx, bundle, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{}))
+}
+
+// trustDomainMigrationPending returns what the cutover to the given trust domain is still
+// waiting for, or an empty string once the server and agents run in it
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) trustDomainMigrationPending(ctx context.Context, trustDomain string) (string, error) {
+	namespace := utils.GetOperatorNamespace()
+	renderedTrustDomain := fmt.Sprintf("%q: %q", "trust_domain", trustDomain)
+
+	serverConfig := &corev1.ConfigMap{}
+	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: spireServerResourceName, Namespace: namespace}, serverConfig); err != nil && !apierror.IsNotFound(err) {
+		return "", err
+	}
+	if serverConfig.Name != "" && !strings.Contains(serverConfig.Data["server.conf"], renderedTrustDomain) {
+		return "waiting for the server configuration to be updated", nil
+	}
+	statefulSet := &appsv1.StatefulSet{}
+	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: spireServerResourceName, Namespace: namespace}, statefulSet); err != nil && !apierror.IsNotFound(err) {
+		return "", err
+	}
+	if statefulSet.Name != "" && !statefulSetRolledOut(statefulSet) {
+		return "waiting for the server to roll out", nil
+	}
+
+	agentConfig := &corev1.ConfigMap{}
+	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: spireAgentResourceName, Namespace: namespace}, agentConfig); err != nil && !apierror.IsNotFound(err) {
+		return "", err
+	}
+	if agentConfig.Name != "" && !strings.Contains(agentConfig.Data["agent.conf"], renderedTrustDomain) {
+		return "waiting for the agent configuration to be updated", nil
+	}
+	daemonSet := &appsv1.DaemonSet{}
+	if err := r.ctrlClient.Get(ctx, types.NamespacedName{Name: spireAgentResourceName, Namespace: namespace}, daemonSet); err != nil && !apierror.IsNotFound(err) {
+		return "", err
+	}
+	if daemonSet.Name != "" && !daemonSetRolledOut(daemonSet) {
+		return "waiting for the agents to roll out", nil
+	}
+	return "", nil
+}
+
+// clusterSPIFFEIDsInTrustDomain returns the ClusterSPIFFEIDs whose spiffeIDTemplate hardcodes the
+// trust domain, which spire-controller-manager stops registering once it moves to another one
+func (r *ZeroTrustWorkloadIdentityManagerReconciler) clusterSPIFFEIDsInTrustDomain(ctx context.Context, trustDomain string) ([]string, error) {
+	var clusterSPIFFEIDs spiffev1alpha1.ClusterSPIFFEIDList
+	if err := r.ctrlClient.ListUncached(ctx, &clusterSPIFFEIDs); err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, clusterSPIFFEID := range clusterSPIFFEIDs.Items {
+		if strings.HasPrefix(strings.TrimSpace(clusterSPIFFEID.Spec.SPIFFEIDTemplate), "spiffe://"+trustDomain+"/") {
+			names = append(names, clusterSPIFFEID.Name)
+		}
+	}
+	return names, nil
+}
+
+// bundleTrustDomain returns the trust domain of the first CA of the PEM bundle carrying a SPIFFE
+// ID, or an empty string when none does
+func bundleTrustDomain(pemBundle string) string {
+	rest := []byte(pemBundle)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return ""
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		for _, uri := range cert.URIs {
+			if uri.Scheme == "spiffe" && uri.Host != "" {
+				return uri.Host
+			}
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package zero_trust_workload_identity_manager
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	spiffev1alpha1 "github.com/spiffe/spire-controller-manager/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/status"
+)
+
+// testSPIFFECACertificate returns a self-signed CA certificate of the trust domain in PEM
+func testSPIFFECACertificate(t *testing.T, trustDomain string) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: trustDomain},
+		URIs:                  []*url.URL{{Scheme: "spiffe", Host: trustDomain}},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestBundleTrustDomain(t *testing.T) {
+	if td := bundleTrustDomain(testCACertificate(t) + testSPIFFECACertificate(t, "old.example.org")); td != "old.example.org" {
+		t.Errorf("Expected old.example.org, got %q", td)
+	}
+	if td := bundleTrustDomain(testCACertificate(t)); td != "" {
+		t.Errorf("Expected no trust domain, got %q", td)
+	}
+}
+
+func TestReconcileTrustDomainMigration(t *testing.T) {
+	oldBundle := testSPIFFECACertificate(t, "old.example.org")
+	newBundle := testSPIFFECACertificate(t, "new.example.org")
+	previous := map[string]string{spireBundleDataKey: newBundle, previousBundleDataKey: oldBundle}
+	migrating := []metav1.Condition{{Type: TrustDomainMigration, Status: metav1.ConditionTrue}}
+	renderedNew := `"trust_domain": "new.example.org"`
+
+	tests := []struct {
+		name             string
+		trustDomain      string
+		stage            v1alpha2.TrustDomainMigrationStage
+		bundleData       map[string]string
+		serverConf       string
+		agentConf        string
+		existing         []metav1.Condition
+		clusterSPIFFEIDs []spiffev1alpha1.ClusterSPIFFEID
+		expectReason     string
+		expectInProgress bool
+		expectPrevious   string
+		expectPatch      bool
+	}{
+		{
+			name:        "not configured",
+			trustDomain: "old.example.org",
+			bundleData:  map[string]string{spireBundleDataKey: oldBundle},
+		},
+		{
+			name:             "prepare waits for the bundle",
+			trustDomain:      "old.example.org",
+			stage:            v1alpha2.TrustDomainMigrationStagePrepare,
+			expectReason:     TrustDomainMigrationReasonPreparing,
+			expectInProgress: true,
+		},
+		{
+			name:        "prepare preserves the bundle of the old trust domain",
+			trustDomain: "old.example.org",
+			stage:       v1alpha2.TrustDomainMigrationStagePrepare,
+			bundleData:  map[string]string{spireBundleDataKey: oldBundle},
+			clusterSPIFFEIDs: []spiffev1alpha1.ClusterSPIFFEID{
+				{ObjectMeta: metav1.ObjectMeta{Name: "hardcoded"}, Spec: spiffev1alpha1.ClusterSPIFFEIDSpec{SPIFFEIDTemplate: "spiffe://old.example.org/ns/{{ .PodMeta.Namespace }}"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "templated"}, Spec: spiffev1alpha1.ClusterSPIFFEIDSpec{SPIFFEIDTemplate: "spiffe://{{ .TrustDomain }}/ns/{{ .PodMeta.Namespace }}"}},
+			},
+			expectReason:   TrustDomainMigrationReasonPrepared,
+			expectPrevious: oldBundle,
+			expectPatch:    true,
+		},
+		{
+			name:             "prepare does not preserve the bundle of another trust domain",
+			trustDomain:      "old.example.org",
+			stage:            v1alpha2.TrustDomainMigrationStagePrepare,
+			bundleData:       map[string]string{spireBundleDataKey: newBundle},
+			expectReason:     TrustDomainMigrationReasonPreparing,
+			expectInProgress: true,
+		},
+		{
+			name:         "cutover without the preserved bundle",
+			trustDomain:  "old.example.org",
+			stage:        v1alpha2.TrustDomainMigrationStageCutover,
+			bundleData:   map[string]string{spireBundleDataKey: oldBundle},
+			expectReason: TrustDomainMigrationReasonFailed,
+		},
+		{
+			name:             "cutover waits for the operands",
+			trustDomain:      "old.example.org",
+			stage:            v1alpha2.TrustDomainMigrationStageCutover,
+			bundleData:       previous,
+			serverConf:       `"trust_domain": "old.example.org"`,
+			expectReason:     TrustDomainMigrationReasonCuttingOver,
+			expectInProgress: true,
+		},
+		{
+			name:         "cut over",
+			trustDomain:  "old.example.org",
+			stage:        v1alpha2.TrustDomainMigrationStageCutover,
+			bundleData:   previous,
+			serverConf:   renderedNew,
+			agentConf:    renderedNew,
+			expectReason: TrustDomainMigrationReasonCutOver,
+		},
+		{
+			name:         "complete removes the bundle of the old trust domain",
+			trustDomain:  "old.example.org",
+			stage:        v1alpha2.TrustDomainMigrationStageComplete,
+			bundleData:   previous,
+			expectReason: TrustDomainMigrationReasonComplete,
+			expectPatch:  true,
+		},
+		{
+			name:         "trust domain moved",
+			trustDomain:  "new.example.org",
+			stage:        v1alpha2.TrustDomainMigrationStageComplete,
+			bundleData:   map[string]string{spireBundleDataKey: newBundle},
+			existing:     migrating,
+			expectReason: TrustDomainMigrationReasonComplete,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &v1alpha2.ZeroTrustWorkloadIdentityManager{
+				Spec: v1alpha2.ZeroTrustWorkloadIdentityManagerSpec{TrustDomain: tt.trustDomain, BundleConfigMap: "spire-bundle"},
+				Status: v1alpha2.ZeroTrustWorkloadIdentityManagerStatus{
+					ConditionalStatus: v1alpha2.ConditionalStatus{Conditions: tt.existing},
+				},
+			}
+			if tt.stage != "" {
+				config.Spec.TrustDomainMigration = &v1alpha2.TrustDomainMigrationConfig{
+					OldTrustDomain: "old.example.org",
+					NewTrustDomain: "new.example.org",
+					Stage:          tt.stage,
+				}
+			}
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				switch o := obj.(type) {
+				case *corev1.ConfigMap:
+					switch key.Name {
+					case "spire-bundle":
+						o.Name = key.Name
+						o.Data = map[string]string{}
+						for k, v := range tt.bundleData {
+							o.Data[k] = v
+						}
+					case spireServerResourceName:
+						o.Name = key.Name
+						o.Data = map[string]string{"server.conf": tt.serverConf}
+					case spireAgentResourceName:
+						o.Name = key.Name
+						o.Data = map[string]string{"agent.conf": tt.agentConf}
+					}
+					return nil
+				case *appsv1.StatefulSet:
+					rolledOutServerStatefulSet().DeepCopyInto(o)
+					return nil
+				case *appsv1.DaemonSet:
+					rolledOutAgentDaemonSet("spire-bundle").DeepCopyInto(o)
+					return nil
+				}
+				return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+			}
+			fakeClient.ListUncachedStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				list.(*spiffev1alpha1.ClusterSPIFFEIDList).Items = tt.clusterSPIFFEIDs
+				return nil
+			}
+			statusMgr := status.NewManager(fakeClient)
+
+			inProgress := newTestReconciler(fakeClient).reconcileTrustDomainMigration(context.Background(), config, statusMgr)
+			if inProgress != tt.expectInProgress {
+				t.Errorf("Expected in progress %v, got %v", tt.expectInProgress, inProgress)
+			}
+			cond, ok := statusMgr.GetCondition(TrustDomainMigration)
+			if tt.expectReason == "" {
+				if ok {
+					t.Errorf("Expected no condition, got %v", cond)
+				}
+			} else if cond.Reason != tt.expectReason {
+				t.Errorf("Expected reason %s, got %s: %s", tt.expectReason, cond.Reason, cond.Message)
+			}
+			if len(tt.clusterSPIFFEIDs) > 0 && (!strings.Contains(cond.Message, "hardcoded") || strings.Contains(cond.Message, "templated")) {
+				t.Errorf("Expected only the hardcoded ClusterSPIFFEID to be reported, got %q", cond.Message)
+			}
+			if (fakeClient.PatchCallCount() == 1) != tt.expectPatch {
+				t.Fatalf("Expected patch %v, got %d patches", tt.expectPatch, fakeClient.PatchCallCount())
+			}
+			if tt.expectPatch {
+				_, obj, _, _ := fakeClient.PatchArgsForCall(0)
+				if patched := obj.(*corev1.ConfigMap); patched.Data[previousBundleDataKey] != tt.expectPrevious {
+					t.Errorf("Expected the previous bundle %q, got %q", tt.expectPrevious, patched.Data[previousBundleDataKey])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package assets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	verifyOnce sync.Once
+	verifyErr  error
+)
+
+// Verify checks the embedded assets against the checksums recorded when they were embedded, to
+// detect a corrupted operator image or assets regenerated without their checksums. The result
+// is computed once.
+func Verify() error {
+	verifyOnce.Do(func() { verifyErr = verify(AssetNames(), Asset, assetChecksums) })
+	return verifyErr
+}
+
+func verify(names []string, asset func(string) ([]byte, error), checksums map[string]string) error {
+	var problems []string
+	embedded := map[string]bool{}
+	for _, name := range names {
+		embedded[name] = true
+		data, err := asset(name)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s cannot be read: %v", name, err))
+			continue
+		}
+		expected, ok := checksums[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s has no recorded checksum", name))
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if actual := hex.EncodeToString(sum[:]); actual != expected {
+			problems = append(problems, fmt.Sprintf("%s has checksum %s, expected %s", name, actual, expected))
+		}
+	}
+	for name := range checksums {
+		if !embedded[name] {
+			problems = append(problems, fmt.Sprintf("%s is not embedded", name))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("static assets failed the integrity check: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
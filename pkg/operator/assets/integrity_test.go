@@ -0,0 +1,46 @@
+package assets
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestVerify fails when the assets were regenerated without their checksums, see make update-bindata
+func TestVerify(t *testing.T) {
+	if err := Verify(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyDetectsMismatches(t *testing.T) {
+	assets := map[string][]byte{
+		"spire-agent/service.yaml": []byte("kind: Service\n"),
+		"spire-agent/role.yaml":    []byte("kind: Role\n"),
+	}
+	asset := func(name string) ([]byte, error) {
+		if data, ok := assets[name]; ok {
+			return data, nil
+		}
+		return nil, errors.New("not found")
+	}
+	checksums := map[string]string{
+		// Recorded for other content
+		"spire-agent/service.yaml": "8b5f3f2d41c7e6b0c1d9a2f4e7b3c5d6a9e8f1b2c3d4e5f6a7b8c9d0e1f2a3b4",
+		"spire-agent/removed.yaml": "00",
+	}
+
+	err := verify([]string{"spire-agent/service.yaml", "spire-agent/role.yaml"}, asset, checksums)
+	if err == nil {
+		t.Fatal("Expected the integrity check to fail")
+	}
+	for _, expected := range []string{
+		"spire-agent/service.yaml has checksum",
+		"spire-agent/role.yaml has no recorded checksum",
+		"spire-agent/removed.yaml is not embedded",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected the error to contain %q, got %v", expected, err)
+		}
+	}
+}
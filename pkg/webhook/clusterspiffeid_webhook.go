@@ -0,0 +1,120 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	spiffev1alpha1 "github.com/spiffe/spire-controller-manager/api/v1alpha1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	spiffeIDPolicy "github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/spiffe-id-policy"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// spiffeIDScheme is the prefix of every SPIFFE ID
+const spiffeIDScheme = "spiffe://"
+
+// trustDomainTemplate matches the template expression spire-controller-manager expands to the
+// trust domain it is configured with, e.g. "{{ .TrustDomain }}"
+var trustDomainTemplate = regexp.MustCompile(`^\{\{-?\s*\.TrustDomain\s*-?\}\}$`)
+
+// +kubebuilder:webhook:path=/validate-spire-spiffe-io-v1alpha1-clusterspiffeid,mutating=false,failurePolicy=ignore,sideEffects=None,groups=spire.spiffe.io,resources=clusterspiffeids,verbs=create;update,versions=v1alpha1,name=vclusterspiffeid.operator.openshift.io,admissionReviewVersions=v1
+
+// ClusterSPIFFEIDCustomValidator refuses ClusterSPIFFEIDs whose SPIFFE ID template is outside the
+// trust domain of the ZeroTrustWorkloadIdentityManager: SPIRE does not register such entries, and
+// the failure is otherwise only visible in the spire-controller-manager logs. The templates outside
+// the paths the SPIFFEIDPolicies allow them are refused as well.
+type ClusterSPIFFEIDCustomValidator struct {
+	ctrlClient customClient.CustomCtrlClient
+}
+
+var _ admission.CustomValidator = &ClusterSPIFFEIDCustomValidator{}
+
+// SetupClusterSPIFFEIDWebhookWithManager registers the ClusterSPIFFEID validating webhook with the manager
+func SetupClusterSPIFFEIDWebhookWithManager(mgr ctrl.Manager) error {
+	c, err := customClient.NewCustomClient(mgr)
+	if err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&spiffev1alpha1.ClusterSPIFFEID{}).
+		WithValidator(&ClusterSPIFFEIDCustomValidator{ctrlClient: c}).
+		Complete()
+}
+
+// ValidateCreate checks the SPIFFE ID template of the new ClusterSPIFFEID
+func (v *ClusterSPIFFEIDCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, obj)
+}
+
+// ValidateUpdate checks the SPIFFE ID template of the updated ClusterSPIFFEID
+func (v *ClusterSPIFFEIDCustomValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, newObj)
+}
+
+// ValidateDelete allows every deletion
+func (v *ClusterSPIFFEIDCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *ClusterSPIFFEIDCustomValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	clusterSPIFFEID, ok := obj.(*spiffev1alpha1.ClusterSPIFFEID)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterSPIFFEID object but got %T", obj)
+	}
+
+	warnings, err := v.checkTrustDomain(ctx, clusterSPIFFEID)
+	if err != nil {
+		return warnings, err
+	}
+	policyWarnings, err := checkSPIFFEIDPolicies(ctx, v.ctrlClient, spiffeIDPolicy.EntryFromClusterSPIFFEID(clusterSPIFFEID))
+	return append(warnings, policyWarnings...), err
+}
+
+// checkTrustDomain checks the SPIFFE ID template is in the trust domain of the cluster
+func (v *ClusterSPIFFEIDCustomValidator) checkTrustDomain(ctx context.Context, clusterSPIFFEID *spiffev1alpha1.ClusterSPIFFEID) (admission.Warnings, error) {
+	var config v1alpha2.ZeroTrustWorkloadIdentityManager
+	err := v.ctrlClient.Get(ctx, types.NamespacedName{Name: "cluster"}, &config)
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the trust domain: %w", err)
+	}
+	// The workloads are registered in the new trust domain of a migration once it is cut over
+	trustDomain := utils.TrustDomain(&config)
+	if trustDomain == "" {
+		return nil, nil
+	}
+	return checkSPIFFEIDTemplate(clusterSPIFFEID.Name, clusterSPIFFEID.Spec.SPIFFEIDTemplate, trustDomain)
+}
+
+// checkSPIFFEIDTemplate refuses a template whose trust domain is neither the configured trust
+// domain nor the {{ .TrustDomain }} expression. A trust domain computed by another template
+// expression cannot be checked before it is rendered, so it is only warned about.
+func checkSPIFFEIDTemplate(name, template, trustDomain string) (admission.Warnings, error) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(template), spiffeIDScheme)
+	if !ok {
+		return nil, fmt.Errorf("ClusterSPIFFEID %s: spiffeIDTemplate %q must start with %s%s/", name, template, spiffeIDScheme, trustDomain)
+	}
+	templateTrustDomain, _, _ := strings.Cut(rest, "/")
+	switch {
+	case templateTrustDomain == trustDomain, trustDomainTemplate.MatchString(templateTrustDomain):
+		return nil, nil
+	case strings.Contains(templateTrustDomain, "{{"):
+		return admission.Warnings{fmt.Sprintf("ClusterSPIFFEID %s: the trust domain of spiffeIDTemplate %q cannot be checked before it is rendered, SPIRE only registers entries in the trust domain %s",
+			name, template, trustDomain)}, nil
+	default:
+		return nil, fmt.Errorf("ClusterSPIFFEID %s: spiffeIDTemplate %q is outside the trust domain %s, SPIRE would not register its entries: use %s{{ .TrustDomain }}/... instead",
+			name, template, trustDomain, spiffeIDScheme)
+	}
+}
@@ -0,0 +1,85 @@
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	spiffev1alpha1 "github.com/spiffe/spire-controller-manager/api/v1alpha1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+)
+
+func TestClusterSPIFFEIDValidateCreate(t *testing.T) {
+	tests := []struct {
+		name           string
+		template       string
+		noConfig       bool
+		expectErr      string
+		expectWarnings bool
+	}{
+		{
+			name:     "trust domain template",
+			template: "spiffe://{{ .TrustDomain }}/ns/{{ .PodMeta.Namespace }}/sa/{{ .PodSpec.ServiceAccountName }}",
+		},
+		{
+			name:     "configured trust domain",
+			template: "spiffe://example.org/ns/{{ .PodMeta.Namespace }}",
+		},
+		{
+			name:      "other trust domain",
+			template:  "spiffe://other.org/ns/{{ .PodMeta.Namespace }}",
+			expectErr: "outside the trust domain example.org",
+		},
+		{
+			name:      "not a SPIFFE ID",
+			template:  "https://example.org/ns/{{ .PodMeta.Namespace }}",
+			expectErr: "must start with spiffe://example.org/",
+		},
+		{
+			name:           "computed trust domain",
+			template:       "spiffe://{{ .PodMeta.Namespace }}.example.org/workload",
+			expectWarnings: true,
+		},
+		{
+			name:     "no trust domain configured",
+			template: "spiffe://other.org/workload",
+			noConfig: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.GetStub = func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				if tt.noConfig {
+					return kerrors.NewNotFound(schema.GroupResource{Group: "operator.openshift.io", Resource: "zerotrustworkloadidentitymanagers"}, "cluster")
+				}
+				obj.(*v1alpha2.ZeroTrustWorkloadIdentityManager).Spec.TrustDomain = "example.org"
+				return nil
+			}
+			validator := &ClusterSPIFFEIDCustomValidator{ctrlClient: fakeClient}
+			clusterSPIFFEID := &spiffev1alpha1.ClusterSPIFFEID{
+				ObjectMeta: metav1.ObjectMeta{Name: "workloads"},
+				Spec:       spiffev1alpha1.ClusterSPIFFEIDSpec{SPIFFEIDTemplate: tt.template},
+			}
+
+			warnings, err := validator.ValidateCreate(context.Background(), clusterSPIFFEID)
+			if tt.expectErr == "" && err != nil {
+				t.Fatalf("Expected the ClusterSPIFFEID to be allowed, got %v", err)
+			}
+			if tt.expectErr != "" && (err == nil || !strings.Contains(err.Error(), tt.expectErr)) {
+				t.Fatalf("Expected error containing %q, got %v", tt.expectErr, err)
+			}
+			if (len(warnings) > 0) != tt.expectWarnings {
+				t.Errorf("Expected warnings=%v, got %v", tt.expectWarnings, warnings)
+			}
+		})
+	}
+}
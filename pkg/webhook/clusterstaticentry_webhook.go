@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	spiffev1alpha1 "github.com/spiffe/spire-controller-manager/api/v1alpha1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	spiffeIDPolicy "github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/spiffe-id-policy"
+)
+
+// +kubebuilder:webhook:path=/validate-spire-spiffe-io-v1alpha1-clusterstaticentry,mutating=false,failurePolicy=ignore,sideEffects=None,groups=spire.spiffe.io,resources=clusterstaticentries,verbs=create;update,versions=v1alpha1,name=vclusterstaticentry.operator.openshift.io,admissionReviewVersions=v1
+
+// ClusterStaticEntryCustomValidator refuses ClusterStaticEntries whose SPIFFE ID is outside the
+// paths the SPIFFEIDPolicies allow them.
+type ClusterStaticEntryCustomValidator struct {
+	ctrlClient customClient.CustomCtrlClient
+}
+
+var _ admission.CustomValidator = &ClusterStaticEntryCustomValidator{}
+
+// SetupClusterStaticEntryWebhookWithManager registers the ClusterStaticEntry validating webhook with the manager
+func SetupClusterStaticEntryWebhookWithManager(mgr ctrl.Manager) error {
+	c, err := customClient.NewCustomClient(mgr)
+	if err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&spiffev1alpha1.ClusterStaticEntry{}).
+		WithValidator(&ClusterStaticEntryCustomValidator{ctrlClient: c}).
+		Complete()
+}
+
+// ValidateCreate checks the SPIFFE ID of the new ClusterStaticEntry
+func (v *ClusterStaticEntryCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, obj)
+}
+
+// ValidateUpdate checks the SPIFFE ID of the updated ClusterStaticEntry
+func (v *ClusterStaticEntryCustomValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, newObj)
+}
+
+// ValidateDelete allows every deletion
+func (v *ClusterStaticEntryCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *ClusterStaticEntryCustomValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	entry, ok := obj.(*spiffev1alpha1.ClusterStaticEntry)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterStaticEntry object but got %T", obj)
+	}
+	return checkSPIFFEIDPolicies(ctx, v.ctrlClient, spiffeIDPolicy.EntryFromClusterStaticEntry(entry))
+}
+
+// checkSPIFFEIDPolicies refuses the entry when it violates a SPIFFEIDPolicy enforced with Deny,
+// and warns about the violations of the policies enforced with Warn
+func checkSPIFFEIDPolicies(ctx context.Context, c customClient.CustomCtrlClient, entry spiffeIDPolicy.Entry) (admission.Warnings, error) {
+	var policies v1alpha2.SPIFFEIDPolicyList
+	if err := c.List(ctx, &policies); err != nil {
+		return nil, fmt.Errorf("failed to list SPIFFEIDPolicies: %w", err)
+	}
+
+	var warnings admission.Warnings
+	var denials []string
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		rules, err := spiffeIDPolicy.Check(policy, entry)
+		if err != nil {
+			// The policy is reported invalid by its audit
+			warnings = append(warnings, fmt.Sprintf("SPIFFEIDPolicy %s is not enforced: %v", policy.Name, err))
+			continue
+		}
+		if len(rules) == 0 {
+			continue
+		}
+		message := spiffeIDPolicy.Message(policy, entry, rules)
+		if policy.Spec.Enforcement == v1alpha2.SPIFFEIDPolicyEnforcementWarn {
+			warnings = append(warnings, message)
+			continue
+		}
+		denials = append(denials, message)
+	}
+	if len(denials) > 0 {
+		return warnings, errors.New(strings.Join(denials, "; "))
+	}
+	return warnings, nil
+}
@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	spiffev1alpha1 "github.com/spiffe/spire-controller-manager/api/v1alpha1"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+)
+
+func TestClusterStaticEntryValidateCreate(t *testing.T) {
+	tests := []struct {
+		name           string
+		spiffeID       string
+		enforcement    v1alpha2.SPIFFEIDPolicyEnforcement
+		expectErr      string
+		expectWarnings bool
+	}{
+		{
+			name:     "allowed path",
+			spiffeID: "spiffe://example.org/ns/payments/db",
+		},
+		{
+			name:      "denied path",
+			spiffeID:  "spiffe://example.org/ns/billing/db",
+			expectErr: "outside the paths allowed by rules payments of SPIFFEIDPolicy team-paths",
+		},
+		{
+			name:           "warned path",
+			spiffeID:       "spiffe://example.org/ns/billing/db",
+			enforcement:    v1alpha2.SPIFFEIDPolicyEnforcementWarn,
+			expectWarnings: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+				list.(*v1alpha2.SPIFFEIDPolicyList).Items = []v1alpha2.SPIFFEIDPolicy{{
+					ObjectMeta: metav1.ObjectMeta{Name: "team-paths"},
+					Spec: v1alpha2.SPIFFEIDPolicySpec{
+						Enforcement: tt.enforcement,
+						Rules: []v1alpha2.SPIFFEIDPolicyRule{{
+							Name:                "payments",
+							EntrySelector:       metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+							AllowedPathPrefixes: []string{"/ns/payments"},
+						}},
+					},
+				}}
+				return nil
+			}
+			validator := &ClusterStaticEntryCustomValidator{ctrlClient: fakeClient}
+			entry := &spiffev1alpha1.ClusterStaticEntry{
+				ObjectMeta: metav1.ObjectMeta{Name: "payments-db", Labels: map[string]string{"team": "payments"}},
+				Spec:       spiffev1alpha1.ClusterStaticEntrySpec{SPIFFEID: tt.spiffeID},
+			}
+
+			warnings, err := validator.ValidateCreate(context.Background(), entry)
+			if tt.expectErr == "" && err != nil {
+				t.Fatalf("Expected the ClusterStaticEntry to be allowed, got %v", err)
+			}
+			if tt.expectErr != "" && (err == nil || !strings.Contains(err.Error(), tt.expectErr)) {
+				t.Fatalf("Expected error containing %q, got %v", tt.expectErr, err)
+			}
+			if (len(warnings) > 0) != tt.expectWarnings {
+				t.Errorf("Expected warnings %v, got %v", tt.expectWarnings, warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package webhook
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+)
+
+// SetupConversionWebhookWithManager registers the conversion webhook of the kinds whose
+// v1alpha1 schema differs from v1alpha2, the hub version. Their CRDs request the conversion
+// of v1alpha1 objects from the webhook, the other kinds are converted by the API server.
+func SetupConversionWebhookWithManager(mgr ctrl.Manager) error {
+	for _, obj := range []client.Object{
+		&v1alpha2.ZeroTrustWorkloadIdentityManager{},
+		&v1alpha2.SpireServer{},
+		&v1alpha2.SpireAgent{},
+		&v1alpha2.SpireOIDCDiscoveryProvider{},
+		&v1alpha2.SpiffeCSIDriver{},
+	} {
+		if err := ctrl.NewWebhookManagedBy(mgr).For(obj).Complete(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
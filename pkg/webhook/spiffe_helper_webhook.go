@@ -0,0 +1,70 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	spiffeHelper "github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/spiffe-helper"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// The webhook only receives the pods labelled with ztwim.openshift.io/spiffe-helper. The marker has
+// no objectSelector option, it is set by config/webhook/spiffe_helper_objectselector_patch.yaml.
+// +kubebuilder:webhook:path=/mutate--v1-pod,mutating=true,failurePolicy=ignore,sideEffects=None,groups="",resources=pods,verbs=create,versions=v1,name=mspiffehelper.operator.openshift.io,admissionReviewVersions=v1,reinvocationPolicy=Never
+
+// SpiffeHelperInjector injects the spiffe-helper sidecar into the pods labelled with
+// ztwim.openshift.io/spiffe-helper, configured by the SpiffeHelperConfig of their namespace the
+// label names. Pods without the label are left as is.
+type SpiffeHelperInjector struct {
+	ctrlClient customClient.CustomCtrlClient
+}
+
+var _ admission.CustomDefaulter = &SpiffeHelperInjector{}
+
+// SetupSpiffeHelperWebhookWithManager registers the spiffe-helper injection webhook with the manager
+func SetupSpiffeHelperWebhookWithManager(mgr ctrl.Manager) error {
+	c, err := customClient.NewCustomClient(mgr)
+	if err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&corev1.Pod{}).
+		WithDefaulter(&SpiffeHelperInjector{ctrlClient: c}).
+		Complete()
+}
+
+// Default injects the sidecar into the labelled pods. A pod naming a SpiffeHelperConfig which does
+// not exist is refused, as its applications would wait for the files of the helper forever.
+func (i *SpiffeHelperInjector) Default(ctx context.Context, obj runtime.Object) error {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return fmt.Errorf("expected a Pod object but got %T", obj)
+	}
+	configName, ok := pod.Labels[utils.SpiffeHelperLabel]
+	if !ok {
+		return nil
+	}
+
+	// Pods created by their controllers have no namespace until admitted
+	namespace := pod.Namespace
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.Namespace != "" {
+		namespace = req.Namespace
+	}
+	var config v1alpha2.SpiffeHelperConfig
+	if err := i.ctrlClient.Get(ctx, types.NamespacedName{Name: configName, Namespace: namespace}, &config); err != nil {
+		if kerrors.IsNotFound(err) {
+			return fmt.Errorf("SpiffeHelperConfig %s named by the %s label of the pod does not exist in namespace %s", configName, utils.SpiffeHelperLabel, namespace)
+		}
+		return fmt.Errorf("failed to get SpiffeHelperConfig %s: %w", configName, err)
+	}
+	return spiffeHelper.Inject(pod, &config, utils.GetSpiffeHelperImage())
+}
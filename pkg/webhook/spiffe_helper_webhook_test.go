@@ -0,0 +1,140 @@
+package webhook
+
+import (
+	"context"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func TestSpiffeHelperInjectorDefault(t *testing.T) {
+	t.Setenv(utils.SpiffeHelperImageEnv, "ghcr.io/spiffe/spiffe-helper:test")
+	tests := []struct {
+		name         string
+		labels       map[string]string
+		noConfig     bool
+		expectErr    string
+		expectInject bool
+	}{
+		{
+			name: "pod not labelled",
+		},
+		{
+			name:         "pod labelled",
+			labels:       map[string]string{utils.SpiffeHelperLabel: "legacy-app"},
+			expectInject: true,
+		},
+		{
+			name:      "configuration not found",
+			labels:    map[string]string{utils.SpiffeHelperLabel: "legacy-app"},
+			noConfig:  true,
+			expectErr: "SpiffeHelperConfig legacy-app named by the ztwim.openshift.io/spiffe-helper label of the pod does not exist in namespace apps",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				if tt.noConfig {
+					return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+				}
+				obj.(*v1alpha2.SpiffeHelperConfig).ObjectMeta = metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}
+				return nil
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "apps", Labels: tt.labels},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+			}
+
+			err := (&SpiffeHelperInjector{ctrlClient: fakeClient}).Default(context.Background(), pod)
+			if tt.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+					t.Fatalf("Expected error %q, got %v", tt.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if injected := len(pod.Spec.InitContainers) == 1; injected != tt.expectInject {
+				t.Errorf("Expected injected %v, got %v", tt.expectInject, pod.Spec.InitContainers)
+			}
+			if !tt.expectInject && fakeClient.GetCallCount() != 0 {
+				t.Error("Expected the configuration not to be read for pods not labelled")
+			}
+		})
+	}
+}
+
+// TestSpiffeHelperWebhookObjectSelector checks that the injection webhook is only called for the
+// pods labelled for injection, both in the kustomize manifests and in the bundle
+func TestSpiffeHelperWebhookObjectSelector(t *testing.T) {
+	const webhookName = "mspiffehelper.operator.openshift.io"
+	expectSelector := func(source string, selector *metav1.LabelSelector) {
+		t.Helper()
+		if selector == nil || len(selector.MatchLabels) != 0 || len(selector.MatchExpressions) != 1 {
+			t.Fatalf("Expected %s to select on the %s label only, got %v", source, utils.SpiffeHelperLabel, selector)
+		}
+		expression := selector.MatchExpressions[0]
+		if expression.Key != utils.SpiffeHelperLabel || expression.Operator != metav1.LabelSelectorOpExists {
+			t.Errorf("Expected %s to select the pods with the %s label, got %v", source, utils.SpiffeHelperLabel, expression)
+		}
+	}
+
+	var generated, patch admissionregistrationv1.MutatingWebhookConfiguration
+	readYAML(t, "../../config/webhook/manifests.yaml", &generated)
+	readYAML(t, "../../config/webhook/spiffe_helper_objectselector_patch.yaml", &patch)
+	if !slices.ContainsFunc(generated.Webhooks, func(w admissionregistrationv1.MutatingWebhook) bool { return w.Name == webhookName }) {
+		t.Fatalf("Expected %s in the generated webhook manifests", webhookName)
+	}
+	if patch.Name != generated.Name || len(patch.Webhooks) != 1 || patch.Webhooks[0].Name != webhookName {
+		t.Fatalf("Expected the patch to target %s of %s, got %v", webhookName, generated.Name, patch)
+	}
+	expectSelector("the kustomize patch", patch.Webhooks[0].ObjectSelector)
+
+	var csv struct {
+		Spec struct {
+			WebhookDefinitions []struct {
+				GenerateName   string                `json:"generateName"`
+				ObjectSelector *metav1.LabelSelector `json:"objectSelector"`
+			} `json:"webhookdefinitions"`
+		} `json:"spec"`
+	}
+	readYAML(t, "../../bundle/manifests/zero-trust-workload-identity-manager.clusterserviceversion.yaml", &csv)
+	found := false
+	for _, definition := range csv.Spec.WebhookDefinitions {
+		if definition.GenerateName == webhookName {
+			found = true
+			expectSelector("the CSV webhook definition", definition.ObjectSelector)
+		}
+	}
+	if !found {
+		t.Errorf("Expected %s in the CSV webhook definitions", webhookName)
+	}
+}
+
+func readYAML(t *testing.T, path string, obj interface{}) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	// The generated manifests start with a document separator
+	if err := yaml.Unmarshal([]byte(strings.TrimPrefix(string(data), "---\n")), obj); err != nil {
+		t.Fatalf("Failed to decode %s: %v", path, err)
+	}
+}
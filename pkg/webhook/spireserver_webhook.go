@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	customClient "github.com/openshift/zero-trust-workload-identity-manager/pkg/client"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+// +kubebuilder:webhook:path=/validate-operator-openshift-io-v1alpha2-spireserver,mutating=false,failurePolicy=ignore,sideEffects=None,groups=operator.openshift.io,resources=spireservers,verbs=delete,versions=v1alpha2,name=vspireserver.operator.openshift.io,admissionReviewVersions=v1
+
+// SpireServerCustomValidator refuses the deletion of the SpireServer while SPIRE agents are
+// still attested to it, since removing the server would break workload identity cluster-wide.
+// Deletion is allowed regardless when the force-delete annotation is set to "true".
+type SpireServerCustomValidator struct {
+	ctrlClient customClient.CustomCtrlClient
+}
+
+var _ admission.CustomValidator = &SpireServerCustomValidator{}
+
+// SetupSpireServerWebhookWithManager registers the SpireServer validating webhook with the manager
+func SetupSpireServerWebhookWithManager(mgr ctrl.Manager) error {
+	c, err := customClient.NewCustomClient(mgr)
+	if err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha2.SpireServer{}).
+		WithValidator(&SpireServerCustomValidator{ctrlClient: c}).
+		Complete()
+}
+
+// ValidateCreate allows every creation, the spec is validated by the CRD schema
+func (v *SpireServerCustomValidator) ValidateCreate(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateUpdate allows every update, the spec is validated by the CRD schema
+func (v *SpireServerCustomValidator) ValidateUpdate(_ context.Context, _, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateDelete refuses the deletion while the agent DaemonSets, that of every agent pool
+// included, report ready, and therefore attested, agents
+func (v *SpireServerCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	server, ok := obj.(*v1alpha2.SpireServer)
+	if !ok {
+		return nil, fmt.Errorf("expected a SpireServer object but got %T", obj)
+	}
+
+	if utils.StringToBool(server.Annotations[utils.ForceDeleteAnnotation]) {
+		return admission.Warnings{fmt.Sprintf("SpireServer %s is force deleted: attested SPIRE agents will lose access to the server", server.Name)}, nil
+	}
+
+	var agents appsv1.DaemonSetList
+	if err := v.ctrlClient.List(ctx, &agents, client.InNamespace(utils.GetOperatorNamespace()),
+		utils.WorkloadSelector(utils.SpireAgentLabels(nil))); err != nil {
+		return nil, fmt.Errorf("failed to check for attested SPIRE agents: %w", err)
+	}
+
+	var ready int32
+	for _, daemonSet := range agents.Items {
+		ready += daemonSet.Status.NumberReady
+	}
+	if ready > 0 {
+		return nil, fmt.Errorf("SpireServer %s cannot be deleted while %d SPIRE agent(s) are attested: delete the SpireAgents first, or set the %s annotation to \"true\" to delete it anyway",
+			server.Name, ready, utils.ForceDeleteAnnotation)
+	}
+	return nil, nil
+}
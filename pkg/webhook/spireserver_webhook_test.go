@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/zero-trust-workload-identity-manager/api/v1alpha2"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/client/fakes"
+	"github.com/openshift/zero-trust-workload-identity-manager/pkg/controller/utils"
+)
+
+func TestSpireServerValidateDelete(t *testing.T) {
+	tests := []struct {
+		name           string
+		annotations    map[string]string
+		readyAgents    []int32
+		listErr        error
+		expectErr      string
+		expectWarnings bool
+	}{
+		{
+			name: "no agent daemonset",
+		},
+		{
+			name:        "no attested agents",
+			readyAgents: []int32{0},
+		},
+		{
+			name:        "attested agents",
+			readyAgents: []int32{3},
+			expectErr:   "while 3 SPIRE agent(s) are attested",
+		},
+		{
+			name:        "attested agents of agent pools",
+			readyAgents: []int32{0, 2, 1},
+			expectErr:   "while 3 SPIRE agent(s) are attested",
+		},
+		{
+			name:           "attested agents with force annotation",
+			annotations:    map[string]string{utils.ForceDeleteAnnotation: "true"},
+			readyAgents:    []int32{3},
+			expectWarnings: true,
+		},
+		{
+			name:      "daemonset lookup fails",
+			listErr:   errors.New("connection refused"),
+			expectErr: "failed to check for attested SPIRE agents",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakeCustomCtrlClient{}
+			fakeClient.ListStub = func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+				listOpts := &client.ListOptions{}
+				listOpts.ApplyOptions(opts)
+				if !listOpts.LabelSelector.Matches(labels.Set(utils.SpireAgentLabels(map[string]string{utils.AgentPoolLabel: "gpu"}))) {
+					t.Errorf("Expected the agent DaemonSets to be selected, got %v", listOpts.LabelSelector)
+				}
+				if tt.listErr != nil {
+					return tt.listErr
+				}
+				for _, ready := range tt.readyAgents {
+					list.(*appsv1.DaemonSetList).Items = append(list.(*appsv1.DaemonSetList).Items,
+						appsv1.DaemonSet{Status: appsv1.DaemonSetStatus{NumberReady: ready}})
+				}
+				return nil
+			}
+			validator := &SpireServerCustomValidator{ctrlClient: fakeClient}
+			server := &v1alpha2.SpireServer{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Annotations: tt.annotations}}
+
+			warnings, err := validator.ValidateDelete(context.Background(), server)
+			if tt.expectErr == "" && err != nil {
+				t.Fatalf("Expected deletion to be allowed, got %v", err)
+			}
+			if tt.expectErr != "" && (err == nil || !strings.Contains(err.Error(), tt.expectErr)) {
+				t.Fatalf("Expected error containing %q, got %v", tt.expectErr, err)
+			}
+			if (len(warnings) > 0) != tt.expectWarnings {
+				t.Errorf("Expected warnings=%v, got %v", tt.expectWarnings, warnings)
+			}
+		})
+	}
+}
+
+func TestSpireServerValidateDelete_WrongType(t *testing.T) {
+	validator := &SpireServerCustomValidator{ctrlClient: &fakes.FakeCustomCtrlClient{}}
+	if _, err := validator.ValidateDelete(context.Background(), &v1alpha2.SpireAgent{}); err == nil {
+		t.Error("Expected an error for a non-SpireServer object")
+	}
+}
@@ -346,6 +346,23 @@ var _ = Describe("Zero Trust Workload Identity Manager", Ordered, func() {
 			fmt.Fprintf(GinkgoWriter, "Upgradeable condition is correctly set: Status=%s, Reason=%s\n", condition.Status, condition.Reason)
 		})
 
+		It("Degraded should be False and OperandsAvailable should be True when all operands are ready", func() {
+			By("Verifying Degraded condition details")
+			degraded, err := utils.GetOperatorConditionCondition(testCtx, k8sClient, utils.OperatorNamespace, operatorConditionName, operatorv1alpha1.Degraded)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(degraded.Status).To(Equal(metav1.ConditionFalse), "Degraded should be %s", metav1.ConditionFalse)
+			Expect(degraded.Reason).To(Equal(operatorv1alpha1.ReasonReady), "Degraded reason should be %s", operatorv1alpha1.ReasonReady)
+
+			By("Verifying OperandsAvailable condition details")
+			operandsAvailable, err := utils.GetOperatorConditionCondition(testCtx, k8sClient, utils.OperatorNamespace, operatorConditionName, "OperandsAvailable")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(operandsAvailable.Status).To(Equal(metav1.ConditionTrue), "OperandsAvailable should be %s", metav1.ConditionTrue)
+			for _, kind := range []string{"SpireServer", "SpireAgent", "SpiffeCSIDriver", "SpireOIDCDiscoveryProvider"} {
+				Expect(operandsAvailable.Message).To(ContainSubstring(kind+": Ready"), "OperandsAvailable message should summarize %s", kind)
+			}
+			fmt.Fprintf(GinkgoWriter, "OperatorCondition conditions are correctly set: Degraded=%s, OperandsAvailable=%s\n", degraded.Status, operandsAvailable.Status)
+		})
+
 		It("Upgradeable should be False when SPIRE Server pod is deleted and recover to True after recovery", func() {
 			By("Getting SPIRE Server pod")
 			pods, err := clientset.CoreV1().Pods(utils.OperatorNamespace).List(testCtx, metav1.ListOptions{LabelSelector: utils.SpireServerPodLabel})
@@ -840,6 +857,27 @@ var _ = Describe("Zero Trust Workload Identity Manager", Ordered, func() {
 			Expect(logLevel).To(Equal(newLogLevel), "log_level should be updated to %s", newLogLevel)
 		})
 
+		It("SPIRE Server TTLs longer than the CA validity are rejected", func() {
+			By("Getting SpireServer object")
+			spireServer := &operatorv1alpha1.SpireServer{}
+			err := k8sClient.Get(testCtx, client.ObjectKey{Name: "cluster"}, spireServer)
+			Expect(err).NotTo(HaveOccurred(), "failed to get SpireServer object")
+
+			By("Updating SpireServer object with a defaultJWTValidity longer than caValidity")
+			err = utils.UpdateCRWithRetry(testCtx, k8sClient, spireServer, func() {
+				spireServer.Spec.DefaultJWTValidity = metav1.Duration{Duration: spireServer.Spec.CAValidity.Duration + time.Hour}
+			})
+			Expect(err).To(HaveOccurred(), "SpireServer with a defaultJWTValidity longer than caValidity should be rejected")
+			Expect(err.Error()).To(ContainSubstring("defaultJWTValidity must be a positive duration shorter than caValidity"))
+
+			By("Updating SpireServer object with an agentTTL longer than caValidity")
+			err = utils.UpdateCRWithRetry(testCtx, k8sClient, spireServer, func() {
+				spireServer.Spec.AgentTTL = &metav1.Duration{Duration: spireServer.Spec.CAValidity.Duration + time.Hour}
+			})
+			Expect(err).To(HaveOccurred(), "SpireServer with an agentTTL longer than caValidity should be rejected")
+			Expect(err.Error()).To(ContainSubstring("agentTTL must be a positive duration not exceeding caValidity"))
+		})
+
 		It("SPIRE Server custom labels can be configured through CR and propagated to pod", func() {
 			By("Getting SpireServer object")
 			spireServer := &operatorv1alpha1.SpireServer{}
@@ -1118,6 +1156,49 @@ var _ = Describe("Zero Trust Workload Identity Manager", Ordered, func() {
 			Expect(logLevel).To(Equal(newLogLevel), "log_level should be updated to %s", newLogLevel)
 		})
 
+		It("SPIRE Agent SVID cache sizes can be configured through CR", func() {
+			By("Getting SpireAgent object")
+			spireAgent := &operatorv1alpha1.SpireAgent{}
+			err := k8sClient.Get(testCtx, client.ObjectKey{Name: "cluster"}, spireAgent)
+			Expect(err).NotTo(HaveOccurred(), "failed to get SpireAgent object")
+
+			// record initial generation of the DaemonSet before updating SpireAgent object
+			daemonset, err := clientset.AppsV1().DaemonSets(utils.OperatorNamespace).Get(testCtx, utils.SpireAgentDaemonSetName, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred(), "failed to get SpireAgent DaemonSet")
+			initialGen := daemonset.Generation
+
+			By("Patching SpireAgent object with SVID cache sizes")
+			err = utils.UpdateCRWithRetry(testCtx, k8sClient, spireAgent, func() {
+				spireAgent.Spec.SVIDCache = &operatorv1alpha1.AgentSVIDCacheConfig{
+					X509MaxSize: ptr.To[int32](2000),
+					JWTMaxSize:  ptr.To[int32](1500),
+				}
+			})
+			Expect(err).NotTo(HaveOccurred(), "failed to patch SpireAgent with SVID cache sizes")
+			DeferCleanup(func(ctx context.Context) {
+				By("Resetting SpireAgent SVID cache sizes")
+				agent := &operatorv1alpha1.SpireAgent{}
+				if err := k8sClient.Get(ctx, client.ObjectKey{Name: "cluster"}, agent); err == nil {
+					agent.Spec.SVIDCache = nil
+					k8sClient.Update(ctx, agent)
+				}
+			})
+
+			By("Waiting for SPIRE Agent DaemonSet rolling update to start")
+			utils.WaitForDaemonSetRollingUpdate(testCtx, clientset, utils.SpireAgentDaemonSetName, utils.OperatorNamespace, initialGen, utils.ShortTimeout)
+
+			By("Waiting for SPIRE Agent DaemonSet to become Available")
+			utils.WaitForDaemonSetAvailable(testCtx, clientset, utils.SpireAgentDaemonSetName, utils.OperatorNamespace, utils.DefaultTimeout)
+
+			By("Verifying if SPIRE Agent ConfigMap has the expected SVID cache sizes")
+			for field, expected := range map[string]int64{"x509_svid_cache_max_size": 2000, "jwt_svid_cache_max_size": 1500} {
+				size, found, err := utils.GetNestedInt64FromConfigMapJSON(testCtx, clientset, utils.OperatorNamespace, utils.SpireAgentConfigMapName, utils.SpireAgentConfigKey, "agent", field)
+				Expect(err).NotTo(HaveOccurred(), "failed to get agent.%s from ConfigMap", field)
+				Expect(found).To(BeTrue(), "agent.%s should exist in ConfigMap", field)
+				Expect(size).To(Equal(expected), "%s should be updated to %d", field, expected)
+			}
+		})
+
 		It("SPIRE Agent custom labels can be configured through CR and propagated to pod", func() {
 			By("Getting SpireAgent object")
 			spireAgent := &operatorv1alpha1.SpireAgent{}
@@ -783,27 +783,52 @@ func GetDeploymentEnvVar(ctx context.Context, clientset kubernetes.Interface, na
 
 // GetNestedStringFromConfigMapJSON retrieves a nested string value from a JSON-formatted ConfigMap data field
 func GetNestedStringFromConfigMapJSON(ctx context.Context, clientset kubernetes.Interface, namespace, configMapName, dataKey string, fields ...string) (string, bool, error) {
+	configData, err := getConfigMapJSON(ctx, clientset, namespace, configMapName, dataKey)
+	if err != nil {
+		return "", false, err
+	}
+
+	value, found, err := unstructured.NestedString(configData, fields...)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get nested field %v: %w", fields, err)
+	}
+
+	return value, found, nil
+}
+
+// GetNestedInt64FromConfigMapJSON retrieves a nested number from a JSON-formatted ConfigMap data key
+func GetNestedInt64FromConfigMapJSON(ctx context.Context, clientset kubernetes.Interface, namespace, configMapName, dataKey string, fields ...string) (int64, bool, error) {
+	configData, err := getConfigMapJSON(ctx, clientset, namespace, configMapName, dataKey)
+	if err != nil {
+		return 0, false, err
+	}
+
+	// JSON numbers are decoded as float64
+	value, found, err := unstructured.NestedFloat64(configData, fields...)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get nested field %v: %w", fields, err)
+	}
+
+	return int64(value), found, nil
+}
+
+// getConfigMapJSON parses a JSON-formatted ConfigMap data key
+func getConfigMapJSON(ctx context.Context, clientset kubernetes.Interface, namespace, configMapName, dataKey string) (map[string]interface{}, error) {
 	cm, err := clientset.CoreV1().ConfigMaps(namespace).Get(ctx, configMapName, metav1.GetOptions{})
 	if err != nil {
-		return "", false, fmt.Errorf("failed to get ConfigMap: %w", err)
+		return nil, fmt.Errorf("failed to get ConfigMap: %w", err)
 	}
 
 	data, ok := cm.Data[dataKey]
 	if !ok {
-		return "", false, fmt.Errorf("key %s not found in ConfigMap", dataKey)
+		return nil, fmt.Errorf("key %s not found in ConfigMap", dataKey)
 	}
 
 	var configData map[string]interface{}
 	if err := json.Unmarshal([]byte(data), &configData); err != nil {
-		return "", false, fmt.Errorf("failed to parse JSON from ConfigMap data: %w", err)
-	}
-
-	value, found, err := unstructured.NestedString(configData, fields...)
-	if err != nil {
-		return "", false, fmt.Errorf("failed to get nested field %v: %w", fields, err)
+		return nil, fmt.Errorf("failed to parse JSON from ConfigMap data: %w", err)
 	}
-
-	return value, found, nil
+	return configData, nil
 }
 
 // FindOperatorConditionName finds an OLM OperatorCondition by name fragment in the specified namespace
@@ -831,16 +856,21 @@ func FindOperatorConditionName(ctx context.Context, k8sClient client.Client, nam
 
 // GetUpgradeableCondition fetches the current Upgradeable condition from the OperatorCondition.
 func GetUpgradeableCondition(ctx context.Context, k8sClient client.Client, namespace, operatorConditionName string) (*metav1.Condition, error) {
+	return GetOperatorConditionCondition(ctx, k8sClient, namespace, operatorConditionName, operatorv1.Upgradeable)
+}
+
+// GetOperatorConditionCondition fetches the condition of the given type from the OperatorCondition status.
+func GetOperatorConditionCondition(ctx context.Context, k8sClient client.Client, namespace, operatorConditionName, conditionType string) (*metav1.Condition, error) {
 	operatorCondition := &operatorv1.OperatorCondition{}
 	if err := k8sClient.Get(ctx, client.ObjectKey{Name: operatorConditionName, Namespace: namespace}, operatorCondition); err != nil {
 		return nil, fmt.Errorf("failed to get OperatorCondition '%s': %w", operatorConditionName, err)
 	}
 	for i := range operatorCondition.Status.Conditions {
-		if operatorCondition.Status.Conditions[i].Type == operatorv1.Upgradeable {
+		if operatorCondition.Status.Conditions[i].Type == conditionType {
 			return &operatorCondition.Status.Conditions[i], nil
 		}
 	}
-	return nil, fmt.Errorf("upgradeable condition not found in OperatorCondition '%s'", operatorConditionName)
+	return nil, fmt.Errorf("%s condition not found in OperatorCondition '%s'", conditionType, operatorConditionName)
 }
 
 // WaitForUpgradeableStatus waits for Upgradeable condition to reach expected status within timeout.